@@ -0,0 +1,351 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archivestore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+// The archive is a single file made up of a header followed by a log of
+// encrypted records.  Each update appends a record, with later records
+// superseding earlier ones for the same item; compaction rewrites the log
+// with only the current records.
+//
+// The header contains the magic bytes, the format version, the scrypt work
+// factor and salt used to derive the encryption key from the passphrase, and
+// a check value that allows an incorrect passphrase to be detected.  Each
+// record is a 4-byte big-endian length followed by a nonce and the AES-GCM
+// encryption of the record, with the header and record sequence number as
+// additional data so that records cannot be moved within or between archives.
+
+var archiveMagic = []byte("ethdoarc")
+
+const (
+	archiveVersion = 1
+	// scryptLogN is the base-2 logarithm of the scrypt work factor.
+	scryptLogN = 15
+	saltLength = 32
+	keyLength  = 32
+	// headerLength is the length of the header before its check value.
+	headerLength = 8 + 1 + 1 + saltLength
+	// checkLength is the length of the check value, being an AES-GCM nonce
+	// and tag.
+	checkLength = 12 + 16
+	// maxRecordLength is the maximum length of a single record, to avoid
+	// allocating unreasonable amounts of memory for a corrupt archive.
+	maxRecordLength = 16 * 1024 * 1024
+)
+
+const (
+	recordKindWallet  = "wallet"
+	recordKindAccount = "account"
+	recordKindIndex   = "index"
+	recordKindDelete  = "delete"
+)
+
+// record is a single update to the archive.
+type record struct {
+	Kind      string `json:"kind"`
+	WalletID  string `json:"wallet_id"`
+	AccountID string `json:"account_id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Data      []byte `json:"data"`
+}
+
+// archive is an open archive file.
+type archive struct {
+	header  []byte
+	aead    cipher.AEAD
+	records []*record
+	// data is the encoded archive.
+	data []byte
+}
+
+// newArchive creates a new empty archive with a fresh salt.
+func newArchive(passphrase []byte) (*archive, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+	header := make([]byte, 0, headerLength)
+	header = append(header, archiveMagic...)
+	header = append(header, archiveVersion, scryptLogN)
+	header = append(header, salt...)
+
+	aead, err := newAEAD(passphrase, header)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &archive{
+		header: header,
+		aead:   aead,
+	}
+	check, err := a.seal(nil, header)
+	if err != nil {
+		return nil, err
+	}
+	a.data = append(append([]byte{}, header...), check...)
+
+	return a, nil
+}
+
+// openArchive opens an archive from its encoded data.  If a previous archive
+// with the same header is supplied its key is reused, avoiding the cost of
+// deriving it again.
+func openArchive(data []byte, passphrase []byte, previous *archive) (*archive, error) {
+	if !bytes.HasPrefix(data, archiveMagic) {
+		return nil, errors.New("file is not a wallet archive")
+	}
+	if len(data) < headerLength+checkLength {
+		return nil, errors.New("archive is truncated")
+	}
+	header := data[:headerLength]
+	if header[len(archiveMagic)] != archiveVersion {
+		return nil, errors.Errorf("unsupported archive version %d", header[len(archiveMagic)])
+	}
+
+	var aead cipher.AEAD
+	if previous != nil && bytes.Equal(previous.header, header) {
+		aead = previous.aead
+	} else {
+		var err error
+		aead, err = newAEAD(passphrase, header)
+		if err != nil {
+			return nil, err
+		}
+	}
+	a := &archive{
+		header: append([]byte{}, header...),
+		aead:   aead,
+		data:   data,
+	}
+	if _, err := a.open(data[headerLength:headerLength+checkLength], header); err != nil {
+		return nil, errors.New("incorrect passphrase for archive")
+	}
+
+	for offset := headerLength + checkLength; offset < len(data); {
+		if len(data)-offset < 4 {
+			return nil, errors.New("archive is truncated")
+		}
+		length := int(binary.BigEndian.Uint32(data[offset:]))
+		offset += 4
+		if length > maxRecordLength || len(data)-offset < length {
+			return nil, errors.New("archive is truncated")
+		}
+		plaintext, err := a.open(data[offset:offset+length], a.additionalData(len(a.records)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decrypt record %d", len(a.records))
+		}
+		offset += length
+
+		rec := &record{}
+		if err := json.Unmarshal(plaintext, rec); err != nil {
+			return nil, errors.Wrapf(err, "invalid record %d", len(a.records))
+		}
+		a.records = append(a.records, rec)
+	}
+
+	return a, nil
+}
+
+// append appends a record to the archive.
+func (a *archive) append(rec *record) error {
+	plaintext, err := json.Marshal(rec)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode record")
+	}
+	sealed, err := a.seal(plaintext, a.additionalData(len(a.records)))
+	if err != nil {
+		return err
+	}
+
+	data := make([]byte, len(a.data)+4, len(a.data)+4+len(sealed))
+	copy(data, a.data)
+	binary.BigEndian.PutUint32(data[len(a.data):], uint32(len(sealed)))
+	a.data = append(data, sealed...)
+	a.records = append(a.records, rec)
+
+	return nil
+}
+
+// clone returns a copy of the archive that can be appended to without
+// affecting the original.
+func (a *archive) clone() *archive {
+	return &archive{
+		header:  a.header,
+		aead:    a.aead,
+		records: a.records[:len(a.records):len(a.records)],
+		data:    a.data[:len(a.data):len(a.data)],
+	}
+}
+
+// wallets returns the current state of the wallets in the archive.
+func (a *archive) wallets() (map[uuid.UUID]*walletState, error) {
+	wallets := make(map[uuid.UUID]*walletState)
+	for i, rec := range a.records {
+		walletID, err := uuid.Parse(rec.WalletID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid wallet ID in record %d", i)
+		}
+		wallet, exists := wallets[walletID]
+		if !exists {
+			wallet = &walletState{
+				id:       walletID,
+				accounts: make(map[uuid.UUID][]byte),
+			}
+			wallets[walletID] = wallet
+		}
+		switch rec.Kind {
+		case recordKindWallet:
+			wallet.name = rec.Name
+			wallet.data = rec.Data
+		case recordKindAccount:
+			accountID, err := uuid.Parse(rec.AccountID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid account ID in record %d", i)
+			}
+			wallet.accounts[accountID] = rec.Data
+		case recordKindIndex:
+			wallet.index = rec.Data
+		case recordKindDelete:
+			if rec.AccountID == "" {
+				delete(wallets, walletID)
+				continue
+			}
+			accountID, err := uuid.Parse(rec.AccountID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid account ID in record %d", i)
+			}
+			delete(wallet.accounts, accountID)
+		default:
+			return nil, errors.Errorf("unknown kind %q in record %d", rec.Kind, i)
+		}
+	}
+
+	// Accounts are only stored for existing wallets, so a wallet with accounts
+	// but without data indicates a corrupt archive.  A wallet with only an
+	// index has yet to be stored.
+	for walletID, wallet := range wallets {
+		if wallet.data == nil {
+			if len(wallet.accounts) > 0 {
+				return nil, errors.Errorf("no data for wallet %s", wallet.id)
+			}
+			delete(wallets, walletID)
+		}
+	}
+
+	return wallets, nil
+}
+
+func (a *archive) additionalData(seq int) []byte {
+	ad := make([]byte, len(a.header)+8)
+	copy(ad, a.header)
+	binary.BigEndian.PutUint64(ad[len(a.header):], uint64(seq))
+
+	return ad
+}
+
+func (a *archive) seal(plaintext []byte, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, a.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+
+	return a.aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func (a *archive) open(sealed []byte, additionalData []byte) ([]byte, error) {
+	if len(sealed) < a.aead.NonceSize() {
+		return nil, errors.New("sealed data too short")
+	}
+	nonce := sealed[:a.aead.NonceSize()]
+
+	return a.aead.Open(nil, nonce, sealed[a.aead.NonceSize():], additionalData)
+}
+
+func newAEAD(passphrase []byte, header []byte) (cipher.AEAD, error) {
+	logN := header[len(archiveMagic)+1]
+	if logN < 10 || logN > 20 {
+		return nil, errors.Errorf("unsupported work factor %d", logN)
+	}
+	salt := header[len(archiveMagic)+2 : headerLength]
+	key, err := scrypt.Key(passphrase, salt, 1<<logN, 8, 1, keyLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AEAD")
+	}
+
+	return aead, nil
+}
+
+// writeFileAtomic writes data to a temporary file alongside the path and
+// renames it over the path, so that the file is either entirely updated or
+// not updated at all.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return errors.Wrap(err, "failed to create archive directory")
+	}
+
+	tmp, err := os.CreateTemp(dir, tempFilePattern(path))
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary file")
+	}
+	tmpName := tmp.Name()
+	defer func() {
+		// Only has an effect if the rename has not taken place.
+		_ = os.Remove(tmpName)
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "failed to write temporary file")
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "failed to sync temporary file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to close temporary file")
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return errors.Wrap(err, "failed to replace archive")
+	}
+
+	return nil
+}
+
+// tempFilePattern is the pattern for temporary files used when updating the archive.
+func tempFilePattern(path string) string {
+	return "." + filepath.Base(path) + ".tmp-*"
+}
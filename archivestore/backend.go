@@ -0,0 +1,158 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archivestore
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// backend holds the encoded archive.
+type backend interface {
+	// location provides the location of the archive.
+	location() string
+	// read reads the archive, along with a version that identifies the data
+	// read.  It returns nil data if the archive does not exist.
+	read() ([]byte, string, error)
+	// write writes the archive if it is still at the given version, where an
+	// empty version is that of an archive that does not exist.  It returns
+	// the version of the data written.
+	write(data []byte, version string) (string, error)
+}
+
+// lockTimeout is the time to wait for another process to release the lock on
+// an archive file.
+var lockTimeout = 10 * time.Second
+
+// staleLockAge is the age after which a lock left by an interrupted update is
+// considered stale.
+var staleLockAge = time.Minute
+
+// fileBackend holds the archive in a local file.  Updates are serialized
+// across processes with a lock file, and the file is replaced atomically.
+type fileBackend struct {
+	path string
+}
+
+func (b *fileBackend) location() string {
+	return b.path
+}
+
+func (b *fileBackend) read() ([]byte, string, error) {
+	data, err := os.ReadFile(b.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", nil
+		}
+		return nil, "", errors.Wrap(err, "failed to read archive")
+	}
+
+	return data, fileVersion(data), nil
+}
+
+func (b *fileBackend) write(data []byte, version string) (string, error) {
+	unlock, err := b.lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	// Another process could have updated the archive since it was read.
+	_, currentVersion, err := b.read()
+	if err != nil {
+		return "", err
+	}
+	if currentVersion != version {
+		return "", errors.New("archive was updated elsewhere; try again")
+	}
+
+	if err := writeFileAtomic(b.path, data); err != nil {
+		return "", err
+	}
+
+	return fileVersion(data), nil
+}
+
+// lock takes the lock on the archive file, returning a function to release
+// it.
+func (b *fileBackend) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o700); err != nil {
+		return nil, errors.Wrap(err, "failed to create archive directory")
+	}
+
+	lockPath := b.path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		lockFile, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_ = lockFile.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, errors.Wrap(err, "failed to create lock file")
+		}
+
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			// Left by an interrupted update.
+			_ = os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("archive is locked by another process; remove %s if no other process is using it", lockPath)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// fileVersion provides the version of the archive file's data.  An archive
+// that does not exist has an empty version.
+func fileVersion(data []byte) string {
+	if data == nil {
+		return ""
+	}
+	hash := sha256.Sum256(data)
+
+	return fmt.Sprintf("%x", hash)
+}
+
+// removeStaleTempFiles removes temporary files left by interrupted updates.
+// Recent files are left alone, as they could belong to an update in progress.
+func (b *fileBackend) removeStaleTempFiles() (int, error) {
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(b.path), tempFilePattern(b.path)))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to search for temporary files")
+	}
+
+	removed := 0
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			continue
+		}
+		if time.Since(info.ModTime()) < staleTempFileAge {
+			continue
+		}
+		if err := os.Remove(match); err != nil {
+			return removed, errors.Wrap(err, "failed to remove temporary file")
+		}
+		removed++
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,82 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archivestore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileBackendWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive")
+	b := &fileBackend{path: path}
+
+	data, version, err := b.read()
+	require.NoError(t, err)
+	require.Nil(t, data)
+	require.Empty(t, version)
+
+	version1, err := b.write([]byte("first"), "")
+	require.NoError(t, err)
+	require.NotEmpty(t, version1)
+
+	// Writing as if the archive did not exist must fail.
+	_, err = b.write([]byte("other"), "")
+	require.EqualError(t, err, "archive was updated elsewhere; try again")
+
+	data, version, err = b.read()
+	require.NoError(t, err)
+	require.Equal(t, []byte("first"), data)
+	require.Equal(t, version1, version)
+
+	version2, err := b.write([]byte("second"), version1)
+	require.NoError(t, err)
+	require.NotEqual(t, version1, version2)
+
+	// Writing from the superseded version must fail.
+	_, err = b.write([]byte("other"), version1)
+	require.EqualError(t, err, "archive was updated elsewhere; try again")
+
+	data, _, err = b.read()
+	require.NoError(t, err)
+	require.Equal(t, []byte("second"), data)
+
+	// The lock is released after each write.
+	_, err = os.Stat(path + ".lock")
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestFileBackendLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive")
+	b := &fileBackend{path: path}
+
+	oldLockTimeout := lockTimeout
+	lockTimeout = 100 * time.Millisecond
+	defer func() { lockTimeout = oldLockTimeout }()
+
+	// A lock held by another process blocks the write.
+	require.NoError(t, os.WriteFile(path+".lock", nil, 0o600))
+	_, err := b.write([]byte("data"), "")
+	require.ErrorContains(t, err, "archive is locked by another process")
+
+	// A stale lock is removed.
+	stale := time.Now().Add(-2 * staleLockAge)
+	require.NoError(t, os.Chtimes(path+".lock", stale, stale))
+	_, err = b.write([]byte("data"), "")
+	require.NoError(t, err)
+}
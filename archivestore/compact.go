@@ -0,0 +1,124 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archivestore
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// staleTempFileAge is the age after which a temporary file left by an
+// interrupted update is considered stale.
+var staleTempFileAge = time.Hour
+
+// CompactResult contains the results of compacting an archive.
+type CompactResult struct {
+	RecordsBefore    int
+	RecordsAfter     int
+	SizeBefore       int
+	SizeAfter        int
+	TempFilesRemoved int
+}
+
+// Compact rewrites the archive with only the current record for each item,
+// discarding superseded records, and removes any stale temporary files left
+// by interrupted updates.
+func (s *Service) Compact() (*CompactResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, version, err := s.backend.read()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.New("archive does not exist")
+	}
+	a, err := s.open(data)
+	if err != nil {
+		return nil, err
+	}
+	wallets, err := a.wallets()
+	if err != nil {
+		return nil, err
+	}
+
+	compacted, err := newArchive(s.passphrase)
+	if err != nil {
+		return nil, err
+	}
+	for _, wallet := range sortedWallets(wallets) {
+		if err := compacted.append(&record{
+			Kind:     recordKindWallet,
+			WalletID: wallet.id.String(),
+			Name:     wallet.name,
+			Data:     wallet.data,
+		}); err != nil {
+			return nil, err
+		}
+
+		accountIDs := make([]uuid.UUID, 0, len(wallet.accounts))
+		for accountID := range wallet.accounts {
+			accountIDs = append(accountIDs, accountID)
+		}
+		sort.Slice(accountIDs, func(i, j int) bool {
+			return bytes.Compare(accountIDs[i][:], accountIDs[j][:]) < 0
+		})
+		for _, accountID := range accountIDs {
+			if err := compacted.append(&record{
+				Kind:      recordKindAccount,
+				WalletID:  wallet.id.String(),
+				AccountID: accountID.String(),
+				Data:      wallet.accounts[accountID],
+			}); err != nil {
+				return nil, err
+			}
+		}
+
+		if wallet.index != nil {
+			if err := compacted.append(&record{
+				Kind:     recordKindIndex,
+				WalletID: wallet.id.String(),
+				Data:     wallet.index,
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if _, err := s.backend.write(compacted.data, version); err != nil {
+		return nil, err
+	}
+	s.current = compacted
+
+	removed := 0
+	if localBackend, isLocal := s.backend.(*fileBackend); isLocal {
+		removed, err = localBackend.removeStaleTempFiles()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &CompactResult{
+		RecordsBefore:    len(a.records),
+		RecordsAfter:     len(compacted.records),
+		SizeBefore:       len(a.data),
+		SizeAfter:        len(compacted.data),
+		TempFilesRemoved: removed,
+	}, nil
+}
@@ -0,0 +1,125 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archivestore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+)
+
+// gcsGenerationHeader is the header in which Google Cloud Storage provides the
+// generation of an object.
+const gcsGenerationHeader = "x-goog-generation"
+
+// objectBackend holds the archive as a single object in an S3-compatible
+// object store.  Object stores do not provide locking, so each write is
+// conditional on the object being unchanged since it was read: Amazon S3
+// compares the ETag of the object, and Google Cloud Storage its generation.
+// A write that loses a race with a write from elsewhere fails, rather than
+// overwriting it.
+type objectBackend struct {
+	client s3iface.S3API
+	scheme string
+	bucket string
+	key    string
+}
+
+func (b *objectBackend) location() string {
+	return fmt.Sprintf("%s://%s/%s", b.scheme, b.bucket, b.key)
+}
+
+func (b *objectBackend) read() ([]byte, string, error) {
+	var generation string
+	res, err := b.client.GetObjectWithContext(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key),
+	}, request.WithGetResponseHeader(gcsGenerationHeader, &generation))
+	if err != nil {
+		if reqErr, isReqErr := err.(awserr.RequestFailure); isReqErr && reqErr.StatusCode() == http.StatusNotFound {
+			return nil, "", nil
+		}
+		return nil, "", errors.Wrap(err, "failed to read archive")
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to read archive")
+	}
+
+	version, err := b.version(aws.StringValue(res.ETag), generation)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, version, nil
+}
+
+func (b *objectBackend) write(data []byte, version string) (string, error) {
+	var generation string
+	res, err := b.client.PutObjectWithContext(context.Background(), &s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key),
+		Body:   bytes.NewReader(data),
+	}, b.precondition(version), request.WithGetResponseHeader(gcsGenerationHeader, &generation))
+	if err != nil {
+		if reqErr, isReqErr := err.(awserr.RequestFailure); isReqErr &&
+			(reqErr.StatusCode() == http.StatusPreconditionFailed || reqErr.StatusCode() == http.StatusConflict) {
+			return "", errors.New("archive was updated elsewhere; try again")
+		}
+		return "", errors.Wrap(err, "failed to write archive")
+	}
+
+	return b.version(aws.StringValue(res.ETag), generation)
+}
+
+// precondition returns a request option that makes a write conditional on
+// the object being at the given version.
+func (b *objectBackend) precondition(version string) request.Option {
+	return func(r *request.Request) {
+		switch {
+		case b.scheme == "gs" && version == "":
+			r.HTTPRequest.Header.Set("x-goog-if-generation-match", "0")
+		case b.scheme == "gs":
+			r.HTTPRequest.Header.Set("x-goog-if-generation-match", version)
+		case version == "":
+			r.HTTPRequest.Header.Set("If-None-Match", "*")
+		default:
+			r.HTTPRequest.Header.Set("If-Match", version)
+		}
+	}
+}
+
+// version returns the version of the object from the response.
+func (b *objectBackend) version(etag string, generation string) (string, error) {
+	version := etag
+	if b.scheme == "gs" {
+		version = generation
+	}
+	if version == "" {
+		return "", errors.New("object store did not provide the version of the archive")
+	}
+
+	return version, nil
+}
@@ -0,0 +1,201 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archivestore_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/archivestore"
+)
+
+// objectStore is an object store holding a single object, that applies the
+// preconditions of Amazon S3 or Google Cloud Storage to writes.
+type objectStore struct {
+	s3iface.S3API
+	mu         sync.Mutex
+	gcs        bool
+	data       []byte
+	generation int
+	// beforePut is called once, before the next write is applied.
+	beforePut func()
+}
+
+func (o *objectStore) GetObjectWithContext(_ aws.Context, _ *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.data == nil {
+		return nil, awserr.NewRequestFailure(awserr.New("NoSuchKey", "not found", nil), http.StatusNotFound, "")
+	}
+	o.complete(opts)
+
+	return &s3.GetObjectOutput{
+		Body: io.NopCloser(bytes.NewReader(o.data)),
+		ETag: aws.String(o.etag()),
+	}, nil
+}
+
+func (o *objectStore) PutObjectWithContext(_ aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error) {
+	if o.beforePut != nil {
+		beforePut := o.beforePut
+		o.beforePut = nil
+		beforePut()
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.preconditionMet(opts) {
+		return nil, awserr.NewRequestFailure(awserr.New("PreconditionFailed", "precondition failed", nil), http.StatusPreconditionFailed, "")
+	}
+	data, err := io.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	o.data = data
+	o.generation++
+	o.complete(opts)
+
+	return &s3.PutObjectOutput{
+		ETag: aws.String(o.etag()),
+	}, nil
+}
+
+func (o *objectStore) etag() string {
+	return fmt.Sprintf(`"%d"`, o.generation)
+}
+
+// preconditionMet returns true if the write carries a precondition that is met
+// by the current object.
+func (o *objectStore) preconditionMet(opts []request.Option) bool {
+	req := &request.Request{HTTPRequest: httptest.NewRequest(http.MethodPut, "/", nil)}
+	for _, opt := range opts {
+		opt(req)
+	}
+	header := req.HTTPRequest.Header
+	if o.gcs {
+		return header.Get("x-goog-if-generation-match") == strconv.Itoa(o.generation)
+	}
+	if header.Get("If-None-Match") == "*" {
+		return o.data == nil
+	}
+
+	return header.Get("If-Match") != "" && header.Get("If-Match") == o.etag()
+}
+
+// complete completes the request, supplying the response headers.
+func (o *objectStore) complete(opts []request.Option) {
+	req := &request.Request{HTTPRequest: httptest.NewRequest(http.MethodGet, "/", nil)}
+	for _, opt := range opts {
+		opt(req)
+	}
+	req.HTTPResponse = &http.Response{Header: http.Header{}}
+	req.HTTPResponse.Header.Set("ETag", o.etag())
+	if o.gcs {
+		req.HTTPResponse.Header.Set("x-goog-generation", strconv.Itoa(o.generation))
+	}
+	req.Handlers.Complete.Run(req)
+}
+
+func TestObject(t *testing.T) {
+	for _, scheme := range []string{"s3", "gs"} {
+		t.Run(scheme, func(t *testing.T) {
+			objects := &objectStore{gcs: scheme == "gs"}
+			store, err := archivestore.New(archivestore.WithObject(objects, scheme, "bucket", "path/wallets.archive"),
+				archivestore.WithPassphrase([]byte("secret")),
+			)
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprintf("%s://bucket/path/wallets.archive", scheme), store.Location())
+
+			walletID := uuid.New()
+			accountID := uuid.New()
+			require.NoError(t, store.StoreWallet(walletID, "Test wallet", []byte("wallet")))
+			require.NoError(t, store.StoreAccount(walletID, accountID, []byte("account")))
+			require.NoError(t, store.StoreAccountsIndex(walletID, []byte("index")))
+
+			// A second store sees the same data.
+			other, err := archivestore.New(archivestore.WithObject(objects, scheme, "bucket", "path/wallets.archive"),
+				archivestore.WithPassphrase([]byte("secret")),
+			)
+			require.NoError(t, err)
+			data, err := other.RetrieveAccount(walletID, accountID)
+			require.NoError(t, err)
+			require.Equal(t, []byte("account"), data)
+
+			// Deletion.
+			require.NoError(t, other.DeleteAccount(walletID, accountID))
+			_, err = store.RetrieveAccount(walletID, accountID)
+			require.EqualError(t, err, "account not found")
+			require.NoError(t, store.DeleteWallet(walletID))
+			_, err = other.RetrieveWalletByID(walletID)
+			require.EqualError(t, err, "wallet not found")
+
+			// Compaction.
+			res, err := store.Compact()
+			require.NoError(t, err)
+			require.Equal(t, 0, res.RecordsAfter)
+		})
+	}
+}
+
+func TestObjectConflict(t *testing.T) {
+	for _, scheme := range []string{"s3", "gs"} {
+		t.Run(scheme, func(t *testing.T) {
+			objects := &objectStore{gcs: scheme == "gs"}
+			store, err := archivestore.New(archivestore.WithObject(objects, scheme, "bucket", "wallets.archive"),
+				archivestore.WithPassphrase([]byte("secret")),
+			)
+			require.NoError(t, err)
+			other, err := archivestore.New(archivestore.WithObject(objects, scheme, "bucket", "wallets.archive"),
+				archivestore.WithPassphrase([]byte("secret")),
+			)
+			require.NoError(t, err)
+
+			// Both stores attempt to create the archive.
+			objects.beforePut = func() {
+				require.NoError(t, other.StoreWallet(uuid.New(), "Other wallet", []byte("other")))
+			}
+			walletID := uuid.New()
+			require.EqualError(t, store.StoreWallet(walletID, "Test wallet", []byte("wallet")), "archive was updated elsewhere; try again")
+
+			// Both stores attempt to update the archive.
+			objects.beforePut = func() {
+				require.NoError(t, other.StoreWallet(uuid.New(), "Another wallet", []byte("another")))
+			}
+			require.EqualError(t, store.StoreWallet(walletID, "Test wallet", []byte("wallet")), "archive was updated elsewhere; try again")
+
+			// The update succeeds when retried, and no update is lost.
+			require.NoError(t, store.StoreWallet(walletID, "Test wallet", []byte("wallet")))
+			wallets := make([]string, 0)
+			for data := range other.RetrieveWallets() {
+				wallets = append(wallets, string(data))
+			}
+			require.Equal(t, []string{"another", "other", "wallet"}, wallets)
+		})
+	}
+}
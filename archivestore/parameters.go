@@ -0,0 +1,98 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archivestore
+
+import (
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+)
+
+type parameters struct {
+	path         string
+	objectClient s3iface.S3API
+	objectScheme string
+	objectBucket string
+	objectKey    string
+	passphrase   []byte
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithPath sets the path of the archive file.
+func WithPath(path string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.path = path
+	})
+}
+
+// WithObject sets the object that holds the archive in an S3-compatible object
+// store, in place of a file.  The scheme is "s3" for Amazon S3 and other
+// stores that support conditional writes with ETags, or "gs" for Google Cloud
+// Storage.
+func WithObject(client s3iface.S3API, scheme string, bucket string, key string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.objectClient = client
+		p.objectScheme = scheme
+		p.objectBucket = bucket
+		p.objectKey = key
+	})
+}
+
+// WithPassphrase sets the passphrase used to encrypt the archive.
+func WithPassphrase(passphrase []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.passphrase = passphrase
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.objectClient != nil {
+		if parameters.path != "" {
+			return nil, errors.New("only one of path and object allowed")
+		}
+		if parameters.objectScheme != "s3" && parameters.objectScheme != "gs" {
+			return nil, errors.New("unsupported object scheme")
+		}
+		if parameters.objectBucket == "" {
+			return nil, errors.New("no object bucket specified")
+		}
+		if parameters.objectKey == "" {
+			return nil, errors.New("no object key specified")
+		}
+	} else if parameters.path == "" {
+		return nil, errors.New("no path specified")
+	}
+	if len(parameters.passphrase) == 0 {
+		return nil, errors.New("no passphrase specified")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,341 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archivestore
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Service is a wallet store backed by a single encrypted archive, held in a
+// file or in an object store.
+type Service struct {
+	mutex      sync.Mutex
+	backend    backend
+	passphrase []byte
+	// current is the archive as last read from or written to the backend.
+	current *archive
+}
+
+// walletState is the current state of a wallet in the archive.
+type walletState struct {
+	id       uuid.UUID
+	name     string
+	data     []byte
+	index    []byte
+	accounts map[uuid.UUID][]byte
+}
+
+// New creates a new archive store.
+func New(params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	var b backend = &fileBackend{path: parameters.path}
+	if parameters.objectClient != nil {
+		b = &objectBackend{
+			client: parameters.objectClient,
+			scheme: parameters.objectScheme,
+			bucket: parameters.objectBucket,
+			key:    parameters.objectKey,
+		}
+	}
+
+	return &Service{
+		backend:    b,
+		passphrase: parameters.passphrase,
+	}, nil
+}
+
+// Name provides the name of the store.
+func (*Service) Name() string {
+	return "archive"
+}
+
+// Location provides the location of the store.
+func (s *Service) Location() string {
+	return s.backend.location()
+}
+
+// StoreWallet stores wallet data.
+func (s *Service) StoreWallet(walletID uuid.UUID, walletName string, data []byte) error {
+	return s.update(&record{
+		Kind:     recordKindWallet,
+		WalletID: walletID.String(),
+		Name:     walletName,
+		Data:     data,
+	})
+}
+
+// RetrieveWallets retrieves wallet data for all wallets.
+func (s *Service) RetrieveWallets() <-chan []byte {
+	wallets, err := s.wallets()
+	if err != nil {
+		// The interface does not allow errors to be returned.
+		wallets = nil
+	}
+
+	ch := make(chan []byte, len(wallets))
+	for _, wallet := range sortedWallets(wallets) {
+		ch <- wallet.data
+	}
+	close(ch)
+
+	return ch
+}
+
+// RetrieveWallet retrieves wallet data for a wallet with a given name.
+func (s *Service) RetrieveWallet(walletName string) ([]byte, error) {
+	wallets, err := s.wallets()
+	if err != nil {
+		return nil, err
+	}
+	for _, wallet := range wallets {
+		if wallet.name == walletName {
+			return wallet.data, nil
+		}
+	}
+
+	return nil, errors.New("wallet not found")
+}
+
+// RetrieveWalletByID retrieves wallet data for a wallet with a given ID.
+func (s *Service) RetrieveWalletByID(walletID uuid.UUID) ([]byte, error) {
+	wallet, err := s.wallet(walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	return wallet.data, nil
+}
+
+// StoreAccount stores account data.
+func (s *Service) StoreAccount(walletID uuid.UUID, accountID uuid.UUID, data []byte) error {
+	return s.update(&record{
+		Kind:      recordKindAccount,
+		WalletID:  walletID.String(),
+		AccountID: accountID.String(),
+		Data:      data,
+	})
+}
+
+// RetrieveAccounts retrieves account information for all accounts.
+func (s *Service) RetrieveAccounts(walletID uuid.UUID) <-chan []byte {
+	wallet, err := s.wallet(walletID)
+	if err != nil {
+		// The interface does not allow errors to be returned.
+		wallet = &walletState{}
+	}
+
+	accountIDs := make([]uuid.UUID, 0, len(wallet.accounts))
+	for accountID := range wallet.accounts {
+		accountIDs = append(accountIDs, accountID)
+	}
+	sort.Slice(accountIDs, func(i, j int) bool {
+		return bytes.Compare(accountIDs[i][:], accountIDs[j][:]) < 0
+	})
+
+	ch := make(chan []byte, len(accountIDs))
+	for _, accountID := range accountIDs {
+		ch <- wallet.accounts[accountID]
+	}
+	close(ch)
+
+	return ch
+}
+
+// RetrieveAccount retrieves account data for a wallet with a given ID.
+func (s *Service) RetrieveAccount(walletID uuid.UUID, accountID uuid.UUID) ([]byte, error) {
+	wallet, err := s.wallet(walletID)
+	if err != nil {
+		return nil, err
+	}
+	data, exists := wallet.accounts[accountID]
+	if !exists {
+		return nil, errors.New("account not found")
+	}
+
+	return data, nil
+}
+
+// StoreAccountsIndex stores the index of accounts for a given wallet.
+func (s *Service) StoreAccountsIndex(walletID uuid.UUID, data []byte) error {
+	return s.update(&record{
+		Kind:     recordKindIndex,
+		WalletID: walletID.String(),
+		Data:     data,
+	})
+}
+
+// RetrieveAccountsIndex retrieves the index of accounts for a given wallet.
+func (s *Service) RetrieveAccountsIndex(walletID uuid.UUID) ([]byte, error) {
+	wallet, err := s.wallet(walletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.index == nil {
+		return nil, errors.New("accounts index not found")
+	}
+
+	return wallet.index, nil
+}
+
+// DeleteWallet deletes a wallet and its accounts.  The data remains in the
+// archive until it is compacted.
+func (s *Service) DeleteWallet(walletID uuid.UUID) error {
+	return s.update(&record{
+		Kind:     recordKindDelete,
+		WalletID: walletID.String(),
+	})
+}
+
+// DeleteAccount deletes an account from a wallet.  The data remains in the
+// archive until it is compacted.
+func (s *Service) DeleteAccount(walletID uuid.UUID, accountID uuid.UUID) error {
+	wallet, err := s.wallet(walletID)
+	if err != nil {
+		return err
+	}
+	if _, exists := wallet.accounts[accountID]; !exists {
+		return errors.New("account not found")
+	}
+
+	return s.update(&record{
+		Kind:      recordKindDelete,
+		WalletID:  walletID.String(),
+		AccountID: accountID.String(),
+	})
+}
+
+// update appends a record to the archive and writes it to the backend.
+func (s *Service) update(rec *record) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	a, version, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	// Wallet stores write the accounts index of a new wallet before the
+	// wallet itself, so only accounts and deletions require the wallet.
+	if rec.Kind == recordKindAccount || rec.Kind == recordKindDelete {
+		wallets, err := a.wallets()
+		if err != nil {
+			return err
+		}
+		walletID, err := uuid.Parse(rec.WalletID)
+		if err != nil {
+			return errors.Wrap(err, "invalid wallet ID")
+		}
+		if _, exists := wallets[walletID]; !exists {
+			return errors.New("wallet not found")
+		}
+	}
+
+	if err := a.append(rec); err != nil {
+		return err
+	}
+	if _, err := s.backend.write(a.data, version); err != nil {
+		return err
+	}
+	s.current = a
+
+	return nil
+}
+
+// load loads the archive from the backend, along with its version.  A new
+// archive is returned if the backend does not hold one.
+func (s *Service) load() (*archive, string, error) {
+	data, version, err := s.backend.read()
+	if err != nil {
+		return nil, "", err
+	}
+	if data == nil {
+		a, err := newArchive(s.passphrase)
+		if err != nil {
+			return nil, "", err
+		}
+		return a, "", nil
+	}
+
+	a, err := s.open(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return a, version, nil
+}
+
+// open opens the archive from its data, reusing the current archive if the
+// data is unchanged.
+func (s *Service) open(data []byte) (*archive, error) {
+	if s.current != nil && bytes.Equal(data, s.current.data) {
+		// Copy, so that a failed update does not leave the current archive
+		// out of step with the backend.
+		return s.current.clone(), nil
+	}
+
+	a, err := openArchive(data, s.passphrase, s.current)
+	if err != nil {
+		return nil, err
+	}
+	s.current = a
+
+	return a.clone(), nil
+}
+
+func (s *Service) wallets() (map[uuid.UUID]*walletState, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	a, _, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return a.wallets()
+}
+
+func (s *Service) wallet(walletID uuid.UUID) (*walletState, error) {
+	wallets, err := s.wallets()
+	if err != nil {
+		return nil, err
+	}
+	wallet, exists := wallets[walletID]
+	if !exists {
+		return nil, errors.New("wallet not found")
+	}
+
+	return wallet, nil
+}
+
+// sortedWallets returns the wallets ordered by name.
+func sortedWallets(wallets map[uuid.UUID]*walletState) []*walletState {
+	res := make([]*walletState, 0, len(wallets))
+	for _, wallet := range wallets {
+		res = append(res, wallet)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].name < res[j].name
+	})
+
+	return res
+}
@@ -0,0 +1,234 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archivestore_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/archivestore"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name   string
+		params []archivestore.Parameter
+		err    string
+	}{
+		{
+			name: "PathMissing",
+			params: []archivestore.Parameter{
+				archivestore.WithPassphrase([]byte("secret")),
+			},
+			err: "problem with parameters: no path specified",
+		},
+		{
+			name: "PassphraseMissing",
+			params: []archivestore.Parameter{
+				archivestore.WithPath(filepath.Join(t.TempDir(), "wallets.archive")),
+			},
+			err: "problem with parameters: no passphrase specified",
+		},
+		{
+			name: "PathAndObject",
+			params: []archivestore.Parameter{
+				archivestore.WithPath(filepath.Join(t.TempDir(), "wallets.archive")),
+				archivestore.WithObject(&objectStore{}, "s3", "bucket", "wallets.archive"),
+				archivestore.WithPassphrase([]byte("secret")),
+			},
+			err: "problem with parameters: only one of path and object allowed",
+		},
+		{
+			name: "ObjectSchemeInvalid",
+			params: []archivestore.Parameter{
+				archivestore.WithObject(&objectStore{}, "ftp", "bucket", "wallets.archive"),
+				archivestore.WithPassphrase([]byte("secret")),
+			},
+			err: "problem with parameters: unsupported object scheme",
+		},
+		{
+			name: "ObjectBucketMissing",
+			params: []archivestore.Parameter{
+				archivestore.WithObject(&objectStore{}, "s3", "", "wallets.archive"),
+				archivestore.WithPassphrase([]byte("secret")),
+			},
+			err: "problem with parameters: no object bucket specified",
+		},
+		{
+			name: "Good",
+			params: []archivestore.Parameter{
+				archivestore.WithPath(filepath.Join(t.TempDir(), "wallets.archive")),
+				archivestore.WithPassphrase([]byte("secret")),
+			},
+		},
+		{
+			name: "GoodObject",
+			params: []archivestore.Parameter{
+				archivestore.WithObject(&objectStore{}, "gs", "bucket", "wallets.archive"),
+				archivestore.WithPassphrase([]byte("secret")),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := archivestore.New(test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wallets", "wallets.archive")
+	store, err := archivestore.New(archivestore.WithPath(path), archivestore.WithPassphrase([]byte("secret")))
+	require.NoError(t, err)
+	require.Equal(t, "archive", store.Name())
+	require.Equal(t, path, store.Location())
+
+	walletID := uuid.New()
+	accountID := uuid.New()
+
+	// Accounts cannot be stored without their wallet.
+	require.EqualError(t, store.StoreAccount(walletID, accountID, []byte("account")), "wallet not found")
+
+	// The accounts index of a new wallet is stored before the wallet, but the
+	// wallet does not exist until its data is stored.
+	require.NoError(t, store.StoreAccountsIndex(walletID, []byte("[]")))
+	_, err = store.RetrieveWalletByID(walletID)
+	require.EqualError(t, err, "wallet not found")
+
+	require.NoError(t, store.StoreWallet(walletID, "Test wallet", []byte("wallet")))
+	require.NoError(t, store.StoreAccount(walletID, accountID, []byte("account")))
+	require.NoError(t, store.StoreAccountsIndex(walletID, []byte("index")))
+
+	// Reopen the archive to confirm that the data was written.
+	store, err = archivestore.New(archivestore.WithPath(path), archivestore.WithPassphrase([]byte("secret")))
+	require.NoError(t, err)
+
+	data, err := store.RetrieveWallet("Test wallet")
+	require.NoError(t, err)
+	require.Equal(t, []byte("wallet"), data)
+	data, err = store.RetrieveWalletByID(walletID)
+	require.NoError(t, err)
+	require.Equal(t, []byte("wallet"), data)
+	_, err = store.RetrieveWallet("Unknown wallet")
+	require.EqualError(t, err, "wallet not found")
+
+	wallets := 0
+	for range store.RetrieveWallets() {
+		wallets++
+	}
+	require.Equal(t, 1, wallets)
+
+	data, err = store.RetrieveAccount(walletID, accountID)
+	require.NoError(t, err)
+	require.Equal(t, []byte("account"), data)
+	_, err = store.RetrieveAccount(walletID, uuid.New())
+	require.EqualError(t, err, "account not found")
+	accounts := 0
+	for range store.RetrieveAccounts(walletID) {
+		accounts++
+	}
+	require.Equal(t, 1, accounts)
+
+	data, err = store.RetrieveAccountsIndex(walletID)
+	require.NoError(t, err)
+	require.Equal(t, []byte("index"), data)
+
+	// Updates supersede earlier data.
+	require.NoError(t, store.StoreAccount(walletID, accountID, []byte("updated account")))
+	data, err = store.RetrieveAccount(walletID, accountID)
+	require.NoError(t, err)
+	require.Equal(t, []byte("updated account"), data)
+
+	// Accounts can be deleted.
+	require.EqualError(t, store.DeleteAccount(walletID, uuid.New()), "account not found")
+	require.NoError(t, store.DeleteAccount(walletID, accountID))
+	_, err = store.RetrieveAccount(walletID, accountID)
+	require.EqualError(t, err, "account not found")
+	_, err = store.RetrieveWalletByID(walletID)
+	require.NoError(t, err)
+
+	// An incorrect passphrase cannot open the archive.
+	store, err = archivestore.New(archivestore.WithPath(path), archivestore.WithPassphrase([]byte("wrong")))
+	require.NoError(t, err)
+	_, err = store.RetrieveWallet("Test wallet")
+	require.EqualError(t, err, "incorrect passphrase for archive")
+}
+
+func TestCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wallets.archive")
+	store, err := archivestore.New(archivestore.WithPath(path), archivestore.WithPassphrase([]byte("secret")))
+	require.NoError(t, err)
+	require.NoError(t, store.StoreWallet(uuid.New(), "Test wallet", []byte("wallet")))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data[:len(data)-1], 0o600))
+	_, err = store.RetrieveWallet("Test wallet")
+	require.EqualError(t, err, "archive is truncated")
+
+	require.NoError(t, os.WriteFile(path, []byte("not an archive, but long enough to hold a header and check value"), 0o600))
+	_, err = store.RetrieveWallet("Test wallet")
+	require.EqualError(t, err, "file is not a wallet archive")
+}
+
+func TestCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wallets.archive")
+	store, err := archivestore.New(archivestore.WithPath(path), archivestore.WithPassphrase([]byte("secret")))
+	require.NoError(t, err)
+
+	_, err = store.Compact()
+	require.EqualError(t, err, "archive does not exist")
+
+	walletID := uuid.New()
+	accountID := uuid.New()
+	require.NoError(t, store.StoreWallet(walletID, "Test wallet", []byte("wallet")))
+	for i := 0; i < 5; i++ {
+		require.NoError(t, store.StoreAccount(walletID, accountID, []byte{byte(i)}))
+		require.NoError(t, store.StoreAccountsIndex(walletID, []byte{byte(i)}))
+	}
+
+	res, err := store.Compact()
+	require.NoError(t, err)
+	require.Equal(t, 11, res.RecordsBefore)
+	require.Equal(t, 3, res.RecordsAfter)
+	require.True(t, res.SizeAfter < res.SizeBefore)
+
+	// Reopen the archive to confirm that the current data was kept.
+	store, err = archivestore.New(archivestore.WithPath(path), archivestore.WithPassphrase([]byte("secret")))
+	require.NoError(t, err)
+	data, err := store.RetrieveAccount(walletID, accountID)
+	require.NoError(t, err)
+	require.Equal(t, []byte{4}, data)
+	data, err = store.RetrieveAccountsIndex(walletID)
+	require.NoError(t, err)
+	require.Equal(t, []byte{4}, data)
+
+	// Deleted wallets are removed by compaction.
+	require.NoError(t, store.DeleteWallet(walletID))
+	_, err = store.RetrieveWalletByID(walletID)
+	require.EqualError(t, err, "wallet not found")
+	res, err = store.Compact()
+	require.NoError(t, err)
+	require.Equal(t, 4, res.RecordsBefore)
+	require.Equal(t, 0, res.RecordsAfter)
+}
@@ -0,0 +1,141 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auditlog records access to accounts in an append-only log.
+package auditlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// Actions recorded in the audit log.
+const (
+	// ActionUnlock is recorded when an account is unlocked.
+	ActionUnlock = "unlock"
+	// ActionSign is recorded when an account signs an operation.
+	ActionSign = "sign"
+	// ActionExport is recorded when a key is exported.
+	ActionExport = "export"
+)
+
+// Entry is a single entry in the audit log.
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`
+	Action  string    `json:"action"`
+	Account string    `json:"account"`
+	PubKey  string    `json:"pubkey,omitempty"`
+	Hash    string    `json:"hash,omitempty"`
+}
+
+var (
+	mutex   sync.Mutex
+	path    string
+	command string
+)
+
+// Setup sets the file to which entries are appended, and the command recorded
+// with each entry.  An empty path disables the audit log.
+func Setup(logPath string, logCommand string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	path = logPath
+	command = logCommand
+}
+
+// Enabled returns true if the audit log is enabled.
+func Enabled() bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return path != ""
+}
+
+// Record records an action on an account in the audit log.  The hash is the
+// root of the operation signed, if any.
+// An error is returned if the entry cannot be written, in which case the
+// action should not proceed.
+func Record(action string, account e2wtypes.Account, hash []byte) error {
+	if !Enabled() {
+		return nil
+	}
+
+	entry := &Entry{
+		Action:  action,
+		Account: AccountPath(account),
+	}
+	if compositeProvider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider); isProvider && compositeProvider.CompositePublicKey() != nil {
+		entry.PubKey = fmt.Sprintf("%#x", compositeProvider.CompositePublicKey().Marshal())
+	} else if account != nil && account.PublicKey() != nil {
+		entry.PubKey = fmt.Sprintf("%#x", account.PublicKey().Marshal())
+	}
+	if len(hash) > 0 {
+		entry.Hash = fmt.Sprintf("%#x", hash)
+	}
+
+	return RecordEntry(entry)
+}
+
+// RecordEntry records an entry in the audit log, setting its time and command.
+func RecordEntry(entry *Entry) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if path == "" {
+		return nil
+	}
+
+	entry.Time = time.Now().UTC()
+	entry.Command = command
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal audit log entry")
+	}
+
+	// The file is only ever appended to, and is opened for each entry so that
+	// concurrent instances do not interleave partial entries.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open audit log")
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "failed to write audit log")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "failed to close audit log")
+	}
+
+	return nil
+}
+
+// AccountPath returns the path of an account, in the form "wallet/account"
+// where the wallet is known.
+func AccountPath(account e2wtypes.Account) string {
+	if account == nil {
+		return ""
+	}
+	if walletProvider, isProvider := account.(e2wtypes.AccountWalletProvider); isProvider && walletProvider.Wallet() != nil {
+		return fmt.Sprintf("%s/%s", walletProvider.Wallet().Name(), account.Name())
+	}
+
+	return account.Name()
+}
@@ -0,0 +1,117 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/auditlog"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+type testPublicKey []byte
+
+func (k testPublicKey) Marshal() []byte               { return k }
+func (k testPublicKey) Aggregate(_ e2types.PublicKey) {}
+func (k testPublicKey) Copy() e2types.PublicKey       { return k }
+
+type testAccount struct {
+	name   string
+	pubKey testPublicKey
+}
+
+func (a *testAccount) ID() uuid.UUID                { return uuid.UUID{} }
+func (a *testAccount) Name() string                 { return a.name }
+func (a *testAccount) PublicKey() e2types.PublicKey { return a.pubKey }
+
+func readEntries(t *testing.T, path string) []*auditlog.Entry {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	entries := make([]*auditlog.Entry, 0)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		entry := &auditlog.Entry{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+
+	return entries
+}
+
+func TestDisabled(t *testing.T) {
+	auditlog.Setup("", "ethdo account key")
+	require.False(t, auditlog.Enabled())
+	require.NoError(t, auditlog.Record(auditlog.ActionExport, &testAccount{name: "test"}, nil))
+}
+
+func TestRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	account := &testAccount{
+		name:   "Validators/1",
+		pubKey: testPublicKey{0x01, 0x02},
+	}
+
+	auditlog.Setup(path, "ethdo validator exit")
+	defer auditlog.Setup("", "")
+	require.True(t, auditlog.Enabled())
+
+	require.NoError(t, auditlog.Record(auditlog.ActionUnlock, account, nil))
+	require.NoError(t, auditlog.Record(auditlog.ActionSign, account, []byte{0xaa, 0xbb}))
+
+	// A second instance appends to the existing log.
+	auditlog.Setup(path, "ethdo wallet export")
+	require.NoError(t, auditlog.RecordEntry(&auditlog.Entry{
+		Action:  auditlog.ActionExport,
+		Account: "Validators",
+	}))
+
+	entries := readEntries(t, path)
+	require.Len(t, entries, 3)
+	require.Equal(t, "ethdo validator exit", entries[0].Command)
+	require.Equal(t, "unlock", entries[0].Action)
+	require.Equal(t, "Validators/1", entries[0].Account)
+	require.Equal(t, "0x0102", entries[0].PubKey)
+	require.Equal(t, "", entries[0].Hash)
+	require.False(t, entries[0].Time.IsZero())
+	require.Equal(t, "sign", entries[1].Action)
+	require.Equal(t, "0xaabb", entries[1].Hash)
+	require.Equal(t, "ethdo wallet export", entries[2].Command)
+	require.Equal(t, "export", entries[2].Action)
+	require.Equal(t, "Validators", entries[2].Account)
+	require.Equal(t, "", entries[2].PubKey)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestRecordUnwritable(t *testing.T) {
+	auditlog.Setup(filepath.Join(t.TempDir(), "missing", "audit.log"), "ethdo account key")
+	defer auditlog.Setup("", "")
+
+	err := auditlog.Record(auditlog.ActionExport, &testAccount{name: "test"}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to open audit log")
+}
@@ -18,10 +18,12 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
 	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/wealdtech/ethdo/services/chaintime"
@@ -35,6 +37,8 @@ type ChainInfo struct {
 	Epoch                          phase0.Epoch
 	GenesisForkVersion             phase0.Version
 	CurrentForkVersion             phase0.Version
+	CapellaForkVersion             phase0.Version
+	CapellaForkEpoch               phase0.Epoch
 	BLSToExecutionChangeDomainType phase0.DomainType
 	VoluntaryExitDomainType        phase0.DomainType
 }
@@ -46,10 +50,15 @@ type chainInfoJSON struct {
 	Epoch                          string           `json:"epoch"`
 	GenesisForkVersion             string           `json:"genesis_fork_version"`
 	CurrentForkVersion             string           `json:"current_fork_version"`
+	CapellaForkVersion             string           `json:"capella_fork_version,omitempty"`
+	CapellaForkEpoch               string           `json:"capella_fork_epoch,omitempty"`
 	BLSToExecutionChangeDomainType string           `json:"bls_to_execution_change_domain_type"`
 	VoluntaryExitDomainType        string           `json:"voluntary_exit_domain_type"`
 }
 
+// farFutureEpoch is used to denote a fork that is not scheduled.
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
 type chainInfoVersionJSON struct {
 	Version string `json:"version"`
 }
@@ -63,6 +72,8 @@ func (c *ChainInfo) MarshalJSON() ([]byte, error) {
 		Epoch:                          fmt.Sprintf("%d", c.Epoch),
 		GenesisForkVersion:             fmt.Sprintf("%#x", c.GenesisForkVersion),
 		CurrentForkVersion:             fmt.Sprintf("%#x", c.CurrentForkVersion),
+		CapellaForkVersion:             fmt.Sprintf("%#x", c.CapellaForkVersion),
+		CapellaForkEpoch:               fmt.Sprintf("%d", c.CapellaForkEpoch),
 		BLSToExecutionChangeDomainType: fmt.Sprintf("%#x", c.BLSToExecutionChangeDomainType),
 		VoluntaryExitDomainType:        fmt.Sprintf("%#x", c.VoluntaryExitDomainType),
 	})
@@ -142,6 +153,31 @@ func (c *ChainInfo) UnmarshalJSON(input []byte) error {
 	}
 	copy(c.CurrentForkVersion[:], currentForkVersionBytes)
 
+	// Capella information is only present from version 3.
+	c.CapellaForkEpoch = farFutureEpoch
+	if version >= 3 {
+		if data.CapellaForkVersion == "" {
+			return errors.New("capella fork version missing")
+		}
+		capellaForkVersionBytes, err := hex.DecodeString(strings.TrimPrefix(data.CapellaForkVersion, "0x"))
+		if err != nil {
+			return errors.Wrap(err, "capella fork version invalid")
+		}
+		if len(capellaForkVersionBytes) != phase0.ForkVersionLength {
+			return errors.New("capella fork version incorrect length")
+		}
+		copy(c.CapellaForkVersion[:], capellaForkVersionBytes)
+
+		if data.CapellaForkEpoch == "" {
+			return errors.New("capella fork epoch missing")
+		}
+		capellaForkEpoch, err := strconv.ParseUint(data.CapellaForkEpoch, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "capella fork epoch invalid")
+		}
+		c.CapellaForkEpoch = phase0.Epoch(capellaForkEpoch)
+	}
+
 	if data.BLSToExecutionChangeDomainType == "" {
 		return errors.New("bls to execution domain type missing")
 	}
@@ -169,6 +205,23 @@ func (c *ChainInfo) UnmarshalJSON(input []byte) error {
 	return nil
 }
 
+// VoluntaryExitForkVersion provides the fork version with which voluntary exits
+// should be signed, along with the name of the fork to which it belongs.
+// From Capella onwards exits are signed with the Capella fork version, as
+// EIP-7044 fixes the voluntary exit domain to Capella for Deneb and later forks.
+// An error is returned if the Capella fork version is not known, as it is not
+// possible to tell if the chain has passed Capella.
+func (c *ChainInfo) VoluntaryExitForkVersion() (phase0.Version, string, error) {
+	if c.CapellaForkVersion == (phase0.Version{}) {
+		return phase0.Version{}, "", errors.New("capella fork version not known by chain")
+	}
+	if c.CapellaForkEpoch != farFutureEpoch && c.Epoch >= c.CapellaForkEpoch {
+		return c.CapellaForkVersion, "capella", nil
+	}
+
+	return c.CurrentForkVersion, "current", nil
+}
+
 // FetchValidatorInfo fetches validator info given a validator identifier.
 func (c *ChainInfo) FetchValidatorInfo(ctx context.Context, id string) (*ValidatorInfo, error) {
 	var validatorInfo *ValidatorInfo
@@ -229,17 +282,33 @@ func ObtainChainInfoFromNode(ctx context.Context,
 ) (
 	*ChainInfo,
 	error,
+) {
+	return ObtainChainInfoFromNodeForValidators(ctx, consensusClient, chainTime, nil)
+}
+
+// ObtainChainInfoFromNodeForValidators obtains the chain information from a node,
+// with validator information restricted to the supplied validators.  Validators
+// can be supplied as indices, public keys or accounts.  If no validators are
+// supplied then information for all validators is obtained.
+func ObtainChainInfoFromNodeForValidators(ctx context.Context,
+	consensusClient consensusclient.Service,
+	chainTime chaintime.Service,
+	ids []string,
+) (
+	*ChainInfo,
+	error,
 ) {
 	res := &ChainInfo{
-		Version:    2,
-		Validators: make([]*ValidatorInfo, 0),
-		Epoch:      chainTime.CurrentEpoch(),
+		Version:          3,
+		Validators:       make([]*ValidatorInfo, 0),
+		Epoch:            chainTime.CurrentEpoch(),
+		CapellaForkEpoch: farFutureEpoch,
 	}
 
 	// Obtain validators.
-	validators, err := consensusClient.(consensusclient.ValidatorsProvider).Validators(ctx, "head", nil)
+	validators, err := obtainValidators(ctx, consensusClient.(consensusclient.ValidatorsProvider), ids)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to obtain validators")
+		return nil, err
 	}
 
 	for _, validator := range validators {
@@ -250,6 +319,10 @@ func ObtainChainInfoFromNode(ctx context.Context,
 			State:                 validator.Status,
 		})
 	}
+	// Keep a consistent order, as the validators are obtained from a map.
+	sort.Slice(res.Validators, func(i int, j int) bool {
+		return res.Validators[i].Index < res.Validators[j].Index
+	})
 
 	// Genesis validators root obtained from beacon node.
 	genesis, err := consensusClient.(consensusclient.GenesisProvider).Genesis(ctx)
@@ -265,7 +338,7 @@ func ObtainChainInfoFromNode(ctx context.Context,
 	}
 	tmp, exists := spec["GENESIS_FORK_VERSION"]
 	if !exists {
-		return nil, errors.New("capella fork version not known by chain")
+		return nil, errors.New("genesis fork version not known by chain")
 	}
 	var isForkVersion bool
 	res.GenesisForkVersion, isForkVersion = tmp.(phase0.Version)
@@ -274,13 +347,18 @@ func ObtainChainInfoFromNode(ctx context.Context,
 	}
 
 	// Fetch the current fork version from the fork schedule.
-	forkSchedule, err := consensusClient.(consensusclient.ForkScheduleProvider).ForkSchedule(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to obtain fork schedule")
+	currentFork := chainTime.ForkAtEpoch(res.Epoch)
+	if currentFork == nil {
+		return nil, errors.New("failed to obtain current fork")
 	}
-	for i := range forkSchedule {
-		if forkSchedule[i].Epoch <= res.Epoch {
-			res.CurrentForkVersion = forkSchedule[i].CurrentVersion
+	res.CurrentForkVersion = currentFork.Version
+
+	// Fetch the Capella fork information, if the chain knows about it.
+	for _, fork := range chainTime.ForkSchedule() {
+		if fork.Name == "capella" {
+			res.CapellaForkVersion = fork.Version
+			res.CapellaForkEpoch = fork.Epoch
+			break
 		}
 	}
 
@@ -298,3 +376,79 @@ func ObtainChainInfoFromNode(ctx context.Context,
 
 	return res, nil
 }
+
+// obtainValidators obtains the validators with the given identifiers, or all validators if no identifiers are supplied.
+func obtainValidators(ctx context.Context,
+	validatorsProvider consensusclient.ValidatorsProvider,
+	ids []string,
+) (
+	map[phase0.ValidatorIndex]*apiv1.Validator,
+	error,
+) {
+	if len(ids) == 0 {
+		return obtainAllValidators(ctx, validatorsProvider, validatorFetchConcurrency, validatorShardSize)
+	}
+
+	indices := make([]phase0.ValidatorIndex, 0, len(ids))
+	pubkeys := make([]phase0.BLSPubKey, 0, len(ids))
+	for _, id := range ids {
+		switch {
+		case id == "":
+			return nil, errors.New("no validator specified")
+		case strings.HasPrefix(id, "0x"):
+			// A public key.
+			data, err := hex.DecodeString(strings.TrimPrefix(id, "0x"))
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to parse validator public key")
+			}
+			if len(data) != phase0.PublicKeyLength {
+				return nil, errors.New("incorrect length for validator public key")
+			}
+			var pubkey phase0.BLSPubKey
+			copy(pubkey[:], data)
+			pubkeys = append(pubkeys, pubkey)
+		case strings.Contains(id, "/"):
+			// An account.
+			_, account, err := util.WalletAndAccountFromPath(ctx, id)
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to obtain account")
+			}
+			accPubKey, err := util.BestPublicKey(account)
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to obtain public key for account")
+			}
+			var pubkey phase0.BLSPubKey
+			copy(pubkey[:], accPubKey.Marshal())
+			pubkeys = append(pubkeys, pubkey)
+		default:
+			// An index.
+			index, err := strconv.ParseUint(id, 10, 64)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to parse validator index")
+			}
+			indices = append(indices, phase0.ValidatorIndex(index))
+		}
+	}
+
+	res := make(map[phase0.ValidatorIndex]*apiv1.Validator, len(ids))
+	if len(indices) > 0 {
+		validators, err := validatorsProvider.Validators(ctx, "head", indices)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators by index")
+		}
+		for index, validator := range validators {
+			res[index] = validator
+		}
+	}
+	if len(pubkeys) > 0 {
+		validators, err := validatorsProvider.ValidatorsByPubKey(ctx, "head", pubkeys)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators by public key")
+		}
+		for index, validator := range validators {
+			res[index] = validator
+		}
+	}
+
+	return res, nil
+}
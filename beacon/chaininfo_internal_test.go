@@ -0,0 +1,110 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testing/mock"
+)
+
+func TestObtainValidators(t *testing.T) {
+	ctx := context.Background()
+
+	validators := make([]*apiv1.Validator, 0)
+	for i := 0; i < 4; i++ {
+		pubkey := phase0.BLSPubKey{}
+		pubkey[0] = byte(i + 1)
+		validators = append(validators, &apiv1.Validator{
+			Index:  phase0.ValidatorIndex(i),
+			Status: apiv1.ValidatorStateActiveOngoing,
+			Validator: &phase0.Validator{
+				PublicKey: pubkey,
+			},
+		})
+	}
+
+	tests := []struct {
+		name        string
+		ids         []string
+		indices     []phase0.ValidatorIndex
+		fullFetches int
+		err         string
+	}{
+		{
+			name:        "All",
+			indices:     []phase0.ValidatorIndex{0, 1, 2, 3},
+			fullFetches: 0,
+		},
+		{
+			name: "IDEmpty",
+			ids:  []string{""},
+			err:  "no validator specified",
+		},
+		{
+			name: "IndexInvalid",
+			ids:  []string{"bad"},
+			err:  `failed to parse validator index: strconv.ParseUint: parsing "bad": invalid syntax`,
+		},
+		{
+			name: "PubKeyShort",
+			ids:  []string{"0x01"},
+			err:  "incorrect length for validator public key",
+		},
+		{
+			name:    "Index",
+			ids:     []string{"2"},
+			indices: []phase0.ValidatorIndex{2},
+		},
+		{
+			name:    "PubKey",
+			ids:     []string{"0x030000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},
+			indices: []phase0.ValidatorIndex{2},
+		},
+		{
+			name:    "Mixed",
+			ids:     []string{"0", "0x040000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},
+			indices: []phase0.ValidatorIndex{0, 3},
+		},
+		{
+			name:    "Unknown",
+			ids:     []string{"10"},
+			indices: []phase0.ValidatorIndex{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provider := mock.NewValidatorsProvider(validators)
+			res, err := obtainValidators(ctx, newHeadValidatorsProvider(provider), test.ids)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				indices := make([]phase0.ValidatorIndex, 0, len(res))
+				for index := range res {
+					indices = append(indices, index)
+				}
+				sort.Slice(indices, func(i int, j int) bool { return indices[i] < indices[j] })
+				require.Equal(t, test.indices, indices)
+				require.Equal(t, test.fullFetches, provider.(*mock.ValidatorsProvider).FullFetches)
+			}
+		})
+	}
+}
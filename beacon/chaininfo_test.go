@@ -0,0 +1,91 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+)
+
+func TestVoluntaryExitForkVersion(t *testing.T) {
+	currentForkVersion := phase0.Version{0x04, 0x00, 0x00, 0x00}
+	capellaForkVersion := phase0.Version{0x03, 0x00, 0x00, 0x00}
+
+	tests := []struct {
+		name      string
+		chainInfo *beacon.ChainInfo
+		version   phase0.Version
+		fork      string
+		err       string
+	}{
+		{
+			name: "CapellaUnknown",
+			chainInfo: &beacon.ChainInfo{
+				Epoch:              100,
+				CurrentForkVersion: currentForkVersion,
+				CapellaForkEpoch:   0xffffffffffffffff,
+			},
+			err: "capella fork version not known by chain",
+		},
+		{
+			name: "CapellaNotScheduled",
+			chainInfo: &beacon.ChainInfo{
+				Epoch:              100,
+				CurrentForkVersion: currentForkVersion,
+				CapellaForkVersion: capellaForkVersion,
+				CapellaForkEpoch:   0xffffffffffffffff,
+			},
+			version: currentForkVersion,
+			fork:    "current",
+		},
+		{
+			name: "PreCapella",
+			chainInfo: &beacon.ChainInfo{
+				Epoch:              100,
+				CurrentForkVersion: currentForkVersion,
+				CapellaForkVersion: capellaForkVersion,
+				CapellaForkEpoch:   200,
+			},
+			version: currentForkVersion,
+			fork:    "current",
+		},
+		{
+			name: "PostCapella",
+			chainInfo: &beacon.ChainInfo{
+				Epoch:              300,
+				CurrentForkVersion: currentForkVersion,
+				CapellaForkVersion: capellaForkVersion,
+				CapellaForkEpoch:   200,
+			},
+			version: capellaForkVersion,
+			fork:    "capella",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			version, fork, err := test.chainInfo.VoluntaryExitForkVersion()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.version, version)
+			require.Equal(t, test.fork, fork)
+		})
+	}
+}
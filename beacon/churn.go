@@ -0,0 +1,197 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// Churn contains the churn limits of the chain at an epoch.
+type Churn struct {
+	// Electra is true if the churn is balance-based.
+	Electra bool
+	// ActivationChurn is the number of validators that can be activated per
+	// epoch.  From Electra onwards this is expressed in terms of validators
+	// with the minimum activation balance.
+	ActivationChurn uint64
+	// ActivationBalanceChurn is the balance that can be activated per epoch.
+	ActivationBalanceChurn phase0.Gwei
+	// ExitChurn is the number of validators that can exit per epoch.  From
+	// Electra onwards this is expressed in terms of validators with the
+	// minimum activation balance.
+	ExitChurn uint64
+	// ExitBalanceChurn is the balance that can exit per epoch.
+	ExitBalanceChurn phase0.Gwei
+	// BalanceChurn is the total balance churn per epoch from Electra onwards.
+	BalanceChurn phase0.Gwei
+	// ConsolidationBalanceChurn is the balance that can be consolidated per
+	// epoch from Electra onwards.
+	ConsolidationBalanceChurn phase0.Gwei
+	// MaxDepositsPerEpoch is the maximum number of pending deposits processed
+	// per epoch from Electra onwards.
+	MaxDepositsPerEpoch uint64
+}
+
+// IsElectra returns true if the given epoch is at or after the Electra fork.
+func IsElectra(spec map[string]interface{}, epoch phase0.Epoch) (bool, error) {
+	tmp, exists := spec["ELECTRA_FORK_EPOCH"]
+	if !exists {
+		return false, nil
+	}
+	electraForkEpoch, isType := tmp.(uint64)
+	if !isType {
+		return false, errors.New("ELECTRA_FORK_EPOCH of incorrect type")
+	}
+
+	return uint64(epoch) >= electraForkEpoch, nil
+}
+
+// CalculateChurn calculates the churn limits at the given epoch, given the
+// number of active validators and their total effective balance.
+func CalculateChurn(spec map[string]interface{},
+	epoch phase0.Epoch,
+	activeValidators uint64,
+	totalActiveBalance phase0.Gwei,
+) (
+	*Churn,
+	error,
+) {
+	// Churn is based on balance rather than validators from Electra onwards.
+	electra, err := IsElectra(spec, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	if electra {
+		return calculateElectraChurn(spec, totalActiveBalance)
+	}
+
+	return calculatePhase0Churn(spec, activeValidators)
+}
+
+// calculatePhase0Churn calculates the number of validators that can enter
+// and exit per epoch prior to Electra.
+func calculatePhase0Churn(spec map[string]interface{}, activeValidators uint64) (*Churn, error) {
+	var minChurnLimit, churnLimitQuotient, maxEffectiveBalance uint64
+	if err := specValues(spec, map[string]*uint64{
+		"MIN_PER_EPOCH_CHURN_LIMIT": &minChurnLimit,
+		"CHURN_LIMIT_QUOTIENT":      &churnLimitQuotient,
+		"MAX_EFFECTIVE_BALANCE":     &maxEffectiveBalance,
+	}); err != nil {
+		return nil, err
+	}
+
+	churn := &Churn{}
+	churn.ExitChurn = churnLimit(activeValidators, minChurnLimit, churnLimitQuotient)
+	churn.ActivationChurn = churn.ExitChurn
+
+	// Activation churn is capped from Deneb onwards.
+	if tmp, exists := spec["MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT"]; exists {
+		maxActivationChurnLimit, isType := tmp.(uint64)
+		if !isType {
+			return nil, errors.New("MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT of incorrect type")
+		}
+		if churn.ActivationChurn > maxActivationChurnLimit {
+			churn.ActivationChurn = maxActivationChurnLimit
+		}
+	}
+
+	churn.ActivationBalanceChurn = phase0.Gwei(churn.ActivationChurn * maxEffectiveBalance)
+	churn.ExitBalanceChurn = phase0.Gwei(churn.ExitChurn * maxEffectiveBalance)
+
+	return churn, nil
+}
+
+// calculateElectraChurn calculates the balance that can enter, exit and
+// consolidate per epoch from Electra onwards.
+func calculateElectraChurn(spec map[string]interface{}, totalActiveBalance phase0.Gwei) (*Churn, error) {
+	churn := &Churn{
+		Electra: true,
+	}
+
+	var minChurnLimit, churnLimitQuotient, maxActivationExitChurnLimit, effectiveBalanceIncrement, minActivationBalance uint64
+	if err := specValues(spec, map[string]*uint64{
+		"MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA":         &minChurnLimit,
+		"CHURN_LIMIT_QUOTIENT":                      &churnLimitQuotient,
+		"MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT": &maxActivationExitChurnLimit,
+		"EFFECTIVE_BALANCE_INCREMENT":               &effectiveBalanceIncrement,
+		"MIN_ACTIVATION_BALANCE":                    &minActivationBalance,
+		"MAX_PENDING_DEPOSITS_PER_EPOCH":            &churn.MaxDepositsPerEpoch,
+	}); err != nil {
+		return nil, err
+	}
+	if effectiveBalanceIncrement == 0 || minActivationBalance == 0 {
+		return nil, errors.New("spec balance values must be greater than 0")
+	}
+
+	balanceChurn := churnLimit(uint64(totalActiveBalance), minChurnLimit, churnLimitQuotient)
+	churn.BalanceChurn = phase0.Gwei(balanceChurn - balanceChurn%effectiveBalanceIncrement)
+
+	activationExitChurn := churn.BalanceChurn
+	if activationExitChurn > phase0.Gwei(maxActivationExitChurnLimit) {
+		activationExitChurn = phase0.Gwei(maxActivationExitChurnLimit)
+	}
+	churn.ActivationBalanceChurn = activationExitChurn
+	churn.ExitBalanceChurn = activationExitChurn
+	churn.ConsolidationBalanceChurn = churn.BalanceChurn - activationExitChurn
+
+	// Validator churn is expressed in terms of validators with the minimum
+	// activation balance.
+	churn.ActivationChurn = uint64(churn.ActivationBalanceChurn) / minActivationBalance
+	churn.ExitChurn = uint64(churn.ExitBalanceChurn) / minActivationBalance
+
+	return churn, nil
+}
+
+// specValues obtains the given unsigned integer values from the spec.
+func specValues(spec map[string]interface{}, values map[string]*uint64) error {
+	for key, value := range values {
+		tmp, exists := spec[key]
+		if !exists {
+			return fmt.Errorf("spec missing %s", key)
+		}
+		var isType bool
+		*value, isType = tmp.(uint64)
+		if !isType {
+			return fmt.Errorf("%s of incorrect type", key)
+		}
+	}
+
+	return nil
+}
+
+// churnLimit calculates the churn limit for the given number of active
+// validators or, from Electra onwards, the given active balance.
+func churnLimit(active uint64, minChurnLimit uint64, churnLimitQuotient uint64) uint64 {
+	if churnLimitQuotient == 0 {
+		return minChurnLimit
+	}
+	churn := active / churnLimitQuotient
+	if churn < minChurnLimit {
+		return minChurnLimit
+	}
+	return churn
+}
+
+// QueueEpochs calculates the number of epochs required to process a queue
+// with the given churn per epoch.
+func QueueEpochs(queue uint64, churn uint64) uint64 {
+	if queue == 0 || churn == 0 {
+		return 0
+	}
+	return (queue + churn - 1) / churn
+}
@@ -0,0 +1,144 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateChurn(t *testing.T) {
+	spec := map[string]interface{}{
+		"MIN_PER_EPOCH_CHURN_LIMIT":                 uint64(4),
+		"CHURN_LIMIT_QUOTIENT":                      uint64(65536),
+		"MAX_EFFECTIVE_BALANCE":                     uint64(32000000000),
+		"MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT":      uint64(8),
+		"ELECTRA_FORK_EPOCH":                        uint64(1000),
+		"MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA":         uint64(128000000000),
+		"MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT": uint64(256000000000),
+		"EFFECTIVE_BALANCE_INCREMENT":               uint64(1000000000),
+		"MIN_ACTIVATION_BALANCE":                    uint64(32000000000),
+		"MAX_PENDING_DEPOSITS_PER_EPOCH":            uint64(16),
+	}
+
+	tests := []struct {
+		name                      string
+		spec                      map[string]interface{}
+		epoch                     phase0.Epoch
+		activeValidators          uint64
+		totalActiveBalance        phase0.Gwei
+		electra                   bool
+		activationChurn           uint64
+		activationBalanceChurn    phase0.Gwei
+		exitChurn                 uint64
+		exitBalanceChurn          phase0.Gwei
+		balanceChurn              phase0.Gwei
+		consolidationBalanceChurn phase0.Gwei
+		err                       string
+	}{
+		{
+			name: "SpecMissing",
+			spec: map[string]interface{}{
+				"MIN_PER_EPOCH_CHURN_LIMIT": uint64(4),
+				"CHURN_LIMIT_QUOTIENT":      uint64(65536),
+			},
+			epoch: 10,
+			err:   "spec missing MAX_EFFECTIVE_BALANCE",
+		},
+		{
+			name: "SpecIncorrectType",
+			spec: map[string]interface{}{
+				"ELECTRA_FORK_EPOCH": "1000",
+			},
+			epoch: 10,
+			err:   "ELECTRA_FORK_EPOCH of incorrect type",
+		},
+		{
+			name:                   "Minimum",
+			spec:                   spec,
+			epoch:                  10,
+			activeValidators:       100000,
+			totalActiveBalance:     3200000000000000,
+			activationChurn:        4,
+			activationBalanceChurn: 128000000000,
+			exitChurn:              4,
+			exitBalanceChurn:       128000000000,
+		},
+		{
+			name:                   "ActivationCapped",
+			spec:                   spec,
+			epoch:                  10,
+			activeValidators:       1000000,
+			totalActiveBalance:     32000000000000000,
+			activationChurn:        8,
+			activationBalanceChurn: 256000000000,
+			exitChurn:              15,
+			exitBalanceChurn:       480000000000,
+		},
+		{
+			name:                   "ElectraMinimum",
+			spec:                   spec,
+			epoch:                  1000,
+			activeValidators:       100000,
+			totalActiveBalance:     3200000000000000,
+			electra:                true,
+			activationChurn:        4,
+			activationBalanceChurn: 128000000000,
+			exitChurn:              4,
+			exitBalanceChurn:       128000000000,
+			balanceChurn:           128000000000,
+		},
+		{
+			name:                      "Electra",
+			spec:                      spec,
+			epoch:                     1000,
+			activeValidators:          1000000,
+			totalActiveBalance:        32000000000000000,
+			electra:                   true,
+			activationChurn:           8,
+			activationBalanceChurn:    256000000000,
+			exitChurn:                 8,
+			exitBalanceChurn:          256000000000,
+			balanceChurn:              488000000000,
+			consolidationBalanceChurn: 232000000000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			churn, err := CalculateChurn(test.spec, test.epoch, test.activeValidators, test.totalActiveBalance)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.electra, churn.Electra)
+			require.Equal(t, test.activationChurn, churn.ActivationChurn)
+			require.Equal(t, test.activationBalanceChurn, churn.ActivationBalanceChurn)
+			require.Equal(t, test.exitChurn, churn.ExitChurn)
+			require.Equal(t, test.exitBalanceChurn, churn.ExitBalanceChurn)
+			require.Equal(t, test.balanceChurn, churn.BalanceChurn)
+			require.Equal(t, test.consolidationBalanceChurn, churn.ConsolidationBalanceChurn)
+		})
+	}
+}
+
+func TestQueueEpochs(t *testing.T) {
+	require.Equal(t, uint64(0), QueueEpochs(0, 8))
+	require.Equal(t, uint64(0), QueueEpochs(10, 0))
+	require.Equal(t, uint64(2), QueueEpochs(16, 8))
+	require.Equal(t, uint64(3), QueueEpochs(17, 8))
+}
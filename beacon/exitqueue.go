@@ -0,0 +1,218 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"context"
+	"fmt"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+// exitQueueParams are the spec values used to calculate the exit queue.
+type exitQueueParams struct {
+	maxSeedLookahead                 uint64
+	minValidatorWithdrawabilityDelay uint64
+}
+
+// defaultExitQueueParams returns the mainnet values, which are shared by all public networks.
+func defaultExitQueueParams() *exitQueueParams {
+	return &exitQueueParams{
+		maxSeedLookahead:                 4,
+		minValidatorWithdrawabilityDelay: 256,
+	}
+}
+
+// exitQueueParamsFromSpec overrides the parameters with those supplied by the spec.
+func exitQueueParamsFromSpec(p *exitQueueParams, specData map[string]interface{}) error {
+	for name, field := range map[string]*uint64{
+		"MAX_SEED_LOOKAHEAD":                  &p.maxSeedLookahead,
+		"MIN_VALIDATOR_WITHDRAWABILITY_DELAY": &p.minValidatorWithdrawabilityDelay,
+	} {
+		tmp, exists := specData[name]
+		if !exists {
+			// Keep the default.
+			continue
+		}
+		val, good := tmp.(uint64)
+		if !good {
+			return fmt.Errorf("%s value invalid", name)
+		}
+		*field = val
+	}
+
+	return nil
+}
+
+// ExitQueue is the state of the chain's exit queue, used to estimate when
+// new exits will take effect.
+type ExitQueue struct {
+	// Electra is true if the exit queue is balance-based.
+	Electra bool
+	// Length is the number of validators that have initiated an exit but
+	// not yet exited.
+	Length uint64
+	// Balance is the effective balance of the validators that have initiated
+	// an exit but not yet exited.
+	Balance phase0.Gwei
+	// ChurnLimit is the maximum number of validators that can exit in a
+	// single epoch.  From Electra onwards this is expressed in terms of
+	// validators with the minimum activation balance.
+	ChurnLimit uint64
+	// BalanceChurnLimit is the maximum balance that can exit in a single
+	// epoch.
+	BalanceChurnLimit phase0.Gwei
+
+	epoch                phase0.Epoch
+	churn                uint64
+	balanceToConsume     phase0.Gwei
+	withdrawabilityDelay phase0.Epoch
+	balances             map[phase0.ValidatorIndex]phase0.Gwei
+}
+
+// ObtainExitQueue obtains the current state of the exit queue from a node.
+// This requires information about all validators, so can take a while on
+// large chains.
+func ObtainExitQueue(ctx context.Context,
+	consensusClient consensusclient.Service,
+	chainTime chaintime.Service,
+) (
+	*ExitQueue,
+	error,
+) {
+	params := defaultExitQueueParams()
+	specData, err := consensusClient.(consensusclient.SpecProvider).Spec(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+	if err := exitQueueParamsFromSpec(params, specData); err != nil {
+		return nil, err
+	}
+
+	validators, err := obtainAllValidators(ctx, consensusClient.(consensusclient.ValidatorsProvider), validatorFetchConcurrency, validatorShardSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return newExitQueue(validators, chainTime.CurrentEpoch(), params, specData)
+}
+
+// newExitQueue calculates the exit queue from the validators, as per the
+// spec's initiate_validator_exit().
+//
+// From Electra onwards the chain tracks the balance that can still exit in
+// the latest exit epoch, but this is not available from the validators.  It
+// is estimated from the balance of the validators exiting in that epoch, so
+// does not account for partial withdrawals or exits that span epochs.
+func newExitQueue(validators map[phase0.ValidatorIndex]*apiv1.Validator,
+	currentEpoch phase0.Epoch,
+	params *exitQueueParams,
+	specData map[string]interface{},
+) (
+	*ExitQueue,
+	error,
+) {
+	res := &ExitQueue{
+		epoch:                currentEpoch + 1 + phase0.Epoch(params.maxSeedLookahead),
+		withdrawabilityDelay: phase0.Epoch(params.minValidatorWithdrawabilityDelay),
+		balances:             make(map[phase0.ValidatorIndex]phase0.Gwei, len(validators)),
+	}
+
+	activeValidators := uint64(0)
+	totalActiveBalance := phase0.Gwei(0)
+	for index, validator := range validators {
+		if validator.Validator == nil {
+			continue
+		}
+		res.balances[index] = validator.Validator.EffectiveBalance
+		exitEpoch := validator.Validator.ExitEpoch
+		if exitEpoch != farFutureEpoch {
+			if exitEpoch > currentEpoch {
+				res.Length++
+				res.Balance += validator.Validator.EffectiveBalance
+			}
+			if exitEpoch > res.epoch {
+				res.epoch = exitEpoch
+			}
+		}
+		if validator.Validator.ActivationEpoch <= currentEpoch && currentEpoch < exitEpoch {
+			activeValidators++
+			totalActiveBalance += validator.Validator.EffectiveBalance
+		}
+	}
+
+	churn, err := CalculateChurn(specData, currentEpoch, activeValidators, totalActiveBalance)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate churn")
+	}
+	res.Electra = churn.Electra
+	res.ChurnLimit = churn.ExitChurn
+	res.BalanceChurnLimit = churn.ExitBalanceChurn
+
+	consumed := phase0.Gwei(0)
+	for _, validator := range validators {
+		if validator.Validator != nil && validator.Validator.ExitEpoch == res.epoch {
+			res.churn++
+			consumed += validator.Validator.EffectiveBalance
+		}
+	}
+	if consumed < res.BalanceChurnLimit {
+		res.balanceToConsume = res.BalanceChurnLimit - consumed
+	}
+
+	return res, nil
+}
+
+// Add adds the exit of the given validator to the queue, returning its
+// estimated exit and withdrawable epochs.  Exits are assumed to be included
+// on chain in the current epoch, and in the order in which they are added.
+func (q *ExitQueue) Add(index phase0.ValidatorIndex) (phase0.Epoch, phase0.Epoch, error) {
+	balance, exists := q.balances[index]
+	if !exists {
+		return 0, 0, fmt.Errorf("validator %d not known", index)
+	}
+
+	if q.Electra {
+		q.addBalance(balance)
+	} else {
+		if q.churn >= q.ChurnLimit {
+			q.epoch++
+			q.churn = 0
+		}
+		q.churn++
+	}
+	q.Length++
+	q.Balance += balance
+
+	return q.epoch, q.epoch + q.withdrawabilityDelay, nil
+}
+
+// addBalance adds an exit of the given balance to the queue, as per the
+// spec's compute_exit_epoch_and_update_churn().
+func (q *ExitQueue) addBalance(balance phase0.Gwei) {
+	if q.BalanceChurnLimit == 0 {
+		return
+	}
+
+	if balance > q.balanceToConsume {
+		additionalEpochs := (balance-q.balanceToConsume-1)/q.BalanceChurnLimit + 1
+		q.epoch += phase0.Epoch(additionalEpochs)
+		q.balanceToConsume += additionalEpochs * q.BalanceChurnLimit
+	}
+	q.balanceToConsume -= balance
+}
@@ -0,0 +1,238 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+// exitQueueValidators creates active validators, the first of which have
+// exits at the given epochs.
+func exitQueueValidators(active int, exitEpochs ...phase0.Epoch) map[phase0.ValidatorIndex]*apiv1.Validator {
+	res := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+	for i := 0; i < active; i++ {
+		exitEpoch := farFutureEpoch
+		if i < len(exitEpochs) {
+			exitEpoch = exitEpochs[i]
+		}
+		res[phase0.ValidatorIndex(i)] = &apiv1.Validator{
+			Index: phase0.ValidatorIndex(i),
+			Validator: &phase0.Validator{
+				EffectiveBalance: 32000000000,
+				ActivationEpoch:  0,
+				ExitEpoch:        exitEpoch,
+			},
+		}
+	}
+
+	return res
+}
+
+// withEffectiveBalance sets the effective balance of the given validator.
+func withEffectiveBalance(validators map[phase0.ValidatorIndex]*apiv1.Validator,
+	index phase0.ValidatorIndex,
+	balance phase0.Gwei,
+) map[phase0.ValidatorIndex]*apiv1.Validator {
+	validators[index].Validator.EffectiveBalance = balance
+
+	return validators
+}
+
+func TestExitQueue(t *testing.T) {
+	spec := map[string]interface{}{
+		"MIN_PER_EPOCH_CHURN_LIMIT":                 uint64(4),
+		"CHURN_LIMIT_QUOTIENT":                      uint64(65536),
+		"MAX_EFFECTIVE_BALANCE":                     uint64(32000000000),
+		"ELECTRA_FORK_EPOCH":                        uint64(2000),
+		"MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA":         uint64(128000000000),
+		"MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT": uint64(256000000000),
+		"EFFECTIVE_BALANCE_INCREMENT":               uint64(1000000000),
+		"MIN_ACTIVATION_BALANCE":                    uint64(32000000000),
+		"MAX_PENDING_DEPOSITS_PER_EPOCH":            uint64(16),
+	}
+
+	tests := []struct {
+		name              string
+		validators        map[phase0.ValidatorIndex]*apiv1.Validator
+		epoch             phase0.Epoch
+		params            *exitQueueParams
+		spec              map[string]interface{}
+		electra           bool
+		length            uint64
+		balance           phase0.Gwei
+		churnLimit        uint64
+		balanceChurnLimit phase0.Gwei
+		exits             []phase0.Epoch
+		err               string
+	}{
+		{
+			name:       "SpecMissing",
+			validators: exitQueueValidators(100),
+			epoch:      1000,
+			params:     defaultExitQueueParams(),
+			spec: map[string]interface{}{
+				"MIN_PER_EPOCH_CHURN_LIMIT": uint64(4),
+				"CHURN_LIMIT_QUOTIENT":      uint64(65536),
+			},
+			err: "failed to calculate churn: spec missing MAX_EFFECTIVE_BALANCE",
+		},
+		{
+			name:              "Empty",
+			validators:        exitQueueValidators(100),
+			epoch:             1000,
+			params:            defaultExitQueueParams(),
+			spec:              spec,
+			churnLimit:        4,
+			balanceChurnLimit: 128000000000,
+			exits:             []phase0.Epoch{1005, 1005, 1005, 1005, 1006},
+		},
+		{
+			name:              "PastExits",
+			validators:        exitQueueValidators(100, 10, 20),
+			epoch:             1000,
+			params:            defaultExitQueueParams(),
+			spec:              spec,
+			churnLimit:        4,
+			balanceChurnLimit: 128000000000,
+			exits:             []phase0.Epoch{1005},
+		},
+		{
+			name:              "Queue",
+			validators:        exitQueueValidators(100, 1010, 1010, 1010, 1010, 1008),
+			epoch:             1000,
+			params:            defaultExitQueueParams(),
+			spec:              spec,
+			length:            5,
+			balance:           160000000000,
+			churnLimit:        4,
+			balanceChurnLimit: 128000000000,
+			exits:             []phase0.Epoch{1011, 1011, 1011, 1011, 1012},
+		},
+		{
+			name:              "PartialEpoch",
+			validators:        exitQueueValidators(100, 1010, 1010),
+			epoch:             1000,
+			params:            defaultExitQueueParams(),
+			spec:              spec,
+			length:            2,
+			balance:           64000000000,
+			churnLimit:        4,
+			balanceChurnLimit: 128000000000,
+			exits:             []phase0.Epoch{1010, 1010, 1011},
+		},
+		{
+			name:       "ChurnFromActive",
+			validators: exitQueueValidators(100),
+			epoch:      1000,
+			params:     defaultExitQueueParams(),
+			spec: map[string]interface{}{
+				"MIN_PER_EPOCH_CHURN_LIMIT": uint64(1),
+				"CHURN_LIMIT_QUOTIENT":      uint64(50),
+				"MAX_EFFECTIVE_BALANCE":     uint64(32000000000),
+			},
+			churnLimit:        2,
+			balanceChurnLimit: 64000000000,
+			exits:             []phase0.Epoch{1005, 1005, 1006},
+		},
+		{
+			name:              "ElectraEmpty",
+			validators:        exitQueueValidators(100),
+			epoch:             2000,
+			params:            defaultExitQueueParams(),
+			spec:              spec,
+			electra:           true,
+			churnLimit:        4,
+			balanceChurnLimit: 128000000000,
+			exits:             []phase0.Epoch{2005, 2005, 2005, 2005, 2006},
+		},
+		{
+			name:              "ElectraPartialEpoch",
+			validators:        exitQueueValidators(100, 2010, 2010),
+			epoch:             2000,
+			params:            defaultExitQueueParams(),
+			spec:              spec,
+			electra:           true,
+			length:            2,
+			balance:           64000000000,
+			churnLimit:        4,
+			balanceChurnLimit: 128000000000,
+			exits:             []phase0.Epoch{2010, 2010, 2011},
+		},
+		{
+			name:              "ElectraLargeBalance",
+			validators:        withEffectiveBalance(exitQueueValidators(100), 0, 2048000000000),
+			epoch:             2000,
+			params:            defaultExitQueueParams(),
+			spec:              spec,
+			electra:           true,
+			churnLimit:        4,
+			balanceChurnLimit: 128000000000,
+			exits:             []phase0.Epoch{2020, 2021, 2021, 2021, 2021, 2022},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			queue, err := newExitQueue(test.validators, test.epoch, test.params, test.spec)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.electra, queue.Electra)
+			require.Equal(t, test.length, queue.Length)
+			require.Equal(t, test.balance, queue.Balance)
+			require.Equal(t, test.churnLimit, queue.ChurnLimit)
+			require.Equal(t, test.balanceChurnLimit, queue.BalanceChurnLimit)
+			balance := test.balance
+			for i, expected := range test.exits {
+				exitEpoch, withdrawableEpoch, err := queue.Add(phase0.ValidatorIndex(i))
+				require.NoError(t, err)
+				require.Equal(t, expected, exitEpoch)
+				require.Equal(t, expected+phase0.Epoch(test.params.minValidatorWithdrawabilityDelay), withdrawableEpoch)
+				balance += test.validators[phase0.ValidatorIndex(i)].Validator.EffectiveBalance
+			}
+			require.Equal(t, test.length+uint64(len(test.exits)), queue.Length)
+			require.Equal(t, balance, queue.Balance)
+		})
+	}
+}
+
+func TestExitQueueAddUnknown(t *testing.T) {
+	queue, err := newExitQueue(exitQueueValidators(10), 1000, defaultExitQueueParams(), map[string]interface{}{
+		"MIN_PER_EPOCH_CHURN_LIMIT": uint64(4),
+		"CHURN_LIMIT_QUOTIENT":      uint64(65536),
+		"MAX_EFFECTIVE_BALANCE":     uint64(32000000000),
+	})
+	require.NoError(t, err)
+	_, _, err = queue.Add(10)
+	require.EqualError(t, err, "validator 10 not known")
+}
+
+func TestExitQueueParamsFromSpec(t *testing.T) {
+	p := defaultExitQueueParams()
+	require.NoError(t, exitQueueParamsFromSpec(p, map[string]interface{}{
+		"MIN_VALIDATOR_WITHDRAWABILITY_DELAY": uint64(8),
+	}))
+	require.Equal(t, uint64(8), p.minValidatorWithdrawabilityDelay)
+	require.Equal(t, uint64(4), p.maxSeedLookahead)
+
+	require.EqualError(t, exitQueueParamsFromSpec(defaultExitQueueParams(), map[string]interface{}{
+		"MAX_SEED_LOOKAHEAD": "4",
+	}), "MAX_SEED_LOOKAHEAD value invalid")
+}
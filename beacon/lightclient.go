@@ -0,0 +1,409 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// maxLightClientUpdates is the maximum number of light client updates that can be requested at a time.
+const maxLightClientUpdates = 128
+
+// syncCommitteeDomainType is the domain type for sync committee signatures.
+var syncCommitteeDomainType = phase0.DomainType{0x07, 0x00, 0x00, 0x00}
+
+// Generalized indices of the items proven by light client data, prior to and from Electra.
+const (
+	currentSyncCommitteeGindex        = 54
+	nextSyncCommitteeGindex           = 55
+	finalizedRootGindex               = 105
+	electraCurrentSyncCommitteeGindex = 86
+	electraNextSyncCommitteeGindex    = 87
+	electraFinalizedRootGindex        = 169
+)
+
+type lightClientHeader struct {
+	Beacon *phase0.BeaconBlockHeader `json:"beacon"`
+}
+
+type lightClientBootstrap struct {
+	Header                     *lightClientHeader    `json:"header"`
+	CurrentSyncCommittee       *altair.SyncCommittee `json:"current_sync_committee"`
+	CurrentSyncCommitteeBranch []string              `json:"current_sync_committee_branch"`
+}
+
+type lightClientUpdate struct {
+	AttestedHeader          *lightClientHeader    `json:"attested_header"`
+	NextSyncCommittee       *altair.SyncCommittee `json:"next_sync_committee,omitempty"`
+	NextSyncCommitteeBranch []string              `json:"next_sync_committee_branch,omitempty"`
+	FinalizedHeader         *lightClientHeader    `json:"finalized_header"`
+	FinalityBranch          []string              `json:"finality_branch"`
+	SyncAggregate           *altair.SyncAggregate `json:"sync_aggregate"`
+	SignatureSlot           string                `json:"signature_slot"`
+}
+
+type lightClientResponse struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// lightClient follows the chain from a trusted block root using sync committee signatures.
+type lightClient struct {
+	chainTime             chaintime.Service
+	genesisValidatorsRoot phase0.Root
+	// committees are the verified sync committees, by period.
+	committees map[uint64]*altair.SyncCommittee
+	finalized  *phase0.BeaconBlockHeader
+	// forkVersion is the fork version used to verify the most recent signature.
+	forkVersion phase0.Version
+}
+
+// VerifyWithLightClient verifies the chain information obtained from a beacon
+// node that is not trusted, by following the chain from a trusted block root
+// using light client data from the node at the given address.  The sync committee
+// signatures followed are only valid for the canonical chain's genesis validators
+// root and fork versions, which confirms the genesis validators root and current
+// fork version in the chain information; the genesis and Capella fork versions,
+// which are not covered by sync committee signatures, are confirmed against the
+// well-known network with the verified genesis validators root.
+// The validators in the chain information are not verified, as the beacon
+// API does not provide proofs for them.
+// Each request to the node is subject to the timeout.
+// It returns the slot of the most recent finalized header verified.
+func (c *ChainInfo) VerifyWithLightClient(ctx context.Context,
+	address string,
+	timeout time.Duration,
+	chainTime chaintime.Service,
+	trustedRoot phase0.Root,
+) (
+	phase0.Slot,
+	error,
+) {
+	client := &lightClient{
+		chainTime:             chainTime,
+		genesisValidatorsRoot: c.GenesisValidatorsRoot,
+		committees:            make(map[uint64]*altair.SyncCommittee),
+	}
+
+	if err := client.bootstrap(ctx, address, timeout, trustedRoot); err != nil {
+		return 0, err
+	}
+
+	period := chainTime.SlotToSyncCommitteePeriod(client.finalized.Slot)
+	currentPeriod := chainTime.CurrentSyncCommitteePeriod()
+	for period < currentPeriod {
+		count := currentPeriod - period
+		if count > maxLightClientUpdates {
+			count = maxLightClientUpdates
+		}
+		updates, err := fetchLightClientUpdates(ctx, address, timeout, period, count)
+		if err != nil {
+			return 0, err
+		}
+		if len(updates) == 0 {
+			return 0, fmt.Errorf("no light client updates available from period %d", period)
+		}
+		for _, update := range updates {
+			if err := client.applyUpdate(update, true); err != nil {
+				return 0, err
+			}
+		}
+		period += uint64(len(updates))
+	}
+
+	finalityUpdate := &lightClientResponse{}
+	found, err := util.FetchBeaconNodeJSON(ctx, address, timeout, "/eth/v1/beacon/light_client/finality_update", nil, finalityUpdate)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, errors.New("light client finality update not available")
+	}
+	if err := client.applyUpdate(finalityUpdate, false); err != nil {
+		return 0, err
+	}
+
+	if !bytes.Equal(c.CurrentForkVersion[:], client.forkVersion[:]) {
+		return 0, fmt.Errorf("current fork version %#x does not match verified fork version %#x", c.CurrentForkVersion, client.forkVersion)
+	}
+
+	network := networkByGenesisValidatorsRoot(c.GenesisValidatorsRoot)
+	if network == nil {
+		return 0, fmt.Errorf("genesis validators root %#x is not that of a well-known network, so genesis and Capella fork versions cannot be verified", c.GenesisValidatorsRoot)
+	}
+	if genesisForkVersion := network.GenesisForkVersion(); !bytes.Equal(c.GenesisForkVersion[:], genesisForkVersion[:]) {
+		return 0, fmt.Errorf("genesis fork version %#x does not match %s genesis fork version %#x", c.GenesisForkVersion, network.Name, genesisForkVersion)
+	}
+	if capella := network.Fork("capella"); capella != nil && !bytes.Equal(c.CapellaForkVersion[:], capella.Version[:]) {
+		return 0, fmt.Errorf("capella fork version %#x does not match %s capella fork version %#x", c.CapellaForkVersion, network.Name, capella.Version)
+	}
+
+	return client.finalized.Slot, nil
+}
+
+// bootstrap starts the light client at the trusted block root.
+func (l *lightClient) bootstrap(ctx context.Context, address string, timeout time.Duration, trustedRoot phase0.Root) error {
+	res := &lightClientResponse{}
+	found, err := util.FetchBeaconNodeJSON(ctx, address, timeout, fmt.Sprintf("/eth/v1/beacon/light_client/bootstrap/%#x", trustedRoot), nil, res)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("light client bootstrap not available for trusted block root %#x; it must be the root of a recent finalized block", trustedRoot)
+	}
+	bootstrap := &lightClientBootstrap{}
+	if err := json.Unmarshal(res.Data, bootstrap); err != nil {
+		return errors.Wrap(err, "failed to parse light client bootstrap")
+	}
+	if bootstrap.Header == nil || bootstrap.Header.Beacon == nil || bootstrap.CurrentSyncCommittee == nil {
+		return errors.New("light client bootstrap incomplete")
+	}
+
+	root, err := bootstrap.Header.Beacon.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate bootstrap header root")
+	}
+	if !bytes.Equal(root[:], trustedRoot[:]) {
+		return fmt.Errorf("light client bootstrap header root %#x does not match trusted block root %#x", root, trustedRoot)
+	}
+
+	gindex, _, _ := lightClientGindices(res.Version)
+	if err := verifySyncCommitteeBranch(bootstrap.CurrentSyncCommittee, bootstrap.CurrentSyncCommitteeBranch, gindex, bootstrap.Header.Beacon.StateRoot); err != nil {
+		return errors.Wrap(err, "invalid current sync committee in light client bootstrap")
+	}
+
+	l.committees[l.chainTime.SlotToSyncCommitteePeriod(bootstrap.Header.Beacon.Slot)] = bootstrap.CurrentSyncCommittee
+	l.finalized = bootstrap.Header.Beacon
+
+	return nil
+}
+
+// applyUpdate verifies a light client update against the known sync committees,
+// and applies the verified sync committee and finalized header that it contains.
+func (l *lightClient) applyUpdate(res *lightClientResponse, requireNextSyncCommittee bool) error {
+	update := &lightClientUpdate{}
+	if err := json.Unmarshal(res.Data, update); err != nil {
+		return errors.Wrap(err, "failed to parse light client update")
+	}
+	if update.AttestedHeader == nil || update.AttestedHeader.Beacon == nil || update.SyncAggregate == nil {
+		return errors.New("light client update incomplete")
+	}
+	tmp, err := strconv.ParseUint(update.SignatureSlot, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature slot in light client update")
+	}
+	signatureSlot := phase0.Slot(tmp)
+	attested := update.AttestedHeader.Beacon
+	if attested.Slot >= signatureSlot {
+		return fmt.Errorf("light client update for slot %d signed at earlier slot %d", attested.Slot, signatureSlot)
+	}
+
+	period := l.chainTime.SlotToSyncCommitteePeriod(signatureSlot)
+	committee, exists := l.committees[period]
+	if !exists {
+		return fmt.Errorf("no verified sync committee for period %d", period)
+	}
+	if err := l.verifySyncAggregate(committee, update.SyncAggregate, attested, signatureSlot); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("invalid light client update for slot %d", attested.Slot))
+	}
+
+	_, nextGindex, finalizedGindex := lightClientGindices(res.Version)
+
+	if update.NextSyncCommittee != nil && len(update.NextSyncCommitteeBranch) > 0 {
+		if err := verifySyncCommitteeBranch(update.NextSyncCommittee, update.NextSyncCommitteeBranch, nextGindex, attested.StateRoot); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("invalid next sync committee in light client update for slot %d", attested.Slot))
+		}
+		l.committees[l.chainTime.SlotToSyncCommitteePeriod(attested.Slot)+1] = update.NextSyncCommittee
+	} else if requireNextSyncCommittee {
+		return fmt.Errorf("light client update for slot %d does not contain the next sync committee", attested.Slot)
+	}
+
+	if update.FinalizedHeader != nil && update.FinalizedHeader.Beacon != nil && len(update.FinalityBranch) > 0 {
+		finalizedRoot, err := update.FinalizedHeader.Beacon.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "failed to calculate finalized header root")
+		}
+		if err := verifyBranch(finalizedRoot, update.FinalityBranch, finalizedGindex, attested.StateRoot); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("invalid finalized header in light client update for slot %d", attested.Slot))
+		}
+		if update.FinalizedHeader.Beacon.Slot > l.finalized.Slot {
+			l.finalized = update.FinalizedHeader.Beacon
+		}
+	}
+
+	return nil
+}
+
+// verifySyncAggregate verifies that a supermajority of the sync committee signed the header.
+func (l *lightClient) verifySyncAggregate(committee *altair.SyncCommittee,
+	aggregate *altair.SyncAggregate,
+	header *phase0.BeaconBlockHeader,
+	signatureSlot phase0.Slot,
+) error {
+	pubKeys := make([]e2types.PublicKey, 0, len(committee.Pubkeys))
+	for i := range committee.Pubkeys {
+		if !aggregate.SyncCommitteeBits.BitAt(uint64(i)) {
+			continue
+		}
+		pubKey, err := e2types.BLSPublicKeyFromBytes(committee.Pubkeys[i][:])
+		if err != nil {
+			return errors.Wrap(err, "invalid sync committee public key")
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	if len(pubKeys)*3 < len(committee.Pubkeys)*2 {
+		return fmt.Errorf("only %d of %d sync committee members signed", len(pubKeys), len(committee.Pubkeys))
+	}
+
+	// The signature is made with the fork version of the slot before the signature slot.
+	if signatureSlot > 0 {
+		signatureSlot--
+	}
+	fork := l.chainTime.ForkAtEpoch(l.chainTime.SlotToEpoch(signatureSlot))
+	if fork == nil {
+		return errors.New("failed to obtain fork for signature")
+	}
+	forkDataRoot, err := (&phase0.ForkData{
+		CurrentVersion:        fork.Version,
+		GenesisValidatorsRoot: l.genesisValidatorsRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate fork data root")
+	}
+	var domain phase0.Domain
+	copy(domain[:], syncCommitteeDomainType[:])
+	copy(domain[4:], forkDataRoot[:])
+
+	headerRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate header root")
+	}
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: headerRoot,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate signing root")
+	}
+
+	signatureBytes := aggregate.SyncCommitteeSignature
+	signature, err := e2types.BLSSignatureFromBytes(signatureBytes[:])
+	if err != nil {
+		return errors.Wrap(err, "invalid sync committee signature")
+	}
+	if !signature.VerifyAggregateCommon(signingRoot[:], pubKeys) {
+		return errors.New("sync committee signature does not verify; the genesis validators root or fork schedule supplied by the beacon node may be incorrect")
+	}
+	l.forkVersion = fork.Version
+
+	return nil
+}
+
+// lightClientGindices provides the generalized indices of the current sync
+// committee, next sync committee and finalized root for the given fork.
+func lightClientGindices(version string) (uint64, uint64, uint64) {
+	switch strings.ToLower(version) {
+	case "altair", "bellatrix", "capella", "deneb":
+		return currentSyncCommitteeGindex, nextSyncCommitteeGindex, finalizedRootGindex
+	default:
+		// Electra onwards.
+		return electraCurrentSyncCommitteeGindex, electraNextSyncCommitteeGindex, electraFinalizedRootGindex
+	}
+}
+
+// verifySyncCommitteeBranch verifies a Merkle branch for a sync committee.
+func verifySyncCommitteeBranch(committee *altair.SyncCommittee, branch []string, gindex uint64, root phase0.Root) error {
+	leaf, err := committee.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate sync committee root")
+	}
+
+	return verifyBranch(leaf, branch, gindex, root)
+}
+
+// verifyBranch verifies that the leaf is at the given generalized index of the tree with the given root.
+func verifyBranch(leaf phase0.Root, branch []string, gindex uint64, root phase0.Root) error {
+	depth := bits.Len64(gindex) - 1
+	if len(branch) != depth {
+		return fmt.Errorf("branch has %d items, expected %d", len(branch), depth)
+	}
+	index := gindex - (1 << depth)
+
+	value := leaf[:]
+	for i := range branch {
+		sibling, err := hex.DecodeString(strings.TrimPrefix(branch[i], "0x"))
+		if err != nil {
+			return errors.Wrap(err, "invalid branch item")
+		}
+		if len(sibling) != phase0.RootLength {
+			return errors.New("incorrect length for branch item")
+		}
+		hash := sha256.New()
+		if (index>>i)&1 == 1 {
+			hash.Write(sibling)
+			hash.Write(value)
+		} else {
+			hash.Write(value)
+			hash.Write(sibling)
+		}
+		value = hash.Sum(nil)
+	}
+	if !bytes.Equal(value, root[:]) {
+		return errors.New("branch does not match root")
+	}
+
+	return nil
+}
+
+// networkByGenesisValidatorsRoot returns the well-known network with the given
+// genesis validators root, or nil if there is no such network.
+func networkByGenesisValidatorsRoot(root phase0.Root) *Network {
+	for _, network := range networks {
+		if bytes.Equal(network.GenesisValidatorsRoot[:], root[:]) {
+			return network
+		}
+	}
+
+	return nil
+}
+
+// fetchLightClientUpdates fetches the light client updates for the given sync committee periods.
+func fetchLightClientUpdates(ctx context.Context, address string, timeout time.Duration, start uint64, count uint64) ([]*lightClientResponse, error) {
+	updates := make([]*lightClientResponse, 0)
+	found, err := util.FetchBeaconNodeJSON(ctx, address, timeout, fmt.Sprintf("/eth/v1/beacon/light_client/updates?start_period=%d&count=%d", start, count), nil, &updates)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.New("light client updates not available")
+	}
+
+	return updates, nil
+}
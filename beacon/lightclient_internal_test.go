@@ -0,0 +1,288 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// testChainTime provides the parts of the chaintime service used by the light client,
+// with one epoch of 32 slots per sync committee period.
+type testChainTime struct {
+	chaintime.Service
+	currentPeriod uint64
+	forkVersion   phase0.Version
+}
+
+func (c *testChainTime) SlotToEpoch(slot phase0.Slot) phase0.Epoch {
+	return phase0.Epoch(slot / 32)
+}
+
+func (c *testChainTime) SlotToSyncCommitteePeriod(slot phase0.Slot) uint64 {
+	return uint64(slot / 32)
+}
+
+func (c *testChainTime) CurrentSyncCommitteePeriod() uint64 {
+	return c.currentPeriod
+}
+
+func (c *testChainTime) ForkAtEpoch(_ phase0.Epoch) *chaintime.Fork {
+	return &chaintime.Fork{Name: "deneb", Version: c.forkVersion}
+}
+
+// testTree is a sparse Merkle tree with leaves at the given generalized indices.
+type testTree map[uint64]phase0.Root
+
+func (t testTree) node(gindex uint64) []byte {
+	if leaf, exists := t[gindex]; exists {
+		return leaf[:]
+	}
+	if gindex >= 1<<7 {
+		return make([]byte, 32)
+	}
+	hash := sha256.New()
+	hash.Write(t.node(2 * gindex))
+	hash.Write(t.node(2*gindex + 1))
+
+	return hash.Sum(nil)
+}
+
+func (t testTree) root() phase0.Root {
+	var root phase0.Root
+	copy(root[:], t.node(1))
+
+	return root
+}
+
+func (t testTree) branch(gindex uint64) []string {
+	branch := make([]string, 0)
+	for ; gindex > 1; gindex /= 2 {
+		branch = append(branch, fmt.Sprintf("%#x", t.node(gindex^1)))
+	}
+
+	return branch
+}
+
+func TestVerifyBranch(t *testing.T) {
+	leaf := phase0.Root{0x01}
+	tree := testTree{55: leaf, 105: phase0.Root{0x02}}
+	root := tree.root()
+
+	require.NoError(t, verifyBranch(leaf, tree.branch(55), 55, root))
+	require.EqualError(t, verifyBranch(leaf, tree.branch(55), 54, root), "branch does not match root")
+	require.EqualError(t, verifyBranch(phase0.Root{0x03}, tree.branch(55), 55, root), "branch does not match root")
+	require.EqualError(t, verifyBranch(leaf, tree.branch(55)[1:], 55, root), "branch has 4 items, expected 5")
+	badBranch := tree.branch(55)
+	badBranch[0] = "0xzz"
+	require.EqualError(t, verifyBranch(leaf, badBranch, 55, root), "invalid branch item: encoding/hex: invalid byte: U+007A 'z'")
+	badBranch[0] = "0x01"
+	require.EqualError(t, verifyBranch(leaf, badBranch, 55, root), "incorrect length for branch item")
+}
+
+func TestLightClientGindices(t *testing.T) {
+	current, next, finalized := lightClientGindices("capella")
+	require.Equal(t, []uint64{54, 55, 105}, []uint64{current, next, finalized})
+	current, next, finalized = lightClientGindices("electra")
+	require.Equal(t, []uint64{86, 87, 169}, []uint64{current, next, finalized})
+}
+
+func TestVerifyWithLightClient(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	mainnet := networks["mainnet"]
+	forkVersion := phase0.Version{0x04, 0x00, 0x00, 0x00}
+
+	// Create a sync committee, which is used for every period.
+	keys := make([]*e2types.BLSPrivateKey, 512)
+	committee := &altair.SyncCommittee{
+		Pubkeys: make([]phase0.BLSPubKey, 512),
+	}
+	for i := range keys {
+		var err error
+		keys[i], err = e2types.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+		copy(committee.Pubkeys[i][:], keys[i].PublicKey().Marshal())
+	}
+	committeeRoot, err := committee.HashTreeRoot()
+	require.NoError(t, err)
+
+	// syncAggregate signs the header with all members of the committee.
+	syncAggregate := func(header *phase0.BeaconBlockHeader) *altair.SyncAggregate {
+		forkDataRoot, err := (&phase0.ForkData{CurrentVersion: forkVersion, GenesisValidatorsRoot: mainnet.GenesisValidatorsRoot}).HashTreeRoot()
+		require.NoError(t, err)
+		var domain phase0.Domain
+		copy(domain[:], syncCommitteeDomainType[:])
+		copy(domain[4:], forkDataRoot[:])
+		headerRoot, err := header.HashTreeRoot()
+		require.NoError(t, err)
+		signingRoot, err := (&phase0.SigningData{ObjectRoot: headerRoot, Domain: domain}).HashTreeRoot()
+		require.NoError(t, err)
+		sigs := make([]e2types.Signature, len(keys))
+		bits := bitfield.NewBitvector512()
+		for i := range keys {
+			sigs[i] = keys[i].Sign(signingRoot[:])
+			bits.SetBitAt(uint64(i), true)
+		}
+		aggregate := &altair.SyncAggregate{SyncCommitteeBits: bits}
+		copy(aggregate.SyncCommitteeSignature[:], e2types.AggregateSignatures(sigs).Marshal())
+
+		return aggregate
+	}
+
+	// Bootstrap at slot 10, in period 0.
+	bootstrapTree := testTree{54: committeeRoot}
+	bootstrapHeader := &phase0.BeaconBlockHeader{Slot: 10, StateRoot: bootstrapTree.root()}
+	trustedRoot, err := bootstrapHeader.HashTreeRoot()
+	require.NoError(t, err)
+	bootstrap := &lightClientBootstrap{
+		Header:                     &lightClientHeader{Beacon: bootstrapHeader},
+		CurrentSyncCommittee:       committee,
+		CurrentSyncCommitteeBranch: bootstrapTree.branch(54),
+	}
+
+	// update creates an update attested in the given period.
+	update := func(period uint64, withNext bool) *lightClientUpdate {
+		finalizedHeader := &phase0.BeaconBlockHeader{Slot: phase0.Slot(32*period + 5)}
+		finalizedRoot, err := finalizedHeader.HashTreeRoot()
+		require.NoError(t, err)
+		tree := testTree{105: finalizedRoot}
+		if withNext {
+			tree[55] = committeeRoot
+		}
+		attestedHeader := &phase0.BeaconBlockHeader{Slot: phase0.Slot(32*period + 20), StateRoot: tree.root()}
+		res := &lightClientUpdate{
+			AttestedHeader:  &lightClientHeader{Beacon: attestedHeader},
+			FinalizedHeader: &lightClientHeader{Beacon: finalizedHeader},
+			FinalityBranch:  tree.branch(105),
+			SyncAggregate:   syncAggregate(attestedHeader),
+			SignatureSlot:   fmt.Sprintf("%d", 32*period+21),
+		}
+		if withNext {
+			res.NextSyncCommittee = committee
+			res.NextSyncCommitteeBranch = tree.branch(55)
+		}
+
+		return res
+	}
+	updates := []*lightClientUpdate{update(0, true), update(1, true)}
+	finalityUpdate := update(2, false)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var res interface{}
+		switch {
+		case r.URL.Path == fmt.Sprintf("/eth/v1/beacon/light_client/bootstrap/%#x", trustedRoot):
+			res = map[string]interface{}{"version": "deneb", "data": bootstrap}
+		case r.URL.Path == "/eth/v1/beacon/light_client/updates":
+			require.Equal(t, "start_period=0&count=2", r.URL.RawQuery)
+			wrapped := make([]interface{}, 0, len(updates))
+			for _, update := range updates {
+				wrapped = append(wrapped, map[string]interface{}{"version": "deneb", "data": update})
+			}
+			res = wrapped
+		case r.URL.Path == "/eth/v1/beacon/light_client/finality_update":
+			res = map[string]interface{}{"version": "deneb", "data": finalityUpdate}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	defer server.Close()
+
+	chainTime := &testChainTime{currentPeriod: 2, forkVersion: forkVersion}
+
+	tests := []struct {
+		name        string
+		chainInfo   *ChainInfo
+		trustedRoot phase0.Root
+		slot        phase0.Slot
+		err         string
+	}{
+		{
+			name: "Good",
+			chainInfo: &ChainInfo{
+				GenesisValidatorsRoot: mainnet.GenesisValidatorsRoot,
+				GenesisForkVersion:    mainnet.GenesisForkVersion(),
+				CurrentForkVersion:    forkVersion,
+				CapellaForkVersion:    mainnet.Fork("capella").Version,
+			},
+			trustedRoot: trustedRoot,
+			slot:        69,
+		},
+		{
+			name: "UnknownTrustedRoot",
+			chainInfo: &ChainInfo{
+				GenesisValidatorsRoot: mainnet.GenesisValidatorsRoot,
+			},
+			trustedRoot: phase0.Root{0x01},
+			err:         "light client bootstrap not available for trusted block root 0x0100000000000000000000000000000000000000000000000000000000000000; it must be the root of a recent finalized block",
+		},
+		{
+			name: "GenesisValidatorsRootIncorrect",
+			chainInfo: &ChainInfo{
+				GenesisValidatorsRoot: networks["holesky"].GenesisValidatorsRoot,
+			},
+			trustedRoot: trustedRoot,
+			err:         "invalid light client update for slot 20: sync committee signature does not verify; the genesis validators root or fork schedule supplied by the beacon node may be incorrect",
+		},
+		{
+			name: "CurrentForkVersionIncorrect",
+			chainInfo: &ChainInfo{
+				GenesisValidatorsRoot: mainnet.GenesisValidatorsRoot,
+				CurrentForkVersion:    phase0.Version{0x03, 0x00, 0x00, 0x00},
+			},
+			trustedRoot: trustedRoot,
+			err:         "current fork version 0x03000000 does not match verified fork version 0x04000000",
+		},
+		{
+			name: "CapellaForkVersionIncorrect",
+			chainInfo: &ChainInfo{
+				GenesisValidatorsRoot: mainnet.GenesisValidatorsRoot,
+				GenesisForkVersion:    mainnet.GenesisForkVersion(),
+				CurrentForkVersion:    forkVersion,
+				CapellaForkVersion:    phase0.Version{0x04, 0x00, 0x00, 0x00},
+			},
+			trustedRoot: trustedRoot,
+			err:         "capella fork version 0x04000000 does not match mainnet capella fork version 0x03000000",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			slot, err := test.chainInfo.VerifyWithLightClient(ctx, strings.TrimPrefix(server.URL, "http://"), time.Second, chainTime, test.trustedRoot)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.slot, slot)
+			}
+		})
+	}
+}
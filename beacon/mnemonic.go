@@ -0,0 +1,83 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"context"
+	"fmt"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+// ObtainSeedValidators obtains the public keys of the validators on chain whose
+// keys are derived from the given seed at the standard validator paths.  Paths
+// are derived in order until gapLimit consecutive paths have been found without
+// a validator, which matches the way in which a mnemonic is scanned when
+// generating operations.  The public keys are returned in the format 0x….
+func ObtainSeedValidators(ctx context.Context,
+	validatorsProvider consensusclient.ValidatorsProvider,
+	seed []byte,
+	gapLimit int,
+) (
+	[]string,
+	error,
+) {
+	found := make(map[int]string)
+	lastFoundIndex := 0
+	next := 0
+	for next <= lastFoundIndex+gapLimit {
+		end := lastFoundIndex + gapLimit
+		pubKeys := make([]phase0.BLSPubKey, 0, end-next+1)
+		pathIndices := make(map[phase0.BLSPubKey]int, end-next+1)
+		for i := next; i <= end; i++ {
+			privKey, err := ethutil.PrivateKeyFromSeedAndPath(seed, fmt.Sprintf("m/12381/3600/%d/0/0", i))
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to generate validator private key")
+			}
+			var pubKey phase0.BLSPubKey
+			copy(pubKey[:], privKey.PublicKey().Marshal())
+			pubKeys = append(pubKeys, pubKey)
+			pathIndices[pubKey] = i
+		}
+
+		validators, err := validatorsProvider.ValidatorsByPubKey(ctx, "head", pubKeys)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators by public key")
+		}
+		for _, validator := range validators {
+			pathIndex, exists := pathIndices[validator.Validator.PublicKey]
+			if !exists {
+				continue
+			}
+			found[pathIndex] = fmt.Sprintf("%#x", validator.Validator.PublicKey)
+			if pathIndex > lastFoundIndex {
+				lastFoundIndex = pathIndex
+			}
+		}
+		next = end + 1
+	}
+
+	// Return the public keys in path order.
+	res := make([]string, 0, len(found))
+	for i := 0; i < next; i++ {
+		if pubKey, exists := found[i]; exists {
+			res = append(res, pubKey)
+		}
+	}
+
+	return res, nil
+}
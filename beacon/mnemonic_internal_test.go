@@ -0,0 +1,104 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testing/mock"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+func TestObtainSeedValidators(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	seed := make([]byte, 64)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	pubKeyAtPath := func(index int) phase0.BLSPubKey {
+		privKey, err := ethutil.PrivateKeyFromSeedAndPath(seed, fmt.Sprintf("m/12381/3600/%d/0/0", index))
+		require.NoError(t, err)
+		var pubKey phase0.BLSPubKey
+		copy(pubKey[:], privKey.PublicKey().Marshal())
+		return pubKey
+	}
+
+	tests := []struct {
+		name        string
+		pathIndices []int
+		gapLimit    int
+		expected    []int
+	}{
+		{
+			name:     "None",
+			gapLimit: 4,
+			expected: []int{},
+		},
+		{
+			name:        "First",
+			pathIndices: []int{0},
+			gapLimit:    4,
+			expected:    []int{0},
+		},
+		{
+			name:        "WithinGap",
+			pathIndices: []int{2, 0, 6, 10},
+			gapLimit:    4,
+			expected:    []int{0, 2, 6, 10},
+		},
+		{
+			name:        "BeyondGap",
+			pathIndices: []int{1, 4, 9},
+			gapLimit:    4,
+			expected:    []int{1, 4},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validators := make([]*apiv1.Validator, 0, len(test.pathIndices)+1)
+			for i, pathIndex := range test.pathIndices {
+				validators = append(validators, &apiv1.Validator{
+					Index: phase0.ValidatorIndex(i),
+					Validator: &phase0.Validator{
+						PublicKey: pubKeyAtPath(pathIndex),
+					},
+				})
+			}
+			// Add a validator that is not derived from the seed.
+			validators = append(validators, &apiv1.Validator{
+				Index: phase0.ValidatorIndex(len(test.pathIndices)),
+				Validator: &phase0.Validator{
+					PublicKey: phase0.BLSPubKey{0x01},
+				},
+			})
+
+			res, err := ObtainSeedValidators(ctx, mock.NewValidatorsProvider(validators), seed, test.gapLimit)
+			require.NoError(t, err)
+			expected := make([]string, 0, len(test.expected))
+			for _, pathIndex := range test.expected {
+				expected = append(expected, fmt.Sprintf("%#x", pubKeyAtPath(pathIndex)))
+			}
+			require.Equal(t, expected, res)
+		})
+	}
+}
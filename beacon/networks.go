@@ -0,0 +1,160 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// NetworkFork is a fork in the schedule of a network.
+type NetworkFork struct {
+	Name    string
+	Version phase0.Version
+	Epoch   phase0.Epoch
+}
+
+// Network contains the parameters of a well-known network that are required
+// to sign operations without access to a beacon node.
+type Network struct {
+	Name                  string
+	GenesisValidatorsRoot phase0.Root
+	// Forks is the fork schedule of the network, in order, starting with genesis.
+	Forks []*NetworkFork
+}
+
+// networks are the bundled well-known networks.
+var networks = map[string]*Network{
+	"mainnet": {
+		Name:                  "mainnet",
+		GenesisValidatorsRoot: phase0.Root{0x4b, 0x36, 0x3d, 0xb9, 0x4e, 0x28, 0x61, 0x20, 0xd7, 0x6e, 0xb9, 0x05, 0x34, 0x0f, 0xdd, 0x4e, 0x54, 0xbf, 0xe9, 0xf0, 0x6b, 0xf3, 0x3f, 0xf6, 0xcf, 0x5a, 0xd2, 0x7f, 0x51, 0x1b, 0xfe, 0x95},
+		Forks: []*NetworkFork{
+			{Name: "genesis", Version: phase0.Version{0x00, 0x00, 0x00, 0x00}, Epoch: 0},
+			{Name: "altair", Version: phase0.Version{0x01, 0x00, 0x00, 0x00}, Epoch: 74240},
+			{Name: "bellatrix", Version: phase0.Version{0x02, 0x00, 0x00, 0x00}, Epoch: 144896},
+			{Name: "capella", Version: phase0.Version{0x03, 0x00, 0x00, 0x00}, Epoch: 194048},
+			{Name: "deneb", Version: phase0.Version{0x04, 0x00, 0x00, 0x00}, Epoch: 269568},
+			{Name: "electra", Version: phase0.Version{0x05, 0x00, 0x00, 0x00}, Epoch: 364032},
+		},
+	},
+	"holesky": {
+		Name:                  "holesky",
+		GenesisValidatorsRoot: phase0.Root{0x91, 0x43, 0xaa, 0x7c, 0x61, 0x5a, 0x7f, 0x71, 0x15, 0xe2, 0xb6, 0xaa, 0xc3, 0x19, 0xc0, 0x35, 0x29, 0xdf, 0x82, 0x42, 0xae, 0x70, 0x5f, 0xba, 0x9d, 0xf3, 0x9b, 0x79, 0xc5, 0x9f, 0xa8, 0xb1},
+		Forks: []*NetworkFork{
+			{Name: "genesis", Version: phase0.Version{0x01, 0x01, 0x70, 0x00}, Epoch: 0},
+			{Name: "altair", Version: phase0.Version{0x02, 0x01, 0x70, 0x00}, Epoch: 0},
+			{Name: "bellatrix", Version: phase0.Version{0x03, 0x01, 0x70, 0x00}, Epoch: 0},
+			{Name: "capella", Version: phase0.Version{0x04, 0x01, 0x70, 0x00}, Epoch: 256},
+			{Name: "deneb", Version: phase0.Version{0x05, 0x01, 0x70, 0x00}, Epoch: 29696},
+			{Name: "electra", Version: phase0.Version{0x06, 0x01, 0x70, 0x00}, Epoch: 115968},
+		},
+	},
+	"sepolia": {
+		Name:                  "sepolia",
+		GenesisValidatorsRoot: phase0.Root{0xd8, 0xea, 0x17, 0x1f, 0x3c, 0x94, 0xae, 0xa2, 0x1e, 0xbc, 0x42, 0xa1, 0xed, 0x61, 0x05, 0x2a, 0xcf, 0x3f, 0x92, 0x09, 0xc0, 0x0e, 0x4e, 0xfb, 0xaa, 0xdd, 0xac, 0x09, 0xed, 0x9b, 0x80, 0x78},
+		Forks: []*NetworkFork{
+			{Name: "genesis", Version: phase0.Version{0x90, 0x00, 0x00, 0x69}, Epoch: 0},
+			{Name: "altair", Version: phase0.Version{0x90, 0x00, 0x00, 0x70}, Epoch: 50},
+			{Name: "bellatrix", Version: phase0.Version{0x90, 0x00, 0x00, 0x71}, Epoch: 100},
+			{Name: "capella", Version: phase0.Version{0x90, 0x00, 0x00, 0x72}, Epoch: 56832},
+			{Name: "deneb", Version: phase0.Version{0x90, 0x00, 0x00, 0x73}, Epoch: 132608},
+			{Name: "electra", Version: phase0.Version{0x90, 0x00, 0x00, 0x74}, Epoch: 222464},
+		},
+	},
+	"gnosis": {
+		Name:                  "gnosis",
+		GenesisValidatorsRoot: phase0.Root{0xf5, 0xdc, 0xb5, 0x56, 0x4e, 0x82, 0x9a, 0xab, 0x27, 0x26, 0x4b, 0x9b, 0xec, 0xd5, 0xdf, 0xaa, 0x01, 0x70, 0x85, 0x61, 0x12, 0x24, 0xcb, 0x30, 0x36, 0xf5, 0x73, 0x36, 0x8d, 0xbb, 0x9d, 0x47},
+		Forks: []*NetworkFork{
+			{Name: "genesis", Version: phase0.Version{0x00, 0x00, 0x00, 0x64}, Epoch: 0},
+			{Name: "altair", Version: phase0.Version{0x01, 0x00, 0x00, 0x64}, Epoch: 512},
+			{Name: "bellatrix", Version: phase0.Version{0x02, 0x00, 0x00, 0x64}, Epoch: 385536},
+			{Name: "capella", Version: phase0.Version{0x03, 0x00, 0x00, 0x64}, Epoch: 648704},
+			{Name: "deneb", Version: phase0.Version{0x04, 0x00, 0x00, 0x64}, Epoch: 889856},
+			{Name: "electra", Version: phase0.Version{0x05, 0x00, 0x00, 0x64}, Epoch: 1337856},
+		},
+	},
+}
+
+// NetworkNames provides the names of the bundled networks.
+func NetworkNames() []string {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// NetworkByName provides the bundled network with the given name.
+func NetworkByName(name string) (*Network, error) {
+	network, exists := networks[strings.ToLower(name)]
+	if !exists {
+		return nil, fmt.Errorf("unknown network %s; supported networks are %s", name, strings.Join(NetworkNames(), ", "))
+	}
+
+	return network, nil
+}
+
+// GenesisForkVersion provides the genesis fork version of the network.
+func (n *Network) GenesisForkVersion() phase0.Version {
+	return n.Forks[0].Version
+}
+
+// Fork provides the named fork of the network, or nil if it is not scheduled.
+func (n *Network) Fork(name string) *NetworkFork {
+	for _, fork := range n.Forks {
+		if fork.Name == name {
+			return fork
+		}
+	}
+
+	return nil
+}
+
+// ForkAtEpoch provides the fork that is active at the given epoch.
+func (n *Network) ForkAtEpoch(epoch phase0.Epoch) *NetworkFork {
+	res := n.Forks[0]
+	for _, fork := range n.Forks[1:] {
+		if fork.Epoch > epoch {
+			break
+		}
+		res = fork
+	}
+
+	return res
+}
+
+// ApplyNetwork sets the genesis validators root and fork versions of the chain
+// info from the network.  If the chain info already has a genesis validators
+// root, for example because it was loaded from an offline preparation file,
+// it must match that of the network.
+func (c *ChainInfo) ApplyNetwork(network *Network) error {
+	if c.GenesisValidatorsRoot != (phase0.Root{}) && c.GenesisValidatorsRoot != network.GenesisValidatorsRoot {
+		return fmt.Errorf("chain information is not for the %s network", network.Name)
+	}
+
+	c.GenesisValidatorsRoot = network.GenesisValidatorsRoot
+	c.GenesisForkVersion = network.GenesisForkVersion()
+	c.CurrentForkVersion = network.ForkAtEpoch(c.Epoch).Version
+	if capella := network.Fork("capella"); capella != nil {
+		c.CapellaForkVersion = capella.Version
+		c.CapellaForkEpoch = capella.Epoch
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+)
+
+func TestNetworkByName(t *testing.T) {
+	_, err := beacon.NetworkByName("unknown")
+	require.EqualError(t, err, "unknown network unknown; supported networks are gnosis, holesky, mainnet, sepolia")
+
+	network, err := beacon.NetworkByName("Mainnet")
+	require.NoError(t, err)
+	require.Equal(t, "mainnet", network.Name)
+	require.Equal(t, phase0.Version{0x00, 0x00, 0x00, 0x00}, network.GenesisForkVersion())
+	require.Equal(t, phase0.Epoch(194048), network.Fork("capella").Epoch)
+	require.Nil(t, network.Fork("unknown"))
+}
+
+func TestNetworkForkAtEpoch(t *testing.T) {
+	network, err := beacon.NetworkByName("mainnet")
+	require.NoError(t, err)
+
+	require.Equal(t, "genesis", network.ForkAtEpoch(0).Name)
+	require.Equal(t, "genesis", network.ForkAtEpoch(74239).Name)
+	require.Equal(t, "altair", network.ForkAtEpoch(74240).Name)
+	require.Equal(t, "capella", network.ForkAtEpoch(200000).Name)
+	require.Equal(t, "deneb", network.ForkAtEpoch(300000).Name)
+	require.Equal(t, "electra", network.ForkAtEpoch(400000).Name)
+}
+
+func TestNetworkLiveFork(t *testing.T) {
+	// The final fork of each schedule must be the fork that is live on the network.
+	liveForks := map[string]string{
+		"gnosis":  "electra",
+		"holesky": "electra",
+		"mainnet": "electra",
+		"sepolia": "electra",
+	}
+	for _, name := range beacon.NetworkNames() {
+		t.Run(name, func(t *testing.T) {
+			liveFork, exists := liveForks[name]
+			require.True(t, exists, "no live fork for network")
+			network, err := beacon.NetworkByName(name)
+			require.NoError(t, err)
+			lastFork := network.Forks[len(network.Forks)-1]
+			require.Equal(t, liveFork, lastFork.Name)
+			require.Equal(t, lastFork, network.ForkAtEpoch(lastFork.Epoch+1000000))
+			for i := 1; i < len(network.Forks); i++ {
+				require.LessOrEqual(t, network.Forks[i-1].Epoch, network.Forks[i].Epoch)
+			}
+		})
+	}
+}
+
+func TestApplyNetwork(t *testing.T) {
+	network, err := beacon.NetworkByName("sepolia")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		chainInfo *beacon.ChainInfo
+		err       string
+	}{
+		{
+			name: "Empty",
+			chainInfo: &beacon.ChainInfo{
+				Epoch: 100,
+			},
+		},
+		{
+			name: "Matching",
+			chainInfo: &beacon.ChainInfo{
+				Epoch:                 100,
+				GenesisValidatorsRoot: network.GenesisValidatorsRoot,
+			},
+		},
+		{
+			name: "Mismatch",
+			chainInfo: &beacon.ChainInfo{
+				Epoch:                 100,
+				GenesisValidatorsRoot: phase0.Root{0x01},
+			},
+			err: "chain information is not for the sepolia network",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.chainInfo.ApplyNetwork(network)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, network.GenesisValidatorsRoot, test.chainInfo.GenesisValidatorsRoot)
+				require.Equal(t, phase0.Version{0x90, 0x00, 0x00, 0x69}, test.chainInfo.GenesisForkVersion)
+				require.Equal(t, phase0.Version{0x90, 0x00, 0x00, 0x71}, test.chainInfo.CurrentForkVersion)
+				require.Equal(t, phase0.Version{0x90, 0x00, 0x00, 0x72}, test.chainInfo.CapellaForkVersion)
+				require.Equal(t, phase0.Epoch(56832), test.chainInfo.CapellaForkEpoch)
+			}
+		})
+	}
+}
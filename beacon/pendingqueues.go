@@ -0,0 +1,202 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// The pending queues introduced with Electra are not available through the
+// client, so are obtained directly from the beacon node's API.
+
+type pendingDepositsJSON struct {
+	Data []*pendingDepositJSON `json:"data"`
+}
+
+type pendingDepositJSON struct {
+	Amount string `json:"amount"`
+	Slot   string `json:"slot"`
+}
+
+type pendingPartialWithdrawalsJSON struct {
+	Data []*pendingPartialWithdrawalJSON `json:"data"`
+}
+
+type pendingPartialWithdrawalJSON struct {
+	ValidatorIndex    string `json:"validator_index"`
+	Amount            string `json:"amount"`
+	WithdrawableEpoch string `json:"withdrawable_epoch"`
+}
+
+type pendingConsolidationsJSON struct {
+	Data []*pendingConsolidationJSON `json:"data"`
+}
+
+type pendingConsolidationJSON struct {
+	SourceIndex string `json:"source_index"`
+	TargetIndex string `json:"target_index"`
+}
+
+// PendingDeposit is a deposit waiting to be applied to a validator.
+type PendingDeposit struct {
+	Amount phase0.Gwei
+	Slot   phase0.Slot
+}
+
+// PendingPartialWithdrawal is a partial withdrawal waiting to be processed.
+type PendingPartialWithdrawal struct {
+	ValidatorIndex    phase0.ValidatorIndex
+	Amount            phase0.Gwei
+	WithdrawableEpoch phase0.Epoch
+}
+
+// PendingConsolidation is a consolidation waiting for its source validator
+// to become withdrawable.
+type PendingConsolidation struct {
+	SourceIndex phase0.ValidatorIndex
+	TargetIndex phase0.ValidatorIndex
+}
+
+// ObtainPendingDeposits obtains the deposits pending in the given state.
+func ObtainPendingDeposits(ctx context.Context,
+	address string,
+	timeout time.Duration,
+	stateID string,
+) (
+	[]*PendingDeposit,
+	error,
+) {
+	data := &pendingDepositsJSON{}
+	if err := obtainPendingQueue(ctx, address, timeout, stateID, "pending_deposits", "pending deposits", data); err != nil {
+		return nil, err
+	}
+
+	res := make([]*PendingDeposit, 0, len(data.Data))
+	for _, pendingDeposit := range data.Data {
+		amount, err := strconv.ParseUint(pendingDeposit.Amount, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending deposit amount")
+		}
+		slot, err := strconv.ParseUint(pendingDeposit.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending deposit slot")
+		}
+		res = append(res, &PendingDeposit{
+			Amount: phase0.Gwei(amount),
+			Slot:   phase0.Slot(slot),
+		})
+	}
+
+	return res, nil
+}
+
+// ObtainPendingPartialWithdrawals obtains the partial withdrawals pending in
+// the given state.
+func ObtainPendingPartialWithdrawals(ctx context.Context,
+	address string,
+	timeout time.Duration,
+	stateID string,
+) (
+	[]*PendingPartialWithdrawal,
+	error,
+) {
+	data := &pendingPartialWithdrawalsJSON{}
+	if err := obtainPendingQueue(ctx, address, timeout, stateID, "pending_partial_withdrawals", "pending partial withdrawals", data); err != nil {
+		return nil, err
+	}
+
+	res := make([]*PendingPartialWithdrawal, 0, len(data.Data))
+	for _, pendingPartialWithdrawal := range data.Data {
+		index, err := strconv.ParseUint(pendingPartialWithdrawal.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending partial withdrawal validator index")
+		}
+		amount, err := strconv.ParseUint(pendingPartialWithdrawal.Amount, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending partial withdrawal amount")
+		}
+		withdrawableEpoch, err := strconv.ParseUint(pendingPartialWithdrawal.WithdrawableEpoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending partial withdrawal withdrawable epoch")
+		}
+		res = append(res, &PendingPartialWithdrawal{
+			ValidatorIndex:    phase0.ValidatorIndex(index),
+			Amount:            phase0.Gwei(amount),
+			WithdrawableEpoch: phase0.Epoch(withdrawableEpoch),
+		})
+	}
+
+	return res, nil
+}
+
+// ObtainPendingConsolidations obtains the consolidations pending in the given
+// state.
+func ObtainPendingConsolidations(ctx context.Context,
+	address string,
+	timeout time.Duration,
+	stateID string,
+) (
+	[]*PendingConsolidation,
+	error,
+) {
+	data := &pendingConsolidationsJSON{}
+	if err := obtainPendingQueue(ctx, address, timeout, stateID, "pending_consolidations", "pending consolidations", data); err != nil {
+		return nil, err
+	}
+
+	res := make([]*PendingConsolidation, 0, len(data.Data))
+	for _, pendingConsolidation := range data.Data {
+		sourceIndex, err := strconv.ParseUint(pendingConsolidation.SourceIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending consolidation source index")
+		}
+		targetIndex, err := strconv.ParseUint(pendingConsolidation.TargetIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending consolidation target index")
+		}
+		res = append(res, &PendingConsolidation{
+			SourceIndex: phase0.ValidatorIndex(sourceIndex),
+			TargetIndex: phase0.ValidatorIndex(targetIndex),
+		})
+	}
+
+	return res, nil
+}
+
+// obtainPendingQueue obtains the named pending queue of the given state.
+func obtainPendingQueue(ctx context.Context,
+	address string,
+	timeout time.Duration,
+	stateID string,
+	endpoint string,
+	name string,
+	res interface{},
+) error {
+	found, err := util.FetchBeaconNodeJSON(ctx, address, timeout, fmt.Sprintf("/eth/v1/beacon/states/%s/%s", stateID, endpoint), nil, res)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain %s", name))
+	}
+	if !found {
+		return fmt.Errorf("node does not provide %s", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObtainPendingDeposits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/states/100/pending_deposits":
+			_, _ = w.Write([]byte(`{"data":[{"pubkey":"0x00","amount":"32000000000","slot":"90"},{"pubkey":"0x01","amount":"1000000000","slot":"95"}]}`))
+		case "/eth/v1/beacon/states/101/pending_deposits":
+			_, _ = w.Write([]byte(`{"data":[{"amount":"invalid","slot":"90"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		stateID  string
+		deposits []*PendingDeposit
+		err      string
+	}{
+		{
+			name:    "NotProvided",
+			stateID: "head",
+			err:     "node does not provide pending deposits",
+		},
+		{
+			name:    "InvalidAmount",
+			stateID: "101",
+			err:     "invalid pending deposit amount: strconv.ParseUint: parsing \"invalid\": invalid syntax",
+		},
+		{
+			name:    "Good",
+			stateID: "100",
+			deposits: []*PendingDeposit{
+				{Amount: 32000000000, Slot: 90},
+				{Amount: 1000000000, Slot: 95},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deposits, err := ObtainPendingDeposits(context.Background(), server.URL, time.Second, test.stateID)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.deposits, deposits)
+		})
+	}
+}
+
+func TestObtainPendingPartialWithdrawals(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/states/100/pending_partial_withdrawals":
+			_, _ = w.Write([]byte(`{"data":[{"validator_index":"5","amount":"2000000000","withdrawable_epoch":"300"}]}`))
+		case "/eth/v1/beacon/states/101/pending_partial_withdrawals":
+			_, _ = w.Write([]byte(`{"data":[{"validator_index":"5","amount":"2000000000","withdrawable_epoch":"invalid"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		stateID     string
+		withdrawals []*PendingPartialWithdrawal
+		err         string
+	}{
+		{
+			name:    "NotProvided",
+			stateID: "head",
+			err:     "node does not provide pending partial withdrawals",
+		},
+		{
+			name:    "InvalidWithdrawableEpoch",
+			stateID: "101",
+			err:     "invalid pending partial withdrawal withdrawable epoch: strconv.ParseUint: parsing \"invalid\": invalid syntax",
+		},
+		{
+			name:    "Good",
+			stateID: "100",
+			withdrawals: []*PendingPartialWithdrawal{
+				{ValidatorIndex: 5, Amount: 2000000000, WithdrawableEpoch: 300},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			withdrawals, err := ObtainPendingPartialWithdrawals(context.Background(), server.URL, time.Second, test.stateID)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.withdrawals, withdrawals)
+		})
+	}
+}
+
+func TestObtainPendingConsolidations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/states/100/pending_consolidations":
+			_, _ = w.Write([]byte(`{"data":[{"source_index":"7","target_index":"8"}]}`))
+		case "/eth/v1/beacon/states/101/pending_consolidations":
+			_, _ = w.Write([]byte(`{"data":[{"source_index":"-1","target_index":"8"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		stateID        string
+		consolidations []*PendingConsolidation
+		err            string
+	}{
+		{
+			name:    "NotProvided",
+			stateID: "head",
+			err:     "node does not provide pending consolidations",
+		},
+		{
+			name:    "InvalidSourceIndex",
+			stateID: "101",
+			err:     "invalid pending consolidation source index: strconv.ParseUint: parsing \"-1\": invalid syntax",
+		},
+		{
+			name:    "Good",
+			stateID: "100",
+			consolidations: []*PendingConsolidation{
+				{SourceIndex: 7, TargetIndex: 8},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			consolidations, err := ObtainPendingConsolidations(context.Background(), server.URL, time.Second, test.stateID)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.consolidations, consolidations)
+		})
+	}
+}
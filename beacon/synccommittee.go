@@ -0,0 +1,123 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// Weights from the Altair specification.
+const (
+	syncRewardWeight  = 2
+	weightDenominator = 64
+)
+
+// SyncAggregate obtains the sync aggregate from a block.
+func SyncAggregate(block *spec.VersionedSignedBeaconBlock) (*altair.SyncAggregate, error) {
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		return nil, errors.New("phase0 blocks do not have sync aggregates")
+	case spec.DataVersionAltair:
+		if block.Altair == nil || block.Altair.Message == nil || block.Altair.Message.Body == nil {
+			return nil, errors.New("no altair block")
+		}
+		return block.Altair.Message.Body.SyncAggregate, nil
+	case spec.DataVersionBellatrix:
+		if block.Bellatrix == nil || block.Bellatrix.Message == nil || block.Bellatrix.Message.Body == nil {
+			return nil, errors.New("no bellatrix block")
+		}
+		return block.Bellatrix.Message.Body.SyncAggregate, nil
+	case spec.DataVersionCapella:
+		if block.Capella == nil || block.Capella.Message == nil || block.Capella.Message.Body == nil {
+			return nil, errors.New("no capella block")
+		}
+		return block.Capella.Message.Body.SyncAggregate, nil
+	default:
+		return nil, fmt.Errorf("unhandled block version %v", block.Version)
+	}
+}
+
+// ObtainSyncCommitteeParticipantReward obtains the reward for a single sync
+// committee participant at a single slot.  The total active balance is taken
+// from the head state, so the reward is an estimate for historical periods.
+func ObtainSyncCommitteeParticipantReward(ctx context.Context,
+	consensusClient consensusclient.Service,
+	slotsPerEpoch uint64,
+) (
+	phase0.Gwei,
+	error,
+) {
+	specData, err := consensusClient.(consensusclient.SpecProvider).Spec(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain spec")
+	}
+	specValues := make(map[string]uint64)
+	for _, key := range []string{"EFFECTIVE_BALANCE_INCREMENT", "BASE_REWARD_FACTOR", "SYNC_COMMITTEE_SIZE"} {
+		tmp, exists := specData[key]
+		if !exists {
+			return 0, fmt.Errorf("spec missing %s", key)
+		}
+		val, isType := tmp.(uint64)
+		if !isType {
+			return 0, fmt.Errorf("%s of incorrect type", key)
+		}
+		specValues[key] = val
+	}
+
+	validators, err := consensusClient.(consensusclient.ValidatorsProvider).Validators(ctx, "head", nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain validators")
+	}
+	totalActiveBalance := uint64(0)
+	for _, validator := range validators {
+		if validator.Status.IsActive() {
+			totalActiveBalance += uint64(validator.Validator.EffectiveBalance)
+		}
+	}
+
+	return syncCommitteeParticipantReward(totalActiveBalance,
+		specValues["EFFECTIVE_BALANCE_INCREMENT"],
+		specValues["BASE_REWARD_FACTOR"],
+		slotsPerEpoch,
+		specValues["SYNC_COMMITTEE_SIZE"],
+	), nil
+}
+
+// syncCommitteeParticipantReward calculates the per-slot reward for a sync
+// committee participant, as per the Altair specification.
+func syncCommitteeParticipantReward(totalActiveBalance uint64,
+	effectiveBalanceIncrement uint64,
+	baseRewardFactor uint64,
+	slotsPerEpoch uint64,
+	syncCommitteeSize uint64,
+) phase0.Gwei {
+	if totalActiveBalance == 0 || effectiveBalanceIncrement == 0 || slotsPerEpoch == 0 || syncCommitteeSize == 0 {
+		return 0
+	}
+	sqrtBalance := new(big.Int).Sqrt(new(big.Int).SetUint64(totalActiveBalance)).Uint64()
+	baseRewardPerIncrement := effectiveBalanceIncrement * baseRewardFactor / sqrtBalance
+	totalActiveIncrements := totalActiveBalance / effectiveBalanceIncrement
+	totalBaseRewards := baseRewardPerIncrement * totalActiveIncrements
+	maxParticipantRewards := totalBaseRewards * syncRewardWeight / weightDenominator / slotsPerEpoch
+
+	return phase0.Gwei(maxParticipantRewards / syncCommitteeSize)
+}
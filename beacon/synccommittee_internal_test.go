@@ -0,0 +1,130 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncAggregate(t *testing.T) {
+	aggregate := &altair.SyncAggregate{
+		SyncCommitteeSignature: phase0.BLSSignature{0x01},
+	}
+
+	tests := []struct {
+		name  string
+		block *spec.VersionedSignedBeaconBlock
+		err   string
+	}{
+		{
+			name: "Phase0",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionPhase0,
+			},
+			err: "phase0 blocks do not have sync aggregates",
+		},
+		{
+			name: "AltairMissing",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionAltair,
+				Altair:  &altair.SignedBeaconBlock{},
+			},
+			err: "no altair block",
+		},
+		{
+			name: "UnknownVersion",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: 99,
+			},
+			err: "unhandled block version unknown",
+		},
+		{
+			name: "Altair",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionAltair,
+				Altair: &altair.SignedBeaconBlock{
+					Message: &altair.BeaconBlock{Body: &altair.BeaconBlockBody{SyncAggregate: aggregate}},
+				},
+			},
+		},
+		{
+			name: "Bellatrix",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionBellatrix,
+				Bellatrix: &bellatrix.SignedBeaconBlock{
+					Message: &bellatrix.BeaconBlock{Body: &bellatrix.BeaconBlockBody{SyncAggregate: aggregate}},
+				},
+			},
+		},
+		{
+			name: "Capella",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionCapella,
+				Capella: &capella.SignedBeaconBlock{
+					Message: &capella.BeaconBlock{Body: &capella.BeaconBlockBody{SyncAggregate: aggregate}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := SyncAggregate(test.block)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, aggregate, res)
+			}
+		})
+	}
+}
+
+func TestSyncCommitteeParticipantReward(t *testing.T) {
+	tests := []struct {
+		name               string
+		totalActiveBalance uint64
+		res                phase0.Gwei
+	}{
+		{
+			name:               "Zero",
+			totalActiveBalance: 0,
+			res:                0,
+		},
+		{
+			name:               "Small",
+			totalActiveBalance: 100000 * 32000000000,
+			res:                6903,
+		},
+		{
+			name:               "Large",
+			totalActiveBalance: 500000 * 32000000000,
+			res:                15411,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := syncCommitteeParticipantReward(test.totalActiveBalance, 1000000000, 64, 32, 512)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// defaultValidatorFetchConcurrency is the default number of concurrent
+// requests used when obtaining all validators.
+const defaultValidatorFetchConcurrency = 4
+
+// validatorFetchConcurrency is the number of concurrent requests used when
+// obtaining all validators.  A value of 1 obtains all validators in a single
+// request.
+var validatorFetchConcurrency = defaultValidatorFetchConcurrency
+
+// validatorShardSize is the number of validators obtained by each request when
+// obtaining all validators concurrently.  This matches the number of indices
+// that the client sends to the beacon node in a single request.
+var validatorShardSize = 1000
+
+// SetValidatorFetchConcurrency sets the number of concurrent requests used
+// when obtaining all validators.
+func SetValidatorFetchConcurrency(concurrency int) error {
+	if concurrency < 1 {
+		return errors.New("validator fetch concurrency must be at least 1")
+	}
+	validatorFetchConcurrency = concurrency
+
+	return nil
+}
+
+// obtainAllValidators obtains all validators.  Validators are requested in
+// shards of consecutive indices across concurrent requests, as a single
+// request for all validators can be very slow on large chains.  All shards are
+// requested from the same state, pinned by the state root of the head block
+// when the fetch starts, so that the results are consistent.  The number of
+// validators in the state is given by the first shard that is not full, and
+// the results are checked to contain exactly the validators up to that number.
+func obtainAllValidators(ctx context.Context,
+	validatorsProvider consensusclient.ValidatorsProvider,
+	concurrency int,
+	shardSize int,
+) (
+	map[phase0.ValidatorIndex]*apiv1.Validator,
+	error,
+) {
+	// Obtaining all validators can take a while, so show that it is happening.
+	progress := util.NewProgress("Obtaining validators", 0)
+	defer progress.Finish()
+
+	if concurrency <= 1 {
+		// A single request is for a single state, so does not need pinning.
+		validators, err := validatorsProvider.Validators(ctx, "head", nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators")
+		}
+		return validators, nil
+	}
+
+	stateID, err := headStateID(ctx, validatorsProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	res := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+	// nextShard is the next shard to be requested.
+	nextShard := 0
+	// lastShard is the first shard found not to be full; no shards beyond it
+	// are requested.
+	lastShard := -1
+	// lastShardValidators is the number of validators in the last shard.
+	lastShardValidators := 0
+	var firstErr error
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				if firstErr != nil || (lastShard != -1 && nextShard > lastShard) {
+					mu.Unlock()
+					return
+				}
+				shard := nextShard
+				nextShard++
+				mu.Unlock()
+
+				start := shard * shardSize
+				indices := make([]phase0.ValidatorIndex, shardSize)
+				for j := range indices {
+					indices[j] = phase0.ValidatorIndex(start + j)
+				}
+				validators, err := validatorsProvider.Validators(ctx, stateID, indices)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = errors.Wrap(err, fmt.Sprintf("failed to obtain validators %d-%d", start, start+shardSize-1))
+						cancel()
+					}
+					mu.Unlock()
+					return
+				}
+				for index, validator := range validators {
+					res[index] = validator
+				}
+				if len(validators) < shardSize && (lastShard == -1 || shard < lastShard) {
+					lastShard = shard
+					lastShardValidators = len(validators)
+				}
+				mu.Unlock()
+				progress.Add(len(validators))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	// Validators are never removed from the state, so the state holds
+	// validators with indices from 0 up to its number of validators.
+	validatorCount := lastShard*shardSize + lastShardValidators
+	if len(res) != validatorCount {
+		return nil, fmt.Errorf("obtained %d validators but state %s has %d", len(res), stateID, validatorCount)
+	}
+	for i := 0; i < validatorCount; i++ {
+		if _, exists := res[phase0.ValidatorIndex(i)]; !exists {
+			return nil, fmt.Errorf("validator %d missing from state %s", i, stateID)
+		}
+	}
+
+	return res, nil
+}
+
+// headStateID returns an identifier for the state of the current head block,
+// which continues to refer to the same state as the chain progresses.
+func headStateID(ctx context.Context, provider interface{}) (string, error) {
+	headersProvider, isProvider := provider.(consensusclient.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return "", errors.New("connection does not provide beacon block headers")
+	}
+	header, err := headersProvider.BeaconBlockHeader(ctx, "head")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to obtain head block header")
+	}
+	if header == nil || header.Header == nil || header.Header.Message == nil {
+		return "", errors.New("head block header not returned")
+	}
+
+	return fmt.Sprintf("%#x", header.Header.Message.StateRoot), nil
+}
@@ -0,0 +1,241 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testing/mock"
+)
+
+// erroringValidatorsProvider fails requests that include a given index.
+type erroringValidatorsProvider struct {
+	eth2client.ValidatorsProvider
+	failIndex phase0.ValidatorIndex
+}
+
+func (p *erroringValidatorsProvider) Validators(ctx context.Context, stateID string, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	for _, index := range validatorIndices {
+		if index == p.failIndex {
+			return nil, errors.New("mock error")
+		}
+	}
+	return p.ValidatorsProvider.Validators(ctx, stateID, validatorIndices)
+}
+
+// headValidatorsProvider provides the header of the head block, and records
+// the states from which validators are requested.
+type headValidatorsProvider struct {
+	eth2client.ValidatorsProvider
+	stateRoot phase0.Root
+	mu        sync.Mutex
+	stateIDs  map[string]bool
+}
+
+func newHeadValidatorsProvider(provider eth2client.ValidatorsProvider) *headValidatorsProvider {
+	return &headValidatorsProvider{
+		ValidatorsProvider: provider,
+		stateRoot:          phase0.Root{0x01},
+		stateIDs:           make(map[string]bool),
+	}
+}
+
+func (p *headValidatorsProvider) BeaconBlockHeader(_ context.Context, _ string) (*apiv1.BeaconBlockHeader, error) {
+	return &apiv1.BeaconBlockHeader{
+		Header: &phase0.SignedBeaconBlockHeader{
+			Message: &phase0.BeaconBlockHeader{
+				StateRoot: p.stateRoot,
+			},
+		},
+	}, nil
+}
+
+func (p *headValidatorsProvider) Validators(ctx context.Context, stateID string, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	p.mu.Lock()
+	p.stateIDs[stateID] = true
+	p.mu.Unlock()
+	return p.ValidatorsProvider.Validators(ctx, stateID, validatorIndices)
+}
+
+// strayValidatorsProvider returns an additional validator, outside of the
+// requested indices, for requests that include a given index.
+type strayValidatorsProvider struct {
+	eth2client.ValidatorsProvider
+	strayIndex phase0.ValidatorIndex
+}
+
+func (p *strayValidatorsProvider) Validators(ctx context.Context, stateID string, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	res, err := p.ValidatorsProvider.Validators(ctx, stateID, validatorIndices)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range validatorIndices {
+		if index == p.strayIndex {
+			res[1000] = &apiv1.Validator{Index: 1000, Validator: &phase0.Validator{}}
+		}
+	}
+
+	return res, nil
+}
+
+// countingValidatorsProvider counts the number of requests for validators.
+type countingValidatorsProvider struct {
+	eth2client.ValidatorsProvider
+	mu       sync.Mutex
+	requests int
+}
+
+func (p *countingValidatorsProvider) Validators(ctx context.Context, stateID string, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	p.mu.Lock()
+	p.requests++
+	p.mu.Unlock()
+	return p.ValidatorsProvider.Validators(ctx, stateID, validatorIndices)
+}
+
+func TestSetValidatorFetchConcurrency(t *testing.T) {
+	defer func() {
+		validatorFetchConcurrency = defaultValidatorFetchConcurrency
+	}()
+
+	require.EqualError(t, SetValidatorFetchConcurrency(0), "validator fetch concurrency must be at least 1")
+	require.NoError(t, SetValidatorFetchConcurrency(16))
+	require.Equal(t, 16, validatorFetchConcurrency)
+}
+
+func TestObtainAllValidators(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		validators  int
+		concurrency int
+		shardSize   int
+		failIndex   phase0.ValidatorIndex
+		strayIndex  phase0.ValidatorIndex
+		fullFetches int
+		requests    int
+		err         string
+	}{
+		{
+			name:        "Empty",
+			validators:  0,
+			concurrency: 1,
+			shardSize:   10,
+			fullFetches: 1,
+			requests:    1,
+		},
+		{
+			name:        "Single",
+			validators:  95,
+			concurrency: 1,
+			shardSize:   10,
+			fullFetches: 1,
+			requests:    1,
+		},
+		{
+			name:        "ShardedEmpty",
+			validators:  0,
+			concurrency: 4,
+			shardSize:   10,
+		},
+		{
+			name:        "Sharded",
+			validators:  95,
+			concurrency: 4,
+			shardSize:   10,
+		},
+		{
+			name:        "ShardedExact",
+			validators:  100,
+			concurrency: 4,
+			shardSize:   10,
+		},
+		{
+			name:        "ShardedMoreWorkersThanShards",
+			validators:  25,
+			concurrency: 16,
+			shardSize:   10,
+		},
+		{
+			name:        "ShardedInconsistent",
+			validators:  25,
+			concurrency: 4,
+			shardSize:   10,
+			strayIndex:  20,
+			err:         "validator 25 missing from state 0x0100000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:        "ShardedError",
+			validators:  95,
+			concurrency: 4,
+			shardSize:   10,
+			failIndex:   55,
+			err:         "failed to obtain validators 50-59: mock error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validators := make([]*apiv1.Validator, 0, test.validators)
+			for i := 0; i < test.validators; i++ {
+				validators = append(validators, &apiv1.Validator{
+					Index:     phase0.ValidatorIndex(i),
+					Status:    apiv1.ValidatorStateActiveOngoing,
+					Validator: &phase0.Validator{},
+				})
+			}
+			mockProvider := mock.NewValidatorsProvider(validators)
+			counter := &countingValidatorsProvider{ValidatorsProvider: mockProvider}
+			var provider eth2client.ValidatorsProvider = counter
+			if test.failIndex != 0 {
+				provider = &erroringValidatorsProvider{ValidatorsProvider: counter, failIndex: test.failIndex}
+			}
+			if test.strayIndex != 0 {
+				provider = &strayValidatorsProvider{ValidatorsProvider: counter, strayIndex: test.strayIndex}
+			}
+			headProvider := newHeadValidatorsProvider(provider)
+
+			res, err := obtainAllValidators(ctx, headProvider, test.concurrency, test.shardSize)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res, test.validators)
+			for i := 0; i < test.validators; i++ {
+				require.Contains(t, res, phase0.ValidatorIndex(i))
+			}
+			require.Equal(t, test.fullFetches, mockProvider.(*mock.ValidatorsProvider).FullFetches)
+			if test.requests != 0 {
+				require.Equal(t, test.requests, counter.requests)
+				require.Equal(t, map[string]bool{"head": true}, headProvider.stateIDs)
+			} else {
+				// All shards must be requested from the pinned state.
+				require.Equal(t, map[string]bool{"0x0100000000000000000000000000000000000000000000000000000000000000": true}, headProvider.stateIDs)
+				// All shards up to the first that is not full must be requested,
+				// and each worker requests at most one shard beyond it.
+				shards := test.validators/test.shardSize + 1
+				require.GreaterOrEqual(t, counter.requests, shards)
+				require.LessOrEqual(t, counter.requests, shards+test.concurrency-1)
+			}
+		})
+	}
+}
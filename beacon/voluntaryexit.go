@@ -0,0 +1,209 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// ExitCheck is the result of a single check of a signed voluntary exit.
+type ExitCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// exitJSON is the strict JSON structure of a signed voluntary exit.
+type exitJSON struct {
+	Message *struct {
+		Epoch          string `json:"epoch"`
+		ValidatorIndex string `json:"validator_index"`
+	} `json:"message"`
+	Signature string `json:"signature"`
+}
+
+// exitForkCandidate is a fork version with which an exit could have been signed.
+type exitForkCandidate struct {
+	name    string
+	version phase0.Version
+}
+
+// ParseSignedVoluntaryExit parses a signed voluntary exit, rejecting any data
+// beyond that of the exit itself.
+func ParseSignedVoluntaryExit(data []byte) (*phase0.SignedVoluntaryExit, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var strict exitJSON
+	if err := decoder.Decode(&strict); err != nil {
+		return nil, errors.Wrap(err, "invalid exit")
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("additional data after exit")
+	}
+	if strict.Message == nil {
+		return nil, errors.New("message missing")
+	}
+
+	exit := &phase0.SignedVoluntaryExit{}
+	if err := json.Unmarshal(data, exit); err != nil {
+		return nil, errors.Wrap(err, "invalid exit")
+	}
+
+	return exit, nil
+}
+
+// CheckVoluntaryExitSignature checks that the exit is signed by the given
+// public key for the network with the given genesis validators root, and that
+// it is signed with the fork version that the chain requires.  If the
+// signature does not verify with the required fork version the other fork
+// versions known to the chain are tried, to show with which the exit was
+// signed.
+func (c *ChainInfo) CheckVoluntaryExitSignature(exit *phase0.SignedVoluntaryExit,
+	pubKey phase0.BLSPubKey,
+	genesisValidatorsRoot phase0.Root,
+) []*ExitCheck {
+	name := "Exit signature is valid"
+	pubKeyBytes := make([]byte, len(pubKey))
+	copy(pubKeyBytes, pubKey[:])
+	key, err := e2types.BLSPublicKeyFromBytes(pubKeyBytes)
+	if err != nil {
+		return []*ExitCheck{{Name: name, Detail: fmt.Sprintf("invalid public key: %v", err)}}
+	}
+	sigBytes := make([]byte, len(exit.Signature))
+	copy(sigBytes, exit.Signature[:])
+	sig, err := e2types.BLSSignatureFromBytes(sigBytes)
+	if err != nil {
+		return []*ExitCheck{{Name: name, Detail: fmt.Sprintf("invalid signature: %v", err)}}
+	}
+
+	expectedVersion, expectedFork, err := c.VoluntaryExitForkVersion()
+	if err != nil {
+		return []*ExitCheck{{Name: name, Detail: err.Error()}}
+	}
+	var signedWith *exitForkCandidate
+	for _, candidate := range c.exitForkCandidates(expectedVersion, expectedFork) {
+		signingRoot, err := c.VoluntaryExitSigningRoot(exit.Message, candidate.version, genesisValidatorsRoot)
+		if err != nil {
+			return []*ExitCheck{{Name: name, Detail: err.Error()}}
+		}
+		if sig.Verify(signingRoot[:], key) {
+			signedWith = candidate
+			break
+		}
+	}
+	if signedWith == nil {
+		detail := "signature does not verify with any known fork version"
+		return []*ExitCheck{
+			{Name: name, Detail: detail},
+			{Name: "Exit signed with expected domain", Detail: detail},
+		}
+	}
+	res := []*ExitCheck{
+		{Name: name, Passed: true, Detail: fmt.Sprintf("signed with %s fork version %#x", signedWith.name, signedWith.version)},
+	}
+
+	name = "Exit signed with expected domain"
+	if signedWith.version != expectedVersion {
+		return append(res, &ExitCheck{
+			Name:   name,
+			Detail: fmt.Sprintf("signed with %s fork version %#x, expected %s fork version %#x", signedWith.name, signedWith.version, expectedFork, expectedVersion),
+		})
+	}
+
+	return append(res, &ExitCheck{Name: name, Passed: true, Detail: fmt.Sprintf("%s fork version %#x", expectedFork, expectedVersion)})
+}
+
+// CheckVoluntaryExitEpoch checks that the exit can be used now, rather than at
+// some point in the future.
+func (c *ChainInfo) CheckVoluntaryExitEpoch(exit *phase0.SignedVoluntaryExit) *ExitCheck {
+	name := "Exit epoch is not in the future"
+	if exit.Message.Epoch > c.Epoch {
+		return &ExitCheck{Name: name, Detail: fmt.Sprintf("exit is not valid until epoch %d; current epoch is %d", exit.Message.Epoch, c.Epoch)}
+	}
+
+	return &ExitCheck{Name: name, Passed: true, Detail: fmt.Sprintf("epoch %d", exit.Message.Epoch)}
+}
+
+// exitForkCandidates provides the fork versions with which an exit could have
+// been signed, starting with the expected version.
+func (c *ChainInfo) exitForkCandidates(expectedVersion phase0.Version, expectedFork string) []*exitForkCandidate {
+	candidates := []*exitForkCandidate{
+		{name: expectedFork, version: expectedVersion},
+		{name: "genesis", version: c.GenesisForkVersion},
+		{name: "current", version: c.CurrentForkVersion},
+		{name: "capella", version: c.CapellaForkVersion},
+	}
+
+	res := make([]*exitForkCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.version == (phase0.Version{}) && candidate.name == "capella" {
+			// Capella fork version not known.
+			continue
+		}
+		duplicate := false
+		for _, existing := range res {
+			if existing.version == candidate.version {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			res = append(res, candidate)
+		}
+	}
+
+	return res
+}
+
+// VoluntaryExitSigningRoot calculates the signing root of the exit for the
+// given fork version and genesis validators root.
+func (c *ChainInfo) VoluntaryExitSigningRoot(exit *phase0.VoluntaryExit,
+	forkVersion phase0.Version,
+	genesisValidatorsRoot phase0.Root,
+) (
+	phase0.Root,
+	error,
+) {
+	forkDataRoot, err := (&phase0.ForkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to calculate signature domain")
+	}
+	var domain phase0.Domain
+	copy(domain[:], c.VoluntaryExitDomainType[:])
+	copy(domain[4:], forkDataRoot[:])
+
+	root, err := exit.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to generate message root")
+	}
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to generate signing root")
+	}
+
+	return signingRoot, nil
+}
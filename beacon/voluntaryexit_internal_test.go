@@ -0,0 +1,85 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSignedVoluntaryExit(t *testing.T) {
+	signature := "0xa5b7c9d1e3f50214a5b7c9d1e3f50214a5b7c9d1e3f50214a5b7c9d1e3f50214a5b7c9d1e3f50214a5b7c9d1e3f50214a5b7c9d1e3f50214a5b7c9d1e3f50214a5b7c9d1e3f50214a5b7c9d1e3f50214a5b7c9d1e3f50214a5b7c9d1e3f50214"
+
+	tests := []struct {
+		name  string
+		input string
+		exit  *phase0.SignedVoluntaryExit
+		err   string
+	}{
+		{
+			name:  "Empty",
+			input: ``,
+			err:   "invalid exit: EOF",
+		},
+		{
+			name:  "MessageMissing",
+			input: `{"signature":"` + signature + `"}`,
+			err:   "message missing",
+		},
+		{
+			name:  "ExtraTopLevelField",
+			input: `{"message":{"epoch":"1","validator_index":"2"},"signature":"` + signature + `","extra":"data"}`,
+			err:   `invalid exit: json: unknown field "extra"`,
+		},
+		{
+			name:  "ExtraMessageField",
+			input: `{"message":{"epoch":"1","validator_index":"2","extra":"data"},"signature":"` + signature + `"}`,
+			err:   `invalid exit: json: unknown field "extra"`,
+		},
+		{
+			name:  "TrailingData",
+			input: `{"message":{"epoch":"1","validator_index":"2"},"signature":"` + signature + `"}{}`,
+			err:   "additional data after exit",
+		},
+		{
+			name:  "SignatureInvalid",
+			input: `{"message":{"epoch":"1","validator_index":"2"},"signature":"0x01"}`,
+			err:   "invalid exit: incorrect length for signature",
+		},
+		{
+			name:  "Good",
+			input: `{"message":{"epoch":"1","validator_index":"2"},"signature":"` + signature + `"}`,
+			exit: &phase0.SignedVoluntaryExit{
+				Message: &phase0.VoluntaryExit{
+					Epoch:          1,
+					ValidatorIndex: 2,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exit, err := ParseSignedVoluntaryExit([]byte(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.exit.Message, exit.Message)
+			}
+		})
+	}
+}
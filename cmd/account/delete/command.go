@@ -0,0 +1,86 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdelete
+
+import (
+	"context"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	accounts           string
+	confirmPubKeysFile string
+	retention          time.Duration
+
+	// Processing.
+	consensusClient consensusclient.Service
+	wallet          e2wtypes.Wallet
+	matched         []e2wtypes.Account
+
+	// Output.
+	deleted []string
+	purged  int
+	expiry  time.Time
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:              viper.GetBool("quiet"),
+		verbose:            viper.GetBool("verbose"),
+		debug:              viper.GetBool("debug"),
+		accounts:           viper.GetString("accounts"),
+		confirmPubKeysFile: viper.GetString("confirm-pubkeys-file"),
+		retention:          viper.GetDuration("retention"),
+	}
+
+	if viper.GetString("remote") != "" {
+		return nil, errors.New("account delete not available for remote wallets")
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if c.accounts == "" {
+		return nil, errors.New("accounts is required")
+	}
+	if c.confirmPubKeysFile == "" {
+		return nil, errors.New("confirm-pubkeys-file is required")
+	}
+	if c.retention <= 0 {
+		return nil, errors.New("retention must be greater than 0")
+	}
+
+	return c, nil
+}
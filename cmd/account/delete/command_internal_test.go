@@ -0,0 +1,103 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdelete
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "Remote",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"remote":               "remoteaddress",
+				"accounts":             "Test wallet/*",
+				"confirm-pubkeys-file": "pubkeys.txt",
+				"retention":            "168h",
+			},
+			err: "account delete not available for remote wallets",
+		},
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"accounts":             "Test wallet/*",
+				"confirm-pubkeys-file": "pubkeys.txt",
+				"retention":            "168h",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "AccountsMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"confirm-pubkeys-file": "pubkeys.txt",
+				"retention":            "168h",
+			},
+			err: "accounts is required",
+		},
+		{
+			name: "ConfirmPubKeysFileMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"accounts":  "Test wallet/*",
+				"retention": "168h",
+			},
+			err: "confirm-pubkeys-file is required",
+		},
+		{
+			name: "RetentionMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"accounts":             "Test wallet/*",
+				"confirm-pubkeys-file": "pubkeys.txt",
+			},
+			err: "retention must be greater than 0",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"accounts":             "Test wallet/*",
+				"confirm-pubkeys-file": "pubkeys.txt",
+				"retention":            "168h",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdelete
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verbose {
+		for _, name := range c.deleted {
+			builder.WriteString(fmt.Sprintf("Moved %s to recycle bin\n", name))
+		}
+		if c.purged > 0 {
+			builder.WriteString(fmt.Sprintf("Permanently removed %d expired accounts from recycle bin\n", c.purged))
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf("Deleted %d accounts; recoverable from the recycle bin until %s", len(c.deleted), c.expiry.Format(time.RFC3339)))
+
+	return builder.String(), nil
+}
@@ -0,0 +1,402 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdelete
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	pubKeysData, err := os.ReadFile(c.confirmPubKeysFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read confirm pubkeys file")
+	}
+	confirmedPubKeys, err := parsePubKeys(pubKeysData)
+	if err != nil {
+		return err
+	}
+
+	if err := c.matchAccounts(ctx); err != nil {
+		return err
+	}
+
+	accountPubKeys, err := c.checkConfirmed(confirmedPubKeys)
+	if err != nil {
+		return err
+	}
+
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.checkChain(ctx, accountPubKeys); err != nil {
+		return err
+	}
+
+	store, location, err := c.walletStore()
+	if err != nil {
+		return err
+	}
+	bin, err := openRecycleBin(recycleBinLocation(location))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	c.purged, err = bin.purge(now)
+	if err != nil {
+		return err
+	}
+
+	c.expiry = now.Add(c.retention)
+	for _, account := range c.matched {
+		if err := recycleAccount(store, bin, c.wallet.ID(), account.ID(), c.expiry); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to delete account %s", account.Name()))
+		}
+		c.deleted = append(c.deleted, fmt.Sprintf("%s/%s", c.wallet.Name(), account.Name()))
+	}
+
+	if len(c.deleted) > 0 {
+		if err := util.RebuildAccountsIndex(store, c.wallet.ID()); err != nil {
+			return errors.Wrap(err, "failed to rebuild wallet index")
+		}
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	return nil
+}
+
+// matchAccounts obtains the accounts in the wallet whose names match the supplied pattern.
+func (c *command) matchAccounts(ctx context.Context) error {
+	walletName, pattern, err := e2wallet.WalletAndAccountNames(c.accounts)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse accounts")
+	}
+	if pattern == "" {
+		return errors.New("accounts must be of the form wallet/pattern")
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return errors.Wrap(err, "invalid accounts pattern")
+	}
+
+	c.wallet, err = util.WalletFromPath(ctx, walletName)
+	if err != nil {
+		return errors.Wrap(err, "failed to access wallet")
+	}
+
+	c.matched = make([]e2wtypes.Account, 0)
+	for account := range c.wallet.Accounts(ctx) {
+		// Pattern was checked above, so no error can be returned here.
+		if matched, _ := path.Match(pattern, account.Name()); matched {
+			c.matched = append(c.matched, account)
+		}
+	}
+	if len(c.matched) == 0 {
+		return errors.New("no accounts match the supplied pattern")
+	}
+	sort.Slice(c.matched, func(i, j int) bool {
+		return c.matched[i].Name() < c.matched[j].Name()
+	})
+
+	return nil
+}
+
+// checkConfirmed ensures that every matched account has its public key in the confirmed list,
+// returning the public keys of the matched accounts.
+func (c *command) checkConfirmed(confirmed map[phase0.BLSPubKey]bool) (map[phase0.BLSPubKey]string, error) {
+	pubKeys := make(map[phase0.BLSPubKey]string, len(c.matched))
+	unconfirmed := make([]string, 0)
+	for _, account := range c.matched {
+		accountPubKey, err := util.BestPublicKey(account)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain public key for account %s", account.Name()))
+		}
+		pubKey := phase0.BLSPubKey{}
+		copy(pubKey[:], accountPubKey.Marshal())
+		if !confirmed[pubKey] {
+			unconfirmed = append(unconfirmed, account.Name())
+		}
+		pubKeys[pubKey] = account.Name()
+	}
+	if len(unconfirmed) > 0 {
+		return nil, fmt.Errorf("accounts not present in confirm pubkeys file: %s", strings.Join(unconfirmed, ", "))
+	}
+
+	return pubKeys, nil
+}
+
+// checkChain ensures that every account is either unknown to the chain or
+// belongs to a validator that has exited and been fully withdrawn.
+func (c *command) checkChain(ctx context.Context, pubKeys map[phase0.BLSPubKey]string) error {
+	validatorsProvider, isProvider := c.consensusClient.(consensusclient.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide validator information")
+	}
+
+	keys := make([]phase0.BLSPubKey, 0, len(pubKeys))
+	for pubKey := range pubKeys {
+		keys = append(keys, pubKey)
+	}
+	validators, err := validatorsProvider.ValidatorsByPubKey(ctx, "head", keys)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+
+	held := make([]string, 0)
+	for _, validator := range validators {
+		if validator.Validator == nil {
+			continue
+		}
+		if validator.Status != apiv1.ValidatorStateWithdrawalDone {
+			held = append(held, fmt.Sprintf("%s (validator %d is %s)", pubKeys[validator.Validator.PublicKey], validator.Index, validator.Status))
+		}
+	}
+	if len(held) > 0 {
+		sort.Strings(held)
+		return fmt.Errorf("accounts have validators that are not exited and withdrawn: %s", strings.Join(held, ", "))
+	}
+
+	return nil
+}
+
+// walletStore obtains the wallet's store and its location.  Only filesystem
+// stores are supported, as the recycle bin is held alongside the store.
+func (c *command) walletStore() (e2wtypes.Store, string, error) {
+	store, err := util.WalletStore(c.wallet)
+	if err != nil {
+		return nil, "", err
+	}
+	if store.Name() != "filesystem" {
+		return nil, "", fmt.Errorf("cannot delete accounts from %s store automatically, please remove manually", store.Name())
+	}
+	if err := util.CheckAccountRemovable(store); err != nil {
+		return nil, "", err
+	}
+
+	return store, store.(e2wtypes.StoreLocationProvider).Location(), nil
+}
+
+// parsePubKeys parses a list of public keys, one per line.
+// Blank lines and lines starting with '#' are ignored.
+func parsePubKeys(data []byte) (map[phase0.BLSPubKey]bool, error) {
+	pubKeys := make(map[phase0.BLSPubKey]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(text, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid public key on line %d", line))
+		}
+		if len(pubKeyBytes) != phase0.PublicKeyLength {
+			return nil, fmt.Errorf("public key on line %d has incorrect length", line)
+		}
+		pubKey := phase0.BLSPubKey{}
+		copy(pubKey[:], pubKeyBytes)
+		pubKeys[pubKey] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read public keys")
+	}
+	if len(pubKeys) == 0 {
+		return nil, errors.New("no public keys in confirm pubkeys file")
+	}
+
+	return pubKeys, nil
+}
+
+// recycleBinLocation returns the location of the recycle bin for a store.
+// This sits alongside the store, rather than within it, so that it is not
+// mistaken for a wallet.
+func recycleBinLocation(storeLocation string) string {
+	return fmt.Sprintf("%s-recycle", filepath.Clean(storeLocation))
+}
+
+// recycleBin holds deleted accounts until their retention period has passed.
+// The accounts are held in a filesystem store with the same passphrase as the
+// wallet store, from which they can be restored, and the time at which each
+// expires is recorded alongside.
+type recycleBin struct {
+	store        e2wtypes.Store
+	expiriesFile string
+	expiries     map[string]time.Time
+}
+
+// openRecycleBin opens the recycle bin at the given location.
+func openRecycleBin(location string) (*recycleBin, error) {
+	opts := []filesystem.Option{
+		filesystem.WithLocation(location),
+	}
+	if util.GetStorePassphrase("filesystem") != "" {
+		opts = append(opts, filesystem.WithPassphrase([]byte(util.GetStorePassphrase("filesystem"))))
+	}
+	bin := &recycleBin{
+		store:        filesystem.New(opts...),
+		expiriesFile: filepath.Join(location, "expiries.json"),
+		expiries:     make(map[string]time.Time),
+	}
+
+	data, err := os.ReadFile(bin.expiriesFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return bin, nil
+		}
+		return nil, errors.Wrap(err, "failed to read recycle bin")
+	}
+	if err := json.Unmarshal(data, &bin.expiries); err != nil {
+		return nil, errors.Wrap(err, "failed to parse recycle bin")
+	}
+
+	return bin, nil
+}
+
+// add adds an account to the recycle bin, along with its wallet if the
+// recycle bin does not already hold it.
+func (b *recycleBin) add(walletID uuid.UUID, walletData []byte, accountID uuid.UUID, data []byte, expiry time.Time) error {
+	if _, err := b.store.RetrieveWalletByID(walletID); err != nil {
+		wallet := &struct {
+			Name string `json:"name"`
+		}{}
+		if err := json.Unmarshal(walletData, wallet); err != nil {
+			return errors.Wrap(err, "failed to parse wallet")
+		}
+		if err := b.store.StoreWallet(walletID, wallet.Name, walletData); err != nil {
+			return errors.Wrap(err, "failed to store wallet in recycle bin")
+		}
+	}
+	if err := b.store.StoreAccount(walletID, accountID, data); err != nil {
+		return errors.Wrap(err, "failed to store account in recycle bin")
+	}
+	b.expiries[fmt.Sprintf("%s/%s", walletID, accountID)] = expiry.UTC()
+
+	return b.save()
+}
+
+// purge permanently removes accounts in the recycle bin that have expired,
+// returning the number of accounts removed.
+func (b *recycleBin) purge(now time.Time) (int, error) {
+	purged := 0
+	for key, expiry := range b.expiries {
+		if now.Before(expiry) {
+			continue
+		}
+		walletID, accountID, err := parseRecycleKey(key)
+		if err != nil {
+			return purged, err
+		}
+		if err := util.RemoveStoredAccount(b.store, walletID, accountID); err != nil {
+			return purged, errors.Wrap(err, "failed to purge recycle bin")
+		}
+		delete(b.expiries, key)
+		purged++
+	}
+	if purged == 0 {
+		return 0, nil
+	}
+
+	return purged, b.save()
+}
+
+// save saves the expiries of the accounts in the recycle bin.
+func (b *recycleBin) save() error {
+	data, err := json.Marshal(b.expiries)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode recycle bin")
+	}
+	if err := os.MkdirAll(filepath.Dir(b.expiriesFile), 0o700); err != nil {
+		return errors.Wrap(err, "failed to create recycle bin")
+	}
+	if err := util.WriteFileAtomically(b.expiriesFile, data); err != nil {
+		return errors.Wrap(err, "failed to write recycle bin")
+	}
+
+	return nil
+}
+
+// parseRecycleKey parses the wallet and account IDs of an account in the recycle bin.
+func parseRecycleKey(key string) (uuid.UUID, uuid.UUID, error) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 2 {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid recycle bin entry %q", key)
+	}
+	walletID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.Wrap(err, fmt.Sprintf("invalid recycle bin entry %q", key))
+	}
+	accountID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.Wrap(err, fmt.Sprintf("invalid recycle bin entry %q", key))
+	}
+
+	return walletID, accountID, nil
+}
+
+// recycleAccount moves an account from the store to the recycle bin.  The
+// account is only removed from the store once it is held in the recycle bin.
+func recycleAccount(store e2wtypes.Store,
+	bin *recycleBin,
+	walletID uuid.UUID,
+	accountID uuid.UUID,
+	expiry time.Time,
+) error {
+	walletData, err := store.RetrieveWalletByID(walletID)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve wallet")
+	}
+	data, err := store.RetrieveAccount(walletID, accountID)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve account")
+	}
+	if err := bin.add(walletID, walletData, accountID, data, expiry); err != nil {
+		return err
+	}
+
+	return util.RemoveStoredAccount(store, walletID, accountID)
+}
@@ -0,0 +1,147 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdelete
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+)
+
+func TestParsePubKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		count int
+		err   string
+	}{
+		{
+			name:  "Empty",
+			input: "",
+			err:   "no public keys in confirm pubkeys file",
+		},
+		{
+			name:  "CommentsOnly",
+			input: "# Exited validators\n\n",
+			err:   "no public keys in confirm pubkeys file",
+		},
+		{
+			name:  "InvalidHex",
+			input: "0xzz\n",
+			err:   "invalid public key on line 1: encoding/hex: invalid byte: U+007A 'z'",
+		},
+		{
+			name:  "ShortKey",
+			input: "# Exited validators\n0x0102\n",
+			err:   "public key on line 2 has incorrect length",
+		},
+		{
+			name:  "Good",
+			input: "# Exited validators\n0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c\n\n  b89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b  \n",
+			count: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parsePubKeys([]byte(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, test.count)
+			}
+		})
+	}
+}
+
+func TestRecycle(t *testing.T) {
+	base := t.TempDir()
+	storeLocation := filepath.Join(base, "wallets")
+	recycleLocation := recycleBinLocation(storeLocation)
+	require.Equal(t, filepath.Join(base, "wallets-recycle"), recycleLocation)
+
+	store := filesystem.New(filesystem.WithLocation(storeLocation))
+	walletID := uuid.New()
+	require.NoError(t, store.StoreWallet(walletID, "Test wallet", []byte(fmt.Sprintf(`{"uuid":"%s","name":"Test wallet"}`, walletID))))
+	accountIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	for i, accountID := range accountIDs {
+		require.NoError(t, store.StoreAccount(walletID, accountID, []byte(fmt.Sprintf(`{"uuid":"%s","name":"Account %d"}`, accountID, i))))
+	}
+
+	// Nothing to purge if the recycle bin does not exist.
+	bin, err := openRecycleBin(recycleLocation)
+	require.NoError(t, err)
+	purged, err := bin.purge(time.Now())
+	require.NoError(t, err)
+	require.Equal(t, 0, purged)
+
+	now := time.Unix(1700000000, 0)
+	require.NoError(t, recycleAccount(store, bin, walletID, accountIDs[0], now.Add(time.Hour)))
+	require.NoError(t, recycleAccount(store, bin, walletID, accountIDs[1], now.Add(2*time.Hour)))
+	_, err = store.RetrieveAccount(walletID, accountIDs[0])
+	require.Error(t, err)
+	data, err := bin.store.RetrieveAccount(walletID, accountIDs[0])
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"name":"Account 0"`)
+	// The wallet is held alongside the account, so that it can be restored.
+	data, err = bin.store.RetrieveWalletByID(walletID)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"name":"Test wallet"`)
+	_, err = store.RetrieveAccount(walletID, accountIDs[2])
+	require.NoError(t, err)
+
+	// Expiries should persist.
+	bin, err = openRecycleBin(recycleLocation)
+	require.NoError(t, err)
+	require.Len(t, bin.expiries, 2)
+
+	purged, err = bin.purge(now)
+	require.NoError(t, err)
+	require.Equal(t, 0, purged)
+
+	purged, err = bin.purge(now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+	_, err = bin.store.RetrieveAccount(walletID, accountIDs[0])
+	require.Error(t, err)
+	_, err = bin.store.RetrieveAccount(walletID, accountIDs[1])
+	require.NoError(t, err)
+
+	bin, err = openRecycleBin(recycleLocation)
+	require.NoError(t, err)
+	purged, err = bin.purge(now.Add(3 * time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+	require.Empty(t, bin.expiries)
+}
+
+func TestRecycleBinInvalid(t *testing.T) {
+	location := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(location, "expiries.json"), []byte("{"), 0o600))
+	_, err := openRecycleBin(location)
+	require.EqualError(t, err, "failed to parse recycle bin: unexpected end of JSON input")
+
+	require.NoError(t, os.WriteFile(filepath.Join(location, "expiries.json"), []byte(`{"bad":"2023-01-01T00:00:00Z"}`), 0o600))
+	bin, err := openRecycleBin(location)
+	require.NoError(t, err)
+	_, err = bin.purge(time.Now())
+	require.EqualError(t, err, `invalid recycle bin entry "bad"`)
+}
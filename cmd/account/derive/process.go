@@ -17,6 +17,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/auditlog"
 	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
@@ -36,6 +37,11 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain account private key")
 	}
+	if data.showPrivateKey {
+		if err := auditlog.Record(auditlog.ActionExport, account, nil); err != nil {
+			return nil, err
+		}
+	}
 
 	results := &dataOut{
 		showPrivateKey:            data.showPrivateKey,
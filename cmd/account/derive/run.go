@@ -19,6 +19,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethdo/util"
 )
 
 // Run runs the account create data command.
@@ -26,7 +27,7 @@ func Run(cmd *cobra.Command) (string, error) {
 	ctx := context.Background()
 	dataIn, err := input(ctx)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to obtain input")
+		return "", util.NewValidationError(errors.Wrap(err, "failed to obtain input"))
 	}
 
 	// Further errors do not need a usage report.
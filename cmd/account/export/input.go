@@ -0,0 +1,106 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountexport
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type dataIn struct {
+	timeout          time.Duration
+	account          e2wtypes.Account
+	passphrases      []string
+	mnemonic         string
+	file             string
+	exportPassphrase string
+	shards           uint32
+	threshold        uint32
+}
+
+func input(ctx context.Context) (*dataIn, error) {
+	var err error
+	data := &dataIn{}
+
+	if viper.GetString("remote") != "" {
+		return nil, errors.New("account export not available for remote wallets")
+	}
+
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	data.timeout = viper.GetDuration("timeout")
+
+	// Shards.
+	data.shards = viper.GetUint32("shards")
+	data.threshold = viper.GetUint32("threshold")
+	if data.shards > 0 {
+		// Quiet is not allowed, as the shares would be lost.
+		if viper.GetBool("quiet") {
+			return nil, errors.New("quiet not allowed when exporting shards")
+		}
+		if data.threshold == 0 {
+			return nil, errors.New("threshold is required")
+		}
+		if data.threshold > data.shards {
+			return nil, errors.New("threshold cannot be more than shards")
+		}
+	} else if data.threshold > 0 {
+		return nil, errors.New("threshold requires shards")
+	}
+
+	// Account or mnemonic.
+	if viper.GetString("account") == "" && viper.GetString("mnemonic") == "" {
+		return nil, errors.New("account or mnemonic is required")
+	}
+	if viper.GetString("account") != "" && viper.GetString("mnemonic") != "" {
+		return nil, errors.New("only one of account and mnemonic is required")
+	}
+	if viper.GetString("account") != "" {
+		ctx, cancel := context.WithTimeout(ctx, data.timeout)
+		defer cancel()
+		_, data.account, err = util.WalletAndAccountFromInput(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain account")
+		}
+		data.passphrases = util.GetPassphrases()
+	} else {
+		if data.shards == 0 {
+			return nil, errors.New("mnemonic can only be exported with shards")
+		}
+		data.mnemonic = viper.GetString("mnemonic")
+	}
+
+	// Export passphrase.
+	data.exportPassphrase = viper.GetString("export-passphrase")
+	if data.shards == 0 && data.exportPassphrase == "" {
+		return nil, errors.New("export passphrase is required")
+	}
+	if data.shards > 0 && data.exportPassphrase != "" {
+		return nil, errors.New("export passphrase not used when exporting shards")
+	}
+
+	// File.
+	data.file = viper.GetString("file")
+	if data.file == "" {
+		return nil, errors.New("file is required")
+	}
+
+	return data, nil
+}
@@ -0,0 +1,268 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountexport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestInput(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	store := scratch.New()
+	require.NoError(t, e2wallet.UseStore(store))
+	testWallet, err := nd.CreateWallet(context.Background(), "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, testWallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	_, err = testWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Interop 0",
+		hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		res  *dataIn
+		err  string
+	}{
+		{
+			name: "Remote",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"remote":  "remoteaddress",
+			},
+			err: "account export not available for remote wallets",
+		},
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"account":           "Test wallet/Interop 0",
+				"passphrase":        "pass",
+				"export-passphrase": "ce%NohGhah4ye5ra",
+				"file":              "keystore.json",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ShardsQuiet",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"account":    "Test wallet/Interop 0",
+				"passphrase": "pass",
+				"file":       "backup.json",
+				"shards":     "5",
+				"threshold":  "3",
+				"quiet":      true,
+			},
+			err: "quiet not allowed when exporting shards",
+		},
+		{
+			name: "ThresholdMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"account":    "Test wallet/Interop 0",
+				"passphrase": "pass",
+				"file":       "backup.json",
+				"shards":     "5",
+			},
+			err: "threshold is required",
+		},
+		{
+			name: "ThresholdTooHigh",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"account":    "Test wallet/Interop 0",
+				"passphrase": "pass",
+				"file":       "backup.json",
+				"shards":     "5",
+				"threshold":  "6",
+			},
+			err: "threshold cannot be more than shards",
+		},
+		{
+			name: "ThresholdWithoutShards",
+			vars: map[string]interface{}{
+				"timeout":           "5s",
+				"account":           "Test wallet/Interop 0",
+				"passphrase":        "pass",
+				"export-passphrase": "ce%NohGhah4ye5ra",
+				"file":              "keystore.json",
+				"threshold":         "3",
+			},
+			err: "threshold requires shards",
+		},
+		{
+			name: "AccountAndMnemonicMissing",
+			vars: map[string]interface{}{
+				"timeout":           "5s",
+				"export-passphrase": "ce%NohGhah4ye5ra",
+				"file":              "keystore.json",
+			},
+			err: "account or mnemonic is required",
+		},
+		{
+			name: "AccountAndMnemonic",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"account":    "Test wallet/Interop 0",
+				"mnemonic":   "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"passphrase": "pass",
+				"file":       "backup.json",
+				"shards":     "5",
+				"threshold":  "3",
+			},
+			err: "only one of account and mnemonic is required",
+		},
+		{
+			name: "WalletUnknown",
+			vars: map[string]interface{}{
+				"timeout":           "5s",
+				"account":           "Unknown/Interop 0",
+				"passphrase":        "pass",
+				"export-passphrase": "ce%NohGhah4ye5ra",
+				"file":              "keystore.json",
+			},
+			err: "failed to obtain account: failed to open wallet for account: wallet not found",
+		},
+		{
+			name: "MnemonicWithoutShards",
+			vars: map[string]interface{}{
+				"timeout":           "5s",
+				"mnemonic":          "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"export-passphrase": "ce%NohGhah4ye5ra",
+				"file":              "keystore.json",
+			},
+			err: "mnemonic can only be exported with shards",
+		},
+		{
+			name: "ExportPassphraseMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"account":    "Test wallet/Interop 0",
+				"passphrase": "pass",
+				"file":       "keystore.json",
+			},
+			err: "export passphrase is required",
+		},
+		{
+			name: "ExportPassphraseWithShards",
+			vars: map[string]interface{}{
+				"timeout":           "5s",
+				"account":           "Test wallet/Interop 0",
+				"passphrase":        "pass",
+				"export-passphrase": "ce%NohGhah4ye5ra",
+				"file":              "backup.json",
+				"shards":            "5",
+				"threshold":         "3",
+			},
+			err: "export passphrase not used when exporting shards",
+		},
+		{
+			name: "FileMissing",
+			vars: map[string]interface{}{
+				"timeout":           "5s",
+				"account":           "Test wallet/Interop 0",
+				"passphrase":        "pass",
+				"export-passphrase": "ce%NohGhah4ye5ra",
+			},
+			err: "file is required",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":           "5s",
+				"account":           "Test wallet/Interop 0",
+				"passphrase":        "pass",
+				"export-passphrase": "ce%NohGhah4ye5ra",
+				"file":              "keystore.json",
+			},
+			res: &dataIn{
+				timeout:          5 * time.Second,
+				passphrases:      []string{"pass"},
+				exportPassphrase: "ce%NohGhah4ye5ra",
+				file:             "keystore.json",
+			},
+		},
+		{
+			name: "GoodShards",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"account":    "Test wallet/Interop 0",
+				"passphrase": "pass",
+				"file":       "backup.json",
+				"shards":     "5",
+				"threshold":  "3",
+			},
+			res: &dataIn{
+				timeout:     5 * time.Second,
+				passphrases: []string{"pass"},
+				file:        "backup.json",
+				shards:      5,
+				threshold:   3,
+			},
+		},
+		{
+			name: "GoodMnemonic",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"mnemonic":  "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"file":      "backup.json",
+				"shards":    "5",
+				"threshold": "3",
+			},
+			res: &dataIn{
+				timeout:   5 * time.Second,
+				mnemonic:  "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				file:      "backup.json",
+				shards:    5,
+				threshold: 3,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := input(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res.timeout, res.timeout)
+				require.Equal(t, test.res.passphrases, res.passphrases)
+				require.Equal(t, test.res.mnemonic, res.mnemonic)
+				require.Equal(t, test.res.exportPassphrase, res.exportPassphrase)
+				require.Equal(t, test.res.file, res.file)
+				require.Equal(t, test.res.shards, res.shards)
+				require.Equal(t, test.res.threshold, res.threshold)
+			}
+		})
+	}
+}
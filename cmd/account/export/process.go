@@ -0,0 +1,177 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountexport
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/auditlog"
+	"github.com/wealdtech/ethdo/shamir"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/go-ecodec"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type shardedExport struct {
+	Version   uint32          `json:"version"`
+	Shards    uint32          `json:"shards"`
+	Threshold uint32          `json:"threshold"`
+	Keystore  json.RawMessage `json:"keystore,omitempty"`
+	Mnemonic  string          `json:"mnemonic,omitempty"`
+}
+
+func process(ctx context.Context, data *dataIn) (*dataOut, error) {
+	if data == nil {
+		return nil, errors.New("no data")
+	}
+	if data.account == nil && data.mnemonic == "" {
+		return nil, errors.New("account or mnemonic is required")
+	}
+
+	passphrase := data.exportPassphrase
+	var secret []byte
+	if data.shards > 0 {
+		// The export is encrypted with a random passphrase, which is split in to shares.
+		secret = make([]byte, 32)
+		n, err := rand.Read(secret)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate passphrase")
+		}
+		if n != 32 {
+			return nil, errors.New("failed to obtain passphrase")
+		}
+		passphrase = fmt.Sprintf("%x", secret)
+	} else if !util.AcceptablePassphrase(passphrase) {
+		return nil, errors.New("supplied export passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
+	}
+
+	var export []byte
+	var err error
+	if data.account != nil {
+		export, err = exportKeystore(ctx, data, passphrase)
+	} else {
+		export, err = exportMnemonic(data, passphrase)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	results := &dataOut{}
+	if data.shards > 0 {
+		results.shares, err = shamir.Split(secret, int(data.shards), int(data.threshold))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create shamir shares")
+		}
+	}
+
+	if err := os.WriteFile(data.file, export, 0600); err != nil {
+		return nil, errors.Wrap(err, "failed to write export file")
+	}
+
+	return results, nil
+}
+
+// exportKeystore exports the account's private key as an EIP-2335 keystore.
+func exportKeystore(ctx context.Context, data *dataIn, passphrase string) ([]byte, error) {
+	if len(data.passphrases) == 0 {
+		return nil, errors.New("passphrase is required")
+	}
+	privateKeyProvider, isPrivateKeyProvider := data.account.(e2wtypes.AccountPrivateKeyProvider)
+	if !isPrivateKeyProvider {
+		return nil, errors.New("account does not provide its private key")
+	}
+
+	if _, err := util.UnlockAccountWithPassphrase(ctx, data.account, data.passphrases); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := util.LockAccount(ctx, data.account); err != nil {
+			util.Log.Trace().Err(err).Msg("Failed to lock account")
+		}
+	}()
+	key, err := privateKeyProvider.PrivateKey(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain private key")
+	}
+	if err := auditlog.Record(auditlog.ActionExport, data.account, nil); err != nil {
+		return nil, err
+	}
+
+	crypto, err := keystorev4.New().Encrypt(key.Marshal(), passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt key")
+	}
+	path := ""
+	if pathProvider, isPathProvider := data.account.(e2wtypes.AccountPathProvider); isPathProvider {
+		path = pathProvider.Path()
+	}
+	keystore, err := json.Marshal(map[string]interface{}{
+		"crypto":  crypto,
+		"pubkey":  fmt.Sprintf("%x", key.PublicKey().Marshal()),
+		"path":    path,
+		"uuid":    uuid.New().String(),
+		"version": 4,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal keystore")
+	}
+
+	if data.shards == 0 {
+		return keystore, nil
+	}
+
+	return marshalShardedExport(&shardedExport{
+		Version:   1,
+		Shards:    data.shards,
+		Threshold: data.threshold,
+		Keystore:  keystore,
+	})
+}
+
+// exportMnemonic exports the mnemonic encrypted with the given passphrase.
+func exportMnemonic(data *dataIn, passphrase string) ([]byte, error) {
+	encrypted, err := ecodec.Encrypt([]byte(data.mnemonic), []byte(passphrase))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt mnemonic")
+	}
+	if err := auditlog.RecordEntry(&auditlog.Entry{
+		Action:  auditlog.ActionExport,
+		Account: "mnemonic",
+	}); err != nil {
+		return nil, err
+	}
+
+	return marshalShardedExport(&shardedExport{
+		Version:   1,
+		Shards:    data.shards,
+		Threshold: data.threshold,
+		Mnemonic:  fmt.Sprintf("%#x", encrypted),
+	})
+}
+
+func marshalShardedExport(export *shardedExport) ([]byte, error) {
+	res, err := json.Marshal(export)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal sharded export")
+	}
+
+	return res, nil
+}
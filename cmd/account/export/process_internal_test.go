@@ -0,0 +1,193 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/shamir"
+	"github.com/wealdtech/go-ecodec"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	dir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	testNDWallet, err := nd.CreateWallet(context.Background(),
+		"Test",
+		scratch.New(),
+		keystorev4.New(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, testNDWallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	interop0, err := testNDWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Interop 0",
+		hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		dataIn *dataIn
+		shares int
+		err    string
+	}{
+		{
+			name: "Nil",
+			err:  "no data",
+		},
+		{
+			name: "AccountMissing",
+			dataIn: &dataIn{
+				timeout:          5 * time.Second,
+				exportPassphrase: "ce%NohGhah4ye5ra",
+				file:             filepath.Join(dir, "keystore.json"),
+			},
+			err: "account or mnemonic is required",
+		},
+		{
+			name: "ExportPassphraseWeak",
+			dataIn: &dataIn{
+				timeout:          5 * time.Second,
+				account:          interop0,
+				passphrases:      []string{"pass"},
+				exportPassphrase: "poor",
+				file:             filepath.Join(dir, "keystore.json"),
+			},
+			err: "supplied export passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag",
+		},
+		{
+			name: "PassphrasesMissing",
+			dataIn: &dataIn{
+				timeout:          5 * time.Second,
+				account:          interop0,
+				exportPassphrase: "ce%NohGhah4ye5ra",
+				file:             filepath.Join(dir, "keystore.json"),
+			},
+			err: "passphrase is required",
+		},
+		{
+			name: "PassphraseIncorrect",
+			dataIn: &dataIn{
+				timeout:          5 * time.Second,
+				account:          interop0,
+				passphrases:      []string{"wrong"},
+				exportPassphrase: "ce%NohGhah4ye5ra",
+				file:             filepath.Join(dir, "keystore.json"),
+			},
+			err: "failed to unlock account",
+		},
+		{
+			name: "FileInvalid",
+			dataIn: &dataIn{
+				timeout:          5 * time.Second,
+				account:          interop0,
+				passphrases:      []string{"pass"},
+				exportPassphrase: "ce%NohGhah4ye5ra",
+				file:             "/bad/bad/bad/keystore.json",
+			},
+			err: "failed to write export file: open /bad/bad/bad/keystore.json: no such file or directory",
+		},
+		{
+			name: "Keystore",
+			dataIn: &dataIn{
+				timeout:          5 * time.Second,
+				account:          interop0,
+				passphrases:      []string{"pass"},
+				exportPassphrase: "ce%NohGhah4ye5ra",
+				file:             filepath.Join(dir, "keystore.json"),
+			},
+		},
+		{
+			name: "KeystoreShards",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				account:     interop0,
+				passphrases: []string{"pass"},
+				file:        filepath.Join(dir, "keystore-shards.json"),
+				shards:      5,
+				threshold:   3,
+			},
+			shares: 5,
+		},
+		{
+			name: "MnemonicShards",
+			dataIn: &dataIn{
+				timeout:   5 * time.Second,
+				mnemonic:  "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				file:      filepath.Join(dir, "mnemonic-shards.json"),
+				shards:    3,
+				threshold: 2,
+			},
+			shares: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(context.Background(), test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res.shares, test.shares)
+
+			data, err := os.ReadFile(test.dataIn.file)
+			require.NoError(t, err)
+			if test.shares == 0 {
+				keystore := make(map[string]interface{})
+				require.NoError(t, json.Unmarshal(data, &keystore))
+				require.Equal(t, float64(4), keystore["version"])
+				require.Equal(t, "a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c", keystore["pubkey"])
+				return
+			}
+
+			export := &shardedExport{}
+			require.NoError(t, json.Unmarshal(data, export))
+			require.Equal(t, test.dataIn.shards, export.Shards)
+			require.Equal(t, test.dataIn.threshold, export.Threshold)
+			secret, err := shamir.Combine(res.shares[:test.dataIn.threshold])
+			require.NoError(t, err)
+			passphrase := fmt.Sprintf("%x", secret)
+			if test.dataIn.mnemonic != "" {
+				mnemonic, err := ecodec.Decrypt(hexToBytes(export.Mnemonic), []byte(passphrase))
+				require.NoError(t, err)
+				require.Equal(t, test.dataIn.mnemonic, string(mnemonic))
+			} else {
+				keystore := make(map[string]interface{})
+				require.NoError(t, json.Unmarshal(export.Keystore, &keystore))
+				key, err := keystorev4.New().Decrypt(keystore["crypto"].(map[string]interface{}), passphrase)
+				require.NoError(t, err)
+				require.Equal(t, hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), key)
+			}
+		})
+	}
+}
@@ -36,6 +36,8 @@ type dataIn struct {
 	walletPassphrase   string
 	keystore           []byte
 	keystorePassphrase []byte
+	shards             []string
+	path               string
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -91,6 +93,21 @@ func input(ctx context.Context) (*dataIn, error) {
 		}
 	}
 
+	// Shards.
+	data.shards = viper.GetStringSlice("shards")
+	if len(data.shards) > 0 {
+		if viper.GetString("keystore") == "" {
+			return nil, errors.New("keystore is required when supplying shards")
+		}
+		data.keystore, err = obtainKeystore(viper.GetString("keystore"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid keystore")
+		}
+		// Path is only required for exported mnemonics, so is checked when processing.
+		data.path = viper.GetString("path")
+		return data, nil
+	}
+
 	if viper.GetString("keystore") != "" {
 		data.keystorePassphrase = []byte(viper.GetString("keystore-passphrase"))
 		if len(data.keystorePassphrase) == 0 {
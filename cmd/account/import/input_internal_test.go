@@ -134,6 +134,32 @@ func TestInput(t *testing.T) {
 			},
 			err: "must supply keystore passphrase with keystore-passphrase when supplying keystore",
 		},
+		{
+			name: "ShardsNoKeystore",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"account":    "Test wallet/Test account",
+				"passphrase": "ce%NohGhah4ye5ra",
+				"key":        "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+				"shards":     "01 02 03",
+			},
+			err: "keystore is required when supplying shards",
+		},
+		{
+			name: "Shards",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"account":    "Test wallet/Test account",
+				"passphrase": "ce%NohGhah4ye5ra",
+				"keystore":   "{}",
+				"shards":     "01 02 03",
+			},
+			res: &dataIn{
+				timeout:     5 * time.Second,
+				accountName: "Test account",
+				passphrase:  "ce%NohGhah4ye5ra",
+			},
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
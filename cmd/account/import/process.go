@@ -15,9 +15,13 @@ package accountimport
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/shamir"
 	"github.com/wealdtech/ethdo/util"
 	"github.com/wealdtech/go-ecodec"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
@@ -26,6 +30,14 @@ import (
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
+type shardedExport struct {
+	Version   uint32          `json:"version"`
+	Shards    uint32          `json:"shards"`
+	Threshold uint32          `json:"threshold"`
+	Keystore  json.RawMessage `json:"keystore,omitempty"`
+	Mnemonic  string          `json:"mnemonic,omitempty"`
+}
+
 func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if data == nil {
 		return nil, errors.New("no data")
@@ -48,6 +60,9 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		}()
 	}
 
+	if len(data.shards) > 0 {
+		return processFromShards(ctx, data)
+	}
 	if len(data.key) > 0 {
 		return processFromKey(ctx, data)
 	}
@@ -73,6 +88,61 @@ func processFromKey(ctx context.Context, data *dataIn) (*dataOut, error) {
 	return results, nil
 }
 
+func processFromShards(ctx context.Context, data *dataIn) (*dataOut, error) {
+	export := &shardedExport{}
+	if err := json.Unmarshal(data.keystore, export); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal sharded export")
+	}
+	if len(data.shards) != int(export.Threshold) {
+		return nil, fmt.Errorf("import requires %d shares, %d were provided", export.Threshold, len(data.shards))
+	}
+
+	shares := make([][]byte, len(data.shards))
+	for i := range data.shards {
+		var err error
+		shares[i], err = hex.DecodeString(strings.TrimPrefix(data.shards[i], "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid share")
+		}
+	}
+	secret, err := shamir.Combine(shares)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to recreate passphrase from shares")
+	}
+	passphrase := []byte(fmt.Sprintf("%x", secret))
+
+	switch {
+	case len(export.Keystore) > 0:
+		data.keystore = export.Keystore
+		data.keystorePassphrase = passphrase
+		return processFromKeystore(ctx, data)
+	case export.Mnemonic != "":
+		if data.path == "" {
+			return nil, errors.New("path is required to import a mnemonic")
+		}
+		encrypted, err := hex.DecodeString(strings.TrimPrefix(export.Mnemonic, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain mnemonic from export")
+		}
+		mnemonic, err := ecodec.Decrypt(encrypted, passphrase)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt mnemonic")
+		}
+		account, err := util.ParseAccount(ctx, string(mnemonic), []string{data.path}, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to derive account")
+		}
+		key, err := account.(e2wtypes.AccountPrivateKeyProvider).PrivateKey(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain private key")
+		}
+		data.key = key.Marshal()
+		return processFromKey(ctx, data)
+	default:
+		return nil, errors.New("sharded export contains neither keystore nor mnemonic")
+	}
+}
+
 func processFromKeystore(ctx context.Context, data *dataIn) (*dataOut, error) {
 	// Need to import the keystore in to a temporary wallet to fetch the private key.
 	store := scratch.New()
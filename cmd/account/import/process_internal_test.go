@@ -15,10 +15,14 @@ package accountimport
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/shamir"
+	"github.com/wealdtech/go-ecodec"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
@@ -93,3 +97,147 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessShards(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	testNDWallet, err := nd.CreateWallet(context.Background(),
+		"Test",
+		scratch.New(),
+		keystorev4.New(),
+	)
+	require.NoError(t, err)
+
+	secret := hexToBytes("0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20")
+	passphrase := fmt.Sprintf("%x", secret)
+	shares, err := shamir.Split(secret, 3, 2)
+	require.NoError(t, err)
+	encodedShares := make([]string, len(shares))
+	for i := range shares {
+		encodedShares[i] = fmt.Sprintf("%x", shares[i])
+	}
+
+	crypto, err := keystorev4.New().Encrypt(hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), passphrase)
+	require.NoError(t, err)
+	keystore, err := json.Marshal(map[string]interface{}{
+		"crypto":  crypto,
+		"pubkey":  "a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+		"path":    "",
+		"uuid":    "8f3b2b0f-7c0a-4c3e-9a0c-3f5d2d1b6e71",
+		"version": 4,
+	})
+	require.NoError(t, err)
+	keystoreExport, err := json.Marshal(&shardedExport{
+		Version:   1,
+		Shards:    3,
+		Threshold: 2,
+		Keystore:  keystore,
+	})
+	require.NoError(t, err)
+
+	encryptedMnemonic, err := ecodec.Encrypt([]byte("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"), []byte(passphrase))
+	require.NoError(t, err)
+	mnemonicExport, err := json.Marshal(&shardedExport{
+		Version:   1,
+		Shards:    3,
+		Threshold: 2,
+		Mnemonic:  fmt.Sprintf("%#x", encryptedMnemonic),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		dataIn *dataIn
+		err    string
+	}{
+		{
+			name: "ExportBad",
+			dataIn: &dataIn{
+				timeout:          5 * time.Second,
+				wallet:           testNDWallet,
+				accountName:      "ExportBad",
+				passphrase:       "ce%NohGhah4ye5ra",
+				walletPassphrase: "pass",
+				keystore:         []byte("\001"),
+				shards:           encodedShares[:2],
+			},
+			err: "failed to unmarshal sharded export: invalid character '\\x01' looking for beginning of value",
+		},
+		{
+			name: "SharesTooFew",
+			dataIn: &dataIn{
+				timeout:          5 * time.Second,
+				wallet:           testNDWallet,
+				accountName:      "SharesTooFew",
+				passphrase:       "ce%NohGhah4ye5ra",
+				walletPassphrase: "pass",
+				keystore:         keystoreExport,
+				shards:           encodedShares[:1],
+			},
+			err: "import requires 2 shares, 1 were provided",
+		},
+		{
+			name: "ShareBad",
+			dataIn: &dataIn{
+				timeout:          5 * time.Second,
+				wallet:           testNDWallet,
+				accountName:      "ShareBad",
+				passphrase:       "ce%NohGhah4ye5ra",
+				walletPassphrase: "pass",
+				keystore:         keystoreExport,
+				shards:           []string{"xxx", encodedShares[1]},
+			},
+			err: "invalid share: encoding/hex: invalid byte: U+0078 'x'",
+		},
+		{
+			name: "MnemonicPathMissing",
+			dataIn: &dataIn{
+				timeout:          5 * time.Second,
+				wallet:           testNDWallet,
+				accountName:      "MnemonicPathMissing",
+				passphrase:       "ce%NohGhah4ye5ra",
+				walletPassphrase: "pass",
+				keystore:         mnemonicExport,
+				shards:           encodedShares[:2],
+			},
+			err: "path is required to import a mnemonic",
+		},
+		{
+			name: "Keystore",
+			dataIn: &dataIn{
+				timeout:          5 * time.Second,
+				wallet:           testNDWallet,
+				accountName:      "Keystore",
+				passphrase:       "ce%NohGhah4ye5ra",
+				walletPassphrase: "pass",
+				keystore:         keystoreExport,
+				shards:           encodedShares[1:],
+			},
+		},
+		{
+			name: "Mnemonic",
+			dataIn: &dataIn{
+				timeout:          5 * time.Second,
+				wallet:           testNDWallet,
+				accountName:      "Mnemonic",
+				passphrase:       "ce%NohGhah4ye5ra",
+				walletPassphrase: "pass",
+				keystore:         mnemonicExport,
+				shards:           []string{encodedShares[0], encodedShares[2]},
+				path:             "m/12381/3600/0/0",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(context.Background(), test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.dataIn.accountName, res.account.Name())
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountinterop
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	timeout              time.Duration
+	count                uint64
+	startIndex           uint64
+	walletName           string
+	accountPrefix        string
+	keystoreDir          string
+	passphrase           string
+	walletPassphrase     string
+	withdrawalAddressStr string
+	forkVersion          phase0.Version
+	depositDataFile      string
+
+	// Processing.
+	wallet e2wtypes.Wallet
+
+	// Output.
+	depositData []*depositData
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                viper.GetBool("quiet"),
+		verbose:              viper.GetBool("verbose"),
+		debug:                viper.GetBool("debug"),
+		startIndex:           viper.GetUint64("start-index"),
+		keystoreDir:          viper.GetString("keystore-dir"),
+		walletPassphrase:     util.GetWalletPassphrase(),
+		withdrawalAddressStr: viper.GetString("withdrawal-address"),
+		depositDataFile:      viper.GetString("deposit-data"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.count = viper.GetUint64("count")
+	if c.count == 0 {
+		return nil, errors.New("count is required")
+	}
+
+	switch {
+	case viper.GetString("account") == "" && c.keystoreDir == "":
+		return nil, errors.New("one of account or keystore-dir is required")
+	case viper.GetString("account") != "" && c.keystoreDir != "":
+		return nil, errors.New("only one of account and keystore-dir allowed")
+	case viper.GetString("account") != "":
+		var err error
+		c.walletName, c.accountPrefix, err = e2wallet.WalletAndAccountNames(viper.GetString("account"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain wallet and account names")
+		}
+		if c.accountPrefix == "" {
+			return nil, errors.New("account name is required")
+		}
+	}
+
+	var err error
+	c.passphrase, err = util.GetPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	if viper.GetString("fork-version") != "" {
+		data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("fork-version"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode fork version")
+		}
+		if len(data) != phase0.ForkVersionLength {
+			return nil, fmt.Errorf("fork version must be exactly %d bytes in length", phase0.ForkVersionLength)
+		}
+		copy(c.forkVersion[:], data)
+	}
+
+	return c, nil
+}
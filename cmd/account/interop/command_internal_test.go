@@ -0,0 +1,125 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountinterop
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"count":        "4",
+				"keystore-dir": "keys",
+				"passphrase":   "ce%NohGhah4ye5ra",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "CountMissing",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"keystore-dir": "keys",
+				"passphrase":   "ce%NohGhah4ye5ra",
+			},
+			err: "count is required",
+		},
+		{
+			name: "DestinationMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"count":      "4",
+				"passphrase": "ce%NohGhah4ye5ra",
+			},
+			err: "one of account or keystore-dir is required",
+		},
+		{
+			name: "DestinationMultiple",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"count":        "4",
+				"account":      "Interop/Validator",
+				"keystore-dir": "keys",
+				"passphrase":   "ce%NohGhah4ye5ra",
+			},
+			err: "only one of account and keystore-dir allowed",
+		},
+		{
+			name: "AccountNameMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"count":      "4",
+				"account":    "Interop/",
+				"passphrase": "ce%NohGhah4ye5ra",
+			},
+			err: "account name is required",
+		},
+		{
+			name: "PassphraseMissing",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"count":        "4",
+				"keystore-dir": "keys",
+			},
+			err: "passphrase is required",
+		},
+		{
+			name: "ForkVersionInvalid",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"count":        "4",
+				"keystore-dir": "keys",
+				"passphrase":   "ce%NohGhah4ye5ra",
+				"fork-version": "0x0102",
+			},
+			err: "fork version must be exactly 4 bytes in length",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"count":        "4",
+				"account":      "Interop/Validator",
+				"passphrase":   "ce%NohGhah4ye5ra",
+				"fork-version": "0x10000038",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountinterop
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// depositData is the deposit data for a single key.
+type depositData struct {
+	PublicKey             phase0.BLSPubKey
+	WithdrawalCredentials []byte
+	Amount                phase0.Gwei
+	Signature             phase0.BLSSignature
+	DepositMessageRoot    phase0.Root
+	DepositDataRoot       phase0.Root
+	ForkVersion           phase0.Version
+}
+
+// depositDataJSON is the launchpad-compatible format of deposit data.
+type depositDataJSON struct {
+	PublicKey             string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	ForkVersion           string `json:"fork_version"`
+	NetworkName           string `json:"eth2_network_name"`
+	CLIVersion            string `json:"deposit_cli_version"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d *depositData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&depositDataJSON{
+		PublicKey:             fmt.Sprintf("%x", d.PublicKey),
+		WithdrawalCredentials: fmt.Sprintf("%x", d.WithdrawalCredentials),
+		Amount:                uint64(d.Amount),
+		Signature:             fmt.Sprintf("%x", d.Signature),
+		DepositMessageRoot:    fmt.Sprintf("%x", d.DepositMessageRoot),
+		DepositDataRoot:       fmt.Sprintf("%x", d.DepositDataRoot),
+		ForkVersion:           fmt.Sprintf("%x", d.ForkVersion),
+		NetworkName:           "devnet",
+		CLIVersion:            "1.1.0",
+	})
+}
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.depositDataFile != "" {
+		// Deposit data has already been written to the file.
+		if c.verbose {
+			return fmt.Sprintf("Generated %d interop keys, with deposit data written to %s", len(c.depositData), c.depositDataFile), nil
+		}
+		return "", nil
+	}
+
+	data, err := json.Marshal(c.depositData)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal deposit data")
+	}
+
+	return string(data), nil
+}
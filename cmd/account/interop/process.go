@@ -0,0 +1,248 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountinterop
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	ethutil "github.com/wealdtech/go-eth2-util"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// depositAmount is the amount deposited for each validator, in Gwei.
+const depositAmount = phase0.Gwei(32000000000)
+
+func (c *command) process(ctx context.Context) error {
+	if !util.AcceptablePassphrase(c.passphrase) {
+		return errors.New("supplied passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
+	}
+
+	var withdrawalAddress []byte
+	if c.withdrawalAddressStr != "" {
+		var err error
+		withdrawalAddress, err = hex.DecodeString(strings.TrimPrefix(c.withdrawalAddressStr, "0x"))
+		if err != nil {
+			return errors.Wrap(err, "failed to decode withdrawal address")
+		}
+		if len(withdrawalAddress) != 20 {
+			return errors.New("withdrawal address must be exactly 20 bytes in length")
+		}
+		// Ensure the address is properly checksummed.
+		checksummedAddress := addressBytesToEIP55(withdrawalAddress)
+		if checksummedAddress != c.withdrawalAddressStr {
+			return fmt.Errorf("withdrawal address checksum does not match (expected %s)", checksummedAddress)
+		}
+	}
+
+	store, err := c.setupStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	domain := phase0.Domain{}
+	copy(domain[:], e2types.Domain(e2types.DomainDeposit, c.forkVersion[:], e2types.ZeroGenesisValidatorsRoot))
+
+	c.depositData = make([]*depositData, 0, c.count)
+	for index := c.startIndex; index < c.startIndex+c.count; index++ {
+		key := util.InteropPrivateKey(index)
+		account, err := util.NewScratchAccount(key, nil)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to create key %d", index))
+		}
+
+		if err := store(ctx, index, key, account.PublicKey().Marshal()); err != nil {
+			return err
+		}
+
+		depositData, err := c.generateDepositData(ctx, account, withdrawalAddress, domain)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to generate deposit data for key %d", index))
+		}
+		c.depositData = append(c.depositData, depositData)
+
+		util.Log.Debug().Uint64("index", index).Str("pubkey", fmt.Sprintf("%#x", depositData.PublicKey)).Msg("Generated key")
+	}
+
+	if c.depositDataFile != "" {
+		data, err := json.Marshal(c.depositData)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal deposit data")
+		}
+		if err := os.WriteFile(c.depositDataFile, data, 0600); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to write %s", c.depositDataFile))
+		}
+	}
+
+	return nil
+}
+
+// keyStorer stores a private key.
+type keyStorer func(ctx context.Context, index uint64, key []byte, pubKey []byte) error
+
+// setupStore returns the function that stores keys, either in a wallet or as keystores.
+func (c *command) setupStore(ctx context.Context) (keyStorer, error) {
+	encryptor := keystorev4.New()
+
+	if c.keystoreDir != "" {
+		if err := os.MkdirAll(c.keystoreDir, 0700); err != nil {
+			return nil, errors.Wrap(err, "failed to create keystore directory")
+		}
+		return func(_ context.Context, index uint64, key []byte, pubKey []byte) error {
+			crypto, err := encryptor.Encrypt(key, c.passphrase)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to encrypt key %d", index))
+			}
+			data, err := json.Marshal(map[string]interface{}{
+				"crypto":  crypto,
+				"pubkey":  fmt.Sprintf("%x", pubKey),
+				"path":    "",
+				"uuid":    uuid.New().String(),
+				"version": 4,
+			})
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to marshal keystore %d", index))
+			}
+			filename := filepath.Join(c.keystoreDir, fmt.Sprintf("keystore-interop-%d.json", index))
+			if err := os.WriteFile(filename, data, 0600); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to write %s", filename))
+			}
+			return nil
+		}, nil
+	}
+
+	if c.wallet == nil {
+		ctx, cancel := context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+		var err error
+		c.wallet, err = util.WalletFromPath(ctx, c.walletName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain wallet")
+		}
+	}
+	importer, isImporter := c.wallet.(e2wtypes.WalletAccountImporter)
+	if !isImporter {
+		return nil, fmt.Errorf("%s wallets do not support importing accounts", c.wallet.Type())
+	}
+
+	return func(ctx context.Context, index uint64, key []byte, _ []byte) error {
+		if locker, isLocker := c.wallet.(e2wtypes.WalletLocker); isLocker {
+			if err := locker.Unlock(ctx, []byte(c.walletPassphrase)); err != nil {
+				return errors.Wrap(err, "failed to unlock wallet")
+			}
+			defer func() {
+				if err := locker.Lock(ctx); err != nil {
+					util.Log.Trace().Err(err).Msg("Failed to lock wallet")
+				}
+			}()
+		}
+		accountName := fmt.Sprintf("%s%d", c.accountPrefix, index)
+		if _, err := importer.ImportAccount(ctx, accountName, key, []byte(c.passphrase)); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to import account %s", accountName))
+		}
+		return nil
+	}, nil
+}
+
+func (c *command) generateDepositData(ctx context.Context,
+	account *util.ScratchAccount,
+	withdrawalAddress []byte,
+	domain phase0.Domain,
+) (
+	*depositData,
+	error,
+) {
+	var pubKey phase0.BLSPubKey
+	copy(pubKey[:], account.PublicKey().Marshal())
+
+	var withdrawalCredentials []byte
+	if len(withdrawalAddress) > 0 {
+		withdrawalCredentials = make([]byte, 32)
+		copy(withdrawalCredentials[12:32], withdrawalAddress)
+		withdrawalCredentials[0] = byte(1) // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+	} else {
+		// Interop keys withdraw to their own public key.
+		withdrawalCredentials = ethutil.SHA256(pubKey[:])
+		withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
+	}
+
+	depositMessage := &phase0.DepositMessage{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                depositAmount,
+	}
+	depositMessageRoot, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate deposit message root")
+	}
+
+	if err := account.Unlock(ctx, nil); err != nil {
+		return nil, errors.Wrap(err, "failed to unlock key")
+	}
+	sig, err := signing.SignRoot(ctx, account, nil, depositMessageRoot, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign deposit message")
+	}
+
+	depositDataRoot, err := (&phase0.DepositData{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                depositAmount,
+		Signature:             sig,
+	}).HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate deposit data root")
+	}
+
+	return &depositData{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                depositAmount,
+		Signature:             sig,
+		DepositMessageRoot:    depositMessageRoot,
+		DepositDataRoot:       depositDataRoot,
+		ForkVersion:           c.forkVersion,
+	}, nil
+}
+
+// addressBytesToEIP55 converts a byte array in to an EIP-55 string format.
+func addressBytesToEIP55(address []byte) string {
+	bytes := []byte(fmt.Sprintf("%x", address))
+	hash := ethutil.Keccak256(bytes)
+	for i := 0; i < len(bytes); i++ {
+		hashByte := hash[i/2]
+		if i%2 == 0 {
+			hashByte >>= 4
+		} else {
+			hashByte &= 0xf
+		}
+		if bytes[i] > '9' && hashByte > 7 {
+			bytes[i] -= 32
+		}
+	}
+
+	return fmt.Sprintf("0x%s", string(bytes))
+}
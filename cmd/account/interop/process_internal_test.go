@@ -0,0 +1,135 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountinterop
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	testNDWallet, err := nd.CreateWallet(context.Background(),
+		"Test",
+		scratch.New(),
+		keystorev4.New(),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		command     *command
+		keystores   int
+		accounts    int
+		depositData int
+		err         string
+	}{
+		{
+			name: "PassphraseWeak",
+			command: &command{
+				timeout:    5 * time.Second,
+				count:      2,
+				wallet:     testNDWallet,
+				passphrase: "poor",
+			},
+			err: "supplied passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag",
+		},
+		{
+			name: "WithdrawalAddressChecksum",
+			command: &command{
+				timeout:              5 * time.Second,
+				count:                2,
+				wallet:               testNDWallet,
+				passphrase:           "ce%NohGhah4ye5ra",
+				withdrawalAddressStr: "0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f",
+			},
+			err: "withdrawal address checksum does not match (expected 0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F)",
+		},
+		{
+			name: "Wallet",
+			command: &command{
+				timeout:          5 * time.Second,
+				count:            2,
+				startIndex:       1,
+				wallet:           testNDWallet,
+				walletPassphrase: "pass",
+				accountPrefix:    "Validator",
+				passphrase:       "ce%NohGhah4ye5ra",
+			},
+			accounts:    2,
+			depositData: 2,
+		},
+		{
+			name: "Keystores",
+			command: &command{
+				timeout:              5 * time.Second,
+				count:                2,
+				keystoreDir:          t.TempDir(),
+				passphrase:           "ce%NohGhah4ye5ra",
+				withdrawalAddressStr: "0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F",
+			},
+			keystores:   2,
+			depositData: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, test.command.depositData, test.depositData)
+				if test.keystores > 0 {
+					entries, err := os.ReadDir(test.command.keystoreDir)
+					require.NoError(t, err)
+					require.Len(t, entries, test.keystores)
+				}
+				if test.accounts > 0 {
+					accounts := 0
+					for range testNDWallet.Accounts(context.Background()) {
+						accounts++
+					}
+					require.Equal(t, test.accounts, accounts)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessKeys(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	c := &command{
+		timeout:     5 * time.Second,
+		count:       1,
+		keystoreDir: t.TempDir(),
+		passphrase:  "ce%NohGhah4ye5ra",
+	}
+	require.NoError(t, c.process(context.Background()))
+	require.Len(t, c.depositData, 1)
+	require.Equal(t, "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c", fmt.Sprintf("%#x", c.depositData[0].PublicKey))
+	require.Equal(t, byte(0x00), c.depositData[0].WithdrawalCredentials[0])
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/auditlog"
 	"github.com/wealdtech/ethdo/util"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
@@ -52,6 +53,9 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 			if !unlocked {
 				return nil, errors.New("failed to unlock account")
 			}
+			if err := auditlog.Record(auditlog.ActionUnlock, data.account, nil); err != nil {
+				return nil, err
+			}
 			// Because we unlocked the accout we should re-lock it when we're done.
 			defer func() {
 				if err := locker.Lock(ctx); err != nil {
@@ -64,6 +68,9 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain private key")
 	}
+	if err := auditlog.Record(auditlog.ActionExport, data.account, nil); err != nil {
+		return nil, err
+	}
 	results.key = key.Marshal()
 
 	return results, nil
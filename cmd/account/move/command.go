@@ -0,0 +1,93 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountmove
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	timeout time.Duration
+
+	// Input.
+	account           string
+	destinationWallet string
+	newName           string
+	passphrases       []string
+	newPassphrase     string
+	walletPassphrase  string
+
+	// Processing.
+	source      e2wtypes.Wallet
+	destination e2wtypes.Wallet
+
+	// Output.
+	oldName string
+	name    string
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:             viper.GetBool("quiet"),
+		verbose:           viper.GetBool("verbose"),
+		debug:             viper.GetBool("debug"),
+		account:           viper.GetString("account"),
+		destinationWallet: viper.GetString("destination-wallet"),
+		newName:           viper.GetString("new-name"),
+		passphrases:       util.GetPassphrases(),
+		newPassphrase:     viper.GetString("new-passphrase"),
+		walletPassphrase:  util.GetWalletPassphrase(),
+	}
+
+	if viper.GetString("remote") != "" {
+		return nil, errors.New("account move not available for remote wallets")
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	if c.account == "" {
+		return nil, errors.New("account is required")
+	}
+	if c.destinationWallet == "" {
+		return nil, errors.New("destination-wallet is required")
+	}
+	if c.newName != "" {
+		if err := util.CheckAccountName(c.newName); err != nil {
+			return nil, errors.Wrap(err, "invalid new-name")
+		}
+	}
+
+	if len(c.passphrases) == 0 {
+		return nil, errors.New("passphrase is required")
+	}
+	// If no new passphrase is supplied the account keeps its existing passphrase.
+	if c.newPassphrase != "" && !util.AcceptablePassphrase(c.newPassphrase) {
+		return nil, errors.New("supplied new passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
+	}
+
+	return c, nil
+}
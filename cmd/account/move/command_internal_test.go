@@ -0,0 +1,139 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountmove
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name          string
+		vars          map[string]interface{}
+		newPassphrase string
+		err           string
+	}{
+		{
+			name: "Remote",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"remote":             "remoteaddress",
+				"account":            "Source/Account 1",
+				"destination-wallet": "Destination",
+				"passphrase":         []string{"pass"},
+			},
+			err: "account move not available for remote wallets",
+		},
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"account":            "Source/Account 1",
+				"destination-wallet": "Destination",
+				"passphrase":         []string{"pass"},
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "AccountMissing",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"destination-wallet": "Destination",
+				"passphrase":         []string{"pass"},
+			},
+			err: "account is required",
+		},
+		{
+			name: "DestinationWalletMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"account":    "Source/Account 1",
+				"passphrase": []string{"pass"},
+			},
+			err: "destination-wallet is required",
+		},
+		{
+			name: "NewNameInvalid",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"account":            "Source/Account 1",
+				"destination-wallet": "Destination",
+				"new-name":           "a/b",
+				"passphrase":         []string{"pass"},
+			},
+			err: "invalid new-name: account name cannot contain '/'",
+		},
+		{
+			name: "PassphraseMissing",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"account":            "Source/Account 1",
+				"destination-wallet": "Destination",
+			},
+			err: "passphrase is required",
+		},
+		{
+			name: "NewPassphraseWeak",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"account":            "Source/Account 1",
+				"destination-wallet": "Destination",
+				"passphrase":         []string{"pass"},
+				"new-passphrase":     "weak",
+			},
+			err: "supplied new passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"account":            "Source/Account 1",
+				"destination-wallet": "Destination",
+				"passphrase":         []string{"pass"},
+			},
+		},
+		{
+			name: "GoodNewPassphrase",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"account":                "Source/Account 1",
+				"destination-wallet":     "Destination",
+				"passphrase":             []string{"pass1", "pass2"},
+				"new-passphrase":         "weak",
+				"allow-weak-passphrases": true,
+			},
+			newPassphrase: "weak",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.newPassphrase, c.newPassphrase)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountmove
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	source, account, err := util.WalletAndAccountFromPath(ctx, c.account)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain account")
+	}
+	c.source = source
+	c.oldName = account.Name()
+	c.name = c.newName
+	if c.name == "" {
+		c.name = account.Name()
+	}
+
+	c.destination, err = util.WalletFromPath(ctx, c.destinationWallet)
+	if err != nil {
+		return errors.Wrap(err, "failed to access destination wallet")
+	}
+	if c.destination.ID() == source.ID() {
+		return errors.New("destination wallet is the same as the source wallet; use account rename instead")
+	}
+
+	collisions, err := util.AccountCollisions(ctx, c.destination, map[string]e2wtypes.Account{c.name: account})
+	if err != nil {
+		return err
+	}
+	if len(collisions) > 0 {
+		return fmt.Errorf("account collides with an existing account in %s: %s", c.destination.Name(), strings.Join(collisions, ", "))
+	}
+
+	passphrase, err := util.UnlockAccountWithPassphrase(ctx, account, c.passphrases)
+	if err != nil {
+		return errors.Wrap(err, "failed to unlock account")
+	}
+	defer func() {
+		if err := util.LockAccount(ctx, account); err != nil {
+			util.Log.Trace().Err(err).Msg("Failed to lock account")
+		}
+	}()
+	if c.newPassphrase == "" {
+		c.newPassphrase = passphrase
+	}
+
+	if locker, isLocker := c.destination.(e2wtypes.WalletLocker); isLocker {
+		if err := locker.Unlock(ctx, []byte(c.walletPassphrase)); err != nil {
+			return errors.Wrap(err, "failed to unlock destination wallet")
+		}
+		defer func() {
+			if err := locker.Lock(ctx); err != nil {
+				util.Log.Trace().Err(err).Msg("Failed to lock wallet")
+			}
+		}()
+	}
+
+	if _, err := util.MoveAccount(ctx, source, account, c.destination, c.name, []byte(c.newPassphrase)); err != nil {
+		return errors.Wrap(err, "failed to move account")
+	}
+
+	return nil
+}
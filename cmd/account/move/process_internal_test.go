@@ -0,0 +1,132 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountmove
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	store := filesystem.New(filesystem.WithLocation(t.TempDir()))
+	require.NoError(t, e2wallet.UseStore(store))
+	source, err := nd.CreateWallet(ctx, "Source", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, source.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	account1, err := source.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 1", []byte("pass"))
+	require.NoError(t, err)
+	account2, err := source.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 2", []byte("pass"))
+	require.NoError(t, err)
+
+	destination, err := nd.CreateWallet(ctx, "Destination", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, destination.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	_, err = destination.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 2", []byte("pass"))
+	require.NoError(t, err)
+	// Place a copy of account 2's key in the destination under a different name.
+	require.NoError(t, account2.(e2wtypes.AccountLocker).Unlock(ctx, []byte("pass")))
+	key2, err := account2.(e2wtypes.AccountPrivateKeyProvider).PrivateKey(ctx)
+	require.NoError(t, err)
+	_, err = destination.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, "Copy", key2.Marshal(), []byte("pass"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		account           string
+		destinationWallet string
+		newName           string
+		passphrases       []string
+		err               string
+	}{
+		{
+			name:              "SameWallet",
+			account:           "Source/Account 1",
+			destinationWallet: "Source",
+			passphrases:       []string{"pass"},
+			err:               "destination wallet is the same as the source wallet; use account rename instead",
+		},
+		{
+			name:              "PublicKeyCollision",
+			account:           "Source/Account 2",
+			destinationWallet: "Destination",
+			newName:           "Account 3",
+			passphrases:       []string{"pass"},
+			err:               "account collides with an existing account in Destination: Account 3 (public key already present as Copy)",
+		},
+		{
+			name:              "NameCollision",
+			account:           "Source/Account 1",
+			destinationWallet: "Destination",
+			newName:           "Account 2",
+			passphrases:       []string{"pass"},
+			err:               "account collides with an existing account in Destination: Account 2 (name already in use)",
+		},
+		{
+			name:              "BadPassphrase",
+			account:           "Source/Account 1",
+			destinationWallet: "Destination",
+			passphrases:       []string{"wrong"},
+			err:               "failed to unlock account: failed to unlock account",
+		},
+		{
+			name:              "Good",
+			account:           "Source/Account 1",
+			destinationWallet: "Destination",
+			passphrases:       []string{"pass"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				account:           test.account,
+				destinationWallet: test.destinationWallet,
+				newName:           test.newName,
+				passphrases:       test.passphrases,
+				newPassphrase:     "new passphrase",
+			}
+			err := c.process(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	// Reopen the wallets to confirm the move.
+	source, err = e2wallet.OpenWallet("Source")
+	require.NoError(t, err)
+	_, err = source.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, "Account 1")
+	require.Error(t, err)
+	destination, err = e2wallet.OpenWallet("Destination")
+	require.NoError(t, err)
+	moved, err := destination.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, "Account 1")
+	require.NoError(t, err)
+	require.Equal(t, account1.PublicKey().Marshal(), moved.PublicKey().Marshal())
+	// The account is encrypted with the new passphrase.
+	require.Error(t, moved.(e2wtypes.AccountLocker).Unlock(ctx, []byte("pass")))
+	require.NoError(t, moved.(e2wtypes.AccountLocker).Unlock(ctx, []byte("new passphrase")))
+}
@@ -0,0 +1,101 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountpasswd
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	timeout time.Duration
+
+	// Input.
+	account       string
+	walletName    string
+	all           bool
+	passphrases   []string
+	newPassphrase string
+	workers       int
+
+	// Processing.
+	wallet   e2wtypes.Wallet
+	accounts []e2wtypes.Account
+
+	// Output.
+	changed []string
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:         viper.GetBool("quiet"),
+		verbose:       viper.GetBool("verbose"),
+		debug:         viper.GetBool("debug"),
+		account:       viper.GetString("account"),
+		walletName:    viper.GetString("wallet"),
+		all:           viper.GetBool("all"),
+		passphrases:   util.GetPassphrases(),
+		newPassphrase: viper.GetString("new-passphrase"),
+		workers:       viper.GetInt("workers"),
+	}
+
+	if viper.GetString("remote") != "" {
+		return nil, errors.New("passphrase change not available for remote wallets")
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	if c.all {
+		if c.account != "" {
+			return nil, errors.New("only one of account and all is allowed")
+		}
+		if c.walletName == "" {
+			return nil, errors.New("wallet is required with all")
+		}
+	} else if c.account == "" {
+		return nil, errors.New("account is required")
+	}
+
+	if len(c.passphrases) == 0 {
+		return nil, errors.New("passphrase is required")
+	}
+	if c.newPassphrase == "" {
+		return nil, errors.New("new-passphrase is required")
+	}
+	if !util.AcceptablePassphrase(c.newPassphrase) {
+		return nil, errors.New("supplied new passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
+	}
+
+	if c.workers < 0 {
+		return nil, errors.New("workers cannot be negative")
+	}
+	if c.workers == 0 {
+		c.workers = runtime.NumCPU()
+	}
+
+	return c, nil
+}
@@ -0,0 +1,165 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountpasswd
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		vars    map[string]interface{}
+		workers int
+		err     string
+	}{
+		{
+			name: "Remote",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"remote":         "remoteaddress",
+				"account":        "Test/Account 1",
+				"passphrase":     []string{"pass"},
+				"new-passphrase": "new passphrase",
+			},
+			err: "passphrase change not available for remote wallets",
+		},
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"account":        "Test/Account 1",
+				"passphrase":     []string{"pass"},
+				"new-passphrase": "new passphrase",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "AccountMissing",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"passphrase":     []string{"pass"},
+				"new-passphrase": "new passphrase",
+			},
+			err: "account is required",
+		},
+		{
+			name: "AllWithAccount",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"all":            true,
+				"account":        "Test/Account 1",
+				"wallet":         "Test",
+				"passphrase":     []string{"pass"},
+				"new-passphrase": "new passphrase",
+			},
+			err: "only one of account and all is allowed",
+		},
+		{
+			name: "AllWalletMissing",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"all":            true,
+				"passphrase":     []string{"pass"},
+				"new-passphrase": "new passphrase",
+			},
+			err: "wallet is required with all",
+		},
+		{
+			name: "PassphraseMissing",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"account":        "Test/Account 1",
+				"new-passphrase": "new passphrase",
+			},
+			err: "passphrase is required",
+		},
+		{
+			name: "NewPassphraseMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"account":    "Test/Account 1",
+				"passphrase": []string{"pass"},
+			},
+			err: "new-passphrase is required",
+		},
+		{
+			name: "NewPassphraseWeak",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"account":        "Test/Account 1",
+				"passphrase":     []string{"pass"},
+				"new-passphrase": "weak",
+			},
+			err: "supplied new passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag",
+		},
+		{
+			name: "WorkersNegative",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"account":                "Test/Account 1",
+				"passphrase":             []string{"pass"},
+				"new-passphrase":         "weak",
+				"allow-weak-passphrases": true,
+				"workers":                -1,
+			},
+			err: "workers cannot be negative",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"account":                "Test/Account 1",
+				"passphrase":             []string{"pass"},
+				"new-passphrase":         "weak",
+				"allow-weak-passphrases": true,
+			},
+			workers: runtime.NumCPU(),
+		},
+		{
+			name: "GoodAll",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"all":                    true,
+				"wallet":                 "Test",
+				"passphrase":             []string{"pass1", "pass2"},
+				"new-passphrase":         "weak",
+				"allow-weak-passphrases": true,
+				"workers":                4,
+			},
+			workers: 4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.workers, c.workers)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountpasswd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if !c.verbose {
+		return "", nil
+	}
+
+	if !c.all {
+		return fmt.Sprintf("Changed passphrase of %s/%s", c.wallet.Name(), c.changed[0]), nil
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Changed passphrase of %d accounts in %s", len(c.changed), c.wallet.Name()))
+	if c.debug {
+		for _, name := range c.changed {
+			builder.WriteString(fmt.Sprintf("\n  %s", name))
+		}
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,153 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountpasswd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.obtainAccounts(ctx); err != nil {
+		return err
+	}
+
+	store, err := util.WalletStore(c.wallet)
+	if err != nil {
+		return err
+	}
+
+	// Re-encrypt all accounts before storing any, so that a problem with any
+	// account leaves the wallet untouched.
+	cryptos, err := c.reencryptAccounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.changed = make([]string, 0, len(c.accounts))
+	for i, account := range c.accounts {
+		if err := util.ReencryptStoredAccount(store, c.wallet.ID(), account.ID(), cryptos[i]); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to update account %s after updating %d accounts", account.Name(), len(c.changed)))
+		}
+		c.changed = append(c.changed, account.Name())
+	}
+
+	return nil
+}
+
+// obtainAccounts obtains the wallet and the accounts whose passphrases are to
+// be changed.
+func (c *command) obtainAccounts(ctx context.Context) error {
+	if !c.all {
+		wallet, account, err := util.WalletAndAccountFromPath(ctx, c.account)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain account")
+		}
+		c.wallet = wallet
+		c.accounts = []e2wtypes.Account{account}
+
+		return nil
+	}
+
+	var err error
+	c.wallet, err = util.WalletFromPath(ctx, c.walletName)
+	if err != nil {
+		return errors.Wrap(err, "failed to access wallet")
+	}
+	c.accounts = make([]e2wtypes.Account, 0)
+	for account := range c.wallet.Accounts(ctx) {
+		c.accounts = append(c.accounts, account)
+	}
+	if len(c.accounts) == 0 {
+		return errors.New("wallet has no accounts")
+	}
+	sort.Slice(c.accounts, func(i int, j int) bool {
+		return c.accounts[i].Name() < c.accounts[j].Name()
+	})
+
+	return nil
+}
+
+// reencryptAccounts unlocks each account and encrypts its key with the new
+// passphrase, returning the new crypto for each account.  Both operations
+// are dominated by the key derivation function, so accounts are handled by
+// multiple workers in parallel.
+func (c *command) reencryptAccounts(ctx context.Context) ([]map[string]interface{}, error) {
+	progress := util.NewProgress("Re-encrypting accounts", len(c.accounts))
+	defer progress.Finish()
+
+	cryptos := make([]map[string]interface{}, len(c.accounts))
+	errs := make([]error, len(c.accounts))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < c.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				cryptos[index], errs[index] = c.reencryptAccount(ctx, c.accounts[index])
+				progress.Add(1)
+			}
+		}()
+	}
+	for i := range c.accounts {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to re-encrypt account %s", c.accounts[i].Name()))
+		}
+	}
+
+	return cryptos, nil
+}
+
+// reencryptAccount encrypts the key of a single account with the new
+// passphrase.
+func (c *command) reencryptAccount(ctx context.Context, account e2wtypes.Account) (map[string]interface{}, error) {
+	privateKeyProvider, isProvider := account.(e2wtypes.AccountPrivateKeyProvider)
+	if !isProvider {
+		return nil, errors.New("account does not provide its private key")
+	}
+
+	if _, err := util.UnlockAccountWithPassphrase(ctx, account, c.passphrases); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := util.LockAccount(ctx, account); err != nil {
+			util.Log.Trace().Err(err).Msg("Failed to lock account")
+		}
+	}()
+	key, err := privateKeyProvider.PrivateKey(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain private key")
+	}
+
+	crypto, err := keystorev4.New().Encrypt(key.Marshal(), c.newPassphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt key")
+	}
+
+	return crypto, nil
+}
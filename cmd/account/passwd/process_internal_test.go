@@ -0,0 +1,126 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountpasswd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// unlocksWith returns true if the named account in the wallet can be unlocked
+// with the passphrase.
+func unlocksWith(ctx context.Context, t *testing.T, name string, passphrase string) bool {
+	t.Helper()
+
+	wallet, err := e2wallet.OpenWallet("Test")
+	require.NoError(t, err)
+	account, err := wallet.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, name)
+	require.NoError(t, err)
+
+	return account.(e2wtypes.AccountLocker).Unlock(ctx, []byte(passphrase)) == nil
+}
+
+func TestProcess(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	store := filesystem.New(filesystem.WithLocation(t.TempDir()))
+	require.NoError(t, e2wallet.UseStore(store))
+	wallet, err := nd.CreateWallet(ctx, "Test", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	_, err = wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 1", []byte("pass"))
+	require.NoError(t, err)
+	_, err = wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 2", []byte("pass"))
+	require.NoError(t, err)
+	_, err = wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 3", []byte("other"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		account     string
+		walletName  string
+		all         bool
+		passphrases []string
+		changed     []string
+		err         string
+	}{
+		{
+			name:        "BadPassphrase",
+			account:     "Test/Account 1",
+			passphrases: []string{"wrong"},
+			err:         "failed to re-encrypt account Account 1: failed to unlock account",
+		},
+		{
+			name:        "AllMissingPassphrase",
+			walletName:  "Test",
+			all:         true,
+			passphrases: []string{"pass"},
+			err:         "failed to re-encrypt account Account 3: failed to unlock account",
+		},
+		{
+			name:        "Good",
+			account:     "Test/Account 1",
+			passphrases: []string{"pass"},
+			changed:     []string{"Account 1"},
+		},
+		{
+			name:        "All",
+			walletName:  "Test",
+			all:         true,
+			passphrases: []string{"pass", "other", "new passphrase"},
+			changed:     []string{"Account 1", "Account 2", "Account 3"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				account:       test.account,
+				walletName:    test.walletName,
+				all:           test.all,
+				passphrases:   test.passphrases,
+				newPassphrase: "new passphrase",
+				workers:       2,
+			}
+			err := c.process(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.changed, c.changed)
+			}
+		})
+
+		if test.name == "AllMissingPassphrase" {
+			// A failure leaves all accounts untouched.
+			require.True(t, unlocksWith(ctx, t, "Account 1", "pass"))
+			require.True(t, unlocksWith(ctx, t, "Account 2", "pass"))
+		}
+	}
+
+	for _, name := range []string{"Account 1", "Account 2", "Account 3"} {
+		require.False(t, unlocksWith(ctx, t, name, "pass"))
+		require.False(t, unlocksWith(ctx, t, name, "other"))
+		require.True(t, unlocksWith(ctx, t, name, "new passphrase"))
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountrename
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	timeout time.Duration
+
+	// Input.
+	account string
+	newName string
+
+	// Processing.
+	wallet e2wtypes.Wallet
+
+	// Output.
+	oldName string
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		account: viper.GetString("account"),
+		newName: viper.GetString("new-name"),
+	}
+
+	if viper.GetString("remote") != "" {
+		return nil, errors.New("account rename not available for remote wallets")
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	if c.account == "" {
+		return nil, errors.New("account is required")
+	}
+	if c.newName == "" {
+		return nil, errors.New("new-name is required")
+	}
+	if err := util.CheckAccountName(c.newName); err != nil {
+		return nil, errors.Wrap(err, "invalid new-name")
+	}
+
+	return c, nil
+}
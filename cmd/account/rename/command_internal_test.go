@@ -0,0 +1,98 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountrename
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "Remote",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"remote":   "remoteaddress",
+				"account":  "Test wallet/Account 1",
+				"new-name": "Account 2",
+			},
+			err: "account rename not available for remote wallets",
+		},
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"account":  "Test wallet/Account 1",
+				"new-name": "Account 2",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "AccountMissing",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"new-name": "Account 2",
+			},
+			err: "account is required",
+		},
+		{
+			name: "NewNameMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"account": "Test wallet/Account 1",
+			},
+			err: "new-name is required",
+		},
+		{
+			name: "NewNameInvalid",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"account":  "Test wallet/Account 1",
+				"new-name": "_Account 2",
+			},
+			err: "invalid new-name: account name cannot start with '_'",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"account":  "Test wallet/Account 1",
+				"new-name": "Account 2",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
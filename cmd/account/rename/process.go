@@ -0,0 +1,54 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountrename
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	wallet, account, err := util.WalletAndAccountFromPath(ctx, c.account)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain account")
+	}
+	c.wallet = wallet
+	c.oldName = account.Name()
+
+	if c.newName == c.oldName {
+		return errors.New("new name is the same as the existing name")
+	}
+	if accountByNameProvider, isProvider := wallet.(e2wtypes.WalletAccountByNameProvider); isProvider {
+		if _, err := accountByNameProvider.AccountByName(ctx, c.newName); err == nil {
+			return fmt.Errorf("account %q already exists in wallet %s", c.newName, wallet.Name())
+		}
+	}
+
+	store, err := util.WalletStore(wallet)
+	if err != nil {
+		return err
+	}
+	if err := util.RenameStoredAccount(store, wallet.ID(), account.ID(), c.newName); err != nil {
+		return errors.Wrap(err, "failed to rename account")
+	}
+	if err := util.RebuildAccountsIndex(store, wallet.ID()); err != nil {
+		return errors.Wrap(err, "failed to rebuild wallet index")
+	}
+
+	return nil
+}
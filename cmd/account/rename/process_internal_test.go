@@ -0,0 +1,98 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountrename
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	store := filesystem.New(filesystem.WithLocation(t.TempDir()))
+	require.NoError(t, e2wallet.UseStore(store))
+	wallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 1", []byte("pass"))
+	require.NoError(t, err)
+	_, err = wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 2", []byte("pass"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		account string
+		newName string
+		err     string
+	}{
+		{
+			name:    "AccountUnknown",
+			account: "Test wallet/Unknown",
+			newName: "Account 3",
+			err:     "failed to obtain account",
+		},
+		{
+			name:    "SameName",
+			account: "Test wallet/Account 1",
+			newName: "Account 1",
+			err:     "new name is the same as the existing name",
+		},
+		{
+			name:    "Collision",
+			account: "Test wallet/Account 1",
+			newName: "Account 2",
+			err:     "account \"Account 2\" already exists in wallet Test wallet",
+		},
+		{
+			name:    "Good",
+			account: "Test wallet/Account 1",
+			newName: "Account 3",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				account: test.account,
+				newName: test.newName,
+			}
+			err := c.process(ctx)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	// Reopen the wallet to confirm the rename.
+	wallet, err = e2wallet.OpenWallet("Test wallet")
+	require.NoError(t, err)
+	renamed, err := wallet.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, "Account 3")
+	require.NoError(t, err)
+	require.Equal(t, account.ID(), renamed.ID())
+	require.Equal(t, account.PublicKey().Marshal(), renamed.PublicKey().Marshal())
+	_, err = wallet.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, "Account 1")
+	require.Error(t, err)
+}
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	accountcreate "github.com/wealdtech/ethdo/cmd/account/create"
@@ -38,7 +36,9 @@ In quiet mode this will return 0 if the account is created successfully, otherwi
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
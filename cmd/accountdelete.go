@@ -0,0 +1,71 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountdelete "github.com/wealdtech/ethdo/cmd/account/delete"
+)
+
+var accountDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete accounts",
+	Long: `Delete accounts whose validators have exited and been withdrawn.  For example:
+
+    ethdo account delete --accounts="Validators/*" --confirm-pubkeys-file=exited.txt
+
+Every account matched by the pattern must have its public key listed in the confirm pubkeys file, and must either be unknown to the chain or belong to a validator that has been withdrawn.  If any account fails these checks no accounts are deleted.
+
+Deleted accounts are moved to a recycle bin alongside the wallet store, and are permanently removed once the retention period has passed.
+
+In quiet mode this will return 0 if the accounts have been deleted, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := accountdelete.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountCmd.AddCommand(accountDeleteCmd)
+	accountFlags(accountDeleteCmd)
+	accountDeleteCmd.Flags().String("accounts", "", `Accounts to delete, in the form "wallet/pattern" where pattern can contain wildcards`)
+	accountDeleteCmd.Flags().String("confirm-pubkeys-file", "", "File containing the public keys of the accounts to delete, one per line")
+	accountDeleteCmd.Flags().Duration("retention", 7*24*time.Hour, "Period for which deleted accounts are held in the recycle bin before permanent removal")
+}
+
+func accountDeleteBindings() {
+	if err := viper.BindPFlag("accounts", accountDeleteCmd.Flags().Lookup("accounts")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("confirm-pubkeys-file", accountDeleteCmd.Flags().Lookup("confirm-pubkeys-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("retention", accountDeleteCmd.Flags().Lookup("retention")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountexport "github.com/wealdtech/ethdo/cmd/account/export"
+)
+
+var accountExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export an account",
+	Long: `Export an account as an encrypted keystore.  For example:
+
+    ethdo account export --account="Personal wallet/Operations" --passphrase="my account passphrase" --export-passphrase="my export passphrase" --file=keystore.json
+
+The export can instead be protected with Shamir secret sharing, in which case the shares required to decrypt it are output.  For example:
+
+    ethdo account export --account="Personal wallet/Operations" --passphrase="my account passphrase" --shards=5 --threshold=3 --file=backup.json
+
+A mnemonic can be exported with Shamir secret sharing in the same way by supplying --mnemonic in place of --account.
+
+In quiet mode this will return 0 if the account is exported successfully, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := accountexport.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountCmd.AddCommand(accountExportCmd)
+	accountFlags(accountExportCmd)
+	accountExportCmd.Flags().String("export-passphrase", "", "Passphrase with which to encrypt the export")
+	accountExportCmd.Flags().Uint32("shards", 0, "Number of Shamir shares to split the export in to")
+	accountExportCmd.Flags().Uint32("threshold", 0, "Number of Shamir shares required to recover the export")
+	accountExportCmd.Flags().String("file", "", "Name of the file that stores the export")
+}
+
+func accountExportBindings() {
+	if err := viper.BindPFlag("export-passphrase", accountExportCmd.Flags().Lookup("export-passphrase")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("shards", accountExportCmd.Flags().Lookup("shards")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("threshold", accountExportCmd.Flags().Lookup("threshold")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("file", accountExportCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	accountimport "github.com/wealdtech/ethdo/cmd/account/import"
@@ -28,6 +26,12 @@ var accountImportCmd = &cobra.Command{
 
     ethdo account import --account="primary/testing" --key="0x..." --passphrase="my secret"
 
+An export created with "account export --shards" is imported by supplying the export as the keystore along with the required shares.  For example:
+
+    ethdo account import --account="primary/testing" --keystore=backup.json --shards="1234 2345 3456" --passphrase="my secret"
+
+If the export contains a mnemonic then --path must also be supplied to select the account to import.
+
 In quiet mode this will return 0 if the account is imported successfully, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := accountimport.Run(cmd)
@@ -38,7 +42,9 @@ In quiet mode this will return 0 if the account is imported successfully, otherw
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -50,6 +56,7 @@ func init() {
 	accountImportCmd.Flags().String("key", "", "Private key of the account to import (0x...)")
 	accountImportCmd.Flags().String("keystore", "", "Keystore, or path to keystore ")
 	accountImportCmd.Flags().String("keystore-passphrase", "", "Passphrase of keystore")
+	accountImportCmd.Flags().String("shards", "", "Shares required to decrypt a sharded export, separated with spaces")
 }
 
 func accountImportBindings() {
@@ -62,4 +69,7 @@ func accountImportBindings() {
 	if err := viper.BindPFlag("keystore-passphrase", accountImportCmd.Flags().Lookup("keystore-passphrase")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("shards", accountImportCmd.Flags().Lookup("shards")); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,79 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountinterop "github.com/wealdtech/ethdo/cmd/account/interop"
+)
+
+var accountInteropCmd = &cobra.Command{
+	Use:   "interop",
+	Short: "Generate deterministic interop validator keys",
+	Long: `Generate the deterministic interop validator keys used by client testnets and devnets, along with matching deposit data.  For example:
+
+    ethdo account interop --account="Interop/Validator" --count=64 --passphrase="my secret" --fork-version=0x10000038
+
+Keys are stored in a wallet as accounts named with the account name followed by the key index, or alternatively written as EIP-2335 keystores to a directory with --keystore-dir.  These keys are publicly known, and must never be used on a network with value.
+
+In quiet mode this will return 0 if the keys are generated successfully, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := accountinterop.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountCmd.AddCommand(accountInteropCmd)
+	accountFlags(accountInteropCmd)
+	accountInteropCmd.Flags().Uint64("count", 0, "Number of keys to generate")
+	accountInteropCmd.Flags().Uint64("start-index", 0, "Index of the first key to generate")
+	accountInteropCmd.Flags().String("keystore-dir", "", "Directory in which to write keystores (instead of storing keys in a wallet)")
+	accountInteropCmd.Flags().String("withdrawal-address", "", "Execution address to which to direct withdrawals (defaults to BLS withdrawal credentials for each key)")
+	accountInteropCmd.Flags().String("fork-version", "", "Genesis fork version of the chain, for deposit data (defaults to mainnet)")
+	accountInteropCmd.Flags().String("deposit-data", "", "Name of the file to which to write deposit data (defaults to standard output)")
+}
+
+func accountInteropBindings() {
+	if err := viper.BindPFlag("count", accountInteropCmd.Flags().Lookup("count")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("start-index", accountInteropCmd.Flags().Lookup("start-index")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("keystore-dir", accountInteropCmd.Flags().Lookup("keystore-dir")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawal-address", accountInteropCmd.Flags().Lookup("withdrawal-address")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("fork-version", accountInteropCmd.Flags().Lookup("fork-version")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("deposit-data", accountInteropCmd.Flags().Lookup("deposit-data")); err != nil {
+		panic(err)
+	}
+}
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	accountkey "github.com/wealdtech/ethdo/cmd/account/key"
@@ -39,7 +37,9 @@ In quiet mode this will return 0 if the key can be obtained, otherwise 1.`,
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountmove "github.com/wealdtech/ethdo/cmd/account/move"
+)
+
+var accountMoveCmd = &cobra.Command{
+	Use:   "move",
+	Short: "Move an account to another wallet",
+	Long: `Move an account to another wallet.  For example:
+
+    ethdo account move --account="Primary/Validator 1" --destination-wallet="Validators" --passphrase=secret
+
+The account is added to the destination wallet, encrypted with the new passphrase if supplied or its existing passphrase if not, and then removed from its original wallet.  The destination wallet must not already contain an account with the same name or public key.
+
+In quiet mode this will return 0 if the account has been moved, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := accountmove.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountCmd.AddCommand(accountMoveCmd)
+	accountFlags(accountMoveCmd)
+	accountMoveCmd.Flags().String("destination-wallet", "", "Wallet to which to move the account")
+	accountMoveCmd.Flags().String("new-name", "", "New name for the account in the destination wallet (defaults to its existing name)")
+	accountMoveCmd.Flags().String("new-passphrase", "", "Passphrase with which to encrypt the account in the destination wallet (defaults to its existing passphrase)")
+}
+
+func accountMoveBindings() {
+	if err := viper.BindPFlag("destination-wallet", accountMoveCmd.Flags().Lookup("destination-wallet")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("new-name", accountMoveCmd.Flags().Lookup("new-name")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("new-passphrase", accountMoveCmd.Flags().Lookup("new-passphrase")); err != nil {
+		panic(err)
+	}
+}
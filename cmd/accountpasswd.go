@@ -0,0 +1,70 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountpasswd "github.com/wealdtech/ethdo/cmd/account/passwd"
+)
+
+var accountPasswdCmd = &cobra.Command{
+	Use:   "passwd",
+	Short: "Change the passphrase of accounts",
+	Long: `Change the passphrase of an account, or of all accounts in a wallet.  For example:
+
+    ethdo account passwd --account="Validators/1" --passphrase=secret --new-passphrase=newsecret
+
+    ethdo account passwd --all --wallet="Validators" --passphrase=secret --new-passphrase=newsecret
+
+Multiple existing passphrases can be supplied, in which case each account is unlocked with whichever of them matches.  All accounts are re-encrypted before any are updated, using multiple workers in parallel; if any account cannot be unlocked no accounts are changed.  Each account is replaced atomically, so an interruption leaves it with either its old or new passphrase.
+
+In quiet mode this will return 0 if the passphrases have been changed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := accountpasswd.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountCmd.AddCommand(accountPasswdCmd)
+	accountFlags(accountPasswdCmd)
+	walletFlags(accountPasswdCmd)
+	accountPasswdCmd.Flags().String("new-passphrase", "", "Passphrase with which to encrypt the accounts")
+	accountPasswdCmd.Flags().Bool("all", false, "Change the passphrase of all accounts in the wallet")
+	accountPasswdCmd.Flags().Int("workers", 0, "Number of accounts to re-encrypt in parallel (defaults to the number of CPUs)")
+}
+
+func accountPasswdBindings() {
+	if err := viper.BindPFlag("new-passphrase", accountPasswdCmd.Flags().Lookup("new-passphrase")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("all", accountPasswdCmd.Flags().Lookup("all")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("workers", accountPasswdCmd.Flags().Lookup("workers")); err != nil {
+		panic(err)
+	}
+}
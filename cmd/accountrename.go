@@ -0,0 +1,59 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountrename "github.com/wealdtech/ethdo/cmd/account/rename"
+)
+
+var accountRenameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Rename an account",
+	Long: `Rename an account within its wallet.  For example:
+
+    ethdo account rename --account="Validators/1" --new-name="Validator 1"
+
+The account keeps its identifier and key; only its name is changed.  The new name must not already be in use in the wallet.
+
+In quiet mode this will return 0 if the account has been renamed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := accountrename.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountCmd.AddCommand(accountRenameCmd)
+	accountFlags(accountRenameCmd)
+	accountRenameCmd.Flags().String("new-name", "", "New name for the account")
+}
+
+func accountRenameBindings() {
+	if err := viper.BindPFlag("new-name", accountRenameCmd.Flags().Lookup("new-name")); err != nil {
+		panic(err)
+	}
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/auditlog"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
@@ -55,6 +56,7 @@ In quiet mode this will return 0 if the account is unlocked, otherwise 1.`,
 		}
 
 		assert(unlocked, "Failed to unlock account")
+		errCheck(auditlog.Record(auditlog.ActionUnlock, account, nil), "Failed to record unlock")
 		os.Exit(_exitSuccess)
 	},
 }
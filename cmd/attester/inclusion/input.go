@@ -15,7 +15,6 @@ package attesterinclusion
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -32,6 +31,8 @@ type dataIn struct {
 	quiet   bool
 	verbose bool
 	debug   bool
+	// Output.
+	outputFormat string
 	// Chain information.
 	slotsPerEpoch uint64
 	// Operation.
@@ -53,6 +54,7 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
+	data.outputFormat = viper.GetString("output")
 
 	// Account.
 	data.account = viper.GetString("account")
@@ -94,9 +96,7 @@ func input(ctx context.Context) (*dataIn, error) {
 		}
 	}
 	data.epoch = spec.Epoch(epoch)
-	if data.debug {
-		fmt.Printf("Epoch is %d\n", data.epoch)
-	}
+	util.Log.Debug().Uint64("epoch", uint64(data.epoch)).Msg("Obtained epoch")
 
 	return data, nil
 }
@@ -20,12 +20,14 @@ import (
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type dataOut struct {
 	debug            bool
 	quiet            bool
 	verbose          bool
+	outputFormat     string
 	attestation      *phase0.Attestation
 	slot             phase0.Slot
 	attestationIndex uint64
@@ -38,12 +40,28 @@ type dataOut struct {
 	targetTimely     bool
 }
 
+type inclusionResult struct {
+	Found            bool        `json:"found"`
+	Slot             phase0.Slot `json:"slot"`
+	AttestationIndex uint64      `json:"attestation_index"`
+	InclusionDelay   phase0.Slot `json:"inclusion_delay"`
+	HeadCorrect      bool        `json:"head_correct"`
+	HeadTimely       bool        `json:"head_timely"`
+	SourceTimely     bool        `json:"source_timely"`
+	TargetCorrect    bool        `json:"target_correct"`
+	TargetTimely     bool        `json:"target_timely"`
+}
+
 func output(ctx context.Context, data *dataOut) (string, error) {
 	buf := strings.Builder{}
 	if data == nil {
 		return buf.String(), errors.New("no data")
 	}
 
+	if data.outputFormat != "" && !data.quiet {
+		return util.RenderOutput(data.outputFormat, data.result())
+	}
+
 	if !data.quiet {
 		if data.found {
 			buf.WriteString("Attestation included in block ")
@@ -90,3 +108,41 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 	}
 	return buf.String(), nil
 }
+
+func (d *dataOut) result() *inclusionResult {
+	return &inclusionResult{
+		Found:            d.found,
+		Slot:             d.slot,
+		AttestationIndex: d.attestationIndex,
+		InclusionDelay:   d.inclusionDelay,
+		HeadCorrect:      d.headCorrect,
+		HeadTimely:       d.headTimely,
+		SourceTimely:     d.sourceTimely,
+		TargetCorrect:    d.targetCorrect,
+		TargetTimely:     d.targetTimely,
+	}
+}
+
+// TableHeaders provides the headers for table output.
+func (r *inclusionResult) TableHeaders() []string {
+	return []string{"Found", "Slot", "Index", "Delay", "Head correct", "Head timely", "Source timely", "Target correct", "Target timely"}
+}
+
+// TableRows provides the rows for table output.
+func (r *inclusionResult) TableRows() [][]string {
+	if !r.Found {
+		return [][]string{{"false", "", "", "", "", "", "", "", ""}}
+	}
+
+	return [][]string{{
+		"true",
+		fmt.Sprintf("%d", r.Slot),
+		fmt.Sprintf("%d", r.AttestationIndex),
+		fmt.Sprintf("%d", r.InclusionDelay),
+		fmt.Sprintf("%t", r.HeadCorrect),
+		fmt.Sprintf("%t", r.HeadTimely),
+		fmt.Sprintf("%t", r.SourceTimely),
+		fmt.Sprintf("%t", r.TargetCorrect),
+		fmt.Sprintf("%t", r.TargetTimely),
+	}}
+}
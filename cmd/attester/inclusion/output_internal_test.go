@@ -68,6 +68,37 @@ Source timely: ✕
 Target correct: ✓
 Target timely: ✓`,
 		},
+		{
+			name: "JSON",
+			dataOut: &dataOut{
+				outputFormat:     "json",
+				found:            true,
+				slot:             123,
+				attestationIndex: 456,
+				inclusionDelay:   7,
+				headCorrect:      true,
+				sourceTimely:     false,
+				targetCorrect:    true,
+				targetTimely:     true,
+			},
+			res: `{"found":true,"slot":123,"attestation_index":456,"inclusion_delay":7,"head_correct":true,"head_timely":false,"source_timely":false,"target_correct":true,"target_timely":true}`,
+		},
+		{
+			name: "Table",
+			dataOut: &dataOut{
+				outputFormat:     "table",
+				found:            true,
+				slot:             123,
+				attestationIndex: 456,
+				inclusionDelay:   7,
+				headCorrect:      true,
+				sourceTimely:     false,
+				targetCorrect:    true,
+				targetTimely:     true,
+			},
+			res: `Found  Slot  Index  Delay  Head correct  Head timely  Source timely  Target correct  Target timely
+true   123   456    7      true          false        false          true            true`,
+		},
 	}
 
 	for _, test := range tests {
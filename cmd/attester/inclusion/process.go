@@ -59,18 +59,17 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	}
 
 	results := &dataOut{
-		debug:   data.debug,
-		quiet:   data.quiet,
-		verbose: data.verbose,
+		debug:        data.debug,
+		quiet:        data.quiet,
+		verbose:      data.verbose,
+		outputFormat: data.outputFormat,
 	}
 
 	duty, err := duty(ctx, data.eth2Client, validator, data.epoch, data.slotsPerEpoch)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain duty for validator")
 	}
-	if data.debug {
-		fmt.Printf("Duty is %s\n", duty.String())
-	}
+	util.Log.Debug().Stringer("duty", duty).Msg("Obtained duty")
 
 	startSlot := duty.Slot + 1
 	endSlot := startSlot + 32
@@ -89,9 +88,7 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		if blockSlot != slot {
 			continue
 		}
-		if data.debug {
-			fmt.Printf("Fetched block for slot %d\n", slot)
-		}
+		util.Log.Debug().Uint64("slot", uint64(slot)).Msg("Fetched block")
 		attestations, err := signedBlock.Attestations()
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to obtain block attestations")
@@ -103,7 +100,7 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 
 				headCorrect := false
 				targetCorrect := false
-				if data.verbose {
+				if data.verbose || data.outputFormat != "" {
 					headCorrect, err = calcHeadCorrect(ctx, data, attestation)
 					if err != nil {
 						return nil, errors.Wrap(err, "failed to obtain head correct result")
@@ -123,9 +120,7 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 				results.targetTimely = targetCorrect && results.inclusionDelay <= 32
 				results.headCorrect = headCorrect
 				results.headTimely = headCorrect && results.inclusionDelay == 1
-				if data.debug {
-					fmt.Printf("Attestation is %s\n", attestation.String())
-				}
+				util.Log.Debug().Stringer("attestation", attestation).Msg("Found attestation")
 				return results, nil
 			}
 		}
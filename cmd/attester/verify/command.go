@@ -0,0 +1,116 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterverify
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	attestation        string
+	sourceEpoch        *phase0.Epoch
+	targetEpoch        *phase0.Epoch
+	signingRoot        *phase0.Root
+	pubKey             *phase0.BLSPubKey
+	slashingProtection string
+
+	// Output.
+	result *result
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	c.slashingProtection = viper.GetString("slashing-protection")
+	if c.slashingProtection == "" {
+		return nil, errors.New("slashing-protection is required")
+	}
+
+	var err error
+	c.attestation = viper.GetString("attestation")
+	c.sourceEpoch, err = parseEpoch(viper.GetString("source-epoch"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid source epoch")
+	}
+	c.targetEpoch, err = parseEpoch(viper.GetString("target-epoch"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid target epoch")
+	}
+	switch {
+	case c.attestation != "" && (c.sourceEpoch != nil || c.targetEpoch != nil):
+		return nil, errors.New("only one of attestation and source/target epochs is allowed")
+	case c.attestation == "" && (c.sourceEpoch == nil || c.targetEpoch == nil):
+		return nil, errors.New("attestation or source and target epochs are required")
+	case c.sourceEpoch != nil && *c.sourceEpoch > *c.targetEpoch:
+		return nil, errors.New("source epoch must not be after target epoch")
+	}
+
+	if viper.GetString("signing-root") != "" {
+		data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("signing-root"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode signing root")
+		}
+		if len(data) != phase0.RootLength {
+			return nil, errors.New("signing root has incorrect length")
+		}
+		c.signingRoot = &phase0.Root{}
+		copy(c.signingRoot[:], data)
+	}
+
+	if viper.GetString("pubkey") != "" {
+		data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("pubkey"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode public key")
+		}
+		if len(data) != phase0.PublicKeyLength {
+			return nil, errors.New("public key has incorrect length")
+		}
+		c.pubKey = &phase0.BLSPubKey{}
+		copy(c.pubKey[:], data)
+	}
+
+	return c, nil
+}
+
+// parseEpoch parses an optional epoch.
+func parseEpoch(input string) (*phase0.Epoch, error) {
+	if input == "" {
+		return nil, nil
+	}
+	val, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	epoch := phase0.Epoch(val)
+
+	return &epoch, nil
+}
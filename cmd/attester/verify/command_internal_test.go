@@ -0,0 +1,146 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterverify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "SlashingProtectionMissing",
+			vars: map[string]interface{}{
+				"source-epoch": "1",
+				"target-epoch": "2",
+			},
+			err: "slashing-protection is required",
+		},
+		{
+			name: "SourceEpochInvalid",
+			vars: map[string]interface{}{
+				"slashing-protection": "history.json",
+				"source-epoch":        "invalid",
+				"target-epoch":        "2",
+			},
+			err: "invalid source epoch: strconv.ParseUint: parsing \"invalid\": invalid syntax",
+		},
+		{
+			name: "TargetEpochInvalid",
+			vars: map[string]interface{}{
+				"slashing-protection": "history.json",
+				"source-epoch":        "1",
+				"target-epoch":        "invalid",
+			},
+			err: "invalid target epoch: strconv.ParseUint: parsing \"invalid\": invalid syntax",
+		},
+		{
+			name: "AttestationAndEpochs",
+			vars: map[string]interface{}{
+				"slashing-protection": "history.json",
+				"attestation":         "attestation.json",
+				"source-epoch":        "1",
+			},
+			err: "only one of attestation and source/target epochs is allowed",
+		},
+		{
+			name: "TargetEpochMissing",
+			vars: map[string]interface{}{
+				"slashing-protection": "history.json",
+				"source-epoch":        "1",
+			},
+			err: "attestation or source and target epochs are required",
+		},
+		{
+			name: "SourceAfterTarget",
+			vars: map[string]interface{}{
+				"slashing-protection": "history.json",
+				"source-epoch":        "3",
+				"target-epoch":        "2",
+			},
+			err: "source epoch must not be after target epoch",
+		},
+		{
+			name: "SigningRootInvalid",
+			vars: map[string]interface{}{
+				"slashing-protection": "history.json",
+				"source-epoch":        "1",
+				"target-epoch":        "2",
+				"signing-root":        "invalid",
+			},
+			err: "failed to decode signing root: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "SigningRootShort",
+			vars: map[string]interface{}{
+				"slashing-protection": "history.json",
+				"source-epoch":        "1",
+				"target-epoch":        "2",
+				"signing-root":        "0x0102",
+			},
+			err: "signing root has incorrect length",
+		},
+		{
+			name: "PubKeyShort",
+			vars: map[string]interface{}{
+				"slashing-protection": "history.json",
+				"source-epoch":        "1",
+				"target-epoch":        "2",
+				"pubkey":              "0x0102",
+			},
+			err: "public key has incorrect length",
+		},
+		{
+			name: "GoodEpochs",
+			vars: map[string]interface{}{
+				"slashing-protection": "history.json",
+				"source-epoch":        "1",
+				"target-epoch":        "2",
+				"signing-root":        "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20",
+			},
+		},
+		{
+			name: "GoodAttestation",
+			vars: map[string]interface{}{
+				"slashing-protection": "history.json",
+				"attestation":         "attestation.json",
+				"pubkey":              "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
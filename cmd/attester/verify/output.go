@@ -0,0 +1,66 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterverify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Validator: %s\n", c.result.PubKey))
+	}
+	builder.WriteString(fmt.Sprintf("Attestation %d->%d ", c.result.SourceEpoch, c.result.TargetEpoch))
+	if c.result.Slashable {
+		builder.WriteString("is slashable")
+	} else {
+		builder.WriteString("is not slashable")
+	}
+	for _, conflict := range c.result.Conflicts {
+		builder.WriteString(fmt.Sprintf("\n  %s with signed attestation %d->%d", conflict.Reason, conflict.SourceEpoch, conflict.TargetEpoch))
+		if c.verbose && conflict.SigningRoot != "" {
+			builder.WriteString(fmt.Sprintf(" (signing root %s)", conflict.SigningRoot))
+		}
+	}
+	if c.result.BelowWatermark {
+		builder.WriteString("\nWarning: attestation is below the lowest epochs of the signing history, so validator clients will refuse to sign it")
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterverify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(_ context.Context) error {
+	data, err := os.ReadFile(c.slashingProtection)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to read %s", c.slashingProtection))
+	}
+	slashingProtection := &util.SlashingProtection{}
+	if err := json.Unmarshal(data, slashingProtection); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("invalid slashing protection data in %s", c.slashingProtection))
+	}
+	history, err := selectHistory(slashingProtection, c.pubKey)
+	if err != nil {
+		return err
+	}
+	util.Log.Debug().Int("attestations", len(history.SignedAttestations)).Msg("Obtained signing history")
+
+	if c.attestation != "" {
+		var input []byte
+		if strings.HasPrefix(strings.TrimSpace(c.attestation), "{") {
+			input = []byte(c.attestation)
+		} else {
+			input, err = os.ReadFile(c.attestation)
+			if err != nil {
+				return errors.Wrap(err, "failed to read attestation file")
+			}
+		}
+		attestationData, err := parseAttestationData(input)
+		if err != nil {
+			return err
+		}
+		c.sourceEpoch = &attestationData.Source.Epoch
+		c.targetEpoch = &attestationData.Target.Epoch
+	}
+
+	c.result = verifyAttestation(history, *c.sourceEpoch, *c.targetEpoch, c.signingRoot)
+
+	return nil
+}
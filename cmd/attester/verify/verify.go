@@ -0,0 +1,178 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterverify
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+const (
+	reasonDoubleVote     = "double vote"
+	reasonSurroundVote   = "surround vote"
+	reasonSurroundedVote = "surrounded vote"
+)
+
+// result is the result of checking an attestation against a signing history.
+type result struct {
+	PubKey      string       `json:"pubkey"`
+	SourceEpoch phase0.Epoch `json:"source_epoch"`
+	TargetEpoch phase0.Epoch `json:"target_epoch"`
+	Slashable   bool         `json:"slashable"`
+	// BelowWatermark is true if the attestation is at or below the lowest
+	// epochs in the signing history.  Such an attestation cannot be checked
+	// fully against a pruned history, so validator clients refuse to sign it.
+	BelowWatermark bool        `json:"below_watermark"`
+	Conflicts      []*conflict `json:"conflicts,omitempty"`
+}
+
+// conflict is a previously signed attestation that would make the attestation slashable.
+type conflict struct {
+	Reason      string       `json:"reason"`
+	SourceEpoch phase0.Epoch `json:"source_epoch"`
+	TargetEpoch phase0.Epoch `json:"target_epoch"`
+	SigningRoot string       `json:"signing_root,omitempty"`
+}
+
+// verifyAttestation checks if signing an attestation with the given source and
+// target epochs would be slashable given the validator's signing history.
+// The signing root is optional; if it is present then re-signing an attestation
+// with the same signing root as one in the history is not considered slashable.
+func verifyAttestation(history *util.SlashingProtectionData,
+	sourceEpoch phase0.Epoch,
+	targetEpoch phase0.Epoch,
+	signingRoot *phase0.Root,
+) *result {
+	res := &result{
+		PubKey:      fmt.Sprintf("%#x", history.PubKey),
+		SourceEpoch: sourceEpoch,
+		TargetEpoch: targetEpoch,
+		Conflicts:   make([]*conflict, 0),
+	}
+
+	for _, attestation := range history.SignedAttestations {
+		reason := ""
+		switch {
+		case attestation.TargetEpoch == targetEpoch:
+			if signingRoot != nil && attestation.SigningRoot != nil && *signingRoot == *attestation.SigningRoot {
+				// Same attestation.
+				continue
+			}
+			reason = reasonDoubleVote
+		case sourceEpoch < attestation.SourceEpoch && attestation.TargetEpoch < targetEpoch:
+			reason = reasonSurroundVote
+		case attestation.SourceEpoch < sourceEpoch && targetEpoch < attestation.TargetEpoch:
+			reason = reasonSurroundedVote
+		}
+		if reason != "" {
+			res.Conflicts = append(res.Conflicts, &conflict{
+				Reason:      reason,
+				SourceEpoch: attestation.SourceEpoch,
+				TargetEpoch: attestation.TargetEpoch,
+			})
+			if attestation.SigningRoot != nil {
+				res.Conflicts[len(res.Conflicts)-1].SigningRoot = fmt.Sprintf("%#x", *attestation.SigningRoot)
+			}
+		}
+	}
+	res.Slashable = len(res.Conflicts) > 0
+
+	if len(history.SignedAttestations) > 0 {
+		res.BelowWatermark = sourceEpoch < lowestSource(history) || targetEpoch <= lowestTarget(history)
+	}
+
+	return res
+}
+
+// lowestSource returns the lowest source epoch in the signing history.
+func lowestSource(history *util.SlashingProtectionData) phase0.Epoch {
+	lowest := history.SignedAttestations[0].SourceEpoch
+	for _, attestation := range history.SignedAttestations[1:] {
+		if attestation.SourceEpoch < lowest {
+			lowest = attestation.SourceEpoch
+		}
+	}
+
+	return lowest
+}
+
+// lowestTarget returns the lowest target epoch in the signing history.
+func lowestTarget(history *util.SlashingProtectionData) phase0.Epoch {
+	lowest := history.SignedAttestations[0].TargetEpoch
+	for _, attestation := range history.SignedAttestations[1:] {
+		if attestation.TargetEpoch < lowest {
+			lowest = attestation.TargetEpoch
+		}
+	}
+
+	return lowest
+}
+
+// parseAttestationData parses attestation data, either on its own or as part
+// of an attestation.
+func parseAttestationData(input []byte) (*phase0.AttestationData, error) {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(input, &probe); err != nil {
+		return nil, errors.Wrap(err, "invalid JSON")
+	}
+	if data, exists := probe["data"]; exists {
+		input = data
+	}
+
+	data := &phase0.AttestationData{}
+	if err := json.Unmarshal(input, data); err != nil {
+		return nil, errors.Wrap(err, "invalid attestation data")
+	}
+	if data.Source.Epoch > data.Target.Epoch {
+		return nil, errors.New("source epoch must not be after target epoch")
+	}
+
+	return data, nil
+}
+
+// selectHistory selects the signing history for a validator from slashing
+// protection data.  If no public key is supplied then the data must contain a
+// single validator.
+func selectHistory(slashingProtection *util.SlashingProtection, pubKey *phase0.BLSPubKey) (*util.SlashingProtectionData, error) {
+	if pubKey == nil {
+		if len(slashingProtection.Data) != 1 {
+			return nil, fmt.Errorf("slashing protection data contains %d validators; pubkey is required", len(slashingProtection.Data))
+		}
+		return slashingProtection.Data[0], nil
+	}
+
+	// The history for a validator may be spread over multiple entries.
+	res := &util.SlashingProtectionData{
+		PubKey:             *pubKey,
+		SignedBlocks:       make([]*util.SlashingProtectionBlock, 0),
+		SignedAttestations: make([]*util.SlashingProtectionAttestation, 0),
+	}
+	found := false
+	for _, data := range slashingProtection.Data {
+		if data.PubKey == *pubKey {
+			found = true
+			res.SignedBlocks = append(res.SignedBlocks, data.SignedBlocks...)
+			res.SignedAttestations = append(res.SignedAttestations, data.SignedAttestations...)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no slashing protection data for %#x", *pubKey)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,251 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterverify
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestVerifyAttestation(t *testing.T) {
+	root1 := phase0.Root{0x01}
+	root2 := phase0.Root{0x02}
+	history := &util.SlashingProtectionData{
+		PubKey: phase0.BLSPubKey{0x01},
+		SignedAttestations: []*util.SlashingProtectionAttestation{
+			{SourceEpoch: 10, TargetEpoch: 11, SigningRoot: &root1},
+			{SourceEpoch: 11, TargetEpoch: 12},
+			{SourceEpoch: 15, TargetEpoch: 20, SigningRoot: &root2},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		history        *util.SlashingProtectionData
+		source         phase0.Epoch
+		target         phase0.Epoch
+		signingRoot    *phase0.Root
+		slashable      bool
+		belowWatermark bool
+		reasons        []string
+	}{
+		{
+			name:    "Empty",
+			history: &util.SlashingProtectionData{},
+			source:  5,
+			target:  6,
+			reasons: []string{},
+		},
+		{
+			name:    "Good",
+			history: history,
+			source:  20,
+			target:  21,
+			reasons: []string{},
+		},
+		{
+			name:      "DoubleVote",
+			history:   history,
+			source:    12,
+			target:    12,
+			slashable: true,
+			reasons:   []string{reasonDoubleVote},
+		},
+		{
+			name:        "DoubleVoteSameSigningRoot",
+			history:     history,
+			source:      15,
+			target:      20,
+			signingRoot: &root2,
+			reasons:     []string{},
+		},
+		{
+			name:        "DoubleVoteDifferentSigningRoot",
+			history:     history,
+			source:      15,
+			target:      20,
+			signingRoot: &root1,
+			slashable:   true,
+			reasons:     []string{reasonDoubleVote},
+		},
+		{
+			name:        "DoubleVoteUnknownSigningRoot",
+			history:     history,
+			source:      11,
+			target:      12,
+			signingRoot: &root1,
+			slashable:   true,
+			reasons:     []string{reasonDoubleVote},
+		},
+		{
+			name:      "SurroundVote",
+			history:   history,
+			source:    14,
+			target:    21,
+			slashable: true,
+			reasons:   []string{reasonSurroundVote},
+		},
+		{
+			name:      "SurroundedVote",
+			history:   history,
+			source:    16,
+			target:    19,
+			slashable: true,
+			reasons:   []string{reasonSurroundedVote},
+		},
+		{
+			name:      "Multiple",
+			history:   history,
+			source:    9,
+			target:    20,
+			slashable: true,
+			reasons:   []string{reasonSurroundVote, reasonSurroundVote, reasonDoubleVote},
+			// Source is below the lowest source in the history.
+			belowWatermark: true,
+		},
+		{
+			name:           "BelowWatermark",
+			history:        history,
+			source:         10,
+			target:         10,
+			belowWatermark: true,
+			reasons:        []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := verifyAttestation(test.history, test.source, test.target, test.signingRoot)
+			require.Equal(t, test.source, res.SourceEpoch)
+			require.Equal(t, test.target, res.TargetEpoch)
+			require.Equal(t, test.slashable, res.Slashable)
+			require.Equal(t, test.belowWatermark, res.BelowWatermark)
+			reasons := make([]string, 0, len(res.Conflicts))
+			for _, conflict := range res.Conflicts {
+				reasons = append(reasons, conflict.Reason)
+			}
+			require.Equal(t, test.reasons, reasons)
+		})
+	}
+}
+
+func TestParseAttestationData(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		source phase0.Epoch
+		target phase0.Epoch
+		err    string
+	}{
+		{
+			name:  "Invalid",
+			input: `{`,
+			err:   "invalid JSON: unexpected end of JSON input",
+		},
+		{
+			name:   "AttestationData",
+			input:  `{"slot":"100","index":"1","beacon_block_root":"0x0000000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"2","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"3","root":"0x0000000000000000000000000000000000000000000000000000000000000000"}}`,
+			source: 2,
+			target: 3,
+		},
+		{
+			name:   "Attestation",
+			input:  `{"aggregation_bits":"0x01","data":{"slot":"100","index":"1","beacon_block_root":"0x0000000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"2","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"3","root":"0x0000000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x00"}`,
+			source: 2,
+			target: 3,
+		},
+		{
+			name:  "SourceAfterTarget",
+			input: `{"slot":"100","index":"1","beacon_block_root":"0x0000000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"4","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"3","root":"0x0000000000000000000000000000000000000000000000000000000000000000"}}`,
+			err:   "source epoch must not be after target epoch",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseAttestationData([]byte(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.source, res.Source.Epoch)
+				require.Equal(t, test.target, res.Target.Epoch)
+			}
+		})
+	}
+}
+
+func TestSelectHistory(t *testing.T) {
+	pubKey1 := phase0.BLSPubKey{0x01}
+	pubKey2 := phase0.BLSPubKey{0x02}
+	pubKey3 := phase0.BLSPubKey{0x03}
+	single := &util.SlashingProtection{
+		Data: []*util.SlashingProtectionData{
+			{PubKey: pubKey1, SignedAttestations: []*util.SlashingProtectionAttestation{{SourceEpoch: 1, TargetEpoch: 2}}},
+		},
+	}
+	multiple := &util.SlashingProtection{
+		Data: []*util.SlashingProtectionData{
+			{PubKey: pubKey1, SignedAttestations: []*util.SlashingProtectionAttestation{{SourceEpoch: 1, TargetEpoch: 2}}},
+			{PubKey: pubKey2, SignedAttestations: []*util.SlashingProtectionAttestation{{SourceEpoch: 1, TargetEpoch: 2}}},
+			{PubKey: pubKey1, SignedAttestations: []*util.SlashingProtectionAttestation{{SourceEpoch: 2, TargetEpoch: 3}}},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		slashingProtection *util.SlashingProtection
+		pubKey             *phase0.BLSPubKey
+		attestations       int
+		err                string
+	}{
+		{
+			name:               "Single",
+			slashingProtection: single,
+			attestations:       1,
+		},
+		{
+			name:               "MultipleNoPubKey",
+			slashingProtection: multiple,
+			err:                "slashing protection data contains 3 validators; pubkey is required",
+		},
+		{
+			name:               "MultipleEntries",
+			slashingProtection: multiple,
+			pubKey:             &pubKey1,
+			attestations:       2,
+		},
+		{
+			name:               "Unknown",
+			slashingProtection: multiple,
+			pubKey:             &pubKey3,
+			err:                "no slashing protection data for 0x030000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := selectHistory(test.slashingProtection, test.pubKey)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res.SignedAttestations, test.attestations)
+			}
+		})
+	}
+}
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	attesterduties "github.com/wealdtech/ethdo/cmd/attester/duties"
@@ -38,7 +36,9 @@ In quiet mode this will return 0 if a duty from the attester is found, otherwise
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -0,0 +1,83 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	attesterverify "github.com/wealdtech/ethdo/cmd/attester/verify"
+)
+
+var attesterVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check if signing an attestation would be slashable",
+	Long: `Check if signing an attestation would be slashable, given a validator's signing history in EIP-3076 format.  For example:
+
+    ethdo attester verify --slashing-protection=history.json --source-epoch=100 --target-epoch=101
+
+The attestation can be supplied as JSON, either inline or as a file, or as source and target epochs.  If the signing root of the attestation is supplied then re-signing an attestation already in the history is not reported as slashable.
+
+In quiet mode this will return 0 if signing the attestation would not be slashable, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := attesterverify.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	attesterCmd.AddCommand(attesterVerifyCmd)
+	attesterFlags(attesterVerifyCmd)
+	attesterVerifyCmd.Flags().String("slashing-protection", "", "File containing the validator's signing history in EIP-3076 format")
+	attesterVerifyCmd.Flags().String("pubkey", "", "Public key of the validator (required if the signing history contains multiple validators)")
+	attesterVerifyCmd.Flags().String("attestation", "", "The attestation or attestation data to check, as JSON or the name of a file containing JSON")
+	attesterVerifyCmd.Flags().String("source-epoch", "", "The source epoch of the attestation to check")
+	attesterVerifyCmd.Flags().String("target-epoch", "", "The target epoch of the attestation to check")
+	attesterVerifyCmd.Flags().String("signing-root", "", "The signing root of the attestation to check")
+	attesterVerifyCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func attesterVerifyBindings() {
+	if err := viper.BindPFlag("slashing-protection", attesterVerifyCmd.Flags().Lookup("slashing-protection")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("pubkey", attesterVerifyCmd.Flags().Lookup("pubkey")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("attestation", attesterVerifyCmd.Flags().Lookup("attestation")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("source-epoch", attesterVerifyCmd.Flags().Lookup("source-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("target-epoch", attesterVerifyCmd.Flags().Lookup("target-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("signing-root", attesterVerifyCmd.Flags().Lookup("signing-root")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", attesterVerifyCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
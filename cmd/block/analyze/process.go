@@ -61,9 +61,7 @@ func (c *command) process(ctx context.Context) error {
 			minSlot = attestation.Data.Slot
 		}
 	}
-	if c.debug {
-		fmt.Printf("Need to fetch blocks to slot %d\n", minSlot)
-	}
+	util.Log.Debug().Uint64("slot", uint64(minSlot)).Msg("Fetching parent blocks")
 
 	if err := c.fetchParents(ctx, block, minSlot); err != nil {
 		return err
@@ -98,9 +96,7 @@ func (c *command) analyzeAttestations(ctx context.Context, block *spec.Versioned
 
 	blockVotes := make(map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist)
 	for i, attestation := range attestations {
-		if c.debug {
-			fmt.Printf("Processing attestation %d\n", i)
-		}
+		util.Log.Debug().Int("attestation", i).Msg("Processing attestation")
 		analysis := &attestationAnalysis{
 			Head:     attestation.Data.BeaconBlockRoot,
 			Target:   attestation.Data.Target.Root,
@@ -222,9 +218,7 @@ func (c *command) processParentBlock(ctx context.Context, block *spec.VersionedS
 	if err != nil {
 		return err
 	}
-	if c.debug {
-		fmt.Printf("Processing block %d\n", slot)
-	}
+	util.Log.Debug().Uint64("slot", uint64(slot)).Msg("Processing block")
 
 	for i, attestation := range attestations {
 		root, err := attestation.HashTreeRoot()
@@ -0,0 +1,119 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockbids
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	blockID    string
+	relays     []string
+	localValue *big.Int
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	eth2Client     eth2client.Service
+	blocksProvider eth2client.SignedBeaconBlockProvider
+	httpClient     *http.Client
+
+	// Output.
+	slot         phase0.Slot
+	blockHash    string
+	relayResults []*relayResult
+	deliveredBy  string
+	actualValue  *big.Int
+	bestValue    *big.Int
+	lostValue    *big.Int
+}
+
+// relayResult contains the bids seen by a single relay for the slot.
+type relayResult struct {
+	Relay          string   `json:"relay"`
+	Delivered      bool     `json:"delivered"`
+	DeliveredValue *big.Int `json:"delivered_value,omitempty"`
+	BestBid        *big.Int `json:"best_bid,omitempty"`
+	Bids           int      `json:"bids"`
+	Error          string   `json:"error,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+	c.httpClient = &http.Client{
+		Timeout: c.timeout,
+	}
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.blockID = viper.GetString("blockid")
+	if c.blockID == "" {
+		return nil, errors.New("blockid is required")
+	}
+
+	for _, relay := range viper.GetStringSlice("relays") {
+		relay = strings.TrimSuffix(strings.TrimSpace(relay), "/")
+		if relay == "" {
+			continue
+		}
+		if !strings.HasPrefix(relay, "http://") && !strings.HasPrefix(relay, "https://") {
+			return nil, errors.New("relays must be HTTP or HTTPS URLs")
+		}
+		c.relays = append(c.relays, relay)
+	}
+	if len(c.relays) == 0 {
+		return nil, errors.New("at least one relay is required")
+	}
+
+	if viper.GetString("local-value") != "" {
+		localValue, err := string2eth.StringToWei(viper.GetString("local-value"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid local value")
+		}
+		c.localValue = localValue
+	}
+
+	return c, nil
+}
@@ -0,0 +1,99 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockbids
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"blockid": "head",
+				"relays":  []string{"https://relay.example.com"},
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "BlockIDMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"relays":  []string{"https://relay.example.com"},
+			},
+			err: "blockid is required",
+		},
+		{
+			name: "RelaysMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"blockid": "head",
+			},
+			err: "at least one relay is required",
+		},
+		{
+			name: "RelayInvalid",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"blockid": "head",
+				"relays":  []string{"relay.example.com"},
+			},
+			err: "relays must be HTTP or HTTPS URLs",
+		},
+		{
+			name: "LocalValueInvalid",
+			vars: map[string]interface{}{
+				"timeout":     "5s",
+				"blockid":     "head",
+				"relays":      []string{"https://relay.example.com"},
+				"local-value": "bad",
+			},
+			err: "invalid local value",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":     "5s",
+				"blockid":     "head",
+				"relays":      []string{"https://relay1.example.com/", "https://relay2.example.com"},
+				"local-value": "0.02Ether",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockbids
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type jsonOutput struct {
+	Slot        phase0.Slot    `json:"slot"`
+	BlockHash   string         `json:"block_hash"`
+	DeliveredBy string         `json:"delivered_by,omitempty"`
+	Value       *big.Int       `json:"value,omitempty"`
+	LocalValue  *big.Int       `json:"local_value,omitempty"`
+	BestValue   *big.Int       `json:"best_value,omitempty"`
+	LostValue   *big.Int       `json:"lost_value,omitempty"`
+	Relays      []*relayResult `json:"relays"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		Slot:        c.slot,
+		BlockHash:   c.blockHash,
+		DeliveredBy: c.deliveredBy,
+		Value:       c.actualValue,
+		LocalValue:  c.localValue,
+		BestValue:   c.bestValue,
+		LostValue:   c.lostValue,
+		Relays:      c.relayResults,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Slot: %d\n", c.slot))
+	builder.WriteString(fmt.Sprintf("Execution block hash: %s\n", c.blockHash))
+	if c.deliveredBy != "" {
+		builder.WriteString(fmt.Sprintf("Delivered by: %s\n", c.deliveredBy))
+	} else {
+		builder.WriteString("Delivered by: none (built locally)\n")
+	}
+
+	builder.WriteString("Relays:\n")
+	for _, res := range c.relayResults {
+		builder.WriteString(fmt.Sprintf("  %s: ", res.Relay))
+		if res.Error != "" {
+			builder.WriteString(fmt.Sprintf("error: %s\n", res.Error))
+			continue
+		}
+		if res.Delivered {
+			builder.WriteString(fmt.Sprintf("delivered %s, ", string2eth.WeiToString(res.DeliveredValue, true)))
+		}
+		if res.BestBid == nil {
+			builder.WriteString("no bids\n")
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("best bid %s from %d bids\n", string2eth.WeiToString(res.BestBid, true), res.Bids))
+	}
+
+	if c.localValue != nil {
+		builder.WriteString(fmt.Sprintf("Local estimate: %s\n", string2eth.WeiToString(c.localValue, true)))
+	}
+	if c.actualValue != nil {
+		builder.WriteString(fmt.Sprintf("Value: %s\n", string2eth.WeiToString(c.actualValue, true)))
+	} else {
+		builder.WriteString("Value: unknown (supply the locally-built value with --local-value)\n")
+	}
+	if c.lostValue != nil {
+		builder.WriteString(fmt.Sprintf("Lost value: %s", string2eth.WeiToString(c.lostValue, true)))
+	} else {
+		builder.WriteString("Lost value: none")
+	}
+
+	return builder.String(), nil
+}
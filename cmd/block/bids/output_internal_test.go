@@ -0,0 +1,75 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockbids
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "NoBids",
+			command: &command{
+				slot:      100,
+				blockHash: "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20",
+				relayResults: []*relayResult{
+					{Relay: "https://relay1.example.com"},
+					{Relay: "https://relay2.example.com", Error: "failed to send request"},
+				},
+			},
+			res: `Slot: 100
+Execution block hash: 0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20
+Delivered by: none (built locally)
+Relays:
+  https://relay1.example.com: no bids
+  https://relay2.example.com: error: failed to send request
+Value: unknown (supply the locally-built value with --local-value)
+Lost value: none`,
+		},
+		{
+			name: "JSON",
+			command: &command{
+				json:        true,
+				slot:        100,
+				blockHash:   "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20",
+				deliveredBy: "https://relay1.example.com",
+				actualValue: big.NewInt(30000000000000000),
+				bestValue:   big.NewInt(40000000000000000),
+				lostValue:   big.NewInt(10000000000000000),
+				relayResults: []*relayResult{
+					{Relay: "https://relay1.example.com", Delivered: true, DeliveredValue: big.NewInt(30000000000000000), BestBid: big.NewInt(30000000000000000), Bids: 2},
+					{Relay: "https://relay2.example.com", BestBid: big.NewInt(40000000000000000), Bids: 1},
+				},
+			},
+			res: `{"slot":100,"block_hash":"0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20","delivered_by":"https://relay1.example.com","value":30000000000000000,"best_value":40000000000000000,"lost_value":10000000000000000,"relays":[{"relay":"https://relay1.example.com","delivered":true,"delivered_value":30000000000000000,"best_bid":30000000000000000,"bids":2},{"relay":"https://relay2.example.com","delivered":false,"best_bid":40000000000000000,"bids":1}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockbids
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// bidTrace is the relay data API representation of a bid.
+type bidTrace struct {
+	Slot      string `json:"slot"`
+	BlockHash string `json:"block_hash"`
+	Value     string `json:"value"`
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	block, err := c.blocksProvider.SignedBeaconBlock(ctx, c.blockID)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block")
+	}
+	if block == nil {
+		return errors.New("empty beacon block")
+	}
+	c.slot, err = block.Slot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block slot")
+	}
+	switch block.Version {
+	case spec.DataVersionBellatrix:
+		c.blockHash = fmt.Sprintf("%#x", block.Bellatrix.Message.Body.ExecutionPayload.BlockHash)
+	case spec.DataVersionCapella:
+		c.blockHash = fmt.Sprintf("%#x", block.Capella.Message.Body.ExecutionPayload.BlockHash)
+	default:
+		return errors.New("block does not contain an execution payload")
+	}
+
+	for _, relay := range c.relays {
+		c.relayResults = append(c.relayResults, c.obtainRelayResult(ctx, relay))
+	}
+
+	c.summarise()
+
+	return nil
+}
+
+// obtainRelayResult obtains the bids and delivered payload for the slot from a relay.
+func (c *command) obtainRelayResult(ctx context.Context, relay string) *relayResult {
+	res := &relayResult{
+		Relay: relay,
+	}
+
+	delivered, err := c.bidTraces(ctx, relay, "proposer_payload_delivered")
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	for _, trace := range delivered {
+		if strings.EqualFold(trace.BlockHash, c.blockHash) {
+			value, err := parseValue(trace.Value)
+			if err != nil {
+				res.Error = err.Error()
+				return res
+			}
+			res.Delivered = true
+			res.DeliveredValue = value
+		}
+	}
+
+	received, err := c.bidTraces(ctx, relay, "builder_blocks_received")
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	for _, trace := range received {
+		value, err := parseValue(trace.Value)
+		if err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		res.Bids++
+		if res.BestBid == nil || value.Cmp(res.BestBid) > 0 {
+			res.BestBid = value
+		}
+	}
+
+	return res
+}
+
+// bidTraces obtains bid traces for the slot from the given relay data API endpoint.
+func (c *command) bidTraces(ctx context.Context, relay string, endpoint string) ([]*bidTrace, error) {
+	url := fmt.Sprintf("%s/relay/v1/data/bidtraces/%s?slot=%d", relay, endpoint, c.slot)
+	util.Log.Debug().Str("url", url).Msg("Fetching")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s request returned status %d", endpoint, resp.StatusCode)
+	}
+
+	traces := make([]*bidTrace, 0)
+	if err := json.NewDecoder(resp.Body).Decode(&traces); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse %s response", endpoint))
+	}
+
+	return traces, nil
+}
+
+// summarise works out the value the proposer obtained for the block, the best
+// value that was available to them, and the difference between the two.
+func (c *command) summarise() {
+	for _, res := range c.relayResults {
+		if res.Delivered && c.deliveredBy == "" {
+			c.deliveredBy = res.Relay
+			c.actualValue = res.DeliveredValue
+		}
+		if res.BestBid != nil && (c.bestValue == nil || res.BestBid.Cmp(c.bestValue) > 0) {
+			c.bestValue = res.BestBid
+		}
+	}
+
+	if c.deliveredBy == "" {
+		// Not delivered by any relay, so assume that the block was built locally.
+		c.actualValue = c.localValue
+	}
+	if c.localValue != nil && (c.bestValue == nil || c.localValue.Cmp(c.bestValue) > 0) {
+		c.bestValue = c.localValue
+	}
+
+	if c.actualValue != nil && c.bestValue != nil && c.bestValue.Cmp(c.actualValue) > 0 {
+		c.lostValue = new(big.Int).Sub(c.bestValue, c.actualValue)
+	}
+}
+
+// parseValue parses a decimal value in wei.
+func parseValue(input string) (*big.Int, error) {
+	value, success := new(big.Int).SetString(input, 10)
+	if !success {
+		return nil, fmt.Errorf("invalid value %q", input)
+	}
+
+	return value, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon block information")
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockbids
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObtainRelayResult(t *testing.T) {
+	blockHash := "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "100", r.URL.Query().Get("slot"))
+		switch r.URL.Path {
+		case "/good/relay/v1/data/bidtraces/proposer_payload_delivered":
+			fmt.Fprintf(w, `[{"slot":"100","block_hash":"%s","value":"30000000000000000"}]`, blockHash)
+		case "/good/relay/v1/data/bidtraces/builder_blocks_received":
+			fmt.Fprintf(w, `[{"slot":"100","block_hash":"0x01","value":"20000000000000000"},{"slot":"100","block_hash":"%s","value":"30000000000000000"},{"slot":"100","block_hash":"0x02","value":"25000000000000000"}]`, blockHash)
+		case "/other/relay/v1/data/bidtraces/proposer_payload_delivered":
+			fmt.Fprint(w, `[]`)
+		case "/other/relay/v1/data/bidtraces/builder_blocks_received":
+			fmt.Fprint(w, `[{"slot":"100","block_hash":"0x03","value":"40000000000000000"}]`)
+		case "/badvalue/relay/v1/data/bidtraces/proposer_payload_delivered":
+			fmt.Fprint(w, `[]`)
+		case "/badvalue/relay/v1/data/bidtraces/builder_blocks_received":
+			fmt.Fprint(w, `[{"slot":"100","block_hash":"0x03","value":"lots"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		relay    string
+		expected *relayResult
+	}{
+		{
+			name:  "Delivered",
+			relay: server.URL + "/good",
+			expected: &relayResult{
+				Relay:          server.URL + "/good",
+				Delivered:      true,
+				DeliveredValue: big.NewInt(30000000000000000),
+				BestBid:        big.NewInt(30000000000000000),
+				Bids:           3,
+			},
+		},
+		{
+			name:  "NotDelivered",
+			relay: server.URL + "/other",
+			expected: &relayResult{
+				Relay:   server.URL + "/other",
+				BestBid: big.NewInt(40000000000000000),
+				Bids:    1,
+			},
+		},
+		{
+			name:  "BadValue",
+			relay: server.URL + "/badvalue",
+			expected: &relayResult{
+				Relay: server.URL + "/badvalue",
+				Error: `invalid value "lots"`,
+			},
+		},
+		{
+			name:  "NotFound",
+			relay: server.URL + "/missing",
+			expected: &relayResult{
+				Relay: server.URL + "/missing",
+				Error: "proposer_payload_delivered request returned status 404",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				httpClient: server.Client(),
+				slot:       100,
+				blockHash:  blockHash,
+			}
+			require.Equal(t, test.expected, c.obtainRelayResult(context.Background(), test.relay))
+		})
+	}
+}
+
+func TestSummarise(t *testing.T) {
+	tests := []struct {
+		name         string
+		relayResults []*relayResult
+		localValue   *big.Int
+		deliveredBy  string
+		actualValue  *big.Int
+		bestValue    *big.Int
+		lostValue    *big.Int
+	}{
+		{
+			name: "RelayBest",
+			relayResults: []*relayResult{
+				{Relay: "a", Delivered: true, DeliveredValue: big.NewInt(30), BestBid: big.NewInt(30)},
+				{Relay: "b", BestBid: big.NewInt(20)},
+			},
+			localValue:  big.NewInt(10),
+			deliveredBy: "a",
+			actualValue: big.NewInt(30),
+			bestValue:   big.NewInt(30),
+		},
+		{
+			name: "RelayBeatenByOtherRelay",
+			relayResults: []*relayResult{
+				{Relay: "a", Delivered: true, DeliveredValue: big.NewInt(30), BestBid: big.NewInt(30)},
+				{Relay: "b", BestBid: big.NewInt(45)},
+			},
+			deliveredBy: "a",
+			actualValue: big.NewInt(30),
+			bestValue:   big.NewInt(45),
+			lostValue:   big.NewInt(15),
+		},
+		{
+			name: "RelayBeatenByLocal",
+			relayResults: []*relayResult{
+				{Relay: "a", Delivered: true, DeliveredValue: big.NewInt(30), BestBid: big.NewInt(30)},
+			},
+			localValue:  big.NewInt(50),
+			deliveredBy: "a",
+			actualValue: big.NewInt(30),
+			bestValue:   big.NewInt(50),
+			lostValue:   big.NewInt(20),
+		},
+		{
+			name: "LocalBeatenByRelay",
+			relayResults: []*relayResult{
+				{Relay: "a", BestBid: big.NewInt(30)},
+				{Relay: "b", Error: "failed"},
+			},
+			localValue:  big.NewInt(10),
+			actualValue: big.NewInt(10),
+			bestValue:   big.NewInt(30),
+			lostValue:   big.NewInt(20),
+		},
+		{
+			name: "LocalUnknown",
+			relayResults: []*relayResult{
+				{Relay: "a", BestBid: big.NewInt(30)},
+			},
+			bestValue: big.NewInt(30),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				relayResults: test.relayResults,
+				localValue:   test.localValue,
+			}
+			c.summarise()
+			require.Equal(t, test.deliveredBy, c.deliveredBy)
+			require.Equal(t, test.actualValue, c.actualValue)
+			require.Equal(t, test.bestValue, c.bestValue)
+			require.Equal(t, test.lostValue, c.lostValue)
+		})
+	}
+}
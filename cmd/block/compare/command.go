@@ -0,0 +1,107 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockcompare
+
+import (
+	"context"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connections.
+	timeout                  time.Duration
+	connection               string
+	connection2              string
+	allowInsecureConnections bool
+
+	// Input.
+	blockID string
+	state   bool
+
+	// Processing.
+	consensusClient      consensusclient.Service
+	otherConsensusClient consensusclient.Service
+
+	// Output.
+	sourceName     string
+	otherName      string
+	root           *phase0.Root
+	otherRoot      *phase0.Root
+	stateRoot      *phase0.Root
+	otherStateRoot *phase0.Root
+	diffs          []*fieldDiff
+}
+
+// fieldDiff is a field whose value differs between the two blocks.  An
+// empty value means that the field is not present in that block.
+type fieldDiff struct {
+	Field string `json:"field"`
+	Value string `json:"value,omitempty"`
+	Other string `json:"other,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+		blockID: viper.GetString("blockid"),
+		state:   viper.GetBool("state"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.connection2 = viper.GetString("connection2")
+	if c.connection2 == "" {
+		return nil, errors.New("connection2 is required")
+	}
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if c.blockID == "" {
+		return nil, errors.New("blockid is required")
+	}
+
+	return c, nil
+}
+
+// identical returns true if the blocks, and state roots if requested, are
+// the same on both nodes.
+func (c *command) identical() bool {
+	if c.root == nil || c.otherRoot == nil || *c.root != *c.otherRoot || len(c.diffs) > 0 {
+		return false
+	}
+	if c.state {
+		if c.stateRoot == nil || c.otherStateRoot == nil || *c.stateRoot != *c.otherStateRoot {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,151 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockcompare
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/pkg/errors"
+)
+
+// blockData returns the generic JSON representation of a block, for comparison.
+func blockData(block *spec.VersionedSignedBeaconBlock) (interface{}, error) {
+	var data interface{}
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		data = block.Phase0
+	case spec.DataVersionAltair:
+		data = block.Altair
+	case spec.DataVersionBellatrix:
+		data = block.Bellatrix
+	case spec.DataVersionCapella:
+		data = block.Capella
+	default:
+		return nil, fmt.Errorf("unhandled block version %v", block.Version)
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal block")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var res interface{}
+	if err := decoder.Decode(&res); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal block")
+	}
+
+	return res, nil
+}
+
+// diffValues returns the fields whose values differ between two generic JSON
+// values, in the order in which they appear.
+func diffValues(path string, value interface{}, other interface{}) []*fieldDiff {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		o, isMap := other.(map[string]interface{})
+		if !isMap {
+			break
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		for k := range o {
+			if _, exists := v[k]; !exists {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		res := make([]*fieldDiff, 0)
+		for _, k := range keys {
+			res = append(res, diffValues(joinPath(path, k), v[k], o[k])...)
+		}
+		return res
+	case []interface{}:
+		o, isSlice := other.([]interface{})
+		if !isSlice {
+			break
+		}
+		res := make([]*fieldDiff, 0)
+		if len(v) != len(o) {
+			res = append(res, &fieldDiff{
+				Field: fmt.Sprintf("%s.length", path),
+				Value: fmt.Sprintf("%d", len(v)),
+				Other: fmt.Sprintf("%d", len(o)),
+			})
+		}
+		for i := 0; i < len(v) || i < len(o); i++ {
+			var item interface{}
+			if i < len(v) {
+				item = v[i]
+			}
+			var otherItem interface{}
+			if i < len(o) {
+				otherItem = o[i]
+			}
+			res = append(res, diffValues(fmt.Sprintf("%s[%d]", path, i), item, otherItem)...)
+		}
+		return res
+	}
+
+	valueStr := formatValue(value)
+	otherStr := formatValue(other)
+	// Hex values are not consistently cased between implementations.
+	if strings.EqualFold(valueStr, otherStr) {
+		return nil
+	}
+
+	return []*fieldDiff{
+		{
+			Field: path,
+			Value: valueStr,
+			Other: otherStr,
+		},
+	}
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return fmt.Sprintf("%s.%s", path, key)
+}
+
+// formatValue formats a generic JSON value as a string, with missing values
+// returned as empty strings.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprintf("%t", v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockcompare
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		other string
+		diffs []*fieldDiff
+	}{
+		{
+			name:  "Identical",
+			value: `{"message":{"slot":"1","body":{"graffiti":"0xab"}},"signature":"0x01"}`,
+			other: `{"message":{"slot":"1","body":{"graffiti":"0xab"}},"signature":"0x01"}`,
+			diffs: []*fieldDiff{},
+		},
+		{
+			name:  "HexCase",
+			value: `{"signature":"0xAB"}`,
+			other: `{"signature":"0xab"}`,
+			diffs: []*fieldDiff{},
+		},
+		{
+			name:  "Nested",
+			value: `{"message":{"slot":"1","body":{"graffiti":"0xab"}},"signature":"0x01"}`,
+			other: `{"message":{"slot":"1","body":{"graffiti":"0xcd"}},"signature":"0x02"}`,
+			diffs: []*fieldDiff{
+				{Field: "message.body.graffiti", Value: "0xab", Other: "0xcd"},
+				{Field: "signature", Value: "0x01", Other: "0x02"},
+			},
+		},
+		{
+			name:  "ListLength",
+			value: `{"attestations":[{"index":"1"},{"index":"2"}]}`,
+			other: `{"attestations":[{"index":"1"}]}`,
+			diffs: []*fieldDiff{
+				{Field: "attestations.length", Value: "2", Other: "1"},
+				{Field: "attestations[1]", Value: `{"index":"2"}`},
+			},
+		},
+		{
+			name:  "FieldMissing",
+			value: `{"a":"1"}`,
+			other: `{"a":"1","b":"2"}`,
+			diffs: []*fieldDiff{
+				{Field: "b", Other: "2"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var value interface{}
+			require.NoError(t, json.Unmarshal([]byte(test.value), &value))
+			var other interface{}
+			require.NoError(t, json.Unmarshal([]byte(test.other), &other))
+			require.Equal(t, test.diffs, diffValues("", value, other))
+		})
+	}
+}
+
+func TestBlockData(t *testing.T) {
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.SignedBeaconBlock{
+			Message: &phase0.BeaconBlock{
+				Slot: 12345,
+				Body: &phase0.BeaconBlockBody{
+					ETH1Data: &phase0.ETH1Data{
+						BlockHash: make([]byte, 32),
+					},
+				},
+			},
+		},
+	}
+	data, err := blockData(block)
+	require.NoError(t, err)
+
+	otherBlock := &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.SignedBeaconBlock{
+			Message: &phase0.BeaconBlock{
+				Slot: 12345,
+				Body: &phase0.BeaconBlockBody{
+					ETH1Data: &phase0.ETH1Data{
+						BlockHash:    make([]byte, 32),
+						DepositCount: 5,
+					},
+				},
+			},
+		},
+	}
+	otherData, err := blockData(otherBlock)
+	require.NoError(t, err)
+
+	require.Equal(t, []*fieldDiff{
+		{Field: "message.body.eth1_data.deposit_count", Value: "0", Other: "5"},
+	}, diffValues("", data, otherData))
+
+	_, err = blockData(&spec.VersionedSignedBeaconBlock{Version: spec.DataVersion(99)})
+	require.EqualError(t, err, "unhandled block version unknown")
+}
@@ -0,0 +1,126 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockcompare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// maxValueLength is the maximum length of a value in text output, unless verbose.
+const maxValueLength = 68
+
+type outputJSON struct {
+	Source         string       `json:"source"`
+	Other          string       `json:"other"`
+	Identical      bool         `json:"identical"`
+	Root           string       `json:"root,omitempty"`
+	OtherRoot      string       `json:"other_root,omitempty"`
+	StateRoot      string       `json:"state_root,omitempty"`
+	OtherStateRoot string       `json:"other_state_root,omitempty"`
+	Diffs          []*fieldDiff `json:"diffs"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &outputJSON{
+		Source:         c.sourceName,
+		Other:          c.otherName,
+		Identical:      c.identical(),
+		Root:           formatRoot(c.root),
+		OtherRoot:      formatRoot(c.otherRoot),
+		StateRoot:      formatRoot(c.stateRoot),
+		OtherStateRoot: formatRoot(c.otherStateRoot),
+		Diffs:          c.diffs,
+	}
+	if output.Diffs == nil {
+		output.Diffs = make([]*fieldDiff, 0)
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	switch {
+	case c.root == nil:
+		builder.WriteString(fmt.Sprintf("Block not present on %s\n", c.sourceName))
+	case c.otherRoot == nil:
+		builder.WriteString(fmt.Sprintf("Block not present on %s\n", c.otherName))
+	case *c.root == *c.otherRoot:
+		builder.WriteString(fmt.Sprintf("Blocks are identical with root %#x\n", *c.root))
+	default:
+		builder.WriteString(fmt.Sprintf("Blocks differ: %#x on %s, %#x on %s\n", *c.root, c.sourceName, *c.otherRoot, c.otherName))
+	}
+
+	for _, diff := range c.diffs {
+		builder.WriteString(fmt.Sprintf("%s:\n", diff.Field))
+		builder.WriteString(fmt.Sprintf("  %s: %s\n", c.sourceName, c.formatDiffValue(diff.Value)))
+		builder.WriteString(fmt.Sprintf("  %s: %s\n", c.otherName, c.formatDiffValue(diff.Other)))
+	}
+
+	if c.state {
+		switch {
+		case c.stateRoot == nil || c.otherStateRoot == nil:
+			builder.WriteString("State root not available from both nodes\n")
+		case *c.stateRoot == *c.otherStateRoot:
+			builder.WriteString(fmt.Sprintf("State roots are identical with root %#x\n", *c.stateRoot))
+		default:
+			builder.WriteString(fmt.Sprintf("State roots differ: %#x on %s, %#x on %s\n", *c.stateRoot, c.sourceName, *c.otherStateRoot, c.otherName))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// formatDiffValue formats a value for text output, truncating long values
+// such as transactions unless verbose.
+func (c *command) formatDiffValue(value string) string {
+	if value == "" {
+		return "(not present)"
+	}
+	if !c.verbose && len(value) > maxValueLength {
+		return fmt.Sprintf("%s… (%d characters)", value[:maxValueLength], len(value))
+	}
+
+	return value
+}
+
+func formatRoot(root *phase0.Root) string {
+	if root == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%#x", *root)
+}
@@ -0,0 +1,163 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockcompare
+
+import (
+	"context"
+	"fmt"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.sourceName = c.connection
+	if c.sourceName == "" {
+		c.sourceName = c.consensusClient.Address()
+	}
+	c.otherName = c.connection2
+
+	block, err := c.consensusClient.(consensusclient.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, c.blockID)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block")
+	}
+	otherBlock, err := c.otherConsensusClient.(consensusclient.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, c.blockID)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block from other connection")
+	}
+	if block == nil && otherBlock == nil {
+		return fmt.Errorf("block %s not found on either node", c.blockID)
+	}
+
+	c.root, err = blockRoot(block)
+	if err != nil {
+		return err
+	}
+	c.otherRoot, err = blockRoot(otherBlock)
+	if err != nil {
+		return errors.Wrap(err, "other connection")
+	}
+
+	if block != nil && otherBlock != nil {
+		if block.Version != otherBlock.Version {
+			c.diffs = append(c.diffs, &fieldDiff{
+				Field: "version",
+				Value: block.Version.String(),
+				Other: otherBlock.Version.String(),
+			})
+		}
+		data, err := blockData(block)
+		if err != nil {
+			return err
+		}
+		otherData, err := blockData(otherBlock)
+		if err != nil {
+			return errors.Wrap(err, "other connection")
+		}
+		c.diffs = append(c.diffs, diffValues("", data, otherData)...)
+		util.Log.Debug().Int("differences", len(c.diffs)).Msg("Compared blocks")
+	}
+
+	if c.state {
+		if err := c.compareStateRoots(ctx, block, otherBlock); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compareStateRoots obtains the state roots at the slot of the block from
+// both nodes.
+func (c *command) compareStateRoots(ctx context.Context,
+	block *spec.VersionedSignedBeaconBlock,
+	otherBlock *spec.VersionedSignedBeaconBlock,
+) error {
+	// Use a slot rather than the block ID so that both nodes return the state
+	// for the same slot even if they disagree about the block.
+	if block == nil {
+		block = otherBlock
+	}
+	slot, err := block.Slot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block slot")
+	}
+
+	c.stateRoot, err = c.consensusClient.(consensusclient.BeaconStateRootProvider).BeaconStateRoot(ctx, fmt.Sprintf("%d", slot))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain state root")
+	}
+	c.otherStateRoot, err = c.otherConsensusClient.(consensusclient.BeaconStateRootProvider).BeaconStateRoot(ctx, fmt.Sprintf("%d", slot))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain state root from other connection")
+	}
+
+	return nil
+}
+
+// blockRoot returns the root of the block, or nil if there is no block.
+func blockRoot(block *spec.VersionedSignedBeaconBlock) (*phase0.Root, error) {
+	if block == nil {
+		return nil, nil
+	}
+	root, err := block.Root()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate block root")
+	}
+
+	return &root, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus nodes.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+	if err := checkProviders(c.consensusClient, c.state); err != nil {
+		return errors.Wrap(err, "consensus node")
+	}
+
+	c.otherConsensusClient, err = util.ConnectToBeaconNode(ctx, c.connection2, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to other consensus node")
+	}
+	if err := checkProviders(c.otherConsensusClient, c.state); err != nil {
+		return errors.Wrap(err, "other consensus node")
+	}
+
+	return nil
+}
+
+func checkProviders(consensusClient consensusclient.Service, state bool) error {
+	if _, isProvider := consensusClient.(consensusclient.SignedBeaconBlockProvider); !isProvider {
+		return errors.New("does not provide signed beacon blocks")
+	}
+	if state {
+		if _, isProvider := consensusClient.(consensusclient.BeaconStateRootProvider); !isProvider {
+			return errors.New("does not provide beacon state roots")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockpackingadvise
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	slot string
+
+	// Data access.
+	eth2Client              eth2client.Service
+	chainTime               chaintime.Service
+	blocksProvider          eth2client.SignedBeaconBlockProvider
+	blockHeadersProvider    eth2client.BeaconBlockHeadersProvider
+	attestationPoolProvider eth2client.AttestationPoolProvider
+	validatorsProvider      eth2client.ValidatorsProvider
+
+	// Constants.
+	timelySourceWeight        uint64
+	timelyTargetWeight        uint64
+	timelyHeadWeight          uint64
+	proposerWeight            uint64
+	weightDenominator         uint64
+	maxAttestations           uint64
+	effectiveBalanceIncrement uint64
+	baseRewardFactor          uint64
+	maxEffectiveBalance       uint64
+
+	// Processing.
+	// Votes already included in blocks prior to the slot.
+	// Map is slot -> committee index -> validator committee index -> votes.
+	priorVotes votes
+	// Head roots provides the root of the head slot at given slots.
+	headRoots map[phase0.Slot]phase0.Root
+	// Target roots provides the root of the target epoch at given slots.
+	targetRoots map[phase0.Slot]phase0.Root
+
+	// Results.
+	advice *advice
+}
+
+type advice struct {
+	Slot                phase0.Slot         `json:"slot"`
+	Candidates          int                 `json:"candidates"`
+	BlockAttestations   int                 `json:"block_attestations"`
+	BlockValue          float64             `json:"block_value"`
+	BlockReward         phase0.Gwei         `json:"block_reward"`
+	OptimalAttestations int                 `json:"optimal_attestations"`
+	OptimalValue        float64             `json:"optimal_value"`
+	OptimalReward       phase0.Gwei         `json:"optimal_reward"`
+	Missed              []*attestationValue `json:"missed,omitempty"`
+}
+
+type attestationValue struct {
+	Slot           phase0.Slot           `json:"slot"`
+	CommitteeIndex phase0.CommitteeIndex `json:"committee_index"`
+	NewVotes       int                   `json:"new_votes"`
+	Value          float64               `json:"value"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:       viper.GetBool("quiet"),
+		verbose:     viper.GetBool("verbose"),
+		debug:       viper.GetBool("debug"),
+		json:        viper.GetBool("json"),
+		slot:        viper.GetString("slot"),
+		priorVotes:  make(votes),
+		headRoots:   make(map[phase0.Slot]phase0.Root),
+		targetRoots: make(map[phase0.Slot]phase0.Root),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if c.slot == "" {
+		return nil, errors.New("slot is required")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,72 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockpackingadvise
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+	if c.advice == nil {
+		return "", errors.New("no advice")
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.advice)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Slot: %d\n", c.advice.Slot))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Candidate attestations: %d\n", c.advice.Candidates))
+	}
+	builder.WriteString(fmt.Sprintf("Block: %d attestations with value %.2f (%d Gwei)\n", c.advice.BlockAttestations, c.advice.BlockValue, c.advice.BlockReward))
+	builder.WriteString(fmt.Sprintf("Optimal: %d attestations with value %.2f (%d Gwei)\n", c.advice.OptimalAttestations, c.advice.OptimalValue, c.advice.OptimalReward))
+
+	missedValue := c.advice.OptimalValue - c.advice.BlockValue
+	if missedValue <= 0 {
+		builder.WriteString("Block packing is optimal")
+		return builder.String(), nil
+	}
+	builder.WriteString(fmt.Sprintf("Missed: value %.2f (%d Gwei, %.1f%%)", missedValue, c.advice.OptimalReward-c.advice.BlockReward, 100*missedValue/c.advice.OptimalValue))
+	if c.verbose {
+		builder.WriteString("\nAttestations not in block:")
+		for _, missed := range c.advice.Missed {
+			builder.WriteString(fmt.Sprintf("\n  slot %d committee %d: %d new votes, value %.2f", missed.Slot, missed.CommitteeIndex, missed.NewVotes, missed.Value))
+		}
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,134 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockpackingadvise
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// votes are the attestation votes seen, by slot, committee index and
+// position in the committee.
+type votes map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist
+
+// candidate is an attestation that could be included in the block.
+type candidate struct {
+	attestation *phase0.Attestation
+	// score is the value of each new vote provided by the attestation.
+	score   float64
+	inBlock bool
+}
+
+// newVotes returns the number of votes in the attestation that are not
+// already present.
+func (v votes) newVotes(attestation *phase0.Attestation) int {
+	existing := v[attestation.Data.Slot][attestation.Data.Index]
+	res := 0
+	for i := uint64(0); i < attestation.AggregationBits.Len(); i++ {
+		if !attestation.AggregationBits.BitAt(i) {
+			continue
+		}
+		if existing != nil && existing.Len() == attestation.AggregationBits.Len() && existing.BitAt(i) {
+			continue
+		}
+		res++
+	}
+
+	return res
+}
+
+// add adds the votes in the attestation.
+func (v votes) add(attestation *phase0.Attestation) {
+	data := attestation.Data
+	if _, exists := v[data.Slot]; !exists {
+		v[data.Slot] = make(map[phase0.CommitteeIndex]bitfield.Bitlist)
+	}
+	if _, exists := v[data.Slot][data.Index]; !exists {
+		v[data.Slot][data.Index] = bitfield.NewBitlist(attestation.AggregationBits.Len())
+	}
+	existing := v[data.Slot][data.Index]
+	if existing.Len() != attestation.AggregationBits.Len() {
+		// Malformed attestation; ignore.
+		return
+	}
+	for i := uint64(0); i < attestation.AggregationBits.Len(); i++ {
+		if attestation.AggregationBits.BitAt(i) {
+			existing.SetBitAt(i, true)
+		}
+	}
+}
+
+// copy returns a deep copy of the votes.
+func (v votes) copy() votes {
+	res := make(votes, len(v))
+	for slot, committees := range v {
+		res[slot] = make(map[phase0.CommitteeIndex]bitfield.Bitlist, len(committees))
+		for index, bits := range committees {
+			res[slot][index] = append(bitfield.Bitlist{}, bits...)
+		}
+	}
+
+	return res
+}
+
+// evaluate returns the value of the attestations in the order given, taking
+// in to account the votes already present.
+func evaluate(attestations []*candidate, prior votes) ([]*attestationValue, float64) {
+	seen := prior.copy()
+	values := make([]*attestationValue, len(attestations))
+	total := 0.0
+	for i, candidate := range attestations {
+		newVotes := seen.newVotes(candidate.attestation)
+		values[i] = &attestationValue{
+			Slot:           candidate.attestation.Data.Slot,
+			CommitteeIndex: candidate.attestation.Data.Index,
+			NewVotes:       newVotes,
+			Value:          candidate.score * float64(newVotes),
+		}
+		total += values[i].Value
+		seen.add(candidate.attestation)
+	}
+
+	return values, total
+}
+
+// pack selects up to maxAttestations of the candidates to maximise the value
+// of the votes they provide.  Each step selects the candidate that adds the
+// most value given those already selected; this greedy approach is within a
+// constant factor of the optimal packing, and in practice matches it.
+func pack(candidates []*candidate, prior votes, maxAttestations uint64) []*candidate {
+	seen := prior.copy()
+	remaining := append([]*candidate{}, candidates...)
+	res := make([]*candidate, 0)
+	for uint64(len(res)) < maxAttestations && len(remaining) > 0 {
+		best := -1
+		bestValue := 0.0
+		for i, candidate := range remaining {
+			value := candidate.score * float64(seen.newVotes(candidate.attestation))
+			if value > bestValue {
+				best = i
+				bestValue = value
+			}
+		}
+		if best == -1 {
+			// Nothing left adds value.
+			break
+		}
+		res = append(res, remaining[best])
+		seen.add(remaining[best].attestation)
+		remaining = append(remaining[:best], remaining[best+1:]...)
+	}
+
+	return res
+}
@@ -0,0 +1,130 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockpackingadvise
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func testAttestation(slot phase0.Slot, index phase0.CommitteeIndex, bits ...uint64) *phase0.Attestation {
+	aggregationBits := bitfield.NewBitlist(8)
+	for _, bit := range bits {
+		aggregationBits.SetBitAt(bit, true)
+	}
+
+	return &phase0.Attestation{
+		AggregationBits: aggregationBits,
+		Data: &phase0.AttestationData{
+			Slot:  slot,
+			Index: index,
+		},
+	}
+}
+
+func TestVotes(t *testing.T) {
+	v := make(votes)
+	require.Equal(t, 3, v.newVotes(testAttestation(1, 0, 0, 1, 2)))
+
+	v.add(testAttestation(1, 0, 0, 1))
+	require.Equal(t, 1, v.newVotes(testAttestation(1, 0, 0, 1, 2)))
+	require.Equal(t, 3, v.newVotes(testAttestation(1, 1, 0, 1, 2)))
+
+	copied := v.copy()
+	copied.add(testAttestation(1, 0, 2))
+	require.Equal(t, 1, v.newVotes(testAttestation(1, 0, 0, 1, 2)))
+	require.Equal(t, 0, copied.newVotes(testAttestation(1, 0, 0, 1, 2)))
+}
+
+func TestPack(t *testing.T) {
+	small := &candidate{attestation: testAttestation(1, 0, 0, 1), score: 1}
+	large := &candidate{attestation: testAttestation(1, 0, 0, 1, 2, 3), score: 1}
+	other := &candidate{attestation: testAttestation(1, 1, 0), score: 1}
+	worthless := &candidate{attestation: testAttestation(1, 2, 0), score: 0}
+
+	tests := []struct {
+		name            string
+		candidates      []*candidate
+		prior           votes
+		maxAttestations uint64
+		res             []*candidate
+	}{
+		{
+			name:            "Empty",
+			prior:           make(votes),
+			maxAttestations: 128,
+			res:             []*candidate{},
+		},
+		{
+			name:            "Subsumed",
+			candidates:      []*candidate{small, large, other},
+			prior:           make(votes),
+			maxAttestations: 128,
+			res:             []*candidate{large, other},
+		},
+		{
+			name:            "Limited",
+			candidates:      []*candidate{small, other, large},
+			prior:           make(votes),
+			maxAttestations: 1,
+			res:             []*candidate{large},
+		},
+		{
+			name:       "Prior",
+			candidates: []*candidate{small, other},
+			prior: func() votes {
+				v := make(votes)
+				v.add(testAttestation(1, 0, 0, 1))
+				return v
+			}(),
+			maxAttestations: 128,
+			res:             []*candidate{other},
+		},
+		{
+			name:            "Worthless",
+			candidates:      []*candidate{worthless},
+			prior:           make(votes),
+			maxAttestations: 128,
+			res:             []*candidate{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, pack(test.candidates, test.prior, test.maxAttestations))
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	attestations := []*candidate{
+		{attestation: testAttestation(1, 0, 0, 1), score: 0.5},
+		{attestation: testAttestation(1, 0, 0, 1, 2), score: 0.5},
+	}
+	values, total := evaluate(attestations, make(votes))
+	require.Len(t, values, 2)
+	require.Equal(t, 2, values[0].NewVotes)
+	require.Equal(t, 1, values[1].NewVotes)
+	require.Equal(t, 1.5, total)
+}
+
+func TestProposerRewardPerValue(t *testing.T) {
+	require.Equal(t, 0.0, proposerRewardPerValue(0, 1000000000, 64, 32000000000, 8, 64))
+	// 2^20 validators with 32 ETH.
+	reward := proposerRewardPerValue(32000000000*1048576, 1000000000, 64, 32000000000, 8, 64)
+	require.InDelta(t, 1595.43, reward, 0.01)
+}
@@ -0,0 +1,386 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockpackingadvise
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	block, err := c.blocksProvider.SignedBeaconBlock(ctx, c.slot)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain beacon block")
+	}
+	if block == nil {
+		return errors.New("no block at slot")
+	}
+	slot, err := block.Slot()
+	if err != nil {
+		return err
+	}
+	blockAttestations, err := block.Attestations()
+	if err != nil {
+		return err
+	}
+
+	// Attestations can be included up to an epoch after their slot.
+	minSlot := phase0.Slot(0)
+	if uint64(slot) > c.chainTime.SlotsPerEpoch() {
+		minSlot = slot - phase0.Slot(c.chainTime.SlotsPerEpoch())
+	}
+	if err := c.fetchParents(ctx, block, minSlot); err != nil {
+		return err
+	}
+
+	candidates, actual, err := c.obtainCandidates(ctx, slot, minSlot, blockAttestations)
+	if err != nil {
+		return err
+	}
+	util.Log.Debug().Uint64("slot", uint64(slot)).Int("candidates", len(candidates)).Msg("Obtained candidate attestations")
+
+	rewardPerValue, err := c.rewardPerValue(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.advice = &advice{
+		Slot:              slot,
+		Candidates:        len(candidates),
+		BlockAttestations: len(actual),
+	}
+	_, c.advice.BlockValue = evaluate(actual, c.priorVotes)
+	c.advice.BlockReward = phase0.Gwei(c.advice.BlockValue * rewardPerValue)
+
+	optimal := pack(candidates, c.priorVotes, c.maxAttestations)
+	optimalValues, optimalValue := evaluate(optimal, c.priorVotes)
+	c.advice.OptimalAttestations = len(optimal)
+	c.advice.OptimalValue = optimalValue
+	c.advice.OptimalReward = phase0.Gwei(optimalValue * rewardPerValue)
+	for i, candidate := range optimal {
+		if !candidate.inBlock {
+			c.advice.Missed = append(c.advice.Missed, optimalValues[i])
+		}
+	}
+
+	return nil
+}
+
+// obtainCandidates obtains the attestations that could have been included in
+// the block, being those in the node's attestation pool along with those in
+// the block itself.  It also returns the block's attestations as candidates.
+func (c *command) obtainCandidates(ctx context.Context,
+	slot phase0.Slot,
+	minSlot phase0.Slot,
+	blockAttestations []*phase0.Attestation,
+) (
+	[]*candidate,
+	[]*candidate,
+	error,
+) {
+	candidates := make([]*candidate, 0)
+	seen := make(map[phase0.Root]bool)
+
+	actual := make([]*candidate, 0, len(blockAttestations))
+	for _, attestation := range blockAttestations {
+		candidate, err := c.newCandidate(ctx, slot, attestation)
+		if err != nil {
+			return nil, nil, err
+		}
+		candidate.inBlock = true
+		actual = append(actual, candidate)
+		root, err := attestation.HashTreeRoot()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to calculate attestation root")
+		}
+		if !seen[root] {
+			seen[root] = true
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	for attestationSlot := minSlot; attestationSlot < slot; attestationSlot++ {
+		attestations, err := c.attestationPoolProvider.AttestationPool(ctx, attestationSlot)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, fmt.Sprintf("failed to obtain attestation pool for slot %d", attestationSlot))
+		}
+		for _, attestation := range attestations {
+			if attestation.Data.Slot != attestationSlot {
+				continue
+			}
+			root, err := attestation.HashTreeRoot()
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "failed to calculate attestation root")
+			}
+			if seen[root] {
+				continue
+			}
+			seen[root] = true
+			candidate, err := c.newCandidate(ctx, slot, attestation)
+			if err != nil {
+				return nil, nil, err
+			}
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates, actual, nil
+}
+
+// newCandidate scores an attestation for inclusion in a block at the given slot.
+func (c *command) newCandidate(ctx context.Context, slot phase0.Slot, attestation *phase0.Attestation) (*candidate, error) {
+	res := &candidate{
+		attestation: attestation,
+	}
+
+	distance := slot - attestation.Data.Slot
+	targetCorrect, err := c.calcTargetCorrect(ctx, attestation)
+	if err != nil {
+		return nil, err
+	}
+	if targetCorrect && uint64(distance) <= c.chainTime.SlotsPerEpoch() {
+		res.score += float64(c.timelyTargetWeight) / float64(c.weightDenominator)
+	}
+	if distance <= 5 {
+		res.score += float64(c.timelySourceWeight) / float64(c.weightDenominator)
+	}
+	if distance == 1 {
+		headCorrect, err := c.calcHeadCorrect(ctx, attestation)
+		if err != nil {
+			return nil, err
+		}
+		if headCorrect {
+			res.score += float64(c.timelyHeadWeight) / float64(c.weightDenominator)
+		}
+	}
+
+	return res, nil
+}
+
+// rewardPerValue calculates the proposer reward, in Gwei, for each unit of
+// value, assuming that attesters have the maximum effective balance.
+func (c *command) rewardPerValue(ctx context.Context) (float64, error) {
+	// The total active balance is taken from the head state, so the reward is
+	// an estimate for historical slots.
+	validators, err := c.validatorsProvider.Validators(ctx, "head", nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain validators")
+	}
+	totalActiveBalance := uint64(0)
+	for _, validator := range validators {
+		if validator.Status.IsActive() {
+			totalActiveBalance += uint64(validator.Validator.EffectiveBalance)
+		}
+	}
+	util.Log.Debug().Uint64("total_active_balance", totalActiveBalance).Msg("Obtained total active balance")
+
+	return proposerRewardPerValue(totalActiveBalance,
+		c.effectiveBalanceIncrement,
+		c.baseRewardFactor,
+		c.maxEffectiveBalance,
+		c.proposerWeight,
+		c.weightDenominator,
+	), nil
+}
+
+// proposerRewardPerValue calculates the proposer reward for including a vote
+// with a weight of WEIGHT_DENOMINATOR, as per the Altair specification.
+func proposerRewardPerValue(totalActiveBalance uint64,
+	effectiveBalanceIncrement uint64,
+	baseRewardFactor uint64,
+	maxEffectiveBalance uint64,
+	proposerWeight uint64,
+	weightDenominator uint64,
+) float64 {
+	if totalActiveBalance == 0 || effectiveBalanceIncrement == 0 || proposerWeight >= weightDenominator {
+		return 0
+	}
+	sqrtBalance := new(big.Int).Sqrt(new(big.Int).SetUint64(totalActiveBalance)).Uint64()
+	baseRewardPerIncrement := effectiveBalanceIncrement * baseRewardFactor / sqrtBalance
+	baseReward := maxEffectiveBalance / effectiveBalanceIncrement * baseRewardPerIncrement
+
+	return float64(baseReward) * float64(proposerWeight) / float64(weightDenominator-proposerWeight)
+}
+
+func (c *command) fetchParents(ctx context.Context, block *spec.VersionedSignedBeaconBlock, minSlot phase0.Slot) error {
+	parentRoot, err := block.ParentRoot()
+	if err != nil {
+		return err
+	}
+
+	// Obtain the parent block.
+	parentBlock, err := c.blocksProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%#x", parentRoot))
+	if err != nil {
+		return err
+	}
+	if parentBlock == nil {
+		return fmt.Errorf("unable to obtain parent block %#x", parentRoot)
+	}
+
+	parentSlot, err := parentBlock.Slot()
+	if err != nil {
+		return err
+	}
+	if parentSlot < minSlot {
+		return nil
+	}
+
+	attestations, err := parentBlock.Attestations()
+	if err != nil {
+		return err
+	}
+	for _, attestation := range attestations {
+		c.priorVotes.add(attestation)
+	}
+
+	if parentSlot == 0 {
+		return nil
+	}
+
+	return c.fetchParents(ctx, parentBlock, minSlot)
+}
+
+func (c *command) calcHeadCorrect(ctx context.Context, attestation *phase0.Attestation) (bool, error) {
+	slot := attestation.Data.Slot
+	root, exists := c.headRoots[slot]
+	if !exists {
+		for {
+			header, err := c.blockHeadersProvider.BeaconBlockHeader(ctx, fmt.Sprintf("%d", slot))
+			if err != nil {
+				return false, nil
+			}
+			if header == nil || !header.Canonical {
+				// No canonical block.
+				slot--
+				continue
+			}
+			c.headRoots[attestation.Data.Slot] = header.Root
+			root = header.Root
+			break
+		}
+	}
+
+	return bytes.Equal(root[:], attestation.Data.BeaconBlockRoot[:]), nil
+}
+
+func (c *command) calcTargetCorrect(ctx context.Context, attestation *phase0.Attestation) (bool, error) {
+	root, exists := c.targetRoots[attestation.Data.Slot]
+	if !exists {
+		// Start with first slot of the target epoch.
+		slot := c.chainTime.FirstSlotOfEpoch(attestation.Data.Target.Epoch)
+		for {
+			header, err := c.blockHeadersProvider.BeaconBlockHeader(ctx, fmt.Sprintf("%d", slot))
+			if err != nil {
+				return false, nil
+			}
+			if header == nil || !header.Canonical {
+				// No canonical block.
+				slot--
+				continue
+			}
+			c.targetRoots[attestation.Data.Slot] = header.Root
+			root = header.Root
+			break
+		}
+	}
+
+	return bytes.Equal(root[:], attestation.Data.Target.Root[:]), nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon block information")
+	}
+	c.blockHeadersProvider, isProvider = c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon block header information")
+	}
+	c.attestationPoolProvider, isProvider = c.eth2Client.(eth2client.AttestationPoolProvider)
+	if !isProvider {
+		return errors.New("connection does not provide attestation pool information")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	specData, err := specProvider.Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+
+	// Defaults are based on the Altair spec.
+	for name, field := range map[string]struct {
+		value        *uint64
+		defaultValue uint64
+	}{
+		"TIMELY_SOURCE_WEIGHT":        {&c.timelySourceWeight, 14},
+		"TIMELY_TARGET_WEIGHT":        {&c.timelyTargetWeight, 26},
+		"TIMELY_HEAD_WEIGHT":          {&c.timelyHeadWeight, 14},
+		"PROPOSER_WEIGHT":             {&c.proposerWeight, 8},
+		"WEIGHT_DENOMINATOR":          {&c.weightDenominator, 64},
+		"MAX_ATTESTATIONS":            {&c.maxAttestations, 128},
+		"EFFECTIVE_BALANCE_INCREMENT": {&c.effectiveBalanceIncrement, 1000000000},
+		"BASE_REWARD_FACTOR":          {&c.baseRewardFactor, 64},
+		"MAX_EFFECTIVE_BALANCE":       {&c.maxEffectiveBalance, 32000000000},
+	} {
+		tmp, exists := specData[name]
+		if !exists {
+			*field.value = field.defaultValue
+			continue
+		}
+		val, isUint64 := tmp.(uint64)
+		if !isUint64 {
+			return fmt.Errorf("%s of unexpected type", name)
+		}
+		*field.value = val
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockpartialreplay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+type jsonOutput struct {
+	Slot            phase0.Slot `json:"slot"`
+	PreStateSlot    phase0.Slot `json:"pre_state_slot"`
+	EpochTransition bool        `json:"epoch_transition"`
+	Passed          bool        `json:"passed"`
+	Checks          []*check    `json:"checks"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		Slot:            c.block.Slot,
+		PreStateSlot:    c.preState.Slot,
+		EpochTransition: c.epochTransition,
+		Passed:          c.passed(),
+		Checks:          c.checks,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Partially replaying block at slot %d against pre-state at slot %d; committee membership and signatures are not checked\n", c.block.Slot, c.preState.Slot))
+	if c.epochTransition {
+		builder.WriteString("Epoch transition between pre-state and block is not replayed; checks that depend on it are skipped\n")
+	}
+	for _, check := range c.checks {
+		if check.Passed {
+			builder.WriteString(fmt.Sprintf("%s: passed", check.Name))
+			if c.verbose && check.Detail != "" {
+				builder.WriteString(fmt.Sprintf(" (%s)", check.Detail))
+			}
+		} else {
+			builder.WriteString(fmt.Sprintf("%s: FAILED (%s)", check.Name, check.Detail))
+		}
+		builder.WriteString("\n")
+	}
+
+	if c.passed() {
+		builder.WriteString("Block passed partial replay")
+	} else {
+		builder.WriteString("Block FAILED partial replay")
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,155 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockpartialreplay
+
+import (
+	"context"
+	"fmt"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	signedBlock, err := c.consensusClient.(consensusclient.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, c.blockID)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block")
+	}
+	if signedBlock == nil {
+		return errors.New("block not found")
+	}
+	if signedBlock.Version != spec.DataVersionCapella {
+		// Only the Capella state transition is replayed.
+		return fmt.Errorf("block is a %v block; only capella blocks can be replayed", signedBlock.Version)
+	}
+	if signedBlock.Capella == nil || signedBlock.Capella.Message == nil || signedBlock.Capella.Message.Body == nil {
+		return errors.New("no capella block")
+	}
+	c.block = signedBlock.Capella.Message
+
+	if err := c.obtainPreState(ctx); err != nil {
+		return err
+	}
+	c.epochTransition = c.params.epoch(c.preState.Slot) != c.params.epoch(c.block.Slot)
+	util.Log.Debug().Uint64("slot", uint64(c.block.Slot)).Uint64("pre_state_slot", uint64(c.preState.Slot)).Msg("Replaying block")
+
+	if err := c.replay(ctx); err != nil {
+		return err
+	}
+
+	return c.checkPostStateRoot(ctx)
+}
+
+// obtainPreState obtains the state following the block's parent, against
+// which the block is replayed.
+func (c *command) obtainPreState(ctx context.Context) error {
+	parent, err := c.consensusClient.(consensusclient.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, fmt.Sprintf("%#x", c.block.ParentRoot))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain parent block")
+	}
+	if parent == nil {
+		return errors.New("parent block not found")
+	}
+	parentSlot, err := parent.Slot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain parent block slot")
+	}
+
+	state, err := c.consensusClient.(consensusclient.BeaconStateProvider).BeaconState(ctx, fmt.Sprintf("%d", parentSlot))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain pre-state")
+	}
+	if state == nil {
+		return errors.New("pre-state not returned by beacon node")
+	}
+	// The Capella fork boundary requires an upgrade of the state, which is
+	// not replayed.
+	if state.Version != spec.DataVersionCapella || state.Capella == nil {
+		return fmt.Errorf("replay against a %v pre-state is not supported", state.Version)
+	}
+	c.preState = state.Capella
+
+	return nil
+}
+
+// checkPostStateRoot checks the root of the state following the block, as
+// served by the beacon node, against the state root committed to by the block.
+func (c *command) checkPostStateRoot(ctx context.Context) error {
+	state, err := c.consensusClient.(consensusclient.BeaconStateProvider).BeaconState(ctx, fmt.Sprintf("%d", c.block.Slot))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain post-state")
+	}
+	if state == nil || state.Capella == nil {
+		return errors.New("post-state not returned by beacon node")
+	}
+	root, err := state.Capella.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate post-state root")
+	}
+
+	if phase0.Root(root) != c.block.StateRoot {
+		c.addCheck("Post-state root", false, fmt.Sprintf("computed %#x, block has %#x", root, c.block.StateRoot))
+	} else {
+		c.addCheck("Post-state root", true, fmt.Sprintf("%#x", root))
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	if _, isProvider := c.consensusClient.(consensusclient.SignedBeaconBlockProvider); !isProvider {
+		return errors.New("consensus node does not provide blocks")
+	}
+	if _, isProvider := c.consensusClient.(consensusclient.BeaconStateProvider); !isProvider {
+		return errors.New("consensus node does not provide states")
+	}
+	specProvider, isProvider := c.consensusClient.(consensusclient.SpecProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide spec")
+	}
+	specData, err := specProvider.Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+
+	c.params, err = paramsFromSpec(specData)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain parameters from spec")
+	}
+
+	return nil
+}
+
+func (c *command) addCheck(name string, passed bool, detail string) {
+	c.checks = append(c.checks, &check{
+		Name:   name,
+		Passed: passed,
+		Detail: detail,
+	})
+}
@@ -0,0 +1,641 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockpartialreplay
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// farFutureEpoch is the epoch used to denote an unset epoch.
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+// depositContractTreeDepth is the depth of the deposit contract's Merkle tree.
+const depositContractTreeDepth = 32
+
+// params are the spec values used when replaying a block.
+type params struct {
+	slotsPerEpoch                    uint64
+	minAttestationInclusionDelay     uint64
+	shardCommitteePeriod             uint64
+	epochsPerETH1VotingPeriod        uint64
+	maxDeposits                      uint64
+	maxEffectiveBalance              uint64
+	maxWithdrawalsPerPayload         uint64
+	maxValidatorsPerWithdrawalsSweep uint64
+	genesisForkVersion               phase0.Version
+	beaconProposerDomainType         phase0.DomainType
+	voluntaryExitDomainType          phase0.DomainType
+	blsToExecutionChangeDomainType   phase0.DomainType
+}
+
+// paramsFromSpec obtains the parameters from the spec supplied by the beacon
+// node.  All parameters are required; there are no defaults, as a value for
+// the wrong network would silently produce incorrect results.
+func paramsFromSpec(specData map[string]interface{}) (*params, error) {
+	p := &params{}
+
+	for name, field := range map[string]*uint64{
+		"SLOTS_PER_EPOCH":                      &p.slotsPerEpoch,
+		"MIN_ATTESTATION_INCLUSION_DELAY":      &p.minAttestationInclusionDelay,
+		"SHARD_COMMITTEE_PERIOD":               &p.shardCommitteePeriod,
+		"EPOCHS_PER_ETH1_VOTING_PERIOD":        &p.epochsPerETH1VotingPeriod,
+		"MAX_DEPOSITS":                         &p.maxDeposits,
+		"MAX_EFFECTIVE_BALANCE":                &p.maxEffectiveBalance,
+		"MAX_WITHDRAWALS_PER_PAYLOAD":          &p.maxWithdrawalsPerPayload,
+		"MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP": &p.maxValidatorsPerWithdrawalsSweep,
+	} {
+		tmp, exists := specData[name]
+		if !exists {
+			return nil, fmt.Errorf("%s not found in spec", name)
+		}
+		val, good := tmp.(uint64)
+		if !good {
+			return nil, fmt.Errorf("%s value invalid", name)
+		}
+		*field = val
+	}
+	if p.slotsPerEpoch == 0 {
+		return nil, errors.New("SLOTS_PER_EPOCH cannot be 0")
+	}
+
+	tmp, exists := specData["GENESIS_FORK_VERSION"]
+	if !exists {
+		return nil, errors.New("GENESIS_FORK_VERSION not found in spec")
+	}
+	version, good := tmp.(phase0.Version)
+	if !good {
+		return nil, errors.New("GENESIS_FORK_VERSION value invalid")
+	}
+	p.genesisForkVersion = version
+
+	for name, field := range map[string]*phase0.DomainType{
+		"DOMAIN_BEACON_PROPOSER":         &p.beaconProposerDomainType,
+		"DOMAIN_VOLUNTARY_EXIT":          &p.voluntaryExitDomainType,
+		"DOMAIN_BLS_TO_EXECUTION_CHANGE": &p.blsToExecutionChangeDomainType,
+	} {
+		tmp, exists := specData[name]
+		if !exists {
+			return nil, fmt.Errorf("%s not found in spec", name)
+		}
+		domainType, good := tmp.(phase0.DomainType)
+		if !good {
+			return nil, fmt.Errorf("%s value invalid", name)
+		}
+		*field = domainType
+	}
+
+	return p, nil
+}
+
+// epoch is the spec's compute_epoch_at_slot().
+func (p *params) epoch(slot phase0.Slot) phase0.Epoch {
+	return phase0.Epoch(uint64(slot) / p.slotsPerEpoch)
+}
+
+// replay applies the block to the pre-state, recording the result of each
+// step of the state transition function in the order that a client carries
+// them out.  Unlike a client, replay continues after a failure so that the
+// results for all operations are available.
+func (c *command) replay(_ context.Context) error {
+	preStateRoot, err := c.preState.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate pre-state root")
+	}
+
+	c.processBlockHeader(preStateRoot)
+	if err := c.processWithdrawals(); err != nil {
+		return err
+	}
+	// Validators exited and credentials changed earlier in the block affect
+	// the processing of later operations.
+	exiting := make(map[phase0.ValidatorIndex]bool)
+	if err := c.processProposerSlashings(exiting); err != nil {
+		return err
+	}
+	c.processAttesterSlashings(exiting)
+	c.processAttestations()
+	if err := c.processDeposits(); err != nil {
+		return err
+	}
+	if err := c.processVoluntaryExits(exiting); err != nil {
+		return err
+	}
+
+	return c.processBLSToExecutionChanges()
+}
+
+// processBlockHeader is the spec's process_block_header().  The proposer index
+// is not checked, as that requires the shuffling.
+func (c *command) processBlockHeader(preStateRoot phase0.Root) {
+	name := "Block slot"
+	if c.block.Slot <= c.preState.LatestBlockHeader.Slot {
+		c.addCheck(name, false, fmt.Sprintf("block slot %d is not after parent slot %d", c.block.Slot, c.preState.LatestBlockHeader.Slot))
+	} else {
+		c.addCheck(name, true, fmt.Sprintf("slot %d", c.block.Slot))
+	}
+
+	// The state root of the latest block header is filled in by the first
+	// slot processed after the block.
+	header := *c.preState.LatestBlockHeader
+	if header.StateRoot == (phase0.Root{}) {
+		header.StateRoot = preStateRoot
+	}
+	name = "Parent root"
+	parentRoot, err := header.HashTreeRoot()
+	switch {
+	case err != nil:
+		c.addCheck(name, false, fmt.Sprintf("failed to calculate parent root: %v", err))
+	case phase0.Root(parentRoot) != c.block.ParentRoot:
+		c.addCheck(name, false, fmt.Sprintf("block has parent root %#x, pre-state has %#x", c.block.ParentRoot, parentRoot))
+	default:
+		c.addCheck(name, true, fmt.Sprintf("%#x", parentRoot))
+	}
+
+	name = "Proposer not slashed"
+	if uint64(c.block.ProposerIndex) >= uint64(len(c.preState.Validators)) {
+		c.addCheck(name, false, fmt.Sprintf("proposer %d unknown", c.block.ProposerIndex))
+	} else if c.preState.Validators[c.block.ProposerIndex].Slashed {
+		c.addCheck(name, false, fmt.Sprintf("proposer %d is slashed", c.block.ProposerIndex))
+	} else {
+		c.addCheck(name, true, fmt.Sprintf("proposer %d", c.block.ProposerIndex))
+	}
+}
+
+// processWithdrawals is the spec's process_withdrawals().
+func (c *command) processWithdrawals() error {
+	name := "Withdrawals"
+	if c.epochTransition {
+		c.addCheck(name, true, "skipped as balances are updated by the epoch transition")
+		return nil
+	}
+	if c.block.Body.ExecutionPayload == nil {
+		return errors.New("block has no execution payload")
+	}
+
+	expected := c.expectedWithdrawals()
+	actual := c.block.Body.ExecutionPayload.Withdrawals
+	if len(expected) != len(actual) {
+		c.addCheck(name, false, fmt.Sprintf("expected %d withdrawals, block has %d", len(expected), len(actual)))
+		return nil
+	}
+	for i := range expected {
+		if *expected[i] != *actual[i] {
+			c.addCheck(name, false, fmt.Sprintf("withdrawal %d: expected %d gwei to validator %d, block has %d gwei to validator %d", i, expected[i].Amount, expected[i].ValidatorIndex, actual[i].Amount, actual[i].ValidatorIndex))
+			return nil
+		}
+	}
+	c.addCheck(name, true, fmt.Sprintf("%d withdrawals", len(actual)))
+
+	return nil
+}
+
+// expectedWithdrawals is the spec's get_expected_withdrawals().
+func (c *command) expectedWithdrawals() []*capella.Withdrawal {
+	epoch := c.params.epoch(c.block.Slot)
+	validators := c.preState.Validators
+	withdrawalIndex := c.preState.NextWithdrawalIndex
+	validatorIndex := c.preState.NextWithdrawalValidatorIndex
+
+	res := make([]*capella.Withdrawal, 0)
+	if len(validators) == 0 {
+		return res
+	}
+	bound := uint64(len(validators))
+	if bound > c.params.maxValidatorsPerWithdrawalsSweep {
+		bound = c.params.maxValidatorsPerWithdrawalsSweep
+	}
+	for i := uint64(0); i < bound; i++ {
+		validator := validators[validatorIndex]
+		balance := c.preState.Balances[validatorIndex]
+		amount := phase0.Gwei(0)
+		if hasETH1WithdrawalCredential(validator) {
+			switch {
+			case validator.WithdrawableEpoch <= epoch && balance > 0:
+				amount = balance
+			case uint64(validator.EffectiveBalance) == c.params.maxEffectiveBalance && uint64(balance) > c.params.maxEffectiveBalance:
+				amount = balance - phase0.Gwei(c.params.maxEffectiveBalance)
+			}
+		}
+		if amount > 0 {
+			withdrawal := &capella.Withdrawal{
+				Index:          withdrawalIndex,
+				ValidatorIndex: validatorIndex,
+				Amount:         amount,
+			}
+			copy(withdrawal.Address[:], validator.WithdrawalCredentials[12:])
+			res = append(res, withdrawal)
+			withdrawalIndex++
+		}
+		if uint64(len(res)) == c.params.maxWithdrawalsPerPayload {
+			break
+		}
+		validatorIndex = phase0.ValidatorIndex((uint64(validatorIndex) + 1) % uint64(len(validators)))
+	}
+
+	return res
+}
+
+// processProposerSlashings is the spec's process_proposer_slashing() for each
+// proposer slashing in the block.
+func (c *command) processProposerSlashings(exiting map[phase0.ValidatorIndex]bool) error {
+	epoch := c.params.epoch(c.block.Slot)
+	for i, slashing := range c.block.Body.ProposerSlashings {
+		name := fmt.Sprintf("Proposer slashing %d", i)
+		header1 := slashing.SignedHeader1.Message
+		header2 := slashing.SignedHeader2.Message
+		if header1.Slot != header2.Slot {
+			c.addCheck(name, false, fmt.Sprintf("headers are for slots %d and %d", header1.Slot, header2.Slot))
+			continue
+		}
+		if header1.ProposerIndex != header2.ProposerIndex {
+			c.addCheck(name, false, fmt.Sprintf("headers are for proposers %d and %d", header1.ProposerIndex, header2.ProposerIndex))
+			continue
+		}
+		root1, err := header1.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "failed to calculate header root")
+		}
+		root2, err := header2.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "failed to calculate header root")
+		}
+		if root1 == root2 {
+			c.addCheck(name, false, "headers are identical")
+			continue
+		}
+		if !c.isSlashable(header1.ProposerIndex, epoch) {
+			c.addCheck(name, false, fmt.Sprintf("proposer %d is not slashable", header1.ProposerIndex))
+			continue
+		}
+		pubKey := c.preState.Validators[header1.ProposerIndex].PublicKey
+		forkVersion := c.forkVersion(c.params.epoch(header1.Slot))
+		valid := true
+		for _, header := range []*phase0.SignedBeaconBlockHeader{slashing.SignedHeader1, slashing.SignedHeader2} {
+			root, err := header.Message.HashTreeRoot()
+			if err != nil {
+				return errors.Wrap(err, "failed to calculate header root")
+			}
+			if err := c.verifySignature(root, header.Signature, pubKey, c.params.beaconProposerDomainType, forkVersion); err != nil {
+				c.addCheck(name, false, fmt.Sprintf("header for slot %d: %v", header.Message.Slot, err))
+				valid = false
+				break
+			}
+		}
+		if !valid {
+			continue
+		}
+		exiting[header1.ProposerIndex] = true
+		c.addCheck(name, true, fmt.Sprintf("slashes proposer %d", header1.ProposerIndex))
+	}
+
+	return nil
+}
+
+// processAttesterSlashings is the spec's process_attester_slashing() for each
+// attester slashing in the block.  The signatures of the indexed attestations
+// are not checked.
+func (c *command) processAttesterSlashings(exiting map[phase0.ValidatorIndex]bool) {
+	epoch := c.params.epoch(c.block.Slot)
+	for i, slashing := range c.block.Body.AttesterSlashings {
+		name := fmt.Sprintf("Attester slashing %d", i)
+		data1 := slashing.Attestation1.Data
+		data2 := slashing.Attestation2.Data
+		if !isSlashableAttestationData(data1, data2) {
+			c.addCheck(name, false, "attestation data is not slashable")
+			continue
+		}
+		if !sortedUnique(slashing.Attestation1.AttestingIndices) || !sortedUnique(slashing.Attestation2.AttestingIndices) {
+			c.addCheck(name, false, "attesting indices are not present, sorted and unique")
+			continue
+		}
+		slashed := make([]phase0.ValidatorIndex, 0)
+		indices := make(map[uint64]bool, len(slashing.Attestation1.AttestingIndices))
+		for _, index := range slashing.Attestation1.AttestingIndices {
+			indices[index] = true
+		}
+		for _, index := range slashing.Attestation2.AttestingIndices {
+			if indices[index] && c.isSlashable(phase0.ValidatorIndex(index), epoch) {
+				slashed = append(slashed, phase0.ValidatorIndex(index))
+			}
+		}
+		if len(slashed) == 0 {
+			c.addCheck(name, false, "no slashable validators")
+			continue
+		}
+		for _, index := range slashed {
+			exiting[index] = true
+		}
+		c.addCheck(name, true, fmt.Sprintf("slashes validators %v", slashed))
+	}
+}
+
+// processAttestations is the spec's process_attestation() for each attestation
+// in the block.  Committees and signatures are not checked.
+func (c *command) processAttestations() {
+	currentEpoch := c.params.epoch(c.block.Slot)
+	previousEpoch := currentEpoch
+	if previousEpoch > 0 {
+		previousEpoch--
+	}
+	for i, attestation := range c.block.Body.Attestations {
+		name := fmt.Sprintf("Attestation %d", i)
+		data := attestation.Data
+		if data.Target.Epoch != currentEpoch && data.Target.Epoch != previousEpoch {
+			c.addCheck(name, false, fmt.Sprintf("target epoch %d is not the current or previous epoch", data.Target.Epoch))
+			continue
+		}
+		if data.Target.Epoch != c.params.epoch(data.Slot) {
+			c.addCheck(name, false, fmt.Sprintf("target epoch %d does not match slot %d", data.Target.Epoch, data.Slot))
+			continue
+		}
+		if uint64(data.Slot)+c.params.minAttestationInclusionDelay > uint64(c.block.Slot) {
+			c.addCheck(name, false, fmt.Sprintf("attestation for slot %d included too early", data.Slot))
+			continue
+		}
+		if uint64(c.block.Slot) > uint64(data.Slot)+c.params.slotsPerEpoch {
+			c.addCheck(name, false, fmt.Sprintf("attestation for slot %d included too late", data.Slot))
+			continue
+		}
+		if !c.epochTransition {
+			justified := c.preState.PreviousJustifiedCheckpoint
+			if data.Target.Epoch == currentEpoch {
+				justified = c.preState.CurrentJustifiedCheckpoint
+			}
+			if data.Source.Epoch != justified.Epoch || data.Source.Root != justified.Root {
+				c.addCheck(name, false, fmt.Sprintf("source %d/%#x does not match justified checkpoint %d/%#x", data.Source.Epoch, data.Source.Root, justified.Epoch, justified.Root))
+				continue
+			}
+		}
+		c.addCheck(name, true, fmt.Sprintf("slot %d, committee %d", data.Slot, data.Index))
+	}
+}
+
+// processDeposits checks the number of deposits in the block and is the spec's
+// process_deposit() for each deposit.
+func (c *command) processDeposits() error {
+	// The block's ETH1 data vote may change the ETH1 data before operations
+	// are processed.
+	eth1Data := c.preState.ETH1Data
+	if !c.epochTransition {
+		votes := uint64(1)
+		for _, vote := range c.preState.ETH1DataVotes {
+			if eth1DataEqual(vote, c.block.Body.ETH1Data) {
+				votes++
+			}
+		}
+		if votes*2 > c.params.epochsPerETH1VotingPeriod*c.params.slotsPerEpoch {
+			eth1Data = c.block.Body.ETH1Data
+		}
+	}
+
+	depositIndex := c.preState.ETH1DepositIndex
+	expected := eth1Data.DepositCount - depositIndex
+	if expected > c.params.maxDeposits {
+		expected = c.params.maxDeposits
+	}
+	name := "Deposit count"
+	if uint64(len(c.block.Body.Deposits)) != expected {
+		c.addCheck(name, false, fmt.Sprintf("expected %d deposits, block has %d", expected, len(c.block.Body.Deposits)))
+	} else {
+		c.addCheck(name, true, fmt.Sprintf("%d deposits", expected))
+	}
+
+	for i, deposit := range c.block.Body.Deposits {
+		name := fmt.Sprintf("Deposit %d", i)
+		leaf, err := deposit.Data.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "failed to calculate deposit data root")
+		}
+		if !isValidMerkleBranch(leaf, deposit.Proof, depositContractTreeDepth+1, depositIndex, eth1Data.DepositRoot) {
+			c.addCheck(name, false, fmt.Sprintf("invalid proof for deposit index %d", depositIndex))
+		} else {
+			c.addCheck(name, true, fmt.Sprintf("deposit index %d for %#x", depositIndex, deposit.Data.PublicKey))
+		}
+		depositIndex++
+	}
+
+	return nil
+}
+
+// processVoluntaryExits is the spec's process_voluntary_exit() for each
+// voluntary exit in the block.
+func (c *command) processVoluntaryExits(exiting map[phase0.ValidatorIndex]bool) error {
+	currentEpoch := c.params.epoch(c.block.Slot)
+	for i, exit := range c.block.Body.VoluntaryExits {
+		name := fmt.Sprintf("Voluntary exit %d", i)
+		message := exit.Message
+		if uint64(message.ValidatorIndex) >= uint64(len(c.preState.Validators)) {
+			c.addCheck(name, false, fmt.Sprintf("validator %d unknown", message.ValidatorIndex))
+			continue
+		}
+		validator := c.preState.Validators[message.ValidatorIndex]
+		if validator.ActivationEpoch > currentEpoch || currentEpoch >= validator.ExitEpoch {
+			c.addCheck(name, false, fmt.Sprintf("validator %d is not active", message.ValidatorIndex))
+			continue
+		}
+		if validator.ExitEpoch != farFutureEpoch || exiting[message.ValidatorIndex] {
+			c.addCheck(name, false, fmt.Sprintf("validator %d is already exiting", message.ValidatorIndex))
+			continue
+		}
+		if currentEpoch < message.Epoch {
+			c.addCheck(name, false, fmt.Sprintf("exit is not valid until epoch %d", message.Epoch))
+			continue
+		}
+		if uint64(currentEpoch) < uint64(validator.ActivationEpoch)+c.params.shardCommitteePeriod {
+			c.addCheck(name, false, fmt.Sprintf("validator %d has not been active long enough", message.ValidatorIndex))
+			continue
+		}
+		root, err := message.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "failed to calculate exit root")
+		}
+		if err := c.verifySignature(root, exit.Signature, validator.PublicKey, c.params.voluntaryExitDomainType, c.forkVersion(message.Epoch)); err != nil {
+			c.addCheck(name, false, fmt.Sprintf("validator %d: %v", message.ValidatorIndex, err))
+			continue
+		}
+		exiting[message.ValidatorIndex] = true
+		c.addCheck(name, true, fmt.Sprintf("validator %d", message.ValidatorIndex))
+	}
+
+	return nil
+}
+
+// processBLSToExecutionChanges is the spec's process_bls_to_execution_change()
+// for each credentials change in the block.
+func (c *command) processBLSToExecutionChanges() error {
+	changed := make(map[phase0.ValidatorIndex]bool)
+	for i, change := range c.block.Body.BLSToExecutionChanges {
+		name := fmt.Sprintf("Credentials change %d", i)
+		message := change.Message
+		if uint64(message.ValidatorIndex) >= uint64(len(c.preState.Validators)) {
+			c.addCheck(name, false, fmt.Sprintf("validator %d unknown", message.ValidatorIndex))
+			continue
+		}
+		credentials := c.preState.Validators[message.ValidatorIndex].WithdrawalCredentials
+		if credentials[0] != 0x00 || changed[message.ValidatorIndex] {
+			c.addCheck(name, false, fmt.Sprintf("validator %d does not have BLS withdrawal credentials", message.ValidatorIndex))
+			continue
+		}
+		pubKeyHash := sha256.Sum256(message.FromBLSPubkey[:])
+		if !bytes.Equal(credentials[1:], pubKeyHash[1:]) {
+			c.addCheck(name, false, fmt.Sprintf("public key does not match withdrawal credentials of validator %d", message.ValidatorIndex))
+			continue
+		}
+		root, err := message.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "failed to calculate credentials change root")
+		}
+		// Credentials changes are always signed with the genesis fork version.
+		if err := c.verifySignature(root, change.Signature, message.FromBLSPubkey, c.params.blsToExecutionChangeDomainType, c.params.genesisForkVersion); err != nil {
+			c.addCheck(name, false, fmt.Sprintf("validator %d: %v", message.ValidatorIndex, err))
+			continue
+		}
+		changed[message.ValidatorIndex] = true
+		c.addCheck(name, true, fmt.Sprintf("validator %d to %#x", message.ValidatorIndex, message.ToExecutionAddress))
+	}
+
+	return nil
+}
+
+// isSlashable is the spec's is_slashable_validator().
+func (c *command) isSlashable(index phase0.ValidatorIndex, epoch phase0.Epoch) bool {
+	if uint64(index) >= uint64(len(c.preState.Validators)) {
+		return false
+	}
+	validator := c.preState.Validators[index]
+
+	return !validator.Slashed && validator.ActivationEpoch <= epoch && epoch < validator.WithdrawableEpoch
+}
+
+// forkVersion provides the fork version of the pre-state for the given epoch,
+// as used by the spec's get_domain().
+func (c *command) forkVersion(epoch phase0.Epoch) phase0.Version {
+	if epoch < c.preState.Fork.Epoch {
+		return c.preState.Fork.PreviousVersion
+	}
+
+	return c.preState.Fork.CurrentVersion
+}
+
+// verifySignature verifies a signature over a root.
+func (c *command) verifySignature(root phase0.Root,
+	signature phase0.BLSSignature,
+	pubKey phase0.BLSPubKey,
+	domainType phase0.DomainType,
+	forkVersion phase0.Version,
+) error {
+	forkDataRoot, err := (&phase0.ForkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: c.preState.GenesisValidatorsRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate signature domain")
+	}
+	var domain phase0.Domain
+	copy(domain[:], domainType[:])
+	copy(domain[4:], forkDataRoot[:])
+
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate signing root")
+	}
+
+	key, err := e2types.BLSPublicKeyFromBytes(pubKey[:])
+	if err != nil {
+		return errors.Wrap(err, "invalid public key")
+	}
+	sig, err := e2types.BLSSignatureFromBytes(signature[:])
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	if !sig.Verify(signingRoot[:], key) {
+		return errors.New("signature does not verify")
+	}
+
+	return nil
+}
+
+// hasETH1WithdrawalCredential is the spec's has_eth1_withdrawal_credential().
+func hasETH1WithdrawalCredential(validator *phase0.Validator) bool {
+	return len(validator.WithdrawalCredentials) == 32 && validator.WithdrawalCredentials[0] == 0x01
+}
+
+// isSlashableAttestationData is the spec's is_slashable_attestation_data().
+func isSlashableAttestationData(data1 *phase0.AttestationData, data2 *phase0.AttestationData) bool {
+	// Double vote.
+	if !attestationDataEqual(data1, data2) && data1.Target.Epoch == data2.Target.Epoch {
+		return true
+	}
+
+	// Surround vote.
+	return data1.Source.Epoch < data2.Source.Epoch && data2.Target.Epoch < data1.Target.Epoch
+}
+
+func attestationDataEqual(data1 *phase0.AttestationData, data2 *phase0.AttestationData) bool {
+	return data1.Slot == data2.Slot &&
+		data1.Index == data2.Index &&
+		data1.BeaconBlockRoot == data2.BeaconBlockRoot &&
+		*data1.Source == *data2.Source &&
+		*data1.Target == *data2.Target
+}
+
+func eth1DataEqual(data1 *phase0.ETH1Data, data2 *phase0.ETH1Data) bool {
+	return data1.DepositRoot == data2.DepositRoot &&
+		data1.DepositCount == data2.DepositCount &&
+		bytes.Equal(data1.BlockHash, data2.BlockHash)
+}
+
+// sortedUnique returns true if the indices are present, sorted and unique.
+func sortedUnique(indices []uint64) bool {
+	if len(indices) == 0 {
+		return false
+	}
+	for i := 1; i < len(indices); i++ {
+		if indices[i] <= indices[i-1] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isValidMerkleBranch is the spec's is_valid_merkle_branch().
+func isValidMerkleBranch(leaf phase0.Root, branch [][]byte, depth uint64, index uint64, root phase0.Root) bool {
+	if uint64(len(branch)) != depth {
+		return false
+	}
+	value := leaf[:]
+	for i := uint64(0); i < depth; i++ {
+		var hash [32]byte
+		if (index>>i)&1 == 1 {
+			hash = sha256.Sum256(append(append([]byte{}, branch[i]...), value...))
+		} else {
+			hash = sha256.Sum256(append(append([]byte{}, value...), branch[i]...))
+		}
+		value = hash[:]
+	}
+
+	return bytes.Equal(value, root[:])
+}
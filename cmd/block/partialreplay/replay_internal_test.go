@@ -0,0 +1,215 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockpartialreplay
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+// mainnetSpec returns the parts of the mainnet spec used by the replay.
+func mainnetSpec() map[string]interface{} {
+	return map[string]interface{}{
+		"SLOTS_PER_EPOCH":                      uint64(32),
+		"MIN_ATTESTATION_INCLUSION_DELAY":      uint64(1),
+		"SHARD_COMMITTEE_PERIOD":               uint64(256),
+		"EPOCHS_PER_ETH1_VOTING_PERIOD":        uint64(64),
+		"MAX_DEPOSITS":                         uint64(16),
+		"MAX_EFFECTIVE_BALANCE":                uint64(32000000000),
+		"MAX_WITHDRAWALS_PER_PAYLOAD":          uint64(16),
+		"MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP": uint64(16384),
+		"GENESIS_FORK_VERSION":                 phase0.Version{0x00, 0x00, 0x00, 0x00},
+		"DOMAIN_BEACON_PROPOSER":               phase0.DomainType{0x00, 0x00, 0x00, 0x00},
+		"DOMAIN_VOLUNTARY_EXIT":                phase0.DomainType{0x04, 0x00, 0x00, 0x00},
+		"DOMAIN_BLS_TO_EXECUTION_CHANGE":       phase0.DomainType{0x0a, 0x00, 0x00, 0x00},
+	}
+}
+
+func testParams(t *testing.T) *params {
+	t.Helper()
+	p, err := paramsFromSpec(mainnetSpec())
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestParamsFromSpec(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(map[string]interface{})
+		err    string
+	}{
+		{
+			name:   "Good",
+			modify: func(map[string]interface{}) {},
+		},
+		{
+			name:   "SlotsPerEpochMissing",
+			modify: func(specData map[string]interface{}) { delete(specData, "SLOTS_PER_EPOCH") },
+			err:    "SLOTS_PER_EPOCH not found in spec",
+		},
+		{
+			name:   "SlotsPerEpochZero",
+			modify: func(specData map[string]interface{}) { specData["SLOTS_PER_EPOCH"] = uint64(0) },
+			err:    "SLOTS_PER_EPOCH cannot be 0",
+		},
+		{
+			name:   "GenesisForkVersionMissing",
+			modify: func(specData map[string]interface{}) { delete(specData, "GENESIS_FORK_VERSION") },
+			err:    "GENESIS_FORK_VERSION not found in spec",
+		},
+		{
+			name:   "DomainInvalid",
+			modify: func(specData map[string]interface{}) { specData["DOMAIN_VOLUNTARY_EXIT"] = "0x04000000" },
+			err:    "DOMAIN_VOLUNTARY_EXIT value invalid",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			specData := mainnetSpec()
+			test.modify(specData)
+			p, err := paramsFromSpec(specData)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, uint64(32), p.slotsPerEpoch)
+				require.Equal(t, phase0.DomainType{0x0a, 0x00, 0x00, 0x00}, p.blsToExecutionChangeDomainType)
+			}
+		})
+	}
+}
+
+func TestIsValidMerkleBranch(t *testing.T) {
+	leaf := phase0.Root{0x01}
+	sibling := []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	// Leaf at index 0 is hashed on the left, at index 1 on the right.
+	left := sha256.Sum256(append(append([]byte{}, leaf[:]...), sibling...))
+	right := sha256.Sum256(append(append([]byte{}, sibling...), leaf[:]...))
+
+	require.True(t, isValidMerkleBranch(leaf, [][]byte{sibling}, 1, 0, left))
+	require.True(t, isValidMerkleBranch(leaf, [][]byte{sibling}, 1, 1, right))
+	require.False(t, isValidMerkleBranch(leaf, [][]byte{sibling}, 1, 1, left))
+	require.False(t, isValidMerkleBranch(leaf, [][]byte{sibling}, 2, 0, left))
+}
+
+func TestIsSlashableAttestationData(t *testing.T) {
+	data := func(source phase0.Epoch, target phase0.Epoch, root byte) *phase0.AttestationData {
+		return &phase0.AttestationData{
+			Slot:            phase0.Slot(uint64(target) * 32),
+			BeaconBlockRoot: phase0.Root{root},
+			Source:          &phase0.Checkpoint{Epoch: source},
+			Target:          &phase0.Checkpoint{Epoch: target},
+		}
+	}
+
+	require.False(t, isSlashableAttestationData(data(1, 2, 0x01), data(1, 2, 0x01)))
+	require.True(t, isSlashableAttestationData(data(1, 2, 0x01), data(1, 2, 0x02)))
+	require.True(t, isSlashableAttestationData(data(1, 5, 0x01), data(2, 4, 0x01)))
+	require.False(t, isSlashableAttestationData(data(2, 4, 0x01), data(1, 5, 0x01)))
+	require.False(t, isSlashableAttestationData(data(1, 2, 0x01), data(2, 3, 0x01)))
+}
+
+func TestSortedUnique(t *testing.T) {
+	require.False(t, sortedUnique(nil))
+	require.True(t, sortedUnique([]uint64{1}))
+	require.True(t, sortedUnique([]uint64{1, 2, 5}))
+	require.False(t, sortedUnique([]uint64{1, 1, 5}))
+	require.False(t, sortedUnique([]uint64{2, 1}))
+}
+
+func TestExpectedWithdrawals(t *testing.T) {
+	eth1Credentials := func(address byte) []byte {
+		credentials := make([]byte, 32)
+		credentials[0] = 0x01
+		credentials[31] = address
+		return credentials
+	}
+
+	c := &command{
+		params: testParams(t),
+		block:  &capella.BeaconBlock{Slot: 320},
+		preState: &capella.BeaconState{
+			Validators: []*phase0.Validator{
+				// BLS credentials; never withdrawn.
+				{WithdrawalCredentials: make([]byte, 32), EffectiveBalance: 32000000000, WithdrawableEpoch: farFutureEpoch},
+				// Partial withdrawal.
+				{WithdrawalCredentials: eth1Credentials(0x01), EffectiveBalance: 32000000000, WithdrawableEpoch: farFutureEpoch},
+				// Full withdrawal.
+				{WithdrawalCredentials: eth1Credentials(0x02), EffectiveBalance: 0, WithdrawableEpoch: 5},
+				// Not yet withdrawable.
+				{WithdrawalCredentials: eth1Credentials(0x03), EffectiveBalance: 31000000000, WithdrawableEpoch: farFutureEpoch},
+			},
+			Balances:                     []phase0.Gwei{32100000000, 32100000000, 1000, 31000000000},
+			NextWithdrawalIndex:          10,
+			NextWithdrawalValidatorIndex: 2,
+		},
+	}
+
+	withdrawals := c.expectedWithdrawals()
+	require.Len(t, withdrawals, 2)
+	require.Equal(t, capella.WithdrawalIndex(10), withdrawals[0].Index)
+	require.Equal(t, phase0.ValidatorIndex(2), withdrawals[0].ValidatorIndex)
+	require.Equal(t, phase0.Gwei(1000), withdrawals[0].Amount)
+	require.Equal(t, byte(0x02), withdrawals[0].Address[19])
+	require.Equal(t, capella.WithdrawalIndex(11), withdrawals[1].Index)
+	require.Equal(t, phase0.ValidatorIndex(1), withdrawals[1].ValidatorIndex)
+	require.Equal(t, phase0.Gwei(100000000), withdrawals[1].Amount)
+}
+
+func TestProcessAttestations(t *testing.T) {
+	justified := &phase0.Checkpoint{Epoch: 9, Root: phase0.Root{0x09}}
+	attestation := func(slot phase0.Slot, target phase0.Epoch, source *phase0.Checkpoint) *phase0.Attestation {
+		return &phase0.Attestation{
+			Data: &phase0.AttestationData{
+				Slot:   slot,
+				Source: source,
+				Target: &phase0.Checkpoint{Epoch: target},
+			},
+		}
+	}
+
+	c := &command{
+		params: testParams(t),
+		block: &capella.BeaconBlock{
+			Slot: 330,
+			Body: &capella.BeaconBlockBody{
+				Attestations: []*phase0.Attestation{
+					attestation(329, 10, justified),
+					attestation(330, 10, justified),
+					attestation(329, 9, justified),
+					attestation(329, 10, &phase0.Checkpoint{Epoch: 8}),
+				},
+			},
+		},
+		preState: &capella.BeaconState{
+			CurrentJustifiedCheckpoint:  justified,
+			PreviousJustifiedCheckpoint: &phase0.Checkpoint{Epoch: 8},
+		},
+	}
+
+	c.processAttestations()
+	require.Len(t, c.checks, 4)
+	require.True(t, c.checks[0].Passed)
+	require.False(t, c.checks[1].Passed)
+	require.Equal(t, "attestation for slot 330 included too early", c.checks[1].Detail)
+	require.False(t, c.checks[2].Passed)
+	require.Equal(t, "target epoch 9 does not match slot 329", c.checks[2].Detail)
+	require.False(t, c.checks[3].Passed)
+}
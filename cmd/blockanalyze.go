@@ -14,7 +14,7 @@
 package cmd
 
 import (
-	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -38,7 +38,9 @@ In quiet mode this will return 0 if the block information is present and not ski
 			return nil
 		}
 		if res != "" {
-			fmt.Print(res)
+			if err := outputResult(strings.TrimSuffix(res, "\n")); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	blockbids "github.com/wealdtech/ethdo/cmd/block/bids"
+)
+
+var blockBidsCmd = &cobra.Command{
+	Use:   "bids",
+	Short: "Compare relay bids for a block",
+	Long: `Compare the bids made by MEV relays for a block with the value the proposer obtained.  For example:
+
+    ethdo block bids --blockid=6000000 --relays=https://relay1.example.com,https://relay2.example.com --local-value=0.02Ether
+
+If the block was not delivered by any of the relays it is assumed to have been built locally, in which case its value is that supplied by --local-value.
+
+In quiet mode this will return 0 if the proposer did not lose value, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := blockbids.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	blockCmd.AddCommand(blockBidsCmd)
+	blockFlags(blockBidsCmd)
+	blockBidsCmd.Flags().String("blockid", "head", "the ID of the block for which to compare bids")
+	blockBidsCmd.Flags().StringSlice("relays", nil, "the URLs of the relays to query")
+	blockBidsCmd.Flags().String("local-value", "", "the value of the locally-built payload for the slot, for example 0.02Ether")
+	blockBidsCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func blockBidsBindings() {
+	if err := viper.BindPFlag("blockid", blockBidsCmd.Flags().Lookup("blockid")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("relays", blockBidsCmd.Flags().Lookup("relays")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("local-value", blockBidsCmd.Flags().Lookup("local-value")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", blockBidsCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
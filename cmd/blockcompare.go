@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	blockcompare "github.com/wealdtech/ethdo/cmd/block/compare"
+)
+
+var blockCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare a block between two beacon nodes",
+	Long: `Compare a block as returned by two beacon nodes, showing each field that differs.  For example:
+
+    ethdo block compare --connection=http://node1:5052 --connection2=http://node2:5052 --blockid=12345
+
+If --state is supplied the state roots of the two nodes at the slot of the block are also compared.
+
+In quiet mode this will return 0 if the blocks are identical, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := blockcompare.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	blockCmd.AddCommand(blockCompareCmd)
+	blockFlags(blockCompareCmd)
+	blockCompareCmd.Flags().String("connection2", "", "the connection to the beacon node whose block to compare against")
+	blockCompareCmd.Flags().String("blockid", "head", "the ID of the block to compare")
+	blockCompareCmd.Flags().Bool("state", false, "also compare the state roots at the slot of the block")
+	blockCompareCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func blockCompareBindings() {
+	if err := viper.BindPFlag("connection2", blockCompareCmd.Flags().Lookup("connection2")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("blockid", blockCompareCmd.Flags().Lookup("blockid")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("state", blockCompareCmd.Flags().Lookup("state")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", blockCompareCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
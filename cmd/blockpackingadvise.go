@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	blockpackingadvise "github.com/wealdtech/ethdo/cmd/block/packing/advise"
+)
+
+var blockPackingAdviseCmd = &cobra.Command{
+	Use:   "advise",
+	Short: "Compare the attestations in a block with an optimal packing",
+	Long: `Compare the attestations in a block with an optimal packing of the attestations available to the node.  For example:
+
+    ethdo block packing advise --slot=12345
+
+The candidate attestations are those in the node's attestation pool that could have been included in the block, along with those in the block itself.  Attestations are valued by the votes they add that are not present in earlier blocks, weighted by the timeliness and correctness of their source, target and head, and the optimal packing is the set that maximises this value.  The shortfall of the block is shown in value and as an estimate of the proposer reward forgone.
+
+As nodes prune their attestation pools the results are most useful for recent slots.
+
+In quiet mode this will return 0 if the advice can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := blockpackingadvise.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	blockPackingCmd.AddCommand(blockPackingAdviseCmd)
+	blockFlags(blockPackingAdviseCmd)
+	blockPackingAdviseCmd.Flags().String("slot", "head", "the slot of the block to compare")
+	blockPackingAdviseCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func blockPackingAdviseBindings() {
+	if err := viper.BindPFlag("slot", blockPackingAdviseCmd.Flags().Lookup("slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", blockPackingAdviseCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	blockpartialreplay "github.com/wealdtech/ethdo/cmd/block/partialreplay"
+)
+
+var blockPartialReplayCmd = &cobra.Command{
+	Use:   "partialreplay",
+	Short: "Partially replay a block against its pre-state",
+	Long: `Partially replay a block against the state following its parent, using a subset of the checks of the state transition function.  For example:
+
+    ethdo block partialreplay --blockid=12345
+
+The result shows the outcome of processing the block header, withdrawals, and each proposer slashing, attester slashing, attestation, deposit, voluntary exit and credentials change in the block, followed by a comparison of the root of the post-state with the state root in the block.  Unlike a client, the replay continues after a failure so that results are available for every operation.
+
+This is not a full state transition, and a block that passes may still be invalid:
+  - only Capella blocks can be replayed; blocks from other forks are rejected
+  - epoch processing is not carried out, so if there is an epoch transition between the parent and the block the checks that depend on it are skipped
+  - committee membership is not checked
+  - signatures are not checked; use "chain verify block" to verify the signatures in a block
+
+blockid can be a slot, a block root, or one of "head", "finalized" or "genesis".  The chain parameters used by the replay are taken from the spec supplied by the beacon node.
+
+In quiet mode this will return 0 if the block passes the partial replay, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := blockpartialreplay.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	blockCmd.AddCommand(blockPartialReplayCmd)
+	blockFlags(blockPartialReplayCmd)
+	blockPartialReplayCmd.Flags().String("blockid", "head", "the ID of the block to replay")
+	blockPartialReplayCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func blockPartialReplayBindings() {
+	if err := viper.BindPFlag("blockid", blockPartialReplayCmd.Flags().Lookup("blockid")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", blockPartialReplayCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainchurn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type jsonOutput struct {
+	ActiveValidators             uint64      `json:"active_validators"`
+	ActiveBalance                phase0.Gwei `json:"active_balance"`
+	ActivationChurn              uint64      `json:"activation_churn"`
+	ActivationBalanceChurn       phase0.Gwei `json:"activation_balance_churn"`
+	ActivationChurnPerDay        uint64      `json:"activation_churn_per_day"`
+	ActivationBalanceChurnPerDay phase0.Gwei `json:"activation_balance_churn_per_day"`
+	ExitChurn                    uint64      `json:"exit_churn"`
+	ExitBalanceChurn             phase0.Gwei `json:"exit_balance_churn"`
+	ExitChurnPerDay              uint64      `json:"exit_churn_per_day"`
+	ExitBalanceChurnPerDay       phase0.Gwei `json:"exit_balance_churn_per_day"`
+	BalanceChurn                 phase0.Gwei `json:"balance_churn,omitempty"`
+	ConsolidationBalanceChurn    phase0.Gwei `json:"consolidation_balance_churn,omitempty"`
+	MaxDepositsPerEpoch          uint64      `json:"max_deposits_per_epoch,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	epochsPerDay := c.epochsPerDay()
+	output := &jsonOutput{
+		ActiveValidators:             c.activeValidators,
+		ActiveBalance:                c.totalActiveBalance,
+		ActivationChurn:              c.activationChurn,
+		ActivationBalanceChurn:       c.activationBalanceChurn,
+		ActivationChurnPerDay:        c.activationChurn * epochsPerDay,
+		ActivationBalanceChurnPerDay: c.activationBalanceChurn * phase0.Gwei(epochsPerDay),
+		ExitChurn:                    c.exitChurn,
+		ExitBalanceChurn:             c.exitBalanceChurn,
+		ExitChurnPerDay:              c.exitChurn * epochsPerDay,
+		ExitBalanceChurnPerDay:       c.exitBalanceChurn * phase0.Gwei(epochsPerDay),
+		BalanceChurn:                 c.balanceChurn,
+		ConsolidationBalanceChurn:    c.consolidationBalanceChurn,
+		MaxDepositsPerEpoch:          c.maxDepositsPerEpoch,
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	epochsPerDay := c.epochsPerDay()
+	builder.WriteString(fmt.Sprintf("Active validators: %d\n", c.activeValidators))
+	builder.WriteString(fmt.Sprintf("Active balance: %s\n", string2eth.GWeiToString(uint64(c.totalActiveBalance), true)))
+	if c.electra {
+		builder.WriteString(fmt.Sprintf("Balance churn: %s per epoch\n", string2eth.GWeiToString(uint64(c.balanceChurn), true)))
+	}
+	builder.WriteString(churnText("Activation", c.activationChurn, c.activationBalanceChurn, epochsPerDay))
+	builder.WriteString(churnText("Exit", c.exitChurn, c.exitBalanceChurn, epochsPerDay))
+	if c.electra {
+		builder.WriteString(fmt.Sprintf("Consolidation churn: %s per epoch; %s per day\n",
+			string2eth.GWeiToString(uint64(c.consolidationBalanceChurn), true),
+			string2eth.GWeiToString(uint64(c.consolidationBalanceChurn)*epochsPerDay, true),
+		))
+		builder.WriteString(fmt.Sprintf("Deposit processing: up to %d deposits per epoch; %d per day\n", c.maxDepositsPerEpoch, c.maxDepositsPerEpoch*epochsPerDay))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func churnText(name string, churn uint64, balanceChurn phase0.Gwei, epochsPerDay uint64) string {
+	return fmt.Sprintf("%s churn: %d validators (%s) per epoch; %d validators (%s) per day\n",
+		name,
+		churn,
+		string2eth.GWeiToString(uint64(balanceChurn), true),
+		churn*epochsPerDay,
+		string2eth.GWeiToString(uint64(balanceChurn)*epochsPerDay, true),
+	)
+}
@@ -0,0 +1,90 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainchurn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet:            true,
+				activeValidators: 1000000,
+			},
+		},
+		{
+			name: "Text",
+			c: &command{
+				epochDuration:          384 * time.Second,
+				activeValidators:       1000000,
+				totalActiveBalance:     32000000000000000,
+				activationChurn:        8,
+				activationBalanceChurn: 256000000000,
+				exitChurn:              15,
+				exitBalanceChurn:       480000000000,
+			},
+			res: "Active validators: 1000000\nActive balance: 32000000 Ether\nActivation churn: 8 validators (256 Ether) per epoch; 1800 validators (57600 Ether) per day\nExit churn: 15 validators (480 Ether) per epoch; 3375 validators (108000 Ether) per day",
+		},
+		{
+			name: "TextElectra",
+			c: &command{
+				electra:                   true,
+				epochDuration:             384 * time.Second,
+				activeValidators:          1000000,
+				totalActiveBalance:        32000000000000000,
+				activationChurn:           8,
+				activationBalanceChurn:    256000000000,
+				exitChurn:                 8,
+				exitBalanceChurn:          256000000000,
+				balanceChurn:              488000000000,
+				consolidationBalanceChurn: 232000000000,
+				maxDepositsPerEpoch:       16,
+			},
+			res: "Active validators: 1000000\nActive balance: 32000000 Ether\nBalance churn: 488 Ether per epoch\nActivation churn: 8 validators (256 Ether) per epoch; 1800 validators (57600 Ether) per day\nExit churn: 8 validators (256 Ether) per epoch; 1800 validators (57600 Ether) per day\nConsolidation churn: 232 Ether per epoch; 52200 Ether per day\nDeposit processing: up to 16 deposits per epoch; 3600 per day",
+		},
+		{
+			name: "JSON",
+			c: &command{
+				json:                   true,
+				epochDuration:          384 * time.Second,
+				activeValidators:       1000000,
+				totalActiveBalance:     32000000000000000,
+				activationChurn:        8,
+				activationBalanceChurn: 256000000000,
+				exitChurn:              15,
+				exitBalanceChurn:       480000000000,
+			},
+			res: `{"active_validators":1000000,"active_balance":32000000000000000,"activation_churn":8,"activation_balance_churn":256000000000,"activation_churn_per_day":1800,"activation_balance_churn_per_day":57600000000000,"exit_churn":15,"exit_balance_churn":480000000000,"exit_churn_per_day":3375,"exit_balance_churn_per_day":108000000000000}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainchurn
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	epoch, err := util.ParseEpoch(ctx, c.chainTime, c.epoch)
+	if err != nil {
+		return err
+	}
+
+	validators, err := c.validatorsProvider.Validators(ctx, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch)), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+
+	for _, validator := range validators {
+		if validator.Validator == nil {
+			continue
+		}
+		if validator.Validator.ActivationEpoch <= epoch && validator.Validator.ExitEpoch > epoch {
+			c.activeValidators++
+			c.totalActiveBalance += validator.Validator.EffectiveBalance
+		}
+	}
+
+	spec, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+
+	c.epochDuration = c.chainTime.SlotDuration() * time.Duration(c.chainTime.SlotsPerEpoch())
+
+	return c.calculateChurn(spec, epoch)
+}
+
+// calculateChurn calculates the churn limits at the given epoch.
+func (c *command) calculateChurn(spec map[string]interface{}, epoch phase0.Epoch) error {
+	churn, err := beacon.CalculateChurn(spec, epoch, c.activeValidators, c.totalActiveBalance)
+	if err != nil {
+		return err
+	}
+
+	c.electra = churn.Electra
+	c.activationChurn = churn.ActivationChurn
+	c.activationBalanceChurn = churn.ActivationBalanceChurn
+	c.exitChurn = churn.ExitChurn
+	c.exitBalanceChurn = churn.ExitBalanceChurn
+	c.balanceChurn = churn.BalanceChurn
+	c.consolidationBalanceChurn = churn.ConsolidationBalanceChurn
+	c.maxDepositsPerEpoch = churn.MaxDepositsPerEpoch
+
+	return nil
+}
+
+// epochsPerDay calculates the number of epochs in a day.
+func (c *command) epochsPerDay() uint64 {
+	if c.epochDuration == 0 {
+		return 0
+	}
+	return uint64(24 * time.Hour / c.epochDuration)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindecentralization
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/pkg/errors"
+)
+
+// executionClients are the two-letter execution client codes used in graffiti.
+var executionClients = map[string]string{
+	"BU": "Besu",
+	"EG": "Erigon",
+	"GE": "Geth",
+	"NM": "Nethermind",
+	"RH": "Reth",
+}
+
+// consensusClients are the two-letter consensus client codes used in graffiti.
+var consensusClients = map[string]string{
+	"GD": "Grandine",
+	"LH": "Lighthouse",
+	"LS": "Lodestar",
+	"NB": "Nimbus",
+	"PM": "Prysm",
+	"TK": "Teku",
+}
+
+// clientGraffiti matches the client version graffiti added by consensus
+// clients, for example "GE1a2bLH3c4d".
+var clientGraffiti = regexp.MustCompile(`^(BU|EG|GE|NM|RH)[0-9a-f]{0,8}(GD|LH|LS|NB|PM|TK)[0-9a-f]{0,8}`)
+
+// unknownClient is the name used when the client cannot be identified.
+const unknownClient = "unknown"
+
+// clientsFromGraffiti returns the consensus and execution clients identified
+// in graffiti.  Either may be unknown.
+func clientsFromGraffiti(graffiti []byte) (string, string) {
+	text := strings.TrimSpace(string(bytes.TrimRight(graffiti, "\x00")))
+
+	if match := clientGraffiti.FindStringSubmatch(text); match != nil {
+		return consensusClients[match[2]], executionClients[match[1]]
+	}
+
+	// Fall back to looking for a client name anywhere in the graffiti.
+	lower := strings.ToLower(text)
+	for _, code := range []string{"GD", "LH", "LS", "NB", "PM", "TK"} {
+		if strings.Contains(lower, strings.ToLower(consensusClients[code])) {
+			return consensusClients[code], unknownClient
+		}
+	}
+
+	return unknownClient, unknownClient
+}
+
+// blockGraffiti returns the graffiti of a block.
+func blockGraffiti(block *spec.VersionedSignedBeaconBlock) ([]byte, error) {
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		if block.Phase0 == nil || block.Phase0.Message == nil || block.Phase0.Message.Body == nil {
+			return nil, errors.New("no phase0 block")
+		}
+		return block.Phase0.Message.Body.Graffiti[:], nil
+	case spec.DataVersionAltair:
+		if block.Altair == nil || block.Altair.Message == nil || block.Altair.Message.Body == nil {
+			return nil, errors.New("no altair block")
+		}
+		return block.Altair.Message.Body.Graffiti[:], nil
+	case spec.DataVersionBellatrix:
+		if block.Bellatrix == nil || block.Bellatrix.Message == nil || block.Bellatrix.Message.Body == nil {
+			return nil, errors.New("no bellatrix block")
+		}
+		return block.Bellatrix.Message.Body.Graffiti[:], nil
+	case spec.DataVersionCapella:
+		if block.Capella == nil || block.Capella.Message == nil || block.Capella.Message.Body == nil {
+			return nil, errors.New("no capella block")
+		}
+		return block.Capella.Message.Body.Graffiti[:], nil
+	default:
+		return nil, errors.New("unhandled block version")
+	}
+}
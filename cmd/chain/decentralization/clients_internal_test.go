@@ -0,0 +1,80 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindecentralization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientsFromGraffiti(t *testing.T) {
+	tests := []struct {
+		name      string
+		graffiti  []byte
+		consensus string
+		execution string
+	}{
+		{
+			name:      "Empty",
+			graffiti:  make([]byte, 32),
+			consensus: "unknown",
+			execution: "unknown",
+		},
+		{
+			name:      "Codes",
+			graffiti:  []byte("GELH"),
+			consensus: "Lighthouse",
+			execution: "Geth",
+		},
+		{
+			name:      "CodesWithVersions",
+			graffiti:  []byte("NM1a2b3c4dTKe5f6a7b8 my validator"),
+			consensus: "Teku",
+			execution: "Nethermind",
+		},
+		{
+			name:      "Padded",
+			graffiti:  append([]byte("RHPM"), make([]byte, 28)...),
+			consensus: "Prysm",
+			execution: "Reth",
+		},
+		{
+			name:      "Name",
+			graffiti:  []byte("Lighthouse/v4.5.0"),
+			consensus: "Lighthouse",
+			execution: "unknown",
+		},
+		{
+			name:      "NameMixedCase",
+			graffiti:  []byte("powered by NIMBUS"),
+			consensus: "Nimbus",
+			execution: "unknown",
+		},
+		{
+			name:      "Unknown",
+			graffiti:  []byte("hello world"),
+			consensus: "unknown",
+			execution: "unknown",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			consensus, execution := clientsFromGraffiti(test.graffiti)
+			require.Equal(t, test.consensus, consensus)
+			require.Equal(t, test.execution, execution)
+		})
+	}
+}
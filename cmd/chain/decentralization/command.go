@@ -0,0 +1,91 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindecentralization
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Execution node connection.
+	executionConnection string
+
+	// Input.
+	labelsFile string
+	epochs     uint64
+
+	// Data access.
+	eth2Client                eth2client.Service
+	validatorsProvider        eth2client.ValidatorsProvider
+	signedBeaconBlockProvider eth2client.SignedBeaconBlockProvider
+	executionClient           *util.ExecutionClient
+	chainTime                 chaintime.Service
+
+	// Processing.
+	labels *labels
+
+	// Output.
+	validators        int
+	withdrawal        *distribution
+	deposit           *distribution
+	entity            *distribution
+	entityCoverage    float64
+	clients           map[string]int
+	clientsBlocks     int
+	clientsStartEpoch uint64
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.epochs = viper.GetUint64("epochs")
+	if c.epochs == 0 {
+		return nil, errors.New("epochs must be at least 1")
+	}
+
+	c.labelsFile = viper.GetString("labels")
+	c.connection = viper.GetString("connection")
+	c.executionConnection = viper.GetString("execution-connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	return c, nil
+}
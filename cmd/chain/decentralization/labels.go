@@ -0,0 +1,156 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindecentralization
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// labels map validators to the entities that operate them.
+type labels struct {
+	indices   map[phase0.ValidatorIndex]string
+	pubkeys   map[phase0.BLSPubKey]string
+	addresses map[bellatrix.ExecutionAddress]string
+}
+
+func (c *command) loadLabels(_ context.Context) error {
+	f, err := os.Open(c.labelsFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to open labels")
+	}
+	defer f.Close()
+
+	c.labels, err = parseLabels(f)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse labels")
+	}
+
+	util.Log.Debug().Int("labels", len(c.labels.indices)+len(c.labels.pubkeys)+len(c.labels.addresses)).Msg("Loaded labels")
+
+	return nil
+}
+
+// parseLabels parses CSV data of the form "validator,entity", where validator
+// is a validator index, a validator public key or a withdrawal address.  A
+// header line is permitted.
+func parseLabels(input io.Reader) (*labels, error) {
+	reader := csv.NewReader(input)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	res := &labels{
+		indices:   make(map[phase0.ValidatorIndex]string),
+		pubkeys:   make(map[phase0.BLSPubKey]string),
+		addresses: make(map[bellatrix.ExecutionAddress]string),
+	}
+	entries := 0
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		key := strings.TrimSpace(record[0])
+		entity := strings.TrimSpace(record[1])
+		if entity == "" {
+			return nil, fmt.Errorf("line %d: entity missing", line)
+		}
+
+		if !strings.HasPrefix(key, "0x") {
+			index, err := strconv.ParseUint(key, 10, 64)
+			if err != nil {
+				if first {
+					// Assume this is a header.
+					continue
+				}
+				return nil, fmt.Errorf("line %d: invalid validator %q", line, key)
+			}
+			res.indices[phase0.ValidatorIndex(index)] = entity
+			entries++
+			continue
+		}
+
+		data, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid validator %q", line, key)
+		}
+		switch len(data) {
+		case phase0.PublicKeyLength:
+			var pubkey phase0.BLSPubKey
+			copy(pubkey[:], data)
+			res.pubkeys[pubkey] = entity
+		case bellatrix.ExecutionAddressLength:
+			var address bellatrix.ExecutionAddress
+			copy(address[:], data)
+			res.addresses[address] = entity
+		default:
+			return nil, fmt.Errorf("line %d: %q is neither a public key nor an address", line, key)
+		}
+		entries++
+	}
+
+	if entries == 0 {
+		return nil, errors.New("no labels present")
+	}
+
+	return res, nil
+}
+
+// entity returns the entity for a validator, or an empty string if the
+// validator is not labelled.  Labels for indices take precedence over those
+// for public keys, which take precedence over those for addresses.
+func (l *labels) entity(index phase0.ValidatorIndex, pubkey phase0.BLSPubKey, withdrawalCredentials []byte) string {
+	if entity, exists := l.indices[index]; exists {
+		return entity
+	}
+	if entity, exists := l.pubkeys[pubkey]; exists {
+		return entity
+	}
+	if address, isAddress := withdrawalAddress(withdrawalCredentials); isAddress {
+		if entity, exists := l.addresses[address]; exists {
+			return entity
+		}
+	}
+
+	return ""
+}
+
+// withdrawalAddress returns the execution address in withdrawal credentials,
+// if present.
+func withdrawalAddress(withdrawalCredentials []byte) (bellatrix.ExecutionAddress, bool) {
+	var address bellatrix.ExecutionAddress
+	if len(withdrawalCredentials) != 32 || withdrawalCredentials[0] == 0x00 {
+		return address, false
+	}
+	copy(address[:], withdrawalCredentials[12:])
+
+	return address, true
+}
@@ -0,0 +1,116 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindecentralization
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		indices   int
+		pubkeys   int
+		addresses int
+		err       string
+	}{
+		{
+			name:  "Empty",
+			input: "",
+			err:   "no labels present",
+		},
+		{
+			name:  "HeaderOnly",
+			input: "validator,entity\n",
+			err:   "no labels present",
+		},
+		{
+			name:  "EntityMissing",
+			input: "1,\n",
+			err:   "line 1: entity missing",
+		},
+		{
+			name:  "ValidatorInvalid",
+			input: "validator,entity\n1,A\nbad,B\n",
+			err:   `line 3: invalid validator "bad"`,
+		},
+		{
+			name:  "HexInvalid",
+			input: "0xzz,A\n",
+			err:   `line 1: invalid validator "0xzz"`,
+		},
+		{
+			name:  "HexLength",
+			input: "0x0102,A\n",
+			err:   `line 1: "0x0102" is neither a public key nor an address`,
+		},
+		{
+			name:  "FieldsMissing",
+			input: "1\n",
+			err:   "record on line 1: wrong number of fields",
+		},
+		{
+			name:    "Index",
+			input:   "validator,entity\n1,A\n2, B\n",
+			indices: 2,
+		},
+		{
+			name: "Mixed",
+			input: `# Comment
+1,A
+0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c,B
+0x8c1ff2f1a1e8bd5c8e0f1e5f3b2cdaa1b4e1c9e2,C
+`,
+			indices:   1,
+			pubkeys:   1,
+			addresses: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseLabels(strings.NewReader(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res.indices, test.indices)
+				require.Len(t, res.pubkeys, test.pubkeys)
+				require.Len(t, res.addresses, test.addresses)
+			}
+		})
+	}
+}
+
+func TestLabelsEntity(t *testing.T) {
+	labels, err := parseLabels(strings.NewReader(`1,A
+0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c,B
+0x8c1ff2f1a1e8bd5c8e0f1e5f3b2cdaa1b4e1c9e2,C
+`))
+	require.NoError(t, err)
+
+	labelledPubkey := phase0.BLSPubKey{0xa9, 0x9a, 0x76, 0xed, 0x77, 0x96, 0xf7, 0xbe, 0x22, 0xd5, 0xb7, 0xe8, 0x5d, 0xee, 0xb7, 0xc5, 0x67, 0x7e, 0x88, 0xe5, 0x11, 0xe0, 0xb3, 0x37, 0x61, 0x8f, 0x8c, 0x4e, 0xb6, 0x13, 0x49, 0xb4, 0xbf, 0x2d, 0x15, 0x3f, 0x64, 0x9f, 0x7b, 0x53, 0x35, 0x9f, 0xe8, 0xb9, 0x4a, 0x38, 0xe4, 0x4c}
+	addressCredentials := []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x8c, 0x1f, 0xf2, 0xf1, 0xa1, 0xe8, 0xbd, 0x5c, 0x8e, 0x0f, 0x1e, 0x5f, 0x3b, 0x2c, 0xda, 0xa1, 0xb4, 0xe1, 0xc9, 0xe2}
+	blsCredentials := make([]byte, 32)
+
+	require.Equal(t, "A", labels.entity(1, labelledPubkey, addressCredentials))
+	require.Equal(t, "B", labels.entity(2, labelledPubkey, addressCredentials))
+	require.Equal(t, "C", labels.entity(3, phase0.BLSPubKey{}, addressCredentials))
+	require.Equal(t, "", labels.entity(3, phase0.BLSPubKey{}, blsCredentials))
+}
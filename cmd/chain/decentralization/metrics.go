@@ -0,0 +1,104 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindecentralization
+
+import (
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// distribution provides metrics for the distribution of stake between groups.
+type distribution struct {
+	// Groups is the number of groups.
+	Groups int `json:"groups"`
+	// Gini is the Gini coefficient of stake per group, from 0 (equal stake)
+	// to 1 (all stake in a single group).
+	Gini float64 `json:"gini"`
+	// Nakamoto is the minimum number of groups that together hold more
+	// than a third of the stake.
+	Nakamoto int `json:"nakamoto"`
+	// LargestShare is the share of stake held by the largest group.
+	LargestShare float64 `json:"largest_share"`
+	// Largest is the name of the largest group.
+	Largest string `json:"largest"`
+}
+
+// newDistribution calculates the distribution of the given stake per group.
+func newDistribution(stakes map[string]phase0.Gwei) *distribution {
+	res := &distribution{
+		Groups: len(stakes),
+	}
+	if len(stakes) == 0 {
+		return res
+	}
+
+	type group struct {
+		name  string
+		stake phase0.Gwei
+	}
+	groups := make([]*group, 0, len(stakes))
+	total := phase0.Gwei(0)
+	for name, stake := range stakes {
+		groups = append(groups, &group{name: name, stake: stake})
+		total += stake
+	}
+	if total == 0 {
+		return res
+	}
+	// Sort by stake, descending, with name as a tiebreaker for consistency.
+	sort.Slice(groups, func(i int, j int) bool {
+		if groups[i].stake != groups[j].stake {
+			return groups[i].stake > groups[j].stake
+		}
+		return groups[i].name < groups[j].name
+	})
+
+	res.Largest = groups[0].name
+	res.LargestShare = float64(groups[0].stake) / float64(total)
+
+	cumulative := phase0.Gwei(0)
+	for i, g := range groups {
+		cumulative += g.stake
+		if cumulative*3 > total {
+			res.Nakamoto = i + 1
+			break
+		}
+	}
+
+	values := make([]phase0.Gwei, len(groups))
+	for i := range groups {
+		// Gini requires values in ascending order.
+		values[len(groups)-1-i] = groups[i].stake
+	}
+	res.Gini = gini(values, total)
+
+	return res
+}
+
+// gini calculates the Gini coefficient of the supplied values, which must be
+// in ascending order and sum to total.
+func gini(values []phase0.Gwei, total phase0.Gwei) float64 {
+	if len(values) == 0 || total == 0 {
+		return 0
+	}
+
+	weighted := float64(0)
+	for i, value := range values {
+		weighted += float64(i+1) * float64(value)
+	}
+	n := float64(len(values))
+
+	return (2*weighted)/(n*float64(total)) - (n+1)/n
+}
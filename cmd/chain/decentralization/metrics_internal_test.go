@@ -0,0 +1,107 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindecentralization
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDistribution(t *testing.T) {
+	tests := []struct {
+		name     string
+		stakes   map[string]phase0.Gwei
+		expected *distribution
+	}{
+		{
+			name:     "Empty",
+			stakes:   map[string]phase0.Gwei{},
+			expected: &distribution{},
+		},
+		{
+			name: "Single",
+			stakes: map[string]phase0.Gwei{
+				"a": 32000000000,
+			},
+			expected: &distribution{
+				Groups:       1,
+				Gini:         0,
+				Nakamoto:     1,
+				LargestShare: 1,
+				Largest:      "a",
+			},
+		},
+		{
+			name: "Equal",
+			stakes: map[string]phase0.Gwei{
+				"a": 32000000000,
+				"b": 32000000000,
+				"c": 32000000000,
+				"d": 32000000000,
+			},
+			expected: &distribution{
+				Groups:       4,
+				Gini:         0,
+				Nakamoto:     2,
+				LargestShare: 0.25,
+				Largest:      "a",
+			},
+		},
+		{
+			name: "Unequal",
+			stakes: map[string]phase0.Gwei{
+				"a": 0,
+				"b": 0,
+				"c": 0,
+				"d": 100,
+			},
+			expected: &distribution{
+				Groups:       4,
+				Gini:         0.75,
+				Nakamoto:     1,
+				LargestShare: 1,
+				Largest:      "d",
+			},
+		},
+		{
+			name: "Mixed",
+			stakes: map[string]phase0.Gwei{
+				"a": 10,
+				"b": 20,
+				"c": 30,
+				"d": 40,
+			},
+			expected: &distribution{
+				Groups:       4,
+				Gini:         0.25,
+				Nakamoto:     1,
+				LargestShare: 0.4,
+				Largest:      "d",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := newDistribution(test.stakes)
+			require.Equal(t, test.expected.Groups, res.Groups)
+			require.InDelta(t, test.expected.Gini, res.Gini, 0.0001)
+			require.Equal(t, test.expected.Nakamoto, res.Nakamoto)
+			require.InDelta(t, test.expected.LargestShare, res.LargestShare, 0.0001)
+			require.Equal(t, test.expected.Largest, res.Largest)
+		})
+	}
+}
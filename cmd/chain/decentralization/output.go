@@ -0,0 +1,110 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindecentralization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type outputJSON struct {
+	Validators     int            `json:"validators"`
+	Withdrawal     *distribution  `json:"withdrawal_address"`
+	Deposit        *distribution  `json:"deposit_address,omitempty"`
+	Entity         *distribution  `json:"entity,omitempty"`
+	EntityCoverage *float64       `json:"entity_coverage,omitempty"`
+	Clients        map[string]int `json:"clients"`
+	ClientsBlocks  int            `json:"clients_blocks"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &outputJSON{
+		Validators:    c.validators,
+		Withdrawal:    c.withdrawal,
+		Deposit:       c.deposit,
+		Entity:        c.entity,
+		Clients:       c.clients,
+		ClientsBlocks: c.clientsBlocks,
+	}
+	if c.entity != nil {
+		output.EntityCoverage = &c.entityCoverage
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Active validators: %d\n", c.validators))
+	c.outputDistribution(&builder, "Withdrawal addresses", c.withdrawal)
+	if c.deposit != nil {
+		c.outputDistribution(&builder, "Deposit addresses", c.deposit)
+	}
+	if c.entity != nil {
+		c.outputDistribution(&builder, "Entities", c.entity)
+		builder.WriteString(fmt.Sprintf("  Labelled stake: %.2f%%\n", c.entityCoverage*100))
+	}
+
+	builder.WriteString(fmt.Sprintf("Consensus clients (%d blocks from epoch %d):\n", c.clientsBlocks, c.clientsStartEpoch))
+	names := make([]string, 0, len(c.clients))
+	for name := range c.clients {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i int, j int) bool {
+		if c.clients[names[i]] != c.clients[names[j]] {
+			return c.clients[names[i]] > c.clients[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		share := float64(0)
+		if c.clientsBlocks > 0 {
+			share = float64(c.clients[name]) * 100 / float64(c.clientsBlocks)
+		}
+		builder.WriteString(fmt.Sprintf("  %s: %d (%.2f%%)\n", name, c.clients[name], share))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (c *command) outputDistribution(builder *strings.Builder, name string, dist *distribution) {
+	builder.WriteString(fmt.Sprintf("%s: %d\n", name, dist.Groups))
+	builder.WriteString(fmt.Sprintf("  Gini coefficient: %.4f\n", dist.Gini))
+	builder.WriteString(fmt.Sprintf("  Nakamoto coefficient: %d\n", dist.Nakamoto))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("  Largest: %s (%.2f%%)\n", dist.Largest, dist.LargestShare*100))
+	} else {
+		builder.WriteString(fmt.Sprintf("  Largest share: %.2f%%\n", dist.LargestShare*100))
+	}
+}
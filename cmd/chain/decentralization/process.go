@@ -0,0 +1,228 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindecentralization
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if c.labelsFile != "" {
+		if err := c.loadLabels(ctx); err != nil {
+			return err
+		}
+	}
+
+	validators, err := c.validatorsProvider.Validators(ctx, "head", nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+	active := make([]*apiv1.Validator, 0, len(validators))
+	for _, validator := range validators {
+		if validator.Validator != nil && validator.Status.IsActive() {
+			active = append(active, validator)
+		}
+	}
+	if len(active) == 0 {
+		return errors.New("no active validators")
+	}
+	c.validators = len(active)
+	util.Log.Debug().Int("validators", len(active)).Msg("Obtained active validators")
+
+	c.withdrawal = newDistribution(withdrawalStakes(active))
+
+	if c.labels != nil {
+		stakes, coverage := c.entityStakes(active)
+		c.entity = newDistribution(stakes)
+		c.entityCoverage = coverage
+	}
+
+	if c.executionClient != nil {
+		stakes, err := c.depositStakes(ctx, active)
+		if err != nil {
+			return err
+		}
+		c.deposit = newDistribution(stakes)
+	}
+
+	return c.sampleClients(ctx)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.signedBeaconBlockProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon block information")
+	}
+
+	if c.executionConnection != "" {
+		c.executionClient, err = util.ConnectToExecutionNode(ctx, c.executionConnection, c.timeout, c.allowInsecureConnections)
+		if err != nil {
+			return errors.Wrap(err, "failed to connect to execution node")
+		}
+	}
+
+	return nil
+}
+
+// unknownDepositor is the name used for validators without a deposit transaction.
+const unknownDepositor = "unknown"
+
+// withdrawalStakes returns the effective balance of validators grouped by
+// withdrawal address.  Validators with BLS withdrawal credentials are grouped
+// by their full credentials.
+func withdrawalStakes(validators []*apiv1.Validator) map[string]phase0.Gwei {
+	stakes := make(map[string]phase0.Gwei)
+	for _, validator := range validators {
+		key := fmt.Sprintf("%#x", validator.Validator.WithdrawalCredentials)
+		if address, isAddress := withdrawalAddress(validator.Validator.WithdrawalCredentials); isAddress {
+			key = address.String()
+		}
+		stakes[key] += validator.Validator.EffectiveBalance
+	}
+
+	return stakes
+}
+
+// entityStakes returns the effective balance of labelled validators grouped
+// by entity, along with the share of total stake that is labelled.
+func (c *command) entityStakes(validators []*apiv1.Validator) (map[string]phase0.Gwei, float64) {
+	stakes := make(map[string]phase0.Gwei)
+	total := phase0.Gwei(0)
+	labelled := phase0.Gwei(0)
+	for _, validator := range validators {
+		total += validator.Validator.EffectiveBalance
+		entity := c.labels.entity(validator.Index, validator.Validator.PublicKey, validator.Validator.WithdrawalCredentials)
+		if entity == "" {
+			continue
+		}
+		stakes[entity] += validator.Validator.EffectiveBalance
+		labelled += validator.Validator.EffectiveBalance
+	}
+	if total == 0 {
+		return stakes, 0
+	}
+
+	return stakes, float64(labelled) / float64(total)
+}
+
+// depositStakes returns the effective balance of validators grouped by the
+// address that sent their first deposit.
+func (c *command) depositStakes(ctx context.Context, validators []*apiv1.Validator) (map[string]phase0.Gwei, error) {
+	deposits, err := c.executionClient.Deposits(ctx, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain deposits from execution node")
+	}
+	util.Log.Debug().Int("deposits", len(deposits)).Msg("Obtained deposits from execution node")
+
+	// Deposits are in order, so the first seen for each public key is the
+	// one that created the validator.
+	firstDeposits := make(map[phase0.BLSPubKey][]byte)
+	for _, deposit := range deposits {
+		var pubkey phase0.BLSPubKey
+		copy(pubkey[:], deposit.PublicKey)
+		if _, exists := firstDeposits[pubkey]; !exists {
+			firstDeposits[pubkey] = deposit.TransactionHash
+		}
+	}
+
+	senders := make(map[string]string)
+	stakes := make(map[string]phase0.Gwei)
+	for _, validator := range validators {
+		txHash, exists := firstDeposits[validator.Validator.PublicKey]
+		if !exists {
+			// Genesis validators on some networks have no deposit transaction.
+			stakes[unknownDepositor] += validator.Validator.EffectiveBalance
+			continue
+		}
+		sender, exists := senders[string(txHash)]
+		if !exists {
+			from, err := c.executionClient.TransactionSender(ctx, txHash)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to obtain deposit sender")
+			}
+			sender = fmt.Sprintf("%#x", from)
+			senders[string(txHash)] = sender
+		}
+		stakes[sender] += validator.Validator.EffectiveBalance
+	}
+
+	return stakes, nil
+}
+
+// sampleClients estimates client diversity from the graffiti of recent blocks.
+func (c *command) sampleClients(ctx context.Context) error {
+	currentEpoch := c.chainTime.CurrentEpoch()
+	startEpoch := phase0.Epoch(0)
+	if uint64(currentEpoch) >= c.epochs {
+		startEpoch = currentEpoch - phase0.Epoch(c.epochs) + 1
+	}
+	c.clientsStartEpoch = uint64(startEpoch)
+
+	c.clients = make(map[string]int)
+	for slot := c.chainTime.FirstSlotOfEpoch(startEpoch); slot <= c.chainTime.CurrentSlot(); slot++ {
+		block, err := c.signedBeaconBlockProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if block == nil {
+			// Missed slot.
+			continue
+		}
+		c.clientsBlocks++
+		graffiti, err := blockGraffiti(block)
+		if err != nil {
+			util.Log.Debug().Uint64("slot", uint64(slot)).Err(err).Msg("Failed to obtain graffiti")
+			c.clients[unknownClient]++
+			continue
+		}
+		consensusClient, _ := clientsFromGraffiti(graffiti)
+		c.clients[consensusClient]++
+	}
+
+	return nil
+}
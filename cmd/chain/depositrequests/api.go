@@ -0,0 +1,173 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindepositrequests
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// Deposit requests and pending deposits are not available through the
+// client, so are obtained directly from the beacon node's API.
+
+type blockJSON struct {
+	Version string `json:"version"`
+	Data    struct {
+		Message struct {
+			Body struct {
+				ExecutionRequests *executionRequestsJSON `json:"execution_requests"`
+			} `json:"body"`
+		} `json:"message"`
+	} `json:"data"`
+}
+
+type executionRequestsJSON struct {
+	Deposits []*depositRequestJSON `json:"deposits"`
+}
+
+type depositRequestJSON struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	Signature             string `json:"signature"`
+	Index                 string `json:"index"`
+}
+
+type pendingDepositsJSON struct {
+	Data []*pendingDepositJSON `json:"data"`
+}
+
+type pendingDepositJSON struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	Signature             string `json:"signature"`
+	Slot                  string `json:"slot"`
+}
+
+// blockDepositRequests obtains the deposit requests in the block at the given
+// slot.  Blocks prior to Electra do not contain deposit requests.
+func (c *command) blockDepositRequests(ctx context.Context, slot phase0.Slot) ([]*depositRequest, error) {
+	block := &blockJSON{}
+	found, err := util.FetchBeaconNodeJSON(ctx, c.eth2Client.Address(), c.timeout, fmt.Sprintf("/eth/v2/beacon/blocks/%d", slot), nil, block)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block at slot %d", slot))
+	}
+	if !found || block.Data.Message.Body.ExecutionRequests == nil {
+		// Missed slot, or a block without execution requests.
+		return nil, nil
+	}
+
+	requests := make([]*depositRequest, 0, len(block.Data.Message.Body.ExecutionRequests.Deposits))
+	for _, data := range block.Data.Message.Body.ExecutionRequests.Deposits {
+		request, err := parseDepositRequest(slot, data)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid deposit request in block at slot %d", slot))
+		}
+		requests = append(requests, request)
+	}
+
+	return requests, nil
+}
+
+// pendingDeposits obtains the keys of the deposits pending in the head state.
+func (c *command) pendingDeposits(ctx context.Context) (map[string]bool, error) {
+	data := &pendingDepositsJSON{}
+	found, err := util.FetchBeaconNodeJSON(ctx, c.eth2Client.Address(), c.timeout, "/eth/v1/beacon/states/head/pending_deposits", nil, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain pending deposits")
+	}
+	if !found {
+		return nil, errors.New("node does not provide pending deposits")
+	}
+
+	res := make(map[string]bool, len(data.Data))
+	for _, pendingDeposit := range data.Data {
+		pubkey, err := decodeHex(pendingDeposit.Pubkey, phase0.PublicKeyLength)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending deposit public key")
+		}
+		signature, err := decodeHex(pendingDeposit.Signature, phase0.SignatureLength)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending deposit signature")
+		}
+		slot, err := strconv.ParseUint(pendingDeposit.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending deposit slot")
+		}
+		res[depositKey(pubkey, signature, phase0.Slot(slot))] = true
+	}
+
+	return res, nil
+}
+
+func parseDepositRequest(slot phase0.Slot, data *depositRequestJSON) (*depositRequest, error) {
+	request := &depositRequest{
+		Slot: slot,
+	}
+
+	pubkey, err := decodeHex(data.Pubkey, phase0.PublicKeyLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	copy(request.Pubkey[:], pubkey)
+
+	request.WithdrawalCredentials, err = decodeHex(data.WithdrawalCredentials, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid withdrawal credentials")
+	}
+
+	amount, err := strconv.ParseUint(data.Amount, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid amount")
+	}
+	request.Amount = phase0.Gwei(amount)
+
+	signature, err := decodeHex(data.Signature, phase0.SignatureLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+	copy(request.Signature[:], signature)
+
+	request.Index, err = strconv.ParseUint(data.Index, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid index")
+	}
+
+	return request, nil
+}
+
+func decodeHex(input string, length int) ([]byte, error) {
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(res) != length {
+		return nil, fmt.Errorf("expected %d bytes, found %d", length, len(res))
+	}
+
+	return res, nil
+}
+
+// depositKey provides a key to match deposit requests with pending deposits.
+func depositKey(pubkey []byte, signature []byte, slot phase0.Slot) string {
+	return fmt.Sprintf("%#x:%#x:%d", pubkey, signature, slot)
+}
@@ -0,0 +1,99 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindepositrequests
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// blockRange is a range of blocks, inclusive of both ends.  Either end of the
+// range can be the chain head, which is resolved when processing.
+type blockRange struct {
+	start     phase0.Slot
+	startHead bool
+	end       phase0.Slot
+	endHead   bool
+}
+
+// parseBlocks parses a range of blocks of the form "start-end", where each of
+// start and end is a slot or "head".  A single value is a range of one block.
+func parseBlocks(input string) (*blockRange, error) {
+	parts := strings.Split(input, "-")
+	if len(parts) > 2 {
+		return nil, fmt.Errorf("invalid blocks %q; should be of the form start-end", input)
+	}
+
+	res := &blockRange{}
+	var err error
+	res.start, res.startHead, err = parseBlock(parts[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid start of blocks")
+	}
+	if len(parts) == 1 {
+		res.end = res.start
+		res.endHead = res.startHead
+	} else {
+		res.end, res.endHead, err = parseBlock(parts[1])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid end of blocks")
+		}
+	}
+
+	if !res.startHead && !res.endHead && res.start > res.end {
+		return nil, errors.New("start of blocks after end of blocks")
+	}
+
+	return res, nil
+}
+
+func parseBlock(input string) (phase0.Slot, bool, error) {
+	input = strings.TrimSpace(input)
+	if input == "head" {
+		return 0, true, nil
+	}
+	slot, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("%q is not a slot", input)
+	}
+
+	return phase0.Slot(slot), false, nil
+}
+
+// resolve resolves the range given the slot of the chain head.
+func (r *blockRange) resolve(headSlot phase0.Slot) (phase0.Slot, phase0.Slot, error) {
+	start := r.start
+	if r.startHead {
+		start = headSlot
+	}
+	end := r.end
+	if r.endHead {
+		end = headSlot
+	}
+	if start > headSlot {
+		return 0, 0, fmt.Errorf("start of blocks %d is after the chain head %d", start, headSlot)
+	}
+	if end > headSlot {
+		end = headSlot
+	}
+	if start > end {
+		return 0, 0, errors.New("start of blocks after end of blocks")
+	}
+
+	return start, end, nil
+}
@@ -0,0 +1,119 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindepositrequests
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		headSlot phase0.Slot
+		start    phase0.Slot
+		end      phase0.Slot
+		parseErr string
+		err      string
+	}{
+		{
+			name:     "Empty",
+			input:    "",
+			parseErr: `invalid start of blocks: "" is not a slot`,
+		},
+		{
+			name:     "TooManyParts",
+			input:    "1-2-3",
+			parseErr: `invalid blocks "1-2-3"; should be of the form start-end`,
+		},
+		{
+			name:     "StartInvalid",
+			input:    "a-2",
+			parseErr: `invalid start of blocks: "a" is not a slot`,
+		},
+		{
+			name:     "EndInvalid",
+			input:    "1-b",
+			parseErr: `invalid end of blocks: "b" is not a slot`,
+		},
+		{
+			name:     "Reversed",
+			input:    "10-5",
+			parseErr: "start of blocks after end of blocks",
+		},
+		{
+			name:     "Single",
+			input:    "5",
+			headSlot: 100,
+			start:    5,
+			end:      5,
+		},
+		{
+			name:     "Head",
+			input:    "head",
+			headSlot: 100,
+			start:    100,
+			end:      100,
+		},
+		{
+			name:     "Range",
+			input:    "5-10",
+			headSlot: 100,
+			start:    5,
+			end:      10,
+		},
+		{
+			name:     "RangeToHead",
+			input:    "90-head",
+			headSlot: 100,
+			start:    90,
+			end:      100,
+		},
+		{
+			name:     "EndAfterHead",
+			input:    "90-200",
+			headSlot: 100,
+			start:    90,
+			end:      100,
+		},
+		{
+			name:     "StartAfterHead",
+			input:    "200-head",
+			headSlot: 100,
+			err:      "start of blocks 200 is after the chain head 100",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			blocks, err := parseBlocks(test.input)
+			if test.parseErr != "" {
+				require.EqualError(t, err, test.parseErr)
+				return
+			}
+			require.NoError(t, err)
+			start, end, err := blocks.resolve(test.headSlot)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.start, start)
+			require.Equal(t, test.end, end)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindepositrequests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type requestJSON struct {
+	Slot                  string `json:"slot"`
+	Index                 string `json:"index"`
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	Status                string `json:"status"`
+	Detail                string `json:"detail"`
+	ValidatorIndex        string `json:"validator_index,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := make([]*requestJSON, 0, len(c.requests))
+	for _, request := range c.requests {
+		entry := &requestJSON{
+			Slot:                  fmt.Sprintf("%d", request.Slot),
+			Index:                 fmt.Sprintf("%d", request.Index),
+			Pubkey:                fmt.Sprintf("%#x", request.Pubkey),
+			WithdrawalCredentials: fmt.Sprintf("%#x", request.WithdrawalCredentials),
+			Amount:                fmt.Sprintf("%d", request.Amount),
+			Status:                request.Status,
+			Detail:                request.Detail,
+		}
+		if request.ValidatorIndex != nil {
+			entry.ValidatorIndex = fmt.Sprintf("%d", *request.ValidatorIndex)
+		}
+		output = append(output, entry)
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	if len(c.requests) == 0 {
+		return fmt.Sprintf("No deposit requests in blocks %d-%d", c.startSlot, c.endSlot), nil
+	}
+
+	builder := strings.Builder{}
+
+	counts := make(map[string]int)
+	for _, request := range c.requests {
+		counts[request.Status]++
+		builder.WriteString(fmt.Sprintf("Deposit request %d (slot %d): %#x %s %s",
+			request.Index,
+			request.Slot,
+			request.Pubkey,
+			string2eth.GWeiToString(uint64(request.Amount), true),
+			request.Status,
+		))
+		if c.verbose || request.Status == statusInvalid || request.Status == statusStuck {
+			builder.WriteString(fmt.Sprintf(" (%s)", request.Detail))
+		}
+		builder.WriteString("\n")
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("  Withdrawal credentials: %#x\n", request.WithdrawalCredentials))
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf("%d deposit requests in blocks %d-%d: %d processed, %d pending, %d invalid, %d stuck",
+		len(c.requests),
+		c.startSlot,
+		c.endSlot,
+		counts[statusProcessed],
+		counts[statusPending],
+		counts[statusInvalid],
+		counts[statusStuck],
+	))
+
+	return builder.String(), nil
+}
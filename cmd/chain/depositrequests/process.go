@@ -0,0 +1,150 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindepositrequests
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	headSlot := c.chainTime.CurrentSlot()
+	if c.blocks == nil {
+		// Default to the last epoch of blocks.
+		c.blocks = &blockRange{
+			startHead: true,
+			endHead:   true,
+		}
+		if uint64(headSlot) >= c.chainTime.SlotsPerEpoch() {
+			c.blocks.startHead = false
+			c.blocks.start = headSlot - phase0.Slot(c.chainTime.SlotsPerEpoch()) + 1
+		}
+	}
+	var err error
+	c.startSlot, c.endSlot, err = c.blocks.resolve(headSlot)
+	if err != nil {
+		return err
+	}
+
+	c.requests = make([]*depositRequest, 0)
+	for slot := c.startSlot; slot <= c.endSlot; slot++ {
+		requests, err := c.blockDepositRequests(ctx, slot)
+		if err != nil {
+			return err
+		}
+		if len(requests) > 0 {
+			util.Log.Debug().Uint64("slot", uint64(slot)).Int("requests", len(requests)).Msg("Found deposit requests in block")
+		}
+		c.requests = append(c.requests, requests...)
+	}
+	if len(c.requests) == 0 {
+		return nil
+	}
+
+	pending, err := c.pendingDeposits(ctx)
+	if err != nil {
+		return err
+	}
+
+	pubkeys := make([]phase0.BLSPubKey, 0, len(c.requests))
+	for _, request := range c.requests {
+		pubkeys = append(pubkeys, request.Pubkey)
+	}
+	validators, err := c.validatorsProvider.ValidatorsByPubKey(ctx, "head", pubkeys)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+	validatorIndices := make(map[phase0.BLSPubKey]phase0.ValidatorIndex, len(validators))
+	for index, validator := range validators {
+		if validator.Validator == nil {
+			continue
+		}
+		validatorIndices[validator.Validator.PublicKey] = index
+	}
+
+	c.classify(c.requests, pending, validatorIndices)
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	spec, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	genesisForkVersion, exists := spec["GENESIS_FORK_VERSION"].(phase0.Version)
+	if !exists {
+		return errors.New("failed to obtain GENESIS_FORK_VERSION")
+	}
+	depositDomainType, exists := spec["DOMAIN_DEPOSIT"].(phase0.DomainType)
+	if !exists {
+		return errors.New("failed to obtain DOMAIN_DEPOSIT")
+	}
+	c.depositDomain, err = depositDomain(depositDomainType, genesisForkVersion)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// depositDomain is the spec's compute_domain() for deposits, which are
+// valid across forks so use the genesis fork version and an empty genesis
+// validators root.
+func depositDomain(domainType phase0.DomainType, genesisForkVersion phase0.Version) (phase0.Domain, error) {
+	forkDataRoot, err := (&phase0.ForkData{
+		CurrentVersion: genesisForkVersion,
+	}).HashTreeRoot()
+	if err != nil {
+		return phase0.Domain{}, errors.Wrap(err, "failed to calculate deposit domain")
+	}
+
+	var domain phase0.Domain
+	copy(domain[:], domainType[:])
+	copy(domain[4:], forkDataRoot[:])
+
+	return domain, nil
+}
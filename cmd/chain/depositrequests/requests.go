@@ -0,0 +1,119 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindepositrequests
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+const (
+	// statusPending is a deposit request waiting in the pending deposits queue.
+	statusPending = "pending"
+	// statusProcessed is a deposit request that has been applied to the validator registry.
+	statusProcessed = "processed"
+	// statusInvalid is a deposit request for a new validator with an invalid signature.
+	statusInvalid = "invalid"
+	// statusStuck is a deposit request that is neither pending nor applied.
+	statusStuck = "stuck"
+)
+
+// classify sets the status of each deposit request, given the pending
+// deposits and validators in the head state.
+func (c *command) classify(requests []*depositRequest,
+	pending map[string]bool,
+	validators map[phase0.BLSPubKey]phase0.ValidatorIndex,
+) {
+	for _, request := range requests {
+		validatorIndex, isValidator := validators[request.Pubkey]
+		if isValidator {
+			request.ValidatorIndex = &validatorIndex
+		}
+		// Signatures are only checked for deposits that create a validator.
+		validSignature := isValidator || c.verifySignature(request) == nil
+
+		switch {
+		case pending[depositKey(request.Pubkey[:], request.Signature[:], request.Slot)]:
+			if validSignature {
+				request.Status = statusPending
+				request.Detail = "in the pending deposits queue"
+			} else {
+				request.Status = statusInvalid
+				request.Detail = "in the pending deposits queue, but the signature is invalid so it will be dropped"
+			}
+		case isValidator:
+			request.Status = statusProcessed
+			request.Detail = fmt.Sprintf("applied to validator %d", validatorIndex)
+		case !validSignature:
+			request.Status = statusInvalid
+			request.Detail = "signature is invalid so the deposit was dropped"
+		default:
+			request.Status = statusStuck
+			request.Detail = "neither in the pending deposits queue nor the validator registry"
+		}
+	}
+}
+
+// verifySignature verifies the signature of a deposit request.
+func (c *command) verifySignature(request *depositRequest) error {
+	root, err := (&phase0.DepositMessage{
+		PublicKey:             request.Pubkey,
+		WithdrawalCredentials: request.WithdrawalCredentials,
+		Amount:                request.Amount,
+	}).HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate deposit message root")
+	}
+
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     c.depositDomain,
+	}).HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate signing root")
+	}
+
+	pubKeyBytes := make([]byte, len(request.Pubkey))
+	copy(pubKeyBytes, request.Pubkey[:])
+	key, err := e2types.BLSPublicKeyFromBytes(pubKeyBytes)
+	if err != nil {
+		return errors.Wrap(err, "invalid public key")
+	}
+	sigBytes := make([]byte, len(request.Signature))
+	copy(sigBytes, request.Signature[:])
+	sig, err := e2types.BLSSignatureFromBytes(sigBytes)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	if !sig.Verify(signingRoot[:], key) {
+		return errors.New("signature does not verify")
+	}
+
+	return nil
+}
+
+// problems returns the number of deposit requests that are stuck or invalid.
+func (c *command) problems() int {
+	problems := 0
+	for _, request := range c.requests {
+		if request.Status == statusInvalid || request.Status == statusStuck {
+			problems++
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,146 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindepositrequests
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// signedDepositRequest creates a deposit request signed by a new key.
+func signedDepositRequest(t *testing.T, domain phase0.Domain, slot phase0.Slot, index uint64) *depositRequest {
+	t.Helper()
+
+	key, err := e2types.GenerateBLSPrivateKey()
+	require.NoError(t, err)
+
+	request := &depositRequest{
+		Slot:                  slot,
+		Index:                 index,
+		WithdrawalCredentials: make([]byte, 32),
+		Amount:                32000000000,
+	}
+	copy(request.Pubkey[:], key.PublicKey().Marshal())
+	request.WithdrawalCredentials[0] = 0x01
+
+	root, err := (&phase0.DepositMessage{
+		PublicKey:             request.Pubkey,
+		WithdrawalCredentials: request.WithdrawalCredentials,
+		Amount:                request.Amount,
+	}).HashTreeRoot()
+	require.NoError(t, err)
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	}).HashTreeRoot()
+	require.NoError(t, err)
+	copy(request.Signature[:], key.Sign(signingRoot[:]).Marshal())
+
+	return request
+}
+
+func TestClassify(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	domain, err := depositDomain(phase0.DomainType{0x03, 0x00, 0x00, 0x00}, phase0.Version{0x00, 0x00, 0x00, 0x00})
+	require.NoError(t, err)
+	c := &command{
+		depositDomain: domain,
+	}
+
+	processed := signedDepositRequest(t, domain, 100, 1)
+	pending := signedDepositRequest(t, domain, 100, 2)
+	stuck := signedDepositRequest(t, domain, 101, 3)
+	invalid := signedDepositRequest(t, domain, 101, 4)
+	invalid.Amount = 1000000000
+	invalidPending := signedDepositRequest(t, domain, 102, 5)
+	invalidPending.Amount = 1000000000
+	topUp := signedDepositRequest(t, domain, 102, 6)
+	topUp.Signature = phase0.BLSSignature{}
+
+	c.requests = []*depositRequest{processed, pending, stuck, invalid, invalidPending, topUp}
+	c.classify(c.requests,
+		map[string]bool{
+			depositKey(pending.Pubkey[:], pending.Signature[:], pending.Slot):                      true,
+			depositKey(invalidPending.Pubkey[:], invalidPending.Signature[:], invalidPending.Slot): true,
+		},
+		map[phase0.BLSPubKey]phase0.ValidatorIndex{
+			processed.Pubkey: 10,
+			topUp.Pubkey:     11,
+		},
+	)
+
+	require.Equal(t, statusProcessed, processed.Status)
+	require.Equal(t, phase0.ValidatorIndex(10), *processed.ValidatorIndex)
+	require.Equal(t, statusPending, pending.Status)
+	require.Nil(t, pending.ValidatorIndex)
+	require.Equal(t, statusStuck, stuck.Status)
+	require.Equal(t, statusInvalid, invalid.Status)
+	require.Equal(t, statusInvalid, invalidPending.Status)
+	// Signatures are not checked for top-ups.
+	require.Equal(t, statusProcessed, topUp.Status)
+	require.Equal(t, 3, c.problems())
+}
+
+func TestParseDepositRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		data *depositRequestJSON
+		err  string
+	}{
+		{
+			name: "PubkeyInvalid",
+			data: &depositRequestJSON{
+				Pubkey: "0x01",
+			},
+			err: "invalid public key: expected 48 bytes, found 1",
+		},
+		{
+			name: "AmountInvalid",
+			data: &depositRequestJSON{
+				Pubkey:                "0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+				WithdrawalCredentials: "0x0100000000000000000000000000000000000000000000000000000000000000",
+				Amount:                "bad",
+			},
+			err: `invalid amount: strconv.ParseUint: parsing "bad": invalid syntax`,
+		},
+		{
+			name: "Good",
+			data: &depositRequestJSON{
+				Pubkey:                "0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+				WithdrawalCredentials: "0x0100000000000000000000000000000000000000000000000000000000000000",
+				Amount:                "32000000000",
+				Signature:             "0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+				Index:                 "7",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := parseDepositRequest(5, test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, phase0.Slot(5), request.Slot)
+			require.Equal(t, uint64(7), request.Index)
+			require.Equal(t, phase0.Gwei(32000000000), request.Amount)
+		})
+	}
+}
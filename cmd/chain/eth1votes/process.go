@@ -15,7 +15,6 @@ package chaineth1votes
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -66,16 +65,11 @@ func (c *command) process(ctx context.Context) error {
 		return errors.New("state not returned by beacon node")
 	}
 
-	if c.debug {
-		data, err := json.Marshal(state)
-		if err == nil {
-			fmt.Printf("%s\n", string(data))
-		}
-	}
+	util.Log.Trace().Interface("state", state).Msg("Obtained state")
 
 	switch state.Version {
 	case spec.DataVersionPhase0:
-		c.slot = phase0.Slot(state.Phase0.Slot)
+		c.slot = state.Phase0.Slot
 		c.incumbent = state.Phase0.ETH1Data
 		c.eth1DataVotes = state.Phase0.ETH1DataVotes
 	case spec.DataVersionAltair:
@@ -0,0 +1,70 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainforks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hako/durafmt"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.forks)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	for _, fork := range c.forks {
+		builder.WriteString(fork.Name)
+		if fork.Current {
+			builder.WriteString(" (current)")
+		}
+		builder.WriteString(":\n")
+		builder.WriteString(fmt.Sprintf("  Version: %s\n", fork.Version))
+		if fork.Epoch == nil {
+			builder.WriteString("  Epoch: not scheduled\n")
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("  Epoch: %d\n", *fork.Epoch))
+		builder.WriteString(fmt.Sprintf("  Time: %s", fork.Timestamp.Format("2006-01-02 15:04:05")))
+		if fork.Timestamp.After(c.now) {
+			builder.WriteString(fmt.Sprintf(" (in %s)", durafmt.Parse(fork.Timestamp.Sub(c.now)).LimitFirstN(2).String()))
+		} else if c.verbose {
+			builder.WriteString(fmt.Sprintf(" (%s ago)", durafmt.Parse(c.now.Sub(*fork.Timestamp)).LimitFirstN(2).String()))
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
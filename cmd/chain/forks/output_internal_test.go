@@ -0,0 +1,98 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainforks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	genesisEpoch := uint64(0)
+	genesisTime := time.Date(2020, 12, 1, 12, 0, 23, 0, time.UTC)
+	nextEpoch := uint64(1000)
+	nextTime := now.Add(50 * time.Hour)
+
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet: true,
+			},
+		},
+		{
+			name: "Text",
+			c: &command{
+				now: now,
+				forks: []*fork{
+					{
+						Name:      "phase0",
+						Version:   "0x00000000",
+						Epoch:     &genesisEpoch,
+						Timestamp: &genesisTime,
+						Current:   true,
+					},
+					{
+						Name:      "altair",
+						Version:   "0x01000000",
+						Epoch:     &nextEpoch,
+						Timestamp: &nextTime,
+					},
+					{
+						Name:    "bellatrix",
+						Version: "0x02000000",
+					},
+				},
+			},
+			res: "phase0 (current):\n  Version: 0x00000000\n  Epoch: 0\n  Time: 2020-12-01 12:00:23\naltair:\n  Version: 0x01000000\n  Epoch: 1000\n  Time: 2023-06-03 02:00:00 (in 2 days 2 hours)\nbellatrix:\n  Version: 0x02000000\n  Epoch: not scheduled",
+		},
+		{
+			name: "JSON",
+			c: &command{
+				json: true,
+				now:  now,
+				forks: []*fork{
+					{
+						Name:      "phase0",
+						Version:   "0x00000000",
+						Epoch:     &genesisEpoch,
+						Timestamp: &genesisTime,
+						Current:   true,
+					},
+					{
+						Name:    "altair",
+						Version: "0x01000000",
+					},
+				},
+			},
+			res: `[{"name":"phase0","version":"0x00000000","epoch":0,"timestamp":"2020-12-01T12:00:23Z","current":true},{"name":"altair","version":"0x01000000","current":false}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainforks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// farFutureEpoch is used to denote a fork that is not scheduled.
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.now = time.Now()
+	c.forks = c.buildForks(c.chainTime.CurrentEpoch())
+
+	return nil
+}
+
+// buildForks builds the output fork information from the fork schedule.
+func (c *command) buildForks(currentEpoch phase0.Epoch) []*fork {
+	currentFork := c.chainTime.ForkAtEpoch(currentEpoch)
+
+	forks := make([]*fork, 0, len(c.chainTime.ForkSchedule()))
+	for _, scheduledFork := range c.chainTime.ForkSchedule() {
+		name := scheduledFork.Name
+		if name == "" {
+			name = "unknown"
+		}
+		f := &fork{
+			Name:    name,
+			Version: fmt.Sprintf("%#x", scheduledFork.Version),
+			Current: currentFork != nil && scheduledFork.Version == currentFork.Version,
+		}
+		if scheduledFork.Epoch != farFutureEpoch {
+			epoch := uint64(scheduledFork.Epoch)
+			f.Epoch = &epoch
+			timestamp := c.chainTime.StartOfEpoch(scheduledFork.Epoch)
+			f.Timestamp = &timestamp
+		}
+		forks = append(forks, f)
+	}
+
+	return forks
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	forkScheduleProvider, isProvider := c.eth2Client.(eth2client.ForkScheduleProvider)
+	if !isProvider {
+		return errors.New("connection does not provide fork schedule information")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+		standardchaintime.WithForkScheduleProvider(forkScheduleProvider),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainforks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/testing/mock"
+)
+
+func TestBuildForks(t *testing.T) {
+	genesisTime := time.Unix(1606824023, 0)
+	chainTime, err := standardchaintime.New(context.Background(),
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+		standardchaintime.WithForkScheduleProvider(mock.NewForkScheduleProvider([]*phase0.Fork{
+			{
+				PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+				CurrentVersion:  phase0.Version{0x00, 0x00, 0x00, 0x00},
+				Epoch:           0,
+			},
+			{
+				PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+				CurrentVersion:  phase0.Version{0x01, 0x00, 0x00, 0x00},
+				Epoch:           10,
+			},
+			{
+				PreviousVersion: phase0.Version{0x01, 0x00, 0x00, 0x00},
+				CurrentVersion:  phase0.Version{0x02, 0x00, 0x00, 0x00},
+				Epoch:           farFutureEpoch,
+			},
+		})),
+	)
+	require.NoError(t, err)
+
+	c := &command{
+		chainTime: chainTime,
+	}
+	forks := c.buildForks(20)
+	require.Len(t, forks, 3)
+
+	require.Equal(t, "0x00000000", forks[0].Version)
+	require.False(t, forks[0].Current)
+	require.Equal(t, uint64(0), *forks[0].Epoch)
+	require.Equal(t, genesisTime, *forks[0].Timestamp)
+
+	require.Equal(t, "0x01000000", forks[1].Version)
+	require.True(t, forks[1].Current)
+	require.Equal(t, uint64(10), *forks[1].Epoch)
+	require.Equal(t, genesisTime.Add(10*32*12*time.Second), *forks[1].Timestamp)
+
+	require.Equal(t, "0x02000000", forks[2].Version)
+	require.False(t, forks[2].Current)
+	require.Nil(t, forks[2].Epoch)
+	require.Nil(t, forks[2].Timestamp)
+}
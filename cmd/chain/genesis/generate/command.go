@@ -0,0 +1,139 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingenesisgenerate
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	configFile             string
+	genesisTime            uint64
+	depositData            string
+	mnemonic               string
+	count                  uint64
+	withdrawalAddress      []byte
+	eth1BlockHash          *phase0.Hash32
+	executionPayloadHeader string
+	outputFile             string
+
+	// Processing.
+	config *genesisConfig
+
+	// Output.
+	result    *genesisResult
+	stateRoot phase0.Root
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                  viper.GetBool("quiet"),
+		verbose:                viper.GetBool("verbose"),
+		debug:                  viper.GetBool("debug"),
+		configFile:             viper.GetString("chain-config"),
+		depositData:            viper.GetString("deposit-data"),
+		mnemonic:               viper.GetString("mnemonic"),
+		count:                  viper.GetUint64("count"),
+		executionPayloadHeader: viper.GetString("execution-payload-header"),
+		outputFile:             viper.GetString("file"),
+	}
+
+	if c.configFile == "" {
+		return nil, errors.New("chain config is required")
+	}
+
+	if viper.GetString("genesis-time") == "" {
+		return nil, errors.New("genesis time is required")
+	}
+	var err error
+	c.genesisTime, err = parseGenesisTime(viper.GetString("genesis-time"))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.depositData == "" && c.mnemonic == "" {
+		return nil, errors.New("one of deposit-data or mnemonic is required")
+	}
+	if c.depositData != "" && c.mnemonic != "" {
+		return nil, errors.New("only one of deposit-data and mnemonic is allowed")
+	}
+	if c.mnemonic != "" && c.count == 0 {
+		return nil, errors.New("count is required with mnemonic")
+	}
+	if c.mnemonic == "" && c.count != 0 {
+		return nil, errors.New("count can only be used with mnemonic")
+	}
+
+	if viper.GetString("withdrawal-address") != "" {
+		if c.mnemonic == "" {
+			return nil, errors.New("withdrawal address can only be used with mnemonic")
+		}
+		c.withdrawalAddress, err = hex.DecodeString(strings.TrimPrefix(viper.GetString("withdrawal-address"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid withdrawal address")
+		}
+		if len(c.withdrawalAddress) != 20 {
+			return nil, errors.New("withdrawal address must be 20 bytes")
+		}
+	}
+
+	if viper.GetString("eth1-block-hash") != "" {
+		data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("eth1-block-hash"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid eth1 block hash")
+		}
+		if len(data) != 32 {
+			return nil, errors.New("eth1 block hash must be 32 bytes")
+		}
+		hash := phase0.Hash32{}
+		copy(hash[:], data)
+		c.eth1BlockHash = &hash
+	}
+
+	if c.outputFile == "" {
+		return nil, errors.New("file is required")
+	}
+
+	return c, nil
+}
+
+// parseGenesisTime parses a genesis time supplied either as a Unix timestamp
+// or in RFC3339 format.
+func parseGenesisTime(input string) (uint64, error) {
+	if genesisTime, err := strconv.ParseUint(input, 10, 64); err == nil {
+		return genesisTime, nil
+	}
+	genesisTime, err := time.Parse(time.RFC3339, input)
+	if err != nil {
+		return 0, errors.New("invalid genesis time; must be a Unix timestamp or RFC3339 time")
+	}
+	if genesisTime.Unix() < 0 {
+		return 0, errors.New("genesis time cannot be before 1970")
+	}
+
+	return uint64(genesisTime.Unix()), nil
+}
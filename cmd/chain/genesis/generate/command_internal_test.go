@@ -0,0 +1,199 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingenesisgenerate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "ChainConfigMissing",
+			vars: map[string]interface{}{
+				"genesis-time": "1700000000",
+				"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"count":        "64",
+				"file":         "genesis.ssz",
+			},
+			err: "chain config is required",
+		},
+		{
+			name: "GenesisTimeMissing",
+			vars: map[string]interface{}{
+				"chain-config": "config.yaml",
+				"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"count":        "64",
+				"file":         "genesis.ssz",
+			},
+			err: "genesis time is required",
+		},
+		{
+			name: "GenesisTimeInvalid",
+			vars: map[string]interface{}{
+				"chain-config": "config.yaml",
+				"genesis-time": "tomorrow",
+				"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"count":        "64",
+				"file":         "genesis.ssz",
+			},
+			err: "invalid genesis time; must be a Unix timestamp or RFC3339 time",
+		},
+		{
+			name: "DepositsMissing",
+			vars: map[string]interface{}{
+				"chain-config": "config.yaml",
+				"genesis-time": "1700000000",
+				"file":         "genesis.ssz",
+			},
+			err: "one of deposit-data or mnemonic is required",
+		},
+		{
+			name: "DepositsMultiple",
+			vars: map[string]interface{}{
+				"chain-config": "config.yaml",
+				"genesis-time": "1700000000",
+				"deposit-data": "deposits.json",
+				"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"count":        "64",
+				"file":         "genesis.ssz",
+			},
+			err: "only one of deposit-data and mnemonic is allowed",
+		},
+		{
+			name: "CountMissing",
+			vars: map[string]interface{}{
+				"chain-config": "config.yaml",
+				"genesis-time": "1700000000",
+				"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"file":         "genesis.ssz",
+			},
+			err: "count is required with mnemonic",
+		},
+		{
+			name: "CountWithDepositData",
+			vars: map[string]interface{}{
+				"chain-config": "config.yaml",
+				"genesis-time": "1700000000",
+				"deposit-data": "deposits.json",
+				"count":        "64",
+				"file":         "genesis.ssz",
+			},
+			err: "count can only be used with mnemonic",
+		},
+		{
+			name: "WithdrawalAddressWithDepositData",
+			vars: map[string]interface{}{
+				"chain-config":       "config.yaml",
+				"genesis-time":       "1700000000",
+				"deposit-data":       "deposits.json",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+				"file":               "genesis.ssz",
+			},
+			err: "withdrawal address can only be used with mnemonic",
+		},
+		{
+			name: "WithdrawalAddressShort",
+			vars: map[string]interface{}{
+				"chain-config":       "config.yaml",
+				"genesis-time":       "1700000000",
+				"mnemonic":           "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"count":              "64",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac",
+				"file":               "genesis.ssz",
+			},
+			err: "withdrawal address must be 20 bytes",
+		},
+		{
+			name: "Eth1BlockHashInvalid",
+			vars: map[string]interface{}{
+				"chain-config":    "config.yaml",
+				"genesis-time":    "1700000000",
+				"mnemonic":        "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"count":           "64",
+				"eth1-block-hash": "0x0102",
+				"file":            "genesis.ssz",
+			},
+			err: "eth1 block hash must be 32 bytes",
+		},
+		{
+			name: "FileMissing",
+			vars: map[string]interface{}{
+				"chain-config": "config.yaml",
+				"genesis-time": "1700000000",
+				"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"count":        "64",
+			},
+			err: "file is required",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"chain-config":       "config.yaml",
+				"genesis-time":       "2023-11-14T22:13:20Z",
+				"mnemonic":           "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+				"count":              "64",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+				"file":               "genesis.ssz",
+			},
+		},
+		{
+			name: "GoodDepositData",
+			vars: map[string]interface{}{
+				"chain-config":    "config.yaml",
+				"genesis-time":    "1700000000",
+				"deposit-data":    "deposits.json",
+				"eth1-block-hash": "0x0101010101010101010101010101010101010101010101010101010101010101",
+				"file":            "genesis.ssz",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseGenesisTime(t *testing.T) {
+	res, err := parseGenesisTime("1700000000")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1700000000), res)
+
+	res, err = parseGenesisTime("2023-11-14T22:13:20Z")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1700000000), res)
+
+	_, err = parseGenesisTime("1969-12-31T23:59:59Z")
+	require.EqualError(t, err, "genesis time cannot be before 1970")
+}
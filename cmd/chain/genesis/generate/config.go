@@ -0,0 +1,157 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingenesisgenerate
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// genesisConfig is the information from a chain configuration required to
+// generate a genesis state.
+type genesisConfig struct {
+	// version is the fork of the genesis state.
+	version            spec.DataVersion
+	genesisForkVersion phase0.Version
+	previousVersion    phase0.Version
+	currentVersion     phase0.Version
+	// minGenesisActiveValidatorCount is the minimum number of active
+	// validators for the genesis state, if supplied.
+	minGenesisActiveValidatorCount uint64
+}
+
+// forkConfig is the configuration for a fork.
+type forkConfig struct {
+	name    string
+	version spec.DataVersion
+}
+
+// supportedForks are the forks at which a genesis state can be generated, in order.
+var supportedForks = []*forkConfig{
+	{name: "ALTAIR", version: spec.DataVersionAltair},
+	{name: "BELLATRIX", version: spec.DataVersionBellatrix},
+	{name: "CAPELLA", version: spec.DataVersionCapella},
+}
+
+// unsupportedForks are later forks that cannot be active at genesis.
+var unsupportedForks = []string{"DENEB", "ELECTRA"}
+
+// parseConfig parses a chain configuration in the YAML format used by the
+// consensus specifications.
+func parseConfig(data []byte) (*genesisConfig, error) {
+	values := make(map[string]string)
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, errors.Wrap(err, "failed to parse configuration")
+	}
+
+	// The state types are sized for the mainnet preset.
+	if preset, exists := values["PRESET_BASE"]; exists && preset != "mainnet" {
+		return nil, fmt.Errorf("preset %s not supported; only the mainnet preset is supported", preset)
+	}
+
+	res := &genesisConfig{
+		version: spec.DataVersionPhase0,
+	}
+
+	var err error
+	res.genesisForkVersion, err = configVersion(values, "GENESIS_FORK_VERSION")
+	if err != nil {
+		return nil, err
+	}
+	res.previousVersion = res.genesisForkVersion
+	res.currentVersion = res.genesisForkVersion
+
+	for _, fork := range supportedForks {
+		active, err := activeAtGenesis(values, fork.name)
+		if err != nil {
+			return nil, err
+		}
+		if !active {
+			break
+		}
+		version, err := configVersion(values, fmt.Sprintf("%s_FORK_VERSION", fork.name))
+		if err != nil {
+			return nil, err
+		}
+		// The previous version of a genesis state after phase 0 is the
+		// version of the prior fork, except for Altair where it remains
+		// that of genesis.
+		if res.version != spec.DataVersionPhase0 {
+			res.previousVersion = res.currentVersion
+		}
+		res.currentVersion = version
+		res.version = fork.version
+	}
+	for _, name := range unsupportedForks {
+		active, err := activeAtGenesis(values, name)
+		if err != nil {
+			return nil, err
+		}
+		if active {
+			return nil, fmt.Errorf("genesis at %s is not supported", strings.ToLower(name))
+		}
+	}
+	if res.version == spec.DataVersionPhase0 {
+		return nil, errors.New("genesis at phase0 is not supported; ALTAIR_FORK_EPOCH must be 0")
+	}
+
+	if value, exists := values["MIN_GENESIS_ACTIVE_VALIDATOR_COUNT"]; exists {
+		res.minGenesisActiveValidatorCount, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid MIN_GENESIS_ACTIVE_VALIDATOR_COUNT")
+		}
+	}
+
+	return res, nil
+}
+
+// activeAtGenesis returns true if the named fork is active at genesis.
+func activeAtGenesis(values map[string]string, name string) (bool, error) {
+	key := fmt.Sprintf("%s_FORK_EPOCH", name)
+	value, exists := values[key]
+	if !exists {
+		return false, nil
+	}
+	epoch, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return false, errors.Wrap(err, fmt.Sprintf("invalid %s", key))
+	}
+
+	return epoch == 0, nil
+}
+
+func configVersion(values map[string]string, key string) (phase0.Version, error) {
+	version := phase0.Version{}
+	value, exists := values[key]
+	if !exists {
+		return version, fmt.Errorf("%s missing", key)
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return version, errors.Wrap(err, fmt.Sprintf("invalid %s", key))
+	}
+	if len(data) != phase0.ForkVersionLength {
+		return version, fmt.Errorf("%s must be %d bytes", key, phase0.ForkVersionLength)
+	}
+	copy(version[:], data)
+
+	return version, nil
+}
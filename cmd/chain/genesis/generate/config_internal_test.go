@@ -0,0 +1,100 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingenesisgenerate
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected *genesisConfig
+		err      string
+	}{
+		{
+			name:  "Invalid",
+			input: "[",
+			err:   "failed to parse configuration: yaml: line 1: did not find expected node content",
+		},
+		{
+			name:  "PresetUnsupported",
+			input: "PRESET_BASE: 'minimal'\n",
+			err:   "preset minimal not supported; only the mainnet preset is supported",
+		},
+		{
+			name:  "GenesisForkVersionMissing",
+			input: "PRESET_BASE: 'mainnet'\n",
+			err:   "GENESIS_FORK_VERSION missing",
+		},
+		{
+			name:  "GenesisForkVersionInvalid",
+			input: "GENESIS_FORK_VERSION: 0x0102\n",
+			err:   "GENESIS_FORK_VERSION must be 4 bytes",
+		},
+		{
+			name:  "Phase0",
+			input: "GENESIS_FORK_VERSION: 0x10000038\nALTAIR_FORK_VERSION: 0x20000038\nALTAIR_FORK_EPOCH: 10\n",
+			err:   "genesis at phase0 is not supported; ALTAIR_FORK_EPOCH must be 0",
+		},
+		{
+			name:  "ForkEpochInvalid",
+			input: "GENESIS_FORK_VERSION: 0x10000038\nALTAIR_FORK_EPOCH: bad\n",
+			err:   "invalid ALTAIR_FORK_EPOCH: strconv.ParseUint: parsing \"bad\": invalid syntax",
+		},
+		{
+			name:  "Deneb",
+			input: "GENESIS_FORK_VERSION: 0x10000038\nALTAIR_FORK_VERSION: 0x20000038\nALTAIR_FORK_EPOCH: 0\nBELLATRIX_FORK_VERSION: 0x30000038\nBELLATRIX_FORK_EPOCH: 0\nCAPELLA_FORK_VERSION: 0x40000038\nCAPELLA_FORK_EPOCH: 0\nDENEB_FORK_VERSION: 0x50000038\nDENEB_FORK_EPOCH: 0\n",
+			err:   "genesis at deneb is not supported",
+		},
+		{
+			name:  "Altair",
+			input: "PRESET_BASE: 'mainnet'\nGENESIS_FORK_VERSION: 0x10000038\nALTAIR_FORK_VERSION: 0x20000038\nALTAIR_FORK_EPOCH: 0\nBELLATRIX_FORK_VERSION: 0x30000038\nBELLATRIX_FORK_EPOCH: 18446744073709551615\nMIN_GENESIS_ACTIVE_VALIDATOR_COUNT: 64\n",
+			expected: &genesisConfig{
+				version:                        spec.DataVersionAltair,
+				genesisForkVersion:             phase0.Version{0x10, 0x00, 0x00, 0x38},
+				previousVersion:                phase0.Version{0x10, 0x00, 0x00, 0x38},
+				currentVersion:                 phase0.Version{0x20, 0x00, 0x00, 0x38},
+				minGenesisActiveValidatorCount: 64,
+			},
+		},
+		{
+			name:  "Capella",
+			input: "GENESIS_FORK_VERSION: 0x10000038\nALTAIR_FORK_VERSION: 0x20000038\nALTAIR_FORK_EPOCH: 0\nBELLATRIX_FORK_VERSION: 0x30000038\nBELLATRIX_FORK_EPOCH: 0\nCAPELLA_FORK_VERSION: 0x40000038\nCAPELLA_FORK_EPOCH: 0\nDENEB_FORK_VERSION: 0x50000038\nDENEB_FORK_EPOCH: 100\n",
+			expected: &genesisConfig{
+				version:            spec.DataVersionCapella,
+				genesisForkVersion: phase0.Version{0x10, 0x00, 0x00, 0x38},
+				previousVersion:    phase0.Version{0x30, 0x00, 0x00, 0x38},
+				currentVersion:     phase0.Version{0x40, 0x00, 0x00, 0x38},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseConfig([]byte(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
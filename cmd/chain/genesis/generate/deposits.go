@@ -0,0 +1,159 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingenesisgenerate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+// mnemonicDepositAmount is the amount deposited for each validator generated
+// from a mnemonic, in Gwei.
+const mnemonicDepositAmount = phase0.Gwei(32000000000)
+
+// obtainDeposits obtains the deposits for the genesis state, either from
+// deposit data or by generating them from a mnemonic.
+func (c *command) obtainDeposits(ctx context.Context) ([]*phase0.DepositData, error) {
+	if c.mnemonic != "" {
+		return mnemonicDeposits(ctx, c.mnemonic, c.count, c.withdrawalAddress, c.config.genesisForkVersion)
+	}
+
+	var data []byte
+	// Input could be JSON or a path to JSON.
+	switch {
+	case strings.HasPrefix(c.depositData, "{"):
+		data = []byte("[" + c.depositData + "]")
+	case strings.HasPrefix(c.depositData, "["):
+		data = []byte(c.depositData)
+	default:
+		var err error
+		data, err = os.ReadFile(c.depositData)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read deposit data file")
+		}
+		if len(data) > 0 && data[0] == '{' {
+			data = []byte("[" + string(data) + "]")
+		}
+	}
+
+	infos, err := util.DepositInfoFromJSON(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse deposit data")
+	}
+
+	deposits := make([]*phase0.DepositData, len(infos))
+	for i, info := range infos {
+		if len(info.PublicKey) != phase0.PublicKeyLength {
+			return nil, fmt.Errorf("deposit %d public key invalid", i)
+		}
+		if len(info.WithdrawalCredentials) != 32 {
+			return nil, fmt.Errorf("deposit %d withdrawal credentials invalid", i)
+		}
+		if len(info.Signature) != phase0.SignatureLength {
+			return nil, fmt.Errorf("deposit %d signature invalid", i)
+		}
+		if info.Amount == 0 {
+			return nil, fmt.Errorf("deposit %d has no amount", i)
+		}
+		deposits[i] = &phase0.DepositData{
+			WithdrawalCredentials: info.WithdrawalCredentials,
+			Amount:                phase0.Gwei(info.Amount),
+		}
+		copy(deposits[i].PublicKey[:], info.PublicKey)
+		copy(deposits[i].Signature[:], info.Signature)
+	}
+
+	return deposits, nil
+}
+
+// mnemonicDeposits generates deposits for the first count validators of a
+// mnemonic, using the standard validator key paths.
+func mnemonicDeposits(ctx context.Context,
+	mnemonic string,
+	count uint64,
+	withdrawalAddress []byte,
+	genesisForkVersion phase0.Version,
+) (
+	[]*phase0.DepositData,
+	error,
+) {
+	seed, err := util.SeedFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := phase0.Domain{}
+	copy(domain[:], e2types.Domain(e2types.DomainDeposit, genesisForkVersion[:], e2types.ZeroGenesisValidatorsRoot))
+
+	deposits := make([]*phase0.DepositData, 0, count)
+	for i := uint64(0); i < count; i++ {
+		key, err := ethutil.PrivateKeyFromSeedAndPath(seed, fmt.Sprintf("m/12381/3600/%d/0/0", i))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to generate validator key %d", i))
+		}
+		account, err := util.NewScratchAccount(key.Marshal(), nil)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to create validator account %d", i))
+		}
+
+		var withdrawalCredentials []byte
+		if len(withdrawalAddress) > 0 {
+			withdrawalCredentials = make([]byte, 32)
+			copy(withdrawalCredentials[12:32], withdrawalAddress)
+			withdrawalCredentials[0] = byte(1) // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+		} else {
+			withdrawalKey, err := ethutil.PrivateKeyFromSeedAndPath(seed, fmt.Sprintf("m/12381/3600/%d/0", i))
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to generate withdrawal key %d", i))
+			}
+			withdrawalCredentials = ethutil.SHA256(withdrawalKey.PublicKey().Marshal())
+			withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
+		}
+
+		deposit := &phase0.DepositData{
+			WithdrawalCredentials: withdrawalCredentials,
+			Amount:                mnemonicDepositAmount,
+		}
+		copy(deposit.PublicKey[:], account.PublicKey().Marshal())
+
+		depositMessageRoot, err := (&phase0.DepositMessage{
+			PublicKey:             deposit.PublicKey,
+			WithdrawalCredentials: deposit.WithdrawalCredentials,
+			Amount:                deposit.Amount,
+		}).HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate deposit message root")
+		}
+		if err := account.Unlock(ctx, nil); err != nil {
+			return nil, errors.Wrap(err, "failed to unlock key")
+		}
+		deposit.Signature, err = signing.SignRoot(ctx, account, nil, depositMessageRoot, domain)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to sign deposit message")
+		}
+
+		deposits = append(deposits, deposit)
+	}
+
+	return deposits, nil
+}
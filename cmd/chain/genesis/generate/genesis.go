@@ -0,0 +1,511 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingenesisgenerate
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// Values from the mainnet preset, to which the state types are sized.
+const (
+	slotsPerHistoricalRoot    = 8192
+	epochsPerHistoricalVector = 65536
+	epochsPerSlashingsVector  = 8192
+	syncCommitteeSize         = 512
+	shuffleRoundCount         = 90
+	minSeedLookahead          = 1
+	maxEffectiveBalance       = phase0.Gwei(32000000000)
+	effectiveBalanceIncrement = phase0.Gwei(1000000000)
+	validatorRegistryLimit    = 1099511627776
+	depositListLimit          = 4294967296
+)
+
+// farFutureEpoch is the epoch used to denote an unset epoch.
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+// syncCommitteeDomainType is the spec's DOMAIN_SYNC_COMMITTEE.
+var syncCommitteeDomainType = phase0.DomainType{0x07, 0x00, 0x00, 0x00}
+
+// genesisInput is the information from which a genesis state is generated.
+type genesisInput struct {
+	config        *genesisConfig
+	genesisTime   uint64
+	eth1BlockHash phase0.Hash32
+	deposits      []*phase0.DepositData
+	// Execution payload headers, used for genesis at Bellatrix and Capella.
+	bellatrixHeader *bellatrix.ExecutionPayloadHeader
+	capellaHeader   *capella.ExecutionPayloadHeader
+}
+
+// genesisResult is a generated genesis state.
+type genesisResult struct {
+	state                 *spec.VersionedBeaconState
+	genesisValidatorsRoot phase0.Root
+	// validators is the number of validators in the genesis state.
+	validators int
+	// skipped are the indices of deposits whose signatures did not verify.
+	skipped []int
+	// active is the number of validators active at genesis.
+	active int
+}
+
+// generateGenesis is the spec's initialize_beacon_state_from_eth1(), creating
+// the state directly at the fork in the configuration.
+func generateGenesis(input *genesisInput) (*genesisResult, error) {
+	res := &genesisResult{}
+
+	depositRoot, err := depositDataRoot(input.deposits)
+	if err != nil {
+		return nil, err
+	}
+
+	validators, balances, skipped := applyDeposits(input.deposits, input.config.genesisForkVersion)
+	res.skipped = skipped
+
+	// Process activations.
+	for i, validator := range validators {
+		balance := balances[i]
+		validator.EffectiveBalance = balance - balance%effectiveBalanceIncrement
+		if validator.EffectiveBalance > maxEffectiveBalance {
+			validator.EffectiveBalance = maxEffectiveBalance
+		}
+		if validator.EffectiveBalance == maxEffectiveBalance {
+			validator.ActivationEligibilityEpoch = 0
+			validator.ActivationEpoch = 0
+			res.active++
+		}
+	}
+	if res.active == 0 {
+		return nil, errors.New("no validators are active at genesis")
+	}
+	if uint64(res.active) < input.config.minGenesisActiveValidatorCount {
+		return nil, fmt.Errorf("%d validators are active at genesis, configuration requires at least %d", res.active, input.config.minGenesisActiveValidatorCount)
+	}
+
+	res.validators = len(validators)
+	res.genesisValidatorsRoot, err = validatorsRoot(validators)
+	if err != nil {
+		return nil, err
+	}
+
+	randaoMixes := make([]phase0.Root, epochsPerHistoricalVector)
+	for i := range randaoMixes {
+		randaoMixes[i] = phase0.Root(input.eth1BlockHash)
+	}
+
+	syncCommittee, err := nextSyncCommittee(validators, phase0.Root(input.eth1BlockHash))
+	if err != nil {
+		return nil, err
+	}
+
+	bodyRoot, err := emptyBodyRoot(input.config.version)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &altair.BeaconState{
+		GenesisTime:           input.genesisTime,
+		GenesisValidatorsRoot: res.genesisValidatorsRoot,
+		Fork: &phase0.Fork{
+			PreviousVersion: input.config.previousVersion,
+			CurrentVersion:  input.config.currentVersion,
+			Epoch:           0,
+		},
+		LatestBlockHeader: &phase0.BeaconBlockHeader{
+			BodyRoot: bodyRoot,
+		},
+		BlockRoots:      make([]phase0.Root, slotsPerHistoricalRoot),
+		StateRoots:      make([]phase0.Root, slotsPerHistoricalRoot),
+		HistoricalRoots: make([]phase0.Root, 0),
+		ETH1Data: &phase0.ETH1Data{
+			DepositRoot:  depositRoot,
+			DepositCount: uint64(len(input.deposits)),
+			BlockHash:    input.eth1BlockHash[:],
+		},
+		ETH1DataVotes:               make([]*phase0.ETH1Data, 0),
+		ETH1DepositIndex:            uint64(len(input.deposits)),
+		Validators:                  validators,
+		Balances:                    balances,
+		RANDAOMixes:                 randaoMixes,
+		Slashings:                   make([]phase0.Gwei, epochsPerSlashingsVector),
+		PreviousEpochParticipation:  make([]altair.ParticipationFlags, len(validators)),
+		CurrentEpochParticipation:   make([]altair.ParticipationFlags, len(validators)),
+		JustificationBits:           bitfield.NewBitvector4(),
+		PreviousJustifiedCheckpoint: &phase0.Checkpoint{},
+		CurrentJustifiedCheckpoint:  &phase0.Checkpoint{},
+		FinalizedCheckpoint:         &phase0.Checkpoint{},
+		InactivityScores:            make([]uint64, len(validators)),
+		CurrentSyncCommittee:        syncCommittee,
+		NextSyncCommittee:           syncCommittee,
+	}
+
+	switch input.config.version {
+	case spec.DataVersionAltair:
+		res.state = &spec.VersionedBeaconState{
+			Version: spec.DataVersionAltair,
+			Altair:  state,
+		}
+	case spec.DataVersionBellatrix:
+		header := input.bellatrixHeader
+		if header == nil {
+			header = &bellatrix.ExecutionPayloadHeader{}
+		}
+		res.state = &spec.VersionedBeaconState{
+			Version:   spec.DataVersionBellatrix,
+			Bellatrix: bellatrixState(state, header),
+		}
+	case spec.DataVersionCapella:
+		header := input.capellaHeader
+		if header == nil {
+			header = &capella.ExecutionPayloadHeader{}
+		}
+		res.state = &spec.VersionedBeaconState{
+			Version: spec.DataVersionCapella,
+			Capella: capellaState(state, header),
+		}
+	default:
+		return nil, fmt.Errorf("unsupported genesis version %v", input.config.version)
+	}
+
+	return res, nil
+}
+
+// applyDeposits is the spec's apply_deposit() for each of the deposits, without
+// the Merkle proofs which are not required as the deposits are supplied directly.
+func applyDeposits(deposits []*phase0.DepositData,
+	genesisForkVersion phase0.Version,
+) (
+	[]*phase0.Validator,
+	[]phase0.Gwei,
+	[]int,
+) {
+	domain := phase0.Domain{}
+	copy(domain[:], e2types.Domain(e2types.DomainDeposit, genesisForkVersion[:], e2types.ZeroGenesisValidatorsRoot))
+
+	validators := make([]*phase0.Validator, 0, len(deposits))
+	balances := make([]phase0.Gwei, 0, len(deposits))
+	skipped := make([]int, 0)
+	indices := make(map[phase0.BLSPubKey]int, len(deposits))
+	for i, deposit := range deposits {
+		if index, exists := indices[deposit.PublicKey]; exists {
+			// Top-up.
+			balances[index] += deposit.Amount
+			continue
+		}
+
+		if !validDepositSignature(deposit, domain) {
+			skipped = append(skipped, i)
+			continue
+		}
+		effectiveBalance := deposit.Amount - deposit.Amount%effectiveBalanceIncrement
+		if effectiveBalance > maxEffectiveBalance {
+			effectiveBalance = maxEffectiveBalance
+		}
+		indices[deposit.PublicKey] = len(validators)
+		validators = append(validators, &phase0.Validator{
+			PublicKey:                  deposit.PublicKey,
+			WithdrawalCredentials:      deposit.WithdrawalCredentials,
+			EffectiveBalance:           effectiveBalance,
+			ActivationEligibilityEpoch: farFutureEpoch,
+			ActivationEpoch:            farFutureEpoch,
+			ExitEpoch:                  farFutureEpoch,
+			WithdrawableEpoch:          farFutureEpoch,
+		})
+		balances = append(balances, deposit.Amount)
+	}
+
+	return validators, balances, skipped
+}
+
+// validDepositSignature returns true if the deposit is signed by the key it
+// deposits for.
+func validDepositSignature(deposit *phase0.DepositData, domain phase0.Domain) bool {
+	messageRoot, err := (&phase0.DepositMessage{
+		PublicKey:             deposit.PublicKey,
+		WithdrawalCredentials: deposit.WithdrawalCredentials,
+		Amount:                deposit.Amount,
+	}).HashTreeRoot()
+	if err != nil {
+		return false
+	}
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: messageRoot,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return false
+	}
+	pubKeyBytes := make([]byte, len(deposit.PublicKey))
+	copy(pubKeyBytes, deposit.PublicKey[:])
+	pubKey, err := e2types.BLSPublicKeyFromBytes(pubKeyBytes)
+	if err != nil {
+		return false
+	}
+	sigBytes := make([]byte, len(deposit.Signature))
+	copy(sigBytes, deposit.Signature[:])
+	signature, err := e2types.BLSSignatureFromBytes(sigBytes)
+	if err != nil {
+		return false
+	}
+
+	return signature.Verify(signingRoot[:], pubKey)
+}
+
+// depositDataRoot is the root of the list of deposit data, as held in the
+// deposit contract.
+func depositDataRoot(deposits []*phase0.DepositData) (phase0.Root, error) {
+	hh := ssz.NewHasher()
+	indx := hh.Index()
+	for _, deposit := range deposits {
+		if err := deposit.HashTreeRootWith(hh); err != nil {
+			return phase0.Root{}, errors.Wrap(err, "failed to calculate deposit data root")
+		}
+	}
+	hh.MerkleizeWithMixin(indx, uint64(len(deposits)), depositListLimit)
+
+	root, err := hh.HashRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to calculate deposit data root")
+	}
+
+	return root, nil
+}
+
+// validatorsRoot is the root of the validator registry, which at genesis is
+// the genesis validators root.
+func validatorsRoot(validators []*phase0.Validator) (phase0.Root, error) {
+	hh := ssz.NewHasher()
+	indx := hh.Index()
+	for _, validator := range validators {
+		if err := validator.HashTreeRootWith(hh); err != nil {
+			return phase0.Root{}, errors.Wrap(err, "failed to calculate validators root")
+		}
+	}
+	hh.MerkleizeWithMixin(indx, uint64(len(validators)), validatorRegistryLimit)
+
+	root, err := hh.HashRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to calculate validators root")
+	}
+
+	return root, nil
+}
+
+// nextSyncCommittee is the spec's get_next_sync_committee() for a genesis
+// state, where all validators are active or pending and all RANDAO mixes
+// are the same.
+func nextSyncCommittee(validators []*phase0.Validator, mix phase0.Root) (*altair.SyncCommittee, error) {
+	// The sync committee is for the epoch after genesis.
+	epoch := phase0.Epoch(1)
+	activeIndices := make([]int, 0, len(validators))
+	for i, validator := range validators {
+		if validator.ActivationEpoch <= epoch && epoch < validator.ExitEpoch {
+			activeIndices = append(activeIndices, i)
+		}
+	}
+	if len(activeIndices) == 0 {
+		return nil, errors.New("no active validators for sync committee")
+	}
+	activeCount := uint64(len(activeIndices))
+
+	seed := syncCommitteeSeed(epoch, mix)
+	committee := &altair.SyncCommittee{
+		Pubkeys: make([]phase0.BLSPubKey, 0, syncCommitteeSize),
+	}
+	var randomBytes [32]byte
+	for i := uint64(0); len(committee.Pubkeys) < syncCommitteeSize; i++ {
+		if i%32 == 0 {
+			randomBytes = hashWithUint64(seed[:], i/32)
+		}
+		shuffledIndex := computeShuffledIndex(i%activeCount, activeCount, seed)
+		candidate := validators[activeIndices[shuffledIndex]]
+		if uint64(candidate.EffectiveBalance)*255 >= uint64(maxEffectiveBalance)*uint64(randomBytes[i%32]) {
+			committee.Pubkeys = append(committee.Pubkeys, candidate.PublicKey)
+		}
+	}
+
+	aggregate, err := e2types.BLSPublicKeyFromBytes(committee.Pubkeys[0][:])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid sync committee public key")
+	}
+	for _, pubKey := range committee.Pubkeys[1:] {
+		key, err := e2types.BLSPublicKeyFromBytes(pubKey[:])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid sync committee public key")
+		}
+		aggregate.Aggregate(key)
+	}
+	copy(committee.AggregatePubkey[:], aggregate.Marshal())
+
+	return committee, nil
+}
+
+// syncCommitteeSeed is the spec's get_seed() for the sync committee, given the
+// RANDAO mix for the relevant epoch.
+func syncCommitteeSeed(epoch phase0.Epoch, mix phase0.Root) [32]byte {
+	// The mix is for epoch + EPOCHS_PER_HISTORICAL_VECTOR - MIN_SEED_LOOKAHEAD - 1,
+	// but at genesis all mixes are the same.
+	data := make([]byte, 4+8+32)
+	copy(data, syncCommitteeDomainType[:])
+	binary.LittleEndian.PutUint64(data[4:], uint64(epoch))
+	copy(data[12:], mix[:])
+
+	return sha256.Sum256(data)
+}
+
+// computeShuffledIndex is the spec's compute_shuffled_index().
+func computeShuffledIndex(index uint64, indexCount uint64, seed [32]byte) uint64 {
+	buf := make([]byte, 32+1+4)
+	copy(buf, seed[:])
+	for round := 0; round < shuffleRoundCount; round++ {
+		buf[32] = byte(round)
+		pivotHash := sha256.Sum256(buf[:33])
+		pivot := binary.LittleEndian.Uint64(pivotHash[:8]) % indexCount
+		flip := (pivot + indexCount - index) % indexCount
+		position := index
+		if flip > position {
+			position = flip
+		}
+		binary.LittleEndian.PutUint32(buf[33:], uint32(position/256))
+		source := sha256.Sum256(buf)
+		bit := (source[(position%256)/8] >> (position % 8)) & 0x01
+		if bit == 1 {
+			index = flip
+		}
+	}
+
+	return index
+}
+
+func hashWithUint64(data []byte, val uint64) [32]byte {
+	buf := make([]byte, len(data)+8)
+	copy(buf, data)
+	binary.LittleEndian.PutUint64(buf[len(data):], val)
+
+	return sha256.Sum256(buf)
+}
+
+// emptyBodyRoot is the root of an empty beacon block body for the version.
+func emptyBodyRoot(version spec.DataVersion) (phase0.Root, error) {
+	eth1Data := &phase0.ETH1Data{
+		BlockHash: make([]byte, 32),
+	}
+	syncAggregate := &altair.SyncAggregate{
+		SyncCommitteeBits: bitfield.NewBitvector512(),
+	}
+
+	var root phase0.Root
+	var err error
+	switch version {
+	case spec.DataVersionAltair:
+		root, err = (&altair.BeaconBlockBody{
+			ETH1Data:      eth1Data,
+			SyncAggregate: syncAggregate,
+		}).HashTreeRoot()
+	case spec.DataVersionBellatrix:
+		root, err = (&bellatrix.BeaconBlockBody{
+			ETH1Data:         eth1Data,
+			SyncAggregate:    syncAggregate,
+			ExecutionPayload: &bellatrix.ExecutionPayload{},
+		}).HashTreeRoot()
+	case spec.DataVersionCapella:
+		root, err = (&capella.BeaconBlockBody{
+			ETH1Data:         eth1Data,
+			SyncAggregate:    syncAggregate,
+			ExecutionPayload: &capella.ExecutionPayload{},
+		}).HashTreeRoot()
+	default:
+		return root, fmt.Errorf("unsupported genesis version %v", version)
+	}
+	if err != nil {
+		return root, errors.Wrap(err, "failed to calculate block body root")
+	}
+
+	return root, nil
+}
+
+func bellatrixState(state *altair.BeaconState, header *bellatrix.ExecutionPayloadHeader) *bellatrix.BeaconState {
+	return &bellatrix.BeaconState{
+		GenesisTime:                  state.GenesisTime,
+		GenesisValidatorsRoot:        state.GenesisValidatorsRoot,
+		Slot:                         state.Slot,
+		Fork:                         state.Fork,
+		LatestBlockHeader:            state.LatestBlockHeader,
+		BlockRoots:                   state.BlockRoots,
+		StateRoots:                   state.StateRoots,
+		HistoricalRoots:              state.HistoricalRoots,
+		ETH1Data:                     state.ETH1Data,
+		ETH1DataVotes:                state.ETH1DataVotes,
+		ETH1DepositIndex:             state.ETH1DepositIndex,
+		Validators:                   state.Validators,
+		Balances:                     state.Balances,
+		RANDAOMixes:                  state.RANDAOMixes,
+		Slashings:                    state.Slashings,
+		PreviousEpochParticipation:   state.PreviousEpochParticipation,
+		CurrentEpochParticipation:    state.CurrentEpochParticipation,
+		JustificationBits:            state.JustificationBits,
+		PreviousJustifiedCheckpoint:  state.PreviousJustifiedCheckpoint,
+		CurrentJustifiedCheckpoint:   state.CurrentJustifiedCheckpoint,
+		FinalizedCheckpoint:          state.FinalizedCheckpoint,
+		InactivityScores:             state.InactivityScores,
+		CurrentSyncCommittee:         state.CurrentSyncCommittee,
+		NextSyncCommittee:            state.NextSyncCommittee,
+		LatestExecutionPayloadHeader: header,
+	}
+}
+
+func capellaState(state *altair.BeaconState, header *capella.ExecutionPayloadHeader) *capella.BeaconState {
+	return &capella.BeaconState{
+		GenesisTime:                  state.GenesisTime,
+		GenesisValidatorsRoot:        state.GenesisValidatorsRoot,
+		Slot:                         state.Slot,
+		Fork:                         state.Fork,
+		LatestBlockHeader:            state.LatestBlockHeader,
+		BlockRoots:                   state.BlockRoots,
+		StateRoots:                   state.StateRoots,
+		HistoricalRoots:              state.HistoricalRoots,
+		ETH1Data:                     state.ETH1Data,
+		ETH1DataVotes:                state.ETH1DataVotes,
+		ETH1DepositIndex:             state.ETH1DepositIndex,
+		Validators:                   state.Validators,
+		Balances:                     state.Balances,
+		RANDAOMixes:                  state.RANDAOMixes,
+		Slashings:                    state.Slashings,
+		PreviousEpochParticipation:   state.PreviousEpochParticipation,
+		CurrentEpochParticipation:    state.CurrentEpochParticipation,
+		JustificationBits:            state.JustificationBits,
+		PreviousJustifiedCheckpoint:  state.PreviousJustifiedCheckpoint,
+		CurrentJustifiedCheckpoint:   state.CurrentJustifiedCheckpoint,
+		FinalizedCheckpoint:          state.FinalizedCheckpoint,
+		InactivityScores:             state.InactivityScores,
+		CurrentSyncCommittee:         state.CurrentSyncCommittee,
+		NextSyncCommittee:            state.NextSyncCommittee,
+		LatestExecutionPayloadHeader: header,
+		NextWithdrawalIndex:          0,
+		NextWithdrawalValidatorIndex: 0,
+		HistoricalSummaries:          make([]*capella.HistoricalSummary, 0),
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingenesisgenerate
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// testDeposit creates a signed deposit for a key generated from the index.
+func testDeposit(t *testing.T, index byte, amount phase0.Gwei, forkVersion phase0.Version) *phase0.DepositData {
+	t.Helper()
+
+	keyBytes := make([]byte, 32)
+	keyBytes[0] = 0x01
+	keyBytes[31] = index + 1
+	key, err := e2types.BLSPrivateKeyFromBytes(keyBytes)
+	require.NoError(t, err)
+
+	deposit := &phase0.DepositData{
+		WithdrawalCredentials: make([]byte, 32),
+		Amount:                amount,
+	}
+	copy(deposit.PublicKey[:], key.PublicKey().Marshal())
+	deposit.WithdrawalCredentials[0] = 0x01
+	deposit.WithdrawalCredentials[31] = index
+
+	messageRoot, err := (&phase0.DepositMessage{
+		PublicKey:             deposit.PublicKey,
+		WithdrawalCredentials: deposit.WithdrawalCredentials,
+		Amount:                deposit.Amount,
+	}).HashTreeRoot()
+	require.NoError(t, err)
+	domain := phase0.Domain{}
+	copy(domain[:], e2types.Domain(e2types.DomainDeposit, forkVersion[:], e2types.ZeroGenesisValidatorsRoot))
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: messageRoot,
+		Domain:     domain,
+	}).HashTreeRoot()
+	require.NoError(t, err)
+	copy(deposit.Signature[:], key.Sign(signingRoot[:]).Marshal())
+
+	return deposit
+}
+
+func TestGenerateGenesis(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	genesisForkVersion := phase0.Version{0x10, 0x00, 0x00, 0x38}
+	config := &genesisConfig{
+		version:            spec.DataVersionCapella,
+		genesisForkVersion: genesisForkVersion,
+		previousVersion:    phase0.Version{0x30, 0x00, 0x00, 0x38},
+		currentVersion:     phase0.Version{0x40, 0x00, 0x00, 0x38},
+	}
+
+	deposits := []*phase0.DepositData{
+		testDeposit(t, 0, 32000000000, genesisForkVersion),
+		testDeposit(t, 1, 32000000000, genesisForkVersion),
+		// Partial deposit, topped up below.
+		testDeposit(t, 2, 16000000000, genesisForkVersion),
+		// Signed with the wrong fork version.
+		testDeposit(t, 3, 32000000000, phase0.Version{0x00, 0x00, 0x00, 0x00}),
+		// Insufficient for activation.
+		testDeposit(t, 4, 31500000000, genesisForkVersion),
+	}
+	topUp := *deposits[2]
+	topUp.Signature = phase0.BLSSignature{}
+	deposits = append(deposits, &topUp)
+
+	res, err := generateGenesis(&genesisInput{
+		config:        config,
+		genesisTime:   1700000000,
+		eth1BlockHash: phase0.Hash32{0x01},
+		deposits:      deposits,
+		capellaHeader: &capella.ExecutionPayloadHeader{BlockHash: phase0.Hash32{0x01}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{3}, res.skipped)
+	require.Equal(t, 4, res.validators)
+	require.Equal(t, 3, res.active)
+
+	state := res.state.Capella
+	require.NotNil(t, state)
+	require.Equal(t, uint64(1700000000), state.GenesisTime)
+	require.Equal(t, config.previousVersion, state.Fork.PreviousVersion)
+	require.Equal(t, config.currentVersion, state.Fork.CurrentVersion)
+	require.Equal(t, uint64(6), state.ETH1Data.DepositCount)
+	require.Equal(t, uint64(6), state.ETH1DepositIndex)
+	require.Equal(t, phase0.Gwei(32000000000), state.Balances[2])
+	require.Equal(t, phase0.Gwei(32000000000), state.Validators[2].EffectiveBalance)
+	require.Equal(t, phase0.Epoch(0), state.Validators[2].ActivationEpoch)
+	require.Equal(t, phase0.Gwei(31000000000), state.Validators[3].EffectiveBalance)
+	require.Equal(t, farFutureEpoch, state.Validators[3].ActivationEpoch)
+	require.Len(t, state.CurrentSyncCommittee.Pubkeys, syncCommitteeSize)
+	require.Equal(t, state.CurrentSyncCommittee, state.NextSyncCommittee)
+	require.Equal(t, phase0.Root{0x01}, state.RANDAOMixes[12345])
+
+	// Only active validators are in the sync committee.
+	inactive := state.Validators[3].PublicKey
+	for _, pubKey := range state.CurrentSyncCommittee.Pubkeys {
+		require.NotEqual(t, inactive, pubKey)
+	}
+
+	validatorsRoot, err := validatorsRoot(state.Validators)
+	require.NoError(t, err)
+	require.Equal(t, validatorsRoot, state.GenesisValidatorsRoot)
+
+	// Ensure the state round-trips.
+	data, err := state.MarshalSSZ()
+	require.NoError(t, err)
+	decoded := &capella.BeaconState{}
+	require.NoError(t, decoded.UnmarshalSSZ(data))
+	stateRoot, err := state.HashTreeRoot()
+	require.NoError(t, err)
+	decodedRoot, err := decoded.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, stateRoot, decodedRoot)
+}
+
+func TestGenerateGenesisVersions(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	genesisForkVersion := phase0.Version{0x10, 0x00, 0x00, 0x38}
+	deposits := []*phase0.DepositData{
+		testDeposit(t, 0, 32000000000, genesisForkVersion),
+	}
+
+	for _, version := range []spec.DataVersion{spec.DataVersionAltair, spec.DataVersionBellatrix, spec.DataVersionCapella} {
+		t.Run(version.String(), func(t *testing.T) {
+			res, err := generateGenesis(&genesisInput{
+				config: &genesisConfig{
+					version:            version,
+					genesisForkVersion: genesisForkVersion,
+				},
+				deposits: deposits,
+			})
+			require.NoError(t, err)
+			require.Equal(t, version, res.state.Version)
+		})
+	}
+}
+
+func TestGenerateGenesisErrors(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	genesisForkVersion := phase0.Version{0x10, 0x00, 0x00, 0x38}
+	config := &genesisConfig{
+		version:                        spec.DataVersionAltair,
+		genesisForkVersion:             genesisForkVersion,
+		minGenesisActiveValidatorCount: 2,
+	}
+
+	_, err := generateGenesis(&genesisInput{
+		config:   config,
+		deposits: []*phase0.DepositData{testDeposit(t, 0, 16000000000, genesisForkVersion)},
+	})
+	require.EqualError(t, err, "no validators are active at genesis")
+
+	_, err = generateGenesis(&genesisInput{
+		config:   config,
+		deposits: []*phase0.DepositData{testDeposit(t, 0, 32000000000, genesisForkVersion)},
+	})
+	require.EqualError(t, err, "1 validators are active at genesis, configuration requires at least 2")
+}
+
+func TestComputeShuffledIndex(t *testing.T) {
+	seed := [32]byte{0x01, 0x02, 0x03}
+	for _, count := range []uint64{1, 2, 10, 100} {
+		seen := make(map[uint64]bool)
+		for i := uint64(0); i < count; i++ {
+			index := computeShuffledIndex(i, count, seed)
+			require.Less(t, index, count)
+			seen[index] = true
+		}
+		// The shuffle is a permutation.
+		require.Len(t, seen, int(count))
+	}
+}
+
+func TestDepositDataRoot(t *testing.T) {
+	// The root of an empty deposit contract.
+	root, err := depositDataRoot(nil)
+	require.NoError(t, err)
+	require.Equal(t, "0xd70a234731285c6804c2a4f56711ddb8c82c99740f207854891028af34e27e5e", root.String())
+}
@@ -0,0 +1,51 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingenesisgenerate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+	state := c.result.state
+
+	builder.WriteString(fmt.Sprintf("Genesis state written to %s\n", c.outputFile))
+	builder.WriteString(fmt.Sprintf("Fork: %s\n", state.Version))
+	builder.WriteString(fmt.Sprintf("Genesis time: %s\n", time.Unix(int64(c.genesisTime), 0).Format("2006-01-02 15:04:05")))
+	builder.WriteString(fmt.Sprintf("Fork version: %#x\n", c.config.currentVersion))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Genesis fork version: %#x\n", c.config.genesisForkVersion))
+	}
+	builder.WriteString(fmt.Sprintf("Validators: %d (%d active)\n", c.result.validators, c.result.active))
+	if len(c.result.skipped) > 0 {
+		builder.WriteString(fmt.Sprintf("Deposits with invalid signatures: %d\n", len(c.result.skipped)))
+		if c.verbose {
+			for _, index := range c.result.skipped {
+				builder.WriteString(fmt.Sprintf("  Deposit %d\n", index))
+			}
+		}
+	}
+	builder.WriteString(fmt.Sprintf("Genesis validators root: %#x\n", c.result.genesisValidatorsRoot))
+	builder.WriteString(fmt.Sprintf("State root: %#x", c.stateRoot))
+
+	return builder.String(), nil
+}
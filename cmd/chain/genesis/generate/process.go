@@ -0,0 +1,136 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingenesisgenerate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// sszObject is a state that can be hashed and marshalled.
+type sszObject interface {
+	HashTreeRoot() ([32]byte, error)
+	MarshalSSZ() ([]byte, error)
+}
+
+func (c *command) process(ctx context.Context) error {
+	data, err := os.ReadFile(c.configFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read configuration file")
+	}
+	c.config, err = parseConfig(data)
+	if err != nil {
+		return err
+	}
+	util.Log.Debug().Stringer("version", c.config.version).Msg("Generating genesis state")
+
+	input := &genesisInput{
+		config:      c.config,
+		genesisTime: c.genesisTime,
+	}
+
+	input.deposits, err = c.obtainDeposits(ctx)
+	if err != nil {
+		return err
+	}
+	util.Log.Debug().Int("deposits", len(input.deposits)).Msg("Obtained deposits")
+
+	if c.executionPayloadHeader != "" {
+		if err := c.obtainExecutionPayloadHeader(input); err != nil {
+			return err
+		}
+	}
+
+	// The ETH1 block hash defaults to that of the execution genesis block if
+	// supplied.
+	switch {
+	case c.eth1BlockHash != nil:
+		input.eth1BlockHash = *c.eth1BlockHash
+	case input.bellatrixHeader != nil:
+		input.eth1BlockHash = input.bellatrixHeader.BlockHash
+	case input.capellaHeader != nil:
+		input.eth1BlockHash = input.capellaHeader.BlockHash
+	}
+
+	c.result, err = generateGenesis(input)
+	if err != nil {
+		return err
+	}
+
+	var state sszObject
+	switch c.result.state.Version {
+	case spec.DataVersionAltair:
+		state = c.result.state.Altair
+	case spec.DataVersionBellatrix:
+		state = c.result.state.Bellatrix
+	case spec.DataVersionCapella:
+		state = c.result.state.Capella
+	default:
+		return fmt.Errorf("unsupported genesis version %v", c.result.state.Version)
+	}
+	c.stateRoot, err = state.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate state root")
+	}
+	data, err = state.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal genesis state")
+	}
+	if err := os.WriteFile(c.outputFile, data, 0600); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to write %s", c.outputFile))
+	}
+
+	return nil
+}
+
+// obtainExecutionPayloadHeader obtains the execution payload header for the
+// genesis state, which should be that of the execution genesis block.
+func (c *command) obtainExecutionPayloadHeader(input *genesisInput) error {
+	var data []byte
+	if strings.HasPrefix(c.executionPayloadHeader, "{") {
+		data = []byte(c.executionPayloadHeader)
+	} else {
+		var err error
+		data, err = os.ReadFile(c.executionPayloadHeader)
+		if err != nil {
+			return errors.Wrap(err, "failed to read execution payload header file")
+		}
+	}
+
+	switch c.config.version {
+	case spec.DataVersionBellatrix:
+		input.bellatrixHeader = &bellatrix.ExecutionPayloadHeader{}
+		if err := json.Unmarshal(data, input.bellatrixHeader); err != nil {
+			return errors.Wrap(err, "invalid execution payload header")
+		}
+	case spec.DataVersionCapella:
+		input.capellaHeader = &capella.ExecutionPayloadHeader{}
+		if err := json.Unmarshal(data, input.capellaHeader); err != nil {
+			return errors.Wrap(err, "invalid execution payload header")
+		}
+	default:
+		return fmt.Errorf("execution payload header not used for genesis at %s", c.config.version)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainheads
+
+// Chain heads and fork choice are not available through the client, so are
+// obtained directly from the beacon node's debug API.
+
+type headsJSON struct {
+	Data []*headJSON `json:"data"`
+}
+
+type headJSON struct {
+	Root                string `json:"root"`
+	Slot                string `json:"slot"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+type forkChoiceJSON struct {
+	JustifiedCheckpoint *checkpointJSON       `json:"justified_checkpoint"`
+	FinalizedCheckpoint *checkpointJSON       `json:"finalized_checkpoint"`
+	Nodes               []*forkChoiceNodeJSON `json:"fork_choice_nodes"`
+}
+
+type checkpointJSON struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+type forkChoiceNodeJSON struct {
+	Slot       string `json:"slot"`
+	BlockRoot  string `json:"block_root"`
+	ParentRoot string `json:"parent_root"`
+	Weight     string `json:"weight"`
+	Validity   string `json:"validity"`
+}
@@ -0,0 +1,82 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainheads
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	eth2Client                 eth2client.Service
+	beaconBlockHeadersProvider eth2client.BeaconBlockHeadersProvider
+
+	// Output.
+	canonicalRoot  phase0.Root
+	forkChoice     bool
+	justifiedEpoch phase0.Epoch
+	finalizedEpoch phase0.Epoch
+	heads          []*head
+}
+
+// head is a chain head known to the beacon node.
+type head struct {
+	Root                phase0.Root
+	Slot                phase0.Slot
+	ExecutionOptimistic bool
+	Canonical           bool
+	// Weight is the fork choice weight of the head, if known.
+	Weight *phase0.Gwei
+	// Validity is the execution validity of the head, if known.
+	Validity string
+	// Divergence is the most recent block shared by the head and the
+	// canonical chain, if known.
+	Divergence *forkChoiceNode
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	return c, nil
+}
@@ -0,0 +1,159 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainheads
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// forkChoiceNode is a block in the node's fork choice store.
+type forkChoiceNode struct {
+	Slot       phase0.Slot
+	Root       phase0.Root
+	ParentRoot phase0.Root
+	Weight     phase0.Gwei
+	Validity   string
+}
+
+func parseHeads(data *headsJSON) ([]*head, error) {
+	heads := make([]*head, 0, len(data.Data))
+	for _, item := range data.Data {
+		root, err := parseRoot(item.Root)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid head root")
+		}
+		slot, err := strconv.ParseUint(item.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid head slot")
+		}
+		heads = append(heads, &head{
+			Root:                root,
+			Slot:                phase0.Slot(slot),
+			ExecutionOptimistic: item.ExecutionOptimistic,
+		})
+	}
+
+	return heads, nil
+}
+
+func parseForkChoiceNodes(data []*forkChoiceNodeJSON) (map[phase0.Root]*forkChoiceNode, error) {
+	nodes := make(map[phase0.Root]*forkChoiceNode, len(data))
+	for _, item := range data {
+		node := &forkChoiceNode{
+			Validity: item.Validity,
+		}
+		slot, err := strconv.ParseUint(item.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid fork choice node slot")
+		}
+		node.Slot = phase0.Slot(slot)
+		node.Root, err = parseRoot(item.BlockRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid fork choice node block root")
+		}
+		node.ParentRoot, err = parseRoot(item.ParentRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid fork choice node parent root")
+		}
+		weight, err := strconv.ParseUint(item.Weight, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid fork choice node weight")
+		}
+		node.Weight = phase0.Gwei(weight)
+		nodes[node.Root] = node
+	}
+
+	return nodes, nil
+}
+
+// analyseHeads annotates the heads with their fork choice information and
+// the point at which they diverge from the canonical chain, and sorts them
+// with the canonical head first followed by the heaviest.
+func analyseHeads(heads []*head, nodes map[phase0.Root]*forkChoiceNode, canonicalRoot phase0.Root) {
+	// Mark the ancestors of the canonical head.
+	canonical := make(map[phase0.Root]bool)
+	for root := canonicalRoot; ; {
+		node, exists := nodes[root]
+		if !exists || canonical[root] {
+			break
+		}
+		canonical[root] = true
+		root = node.ParentRoot
+	}
+
+	for _, head := range heads {
+		head.Canonical = head.Root == canonicalRoot
+		if node, exists := nodes[head.Root]; exists {
+			weight := node.Weight
+			head.Weight = &weight
+			head.Validity = node.Validity
+		}
+		if head.Canonical {
+			continue
+		}
+		// Walk back until we reach a block on the canonical chain.
+		visited := make(map[phase0.Root]bool)
+		for root := head.Root; !visited[root]; {
+			visited[root] = true
+			node, exists := nodes[root]
+			if !exists {
+				break
+			}
+			if canonical[root] {
+				head.Divergence = node
+				break
+			}
+			root = node.ParentRoot
+		}
+	}
+
+	sort.SliceStable(heads, func(i int, j int) bool {
+		if heads[i].Canonical != heads[j].Canonical {
+			return heads[i].Canonical
+		}
+		weightI := phase0.Gwei(0)
+		if heads[i].Weight != nil {
+			weightI = *heads[i].Weight
+		}
+		weightJ := phase0.Gwei(0)
+		if heads[j].Weight != nil {
+			weightJ = *heads[j].Weight
+		}
+		if weightI != weightJ {
+			return weightI > weightJ
+		}
+		return heads[i].Slot > heads[j].Slot
+	})
+}
+
+func parseRoot(input string) (phase0.Root, error) {
+	root := phase0.Root{}
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return root, err
+	}
+	if len(data) != phase0.RootLength {
+		return root, fmt.Errorf("expected %d bytes, found %d", phase0.RootLength, len(data))
+	}
+	copy(root[:], data)
+
+	return root, nil
+}
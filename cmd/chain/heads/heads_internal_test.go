@@ -0,0 +1,137 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainheads
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeads(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *headsJSON
+		heads int
+		err   string
+	}{
+		{
+			name:  "Empty",
+			input: &headsJSON{},
+		},
+		{
+			name: "RootInvalid",
+			input: &headsJSON{
+				Data: []*headJSON{{Root: "0xinvalid", Slot: "1"}},
+			},
+			err: "invalid head root: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "RootShort",
+			input: &headsJSON{
+				Data: []*headJSON{{Root: "0x0102", Slot: "1"}},
+			},
+			err: "invalid head root: expected 32 bytes, found 2",
+		},
+		{
+			name: "SlotInvalid",
+			input: &headsJSON{
+				Data: []*headJSON{{Root: "0x0000000000000000000000000000000000000000000000000000000000000001", Slot: "-1"}},
+			},
+			err: "invalid head slot: strconv.ParseUint: parsing \"-1\": invalid syntax",
+		},
+		{
+			name: "Good",
+			input: &headsJSON{
+				Data: []*headJSON{
+					{Root: "0x0000000000000000000000000000000000000000000000000000000000000001", Slot: "1"},
+					{Root: "0x0000000000000000000000000000000000000000000000000000000000000002", Slot: "2", ExecutionOptimistic: true},
+				},
+			},
+			heads: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			heads, err := parseHeads(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, heads, test.heads)
+			}
+		})
+	}
+}
+
+func TestAnalyseHeads(t *testing.T) {
+	root := func(i byte) phase0.Root {
+		return phase0.Root{i}
+	}
+
+	// Chain is 1 <- 2 <- 3 <- 4 (canonical), with 2 <- 5 <- 6 and 3 <- 7.
+	nodes := map[phase0.Root]*forkChoiceNode{
+		root(1): {Slot: 1, Root: root(1), Weight: 300},
+		root(2): {Slot: 2, Root: root(2), ParentRoot: root(1), Weight: 300},
+		root(3): {Slot: 3, Root: root(3), ParentRoot: root(2), Weight: 200},
+		root(4): {Slot: 4, Root: root(4), ParentRoot: root(3), Weight: 150, Validity: "valid"},
+		root(5): {Slot: 4, Root: root(5), ParentRoot: root(2), Weight: 100},
+		root(6): {Slot: 5, Root: root(6), ParentRoot: root(5), Weight: 100, Validity: "optimistic"},
+		root(7): {Slot: 4, Root: root(7), ParentRoot: root(3), Weight: 50},
+	}
+
+	heads := []*head{
+		{Root: root(7), Slot: 4},
+		{Root: root(6), Slot: 5},
+		{Root: root(4), Slot: 4},
+		{Root: root(8), Slot: 6},
+	}
+	analyseHeads(heads, nodes, root(4))
+
+	require.Len(t, heads, 4)
+
+	require.Equal(t, root(4), heads[0].Root)
+	require.True(t, heads[0].Canonical)
+	require.Equal(t, phase0.Gwei(150), *heads[0].Weight)
+	require.Equal(t, "valid", heads[0].Validity)
+	require.Nil(t, heads[0].Divergence)
+
+	require.Equal(t, root(6), heads[1].Root)
+	require.False(t, heads[1].Canonical)
+	require.Equal(t, phase0.Gwei(100), *heads[1].Weight)
+	require.Equal(t, root(2), heads[1].Divergence.Root)
+
+	require.Equal(t, root(7), heads[2].Root)
+	require.Equal(t, root(3), heads[2].Divergence.Root)
+
+	// Unknown to fork choice.
+	require.Equal(t, root(8), heads[3].Root)
+	require.Nil(t, heads[3].Weight)
+	require.Nil(t, heads[3].Divergence)
+}
+
+func TestAnalyseHeadsNoForkChoice(t *testing.T) {
+	heads := []*head{
+		{Root: phase0.Root{0x01}, Slot: 4},
+		{Root: phase0.Root{0x02}, Slot: 5},
+	}
+	analyseHeads(heads, map[phase0.Root]*forkChoiceNode{}, phase0.Root{0x01})
+
+	require.True(t, heads[0].Canonical)
+	require.Equal(t, phase0.Root{0x01}, heads[0].Root)
+	require.False(t, heads[1].Canonical)
+	require.Nil(t, heads[1].Divergence)
+}
@@ -0,0 +1,123 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainheads
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type headOutputJSON struct {
+	Root                string `json:"root"`
+	Slot                string `json:"slot"`
+	Canonical           bool   `json:"canonical"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+	Weight              string `json:"weight,omitempty"`
+	Validity            string `json:"validity,omitempty"`
+	DivergenceRoot      string `json:"divergence_root,omitempty"`
+	DivergenceSlot      string `json:"divergence_slot,omitempty"`
+}
+
+type outputJSON struct {
+	JustifiedEpoch string            `json:"justified_epoch,omitempty"`
+	FinalizedEpoch string            `json:"finalized_epoch,omitempty"`
+	Heads          []*headOutputJSON `json:"heads"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &outputJSON{
+		Heads: make([]*headOutputJSON, 0, len(c.heads)),
+	}
+	if c.forkChoice {
+		output.JustifiedEpoch = fmt.Sprintf("%d", c.justifiedEpoch)
+		output.FinalizedEpoch = fmt.Sprintf("%d", c.finalizedEpoch)
+	}
+	for _, head := range c.heads {
+		entry := &headOutputJSON{
+			Root:                fmt.Sprintf("%#x", head.Root),
+			Slot:                fmt.Sprintf("%d", head.Slot),
+			Canonical:           head.Canonical,
+			ExecutionOptimistic: head.ExecutionOptimistic,
+			Validity:            head.Validity,
+		}
+		if head.Weight != nil {
+			entry.Weight = fmt.Sprintf("%d", *head.Weight)
+		}
+		if head.Divergence != nil {
+			entry.DivergenceRoot = fmt.Sprintf("%#x", head.Divergence.Root)
+			entry.DivergenceSlot = fmt.Sprintf("%d", head.Divergence.Slot)
+		}
+		output.Heads = append(output.Heads, entry)
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.forkChoice {
+		builder.WriteString(fmt.Sprintf("Justified epoch: %d\n", c.justifiedEpoch))
+		builder.WriteString(fmt.Sprintf("Finalized epoch: %d\n", c.finalizedEpoch))
+	}
+	builder.WriteString(fmt.Sprintf("Heads: %d\n", len(c.heads)))
+
+	for _, head := range c.heads {
+		builder.WriteString(fmt.Sprintf("%#x (slot %d)", head.Root, head.Slot))
+		if head.Canonical {
+			builder.WriteString(" canonical")
+		}
+		if head.ExecutionOptimistic {
+			builder.WriteString(" optimistic")
+		}
+		builder.WriteString("\n")
+		if head.Weight != nil {
+			builder.WriteString(fmt.Sprintf("  Weight: %s\n", string2eth.GWeiToString(uint64(*head.Weight), true)))
+		}
+		if c.verbose && head.Validity != "" {
+			builder.WriteString(fmt.Sprintf("  Validity: %s\n", head.Validity))
+		}
+		if head.Canonical {
+			continue
+		}
+		switch {
+		case head.Divergence != nil:
+			builder.WriteString(fmt.Sprintf("  Diverges from canonical chain after slot %d (%#x), %d slots before head\n", head.Divergence.Slot, head.Divergence.Root, head.Slot-head.Divergence.Slot))
+		case c.forkChoice:
+			builder.WriteString("  Divergence from canonical chain not found in fork choice\n")
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,108 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainheads
+
+import (
+	"context"
+	"strconv"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	headsData := &headsJSON{}
+	found, err := util.FetchBeaconNodeJSON(ctx, c.eth2Client.Address(), c.timeout, "/eth/v2/debug/beacon/heads", nil, headsData)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain heads")
+	}
+	if !found {
+		return errors.New("node does not provide heads; its debug API may need to be enabled")
+	}
+	c.heads, err = parseHeads(headsData)
+	if err != nil {
+		return err
+	}
+
+	header, err := c.beaconBlockHeadersProvider.BeaconBlockHeader(ctx, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain canonical head")
+	}
+	if header == nil {
+		return errors.New("canonical head not returned")
+	}
+	c.canonicalRoot = header.Root
+
+	// Fork choice is optional; without it the heads are listed but weights
+	// and divergence points are not available.
+	nodes := make(map[phase0.Root]*forkChoiceNode)
+	forkChoiceData := &forkChoiceJSON{}
+	found, err = util.FetchBeaconNodeJSON(ctx, c.eth2Client.Address(), c.timeout, "/eth/v1/debug/fork_choice", nil, forkChoiceData)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain fork choice")
+	}
+	if found {
+		c.forkChoice = true
+		nodes, err = parseForkChoiceNodes(forkChoiceData.Nodes)
+		if err != nil {
+			return err
+		}
+		if forkChoiceData.JustifiedCheckpoint != nil {
+			epoch, err := strconv.ParseUint(forkChoiceData.JustifiedCheckpoint.Epoch, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "invalid justified epoch")
+			}
+			c.justifiedEpoch = phase0.Epoch(epoch)
+		}
+		if forkChoiceData.FinalizedCheckpoint != nil {
+			epoch, err := strconv.ParseUint(forkChoiceData.FinalizedCheckpoint.Epoch, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "invalid finalized epoch")
+			}
+			c.finalizedEpoch = phase0.Epoch(epoch)
+		}
+		util.Log.Debug().Int("nodes", len(nodes)).Msg("Obtained fork choice nodes")
+	} else {
+		util.Log.Debug().Msg("Node does not provide fork choice")
+	}
+
+	analyseHeads(c.heads, nodes, c.canonicalRoot)
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.beaconBlockHeadersProvider, isProvider = c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon block headers")
+	}
+
+	return nil
+}
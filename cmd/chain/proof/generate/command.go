@@ -0,0 +1,92 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproofgenerate
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	state string
+	field string
+	index *uint64
+
+	// Processing.
+	consensusClient consensusclient.Service
+	chainTime       chaintime.Service
+
+	// Output.
+	proof *util.MerkleProof
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+		state:   viper.GetString("state"),
+		field:   strings.ToLower(strings.ReplaceAll(viper.GetString("field"), "-", "_")),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if c.field == "" {
+		return nil, errors.New("field is required")
+	}
+
+	if viper.GetString("index") != "" {
+		index, err := strconv.ParseUint(viper.GetString("index"), 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid index")
+		}
+		c.index = &index
+	}
+	_, isElementField := elementFields[c.field]
+	if isElementField && c.index == nil {
+		return nil, errors.New("index is required for this field")
+	}
+	if !isElementField && c.index != nil {
+		return nil, errors.New("index is not supported for this field")
+	}
+
+	return c, nil
+}
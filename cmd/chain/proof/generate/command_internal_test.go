@@ -0,0 +1,102 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproofgenerate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"field": "slot",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "FieldMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "field is required",
+		},
+		{
+			name: "IndexInvalid",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"field":   "validators",
+				"index":   "invalid",
+			},
+			err: "invalid index: strconv.ParseUint: parsing \"invalid\": invalid syntax",
+		},
+		{
+			name: "IndexMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"field":   "validators",
+			},
+			err: "index is required for this field",
+		},
+		{
+			name: "IndexNotSupported",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"field":   "slot",
+				"index":   "1",
+			},
+			err: "index is not supported for this field",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"field":   "finalized_checkpoint",
+			},
+		},
+		{
+			name: "GoodElement",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"field":   "block-roots",
+				"index":   "100",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproofgenerate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+	if c.proof == nil {
+		return "", errors.New("no proof")
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.proof)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("State root: %#x\n", c.proof.StateRoot))
+	builder.WriteString(fmt.Sprintf("Slot: %d\n", c.proof.Slot))
+	builder.WriteString(fmt.Sprintf("Field: %s\n", c.proof.Field))
+	if c.proof.Index != nil {
+		builder.WriteString(fmt.Sprintf("Index: %d\n", *c.proof.Index))
+	}
+	builder.WriteString(fmt.Sprintf("Generalized index: %d\n", c.proof.GeneralizedIndex))
+	builder.WriteString(fmt.Sprintf("Leaf: %#x\n", c.proof.Leaf))
+	builder.WriteString("Branch:")
+	for i := range c.proof.Branch {
+		builder.WriteString(fmt.Sprintf("\n  %#x", c.proof.Branch[i]))
+	}
+
+	return builder.String(), nil
+}
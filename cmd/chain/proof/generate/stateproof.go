@@ -0,0 +1,432 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproofgenerate
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// elementField is a state field whose individual elements can be proved.
+type elementField struct {
+	// list is true if the field is a list, in which case its length is
+	// mixed in to its root.
+	list bool
+	// depth is the depth of the tree of a list's chunks.  The depth of a
+	// vector is obtained from its length.
+	depth int
+	// perChunk is the number of elements packed in to each chunk.
+	perChunk uint64
+}
+
+var elementFields = map[string]*elementField{
+	"validators":   {list: true, depth: 40, perChunk: 1},
+	"balances":     {list: true, depth: 38, perChunk: 4},
+	"block_roots":  {perChunk: 1},
+	"state_roots":  {perChunk: 1},
+	"randao_mixes": {perChunk: 1},
+}
+
+var altairStateFields = []string{
+	"genesis_time",
+	"genesis_validators_root",
+	"slot",
+	"fork",
+	"latest_block_header",
+	"block_roots",
+	"state_roots",
+	"historical_roots",
+	"eth1_data",
+	"eth1_data_votes",
+	"eth1_deposit_index",
+	"validators",
+	"balances",
+	"randao_mixes",
+	"slashings",
+	"previous_epoch_participation",
+	"current_epoch_participation",
+	"justification_bits",
+	"previous_justified_checkpoint",
+	"current_justified_checkpoint",
+	"finalized_checkpoint",
+	"inactivity_scores",
+	"current_sync_committee",
+	"next_sync_committee",
+}
+
+var bellatrixStateFields = append(append([]string{}, altairStateFields...),
+	"latest_execution_payload_header",
+)
+
+var capellaStateFields = append(append([]string{}, bellatrixStateFields...),
+	"next_withdrawal_index",
+	"next_withdrawal_validator_index",
+	"historical_summaries",
+)
+
+// zeroHashes are the roots of empty trees of each depth.
+var zeroHashes [64]phase0.Root
+
+func init() {
+	for i := 1; i < len(zeroHashes); i++ {
+		zeroHashes[i] = hashPair(zeroHashes[i-1], zeroHashes[i-1])
+	}
+}
+
+// stateData is the version-independent data from a state required to
+// generate proofs.
+type stateData struct {
+	state       ssz.HashRoot
+	fields      []string
+	slot        phase0.Slot
+	validators  []*phase0.Validator
+	balances    []phase0.Gwei
+	blockRoots  []phase0.Root
+	stateRoots  []phase0.Root
+	randaoMixes []phase0.Root
+}
+
+// subtreeProof is a proof of a leaf within the subtree of a single field.
+type subtreeProof struct {
+	leaf   phase0.Root
+	branch []phase0.Root
+	gindex uint64
+	root   phase0.Root
+}
+
+func newStateData(state *spec.VersionedBeaconState) (*stateData, error) {
+	switch state.Version {
+	case spec.DataVersionAltair:
+		if state.Altair == nil {
+			return nil, errors.New("no altair state")
+		}
+		return &stateData{
+			state:       state.Altair,
+			fields:      altairStateFields,
+			slot:        state.Altair.Slot,
+			validators:  state.Altair.Validators,
+			balances:    state.Altair.Balances,
+			blockRoots:  state.Altair.BlockRoots,
+			stateRoots:  state.Altair.StateRoots,
+			randaoMixes: state.Altair.RANDAOMixes,
+		}, nil
+	case spec.DataVersionBellatrix:
+		if state.Bellatrix == nil {
+			return nil, errors.New("no bellatrix state")
+		}
+		return &stateData{
+			state:       state.Bellatrix,
+			fields:      bellatrixStateFields,
+			slot:        state.Bellatrix.Slot,
+			validators:  state.Bellatrix.Validators,
+			balances:    state.Bellatrix.Balances,
+			blockRoots:  state.Bellatrix.BlockRoots,
+			stateRoots:  state.Bellatrix.StateRoots,
+			randaoMixes: state.Bellatrix.RANDAOMixes,
+		}, nil
+	case spec.DataVersionCapella:
+		if state.Capella == nil {
+			return nil, errors.New("no capella state")
+		}
+		return &stateData{
+			state:       state.Capella,
+			fields:      capellaStateFields,
+			slot:        state.Capella.Slot,
+			validators:  state.Capella.Validators,
+			balances:    state.Capella.Balances,
+			blockRoots:  state.Capella.BlockRoots,
+			stateRoots:  state.Capella.StateRoots,
+			randaoMixes: state.Capella.RANDAOMixes,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported state version %v", state.Version)
+	}
+}
+
+// generateProof generates a proof of the given field of the state, or of an
+// element of the field if an index is supplied.
+func generateProof(state *spec.VersionedBeaconState, field string, index *uint64) (*util.MerkleProof, error) {
+	data, err := newStateData(state)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldIndex := -1
+	for i := range data.fields {
+		if data.fields[i] == field {
+			fieldIndex = i
+			break
+		}
+	}
+	if fieldIndex == -1 {
+		return nil, fmt.Errorf("unknown field %s for %v state", field, state.Version)
+	}
+
+	fieldRoots, stateRoot, err := stateFieldRoots(data.state)
+	if err != nil {
+		return nil, err
+	}
+	if len(fieldRoots) != len(data.fields) {
+		return nil, fmt.Errorf("expected %d fields in %v state, found %d", len(data.fields), state.Version, len(fieldRoots))
+	}
+
+	depth := bits.Len64(uint64(len(fieldRoots) - 1))
+	branch, root := merkleBranch(fieldRoots, depth, uint64(fieldIndex))
+	if root != stateRoot {
+		return nil, errors.New("calculated state root does not match that of the state")
+	}
+	proof := &util.MerkleProof{
+		StateRoot:        stateRoot,
+		Slot:             data.slot,
+		Field:            field,
+		GeneralizedIndex: uint64(1)<<depth + uint64(fieldIndex),
+		Leaf:             fieldRoots[fieldIndex],
+		Branch:           branch,
+	}
+	if index == nil {
+		return proof, nil
+	}
+
+	elementProof, err := data.elementProof(field, *index)
+	if err != nil {
+		return nil, err
+	}
+	if elementProof.root != fieldRoots[fieldIndex] {
+		return nil, fmt.Errorf("calculated root of %s does not match that of the state", field)
+	}
+	proof.Index = index
+	proof.Leaf = elementProof.leaf
+	proof.Branch = append(elementProof.branch, proof.Branch...)
+	proof.GeneralizedIndex = concatGindices(proof.GeneralizedIndex, elementProof.gindex)
+
+	return proof, nil
+}
+
+// elementProof generates a proof of the chunk holding an element of a field
+// against the root of the field.
+func (d *stateData) elementProof(name string, index uint64) (*subtreeProof, error) {
+	field, exists := elementFields[name]
+	if !exists {
+		return nil, fmt.Errorf("elements of %s cannot be proved", name)
+	}
+
+	var chunks []phase0.Root
+	var length uint64
+	switch name {
+	case "validators":
+		length = uint64(len(d.validators))
+		chunks = make([]phase0.Root, len(d.validators))
+		for i := range d.validators {
+			root, err := d.validators[i].HashTreeRoot()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to calculate validator root")
+			}
+			chunks[i] = root
+		}
+	case "balances":
+		length = uint64(len(d.balances))
+		chunks = make([]phase0.Root, (len(d.balances)+3)/4)
+		for i := range d.balances {
+			binary.LittleEndian.PutUint64(chunks[i/4][(i%4)*8:], uint64(d.balances[i]))
+		}
+	case "block_roots":
+		length = uint64(len(d.blockRoots))
+		chunks = d.blockRoots
+	case "state_roots":
+		length = uint64(len(d.stateRoots))
+		chunks = d.stateRoots
+	case "randao_mixes":
+		length = uint64(len(d.randaoMixes))
+		chunks = d.randaoMixes
+	}
+	if index >= length {
+		return nil, fmt.Errorf("index %d out of range for %s, which has %d elements", index, name, length)
+	}
+
+	depth := field.depth
+	if !field.list {
+		depth = bits.Len64(uint64(len(chunks) - 1))
+	}
+	chunkIndex := index / field.perChunk
+	branch, root := merkleBranch(chunks, depth, chunkIndex)
+	proof := &subtreeProof{
+		leaf:   chunks[chunkIndex],
+		branch: branch,
+		gindex: uint64(1)<<depth + chunkIndex,
+		root:   root,
+	}
+	if field.list {
+		// The root of a list is the root of its chunks mixed in with its length.
+		var lengthChunk phase0.Root
+		binary.LittleEndian.PutUint64(lengthChunk[:], length)
+		proof.branch = append(proof.branch, lengthChunk)
+		proof.gindex = concatGindices(2, proof.gindex)
+		proof.root = hashPair(root, lengthChunk)
+	}
+
+	return proof, nil
+}
+
+// fieldRootsWalker is a hash walker that captures the roots of the top-level
+// fields of the container being hashed.
+type fieldRootsWalker struct {
+	*ssz.Hasher
+	depth int
+	roots []phase0.Root
+}
+
+// stateFieldRoots provides the roots of the top-level fields of the state,
+// along with the root of the state itself.
+func stateFieldRoots(state ssz.HashRoot) ([]phase0.Root, phase0.Root, error) {
+	w := &fieldRootsWalker{
+		Hasher: ssz.NewHasher(),
+	}
+	if err := state.HashTreeRootWith(w); err != nil {
+		return nil, phase0.Root{}, errors.Wrap(err, "failed to hash state")
+	}
+	root, err := w.HashRoot()
+	if err != nil {
+		return nil, phase0.Root{}, errors.Wrap(err, "failed to obtain state root")
+	}
+
+	return w.roots, root, nil
+}
+
+// Index marks the start of a container or collection.
+func (w *fieldRootsWalker) Index() int {
+	w.depth++
+	return w.Hasher.Index()
+}
+
+// Merkleize marks the end of a container or collection.
+func (w *fieldRootsWalker) Merkleize(indx int) {
+	w.Hasher.Merkleize(indx)
+	w.depth--
+	w.capture()
+}
+
+// MerkleizeWithMixin marks the end of a list.
+func (w *fieldRootsWalker) MerkleizeWithMixin(indx int, num, limit uint64) {
+	w.Hasher.MerkleizeWithMixin(indx, num, limit)
+	w.depth--
+	w.capture()
+}
+
+// PutUint64 adds a basic value.
+func (w *fieldRootsWalker) PutUint64(i uint64) {
+	w.Hasher.PutUint64(i)
+	w.capture()
+}
+
+// PutUint32 adds a basic value.
+func (w *fieldRootsWalker) PutUint32(i uint32) {
+	w.Hasher.PutUint32(i)
+	w.capture()
+}
+
+// PutUint16 adds a basic value.
+func (w *fieldRootsWalker) PutUint16(i uint16) {
+	w.Hasher.PutUint16(i)
+	w.capture()
+}
+
+// PutUint8 adds a basic value.
+func (w *fieldRootsWalker) PutUint8(i uint8) {
+	w.Hasher.PutUint8(i)
+	w.capture()
+}
+
+// PutBool adds a basic value.
+func (w *fieldRootsWalker) PutBool(b bool) {
+	w.Hasher.PutBool(b)
+	w.capture()
+}
+
+// PutBytes adds a byte array.
+func (w *fieldRootsWalker) PutBytes(b []byte) {
+	w.Hasher.PutBytes(b)
+	w.capture()
+}
+
+// PutBitlist adds a bitlist.
+func (w *fieldRootsWalker) PutBitlist(bb []byte, maxSize uint64) {
+	w.Hasher.PutBitlist(bb, maxSize)
+	w.capture()
+}
+
+// capture captures the most recent root if it is that of a top-level field.
+func (w *fieldRootsWalker) capture() {
+	if w.depth != 1 {
+		return
+	}
+	var root phase0.Root
+	copy(root[:], w.Hash())
+	w.roots = append(w.roots, root)
+}
+
+// merkleBranch provides the branch for the chunk at the given index of a tree
+// of the given depth, along with the root of the tree.  Chunks beyond those
+// supplied are zero.
+func merkleBranch(chunks []phase0.Root, depth int, index uint64) ([]phase0.Root, phase0.Root) {
+	branch := make([]phase0.Root, 0, depth)
+	layer := chunks
+	for level := 0; level < depth; level++ {
+		if sibling := index ^ 1; sibling < uint64(len(layer)) {
+			branch = append(branch, layer[sibling])
+		} else {
+			branch = append(branch, zeroHashes[level])
+		}
+
+		next := make([]phase0.Root, (len(layer)+1)/2)
+		for i := range next {
+			right := zeroHashes[level]
+			if 2*i+1 < len(layer) {
+				right = layer[2*i+1]
+			}
+			next[i] = hashPair(layer[2*i], right)
+		}
+		layer = next
+		index /= 2
+	}
+
+	if len(layer) == 0 {
+		return branch, zeroHashes[depth]
+	}
+	return branch, layer[0]
+}
+
+// concatGindices provides the generalized index of a node within a subtree
+// whose root is at the given generalized index.
+func concatGindices(root uint64, gindex uint64) uint64 {
+	depth := bits.Len64(gindex) - 1
+	return root<<depth | (gindex - uint64(1)<<depth)
+}
+
+func hashPair(left phase0.Root, right phase0.Root) phase0.Root {
+	hash := sha256.New()
+	hash.Write(left[:])
+	hash.Write(right[:])
+	var root phase0.Root
+	copy(root[:], hash.Sum(nil))
+
+	return root
+}
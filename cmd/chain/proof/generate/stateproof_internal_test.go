@@ -0,0 +1,215 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproofgenerate
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func testState(t *testing.T, validators int) *spec.VersionedBeaconState {
+	t.Helper()
+
+	state := &capella.BeaconState{
+		GenesisTime: 1606824023,
+		Slot:        12345,
+		BlockRoots:  make([]phase0.Root, 8192),
+		StateRoots:  make([]phase0.Root, 8192),
+		RANDAOMixes: make([]phase0.Root, 65536),
+		Slashings:   make([]phase0.Gwei, 8192),
+		ETH1Data: &phase0.ETH1Data{
+			BlockHash: make([]byte, 32),
+		},
+		FinalizedCheckpoint: &phase0.Checkpoint{
+			Epoch: 380,
+			Root:  phase0.Root{0x01, 0x02},
+		},
+		JustificationBits: []byte{0x0f},
+		CurrentSyncCommittee: &altair.SyncCommittee{
+			Pubkeys: make([]phase0.BLSPubKey, 512),
+		},
+		NextSyncCommittee: &altair.SyncCommittee{
+			Pubkeys: make([]phase0.BLSPubKey, 512),
+		},
+		LatestExecutionPayloadHeader: &capella.ExecutionPayloadHeader{},
+	}
+	for i := range state.BlockRoots {
+		state.BlockRoots[i] = phase0.Root{byte(i), byte(i >> 8), 0x01}
+		state.StateRoots[i] = phase0.Root{byte(i), byte(i >> 8), 0x02}
+	}
+	for i := 0; i < validators; i++ {
+		state.Validators = append(state.Validators, &phase0.Validator{
+			PublicKey:                  phase0.BLSPubKey{byte(i), byte(i >> 8)},
+			WithdrawalCredentials:      make([]byte, 32),
+			EffectiveBalance:           32000000000,
+			ActivationEligibilityEpoch: phase0.Epoch(i),
+			ExitEpoch:                  0xffffffffffffffff,
+			WithdrawableEpoch:          0xffffffffffffffff,
+		})
+		state.Balances = append(state.Balances, phase0.Gwei(32000000000+i))
+		state.PreviousEpochParticipation = append(state.PreviousEpochParticipation, 0x07)
+		state.CurrentEpochParticipation = append(state.CurrentEpochParticipation, 0x03)
+		state.InactivityScores = append(state.InactivityScores, uint64(i))
+	}
+
+	return &spec.VersionedBeaconState{
+		Version: spec.DataVersionCapella,
+		Capella: state,
+	}
+}
+
+func TestGenerateProof(t *testing.T) {
+	state := testState(t, 5)
+	stateRoot, err := state.Capella.HashTreeRoot()
+	require.NoError(t, err)
+
+	validatorRoot, err := state.Capella.Validators[3].HashTreeRoot()
+	require.NoError(t, err)
+	var slotLeaf phase0.Root
+	binary.LittleEndian.PutUint64(slotLeaf[:], 12345)
+	var balancesLeaf phase0.Root
+	binary.LittleEndian.PutUint64(balancesLeaf[0:], 32000000004)
+	checkpointRoot, err := state.Capella.FinalizedCheckpoint.HashTreeRoot()
+	require.NoError(t, err)
+
+	index := func(i uint64) *uint64 { return &i }
+
+	tests := []struct {
+		name   string
+		state  *spec.VersionedBeaconState
+		field  string
+		index  *uint64
+		gindex uint64
+		leaf   phase0.Root
+		err    string
+	}{
+		{
+			name:  "Phase0",
+			state: &spec.VersionedBeaconState{Version: spec.DataVersionPhase0, Phase0: &phase0.BeaconState{}},
+			field: "slot",
+			err:   "unsupported state version phase0",
+		},
+		{
+			name:  "UnknownField",
+			state: state,
+			field: "unknown",
+			err:   "unknown field unknown for capella state",
+		},
+		{
+			name:  "ElementNotSupported",
+			state: state,
+			field: "slot",
+			index: index(1),
+			err:   "elements of slot cannot be proved",
+		},
+		{
+			name:  "IndexOutOfRange",
+			state: state,
+			field: "validators",
+			index: index(5),
+			err:   "index 5 out of range for validators, which has 5 elements",
+		},
+		{
+			name:   "Slot",
+			state:  state,
+			field:  "slot",
+			gindex: 34,
+			leaf:   slotLeaf,
+		},
+		{
+			name:   "FinalizedCheckpoint",
+			state:  state,
+			field:  "finalized_checkpoint",
+			gindex: 52,
+			leaf:   checkpointRoot,
+		},
+		{
+			name:   "Validator",
+			state:  state,
+			field:  "validators",
+			index:  index(3),
+			gindex: 43<<41 + 3,
+			leaf:   validatorRoot,
+		},
+		{
+			name:   "Balance",
+			state:  state,
+			field:  "balances",
+			index:  index(4),
+			gindex: 44<<39 + 1,
+			leaf:   balancesLeaf,
+		},
+		{
+			name:   "BlockRoot",
+			state:  state,
+			field:  "block_roots",
+			index:  index(1000),
+			gindex: 37<<13 + 1000,
+			leaf:   state.Capella.BlockRoots[1000],
+		},
+		{
+			name:   "StateRoot",
+			state:  state,
+			field:  "state_roots",
+			index:  index(8191),
+			gindex: 38<<13 + 8191,
+			leaf:   state.Capella.StateRoots[8191],
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proof, err := generateProof(test.state, test.field, test.index)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, phase0.Root(stateRoot), proof.StateRoot)
+			require.Equal(t, phase0.Slot(12345), proof.Slot)
+			require.Equal(t, test.gindex, proof.GeneralizedIndex)
+			require.Equal(t, test.leaf, proof.Leaf)
+			require.NoError(t, proof.Verify(stateRoot))
+		})
+	}
+}
+
+func TestMerkleBranch(t *testing.T) {
+	chunks := []phase0.Root{{0x01}, {0x02}, {0x03}}
+
+	branch, root := merkleBranch(chunks, 2, 2)
+	require.Equal(t, []phase0.Root{{}, hashPair(chunks[0], chunks[1])}, branch)
+	require.Equal(t, hashPair(hashPair(chunks[0], chunks[1]), hashPair(chunks[2], phase0.Root{})), root)
+
+	// Deeper trees are padded with the roots of empty subtrees.
+	branch, deepRoot := merkleBranch(chunks, 4, 0)
+	require.Len(t, branch, 4)
+	require.Equal(t, zeroHashes[2], branch[2])
+	require.Equal(t, hashPair(hashPair(root, zeroHashes[2]), zeroHashes[3]), deepRoot)
+
+	_, emptyRoot := merkleBranch(nil, 3, 0)
+	require.Equal(t, zeroHashes[3], emptyRoot)
+}
+
+func TestConcatGindices(t *testing.T) {
+	require.Equal(t, uint64(43), concatGindices(1, 43))
+	require.Equal(t, uint64(86), concatGindices(43, 2))
+	require.Equal(t, uint64(43<<41+3), concatGindices(43, concatGindices(2, 1<<40+3)))
+}
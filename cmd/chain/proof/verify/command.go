@@ -0,0 +1,72 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproofverify
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	proofInput string
+	stateRoot  *phase0.Root
+
+	// Processing.
+	proof *util.MerkleProof
+
+	// Output.
+	verified bool
+	reason   string
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:      viper.GetBool("quiet"),
+		verbose:    viper.GetBool("verbose"),
+		debug:      viper.GetBool("debug"),
+		json:       viper.GetBool("json"),
+		proofInput: viper.GetString("proof"),
+	}
+
+	if c.proofInput == "" {
+		return nil, errors.New("proof is required")
+	}
+
+	if viper.GetString("state-root") != "" {
+		data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("state-root"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid state root")
+		}
+		if len(data) != phase0.RootLength {
+			return nil, errors.New("state root must be 32 bytes")
+		}
+		stateRoot := phase0.Root{}
+		copy(stateRoot[:], data)
+		c.stateRoot = &stateRoot
+	}
+
+	return c, nil
+}
@@ -0,0 +1,100 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproofverify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+type resultJSON struct {
+	StateRoot        string `json:"state_root"`
+	Field            string `json:"field"`
+	Index            string `json:"index,omitempty"`
+	GeneralizedIndex string `json:"gindex"`
+	Leaf             string `json:"leaf"`
+	Verified         bool   `json:"verified"`
+	Reason           string `json:"reason,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+	if c.proof == nil {
+		return "", errors.New("no proof")
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	res := &resultJSON{
+		StateRoot:        fmt.Sprintf("%#x", c.verifiedStateRoot()),
+		Field:            c.proof.Field,
+		GeneralizedIndex: fmt.Sprintf("%d", c.proof.GeneralizedIndex),
+		Leaf:             fmt.Sprintf("%#x", c.proof.Leaf),
+		Verified:         c.verified,
+		Reason:           c.reason,
+	}
+	if c.proof.Index != nil {
+		res.Index = fmt.Sprintf("%d", *c.proof.Index)
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verbose || !c.verified {
+		builder.WriteString(fmt.Sprintf("State root: %#x\n", c.verifiedStateRoot()))
+		builder.WriteString(fmt.Sprintf("Field: %s\n", c.proof.Field))
+		if c.proof.Index != nil {
+			builder.WriteString(fmt.Sprintf("Index: %d\n", *c.proof.Index))
+		}
+		builder.WriteString(fmt.Sprintf("Generalized index: %d\n", c.proof.GeneralizedIndex))
+		builder.WriteString(fmt.Sprintf("Leaf: %#x\n", c.proof.Leaf))
+	}
+	if c.verified {
+		builder.WriteString("Proof verified")
+		if c.stateRoot == nil {
+			builder.WriteString(" against the state root in the proof; supply --state-root to verify against a trusted state root")
+		}
+	} else {
+		builder.WriteString(fmt.Sprintf("Proof FAILED verification: %s", c.reason))
+	}
+
+	return builder.String(), nil
+}
+
+// verifiedStateRoot is the state root against which the proof was verified.
+func (c *command) verifiedStateRoot() phase0.Root {
+	if c.stateRoot != nil {
+		return *c.stateRoot
+	}
+	return c.proof.StateRoot
+}
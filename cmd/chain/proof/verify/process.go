@@ -0,0 +1,57 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproofverify
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(_ context.Context) error {
+	var data []byte
+	if strings.HasPrefix(c.proofInput, "{") {
+		data = []byte(c.proofInput)
+	} else {
+		var err error
+		data, err = os.ReadFile(c.proofInput)
+		if err != nil {
+			return errors.Wrap(err, "failed to read proof file")
+		}
+	}
+
+	c.proof = &util.MerkleProof{}
+	if err := json.Unmarshal(data, c.proof); err != nil {
+		return errors.Wrap(err, "failed to parse proof")
+	}
+
+	// A proof is only meaningful against a trusted state root; if one is not
+	// supplied the proof is checked against its own state root.
+	stateRoot := c.proof.StateRoot
+	if c.stateRoot != nil {
+		stateRoot = *c.stateRoot
+	}
+
+	if err := c.proof.Verify(stateRoot); err != nil {
+		c.reason = err.Error()
+		return nil
+	}
+	c.verified = true
+
+	return nil
+}
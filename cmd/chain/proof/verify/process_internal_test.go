@@ -0,0 +1,102 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproofverify
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	// Proof of the leaf 0x02… at gindex 2 of the tree with leaves 0x02… and
+	// 0x03….  The state root in the proof is not the root of the tree.
+	proof := `{"state_root":"0x0100000000000000000000000000000000000000000000000000000000000000","slot":"1","field":"slot","gindex":"2","leaf":"0x0200000000000000000000000000000000000000000000000000000000000000","branch":["0x0300000000000000000000000000000000000000000000000000000000000000"]}`
+	left := phase0.Root{0x02}
+	right := phase0.Root{0x03}
+	root := phase0.Root(sha256.Sum256(append(left[:], right[:]...)))
+	wrongRoot := phase0.Root{0x04}
+
+	dir := t.TempDir()
+	proofFile := filepath.Join(dir, "proof.json")
+	require.NoError(t, os.WriteFile(proofFile, []byte(proof), 0o600))
+
+	tests := []struct {
+		name     string
+		command  *command
+		verified bool
+		err      string
+	}{
+		{
+			name: "FileMissing",
+			command: &command{
+				proofInput: filepath.Join(dir, "missing.json"),
+			},
+			err: "failed to read proof file: open " + filepath.Join(dir, "missing.json") + ": no such file or directory",
+		},
+		{
+			name: "Invalid",
+			command: &command{
+				proofInput: `{"state_root":"0x01"}`,
+			},
+			err: "failed to parse proof: state root invalid: incorrect length",
+		},
+		{
+			name: "OwnStateRoot",
+			command: &command{
+				proofInput: proof,
+			},
+		},
+		{
+			name: "WrongStateRoot",
+			command: &command{
+				proofInput: proof,
+				stateRoot:  &wrongRoot,
+			},
+		},
+		{
+			name: "Good",
+			command: &command{
+				proofInput: proof,
+				stateRoot:  &root,
+			},
+			verified: true,
+		},
+		{
+			name: "GoodFile",
+			command: &command{
+				proofInput: proofFile,
+				stateRoot:  &root,
+			},
+			verified: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.verified, test.command.verified)
+		})
+	}
+}
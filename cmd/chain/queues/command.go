@@ -18,6 +18,7 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
@@ -43,8 +44,26 @@ type command struct {
 	chainTime          chaintime.Service
 
 	// Output.
-	activationQueue int
-	exitQueue       int
+	epochDuration                    time.Duration
+	electra                          bool
+	activationQueue                  int
+	activationChurn                  uint64
+	exitQueue                        int
+	exitQueueBalance                 phase0.Gwei
+	exitChurn                        uint64
+	exitBalanceChurn                 phase0.Gwei
+	pendingDeposits                  int
+	pendingDepositsBalance           phase0.Gwei
+	activationBalanceChurn           phase0.Gwei
+	maxDepositsPerEpoch              uint64
+	pendingPartialWithdrawals        int
+	pendingPartialWithdrawalsBalance phase0.Gwei
+	pendingPartialWithdrawalsDelay   uint64
+	maxPartialWithdrawalsPerEpoch    uint64
+	pendingConsolidations            int
+	pendingConsolidationsBalance     phase0.Gwei
+	pendingConsolidationsDelay       uint64
+	consolidationBalanceChurn        phase0.Gwei
 }
 
 func newCommand(ctx context.Context) (*command, error) {
@@ -18,11 +18,34 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/hako/durafmt"
+	"github.com/wealdtech/ethdo/beacon"
+	string2eth "github.com/wealdtech/go-string2eth"
 )
 
 type jsonOutput struct {
-	ActivationQueue int `json:"activation_queue"`
-	ExitQueue       int `json:"exit_queue"`
+	ActivationQueue           int               `json:"activation_queue"`
+	ActivationChurn           uint64            `json:"activation_churn"`
+	ActivationQueueEpochs     uint64            `json:"activation_queue_epochs"`
+	ExitQueue                 int               `json:"exit_queue"`
+	ExitQueueBalance          phase0.Gwei       `json:"exit_queue_balance,omitempty"`
+	ExitChurn                 uint64            `json:"exit_churn"`
+	ExitBalanceChurn          phase0.Gwei       `json:"exit_balance_churn,omitempty"`
+	ExitQueueEpochs           uint64            `json:"exit_queue_epochs"`
+	PendingDeposits           *pendingQueueJSON `json:"pending_deposits,omitempty"`
+	PendingPartialWithdrawals *pendingQueueJSON `json:"pending_partial_withdrawals,omitempty"`
+	PendingConsolidations     *pendingQueueJSON `json:"pending_consolidations,omitempty"`
+}
+
+type pendingQueueJSON struct {
+	Length       int         `json:"length"`
+	Balance      phase0.Gwei `json:"balance"`
+	BalanceChurn phase0.Gwei `json:"balance_churn,omitempty"`
+	MaxPerEpoch  uint64      `json:"max_per_epoch,omitempty"`
+	Epochs       uint64      `json:"epochs"`
 }
 
 func (c *command) output(ctx context.Context) (string, error) {
@@ -36,10 +59,37 @@ func (c *command) output(ctx context.Context) (string, error) {
 	return c.outputText(ctx)
 }
 
-func (c *command) outputJSON(ctx context.Context) (string, error) {
+func (c *command) outputJSON(_ context.Context) (string, error) {
 	output := &jsonOutput{
-		ActivationQueue: c.activationQueue,
-		ExitQueue:       c.exitQueue,
+		ActivationQueue:       c.activationQueue,
+		ActivationChurn:       c.activationChurn,
+		ActivationQueueEpochs: c.activationQueueEpochs(),
+		ExitQueue:             c.exitQueue,
+		ExitChurn:             c.exitChurn,
+		ExitQueueEpochs:       c.exitQueueEpochs(),
+	}
+	if c.electra {
+		output.ExitQueueBalance = c.exitQueueBalance
+		output.ExitBalanceChurn = c.exitBalanceChurn
+		output.PendingDeposits = &pendingQueueJSON{
+			Length:       c.pendingDeposits,
+			Balance:      c.pendingDepositsBalance,
+			BalanceChurn: c.activationBalanceChurn,
+			MaxPerEpoch:  c.maxDepositsPerEpoch,
+			Epochs:       c.pendingDepositsEpochs(),
+		}
+		output.PendingPartialWithdrawals = &pendingQueueJSON{
+			Length:      c.pendingPartialWithdrawals,
+			Balance:     c.pendingPartialWithdrawalsBalance,
+			MaxPerEpoch: c.maxPartialWithdrawalsPerEpoch,
+			Epochs:      c.pendingPartialWithdrawalsEpochs(),
+		}
+		output.PendingConsolidations = &pendingQueueJSON{
+			Length:       c.pendingConsolidations,
+			Balance:      c.pendingConsolidationsBalance,
+			BalanceChurn: c.consolidationBalanceChurn,
+			Epochs:       c.pendingConsolidationsDelay,
+		}
 	}
 	data, err := json.Marshal(output)
 	if err != nil {
@@ -49,15 +99,93 @@ func (c *command) outputJSON(ctx context.Context) (string, error) {
 	return string(data), nil
 }
 
-func (c *command) outputText(ctx context.Context) (string, error) {
+func (c *command) outputText(_ context.Context) (string, error) {
 	builder := strings.Builder{}
 
 	if c.activationQueue > 0 {
 		builder.WriteString(fmt.Sprintf("Activation queue: %d\n", c.activationQueue))
+		if !c.electra {
+			// Activations are limited by the pending deposits queue from Electra onwards.
+			builder.WriteString(fmt.Sprintf("Activation churn: %d per epoch\n", c.activationChurn))
+			builder.WriteString(c.queueTime("Activation", c.activationQueueEpochs()))
+		}
 	}
 	if c.exitQueue > 0 {
-		builder.WriteString(fmt.Sprintf("Exit queue: %d\n", c.exitQueue))
+		if c.electra {
+			builder.WriteString(fmt.Sprintf("Exit queue: %d (%s)\n", c.exitQueue, ethString(c.exitQueueBalance)))
+			builder.WriteString(fmt.Sprintf("Exit churn: %s per epoch\n", ethString(c.exitBalanceChurn)))
+		} else {
+			builder.WriteString(fmt.Sprintf("Exit queue: %d\n", c.exitQueue))
+			builder.WriteString(fmt.Sprintf("Exit churn: %d per epoch\n", c.exitChurn))
+		}
+		builder.WriteString(c.queueTime("Exit", c.exitQueueEpochs()))
+	}
+	if c.pendingDeposits > 0 {
+		builder.WriteString(fmt.Sprintf("Pending deposits queue: %d (%s)\n", c.pendingDeposits, ethString(c.pendingDepositsBalance)))
+		builder.WriteString(fmt.Sprintf("Pending deposits churn: %s per epoch, at most %d deposits per epoch\n", ethString(c.activationBalanceChurn), c.maxDepositsPerEpoch))
+		builder.WriteString(c.queueTime("Pending deposits", c.pendingDepositsEpochs()))
+	}
+	if c.pendingPartialWithdrawals > 0 {
+		builder.WriteString(fmt.Sprintf("Pending partial withdrawals queue: %d (%s)\n", c.pendingPartialWithdrawals, ethString(c.pendingPartialWithdrawalsBalance)))
+		builder.WriteString(fmt.Sprintf("Pending partial withdrawals churn: at most %d per epoch\n", c.maxPartialWithdrawalsPerEpoch))
+		builder.WriteString(c.queueTime("Pending partial withdrawals", c.pendingPartialWithdrawalsEpochs()))
+	}
+	if c.pendingConsolidations > 0 {
+		builder.WriteString(fmt.Sprintf("Pending consolidations queue: %d (%s)\n", c.pendingConsolidations, ethString(c.pendingConsolidationsBalance)))
+		builder.WriteString(fmt.Sprintf("Pending consolidations churn: %s per epoch\n", ethString(c.consolidationBalanceChurn)))
+		builder.WriteString(c.queueTime("Pending consolidations", c.pendingConsolidationsDelay))
 	}
 
 	return strings.TrimSuffix(builder.String(), "\n"), nil
 }
+
+// activationQueueEpochs calculates the number of epochs to process the
+// activation queue prior to Electra.
+func (c *command) activationQueueEpochs() uint64 {
+	return beacon.QueueEpochs(uint64(c.activationQueue), c.activationChurn)
+}
+
+// exitQueueEpochs calculates the number of epochs to process the exit queue,
+// which is limited by balance from Electra onwards.
+func (c *command) exitQueueEpochs() uint64 {
+	if c.electra {
+		return beacon.QueueEpochs(uint64(c.exitQueueBalance), uint64(c.exitBalanceChurn))
+	}
+
+	return beacon.QueueEpochs(uint64(c.exitQueue), c.exitChurn)
+}
+
+// pendingDepositsEpochs calculates the number of epochs to process the
+// pending deposits queue, which is limited by both balance and number.
+func (c *command) pendingDepositsEpochs() uint64 {
+	balanceEpochs := beacon.QueueEpochs(uint64(c.pendingDepositsBalance), uint64(c.activationBalanceChurn))
+	countEpochs := beacon.QueueEpochs(uint64(c.pendingDeposits), c.maxDepositsPerEpoch)
+	if countEpochs > balanceEpochs {
+		return countEpochs
+	}
+
+	return balanceEpochs
+}
+
+// pendingPartialWithdrawalsEpochs calculates the number of epochs to process
+// the pending partial withdrawals queue, which is limited by number and by
+// the time until the withdrawals become withdrawable.
+func (c *command) pendingPartialWithdrawalsEpochs() uint64 {
+	countEpochs := beacon.QueueEpochs(uint64(c.pendingPartialWithdrawals), c.maxPartialWithdrawalsPerEpoch)
+	if countEpochs > c.pendingPartialWithdrawalsDelay {
+		return countEpochs
+	}
+
+	return c.pendingPartialWithdrawalsDelay
+}
+
+func (c *command) queueTime(name string, epochs uint64) string {
+	return fmt.Sprintf("%s queue processing time: %s\n",
+		name,
+		durafmt.Parse(time.Duration(epochs)*c.epochDuration).LimitFirstN(2).String(),
+	)
+}
+
+func ethString(balance phase0.Gwei) string {
+	return string2eth.GWeiToString(uint64(balance), true)
+}
@@ -0,0 +1,123 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainqueues
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet:           true,
+				activationQueue: 16,
+			},
+		},
+		{
+			name: "Empty",
+			c:    &command{},
+		},
+		{
+			name: "Text",
+			c: &command{
+				epochDuration:   384 * time.Second,
+				activationQueue: 16,
+				activationChurn: 8,
+				exitQueue:       1,
+				exitChurn:       8,
+			},
+			res: "Activation queue: 16\nActivation churn: 8 per epoch\nActivation queue processing time: 12 minutes 48 seconds\nExit queue: 1\nExit churn: 8 per epoch\nExit queue processing time: 6 minutes 24 seconds",
+		},
+		{
+			name: "JSON",
+			c: &command{
+				json:            true,
+				epochDuration:   384 * time.Second,
+				activationQueue: 17,
+				activationChurn: 8,
+				exitChurn:       8,
+			},
+			res: `{"activation_queue":17,"activation_churn":8,"activation_queue_epochs":3,"exit_queue":0,"exit_churn":8,"exit_queue_epochs":0}`,
+		},
+		{
+			name: "TextElectra",
+			c: &command{
+				electra:                          true,
+				epochDuration:                    384 * time.Second,
+				activationQueue:                  16,
+				exitQueue:                        3,
+				exitQueueBalance:                 2112000000000,
+				exitChurn:                        8,
+				exitBalanceChurn:                 256000000000,
+				pendingDeposits:                  40,
+				pendingDepositsBalance:           1280000000000,
+				activationBalanceChurn:           256000000000,
+				maxDepositsPerEpoch:              16,
+				pendingPartialWithdrawals:        2,
+				pendingPartialWithdrawalsBalance: 4000000000,
+				pendingPartialWithdrawalsDelay:   4,
+				maxPartialWithdrawalsPerEpoch:    256,
+				pendingConsolidations:            1,
+				pendingConsolidationsBalance:     32000000000,
+				pendingConsolidationsDelay:       260,
+				consolidationBalanceChurn:        232000000000,
+			},
+			res: "Activation queue: 16\nExit queue: 3 (2112 Ether)\nExit churn: 256 Ether per epoch\nExit queue processing time: 57 minutes 36 seconds\nPending deposits queue: 40 (1280 Ether)\nPending deposits churn: 256 Ether per epoch, at most 16 deposits per epoch\nPending deposits queue processing time: 32 minutes\nPending partial withdrawals queue: 2 (4 Ether)\nPending partial withdrawals churn: at most 256 per epoch\nPending partial withdrawals queue processing time: 25 minutes 36 seconds\nPending consolidations queue: 1 (32 Ether)\nPending consolidations churn: 232 Ether per epoch\nPending consolidations queue processing time: 1 day 3 hours",
+		},
+		{
+			name: "JSONElectra",
+			c: &command{
+				json:                             true,
+				electra:                          true,
+				epochDuration:                    384 * time.Second,
+				activationQueue:                  16,
+				exitQueue:                        3,
+				exitQueueBalance:                 2112000000000,
+				exitChurn:                        8,
+				exitBalanceChurn:                 256000000000,
+				pendingDeposits:                  40,
+				pendingDepositsBalance:           1280000000000,
+				activationBalanceChurn:           256000000000,
+				maxDepositsPerEpoch:              16,
+				pendingPartialWithdrawals:        2,
+				pendingPartialWithdrawalsBalance: 4000000000,
+				pendingPartialWithdrawalsDelay:   4,
+				maxPartialWithdrawalsPerEpoch:    256,
+				pendingConsolidations:            1,
+				pendingConsolidationsBalance:     32000000000,
+				pendingConsolidationsDelay:       260,
+				consolidationBalanceChurn:        232000000000,
+			},
+			res: `{"activation_queue":16,"activation_churn":0,"activation_queue_epochs":0,"exit_queue":3,"exit_queue_balance":2112000000000,"exit_churn":8,"exit_balance_churn":256000000000,"exit_queue_epochs":9,"pending_deposits":{"length":40,"balance":1280000000000,"balance_churn":256000000000,"max_per_epoch":16,"epochs":5},"pending_partial_withdrawals":{"length":2,"balance":4000000000,"max_per_epoch":256,"epochs":4},"pending_consolidations":{"length":1,"balance":32000000000,"balance_churn":232000000000,"epochs":260}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
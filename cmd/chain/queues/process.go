@@ -16,9 +16,13 @@ package chainqueues
 import (
 	"context"
 	"fmt"
+	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
 )
@@ -33,27 +37,141 @@ func (c *command) process(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	stateID := fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch))
 
-	validators, err := c.validatorsProvider.Validators(ctx, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch)), nil)
+	validators, err := c.validatorsProvider.Validators(ctx, stateID, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain validators")
 	}
 
+	activeValidators := uint64(0)
+	totalActiveBalance := phase0.Gwei(0)
 	for _, validator := range validators {
 		if validator.Validator == nil {
 			continue
 		}
+		if validator.Validator.ActivationEpoch <= epoch && validator.Validator.ExitEpoch > epoch {
+			activeValidators++
+			totalActiveBalance += validator.Validator.EffectiveBalance
+		}
 		if validator.Validator.ActivationEligibilityEpoch <= epoch && validator.Validator.ActivationEpoch > epoch {
 			c.activationQueue++
 		}
 		if validator.Validator.ExitEpoch != 0xffffffffffffffff && validator.Validator.ExitEpoch > epoch {
 			c.exitQueue++
+			c.exitQueueBalance += validator.Validator.EffectiveBalance
 		}
 	}
 
+	spec, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+
+	if err := c.calculateChurn(spec, epoch, activeValidators, totalActiveBalance); err != nil {
+		return err
+	}
+
+	if !c.electra {
+		return nil
+	}
+
+	return c.processPendingQueues(ctx, spec, stateID, epoch, validators)
+}
+
+// calculateChurn calculates the churn of the activation and exit queues.
+func (c *command) calculateChurn(spec map[string]interface{},
+	epoch phase0.Epoch,
+	activeValidators uint64,
+	totalActiveBalance phase0.Gwei,
+) error {
+	churn, err := beacon.CalculateChurn(spec, epoch, activeValidators, totalActiveBalance)
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate churn")
+	}
+
+	c.electra = churn.Electra
+	if !c.electra {
+		c.activationChurn = churn.ActivationChurn
+	}
+	c.exitChurn = churn.ExitChurn
+	c.exitBalanceChurn = churn.ExitBalanceChurn
+	c.activationBalanceChurn = churn.ActivationBalanceChurn
+	c.consolidationBalanceChurn = churn.ConsolidationBalanceChurn
+	c.maxDepositsPerEpoch = churn.MaxDepositsPerEpoch
+
+	c.epochDuration = c.chainTime.SlotDuration() * time.Duration(c.chainTime.SlotsPerEpoch())
+
 	return nil
 }
 
+// processPendingQueues obtains the pending deposits, partial withdrawals and
+// consolidations queues introduced with Electra.
+func (c *command) processPendingQueues(ctx context.Context,
+	spec map[string]interface{},
+	stateID string,
+	epoch phase0.Epoch,
+	validators map[phase0.ValidatorIndex]*apiv1.Validator,
+) error {
+	tmp, exists := spec["MAX_PENDING_PARTIALS_PER_WITHDRAWALS_SWEEP"]
+	if !exists {
+		return errors.New("spec missing MAX_PENDING_PARTIALS_PER_WITHDRAWALS_SWEEP")
+	}
+	maxPendingPartialsPerSweep, isType := tmp.(uint64)
+	if !isType {
+		return errors.New("MAX_PENDING_PARTIALS_PER_WITHDRAWALS_SWEEP of incorrect type")
+	}
+	// A withdrawals sweep takes place in every slot.
+	c.maxPartialWithdrawalsPerEpoch = maxPendingPartialsPerSweep * c.chainTime.SlotsPerEpoch()
+
+	pendingDeposits, err := beacon.ObtainPendingDeposits(ctx, c.eth2Client.Address(), c.timeout, stateID)
+	if err != nil {
+		return err
+	}
+	c.pendingDeposits = len(pendingDeposits)
+	for _, pendingDeposit := range pendingDeposits {
+		c.pendingDepositsBalance += pendingDeposit.Amount
+	}
+
+	pendingPartialWithdrawals, err := beacon.ObtainPendingPartialWithdrawals(ctx, c.eth2Client.Address(), c.timeout, stateID)
+	if err != nil {
+		return err
+	}
+	c.pendingPartialWithdrawals = len(pendingPartialWithdrawals)
+	for _, pendingPartialWithdrawal := range pendingPartialWithdrawals {
+		c.pendingPartialWithdrawalsBalance += pendingPartialWithdrawal.Amount
+		c.pendingPartialWithdrawalsDelay = laterEpochs(c.pendingPartialWithdrawalsDelay, epoch, pendingPartialWithdrawal.WithdrawableEpoch)
+	}
+
+	pendingConsolidations, err := beacon.ObtainPendingConsolidations(ctx, c.eth2Client.Address(), c.timeout, stateID)
+	if err != nil {
+		return err
+	}
+	c.pendingConsolidations = len(pendingConsolidations)
+	for _, pendingConsolidation := range pendingConsolidations {
+		source, exists := validators[pendingConsolidation.SourceIndex]
+		if !exists || source.Validator == nil {
+			return fmt.Errorf("pending consolidation source validator %d not known", pendingConsolidation.SourceIndex)
+		}
+		c.pendingConsolidationsBalance += source.Validator.EffectiveBalance
+		// Consolidation churn is consumed when the consolidation is requested;
+		// the consolidation is processed once the source is withdrawable.
+		c.pendingConsolidationsDelay = laterEpochs(c.pendingConsolidationsDelay, epoch, source.Validator.WithdrawableEpoch)
+	}
+
+	return nil
+}
+
+// laterEpochs returns the number of epochs from the current epoch until the
+// given epoch, if that is greater than the supplied number of epochs.
+func laterEpochs(epochs uint64, currentEpoch phase0.Epoch, epoch phase0.Epoch) uint64 {
+	if epoch <= currentEpoch || uint64(epoch-currentEpoch) <= epochs {
+		return epochs
+	}
+
+	return uint64(epoch - currentEpoch)
+}
+
 func (c *command) setup(ctx context.Context) error {
 	var err error
 
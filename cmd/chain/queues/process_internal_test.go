@@ -18,6 +18,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
@@ -64,3 +65,44 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestLaterEpochs(t *testing.T) {
+	tests := []struct {
+		name   string
+		epochs uint64
+		epoch  phase0.Epoch
+		res    uint64
+	}{
+		{
+			name:  "Past",
+			epoch: 90,
+		},
+		{
+			name:  "Current",
+			epoch: 100,
+		},
+		{
+			name:  "Future",
+			epoch: 110,
+			res:   10,
+		},
+		{
+			name:   "Earlier",
+			epochs: 20,
+			epoch:  110,
+			res:    20,
+		},
+		{
+			name:   "Later",
+			epochs: 5,
+			epoch:  110,
+			res:    10,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, laterEpochs(test.epochs, 100, test.epoch))
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainrewards
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// Rewards are not available through the client, so are obtained directly
+// from the beacon node's rewards API.
+
+type attestationRewardsJSON struct {
+	Data struct {
+		TotalRewards []*attestationRewardJSON `json:"total_rewards"`
+	} `json:"data"`
+}
+
+type attestationRewardJSON struct {
+	ValidatorIndex string `json:"validator_index"`
+	Head           string `json:"head"`
+	Target         string `json:"target"`
+	Source         string `json:"source"`
+	InclusionDelay string `json:"inclusion_delay"`
+	Inactivity     string `json:"inactivity"`
+}
+
+type blockRewardsJSON struct {
+	Data struct {
+		ProposerIndex string `json:"proposer_index"`
+		Total         string `json:"total"`
+	} `json:"data"`
+}
+
+type syncCommitteeRewardsJSON struct {
+	Data []*syncCommitteeRewardJSON `json:"data"`
+}
+
+type syncCommitteeRewardJSON struct {
+	ValidatorIndex string `json:"validator_index"`
+	Reward         string `json:"reward"`
+}
+
+// attestationReward is the attestation reward for a validator in an epoch.
+type attestationReward struct {
+	Head           int64
+	Source         int64
+	Target         int64
+	InclusionDelay int64
+	Inactivity     int64
+}
+
+// indicesBody returns the request body for rewards of the given validators.
+func indicesBody(indices []phase0.ValidatorIndex) []string {
+	body := make([]string, 0, len(indices))
+	for _, index := range indices {
+		body = append(body, fmt.Sprintf("%d", index))
+	}
+
+	return body
+}
+
+// attestationRewards obtains the attestation rewards for the validators in an epoch.
+func (c *command) attestationRewards(ctx context.Context, epoch phase0.Epoch) (map[phase0.ValidatorIndex]*attestationReward, error) {
+	data := &attestationRewardsJSON{}
+	found, err := util.FetchBeaconNodeJSON(ctx, c.eth2Client.Address(), c.timeout, fmt.Sprintf("/eth/v1/beacon/rewards/attestations/%d", epoch), indicesBody(c.validatorIndices), data)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain attestation rewards for epoch %d", epoch))
+	}
+	if !found {
+		return nil, fmt.Errorf("attestation rewards for epoch %d not available", epoch)
+	}
+
+	return parseAttestationRewards(data)
+}
+
+func parseAttestationRewards(data *attestationRewardsJSON) (map[phase0.ValidatorIndex]*attestationReward, error) {
+	res := make(map[phase0.ValidatorIndex]*attestationReward, len(data.Data.TotalRewards))
+	for _, entry := range data.Data.TotalRewards {
+		index, err := strconv.ParseUint(entry.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid validator index")
+		}
+		reward := &attestationReward{}
+		for _, field := range []struct {
+			name  string
+			input string
+			value *int64
+		}{
+			{name: "head", input: entry.Head, value: &reward.Head},
+			{name: "source", input: entry.Source, value: &reward.Source},
+			{name: "target", input: entry.Target, value: &reward.Target},
+			{name: "inclusion delay", input: entry.InclusionDelay, value: &reward.InclusionDelay},
+			{name: "inactivity", input: entry.Inactivity, value: &reward.Inactivity},
+		} {
+			*field.value, err = parseGwei(field.input)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("invalid %s reward for validator %d", field.name, index))
+			}
+		}
+		res[phase0.ValidatorIndex(index)] = reward
+	}
+
+	return res, nil
+}
+
+// blockReward obtains the proposer and total reward for the block at a slot,
+// returning false if there is no block.
+func (c *command) blockReward(ctx context.Context, slot phase0.Slot) (phase0.ValidatorIndex, int64, bool, error) {
+	data := &blockRewardsJSON{}
+	found, err := util.FetchBeaconNodeJSON(ctx, c.eth2Client.Address(), c.timeout, fmt.Sprintf("/eth/v1/beacon/rewards/blocks/%d", slot), nil, data)
+	if err != nil {
+		return 0, 0, false, errors.Wrap(err, fmt.Sprintf("failed to obtain block rewards for slot %d", slot))
+	}
+	if !found {
+		return 0, 0, false, nil
+	}
+
+	proposerIndex, err := strconv.ParseUint(data.Data.ProposerIndex, 10, 64)
+	if err != nil {
+		return 0, 0, false, errors.Wrap(err, "invalid proposer index")
+	}
+	total, err := parseGwei(data.Data.Total)
+	if err != nil {
+		return 0, 0, false, errors.Wrap(err, "invalid block reward")
+	}
+
+	return phase0.ValidatorIndex(proposerIndex), total, true, nil
+}
+
+// syncCommitteeRewards obtains the sync committee rewards for the validators
+// in the block at a slot.
+func (c *command) syncCommitteeRewards(ctx context.Context, slot phase0.Slot) (map[phase0.ValidatorIndex]int64, error) {
+	data := &syncCommitteeRewardsJSON{}
+	found, err := util.FetchBeaconNodeJSON(ctx, c.eth2Client.Address(), c.timeout, fmt.Sprintf("/eth/v1/beacon/rewards/sync_committee/%d", slot), indicesBody(c.validatorIndices), data)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain sync committee rewards for slot %d", slot))
+	}
+	res := make(map[phase0.ValidatorIndex]int64)
+	if !found {
+		return res, nil
+	}
+
+	for _, entry := range data.Data {
+		index, err := strconv.ParseUint(entry.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid validator index")
+		}
+		reward, err := parseGwei(entry.Reward)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid sync committee reward for validator %d", index))
+		}
+		res[phase0.ValidatorIndex(index)] = reward
+	}
+
+	return res, nil
+}
+
+// parseGwei parses a signed Gwei value; absent values are 0.
+func parseGwei(input string) (int64, error) {
+	if input == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(input, 10, 64)
+}
@@ -0,0 +1,89 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainrewards
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAttestationRewards(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[phase0.ValidatorIndex]*attestationReward
+		err      string
+	}{
+		{
+			name:     "Empty",
+			input:    `{"data":{"ideal_rewards":[],"total_rewards":[]}}`,
+			expected: map[phase0.ValidatorIndex]*attestationReward{},
+		},
+		{
+			name:  "IndexInvalid",
+			input: `{"data":{"total_rewards":[{"validator_index":"bad","head":"1","target":"2","source":"3","inactivity":"0"}]}}`,
+			err:   `invalid validator index: strconv.ParseUint: parsing "bad": invalid syntax`,
+		},
+		{
+			name:  "RewardInvalid",
+			input: `{"data":{"total_rewards":[{"validator_index":"1","head":"1","target":"x","source":"3","inactivity":"0"}]}}`,
+			err:   `invalid target reward for validator 1: strconv.ParseInt: parsing "x": invalid syntax`,
+		},
+		{
+			name:  "Good",
+			input: `{"data":{"total_rewards":[{"validator_index":"1","head":"2856","target":"5306","source":"2858","inactivity":"0"},{"validator_index":"2","head":"0","target":"-5320","source":"-2865","inactivity":"-12"}]}}`,
+			expected: map[phase0.ValidatorIndex]*attestationReward{
+				1: {
+					Head:   2856,
+					Target: 5306,
+					Source: 2858,
+				},
+				2: {
+					Target:     -5320,
+					Source:     -2865,
+					Inactivity: -12,
+				},
+			},
+		},
+		{
+			name:  "InclusionDelay",
+			input: `{"data":{"total_rewards":[{"validator_index":"1","head":"2856","target":"5306","source":"2858","inclusion_delay":"1000","inactivity":"0"}]}}`,
+			expected: map[phase0.ValidatorIndex]*attestationReward{
+				1: {
+					Head:           2856,
+					Target:         5306,
+					Source:         2858,
+					InclusionDelay: 1000,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := &attestationRewardsJSON{}
+			require.NoError(t, json.Unmarshal([]byte(test.input), data))
+			res, err := parseAttestationRewards(data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainrewards
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validators []string
+	epoch      string
+	epochs     uint64
+	jsonOutput bool
+	csvOutput  bool
+
+	// Data access.
+	eth2Client             eth2client.Service
+	chainTime              chaintime.Service
+	validatorsProvider     eth2client.ValidatorsProvider
+	proposerDutiesProvider eth2client.ProposerDutiesProvider
+
+	// Processing.
+	validatorIndices []phase0.ValidatorIndex
+
+	// Output.
+	rewards []*validatorRewards
+}
+
+// validatorRewards are the rewards, in Gwei, for a single validator in a
+// single epoch.  Rewards can be negative if the validator was penalized.
+type validatorRewards struct {
+	Epoch          phase0.Epoch          `json:"epoch"`
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index"`
+	Head           int64                 `json:"head"`
+	Source         int64                 `json:"source"`
+	Target         int64                 `json:"target"`
+	InclusionDelay int64                 `json:"inclusion_delay"`
+	Inactivity     int64                 `json:"inactivity"`
+	SyncCommittee  int64                 `json:"sync_committee"`
+	Proposer       int64                 `json:"proposer"`
+	Total          int64                 `json:"total"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validators = viper.GetStringSlice("validators")
+	if len(c.validators) == 0 {
+		return nil, errors.New("validators are required")
+	}
+
+	c.epoch = viper.GetString("epoch")
+	c.epochs = viper.GetUint64("epochs")
+	if c.epochs == 0 {
+		c.epochs = 1
+	}
+	c.jsonOutput = viper.GetBool("json")
+	c.csvOutput = viper.GetBool("csv")
+	if c.jsonOutput && c.csvOutput {
+		return nil, errors.New("only one of json and csv output allowed")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,123 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainrewards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	if c.csvOutput {
+		return c.outputCSV(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.rewards)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString("epoch,validator_index,head,source,target,inclusion_delay,inactivity,sync_committee,proposer,total\n")
+	for _, reward := range c.rewards {
+		builder.WriteString(fmt.Sprintf("%d,%d,%d,%d,%d,%d,%d,%d,%d,%d\n",
+			reward.Epoch,
+			reward.ValidatorIndex,
+			reward.Head,
+			reward.Source,
+			reward.Target,
+			reward.InclusionDelay,
+			reward.Inactivity,
+			reward.SyncCommittee,
+			reward.Proposer,
+			reward.Total,
+		))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	if len(c.rewards) == 0 {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+
+	// Rewards are ordered by epoch then validator.
+	firstEpoch := c.rewards[0].Epoch
+	lastEpoch := c.rewards[len(c.rewards)-1].Epoch
+	if firstEpoch == lastEpoch {
+		builder.WriteString(fmt.Sprintf("Epoch %d:\n", firstEpoch))
+	} else {
+		builder.WriteString(fmt.Sprintf("Epochs %d-%d:\n", firstEpoch, lastEpoch))
+	}
+
+	totals := make(map[phase0.ValidatorIndex]*validatorRewards)
+	order := make([]phase0.ValidatorIndex, 0)
+	for _, reward := range c.rewards {
+		total, exists := totals[reward.ValidatorIndex]
+		if !exists {
+			total = &validatorRewards{ValidatorIndex: reward.ValidatorIndex}
+			totals[reward.ValidatorIndex] = total
+			order = append(order, reward.ValidatorIndex)
+		}
+		total.Head += reward.Head
+		total.Source += reward.Source
+		total.Target += reward.Target
+		total.InclusionDelay += reward.InclusionDelay
+		total.Inactivity += reward.Inactivity
+		total.SyncCommittee += reward.SyncCommittee
+		total.Proposer += reward.Proposer
+		total.Total += reward.Total
+	}
+
+	overall := int64(0)
+	for _, index := range order {
+		total := totals[index]
+		overall += total.Total
+		builder.WriteString(fmt.Sprintf("  Validator %d: %d Gwei\n", index, total.Total))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("    Attestations: %d Gwei\n", total.Head+total.Source+total.Target+total.InclusionDelay+total.Inactivity))
+			builder.WriteString(fmt.Sprintf("    Sync committee: %d Gwei\n", total.SyncCommittee))
+			builder.WriteString(fmt.Sprintf("    Proposals: %d Gwei\n", total.Proposer))
+		}
+	}
+	if len(order) > 1 {
+		builder.WriteString(fmt.Sprintf("Total: %d Gwei\n", overall))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
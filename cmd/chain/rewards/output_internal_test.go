@@ -0,0 +1,123 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainrewards
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	rewards := []*validatorRewards{
+		{
+			Epoch:          100,
+			ValidatorIndex: 1,
+			Head:           10,
+			Source:         20,
+			Target:         30,
+			SyncCommittee:  5,
+			Proposer:       100,
+			Total:          165,
+		},
+		{
+			Epoch:          100,
+			ValidatorIndex: 2,
+			Source:         -20,
+			Target:         -30,
+			Total:          -50,
+		},
+		{
+			Epoch:          101,
+			ValidatorIndex: 1,
+			Head:           10,
+			Source:         20,
+			Target:         30,
+			Total:          60,
+		},
+		{
+			Epoch:          101,
+			ValidatorIndex: 2,
+			Head:           10,
+			Source:         20,
+			Target:         30,
+			Total:          60,
+		},
+	}
+
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet:   true,
+				rewards: rewards,
+			},
+		},
+		{
+			name: "Empty",
+			c:    &command{},
+		},
+		{
+			name: "Text",
+			c: &command{
+				rewards: rewards,
+			},
+			res: "Epochs 100-101:\n  Validator 1: 225 Gwei\n  Validator 2: 10 Gwei\nTotal: 235 Gwei",
+		},
+		{
+			name: "TextSingle",
+			c: &command{
+				rewards: rewards[:1],
+			},
+			res: "Epoch 100:\n  Validator 1: 165 Gwei",
+		},
+		{
+			name: "TextVerbose",
+			c: &command{
+				verbose: true,
+				rewards: rewards[:1],
+			},
+			res: "Epoch 100:\n  Validator 1: 165 Gwei\n    Attestations: 60 Gwei\n    Sync committee: 5 Gwei\n    Proposals: 100 Gwei",
+		},
+		{
+			name: "CSV",
+			c: &command{
+				csvOutput: true,
+				rewards:   rewards[:2],
+			},
+			res: "epoch,validator_index,head,source,target,inclusion_delay,inactivity,sync_committee,proposer,total\n100,1,10,20,30,0,0,5,100,165\n100,2,0,-20,-30,0,0,0,0,-50",
+		},
+		{
+			name: "JSON",
+			c: &command{
+				jsonOutput: true,
+				rewards:    rewards[1:2],
+			},
+			res: `[{"epoch":100,"validator_index":2,"head":0,"source":-20,"target":-30,"inclusion_delay":0,"inactivity":0,"sync_committee":0,"proposer":0,"total":-50}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainrewards
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+	if err != nil {
+		return err
+	}
+	if len(validators) == 0 {
+		return errors.New("no validators found")
+	}
+	c.validatorIndices = make([]phase0.ValidatorIndex, 0, len(validators))
+	seen := make(map[phase0.ValidatorIndex]bool, len(validators))
+	for _, validator := range validators {
+		if !seen[validator.Index] {
+			c.validatorIndices = append(c.validatorIndices, validator.Index)
+			seen[validator.Index] = true
+		}
+	}
+	sort.Slice(c.validatorIndices, func(i int, j int) bool {
+		return c.validatorIndices[i] < c.validatorIndices[j]
+	})
+
+	firstEpoch, lastEpoch, err := c.epochRange(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.rewards = make([]*validatorRewards, 0, int(lastEpoch-firstEpoch+1)*len(c.validatorIndices))
+	for epoch := firstEpoch; epoch <= lastEpoch; epoch++ {
+		rewards, err := c.epochRewards(ctx, epoch)
+		if err != nil {
+			return err
+		}
+		c.rewards = append(c.rewards, rewards...)
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+
+	return nil
+}
+
+// epochRange returns the first and last epochs for which to obtain rewards.
+func (c *command) epochRange(ctx context.Context) (phase0.Epoch, phase0.Epoch, error) {
+	// Attestation rewards for an epoch are only known once the following
+	// epoch has completed.
+	currentEpoch := c.chainTime.CurrentEpoch()
+	if currentEpoch < 2 {
+		return 0, 0, errors.New("no rewards available yet")
+	}
+	latestEpoch := currentEpoch - 2
+
+	var firstEpoch phase0.Epoch
+	if c.epoch == "" {
+		// Default to the most recent epochs with rewards.
+		if uint64(latestEpoch)+1 < c.epochs {
+			firstEpoch = 0
+		} else {
+			firstEpoch = latestEpoch + 1 - phase0.Epoch(c.epochs)
+		}
+	} else {
+		var err error
+		firstEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "failed to parse epoch")
+		}
+	}
+	lastEpoch := firstEpoch + phase0.Epoch(c.epochs) - 1
+	if lastEpoch > latestEpoch {
+		return 0, 0, fmt.Errorf("rewards for epoch %d not yet available; latest is epoch %d", lastEpoch, latestEpoch)
+	}
+
+	return firstEpoch, lastEpoch, nil
+}
+
+// epochRewards obtains the rewards for the validators in a single epoch.
+func (c *command) epochRewards(ctx context.Context, epoch phase0.Epoch) ([]*validatorRewards, error) {
+	rewards := make(map[phase0.ValidatorIndex]*validatorRewards, len(c.validatorIndices))
+	for _, index := range c.validatorIndices {
+		rewards[index] = &validatorRewards{
+			Epoch:          epoch,
+			ValidatorIndex: index,
+		}
+	}
+
+	attestationRewards, err := c.attestationRewards(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	for index, reward := range attestationRewards {
+		if _, exists := rewards[index]; !exists {
+			continue
+		}
+		rewards[index].Head = reward.Head
+		rewards[index].Source = reward.Source
+		rewards[index].Target = reward.Target
+		rewards[index].InclusionDelay = reward.InclusionDelay
+		rewards[index].Inactivity = reward.Inactivity
+	}
+
+	// Only fetch block rewards for blocks proposed by our validators.
+	duties, err := c.proposerDutiesProvider.ProposerDuties(ctx, epoch, c.validatorIndices)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain proposer duties for epoch %d", epoch))
+	}
+	for _, duty := range duties {
+		if _, exists := rewards[duty.ValidatorIndex]; !exists {
+			continue
+		}
+		proposerIndex, reward, found, err := c.blockReward(ctx, duty.Slot)
+		if err != nil {
+			return nil, err
+		}
+		if !found || proposerIndex != duty.ValidatorIndex {
+			// Missed proposal.
+			util.Log.Debug().Uint64("validator", uint64(duty.ValidatorIndex)).Uint64("slot", uint64(duty.Slot)).Msg("No block from validator")
+			continue
+		}
+		rewards[proposerIndex].Proposer += reward
+	}
+
+	if epoch >= c.chainTime.AltairInitialEpoch() {
+		firstSlot := c.chainTime.FirstSlotOfEpoch(epoch)
+		for slot := firstSlot; slot < firstSlot+phase0.Slot(c.chainTime.SlotsPerEpoch()); slot++ {
+			syncRewards, err := c.syncCommitteeRewards(ctx, slot)
+			if err != nil {
+				return nil, err
+			}
+			for index, reward := range syncRewards {
+				if _, exists := rewards[index]; !exists {
+					continue
+				}
+				rewards[index].SyncCommittee += reward
+			}
+		}
+	}
+
+	res := make([]*validatorRewards, 0, len(c.validatorIndices))
+	for _, index := range c.validatorIndices {
+		reward := rewards[index]
+		reward.Total = reward.Head +
+			reward.Source +
+			reward.Target +
+			reward.InclusionDelay +
+			reward.Inactivity +
+			reward.SyncCommittee +
+			reward.Proposer
+		res = append(res, reward)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,84 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainspecdiff
+
+import (
+	"context"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connections.
+	timeout                  time.Duration
+	connection               string
+	otherConnection          string
+	allowInsecureConnections bool
+
+	// Input.
+	network string
+
+	// Processing.
+	consensusClient      consensusclient.Service
+	otherConsensusClient consensusclient.Service
+
+	// Output.
+	sourceName string
+	otherName  string
+	diffs      []*specDiff
+}
+
+// specDiff is a parameter whose value differs between the two specs.  An
+// empty value means that the parameter is not present in that spec.
+type specDiff struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+	Other string `json:"other,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.otherConnection = viper.GetString("other-connection")
+	c.network = viper.GetString("network")
+	if c.otherConnection == "" && c.network == "" {
+		return nil, errors.New("one of other-connection or network is required")
+	}
+	if c.otherConnection != "" && c.network != "" {
+		return nil, errors.New("only one of other-connection and network is allowed")
+	}
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	return c, nil
+}
@@ -0,0 +1,197 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainspecdiff
+
+import (
+	"sort"
+	"strings"
+)
+
+// mainnetSpec is a snapshot of the mainnet spec as returned by the beacon
+// API, covering the presets and configuration up to Capella.
+var mainnetSpec = map[string]string{
+	// Configuration.
+	"PRESET_BASE":                          "mainnet",
+	"CONFIG_NAME":                          "mainnet",
+	"TERMINAL_TOTAL_DIFFICULTY":            "58750000000000000000000",
+	"TERMINAL_BLOCK_HASH":                  "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"TERMINAL_BLOCK_HASH_ACTIVATION_EPOCH": "18446744073709551615",
+	"MIN_GENESIS_ACTIVE_VALIDATOR_COUNT":   "16384",
+	"MIN_GENESIS_TIME":                     "1606824000",
+	"GENESIS_FORK_VERSION":                 "0x00000000",
+	"GENESIS_DELAY":                        "604800",
+	"ALTAIR_FORK_VERSION":                  "0x01000000",
+	"ALTAIR_FORK_EPOCH":                    "74240",
+	"BELLATRIX_FORK_VERSION":               "0x02000000",
+	"BELLATRIX_FORK_EPOCH":                 "144896",
+	"CAPELLA_FORK_VERSION":                 "0x03000000",
+	"CAPELLA_FORK_EPOCH":                   "194048",
+	"SECONDS_PER_SLOT":                     "12",
+	"SECONDS_PER_ETH1_BLOCK":               "14",
+	"MIN_VALIDATOR_WITHDRAWABILITY_DELAY":  "256",
+	"SHARD_COMMITTEE_PERIOD":               "256",
+	"ETH1_FOLLOW_DISTANCE":                 "2048",
+	"INACTIVITY_SCORE_BIAS":                "4",
+	"INACTIVITY_SCORE_RECOVERY_RATE":       "16",
+	"EJECTION_BALANCE":                     "16000000000",
+	"MIN_PER_EPOCH_CHURN_LIMIT":            "4",
+	"CHURN_LIMIT_QUOTIENT":                 "65536",
+	"PROPOSER_SCORE_BOOST":                 "40",
+	"DEPOSIT_CHAIN_ID":                     "1",
+	"DEPOSIT_NETWORK_ID":                   "1",
+	"DEPOSIT_CONTRACT_ADDRESS":             "0x00000000219ab540356cbb839cbe05303d7705fa",
+
+	// Phase 0 preset.
+	"MAX_COMMITTEES_PER_SLOT":          "64",
+	"TARGET_COMMITTEE_SIZE":            "128",
+	"MAX_VALIDATORS_PER_COMMITTEE":     "2048",
+	"SHUFFLE_ROUND_COUNT":              "90",
+	"HYSTERESIS_QUOTIENT":              "4",
+	"HYSTERESIS_DOWNWARD_MULTIPLIER":   "1",
+	"HYSTERESIS_UPWARD_MULTIPLIER":     "5",
+	"MIN_DEPOSIT_AMOUNT":               "1000000000",
+	"MAX_EFFECTIVE_BALANCE":            "32000000000",
+	"EFFECTIVE_BALANCE_INCREMENT":      "1000000000",
+	"MIN_ATTESTATION_INCLUSION_DELAY":  "1",
+	"SLOTS_PER_EPOCH":                  "32",
+	"MIN_SEED_LOOKAHEAD":               "1",
+	"MAX_SEED_LOOKAHEAD":               "4",
+	"EPOCHS_PER_ETH1_VOTING_PERIOD":    "64",
+	"SLOTS_PER_HISTORICAL_ROOT":        "8192",
+	"MIN_EPOCHS_TO_INACTIVITY_PENALTY": "4",
+	"EPOCHS_PER_HISTORICAL_VECTOR":     "65536",
+	"EPOCHS_PER_SLASHINGS_VECTOR":      "8192",
+	"HISTORICAL_ROOTS_LIMIT":           "16777216",
+	"VALIDATOR_REGISTRY_LIMIT":         "1099511627776",
+	"BASE_REWARD_FACTOR":               "64",
+	"WHISTLEBLOWER_REWARD_QUOTIENT":    "512",
+	"PROPOSER_REWARD_QUOTIENT":         "8",
+	"INACTIVITY_PENALTY_QUOTIENT":      "67108864",
+	"MIN_SLASHING_PENALTY_QUOTIENT":    "128",
+	"PROPORTIONAL_SLASHING_MULTIPLIER": "1",
+	"MAX_PROPOSER_SLASHINGS":           "16",
+	"MAX_ATTESTER_SLASHINGS":           "2",
+	"MAX_ATTESTATIONS":                 "128",
+	"MAX_DEPOSITS":                     "16",
+	"MAX_VOLUNTARY_EXITS":              "16",
+
+	// Altair preset.
+	"INACTIVITY_PENALTY_QUOTIENT_ALTAIR":      "50331648",
+	"MIN_SLASHING_PENALTY_QUOTIENT_ALTAIR":    "64",
+	"PROPORTIONAL_SLASHING_MULTIPLIER_ALTAIR": "2",
+	"SYNC_COMMITTEE_SIZE":                     "512",
+	"EPOCHS_PER_SYNC_COMMITTEE_PERIOD":        "256",
+	"MIN_SYNC_COMMITTEE_PARTICIPANTS":         "1",
+
+	// Bellatrix preset.
+	"INACTIVITY_PENALTY_QUOTIENT_BELLATRIX":      "16777216",
+	"MIN_SLASHING_PENALTY_QUOTIENT_BELLATRIX":    "32",
+	"PROPORTIONAL_SLASHING_MULTIPLIER_BELLATRIX": "3",
+	"MAX_BYTES_PER_TRANSACTION":                  "1073741824",
+	"MAX_TRANSACTIONS_PER_PAYLOAD":               "1048576",
+	"BYTES_PER_LOGS_BLOOM":                       "256",
+	"MAX_EXTRA_DATA_BYTES":                       "32",
+
+	// Capella preset.
+	"MAX_BLS_TO_EXECUTION_CHANGES":         "16",
+	"MAX_WITHDRAWALS_PER_PAYLOAD":          "16",
+	"MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP": "16384",
+
+	// Constants.
+	"BLS_WITHDRAWAL_PREFIX":                    "0x00",
+	"TARGET_AGGREGATORS_PER_COMMITTEE":         "16",
+	"TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE": "16",
+	"SYNC_COMMITTEE_SUBNET_COUNT":              "4",
+	"DOMAIN_BEACON_PROPOSER":                   "0x00000000",
+	"DOMAIN_BEACON_ATTESTER":                   "0x01000000",
+	"DOMAIN_RANDAO":                            "0x02000000",
+	"DOMAIN_DEPOSIT":                           "0x03000000",
+	"DOMAIN_VOLUNTARY_EXIT":                    "0x04000000",
+	"DOMAIN_SELECTION_PROOF":                   "0x05000000",
+	"DOMAIN_AGGREGATE_AND_PROOF":               "0x06000000",
+	"DOMAIN_SYNC_COMMITTEE":                    "0x07000000",
+	"DOMAIN_SYNC_COMMITTEE_SELECTION_PROOF":    "0x08000000",
+	"DOMAIN_CONTRIBUTION_AND_PROOF":            "0x09000000",
+	"DOMAIN_BLS_TO_EXECUTION_CHANGE":           "0x0a000000",
+	"DOMAIN_APPLICATION_MASK":                  "0x00000001",
+}
+
+// networkOverrides are the parameters of public testnets that differ from
+// mainnet.  All of them use the mainnet presets.
+var networkOverrides = map[string]map[string]string{
+	"mainnet": {},
+	"sepolia": {
+		"CONFIG_NAME":                        "sepolia",
+		"TERMINAL_TOTAL_DIFFICULTY":          "17000000000000000",
+		"MIN_GENESIS_ACTIVE_VALIDATOR_COUNT": "1300",
+		"MIN_GENESIS_TIME":                   "1655647200",
+		"GENESIS_FORK_VERSION":               "0x90000069",
+		"GENESIS_DELAY":                      "86400",
+		"ALTAIR_FORK_VERSION":                "0x90000070",
+		"ALTAIR_FORK_EPOCH":                  "50",
+		"BELLATRIX_FORK_VERSION":             "0x90000071",
+		"BELLATRIX_FORK_EPOCH":               "100",
+		"CAPELLA_FORK_VERSION":               "0x90000072",
+		"CAPELLA_FORK_EPOCH":                 "56832",
+		"DEPOSIT_CHAIN_ID":                   "11155111",
+		"DEPOSIT_NETWORK_ID":                 "11155111",
+		"DEPOSIT_CONTRACT_ADDRESS":           "0x7f02c3e3c98b133055b8b348b2ac625669ed295d",
+	},
+	"holesky": {
+		"CONFIG_NAME":               "holesky",
+		"TERMINAL_TOTAL_DIFFICULTY": "0",
+		"MIN_GENESIS_TIME":          "1695902100",
+		"GENESIS_FORK_VERSION":      "0x01017000",
+		"GENESIS_DELAY":             "300",
+		"ALTAIR_FORK_VERSION":       "0x02017000",
+		"ALTAIR_FORK_EPOCH":         "0",
+		"BELLATRIX_FORK_VERSION":    "0x03017000",
+		"BELLATRIX_FORK_EPOCH":      "0",
+		"CAPELLA_FORK_VERSION":      "0x04017000",
+		"CAPELLA_FORK_EPOCH":        "256",
+		"EJECTION_BALANCE":          "28000000000",
+		"DEPOSIT_CHAIN_ID":          "17000",
+		"DEPOSIT_NETWORK_ID":        "17000",
+		"DEPOSIT_CONTRACT_ADDRESS":  "0x4242424242424242424242424242424242424242",
+	},
+}
+
+// NetworkNames provides the names of the networks with bundled specs.
+func NetworkNames() []string {
+	names := make([]string, 0, len(networkOverrides))
+	for name := range networkOverrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// networkSpec returns the bundled spec for the named network.
+func networkSpec(network string) (map[string]string, bool) {
+	overrides, exists := networkOverrides[strings.ToLower(network)]
+	if !exists {
+		return nil, false
+	}
+
+	res := make(map[string]string, len(mainnetSpec))
+	for k, v := range mainnetSpec {
+		res[k] = v
+	}
+	for k, v := range overrides {
+		res[k] = v
+	}
+
+	return res, true
+}
@@ -0,0 +1,80 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainspecdiff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type resultJSON struct {
+	Source string      `json:"source"`
+	Other  string      `json:"other"`
+	Diffs  []*specDiff `json:"diffs"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&resultJSON{
+		Source: c.sourceName,
+		Other:  c.otherName,
+		Diffs:  c.diffs,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	if len(c.diffs) == 0 {
+		return "No differences found", nil
+	}
+
+	builder := strings.Builder{}
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Comparing %s with %s\n", c.sourceName, c.otherName))
+	}
+	for i, diff := range c.diffs {
+		builder.WriteString(fmt.Sprintf("%s: %s -> %s", diff.Key, textValue(diff.Value), textValue(diff.Other)))
+		if i != len(c.diffs)-1 {
+			builder.WriteString("\n")
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// textValue returns the value for text output, showing parameters that are
+// not present explicitly.
+func textValue(value string) string {
+	if value == "" {
+		return "<not present>"
+	}
+
+	return value
+}
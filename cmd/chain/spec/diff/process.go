@@ -0,0 +1,166 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainspecdiff
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	spec, err := obtainSpec(ctx, c.consensusClient)
+	if err != nil {
+		return err
+	}
+	c.sourceName = c.connection
+	if c.sourceName == "" {
+		c.sourceName = c.consensusClient.Address()
+	}
+
+	var other map[string]string
+	if c.otherConsensusClient != nil {
+		other, err = obtainSpec(ctx, c.otherConsensusClient)
+		if err != nil {
+			return errors.Wrap(err, "other connection")
+		}
+		c.otherName = c.otherConnection
+	} else {
+		var exists bool
+		other, exists = networkSpec(c.network)
+		if !exists {
+			return fmt.Errorf("no bundled spec for network %s", c.network)
+		}
+		c.otherName = c.network
+	}
+	util.Log.Debug().Str("source", c.sourceName).Int("source_parameters", len(spec)).Str("other", c.otherName).Int("other_parameters", len(other)).Msg("Comparing parameters")
+
+	// Nodes return parameters that are specific to their implementation, so
+	// when comparing against a bundled spec only report parameters missing
+	// from it if requested.
+	c.diffs = diffSpecs(spec, other, c.otherConsensusClient != nil || c.verbose)
+
+	return nil
+}
+
+// obtainSpec obtains the spec from a consensus client, with the values
+// formatted as they would be returned by the beacon API.
+func obtainSpec(ctx context.Context, consensusClient consensusclient.Service) (map[string]string, error) {
+	spec, err := consensusClient.(consensusclient.SpecProvider).Spec(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+
+	res := make(map[string]string, len(spec))
+	for k, v := range spec {
+		res[k] = formatValue(v)
+	}
+
+	return res, nil
+}
+
+// formatValue formats a parsed spec value in the form in which it is
+// returned by the beacon API.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case uint64:
+		return fmt.Sprintf("%d", v)
+	case time.Duration:
+		return fmt.Sprintf("%d", int64(v.Seconds()))
+	case time.Time:
+		return fmt.Sprintf("%d", v.Unix())
+	case phase0.Version:
+		return fmt.Sprintf("%#x", v)
+	case phase0.DomainType:
+		return fmt.Sprintf("%#x", v)
+	case []byte:
+		return fmt.Sprintf("%#x", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// diffSpecs returns the parameters whose values differ between the specs,
+// sorted by key.  Parameters present in only the first spec are included if
+// includeMissing is set.
+func diffSpecs(spec map[string]string, other map[string]string, includeMissing bool) []*specDiff {
+	keys := make(map[string]struct{}, len(spec))
+	for k := range spec {
+		keys[k] = struct{}{}
+	}
+	for k := range other {
+		keys[k] = struct{}{}
+	}
+
+	res := make([]*specDiff, 0)
+	for k := range keys {
+		value, exists := spec[k]
+		otherValue, otherExists := other[k]
+		if exists && !otherExists && !includeMissing {
+			continue
+		}
+		// Hex values are not consistently cased between implementations.
+		if strings.EqualFold(value, otherValue) {
+			continue
+		}
+		res = append(res, &specDiff{
+			Key:   k,
+			Value: value,
+			Other: otherValue,
+		})
+	}
+	sort.Slice(res, func(i int, j int) bool {
+		return res[i].Key < res[j].Key
+	})
+
+	return res
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+	if _, isProvider := c.consensusClient.(consensusclient.SpecProvider); !isProvider {
+		return errors.New("consensus node does not provide spec")
+	}
+
+	if c.otherConnection != "" {
+		c.otherConsensusClient, err = util.ConnectToBeaconNode(ctx, c.otherConnection, c.timeout, c.allowInsecureConnections)
+		if err != nil {
+			return errors.Wrap(err, "failed to connect to other consensus node")
+		}
+		if _, isProvider := c.otherConsensusClient.(consensusclient.SpecProvider); !isProvider {
+			return errors.New("other consensus node does not provide spec")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainspecdiff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		res   string
+	}{
+		{
+			name:  "String",
+			value: "mainnet",
+			res:   "mainnet",
+		},
+		{
+			name:  "Uint64",
+			value: uint64(32),
+			res:   "32",
+		},
+		{
+			name:  "Duration",
+			value: 12 * time.Second,
+			res:   "12",
+		},
+		{
+			name:  "Time",
+			value: time.Unix(1606824000, 0),
+			res:   "1606824000",
+		},
+		{
+			name:  "Version",
+			value: phase0.Version{0x03, 0x00, 0x00, 0x00},
+			res:   "0x03000000",
+		},
+		{
+			name:  "DomainType",
+			value: phase0.DomainType{0x0a, 0x00, 0x00, 0x00},
+			res:   "0x0a000000",
+		},
+		{
+			name:  "Bytes",
+			value: []byte{0x00},
+			res:   "0x00",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, formatValue(test.value))
+		})
+	}
+}
+
+func TestDiffSpecs(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           map[string]string
+		other          map[string]string
+		includeMissing bool
+		res            []*specDiff
+	}{
+		{
+			name:  "Empty",
+			spec:  map[string]string{},
+			other: map[string]string{},
+			res:   []*specDiff{},
+		},
+		{
+			name: "Identical",
+			spec: map[string]string{
+				"SLOTS_PER_EPOCH": "32",
+			},
+			other: map[string]string{
+				"SLOTS_PER_EPOCH": "32",
+			},
+			res: []*specDiff{},
+		},
+		{
+			name: "HexCase",
+			spec: map[string]string{
+				"DEPOSIT_CONTRACT_ADDRESS": "0x00000000219AB540356CBB839CBE05303D7705FA",
+			},
+			other: map[string]string{
+				"DEPOSIT_CONTRACT_ADDRESS": "0x00000000219ab540356cbb839cbe05303d7705fa",
+			},
+			res: []*specDiff{},
+		},
+		{
+			name: "Differences",
+			spec: map[string]string{
+				"SLOTS_PER_EPOCH":    "8",
+				"SECONDS_PER_SLOT":   "6",
+				"CAPELLA_FORK_EPOCH": "10",
+			},
+			other: map[string]string{
+				"SLOTS_PER_EPOCH":    "32",
+				"SECONDS_PER_SLOT":   "12",
+				"CAPELLA_FORK_EPOCH": "10",
+			},
+			res: []*specDiff{
+				{Key: "SECONDS_PER_SLOT", Value: "6", Other: "12"},
+				{Key: "SLOTS_PER_EPOCH", Value: "8", Other: "32"},
+			},
+		},
+		{
+			name: "MissingExcluded",
+			spec: map[string]string{
+				"CLIENT_SPECIFIC": "1",
+			},
+			other: map[string]string{
+				"DENEB_FORK_EPOCH": "100",
+			},
+			res: []*specDiff{
+				{Key: "DENEB_FORK_EPOCH", Other: "100"},
+			},
+		},
+		{
+			name: "MissingIncluded",
+			spec: map[string]string{
+				"CLIENT_SPECIFIC": "1",
+			},
+			other: map[string]string{
+				"DENEB_FORK_EPOCH": "100",
+			},
+			includeMissing: true,
+			res: []*specDiff{
+				{Key: "CLIENT_SPECIFIC", Value: "1"},
+				{Key: "DENEB_FORK_EPOCH", Other: "100"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, diffSpecs(test.spec, test.other, test.includeMissing))
+		})
+	}
+}
+
+func TestNetworkSpec(t *testing.T) {
+	_, exists := networkSpec("unknown")
+	require.False(t, exists)
+
+	spec, exists := networkSpec("Holesky")
+	require.True(t, exists)
+	require.Equal(t, "0x01017000", spec["GENESIS_FORK_VERSION"])
+	require.Equal(t, "32", spec["SLOTS_PER_EPOCH"])
+
+	// Ensure the overrides are not applied to the shared mainnet spec.
+	require.Equal(t, "0x00000000", mainnetSpec["GENESIS_FORK_VERSION"])
+}
@@ -0,0 +1,81 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainstaterootverify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type resultJSON struct {
+	Epoch             string `json:"epoch"`
+	BlockSlot         string `json:"block_slot"`
+	BlockRoot         string `json:"block_root"`
+	BlockStateRoot    string `json:"block_state_root"`
+	ComputedStateRoot string `json:"computed_state_root"`
+	Verified          bool   `json:"verified"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+	if c.result == nil {
+		return "", errors.New("no result")
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&resultJSON{
+		Epoch:             fmt.Sprintf("%d", c.result.Epoch),
+		BlockSlot:         fmt.Sprintf("%d", c.result.BlockSlot),
+		BlockRoot:         fmt.Sprintf("%#x", c.result.BlockRoot),
+		BlockStateRoot:    fmt.Sprintf("%#x", c.result.BlockStateRoot),
+		ComputedStateRoot: fmt.Sprintf("%#x", c.result.ComputedStateRoot),
+		Verified:          c.result.Verified,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verbose || !c.result.Verified {
+		builder.WriteString(fmt.Sprintf("Epoch: %d\n", c.result.Epoch))
+		builder.WriteString(fmt.Sprintf("Block slot: %d\n", c.result.BlockSlot))
+		builder.WriteString(fmt.Sprintf("Block root: %#x\n", c.result.BlockRoot))
+		builder.WriteString(fmt.Sprintf("Block state root: %#x\n", c.result.BlockStateRoot))
+		builder.WriteString(fmt.Sprintf("Computed state root: %#x\n", c.result.ComputedStateRoot))
+	}
+	if c.result.Verified {
+		builder.WriteString("State root verified")
+	} else {
+		builder.WriteString("State root FAILED verification")
+	}
+
+	return builder.String(), nil
+}
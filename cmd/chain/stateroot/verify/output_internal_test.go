@@ -0,0 +1,102 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainstaterootverify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	verified := &result{
+		Epoch:             2,
+		BlockSlot:         64,
+		BlockRoot:         phase0.Root{0x01},
+		BlockStateRoot:    phase0.Root{0x02},
+		ComputedStateRoot: phase0.Root{0x02},
+		Verified:          true,
+	}
+	failed := &result{
+		Epoch:             2,
+		BlockSlot:         63,
+		BlockRoot:         phase0.Root{0x01},
+		BlockStateRoot:    phase0.Root{0x02},
+		ComputedStateRoot: phase0.Root{0x03},
+	}
+
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+		err     string
+	}{
+		{
+			name:    "Nil",
+			command: &command{},
+			err:     "no result",
+		},
+		{
+			name: "Quiet",
+			command: &command{
+				quiet:  true,
+				result: verified,
+			},
+		},
+		{
+			name: "Verified",
+			command: &command{
+				result: verified,
+			},
+			res: "State root verified",
+		},
+		{
+			name: "VerifiedVerbose",
+			command: &command{
+				verbose: true,
+				result:  verified,
+			},
+			res: "Epoch: 2\nBlock slot: 64\nBlock root: 0x0100000000000000000000000000000000000000000000000000000000000000\nBlock state root: 0x0200000000000000000000000000000000000000000000000000000000000000\nComputed state root: 0x0200000000000000000000000000000000000000000000000000000000000000\nState root verified",
+		},
+		{
+			name: "Failed",
+			command: &command{
+				result: failed,
+			},
+			res: "Epoch: 2\nBlock slot: 63\nBlock root: 0x0100000000000000000000000000000000000000000000000000000000000000\nBlock state root: 0x0200000000000000000000000000000000000000000000000000000000000000\nComputed state root: 0x0300000000000000000000000000000000000000000000000000000000000000\nState root FAILED verification",
+		},
+		{
+			name: "JSON",
+			command: &command{
+				json:   true,
+				result: failed,
+			},
+			res: `{"epoch":"2","block_slot":"63","block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","block_state_root":"0x0200000000000000000000000000000000000000000000000000000000000000","computed_state_root":"0x0300000000000000000000000000000000000000000000000000000000000000","verified":false}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainstaterootverify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	epoch, err := util.ParseEpoch(ctx, c.chainTime, c.epoch)
+	if err != nil {
+		return err
+	}
+	c.result = &result{
+		Epoch: epoch,
+	}
+
+	block, err := c.boundaryBlock(ctx, c.chainTime.FirstSlotOfEpoch(epoch))
+	if err != nil {
+		return err
+	}
+	if c.result.BlockSlot, err = block.Slot(); err != nil {
+		return errors.Wrap(err, "failed to obtain block slot")
+	}
+	if c.result.BlockRoot, err = block.Root(); err != nil {
+		return errors.Wrap(err, "failed to calculate block root")
+	}
+	if c.result.BlockStateRoot, err = block.StateRoot(); err != nil {
+		return errors.Wrap(err, "failed to obtain block state root")
+	}
+	util.Log.Debug().Uint64("epoch", uint64(epoch)).Uint64("slot", uint64(c.result.BlockSlot)).Msg("Obtained boundary block")
+
+	// The state at the block's slot is the state immediately after the block
+	// was applied, whose root is the one committed to by the block.
+	state, err := c.consensusClient.(consensusclient.BeaconStateProvider).BeaconState(ctx, fmt.Sprintf("%d", c.result.BlockSlot))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain state")
+	}
+	if state == nil {
+		return errors.New("state not returned by beacon node")
+	}
+
+	c.result.ComputedStateRoot, err = stateRoot(state)
+	if err != nil {
+		return err
+	}
+	c.result.Verified = bytes.Equal(c.result.ComputedStateRoot[:], c.result.BlockStateRoot[:])
+
+	return nil
+}
+
+// boundaryBlock obtains the block at the epoch boundary.  If the boundary
+// slot is empty this is the latest block before it, as that is the block
+// whose state is carried across the boundary.
+func (c *command) boundaryBlock(ctx context.Context, boundary phase0.Slot) (*spec.VersionedSignedBeaconBlock, error) {
+	slot := boundary
+	for i := uint64(0); i < c.chainTime.SlotsPerEpoch(); i++ {
+		block, err := c.consensusClient.(consensusclient.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if block != nil {
+			return block, nil
+		}
+		util.Log.Debug().Uint64("slot", uint64(slot)).Msg("No block")
+		if slot == 0 {
+			break
+		}
+		slot--
+	}
+
+	return nil, fmt.Errorf("no block found in the epoch up to slot %d", boundary)
+}
+
+// stateRoot calculates the hash tree root of the state.
+func stateRoot(state *spec.VersionedBeaconState) (phase0.Root, error) {
+	var root [32]byte
+	var err error
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		if state.Phase0 == nil {
+			return phase0.Root{}, errors.New("no phase 0 state")
+		}
+		root, err = state.Phase0.HashTreeRoot()
+	case spec.DataVersionAltair:
+		if state.Altair == nil {
+			return phase0.Root{}, errors.New("no altair state")
+		}
+		root, err = state.Altair.HashTreeRoot()
+	case spec.DataVersionBellatrix:
+		if state.Bellatrix == nil {
+			return phase0.Root{}, errors.New("no bellatrix state")
+		}
+		root, err = state.Bellatrix.HashTreeRoot()
+	case spec.DataVersionCapella:
+		if state.Capella == nil {
+			return phase0.Root{}, errors.New("no capella state")
+		}
+		root, err = state.Capella.HashTreeRoot()
+	default:
+		return phase0.Root{}, fmt.Errorf("unsupported state version %v", state.Version)
+	}
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to calculate state root")
+	}
+
+	return root, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	if _, isProvider := c.consensusClient.(consensusclient.SignedBeaconBlockProvider); !isProvider {
+		return errors.New("consensus node does not provide blocks")
+	}
+	if _, isProvider := c.consensusClient.(consensusclient.BeaconStateProvider); !isProvider {
+		return errors.New("consensus node does not provide states")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	return nil
+}
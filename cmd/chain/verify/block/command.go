@@ -0,0 +1,95 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifyblock
+
+import (
+	"context"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	blockID string
+
+	// Processing.
+	consensusClient consensusclient.Service
+	slotsPerEpoch   uint64
+	domainTypes     map[string]phase0.DomainType
+	publicKeys      map[phase0.ValidatorIndex]e2types.PublicKey
+	block           *blockData
+
+	// Output.
+	checks []*check
+}
+
+type check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:       viper.GetBool("quiet"),
+		verbose:     viper.GetBool("verbose"),
+		debug:       viper.GetBool("debug"),
+		json:        viper.GetBool("json"),
+		blockID:     viper.GetString("blockid"),
+		domainTypes: make(map[string]phase0.DomainType),
+		publicKeys:  make(map[phase0.ValidatorIndex]e2types.PublicKey),
+		checks:      make([]*check, 0),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if c.blockID == "" {
+		return nil, errors.New("blockid is required")
+	}
+
+	return c, nil
+}
+
+// passed returns true if all checks passed.
+func (c *command) passed() bool {
+	for _, check := range c.checks {
+		if !check.Passed {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,477 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifyblock
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// blockData contains the parts of a signed beacon block that are verified,
+// independent of the block's version.
+type blockData struct {
+	slot          phase0.Slot
+	proposerIndex phase0.ValidatorIndex
+	parentRoot    phase0.Root
+	messageRoot   phase0.Root
+	signature     phase0.BLSSignature
+	randaoReveal  phase0.BLSSignature
+	attestations  []*phase0.Attestation
+	syncAggregate *altair.SyncAggregate
+}
+
+// infinitySignature is the BLS signature of the point at infinity, used when there are no signers.
+var infinitySignature = phase0.BLSSignature{0xc0}
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	signedBlock, err := c.consensusClient.(consensusclient.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, c.blockID)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block")
+	}
+	if signedBlock == nil {
+		return errors.New("block not found")
+	}
+	c.block, err = extractBlockData(signedBlock)
+	if err != nil {
+		return err
+	}
+	util.Log.Debug().Uint64("slot", uint64(c.block.slot)).Uint64("proposer", uint64(c.block.proposerIndex)).Msg("Obtained block")
+
+	if err := c.checkProposerSignature(ctx); err != nil {
+		return err
+	}
+	if err := c.checkRANDAOReveal(ctx); err != nil {
+		return err
+	}
+	if err := c.checkAttestations(ctx); err != nil {
+		return err
+	}
+	if c.block.syncAggregate != nil {
+		if err := c.checkSyncAggregate(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	if _, isProvider := c.consensusClient.(consensusclient.SignedBeaconBlockProvider); !isProvider {
+		return errors.New("consensus node does not provide blocks")
+	}
+	if _, isProvider := c.consensusClient.(consensusclient.DomainProvider); !isProvider {
+		return errors.New("consensus node does not provide domains")
+	}
+
+	specProvider, isProvider := c.consensusClient.(consensusclient.SpecProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide spec")
+	}
+	specData, err := specProvider.Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+
+	tmp, exists := specData["SLOTS_PER_EPOCH"]
+	if !exists {
+		return errors.New("spec does not contain SLOTS_PER_EPOCH")
+	}
+	var isUint64 bool
+	c.slotsPerEpoch, isUint64 = tmp.(uint64)
+	if !isUint64 {
+		return errors.New("spec returned non-integer value for SLOTS_PER_EPOCH")
+	}
+
+	for _, name := range []string{"DOMAIN_BEACON_PROPOSER", "DOMAIN_RANDAO", "DOMAIN_BEACON_ATTESTER", "DOMAIN_SYNC_COMMITTEE"} {
+		tmp, exists := specData[name]
+		if !exists {
+			// Sync committee domain is not present prior to Altair.
+			continue
+		}
+		domainType, isDomainType := tmp.(phase0.DomainType)
+		if !isDomainType {
+			return fmt.Errorf("spec returned non-domain type value for %s", name)
+		}
+		c.domainTypes[name] = domainType
+	}
+
+	return nil
+}
+
+func (c *command) addCheck(name string, passed bool, detail string) {
+	c.checks = append(c.checks, &check{
+		Name:   name,
+		Passed: passed,
+		Detail: detail,
+	})
+}
+
+// checkProposerSignature checks the proposer's signature over the block.
+func (c *command) checkProposerSignature(ctx context.Context) error {
+	name := "Proposer signature"
+
+	pubKeys, err := c.obtainPublicKeys(ctx, []phase0.ValidatorIndex{c.block.proposerIndex})
+	if err != nil {
+		return err
+	}
+
+	valid, err := c.verify(ctx, "DOMAIN_BEACON_PROPOSER", c.epoch(c.block.slot), c.block.messageRoot, c.block.signature, pubKeys)
+	if err != nil {
+		c.addCheck(name, false, err.Error())
+		return nil
+	}
+	if !valid {
+		c.addCheck(name, false, fmt.Sprintf("signature does not verify for proposer %d", c.block.proposerIndex))
+		return nil
+	}
+	c.addCheck(name, true, fmt.Sprintf("proposer %d", c.block.proposerIndex))
+
+	return nil
+}
+
+// checkRANDAOReveal checks the proposer's RANDAO reveal.
+func (c *command) checkRANDAOReveal(ctx context.Context) error {
+	name := "RANDAO reveal"
+
+	pubKeys, err := c.obtainPublicKeys(ctx, []phase0.ValidatorIndex{c.block.proposerIndex})
+	if err != nil {
+		return err
+	}
+
+	epoch := c.epoch(c.block.slot)
+	valid, err := c.verify(ctx, "DOMAIN_RANDAO", epoch, epochRoot(epoch), c.block.randaoReveal, pubKeys)
+	if err != nil {
+		c.addCheck(name, false, err.Error())
+		return nil
+	}
+	if !valid {
+		c.addCheck(name, false, fmt.Sprintf("reveal does not verify for epoch %d", epoch))
+		return nil
+	}
+	c.addCheck(name, true, fmt.Sprintf("epoch %d", epoch))
+
+	return nil
+}
+
+// checkAttestations checks the aggregate signature of each attestation in the block.
+func (c *command) checkAttestations(ctx context.Context) error {
+	name := "Attestation signatures"
+
+	committeesProvider, isProvider := c.consensusClient.(consensusclient.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide beacon committees")
+	}
+
+	// Committees keyed by epoch, then slot and committee index.
+	committees := make(map[phase0.Epoch]map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+	failures := make([]string, 0)
+	for i, attestation := range c.block.attestations {
+		epoch := c.epoch(attestation.Data.Slot)
+		if _, exists := committees[epoch]; !exists {
+			epochCommittees, err := committeesProvider.BeaconCommittees(ctx, fmt.Sprintf("%d", attestation.Data.Slot))
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to obtain beacon committees for epoch %d", epoch))
+			}
+			committees[epoch] = make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+			for _, committee := range epochCommittees {
+				if _, exists := committees[epoch][committee.Slot]; !exists {
+					committees[epoch][committee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+				}
+				committees[epoch][committee.Slot][committee.Index] = committee.Validators
+			}
+		}
+
+		committee, exists := committees[epoch][attestation.Data.Slot][attestation.Data.Index]
+		if !exists {
+			failures = append(failures, fmt.Sprintf("attestation %d: unknown committee %d at slot %d", i, attestation.Data.Index, attestation.Data.Slot))
+			continue
+		}
+		if attestation.AggregationBits.Len() != uint64(len(committee)) {
+			failures = append(failures, fmt.Sprintf("attestation %d: aggregation bits length %d does not match committee size %d", i, attestation.AggregationBits.Len(), len(committee)))
+			continue
+		}
+		attesters := make([]phase0.ValidatorIndex, 0, len(committee))
+		for j := range committee {
+			if attestation.AggregationBits.BitAt(uint64(j)) {
+				attesters = append(attesters, committee[j])
+			}
+		}
+		util.Log.Debug().Int("attestation", i).Int("attesters", len(attesters)).Msg("Obtained attesters")
+
+		pubKeys, err := c.obtainPublicKeys(ctx, attesters)
+		if err != nil {
+			return err
+		}
+		dataRoot, err := attestation.Data.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain attestation data root")
+		}
+		valid, err := c.verify(ctx, "DOMAIN_BEACON_ATTESTER", attestation.Data.Target.Epoch, dataRoot, attestation.Signature, pubKeys)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("attestation %d: %v", i, err))
+			continue
+		}
+		if !valid {
+			failures = append(failures, fmt.Sprintf("attestation %d: signature does not verify for committee %d at slot %d", i, attestation.Data.Index, attestation.Data.Slot))
+		}
+	}
+
+	if len(failures) > 0 {
+		c.addCheck(name, false, fmt.Sprintf("%d of %d failed: %s", len(failures), len(c.block.attestations), strings.Join(failures, "; ")))
+		return nil
+	}
+	c.addCheck(name, true, fmt.Sprintf("%d attestations", len(c.block.attestations)))
+
+	return nil
+}
+
+// checkSyncAggregate checks the sync aggregate signature, which is over the root of the parent block.
+func (c *command) checkSyncAggregate(ctx context.Context) error {
+	name := "Sync aggregate signature"
+
+	syncCommitteesProvider, isProvider := c.consensusClient.(consensusclient.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide sync committees")
+	}
+	syncCommittee, err := syncCommitteesProvider.SyncCommittee(ctx, fmt.Sprintf("%d", c.block.slot))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain sync committee")
+	}
+
+	participants := make([]phase0.ValidatorIndex, 0, len(syncCommittee.Validators))
+	for i := range syncCommittee.Validators {
+		if c.block.syncAggregate.SyncCommitteeBits.BitAt(uint64(i)) {
+			participants = append(participants, syncCommittee.Validators[i])
+		}
+	}
+
+	if len(participants) == 0 {
+		// With no participants the signature must be the point at infinity.
+		if !bytes.Equal(c.block.syncAggregate.SyncCommitteeSignature[:], infinitySignature[:]) {
+			c.addCheck(name, false, "no participants but signature is not the point at infinity")
+			return nil
+		}
+		c.addCheck(name, true, "no participants")
+		return nil
+	}
+
+	pubKeys, err := c.obtainPublicKeys(ctx, participants)
+	if err != nil {
+		return err
+	}
+
+	// Signature is for the previous slot.
+	signingSlot := c.block.slot
+	if signingSlot > 0 {
+		signingSlot--
+	}
+	valid, err := c.verify(ctx, "DOMAIN_SYNC_COMMITTEE", c.epoch(signingSlot), c.block.parentRoot, c.block.syncAggregate.SyncCommitteeSignature, pubKeys)
+	if err != nil {
+		c.addCheck(name, false, err.Error())
+		return nil
+	}
+	if !valid {
+		c.addCheck(name, false, fmt.Sprintf("signature does not verify for %d participants", len(participants)))
+		return nil
+	}
+	c.addCheck(name, true, fmt.Sprintf("%d participants", len(participants)))
+
+	return nil
+}
+
+// verify verifies a signature over a root in the given domain.
+// An error is returned if the signature could not be verified, for example if it is malformed.
+func (c *command) verify(ctx context.Context,
+	domainName string,
+	epoch phase0.Epoch,
+	root phase0.Root,
+	signature phase0.BLSSignature,
+	pubKeys []e2types.PublicKey,
+) (
+	bool,
+	error,
+) {
+	domainType, exists := c.domainTypes[domainName]
+	if !exists {
+		return false, fmt.Errorf("spec does not contain %s", domainName)
+	}
+	domain, err := c.consensusClient.(consensusclient.DomainProvider).Domain(ctx, domainType, epoch)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain domain")
+	}
+
+	container := &phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	}
+	signingRoot, err := container.HashTreeRoot()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain signing root")
+	}
+
+	sig, err := e2types.BLSSignatureFromBytes(signature[:])
+	if err != nil {
+		return false, errors.Wrap(err, "invalid signature")
+	}
+
+	if len(pubKeys) == 1 {
+		return sig.Verify(signingRoot[:], pubKeys[0]), nil
+	}
+
+	return sig.VerifyAggregateCommon(signingRoot[:], pubKeys), nil
+}
+
+// obtainPublicKeys obtains the public keys for the given validators, fetching any that are not already known.
+func (c *command) obtainPublicKeys(ctx context.Context, indices []phase0.ValidatorIndex) ([]e2types.PublicKey, error) {
+	missing := make([]phase0.ValidatorIndex, 0)
+	for _, index := range indices {
+		if _, exists := c.publicKeys[index]; !exists {
+			missing = append(missing, index)
+		}
+	}
+
+	if len(missing) > 0 {
+		validatorsProvider, isProvider := c.consensusClient.(consensusclient.ValidatorsProvider)
+		if !isProvider {
+			return nil, errors.New("consensus node does not provide validators")
+		}
+		// Validator public keys never change, so the head state is used regardless of the block's slot.
+		validators, err := validatorsProvider.Validators(ctx, "head", missing)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators")
+		}
+		for index, validator := range validators {
+			pubKeyBytes := make([]byte, len(validator.Validator.PublicKey))
+			copy(pubKeyBytes, validator.Validator.PublicKey[:])
+			pubKey, err := e2types.BLSPublicKeyFromBytes(pubKeyBytes)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("invalid public key for validator %d", index))
+			}
+			c.publicKeys[index] = pubKey
+		}
+	}
+
+	pubKeys := make([]e2types.PublicKey, 0, len(indices))
+	for _, index := range indices {
+		pubKey, exists := c.publicKeys[index]
+		if !exists {
+			return nil, fmt.Errorf("unknown validator %d", index)
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	return pubKeys, nil
+}
+
+func (c *command) epoch(slot phase0.Slot) phase0.Epoch {
+	return phase0.Epoch(uint64(slot) / c.slotsPerEpoch)
+}
+
+// epochRoot returns the hash tree root of an epoch, as signed for the RANDAO reveal.
+func epochRoot(epoch phase0.Epoch) phase0.Root {
+	root := phase0.Root{}
+	binary.LittleEndian.PutUint64(root[:8], uint64(epoch))
+
+	return root
+}
+
+// extractBlockData extracts the data to verify from a versioned block.
+func extractBlockData(block *spec.VersionedSignedBeaconBlock) (*blockData, error) {
+	var err error
+	data := &blockData{}
+
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		if block.Phase0 == nil || block.Phase0.Message == nil || block.Phase0.Message.Body == nil {
+			return nil, errors.New("no phase0 block")
+		}
+		message := block.Phase0.Message
+		data.slot = message.Slot
+		data.proposerIndex = message.ProposerIndex
+		data.parentRoot = message.ParentRoot
+		data.signature = block.Phase0.Signature
+		data.randaoReveal = message.Body.RANDAOReveal
+		data.attestations = message.Body.Attestations
+		data.messageRoot, err = message.HashTreeRoot()
+	case spec.DataVersionAltair:
+		if block.Altair == nil || block.Altair.Message == nil || block.Altair.Message.Body == nil {
+			return nil, errors.New("no altair block")
+		}
+		message := block.Altair.Message
+		data.slot = message.Slot
+		data.proposerIndex = message.ProposerIndex
+		data.parentRoot = message.ParentRoot
+		data.signature = block.Altair.Signature
+		data.randaoReveal = message.Body.RANDAOReveal
+		data.attestations = message.Body.Attestations
+		data.syncAggregate = message.Body.SyncAggregate
+		data.messageRoot, err = message.HashTreeRoot()
+	case spec.DataVersionBellatrix:
+		if block.Bellatrix == nil || block.Bellatrix.Message == nil || block.Bellatrix.Message.Body == nil {
+			return nil, errors.New("no bellatrix block")
+		}
+		message := block.Bellatrix.Message
+		data.slot = message.Slot
+		data.proposerIndex = message.ProposerIndex
+		data.parentRoot = message.ParentRoot
+		data.signature = block.Bellatrix.Signature
+		data.randaoReveal = message.Body.RANDAOReveal
+		data.attestations = message.Body.Attestations
+		data.syncAggregate = message.Body.SyncAggregate
+		data.messageRoot, err = message.HashTreeRoot()
+	case spec.DataVersionCapella:
+		if block.Capella == nil || block.Capella.Message == nil || block.Capella.Message.Body == nil {
+			return nil, errors.New("no capella block")
+		}
+		message := block.Capella.Message
+		data.slot = message.Slot
+		data.proposerIndex = message.ProposerIndex
+		data.parentRoot = message.ParentRoot
+		data.signature = block.Capella.Signature
+		data.randaoReveal = message.Body.RANDAOReveal
+		data.attestations = message.Body.Attestations
+		data.syncAggregate = message.Body.SyncAggregate
+		data.messageRoot, err = message.HashTreeRoot()
+	default:
+		return nil, fmt.Errorf("unhandled block version %v", block.Version)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block root")
+	}
+
+	return data, nil
+}
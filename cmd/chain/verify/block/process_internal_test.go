@@ -0,0 +1,209 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifyblock
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testing/mock"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// consensusClient provides the validators and domains required to verify a block.
+type consensusClient struct {
+	*mock.ValidatorsProvider
+	domain phase0.Domain
+}
+
+func (*consensusClient) Name() string {
+	return "mock"
+}
+
+func (*consensusClient) Address() string {
+	return "mock"
+}
+
+func (c *consensusClient) Domain(_ context.Context, _ phase0.DomainType, _ phase0.Epoch) (phase0.Domain, error) {
+	return c.domain, nil
+}
+
+func (c *consensusClient) GenesisDomain(_ context.Context, _ phase0.DomainType) (phase0.Domain, error) {
+	return c.domain, nil
+}
+
+func TestEpochRoot(t *testing.T) {
+	require.Equal(t, phase0.Root{}, epochRoot(0))
+	require.Equal(t, phase0.Root{0x01}, epochRoot(1))
+	require.Equal(t, phase0.Root{0x34, 0x12, 0x01}, epochRoot(0x011234))
+}
+
+func TestExtractBlockData(t *testing.T) {
+	tests := []struct {
+		name  string
+		block *spec.VersionedSignedBeaconBlock
+		err   string
+	}{
+		{
+			name: "Phase0Missing",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionPhase0,
+			},
+			err: "no phase0 block",
+		},
+		{
+			name: "AltairMessageMissing",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionAltair,
+				Altair:  &altair.SignedBeaconBlock{},
+			},
+			err: "no altair block",
+		},
+		{
+			name: "BellatrixMissing",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionBellatrix,
+			},
+			err: "no bellatrix block",
+		},
+		{
+			name: "CapellaMissing",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionCapella,
+			},
+			err: "no capella block",
+		},
+		{
+			name: "UnknownVersion",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: 99,
+			},
+			err: "unhandled block version unknown",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := extractBlockData(test.block)
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestCheckProposer(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	privateKey, err := e2types.BLSPrivateKeyFromBytes([]byte{
+		0x25, 0x29, 0x5f, 0x0d, 0x1d, 0x59, 0x2a, 0x90, 0xb3, 0x33, 0xe2, 0x6e, 0x85, 0x14, 0x97, 0x08,
+		0x20, 0x8e, 0x9f, 0x8e, 0x8b, 0xc1, 0x8f, 0x6c, 0x77, 0xbd, 0x62, 0xf8, 0xad, 0x7a, 0x68, 0x66,
+	})
+	require.NoError(t, err)
+	var pubKey phase0.BLSPubKey
+	copy(pubKey[:], privateKey.PublicKey().Marshal())
+
+	domain := phase0.Domain{0x01, 0x02, 0x03, 0x04}
+	client := &consensusClient{
+		ValidatorsProvider: mock.NewValidatorsProvider([]*apiv1.Validator{
+			{
+				Index: 5,
+				Validator: &phase0.Validator{
+					PublicKey:             pubKey,
+					WithdrawalCredentials: make([]byte, 32),
+				},
+			},
+		}).(*mock.ValidatorsProvider),
+		domain: domain,
+	}
+
+	// sign signs a root in the domain.
+	sign := func(root phase0.Root) phase0.BLSSignature {
+		signingRoot, err := (&phase0.SigningData{
+			ObjectRoot: root,
+			Domain:     domain,
+		}).HashTreeRoot()
+		require.NoError(t, err)
+		var signature phase0.BLSSignature
+		copy(signature[:], privateKey.Sign(signingRoot[:]).Marshal())
+		return signature
+	}
+
+	messageRoot := phase0.Root{0x05}
+	tests := []struct {
+		name   string
+		block  *blockData
+		passed []bool
+	}{
+		{
+			name: "Good",
+			block: &blockData{
+				slot:          64,
+				proposerIndex: 5,
+				messageRoot:   messageRoot,
+				signature:     sign(messageRoot),
+				randaoReveal:  sign(epochRoot(2)),
+			},
+			passed: []bool{true, true},
+		},
+		{
+			name: "BadSignature",
+			block: &blockData{
+				slot:          64,
+				proposerIndex: 5,
+				messageRoot:   messageRoot,
+				signature:     sign(phase0.Root{0x06}),
+				randaoReveal:  sign(epochRoot(2)),
+			},
+			passed: []bool{false, true},
+		},
+		{
+			name: "BadRANDAOReveal",
+			block: &blockData{
+				slot:          64,
+				proposerIndex: 5,
+				messageRoot:   messageRoot,
+				signature:     sign(messageRoot),
+				randaoReveal:  sign(epochRoot(1)),
+			},
+			passed: []bool{true, false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				consensusClient: client,
+				slotsPerEpoch:   32,
+				domainTypes: map[string]phase0.DomainType{
+					"DOMAIN_BEACON_PROPOSER": {0x00, 0x00, 0x00, 0x00},
+					"DOMAIN_RANDAO":          {0x02, 0x00, 0x00, 0x00},
+				},
+				publicKeys: make(map[phase0.ValidatorIndex]e2types.PublicKey),
+				block:      test.block,
+				checks:     make([]*check, 0),
+			}
+			require.NoError(t, c.checkProposerSignature(context.Background()))
+			require.NoError(t, c.checkRANDAOReveal(context.Background()))
+			passed := make([]bool, 0, len(c.checks))
+			for _, check := range c.checks {
+				passed = append(passed, check.Passed)
+			}
+			require.Equal(t, test.passed, passed)
+		})
+	}
+}
@@ -19,7 +19,6 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
-	"os"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/altair"
@@ -145,9 +144,7 @@ func (c *command) isAggregator(ctx context.Context) (bool, error) {
 		return false, errors.New("spec returned non-integer value for SYNC_COMMITTEE_SIZE")
 	}
 	syncCommitteeSize := tmp.(uint64)
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "sync committee size is %d\n", syncCommitteeSize)
-	}
+	util.Log.Debug().Uint64("sync_committee_size", syncCommitteeSize).Msg("Obtained sync committee size")
 
 	tmp, exists = c.spec["SYNC_COMMITTEE_SUBNET_COUNT"]
 	if !exists {
@@ -157,9 +154,7 @@ func (c *command) isAggregator(ctx context.Context) (bool, error) {
 		return false, errors.New("spec returned non-integer value for SYNC_COMMITTEE_SUBNET_COUNT")
 	}
 	syncCommitteeSubnetCount := tmp.(uint64)
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "sync committee subnet count is %d\n", syncCommitteeSubnetCount)
-	}
+	util.Log.Debug().Uint64("sync_committee_subnet_count", syncCommitteeSubnetCount).Msg("Obtained sync committee subnet count")
 
 	tmp, exists = c.spec["TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE"]
 	if !exists {
@@ -169,17 +164,13 @@ func (c *command) isAggregator(ctx context.Context) (bool, error) {
 		return false, errors.New("spec returned non-integer value for TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE")
 	}
 	targetAggregatorsPerSyncSubcommittee := tmp.(uint64)
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "target aggregators per sync subcommittee is %d\n", targetAggregatorsPerSyncSubcommittee)
-	}
+	util.Log.Debug().Uint64("target_aggregators_per_sync_subcommittee", targetAggregatorsPerSyncSubcommittee).Msg("Obtained target aggregators per sync subcommittee")
 
 	modulo := syncCommitteeSize / syncCommitteeSubnetCount / targetAggregatorsPerSyncSubcommittee
 	if modulo < 1 {
 		modulo = 1
 	}
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "modulo is %d\n", modulo)
-	}
+	util.Log.Debug().Uint64("modulo", modulo).Msg("Calculated modulo")
 
 	// Hash the selection proof.
 	sigHash := sha256.New()
@@ -191,9 +182,7 @@ func (c *command) isAggregator(ctx context.Context) (bool, error) {
 		return false, errors.New("failed to write all bytes of the selection proof to the hash")
 	}
 	hash := sigHash.Sum(nil)
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "hash of selection proof is %#x\n", hash)
-	}
+	util.Log.Debug().Str("hash", fmt.Sprintf("%#x", hash)).Msg("Calculated hash of selection proof")
 
 	return binary.LittleEndian.Uint64(hash[:8])%modulo == 0, nil
 }
@@ -215,9 +204,7 @@ func (c *command) confirmContributionSignature(ctx context.Context) error {
 			includedIndices = append(includedIndices, subCommittee[int(i)])
 		}
 	}
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Contribution validator indices: %v (%d)\n", includedIndices, len(includedIndices))
-	}
+	util.Log.Debug().Interface("indices", includedIndices).Int("count", len(includedIndices)).Msg("Obtained contribution validator indices")
 
 	includedValidators, err := c.validatorsProvider.Validators(ctx, "head", includedIndices)
 	if err != nil {
@@ -241,9 +228,7 @@ func (c *command) confirmContributionSignature(ctx context.Context) error {
 			aggregatePubKey.Aggregate(pubKey)
 		}
 	}
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Aggregate public key is %#x\n", aggregatePubKey.Marshal())
-	}
+	util.Log.Debug().Str("pubkey", fmt.Sprintf("%#x", aggregatePubKey.Marshal())).Msg("Calculated aggregate public key")
 
 	// Don't have the ability to carry out the batch verification at current.
 
@@ -280,9 +265,7 @@ func (c *command) confirmContributionAndProofSignature(ctx context.Context) erro
 		return errors.New("spec returned non-domain type value for DOMAIN_CONTRIBUTION_AND_PROOF")
 	}
 	contributionAndProofDomainType := tmp.(phase0.DomainType)
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "contribution and proof domain type is %#x\n", contributionAndProofDomainType)
-	}
+	util.Log.Debug().Str("domain_type", fmt.Sprintf("%#x", contributionAndProofDomainType)).Msg("Obtained contribution and proof domain type")
 	domain, err := c.eth2Client.(eth2client.DomainProvider).Domain(ctx, contributionAndProofDomainType, phase0.Epoch(c.item.Message.Contribution.Slot/32))
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain domain")
@@ -0,0 +1,61 @@
+// Copyright © 2023 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainchurn "github.com/wealdtech/ethdo/cmd/chain/churn"
+)
+
+var chainChurnCmd = &cobra.Command{
+	Use:   "churn",
+	Short: "Show chain churn limits",
+	Long: `Show the number of validators, and amount of Ether, that can enter and leave the beacon chain each epoch and each day given the current validator set.  From Electra onwards this includes the balance churn, consolidation churn and deposit processing limits.  For example:
+
+    ethdo chain churn
+
+In quiet mode this will return 0 if the churn limits can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainchurn.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainChurnCmd)
+	chainFlags(chainChurnCmd)
+	chainChurnCmd.Flags().String("epoch", "", "epoch for which to calculate the churn limits")
+	chainChurnCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func chainChurnBindings() {
+	if err := viper.BindPFlag("epoch", chainChurnCmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", chainChurnCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
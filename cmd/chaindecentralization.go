@@ -0,0 +1,67 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chaindecentralization "github.com/wealdtech/ethdo/cmd/chain/decentralization"
+)
+
+var chainDecentralizationCmd = &cobra.Command{
+	Use:   "decentralization",
+	Short: "Obtain decentralization metrics for the chain",
+	Long: `Obtain metrics for the distribution of stake on the chain.  For example:
+
+    ethdo chain decentralization --labels=operators.csv
+
+Stake is grouped by withdrawal address and, if an execution connection is supplied, by the address that made each validator's first deposit.  If a labels file is supplied, with lines of the form "validator,entity" where validator is an index, public key or withdrawal address, stake is also grouped by entity.  Client diversity is estimated from the graffiti of recent blocks.
+
+In quiet mode this will return 0 if the metrics can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chaindecentralization.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainDecentralizationCmd)
+	chainFlags(chainDecentralizationCmd)
+	chainDecentralizationCmd.Flags().String("labels", "", "CSV file mapping validators to the entities that operate them")
+	chainDecentralizationCmd.Flags().Uint64("epochs", 4, "number of recent epochs of blocks to sample for client diversity")
+	chainDecentralizationCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func chainDecentralizationBindings() {
+	if err := viper.BindPFlag("labels", chainDecentralizationCmd.Flags().Lookup("labels")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epochs", chainDecentralizationCmd.Flags().Lookup("epochs")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", chainDecentralizationCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
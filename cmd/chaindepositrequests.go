@@ -0,0 +1,63 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chaindepositrequests "github.com/wealdtech/ethdo/cmd/chain/depositrequests"
+)
+
+var chainDepositRequestsCmd = &cobra.Command{
+	Use:   "depositrequests",
+	Short: "Track EIP-6110 deposit requests",
+	Long: `Track the deposit requests included in a range of blocks through the pending deposits queue to the validator registry.  For example:
+
+    ethdo chain depositrequests --blocks=9000000-head
+
+If no blocks are supplied the blocks of the last epoch are used.
+
+In quiet mode this will return 0 if no deposit requests are stuck or invalid, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chaindepositrequests.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainDepositRequestsCmd)
+	chainFlags(chainDepositRequestsCmd)
+	chainDepositRequestsCmd.Flags().String("blocks", "", "range of blocks to search for deposit requests, as start-end where each is a slot or \"head\"")
+	chainDepositRequestsCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func chainDepositRequestsBindings() {
+	if err := viper.BindPFlag("blocks", chainDepositRequestsCmd.Flags().Lookup("blocks")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", chainDepositRequestsCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	chaineth1votes "github.com/wealdtech/ethdo/cmd/chain/eth1votes"
@@ -40,7 +38,9 @@ In quiet mode this will return 0 if there is a majority for the votes, otherwise
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -0,0 +1,83 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chaingenesisgenerate "github.com/wealdtech/ethdo/cmd/chain/genesis/generate"
+)
+
+var chainGenesisGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a genesis state for a devnet",
+	Long: `Generate a genesis state for a devnet from a chain configuration, a set of deposits and a genesis time.  For example:
+
+    ethdo chain genesis generate --chain-config=config.yaml --genesis-time=1700000000 --mnemonic="..." --count=64 --file=genesis.ssz
+
+The chain configuration is a YAML file in the format of the consensus specification configuration, as used by beacon nodes.  Deposits are supplied either as deposit data, in the format generated by "ethdo validator depositdata", or as a mnemonic and the number of validators to generate from it.  Genesis can be at Altair, Bellatrix or Capella, as defined by the fork epochs in the configuration; genesis at Bellatrix or later can take an execution payload header.  Only the mainnet preset is supported.
+
+The genesis state is written to the file in SSZ format.
+
+In quiet mode this will return 0 if the genesis state is generated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chaingenesisgenerate.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	chainGenesisCmd.AddCommand(chainGenesisGenerateCmd)
+	chainFlags(chainGenesisGenerateCmd)
+	chainGenesisGenerateCmd.Flags().String("chain-config", "", "the chain configuration YAML file")
+	chainGenesisGenerateCmd.Flags().String("genesis-time", "", "the genesis time, as a Unix timestamp or RFC3339 time")
+	chainGenesisGenerateCmd.Flags().String("deposit-data", "", "deposit data, as JSON or the name of a file containing it")
+	chainGenesisGenerateCmd.Flags().Uint64("count", 0, "the number of validators to generate from the mnemonic")
+	chainGenesisGenerateCmd.Flags().String("withdrawal-address", "", "execution address to which to direct withdrawals of validators generated from the mnemonic (defaults to BLS withdrawal credentials)")
+	chainGenesisGenerateCmd.Flags().String("eth1-block-hash", "", "the eth1 block hash of the genesis state (defaults to the block hash of the execution payload header)")
+	chainGenesisGenerateCmd.Flags().String("execution-payload-header", "", "the execution payload header for genesis, as JSON or the name of a file containing it")
+	chainGenesisGenerateCmd.Flags().String("file", "", "the name of the file to which to write the genesis state")
+}
+
+func chainGenesisGenerateBindings() {
+	if err := viper.BindPFlag("chain-config", chainGenesisGenerateCmd.Flags().Lookup("chain-config")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("genesis-time", chainGenesisGenerateCmd.Flags().Lookup("genesis-time")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("deposit-data", chainGenesisGenerateCmd.Flags().Lookup("deposit-data")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("count", chainGenesisGenerateCmd.Flags().Lookup("count")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawal-address", chainGenesisGenerateCmd.Flags().Lookup("withdrawal-address")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("eth1-block-hash", chainGenesisGenerateCmd.Flags().Lookup("eth1-block-hash")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("execution-payload-header", chainGenesisGenerateCmd.Flags().Lookup("execution-payload-header")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("file", chainGenesisGenerateCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainheads "github.com/wealdtech/ethdo/cmd/chain/heads"
+)
+
+var chainHeadsCmd = &cobra.Command{
+	Use:   "heads",
+	Short: "List the chain heads known to a beacon node",
+	Long: `List the chain heads known to a beacon node, along with their fork choice weights and the point at which each diverges from the canonical chain.  For example:
+
+    ethdo chain heads
+
+The beacon node must have its debug API enabled.  If the node does not provide fork choice information the heads are listed without weights or divergence points.
+
+In quiet mode this will return 0 if the node knows of a single head, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainheads.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainHeadsCmd)
+	chainFlags(chainHeadsCmd)
+	chainHeadsCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func chainHeadsBindings() {
+	if err := viper.BindPFlag("json", chainHeadsCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -16,7 +16,6 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -50,12 +49,12 @@ In quiet mode this will return 0 if the chain information can be obtained, other
 		errCheck(err, "Failed to obtain current fork")
 
 		if quiet {
-			os.Exit(_exitSuccess)
+			exit(_exitSuccess)
 		}
 
 		if viper.GetBool("prepare-offline") {
 			fmt.Printf("Add the following to your command to run it offline:\n  --offline --genesis-validators=root=%#x --fork-version=%#x\n", genesis.GenesisValidatorsRoot, fork.CurrentVersion)
-			os.Exit(_exitSuccess)
+			exit(_exitSuccess)
 		}
 
 		if genesis.GenesisTime.Unix() == 0 {
@@ -82,7 +81,7 @@ In quiet mode this will return 0 if the chain information can be obtained, other
 		fmt.Printf("Seconds per slot: %d\n", int(config["SECONDS_PER_SLOT"].(time.Duration).Seconds()))
 		fmt.Printf("Slots per epoch: %d\n", config["SLOTS_PER_EPOCH"].(uint64))
 
-		os.Exit(_exitSuccess)
+		exit(_exitSuccess)
 	},
 }
 
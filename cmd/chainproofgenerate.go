@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainproofgenerate "github.com/wealdtech/ethdo/cmd/chain/proof/generate"
+)
+
+var chainProofGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a Merkle proof of a beacon state field",
+	Long: `Generate a Merkle proof of a field of a beacon state against the state root.  For example:
+
+    ethdo chain proof generate --state=finalized --field=validators --index=12345 --json
+
+A proof can be generated for any top-level field of the state, given as its specification name (for example "finalized_checkpoint" or "latest_block_header").  A proof of a single element can be generated for the fields "validators", "balances", "block_roots", "state_roots" and "randao_mixes" by supplying its index.  Balances are packed four to a chunk, so the leaf of a proof of a balance is the chunk holding it.
+
+In quiet mode this will return 0 if the proof is generated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainproofgenerate.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	chainProofCmd.AddCommand(chainProofGenerateCmd)
+	chainFlags(chainProofGenerateCmd)
+	chainProofGenerateCmd.Flags().String("state", "", "State for which to generate the proof: a slot, a state root, epoch:<epoch> or fork:<name> (default head)")
+	chainProofGenerateCmd.Flags().String("field", "", "the name of the state field to prove (for example validators or finalized_checkpoint)")
+	chainProofGenerateCmd.Flags().String("index", "", "the index of the element of the field to prove")
+	chainProofGenerateCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func chainProofGenerateBindings() {
+	if err := viper.BindPFlag("state", chainProofGenerateCmd.Flags().Lookup("state")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("field", chainProofGenerateCmd.Flags().Lookup("field")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("index", chainProofGenerateCmd.Flags().Lookup("index")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", chainProofGenerateCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
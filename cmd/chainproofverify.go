@@ -0,0 +1,61 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainproofverify "github.com/wealdtech/ethdo/cmd/chain/proof/verify"
+)
+
+var chainProofVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify a Merkle proof of a beacon state field",
+	Long: `Verify a Merkle proof of a field of a beacon state, as generated by "chain proof generate --json".  For example:
+
+    ethdo chain proof verify --proof=proof.json --state-root=0x…
+
+The proof can be supplied either as JSON or as the name of a file containing it.  The state root should be obtained from a trusted source; if it is not supplied the proof is verified against the state root it contains, which only checks that the proof is self-consistent.  No connection to a beacon node is required.
+
+In quiet mode this will return 0 if the proof verifies, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainproofverify.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	chainProofCmd.AddCommand(chainProofVerifyCmd)
+	chainFlags(chainProofVerifyCmd)
+	chainProofVerifyCmd.Flags().String("proof", "", "the proof to verify, as JSON or the name of a file containing it")
+	chainProofVerifyCmd.Flags().String("state-root", "", "the trusted state root against which to verify the proof (default the state root in the proof)")
+	chainProofVerifyCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func chainProofVerifyBindings() {
+	if err := viper.BindPFlag("proof", chainProofVerifyCmd.Flags().Lookup("proof")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("state-root", chainProofVerifyCmd.Flags().Lookup("state-root")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", chainProofVerifyCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
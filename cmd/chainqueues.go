@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	chainqueues "github.com/wealdtech/ethdo/cmd/chain/queues"
@@ -24,7 +22,7 @@ import (
 var chainQueuesCmd = &cobra.Command{
 	Use:   "queues",
 	Short: "Show chain queues",
-	Long: `Show beacon chain activation and exit queues.  For example:
+	Long: `Show beacon chain activation and exit queues, along with their churn limits and estimated processing times.  From Electra onwards the pending deposits, partial withdrawals and consolidations queues are also shown.  For example:
 
     ethdo chain queues
 
@@ -38,7 +36,9 @@ In quiet mode this will return 0 if the entry and exit queues are 0, otherwise 1
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
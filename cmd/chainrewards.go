@@ -0,0 +1,75 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainrewards "github.com/wealdtech/ethdo/cmd/chain/rewards"
+)
+
+var chainRewardsCmd = &cobra.Command{
+	Use:   "rewards",
+	Short: "Obtain rewards for validators",
+	Long: `Obtain the attestation, sync committee and block proposal rewards for validators over a range of epochs.  For example:
+
+    ethdo chain rewards --validators=1,2,3 --epoch=200000 --epochs=225 --csv
+
+If no epoch is supplied the most recent epochs for which rewards are available are used.  Rewards for an epoch are available once the following epoch has completed.
+
+In quiet mode this will return 0 if rewards can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainrewards.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainRewardsCmd)
+	chainFlags(chainRewardsCmd)
+	chainRewardsCmd.Flags().StringSlice("validators", nil, "the validators for which to obtain rewards, as indices, public keys or ranges of indices")
+	chainRewardsCmd.Flags().String("epoch", "", "the first epoch for which to obtain rewards")
+	chainRewardsCmd.Flags().Uint64("epochs", 1, "the number of epochs for which to obtain rewards")
+	chainRewardsCmd.Flags().Bool("json", false, "output data in JSON format")
+	chainRewardsCmd.Flags().Bool("csv", false, "output data in CSV format")
+}
+
+func chainRewardsBindings() {
+	if err := viper.BindPFlag("validators", chainRewardsCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epoch", chainRewardsCmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epochs", chainRewardsCmd.Flags().Lookup("epochs")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", chainRewardsCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", chainRewardsCmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainspecdiff "github.com/wealdtech/ethdo/cmd/chain/spec/diff"
+)
+
+var chainSpecDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show differences between chain specifications",
+	Long: `Show the parameters that differ between the chain specifications of two beacon nodes.  For example:
+
+    ethdo chain spec diff --connection=http://node1:5052 --other-connection=http://node2:5052
+
+The specification can also be compared against the bundled specification of a named network (mainnet, sepolia or holesky):
+
+    ethdo chain spec diff --network=mainnet
+
+Parameters present on the node but not in the bundled specification are often specific to the node's implementation, so are only shown with --verbose.
+
+In quiet mode this will return 0 if the specifications match, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainspecdiff.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	chainSpecCmd.AddCommand(chainSpecDiffCmd)
+	chainFlags(chainSpecDiffCmd)
+	chainSpecDiffCmd.Flags().String("other-connection", "", "the connection to the beacon node whose specification to compare against")
+	chainSpecDiffCmd.Flags().String("network", "", "the network whose bundled specification to compare against (mainnet, sepolia or holesky)")
+	if err := chainSpecDiffCmd.RegisterFlagCompletionFunc("network", completeNetworks(chainspecdiff.NetworkNames())); err != nil {
+		panic(err)
+	}
+	chainSpecDiffCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func chainSpecDiffBindings() {
+	if err := viper.BindPFlag("other-connection", chainSpecDiffCmd.Flags().Lookup("other-connection")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("network", chainSpecDiffCmd.Flags().Lookup("network")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", chainSpecDiffCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
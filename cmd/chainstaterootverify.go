@@ -0,0 +1,57 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainstaterootverify "github.com/wealdtech/ethdo/cmd/chain/stateroot/verify"
+)
+
+var chainStateRootVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the state root at an epoch boundary",
+	Long: `Verify the state root at an epoch boundary.  For example:
+
+    ethdo chain stateroot verify --epoch=12345
+
+The block at the first slot of the epoch, or the latest block before it if that slot is empty, is fetched along with the state following the block.  The root of the state is calculated locally and compared against the state root in the block, providing an independent check on the integrity of the data served by the beacon node.
+
+In quiet mode this will return 0 if the state root verifies, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainstaterootverify.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	chainStateRootCmd.AddCommand(chainStateRootVerifyCmd)
+	chainFlags(chainStateRootVerifyCmd)
+	chainStateRootVerifyCmd.Flags().String("epoch", "", "the epoch at whose boundary to verify the state root (default current, can be 'current', 'last' or a number)")
+	chainStateRootVerifyCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func chainStateRootVerifyBindings() {
+	if err := viper.BindPFlag("epoch", chainStateRootVerifyCmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", chainStateRootVerifyCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
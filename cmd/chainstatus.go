@@ -16,7 +16,6 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
@@ -25,6 +24,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
 	string2eth "github.com/wealdtech/go-string2eth"
@@ -37,6 +37,12 @@ var chainStatusCmd = &cobra.Command{
 
     ethdo chain status
 
+Historical justification and finalization distances can be output in CSV format with --history, for example:
+
+    ethdo chain status --history --epochs=100
+
+This requires the beacon node to have access to historical states.
+
 In quiet mode this will return 0 if the chain status can be obtained, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
@@ -52,6 +58,12 @@ In quiet mode this will return 0 if the chain status can be obtained, otherwise
 
 		finalityProvider, isProvider := eth2Client.(eth2client.FinalityProvider)
 		assert(isProvider, "beacon node does not provide finality; cannot report on chain status")
+
+		if viper.GetBool("history") {
+			fmt.Print(chainStatusHistory(ctx, chainTime, finalityProvider, viper.GetUint64("epochs")))
+			exit(_exitSuccess)
+		}
+
 		finality, err := finalityProvider.Finality(ctx, "head")
 		errCheck(err, "Failed to obtain finality information")
 
@@ -68,6 +80,36 @@ In quiet mode this will return 0 if the chain status can be obtained, otherwise
 		nextEpochStartSlot := chainTime.FirstSlotOfEpoch(nextEpoch)
 		nextEpochTimestamp := chainTime.StartOfEpoch(nextEpoch)
 
+		if outputFormat := viper.GetString("output"); outputFormat != "" {
+			status := &chainStatus{
+				Slot:                slot,
+				Epoch:               epoch,
+				EpochStartSlot:      epochStartSlot,
+				EpochEndSlot:        epochEndSlot,
+				NextSlotTime:        nextSlotTimestamp.UTC(),
+				NextEpochTime:       nextEpochTimestamp.UTC(),
+				SlotsUntilNextEpoch: nextEpochStartSlot - slot,
+				JustifiedEpoch:      finality.Justified.Epoch,
+				JustifiedDistance:   epoch - finality.Justified.Epoch,
+				FinalizedEpoch:      finality.Finalized.Epoch,
+				FinalizedDistance:   epoch - finality.Finalized.Epoch,
+			}
+			if epoch >= chainTime.AltairInitialEpoch() {
+				period := chainTime.SlotToSyncCommitteePeriod(slot)
+				status.SyncCommitteePeriod = &period
+			}
+			if validatorsProvider, isProvider := eth2Client.(eth2client.ValidatorsProvider); verbose && isProvider {
+				status.Validators, err = chainStatusValidatorStats(ctx, validatorsProvider)
+				errCheck(err, "Failed to obtain validators information")
+			}
+			if !quiet {
+				res, err := util.RenderOutput(outputFormat, status)
+				errCheck(err, "Failed to generate output")
+				errCheck(outputResult(res), "Failed to output result")
+			}
+			exit(_exitSuccess)
+		}
+
 		res := strings.Builder{}
 
 		res.WriteString("Current slot: ")
@@ -121,28 +163,17 @@ In quiet mode this will return 0 if the chain status can be obtained, otherwise
 		if verbose {
 			validatorsProvider, isProvider := eth2Client.(eth2client.ValidatorsProvider)
 			if isProvider {
-				validators, err := validatorsProvider.Validators(ctx, "head", nil)
+				stats, err := chainStatusValidatorStats(ctx, validatorsProvider)
 				errCheck(err, "Failed to obtain validators information")
-				// Stats of inteest.
-				totalBalance := phase0.Gwei(0)
-				activeEffectiveBalance := phase0.Gwei(0)
-				validatorCount := make(map[apiv1.ValidatorState]int)
-				for _, validator := range validators {
-					validatorCount[validator.Status]++
-					totalBalance += validator.Balance
-					if validator.Status.IsActive() {
-						activeEffectiveBalance += validator.Validator.EffectiveBalance
-					}
-				}
-				res.WriteString(fmt.Sprintf("Total balance: %s\n", string2eth.GWeiToString(uint64(totalBalance), true)))
-				res.WriteString(fmt.Sprintf("Active effective balance: %s\n", string2eth.GWeiToString(uint64(activeEffectiveBalance), true)))
+				res.WriteString(fmt.Sprintf("Total balance: %s\n", string2eth.GWeiToString(uint64(stats.TotalBalance), true)))
+				res.WriteString(fmt.Sprintf("Active effective balance: %s\n", string2eth.GWeiToString(uint64(stats.ActiveEffectiveBalance), true)))
 				res.WriteString("Validator states:\n")
-				res.WriteString(fmt.Sprintf("  Pending: %d\n", validatorCount[apiv1.ValidatorStatePendingInitialized]))
-				res.WriteString(fmt.Sprintf("  Activating: %d\n", validatorCount[apiv1.ValidatorStatePendingQueued]))
-				res.WriteString(fmt.Sprintf("  Active: %d\n", validatorCount[apiv1.ValidatorStateActiveOngoing]+validatorCount[apiv1.ValidatorStateActiveSlashed]))
-				res.WriteString(fmt.Sprintf("  Exiting: %d\n", validatorCount[apiv1.ValidatorStateActiveExiting]))
-				res.WriteString(fmt.Sprintf("  Exited: %d\n", validatorCount[apiv1.ValidatorStateExitedUnslashed]+validatorCount[apiv1.ValidatorStateExitedSlashed]+validatorCount[apiv1.ValidatorStateWithdrawalPossible]+validatorCount[apiv1.ValidatorStateWithdrawalDone]))
-				res.WriteString(fmt.Sprintf("  Unknown: %d\n", validatorCount[apiv1.ValidatorStateUnknown]))
+				res.WriteString(fmt.Sprintf("  Pending: %d\n", stats.Pending))
+				res.WriteString(fmt.Sprintf("  Activating: %d\n", stats.Activating))
+				res.WriteString(fmt.Sprintf("  Active: %d\n", stats.Active))
+				res.WriteString(fmt.Sprintf("  Exiting: %d\n", stats.Exiting))
+				res.WriteString(fmt.Sprintf("  Exited: %d\n", stats.Exited))
+				res.WriteString(fmt.Sprintf("  Unknown: %d\n", stats.Unknown))
 			}
 		}
 
@@ -181,11 +212,150 @@ In quiet mode this will return 0 if the chain status can be obtained, otherwise
 
 		fmt.Print(res.String())
 
-		os.Exit(_exitSuccess)
+		exit(_exitSuccess)
 	},
 }
 
+type chainStatus struct {
+	Slot                phase0.Slot            `json:"slot"`
+	Epoch               phase0.Epoch           `json:"epoch"`
+	EpochStartSlot      phase0.Slot            `json:"epoch_start_slot"`
+	EpochEndSlot        phase0.Slot            `json:"epoch_end_slot"`
+	NextSlotTime        time.Time              `json:"next_slot_time"`
+	NextEpochTime       time.Time              `json:"next_epoch_time"`
+	SlotsUntilNextEpoch phase0.Slot            `json:"slots_until_next_epoch"`
+	JustifiedEpoch      phase0.Epoch           `json:"justified_epoch"`
+	JustifiedDistance   phase0.Epoch           `json:"justified_distance"`
+	FinalizedEpoch      phase0.Epoch           `json:"finalized_epoch"`
+	FinalizedDistance   phase0.Epoch           `json:"finalized_distance"`
+	SyncCommitteePeriod *uint64                `json:"sync_committee_period,omitempty"`
+	Validators          *chainStatusValidators `json:"validators,omitempty"`
+}
+
+type chainStatusValidators struct {
+	TotalBalance           phase0.Gwei `json:"total_balance"`
+	ActiveEffectiveBalance phase0.Gwei `json:"active_effective_balance"`
+	Pending                int         `json:"pending"`
+	Activating             int         `json:"activating"`
+	Active                 int         `json:"active"`
+	Exiting                int         `json:"exiting"`
+	Exited                 int         `json:"exited"`
+	Unknown                int         `json:"unknown"`
+}
+
+// TableHeaders provides the headers for table output.
+func (s *chainStatus) TableHeaders() []string {
+	return []string{"Item", "Value"}
+}
+
+// TableRows provides the rows for table output.
+func (s *chainStatus) TableRows() [][]string {
+	rows := [][]string{
+		{"Current slot", fmt.Sprintf("%d", s.Slot)},
+		{"Current epoch", fmt.Sprintf("%d", s.Epoch)},
+		{"Epoch slots", fmt.Sprintf("%d-%d", s.EpochStartSlot, s.EpochEndSlot)},
+		{"Next slot time", s.NextSlotTime.Format(time.RFC3339)},
+		{"Next epoch time", s.NextEpochTime.Format(time.RFC3339)},
+		{"Slots until next epoch", fmt.Sprintf("%d", s.SlotsUntilNextEpoch)},
+		{"Justified epoch", fmt.Sprintf("%d", s.JustifiedEpoch)},
+		{"Justified epoch distance", fmt.Sprintf("%d", s.JustifiedDistance)},
+		{"Finalized epoch", fmt.Sprintf("%d", s.FinalizedEpoch)},
+		{"Finalized epoch distance", fmt.Sprintf("%d", s.FinalizedDistance)},
+	}
+	if s.SyncCommitteePeriod != nil {
+		rows = append(rows, []string{"Sync committee period", fmt.Sprintf("%d", *s.SyncCommitteePeriod)})
+	}
+	if s.Validators != nil {
+		rows = append(rows,
+			[]string{"Total balance", string2eth.GWeiToString(uint64(s.Validators.TotalBalance), true)},
+			[]string{"Active effective balance", string2eth.GWeiToString(uint64(s.Validators.ActiveEffectiveBalance), true)},
+			[]string{"Pending validators", fmt.Sprintf("%d", s.Validators.Pending)},
+			[]string{"Activating validators", fmt.Sprintf("%d", s.Validators.Activating)},
+			[]string{"Active validators", fmt.Sprintf("%d", s.Validators.Active)},
+			[]string{"Exiting validators", fmt.Sprintf("%d", s.Validators.Exiting)},
+			[]string{"Exited validators", fmt.Sprintf("%d", s.Validators.Exited)},
+			[]string{"Unknown validators", fmt.Sprintf("%d", s.Validators.Unknown)},
+		)
+	}
+
+	return rows
+}
+
+// chainStatusValidatorStats provides statistics about the validators on the chain.
+func chainStatusValidatorStats(ctx context.Context, validatorsProvider eth2client.ValidatorsProvider) (*chainStatusValidators, error) {
+	validators, err := validatorsProvider.Validators(ctx, "head", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &chainStatusValidators{}
+	validatorCount := make(map[apiv1.ValidatorState]int)
+	for _, validator := range validators {
+		validatorCount[validator.Status]++
+		stats.TotalBalance += validator.Balance
+		if validator.Status.IsActive() {
+			stats.ActiveEffectiveBalance += validator.Validator.EffectiveBalance
+		}
+	}
+	stats.Pending = validatorCount[apiv1.ValidatorStatePendingInitialized]
+	stats.Activating = validatorCount[apiv1.ValidatorStatePendingQueued]
+	stats.Active = validatorCount[apiv1.ValidatorStateActiveOngoing] + validatorCount[apiv1.ValidatorStateActiveSlashed]
+	stats.Exiting = validatorCount[apiv1.ValidatorStateActiveExiting]
+	stats.Exited = validatorCount[apiv1.ValidatorStateExitedUnslashed] + validatorCount[apiv1.ValidatorStateExitedSlashed] + validatorCount[apiv1.ValidatorStateWithdrawalPossible] + validatorCount[apiv1.ValidatorStateWithdrawalDone]
+	stats.Unknown = validatorCount[apiv1.ValidatorStateUnknown]
+
+	return stats, nil
+}
+
+// chainStatusHistory provides historical justification and finalization distances in CSV format.
+func chainStatusHistory(ctx context.Context,
+	chainTime chaintime.Service,
+	finalityProvider eth2client.FinalityProvider,
+	epochs uint64,
+) string {
+	currentEpoch := chainTime.CurrentEpoch()
+	if epochs > uint64(currentEpoch)+1 {
+		epochs = uint64(currentEpoch) + 1
+	}
+
+	res := strings.Builder{}
+	res.WriteString("epoch,slot,start,justified epoch,justified distance,finalized epoch,finalized distance\n")
+	for epoch := currentEpoch + 1 - phase0.Epoch(epochs); epoch <= currentEpoch; epoch++ {
+		// Use the state at the start of the epoch, which reflects the finality as seen during the epoch.
+		slot := chainTime.FirstSlotOfEpoch(epoch)
+		finality, err := finalityProvider.Finality(ctx, fmt.Sprintf("%d", slot))
+		errCheck(err, fmt.Sprintf("Failed to obtain finality information for epoch %d", epoch))
+		res.WriteString(chainStatusHistoryLine(epoch, slot, chainTime.StartOfEpoch(epoch), finality))
+	}
+
+	return res.String()
+}
+
+// chainStatusHistoryLine provides a single line of historical chain status.
+func chainStatusHistoryLine(epoch phase0.Epoch, slot phase0.Slot, start time.Time, finality *apiv1.Finality) string {
+	return fmt.Sprintf("%d,%d,%s,%d,%d,%d,%d\n",
+		epoch,
+		slot,
+		start.UTC().Format(time.RFC3339),
+		finality.Justified.Epoch,
+		epoch-finality.Justified.Epoch,
+		finality.Finalized.Epoch,
+		epoch-finality.Finalized.Epoch,
+	)
+}
+
 func init() {
 	chainCmd.AddCommand(chainStatusCmd)
 	chainFlags(chainStatusCmd)
+	chainStatusCmd.Flags().Bool("history", false, "Output historical justification and finalization distances in CSV format")
+	chainStatusCmd.Flags().Uint64("epochs", 100, "Number of epochs of history to output")
+}
+
+func chainStatusBindings() {
+	if err := viper.BindPFlag("history", chainStatusCmd.Flags().Lookup("history")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epochs", chainStatusCmd.Flags().Lookup("epochs")); err != nil {
+		panic(err)
+	}
 }
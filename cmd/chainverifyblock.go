@@ -0,0 +1,59 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainverifyblock "github.com/wealdtech/ethdo/cmd/chain/verify/block"
+)
+
+var chainVerifyBlockCmd = &cobra.Command{
+	Use:   "block",
+	Short: "Verify the signatures in a block",
+	Long: `Verify the signatures in a block.  For example:
+
+    ethdo chain verify block --blockid=12345
+
+Checks include the proposer signature, the RANDAO reveal, the aggregate signature of each attestation and the sync aggregate signature.
+
+blockid can be a slot, a block root, or one of "head", "finalized" or "genesis".
+
+In quiet mode this will return 0 if all signatures verify, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainverifyblock.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	chainVerifyCmd.AddCommand(chainVerifyBlockCmd)
+	chainFlags(chainVerifyBlockCmd)
+	chainVerifyBlockCmd.Flags().String("blockid", "head", "the ID of the block to verify")
+	chainVerifyBlockCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func chainVerifyBlockBindings() {
+	if err := viper.BindPFlag("blockid", chainVerifyBlockCmd.Flags().Lookup("blockid")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", chainVerifyBlockCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// Shell completion scripts are generated by the "completion" command that
+// cobra provides.  The functions here provide dynamic completion of flag
+// values that are only known at runtime, such as wallets and accounts in the
+// local store.
+
+// completionWallets returns the names of the wallets in the local store.
+func completionWallets() []string {
+	// Remote wallets are not completed, as that would require a connection
+	// for every completion request.
+	if viper.GetString("remote") != "" {
+		return nil
+	}
+	if err := util.SetupStore(); err != nil {
+		return nil
+	}
+
+	res := make([]string, 0)
+	for wallet := range e2wallet.Wallets() {
+		res = append(res, wallet.Name())
+	}
+
+	return res
+}
+
+// completeWallets completes the name of a wallet.
+func completeWallets(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return completionWallets(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeAccounts completes an account in the format "<wallet>/<account>".
+func completeAccounts(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !strings.Contains(toComplete, "/") {
+		// Complete the wallet, leaving the shell ready for the account.
+		wallets := completionWallets()
+		res := make([]string, 0, len(wallets))
+		for _, wallet := range wallets {
+			res = append(res, fmt.Sprintf("%s/", wallet))
+		}
+		return res, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+
+	walletName := strings.SplitN(toComplete, "/", 2)[0]
+	if viper.GetString("remote") != "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if err := util.SetupStore(); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	wallet, err := e2wallet.OpenWallet(walletName)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	accountsProvider, isProvider := wallet.(e2wtypes.WalletAccountsProvider)
+	if !isProvider {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	res := make([]string, 0)
+	for account := range accountsProvider.Accounts(context.Background()) {
+		res = append(res, fmt.Sprintf("%s/%s", walletName, account.Name()))
+	}
+
+	return res, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeNetworks completes the name of a bundled network.
+func completeNetworks(names []string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -13,7 +13,33 @@
 
 package cmd
 
+import "github.com/wealdtech/ethdo/util"
+
 const (
 	_exitSuccess = 0
 	_exitFailure = 1
+	// _exitConnectionFailure is returned when a node cannot be contacted.
+	_exitConnectionFailure = 2
+	// _exitValidationFailure is returned when the input to a command is invalid.
+	_exitValidationFailure = 3
+	// _exitSigningFailure is returned when data cannot be signed.
+	_exitSigningFailure = 4
+	// _exitBroadcastRejected is returned when a node rejects a submitted operation.
+	_exitBroadcastRejected = 5
 )
+
+// exitStatus returns the exit status for the given error.
+func exitStatus(err error) int {
+	switch util.ErrorClass(err) {
+	case util.ErrorClassConnection:
+		return _exitConnectionFailure
+	case util.ErrorClassValidation:
+		return _exitValidationFailure
+	case util.ErrorClassSigning:
+		return _exitSigningFailure
+	case util.ErrorClassBroadcast:
+		return _exitBroadcastRejected
+	default:
+		return _exitFailure
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/cmd/cron"
+)
+
+var cronCmd = &cobra.Command{
+	Use:   "cron",
+	Short: "Broadcast scheduled operations as they fall due",
+	Long: `Run continuously, broadcasting operations scheduled with "ethdo op schedule" as their epochs arrive.  For example:
+
+    ethdo cron --schedule-file=scheduled-operations.json
+
+The schedule file is checked at the start of each epoch, so operations can be scheduled while this is running.  Operations that fail to broadcast are retried each epoch.  With --once the schedule file is checked a single time, allowing this to be run periodically by an external scheduler instead.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := cron.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(cronCmd)
+	cronCmd.Flags().String("schedule-file", "scheduled-operations.json", "File in which scheduled operations are stored")
+	cronCmd.Flags().Bool("once", false, "Broadcast operations that are due and exit, rather than running continuously")
+}
+
+func cronBindings() {
+	if err := viper.BindPFlag("schedule-file", cronCmd.Flags().Lookup("schedule-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("once", cronCmd.Flags().Lookup("once")); err != nil {
+		panic(err)
+	}
+}
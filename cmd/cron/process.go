@@ -0,0 +1,171 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	for {
+		if err := c.broadcastDue(ctx); err != nil {
+			return err
+		}
+		if c.once {
+			return nil
+		}
+
+		// Wait for the start of the next epoch.
+		next := c.chainTime.StartOfEpoch(c.chainTime.CurrentEpoch() + 1)
+		util.Log.Debug().Time("until", next).Msg("Sleeping")
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Until(next)):
+		}
+	}
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isSubmitter bool
+	c.voluntaryExitSubmitter, isSubmitter = c.eth2Client.(eth2client.VoluntaryExitSubmitter)
+	if !isSubmitter {
+		return errors.New("connection does not support submitting voluntary exits")
+	}
+	c.blsToExecutionChangesSubmitter, isSubmitter = c.eth2Client.(eth2client.BLSToExecutionChangesSubmitter)
+	if !isSubmitter {
+		return errors.New("connection does not support submitting credentials changes")
+	}
+
+	return nil
+}
+
+// broadcastDue broadcasts the scheduled operations that are due, and records
+// the results in the schedule.
+func (c *command) broadcastDue(ctx context.Context) error {
+	schedule, err := util.ReadOperationSchedule(c.scheduleFile)
+	if err != nil {
+		return err
+	}
+
+	epoch := c.chainTime.CurrentEpoch()
+	due := schedule.Due(epoch)
+	util.Log.Debug().Uint64("epoch", uint64(epoch)).Int("operations", len(due)).Msg("Obtained due operations")
+	if len(due) == 0 {
+		return nil
+	}
+
+	for _, op := range due {
+		if err := c.broadcast(ctx, op); err != nil {
+			op.LastError = err.Error()
+			if !c.quiet {
+				fmt.Fprintf(os.Stdout, "Epoch %d: failed to broadcast %s for validators %v: %v\n", epoch, op.Type, op.Validators, err)
+			}
+			continue
+		}
+		now := time.Now().UTC().Truncate(time.Second)
+		op.Broadcast = &now
+		op.LastError = ""
+		if !c.quiet {
+			fmt.Fprintf(os.Stdout, "Epoch %d: broadcast %s for validators %v\n", epoch, op.Type, op.Validators)
+		}
+	}
+
+	// Operations may have been scheduled while broadcasting, so record the
+	// results against the latest version of the schedule.
+	latest, err := util.ReadOperationSchedule(c.scheduleFile)
+	if err != nil {
+		return err
+	}
+	recordResults(latest, due)
+
+	return latest.Write(c.scheduleFile)
+}
+
+// broadcast broadcasts a single scheduled operation.
+func (c *command) broadcast(ctx context.Context, op *util.ScheduledOperation) error {
+	switch op.Type {
+	case util.OperationTypeVoluntaryExit:
+		exit, err := op.VoluntaryExit()
+		if err != nil {
+			return err
+		}
+		return c.voluntaryExitSubmitter.SubmitVoluntaryExit(ctx, exit)
+	case util.OperationTypeBLSToExecutionChange:
+		changes, err := op.BLSToExecutionChanges()
+		if err != nil {
+			return err
+		}
+		return c.blsToExecutionChangesSubmitter.SubmitBLSToExecutionChanges(ctx, changes)
+	default:
+		return fmt.Errorf("unsupported operation type %s", op.Type)
+	}
+}
+
+// recordResults copies the broadcast status of operations in to the matching
+// operations of a schedule.
+func recordResults(schedule *util.OperationSchedule, results []*util.ScheduledOperation) {
+	for _, result := range results {
+		for _, op := range schedule.Operations {
+			if op.Broadcast == nil && sameOperation(op, result) {
+				op.Broadcast = result.Broadcast
+				op.LastError = result.LastError
+				break
+			}
+		}
+	}
+}
+
+// sameOperation returns true if the two scheduled operations are the same.
+func sameOperation(a *util.ScheduledOperation, b *util.ScheduledOperation) bool {
+	return a.Type == b.Type &&
+		a.Epoch == b.Epoch &&
+		a.Added.Equal(b.Added) &&
+		bytes.Equal(a.Operation, b.Operation)
+}
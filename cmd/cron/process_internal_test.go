@@ -0,0 +1,76 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestRecordResults(t *testing.T) {
+	added := time.Unix(1700000000, 0).UTC()
+	broadcast := added.Add(time.Hour)
+
+	first := &util.ScheduledOperation{
+		Type:      util.OperationTypeVoluntaryExit,
+		Epoch:     100,
+		Operation: json.RawMessage(`{"a":1}`),
+		Added:     added,
+	}
+	second := &util.ScheduledOperation{
+		Type:      util.OperationTypeVoluntaryExit,
+		Epoch:     100,
+		Operation: json.RawMessage(`{"a":2}`),
+		Added:     added,
+	}
+	// Added to the schedule while broadcasting.
+	third := &util.ScheduledOperation{
+		Type:      util.OperationTypeVoluntaryExit,
+		Epoch:     50,
+		Operation: json.RawMessage(`{"a":3}`),
+		Added:     added.Add(time.Minute),
+	}
+	schedule := &util.OperationSchedule{
+		Operations: []*util.ScheduledOperation{third, first, second},
+	}
+
+	results := []*util.ScheduledOperation{
+		{
+			Type:      util.OperationTypeVoluntaryExit,
+			Epoch:     100,
+			Operation: json.RawMessage(`{"a":1}`),
+			Added:     added,
+			Broadcast: &broadcast,
+		},
+		{
+			Type:      util.OperationTypeVoluntaryExit,
+			Epoch:     100,
+			Operation: json.RawMessage(`{"a":2}`),
+			Added:     added,
+			LastError: "rejected",
+		},
+	}
+
+	recordResults(schedule, results)
+	require.Equal(t, &broadcast, first.Broadcast)
+	require.Empty(t, first.LastError)
+	require.Nil(t, second.Broadcast)
+	require.Equal(t, "rejected", second.LastError)
+	require.Nil(t, third.Broadcast)
+	require.Empty(t, third.LastError)
+}
@@ -0,0 +1,94 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositcalldata
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+// depositSelector is the selector of the deposit contract function
+// deposit(bytes,bytes,bytes,bytes32).
+var depositSelector = []byte{0x22, 0x89, 0x51, 0x18}
+
+// forkVersionChainIDs maps the genesis fork versions of known networks to
+// the chain IDs of their execution chains.
+var forkVersionChainIDs = map[[4]byte]uint64{
+	{0x00, 0x00, 0x00, 0x00}: 1,
+	{0x00, 0x00, 0x10, 0x20}: 5,
+	{0x01, 0x01, 0x70, 0x00}: 17000,
+	{0x90, 0x00, 0x00, 0x69}: 11155111,
+}
+
+// depositCalldata returns the ABI-encoded call to the deposit contract for the deposit.
+func depositCalldata(deposit *util.DepositInfo) ([]byte, error) {
+	if len(deposit.PublicKey) != 48 {
+		return nil, fmt.Errorf("public key is %d bytes, expected 48", len(deposit.PublicKey))
+	}
+	if len(deposit.WithdrawalCredentials) != 32 {
+		return nil, fmt.Errorf("withdrawal credentials are %d bytes, expected 32", len(deposit.WithdrawalCredentials))
+	}
+	if len(deposit.Signature) != 96 {
+		return nil, fmt.Errorf("signature is %d bytes, expected 96", len(deposit.Signature))
+	}
+	if len(deposit.DepositDataRoot) != 32 {
+		return nil, fmt.Errorf("deposit data root is %d bytes, expected 32", len(deposit.DepositDataRoot))
+	}
+
+	res := make([]byte, 0, 4+32*4+32+64+32+32+32+96)
+	res = append(res, depositSelector...)
+	// Offsets of the dynamic arguments, followed by the deposit data root.
+	res = append(res, abiWord(0x80)...)
+	res = append(res, abiWord(0xe0)...)
+	res = append(res, abiWord(0x120)...)
+	res = append(res, deposit.DepositDataRoot...)
+	// Dynamic arguments, each prefixed by its length and padded to a multiple of 32 bytes.
+	res = append(res, abiBytes(deposit.PublicKey)...)
+	res = append(res, abiBytes(deposit.WithdrawalCredentials)...)
+	res = append(res, abiBytes(deposit.Signature)...)
+
+	return res, nil
+}
+
+// abiWord returns the ABI encoding of an unsigned integer.
+func abiWord(val uint64) []byte {
+	res := make([]byte, 32)
+	binary.BigEndian.PutUint64(res[24:], val)
+
+	return res
+}
+
+// abiBytes returns the ABI encoding of the contents of a dynamic byte array.
+func abiBytes(data []byte) []byte {
+	padded := (len(data) + 31) / 32 * 32
+	res := make([]byte, 32+padded)
+	copy(res, abiWord(uint64(len(data))))
+	copy(res[32:], data)
+
+	return res
+}
+
+// chainIDForForkVersion returns the chain ID for the given genesis fork version,
+// or 0 if the network is not known.
+func chainIDForForkVersion(forkVersion []byte) uint64 {
+	if len(forkVersion) != 4 {
+		return 0
+	}
+	var key [4]byte
+	copy(key[:], forkVersion)
+
+	return forkVersionChainIDs[key]
+}
@@ -0,0 +1,97 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositcalldata
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestDepositCalldata(t *testing.T) {
+	deposit := &util.DepositInfo{
+		PublicKey:             testutil.HexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+		WithdrawalCredentials: testutil.HexToBytes("0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b"),
+		Signature:             testutil.HexToBytes("0xb7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2"),
+		DepositDataRoot:       testutil.HexToBytes("0x9e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a3554"),
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*util.DepositInfo) *util.DepositInfo
+		res    string
+		err    string
+	}{
+		{
+			name: "PublicKeyShort",
+			modify: func(d *util.DepositInfo) *util.DepositInfo {
+				d.PublicKey = d.PublicKey[1:]
+				return d
+			},
+			err: "public key is 47 bytes, expected 48",
+		},
+		{
+			name: "WithdrawalCredentialsShort",
+			modify: func(d *util.DepositInfo) *util.DepositInfo {
+				d.WithdrawalCredentials = d.WithdrawalCredentials[1:]
+				return d
+			},
+			err: "withdrawal credentials are 31 bytes, expected 32",
+		},
+		{
+			name: "SignatureShort",
+			modify: func(d *util.DepositInfo) *util.DepositInfo {
+				d.Signature = d.Signature[1:]
+				return d
+			},
+			err: "signature is 95 bytes, expected 96",
+		},
+		{
+			name: "DepositDataRootMissing",
+			modify: func(d *util.DepositInfo) *util.DepositInfo {
+				d.DepositDataRoot = nil
+				return d
+			},
+			err: "deposit data root is 0 bytes, expected 32",
+		},
+		{
+			name:   "Good",
+			modify: func(d *util.DepositInfo) *util.DepositInfo { return d },
+			res:    "0x22895118000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000000e000000000000000000000000000000000000000000000000000000000000001209e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a35540000000000000000000000000000000000000000000000000000000000000030a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002000fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b0000000000000000000000000000000000000000000000000000000000000060b7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := *deposit
+			res, err := depositCalldata(test.modify(&input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, fmt.Sprintf("%#x", res))
+			}
+		})
+	}
+}
+
+func TestChainIDForForkVersion(t *testing.T) {
+	require.Equal(t, uint64(1), chainIDForForkVersion([]byte{0x00, 0x00, 0x00, 0x00}))
+	require.Equal(t, uint64(17000), chainIDForForkVersion([]byte{0x01, 0x01, 0x70, 0x00}))
+	require.Equal(t, uint64(0), chainIDForForkVersion([]byte{0x01, 0x02, 0x03, 0x04}))
+	require.Equal(t, uint64(0), chainIDForForkVersion(nil))
+}
@@ -0,0 +1,98 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositcalldata
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Execution connection.
+	timeout                  time.Duration
+	executionConnection      string
+	allowInsecureConnections bool
+
+	// Input.
+	data        string
+	transaction bool
+	chainID     uint64
+
+	// Processing.
+	deposits []*util.DepositInfo
+	calldata [][]byte
+	contract []byte
+	fees     *util.FeeEstimate
+
+	// Output.
+	transactions []*unsignedTransaction
+}
+
+// unsignedTransaction is an unsigned EIP-1559 transaction, in the format
+// accepted by eth_signTransaction and most external signers.
+type unsignedTransaction struct {
+	Type                 string `json:"type"`
+	ChainID              string `json:"chainId"`
+	To                   string `json:"to"`
+	Value                string `json:"value"`
+	Gas                  string `json:"gas"`
+	MaxFeePerGas         string `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas,omitempty"`
+	Data                 string `json:"data"`
+}
+
+// gweiToWei is the multiplier to convert deposit amounts to transaction values.
+var gweiToWei = big.NewInt(1000000000)
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		timeout:                  viper.GetDuration("timeout"),
+		executionConnection:      viper.GetString("execution-connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		data:                     viper.GetString("data"),
+		transaction:              viper.GetBool("transaction"),
+		chainID:                  viper.GetUint64("chain-id"),
+	}
+
+	if c.data == "" {
+		return nil, errors.New("data is required")
+	}
+
+	if c.executionConnection != "" && c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if !c.transaction {
+		if c.chainID != 0 {
+			return nil, errors.New("chain ID can only be used with transaction")
+		}
+		if c.executionConnection != "" {
+			return nil, errors.New("execution connection can only be used with transaction")
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,94 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositcalldata
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "DataMissing",
+			vars: map[string]interface{}{},
+			err:  "data is required",
+		},
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"data":                 "deposits.json",
+				"transaction":          true,
+				"execution-connection": "http://localhost:8545/",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ChainIDWithoutTransaction",
+			vars: map[string]interface{}{
+				"data":     "deposits.json",
+				"chain-id": 1,
+			},
+			err: "chain ID can only be used with transaction",
+		},
+		{
+			name: "ExecutionConnectionWithoutTransaction",
+			vars: map[string]interface{}{
+				"data":                 "deposits.json",
+				"timeout":              "5s",
+				"execution-connection": "http://localhost:8545/",
+			},
+			err: "execution connection can only be used with transaction",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"data": "deposits.json",
+			},
+		},
+		{
+			name: "GoodTransaction",
+			vars: map[string]interface{}{
+				"data":                 "deposits.json",
+				"transaction":          true,
+				"timeout":              5 * time.Second,
+				"execution-connection": "http://localhost:8545/",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositcalldata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+	if c.transaction {
+		// One transaction per line, for ease of passing to external signers.
+		for i, transaction := range c.transactions {
+			data, err := json.Marshal(transaction)
+			if err != nil {
+				return "", errors.Wrap(err, "failed to marshal transaction")
+			}
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+			builder.Write(data)
+		}
+
+		return builder.String(), nil
+	}
+
+	for i, calldata := range c.calldata {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		if c.verbose && c.deposits[i].Name != "" {
+			builder.WriteString(fmt.Sprintf("%s: ", c.deposits[i].Name))
+		}
+		builder.WriteString(fmt.Sprintf("%#x", calldata))
+	}
+
+	return builder.String(), nil
+}
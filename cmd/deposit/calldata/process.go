@@ -0,0 +1,145 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositcalldata
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	var data []byte
+	// Input could be JSON or a path to JSON.
+	switch {
+	case strings.HasPrefix(c.data, "{"):
+		data = []byte("[" + c.data + "]")
+	case strings.HasPrefix(c.data, "["):
+		data = []byte(c.data)
+	default:
+		var err error
+		data, err = os.ReadFile(c.data)
+		if err != nil {
+			return errors.Wrap(err, "failed to read deposit data file")
+		}
+		if len(data) > 0 && data[0] == '{' {
+			data = []byte("[" + string(data) + "]")
+		}
+	}
+
+	deposits, err := util.DepositInfoFromJSON(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse deposit data")
+	}
+	c.deposits = deposits
+
+	c.calldata = make([][]byte, len(c.deposits))
+	for i, deposit := range c.deposits {
+		if deposit.Amount == 0 {
+			return fmt.Errorf("deposit %d has no amount", i)
+		}
+		c.calldata[i], err = depositCalldata(deposit)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("invalid deposit %d", i))
+		}
+	}
+
+	if !c.transaction {
+		return nil
+	}
+
+	return c.buildTransactions(ctx)
+}
+
+// buildTransactions builds unsigned transactions to the deposit contract for the deposits.
+func (c *command) buildTransactions(ctx context.Context) error {
+	chainID := c.chainID
+	var executionClient *util.ExecutionClient
+	if c.executionConnection != "" {
+		var err error
+		executionClient, err = util.ConnectToExecutionNode(ctx, c.executionConnection, c.timeout, c.allowInsecureConnections)
+		if err != nil {
+			return errors.Wrap(err, "failed to connect to execution node")
+		}
+		nodeChainID, err := executionClient.ChainID(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain chain ID")
+		}
+		if chainID != 0 && chainID != nodeChainID {
+			return fmt.Errorf("chain ID %d does not match execution node chain ID %d", chainID, nodeChainID)
+		}
+		chainID = nodeChainID
+	}
+
+	// Ensure that the deposits are for the chain to which they will be sent.
+	for i, deposit := range c.deposits {
+		depositChainID := chainIDForForkVersion(deposit.ForkVersion)
+		if depositChainID == 0 {
+			continue
+		}
+		if chainID == 0 {
+			chainID = depositChainID
+		}
+		if depositChainID != chainID {
+			return fmt.Errorf("deposit %d has fork version %#x, which is not for chain ID %d", i, deposit.ForkVersion, chainID)
+		}
+	}
+	if chainID == 0 {
+		return errors.New("chain ID could not be determined; please supply it with --chain-id")
+	}
+
+	var err error
+	c.contract, err = util.DepositContractAddress(chainID)
+	if err != nil {
+		return err
+	}
+
+	if executionClient != nil {
+		// Deposits may have different amounts; estimate using the largest.
+		maxValue := big.NewInt(0)
+		for _, deposit := range c.deposits {
+			value := new(big.Int).Mul(new(big.Int).SetUint64(deposit.Amount), gweiToWei)
+			if value.Cmp(maxValue) > 0 {
+				maxValue = value
+			}
+		}
+		c.fees, err = executionClient.EstimateFees(ctx, util.DepositGasLimit, maxValue)
+		if err != nil {
+			return errors.Wrap(err, "failed to estimate fees")
+		}
+	}
+
+	c.transactions = make([]*unsignedTransaction, len(c.deposits))
+	for i, deposit := range c.deposits {
+		c.transactions[i] = &unsignedTransaction{
+			Type:    "0x2",
+			ChainID: fmt.Sprintf("%#x", chainID),
+			To:      fmt.Sprintf("%#x", c.contract),
+			Value:   fmt.Sprintf("%#x", new(big.Int).Mul(new(big.Int).SetUint64(deposit.Amount), gweiToWei)),
+			Gas:     fmt.Sprintf("%#x", util.DepositGasLimit),
+			Data:    fmt.Sprintf("%#x", c.calldata[i]),
+		}
+		if c.fees != nil {
+			c.transactions[i].MaxFeePerGas = fmt.Sprintf("%#x", c.fees.MaxFeePerGas)
+			c.transactions[i].MaxPriorityFeePerGas = fmt.Sprintf("%#x", c.fees.MaxPriorityFeePerGas)
+		}
+	}
+
+	return nil
+}
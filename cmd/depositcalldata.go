@@ -0,0 +1,61 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	depositcalldata "github.com/wealdtech/ethdo/cmd/deposit/calldata"
+)
+
+var depositCalldataCmd = &cobra.Command{
+	Use:   "calldata",
+	Short: "Generate deposit contract calls from deposit data",
+	Long: `Generate the call to the deposit contract for each deposit in deposit data, as generated by "validator depositdata" or the staking deposit CLI.  For example:
+
+    ethdo deposit calldata --data=deposits.json
+
+By default the ABI-encoded call data is output, one line per deposit.  If --transaction is supplied then an unsigned transaction to the deposit contract is output for each deposit instead, in JSON format suitable for signing elsewhere.  The chain ID of the transaction is obtained from --chain-id, the execution node supplied with --execution-connection, or the fork version of the deposit data, in that order.  If --execution-connection is supplied the transactions also include suggested fees.  The nonce is not included and should be set by the signer.
+
+Nothing is signed or broadcast by this command.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := depositcalldata.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	depositCmd.AddCommand(depositCalldataCmd)
+	depositFlags(depositCalldataCmd)
+	depositCalldataCmd.Flags().String("data", "", "the deposit data, as JSON or the name of a file containing it")
+	depositCalldataCmd.Flags().Bool("transaction", false, "output unsigned transactions rather than call data")
+	depositCalldataCmd.Flags().Uint64("chain-id", 0, "the chain ID for the transactions (default obtained from the execution node or deposit data)")
+}
+
+func depositCalldataBindings() {
+	if err := viper.BindPFlag("data", depositCalldataCmd.Flags().Lookup("data")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("transaction", depositCalldataCmd.Flags().Lookup("transaction")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("chain-id", depositCalldataCmd.Flags().Lookup("chain-id")); err != nil {
+		panic(err)
+	}
+}
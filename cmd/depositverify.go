@@ -15,6 +15,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"os"
@@ -23,6 +24,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	eth2util "github.com/wealdtech/go-eth2-util"
@@ -35,6 +37,7 @@ var depositVerifyWithdrawalAddress string
 var depositVerifyValidatorPubKey string
 var depositVerifyDepositAmount string
 var depositVerifyForkVersion string
+var depositVerifyExecutionFromBlock uint64
 
 var depositVerifyCmd = &cobra.Command{
 	Use:   "verify",
@@ -45,6 +48,8 @@ var depositVerifyCmd = &cobra.Command{
 
 The deposit data is compared to the supplied withdrawal account/public key, validator public key, and value to ensure they match.
 
+If --execution-connection is supplied then the execution chain is also checked to ensure that each deposit has been made to the deposit contract.
+
 In quiet mode this will return 0 if the the data is verified correctly, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		assert(depositVerifyData != "", "--data is required")
@@ -90,7 +95,7 @@ In quiet mode this will return 0 if the the data is verified correctly, otherwis
 			withdrawalCredentials[0] = 0x01 // ETH1_ADDRESS_WITHDRAWAL_PREFIX
 			copy(withdrawalCredentials[12:], withdrawalAddressBytes)
 		}
-		outputIf(debug, fmt.Sprintf("Withdrawal credentials are %#x", withdrawalCredentials))
+		util.Log.Debug().Str("withdrawal_credentials", fmt.Sprintf("%#x", withdrawalCredentials)).Msg("Obtained withdrawal credentials")
 
 		depositAmount := uint64(0)
 		if depositVerifyDepositAmount != "" {
@@ -105,6 +110,16 @@ In quiet mode this will return 0 if the the data is verified correctly, otherwis
 			errCheck(err, "Failed to obtain validator public key(s))")
 		}
 
+		var executionDeposits []*util.ExecutionDeposit
+		if viper.GetString("execution-connection") != "" {
+			ctx := context.Background()
+			executionClient, err := util.ConnectToExecutionNode(ctx, viper.GetString("execution-connection"), viper.GetDuration("timeout"), viper.GetBool("allow-insecure-connections"))
+			errCheck(err, "Failed to connect to execution node")
+			executionDeposits, err = executionClient.Deposits(ctx, depositVerifyExecutionFromBlock)
+			errCheck(err, "Failed to obtain deposits from execution node")
+			util.Log.Debug().Int("deposits", len(executionDeposits)).Msg("Obtained deposits from execution node")
+		}
+
 		failures := false
 		for _, deposit := range deposits {
 			if deposit.Amount == 0 {
@@ -116,6 +131,15 @@ In quiet mode this will return 0 if the the data is verified correctly, otherwis
 			if depositName == "" {
 				depositName = "Deposit"
 			}
+			if verified && executionDeposits != nil {
+				executionDeposit := findExecutionDeposit(deposit, executionDeposits)
+				if executionDeposit == nil {
+					outputIf(!quiet, "Deposit NOT found on execution chain")
+					verified = false
+				} else {
+					outputIf(!quiet, fmt.Sprintf("Deposit found on execution chain in block %d (transaction %#x)", executionDeposit.BlockNumber, executionDeposit.TransactionHash))
+				}
+			}
 			if !verified {
 				failures = true
 				outputIf(!quiet, fmt.Sprintf("%s failed verification", depositName))
@@ -315,6 +339,20 @@ func verifyDeposit(deposit *util.DepositInfo, withdrawalCredentials []byte, vali
 	return true, nil
 }
 
+// findExecutionDeposit finds the deposit on the execution chain that matches the supplied deposit data.
+func findExecutionDeposit(deposit *util.DepositInfo, executionDeposits []*util.ExecutionDeposit) *util.ExecutionDeposit {
+	for _, executionDeposit := range executionDeposits {
+		if bytes.Equal(executionDeposit.PublicKey, deposit.PublicKey) &&
+			bytes.Equal(executionDeposit.WithdrawalCredentials, deposit.WithdrawalCredentials) &&
+			executionDeposit.Amount == deposit.Amount &&
+			bytes.Equal(executionDeposit.Signature, deposit.Signature) {
+			return executionDeposit
+		}
+	}
+
+	return nil
+}
+
 func init() {
 	depositCmd.AddCommand(depositVerifyCmd)
 	depositFlags(depositVerifyCmd)
@@ -324,4 +362,5 @@ func init() {
 	depositVerifyCmd.Flags().StringVar(&depositVerifyDepositAmount, "depositvalue", "32 Ether", "Value of the amount to be deposited")
 	depositVerifyCmd.Flags().StringVar(&depositVerifyValidatorPubKey, "validatorpubkey", "", "Public key(s) of the account(s) that will be carrying out validation")
 	depositVerifyCmd.Flags().StringVar(&depositVerifyForkVersion, "forkversion", "0x00000000", "Fork version of the chain of the deposit")
+	depositVerifyCmd.Flags().Uint64Var(&depositVerifyExecutionFromBlock, "execution-from-block", 0, "Execution block from which to search for deposits when using --execution-connection (defaults to the block in which the deposit contract was created)")
 }
@@ -0,0 +1,277 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dvtinfo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+const (
+	clusterTypeObol = "obol"
+	clusterTypeSSV  = "ssv"
+)
+
+// cluster is the information about validators held in a cluster file.
+type cluster struct {
+	Type       string
+	Name       string
+	Validators []*clusterValidator
+}
+
+// clusterValidator is a validator whose key is split between operators.
+type clusterValidator struct {
+	PubKey    phase0.BLSPubKey
+	Threshold int
+	Shares    []*clusterShare
+	// SharesValid is true if the shares combine to form the public key.
+	SharesValid bool
+}
+
+// clusterShare is the share of a validator key held by an operator.
+type clusterShare struct {
+	ID       uint64
+	Operator string
+	PubKey   phase0.BLSPubKey
+}
+
+// obolClusterLock is the subset of an Obol cluster lock file used here.
+type obolClusterLock struct {
+	Definition *struct {
+		Name      string `json:"name"`
+		Threshold int    `json:"threshold"`
+		Operators []*struct {
+			Address string `json:"address"`
+			ENR     string `json:"enr"`
+		} `json:"operators"`
+	} `json:"cluster_definition"`
+	Validators []*struct {
+		PubKey       string   `json:"distributed_public_key"`
+		PublicShares []string `json:"public_shares"`
+	} `json:"distributed_validators"`
+}
+
+// ssvKeyshares is the subset of an SSV keyshares file used here.
+type ssvKeyshares struct {
+	Shares []*ssvKeyshare
+	// Older keyshares files contain a single share at the top level.
+	ssvKeyshare
+}
+
+type ssvKeyshare struct {
+	Data *struct {
+		PublicKey string `json:"publicKey"`
+		Operators []*struct {
+			ID uint64 `json:"id"`
+		} `json:"operators"`
+	} `json:"data"`
+	Payload *struct {
+		OperatorIDs []uint64 `json:"operatorIds"`
+		SharesData  string   `json:"sharesData"`
+	} `json:"payload"`
+}
+
+// parseCluster parses an Obol cluster lock or SSV keyshares file.
+func parseCluster(data []byte) (*cluster, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.Wrap(err, "invalid JSON")
+	}
+
+	var res *cluster
+	var err error
+	switch {
+	case fields["cluster_definition"] != nil:
+		res, err = parseObolCluster(data)
+	case fields["shares"] != nil, fields["payload"] != nil:
+		res, err = parseSSVCluster(data)
+	default:
+		return nil, errors.New("unrecognised cluster file format; expected an Obol cluster lock or SSV keyshares file")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Validators) == 0 {
+		return nil, errors.New("cluster file contains no validators")
+	}
+
+	for _, validator := range res.Validators {
+		validator.SharesValid = sharesValid(validator)
+	}
+
+	return res, nil
+}
+
+func parseObolCluster(data []byte) (*cluster, error) {
+	var lock obolClusterLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, errors.Wrap(err, "invalid Obol cluster lock")
+	}
+	if lock.Definition == nil {
+		return nil, errors.New("cluster lock missing cluster definition")
+	}
+
+	res := &cluster{
+		Type:       clusterTypeObol,
+		Name:       lock.Definition.Name,
+		Validators: make([]*clusterValidator, 0, len(lock.Validators)),
+	}
+	for i, validatorData := range lock.Validators {
+		validator := &clusterValidator{
+			Threshold: lock.Definition.Threshold,
+			Shares:    make([]*clusterShare, 0, len(validatorData.PublicShares)),
+		}
+		if err := decodePubKey(validatorData.PubKey, &validator.PubKey); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid public key for validator %d", i))
+		}
+		if len(validatorData.PublicShares) != len(lock.Definition.Operators) {
+			return nil, fmt.Errorf("validator %d has %d shares but cluster has %d operators", i, len(validatorData.PublicShares), len(lock.Definition.Operators))
+		}
+		for j, shareData := range validatorData.PublicShares {
+			// Obol share IDs are the 1-based index of the operator.
+			share := &clusterShare{
+				ID:       uint64(j + 1),
+				Operator: lock.Definition.Operators[j].Address,
+			}
+			if share.Operator == "" {
+				share.Operator = lock.Definition.Operators[j].ENR
+			}
+			if err := decodePubKey(shareData, &share.PubKey); err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("invalid public share %d for validator %d", j, i))
+			}
+			validator.Shares = append(validator.Shares, share)
+		}
+		res.Validators = append(res.Validators, validator)
+	}
+
+	return res, nil
+}
+
+func parseSSVCluster(data []byte) (*cluster, error) {
+	var keyshares ssvKeyshares
+	if err := json.Unmarshal(data, &keyshares); err != nil {
+		return nil, errors.Wrap(err, "invalid SSV keyshares")
+	}
+	if len(keyshares.Shares) == 0 && keyshares.Data != nil {
+		keyshares.Shares = []*ssvKeyshare{&keyshares.ssvKeyshare}
+	}
+
+	res := &cluster{
+		Type:       clusterTypeSSV,
+		Validators: make([]*clusterValidator, 0, len(keyshares.Shares)),
+	}
+	for i, keyshare := range keyshares.Shares {
+		if keyshare.Data == nil || keyshare.Payload == nil {
+			return nil, fmt.Errorf("keyshare %d missing data or payload", i)
+		}
+		validator := &clusterValidator{}
+		if err := decodePubKey(keyshare.Data.PublicKey, &validator.PubKey); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid public key for keyshare %d", i))
+		}
+
+		operatorIDs := keyshare.Payload.OperatorIDs
+		if len(operatorIDs) == 0 {
+			for _, operator := range keyshare.Data.Operators {
+				operatorIDs = append(operatorIDs, operator.ID)
+			}
+		}
+		if len(operatorIDs) == 0 {
+			return nil, fmt.Errorf("keyshare %d has no operators", i)
+		}
+		sort.Slice(operatorIDs, func(a int, b int) bool {
+			return operatorIDs[a] < operatorIDs[b]
+		})
+		// SSV tolerates f faulty operators out of 3f+1.
+		validator.Threshold = len(operatorIDs) - (len(operatorIDs)-1)/3
+
+		// The shares data is the signature of the owner followed by the
+		// share public keys and the encrypted share private keys.
+		sharesData, err := hex.DecodeString(strings.TrimPrefix(keyshare.Payload.SharesData, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid shares data for keyshare %d", i))
+		}
+		offset := phase0.SignatureLength
+		if len(sharesData) < offset+len(operatorIDs)*phase0.PublicKeyLength {
+			return nil, fmt.Errorf("shares data for keyshare %d too short", i)
+		}
+		for j, operatorID := range operatorIDs {
+			// SSV share IDs are the 1-based index of the operator.
+			share := &clusterShare{
+				ID:       uint64(j + 1),
+				Operator: fmt.Sprintf("%d", operatorID),
+			}
+			copy(share.PubKey[:], sharesData[offset+j*phase0.PublicKeyLength:offset+(j+1)*phase0.PublicKeyLength])
+			validator.Shares = append(validator.Shares, share)
+		}
+		res.Validators = append(res.Validators, validator)
+	}
+
+	return res, nil
+}
+
+// sharesValid returns true if the first and last threshold shares of a
+// validator both recover the validator's public key.
+func sharesValid(validator *clusterValidator) bool {
+	if validator.Threshold <= 0 || validator.Threshold > len(validator.Shares) {
+		return false
+	}
+
+	for _, shares := range [][]*clusterShare{
+		validator.Shares[:validator.Threshold],
+		validator.Shares[len(validator.Shares)-validator.Threshold:],
+	} {
+		pubKeys := make([]bls.PublicKey, len(shares))
+		ids := make([]bls.ID, len(shares))
+		for i, share := range shares {
+			pubKeyBytes := make([]byte, len(share.PubKey))
+			copy(pubKeyBytes, share.PubKey[:])
+			if err := pubKeys[i].Deserialize(pubKeyBytes); err != nil {
+				return false
+			}
+			ids[i] = *util.BLSID(share.ID)
+		}
+		var pubKey bls.PublicKey
+		if err := pubKey.Recover(pubKeys, ids); err != nil {
+			return false
+		}
+		if !bytes.Equal(pubKey.Serialize(), validator.PubKey[:]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// decodePubKey decodes a hex public key.
+func decodePubKey(input string, pubKey *phase0.BLSPubKey) error {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return err
+	}
+	if len(data) != phase0.PublicKeyLength {
+		return errors.New("incorrect length")
+	}
+	copy(pubKey[:], data)
+
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dvtinfo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// testShares provides the public key and public shares of a validator
+// whose key is split in to the given number of shares.
+func testShares(t *testing.T, threshold int, shares int) (string, []string) {
+	t.Helper()
+
+	var key bls.SecretKey
+	require.NoError(t, key.SetHexString("25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"))
+	masterKeys := key.GetMasterSecretKey(threshold)
+	for i := 1; i < len(masterKeys); i++ {
+		require.NoError(t, masterKeys[i].SetHexString(fmt.Sprintf("1a2b3c4d5e6f%d", i)))
+	}
+
+	pubShares := make([]string, 0, shares)
+	for i := 1; i <= shares; i++ {
+		var share bls.SecretKey
+		require.NoError(t, share.Set(masterKeys, util.BLSID(uint64(i))))
+		pubShares = append(pubShares, fmt.Sprintf("0x%x", share.GetPublicKey().Serialize()))
+	}
+
+	return fmt.Sprintf("0x%x", key.GetPublicKey().Serialize()), pubShares
+}
+
+func obolLock(pubKey string, pubShares []string, threshold int, numOperators int) string {
+	operators := make([]string, 0, numOperators)
+	for i := 0; i < numOperators; i++ {
+		operators = append(operators, fmt.Sprintf(`{"address":"0x%040d","enr":"enr:-test%d"}`, i+1, i+1))
+	}
+
+	return fmt.Sprintf(`{"cluster_definition":{"name":"Test cluster","threshold":%d,"operators":[%s]},"distributed_validators":[{"distributed_public_key":"%s","public_shares":["%s"]}]}`,
+		threshold, strings.Join(operators, ","), pubKey, strings.Join(pubShares, `","`))
+}
+
+func ssvKeysharesFile(pubKey string, pubShares []string, operatorIDs string) string {
+	// Shares data is the owner signature, the share public keys and the encrypted keys.
+	sharesData := fmt.Sprintf("0x%0192d", 0)
+	for _, pubShare := range pubShares {
+		sharesData += strings.TrimPrefix(pubShare, "0x")
+	}
+	sharesData += "abcdef"
+
+	return fmt.Sprintf(`{"version":"v4.0.0","shares":[{"data":{"publicKey":"%s","operators":[]},"payload":{"operatorIds":[%s],"sharesData":"%s"}}]}`,
+		pubKey, operatorIDs, sharesData)
+}
+
+func TestParseCluster(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	obolPubKey, obolShares := testShares(t, 3, 4)
+	ssvPubKey, ssvShares := testShares(t, 3, 4)
+	_, otherShares := testShares(t, 2, 4)
+
+	tests := []struct {
+		name        string
+		input       string
+		clusterType string
+		threshold   int
+		operators   []string
+		sharesValid bool
+		err         string
+	}{
+		{
+			name:  "Invalid",
+			input: `[]`,
+			err:   "invalid JSON",
+		},
+		{
+			name:  "Unknown",
+			input: `{"version":"1"}`,
+			err:   "unrecognised cluster file format; expected an Obol cluster lock or SSV keyshares file",
+		},
+		{
+			name:  "ObolNoValidators",
+			input: `{"cluster_definition":{"threshold":3,"operators":[]},"distributed_validators":[]}`,
+			err:   "cluster file contains no validators",
+		},
+		{
+			name:  "ObolShareCountMismatch",
+			input: obolLock(obolPubKey, obolShares[:3], 3, 4),
+			err:   "validator 0 has 3 shares but cluster has 4 operators",
+		},
+		{
+			name:        "Obol",
+			input:       obolLock(obolPubKey, obolShares, 3, 4),
+			clusterType: clusterTypeObol,
+			threshold:   3,
+			operators: []string{
+				"0x0000000000000000000000000000000000000001",
+				"0x0000000000000000000000000000000000000002",
+				"0x0000000000000000000000000000000000000003",
+				"0x0000000000000000000000000000000000000004",
+			},
+			sharesValid: true,
+		},
+		{
+			name:        "ObolSharesInvalid",
+			input:       obolLock(obolPubKey, append([]string{otherShares[0]}, obolShares[1:]...), 3, 4),
+			clusterType: clusterTypeObol,
+			threshold:   3,
+			operators: []string{
+				"0x0000000000000000000000000000000000000001",
+				"0x0000000000000000000000000000000000000002",
+				"0x0000000000000000000000000000000000000003",
+				"0x0000000000000000000000000000000000000004",
+			},
+		},
+		{
+			name:        "SSV",
+			input:       ssvKeysharesFile(ssvPubKey, ssvShares, "200,17,45,301"),
+			clusterType: clusterTypeSSV,
+			threshold:   3,
+			operators:   []string{"17", "45", "200", "301"},
+			sharesValid: true,
+		},
+		{
+			name:  "SSVSharesDataShort",
+			input: ssvKeysharesFile(ssvPubKey, ssvShares[:3], "17,45,200,301"),
+			err:   "shares data for keyshare 0 too short",
+		},
+		{
+			name:  "SSVNoOperators",
+			input: ssvKeysharesFile(ssvPubKey, ssvShares, ""),
+			err:   "keyshare 0 has no operators",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseCluster([]byte(test.input))
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.clusterType, res.Type)
+			require.Len(t, res.Validators, 1)
+			validator := res.Validators[0]
+			require.Equal(t, test.threshold, validator.Threshold)
+			require.Equal(t, test.sharesValid, validator.SharesValid)
+			operators := make([]string, 0, len(validator.Shares))
+			for i, share := range validator.Shares {
+				require.Equal(t, uint64(i+1), share.ID)
+				operators = append(operators, share.Operator)
+			}
+			require.Equal(t, test.operators, operators)
+		})
+	}
+}
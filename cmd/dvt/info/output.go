@@ -0,0 +1,142 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dvtinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type jsonOutput struct {
+	Type       string                 `json:"type"`
+	Name       string                 `json:"name,omitempty"`
+	Validators []*validatorJSONOutput `json:"validators"`
+}
+
+type validatorJSONOutput struct {
+	PubKey      string             `json:"pubkey"`
+	Threshold   int                `json:"threshold"`
+	Operators   int                `json:"operators"`
+	SharesValid bool               `json:"shares_valid"`
+	Shares      []*shareJSONOutput `json:"shares"`
+	Index       string             `json:"index,omitempty"`
+	State       string             `json:"state,omitempty"`
+	Balance     string             `json:"balance,omitempty"`
+}
+
+type shareJSONOutput struct {
+	ID       uint64 `json:"id"`
+	Operator string `json:"operator"`
+	PubKey   string `json:"pubkey"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Type:       c.cluster.Type,
+		Name:       c.cluster.Name,
+		Validators: make([]*validatorJSONOutput, 0, len(c.cluster.Validators)),
+	}
+	for _, validator := range c.cluster.Validators {
+		validatorOutput := &validatorJSONOutput{
+			PubKey:      fmt.Sprintf("%#x", validator.PubKey),
+			Threshold:   validator.Threshold,
+			Operators:   len(validator.Shares),
+			SharesValid: validator.SharesValid,
+			Shares:      make([]*shareJSONOutput, 0, len(validator.Shares)),
+		}
+		for _, share := range validator.Shares {
+			validatorOutput.Shares = append(validatorOutput.Shares, &shareJSONOutput{
+				ID:       share.ID,
+				Operator: share.Operator,
+				PubKey:   fmt.Sprintf("%#x", share.PubKey),
+			})
+		}
+		if chainValidator, exists := c.validators[validator.PubKey]; exists {
+			validatorOutput.Index = fmt.Sprintf("%d", chainValidator.Index)
+			validatorOutput.State = chainValidator.Status.String()
+			validatorOutput.Balance = fmt.Sprintf("%d", chainValidator.Balance)
+		}
+		output.Validators = append(output.Validators, validatorOutput)
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	switch c.cluster.Type {
+	case clusterTypeObol:
+		builder.WriteString("Obol cluster")
+	case clusterTypeSSV:
+		builder.WriteString("SSV keyshares")
+	}
+	if c.cluster.Name != "" {
+		builder.WriteString(fmt.Sprintf(" %s", c.cluster.Name))
+	}
+	builder.WriteString(fmt.Sprintf(" with %d validators\n", len(c.cluster.Validators)))
+
+	for _, validator := range c.cluster.Validators {
+		builder.WriteString(fmt.Sprintf("Validator %#x\n", validator.PubKey))
+		builder.WriteString(fmt.Sprintf("  Threshold: %d/%d\n", validator.Threshold, len(validator.Shares)))
+		if !validator.SharesValid {
+			builder.WriteString("  Shares: do not combine to the validator public key\n")
+		}
+		c.outputChainText(&builder, c.validators[validator.PubKey])
+		for _, share := range validator.Shares {
+			builder.WriteString(fmt.Sprintf("  Share %d: operator %s", share.ID, share.Operator))
+			if c.verbose {
+				builder.WriteString(fmt.Sprintf(", public key %#x", share.PubKey))
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (c *command) outputChainText(builder *strings.Builder, validator *apiv1.Validator) {
+	if c.offline {
+		return
+	}
+	if validator == nil {
+		builder.WriteString("  Not known on chain\n")
+		return
+	}
+
+	builder.WriteString(fmt.Sprintf("  Index: %d\n", validator.Index))
+	builder.WriteString(fmt.Sprintf("  State: %s\n", validator.Status))
+	builder.WriteString(fmt.Sprintf("  Balance: %s\n", string2eth.GWeiToString(uint64(validator.Balance), true)))
+}
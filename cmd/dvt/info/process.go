@@ -0,0 +1,82 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dvtinfo
+
+import (
+	"context"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	data, err := os.ReadFile(c.clusterFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read cluster file")
+	}
+	c.cluster, err = parseCluster(data)
+	if err != nil {
+		return util.NewValidationError(errors.Wrap(err, "failed to parse cluster file"))
+	}
+
+	if c.offline {
+		return nil
+	}
+
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	return c.obtainValidators(ctx)
+}
+
+// obtainValidators obtains the on-chain information for the validators in
+// the cluster.  Validators that are not on chain are not included.
+func (c *command) obtainValidators(ctx context.Context) error {
+	pubKeys := make([]phase0.BLSPubKey, 0, len(c.cluster.Validators))
+	for _, validator := range c.cluster.Validators {
+		pubKeys = append(pubKeys, validator.PubKey)
+	}
+	validators, err := c.validatorsProvider.ValidatorsByPubKey(ctx, "head", pubKeys)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+	for _, validator := range validators {
+		if validator.Validator == nil {
+			continue
+		}
+		c.validators[validator.Validator.PublicKey] = validator
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	// Connect to the consensus node.
+	consensusClient, err := util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return util.NewConnectionError(errors.Wrap(err, "failed to connect to consensus node"))
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = consensusClient.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide validator information")
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	dvtinfo "github.com/wealdtech/ethdo/cmd/dvt/info"
+)
+
+var dvtInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Obtain information about the validators in a DVT cluster file",
+	Long: `Obtain information about the validators in a DVT cluster file, either an Obol cluster lock or an SSV keyshares file.  For example:
+
+    ethdo dvt info --cluster-file=cluster-lock.json
+
+For each validator the threshold, the operators holding key shares and the on-chain status of the validator are shown.  The shares are checked to ensure that they combine to form the validator's public key.
+
+In quiet mode this will return 0 if the cluster file can be parsed and the shares of all validators combine to form their public keys, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := dvtinfo.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	dvtCmd.AddCommand(dvtInfoCmd)
+	dvtFlags(dvtInfoCmd)
+	dvtInfoCmd.Flags().String("cluster-file", "", "Path to the Obol cluster lock or SSV keyshares file")
+	dvtInfoCmd.Flags().Bool("offline", false, "Do not obtain the on-chain status of the validators")
+	dvtInfoCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func dvtInfoBindings() {
+	if err := viper.BindPFlag("cluster-file", dvtInfoCmd.Flags().Lookup("cluster-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("offline", dvtInfoCmd.Flags().Lookup("offline")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", dvtInfoCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochflags
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.summary)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString("Epoch ")
+	builder.WriteString(fmt.Sprintf("%d:", c.summary.Epoch))
+
+	for _, committee := range c.summary.Committees {
+		builder.WriteString(fmt.Sprintf("\n  Committee %d:", committee.Index))
+		builder.WriteString(fmt.Sprintf("\n    Attested: %d/%d (%0.2f%%)", committee.Attested, committee.Validators, 100.0*float64(committee.Attested)/float64(committee.Validators)))
+		builder.WriteString(fmt.Sprintf("\n    Source timely: %d/%d (%0.2f%%)", committee.SourceTimely, committee.Validators, 100.0*float64(committee.SourceTimely)/float64(committee.Validators)))
+		builder.WriteString(fmt.Sprintf("\n    Target timely: %d/%d (%0.2f%%)", committee.TargetTimely, committee.Validators, 100.0*float64(committee.TargetTimely)/float64(committee.Validators)))
+		builder.WriteString(fmt.Sprintf("\n    Head timely: %d/%d (%0.2f%%)", committee.HeadTimely, committee.Validators, 100.0*float64(committee.HeadTimely)/float64(committee.Validators)))
+		if committee.Attested > 0 {
+			builder.WriteString(fmt.Sprintf("\n    Average inclusion delay: %0.2f", committee.AverageInclusionDelay))
+		}
+	}
+
+	return builder.String(), nil
+}
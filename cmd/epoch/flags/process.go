@@ -0,0 +1,259 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochflags
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// validatorFlags are the flags earned by a validator for its attestation in the epoch.
+type validatorFlags struct {
+	committee      phase0.CommitteeIndex
+	included       bool
+	inclusionDelay phase0.Slot
+	sourceTimely   bool
+	targetTimely   bool
+	headTimely     bool
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	err := c.setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.summary.Epoch, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse epoch")
+	}
+
+	committees, validators, err := c.committees(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Attestations for the epoch can be included anywhere from the second
+	// slot of the epoch to the first slot of the next-but-one epoch.
+	firstSlot := c.chainTime.FirstSlotOfEpoch(c.summary.Epoch) + 1
+	lastSlot := c.chainTime.FirstSlotOfEpoch(c.summary.Epoch + 2)
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+	if err := c.processSlots(ctx, firstSlot, lastSlot, committees, validators); err != nil {
+		return err
+	}
+
+	c.summary.Committees = summarise(validators)
+
+	return nil
+}
+
+// committees obtains the beacon committees for the epoch, and the initial
+// flags for each validator in them.
+func (c *command) committees(ctx context.Context) (
+	map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex,
+	map[phase0.ValidatorIndex]*validatorFlags,
+	error,
+) {
+	beaconCommittees, err := c.beaconCommitteesProvider.BeaconCommittees(ctx, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(c.summary.Epoch)))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to obtain beacon committees")
+	}
+
+	committees := make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+	validators := make(map[phase0.ValidatorIndex]*validatorFlags)
+	for _, beaconCommittee := range beaconCommittees {
+		if _, exists := committees[beaconCommittee.Slot]; !exists {
+			committees[beaconCommittee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+		}
+		committees[beaconCommittee.Slot][beaconCommittee.Index] = beaconCommittee.Validators
+		for _, index := range beaconCommittee.Validators {
+			validators[index] = &validatorFlags{
+				committee: beaconCommittee.Index,
+			}
+		}
+	}
+	if len(validators) == 0 {
+		return nil, nil, errors.New("no beacon committees for epoch")
+	}
+
+	return committees, validators, nil
+}
+
+func (c *command) processSlots(ctx context.Context,
+	firstSlot phase0.Slot,
+	lastSlot phase0.Slot,
+	committees map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex,
+	validators map[phase0.ValidatorIndex]*validatorFlags,
+) error {
+	// Need a cache of beacon block headers to reduce lookup times.
+	headersCache := util.NewBeaconBlockHeaderCache(c.beaconBlockHeadersProvider)
+
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		block, err := c.blocksProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if block == nil {
+			// No block at this slot; that's fine.
+			continue
+		}
+		attestations, err := block.Attestations()
+		if err != nil {
+			return err
+		}
+		for _, attestation := range attestations {
+			if c.chainTime.SlotToEpoch(attestation.Data.Slot) != c.summary.Epoch {
+				// Outside of this epoch's range.
+				continue
+			}
+			committee, exists := committees[attestation.Data.Slot][attestation.Data.Index]
+			if !exists {
+				return fmt.Errorf("no committee %d for slot %d", attestation.Data.Index, attestation.Data.Slot)
+			}
+			headCorrect, err := util.AttestationHeadCorrect(ctx, headersCache, attestation)
+			if err != nil {
+				return err
+			}
+			targetCorrect, err := util.AttestationTargetCorrect(ctx, headersCache, c.chainTime, attestation)
+			if err != nil {
+				return err
+			}
+			recordAttestation(validators, committee, attestation, slot, headCorrect, targetCorrect)
+		}
+	}
+
+	return nil
+}
+
+// recordAttestation records the flags earned by the validators in an
+// attestation included in the block at the given slot.
+func recordAttestation(validators map[phase0.ValidatorIndex]*validatorFlags,
+	committee []phase0.ValidatorIndex,
+	attestation *phase0.Attestation,
+	inclusionSlot phase0.Slot,
+	headCorrect bool,
+	targetCorrect bool,
+) {
+	inclusionDelay := inclusionSlot - attestation.Data.Slot
+	for i := uint64(0); i < attestation.AggregationBits.Len() && i < uint64(len(committee)); i++ {
+		if !attestation.AggregationBits.BitAt(i) {
+			continue
+		}
+		flags, exists := validators[committee[int(i)]]
+		if !exists {
+			continue
+		}
+		if !flags.included || inclusionDelay < flags.inclusionDelay {
+			flags.inclusionDelay = inclusionDelay
+		}
+		flags.included = true
+		// Flags not already earned can be earned by later inclusions, as long as they are timely.
+		if inclusionDelay <= 5 {
+			flags.sourceTimely = true
+		}
+		if targetCorrect && inclusionDelay <= 32 {
+			flags.targetTimely = true
+		}
+		if headCorrect && inclusionDelay == 1 {
+			flags.headTimely = true
+		}
+	}
+}
+
+// summarise generates the per-committee index statistics from the validator flags.
+func summarise(validators map[phase0.ValidatorIndex]*validatorFlags) []*committeeFlags {
+	summaries := make(map[phase0.CommitteeIndex]*committeeFlags)
+	totalInclusionDelays := make(map[phase0.CommitteeIndex]phase0.Slot)
+	for _, flags := range validators {
+		summary, exists := summaries[flags.committee]
+		if !exists {
+			summary = &committeeFlags{
+				Index: flags.committee,
+			}
+			summaries[flags.committee] = summary
+		}
+		summary.Validators++
+		if !flags.included {
+			continue
+		}
+		summary.Attested++
+		totalInclusionDelays[flags.committee] += flags.inclusionDelay
+		if flags.sourceTimely {
+			summary.SourceTimely++
+		}
+		if flags.targetTimely {
+			summary.TargetTimely++
+		}
+		if flags.headTimely {
+			summary.HeadTimely++
+		}
+	}
+
+	res := make([]*committeeFlags, 0, len(summaries))
+	for index, summary := range summaries {
+		if summary.Attested > 0 {
+			summary.AverageInclusionDelay = float64(totalInclusionDelays[index]) / float64(summary.Attested)
+		}
+		res = append(res, summary)
+	}
+	sort.Slice(res, func(i int, j int) bool {
+		return res[i].Index < res[j].Index
+	})
+
+	return res
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	c.beaconCommitteesProvider, isProvider = c.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon committees")
+	}
+	c.beaconBlockHeadersProvider, isProvider = c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon block headers")
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochflags
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	if os.Getenv("ETHDO_TEST_CONNECTION") == "" {
+		t.Skip("ETHDO_TEST_CONNECTION not configured; cannot run tests")
+	}
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":    "60s",
+				"epoch":      "-2",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			cmd, err := newCommand(context.Background())
+			require.NoError(t, err)
+			err = cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFlags(t *testing.T) {
+	validators := map[phase0.ValidatorIndex]*validatorFlags{
+		1: {committee: 0},
+		2: {committee: 0},
+		3: {committee: 0},
+		4: {committee: 1},
+		5: {committee: 1},
+	}
+
+	bits := bitfield.NewBitlist(3)
+	bits.SetBitAt(0, true)
+	bits.SetBitAt(1, true)
+	attestation := &phase0.Attestation{
+		AggregationBits: bits,
+		Data: &phase0.AttestationData{
+			Slot:  10,
+			Index: 0,
+		},
+	}
+	// Validators 1 and 2 included late with an incorrect head.
+	recordAttestation(validators, []phase0.ValidatorIndex{1, 2, 3}, attestation, 13, false, true)
+	// Validator 1 included again, with a correct head but not timely for head.
+	bits = bitfield.NewBitlist(3)
+	bits.SetBitAt(0, true)
+	attestation.AggregationBits = bits
+	recordAttestation(validators, []phase0.ValidatorIndex{1, 2, 3}, attestation, 12, true, true)
+
+	// Validator 4 included immediately with all correct.
+	bits = bitfield.NewBitlist(2)
+	bits.SetBitAt(0, true)
+	recordAttestation(validators, []phase0.ValidatorIndex{4, 5}, &phase0.Attestation{
+		AggregationBits: bits,
+		Data: &phase0.AttestationData{
+			Slot:  11,
+			Index: 1,
+		},
+	}, 12, true, true)
+
+	require.Equal(t, []*committeeFlags{
+		{
+			Index:                 0,
+			Validators:            3,
+			Attested:              2,
+			SourceTimely:          2,
+			TargetTimely:          2,
+			HeadTimely:            0,
+			AverageInclusionDelay: 2.5,
+		},
+		{
+			Index:                 1,
+			Validators:            2,
+			Attested:              1,
+			SourceTimely:          1,
+			TargetTimely:          1,
+			HeadTimely:            1,
+			AverageInclusionDelay: 1,
+		},
+	}, summarise(validators))
+}
@@ -35,9 +35,10 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Operation.
-	epoch      string
-	stream     bool
-	jsonOutput bool
+	epoch        string
+	stream       bool
+	jsonOutput   bool
+	outputFormat string
 
 	// Data access.
 	eth2Client                 eth2client.Service
@@ -106,6 +107,7 @@ func newCommand(ctx context.Context) (*command, error) {
 	c.epoch = viper.GetString("epoch")
 	c.stream = viper.GetBool("stream")
 	c.jsonOutput = viper.GetBool("json")
+	c.outputFormat = viper.GetString("output")
 
 	return c, nil
 }
@@ -18,6 +18,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/wealdtech/ethdo/util"
 )
 
 func (c *command) output(ctx context.Context) (string, error) {
@@ -25,6 +27,10 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
+	if c.outputFormat != "" {
+		return util.RenderOutput(c.outputFormat, c.summary)
+	}
+
 	if c.jsonOutput {
 		return c.outputJSON(ctx)
 	}
@@ -108,3 +114,53 @@ func (c *command) outputTxt(_ context.Context) (string, error) {
 
 	return builder.String(), nil
 }
+
+// TableHeaders provides the headers for table output.
+func (s *epochSummary) TableHeaders() []string {
+	return []string{"Metric", "Count", "Total", "Percentage"}
+}
+
+// TableRows provides the rows for table output.
+func (s *epochSummary) TableRows() [][]string {
+	proposedBlocks := 0
+	for _, proposal := range s.Proposals {
+		if proposal.Block {
+			proposedBlocks++
+		}
+	}
+
+	rows := [][]string{
+		tableRow("Proposals", proposedBlocks, len(s.Proposals)),
+		tableRow("Attestations", s.ParticipatingValidators, s.ActiveValidators),
+		tableRow("Source timely", s.SourceTimelyValidators, s.ActiveValidators),
+		tableRow("Target correct", s.TargetCorrectValidators, s.ActiveValidators),
+		tableRow("Target timely", s.TargetTimelyValidators, s.ActiveValidators),
+		tableRow("Head correct", s.HeadCorrectValidators, s.ActiveValidators),
+		tableRow("Head timely", s.HeadTimelyValidators, s.ActiveValidators),
+	}
+
+	if len(s.SyncCommittee) > 0 {
+		contributions := proposedBlocks * 512 // SYNC_COMMITTEE_SIZE
+		totalMissed := 0
+		for _, contribution := range s.SyncCommittee {
+			totalMissed += contribution.Missed
+		}
+		rows = append(rows, tableRow("Sync committees", contributions-totalMissed, contributions))
+	}
+
+	return rows
+}
+
+func tableRow(metric string, count int, total int) []string {
+	percentage := 0.0
+	if total > 0 {
+		percentage = 100.0 * float64(count) / float64(total)
+	}
+
+	return []string{
+		metric,
+		fmt.Sprintf("%d", count),
+		fmt.Sprintf("%d", total),
+		fmt.Sprintf("%0.2f%%", percentage),
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochsummary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputFormats(t *testing.T) {
+	summary := &epochSummary{
+		Epoch:     1,
+		FirstSlot: 32,
+		LastSlot:  63,
+		Proposals: []*epochProposal{
+			{Slot: 32, Proposer: 1, Block: true},
+			{Slot: 33, Proposer: 2, Block: false},
+		},
+		ActiveValidators:        4,
+		ParticipatingValidators: 3,
+		HeadCorrectValidators:   2,
+		HeadTimelyValidators:    2,
+		SourceTimelyValidators:  3,
+		TargetCorrectValidators: 3,
+		TargetTimelyValidators:  3,
+	}
+
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet:        true,
+				outputFormat: "table",
+				summary:      summary,
+			},
+		},
+		{
+			name: "JSON",
+			c: &command{
+				outputFormat: "json",
+				summary:      summary,
+			},
+			res: `{"epoch":1,"first_slot":32,"last_slot":63,"proposals":[{"slot":32,"proposer":1,"block":true},{"slot":33,"proposer":2,"block":false}],"sync_committees":null,"active_validators":4,"participating_validators":3,"head_correct_validators":2,"head_timely_validators":2,"source_timely_validators":3,"target_correct_validators":3,"target_timely_validators":3,"nonparticipating_validators":null}`,
+		},
+		{
+			name: "Table",
+			c: &command{
+				outputFormat: "table",
+				summary:      summary,
+			},
+			res: `Metric          Count  Total  Percentage
+Proposals       1      2      50.00%
+Attestations    3      4      75.00%
+Source timely   3      4      75.00%
+Target correct  3      4      75.00%
+Target timely   3      4      75.00%
+Head correct    2      4      50.00%
+Head timely     2      4      50.00%`,
+		},
+		{
+			name: "TableSyncCommittees",
+			c: &command{
+				outputFormat: "table",
+				summary: &epochSummary{
+					Proposals: []*epochProposal{
+						{Slot: 32, Proposer: 1, Block: true},
+					},
+					SyncCommittee: []*epochSyncCommittee{
+						{Index: 1, Missed: 1},
+						{Index: 2, Missed: 3},
+					},
+				},
+			},
+			res: `Metric           Count  Total  Percentage
+Proposals        1      1      100.00%
+Attestations     0      0      0.00%
+Source timely    0      0      0.00%
+Target correct   0      0      0.00%
+Target timely    0      0      0.00%
+Head correct     0      0      0.00%
+Head timely      0      0      0.00%
+Sync committees  508    512    99.22%`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
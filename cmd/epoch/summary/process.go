@@ -327,10 +327,12 @@ func (c *command) processSyncCommitteeDuties(ctx context.Context) error {
 func (c *command) setup(ctx context.Context) error {
 	var err error
 
-	// Connect to the client.
-	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
-	if err != nil {
-		return errors.Wrap(err, "failed to connect to beacon node")
+	// Connect to the client, unless a connection has been supplied.
+	if c.eth2Client == nil {
+		c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+		if err != nil {
+			return errors.Wrap(err, "failed to connect to beacon node")
+		}
 	}
 
 	c.chainTime, err = standardchaintime.New(ctx,
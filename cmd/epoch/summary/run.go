@@ -15,10 +15,13 @@ package epochsummary
 
 import (
 	"context"
+	"encoding/json"
 
+	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 )
 
 // Run runs the command.
@@ -27,7 +30,7 @@ func Run(cmd *cobra.Command) (string, error) {
 
 	c, err := newCommand(ctx)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to set up command")
+		return "", util.NewValidationError(errors.Wrap(err, "failed to set up command"))
 	}
 
 	// Further errors do not need a usage report.
@@ -48,3 +51,20 @@ func Run(cmd *cobra.Command) (string, error) {
 
 	return results, nil
 }
+
+// Summary obtains the summary of an epoch over an existing connection,
+// returning it as JSON.  The epoch is supplied in the same form as for the
+// command.
+func Summary(ctx context.Context, eth2Client eth2client.Service, epoch string) ([]byte, error) {
+	c := &command{
+		eth2Client: eth2Client,
+		epoch:      epoch,
+		summary:    &epochSummary{},
+	}
+
+	if err := c.process(ctx); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(c.summary)
+}
@@ -0,0 +1,77 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{},
+			err:  "timeout is required",
+		},
+		{
+			name: "EpochAndTimestamp",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"epoch":     "1",
+				"timestamp": "2020-12-01T12:00:23Z",
+			},
+			err: "only one of epoch and timestamp allowed",
+		},
+		{
+			name: "TimezoneInvalid",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"timezone": "Mars/Olympus_Mons",
+			},
+			err: `unknown timezone "Mars/Olympus_Mons"`,
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"epoch":    "1",
+				"timezone": "UTC",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type epochTimeJSON struct {
+	Epoch     string `json:"epoch"`
+	FirstSlot string `json:"first_slot"`
+	LastSlot  string `json:"last_slot"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&epochTimeJSON{
+		Epoch:     fmt.Sprintf("%d", c.resultEpoch),
+		FirstSlot: fmt.Sprintf("%d", c.firstSlot),
+		LastSlot:  fmt.Sprintf("%d", c.lastSlot),
+		StartTime: c.startTime.Format(time.RFC3339),
+		EndTime:   c.endTime.Format(time.RFC3339),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	if !c.verbose {
+		if c.timestamp != "" {
+			return fmt.Sprintf("%d", c.resultEpoch), nil
+		}
+		return c.startTime.String(), nil
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Epoch %d\n", c.resultEpoch))
+	builder.WriteString(fmt.Sprintf("  Slots: %d - %d\n", c.firstSlot, c.lastSlot))
+	builder.WriteString(fmt.Sprintf("  Start: %s\n", c.startTime))
+	builder.WriteString(fmt.Sprintf("  End: %s", c.endTime))
+
+	return builder.String(), nil
+}
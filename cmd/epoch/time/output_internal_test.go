@@ -0,0 +1,91 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	startTime := time.Unix(1606827863, 0).In(time.UTC)
+	endTime := time.Unix(1606828247, 0).In(time.UTC)
+
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet: true,
+			},
+		},
+		{
+			name: "Epoch",
+			c: &command{
+				resultEpoch: 10,
+				startTime:   startTime,
+				endTime:     endTime,
+			},
+			res: "2020-12-01 13:04:23 +0000 UTC",
+		},
+		{
+			name: "Timestamp",
+			c: &command{
+				timestamp:   "2020-12-01T13:05:00Z",
+				resultEpoch: 10,
+				startTime:   startTime,
+				endTime:     endTime,
+			},
+			res: "10",
+		},
+		{
+			name: "Verbose",
+			c: &command{
+				verbose:     true,
+				resultEpoch: 10,
+				firstSlot:   320,
+				lastSlot:    351,
+				startTime:   startTime,
+				endTime:     endTime,
+			},
+			res: "Epoch 10\n  Slots: 320 - 351\n  Start: 2020-12-01 13:04:23 +0000 UTC\n  End: 2020-12-01 13:10:47 +0000 UTC",
+		},
+		{
+			name: "JSON",
+			c: &command{
+				json:        true,
+				resultEpoch: 10,
+				firstSlot:   320,
+				lastSlot:    351,
+				startTime:   startTime,
+				endTime:     endTime,
+			},
+			res: `{"epoch":"10","first_slot":"320","last_slot":"351","start_time":"2020-12-01T13:04:23Z","end_time":"2020-12-01T13:10:47Z"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
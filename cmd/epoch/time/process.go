@@ -0,0 +1,79 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochtime
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	return c.calculate(ctx)
+}
+
+// calculate calculates the epoch and its times from the input.
+func (c *command) calculate(ctx context.Context) error {
+	if c.timestamp != "" {
+		timestamp, err := util.ParseTimestamp(c.timestamp, c.location)
+		if err != nil {
+			return err
+		}
+		if timestamp.Before(c.chainTime.GenesisTime()) {
+			return errors.New("timestamp prior to genesis")
+		}
+		c.resultEpoch = c.chainTime.TimestampToEpoch(timestamp)
+	} else {
+		var err error
+		c.resultEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.firstSlot = c.chainTime.FirstSlotOfEpoch(c.resultEpoch)
+	c.lastSlot = c.chainTime.FirstSlotOfEpoch(c.resultEpoch+1) - 1
+	c.startTime = c.chainTime.StartOfEpoch(c.resultEpoch).In(c.location)
+	c.endTime = c.chainTime.StartOfEpoch(c.resultEpoch + 1).In(c.location)
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/testing/mock"
+)
+
+func TestCalculate(t *testing.T) {
+	genesisTime := time.Unix(1606824023, 0)
+	chainTime, err := standardchaintime.New(context.Background(),
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		epoch     string
+		timestamp string
+		expected  phase0.Epoch
+		startTime time.Time
+		err       string
+	}{
+		{
+			name:      "Epoch",
+			epoch:     "10",
+			expected:  10,
+			startTime: genesisTime.Add(10 * 32 * 12 * time.Second),
+		},
+		{
+			name:      "Timestamp",
+			timestamp: "2020-12-01T13:05:00Z",
+			expected:  10,
+			startTime: genesisTime.Add(10 * 32 * 12 * time.Second),
+		},
+		{
+			name:      "TimestampUnix",
+			timestamp: "1606824023",
+			expected:  0,
+			startTime: genesisTime,
+		},
+		{
+			name:      "TimestampPreGenesis",
+			timestamp: "2020-11-01T00:00:00Z",
+			err:       "timestamp prior to genesis",
+		},
+		{
+			name:      "TimestampInvalid",
+			timestamp: "soon",
+			err:       `invalid timestamp "soon"; should be a Unix time or of the form YYYY-MM-DDTHH:MM:SS+ZZ:ZZ`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				epoch:     test.epoch,
+				timestamp: test.timestamp,
+				location:  time.UTC,
+				chainTime: chainTime,
+			}
+			err := c.calculate(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, c.resultEpoch)
+			require.Equal(t, phase0.Slot(uint64(test.expected)*32), c.firstSlot)
+			require.Equal(t, phase0.Slot(uint64(test.expected)*32+31), c.lastSlot)
+			require.True(t, test.startTime.Equal(c.startTime))
+			require.Equal(t, time.UTC, c.startTime.Location())
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	epochflags "github.com/wealdtech/ethdo/cmd/epoch/flags"
+)
+
+var epochFlagsCmd = &cobra.Command{
+	Use:   "flags",
+	Short: "Obtain attestation flag statistics for an epoch",
+	Long: `Obtain attestation flag statistics for each committee index in an epoch.  For example:
+
+    ethdo epoch flags --epoch=12345
+
+For each committee index this reports the fraction of validators that earned the timely source, target and head flags, and the average inclusion delay of their attestations.
+
+In quiet mode this will return 0 if information for the epoch is found, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := epochflags.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	epochCmd.AddCommand(epochFlagsCmd)
+	epochFlags(epochFlagsCmd)
+	epochFlagsCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func epochFlagsBindings(cmd *cobra.Command) {
+	epochBindings(cmd)
+	if err := viper.BindPFlag("json", epochFlagsCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	epochsummary "github.com/wealdtech/ethdo/cmd/epoch/summary"
@@ -38,7 +36,9 @@ In quiet mode this will return 0 if information for the epoch is found, otherwis
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -50,8 +50,8 @@ func init() {
 	epochSummaryCmd.Flags().Bool("json", false, "output data in JSON format")
 }
 
-func epochSummaryBindings() {
-	epochBindings()
+func epochSummaryBindings(cmd *cobra.Command) {
+	epochBindings(cmd)
 	if err := viper.BindPFlag("json", epochSummaryCmd.Flags().Lookup("json")); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,70 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	epochtime "github.com/wealdtech/ethdo/cmd/epoch/time"
+)
+
+var epochTimeCmd = &cobra.Command{
+	Use:   "time",
+	Short: "Obtain the time for an epoch, or the epoch at a time",
+	Long: `Obtain the time for an epoch.  For example:
+
+    ethdo epoch time --epoch=12345
+
+Alternatively, obtain the epoch at a given time.  For example:
+
+    ethdo epoch time --timestamp=2023-04-12T22:27:35Z
+
+In quiet mode this will return 0 if the epoch can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := epochtime.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	epochCmd.AddCommand(epochTimeCmd)
+	epochFlags(epochTimeCmd)
+	epochTimeCmd.Flags().String("timestamp", "", "the timestamp for which to obtain the epoch, as a Unix time or a date and time (format YYYY-MM-DDTHH:MM:SS+ZZ:ZZ)")
+	epochTimeCmd.Flags().String("timezone", "", "the timezone in which to output times, and in which to interpret timestamps without a timezone (default local)")
+	epochTimeCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func epochTimeBindings(cmd *cobra.Command) {
+	epochBindings(cmd)
+	if err := viper.BindPFlag("timestamp", epochTimeCmd.Flags().Lookup("timestamp")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("timezone", epochTimeCmd.Flags().Lookup("timezone")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", epochTimeCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
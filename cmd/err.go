@@ -14,21 +14,32 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 )
 
+// errorJSON is the structured form of an error, output when --error-json is set.
+type errorJSON struct {
+	Class    string `json:"class"`
+	ExitCode int    `json:"exit_code"`
+	Message  string `json:"message"`
+}
+
 // errCheck checks for an error and quits if it is present
 func errCheck(err error, msg string) {
 	if err != nil {
+		if msg != "" {
+			err = errors.Wrap(err, msg)
+		}
 		if !quiet {
-			if msg == "" {
-				fmt.Fprintf(os.Stderr, "%s\n", err.Error())
-			} else {
-				fmt.Fprintf(os.Stderr, "%s: %s\n", msg, err.Error())
-			}
+			outputError(err, "")
 		}
-		os.Exit(1)
+		exit(exitStatus(err))
 	}
 }
 
@@ -58,9 +69,32 @@ func assert(condition bool, msg string) {
 // die prints an error and quits
 func die(msg string) {
 	if msg != "" && !quiet {
-		fmt.Fprintf(os.Stderr, "%s\n", msg)
+		outputError(errors.New(msg), "")
+	}
+	exit(_exitFailure)
+}
+
+// outputError outputs an error to stderr, either as text with the given
+// prefix or, if --error-json is set, as a JSON object.
+func outputError(err error, prefix string) {
+	if viper.GetBool("error-json") {
+		data, jsonErr := json.Marshal(&errorJSON{
+			Class:    util.ErrorClass(err),
+			ExitCode: exitStatus(err),
+			Message:  err.Error(),
+		})
+		if jsonErr == nil {
+			fmt.Fprintln(os.Stderr, string(data))
+			return
+		}
 	}
-	os.Exit(_exitFailure)
+	fmt.Fprintf(os.Stderr, "%s%s\n", prefix, err.Error())
+}
+
+// exit outputs telemetry if enabled, and quits with the given status.
+func exit(status int) {
+	outputTelemetry()
+	os.Exit(status)
 }
 
 // warnCheck checks for an error and warns if it is present
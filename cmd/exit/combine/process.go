@@ -0,0 +1,52 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exitcombine
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(_ context.Context) error {
+	for _, file := range c.files {
+		coordination, err := util.ReadExitCoordination(file)
+		if err != nil {
+			return err
+		}
+		util.Log.Debug().Str("file", file).Int("signatures", len(coordination.Signatures)).Msg("Loaded partial signatures")
+		if c.coordination == nil {
+			c.coordination = coordination
+			continue
+		}
+		if err := c.coordination.Merge(coordination); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to merge %s", file))
+		}
+	}
+
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "Combining %d partial signatures for exit of validator %d at epoch %d\n", len(c.coordination.Signatures), c.coordination.Message.ValidatorIndex, c.coordination.Message.Epoch)
+	}
+
+	var err error
+	c.signedOperation, err = c.coordination.Combine()
+	if err != nil {
+		return util.NewValidationError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exitcombine
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// writeCoordination writes a coordination file containing partial
+// signatures from the given shares of a 2-of-3 key.
+func writeCoordination(t *testing.T, exit *phase0.VoluntaryExit, threshold uint32, ids []uint64) string {
+	t.Helper()
+
+	var key bls.SecretKey
+	require.NoError(t, key.SetHexString("25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"))
+	masterKeys := key.GetMasterSecretKey(2)
+	require.NoError(t, masterKeys[1].SetHexString("1a2b3c4d5e6f"))
+
+	pubKey := phase0.BLSPubKey{}
+	copy(pubKey[:], key.GetPublicKey().Serialize())
+	coordination := util.NewExitCoordination(exit, pubKey, phase0.Domain{0x04, 0x00, 0x00, 0x00, 0x01}, threshold)
+	signingRoot, err := coordination.SigningRoot()
+	require.NoError(t, err)
+
+	for _, id := range ids {
+		var share bls.SecretKey
+		require.NoError(t, share.Set(masterKeys, util.BLSID(id)))
+		signature := &util.PartialSignature{
+			ID: id,
+		}
+		copy(signature.PublicKey[:], share.GetPublicKey().Serialize())
+		copy(signature.Signature[:], share.SignByte(signingRoot[:]).Serialize())
+		require.NoError(t, coordination.AddSignature(signature))
+	}
+
+	path := filepath.Join(t.TempDir(), "exit-coordination.json")
+	require.NoError(t, coordination.Write(path))
+
+	return path
+}
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	exit := &phase0.VoluntaryExit{
+		Epoch:          194048,
+		ValidatorIndex: 12345,
+	}
+	otherExit := &phase0.VoluntaryExit{
+		Epoch:          194049,
+		ValidatorIndex: 12345,
+	}
+
+	tests := []struct {
+		name  string
+		files []string
+		err   string
+	}{
+		{
+			name:  "FileMissing",
+			files: []string{filepath.Join(t.TempDir(), "missing.json")},
+			err:   "failed to read exit coordination file",
+		},
+		{
+			name:  "BelowThreshold",
+			files: []string{writeCoordination(t, exit, 2, []uint64{1})},
+			err:   "1 partial signatures present but 2 required",
+		},
+		{
+			name:  "Single",
+			files: []string{writeCoordination(t, exit, 2, []uint64{1, 3})},
+		},
+		{
+			name: "Merged",
+			files: []string{
+				writeCoordination(t, exit, 2, []uint64{1}),
+				writeCoordination(t, exit, 0, []uint64{2}),
+			},
+		},
+		{
+			name: "DifferentExits",
+			files: []string{
+				writeCoordination(t, exit, 2, []uint64{1}),
+				writeCoordination(t, otherExit, 2, []uint64{2}),
+			},
+			err: "exit coordinations are for different exits",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				files: test.files,
+			}
+			err := c.process(context.Background())
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, exit, c.signedOperation.Message)
+		})
+	}
+}
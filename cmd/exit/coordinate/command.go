@@ -0,0 +1,111 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exitcoordinate
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	offline bool
+	json    bool
+
+	// Input.
+	account     string
+	privateKey  string
+	passphrases []string
+	shareID     uint64
+	validator   string
+	epoch       *phase0.Epoch
+	threshold   uint32
+	file        string
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Processing.
+	consensusClient consensusclient.Service
+	chainTime       chaintime.Service
+	chainInfo       *beacon.ChainInfo
+	shareAccount    e2wtypes.Account
+
+	// Output.
+	coordination *util.ExitCoordination
+	created      bool
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		offline:                  viper.GetBool("offline"),
+		json:                     viper.GetBool("json"),
+		account:                  viper.GetString("account"),
+		privateKey:               viper.GetString("private-key"),
+		passphrases:              util.GetPassphrases(),
+		shareID:                  viper.GetUint64("share-id"),
+		validator:                viper.GetString("validator"),
+		threshold:                viper.GetUint32("threshold"),
+		file:                     viper.GetString("file"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.account == "" && c.privateKey == "" {
+		return nil, errors.New("account or private key is required")
+	}
+	if c.account != "" && c.privateKey != "" {
+		return nil, errors.New("only one of account and private key allowed")
+	}
+	if c.privateKey != "" && c.shareID == 0 {
+		return nil, errors.New("share ID is required with private key")
+	}
+
+	if viper.GetString("epoch") != "" {
+		epoch, err := strconv.ParseUint(viper.GetString("epoch"), 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid epoch")
+		}
+		c.epoch = (*phase0.Epoch)(&epoch)
+	}
+
+	if c.file == "" {
+		return nil, errors.New("file is required")
+	}
+
+	return c, nil
+}
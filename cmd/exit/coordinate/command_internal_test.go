@@ -0,0 +1,118 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exitcoordinate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"account": "Test wallet/Test account",
+				"file":    "exit-coordination.json",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "AccountMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    "exit-coordination.json",
+			},
+			err: "account or private key is required",
+		},
+		{
+			name: "AccountAndPrivateKey",
+			vars: map[string]interface{}{
+				"timeout":     "5s",
+				"account":     "Test wallet/Test account",
+				"private-key": "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+				"file":        "exit-coordination.json",
+			},
+			err: "only one of account and private key allowed",
+		},
+		{
+			name: "PrivateKeyShareIDMissing",
+			vars: map[string]interface{}{
+				"timeout":     "5s",
+				"private-key": "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+				"file":        "exit-coordination.json",
+			},
+			err: "share ID is required with private key",
+		},
+		{
+			name: "EpochInvalid",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"account": "Test wallet/Test account",
+				"epoch":   "invalid",
+				"file":    "exit-coordination.json",
+			},
+			err: "invalid epoch: strconv.ParseUint: parsing \"invalid\": invalid syntax",
+		},
+		{
+			name: "FileMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"account": "Test wallet/Test account",
+			},
+			err: "file is required",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"account": "Test wallet/Test account",
+				"file":    "exit-coordination.json",
+			},
+		},
+		{
+			name: "GoodPrivateKey",
+			vars: map[string]interface{}{
+				"timeout":     "5s",
+				"private-key": "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+				"share-id":    "2",
+				"epoch":       "194048",
+				"file":        "exit-coordination.json",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exitcoordinate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.coordination)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.created {
+		builder.WriteString(fmt.Sprintf("Created %s for exit of validator %d at epoch %d\n", c.file, c.coordination.Message.ValidatorIndex, c.coordination.Message.Epoch))
+	}
+	builder.WriteString(fmt.Sprintf("Added partial signature for share %d", c.shareID))
+	if c.coordination.Threshold == 0 {
+		builder.WriteString(fmt.Sprintf("; %d partial signatures collected", len(c.coordination.Signatures)))
+	} else {
+		builder.WriteString(fmt.Sprintf("; %d of %d required partial signatures collected", len(c.coordination.Signatures), c.coordination.Threshold))
+	}
+
+	return builder.String(), nil
+}
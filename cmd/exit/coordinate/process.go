@@ -0,0 +1,244 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exitcoordinate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+var offlinePreparationFilename = "offline-preparation.json"
+
+func (c *command) process(ctx context.Context) error {
+	var err error
+	input := c.account
+	if input == "" {
+		input = c.privateKey
+	}
+	c.shareAccount, err = util.ParseAccount(ctx, input, c.passphrases, true)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain account")
+	}
+
+	if c.shareID == 0 {
+		c.shareID, err = util.ShareID(c.shareAccount)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain share ID; supply it with --share-id")
+		}
+	}
+	if c.threshold == 0 {
+		if thresholdProvider, isProvider := c.shareAccount.(e2wtypes.AccountSigningThresholdProvider); isProvider {
+			c.threshold = thresholdProvider.SigningThreshold()
+		}
+	}
+
+	if err := c.obtainCoordination(ctx); err != nil {
+		return err
+	}
+
+	return c.sign(ctx)
+}
+
+// obtainCoordination reads the coordination file if it exists, otherwise
+// creates a new coordination for the exit.
+func (c *command) obtainCoordination(ctx context.Context) error {
+	_, err := os.Stat(c.file)
+	switch {
+	case err == nil:
+		return c.readCoordination(ctx)
+	case os.IsNotExist(err):
+		return c.createCoordination(ctx)
+	default:
+		return errors.Wrap(err, "failed to access exit coordination file")
+	}
+}
+
+func (c *command) readCoordination(_ context.Context) error {
+	if c.validator != "" || c.epoch != nil {
+		return fmt.Errorf("%s already exists; validator and epoch are taken from it", c.file)
+	}
+
+	var err error
+	c.coordination, err = util.ReadExitCoordination(c.file)
+	if err != nil {
+		return err
+	}
+
+	// Ensure that the exit is for the validator of which this is a share.
+	if compositeProvider, isProvider := c.shareAccount.(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
+		if fmt.Sprintf("%#x", compositeProvider.CompositePublicKey().Marshal()) != fmt.Sprintf("%#x", c.coordination.PublicKey) {
+			return errors.New("exit coordination is for a validator that does not match the account")
+		}
+	}
+	if c.threshold != 0 && c.coordination.Threshold != 0 && c.threshold != c.coordination.Threshold {
+		return fmt.Errorf("threshold %d does not match threshold %d of exit coordination", c.threshold, c.coordination.Threshold)
+	}
+	if c.coordination.Threshold == 0 {
+		c.coordination.Threshold = c.threshold
+	}
+
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "Signing exit for validator %d at epoch %d from %s\n", c.coordination.Message.ValidatorIndex, c.coordination.Message.Epoch, c.file)
+	}
+
+	return nil
+}
+
+func (c *command) createCoordination(ctx context.Context) error {
+	validator := c.validator
+	if validator == "" {
+		compositeProvider, isProvider := c.shareAccount.(e2wtypes.AccountCompositePublicKeyProvider)
+		if !isProvider {
+			return errors.New("validator is required")
+		}
+		validator = fmt.Sprintf("%#x", compositeProvider.CompositePublicKey().Marshal())
+	}
+
+	if err := c.obtainChainInfo(ctx, validator); err != nil {
+		return err
+	}
+
+	validatorInfo, err := c.chainInfo.FetchValidatorInfo(ctx, validator)
+	if err != nil {
+		return err
+	}
+
+	epoch := c.chainInfo.Epoch
+	if c.epoch != nil {
+		epoch = *c.epoch
+	}
+
+	domain, err := c.generateDomain(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.coordination = util.NewExitCoordination(&phase0.VoluntaryExit{
+		Epoch:          epoch,
+		ValidatorIndex: validatorInfo.Index,
+	}, validatorInfo.Pubkey, domain, c.threshold)
+	c.created = true
+
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "Creating exit for validator %d at epoch %d in %s\n", validatorInfo.Index, epoch, c.file)
+	}
+
+	return nil
+}
+
+// obtainChainInfo obtains the chain information required to create the exit,
+// from the offline preparation file if present or else from the beacon node.
+func (c *command) obtainChainInfo(ctx context.Context, validator string) error {
+	data, err := os.ReadFile(offlinePreparationFilename)
+	if err == nil {
+		util.Log.Debug().Str("file", offlinePreparationFilename).Msg("Loading chain state")
+		c.chainInfo = &beacon.ChainInfo{}
+		if err := json.Unmarshal(data, c.chainInfo); err != nil {
+			return errors.Wrap(err, "failed to parse offline preparation file")
+		}
+		return nil
+	}
+
+	if c.offline {
+		return fmt.Errorf("%s is unavailable; this is required to have been previously generated using \"ethdo validator exit --prepare-offline\" on an online machine and be readable in the directory in which this command is being run", offlinePreparationFilename)
+	}
+
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.chainInfo, err = beacon.ObtainChainInfoFromNodeForValidators(ctx, c.consensusClient, c.chainTime, []string{validator})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *command) generateDomain(_ context.Context) (phase0.Domain, error) {
+	forkVersion, _, err := c.chainInfo.VoluntaryExitForkVersion()
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+	root, err := (&phase0.ForkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: c.chainInfo.GenesisValidatorsRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return phase0.Domain{}, errors.Wrap(err, "failed to calculate signature domain")
+	}
+
+	var domain phase0.Domain
+	copy(domain[:], c.chainInfo.VoluntaryExitDomainType[:])
+	copy(domain[4:], root[:])
+	util.Log.Debug().Str("domain", fmt.Sprintf("%#x", domain)).Msg("Obtained domain")
+
+	return domain, nil
+}
+
+// sign adds the partial signature of the share to the coordination file.
+func (c *command) sign(ctx context.Context) error {
+	root, err := c.coordination.Message.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate root for exit operation")
+	}
+
+	signature, err := signing.SignRootShare(ctx, c.shareAccount, c.passphrases, root, c.coordination.Domain)
+	if err != nil {
+		return util.NewSigningError(errors.Wrap(err, "failed to sign exit operation"))
+	}
+
+	partialSignature := &util.PartialSignature{
+		ID:        c.shareID,
+		Signature: signature,
+	}
+	copy(partialSignature.PublicKey[:], c.shareAccount.PublicKey().Marshal())
+	if err := c.coordination.AddSignature(partialSignature); err != nil {
+		return err
+	}
+
+	return c.coordination.Write(c.file)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	// Connect to the consensus node.
+	var err error
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return util.NewConnectionError(errors.Wrap(err, "failed to connect to consensus node"))
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.consensusClient.(consensusclient.ForkScheduleProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exitsimulate
+
+import (
+	"context"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	operation string
+	stateFile string
+
+	// Processing.
+	consensusClient consensusclient.Service
+	params          *params
+	state           *simState
+	exit            *phase0.SignedVoluntaryExit
+
+	// Output.
+	checks             []*check
+	exitEpoch          phase0.Epoch
+	withdrawableEpoch  phase0.Epoch
+	exitQueueIncreased bool
+}
+
+type check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:     viper.GetBool("quiet"),
+		verbose:   viper.GetBool("verbose"),
+		debug:     viper.GetBool("debug"),
+		json:      viper.GetBool("json"),
+		operation: viper.GetString("operation"),
+		stateFile: viper.GetString("state"),
+		params:    defaultParams(),
+		checks:    make([]*check, 0),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if c.operation == "" {
+		return nil, errors.New("operation is required")
+	}
+
+	return c, nil
+}
+
+// passed returns true if all checks passed.
+func (c *command) passed() bool {
+	for _, check := range c.checks {
+		if !check.Passed {
+			return false
+		}
+	}
+
+	return true
+}
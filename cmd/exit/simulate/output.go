@@ -0,0 +1,86 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exitsimulate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+type jsonOutput struct {
+	StateSlot         phase0.Slot  `json:"state_slot"`
+	Accepted          bool         `json:"accepted"`
+	Checks            []*check     `json:"checks"`
+	ExitEpoch         phase0.Epoch `json:"exit_epoch,omitempty"`
+	WithdrawableEpoch phase0.Epoch `json:"withdrawable_epoch,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		StateSlot:         c.state.slot,
+		Accepted:          c.passed(),
+		Checks:            c.checks,
+		ExitEpoch:         c.exitEpoch,
+		WithdrawableEpoch: c.withdrawableEpoch,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Simulating exit against state at slot %d (epoch %d)\n", c.state.slot, c.state.currentEpoch(c.params)))
+	for _, check := range c.checks {
+		if check.Passed {
+			builder.WriteString(fmt.Sprintf("%s: passed", check.Name))
+			if c.verbose && check.Detail != "" {
+				builder.WriteString(fmt.Sprintf(" (%s)", check.Detail))
+			}
+		} else {
+			builder.WriteString(fmt.Sprintf("%s: FAILED (%s)", check.Name, check.Detail))
+		}
+		builder.WriteString("\n")
+	}
+
+	if !c.passed() {
+		builder.WriteString("Exit would be rejected")
+		return builder.String(), nil
+	}
+
+	builder.WriteString(fmt.Sprintf("Exit would be accepted; validator would exit at epoch %d and be withdrawable at epoch %d", c.exitEpoch, c.withdrawableEpoch))
+	if c.verbose && c.exitQueueIncreased {
+		builder.WriteString("\nExit queue churn limit reached; exit epoch is one later than otherwise")
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,157 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exitsimulate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	data, err := os.ReadFile(c.operation)
+	if err != nil {
+		return errors.Wrap(err, "failed to read exit operation")
+	}
+	c.exit = &phase0.SignedVoluntaryExit{}
+	if err := json.Unmarshal(data, c.exit); err != nil {
+		return errors.Wrap(err, "invalid exit operation")
+	}
+	if c.exit.Message == nil {
+		return errors.New("exit operation missing message")
+	}
+
+	state, err := c.obtainState(ctx)
+	if err != nil {
+		return err
+	}
+	c.state, err = newSimState(state)
+	if err != nil {
+		return err
+	}
+
+	return c.simulate()
+}
+
+// obtainState obtains the state against which to simulate the exit, either
+// from a local file or from the beacon node.
+func (c *command) obtainState(ctx context.Context) (*spec.VersionedBeaconState, error) {
+	if c.stateFile != "" {
+		if _, err := os.Stat(c.stateFile); err == nil {
+			// Use the local copy of the state, without contacting a beacon node.
+			data, err := os.ReadFile(c.stateFile)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read state")
+			}
+			return parseState(data)
+		}
+	}
+
+	var err error
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	specData, err := c.consensusClient.(consensusclient.SpecProvider).Spec(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+	if err := paramsFromSpec(c.params, specData); err != nil {
+		return nil, err
+	}
+
+	state, err := c.consensusClient.(consensusclient.BeaconStateProvider).BeaconState(ctx, "head")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain state")
+	}
+	if state == nil {
+		return nil, errors.New("state not returned by beacon node")
+	}
+
+	if c.stateFile != "" {
+		data, err := stateSSZ(state)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(c.stateFile, data, 0o600); err != nil {
+			return nil, errors.Wrap(err, "failed to write state")
+		}
+	}
+
+	return state, nil
+}
+
+// stateSSZ returns the SSZ encoding of a versioned beacon state.
+func stateSSZ(state *spec.VersionedBeaconState) ([]byte, error) {
+	var data []byte
+	var err error
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		data, err = state.Phase0.MarshalSSZ()
+	case spec.DataVersionAltair:
+		data, err = state.Altair.MarshalSSZ()
+	case spec.DataVersionBellatrix:
+		data, err = state.Bellatrix.MarshalSSZ()
+	case spec.DataVersionCapella:
+		data, err = state.Capella.MarshalSSZ()
+	default:
+		return nil, fmt.Errorf("unhandled beacon state version %v", state.Version)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode state")
+	}
+
+	return data, nil
+}
+
+// parseState decodes an SSZ beacon state, trying the most recent version first.
+func parseState(data []byte) (*spec.VersionedBeaconState, error) {
+	capellaState := &capella.BeaconState{}
+	if err := capellaState.UnmarshalSSZ(data); err == nil {
+		return &spec.VersionedBeaconState{Version: spec.DataVersionCapella, Capella: capellaState}, nil
+	}
+	bellatrixState := &bellatrix.BeaconState{}
+	if err := bellatrixState.UnmarshalSSZ(data); err == nil {
+		return &spec.VersionedBeaconState{Version: spec.DataVersionBellatrix, Bellatrix: bellatrixState}, nil
+	}
+	altairState := &altair.BeaconState{}
+	if err := altairState.UnmarshalSSZ(data); err == nil {
+		return &spec.VersionedBeaconState{Version: spec.DataVersionAltair, Altair: altairState}, nil
+	}
+	phase0State := &phase0.BeaconState{}
+	if err := phase0State.UnmarshalSSZ(data); err == nil {
+		return &spec.VersionedBeaconState{Version: spec.DataVersionPhase0, Phase0: phase0State}, nil
+	}
+
+	return nil, errors.New("failed to decode state")
+}
+
+func (c *command) addCheck(name string, passed bool, detail string) {
+	c.checks = append(c.checks, &check{
+		Name:   name,
+		Passed: passed,
+		Detail: detail,
+	})
+}
@@ -0,0 +1,294 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exitsimulate
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/wealdtech/ethdo/beacon"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// farFutureEpoch is the epoch used to denote an unset epoch.
+const farFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+// params are the spec values used when processing a voluntary exit.
+type params struct {
+	slotsPerEpoch                    uint64
+	shardCommitteePeriod             uint64
+	maxSeedLookahead                 uint64
+	minValidatorWithdrawabilityDelay uint64
+	voluntaryExitDomainType          phase0.DomainType
+	// churnSpec contains the spec values used to calculate the churn.
+	churnSpec map[string]interface{}
+}
+
+// defaultParams returns the mainnet values, which are shared by all public networks.
+func defaultParams() *params {
+	return &params{
+		slotsPerEpoch:                    32,
+		shardCommitteePeriod:             256,
+		maxSeedLookahead:                 4,
+		minValidatorWithdrawabilityDelay: 256,
+		voluntaryExitDomainType:          phase0.DomainType{0x04, 0x00, 0x00, 0x00},
+		churnSpec: map[string]interface{}{
+			"MIN_PER_EPOCH_CHURN_LIMIT": uint64(4),
+			"CHURN_LIMIT_QUOTIENT":      uint64(65536),
+			"MAX_EFFECTIVE_BALANCE":     uint64(32000000000),
+		},
+	}
+}
+
+// paramsFromSpec overrides the parameters with those supplied by the spec.
+func paramsFromSpec(p *params, specData map[string]interface{}) error {
+	for name, field := range map[string]*uint64{
+		"SLOTS_PER_EPOCH":                     &p.slotsPerEpoch,
+		"SHARD_COMMITTEE_PERIOD":              &p.shardCommitteePeriod,
+		"MAX_SEED_LOOKAHEAD":                  &p.maxSeedLookahead,
+		"MIN_VALIDATOR_WITHDRAWABILITY_DELAY": &p.minValidatorWithdrawabilityDelay,
+	} {
+		tmp, exists := specData[name]
+		if !exists {
+			// Keep the default.
+			continue
+		}
+		val, good := tmp.(uint64)
+		if !good {
+			return fmt.Errorf("%s value invalid", name)
+		}
+		*field = val
+	}
+
+	if tmp, exists := specData["DOMAIN_VOLUNTARY_EXIT"]; exists {
+		domainType, good := tmp.(phase0.DomainType)
+		if !good {
+			return errors.New("DOMAIN_VOLUNTARY_EXIT value invalid")
+		}
+		p.voluntaryExitDomainType = domainType
+	}
+
+	// The churn calculation obtains its own values from the spec, so keep it
+	// all; the defaults are used only if the state is read from a file.
+	p.churnSpec = specData
+
+	return nil
+}
+
+// simState is the subset of the beacon state required to process a voluntary exit.
+type simState struct {
+	slot                  phase0.Slot
+	fork                  *phase0.Fork
+	genesisValidatorsRoot phase0.Root
+	validators            []*phase0.Validator
+}
+
+// newSimState extracts the required information from a versioned beacon state.
+func newSimState(state *spec.VersionedBeaconState) (*simState, error) {
+	res := &simState{}
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		res.slot = state.Phase0.Slot
+		res.fork = state.Phase0.Fork
+		res.genesisValidatorsRoot = state.Phase0.GenesisValidatorsRoot
+		res.validators = state.Phase0.Validators
+	case spec.DataVersionAltair:
+		res.slot = state.Altair.Slot
+		res.fork = state.Altair.Fork
+		res.genesisValidatorsRoot = state.Altair.GenesisValidatorsRoot
+		res.validators = state.Altair.Validators
+	case spec.DataVersionBellatrix:
+		res.slot = state.Bellatrix.Slot
+		res.fork = state.Bellatrix.Fork
+		res.genesisValidatorsRoot = state.Bellatrix.GenesisValidatorsRoot
+		res.validators = state.Bellatrix.Validators
+	case spec.DataVersionCapella:
+		res.slot = state.Capella.Slot
+		res.fork = state.Capella.Fork
+		res.genesisValidatorsRoot = state.Capella.GenesisValidatorsRoot
+		res.validators = state.Capella.Validators
+	default:
+		return nil, fmt.Errorf("unhandled beacon state version %v", state.Version)
+	}
+	if res.fork == nil {
+		return nil, errors.New("state does not contain fork information")
+	}
+
+	return res, nil
+}
+
+// currentEpoch is the spec's get_current_epoch().
+func (s *simState) currentEpoch(p *params) phase0.Epoch {
+	return phase0.Epoch(uint64(s.slot) / p.slotsPerEpoch)
+}
+
+// simulate applies the checks of the spec's process_voluntary_exit() in order,
+// stopping at the first failure as a client would.  If all checks pass the exit
+// is initiated, setting the exit and withdrawable epochs.
+func (c *command) simulate() error {
+	message := c.exit.Message
+	currentEpoch := c.state.currentEpoch(c.params)
+
+	name := "Validator exists"
+	if uint64(message.ValidatorIndex) >= uint64(len(c.state.validators)) {
+		c.addCheck(name, false, fmt.Sprintf("state has %d validators", len(c.state.validators)))
+		return nil
+	}
+	c.addCheck(name, true, fmt.Sprintf("validator %d", message.ValidatorIndex))
+	validator := c.state.validators[message.ValidatorIndex]
+
+	name = "Validator is active"
+	if validator.ActivationEpoch > currentEpoch || currentEpoch >= validator.ExitEpoch {
+		c.addCheck(name, false, fmt.Sprintf("validator has activation epoch %d and exit epoch %s at epoch %d", validator.ActivationEpoch, epochString(validator.ExitEpoch), currentEpoch))
+		return nil
+	}
+	c.addCheck(name, true, fmt.Sprintf("activated at epoch %d", validator.ActivationEpoch))
+
+	name = "Exit has not been initiated"
+	if validator.ExitEpoch != farFutureEpoch {
+		c.addCheck(name, false, fmt.Sprintf("validator already exiting at epoch %d", validator.ExitEpoch))
+		return nil
+	}
+	c.addCheck(name, true, "")
+
+	name = "Exit epoch has been reached"
+	if currentEpoch < message.Epoch {
+		c.addCheck(name, false, fmt.Sprintf("exit is not valid until epoch %d; state is at epoch %d", message.Epoch, currentEpoch))
+		return nil
+	}
+	c.addCheck(name, true, fmt.Sprintf("epoch %d", message.Epoch))
+
+	name = "Validator has been active long enough"
+	if uint64(currentEpoch) < uint64(validator.ActivationEpoch)+c.params.shardCommitteePeriod {
+		c.addCheck(name, false, fmt.Sprintf("validator cannot exit until epoch %d", uint64(validator.ActivationEpoch)+c.params.shardCommitteePeriod))
+		return nil
+	}
+	c.addCheck(name, true, "")
+
+	name = "Exit signature is valid"
+	forkVersion := c.state.fork.CurrentVersion
+	if message.Epoch < c.state.fork.Epoch {
+		forkVersion = c.state.fork.PreviousVersion
+	}
+	if err := verifySignature(c.exit, validator.PublicKey, c.params.voluntaryExitDomainType, forkVersion, c.state.genesisValidatorsRoot); err != nil {
+		c.addCheck(name, false, fmt.Sprintf("%v with fork version %#x", err, forkVersion))
+		return nil
+	}
+	c.addCheck(name, true, fmt.Sprintf("signed with fork version %#x", forkVersion))
+
+	return c.initiateExit()
+}
+
+// initiateExit is the spec's initiate_validator_exit(), calculating where the
+// validator would enter the exit queue.  The state is at most Capella, so the
+// churn is the number of validators that can exit per epoch.
+func (c *command) initiateExit() error {
+	currentEpoch := c.state.currentEpoch(c.params)
+
+	exitQueueEpoch := currentEpoch + 1 + phase0.Epoch(c.params.maxSeedLookahead)
+	activeValidators := uint64(0)
+	totalActiveBalance := phase0.Gwei(0)
+	for _, validator := range c.state.validators {
+		if validator.ExitEpoch != farFutureEpoch && validator.ExitEpoch > exitQueueEpoch {
+			exitQueueEpoch = validator.ExitEpoch
+		}
+		if validator.ActivationEpoch <= currentEpoch && currentEpoch < validator.ExitEpoch {
+			activeValidators++
+			totalActiveBalance += validator.EffectiveBalance
+		}
+	}
+	exitQueueChurn := uint64(0)
+	for _, validator := range c.state.validators {
+		if validator.ExitEpoch == exitQueueEpoch {
+			exitQueueChurn++
+		}
+	}
+	churn, err := beacon.CalculateChurn(c.params.churnSpec, currentEpoch, activeValidators, totalActiveBalance)
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate churn")
+	}
+	if churn.Electra {
+		return errors.New("balance-based exit queue not supported")
+	}
+	if exitQueueChurn >= churn.ExitChurn {
+		exitQueueEpoch++
+		c.exitQueueIncreased = true
+	}
+
+	c.exitEpoch = exitQueueEpoch
+	c.withdrawableEpoch = exitQueueEpoch + phase0.Epoch(c.params.minValidatorWithdrawabilityDelay)
+
+	return nil
+}
+
+// verifySignature verifies the signature of a voluntary exit.
+func verifySignature(exit *phase0.SignedVoluntaryExit,
+	pubKey phase0.BLSPubKey,
+	domainType phase0.DomainType,
+	forkVersion phase0.Version,
+	genesisValidatorsRoot phase0.Root,
+) error {
+	forkDataRoot, err := (&phase0.ForkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate signature domain")
+	}
+	var domain phase0.Domain
+	copy(domain[:], domainType[:])
+	copy(domain[4:], forkDataRoot[:])
+
+	root, err := exit.Message.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate message root")
+	}
+	signingRoot, err := ssz.HashTreeRoot(&phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to generate signing root")
+	}
+
+	pubKeyBytes := make([]byte, len(pubKey))
+	copy(pubKeyBytes, pubKey[:])
+	pubkey, err := e2types.BLSPublicKeyFromBytes(pubKeyBytes)
+	if err != nil {
+		return errors.Wrap(err, "invalid public key")
+	}
+	sigBytes := make([]byte, len(exit.Signature))
+	copy(sigBytes, exit.Signature[:])
+	sig, err := e2types.BLSSignatureFromBytes(sigBytes)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	if !sig.Verify(signingRoot[:], pubkey) {
+		return errors.New("signature does not verify")
+	}
+
+	return nil
+}
+
+// epochString returns a printable version of an epoch.
+func epochString(epoch phase0.Epoch) string {
+	if epoch == farFutureEpoch {
+		return "not set"
+	}
+
+	return fmt.Sprintf("%d", epoch)
+}
@@ -0,0 +1,313 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exitsimulate
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func validatorWith(activationEpoch phase0.Epoch, exitEpoch phase0.Epoch) *phase0.Validator {
+	return &phase0.Validator{
+		ActivationEpoch:   activationEpoch,
+		ExitEpoch:         exitEpoch,
+		WithdrawableEpoch: farFutureEpoch,
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	// State is at epoch 1000.
+	state := &simState{
+		slot: 32000,
+		fork: &phase0.Fork{
+			PreviousVersion: phase0.Version{0x02, 0x00, 0x00, 0x00},
+			CurrentVersion:  phase0.Version{0x03, 0x00, 0x00, 0x00},
+			Epoch:           500,
+		},
+		validators: []*phase0.Validator{
+			validatorWith(0, farFutureEpoch),
+			validatorWith(900, farFutureEpoch),
+			validatorWith(0, 1010),
+			validatorWith(1200, farFutureEpoch),
+		},
+	}
+
+	tests := []struct {
+		name   string
+		exit   *phase0.VoluntaryExit
+		checks int
+		failed string
+	}{
+		{
+			name: "UnknownValidator",
+			exit: &phase0.VoluntaryExit{
+				ValidatorIndex: 10,
+				Epoch:          1000,
+			},
+			checks: 1,
+			failed: "Validator exists",
+		},
+		{
+			name: "NotActive",
+			exit: &phase0.VoluntaryExit{
+				ValidatorIndex: 3,
+				Epoch:          1000,
+			},
+			checks: 2,
+			failed: "Validator is active",
+		},
+		{
+			name: "AlreadyExiting",
+			exit: &phase0.VoluntaryExit{
+				ValidatorIndex: 2,
+				Epoch:          1000,
+			},
+			checks: 3,
+			failed: "Exit has not been initiated",
+		},
+		{
+			name: "FutureEpoch",
+			exit: &phase0.VoluntaryExit{
+				ValidatorIndex: 0,
+				Epoch:          1001,
+			},
+			checks: 4,
+			failed: "Exit epoch has been reached",
+		},
+		{
+			name: "RecentlyActivated",
+			exit: &phase0.VoluntaryExit{
+				ValidatorIndex: 1,
+				Epoch:          1000,
+			},
+			checks: 5,
+			failed: "Validator has been active long enough",
+		},
+		{
+			name: "BadSignature",
+			exit: &phase0.VoluntaryExit{
+				ValidatorIndex: 0,
+				Epoch:          1000,
+			},
+			checks: 6,
+			failed: "Exit signature is valid",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				params: defaultParams(),
+				state:  state,
+				exit: &phase0.SignedVoluntaryExit{
+					Message: test.exit,
+				},
+			}
+			require.NoError(t, c.simulate())
+			require.Len(t, c.checks, test.checks)
+			for i, check := range c.checks {
+				if i == len(c.checks)-1 {
+					require.Equal(t, test.failed, check.Name)
+					require.False(t, check.Passed)
+				} else {
+					require.True(t, check.Passed)
+				}
+			}
+			require.False(t, c.passed())
+			require.Equal(t, phase0.Epoch(0), c.exitEpoch)
+		})
+	}
+}
+
+func TestSimulateSignedExit(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	privateKey, err := e2types.BLSPrivateKeyFromBytes([]byte{
+		0x25, 0x29, 0x5f, 0x0d, 0x1d, 0x59, 0x2a, 0x90, 0xb3, 0x33, 0xe2, 0x6e, 0x85, 0x14, 0x97, 0x08,
+		0x20, 0x8e, 0x9f, 0x8e, 0x8b, 0xc1, 0x8f, 0x6c, 0x77, 0xbd, 0x62, 0xf8, 0xad, 0x7a, 0x68, 0x66,
+	})
+	require.NoError(t, err)
+	validator := validatorWith(0, farFutureEpoch)
+	copy(validator.PublicKey[:], privateKey.PublicKey().Marshal())
+
+	// State is at epoch 1000, after the fork at epoch 500.
+	state := &simState{
+		slot: 32000,
+		fork: &phase0.Fork{
+			PreviousVersion: phase0.Version{0x02, 0x00, 0x00, 0x00},
+			CurrentVersion:  phase0.Version{0x03, 0x00, 0x00, 0x00},
+			Epoch:           500,
+		},
+		genesisValidatorsRoot: phase0.Root{0x01},
+		validators:            []*phase0.Validator{validator},
+	}
+	params := defaultParams()
+
+	// sign signs an exit with the given fork version.
+	sign := func(epoch phase0.Epoch, forkVersion phase0.Version) *phase0.SignedVoluntaryExit {
+		exit := &phase0.SignedVoluntaryExit{
+			Message: &phase0.VoluntaryExit{
+				Epoch:          epoch,
+				ValidatorIndex: 0,
+			},
+		}
+		forkDataRoot, err := (&phase0.ForkData{
+			CurrentVersion:        forkVersion,
+			GenesisValidatorsRoot: state.genesisValidatorsRoot,
+		}).HashTreeRoot()
+		require.NoError(t, err)
+		var domain phase0.Domain
+		copy(domain[:], params.voluntaryExitDomainType[:])
+		copy(domain[4:], forkDataRoot[:])
+		root, err := exit.Message.HashTreeRoot()
+		require.NoError(t, err)
+		signingRoot, err := (&phase0.SigningData{
+			ObjectRoot: root,
+			Domain:     domain,
+		}).HashTreeRoot()
+		require.NoError(t, err)
+		copy(exit.Signature[:], privateKey.Sign(signingRoot[:]).Marshal())
+		return exit
+	}
+
+	tests := []struct {
+		name   string
+		exit   *phase0.SignedVoluntaryExit
+		passed bool
+	}{
+		{
+			name:   "Good",
+			exit:   sign(1000, state.fork.CurrentVersion),
+			passed: true,
+		},
+		{
+			name:   "GoodPreviousFork",
+			exit:   sign(400, state.fork.PreviousVersion),
+			passed: true,
+		},
+		{
+			name: "WrongFork",
+			exit: sign(1000, state.fork.PreviousVersion),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				params: params,
+				state:  state,
+				exit:   test.exit,
+			}
+			require.NoError(t, c.simulate())
+			require.Len(t, c.checks, 6)
+			require.Equal(t, "Exit signature is valid", c.checks[5].Name)
+			require.Equal(t, test.passed, c.passed())
+			if test.passed {
+				require.Equal(t, phase0.Epoch(1005), c.exitEpoch)
+				require.Equal(t, phase0.Epoch(1261), c.withdrawableEpoch)
+			} else {
+				require.Equal(t, phase0.Epoch(0), c.exitEpoch)
+			}
+		})
+	}
+}
+
+func TestInitiateExit(t *testing.T) {
+	tests := []struct {
+		name              string
+		validators        []*phase0.Validator
+		exitEpoch         phase0.Epoch
+		withdrawableEpoch phase0.Epoch
+		increased         bool
+	}{
+		{
+			name: "EmptyQueue",
+			validators: []*phase0.Validator{
+				validatorWith(0, farFutureEpoch),
+			},
+			exitEpoch:         1005,
+			withdrawableEpoch: 1261,
+		},
+		{
+			name: "QueueBelowChurn",
+			validators: []*phase0.Validator{
+				validatorWith(0, farFutureEpoch),
+				validatorWith(0, 1005),
+				validatorWith(0, 1005),
+				validatorWith(0, 1005),
+			},
+			exitEpoch:         1005,
+			withdrawableEpoch: 1261,
+		},
+		{
+			name: "QueueAtChurn",
+			validators: []*phase0.Validator{
+				validatorWith(0, farFutureEpoch),
+				validatorWith(0, 1005),
+				validatorWith(0, 1005),
+				validatorWith(0, 1005),
+				validatorWith(0, 1005),
+			},
+			exitEpoch:         1006,
+			withdrawableEpoch: 1262,
+			increased:         true,
+		},
+		{
+			name: "LaterQueue",
+			validators: []*phase0.Validator{
+				validatorWith(0, farFutureEpoch),
+				validatorWith(0, 1005),
+				validatorWith(0, 1100),
+			},
+			exitEpoch:         1100,
+			withdrawableEpoch: 1356,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				params: defaultParams(),
+				state: &simState{
+					slot:       32000,
+					validators: test.validators,
+				},
+			}
+			require.NoError(t, c.initiateExit())
+			require.Equal(t, test.exitEpoch, c.exitEpoch)
+			require.Equal(t, test.withdrawableEpoch, c.withdrawableEpoch)
+			require.Equal(t, test.increased, c.exitQueueIncreased)
+		})
+	}
+}
+
+func TestParamsFromSpec(t *testing.T) {
+	p := defaultParams()
+	require.NoError(t, paramsFromSpec(p, map[string]interface{}{
+		"SLOTS_PER_EPOCH":        uint64(8),
+		"SHARD_COMMITTEE_PERIOD": uint64(64),
+	}))
+	require.Equal(t, uint64(8), p.slotsPerEpoch)
+	require.Equal(t, uint64(64), p.shardCommitteePeriod)
+	require.Equal(t, uint64(8), p.churnSpec["SLOTS_PER_EPOCH"])
+
+	require.EqualError(t, paramsFromSpec(defaultParams(), map[string]interface{}{
+		"SLOTS_PER_EPOCH": "8",
+	}), "SLOTS_PER_EPOCH value invalid")
+}
@@ -0,0 +1,102 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exitverifyexternal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"file":               "exit.json",
+				"expected-validator": "1",
+				"expected-network":   "mainnet",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "FileMissing",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"expected-validator": "1",
+				"expected-network":   "mainnet",
+			},
+			err: "file is required",
+		},
+		{
+			name: "ExpectedValidatorMissing",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"file":             "exit.json",
+				"expected-network": "mainnet",
+			},
+			err: "expected validator is required",
+		},
+		{
+			name: "ExpectedNetworkMissing",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"file":               "exit.json",
+				"expected-validator": "1",
+			},
+			err: "expected network is required",
+		},
+		{
+			name: "ExpectedNetworkUnknown",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"file":               "exit.json",
+				"expected-validator": "1",
+				"expected-network":   "unknown",
+			},
+			err: "unknown network unknown; supported networks are gnosis, holesky, mainnet, sepolia",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"file":               "exit.json",
+				"expected-validator": "1",
+				"expected-network":   "mainnet",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exitverifyexternal
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		return errors.Wrap(err, "failed to read exit file")
+	}
+
+	exit, parseErr := beacon.ParseSignedVoluntaryExit(data)
+
+	// Only the expected validator and the validator in the exit are required.
+	validators := []string{c.expectedValidator}
+	if parseErr == nil {
+		validators = append(validators, fmt.Sprintf("%d", exit.Message.ValidatorIndex))
+	}
+	if err := c.setup(ctx, validators); err != nil {
+		return err
+	}
+
+	if parseErr != nil {
+		c.addCheck("Exit contains only voluntary exit data", false, parseErr.Error())
+		// Nothing more can be checked.
+		return nil
+	}
+	c.addCheck("Exit contains only voluntary exit data", true, "")
+
+	genesisValidatorsRoot, err := networkGenesisValidatorsRoot(c.expectedNetwork)
+	if err != nil {
+		return err
+	}
+	c.verify(ctx, exit, genesisValidatorsRoot)
+
+	return nil
+}
+
+// verify verifies the exit against the chain information and the expected network.
+func (c *command) verify(ctx context.Context, exit *phase0.SignedVoluntaryExit, genesisValidatorsRoot phase0.Root) {
+	c.checkNetwork(ctx, genesisValidatorsRoot)
+	validatorInfo := c.checkValidator(ctx, exit)
+	if validatorInfo != nil {
+		c.checks = append(c.checks, c.chainInfo.CheckVoluntaryExitSignature(exit, validatorInfo.Pubkey, genesisValidatorsRoot)...)
+	}
+	c.checks = append(c.checks, c.chainInfo.CheckVoluntaryExitEpoch(exit))
+}
+
+func (c *command) setup(ctx context.Context, validators []string) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.consensusClient.(consensusclient.ForkScheduleProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	c.chainInfo, err = beacon.ObtainChainInfoFromNodeForValidators(ctx, c.consensusClient, c.chainTime, validators)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain chain information")
+	}
+
+	return nil
+}
+
+func (c *command) addCheck(name string, passed bool, detail string) {
+	c.checks = append(c.checks, &beacon.ExitCheck{
+		Name:   name,
+		Passed: passed,
+		Detail: detail,
+	})
+}
+
+// checkNetwork checks that the node is on the expected network.
+func (c *command) checkNetwork(_ context.Context, genesisValidatorsRoot phase0.Root) {
+	name := "Node is on expected network"
+	if !bytes.Equal(c.chainInfo.GenesisValidatorsRoot[:], genesisValidatorsRoot[:]) {
+		c.addCheck(name, false, fmt.Sprintf("node has genesis validators root %#x, expected %#x", c.chainInfo.GenesisValidatorsRoot, genesisValidatorsRoot))
+		return
+	}
+	c.addCheck(name, true, fmt.Sprintf("genesis validators root %#x", genesisValidatorsRoot))
+}
+
+// checkValidator checks that the exit is for the expected validator, and that the validator can exit,
+// returning the validator's information if the exit is for the expected validator.
+func (c *command) checkValidator(ctx context.Context, exit *phase0.SignedVoluntaryExit) *beacon.ValidatorInfo {
+	name := "Exit is for expected validator"
+	expected, err := c.chainInfo.FetchValidatorInfo(ctx, c.expectedValidator)
+	if err != nil {
+		c.addCheck(name, false, fmt.Sprintf("failed to obtain expected validator: %v", err))
+		return nil
+	}
+	if expected.Index != exit.Message.ValidatorIndex {
+		c.addCheck(name, false, fmt.Sprintf("exit is for validator %d, expected %d", exit.Message.ValidatorIndex, expected.Index))
+		return nil
+	}
+	c.addCheck(name, true, fmt.Sprintf("validator %d", expected.Index))
+
+	name = "Validator is able to exit"
+	switch expected.State {
+	case apiv1.ValidatorStateActiveOngoing:
+		c.addCheck(name, true, "")
+	default:
+		c.addCheck(name, false, fmt.Sprintf("validator is in state %v", expected.State))
+	}
+
+	return expected
+}
+
+// networkGenesisValidatorsRoot obtains the genesis validators root for a network,
+// which can be supplied as either the name of a bundled network or the root itself.
+func networkGenesisValidatorsRoot(network string) (phase0.Root, error) {
+	var root phase0.Root
+
+	if !strings.HasPrefix(network, "0x") {
+		known, err := beacon.NetworkByName(network)
+		if err != nil {
+			return root, err
+		}
+		return known.GenesisValidatorsRoot, nil
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(network, "0x"))
+	if err != nil {
+		return root, errors.Wrap(err, "invalid genesis validators root")
+	}
+	if len(data) != phase0.RootLength {
+		return root, errors.New("genesis validators root incorrect length")
+	}
+	copy(root[:], data)
+
+	return root, nil
+}
@@ -0,0 +1,191 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exitverifyexternal
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestNetworkGenesisValidatorsRoot(t *testing.T) {
+	tests := []struct {
+		name    string
+		network string
+		root    string
+		err     string
+	}{
+		{
+			name:    "Unknown",
+			network: "unknown",
+			err:     "unknown network unknown; supported networks are gnosis, holesky, mainnet, sepolia",
+		},
+		{
+			name:    "RootShort",
+			network: "0x0102",
+			err:     "genesis validators root incorrect length",
+		},
+		{
+			name:    "Mainnet",
+			network: "Mainnet",
+			root:    "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+		},
+		{
+			name:    "Root",
+			network: "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20",
+			root:    "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root, err := networkGenesisValidatorsRoot(test.network)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.root, root.String())
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	privateKey, err := e2types.BLSPrivateKeyFromBytes([]byte{
+		0x25, 0x29, 0x5f, 0x0d, 0x1d, 0x59, 0x2a, 0x90, 0xb3, 0x33, 0xe2, 0x6e, 0x85, 0x14, 0x97, 0x08,
+		0x20, 0x8e, 0x9f, 0x8e, 0x8b, 0xc1, 0x8f, 0x6c, 0x77, 0xbd, 0x62, 0xf8, 0xad, 0x7a, 0x68, 0x66,
+	})
+	require.NoError(t, err)
+	var pubkey phase0.BLSPubKey
+	copy(pubkey[:], privateKey.PublicKey().Marshal())
+
+	mainnet, err := networkGenesisValidatorsRoot("mainnet")
+	require.NoError(t, err)
+
+	chainInfo := &beacon.ChainInfo{
+		Version: 3,
+		Validators: []*beacon.ValidatorInfo{
+			{
+				Index:  2,
+				Pubkey: pubkey,
+				State:  apiv1.ValidatorStateActiveOngoing,
+			},
+			{
+				Index:  3,
+				Pubkey: pubkey,
+				State:  apiv1.ValidatorStateExitedUnslashed,
+			},
+		},
+		GenesisValidatorsRoot:   mainnet,
+		Epoch:                   200,
+		GenesisForkVersion:      phase0.Version{0x00, 0x00, 0x00, 0x00},
+		CurrentForkVersion:      phase0.Version{0x04, 0x00, 0x00, 0x00},
+		CapellaForkVersion:      phase0.Version{0x03, 0x00, 0x00, 0x00},
+		CapellaForkEpoch:        100,
+		VoluntaryExitDomainType: phase0.DomainType{0x04, 0x00, 0x00, 0x00},
+	}
+
+	// sign signs an exit for the given network.
+	sign := func(index phase0.ValidatorIndex, epoch phase0.Epoch, genesisValidatorsRoot phase0.Root) *phase0.SignedVoluntaryExit {
+		exit := &phase0.SignedVoluntaryExit{
+			Message: &phase0.VoluntaryExit{
+				Epoch:          epoch,
+				ValidatorIndex: index,
+			},
+		}
+		signingRoot, err := chainInfo.VoluntaryExitSigningRoot(exit.Message, chainInfo.CapellaForkVersion, genesisValidatorsRoot)
+		require.NoError(t, err)
+		copy(exit.Signature[:], privateKey.Sign(signingRoot[:]).Marshal())
+		return exit
+	}
+
+	tests := []struct {
+		name              string
+		exit              *phase0.SignedVoluntaryExit
+		expectedValidator string
+		expectedNetwork   phase0.Root
+		failed            []string
+	}{
+		{
+			name:              "Good",
+			exit:              sign(2, 150, mainnet),
+			expectedValidator: "2",
+			expectedNetwork:   mainnet,
+		},
+		{
+			name:              "WrongValidator",
+			exit:              sign(2, 150, mainnet),
+			expectedValidator: "3",
+			expectedNetwork:   mainnet,
+			failed:            []string{"Exit is for expected validator"},
+		},
+		{
+			name:              "ValidatorExited",
+			exit:              sign(3, 150, mainnet),
+			expectedValidator: "3",
+			expectedNetwork:   mainnet,
+			failed:            []string{"Validator is able to exit"},
+		},
+		{
+			name:              "SignedForOtherNetwork",
+			exit:              sign(2, 150, phase0.Root{0x01}),
+			expectedValidator: "2",
+			expectedNetwork:   mainnet,
+			failed:            []string{"Exit signature is valid", "Exit signed with expected domain"},
+		},
+		{
+			name:              "NodeOnOtherNetwork",
+			exit:              sign(2, 150, phase0.Root{0x01}),
+			expectedValidator: "2",
+			expectedNetwork:   phase0.Root{0x01},
+			failed:            []string{"Node is on expected network"},
+		},
+		{
+			name:              "FutureEpoch",
+			exit:              sign(2, 250, mainnet),
+			expectedValidator: "2",
+			expectedNetwork:   mainnet,
+			failed:            []string{"Exit epoch is not in the future"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				expectedValidator: test.expectedValidator,
+				chainInfo:         chainInfo,
+				checks:            make([]*beacon.ExitCheck, 0),
+			}
+			c.verify(context.Background(), test.exit, test.expectedNetwork)
+			failed := make([]string, 0)
+			for _, check := range c.checks {
+				if !check.Passed {
+					failed = append(failed, check.Name)
+				}
+			}
+			if len(test.failed) == 0 {
+				require.True(t, c.passed())
+			} else {
+				require.Equal(t, test.failed, failed)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	exitcombine "github.com/wealdtech/ethdo/cmd/exit/combine"
+)
+
+var exitCombineCmd = &cobra.Command{
+	Use:   "combine",
+	Short: "Combine the partial signatures of a coordinated exit",
+	Long: `Combine the partial signatures of a coordinated exit, as generated by "ethdo exit coordinate", in to a signed exit for the validator.  For example:
+
+    ethdo exit combine --file=exit-coordination.json > exit-operation.json
+
+If operators have added their partial signatures to separate copies of the coordination file then each file can be supplied with a separate --file, and the partial signatures are merged.  The combined signature is verified against the public key of the validator, and the resultant signed exit can be broadcast with "ethdo validator exit".
+
+In quiet mode this will return 0 if the partial signatures can be combined in to a valid signed exit, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := exitcombine.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	exitCmd.AddCommand(exitCombineCmd)
+	exitFlags(exitCombineCmd)
+	exitCombineCmd.Flags().StringSlice("file", []string{"exit-coordination.json"}, "Path to an exit coordination file (supply once for each file)")
+}
+
+func exitCombineBindings() {
+	if err := viper.BindPFlag("file", exitCombineCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	exitcoordinate "github.com/wealdtech/ethdo/cmd/exit/coordinate"
+)
+
+var exitCoordinateCmd = &cobra.Command{
+	Use:   "coordinate",
+	Short: "Add a partial signature to a coordinated exit",
+	Long: `Add a partial signature to a coordinated exit for a validator whose key is split between multiple operators.  For example:
+
+    ethdo exit coordinate --account=Distributed/Validator1 --passphrase=secret
+
+Each operator runs this command with their key share, supplied either as a distributed account with --account or as a private key with --private-key and --share-id.  The first operator creates the coordination file, which requires access to a beacon node or an offline preparation file generated by "ethdo validator exit --prepare-offline"; the file is then passed to each of the other operators in turn to add their partial signatures, without any further access to the chain.  Alternatively each operator can create their own coordination file from a copy of the original, and the files are merged by "ethdo exit combine".
+
+The validator to exit is supplied with --validator, and defaults to the composite public key of a distributed account.  The exit epoch is supplied with --epoch, and defaults to the current epoch.
+
+In quiet mode this will return 0 if the partial signature has been added to the coordination file, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := exitcoordinate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	exitCmd.AddCommand(exitCoordinateCmd)
+	exitFlags(exitCoordinateCmd)
+	exitCoordinateCmd.Flags().String("file", "exit-coordination.json", "Path to the exit coordination file (created if it does not exist)")
+	exitCoordinateCmd.Flags().String("validator", "", "Validator to exit (defaults to the composite public key of a distributed account)")
+	exitCoordinateCmd.Flags().String("epoch", "", "Epoch at which to exit (defaults to current epoch)")
+	exitCoordinateCmd.Flags().Uint64("share-id", 0, "ID of the key share (defaults to the ID obtained from a distributed account)")
+	exitCoordinateCmd.Flags().Uint32("threshold", 0, "Number of partial signatures required to combine (defaults to the threshold of a distributed account)")
+	exitCoordinateCmd.Flags().Bool("offline", false, "Do not attempt to connect to a beacon node to obtain information for the exit")
+	exitCoordinateCmd.Flags().Bool("json", false, "output the coordination file in JSON format")
+}
+
+func exitCoordinateBindings() {
+	if err := viper.BindPFlag("file", exitCoordinateCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validator", exitCoordinateCmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epoch", exitCoordinateCmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("share-id", exitCoordinateCmd.Flags().Lookup("share-id")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("threshold", exitCoordinateCmd.Flags().Lookup("threshold")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("offline", exitCoordinateCmd.Flags().Lookup("offline")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", exitCoordinateCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
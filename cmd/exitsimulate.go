@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	exitsimulate "github.com/wealdtech/ethdo/cmd/exit/simulate"
+)
+
+var exitSimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Simulate processing of a voluntary exit",
+	Long: `Simulate processing of a voluntary exit against a copy of the current beacon state, using the checks of the state transition function.  For example:
+
+    ethdo exit simulate --operation=exit.json
+
+The result shows each check in the order that a client carries them out, stopping at the first failure.  If the exit would be accepted the epochs at which the validator would exit and become withdrawable are also shown.
+
+If --state is supplied and the file exists the state is read from it and no beacon node is contacted, otherwise the state is obtained from the beacon node and, if --state is supplied, written to the file for later use.
+
+In quiet mode this will return 0 if the exit would be accepted, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := exitsimulate.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	exitCmd.AddCommand(exitSimulateCmd)
+	exitFlags(exitSimulateCmd)
+	exitSimulateCmd.Flags().String("operation", "", "Path to the signed exit operation")
+	exitSimulateCmd.Flags().String("state", "", "Path to an SSZ-encoded beacon state (read if present, otherwise written after fetching)")
+	exitSimulateCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func exitSimulateBindings() {
+	if err := viper.BindPFlag("operation", exitSimulateCmd.Flags().Lookup("operation")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("state", exitSimulateCmd.Flags().Lookup("state")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", exitSimulateCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -63,11 +63,11 @@ In quiet mode this will return 0 if the the exit is verified correctly, otherwis
 		domain := e2types.Domain(e2types.DomainVoluntaryExit, data.ForkVersion[:], genesis.GenesisValidatorsRoot[:])
 		var exitDomain spec.Domain
 		copy(exitDomain[:], domain)
-		exit := &spec.VoluntaryExit{
+		voluntaryExit := &spec.VoluntaryExit{
 			Epoch:          data.Exit.Message.Epoch,
 			ValidatorIndex: data.Exit.Message.ValidatorIndex,
 		}
-		exitRoot, err := exit.HashTreeRoot()
+		exitRoot, err := voluntaryExit.HashTreeRoot()
 		errCheck(err, "Failed to obtain exit hash tree root")
 		signatureBytes := make([]byte, 96)
 		copy(signatureBytes, data.Exit.Signature[:])
@@ -82,7 +82,7 @@ In quiet mode this will return 0 if the the exit is verified correctly, otherwis
 		assert(bytes.Equal(data.ForkVersion[:], fork.CurrentVersion[:]) || bytes.Equal(data.ForkVersion[:], fork.PreviousVersion[:]), "Exit is for an old fork version and is no longer valid")
 
 		outputIf(verbose, "Verified")
-		os.Exit(_exitSuccess)
+		exit(_exitSuccess)
 	},
 }
 
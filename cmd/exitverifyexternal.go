@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	exitverifyexternal "github.com/wealdtech/ethdo/cmd/exit/verifyexternal"
+)
+
+var exitVerifyExternalCmd = &cobra.Command{
+	Use:   "verify-external",
+	Short: "Verify a voluntary exit generated by a third party",
+	Long: `Verify a voluntary exit generated by a third party, such as a staking provider.  For example:
+
+    ethdo exit verify-external --file=exit.json --expected-validator=12345 --expected-network=mainnet
+
+Checks include that the file contains only the signed exit, that the exit is for the expected validator, that it is signed correctly for the expected network and fork, and that its epoch is not in the future.
+
+The expected network can be a network name or a genesis validators root.
+
+In quiet mode this will return 0 if all checks pass, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := exitverifyexternal.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	exitCmd.AddCommand(exitVerifyExternalCmd)
+	exitFlags(exitVerifyExternalCmd)
+	exitVerifyExternalCmd.Flags().String("file", "", "Path to the signed exit")
+	exitVerifyExternalCmd.Flags().String("expected-validator", "", "Account, public key or index of the validator the exit should be for")
+	exitVerifyExternalCmd.Flags().String("expected-network", "", "Name or genesis validators root of the network the exit should be for")
+	exitVerifyExternalCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func exitVerifyExternalBindings() {
+	if err := viper.BindPFlag("file", exitVerifyExternalCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("expected-validator", exitVerifyExternalCmd.Flags().Lookup("expected-validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("expected-network", exitVerifyExternalCmd.Flags().Lookup("expected-network")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", exitVerifyExternalCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
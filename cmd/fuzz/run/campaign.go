@@ -0,0 +1,130 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fuzzrun
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultFuzziness is the fuzziness used for operations that do not specify it.
+const defaultFuzziness = 5
+
+// strategies are the known fuzzing strategies.
+var strategies = map[string]bool{
+	"message":   true,
+	"root":      true,
+	"signature": true,
+}
+
+// campaignSettings are settings that are controlled by the campaign, and so
+// cannot be supplied in an operation's settings.
+var campaignSettings = map[string]bool{
+	"connection":      true,
+	"fuzz-strategies": true,
+	"fuzziness":       true,
+	"seed":            true,
+	"validator":       true,
+}
+
+// campaign is a fuzzing campaign.
+type campaign struct {
+	// Seed is the base seed for the campaign; 0 is random.
+	Seed int64 `yaml:"seed"`
+	// Connections are the beacon nodes against which each operation is run.
+	Connections []string `yaml:"connections"`
+	// Operations are the operations to run.
+	Operations []*operation `yaml:"operations"`
+}
+
+// operation is a single type of fuzzing operation within a campaign.
+type operation struct {
+	// Type is the type of operation, for example "exit".
+	Type string `yaml:"type"`
+	// Iterations is the number of times the operation is run for each target and connection.
+	Iterations uint64 `yaml:"iterations"`
+	// Fuzziness is the fuzziness of the operation; 0 is no fuzziness, 100 is max.
+	Fuzziness *uint64 `yaml:"fuzziness"`
+	// Strategies are the fuzzing strategies to apply; empty is all.
+	Strategies []string `yaml:"strategies"`
+	// Targets are the validators for which the operation is run.
+	Targets []string `yaml:"targets"`
+	// Settings are additional settings for the operation, as per the operation's command-line flags.
+	Settings map[string]string `yaml:"settings"`
+}
+
+// parseCampaign parses and validates a campaign configuration.
+func parseCampaign(data []byte) (*campaign, error) {
+	res := &campaign{}
+	if err := yaml.Unmarshal(data, res); err != nil {
+		return nil, errors.Wrap(err, "failed to parse campaign")
+	}
+
+	if len(res.Operations) == 0 {
+		return nil, errors.New("campaign has no operations")
+	}
+	for i, op := range res.Operations {
+		if op == nil {
+			return nil, fmt.Errorf("operation %d: empty", i)
+		}
+		if op.Type == "" {
+			return nil, fmt.Errorf("operation %d: type is required", i)
+		}
+		if _, exists := runners[op.Type]; !exists {
+			return nil, fmt.Errorf("operation %d: unknown type %s", i, op.Type)
+		}
+		if op.Iterations == 0 {
+			op.Iterations = 1
+		}
+		if op.Fuzziness == nil {
+			fuzziness := uint64(defaultFuzziness)
+			op.Fuzziness = &fuzziness
+		}
+		if *op.Fuzziness > 100 {
+			return nil, fmt.Errorf("operation %d: fuzziness must be between 0 and 100", i)
+		}
+		for _, strategy := range op.Strategies {
+			if !strategies[strategy] {
+				return nil, fmt.Errorf("operation %d: unknown strategy %s", i, strategy)
+			}
+		}
+		for key := range op.Settings {
+			if campaignSettings[key] {
+				return nil, fmt.Errorf("operation %d: setting %s is controlled by the campaign", i, key)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// runs returns the number of runs in the campaign, given the default connection.
+func (c *campaign) runs() uint64 {
+	connections := uint64(len(c.Connections))
+	if connections == 0 {
+		connections = 1
+	}
+	total := uint64(0)
+	for _, op := range c.Operations {
+		targets := uint64(len(op.Targets))
+		if targets == 0 {
+			targets = 1
+		}
+		total += op.Iterations * targets * connections
+	}
+
+	return total
+}
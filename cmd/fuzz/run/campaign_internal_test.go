@@ -0,0 +1,115 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fuzzrun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCampaign(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		runs   uint64
+		err    string
+		verify func(t *testing.T, c *campaign)
+	}{
+		{
+			name:  "Invalid",
+			input: "operations: [",
+			err:   "failed to parse campaign",
+		},
+		{
+			name:  "NoOperations",
+			input: "seed: 1\n",
+			err:   "campaign has no operations",
+		},
+		{
+			name:  "TypeMissing",
+			input: "operations:\n  - iterations: 2\n",
+			err:   "operation 0: type is required",
+		},
+		{
+			name:  "TypeUnknown",
+			input: "operations:\n  - type: deposit\n",
+			err:   "operation 0: unknown type deposit",
+		},
+		{
+			name:  "FuzzinessTooHigh",
+			input: "operations:\n  - type: exit\n    fuzziness: 101\n",
+			err:   "operation 0: fuzziness must be between 0 and 100",
+		},
+		{
+			name:  "StrategyUnknown",
+			input: "operations:\n  - type: exit\n    strategies: [message, pubkey]\n",
+			err:   "operation 0: unknown strategy pubkey",
+		},
+		{
+			name:  "SettingControlled",
+			input: "operations:\n  - type: exit\n  - type: credentials\n    settings:\n      seed: \"5\"\n",
+			err:   "operation 1: setting seed is controlled by the campaign",
+		},
+		{
+			name:  "Defaults",
+			input: "operations:\n  - type: exit\n",
+			runs:  1,
+			verify: func(t *testing.T, c *campaign) {
+				require.Equal(t, uint64(1), c.Operations[0].Iterations)
+				require.Equal(t, uint64(defaultFuzziness), *c.Operations[0].Fuzziness)
+			},
+		},
+		{
+			name: "Good",
+			input: `seed: 12345
+connections:
+  - http://node1:5052
+  - http://node2:5052
+operations:
+  - type: exit
+    iterations: 10
+    fuzziness: 0
+    strategies: [signature]
+    targets: ["1", "2", "3"]
+  - type: credentials
+    iterations: 5
+    settings:
+      withdrawal-address: "0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F"
+`,
+			runs: 70,
+			verify: func(t *testing.T, c *campaign) {
+				require.Equal(t, int64(12345), c.Seed)
+				require.Equal(t, uint64(0), *c.Operations[0].Fuzziness)
+				require.Equal(t, []string{"signature"}, c.Operations[0].Strategies)
+				require.Equal(t, "0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F", c.Operations[1].Settings["withdrawal-address"])
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseCampaign([]byte(test.input))
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.runs, res.runs())
+				if test.verify != nil {
+					test.verify(t, res)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fuzzrun
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorcredentialsfuzz "github.com/wealdtech/ethdo/cmd/validator/credentials/fuzz"
+	validatorexitfuzz "github.com/wealdtech/ethdo/cmd/validator/exitfuzz"
+)
+
+// runner runs a single fuzzing operation.
+type runner func(cmd *cobra.Command) (string, error)
+
+// runners are the fuzzing operations that can be scheduled in a campaign.
+var runners = map[string]runner{
+	"credentials": validatorcredentialsfuzz.Run,
+	"exit":        validatorexitfuzz.Run,
+}
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	campaign   *campaign
+	connection string
+	runners    map[string]runner
+
+	// Output.
+	seed    int64
+	reports []*operationReport
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:      viper.GetBool("quiet"),
+		verbose:    viper.GetBool("verbose"),
+		debug:      viper.GetBool("debug"),
+		json:       viper.GetBool("json"),
+		connection: viper.GetString("connection"),
+		runners:    runners,
+	}
+
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if viper.GetString("campaign-config") == "" {
+		return nil, errors.New("config is required")
+	}
+	data, err := os.ReadFile(viper.GetString("campaign-config"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read config")
+	}
+	c.campaign, err = parseCampaign(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid config")
+	}
+
+	return c, nil
+}
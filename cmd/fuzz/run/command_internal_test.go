@@ -0,0 +1,92 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fuzzrun
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	dir := t.TempDir()
+	goodFile := filepath.Join(dir, "good.yaml")
+	require.NoError(t, os.WriteFile(goodFile, []byte("operations:\n  - type: exit\n    iterations: 10\n"), 0o600))
+	badFile := filepath.Join(dir, "bad.yaml")
+	require.NoError(t, os.WriteFile(badFile, []byte("operations:\n  - type: deposit\n"), 0o600))
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"campaign-config": goodFile,
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ConfigMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "config is required",
+		},
+		{
+			name: "ConfigNotFound",
+			vars: map[string]interface{}{
+				"timeout":         "5s",
+				"campaign-config": filepath.Join(dir, "missing.yaml"),
+			},
+			err: "failed to read config: open " + filepath.Join(dir, "missing.yaml") + ": no such file or directory",
+		},
+		{
+			name: "ConfigInvalid",
+			vars: map[string]interface{}{
+				"timeout":         "5s",
+				"campaign-config": badFile,
+			},
+			err: "invalid config: operation 0: unknown type deposit",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":         "5s",
+				"campaign-config": goodFile,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fuzzrun
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// operationReport is the consolidated report for an operation in a campaign.
+type operationReport struct {
+	Type     string         `json:"type"`
+	Runs     uint64         `json:"runs"`
+	Accepted uint64         `json:"accepted"`
+	Rejected uint64         `json:"rejected"`
+	Errors   []*errorReport `json:"errors,omitempty"`
+
+	errors map[string]uint64
+}
+
+// errorReport is the number of times a given error was seen.
+type errorReport struct {
+	Error string `json:"error"`
+	Count uint64 `json:"count"`
+}
+
+func (c *command) process(ctx context.Context, cmd *cobra.Command) error {
+	c.seed = c.campaign.Seed
+	if c.seed == 0 {
+		c.seed = rand.Int63()
+	}
+
+	connections := c.campaign.Connections
+	if len(connections) == 0 {
+		connections = []string{c.connection}
+	}
+
+	total := c.campaign.runs()
+	run := uint64(0)
+	c.reports = make([]*operationReport, 0, len(c.campaign.Operations))
+	for _, op := range c.campaign.Operations {
+		report := &operationReport{
+			Type:   op.Type,
+			errors: make(map[string]uint64),
+		}
+		c.reports = append(c.reports, report)
+
+		targets := op.Targets
+		if len(targets) == 0 {
+			targets = []string{""}
+		}
+		for _, connection := range connections {
+			for _, target := range targets {
+				for i := uint64(0); i < op.Iterations; i++ {
+					if err := ctx.Err(); err != nil {
+						return err
+					}
+					// Each run has its own seed, so that individual runs can be reproduced.
+					seed := c.seed + int64(run)
+					run++
+					util.Log.Debug().Uint64("run", run).Uint64("total", total).Str("type", op.Type).Str("connection", connection).Str("target", target).Int64("seed", seed).Msg("Starting run")
+					c.runOperation(cmd, op, report, connection, target, seed)
+				}
+			}
+		}
+		report.finalize()
+	}
+
+	return nil
+}
+
+// runOperation runs a single fuzzing operation, recording the result in the report.
+func (c *command) runOperation(cmd *cobra.Command,
+	op *operation,
+	report *operationReport,
+	connection string,
+	target string,
+	seed int64,
+) {
+	settings := map[string]interface{}{
+		"connection":      connection,
+		"validator":       target,
+		"fuzziness":       *op.Fuzziness,
+		"seed":            seed,
+		"fuzz-strategies": op.Strategies,
+		// Operations are broadcast unless their settings state otherwise.
+		"json": false,
+	}
+	for k, v := range op.Settings {
+		settings[k] = v
+	}
+	for k, v := range settings {
+		viper.Set(k, v)
+	}
+	// Settings for this operation must not leak in to the next.
+	defer func() {
+		for k := range op.Settings {
+			viper.Set(k, nil)
+		}
+	}()
+
+	report.Runs++
+	if _, err := c.runners[op.Type](cmd); err != nil {
+		report.Rejected++
+		report.errors[err.Error()]++
+		util.Log.Debug().Err(err).Msg("Run rejected")
+		return
+	}
+	report.Accepted++
+}
+
+// finalize generates the sorted error list for the report.
+func (r *operationReport) finalize() {
+	r.Errors = make([]*errorReport, 0, len(r.errors))
+	for err, count := range r.errors {
+		r.Errors = append(r.Errors, &errorReport{
+			Error: err,
+			Count: count,
+		})
+	}
+	sort.Slice(r.Errors, func(i, j int) bool {
+		if r.Errors[i].Count != r.Errors[j].Count {
+			return r.Errors[i].Count > r.Errors[j].Count
+		}
+		return r.Errors[i].Error < r.Errors[j].Error
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fuzzrun
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	viper.Reset()
+
+	campaign, err := parseCampaign([]byte(`seed: 100
+operations:
+  - type: exit
+    iterations: 2
+    targets: ["1", "2"]
+    settings:
+      epoch: "5"
+  - type: credentials
+`))
+	require.NoError(t, err)
+
+	seen := make([]string, 0)
+	exitRunner := func(_ *cobra.Command) (string, error) {
+		seen = append(seen, viper.GetString("connection")+"/"+viper.GetString("validator"))
+		require.Equal(t, "5", viper.GetString("epoch"))
+		require.False(t, viper.GetBool("json"))
+		// Reject odd seeds.
+		if viper.GetInt64("seed")%2 == 1 {
+			return "", errors.New("rejected")
+		}
+		return "", nil
+	}
+	credentialsRunner := func(_ *cobra.Command) (string, error) {
+		// Settings from the previous operation should not be present.
+		require.Equal(t, "", viper.GetString("epoch"))
+		require.Equal(t, "default", viper.GetString("connection"))
+		require.Equal(t, int64(104), viper.GetInt64("seed"))
+		return "", nil
+	}
+
+	c := &command{
+		campaign:   campaign,
+		connection: "default",
+		runners: map[string]runner{
+			"exit":        exitRunner,
+			"credentials": credentialsRunner,
+		},
+	}
+	require.NoError(t, c.process(context.Background(), &cobra.Command{}))
+
+	require.Equal(t, int64(100), c.seed)
+	require.Equal(t, []string{"default/1", "default/1", "default/2", "default/2"}, seen)
+	require.Len(t, c.reports, 2)
+	require.Equal(t, uint64(4), c.reports[0].Runs)
+	require.Equal(t, uint64(2), c.reports[0].Accepted)
+	require.Equal(t, uint64(2), c.reports[0].Rejected)
+	require.Equal(t, []*errorReport{{Error: "rejected", Count: 2}}, c.reports[0].Errors)
+	require.Equal(t, uint64(1), c.reports[1].Runs)
+	require.Equal(t, uint64(1), c.reports[1].Accepted)
+}
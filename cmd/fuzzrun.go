@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	fuzzrun "github.com/wealdtech/ethdo/cmd/fuzz/run"
+)
+
+var fuzzRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run a fuzzing campaign",
+	Long: `Run a fuzzing campaign defined in a YAML configuration file.  For example:
+
+    ethdo fuzz run --config=campaign.yaml
+
+The campaign can schedule multiple operation types, each with its own iterations, fuzziness, mutation strategies, targets and settings, against one or more beacon nodes.  Results are reported per operation type.
+
+In quiet mode this will return 0 if the campaign ran, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := fuzzrun.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	fuzzCmd.AddCommand(fuzzRunCmd)
+	fuzzFlags(fuzzRunCmd)
+	fuzzRunCmd.Flags().String("config", "", "the YAML file containing the campaign configuration")
+	fuzzRunCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func fuzzRunBindings() {
+	if err := viper.BindPFlag("campaign-config", fuzzRunCmd.Flags().Lookup("config")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", fuzzRunCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
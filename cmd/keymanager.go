@@ -0,0 +1,48 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// keymanagerCmd represents the keymanager command
+var keymanagerCmd = &cobra.Command{
+	Use:   "keymanager",
+	Short: "Manage validator client configuration through the keymanager API",
+	Long:  `Manage validator client configuration through the keymanager API.`,
+}
+
+func init() {
+	RootCmd.AddCommand(keymanagerCmd)
+}
+
+func keymanagerFlags(cmd *cobra.Command) {
+	cmd.Flags().String("keymanager", "", "the address of the validator client keymanager API")
+	cmd.Flags().String("keymanager-token", "", "the bearer token for the keymanager API")
+	cmd.Flags().String("keymanager-token-file", "", "a file containing the bearer token for the keymanager API")
+}
+
+func keymanagerBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("keymanager", cmd.Flags().Lookup("keymanager")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("keymanager-token", cmd.Flags().Lookup("keymanager-token")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("keymanager-token-file", cmd.Flags().Lookup("keymanager-token-file")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerapply
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Keymanager connection.
+	timeout                  time.Duration
+	keymanager               string
+	token                    string
+	allowInsecureConnections bool
+
+	// Operation.
+	file       string
+	dryRun     bool
+	jsonOutput bool
+
+	// Data access.
+	client *util.KeymanagerClient
+	config *config
+
+	// Results.
+	result *result
+}
+
+type result struct {
+	DryRun     bool      `json:"dry_run"`
+	Validators int       `json:"validators"`
+	Changes    []*change `json:"changes"`
+}
+
+// change is a single setting changed for a validator.
+type change struct {
+	PubKey  string `json:"pubkey"`
+	Setting string `json:"setting"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		result: &result{
+			Changes: make([]*change, 0),
+		},
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.keymanager = viper.GetString("keymanager")
+	if c.keymanager == "" {
+		return nil, errors.New("keymanager is required")
+	}
+	token, err := util.KeymanagerToken()
+	if err != nil {
+		return nil, err
+	}
+	c.token = token
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.file = viper.GetString("file")
+	if c.file == "" {
+		return nil, errors.New("file is required")
+	}
+
+	c.dryRun = viper.GetBool("dry-run")
+	c.result.DryRun = c.dryRun
+	c.jsonOutput = viper.GetBool("json")
+
+	return c, nil
+}
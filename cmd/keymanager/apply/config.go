@@ -0,0 +1,93 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerapply
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// config is the declarative configuration for the validators in a keymanager.
+type config struct {
+	// Default are the settings applied to all validators.
+	Default *settings `yaml:"default"`
+	// Validators are per-validator settings, keyed by public key or account,
+	// that override the defaults.
+	Validators map[string]*settings `yaml:"validators"`
+}
+
+// settings are the settings for a validator; nil values are left unchanged.
+type settings struct {
+	Graffiti *string `yaml:"graffiti"`
+	GasLimit *uint64 `yaml:"gas_limit"`
+}
+
+// parseConfig parses and validates a configuration.
+func parseConfig(data []byte) (*config, error) {
+	res := &config{}
+	if err := yaml.Unmarshal(data, res); err != nil {
+		return nil, errors.Wrap(err, "failed to parse configuration")
+	}
+
+	if res.Default == nil && len(res.Validators) == 0 {
+		return nil, errors.New("configuration has no settings")
+	}
+	if res.Default != nil {
+		if err := res.Default.validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid default settings")
+		}
+	}
+	for validator, settings := range res.Validators {
+		if settings == nil {
+			return nil, fmt.Errorf("validator %s has no settings", validator)
+		}
+		if err := settings.validate(); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid settings for validator %s", validator))
+		}
+	}
+
+	return res, nil
+}
+
+func (s *settings) validate() error {
+	if s.Graffiti != nil && len(*s.Graffiti) > 32 {
+		return errors.New("graffiti cannot be longer than 32 bytes")
+	}
+	if s.GasLimit != nil && *s.GasLimit == 0 {
+		return errors.New("gas limit cannot be 0")
+	}
+
+	return nil
+}
+
+// merge returns the settings with any values in the override applied.
+func (s *settings) merge(override *settings) *settings {
+	res := &settings{}
+	if s != nil {
+		res.Graffiti = s.Graffiti
+		res.GasLimit = s.GasLimit
+	}
+	if override != nil {
+		if override.Graffiti != nil {
+			res.Graffiti = override.Graffiti
+		}
+		if override.GasLimit != nil {
+			res.GasLimit = override.GasLimit
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,93 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerapply
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{
+			name: "Empty",
+			data: ``,
+			err:  "configuration has no settings",
+		},
+		{
+			name: "Invalid",
+			data: `default: [`,
+			err:  "failed to parse configuration: yaml: line 1: did not find expected node content",
+		},
+		{
+			name: "GraffitiTooLong",
+			data: `default: {graffiti: "123456789012345678901234567890123"}`,
+			err:  "invalid default settings: graffiti cannot be longer than 32 bytes",
+		},
+		{
+			name: "GasLimitZero",
+			data: `validators: {"0x01": {gas_limit: 0}}`,
+			err:  "invalid settings for validator 0x01: gas limit cannot be 0",
+		},
+		{
+			name: "ValidatorNoSettings",
+			data: `validators: {"0x01": }`,
+			err:  "validator 0x01 has no settings",
+		},
+		{
+			name: "Good",
+			data: `
+default:
+  graffiti: "my fleet"
+  gas_limit: 30000000
+validators:
+  "0x01":
+    graffiti: "special"
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseConfig([]byte(test.data))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	graffiti := "default"
+	override := "override"
+	gasLimit := uint64(30000000)
+
+	var defaults *settings
+	res := defaults.merge(&settings{Graffiti: &override})
+	require.Equal(t, &settings{Graffiti: &override}, res)
+
+	defaults = &settings{Graffiti: &graffiti, GasLimit: &gasLimit}
+	res = defaults.merge(nil)
+	require.Equal(t, defaults, res)
+
+	res = defaults.merge(&settings{Graffiti: &override})
+	require.Equal(t, &settings{Graffiti: &override, GasLimit: &gasLimit}, res)
+}
@@ -0,0 +1,61 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerapply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.result)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if len(c.result.Changes) == 0 {
+		builder.WriteString(fmt.Sprintf("All %d validators match the configuration", c.result.Validators))
+		return builder.String(), nil
+	}
+
+	if c.result.DryRun {
+		builder.WriteString("Changes that would be made:")
+	} else {
+		builder.WriteString("Changes made:")
+	}
+	for _, change := range c.result.Changes {
+		builder.WriteString(fmt.Sprintf("\n  %s %s: %q -> %q", change.PubKey, change.Setting, change.From, change.To))
+	}
+
+	return builder.String(), nil
+}
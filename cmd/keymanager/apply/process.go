@@ -0,0 +1,141 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerapply
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	pubKeys, err := c.client.Keystores(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+	c.result.Validators = len(pubKeys)
+
+	overrides, err := c.overrides(ctx, pubKeys)
+	if err != nil {
+		return err
+	}
+
+	for _, pubKey := range pubKeys {
+		key := fmt.Sprintf("%#x", pubKey)
+		if err := c.apply(ctx, pubKey, c.config.Default.merge(overrides[key])); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to apply configuration for %s", key))
+		}
+	}
+
+	return nil
+}
+
+// overrides resolves the per-validator settings in the configuration to
+// public keys, ensuring that each is managed by the keymanager.
+func (c *command) overrides(ctx context.Context, pubKeys [][]byte) (map[string]*settings, error) {
+	managed := make(map[string]bool, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		managed[fmt.Sprintf("%#x", pubKey)] = true
+	}
+
+	res := make(map[string]*settings, len(c.config.Validators))
+	for validator, settings := range c.config.Validators {
+		pubKey, err := util.KeymanagerPubKey(ctx, validator)
+		if err != nil {
+			return nil, err
+		}
+		key := fmt.Sprintf("%#x", pubKey)
+		if !managed[key] {
+			return nil, fmt.Errorf("validator %s is not managed by the keymanager", validator)
+		}
+		if _, exists := res[key]; exists {
+			return nil, fmt.Errorf("validator %s is configured multiple times", key)
+		}
+		res[key] = settings
+	}
+
+	return res, nil
+}
+
+// apply applies the settings to a single validator, changing only those
+// values that differ from the current configuration.
+func (c *command) apply(ctx context.Context, pubKey []byte, settings *settings) error {
+	if settings.Graffiti != nil {
+		current, err := c.client.Graffiti(ctx, pubKey)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain graffiti")
+		}
+		if current != *settings.Graffiti {
+			c.result.Changes = append(c.result.Changes, &change{
+				PubKey:  fmt.Sprintf("%#x", pubKey),
+				Setting: "graffiti",
+				From:    current,
+				To:      *settings.Graffiti,
+			})
+			if !c.dryRun {
+				if err := c.client.SetGraffiti(ctx, pubKey, *settings.Graffiti); err != nil {
+					return errors.Wrap(err, "failed to set graffiti")
+				}
+			}
+		}
+	}
+
+	if settings.GasLimit != nil {
+		current, err := c.client.GasLimit(ctx, pubKey)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain gas limit")
+		}
+		if current != *settings.GasLimit {
+			c.result.Changes = append(c.result.Changes, &change{
+				PubKey:  fmt.Sprintf("%#x", pubKey),
+				Setting: "gas_limit",
+				From:    fmt.Sprintf("%d", current),
+				To:      fmt.Sprintf("%d", *settings.GasLimit),
+			})
+			if !c.dryRun {
+				if err := c.client.SetGasLimit(ctx, pubKey, *settings.GasLimit); err != nil {
+					return errors.Wrap(err, "failed to set gas limit")
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		return errors.Wrap(err, "failed to read configuration file")
+	}
+	c.config, err = parseConfig(data)
+	if err != nil {
+		return err
+	}
+
+	c.client, err = util.ConnectToKeymanager(ctx, c.keymanager, c.token, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to keymanager")
+	}
+
+	return nil
+}
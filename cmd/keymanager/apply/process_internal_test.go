@@ -0,0 +1,144 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerapply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPubKey1 = "0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"
+	testPubKey2 = "0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"
+	testPubKey3 = "0x030000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"
+)
+
+// testKeymanager serves graffiti and gas limits for two validators.
+type testKeymanager struct {
+	values map[string]string
+	sets   int
+}
+
+func (k *testKeymanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/eth/v1/keystores" {
+		fmt.Fprintf(w, `{"data":[{"validating_pubkey":%q},{"validating_pubkey":%q}]}`, testPubKey1, testPubKey2)
+		return
+	}
+
+	item := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	switch r.Method {
+	case http.MethodGet:
+		fmt.Fprintf(w, `{"data":{%q:%q}}`, item, k.values[r.URL.Path])
+	case http.MethodPost:
+		body := make(map[string]string)
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		k.values[r.URL.Path] = body[item]
+		k.sets++
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func newTestKeymanager() *testKeymanager {
+	values := make(map[string]string)
+	for _, pubKey := range []string{testPubKey1, testPubKey2} {
+		values[fmt.Sprintf("/eth/v1/validator/%s/graffiti", pubKey)] = "old"
+		values[fmt.Sprintf("/eth/v1/validator/%s/gas_limit", pubKey)] = "30000000"
+	}
+
+	return &testKeymanager{values: values}
+}
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		dryRun  bool
+		changes []*change
+		sets    int
+		err     string
+	}{
+		{
+			name:   "UnknownValidator",
+			config: fmt.Sprintf(`validators: {%q: {graffiti: "new"}}`, testPubKey3),
+			err:    fmt.Sprintf("validator %s is not managed by the keymanager", testPubKey3),
+		},
+		{
+			name:    "Unchanged",
+			config:  `default: {graffiti: "old", gas_limit: 30000000}`,
+			changes: []*change{},
+		},
+		{
+			name:   "DryRun",
+			config: `default: {graffiti: "new"}`,
+			dryRun: true,
+			changes: []*change{
+				{PubKey: testPubKey1, Setting: "graffiti", From: "old", To: "new"},
+				{PubKey: testPubKey2, Setting: "graffiti", From: "old", To: "new"},
+			},
+		},
+		{
+			name:   "Override",
+			config: fmt.Sprintf(`{default: {graffiti: "new"}, validators: {%q: {graffiti: "old", gas_limit: 36000000}}}`, testPubKey2),
+			changes: []*change{
+				{PubKey: testPubKey1, Setting: "graffiti", From: "old", To: "new"},
+				{PubKey: testPubKey2, Setting: "gas_limit", From: "30000000", To: "36000000"},
+			},
+			sets: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keymanager := newTestKeymanager()
+			server := httptest.NewServer(keymanager)
+			defer server.Close()
+
+			file := filepath.Join(t.TempDir(), "config.yml")
+			require.NoError(t, os.WriteFile(file, []byte(test.config), 0o600))
+
+			c := &command{
+				timeout:                  time.Second,
+				keymanager:               server.URL,
+				token:                    "token",
+				allowInsecureConnections: true,
+				file:                     file,
+				dryRun:                   test.dryRun,
+				result: &result{
+					Changes: make([]*change, 0),
+				},
+			}
+			err := c.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.changes, c.result.Changes)
+				require.Equal(t, test.sets, keymanager.sets)
+			}
+		})
+	}
+}
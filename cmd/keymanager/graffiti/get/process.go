@@ -0,0 +1,59 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagergraffitiget
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	pubKeys, err := c.client.Validators(ctx, c.validators)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+
+	c.results = make([]*result, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		graffiti, err := c.client.Graffiti(ctx, pubKey)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain graffiti for %#x", pubKey))
+		}
+		c.results = append(c.results, &result{
+			PubKey:   fmt.Sprintf("%#x", pubKey),
+			Graffiti: graffiti,
+		})
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	c.client, err = util.ConnectToKeymanager(ctx, c.keymanager, c.token, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to keymanager")
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagergraffitiset
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token.txt")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token\n"), 0o600))
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"keymanager":       "http://localhost:7500",
+				"keymanager-token": "token",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "KeymanagerMissing",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"keymanager-token": "token",
+			},
+			err: "keymanager is required",
+		},
+		{
+			name: "TokenMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"keymanager": "http://localhost:7500",
+			},
+			err: "keymanager-token or keymanager-token-file is required",
+		},
+		{
+			name: "TokenMultiple",
+			vars: map[string]interface{}{
+				"timeout":               "5s",
+				"keymanager":            "http://localhost:7500",
+				"keymanager-token":      "token",
+				"keymanager-token-file": tokenFile,
+			},
+			err: "only one of keymanager-token and keymanager-token-file allowed",
+		},
+		{
+			name: "GraffitiTooLong",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"keymanager":       "http://localhost:7500",
+				"keymanager-token": "token",
+				"graffiti":         "123456789012345678901234567890123",
+			},
+			err: "graffiti cannot be longer than 32 bytes",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":               "5s",
+				"keymanager":            "http://localhost:7500",
+				"keymanager-token-file": tokenFile,
+				"graffiti":              "my graffiti",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
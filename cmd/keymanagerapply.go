@@ -0,0 +1,77 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	keymanagerapply "github.com/wealdtech/ethdo/cmd/keymanager/apply"
+)
+
+var keymanagerApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a declarative configuration to validators",
+	Long: `Apply a declarative configuration of graffiti and gas limits to validators through a validator client's keymanager API.  For example:
+
+    ethdo keymanager apply --keymanager=https://vc.example.com:7500 --keymanager-token-file=/path/to/api-token.txt --file=fleet.yml
+
+The configuration file is YAML, containing default values that apply to all validators in the keymanager and optional per-validator overrides keyed by public key or account:
+
+    default:
+      graffiti: "my fleet"
+      gas_limit: 30000000
+    validators:
+      0x8021...8bbe:
+        graffiti: "special validator"
+
+Only values that differ from those currently configured are changed.  With --dry-run the changes are reported but not made.
+
+In quiet mode this will return 0 if the configuration is applied, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := keymanagerapply.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	keymanagerCmd.AddCommand(keymanagerApplyCmd)
+	keymanagerFlags(keymanagerApplyCmd)
+	keymanagerApplyCmd.Flags().String("file", "", "the configuration file to apply")
+	keymanagerApplyCmd.Flags().Bool("dry-run", false, "report changes without making them")
+	keymanagerApplyCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func keymanagerApplyBindings(cmd *cobra.Command) {
+	keymanagerBindings(cmd)
+	if err := viper.BindPFlag("file", keymanagerApplyCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("dry-run", keymanagerApplyCmd.Flags().Lookup("dry-run")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", keymanagerApplyCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
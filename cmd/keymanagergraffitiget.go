@@ -0,0 +1,64 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	keymanagergraffitiget "github.com/wealdtech/ethdo/cmd/keymanager/graffiti/get"
+)
+
+var keymanagerGraffitiGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Obtain the graffiti for validators",
+	Long: `Obtain the graffiti for validators from a validator client's keymanager API.  For example:
+
+    ethdo keymanager graffiti get --keymanager=https://vc.example.com:7500 --keymanager-token-file=/path/to/api-token.txt --validators=0x8021...8bbe,0xa7b1...5c0d
+
+Validators can be supplied as public keys or accounts.  If no validators are supplied the graffiti for all validators in the keymanager is returned.
+
+In quiet mode this will return 0 if the graffiti is obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := keymanagergraffitiget.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	keymanagerGraffitiCmd.AddCommand(keymanagerGraffitiGetCmd)
+	keymanagerFlags(keymanagerGraffitiGetCmd)
+	keymanagerGraffitiGetCmd.Flags().StringSlice("validators", nil, "the validators for which to obtain graffiti (default all)")
+	keymanagerGraffitiGetCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func keymanagerGraffitiGetBindings(cmd *cobra.Command) {
+	keymanagerBindings(cmd)
+	if err := viper.BindPFlag("validators", keymanagerGraffitiGetCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", keymanagerGraffitiGetCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
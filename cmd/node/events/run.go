@@ -18,6 +18,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/wealdtech/ethdo/util"
 )
 
 // Run runs the wallet create data command.
@@ -25,7 +26,7 @@ func Run(cmd *cobra.Command) (string, error) {
 	ctx := context.Background()
 	dataIn, err := input(ctx)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to obtain input")
+		return "", util.NewValidationError(errors.Wrap(err, "failed to obtain input"))
 	}
 
 	// Further errors do not need a usage report.
@@ -0,0 +1,125 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodefleet
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	connections []string
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	allowInsecureConnections bool
+
+	// Output.
+	statuses []*nodeStatus
+}
+
+// nodeStatus is the status of a single beacon node in the fleet.
+type nodeStatus struct {
+	Connection   string       `json:"connection"`
+	Version      string       `json:"version,omitempty"`
+	HeadSlot     phase0.Slot  `json:"head_slot"`
+	SyncDistance phase0.Slot  `json:"sync_distance"`
+	Syncing      bool         `json:"syncing"`
+	Optimistic   bool         `json:"optimistic"`
+	ForkVersion  string       `json:"fork_version,omitempty"`
+	ForkEpoch    phase0.Epoch `json:"fork_epoch"`
+	ForkReady    bool         `json:"fork_ready"`
+	Peers        *uint64      `json:"peers,omitempty"`
+	PeersError   string       `json:"peers_error,omitempty"`
+	Error        string       `json:"error,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if viper.GetString("connections-file") == "" {
+		return nil, errors.New("connections-file is required")
+	}
+	var err error
+	c.connections, err = readConnectionsFile(viper.GetString("connections-file"))
+	if err != nil {
+		return nil, err
+	}
+	if len(c.connections) == 0 {
+		return nil, errors.New("no connections in connections file")
+	}
+
+	return c, nil
+}
+
+// readConnectionsFile reads connections from a file, one per line.
+// Empty lines and lines starting with '#' are ignored.
+func readConnectionsFile(name string) ([]string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open connections file")
+	}
+	defer file.Close()
+
+	connections := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		connections = append(connections, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read connections file")
+	}
+
+	return connections, nil
+}
+
+// reachable returns true if all nodes were probed successfully.
+func (c *command) reachable() bool {
+	for _, status := range c.statuses {
+		if status.Error != "" {
+			return false
+		}
+	}
+
+	return true
+}
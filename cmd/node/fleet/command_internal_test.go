@@ -0,0 +1,95 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodefleet
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	dir := t.TempDir()
+	goodFile := filepath.Join(dir, "good.txt")
+	require.NoError(t, os.WriteFile(goodFile, []byte("# Fleet\nhttp://node1:5052\n\n  http://node2:5052  \n"), 0o600))
+	emptyFile := filepath.Join(dir, "empty.txt")
+	require.NoError(t, os.WriteFile(emptyFile, []byte("# No nodes\n\n"), 0o600))
+
+	tests := []struct {
+		name        string
+		vars        map[string]interface{}
+		connections []string
+		err         string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"connections-file": goodFile,
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ConnectionsFileMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "connections-file is required",
+		},
+		{
+			name: "ConnectionsFileNotFound",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"connections-file": filepath.Join(dir, "missing.txt"),
+			},
+			err: "failed to open connections file: open " + filepath.Join(dir, "missing.txt") + ": no such file or directory",
+		},
+		{
+			name: "ConnectionsFileEmpty",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"connections-file": emptyFile,
+			},
+			err: "no connections in connections file",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"connections-file": goodFile,
+			},
+			connections: []string{"http://node1:5052", "http://node2:5052"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.connections, c.connections)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodefleet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.statuses)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := &strings.Builder{}
+	writer := tabwriter.NewWriter(builder, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "Connection\tVersion\tHead slot\tSync\tFork\tPeers")
+	for _, status := range c.statuses {
+		if status.Error != "" {
+			fmt.Fprintf(writer, "%s\tERROR: %s\n", status.Connection, status.Error)
+			continue
+		}
+
+		sync := "synced"
+		switch {
+		case status.Syncing:
+			sync = fmt.Sprintf("syncing (%d behind)", status.SyncDistance)
+		case status.Optimistic:
+			sync = "optimistic"
+		}
+
+		fork := fmt.Sprintf("%s@%d", status.ForkVersion, status.ForkEpoch)
+		if !status.ForkReady {
+			fork += " (not ready)"
+		}
+
+		peers := "-"
+		switch {
+		case status.Peers != nil:
+			peers = fmt.Sprintf("%d", *status.Peers)
+		case status.PeersError != "":
+			peers = fmt.Sprintf("ERROR: %s", status.PeersError)
+		}
+
+		version := status.Version
+		if !c.verbose {
+			// Versions can be long, so only show the client and its version.
+			parts := strings.Split(version, "/")
+			if len(parts) > 2 {
+				version = strings.Join(parts[:2], "/")
+			}
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%s\t%s\t%s\n", status.Connection, version, status.HeadSlot, sync, fork, peers)
+	}
+	if err := writer.Flush(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,59 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodefleet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	peers := uint64(56)
+	c := &command{
+		statuses: []*nodeStatus{
+			{
+				Connection:  "http://node1:5052",
+				Version:     "Lighthouse/v4.0.1-abcdef/x86_64-linux",
+				HeadSlot:    6000000,
+				ForkVersion: "0x03000000",
+				ForkEpoch:   194048,
+				ForkReady:   true,
+				Peers:       &peers,
+			},
+			{
+				Connection:   "http://node2:5052",
+				Version:      "teku/v23.3.1/linux-x86_64",
+				HeadSlot:     5999000,
+				SyncDistance: 1000,
+				Syncing:      true,
+				ForkVersion:  "0x02000000",
+				ForkEpoch:    144896,
+				PeersError:   "peer count not available",
+			},
+			{
+				Connection: "http://node3:5052",
+				Error:      "failed to connect to beacon node",
+			},
+		},
+	}
+
+	res, err := c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, `Connection         Version                   Head slot  Sync                   Fork                           Peers
+http://node1:5052  Lighthouse/v4.0.1-abcdef  6000000    synced                 0x03000000@194048              56
+http://node2:5052  teku/v23.3.1              5999000    syncing (1000 behind)  0x02000000@144896 (not ready)  ERROR: peer count not available
+http://node3:5052  ERROR: failed to connect to beacon node`, res)
+}
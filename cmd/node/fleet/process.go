@@ -0,0 +1,157 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodefleet
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	c.statuses = make([]*nodeStatus, len(c.connections))
+
+	var wg sync.WaitGroup
+	for i := range c.connections {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.statuses[i] = c.probe(ctx, c.connections[i])
+		}(i)
+	}
+	wg.Wait()
+
+	markForkReadiness(c.statuses)
+
+	return nil
+}
+
+// probe obtains the status of a single beacon node.  Failures are recorded
+// in the status rather than returned, so that a single node cannot prevent
+// reporting on the rest of the fleet.
+func (c *command) probe(ctx context.Context, connection string) *nodeStatus {
+	status := &nodeStatus{
+		Connection: connection,
+	}
+
+	client, err := util.ConnectToBeaconNode(ctx, connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	versionProvider, isProvider := client.(eth2client.NodeVersionProvider)
+	if !isProvider {
+		status.Error = "connection does not provide node version"
+		return status
+	}
+	status.Version, err = versionProvider.NodeVersion(ctx)
+	if err != nil {
+		status.Error = errors.Wrap(err, "failed to obtain node version").Error()
+		return status
+	}
+
+	syncingProvider, isProvider := client.(eth2client.NodeSyncingProvider)
+	if !isProvider {
+		status.Error = "connection does not provide sync state"
+		return status
+	}
+	syncState, err := syncingProvider.NodeSyncing(ctx)
+	if err != nil {
+		status.Error = errors.Wrap(err, "failed to obtain sync state").Error()
+		return status
+	}
+	status.HeadSlot = syncState.HeadSlot
+	status.SyncDistance = syncState.SyncDistance
+	status.Syncing = syncState.IsSyncing
+	status.Optimistic = syncState.IsOptimistic
+
+	forkScheduleProvider, isProvider := client.(eth2client.ForkScheduleProvider)
+	if !isProvider {
+		status.Error = "connection does not provide fork schedule"
+		return status
+	}
+	forkSchedule, err := forkScheduleProvider.ForkSchedule(ctx)
+	if err != nil {
+		status.Error = errors.Wrap(err, "failed to obtain fork schedule").Error()
+		return status
+	}
+	if len(forkSchedule) > 0 {
+		latestFork := forkSchedule[len(forkSchedule)-1]
+		status.ForkVersion = fmt.Sprintf("%#x", latestFork.CurrentVersion)
+		status.ForkEpoch = latestFork.Epoch
+	}
+
+	// Peer count is not available through the client, so obtain it directly.
+	peers, err := c.peerCount(ctx, connection)
+	if err != nil {
+		status.PeersError = err.Error()
+	} else {
+		status.Peers = &peers
+	}
+
+	return status
+}
+
+type peerCountJSON struct {
+	Data struct {
+		Connected string `json:"connected"`
+	} `json:"data"`
+}
+
+// peerCount obtains the number of connected peers for a beacon node.
+func (c *command) peerCount(ctx context.Context, address string) (uint64, error) {
+	data := &peerCountJSON{}
+	found, err := util.FetchBeaconNodeJSON(ctx, address, c.timeout, "/eth/v1/node/peer_count", nil, data)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain peer count")
+	}
+	if !found {
+		return 0, errors.New("peer count not available")
+	}
+	peers, err := strconv.ParseUint(data.Data.Connected, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid peer count")
+	}
+
+	return peers, nil
+}
+
+// markForkReadiness marks nodes as ready for the latest fork scheduled by
+// any node in the fleet.
+func markForkReadiness(statuses []*nodeStatus) {
+	var latest *nodeStatus
+	for _, status := range statuses {
+		if status.Error != "" || status.ForkVersion == "" {
+			continue
+		}
+		if latest == nil || status.ForkEpoch > latest.ForkEpoch {
+			latest = status
+		}
+	}
+	if latest == nil {
+		return
+	}
+
+	for _, status := range statuses {
+		status.ForkReady = status.Error == "" &&
+			status.ForkVersion == latest.ForkVersion &&
+			status.ForkEpoch == latest.ForkEpoch
+	}
+}
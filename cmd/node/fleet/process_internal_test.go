@@ -0,0 +1,93 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodefleet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/good/eth/v1/node/peer_count":
+			_, _ = w.Write([]byte(`{"data":{"disconnected":"12","connecting":"1","connected":"56","disconnecting":"0"}}`))
+		case "/invalid/eth/v1/node/peer_count":
+			_, _ = w.Write([]byte(`{"data":{"connected":"many"}}`))
+		case "/error/eth/v1/node/peer_count":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := &command{
+		timeout: 5 * time.Second,
+	}
+
+	peers, err := c.peerCount(context.Background(), server.URL+"/good/")
+	require.NoError(t, err)
+	require.Equal(t, uint64(56), peers)
+
+	// Addresses without a scheme are accepted, as for other connections.
+	peers, err = c.peerCount(context.Background(), strings.TrimPrefix(server.URL, "http://")+"/good")
+	require.NoError(t, err)
+	require.Equal(t, uint64(56), peers)
+
+	_, err = c.peerCount(context.Background(), server.URL+"/invalid")
+	require.EqualError(t, err, "invalid peer count: strconv.ParseUint: parsing \"many\": invalid syntax")
+
+	_, err = c.peerCount(context.Background(), server.URL+"/missing")
+	require.EqualError(t, err, "peer count not available")
+
+	_, err = c.peerCount(context.Background(), server.URL+"/error")
+	require.EqualError(t, err, "failed to obtain peer count: request for /eth/v1/node/peer_count returned status 500")
+}
+
+func TestMarkForkReadiness(t *testing.T) {
+	statuses := []*nodeStatus{
+		{
+			Connection:  "node1",
+			ForkVersion: "0x03000000",
+			ForkEpoch:   194048,
+		},
+		{
+			Connection:  "node2",
+			ForkVersion: "0x02000000",
+			ForkEpoch:   144896,
+		},
+		{
+			Connection:  "node3",
+			ForkVersion: "0x03000000",
+			ForkEpoch:   194048,
+		},
+		{
+			Connection: "node4",
+			Error:      "failed to connect to beacon node",
+		},
+	}
+
+	markForkReadiness(statuses)
+	require.True(t, statuses[0].ForkReady)
+	require.False(t, statuses[1].ForkReady)
+	require.True(t, statuses[2].ForkReady)
+	require.False(t, statuses[3].ForkReady)
+}
@@ -0,0 +1,128 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noderegression
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+// defaultValidators is the number of validators queried if none are supplied.
+const defaultValidators = 32
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	before     string
+	after      string
+	record     string
+	epoch      string
+	epochs     uint64
+	validators []phase0.ValidatorIndex
+
+	// Data access.
+	eth2Client eth2client.Service
+	chainTime  chaintime.Service
+
+	// Snapshots.
+	beforeSnapshot *snapshot
+	afterSnapshot  *snapshot
+
+	// Output.
+	differences []*difference
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:       viper.GetBool("quiet"),
+		verbose:     viper.GetBool("verbose"),
+		debug:       viper.GetBool("debug"),
+		json:        viper.GetBool("json"),
+		differences: make([]*difference, 0),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.before = viper.GetString("before")
+	c.after = viper.GetString("after")
+	if c.after == "" {
+		c.after = "live"
+	}
+	c.record = viper.GetString("record")
+	if c.before == "" && c.record == "" {
+		return nil, errors.New("before or record is required")
+	}
+	if c.after != "live" && c.record != "" {
+		return nil, errors.New("record requires the after snapshot to be live")
+	}
+
+	c.epoch = viper.GetString("epoch")
+	c.epochs = viper.GetUint64("epochs")
+	if c.epochs == 0 {
+		c.epochs = 1
+	}
+
+	validators := viper.GetStringSlice("validators")
+	if len(validators) == 0 {
+		c.validators = make([]phase0.ValidatorIndex, defaultValidators)
+		for i := range c.validators {
+			c.validators[i] = phase0.ValidatorIndex(i)
+		}
+	} else {
+		c.validators = make([]phase0.ValidatorIndex, len(validators))
+		for i := range validators {
+			index, err := strconv.ParseUint(validators[i], 10, 64)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid validator index")
+			}
+			c.validators[i] = phase0.ValidatorIndex(index)
+		}
+	}
+
+	return c, nil
+}
+
+// regressed returns true if any of the differences is a regression.
+func (c *command) regressed() bool {
+	for _, difference := range c.differences {
+		if difference.Regression {
+			return true
+		}
+	}
+
+	return false
+}
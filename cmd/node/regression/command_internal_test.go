@@ -0,0 +1,95 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noderegression
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"before": "before.json",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "BeforeMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "before or record is required",
+		},
+		{
+			name: "RecordNotLive",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"record":  "snapshot.json",
+				"after":   "after.json",
+			},
+			err: "record requires the after snapshot to be live",
+		},
+		{
+			name: "ValidatorsInvalid",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"before":     "before.json",
+				"validators": []string{"1", "two"},
+			},
+			err: "invalid validator index: strconv.ParseUint: parsing \"two\": invalid syntax",
+		},
+		{
+			name: "Record",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"record":  "snapshot.json",
+			},
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"before":     "before.json",
+				"after":      "after.json",
+				"validators": []string{"1", "2"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
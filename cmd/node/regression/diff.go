@@ -0,0 +1,158 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noderegression
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// maxValueLength is the maximum length of a value shown in a difference.
+const maxValueLength = 80
+
+// difference is a difference between the responses to a query.  Differences
+// that are not regressions, such as new fields or newly-answered queries, are
+// reported for information.
+type difference struct {
+	Query      string `json:"query"`
+	Path       string `json:"path,omitempty"`
+	Before     string `json:"before"`
+	After      string `json:"after"`
+	Regression bool   `json:"regression"`
+}
+
+// compareSnapshots compares the responses of two snapshots.
+func compareSnapshots(before *snapshot, after *snapshot) []*difference {
+	names := make([]string, 0, len(before.Queries))
+	for name := range before.Queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	res := make([]*difference, 0)
+	for _, name := range names {
+		beforeResponse := before.Queries[name]
+		afterResponse, exists := after.Queries[name]
+		switch {
+		case !exists:
+			res = append(res, &difference{Query: name, Before: "answered", After: "not queried", Regression: true})
+		case beforeResponse.Error != "" && afterResponse.Error != "":
+			// Neither node could answer the query, so nothing to compare.
+		case beforeResponse.Error != "":
+			res = append(res, &difference{Query: name, Before: fmt.Sprintf("error: %s", beforeResponse.Error), After: "answered"})
+		case afterResponse.Error != "":
+			res = append(res, &difference{Query: name, Before: "answered", After: fmt.Sprintf("error: %s", afterResponse.Error), Regression: true})
+		default:
+			beforeValue, err := decode(beforeResponse.Result)
+			if err != nil {
+				res = append(res, &difference{Query: name, Before: fmt.Sprintf("invalid: %v", err), After: "answered"})
+				continue
+			}
+			afterValue, err := decode(afterResponse.Result)
+			if err != nil {
+				res = append(res, &difference{Query: name, Before: "answered", After: fmt.Sprintf("invalid: %v", err), Regression: true})
+				continue
+			}
+			res = diffValues(res, name, "", beforeValue, afterValue)
+		}
+	}
+
+	return res
+}
+
+// diffValues adds the differences between two decoded JSON values.
+func diffValues(res []*difference, query string, path string, before interface{}, after interface{}) []*difference {
+	switch beforeValue := before.(type) {
+	case map[string]interface{}:
+		afterValue, isMap := after.(map[string]interface{})
+		if !isMap {
+			return append(res, &difference{Query: query, Path: path, Before: render(before), After: render(after), Regression: true})
+		}
+		for _, key := range sortedKeys(beforeValue) {
+			value, exists := afterValue[key]
+			if !exists {
+				res = append(res, &difference{Query: query, Path: joinPath(path, key), Before: render(beforeValue[key]), After: "missing", Regression: true})
+				continue
+			}
+			res = diffValues(res, query, joinPath(path, key), beforeValue[key], value)
+		}
+		for _, key := range sortedKeys(afterValue) {
+			if _, exists := beforeValue[key]; !exists {
+				res = append(res, &difference{Query: query, Path: joinPath(path, key), Before: "missing", After: render(afterValue[key])})
+			}
+		}
+	case []interface{}:
+		afterValue, isSlice := after.([]interface{})
+		if !isSlice {
+			return append(res, &difference{Query: query, Path: path, Before: render(before), After: render(after), Regression: true})
+		}
+		if len(beforeValue) != len(afterValue) {
+			return append(res, &difference{Query: query, Path: path, Before: fmt.Sprintf("%d items", len(beforeValue)), After: fmt.Sprintf("%d items", len(afterValue)), Regression: true})
+		}
+		for i := range beforeValue {
+			res = diffValues(res, query, fmt.Sprintf("%s[%d]", path, i), beforeValue[i], afterValue[i])
+		}
+	default:
+		if !reflect.DeepEqual(before, after) {
+			res = append(res, &difference{Query: query, Path: path, Before: render(before), After: render(after), Regression: true})
+		}
+	}
+
+	return res
+}
+
+func decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Keep numbers as they are, to avoid loss of precision for large values.
+	decoder.UseNumber()
+	var res interface{}
+	if err := decoder.Decode(&res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func render(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	if len(data) > maxValueLength {
+		return fmt.Sprintf("%s...", string(data[:maxValueLength]))
+	}
+
+	return string(data)
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return fmt.Sprintf("%s.%s", path, key)
+}
+
+func sortedKeys(value map[string]interface{}) []string {
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
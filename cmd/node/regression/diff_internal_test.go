@@ -0,0 +1,111 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noderegression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareSnapshots(t *testing.T) {
+	tests := []struct {
+		name        string
+		before      map[string]*queryResponse
+		after       map[string]*queryResponse
+		differences []*difference
+	}{
+		{
+			name: "Same",
+			before: map[string]*queryResponse{
+				"spec": {Result: []byte(`{"SECONDS_PER_SLOT":"12","SLOTS_PER_EPOCH":"32"}`)},
+			},
+			after: map[string]*queryResponse{
+				"spec": {Result: []byte(`{"SLOTS_PER_EPOCH":"32","SECONDS_PER_SLOT":"12"}`)},
+			},
+			differences: []*difference{},
+		},
+		{
+			name: "BothErrored",
+			before: map[string]*queryResponse{
+				"proposer_duties/1": {Error: "not available"},
+			},
+			after: map[string]*queryResponse{
+				"proposer_duties/1": {Error: "still not available"},
+			},
+			differences: []*difference{},
+		},
+		{
+			name: "ValueChanged",
+			before: map[string]*queryResponse{
+				"attester_duties/1": {Result: []byte(`[{"slot":"32","validator_index":"1"}]`)},
+			},
+			after: map[string]*queryResponse{
+				"attester_duties/1": {Result: []byte(`[{"slot":"33","validator_index":"1"}]`)},
+			},
+			differences: []*difference{
+				{Query: "attester_duties/1", Path: "[0].slot", Before: `"32"`, After: `"33"`, Regression: true},
+			},
+		},
+		{
+			name: "LengthChanged",
+			before: map[string]*queryResponse{
+				"fork_schedule": {Result: []byte(`[1,2]`)},
+			},
+			after: map[string]*queryResponse{
+				"fork_schedule": {Result: []byte(`[1,2,3]`)},
+			},
+			differences: []*difference{
+				{Query: "fork_schedule", Before: "2 items", After: "3 items", Regression: true},
+			},
+		},
+		{
+			name: "KeyAddedAndRemoved",
+			before: map[string]*queryResponse{
+				"spec": {Result: []byte(`{"A":"1","B":"2"}`)},
+			},
+			after: map[string]*queryResponse{
+				"spec": {Result: []byte(`{"A":"1","C":"3"}`)},
+			},
+			differences: []*difference{
+				{Query: "spec", Path: "B", Before: `"2"`, After: "missing", Regression: true},
+				{Query: "spec", Path: "C", Before: "missing", After: `"3"`},
+			},
+		},
+		{
+			name: "Errors",
+			before: map[string]*queryResponse{
+				"genesis":   {Result: []byte(`{}`)},
+				"rewards/1": {Error: "state not available"},
+				"spec":      {Result: []byte(`{}`)},
+			},
+			after: map[string]*queryResponse{
+				"genesis":   {Error: "internal error"},
+				"rewards/1": {Result: []byte(`{}`)},
+			},
+			differences: []*difference{
+				{Query: "genesis", Before: "answered", After: "error: internal error", Regression: true},
+				{Query: "rewards/1", Before: "error: state not available", After: "answered"},
+				{Query: "spec", Before: "answered", After: "not queried", Regression: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			differences := compareSnapshots(&snapshot{Queries: test.before}, &snapshot{Queries: test.after})
+			require.Equal(t, test.differences, differences)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noderegression
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type jsonOutput struct {
+	Regression    bool          `json:"regression"`
+	BeforeVersion string        `json:"before_version,omitempty"`
+	AfterVersion  string        `json:"after_version"`
+	Queries       int           `json:"queries"`
+	Differences   []*difference `json:"differences"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Regression:   c.regressed(),
+		AfterVersion: c.afterSnapshot.NodeVersion,
+		Queries:      len(c.afterSnapshot.Queries),
+		Differences:  c.differences,
+	}
+	if c.beforeSnapshot != nil {
+		output.BeforeVersion = c.beforeSnapshot.NodeVersion
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.beforeSnapshot == nil {
+		builder.WriteString(fmt.Sprintf("Snapshot of %d queries against %s written to %s", len(c.afterSnapshot.Queries), c.afterSnapshot.NodeVersion, c.record))
+		return builder.String(), nil
+	}
+
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Before: %s (%s)\n", c.beforeSnapshot.NodeVersion, c.beforeSnapshot.CreatedAt.Format("2006-01-02 15:04:05")))
+		builder.WriteString(fmt.Sprintf("After: %s (%s)\n", c.afterSnapshot.NodeVersion, c.afterSnapshot.CreatedAt.Format("2006-01-02 15:04:05")))
+	}
+
+	regressions := 0
+	for _, difference := range c.differences {
+		if difference.Regression {
+			regressions++
+		} else if !c.verbose {
+			continue
+		}
+		name := difference.Query
+		if difference.Path != "" {
+			name = fmt.Sprintf("%s %s", difference.Query, difference.Path)
+		}
+		label := "changed"
+		if difference.Regression {
+			label = "REGRESSION"
+		}
+		builder.WriteString(fmt.Sprintf("%s: %s: %s -> %s\n", label, name, difference.Before, difference.After))
+	}
+
+	if regressions == 0 {
+		builder.WriteString(fmt.Sprintf("No regressions found in %d queries", len(c.beforeSnapshot.Queries)))
+	} else {
+		builder.WriteString(fmt.Sprintf("%d regressions found in %d queries", regressions, len(c.beforeSnapshot.Queries)))
+	}
+
+	return builder.String(), nil
+}
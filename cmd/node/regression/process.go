@@ -0,0 +1,152 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noderegression
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	var err error
+
+	if c.before != "" {
+		c.beforeSnapshot, err = readSnapshot(c.before)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain before snapshot")
+		}
+	}
+
+	if c.after == "live" {
+		if err := c.setup(ctx); err != nil {
+			return err
+		}
+
+		// Queries must match those of the before snapshot for the answers to be comparable.
+		var epochs []phase0.Epoch
+		validators := c.validators
+		if c.beforeSnapshot != nil {
+			epochs = c.beforeSnapshot.Epochs
+			validators = c.beforeSnapshot.Validators
+		}
+		if len(epochs) == 0 {
+			epochs, err = c.queryEpochs(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		util.Log.Debug().Interface("epochs", epochs).Int("validators", len(validators)).Msg("Querying epochs")
+
+		c.afterSnapshot, err = c.takeSnapshot(ctx, epochs, validators)
+		if err != nil {
+			return errors.Wrap(err, "failed to take snapshot")
+		}
+		if c.record != "" {
+			if err := writeSnapshot(c.record, c.afterSnapshot); err != nil {
+				return err
+			}
+		}
+	} else {
+		c.afterSnapshot, err = readSnapshot(c.after)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain after snapshot")
+		}
+	}
+
+	if c.beforeSnapshot != nil {
+		c.differences = compareSnapshots(c.beforeSnapshot, c.afterSnapshot)
+	}
+
+	return nil
+}
+
+// queryEpochs returns the epochs to query.  By default these are the most
+// recent epochs for which rewards are finalized, so that the answers do not
+// change between snapshots.
+func (c *command) queryEpochs(ctx context.Context) ([]phase0.Epoch, error) {
+	var start phase0.Epoch
+	if c.epoch == "" {
+		finality, err := c.eth2Client.(eth2client.FinalityProvider).Finality(ctx, "head")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain finality")
+		}
+		if finality == nil || finality.Finalized == nil {
+			return nil, errors.New("finality not returned")
+		}
+		if uint64(finality.Finalized.Epoch) < c.epochs {
+			return nil, errors.New("not enough finalized epochs to query")
+		}
+		start = finality.Finalized.Epoch - phase0.Epoch(c.epochs)
+	} else {
+		var err error
+		start, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	epochs := make([]phase0.Epoch, c.epochs)
+	for i := range epochs {
+		epochs[i] = start + phase0.Epoch(i)
+	}
+
+	return epochs, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	if _, isProvider := c.eth2Client.(eth2client.NodeVersionProvider); !isProvider {
+		return errors.New("connection does not provide node version")
+	}
+	if _, isProvider := c.eth2Client.(eth2client.GenesisProvider); !isProvider {
+		return errors.New("connection does not provide genesis")
+	}
+	if _, isProvider := c.eth2Client.(eth2client.ForkScheduleProvider); !isProvider {
+		return errors.New("connection does not provide fork schedule")
+	}
+	if _, isProvider := c.eth2Client.(eth2client.FinalityProvider); !isProvider {
+		return errors.New("connection does not provide finality")
+	}
+	if _, isProvider := c.eth2Client.(eth2client.AttesterDutiesProvider); !isProvider {
+		return errors.New("connection does not provide attester duties")
+	}
+	if _, isProvider := c.eth2Client.(eth2client.ProposerDutiesProvider); !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	if _, isProvider := c.eth2Client.(eth2client.ValidatorBalancesProvider); !isProvider {
+		return errors.New("connection does not provide validator balances")
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noderegression
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	before := filepath.Join(dir, "before.json")
+	require.NoError(t, os.WriteFile(before, []byte(`{"node_version":"node/v1","queries":{"genesis":{"result":{"genesis_time":"1606824023"}}}}`), 0o600))
+	same := filepath.Join(dir, "same.json")
+	require.NoError(t, os.WriteFile(same, []byte(`{"node_version":"node/v2","queries":{"genesis":{"result":{"genesis_time":"1606824023"}}}}`), 0o600))
+	different := filepath.Join(dir, "different.json")
+	require.NoError(t, os.WriteFile(different, []byte(`{"node_version":"node/v2","queries":{"genesis":{"result":{"genesis_time":"1606824024"}}}}`), 0o600))
+	empty := filepath.Join(dir, "empty.json")
+	require.NoError(t, os.WriteFile(empty, []byte(`{"node_version":"node/v2"}`), 0o600))
+
+	tests := []struct {
+		name      string
+		before    string
+		after     string
+		regressed bool
+		err       string
+	}{
+		{
+			name:   "BeforeMissing",
+			before: filepath.Join(dir, "missing.json"),
+			after:  same,
+			err:    "failed to obtain before snapshot: failed to read snapshot: open " + filepath.Join(dir, "missing.json") + ": no such file or directory",
+		},
+		{
+			name:   "AfterEmpty",
+			before: before,
+			after:  empty,
+			err:    "failed to obtain after snapshot: snapshot contains no queries",
+		},
+		{
+			name:   "Same",
+			before: before,
+			after:  same,
+		},
+		{
+			name:      "Different",
+			before:    before,
+			after:     different,
+			regressed: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				before: test.before,
+				after:  test.after,
+			}
+			err := c.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.regressed, c.regressed())
+			}
+		})
+	}
+}
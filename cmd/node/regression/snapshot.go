@@ -0,0 +1,169 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noderegression
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// snapshot is the set of answers provided by a node to a fixed set of queries.
+type snapshot struct {
+	CreatedAt   time.Time                 `json:"created_at"`
+	NodeVersion string                    `json:"node_version"`
+	Epochs      []phase0.Epoch            `json:"epochs"`
+	Validators  []phase0.ValidatorIndex   `json:"validators"`
+	Queries     map[string]*queryResponse `json:"queries"`
+}
+
+// queryResponse is the response to a single query, which is either a result
+// or an error.
+type queryResponse struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// readSnapshot reads a snapshot from a file.
+func readSnapshot(filename string) (*snapshot, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read snapshot")
+	}
+	res := &snapshot{}
+	if err := json.Unmarshal(data, res); err != nil {
+		return nil, errors.Wrap(err, "failed to parse snapshot")
+	}
+	if len(res.Queries) == 0 {
+		return nil, errors.New("snapshot contains no queries")
+	}
+
+	return res, nil
+}
+
+// writeSnapshot writes a snapshot to a file.
+func writeSnapshot(filename string, s *snapshot) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal snapshot")
+	}
+	if err := os.WriteFile(filename, data, 0o600); err != nil {
+		return errors.Wrap(err, "failed to write snapshot")
+	}
+
+	return nil
+}
+
+// takeSnapshot runs the queries against the connected node.
+func (c *command) takeSnapshot(ctx context.Context, epochs []phase0.Epoch, validators []phase0.ValidatorIndex) (*snapshot, error) {
+	res := &snapshot{
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+		Epochs:     epochs,
+		Validators: validators,
+		Queries:    make(map[string]*queryResponse),
+	}
+
+	nodeVersion, err := c.eth2Client.(eth2client.NodeVersionProvider).NodeVersion(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain node version")
+	}
+	res.NodeVersion = nodeVersion
+
+	res.add("spec", func() (interface{}, error) {
+		return c.eth2Client.(eth2client.SpecProvider).Spec(ctx)
+	})
+	res.add("genesis", func() (interface{}, error) {
+		return c.eth2Client.(eth2client.GenesisProvider).Genesis(ctx)
+	})
+	res.add("fork_schedule", func() (interface{}, error) {
+		return c.eth2Client.(eth2client.ForkScheduleProvider).ForkSchedule(ctx)
+	})
+
+	for _, epoch := range epochs {
+		epoch := epoch
+		res.add(fmt.Sprintf("attester_duties/%d", epoch), func() (interface{}, error) {
+			duties, err := c.eth2Client.(eth2client.AttesterDutiesProvider).AttesterDuties(ctx, epoch, validators)
+			if err != nil {
+				return nil, err
+			}
+			// Order is not defined by the API, so sort to avoid false differences.
+			sort.Slice(duties, func(i int, j int) bool {
+				return duties[i].ValidatorIndex < duties[j].ValidatorIndex
+			})
+			return duties, nil
+		})
+		res.add(fmt.Sprintf("proposer_duties/%d", epoch), func() (interface{}, error) {
+			duties, err := c.eth2Client.(eth2client.ProposerDutiesProvider).ProposerDuties(ctx, epoch, nil)
+			if err != nil {
+				return nil, err
+			}
+			sort.Slice(duties, func(i int, j int) bool {
+				return duties[i].Slot < duties[j].Slot
+			})
+			return duties, nil
+		})
+		res.add(fmt.Sprintf("rewards/%d", epoch), func() (interface{}, error) {
+			return c.rewards(ctx, epoch, validators)
+		})
+	}
+
+	return res, nil
+}
+
+// add runs a query and adds its response to the snapshot.
+func (s *snapshot) add(name string, query func() (interface{}, error)) {
+	result, err := query()
+	if err != nil {
+		s.Queries[name] = &queryResponse{Error: err.Error()}
+		return
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		s.Queries[name] = &queryResponse{Error: fmt.Sprintf("failed to marshal result: %v", err)}
+		return
+	}
+	s.Queries[name] = &queryResponse{Result: data}
+}
+
+// rewards calculates the rewards for the validators in the given epoch, as the
+// change in their balances over the epoch.
+func (c *command) rewards(ctx context.Context, epoch phase0.Epoch, validators []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]int64, error) {
+	provider := c.eth2Client.(eth2client.ValidatorBalancesProvider)
+	startBalances, err := provider.ValidatorBalances(ctx, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch)), validators)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain balances at start of epoch")
+	}
+	endBalances, err := provider.ValidatorBalances(ctx, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch+1)), validators)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain balances at end of epoch")
+	}
+
+	res := make(map[phase0.ValidatorIndex]int64, len(startBalances))
+	for index, startBalance := range startBalances {
+		endBalance, exists := endBalances[index]
+		if !exists {
+			continue
+		}
+		res[index] = int64(endBalance) - int64(startBalance)
+	}
+
+	return res, nil
+}
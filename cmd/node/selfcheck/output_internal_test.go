@@ -0,0 +1,80 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeselfcheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Passed",
+			command: &command{
+				checks: []*check{
+					{Name: "Check 1", Passed: true},
+					{Name: "Check 2", Passed: true, Detail: "2 validators compared"},
+				},
+			},
+			res: "Check 1: passed\nCheck 2: passed",
+		},
+		{
+			name: "PassedVerbose",
+			command: &command{
+				verbose: true,
+				checks: []*check{
+					{Name: "Check 1", Passed: true},
+					{Name: "Check 2", Passed: true, Detail: "2 validators compared"},
+				},
+			},
+			res: "Check 1: passed\nCheck 2: passed (2 validators compared)",
+		},
+		{
+			name: "Failed",
+			command: &command{
+				checks: []*check{
+					{Name: "Check 1", Passed: true},
+					{Name: "Check 2", Passed: false, Detail: "bad root"},
+				},
+			},
+			res: "Check 1: passed\nCheck 2: FAILED (bad root)",
+		},
+		{
+			name: "JSON",
+			command: &command{
+				json: true,
+				checks: []*check{
+					{Name: "Check 1", Passed: true},
+					{Name: "Check 2", Passed: false, Detail: "bad root"},
+				},
+			},
+			res: `{"passed":false,"checks":[{"name":"Check 1","passed":true},{"name":"Check 2","passed":false,"detail":"bad root"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeselfcheck
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// validatorSampleSize is the number of validators compared between endpoints.
+const validatorSampleSize = 32
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	// Everything is checked against a fixed head, to avoid a moving chain
+	// resulting in false failures.
+	header, err := c.beaconBlockHeadersProvider.BeaconBlockHeader(ctx, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain head header")
+	}
+	if header == nil || header.Header == nil || header.Header.Message == nil {
+		return errors.New("head header not returned")
+	}
+	util.Log.Debug().Str("root", fmt.Sprintf("%#x", header.Root)).Uint64("slot", uint64(header.Header.Message.Slot)).Msg("Checking against head block")
+
+	c.checkHeaderRoot(ctx, header)
+
+	block, err := c.signedBeaconBlockProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%#x", header.Root))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain head block")
+	}
+	if block == nil {
+		return errors.New("head block not returned")
+	}
+	c.checkBlockRoot(ctx, header, block)
+	c.checkBlockContents(ctx, header, block)
+
+	if err := c.checkStateRoot(ctx, header); err != nil {
+		return err
+	}
+	if err := c.checkValidators(ctx, header); err != nil {
+		return err
+	}
+	if err := c.checkFinality(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *command) addCheck(name string, passed bool, detail string) {
+	c.checks = append(c.checks, &check{
+		Name:   name,
+		Passed: passed,
+		Detail: detail,
+	})
+}
+
+// checkHeaderRoot checks that the root supplied with the header matches the header itself.
+func (c *command) checkHeaderRoot(_ context.Context, header *apiv1.BeaconBlockHeader) {
+	name := "Header root matches header contents"
+	root, err := header.Header.Message.HashTreeRoot()
+	if err != nil {
+		c.addCheck(name, false, fmt.Sprintf("failed to calculate header root: %v", err))
+		return
+	}
+	if !bytes.Equal(root[:], header.Root[:]) {
+		c.addCheck(name, false, fmt.Sprintf("header root %#x, calculated root %#x", header.Root, root))
+		return
+	}
+	c.addCheck(name, true, "")
+}
+
+// checkBlockRoot checks that the root of the block matches the root of the header.
+func (c *command) checkBlockRoot(_ context.Context, header *apiv1.BeaconBlockHeader, block *spec.VersionedSignedBeaconBlock) {
+	name := "Block root matches header root"
+	root, err := block.Root()
+	if err != nil {
+		c.addCheck(name, false, fmt.Sprintf("failed to calculate block root: %v", err))
+		return
+	}
+	if !bytes.Equal(root[:], header.Root[:]) {
+		c.addCheck(name, false, fmt.Sprintf("header root %#x, block root %#x", header.Root, root))
+		return
+	}
+	c.addCheck(name, true, "")
+}
+
+// checkBlockContents checks that the fields of the block match those of the header.
+func (c *command) checkBlockContents(_ context.Context, header *apiv1.BeaconBlockHeader, block *spec.VersionedSignedBeaconBlock) {
+	name := "Block contents match header"
+	slot, err := block.Slot()
+	if err != nil {
+		c.addCheck(name, false, fmt.Sprintf("failed to obtain block slot: %v", err))
+		return
+	}
+	if slot != header.Header.Message.Slot {
+		c.addCheck(name, false, fmt.Sprintf("header slot %d, block slot %d", header.Header.Message.Slot, slot))
+		return
+	}
+	parentRoot, err := block.ParentRoot()
+	if err != nil {
+		c.addCheck(name, false, fmt.Sprintf("failed to obtain block parent root: %v", err))
+		return
+	}
+	if !bytes.Equal(parentRoot[:], header.Header.Message.ParentRoot[:]) {
+		c.addCheck(name, false, fmt.Sprintf("header parent root %#x, block parent root %#x", header.Header.Message.ParentRoot, parentRoot))
+		return
+	}
+	stateRoot, err := block.StateRoot()
+	if err != nil {
+		c.addCheck(name, false, fmt.Sprintf("failed to obtain block state root: %v", err))
+		return
+	}
+	if !bytes.Equal(stateRoot[:], header.Header.Message.StateRoot[:]) {
+		c.addCheck(name, false, fmt.Sprintf("header state root %#x, block state root %#x", header.Header.Message.StateRoot, stateRoot))
+		return
+	}
+	bodyRoot, err := block.BodyRoot()
+	if err != nil {
+		c.addCheck(name, false, fmt.Sprintf("failed to obtain block body root: %v", err))
+		return
+	}
+	if !bytes.Equal(bodyRoot[:], header.Header.Message.BodyRoot[:]) {
+		c.addCheck(name, false, fmt.Sprintf("header body root %#x, block body root %#x", header.Header.Message.BodyRoot, bodyRoot))
+		return
+	}
+	c.addCheck(name, true, "")
+}
+
+// checkStateRoot checks that the state root for the header's slot matches that in the header.
+func (c *command) checkStateRoot(ctx context.Context, header *apiv1.BeaconBlockHeader) error {
+	name := "State root matches header"
+	stateRoot, err := c.beaconStateRootProvider.BeaconStateRoot(ctx, fmt.Sprintf("%d", header.Header.Message.Slot))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain state root")
+	}
+	if stateRoot == nil {
+		c.addCheck(name, false, "no state root returned")
+		return nil
+	}
+	if !bytes.Equal(stateRoot[:], header.Header.Message.StateRoot[:]) {
+		c.addCheck(name, false, fmt.Sprintf("header state root %#x, state root %#x", header.Header.Message.StateRoot, *stateRoot))
+		return nil
+	}
+	c.addCheck(name, true, "")
+
+	return nil
+}
+
+// checkValidators checks that validator information is the same when requested by state root and by slot.
+func (c *command) checkValidators(ctx context.Context, header *apiv1.BeaconBlockHeader) error {
+	name := "Validators consistent between state root and slot"
+	indices := make([]phase0.ValidatorIndex, validatorSampleSize)
+	for i := range indices {
+		indices[i] = phase0.ValidatorIndex(i)
+	}
+
+	byRoot, err := c.validatorsProvider.Validators(ctx, fmt.Sprintf("%#x", header.Header.Message.StateRoot), indices)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators by state root")
+	}
+	bySlot, err := c.validatorsProvider.Validators(ctx, fmt.Sprintf("%d", header.Header.Message.Slot), indices)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators by slot")
+	}
+
+	if len(byRoot) != len(bySlot) {
+		c.addCheck(name, false, fmt.Sprintf("%d validators by state root, %d validators by slot", len(byRoot), len(bySlot)))
+		return nil
+	}
+	for index, validator := range byRoot {
+		other, exists := bySlot[index]
+		if !exists {
+			c.addCheck(name, false, fmt.Sprintf("validator %d missing when fetched by slot", index))
+			return nil
+		}
+		if validator.Validator == nil || other.Validator == nil {
+			c.addCheck(name, false, fmt.Sprintf("validator %d missing data", index))
+			return nil
+		}
+		if !bytes.Equal(validator.Validator.PublicKey[:], other.Validator.PublicKey[:]) ||
+			validator.Balance != other.Balance ||
+			validator.Status != other.Status {
+			c.addCheck(name, false, fmt.Sprintf("validator %d differs between state root and slot", index))
+			return nil
+		}
+	}
+	c.addCheck(name, true, fmt.Sprintf("%d validators compared", len(byRoot)))
+
+	return nil
+}
+
+// checkFinality checks that the finality checkpoints are consistent with the headers.
+func (c *command) checkFinality(ctx context.Context) error {
+	finality, err := c.finalityProvider.Finality(ctx, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain finality")
+	}
+	if finality == nil || finality.Finalized == nil || finality.Justified == nil {
+		return errors.New("finality not returned")
+	}
+
+	name := "Finalized checkpoint matches finalized header"
+	finalizedHeader, err := c.beaconBlockHeadersProvider.BeaconBlockHeader(ctx, "finalized")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain finalized header")
+	}
+	switch {
+	case finalizedHeader == nil || finalizedHeader.Header == nil || finalizedHeader.Header.Message == nil:
+		c.addCheck(name, false, "no finalized header returned")
+	case !bytes.Equal(finalizedHeader.Root[:], finality.Finalized.Root[:]):
+		c.addCheck(name, false, fmt.Sprintf("finalized checkpoint root %#x, finalized header root %#x", finality.Finalized.Root, finalizedHeader.Root))
+	case c.chainTime.SlotToEpoch(finalizedHeader.Header.Message.Slot) > finality.Finalized.Epoch:
+		c.addCheck(name, false, fmt.Sprintf("finalized header slot %d is after finalized checkpoint epoch %d", finalizedHeader.Header.Message.Slot, finality.Finalized.Epoch))
+	default:
+		c.addCheck(name, true, "")
+	}
+
+	name = "Justified checkpoint consistent with finalized checkpoint"
+	if finality.Justified.Epoch < finality.Finalized.Epoch {
+		c.addCheck(name, false, fmt.Sprintf("justified epoch %d is before finalized epoch %d", finality.Justified.Epoch, finality.Finalized.Epoch))
+		return nil
+	}
+	justifiedHeader, err := c.beaconBlockHeadersProvider.BeaconBlockHeader(ctx, fmt.Sprintf("%#x", finality.Justified.Root))
+	if err != nil {
+		c.addCheck(name, false, fmt.Sprintf("justified checkpoint block %#x not available: %v", finality.Justified.Root, err))
+		return nil
+	}
+	if justifiedHeader == nil || justifiedHeader.Header == nil || justifiedHeader.Header.Message == nil {
+		c.addCheck(name, false, fmt.Sprintf("justified checkpoint block %#x not returned", finality.Justified.Root))
+		return nil
+	}
+	if c.chainTime.SlotToEpoch(justifiedHeader.Header.Message.Slot) > finality.Justified.Epoch {
+		c.addCheck(name, false, fmt.Sprintf("justified header slot %d is after justified checkpoint epoch %d", justifiedHeader.Header.Message.Slot, finality.Justified.Epoch))
+		return nil
+	}
+	c.addCheck(name, true, "")
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.beaconBlockHeadersProvider, isProvider = c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon block headers")
+	}
+	c.signedBeaconBlockProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	c.beaconStateRootProvider, isProvider = c.eth2Client.(eth2client.BeaconStateRootProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon state roots")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.finalityProvider, isProvider = c.eth2Client.(eth2client.FinalityProvider)
+	if !isProvider {
+		return errors.New("connection does not provide finality information")
+	}
+
+	return nil
+}
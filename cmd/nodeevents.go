@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	nodeevents "github.com/wealdtech/ethdo/cmd/node/events"
@@ -33,7 +31,9 @@ var nodeEventsCmd = &cobra.Command{
 			return err
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -0,0 +1,57 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	nodefleet "github.com/wealdtech/ethdo/cmd/node/fleet"
+)
+
+var nodeFleetCmd = &cobra.Command{
+	Use:   "fleet",
+	Short: "Obtain an overview of a fleet of nodes",
+	Long: `Obtain an overview of a fleet of nodes, reporting the version, sync status, head slot, fork readiness and peer count of each.  For example:
+
+    ethdo node fleet --connections-file=nodes.txt
+
+The connections file contains one connection per line.  A node is considered ready for forks if it has the latest fork scheduled by any node in the fleet.
+
+In quiet mode this will return 0 if all nodes could be probed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := nodefleet.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	nodeCmd.AddCommand(nodeFleetCmd)
+	nodeFlags(nodeFleetCmd)
+	nodeFleetCmd.Flags().String("connections-file", "", "File containing the connections to the nodes in the fleet, one per line")
+	nodeFleetCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func nodeFleetBindings() {
+	if err := viper.BindPFlag("connections-file", nodeFleetCmd.Flags().Lookup("connections-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", nodeFleetCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -16,7 +16,6 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/spf13/cobra"
@@ -39,7 +38,7 @@ In quiet mode this will return 0 if the node information can be obtained, otherw
 		errCheck(err, "Failed to connect to Ethereum 2 beacon node")
 
 		if quiet {
-			os.Exit(_exitSuccess)
+			exit(_exitSuccess)
 		}
 
 		if verbose {
@@ -52,7 +51,7 @@ In quiet mode this will return 0 if the node information can be obtained, otherw
 		errCheck(err, "failed to obtain node sync state")
 		fmt.Printf("Syncing: %t\n", syncState.SyncDistance != 0)
 
-		os.Exit(_exitSuccess)
+		exit(_exitSuccess)
 	},
 }
 
@@ -0,0 +1,81 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	noderegression "github.com/wealdtech/ethdo/cmd/node/regression"
+)
+
+var nodeRegressionCmd = &cobra.Command{
+	Use:   "regression",
+	Short: "Check a node for regressions against a snapshot",
+	Long: `Check a node for regressions against a snapshot of answers to a set of queries.  Before upgrading a beacon node, record a snapshot:
+
+    ethdo node regression --record=before.json
+
+and after upgrading compare the answers from the upgraded node against it:
+
+    ethdo node regression --before=before.json
+
+Queries include the chain spec, genesis, fork schedule, and attester duties, proposer duties and rewards for finalized epochs.  Answers that have changed or are no longer available are reported as regressions; new fields and queries that could not previously be answered are reported with --verbose.  Snapshots can also be compared with each other using --after=<snapshot>.
+
+In quiet mode this will return 0 if no regressions are found, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := noderegression.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	nodeCmd.AddCommand(nodeRegressionCmd)
+	nodeFlags(nodeRegressionCmd)
+	nodeRegressionCmd.Flags().String("before", "", "the snapshot from before the upgrade")
+	nodeRegressionCmd.Flags().String("after", "live", "the snapshot from after the upgrade, or \"live\" to query the node")
+	nodeRegressionCmd.Flags().String("record", "", "write a snapshot of the node's answers to the named file")
+	nodeRegressionCmd.Flags().String("epoch", "", "the first epoch to query when recording (defaults to the most recent finalized epochs)")
+	nodeRegressionCmd.Flags().Uint64("epochs", 1, "the number of epochs to query when recording")
+	nodeRegressionCmd.Flags().StringSlice("validators", nil, "the indices of the validators to query when recording (defaults to the first 32)")
+	nodeRegressionCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func nodeRegressionBindings() {
+	if err := viper.BindPFlag("before", nodeRegressionCmd.Flags().Lookup("before")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("after", nodeRegressionCmd.Flags().Lookup("after")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("record", nodeRegressionCmd.Flags().Lookup("record")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epoch", nodeRegressionCmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epochs", nodeRegressionCmd.Flags().Lookup("epochs")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators", nodeRegressionCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", nodeRegressionCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	nodeselfcheck "github.com/wealdtech/ethdo/cmd/node/selfcheck"
+)
+
+var nodeSelfcheckCmd = &cobra.Command{
+	Use:   "selfcheck",
+	Short: "Check that a node provides internally consistent data",
+	Long: `Check that a node provides internally consistent data, cross-verifying information obtained from different endpoints.  For example:
+
+    ethdo node selfcheck
+
+Checks include header and block roots, state roots, validator information and finality checkpoints.
+
+In quiet mode this will return 0 if all checks pass, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := nodeselfcheck.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	nodeCmd.AddCommand(nodeSelfcheckCmd)
+	nodeFlags(nodeSelfcheckCmd)
+	nodeSelfcheckCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func nodeSelfcheckBindings() {
+	if err := viper.BindPFlag("json", nodeSelfcheckCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
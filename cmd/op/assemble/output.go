@@ -0,0 +1,34 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opassemble
+
+import (
+	"context"
+	"encoding/json"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	// The signed operation is always output as JSON, in the same format as
+	// operations generated by ethdo, so that it can be broadcast with ethdo.
+	data, err := json.Marshal(c.signedOperation)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,77 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opassemble
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(_ context.Context) error {
+	data, err := os.ReadFile(c.rootFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read root file")
+	}
+	operation := &util.UnsignedOperation{}
+	if err := json.Unmarshal(data, operation); err != nil {
+		return errors.Wrap(err, "failed to parse root file")
+	}
+
+	data, err = os.ReadFile(c.signatureFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read signature file")
+	}
+	signature, err := parseSignature(data)
+	if err != nil {
+		return err
+	}
+	util.Log.Debug().Str("type", operation.Type).Str("signature", fmt.Sprintf("%#x", signature)).Msg("Assembling operation")
+
+	c.signedOperation, err = operation.Assemble(signature)
+	if err != nil {
+		return errors.Wrap(err, "failed to assemble operation")
+	}
+
+	return nil
+}
+
+// parseSignature parses a signature, which can be either raw bytes or a hex string.
+func parseSignature(data []byte) (phase0.BLSSignature, error) {
+	signature := phase0.BLSSignature{}
+
+	if len(data) == phase0.SignatureLength {
+		copy(signature[:], data)
+		return signature, nil
+	}
+
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("0x"))
+	sigData := make([]byte, hex.DecodedLen(len(data)))
+	if _, err := hex.Decode(sigData, data); err != nil {
+		return signature, errors.Wrap(err, "invalid signature")
+	}
+	if len(sigData) != phase0.SignatureLength {
+		return signature, errors.New("signature must be exactly 96 bytes in length")
+	}
+	copy(signature[:], sigData)
+
+	return signature, nil
+}
@@ -0,0 +1,84 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opassemble
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSignature(t *testing.T) {
+	sigHex := "b7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2"
+	sigData, err := hex.DecodeString(sigHex)
+	require.NoError(t, err)
+	signature := phase0.BLSSignature{}
+	copy(signature[:], sigData)
+	raw := bytes.Repeat([]byte{0x01}, 96)
+	rawSignature := phase0.BLSSignature{}
+	copy(rawSignature[:], raw)
+
+	tests := []struct {
+		name      string
+		input     []byte
+		signature phase0.BLSSignature
+		err       string
+	}{
+		{
+			name:  "Empty",
+			input: []byte{},
+			err:   "signature must be exactly 96 bytes in length",
+		},
+		{
+			name:  "InvalidHex",
+			input: []byte("0xzz"),
+			err:   "invalid signature: encoding/hex: invalid byte: U+007A 'z'",
+		},
+		{
+			name:  "Short",
+			input: []byte("0x0102"),
+			err:   "signature must be exactly 96 bytes in length",
+		},
+		{
+			name:      "Hex",
+			input:     []byte(sigHex),
+			signature: signature,
+		},
+		{
+			name:      "HexPrefixNewline",
+			input:     []byte("0x" + sigHex + "\n"),
+			signature: signature,
+		},
+		{
+			name:      "Raw",
+			input:     raw,
+			signature: rawSignature,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseSignature(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.signature, res)
+			}
+		})
+	}
+}
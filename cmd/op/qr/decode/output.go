@@ -0,0 +1,38 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opqrdecode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.file != "" {
+		return fmt.Sprintf("Wrote %d bytes to %s", len(c.data), c.file), nil
+	}
+
+	// Signed operations and root files are JSON, but SSZ-encoded operations
+	// are binary so are output as hex.
+	if !json.Valid(c.data) {
+		return fmt.Sprintf("%#x", c.data), nil
+	}
+
+	return string(c.data), nil
+}
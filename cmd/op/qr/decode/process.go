@@ -0,0 +1,85 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opqrdecode
+
+import (
+	"context"
+	"fmt"
+	"image"
+	// Register image formats supported for decoding.
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(_ context.Context) error {
+	frames := make([]string, 0, len(c.images))
+	for _, filename := range c.images {
+		frame, err := decodeImage(filename)
+		if err != nil {
+			return err
+		}
+		util.Log.Debug().Str("file", filename).Str("frame", frame).Msg("Decoded QR code")
+		frames = append(frames, frame)
+	}
+
+	var err error
+	c.data, err = util.QRData(frames)
+	if err != nil {
+		return err
+	}
+
+	if c.file != "" {
+		if err := os.WriteFile(c.file, c.data, 0o600); err != nil {
+			return errors.Wrap(err, "failed to write file")
+		}
+	}
+
+	return nil
+}
+
+// decodeImage returns the contents of the QR code in an image file.
+func decodeImage(filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("failed to open %s", filename))
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("failed to read image %s", filename))
+	}
+
+	return decodeQR(img)
+}
+
+// decodeQR returns the contents of the QR code in an image.
+func decodeQR(img image.Image) (string, error) {
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to process image")
+	}
+	res, err := qrcode.NewQRCodeReader().Decode(bitmap, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to find QR code")
+	}
+
+	return res.GetText(), nil
+}
@@ -0,0 +1,35 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opqrdecode
+
+import (
+	"image"
+	"testing"
+
+	"github.com/skip2/go-qrcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeQR(t *testing.T) {
+	frame := "ETHDO1:1/1:2cf24dba:aGVsbG8="
+	code, err := qrcode.New(frame, qrcode.Medium)
+	require.NoError(t, err)
+
+	res, err := decodeQR(code.Image(-4))
+	require.NoError(t, err)
+	require.Equal(t, frame, res)
+
+	_, err = decodeQR(image.NewGray(image.Rect(0, 0, 100, 100)))
+	require.Error(t, err)
+}
@@ -0,0 +1,60 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opqrencode
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	file        string
+	imagePrefix string
+	chunkSize   int
+
+	// Output.
+	frames []string
+	images []string
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:       viper.GetBool("quiet"),
+		verbose:     viper.GetBool("verbose"),
+		debug:       viper.GetBool("debug"),
+		file:        viper.GetString("file"),
+		imagePrefix: viper.GetString("image-prefix"),
+		chunkSize:   viper.GetInt("chunk-size"),
+	}
+
+	if c.file == "" {
+		return nil, errors.New("file is required")
+	}
+	if c.chunkSize < 1 {
+		return nil, errors.New("chunk size must be at least 1")
+	}
+	if c.chunkSize > maxChunkSize {
+		return nil, fmt.Errorf("chunk size cannot be more than %d", maxChunkSize)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,85 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opqrencode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "FileMissing",
+			vars: map[string]interface{}{
+				"chunk-size": 800,
+			},
+			err: "file is required",
+		},
+		{
+			name: "ChunkSizeZero",
+			vars: map[string]interface{}{
+				"file":       "exit.json",
+				"chunk-size": 0,
+			},
+			err: "chunk size must be at least 1",
+		},
+		{
+			name: "ChunkSizeTooLarge",
+			vars: map[string]interface{}{
+				"file":       "exit.json",
+				"chunk-size": 2000,
+			},
+			err: "chunk size cannot be more than 1600",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"file":       "exit.json",
+				"chunk-size": 800,
+			},
+		},
+		{
+			name: "GoodImages",
+			vars: map[string]interface{}{
+				"file":         "exit.json",
+				"chunk-size":   800,
+				"image-prefix": "exit",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
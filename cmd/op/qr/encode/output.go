@@ -0,0 +1,55 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opqrencode
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/skip2/go-qrcode"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+
+	if len(c.images) > 0 {
+		for _, image := range c.images {
+			builder.WriteString(fmt.Sprintf("Wrote %s\n", image))
+		}
+		return strings.TrimSuffix(builder.String(), "\n"), nil
+	}
+
+	for i, frame := range c.frames {
+		code, err := qrcode.New(frame, qrcode.Medium)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to generate QR code")
+		}
+		if len(c.frames) > 1 {
+			builder.WriteString(fmt.Sprintf("QR code %d of %d:\n", i+1, len(c.frames)))
+		}
+		builder.WriteString(code.ToSmallString(false))
+		if c.verbose {
+			builder.WriteString(frame)
+			builder.WriteString("\n")
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
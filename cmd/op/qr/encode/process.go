@@ -0,0 +1,72 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opqrencode
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/skip2/go-qrcode"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// maxChunkSize is the largest chunk of data that, once framed and base64
+// encoded, fits in a single QR code at medium error correction.
+const maxChunkSize = 1600
+
+// imageModuleSize is the size in pixels of each module of a QR code image.
+const imageModuleSize = 8
+
+func (c *command) process(_ context.Context) error {
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		return errors.Wrap(err, "failed to read file")
+	}
+
+	c.frames, err = util.QRFrames(data, c.chunkSize)
+	if err != nil {
+		return err
+	}
+	util.Log.Debug().Int("bytes", len(data)).Int("codes", len(c.frames)).Msg("Encoding QR codes")
+
+	if c.imagePrefix == "" {
+		return nil
+	}
+
+	c.images = make([]string, 0, len(c.frames))
+	for i, frame := range c.frames {
+		code, err := qrcode.New(frame, qrcode.Medium)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate QR code")
+		}
+		filename := imageFilename(c.imagePrefix, i+1, len(c.frames))
+		if err := code.WriteFile(-imageModuleSize, filename); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to write %s", filename))
+		}
+		c.images = append(c.images, filename)
+	}
+
+	return nil
+}
+
+// imageFilename returns the name of the image file for a frame.
+func imageFilename(prefix string, index int, total int) string {
+	if total == 1 {
+		return fmt.Sprintf("%s.png", prefix)
+	}
+
+	return fmt.Sprintf("%s-%d-of-%d.png", prefix, index, total)
+}
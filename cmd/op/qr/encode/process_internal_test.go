@@ -0,0 +1,26 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opqrencode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageFilename(t *testing.T) {
+	require.Equal(t, "exit.png", imageFilename("exit", 1, 1))
+	require.Equal(t, "exit-1-of-3.png", imageFilename("exit", 1, 3))
+	require.Equal(t, "out/changes-3-of-3.png", imageFilename("out/changes", 3, 3))
+}
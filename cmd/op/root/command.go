@@ -0,0 +1,105 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oproot
+
+import (
+	"context"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+	offline bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	opType              string
+	validator           string
+	epoch               int64
+	withdrawalAddress   string
+	withdrawalPublicKey string
+	rootFile            string
+
+	// Processing.
+	consensusClient consensusclient.Service
+	chainInfo       *beacon.ChainInfo
+
+	// Output.
+	operation *util.UnsignedOperation
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:               viper.GetBool("quiet"),
+		verbose:             viper.GetBool("verbose"),
+		debug:               viper.GetBool("debug"),
+		json:                viper.GetBool("json"),
+		offline:             viper.GetBool("offline"),
+		opType:              viper.GetString("type"),
+		validator:           viper.GetString("validator"),
+		epoch:               viper.GetInt64("epoch"),
+		withdrawalAddress:   viper.GetString("withdrawal-address"),
+		withdrawalPublicKey: viper.GetString("withdrawal-public-key"),
+		rootFile:            viper.GetString("root-file"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+	if c.rootFile == "" {
+		return nil, errors.New("root file is required")
+	}
+
+	switch c.opType {
+	case "exit":
+		if c.withdrawalAddress != "" || c.withdrawalPublicKey != "" {
+			return nil, errors.New("withdrawal address and public key are not used for exits")
+		}
+	case "credentials":
+		if c.withdrawalAddress == "" {
+			return nil, errors.New("withdrawal address is required")
+		}
+		if c.withdrawalPublicKey == "" {
+			return nil, errors.New("withdrawal public key is required")
+		}
+	case "":
+		return nil, errors.New("type is required")
+	default:
+		return nil, errors.New("type must be one of exit or credentials")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,146 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oproot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"type":      "exit",
+				"validator": "1",
+				"root-file": "root.json",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ValidatorMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"type":      "exit",
+				"root-file": "root.json",
+			},
+			err: "validator is required",
+		},
+		{
+			name: "RootFileMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"type":      "exit",
+				"validator": "1",
+			},
+			err: "root file is required",
+		},
+		{
+			name: "TypeMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"validator": "1",
+				"root-file": "root.json",
+			},
+			err: "type is required",
+		},
+		{
+			name: "TypeInvalid",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"type":      "deposit",
+				"validator": "1",
+				"root-file": "root.json",
+			},
+			err: "type must be one of exit or credentials",
+		},
+		{
+			name: "ExitWithdrawalAddress",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"type":               "exit",
+				"validator":          "1",
+				"root-file":          "root.json",
+				"withdrawal-address": "0x8c1Cc7E6bDB6Df9ba0e8Cff2D6d6E1cF3d8aD7b6",
+			},
+			err: "withdrawal address and public key are not used for exits",
+		},
+		{
+			name: "CredentialsWithdrawalAddressMissing",
+			vars: map[string]interface{}{
+				"timeout":               "5s",
+				"type":                  "credentials",
+				"validator":             "1",
+				"root-file":             "root.json",
+				"withdrawal-public-key": "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+			},
+			err: "withdrawal address is required",
+		},
+		{
+			name: "CredentialsWithdrawalPublicKeyMissing",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"type":               "credentials",
+				"validator":          "1",
+				"root-file":          "root.json",
+				"withdrawal-address": "0x8c1Cc7E6bDB6Df9ba0e8Cff2D6d6E1cF3d8aD7b6",
+			},
+			err: "withdrawal public key is required",
+		},
+		{
+			name: "GoodExit",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"type":      "exit",
+				"validator": "1",
+				"root-file": "root.json",
+			},
+		},
+		{
+			name: "GoodCredentials",
+			vars: map[string]interface{}{
+				"timeout":               "5s",
+				"type":                  "credentials",
+				"validator":             "1",
+				"root-file":             "root.json",
+				"withdrawal-address":    "0x8c1Cc7E6bDB6Df9ba0e8Cff2D6d6E1cF3d8aD7b6",
+				"withdrawal-public-key": "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oproot
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+// offlinePreparationFilename is the name of the file containing chain information for offline use.
+var offlinePreparationFilename = "offline-preparation.json"
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.obtainChainInfo(ctx); err != nil {
+		return err
+	}
+
+	validator, err := c.chainInfo.FetchValidatorInfo(ctx, c.validator)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator")
+	}
+
+	switch c.opType {
+	case "exit":
+		c.operation, err = c.exitOperation(validator)
+	case "credentials":
+		c.operation, err = c.credentialsOperation(validator)
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(c.operation)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode root file")
+	}
+	if err := os.WriteFile(c.rootFile, data, 0o600); err != nil {
+		return errors.Wrap(err, "failed to write root file")
+	}
+
+	return nil
+}
+
+// exitOperation creates an unsigned voluntary exit for the validator.
+func (c *command) exitOperation(validator *beacon.ValidatorInfo) (*util.UnsignedOperation, error) {
+	epoch := c.chainInfo.Epoch
+	if c.epoch >= 0 {
+		epoch = phase0.Epoch(c.epoch)
+	}
+
+	forkVersion, _, err := c.chainInfo.VoluntaryExitForkVersion()
+	if err != nil {
+		return nil, err
+	}
+	domain, err := c.domain(c.chainInfo.VoluntaryExitDomainType, forkVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return util.NewUnsignedOperation(&phase0.VoluntaryExit{
+		Epoch:          epoch,
+		ValidatorIndex: validator.Index,
+	}, validator.Pubkey, domain)
+}
+
+// credentialsOperation creates an unsigned credentials change for the validator.
+func (c *command) credentialsOperation(validator *beacon.ValidatorInfo) (*util.UnsignedOperation, error) {
+	withdrawalPubkey := phase0.BLSPubKey{}
+	data, err := hex.DecodeString(strings.TrimPrefix(c.withdrawalPublicKey, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid withdrawal public key")
+	}
+	if len(data) != phase0.PublicKeyLength {
+		return nil, errors.New("withdrawal public key must be exactly 48 bytes in length")
+	}
+	copy(withdrawalPubkey[:], data)
+
+	withdrawalCredentials := ethutil.SHA256(withdrawalPubkey[:])
+	withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
+	if !bytes.Equal(withdrawalCredentials, validator.WithdrawalCredentials) {
+		return nil, fmt.Errorf("withdrawal public key does not match validator withdrawal credentials %#x", validator.WithdrawalCredentials)
+	}
+
+	withdrawalAddress, err := parseExecutionAddress(c.withdrawalAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Credentials changes are signed with the genesis fork version, as per the spec.
+	domain, err := c.domain(c.chainInfo.BLSToExecutionChangeDomainType, c.chainInfo.GenesisForkVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return util.NewUnsignedOperation(&capella.BLSToExecutionChange{
+		ValidatorIndex:     validator.Index,
+		FromBLSPubkey:      withdrawalPubkey,
+		ToExecutionAddress: withdrawalAddress,
+	}, withdrawalPubkey, domain)
+}
+
+// domain calculates the signature domain for the given domain type and fork version.
+func (c *command) domain(domainType phase0.DomainType, forkVersion phase0.Version) (phase0.Domain, error) {
+	domain := phase0.Domain{}
+
+	root, err := (&phase0.ForkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: c.chainInfo.GenesisValidatorsRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return domain, errors.Wrap(err, "failed to calculate signature domain")
+	}
+	copy(domain[:], domainType[:])
+	copy(domain[4:], root[:])
+	util.Log.Debug().Str("domain", fmt.Sprintf("%#x", domain)).Msg("Obtained domain")
+
+	return domain, nil
+}
+
+// obtainChainInfo obtains the chain information, either from the offline
+// preparation file or from a beacon node.
+func (c *command) obtainChainInfo(ctx context.Context) error {
+	if c.offline {
+		data, err := os.ReadFile(offlinePreparationFilename)
+		if err != nil {
+			return errors.Wrap(err, "failed to read offline preparation file")
+		}
+		c.chainInfo = &beacon.ChainInfo{}
+		if err := json.Unmarshal(data, c.chainInfo); err != nil {
+			return errors.Wrap(err, "failed to parse offline preparation file")
+		}
+
+		return nil
+	}
+
+	var err error
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.consensusClient.(consensusclient.ForkScheduleProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	c.chainInfo, err = beacon.ObtainChainInfoFromNodeForValidators(ctx, c.consensusClient, chainTime, []string{c.validator})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain chain information")
+	}
+
+	return nil
+}
+
+// parseExecutionAddress parses an EIP-55 checksummed execution address.
+func parseExecutionAddress(input string) (bellatrix.ExecutionAddress, error) {
+	address := bellatrix.ExecutionAddress{}
+
+	addressBytes, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return address, errors.Wrap(err, "failed to obtain execution address")
+	}
+	if len(addressBytes) != bellatrix.ExecutionAddressLength {
+		return address, errors.New("withdrawal address must be exactly 20 bytes in length")
+	}
+	// Ensure the address is properly checksummed.
+	checksummedAddress := addressBytesToEIP55(addressBytes)
+	if checksummedAddress != input {
+		return address, fmt.Errorf("withdrawal address checksum does not match (expected %s)", checksummedAddress)
+	}
+	copy(address[:], addressBytes)
+
+	return address, nil
+}
+
+// addressBytesToEIP55 converts a byte array in to an EIP-55 string format.
+func addressBytesToEIP55(address []byte) string {
+	bytes := []byte(fmt.Sprintf("%x", address))
+	hash := ethutil.Keccak256(bytes)
+	for i := 0; i < len(bytes); i++ {
+		hashByte := hash[i/2]
+		if i%2 == 0 {
+			hashByte >>= 4
+		} else {
+			hashByte &= 0xf
+		}
+		if bytes[i] > '9' && hashByte > 7 {
+			bytes[i] -= 32
+		}
+	}
+
+	return fmt.Sprintf("0x%s", string(bytes))
+}
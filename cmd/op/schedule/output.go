@@ -0,0 +1,105 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opschedule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	var data []byte
+	var err error
+	if c.list {
+		data, err = json.Marshal(c.schedule.Operations)
+	} else {
+		data, err = json.Marshal(c.added)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	if !c.list {
+		return fmt.Sprintf("Scheduled %s at epoch %d (%s)",
+			describeOperation(c.added),
+			c.added.Epoch,
+			c.chainTime.StartOfEpoch(c.added.Epoch).Format(time.RFC3339),
+		), nil
+	}
+
+	if len(c.schedule.Operations) == 0 {
+		return "No scheduled operations", nil
+	}
+
+	builder := strings.Builder{}
+	for _, op := range c.schedule.Operations {
+		builder.WriteString(fmt.Sprintf("Epoch %d (%s): %s",
+			op.Epoch,
+			c.chainTime.StartOfEpoch(op.Epoch).Format(time.RFC3339),
+			describeOperation(op),
+		))
+		switch {
+		case op.Broadcast != nil:
+			builder.WriteString(fmt.Sprintf("; broadcast at %s", op.Broadcast.Format(time.RFC3339)))
+		case op.LastError != "":
+			builder.WriteString(fmt.Sprintf("; pending (last attempt failed: %s)", op.LastError))
+		default:
+			builder.WriteString("; pending")
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// describeOperation provides a short description of a scheduled operation.
+func describeOperation(op *util.ScheduledOperation) string {
+	validators := make([]string, 0, len(op.Validators))
+	for _, validator := range op.Validators {
+		validators = append(validators, fmt.Sprintf("%d", validator))
+	}
+
+	switch op.Type {
+	case util.OperationTypeVoluntaryExit:
+		return fmt.Sprintf("voluntary exit for validator %s", strings.Join(validators, ","))
+	case util.OperationTypeBLSToExecutionChange:
+		if len(validators) == 1 {
+			return fmt.Sprintf("credentials change for validator %s", validators[0])
+		}
+		return fmt.Sprintf("%d credentials changes for validators %s", len(validators), strings.Join(validators, ","))
+	default:
+		return op.Type
+	}
+}
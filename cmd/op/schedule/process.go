@@ -0,0 +1,110 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opschedule
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	c.schedule, err = util.ReadOperationSchedule(c.scheduleFile)
+	if err != nil {
+		return err
+	}
+	if c.list {
+		return nil
+	}
+
+	epoch, err := parseAt(c.chainTime, c.at, time.Local)
+	if err != nil {
+		return err
+	}
+	if epoch <= c.chainTime.CurrentEpoch() {
+		return fmt.Errorf("epoch %d is not in the future; broadcast the operation directly instead", epoch)
+	}
+
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		return errors.Wrap(err, "failed to read operation file")
+	}
+	c.added, err = util.NewScheduledOperation(data, epoch)
+	if err != nil {
+		return err
+	}
+	util.Log.Debug().Str("type", c.added.Type).Interface("validators", c.added.Validators).Uint64("epoch", uint64(c.added.Epoch)).Msg("Scheduling operation")
+
+	c.schedule.Add(c.added)
+
+	return c.schedule.Write(c.scheduleFile)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	return nil
+}
+
+// parseAt parses the time at which to broadcast an operation.  This can be an
+// epoch, or a timestamp in which case the first epoch to start at or after
+// the timestamp is used.
+func parseAt(chainTime chaintime.Service, input string, location *time.Location) (phase0.Epoch, error) {
+	if epoch, err := strconv.ParseUint(input, 10, 64); err == nil {
+		return phase0.Epoch(epoch), nil
+	}
+
+	timestamp, err := util.ParseTimestamp(input, location)
+	if err != nil {
+		return 0, err
+	}
+	if timestamp.Before(chainTime.GenesisTime()) {
+		return 0, errors.New("timestamp is before genesis")
+	}
+	epoch := chainTime.TimestampToEpoch(timestamp)
+	if chainTime.StartOfEpoch(epoch).Before(timestamp) {
+		epoch++
+	}
+
+	return epoch, nil
+}
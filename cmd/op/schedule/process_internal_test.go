@@ -0,0 +1,83 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opschedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/testing/mock"
+)
+
+func TestParseAt(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Unix(1606824023, 0)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected phase0.Epoch
+		err      string
+	}{
+		{
+			name:  "Invalid",
+			input: "bad",
+			err:   `invalid timestamp "bad"; should be a Unix time or of the form YYYY-MM-DDTHH:MM:SS+ZZ:ZZ`,
+		},
+		{
+			name:     "Epoch",
+			input:    "300000",
+			expected: 300000,
+		},
+		{
+			name:  "BeforeGenesis",
+			input: "2020-01-01T00:00:00Z",
+			err:   "timestamp is before genesis",
+		},
+		{
+			name:     "StartOfEpoch",
+			input:    genesisTime.Add(10 * 384 * time.Second).UTC().Format(time.RFC3339),
+			expected: 10,
+		},
+		{
+			name:     "DuringEpoch",
+			input:    genesisTime.Add(10*384*time.Second + time.Second).UTC().Format(time.RFC3339),
+			expected: 11,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			epoch, err := parseAt(chainTime, test.input, time.UTC)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, epoch)
+			}
+		})
+	}
+}
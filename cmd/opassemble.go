@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	opassemble "github.com/wealdtech/ethdo/cmd/op/assemble"
+)
+
+var opAssembleCmd = &cobra.Command{
+	Use:   "assemble",
+	Short: "Assemble an operation from its root file and an external signature",
+	Long: `Assemble a signed operation from a root file generated by "ethdo op root" and a signature generated externally.  For example:
+
+    ethdo op assemble --root-file=exit-root.json --signature-file=exit-signature.txt
+
+The signature file can contain either the hex-encoded signature or the raw 96-byte signature.  The signing root is recalculated from the operation in the root file, and the signature verified against it, before the signed operation is output.  The output is in the same format as that generated by "ethdo validator exit --json" or "ethdo validator credentials set --json", and can be broadcast in the same way.
+
+In quiet mode this will return 0 if the signature is valid for the operation, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := opassemble.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	opCmd.AddCommand(opAssembleCmd)
+	opFlags(opAssembleCmd)
+	opAssembleCmd.Flags().String("root-file", "", "Root file generated by \"ethdo op root\"")
+	opAssembleCmd.Flags().String("signature-file", "", "File containing the external signature of the signing root")
+}
+
+func opAssembleBindings() {
+	if err := viper.BindPFlag("root-file", opAssembleCmd.Flags().Lookup("root-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("signature-file", opAssembleCmd.Flags().Lookup("signature-file")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// opQRCmd represents the op qr command
+var opQRCmd = &cobra.Command{
+	Use:   "qr",
+	Short: "Transfer operations using QR codes",
+	Long:  `Transfer signed operations and signing requests between online and air-gapped computers using QR codes.`,
+}
+
+func init() {
+	opCmd.AddCommand(opQRCmd)
+}
+
+func opQRFlags(cmd *cobra.Command) {
+}
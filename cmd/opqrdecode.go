@@ -0,0 +1,63 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	opqrdecode "github.com/wealdtech/ethdo/cmd/op/qr/decode"
+)
+
+var opQRDecodeCmd = &cobra.Command{
+	Use:   "decode",
+	Short: "Decode an operation from scanned QR codes",
+	Long: `Decode data encoded by "ethdo op qr encode" from images of its QR codes.  For example:
+
+    ethdo op qr decode --images=exit-1-of-2.png,exit-2-of-2.png --file=exit-operations.json
+
+Images can be PNG or JPEG, and must be supplied for all of the QR codes generated for the data, in any order.  The data is checked for integrity before it is written to the file or, if no file is supplied, output.
+
+In quiet mode this will return 0 if the data is decoded, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := opqrdecode.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	opQRCmd.AddCommand(opQRDecodeCmd)
+	opQRFlags(opQRDecodeCmd)
+	opQRDecodeCmd.Flags().StringSlice("images", nil, "Images of the QR codes to decode")
+	opQRDecodeCmd.Flags().String("file", "", "File to which to write the decoded data (defaults to standard output)")
+}
+
+func opQRDecodeBindings() {
+	if err := viper.BindPFlag("images", opQRDecodeCmd.Flags().Lookup("images")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("file", opQRDecodeCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
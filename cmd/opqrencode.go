@@ -0,0 +1,67 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	opqrencode "github.com/wealdtech/ethdo/cmd/op/qr/encode"
+)
+
+var opQREncodeCmd = &cobra.Command{
+	Use:   "encode",
+	Short: "Encode an operation as one or more QR codes",
+	Long: `Encode a file, such as signed operations generated by "ethdo validator exit --json" or a root file generated by "ethdo op root", as one or more QR codes.  For example:
+
+    ethdo op qr encode --file=exit-operations.json
+
+By default the QR codes are printed to the terminal.  If --image-prefix is supplied they are instead written to PNG files.  Data too large for a single QR code is split over multiple codes, which can be scanned in any order and reassembled with "ethdo op qr decode".
+
+In quiet mode this will return 0 if the QR codes are generated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := opqrencode.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	opQRCmd.AddCommand(opQREncodeCmd)
+	opQRFlags(opQREncodeCmd)
+	opQREncodeCmd.Flags().String("file", "", "File containing the data to encode")
+	opQREncodeCmd.Flags().String("image-prefix", "", "Write the QR codes to PNG files with this prefix rather than printing them")
+	opQREncodeCmd.Flags().Int("chunk-size", 800, "Maximum number of bytes of data in each QR code; smaller values give QR codes that are easier to scan")
+}
+
+func opQREncodeBindings() {
+	if err := viper.BindPFlag("file", opQREncodeCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("image-prefix", opQREncodeCmd.Flags().Lookup("image-prefix")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("chunk-size", opQREncodeCmd.Flags().Lookup("chunk-size")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	oproot "github.com/wealdtech/ethdo/cmd/op/root"
+)
+
+var opRootCmd = &cobra.Command{
+	Use:   "root",
+	Short: "Generate the signing root of an operation for external signing",
+	Long: `Generate the signing root of an operation, for signing by infrastructure that ethdo cannot access directly.  For example:
+
+    ethdo op root --type=exit --validator=12345 --root-file=exit-root.json
+
+The type of operation can be "exit" for a voluntary exit, or "credentials" for a change of withdrawal credentials; the latter requires --withdrawal-address and --withdrawal-public-key.
+
+The root file contains the operation, the domain, the signing root and the public key that must sign it.  The signing root should be signed externally, and the resultant signature combined with the root file using "ethdo op assemble".
+
+In quiet mode this will return 0 if the root file has been written, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := oproot.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	opCmd.AddCommand(opRootCmd)
+	opFlags(opRootCmd)
+	opRootCmd.Flags().String("type", "", "Type of operation: exit or credentials")
+	opRootCmd.Flags().String("validator", "", "Validator for the operation")
+	opRootCmd.Flags().Int64("epoch", -1, "Epoch at which to exit (defaults to current epoch)")
+	opRootCmd.Flags().String("withdrawal-address", "", "Execution address to which to change withdrawal credentials")
+	opRootCmd.Flags().String("withdrawal-public-key", "", "Public key of the BLS withdrawal credentials that will sign a credentials change")
+	opRootCmd.Flags().String("root-file", "", "File to which to write the operation and its signing root")
+	opRootCmd.Flags().Bool("offline", false, "Use offline-preparation.json rather than connecting to a beacon node")
+	opRootCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func opRootBindings() {
+	if err := viper.BindPFlag("type", opRootCmd.Flags().Lookup("type")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validator", opRootCmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epoch", opRootCmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawal-address", opRootCmd.Flags().Lookup("withdrawal-address")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawal-public-key", opRootCmd.Flags().Lookup("withdrawal-public-key")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("root-file", opRootCmd.Flags().Lookup("root-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("offline", opRootCmd.Flags().Lookup("offline")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", opRootCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
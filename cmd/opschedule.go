@@ -0,0 +1,75 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	opschedule "github.com/wealdtech/ethdo/cmd/op/schedule"
+)
+
+var opScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Schedule a signed operation for future broadcast",
+	Long: `Schedule a signed operation, such as a voluntary exit or credentials change generated with --json, to be broadcast once a future epoch arrives.  For example:
+
+    ethdo op schedule --file=exit-operations.json --at=2025-06-30T12:00:00Z
+
+The time can be an epoch, or a timestamp in which case the first epoch to start at or after the timestamp is used.  Scheduled operations are stored in the schedule file, and broadcast by "ethdo cron".  Scheduled operations can be listed with --list.
+
+In quiet mode this will return 0 if the operation is scheduled, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := opschedule.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	opCmd.AddCommand(opScheduleCmd)
+	opFlags(opScheduleCmd)
+	opScheduleCmd.Flags().String("file", "", "File containing the signed operation to schedule")
+	opScheduleCmd.Flags().String("at", "", "Epoch or timestamp at which to broadcast the operation")
+	opScheduleCmd.Flags().String("schedule-file", "scheduled-operations.json", "File in which scheduled operations are stored")
+	opScheduleCmd.Flags().Bool("list", false, "List the scheduled operations rather than adding one")
+	opScheduleCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func opScheduleBindings() {
+	if err := viper.BindPFlag("file", opScheduleCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("at", opScheduleCmd.Flags().Lookup("at")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("schedule-file", opScheduleCmd.Flags().Lookup("schedule-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("list", opScheduleCmd.Flags().Lookup("list")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", opScheduleCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
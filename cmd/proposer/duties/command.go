@@ -37,7 +37,9 @@ type command struct {
 
 	// Operation.
 	epoch      string
+	epochs     uint64
 	jsonOutput bool
+	csvOutput  bool
 
 	// Data access.
 	eth2Client             eth2client.Service
@@ -45,7 +47,7 @@ type command struct {
 	proposerDutiesProvider eth2client.ProposerDutiesProvider
 
 	// Results.
-	results *results
+	results []*results
 }
 
 type results struct {
@@ -58,7 +60,7 @@ func newCommand(ctx context.Context) (*command, error) {
 		quiet:   viper.GetBool("quiet"),
 		verbose: viper.GetBool("verbose"),
 		debug:   viper.GetBool("debug"),
-		results: &results{},
+		results: make([]*results, 0),
 	}
 
 	// Timeout.
@@ -71,7 +73,15 @@ func newCommand(ctx context.Context) (*command, error) {
 	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
 
 	c.epoch = viper.GetString("epoch")
+	c.epochs = viper.GetUint64("epochs")
+	if c.epochs == 0 {
+		c.epochs = 1
+	}
 	c.jsonOutput = viper.GetBool("json")
+	c.csvOutput = viper.GetBool("csv")
+	if c.jsonOutput && c.csvOutput {
+		return nil, errors.New("only one of json and csv output allowed")
+	}
 
 	return c, nil
 }
@@ -37,6 +37,16 @@ func TestInput(t *testing.T) {
 			vars: map[string]interface{}{},
 			err:  "timeout is required",
 		},
+		{
+			name: "JSONAndCSV",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"json":       true,
+				"csv":        true,
+			},
+			err: "only one of json and csv output allowed",
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
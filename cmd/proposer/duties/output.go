@@ -29,11 +29,22 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return c.outputJSON(ctx)
 	}
 
+	if c.csvOutput {
+		return c.outputCSV(ctx)
+	}
+
 	return c.outputTxt(ctx)
 }
 
 func (c *command) outputJSON(_ context.Context) (string, error) {
-	data, err := json.Marshal(c.results)
+	var data []byte
+	var err error
+	if len(c.results) == 1 {
+		// Retain the single-epoch format.
+		data, err = json.Marshal(c.results[0])
+	} else {
+		data, err = json.Marshal(c.results)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -43,19 +54,34 @@ func (c *command) outputJSON(_ context.Context) (string, error) {
 func (c *command) outputTxt(_ context.Context) (string, error) {
 	builder := strings.Builder{}
 
-	builder.WriteString("Epoch ")
-	builder.WriteString(fmt.Sprintf("%d:\n", c.results.Epoch))
-
-	for _, duty := range c.results.Duties {
-		builder.WriteString("  Slot ")
-		builder.WriteString(fmt.Sprintf("%d: ", duty.Slot))
-		builder.WriteString("validator ")
-		builder.WriteString(fmt.Sprintf("%d", duty.ValidatorIndex))
-		if c.verbose {
-			builder.WriteString(" (pubkey ")
-			builder.WriteString(fmt.Sprintf("%#x)", duty.PubKey))
+	for _, result := range c.results {
+		builder.WriteString("Epoch ")
+		builder.WriteString(fmt.Sprintf("%d:\n", result.Epoch))
+
+		for _, duty := range result.Duties {
+			builder.WriteString("  Slot ")
+			builder.WriteString(fmt.Sprintf("%d: ", duty.Slot))
+			builder.WriteString("validator ")
+			builder.WriteString(fmt.Sprintf("%d", duty.ValidatorIndex))
+			if c.verbose {
+				builder.WriteString(" (pubkey ")
+				builder.WriteString(fmt.Sprintf("%#x)", duty.PubKey))
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString("slot,validator_index,pubkey\n")
+	for _, result := range c.results {
+		for _, duty := range result.Duties {
+			builder.WriteString(fmt.Sprintf("%d,%d,%#x\n", duty.Slot, duty.ValidatorIndex, duty.PubKey))
 		}
-		builder.WriteString("\n")
 	}
 
 	return strings.TrimSuffix(builder.String(), "\n"), nil
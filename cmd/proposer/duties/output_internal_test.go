@@ -0,0 +1,94 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposerduties
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	pubKey := phase0.BLSPubKey{0x01}
+	results := []*results{
+		{
+			Epoch: 5,
+			Duties: []*apiv1.ProposerDuty{
+				{
+					PubKey:         pubKey,
+					Slot:           160,
+					ValidatorIndex: 8221,
+				},
+			},
+		},
+		{
+			Epoch: 6,
+			Duties: []*apiv1.ProposerDuty{
+				{
+					PubKey:         pubKey,
+					Slot:           192,
+					ValidatorIndex: 631,
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet:   true,
+				results: results,
+			},
+		},
+		{
+			name: "Text",
+			c: &command{
+				results: results,
+			},
+			res: "Epoch 5:\n  Slot 160: validator 8221\nEpoch 6:\n  Slot 192: validator 631",
+		},
+		{
+			name: "CSV",
+			c: &command{
+				csvOutput: true,
+				results:   results,
+			},
+			res: "slot,validator_index,pubkey\n160,8221,0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000\n192,631,0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name: "JSONSingle",
+			c: &command{
+				jsonOutput: true,
+				results:    results[:1],
+			},
+			res: `{"epoch":5,"duties":[{"pubkey":"0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","slot":"160","validator_index":"8221"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
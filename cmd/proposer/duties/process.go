@@ -15,8 +15,10 @@ package proposerduties
 
 import (
 	"context"
+	"fmt"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
@@ -29,14 +31,25 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	c.results.Epoch, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
+	firstEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.epoch)
 	if err != nil {
 		return errors.Wrap(err, "failed to parse epoch")
 	}
 
-	c.results.Duties, err = c.proposerDutiesProvider.ProposerDuties(ctx, c.results.Epoch, nil)
-	if err != nil {
-		return errors.Wrap(err, "failed to obtain proposer duties")
+	for epoch := firstEpoch; epoch < firstEpoch+phase0.Epoch(c.epochs); epoch++ {
+		duties, err := c.proposerDutiesProvider.ProposerDuties(ctx, epoch, nil)
+		if err != nil {
+			if len(c.results) > 0 && epoch > c.chainTime.CurrentEpoch() {
+				// The node does not provide duties this far ahead; return what we have.
+				util.Log.Debug().Uint64("epoch", uint64(epoch)).Err(err).Msg("Duties not available")
+				break
+			}
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain proposer duties for epoch %d", epoch))
+		}
+		c.results = append(c.results, &results{
+			Epoch:  epoch,
+			Duties: duties,
+		})
 	}
 
 	return nil
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	proposerduties "github.com/wealdtech/ethdo/cmd/proposer/duties"
@@ -28,6 +26,12 @@ var proposerDutiesCmd = &cobra.Command{
 
     ethdo proposer duties --epoch=12345
 
+Duties for a range of epochs can be obtained with --epochs, for example:
+
+    ethdo proposer duties --epochs=2 --csv
+
+Duties are returned for as many of the requested epochs as the beacon node will provide.
+
 In quiet mode this will return 0 if duties can be obtained, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := proposerduties.Run(cmd)
@@ -38,7 +42,9 @@ In quiet mode this will return 0 if duties can be obtained, otherwise 1.`,
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -48,14 +54,22 @@ func init() {
 	proposerCmd.AddCommand(proposerDutiesCmd)
 	proposerFlags(proposerDutiesCmd)
 	proposerDutiesCmd.Flags().String("epoch", "", "the epoch for which to fetch duties")
+	proposerDutiesCmd.Flags().Uint64("epochs", 1, "the number of epochs for which to fetch duties, starting with the given epoch")
 	proposerDutiesCmd.Flags().Bool("json", false, "output data in JSON format")
+	proposerDutiesCmd.Flags().Bool("csv", false, "output data in CSV format")
 }
 
 func proposerDutiesBindings() {
 	if err := viper.BindPFlag("epoch", proposerDutiesCmd.Flags().Lookup("epoch")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("epochs", proposerDutiesCmd.Flags().Lookup("epochs")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("json", proposerDutiesCmd.Flags().Lookup("json")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("csv", proposerDutiesCmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
 }
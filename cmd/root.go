@@ -23,9 +23,10 @@ import (
 
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
-	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/auditlog"
+	"github.com/wealdtech/ethdo/beacon"
 	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
@@ -38,12 +39,24 @@ var quiet bool
 var verbose bool
 var debug bool
 
+// outputCommands are the commands that support the output flag.
+var outputCommands = map[string]bool{
+	"attester/inclusion":    true,
+	"chain/status":          true,
+	"epoch/summary":         true,
+	"validator/info":        true,
+	"validator/performance": true,
+}
+
 // RootCmd represents the base command when called without any subcommands
 var RootCmd = &cobra.Command{
 	Use:               "ethdo",
 	Short:             "Ethereum 2 CLI",
 	Long:              `Manage common Ethereum 2 tasks from the command line.`,
 	PersistentPreRunE: persistentPreRunE,
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		outputTelemetry()
+	},
 }
 
 func persistentPreRunE(cmd *cobra.Command, args []string) error {
@@ -57,8 +70,33 @@ func persistentPreRunE(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Disable service logging.
-	zerolog.SetGlobalLevel(zerolog.Disabled)
+	if strings.HasPrefix(commandPath(cmd), "completion") || cmd.Name() == cobra.ShellCompRequestCmd {
+		// User just wants shell completion; completion functions carry out
+		// their own setup
+		return nil
+	}
+
+	if err := util.InitLogging(); err != nil {
+		return err
+	}
+
+	if viper.GetBool("telemetry") {
+		util.EnableTelemetry()
+	}
+
+	if err := util.SetBeaconNodeRetries(viper.GetInt("retries"), viper.GetDuration("retry-backoff")); err != nil {
+		return err
+	}
+
+	if err := util.SetBeaconNodeHeaders(viper.GetStringSlice("connection-headers"), viper.GetString("jwt-secret")); err != nil {
+		return err
+	}
+
+	if err := beacon.SetValidatorFetchConcurrency(viper.GetInt("validator-fetch-concurrency")); err != nil {
+		return err
+	}
+
+	auditlog.Setup(viper.GetString("audit-log"), cmd.CommandPath())
 
 	// We bind viper here so that we bind to the correct command.
 	quiet = viper.GetBool("quiet")
@@ -74,6 +112,38 @@ func persistentPreRunE(cmd *cobra.Command, args []string) error {
 		fmt.Println("Cannot supply both quiet and debug flags")
 	}
 
+	outputFormat := viper.GetString("output")
+	if outputFormat != "" {
+		if !util.ValidOutputFormat(outputFormat) {
+			return fmt.Errorf("unknown output format %q; supported formats are json, yaml and table", outputFormat)
+		}
+		if !outputCommands[commandPath(cmd)] {
+			return errors.New("output is not supported by this command")
+		}
+	}
+
+	if viper.GetString("format-template") != "" {
+		// Templates are applied to the JSON output of the command.
+		switch {
+		case outputCommands[commandPath(cmd)]:
+			if outputFormat != "" && outputFormat != util.OutputFormatJSON {
+				return errors.New("format-template requires JSON output")
+			}
+			viper.Set("output", util.OutputFormatJSON)
+		case cmd.Flags().Lookup("json") != nil:
+			viper.Set("json", true)
+		default:
+			return errors.New("format-template is not supported by this command")
+		}
+		if _, err := util.ParseFormatTemplate(viper.GetString("format-template")); err != nil {
+			return err
+		}
+	}
+
+	if err := util.SetupSecrets(os.Stdin); err != nil {
+		return err
+	}
+
 	return util.SetupStore()
 }
 
@@ -82,46 +152,150 @@ func includeCommandBindings(cmd *cobra.Command) {
 	switch commandPath(cmd) {
 	case "account/create":
 		accountCreateBindings()
+	case "account/delete":
+		accountDeleteBindings()
 	case "account/derive":
 		accountDeriveBindings()
+	case "account/export":
+		accountExportBindings()
+	case "account/interop":
+		accountInteropBindings()
 	case "account/import":
 		accountImportBindings()
+	case "account/move":
+		accountMoveBindings()
+	case "account/passwd":
+		accountPasswdBindings()
+	case "account/rename":
+		accountRenameBindings()
 	case "attester/duties":
 		attesterDutiesBindings()
 	case "attester/inclusion":
 		attesterInclusionBindings()
+	case "attester/verify":
+		attesterVerifyBindings()
 	case "block/analyze":
 		blockAnalyzeBindings()
+	case "block/bids":
+		blockBidsBindings()
+	case "block/compare":
+		blockCompareBindings()
 	case "block/info":
 		blockInfoBindings()
+	case "block/packing/advise":
+		blockPackingAdviseBindings()
+	case "block/partialreplay":
+		blockPartialReplayBindings()
+	case "chain/churn":
+		chainChurnBindings()
+	case "chain/decentralization":
+		chainDecentralizationBindings()
+	case "chain/depositrequests":
+		chainDepositRequestsBindings()
 	case "chain/eth1votes":
 		chainEth1VotesBindings()
+	case "chain/forks":
+		chainForksBindings()
+	case "chain/genesis/generate":
+		chainGenesisGenerateBindings()
+	case "chain/heads":
+		chainHeadsBindings()
 	case "chain/info":
 		chainInfoBindings()
+	case "chain/proof/generate":
+		chainProofGenerateBindings()
+	case "chain/proof/verify":
+		chainProofVerifyBindings()
 	case "chain/queues":
 		chainQueuesBindings()
+	case "chain/rewards":
+		chainRewardsBindings()
+	case "chain/spec/diff":
+		chainSpecDiffBindings()
+	case "chain/stateroot/verify":
+		chainStateRootVerifyBindings()
+	case "chain/status":
+		chainStatusBindings()
 	case "chain/time":
 		chainTimeBindings()
+	case "chain/verify/block":
+		chainVerifyBlockBindings()
 	case "chain/verify/signedcontributionandproof":
 		chainVerifySignedContributionAndProofBindings(cmd)
+	case "cron":
+		cronBindings()
+	case "deposit/calldata":
+		depositCalldataBindings()
+	case "dvt/info":
+		dvtInfoBindings()
+	case "epoch/flags":
+		epochFlagsBindings(cmd)
 	case "epoch/summary":
-		epochSummaryBindings()
+		epochSummaryBindings(cmd)
+	case "epoch/time":
+		epochTimeBindings(cmd)
+	case "exit/combine":
+		exitCombineBindings()
+	case "exit/coordinate":
+		exitCoordinateBindings()
+	case "exit/simulate":
+		exitSimulateBindings()
 	case "exit/verify":
 		exitVerifyBindings()
+	case "exit/verify-external":
+		exitVerifyExternalBindings()
+	case "fuzz/run":
+		fuzzRunBindings()
+	case "keymanager/apply":
+		keymanagerApplyBindings(cmd)
+	case "keymanager/graffiti/get":
+		keymanagerGraffitiGetBindings(cmd)
+	case "keymanager/graffiti/set":
+		keymanagerGraffitiSetBindings(cmd)
 	case "node/events":
 		nodeEventsBindings()
+	case "node/fleet":
+		nodeFleetBindings()
+	case "node/regression":
+		nodeRegressionBindings()
+	case "node/selfcheck":
+		nodeSelfcheckBindings()
+	case "op/assemble":
+		opAssembleBindings()
+	case "op/qr/decode":
+		opQRDecodeBindings()
+	case "op/qr/encode":
+		opQREncodeBindings()
+	case "op/root":
+		opRootBindings()
+	case "op/schedule":
+		opScheduleBindings()
 	case "proposer/duties":
 		proposerDutiesBindings()
+	case "serve":
+		serveBindings()
 	case "slot/time":
 		slotTimeBindings()
 	case "synccommittee/inclusion":
 		synccommitteeInclusionBindings()
 	case "synccommittee/members":
 		synccommitteeMembersBindings()
+	case "synccommittee/performance":
+		synccommitteePerformanceBindings()
+	case "synccommittee/rewards":
+		synccommitteeRewardsBindings()
+	case "top":
+		topBindings()
+	case "validator/alive":
+		validatorAliveBindings()
+	case "validator/balancehistory":
+		validatorBalanceHistoryBindings()
 	case "validator/credentials/get":
 		validatorCredentialsGetBindings()
 	case "validator/credentials/set":
 		validatorCredentialsSetBindings()
+	case "validator/credentials/track":
+		validatorCredentialsTrackBindings()
 	case "validator/credentials/fuzz":
 		validatorCredentialsFuzzBindings()
 	case "validator/depositdata":
@@ -130,14 +304,30 @@ func includeCommandBindings(cmd *cobra.Command) {
 		validatorDutiesBindings()
 	case "validator/exit":
 		validatorExitBindings()
+	case "validator/exit/verify":
+		validatorExitVerifyBindings()
 	case "validator/exitfuzz":
 		validatorExitFuzzBindings()
 	case "validator/info":
 		validatorInfoBindings()
 	case "validator/keycheck":
 		validatorKeycheckBindings()
+	case "validator/performance":
+		validatorPerformanceBindings()
+	case "validator/proposals":
+		validatorProposalsBindings()
+	case "validator/resolve":
+		validatorResolveBindings()
+	case "validator/slashings":
+		validatorSlashingsBindings()
+	case "validator/slashingprotection/export":
+		validatorSlashingProtectionExportBindings()
+	case "validator/slashingprotection/import":
+		validatorSlashingProtectionImportBindings()
 	case "validator/summary":
 		validatorSummaryBindings()
+	case "validator/withdrawals":
+		validatorWithdrawalsBindings()
 	case "validator/yield":
 		validatorYieldBindings()
 	case "validator/expectation":
@@ -146,6 +336,10 @@ func includeCommandBindings(cmd *cobra.Command) {
 		walletCreateBindings()
 	case "wallet/import":
 		walletImportBindings()
+	case "wallet/merge":
+		walletMergeBindings()
+	case "wallet/passwd":
+		walletPasswdBindings()
 	case "wallet/sharedexport":
 		walletSharedExportBindings()
 	case "wallet/sharedimport":
@@ -157,7 +351,8 @@ func includeCommandBindings(cmd *cobra.Command) {
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	if err := RootCmd.Execute(); err != nil {
-		os.Exit(_exitFailure)
+		outputError(err, "Error: ")
+		exit(exitStatus(err))
 	}
 }
 
@@ -170,6 +365,12 @@ func init() {
 
 	cobra.OnInitialize(initConfig)
 
+	// Errors are output by Execute(), to allow them to be output as JSON.
+	RootCmd.SilenceErrors = true
+	RootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return util.NewValidationError(err)
+	})
+
 	RootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.ethdo.yaml)")
 	RootCmd.PersistentFlags().String("log", "", "log activity to the named file (default $HOME/ethdo.log).  Logs are written for every action that generates a transaction")
 	if err := viper.BindPFlag("log", RootCmd.PersistentFlags().Lookup("log")); err != nil {
@@ -183,10 +384,17 @@ func init() {
 	if err := viper.BindPFlag("account", RootCmd.PersistentFlags().Lookup("account")); err != nil {
 		panic(err)
 	}
-	RootCmd.PersistentFlags().String("mnemonic", "", "Mnemonic to provide access to an account")
+	if err := RootCmd.RegisterFlagCompletionFunc("account", completeAccounts); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("mnemonic", "", "Mnemonic to provide access to an account (\"-\" to enter it interactively)")
 	if err := viper.BindPFlag("mnemonic", RootCmd.PersistentFlags().Lookup("mnemonic")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().Bool("mnemonic-stdin", false, "Read the mnemonic from standard input")
+	if err := viper.BindPFlag("mnemonic-stdin", RootCmd.PersistentFlags().Lookup("mnemonic-stdin")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().String("path", "", "Hierarchical derivation path used with mnemonic to provide access to an account")
 	if err := viper.BindPFlag("path", RootCmd.PersistentFlags().Lookup("path")); err != nil {
 		panic(err)
@@ -206,7 +414,7 @@ func init() {
 	if err := RootCmd.PersistentFlags().MarkDeprecated("basedir", "use --base-dir"); err != nil {
 		panic(err)
 	}
-	RootCmd.PersistentFlags().String("base-dir", "", "Base directory for filesystem wallets")
+	RootCmd.PersistentFlags().String("base-dir", "", "Base directory for filesystem wallets, or an s3:// or gs:// location for wallets held in an object store")
 	if err := viper.BindPFlag("base-dir", RootCmd.PersistentFlags().Lookup("base-dir")); err != nil {
 		panic(err)
 	}
@@ -236,6 +444,14 @@ func init() {
 	if err := viper.BindPFlag("passphrase", RootCmd.PersistentFlags().Lookup("passphrase")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().String("passphrase-file", "", "File containing passphrases for account, one per line (alternatively supply a file descriptor in ETHDO_PASSPHRASE_FD; other passphrase flags use ETHDO_<FLAG>_FILE or ETHDO_<FLAG>_FD)")
+	if err := viper.BindPFlag("passphrase-file", RootCmd.PersistentFlags().Lookup("passphrase-file")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("passphrase-manifest", "", "File containing the passphrases of individual accounts")
+	if err := viper.BindPFlag("passphrase-manifest", RootCmd.PersistentFlags().Lookup("passphrase-manifest")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().Bool("quiet", false, "do not generate any output")
 	if err := viper.BindPFlag("quiet", RootCmd.PersistentFlags().Lookup("quiet")); err != nil {
 		panic(err)
@@ -248,14 +464,70 @@ func init() {
 	if err := viper.BindPFlag("debug", RootCmd.PersistentFlags().Lookup("debug")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().String("log-level", "", "minimum level of log messages to output (trace, debug, info, warn, error); overrides --debug")
+	if err := viper.BindPFlag("log-level", RootCmd.PersistentFlags().Lookup("log-level")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("log-file", "", "file to which to append log messages, rather than stderr")
+	if err := viper.BindPFlag("log-file", RootCmd.PersistentFlags().Lookup("log-file")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("log-format", "text", "format of log messages (text or json)")
+	if err := viper.BindPFlag("log-format", RootCmd.PersistentFlags().Lookup("log-format")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("format-template", "", "Go text template applied to the JSON output of the command, for example '{{.exit_epoch}}'")
+	if err := viper.BindPFlag("format-template", RootCmd.PersistentFlags().Lookup("format-template")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("output", "", "format for the output of the command: json, yaml or table.  Only supported by some commands")
+	if err := viper.BindPFlag("output", RootCmd.PersistentFlags().Lookup("output")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Bool("error-json", false, "output errors to stderr as JSON objects containing the error class, exit code and message")
+	if err := viper.BindPFlag("error-json", RootCmd.PersistentFlags().Lookup("error-json")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("audit-log", "", "append a record to the named file whenever an account is unlocked, signs an operation or has its key exported")
+	if err := viper.BindPFlag("audit-log", RootCmd.PersistentFlags().Lookup("audit-log")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Bool("telemetry", false, "output a summary of beacon node API usage and timings to stderr when the command completes")
+	if err := viper.BindPFlag("telemetry", RootCmd.PersistentFlags().Lookup("telemetry")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().String("connection", "", "URL to an Ethereum 2 node's REST API endpoint")
 	if err := viper.BindPFlag("connection", RootCmd.PersistentFlags().Lookup("connection")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().StringArray("connection-headers", nil, "additional header to send to the beacon node, in the form \"name: value\"; can be supplied multiple times")
+	if err := viper.BindPFlag("connection-headers", RootCmd.PersistentFlags().Lookup("connection-headers")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("jwt-secret", "", "secret, in hex or the name of a file containing it, used to authenticate to the beacon node with a JSON web token")
+	if err := viper.BindPFlag("jwt-secret", RootCmd.PersistentFlags().Lookup("jwt-secret")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("execution-connection", "", "URL to an Ethereum execution node's JSON-RPC endpoint, used by some commands to cross-check data")
+	if err := viper.BindPFlag("execution-connection", RootCmd.PersistentFlags().Lookup("execution-connection")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().Duration("timeout", 10*time.Second, "the time after which a network request will be considered failed.  Increase this if you are running on an error-prone, high-latency or low-bandwidth connection")
 	if err := viper.BindPFlag("timeout", RootCmd.PersistentFlags().Lookup("timeout")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().Int("retries", 0, "the number of times to retry connecting to the beacon node, and requests made directly by ethdo such as for rewards, that fail with a timeout or server error (requests made through the beacon node client library are not retried)")
+	if err := viper.BindPFlag("retries", RootCmd.PersistentFlags().Lookup("retries")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Duration("retry-backoff", time.Second, "the delay before the first retry of a failed beacon node request; the delay doubles, with jitter, for each subsequent retry")
+	if err := viper.BindPFlag("retry-backoff", RootCmd.PersistentFlags().Lookup("retry-backoff")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Int("validator-fetch-concurrency", 4, "the number of concurrent requests used when obtaining all validators from the beacon node; 1 obtains them in a single request")
+	if err := viper.BindPFlag("validator-fetch-concurrency", RootCmd.PersistentFlags().Lookup("validator-fetch-concurrency")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().String("remote", "", "connection to a remote wallet daemon")
 	if err := viper.BindPFlag("remote", RootCmd.PersistentFlags().Lookup("remote")); err != nil {
 		panic(err)
@@ -318,6 +590,28 @@ func outputIf(condition bool, msg string) {
 	}
 }
 
+// outputResult outputs the result of a command, applying the format template if supplied.
+func outputResult(res string) error {
+	util.StartTelemetryPhase("output")
+	if viper.GetString("format-template") != "" {
+		var err error
+		res, err = util.ApplyFormatTemplate(viper.GetString("format-template"), res)
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Println(res)
+
+	return nil
+}
+
+// outputTelemetry outputs the telemetry summary, if telemetry is enabled.
+func outputTelemetry() {
+	if summary := util.TelemetrySummary(); summary != "" {
+		fmt.Fprint(os.Stderr, summary)
+	}
+}
+
 // walletFromInput obtains a wallet given the information in the viper variable
 // "account", or if not present the viper variable "wallet".
 func walletFromInput(ctx context.Context) (e2wtypes.Wallet, error) {
@@ -0,0 +1,127 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type outputTestResult struct {
+	Index   uint64 `json:"index"`
+	Balance string `json:"balance"`
+}
+
+func (r *outputTestResult) TableHeaders() []string {
+	return []string{"Index", "Balance"}
+}
+
+func (r *outputTestResult) TableRows() [][]string {
+	return [][]string{{"1", r.Balance}}
+}
+
+// captureStdout runs the supplied function and returns what it wrote to stdout.
+func captureStdout(t *testing.T, f func() error) (string, error) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	fErr := f()
+	require.NoError(t, w.Close())
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(data), fErr
+}
+
+func TestOutputResult(t *testing.T) {
+	result := &outputTestResult{
+		Index:   1,
+		Balance: "32000000000",
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		template string
+		expected string
+		err      string
+	}{
+		{
+			name:     "Plain",
+			expected: "Index: 1\n",
+		},
+		{
+			name:     "JSON",
+			format:   util.OutputFormatJSON,
+			expected: "{\"index\":1,\"balance\":\"32000000000\"}\n",
+		},
+		{
+			name:     "YAML",
+			format:   util.OutputFormatYAML,
+			expected: "index: 1\nbalance: \"32000000000\"\n",
+		},
+		{
+			name:     "Table",
+			format:   util.OutputFormatTable,
+			expected: "Index  Balance\n1      32000000000\n",
+		},
+		{
+			name:     "Template",
+			format:   util.OutputFormatJSON,
+			template: "{{.index}} has {{.balance}} Gwei",
+			expected: "1 has 32000000000 Gwei\n",
+		},
+		{
+			name:     "TemplateBadInput",
+			template: "{{.index}}",
+			err:      "failed to decode command output: invalid character 'I' looking for beginning of value",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("format-template", test.template)
+			defer viper.Set("format-template", "")
+
+			res := "Index: 1"
+			if test.format != "" {
+				var err error
+				res, err = util.RenderOutput(test.format, result)
+				require.NoError(t, err)
+			}
+
+			output, err := captureStdout(t, func() error {
+				return outputResult(res)
+			})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/cmd/serve"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve chain and validator information over HTTP",
+	Long: `Run continuously, serving chain and validator information over an authenticated HTTP API.  For example:
+
+    ethdo serve --listen-address=localhost:9870 --api-token-file=/path/to/token
+
+The API token is read from the file given by --api-token-file, or from the ETHDO_API_TOKEN environment variable.  Requests must supply the API token in an "Authorization: Bearer" header.  Serving on an address other than loopback requires TLS, configured with --tls-cert and --tls-key.  The following endpoints are available:
+
+    GET /v1/chain/status              the current slot and epoch, and the justified and finalized checkpoints
+    GET /v1/validators/{id}           information about a validator, given its index or public key
+    GET /v1/epochs/{epoch}/summary    a summary of the given epoch
+
+With --allow-signing the following endpoint is also available:
+
+    POST /v1/sign/exit                a signed exit for a validator held in a wallet, given its account
+
+Accounts that can sign are supplied with --signing-accounts, and are unlocked when the server starts with the passphrases supplied to the command.  Passphrases are not accepted by the API.  Signed exits are returned to the caller, and are not broadcast.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := serve.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().String("listen-address", "localhost:9870", "Address on which to serve the API")
+	serveCmd.Flags().String("api-token-file", "", "File containing the token that clients must supply to access the API")
+	serveCmd.Flags().String("tls-cert", "", "File containing the TLS certificate with which to serve the API")
+	serveCmd.Flags().String("tls-key", "", "File containing the TLS key with which to serve the API")
+	serveCmd.Flags().Bool("allow-signing", false, "Serve endpoints that sign operations with accounts in local or remote wallets")
+	serveCmd.Flags().StringSlice("signing-accounts", nil, "Accounts to unlock for signing, if signing is allowed")
+}
+
+func serveBindings() {
+	if err := viper.BindPFlag("listen-address", serveCmd.Flags().Lookup("listen-address")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("api-token-file", serveCmd.Flags().Lookup("api-token-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("tls-cert", serveCmd.Flags().Lookup("tls-cert")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("tls-key", serveCmd.Flags().Lookup("tls-key")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("allow-signing", serveCmd.Flags().Lookup("allow-signing")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("signing-accounts", serveCmd.Flags().Lookup("signing-accounts")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"context"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	listenAddress   string
+	apiToken        string
+	tlsCert         string
+	tlsKey          string
+	allowSigning    bool
+	signingAccounts []string
+
+	// Data access.
+	eth2Client         eth2client.Service
+	chainTime          chaintime.Service
+	finalityProvider   eth2client.FinalityProvider
+	validatorsProvider eth2client.ValidatorsProvider
+	// accounts are the unlocked accounts available for signing, by path.
+	accounts map[string]e2wtypes.Account
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:           viper.GetBool("quiet"),
+		verbose:         viper.GetBool("verbose"),
+		debug:           viper.GetBool("debug"),
+		listenAddress:   viper.GetString("listen-address"),
+		tlsCert:         viper.GetString("tls-cert"),
+		tlsKey:          viper.GetString("tls-key"),
+		allowSigning:    viper.GetBool("allow-signing"),
+		signingAccounts: viper.GetStringSlice("signing-accounts"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if c.listenAddress == "" {
+		return nil, errors.New("listen address is required")
+	}
+
+	if (c.tlsCert == "") != (c.tlsKey == "") {
+		return nil, errors.New("tls-cert and tls-key must be supplied together")
+	}
+	// The API token would be sent in the clear, so TLS is required unless the
+	// API is only available on this machine.
+	if c.tlsCert == "" && !isLoopbackAddress(c.listenAddress) {
+		return nil, errors.New("TLS is required to serve on a non-loopback address")
+	}
+
+	// The API is always authenticated, as it provides access to the beacon
+	// node and potentially to signing.  The token is not accepted on the
+	// command line, where it would be visible to other users.
+	var err error
+	c.apiToken, err = obtainAPIToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.allowSigning && len(c.signingAccounts) == 0 {
+		return nil, errors.New("signing accounts are required to allow signing")
+	}
+	if !c.allowSigning && len(c.signingAccounts) > 0 {
+		return nil, errors.New("signing accounts require allow-signing")
+	}
+
+	return c, nil
+}
+
+// obtainAPIToken obtains the API token from a file, or from ETHDO_API_TOKEN.
+func obtainAPIToken() (string, error) {
+	apiTokenFile := viper.GetString("api-token-file")
+	apiToken := viper.GetString("api-token")
+	if apiTokenFile != "" && apiToken != "" {
+		return "", errors.New("only one of api-token-file and ETHDO_API_TOKEN allowed")
+	}
+	if apiTokenFile != "" {
+		data, err := os.ReadFile(apiTokenFile)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read API token file")
+		}
+		apiToken = strings.TrimSpace(string(data))
+	}
+	if apiToken == "" {
+		return "", errors.New("API token is required")
+	}
+
+	return apiToken, nil
+}
+
+// isLoopbackAddress returns true if the listen address is only reachable
+// from this machine.
+func isLoopbackAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,191 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0o600))
+	emptyTokenFile := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(emptyTokenFile, []byte("\n"), 0o600))
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"listen-address": "localhost:9870",
+				"api-token":      "secret",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ListenAddressMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"api-token": "secret",
+			},
+			err: "listen address is required",
+		},
+		{
+			name: "APITokenMissing",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"listen-address": "localhost:9870",
+			},
+			err: "API token is required",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"listen-address": "localhost:9870",
+				"api-token":      "secret",
+			},
+		},
+		{
+			name: "APITokenFileAndEnv",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"listen-address": "localhost:9870",
+				"api-token":      "secret",
+				"api-token-file": tokenFile,
+			},
+			err: "only one of api-token-file and ETHDO_API_TOKEN allowed",
+		},
+		{
+			name: "APITokenFileMissing",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"listen-address": "localhost:9870",
+				"api-token-file": filepath.Join(dir, "missing"),
+			},
+			err: fmt.Sprintf("failed to read API token file: open %s: no such file or directory", filepath.Join(dir, "missing")),
+		},
+		{
+			name: "APITokenFileEmpty",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"listen-address": "localhost:9870",
+				"api-token-file": emptyTokenFile,
+			},
+			err: "API token is required",
+		},
+		{
+			name: "NonLoopbackWithoutTLS",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"listen-address": "0.0.0.0:9870",
+				"api-token":      "secret",
+			},
+			err: "TLS is required to serve on a non-loopback address",
+		},
+		{
+			name: "TLSKeyMissing",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"listen-address": "0.0.0.0:9870",
+				"api-token":      "secret",
+				"tls-cert":       "cert.pem",
+			},
+			err: "tls-cert and tls-key must be supplied together",
+		},
+		{
+			name: "SigningAccountsMissing",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"listen-address": "localhost:9870",
+				"api-token":      "secret",
+				"allow-signing":  true,
+			},
+			err: "signing accounts are required to allow signing",
+		},
+		{
+			name: "SigningAccountsWithoutAllowSigning",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"listen-address":   "localhost:9870",
+				"api-token":        "secret",
+				"signing-accounts": []string{"Test wallet/Test account"},
+			},
+			err: "signing accounts require allow-signing",
+		},
+		{
+			name: "GoodAPITokenFile",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"listen-address": "localhost:9870",
+				"api-token-file": tokenFile,
+			},
+		},
+		{
+			name: "GoodIPv6Loopback",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"listen-address": "[::1]:9870",
+				"api-token":      "secret",
+			},
+		},
+		{
+			name: "GoodTLS",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"listen-address": "0.0.0.0:9870",
+				"api-token":      "secret",
+				"tls-cert":       "cert.pem",
+				"tls-key":        "key.pem",
+			},
+		},
+		{
+			name: "GoodAllowSigning",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"listen-address":   "localhost:9870",
+				"api-token":        "secret",
+				"allow-signing":    true,
+				"signing-accounts": []string{"Test wallet/Test account"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/beacon"
+	epochsummary "github.com/wealdtech/ethdo/cmd/epoch/summary"
+	"github.com/wealdtech/ethdo/ops"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type chainStatusJSON struct {
+	Slot      string             `json:"slot"`
+	Epoch     string             `json:"epoch"`
+	Justified *phase0.Checkpoint `json:"justified"`
+	Finalized *phase0.Checkpoint `json:"finalized"`
+}
+
+type signExitRequestJSON struct {
+	Account string `json:"account"`
+	Epoch   string `json:"epoch,omitempty"`
+}
+
+type errorJSON struct {
+	Error string `json:"error"`
+}
+
+// handler returns the handler for the API.
+func (c *command) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/chain/status", c.handleChainStatus)
+	mux.HandleFunc("/v1/validators/", c.handleValidator)
+	mux.HandleFunc("/v1/epochs/", c.handleEpochSummary)
+	// Signing is only available if explicitly allowed.
+	if c.allowSigning {
+		mux.HandleFunc("/v1/sign/exit", c.handleSignExit)
+	}
+
+	return c.authenticate(mux)
+}
+
+// authenticate ensures that requests supply the API token.
+func (c *command) authenticate(next http.Handler) http.Handler {
+	expected := []byte(fmt.Sprintf("Bearer %s", c.apiToken))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		util.Log.Debug().Str("method", r.Method).Str("path", r.URL.Path).Msg("Received request")
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (c *command) handleChainStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	finality, err := c.finalityProvider.Finality(r.Context(), "head")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to obtain finality: %v", err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &chainStatusJSON{
+		Slot:      fmt.Sprintf("%d", c.chainTime.CurrentSlot()),
+		Epoch:     fmt.Sprintf("%d", c.chainTime.CurrentEpoch()),
+		Justified: finality.Justified,
+		Finalized: finality.Finalized,
+	})
+}
+
+func (c *command) handleValidator(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	// Only indices and public keys are accepted; accounts would give access
+	// to the local wallets.
+	id := strings.TrimPrefix(r.URL.Path, "/v1/validators/")
+	if id == "" || strings.Contains(id, "/") {
+		writeError(w, http.StatusBadRequest, "validator must be an index or public key")
+		return
+	}
+
+	validator, err := util.ParseValidator(r.Context(), c.validatorsProvider, id, "head")
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, validator)
+}
+
+func (c *command) handleEpochSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	epoch := strings.TrimPrefix(r.URL.Path, "/v1/epochs/")
+	if !strings.HasSuffix(epoch, "/summary") {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	epoch = strings.TrimSuffix(epoch, "/summary")
+
+	summary, err := epochsummary.Summary(r.Context(), c.eth2Client, epoch)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to obtain epoch summary: %v", err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, json.RawMessage(summary))
+}
+
+// handleSignExit generates a signed exit for a validator held in one of the
+// accounts unlocked when the server started.  The exit is returned to the
+// caller, and not broadcast.
+func (c *command) handleSignExit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	ctx := r.Context()
+
+	var req signExitRequestJSON
+	decoder := json.NewDecoder(r.Body)
+	// Reject unknown fields, so that passphrases are not silently accepted.
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+	if req.Account == "" {
+		writeError(w, http.StatusBadRequest, "account is required")
+		return
+	}
+
+	account, exists := c.accounts[req.Account]
+	if !exists {
+		writeError(w, http.StatusBadRequest, "account not available for signing")
+		return
+	}
+	pubKey, err := util.BestPublicKey(account)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("failed to obtain public key for account: %v", err))
+		return
+	}
+	pubKeyStr := fmt.Sprintf("%#x", pubKey.Marshal())
+
+	validator, err := util.ParseValidator(ctx, c.validatorsProvider, pubKeyStr, "head")
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	epoch := c.chainTime.CurrentEpoch()
+	if req.Epoch != "" {
+		epoch, err = util.ParseEpoch(ctx, c.chainTime, req.Epoch)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid epoch: %v", err))
+			return
+		}
+	}
+
+	chainInfo, err := beacon.ObtainChainInfoFromNodeForValidators(ctx, c.eth2Client, c.chainTime, []string{pubKeyStr})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to obtain chain information: %v", err))
+		return
+	}
+	forkVersion, _, err := chainInfo.VoluntaryExitForkVersion()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to obtain fork version: %v", err))
+		return
+	}
+	domain, err := ops.ComputeDomain(chainInfo.VoluntaryExitDomainType, forkVersion, chainInfo.GenesisValidatorsRoot)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// The account was unlocked when the server started.
+	exit, err := ops.BuildSignedVoluntaryExit(ctx, account, nil, validator.Index, epoch, domain)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, exit)
+}
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	res, err := json.Marshal(data)
+	if err != nil {
+		status = http.StatusInternalServerError
+		res = []byte(`{"error":"failed to generate response"}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(res); err != nil {
+		util.Log.Debug().Err(err).Msg("Failed to write response")
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, &errorJSON{Error: msg})
+}
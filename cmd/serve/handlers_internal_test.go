@@ -0,0 +1,216 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/testing/mock"
+	"github.com/wealdtech/ethdo/testutil"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type finalityProvider struct{}
+
+func (*finalityProvider) Finality(_ context.Context, _ string) (*apiv1.Finality, error) {
+	return &apiv1.Finality{
+		Finalized: &phase0.Checkpoint{Epoch: 8, Root: phase0.Root{0x08}},
+		Justified: &phase0.Checkpoint{Epoch: 9, Root: phase0.Root{0x09}},
+	}, nil
+}
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now().Add(-10*32*12*time.Second))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+	)
+	require.NoError(t, err)
+
+	c := &command{
+		apiToken:         "secret",
+		chainTime:        chainTime,
+		finalityProvider: &finalityProvider{},
+		validatorsProvider: mock.NewValidatorsProvider([]*apiv1.Validator{
+			{
+				Index:  1,
+				Status: apiv1.ValidatorStateActiveOngoing,
+				Validator: &phase0.Validator{
+					PublicKey:             testutil.HexToPubKey("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+					WithdrawalCredentials: make([]byte, 32),
+					EffectiveBalance:      32000000000,
+					ExitEpoch:             0xffffffffffffffff,
+					WithdrawableEpoch:     0xffffffffffffffff,
+				},
+			},
+		}),
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		token    string
+		status   int
+		contains string
+	}{
+		{
+			name:   "NoToken",
+			method: http.MethodGet,
+			path:   "/v1/chain/status",
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "BadToken",
+			method: http.MethodGet,
+			path:   "/v1/chain/status",
+			token:  "Bearer bad",
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:     "ChainStatus",
+			method:   http.MethodGet,
+			path:     "/v1/chain/status",
+			token:    "Bearer secret",
+			status:   http.StatusOK,
+			contains: `"finalized":{"epoch":"8"`,
+		},
+		{
+			name:   "ChainStatusBadMethod",
+			method: http.MethodPost,
+			path:   "/v1/chain/status",
+			token:  "Bearer secret",
+			status: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "ValidatorIndex",
+			method:   http.MethodGet,
+			path:     "/v1/validators/1",
+			token:    "Bearer secret",
+			status:   http.StatusOK,
+			contains: `"index":"1"`,
+		},
+		{
+			name:     "ValidatorPubKey",
+			method:   http.MethodGet,
+			path:     "/v1/validators/0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+			token:    "Bearer secret",
+			status:   http.StatusOK,
+			contains: `"index":"1"`,
+		},
+		{
+			name:     "ValidatorUnknown",
+			method:   http.MethodGet,
+			path:     "/v1/validators/2",
+			token:    "Bearer secret",
+			status:   http.StatusNotFound,
+			contains: "unknown validator",
+		},
+		{
+			name:     "ValidatorAccount",
+			method:   http.MethodGet,
+			path:     "/v1/validators/wallet/account",
+			token:    "Bearer secret",
+			status:   http.StatusBadRequest,
+			contains: "validator must be an index or public key",
+		},
+		{
+			name:   "SigningNotAllowed",
+			method: http.MethodPost,
+			path:   "/v1/sign/exit",
+			token:  "Bearer secret",
+			status: http.StatusNotFound,
+		},
+	}
+
+	handler := c.handler()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, test.path, nil)
+			if test.token != "" {
+				req.Header.Set("Authorization", test.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, test.status, rec.Code)
+			if test.contains != "" {
+				require.True(t, strings.Contains(rec.Body.String(), test.contains), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestSignExitHandler(t *testing.T) {
+	c := &command{
+		apiToken:     "secret",
+		allowSigning: true,
+		accounts:     map[string]e2wtypes.Account{},
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		status   int
+		contains string
+	}{
+		{
+			name:     "BadJSON",
+			body:     `{`,
+			status:   http.StatusBadRequest,
+			contains: "invalid request",
+		},
+		{
+			name:     "Passphrase",
+			body:     `{"account":"Test wallet/Test account","passphrase":"secret"}`,
+			status:   http.StatusBadRequest,
+			contains: "invalid request",
+		},
+		{
+			name:     "AccountMissing",
+			body:     `{}`,
+			status:   http.StatusBadRequest,
+			contains: "account is required",
+		},
+		{
+			name:     "AccountNotUnlocked",
+			body:     `{"account":"Test wallet/Test account"}`,
+			status:   http.StatusBadRequest,
+			contains: "account not available for signing",
+		},
+	}
+
+	handler := c.handler()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/sign/exit", strings.NewReader(test.body))
+			req.Header.Set("Authorization", "Bearer secret")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, test.status, rec.Code)
+			require.True(t, strings.Contains(rec.Body.String(), test.contains), rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	server := &http.Server{
+		Addr:              c.listenAddress,
+		Handler:           c.handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		if c.tlsCert != "" {
+			errCh <- server.ListenAndServeTLS(c.tlsCert, c.tlsKey)
+		} else {
+			errCh <- server.ListenAndServe()
+		}
+	}()
+	if !c.quiet {
+		fmt.Fprintf(os.Stdout, "Listening on %s\n", c.listenAddress)
+	}
+
+	select {
+	case err := <-errCh:
+		return errors.Wrap(err, "failed to serve API")
+	case <-ctx.Done():
+	}
+
+	util.Log.Debug().Msg("Shutting down")
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), c.timeout)
+	defer shutdownCancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return errors.Wrap(err, "failed to shut down API")
+	}
+
+	return c.lockAccounts(shutdownCtx)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.finalityProvider, isProvider = c.eth2Client.(eth2client.FinalityProvider)
+	if !isProvider {
+		return errors.New("connection does not provide finality")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+
+	return c.unlockAccounts(ctx)
+}
+
+// unlockAccounts unlocks the signing accounts with the passphrases supplied
+// to the command, so that passphrases are never sent to the API.
+func (c *command) unlockAccounts(ctx context.Context) error {
+	c.accounts = make(map[string]e2wtypes.Account, len(c.signingAccounts))
+	for _, path := range c.signingAccounts {
+		_, account, err := util.WalletAndAccountFromPath(ctx, path)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain account %s", path))
+		}
+		if _, err := signing.Unlock(ctx, account, util.GetPassphrases()); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to unlock account %s", path))
+		}
+		c.accounts[path] = account
+	}
+
+	return nil
+}
+
+// lockAccounts locks the signing accounts.
+func (c *command) lockAccounts(ctx context.Context) error {
+	for path, account := range c.accounts {
+		if err := signing.Lock(ctx, account); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to lock account %s", path))
+		}
+	}
+
+	return nil
+}
@@ -27,30 +27,47 @@ import (
 )
 
 var signatureAggregateSignatures []string
+var signatureAggregatePubKeys []string
 
 // signatureAggregateCmd represents the signature aggregate command
 var signatureAggregateCmd = &cobra.Command{
 	Use:   "aggregate",
-	Short: "Aggregate signatures",
-	Long: `Aggregate signatures, either threshold or absolute.  For example:
+	Short: "Aggregate signatures and public keys",
+	Long: `Aggregate signatures, either threshold or absolute, and public keys.  For example:
 
-    ethdo signature aggregate --signatures=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7,
+    ethdo signature aggregate --signature=0x8f2c...  --signature=0xa41b...
 
 Signatures are specified as "signature" for simple aggregation, and as "id:signature" for threshold aggregation.
 
-In quiet mode this will return 0 if the signatures can be aggregated, otherwise 1.`,
+Public keys can be aggregated alongside, or instead of, signatures.  For example:
+
+    ethdo signature aggregate --pubkey=0xa99a... --pubkey=0xb89b...
+
+If both signatures and public keys are supplied the aggregate signature is output first, followed by the aggregate public key.
+
+In quiet mode this will return 0 if the signatures and public keys can be aggregated, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		assert(len(signatureAggregateSignatures) > 1, "multiple signatures required to aggregate")
-		var signature *bls.Sign
-		var err error
-		if strings.Contains(signatureAggregateSignatures[0], ":") {
-			signature, err = generateThresholdSignature()
-		} else {
-			signature, err = generateAggregateSignature()
+		assert(len(signatureAggregateSignatures) > 0 || len(signatureAggregatePubKeys) > 0, "signatures or public keys required to aggregate")
+		if len(signatureAggregateSignatures) > 0 {
+			assert(len(signatureAggregateSignatures) > 1, "multiple signatures required to aggregate")
+			var signature *bls.Sign
+			var err error
+			if strings.Contains(signatureAggregateSignatures[0], ":") {
+				signature, err = generateThresholdSignature()
+			} else {
+				signature, err = generateAggregateSignature()
+			}
+			errCheck(err, "Failed to aggregate signature")
+			outputIf(!quiet, fmt.Sprintf("%#x", signature.Serialize()))
+		}
+
+		if len(signatureAggregatePubKeys) > 0 {
+			assert(len(signatureAggregatePubKeys) > 1, "multiple public keys required to aggregate")
+			pubKey, err := generateAggregatePublicKey(signatureAggregatePubKeys)
+			errCheck(err, "Failed to aggregate public key")
+			outputIf(!quiet, fmt.Sprintf("%#x", pubKey.Serialize()))
 		}
-		errCheck(err, "Failed to aggregate signature")
 
-		outputIf(!quiet, fmt.Sprintf("%#x", signature.Serialize()))
 		os.Exit(_exitSuccess)
 	},
 }
@@ -103,8 +120,30 @@ func generateAggregateSignature() (*bls.Sign, error) {
 	return &aggregateSig, nil
 }
 
+func generateAggregatePublicKey(pubKeys []string) (*bls.PublicKey, error) {
+	var aggregatePubKey bls.PublicKey
+	for i := range pubKeys {
+		pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(pubKeys[i], "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode public key")
+		}
+		var pubKey bls.PublicKey
+		if err := pubKey.Deserialize(pubKeyBytes); err != nil {
+			return nil, errors.Wrap(err, "invalid public key")
+		}
+		if i == 0 {
+			aggregatePubKey = pubKey
+		} else {
+			aggregatePubKey.Add(&pubKey)
+		}
+	}
+
+	return &aggregatePubKey, nil
+}
+
 func init() {
 	signatureCmd.AddCommand(signatureAggregateCmd)
 	signatureAggregateCmd.Flags().StringArrayVar(&signatureAggregateSignatures, "signature", nil, "a signature to aggregate (supply once for each signature)")
+	signatureAggregateCmd.Flags().StringArrayVar(&signatureAggregatePubKeys, "pubkey", nil, "a public key to aggregate (supply once for each public key)")
 	signatureFlags(signatureAggregateCmd)
 }
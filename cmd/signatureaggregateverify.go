@@ -0,0 +1,89 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/go-bytesutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+var signatureAggregateVerifySignature string
+var signatureAggregateVerifyPubKeys []string
+
+// signatureAggregateVerifyCmd represents the signature aggregate verify command
+var signatureAggregateVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify an aggregate signature",
+	Long: `Verify an aggregate signature over a root, given the public keys of all signers.  For example:
+
+    ethdo signature aggregate verify --data=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7 --signature=0x8888... --pubkey=0xa99a... --pubkey=0xb89b...
+
+All signers must have signed the same data, as is the case for attestations and sync committee messages.
+
+In quiet mode this will return 0 if the aggregate signature is verified, otherwise 1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assert(viper.GetString("signature-data") != "", "--data is required")
+		data, err := bytesutil.FromHexString(viper.GetString("signature-data"))
+		errCheck(err, "Failed to parse data")
+		assert(len(data) == 32, "data to verify must be 32 bytes")
+
+		assert(signatureAggregateVerifySignature != "", "--signature is required")
+		signatureBytes, err := bytesutil.FromHexString(signatureAggregateVerifySignature)
+		errCheck(err, "Failed to parse signature")
+		signature, err := e2types.BLSSignatureFromBytes(signatureBytes)
+		errCheck(err, "Invalid signature")
+
+		assert(len(signatureAggregateVerifyPubKeys) > 0, "--pubkey is required")
+		pubKeys := make([]e2types.PublicKey, len(signatureAggregateVerifyPubKeys))
+		for i := range signatureAggregateVerifyPubKeys {
+			pubKeyBytes, err := bytesutil.FromHexString(signatureAggregateVerifyPubKeys[i])
+			errCheck(err, "Failed to parse public key")
+			pubKeys[i], err = e2types.BLSPublicKeyFromBytes(pubKeyBytes)
+			errCheck(err, "Invalid public key")
+		}
+
+		domain := e2types.Domain(e2types.DomainType([4]byte{0, 0, 0, 0}), e2types.ZeroForkVersion, e2types.ZeroGenesisValidatorsRoot)
+		if viper.GetString("signature-domain") != "" {
+			domain, err = bytesutil.FromHexString(viper.GetString("signature-domain"))
+			errCheck(err, "Failed to parse domain")
+			assert(len(domain) == 32, "Domain data invalid")
+		}
+
+		container := &spec.SigningData{}
+		copy(container.ObjectRoot[:], data)
+		copy(container.Domain[:], domain)
+		signingRoot, err := container.HashTreeRoot()
+		errCheck(err, "Failed to obtain signing root")
+		util.Log.Debug().Str("signing_root", fmt.Sprintf("%#x", signingRoot)).Msg("Obtained signing root")
+
+		assert(signature.VerifyAggregateCommon(signingRoot[:], pubKeys), "Failed to verify")
+
+		outputIf(verbose, "Verified")
+		os.Exit(_exitSuccess)
+	},
+}
+
+func init() {
+	signatureAggregateCmd.AddCommand(signatureAggregateVerifyCmd)
+	signatureFlags(signatureAggregateVerifyCmd)
+	signatureAggregateVerifyCmd.Flags().StringVar(&signatureAggregateVerifySignature, "signature", "", "the aggregate signature to verify")
+	signatureAggregateVerifyCmd.Flags().StringArrayVar(&signatureAggregateVerifyPubKeys, "pubkey", nil, "the public key of a signer (supply once for each signer)")
+}
@@ -51,7 +51,7 @@ In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 			errCheck(err, "Failed to parse domain")
 			assert(len(domain) == 32, "Domain data invalid")
 		}
-		outputIf(debug, fmt.Sprintf("Domain is %#x", domain))
+		util.Log.Debug().Str("domain", fmt.Sprintf("%#x", domain)).Msg("Obtained domain")
 
 		var account e2wtypes.Account
 		switch {
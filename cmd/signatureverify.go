@@ -17,8 +17,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 
+	eth2client "github.com/attestantio/go-eth2-client"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/util"
@@ -29,6 +32,9 @@ import (
 
 var signatureVerifySignature string
 var signatureVerifySigner string
+var signatureVerifyContainerFile string
+var signatureVerifyDomainType string
+var signatureVerifyEpoch string
 
 // signatureVerifyCmd represents the signature verify command
 var signatureVerifyCmd = &cobra.Command{
@@ -38,28 +44,48 @@ var signatureVerifyCmd = &cobra.Command{
 
     ethdo signature verify --data=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7 --signature=0x8888... --account="Personal wallet/Operations"
 
+A signed SSZ container can be verified directly, with the signing root calculated from the container, domain type and epoch.  For example:
+
+    ethdo signature verify --container-file=exit.ssz --domain-type=DOMAIN_VOLUNTARY_EXIT --epoch=194048 --signature=0x8888... --public-key=0xa99a...
+
 In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 		defer cancel()
 
-		assert(viper.GetString("signature-data") != "", "--data is required")
-		data, err := bytesutil.FromHexString(viper.GetString("signature-data"))
-		errCheck(err, "Failed to parse data")
-		assert(len(data) == 32, "data to verify must be 32 bytes")
-
 		assert(signatureVerifySignature != "", "--signature is required")
 		signatureBytes, err := bytesutil.FromHexString(signatureVerifySignature)
 		errCheck(err, "Failed to parse signature")
 		signature, err := e2types.BLSSignatureFromBytes(signatureBytes)
 		errCheck(err, "Invalid signature")
 
-		domain := e2types.Domain(e2types.DomainType([4]byte{0, 0, 0, 0}), e2types.ZeroForkVersion, e2types.ZeroGenesisValidatorsRoot)
-		if viper.GetString("signature-domain") != "" {
-			domain, err = bytesutil.FromHexString(viper.GetString("signature-domain"))
-			errCheck(err, "Failed to parse domain")
-			assert(len(domain) == 32, "Domain data invalid")
+		var root [32]byte
+		var specDomain spec.Domain
+		if signatureVerifyContainerFile != "" {
+			assert(signatureVerifyDomainType != "", "--domain-type is required with --container-file")
+			containerData, err := os.ReadFile(signatureVerifyContainerFile)
+			errCheck(err, "Failed to read container file")
+			root, err = util.SigningContainerRoot(signatureVerifyDomainType, containerData)
+			errCheck(err, "Failed to obtain container root")
+			specDomain, err = signatureVerifyDomain(ctx, signatureVerifyDomainType, signatureVerifyEpoch)
+			errCheck(err, "Failed to obtain domain")
+		} else {
+			assert(viper.GetString("signature-data") != "", "--data is required")
+			data, err := bytesutil.FromHexString(viper.GetString("signature-data"))
+			errCheck(err, "Failed to parse data")
+			assert(len(data) == 32, "data to verify must be 32 bytes")
+			copy(root[:], data)
+
+			domain := e2types.Domain(e2types.DomainType([4]byte{0, 0, 0, 0}), e2types.ZeroForkVersion, e2types.ZeroGenesisValidatorsRoot)
+			if viper.GetString("signature-domain") != "" {
+				domain, err = bytesutil.FromHexString(viper.GetString("signature-domain"))
+				errCheck(err, "Failed to parse domain")
+				assert(len(domain) == 32, "Domain data invalid")
+			}
+			copy(specDomain[:], domain)
 		}
+		util.Log.Debug().Str("root", fmt.Sprintf("%#x", root)).Msg("Obtained root")
+		util.Log.Debug().Str("domain", fmt.Sprintf("%#x", specDomain)).Msg("Obtained domain")
 
 		var account e2wtypes.Account
 		switch {
@@ -71,18 +97,14 @@ In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 			account, err = util.ParseAccount(ctx, viper.GetString("public-key"), nil, false)
 		}
 		errCheck(err, "Failed to obtain account")
-		outputIf(debug, fmt.Sprintf("Public key is %#x", account.PublicKey().Marshal()))
+		util.Log.Debug().Str("pubkey", fmt.Sprintf("%#x", account.PublicKey().Marshal())).Msg("Obtained public key")
 
-		var specDomain spec.Domain
-		copy(specDomain[:], domain)
-		var root [32]byte
-		copy(root[:], data)
 		verified, err := util.VerifyRoot(account, root, specDomain, signature)
 		errCheck(err, "Failed to verify data")
 		assert(verified, "Failed to verify")
 
 		outputIf(verbose, "Verified")
-		os.Exit(_exitSuccess)
+		exit(_exitSuccess)
 	},
 }
 
@@ -91,4 +113,74 @@ func init() {
 	signatureFlags(signatureVerifyCmd)
 	signatureVerifyCmd.Flags().StringVar(&signatureVerifySignature, "signature", "", "the signature to verify")
 	signatureVerifyCmd.Flags().StringVar(&signatureVerifySigner, "signer", "", "the public key of the signer (only if --account is not supplied)")
+	signatureVerifyCmd.Flags().StringVar(&signatureVerifyContainerFile, "container-file", "", "a file containing the SSZ-encoded container that was signed, as an alternative to --data")
+	signatureVerifyCmd.Flags().StringVar(&signatureVerifyDomainType, "domain-type", "", "the name of the domain type in which the container was signed, for example DOMAIN_VOLUNTARY_EXIT")
+	signatureVerifyCmd.Flags().StringVar(&signatureVerifyEpoch, "epoch", "", "the epoch at which the container was signed, used to select the fork version")
+}
+
+// signatureVerifyDomain obtains the domain for the given domain type name at the given epoch from the chain.
+func signatureVerifyDomain(ctx context.Context, domainTypeName string, epochStr string) (spec.Domain, error) {
+	consensusClient, err := util.ConnectToBeaconNode(ctx, viper.GetString("connection"), viper.GetDuration("timeout"), viper.GetBool("allow-insecure-connections"))
+	if err != nil {
+		return spec.Domain{}, errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	chainSpec, err := consensusClient.(eth2client.SpecProvider).Spec(ctx)
+	if err != nil {
+		return spec.Domain{}, errors.Wrap(err, "failed to obtain spec")
+	}
+	domainType, exists := chainSpec[domainTypeName].(spec.DomainType)
+	if !exists {
+		return spec.Domain{}, fmt.Errorf("domain type %s not known by chain", domainTypeName)
+	}
+
+	var forkVersion spec.Version
+	genesisValidatorsRoot := spec.Root{}
+	if domainTypeName == "DOMAIN_DEPOSIT" {
+		// Deposits are signed with the genesis fork version and no genesis validators root.
+		forkVersion, exists = chainSpec["GENESIS_FORK_VERSION"].(spec.Version)
+		if !exists {
+			return spec.Domain{}, errors.New("genesis fork version not known by chain")
+		}
+	} else {
+		if epochStr == "" {
+			return spec.Domain{}, errors.New("--epoch is required")
+		}
+		epoch, err := strconv.ParseUint(epochStr, 10, 64)
+		if err != nil {
+			return spec.Domain{}, errors.Wrap(err, "invalid epoch")
+		}
+
+		genesis, err := consensusClient.(eth2client.GenesisProvider).Genesis(ctx)
+		if err != nil {
+			return spec.Domain{}, errors.Wrap(err, "failed to obtain genesis")
+		}
+		genesisValidatorsRoot = genesis.GenesisValidatorsRoot
+
+		forkSchedule, err := consensusClient.(eth2client.ForkScheduleProvider).ForkSchedule(ctx)
+		if err != nil {
+			return spec.Domain{}, errors.Wrap(err, "failed to obtain fork schedule")
+		}
+		for _, fork := range forkSchedule {
+			if uint64(fork.Epoch) > epoch {
+				break
+			}
+			forkVersion = fork.CurrentVersion
+			if domainTypeName == "DOMAIN_VOLUNTARY_EXIT" {
+				if capellaForkVersion, exists := chainSpec["CAPELLA_FORK_VERSION"].(spec.Version); exists && fork.CurrentVersion == capellaForkVersion {
+					// Exits from Capella onwards are signed with the Capella fork version, as per EIP-7044.
+					break
+				}
+			}
+		}
+	}
+
+	domain, err := e2types.ComputeDomain(e2types.DomainType(domainType), forkVersion[:], genesisValidatorsRoot[:])
+	if err != nil {
+		return spec.Domain{}, errors.Wrap(err, "failed to compute domain")
+	}
+	var res spec.Domain
+	copy(res[:], domain)
+
+	return res, nil
 }
@@ -29,8 +29,11 @@ type dataIn struct {
 	quiet   bool
 	verbose bool
 	debug   bool
+	json    bool
 	// Operation.
 	slot       string
+	timestamp  string
+	location   *time.Location
 	eth2Client eth2client.Service
 }
 
@@ -44,14 +47,24 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
+	data.json = viper.GetBool("json")
 
-	if viper.GetString("slot") == "" {
-		return nil, errors.New("slot is required")
-	}
 	data.slot = viper.GetString("slot")
+	data.timestamp = viper.GetString("timestamp")
+	if data.slot == "" && data.timestamp == "" {
+		return nil, errors.New("one of slot or timestamp is required")
+	}
+	if data.slot != "" && data.timestamp != "" {
+		return nil, errors.New("only one of slot and timestamp allowed")
+	}
 
-	// Ethereum 2 client.
 	var err error
+	data.location, err = util.ParseTimezone(viper.GetString("timezone"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Ethereum 2 client.
 	data.eth2Client, err = util.ConnectToBeaconNode(ctx, viper.GetString("connection"), viper.GetDuration("timeout"), viper.GetBool("allow-insecure-connections"))
 	if err != nil {
 		return nil, err
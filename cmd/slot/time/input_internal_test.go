@@ -65,7 +65,25 @@ func TestInput(t *testing.T) {
 			vars: map[string]interface{}{
 				"timeout": "5s",
 			},
-			err: "slot is required",
+			err: "one of slot or timestamp is required",
+		},
+		{
+			name: "SlotAndTimestamp",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"slot":      "1",
+				"timestamp": "2020-12-01T12:00:23Z",
+			},
+			err: "only one of slot and timestamp allowed",
+		},
+		{
+			name: "TimezoneInvalid",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"slot":     "1",
+				"timezone": "Mars/Olympus_Mons",
+			},
+			err: `unknown timezone "Mars/Olympus_Mons"`,
 		},
 		{
 			name: "ConnectionMissing",
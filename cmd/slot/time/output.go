@@ -15,18 +15,31 @@ package slottime
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
 
 type dataOut struct {
-	debug     bool
-	quiet     bool
-	verbose   bool
-	startTime time.Time
-	endTime   time.Time
+	debug         bool
+	quiet         bool
+	verbose       bool
+	json          bool
+	fromTimestamp bool
+	slot          phase0.Slot
+	epoch         phase0.Epoch
+	startTime     time.Time
+	endTime       time.Time
+}
+
+type slotTimeJSON struct {
+	Slot      string `json:"slot"`
+	Epoch     string `json:"epoch"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
 }
 
 func output(ctx context.Context, data *dataOut) (string, error) {
@@ -37,6 +50,24 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 	if data.quiet {
 		return "", nil
 	}
+	if data.json {
+		res, err := json.Marshal(&slotTimeJSON{
+			Slot:      fmt.Sprintf("%d", data.slot),
+			Epoch:     fmt.Sprintf("%d", data.epoch),
+			StartTime: data.startTime.Format(time.RFC3339),
+			EndTime:   data.endTime.Format(time.RFC3339),
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "failed to generate JSON")
+		}
+		return string(res), nil
+	}
+	if data.fromTimestamp {
+		if data.verbose {
+			return fmt.Sprintf("%d (%s - %s)", data.slot, data.startTime, data.endTime), nil
+		}
+		return fmt.Sprintf("%d", data.slot), nil
+	}
 	if data.verbose {
 		return fmt.Sprintf("%s - %s", data.startTime, data.endTime), nil
 	}
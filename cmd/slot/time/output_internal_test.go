@@ -48,6 +48,37 @@ func TestOutput(t *testing.T) {
 			},
 			res: "2020-12-01 12:00:23 +0000 UTC - 2020-12-01 12:00:35 +0000 UTC",
 		},
+		{
+			name: "FromTimestamp",
+			dataOut: &dataOut{
+				fromTimestamp: true,
+				slot:          1,
+				startTime:     time.Unix(1606824035, 0),
+				endTime:       time.Unix(1606824047, 0),
+			},
+			res: "1",
+		},
+		{
+			name: "FromTimestampVerbose",
+			dataOut: &dataOut{
+				fromTimestamp: true,
+				verbose:       true,
+				slot:          1,
+				startTime:     time.Unix(1606824035, 0),
+				endTime:       time.Unix(1606824047, 0),
+			},
+			res: "1 (2020-12-01 12:00:35 +0000 UTC - 2020-12-01 12:00:47 +0000 UTC)",
+		},
+		{
+			name: "JSON",
+			dataOut: &dataOut{
+				json:      true,
+				slot:      1,
+				startTime: time.Unix(1606824035, 0).In(time.UTC),
+				endTime:   time.Unix(1606824047, 0).In(time.UTC),
+			},
+			res: `{"slot":"1","epoch":"0","start_time":"2020-12-01T12:00:35Z","end_time":"2020-12-01T12:00:47Z"}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -19,7 +19,9 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
 )
 
 func process(ctx context.Context, data *dataIn) (*dataOut, error) {
@@ -28,17 +30,11 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	}
 
 	results := &dataOut{
-		debug:   data.debug,
-		quiet:   data.quiet,
-		verbose: data.verbose,
-	}
-
-	slot, err := strconv.ParseInt(data.slot, 10, 64)
-	if err != nil {
-		return nil, errors.Wrap(err, "invalid slot specified")
-	}
-	if slot < 0 {
-		return nil, errors.New("slot must be a positive integer")
+		debug:         data.debug,
+		quiet:         data.quiet,
+		verbose:       data.verbose,
+		json:          data.json,
+		fromTimestamp: data.timestamp != "",
 	}
 
 	genesis, err := data.eth2Client.(eth2client.GenesisProvider).Genesis(ctx)
@@ -52,9 +48,39 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	}
 
 	slotDuration := config["SECONDS_PER_SLOT"].(time.Duration)
+	slotsPerEpoch := config["SLOTS_PER_EPOCH"].(uint64)
 
-	results.startTime = genesis.GenesisTime.Add((time.Duration(slot*int64(slotDuration.Seconds())) * time.Second))
+	if data.timestamp != "" {
+		location := data.location
+		if location == nil {
+			location = time.Local
+		}
+		timestamp, err := util.ParseTimestamp(data.timestamp, location)
+		if err != nil {
+			return nil, err
+		}
+		if timestamp.Before(genesis.GenesisTime) {
+			return nil, errors.New("timestamp prior to genesis")
+		}
+		results.slot = phase0.Slot(timestamp.Sub(genesis.GenesisTime) / slotDuration)
+	} else {
+		slot, err := strconv.ParseInt(data.slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid slot specified")
+		}
+		if slot < 0 {
+			return nil, errors.New("slot must be a positive integer")
+		}
+		results.slot = phase0.Slot(slot)
+	}
+
+	results.epoch = phase0.Epoch(uint64(results.slot) / slotsPerEpoch)
+	results.startTime = genesis.GenesisTime.Add((time.Duration(uint64(results.slot)*uint64(slotDuration.Seconds())) * time.Second))
 	results.endTime = results.startTime.Add(slotDuration)
+	if data.location != nil {
+		results.startTime = results.startTime.In(data.location)
+		results.endTime = results.endTime.In(data.location)
+	}
 
 	return results, nil
 }
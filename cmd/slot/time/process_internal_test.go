@@ -60,6 +60,22 @@ func TestProcess(t *testing.T) {
 			},
 			expected: time.Unix(1606824035, 0),
 		},
+		{
+			name: "Timestamp",
+			dataIn: &dataIn{
+				eth2Client: eth2Client,
+				timestamp:  "2020-12-01T12:00:40Z",
+			},
+			expected: time.Unix(1606824035, 0),
+		},
+		{
+			name: "TimestampPreGenesis",
+			dataIn: &dataIn{
+				eth2Client: eth2Client,
+				timestamp:  "2020-11-01T00:00:00Z",
+			},
+			err: "timestamp prior to genesis",
+		},
 	}
 
 	for _, test := range tests {
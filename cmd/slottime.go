@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	slottime "github.com/wealdtech/ethdo/cmd/slot/time"
@@ -23,11 +21,15 @@ import (
 
 var slotTimeCmd = &cobra.Command{
 	Use:   "time",
-	Short: "Obtain the time for a slot",
+	Short: "Obtain the time for a slot, or the slot at a time",
 	Long: `Obtain the time(s) for a slot.  For example:
 
     ethdo slot time --slot=12345
 
+Alternatively, obtain the slot at a given time.  For example:
+
+    ethdo slot time --timestamp=2023-04-12T22:27:35Z
+
 In quiet mode this will return 0.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := slottime.Run(cmd)
@@ -38,7 +40,9 @@ In quiet mode this will return 0.`,
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -48,10 +52,22 @@ func init() {
 	slotCmd.AddCommand(slotTimeCmd)
 	slotFlags(slotTimeCmd)
 	slotTimeCmd.Flags().String("slot", "", "the ID of the slot to fetch")
+	slotTimeCmd.Flags().String("timestamp", "", "the timestamp for which to obtain the slot, as a Unix time or a date and time (format YYYY-MM-DDTHH:MM:SS+ZZ:ZZ)")
+	slotTimeCmd.Flags().String("timezone", "", "the timezone in which to output times, and in which to interpret timestamps without a timezone (default local)")
+	slotTimeCmd.Flags().Bool("json", false, "output data in JSON format")
 }
 
 func slotTimeBindings() {
 	if err := viper.BindPFlag("slot", slotTimeCmd.Flags().Lookup("slot")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("timestamp", slotTimeCmd.Flags().Lookup("timestamp")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("timezone", slotTimeCmd.Flags().Lookup("timezone")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", slotTimeCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
 }
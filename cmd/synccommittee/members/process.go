@@ -21,6 +21,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
 )
 
 func process(ctx context.Context, data *dataIn) (*dataOut, error) {
@@ -69,9 +70,7 @@ func calculateEpoch(ctx context.Context, data *dataIn) (phase0.Epoch, error) {
 		}
 	}
 
-	if data.debug {
-		fmt.Printf("epoch is %d\n", epoch)
-	}
+	util.Log.Debug().Uint64("epoch", uint64(epoch)).Msg("Obtained epoch")
 
 	return epoch, nil
 }
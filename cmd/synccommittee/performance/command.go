@@ -0,0 +1,109 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package performance
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet      bool
+	verbose    bool
+	debug      bool
+	jsonOutput bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validators []string
+	period     string
+
+	// Data access.
+	eth2Client eth2client.Service
+	chainTime  chaintime.Service
+
+	// Results.
+	results *results
+}
+
+type results struct {
+	Period            uint64                  `json:"period"`
+	FirstEpoch        phase0.Epoch            `json:"first_epoch"`
+	LastEpoch         phase0.Epoch            `json:"last_epoch"`
+	FirstSlot         phase0.Slot             `json:"first_slot"`
+	ParticipantReward phase0.Gwei             `json:"participant_reward"`
+	Validators        []*validatorPerformance `json:"validators"`
+}
+
+type validatorPerformance struct {
+	Index            phase0.ValidatorIndex `json:"index"`
+	InCommittee      bool                  `json:"in_committee"`
+	CommitteeIndices []uint64              `json:"committee_indices,omitempty"`
+	Included         int                   `json:"included"`
+	Missed           int                   `json:"missed"`
+	NoBlock          int                   `json:"no_block"`
+	Rewards          phase0.Gwei           `json:"rewards"`
+	Penalties        phase0.Gwei           `json:"penalties"`
+	// Participation contains the per-slot participation, with one entry per slot per committee index.
+	Participation string `json:"participation,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:      viper.GetBool("quiet"),
+		verbose:    viper.GetBool("verbose"),
+		debug:      viper.GetBool("debug"),
+		jsonOutput: viper.GetBool("json"),
+		results:    &results{},
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	// Connection.
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	// Validators.
+	c.validators = viper.GetStringSlice("validators")
+	if len(c.validators) == 0 {
+		return nil, errors.New("validators are required")
+	}
+
+	// Period.
+	c.period = viper.GetString("period")
+	switch c.period {
+	case "", "current", "last":
+	default:
+		if _, err := strconv.ParseUint(c.period, 10, 64); err != nil {
+			return nil, errors.New("period must be current, last or a period number")
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,110 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package performance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Period %d (epochs %d-%d):\n", c.results.Period, c.results.FirstEpoch, c.results.LastEpoch))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Reward per slot: %d Gwei\n", c.results.ParticipantReward))
+	}
+
+	included := 0
+	missed := 0
+	noBlock := 0
+	rewards := int64(0)
+	penalties := int64(0)
+	for _, validator := range c.results.Validators {
+		builder.WriteString(fmt.Sprintf("  Validator %d: ", validator.Index))
+		if !validator.InCommittee {
+			builder.WriteString("not in sync committee\n")
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("included %d, missed %d, no block %d; rewards %d Gwei, penalties %d Gwei, net %d Gwei\n",
+			validator.Included,
+			validator.Missed,
+			validator.NoBlock,
+			validator.Rewards,
+			validator.Penalties,
+			int64(validator.Rewards)-int64(validator.Penalties),
+		))
+		if c.verbose {
+			builder.WriteString("    Per-slot result: ")
+			builder.WriteString(groupParticipation(validator.Participation))
+			builder.WriteString("\n")
+		}
+		included += validator.Included
+		missed += validator.Missed
+		noBlock += validator.NoBlock
+		rewards += int64(validator.Rewards)
+		penalties += int64(validator.Penalties)
+	}
+
+	if len(c.results.Validators) > 1 {
+		builder.WriteString(fmt.Sprintf("Total: included %d, missed %d, no block %d; rewards %d Gwei, penalties %d Gwei, net %d Gwei\n",
+			included,
+			missed,
+			noBlock,
+			rewards,
+			penalties,
+			rewards-penalties,
+		))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// groupParticipation splits participation data in to groups of eight for readability.
+func groupParticipation(participation string) string {
+	builder := strings.Builder{}
+	count := utf8.RuneCountInString(participation)
+	i := 0
+	for _, r := range participation {
+		builder.WriteRune(r)
+		if i%8 == 7 && i != count-1 {
+			builder.WriteString(" ")
+		}
+		i++
+	}
+	return builder.String()
+}
@@ -0,0 +1,105 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package performance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	res := &results{
+		Period:            2,
+		FirstEpoch:        512,
+		LastEpoch:         767,
+		FirstSlot:         16384,
+		ParticipantReward: 10,
+		Validators: []*validatorPerformance{
+			{
+				Index: 1,
+			},
+			{
+				Index:            2,
+				InCommittee:      true,
+				CommitteeIndices: []uint64{5},
+				Included:         8,
+				Missed:           1,
+				NoBlock:          1,
+				Rewards:          80,
+				Penalties:        10,
+				Participation:    "✓✓✓-✓✓✓✓✕✓",
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet:   true,
+				results: res,
+			},
+		},
+		{
+			name: "Text",
+			c: &command{
+				results: res,
+			},
+			res: "Period 2 (epochs 512-767):\n  Validator 1: not in sync committee\n  Validator 2: included 8, missed 1, no block 1; rewards 80 Gwei, penalties 10 Gwei, net 70 Gwei\nTotal: included 8, missed 1, no block 1; rewards 80 Gwei, penalties 10 Gwei, net 70 Gwei",
+		},
+		{
+			name: "Verbose",
+			c: &command{
+				verbose: true,
+				results: &results{
+					Period:            2,
+					FirstEpoch:        512,
+					LastEpoch:         767,
+					ParticipantReward: 10,
+					Validators:        res.Validators[1:],
+				},
+			},
+			res: "Period 2 (epochs 512-767):\nReward per slot: 10 Gwei\n  Validator 2: included 8, missed 1, no block 1; rewards 80 Gwei, penalties 10 Gwei, net 70 Gwei\n    Per-slot result: ✓✓✓-✓✓✓✓ ✕✓",
+		},
+		{
+			name: "JSON",
+			c: &command{
+				jsonOutput: true,
+				results: &results{
+					Period:            2,
+					FirstEpoch:        512,
+					LastEpoch:         767,
+					FirstSlot:         16384,
+					ParticipantReward: 10,
+					Validators:        res.Validators[:1],
+				},
+			},
+			res: `{"period":2,"first_epoch":512,"last_epoch":767,"first_slot":16384,"participant_reward":10,"validators":[{"index":1,"in_committee":false,"included":0,"missed":0,"no_block":0,"rewards":0,"penalties":0}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package performance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.calculatePeriod(ctx); err != nil {
+		return err
+	}
+
+	validators, err := util.ParseValidators(ctx, c.eth2Client.(eth2client.ValidatorsProvider), c.validators, "head")
+	if err != nil {
+		return err
+	}
+	sort.Slice(validators, func(i int, j int) bool {
+		return validators[i].Index < validators[j].Index
+	})
+
+	// States prior to the current period only know about their own sync committee,
+	// so request the committee from the first slot of the period.
+	stateID := "head"
+	if c.results.Period < c.chainTime.CurrentSyncCommitteePeriod() {
+		stateID = fmt.Sprintf("%d", c.results.FirstSlot)
+	}
+	syncCommittee, err := c.eth2Client.(eth2client.SyncCommitteesProvider).SyncCommitteeAtEpoch(ctx, stateID, c.results.FirstEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain sync committee information")
+	}
+	if syncCommittee == nil {
+		return errors.New("no sync committee returned")
+	}
+
+	inCommittee := false
+	c.results.Validators = make([]*validatorPerformance, 0, len(validators))
+	for _, validator := range validators {
+		performance := &validatorPerformance{
+			Index: validator.Index,
+		}
+		// A validator can appear in the sync committee more than once.
+		for i := range syncCommittee.Validators {
+			if syncCommittee.Validators[i] == validator.Index {
+				performance.InCommittee = true
+				performance.CommitteeIndices = append(performance.CommitteeIndices, uint64(i))
+			}
+		}
+		if performance.InCommittee {
+			inCommittee = true
+		}
+		c.results.Validators = append(c.results.Validators, performance)
+	}
+	if !inCommittee {
+		// Nothing more to do.
+		return nil
+	}
+
+	if err := c.calculateParticipantReward(ctx); err != nil {
+		return err
+	}
+
+	return c.processSlots(ctx)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return err
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	return nil
+}
+
+// calculatePeriod calculates the sync committee period and its epochs.
+func (c *command) calculatePeriod(_ context.Context) error {
+	currentPeriod := c.chainTime.CurrentSyncCommitteePeriod()
+	switch c.period {
+	case "", "current":
+		c.results.Period = currentPeriod
+	case "last":
+		if currentPeriod == 0 {
+			return errors.New("no last period")
+		}
+		c.results.Period = currentPeriod - 1
+	default:
+		period, err := strconv.ParseUint(c.period, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid period")
+		}
+		c.results.Period = period
+	}
+
+	if c.results.Period < c.chainTime.AltairInitialSyncCommitteePeriod() {
+		return errors.New("period is before the Altair hard fork")
+	}
+	if c.results.Period > currentPeriod {
+		return errors.New("period is in the future")
+	}
+
+	c.results.FirstEpoch = c.chainTime.FirstEpochOfSyncPeriod(c.results.Period)
+	c.results.LastEpoch = c.chainTime.FirstEpochOfSyncPeriod(c.results.Period+1) - 1
+	c.results.FirstSlot = c.chainTime.FirstSlotOfEpoch(c.results.FirstEpoch)
+
+	return nil
+}
+
+// calculateParticipantReward calculates the reward for a single sync committee
+// participant at a single slot.
+func (c *command) calculateParticipantReward(ctx context.Context) error {
+	var err error
+	c.results.ParticipantReward, err = beacon.ObtainSyncCommitteeParticipantReward(ctx, c.eth2Client, c.chainTime.SlotsPerEpoch())
+	if err != nil {
+		return err
+	}
+	util.Log.Debug().Uint64("participant_reward", uint64(c.results.ParticipantReward)).Msg("Obtained participant reward")
+
+	return nil
+}
+
+// processSlots works through the slots of the period to obtain the validators' participation.
+func (c *command) processSlots(ctx context.Context) error {
+	lastSlot := c.chainTime.FirstSlotOfEpoch(c.results.LastEpoch + 1)
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+
+	participations := make(map[phase0.ValidatorIndex]*strings.Builder)
+	for _, validator := range c.results.Validators {
+		participations[validator.Index] = &strings.Builder{}
+	}
+
+	for slot := c.results.FirstSlot; slot < lastSlot; slot++ {
+		block, err := c.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		var aggregate *altair.SyncAggregate
+		if block != nil {
+			aggregate, err = beacon.SyncAggregate(block)
+			if err != nil {
+				return err
+			}
+		}
+		for _, validator := range c.results.Validators {
+			for _, committeeIndex := range validator.CommitteeIndices {
+				switch {
+				case aggregate == nil:
+					validator.NoBlock++
+					participations[validator.Index].WriteString("-")
+				case aggregate.SyncCommitteeBits.BitAt(committeeIndex):
+					validator.Included++
+					validator.Rewards += c.results.ParticipantReward
+					participations[validator.Index].WriteString("✓")
+				default:
+					validator.Missed++
+					validator.Penalties += c.results.ParticipantReward
+					participations[validator.Index].WriteString("✕")
+				}
+			}
+		}
+	}
+
+	for _, validator := range c.results.Validators {
+		validator.Participation = participations[validator.Index].String()
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rewards
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+// slotResult is the result of a validator's sync committee duty at a slot.
+type slotResult int
+
+const (
+	// slotResultNoBlock is a slot without a block.
+	slotResultNoBlock slotResult = iota
+	// slotResultIncluded is a slot where the validator's signature was included.
+	slotResultIncluded
+	// slotResultMissedOnline is a slot where the validator's signature was missing
+	// but the validator attested in the same epoch.
+	slotResultMissedOnline
+	// slotResultMissedOffline is a slot where the validator's signature was missing
+	// and the validator did not attest in the same epoch.
+	slotResultMissedOffline
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validator string
+	period    string
+
+	// Data access.
+	eth2Client eth2client.Service
+	chainTime  chaintime.Service
+	blocks     map[phase0.Slot]*spec.VersionedSignedBeaconBlock
+
+	// Output.
+	periodNum         uint64
+	firstEpoch        phase0.Epoch
+	lastEpoch         phase0.Epoch
+	validatorIndex    phase0.ValidatorIndex
+	inCommittee       bool
+	committeeIndices  []uint64
+	participantReward phase0.Gwei
+	results           []slotResult
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		blocks:  make(map[phase0.Slot]*spec.VersionedSignedBeaconBlock),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	// Connection.
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	// Validator.
+	c.validator = viper.GetString("validator")
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+
+	// Period.
+	c.period = viper.GetString("period")
+	switch c.period {
+	case "", "current", "last":
+	default:
+		if _, err := strconv.ParseUint(c.period, 10, 64); err != nil {
+			return nil, errors.New("period must be current, last or a period number")
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,95 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Period: %d (epochs %d-%d)\n", c.periodNum, c.firstEpoch, c.lastEpoch))
+	}
+
+	if !c.inCommittee {
+		builder.WriteString("Validator not in sync committee")
+		return builder.String(), nil
+	}
+
+	if c.verbose {
+		builder.WriteString("Validator sync committee indices: ")
+		for i, committeeIndex := range c.committeeIndices {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(fmt.Sprintf("%d", committeeIndex))
+		}
+		builder.WriteString("\n")
+		builder.WriteString(fmt.Sprintf("Reward per slot: %d Gwei\n", c.participantReward))
+	}
+
+	noBlock := 0
+	included := 0
+	missedOnline := 0
+	missedOffline := 0
+	for _, result := range c.results {
+		switch result {
+		case slotResultNoBlock:
+			noBlock++
+		case slotResultIncluded:
+			included++
+		case slotResultMissedOnline:
+			missedOnline++
+		case slotResultMissedOffline:
+			missedOffline++
+		}
+	}
+	reward := int64(c.participantReward)
+
+	builder.WriteString(fmt.Sprintf("Expected: %d\n", len(c.results)))
+	builder.WriteString(fmt.Sprintf("Included: %d (%d Gwei)\n", included, int64(included)*reward))
+	builder.WriteString(fmt.Sprintf("Missed while attesting: %d (%d Gwei)\n", missedOnline, -int64(missedOnline)*reward))
+	builder.WriteString(fmt.Sprintf("Missed while not attesting: %d (%d Gwei)\n", missedOffline, -int64(missedOffline)*reward))
+	builder.WriteString(fmt.Sprintf("No block: %d (%d Gwei forgone)\n", noBlock, int64(noBlock)*reward))
+	builder.WriteString(fmt.Sprintf("Net: %d Gwei", int64(included-missedOnline-missedOffline)*reward))
+
+	if c.verbose {
+		builder.WriteString("\nPer-slot result: ")
+		for i, result := range c.results {
+			switch result {
+			case slotResultNoBlock:
+				builder.WriteString("-")
+			case slotResultIncluded:
+				builder.WriteString("✓")
+			case slotResultMissedOnline:
+				builder.WriteString("~")
+			case slotResultMissedOffline:
+				builder.WriteString("✕")
+			}
+			if i%8 == 7 && i != len(c.results)-1 {
+				builder.WriteString(" ")
+			}
+		}
+	}
+
+	return builder.String(), nil
+}
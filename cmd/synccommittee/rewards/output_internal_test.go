@@ -0,0 +1,86 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rewards
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet: true,
+			},
+		},
+		{
+			name: "NotInCommittee",
+			c:    &command{},
+			res:  "Validator not in sync committee",
+		},
+		{
+			name: "Good",
+			c: &command{
+				inCommittee:       true,
+				committeeIndices:  []uint64{5},
+				participantReward: 10,
+				results: []slotResult{
+					slotResultIncluded,
+					slotResultIncluded,
+					slotResultIncluded,
+					slotResultNoBlock,
+					slotResultMissedOnline,
+					slotResultMissedOffline,
+					slotResultMissedOffline,
+				},
+			},
+			res: "Expected: 7\nIncluded: 3 (30 Gwei)\nMissed while attesting: 1 (-10 Gwei)\nMissed while not attesting: 2 (-20 Gwei)\nNo block: 1 (10 Gwei forgone)\nNet: 0 Gwei",
+		},
+		{
+			name: "Verbose",
+			c: &command{
+				verbose:           true,
+				periodNum:         2,
+				firstEpoch:        512,
+				lastEpoch:         767,
+				inCommittee:       true,
+				committeeIndices:  []uint64{5, 100},
+				participantReward: 10,
+				results: []slotResult{
+					slotResultIncluded,
+					slotResultIncluded,
+					slotResultNoBlock,
+					slotResultMissedOnline,
+				},
+			},
+			res: "Period: 2 (epochs 512-767)\nValidator sync committee indices: 5, 100\nReward per slot: 10 Gwei\nExpected: 4\nIncluded: 2 (20 Gwei)\nMissed while attesting: 1 (-10 Gwei)\nMissed while not attesting: 0 (0 Gwei)\nNo block: 1 (10 Gwei forgone)\nNet: 10 Gwei\nPer-slot result: ✓✓-~",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
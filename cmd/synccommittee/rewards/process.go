@@ -0,0 +1,268 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.calculatePeriod(ctx); err != nil {
+		return err
+	}
+
+	validator, err := util.ParseValidator(ctx, c.eth2Client.(eth2client.ValidatorsProvider), c.validator, "head")
+	if err != nil {
+		return err
+	}
+	c.validatorIndex = validator.Index
+
+	// States prior to the current period only know about their own sync committee,
+	// so request the committee from the first slot of the period.
+	stateID := "head"
+	if c.periodNum < c.chainTime.CurrentSyncCommitteePeriod() {
+		stateID = fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(c.firstEpoch))
+	}
+	syncCommittee, err := c.eth2Client.(eth2client.SyncCommitteesProvider).SyncCommitteeAtEpoch(ctx, stateID, c.firstEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain sync committee information")
+	}
+	if syncCommittee == nil {
+		return errors.New("no sync committee returned")
+	}
+
+	// A validator can appear in the sync committee more than once.
+	for i := range syncCommittee.Validators {
+		if syncCommittee.Validators[i] == c.validatorIndex {
+			c.inCommittee = true
+			c.committeeIndices = append(c.committeeIndices, uint64(i))
+		}
+	}
+	if !c.inCommittee {
+		return nil
+	}
+
+	if err := c.calculateParticipantReward(ctx); err != nil {
+		return err
+	}
+
+	return c.processSlots(ctx)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return err
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	return nil
+}
+
+// calculatePeriod calculates the sync committee period and its epochs.
+func (c *command) calculatePeriod(_ context.Context) error {
+	currentPeriod := c.chainTime.CurrentSyncCommitteePeriod()
+	switch c.period {
+	case "", "current":
+		c.periodNum = currentPeriod
+	case "last":
+		if currentPeriod == 0 {
+			return errors.New("no last period")
+		}
+		c.periodNum = currentPeriod - 1
+	default:
+		period, err := strconv.ParseUint(c.period, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid period")
+		}
+		c.periodNum = period
+	}
+
+	if c.periodNum < c.chainTime.AltairInitialSyncCommitteePeriod() {
+		return errors.New("period is before the Altair hard fork")
+	}
+	if c.periodNum > currentPeriod {
+		return errors.New("period is in the future")
+	}
+
+	c.firstEpoch = c.chainTime.FirstEpochOfSyncPeriod(c.periodNum)
+	c.lastEpoch = c.chainTime.FirstEpochOfSyncPeriod(c.periodNum+1) - 1
+
+	return nil
+}
+
+// calculateParticipantReward calculates the reward for a single sync committee
+// participant at a single slot.
+func (c *command) calculateParticipantReward(ctx context.Context) error {
+	var err error
+	c.participantReward, err = beacon.ObtainSyncCommitteeParticipantReward(ctx, c.eth2Client, c.chainTime.SlotsPerEpoch())
+	if err != nil {
+		return err
+	}
+	util.Log.Debug().Uint64("participant_reward", uint64(c.participantReward)).Msg("Obtained participant reward")
+
+	return nil
+}
+
+// processSlots works through the slots of the period to obtain the validator's results.
+func (c *command) processSlots(ctx context.Context) error {
+	firstSlot := c.chainTime.FirstSlotOfEpoch(c.firstEpoch)
+	lastSlot := c.chainTime.FirstSlotOfEpoch(c.lastEpoch + 1)
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+
+	// Attestation liveness is obtained lazily, as it is only required for epochs with misses.
+	attested := make(map[phase0.Epoch]bool)
+
+	c.results = make([]slotResult, 0)
+	for slot := firstSlot; slot < lastSlot; slot++ {
+		block, err := c.block(ctx, slot)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			for range c.committeeIndices {
+				c.results = append(c.results, slotResultNoBlock)
+			}
+			continue
+		}
+		aggregate, err := beacon.SyncAggregate(block)
+		if err != nil {
+			return err
+		}
+		for _, committeeIndex := range c.committeeIndices {
+			if aggregate.SyncCommitteeBits.BitAt(committeeIndex) {
+				c.results = append(c.results, slotResultIncluded)
+				continue
+			}
+			epoch := c.chainTime.SlotToEpoch(slot)
+			epochAttested, exists := attested[epoch]
+			if !exists {
+				epochAttested, err = c.attestedInEpoch(ctx, epoch)
+				if err != nil {
+					return err
+				}
+				attested[epoch] = epochAttested
+			}
+			if epochAttested {
+				c.results = append(c.results, slotResultMissedOnline)
+			} else {
+				c.results = append(c.results, slotResultMissedOffline)
+			}
+		}
+	}
+
+	return nil
+}
+
+// attestedInEpoch returns true if the validator's attestation for the given epoch was included on-chain.
+func (c *command) attestedInEpoch(ctx context.Context, epoch phase0.Epoch) (bool, error) {
+	beaconCommittees, err := c.eth2Client.(eth2client.BeaconCommitteesProvider).BeaconCommittees(ctx, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch)))
+	if err != nil {
+		return false, errors.Wrap(err, fmt.Sprintf("failed to obtain beacon committees for epoch %d", epoch))
+	}
+
+	found := false
+	var dutySlot phase0.Slot
+	var dutyCommitteeIndex phase0.CommitteeIndex
+	var dutyPosition uint64
+	for _, beaconCommittee := range beaconCommittees {
+		for i, index := range beaconCommittee.Validators {
+			if index == c.validatorIndex {
+				found = true
+				dutySlot = beaconCommittee.Slot
+				dutyCommitteeIndex = beaconCommittee.Index
+				dutyPosition = uint64(i)
+				break
+			}
+		}
+		if found {
+			break
+		}
+	}
+	if !found {
+		return false, fmt.Errorf("failed to find attestation duty for epoch %d", epoch)
+	}
+
+	// Attestations can be included up to an epoch after their slot.
+	lastSlot := dutySlot + phase0.Slot(c.chainTime.SlotsPerEpoch())
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+	for slot := dutySlot + 1; slot <= lastSlot; slot++ {
+		block, err := c.block(ctx, slot)
+		if err != nil {
+			return false, err
+		}
+		if block == nil {
+			continue
+		}
+		attestations, err := block.Attestations()
+		if err != nil {
+			return false, err
+		}
+		for _, attestation := range attestations {
+			if attestation.Data.Slot != dutySlot || attestation.Data.Index != dutyCommitteeIndex {
+				continue
+			}
+			if attestation.AggregationBits.BitAt(dutyPosition) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// block obtains the block at the given slot, using a cache to avoid repeated requests.
+func (c *command) block(ctx context.Context, slot phase0.Slot) (*spec.VersionedSignedBeaconBlock, error) {
+	if block, exists := c.blocks[slot]; exists {
+		util.RecordCacheHit("blocks")
+		return block, nil
+	}
+	util.RecordCacheMiss("blocks")
+	block, err := c.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+	}
+	c.blocks[slot] = block
+
+	return block, nil
+}
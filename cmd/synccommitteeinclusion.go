@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	synccommitteeinclusion "github.com/wealdtech/ethdo/cmd/synccommittee/inclusion"
@@ -40,7 +38,9 @@ epoch can be a specific epoch; If not supplied all slots for the current sync co
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	synccommitteemembers "github.com/wealdtech/ethdo/cmd/synccommittee/members"
@@ -40,7 +38,9 @@ epoch can be a specific epoch.  period can be 'current' for the current sync per
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
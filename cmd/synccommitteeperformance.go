@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	synccommitteeperformance "github.com/wealdtech/ethdo/cmd/synccommittee/performance"
+)
+
+var synccommitteePerformanceCmd = &cobra.Command{
+	Use:   "performance",
+	Short: "Obtain sync committee performance for a set of validators over a period",
+	Long: `Obtain sync committee performance for a set of validators over a sync committee period.  For example:
+
+    ethdo synccommittee performance --validators=11111,22222 --period=last
+
+For each validator this reports the number of included and missed sync committee contributions and slots without blocks, along with estimated rewards and penalties.  Rewards are estimated from the current total active balance.
+
+period can be "current" (the default), "last" or a specific sync committee period.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := synccommitteeperformance.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	synccommitteeCmd.AddCommand(synccommitteePerformanceCmd)
+	synccommitteeFlags(synccommitteePerformanceCmd)
+	synccommitteePerformanceCmd.Flags().StringSlice("validators", nil, "the list of validators for which to obtain performance")
+	synccommitteePerformanceCmd.Flags().String("period", "current", "the sync committee period for which to obtain performance ('current', 'last' or a period number)")
+	synccommitteePerformanceCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func synccommitteePerformanceBindings() {
+	if err := viper.BindPFlag("validators", synccommitteePerformanceCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("period", synccommitteePerformanceCmd.Flags().Lookup("period")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", synccommitteePerformanceCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
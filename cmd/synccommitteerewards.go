@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	synccommitteerewards "github.com/wealdtech/ethdo/cmd/synccommittee/rewards"
+)
+
+var synccommitteeRewardsCmd = &cobra.Command{
+	Use:   "rewards",
+	Short: "Obtain sync committee rewards for a validator over a period",
+	Long: `Obtain sync committee rewards and penalties for a validator over a sync committee period.  For example:
+
+    ethdo synccommittee rewards --validator=11111 --period=last
+
+Penalties are split between those where the validator attested in the same epoch, which suggests a network or late block issue, and those where it did not, which suggests a node fault.  Slots without blocks are reported separately as rewards forgone.  Rewards are estimated from the current total active balance.
+
+period can be "current" (the default), "last" or a specific sync committee period.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := synccommitteerewards.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	synccommitteeCmd.AddCommand(synccommitteeRewardsCmd)
+	synccommitteeFlags(synccommitteeRewardsCmd)
+	synccommitteeRewardsCmd.Flags().String("validator", "", "the account, public key or index of the validator")
+	synccommitteeRewardsCmd.Flags().String("period", "current", "the sync committee period for which to calculate rewards ('current', 'last' or a period number)")
+}
+
+func synccommitteeRewardsBindings() {
+	if err := viper.BindPFlag("validator", synccommitteeRewardsCmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("period", synccommitteeRewardsCmd.Flags().Lookup("period")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/cmd/top"
+)
+
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Show a live dashboard for a set of validators",
+	Long: `Show a live dashboard for a set of validators, with the status of the chain, upcoming duties, recent attestation results and balance changes.  For example:
+
+    ethdo top --validators=Validators/1,Validators/2
+
+The dashboard is updated as new blocks arrive; press q to quit.  If the output is not a terminal a single snapshot of the dashboard is output.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := top.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(topCmd)
+	topCmd.Flags().StringSlice("validators", nil, "Validators to show, as accounts, indices or public keys")
+}
+
+func topBindings() {
+	if err := viper.BindPFlag("validators", topCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top
+
+import (
+	"context"
+	"os"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"golang.org/x/term"
+)
+
+// recentEpochs is the number of epochs for which attestation results are shown.
+const recentEpochs = 4
+
+// maxUpcomingDuties is the maximum number of upcoming duties shown.
+const maxUpcomingDuties = 10
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	validators  []string
+	interactive bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	consensusClient        eth2client.Service
+	chainTime              chaintime.Service
+	validatorsProvider     eth2client.ValidatorsProvider
+	balancesProvider       eth2client.ValidatorBalancesProvider
+	attesterDutiesProvider eth2client.AttesterDutiesProvider
+	proposerDutiesProvider eth2client.ProposerDutiesProvider
+	blocksProvider         eth2client.SignedBeaconBlockProvider
+	finalityProvider       eth2client.FinalityProvider
+	eventsProvider         eth2client.EventsProvider
+
+	// Processing.
+	indices          []phase0.ValidatorIndex
+	startSlot        phase0.Slot
+	dutiesEpochs     map[phase0.Epoch]bool
+	pendingDuties    map[phase0.Slot][]*apiv1.AttesterDuty
+	proposals        []*apiv1.ProposerDuty
+	balancesEpoch    phase0.Epoch
+	startBalances    map[phase0.ValidatorIndex]phase0.Gwei
+	previousBalances map[phase0.ValidatorIndex]phase0.Gwei
+
+	// Output.
+	dashboard *dashboard
+}
+
+type dashboard struct {
+	headSlot       phase0.Slot
+	headEpoch      phase0.Epoch
+	justifiedEpoch phase0.Epoch
+	finalizedEpoch phase0.Epoch
+	duties         []*upcomingDuty
+	attestations   map[phase0.Epoch]*epochAttestations
+	balances       []*validatorBalance
+	updated        time.Time
+	err            string
+}
+
+type upcomingDuty struct {
+	slot      phase0.Slot
+	start     time.Time
+	proposal  bool
+	validator phase0.ValidatorIndex
+}
+
+type epochAttestations struct {
+	expected          int
+	included          int
+	inclusionDistance uint64
+	missed            []phase0.ValidatorIndex
+}
+
+type validatorBalance struct {
+	index   phase0.ValidatorIndex
+	balance phase0.Gwei
+	// epochChange is the change in balance since the start of the previous epoch.
+	epochChange int64
+	// sessionChange is the change in balance since the dashboard started.
+	sessionChange int64
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:         viper.GetBool("quiet"),
+		verbose:       viper.GetBool("verbose"),
+		debug:         viper.GetBool("debug"),
+		interactive:   term.IsTerminal(int(os.Stdout.Fd())) && term.IsTerminal(int(os.Stdin.Fd())),
+		dutiesEpochs:  make(map[phase0.Epoch]bool),
+		pendingDuties: make(map[phase0.Slot][]*apiv1.AttesterDuty),
+		dashboard: &dashboard{
+			attestations: make(map[phase0.Epoch]*epochAttestations),
+		},
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validators = viper.GetStringSlice("validators")
+	if len(c.validators) == 0 {
+		return nil, errors.New("validators are required")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,150 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// maxBalanceRows is the maximum number of validators for which individual balances are shown.
+const maxBalanceRows = 10
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet || c.interactive {
+		return "", nil
+	}
+
+	return c.render(time.Now(), 0), nil
+}
+
+// render renders the dashboard, truncating lines to the given width if it is non-zero.
+func (c *command) render(now time.Time, width int) string {
+	d := c.dashboard
+	lines := make([]string, 0)
+
+	lines = append(lines,
+		fmt.Sprintf("Slot %d (epoch %d); justified epoch %d, finalized epoch %d", d.headSlot, d.headEpoch, d.justifiedEpoch, d.finalizedEpoch),
+		fmt.Sprintf("Validators: %d", len(c.indices)),
+	)
+
+	lines = append(lines, "", "Upcoming duties")
+	if len(d.duties) == 0 {
+		lines = append(lines, "  None")
+	}
+	for _, duty := range d.duties {
+		until := "now"
+		if duty.start.After(now) {
+			until = fmt.Sprintf("in %v", duty.start.Sub(now).Round(time.Second))
+		}
+		kind := "attestation"
+		if duty.proposal {
+			kind = "proposal"
+		}
+		lines = append(lines, fmt.Sprintf("  Slot %d (%s): %s by validator %d", duty.slot, until, kind, duty.validator))
+	}
+
+	lines = append(lines, "", "Attestations")
+	epochs := make([]phase0.Epoch, 0, len(d.attestations))
+	for epoch, results := range d.attestations {
+		if epoch > d.headEpoch || results.expected == 0 {
+			continue
+		}
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i] > epochs[j]
+	})
+	if len(epochs) == 0 {
+		lines = append(lines, "  None")
+	}
+	for _, epoch := range epochs {
+		lines = append(lines, fmt.Sprintf("  Epoch %d: %s", epoch, d.attestations[epoch].summary()))
+	}
+
+	lines = append(lines, "", "Balances")
+	total := phase0.Gwei(0)
+	epochChange := int64(0)
+	sessionChange := int64(0)
+	for _, balance := range d.balances {
+		total += balance.balance
+		epochChange += balance.epochChange
+		sessionChange += balance.sessionChange
+		if len(d.balances) <= maxBalanceRows {
+			lines = append(lines, fmt.Sprintf("  Validator %d: %s (%s this epoch, %s since start)",
+				balance.index,
+				string2eth.GWeiToString(uint64(balance.balance), true),
+				gweiChange(balance.epochChange),
+				gweiChange(balance.sessionChange),
+			))
+		}
+	}
+	lines = append(lines, fmt.Sprintf("  Total: %s (%s this epoch, %s since start)",
+		string2eth.GWeiToString(uint64(total), true),
+		gweiChange(epochChange),
+		gweiChange(sessionChange),
+	))
+
+	if d.err != "" {
+		lines = append(lines, "", fmt.Sprintf("Error: %s", d.err))
+	}
+	if c.interactive {
+		lines = append(lines, "", fmt.Sprintf("Updated %s; press q to quit", d.updated.Format("15:04:05")))
+	}
+
+	if width > 0 {
+		for i := range lines {
+			if len(lines[i]) > width {
+				lines[i] = lines[i][:width]
+			}
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// summary summarises the attestation results for an epoch.
+func (e *epochAttestations) summary() string {
+	parts := []string{fmt.Sprintf("%d/%d included", e.included, e.expected)}
+	if e.included > 0 {
+		parts = append(parts, fmt.Sprintf("average inclusion distance %.2f", float64(e.inclusionDistance)/float64(e.included)))
+	}
+	if pending := e.expected - e.included - len(e.missed); pending > 0 {
+		parts = append(parts, fmt.Sprintf("%d pending", pending))
+	}
+	if len(e.missed) > 0 {
+		missed := make([]string, len(e.missed))
+		for i := range e.missed {
+			missed[i] = fmt.Sprintf("%d", e.missed[i])
+		}
+		parts = append(parts, fmt.Sprintf("missed by %s", strings.Join(missed, ", ")))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// gweiChange renders a signed change in Gwei.
+func gweiChange(change int64) string {
+	if change < 0 {
+		return fmt.Sprintf("-%s", string2eth.GWeiToString(uint64(-change), true))
+	}
+
+	return fmt.Sprintf("+%s", string2eth.GWeiToString(uint64(change), true))
+}
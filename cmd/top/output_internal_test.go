@@ -0,0 +1,97 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name     string
+		command  *command
+		width    int
+		expected string
+	}{
+		{
+			name: "Empty",
+			command: &command{
+				indices: []phase0.ValidatorIndex{1},
+				dashboard: &dashboard{
+					headSlot:       100,
+					headEpoch:      3,
+					justifiedEpoch: 1,
+					finalizedEpoch: 0,
+				},
+			},
+			expected: "Slot 100 (epoch 3); justified epoch 1, finalized epoch 0\nValidators: 1\n\nUpcoming duties\n  None\n\nAttestations\n  None\n\nBalances\n  Total: 0 (+0 this epoch, +0 since start)",
+		},
+		{
+			name: "Full",
+			command: &command{
+				indices: []phase0.ValidatorIndex{1, 2},
+				dashboard: &dashboard{
+					headSlot:       100,
+					headEpoch:      3,
+					justifiedEpoch: 1,
+					finalizedEpoch: 0,
+					duties: []*upcomingDuty{
+						{slot: 100, start: now.Add(-2 * time.Second), validator: 1},
+						{slot: 105, start: now.Add(58 * time.Second), proposal: true, validator: 2},
+					},
+					attestations: map[phase0.Epoch]*epochAttestations{
+						2: {expected: 2, included: 1, inclusionDistance: 1, missed: []phase0.ValidatorIndex{2}},
+						3: {expected: 2, included: 1, inclusionDistance: 3},
+						4: {expected: 2},
+					},
+					balances: []*validatorBalance{
+						{index: 1, balance: 32000020000, epochChange: 10000, sessionChange: 20000},
+						{index: 2, balance: 31999995000, epochChange: 5000, sessionChange: -5000},
+					},
+					err: "failed to obtain finality",
+				},
+			},
+			expected: "Slot 100 (epoch 3); justified epoch 1, finalized epoch 0\nValidators: 2\n\nUpcoming duties\n  Slot 100 (now): attestation by validator 1\n  Slot 105 (in 58s): proposal by validator 2\n\nAttestations\n  Epoch 3: 1/2 included, average inclusion distance 3.00, 1 pending\n  Epoch 2: 1/2 included, average inclusion distance 1.00, missed by 2\n\nBalances\n  Validator 1: 32.00002 Ether (+0.00001 Ether this epoch, +0.00002 Ether since start)\n  Validator 2: 31.999995 Ether (+0.000005 Ether this epoch, -0.000005 Ether since start)\n  Total: 64.000015 Ether (+0.000015 Ether this epoch, +0.000015 Ether since start)\n\nError: failed to obtain finality",
+		},
+		{
+			name: "Truncated",
+			command: &command{
+				indices: []phase0.ValidatorIndex{1},
+				dashboard: &dashboard{
+					headSlot:  100,
+					headEpoch: 3,
+				},
+			},
+			width:    10,
+			expected: "Slot 100 (\nValidators\n\nUpcoming d\n  None\n\nAttestatio\n  None\n\nBalances\n  Total: 0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, test.command.render(now, test.width))
+		})
+	}
+
+	res, err := (&command{quiet: true}).output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "", res)
+}
@@ -0,0 +1,446 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	"golang.org/x/term"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.startSlot = c.chainTime.CurrentSlot()
+	if err := c.refresh(ctx, c.startSlot); err != nil {
+		return err
+	}
+
+	if !c.interactive {
+		// A single snapshot of the dashboard is output.
+		return nil
+	}
+
+	return c.run(ctx)
+}
+
+// run updates the dashboard as new heads arrive, until the user quits.
+func (c *command) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	heads := make(chan phase0.Slot, 16)
+	if err := c.eventsProvider.Events(ctx, []string{"head"}, func(event *apiv1.Event) {
+		head, isHead := event.Data.(*apiv1.HeadEvent)
+		if !isHead {
+			return
+		}
+		select {
+		case heads <- head.Slot:
+		default:
+			// Dashboard is busy; the next head will catch up.
+		}
+	}); err != nil {
+		return errors.Wrap(err, "failed to subscribe to head events")
+	}
+
+	// Raw mode allows individual key presses to be read.
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return errors.Wrap(err, "failed to set up terminal")
+	}
+	defer func() {
+		if err := term.Restore(int(os.Stdin.Fd()), state); err != nil {
+			util.Log.Trace().Err(err).Msg("Failed to restore terminal")
+		}
+		// Clear the screen and show the cursor.
+		fmt.Print("\033[H\033[2J\033[?25h")
+	}()
+	// Hide the cursor.
+	fmt.Print("\033[?25l")
+
+	keys := make(chan byte)
+	go readKeys(keys)
+
+	// Redraw regularly to keep the times of upcoming duties current.
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	c.draw()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case key := <-keys:
+			// q or ctrl-c quits.
+			if key == 'q' || key == 0x03 {
+				return nil
+			}
+		case slot := <-heads:
+			c.processHead(ctx, slot)
+			c.draw()
+		case <-ticker.C:
+			c.draw()
+		}
+	}
+}
+
+func readKeys(keys chan<- byte) {
+	buf := make([]byte, 1)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return
+		}
+		if n == 1 {
+			keys <- buf[0]
+		}
+	}
+}
+
+// draw draws the dashboard on the terminal.
+func (c *command) draw() {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		width = 80
+	}
+	// Raw mode requires explicit carriage returns.
+	frame := strings.ReplaceAll(c.render(time.Now(), width), "\n", "\r\n")
+	fmt.Printf("\033[H\033[2J%s", frame)
+}
+
+// processHead processes the blocks up to a new head, and refreshes the dashboard.
+// Errors are shown on the dashboard rather than returned, as they may be transient.
+func (c *command) processHead(ctx context.Context, slot phase0.Slot) {
+	if slot <= c.dashboard.headSlot {
+		return
+	}
+	firstSlot := c.dashboard.headSlot + 1
+	if slotsPerEpoch := phase0.Slot(c.chainTime.SlotsPerEpoch()); slot-firstSlot > slotsPerEpoch {
+		// Blocks older than an epoch cannot include attestations for outstanding duties.
+		firstSlot = slot - slotsPerEpoch
+	}
+	for blockSlot := firstSlot; blockSlot <= slot; blockSlot++ {
+		if err := c.processBlock(ctx, blockSlot); err != nil {
+			c.dashboard.err = err.Error()
+			return
+		}
+	}
+	if err := c.refresh(ctx, slot); err != nil {
+		c.dashboard.err = err.Error()
+		return
+	}
+	c.dashboard.err = ""
+}
+
+// processBlock records the attestations for our validators in the block at the given slot.
+func (c *command) processBlock(ctx context.Context, slot phase0.Slot) error {
+	block, err := c.blocksProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+	}
+	if block != nil {
+		attestations, err := block.Attestations()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain attestations for slot %d", slot))
+		}
+		c.recordAttestations(slot, attestations)
+	}
+	c.expireDuties(slot)
+
+	return nil
+}
+
+// recordAttestations marks duties as included if they are attested to in a block at the given slot.
+func (c *command) recordAttestations(slot phase0.Slot, attestations []*phase0.Attestation) {
+	for _, attestation := range attestations {
+		if attestation == nil || attestation.Data == nil {
+			continue
+		}
+		duties, exists := c.pendingDuties[attestation.Data.Slot]
+		if !exists {
+			continue
+		}
+		remaining := make([]*apiv1.AttesterDuty, 0, len(duties))
+		for _, duty := range duties {
+			if duty.CommitteeIndex == attestation.Data.Index &&
+				duty.ValidatorCommitteeIndex < attestation.AggregationBits.Len() &&
+				attestation.AggregationBits.BitAt(duty.ValidatorCommitteeIndex) {
+				results := c.epochAttestations(c.chainTime.SlotToEpoch(duty.Slot))
+				results.included++
+				results.inclusionDistance += uint64(slot - attestation.Data.Slot)
+				continue
+			}
+			remaining = append(remaining, duty)
+		}
+		if len(remaining) == 0 {
+			delete(c.pendingDuties, attestation.Data.Slot)
+		} else {
+			c.pendingDuties[attestation.Data.Slot] = remaining
+		}
+	}
+}
+
+// expireDuties marks duties as missed if they can no longer be included as of the given slot.
+func (c *command) expireDuties(slot phase0.Slot) {
+	slotsPerEpoch := phase0.Slot(c.chainTime.SlotsPerEpoch())
+	for dutySlot, duties := range c.pendingDuties {
+		if dutySlot+slotsPerEpoch >= slot {
+			continue
+		}
+		results := c.epochAttestations(c.chainTime.SlotToEpoch(dutySlot))
+		for _, duty := range duties {
+			results.missed = append(results.missed, duty.ValidatorIndex)
+		}
+		sort.Slice(results.missed, func(i, j int) bool {
+			return results.missed[i] < results.missed[j]
+		})
+		delete(c.pendingDuties, dutySlot)
+	}
+}
+
+func (c *command) epochAttestations(epoch phase0.Epoch) *epochAttestations {
+	results, exists := c.dashboard.attestations[epoch]
+	if !exists {
+		results = &epochAttestations{}
+		c.dashboard.attestations[epoch] = results
+	}
+
+	return results
+}
+
+// refresh refreshes the chain status, duties and balances for a new head.
+func (c *command) refresh(ctx context.Context, slot phase0.Slot) error {
+	epoch := c.chainTime.SlotToEpoch(slot)
+	c.dashboard.headSlot = slot
+	c.dashboard.headEpoch = epoch
+
+	finality, err := c.finalityProvider.Finality(ctx, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain finality")
+	}
+	c.dashboard.justifiedEpoch = finality.Justified.Epoch
+	c.dashboard.finalizedEpoch = finality.Finalized.Epoch
+
+	if err := c.fetchDuties(ctx, epoch); err != nil {
+		return err
+	}
+	if err := c.fetchDuties(ctx, epoch+1); err != nil {
+		// Not all beacon nodes provide duties for the next epoch, so failure is not fatal.
+		util.Log.Debug().Uint64("epoch", uint64(epoch+1)).Err(err).Msg("Failed to obtain next epoch duties")
+	}
+	c.updateDuties()
+
+	if c.startBalances == nil || epoch != c.balancesEpoch {
+		if err := c.fetchBalances(ctx, epoch); err != nil {
+			return err
+		}
+	}
+
+	// Remove attestation results that are no longer shown.
+	for resultsEpoch := range c.dashboard.attestations {
+		if resultsEpoch+recentEpochs <= epoch {
+			delete(c.dashboard.attestations, resultsEpoch)
+		}
+	}
+
+	c.dashboard.updated = time.Now()
+
+	return nil
+}
+
+// fetchDuties fetches the attester and proposer duties for our validators in the given epoch.
+func (c *command) fetchDuties(ctx context.Context, epoch phase0.Epoch) error {
+	if c.dutiesEpochs[epoch] {
+		return nil
+	}
+
+	attesterDuties, err := c.attesterDutiesProvider.AttesterDuties(ctx, epoch, c.indices)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain attester duties for epoch %d", epoch))
+	}
+	proposerDuties, err := c.proposerDutiesProvider.ProposerDuties(ctx, epoch, c.indices)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain proposer duties for epoch %d", epoch))
+	}
+
+	for _, duty := range attesterDuties {
+		// Attestations for slots before the dashboard started may have been included already.
+		if duty.Slot < c.startSlot {
+			continue
+		}
+		c.pendingDuties[duty.Slot] = append(c.pendingDuties[duty.Slot], duty)
+		c.epochAttestations(epoch).expected++
+	}
+	c.proposals = append(c.proposals, proposerDuties...)
+	c.dutiesEpochs[epoch] = true
+
+	return nil
+}
+
+// updateDuties updates the upcoming duties on the dashboard.
+func (c *command) updateDuties() {
+	duties := make([]*upcomingDuty, 0)
+	for slot, attesterDuties := range c.pendingDuties {
+		if slot <= c.dashboard.headSlot {
+			continue
+		}
+		for _, duty := range attesterDuties {
+			duties = append(duties, &upcomingDuty{
+				slot:      slot,
+				start:     c.chainTime.StartOfSlot(slot),
+				validator: duty.ValidatorIndex,
+			})
+		}
+	}
+	proposals := make([]*apiv1.ProposerDuty, 0, len(c.proposals))
+	for _, duty := range c.proposals {
+		if duty.Slot <= c.dashboard.headSlot {
+			continue
+		}
+		proposals = append(proposals, duty)
+		duties = append(duties, &upcomingDuty{
+			slot:      duty.Slot,
+			start:     c.chainTime.StartOfSlot(duty.Slot),
+			proposal:  true,
+			validator: duty.ValidatorIndex,
+		})
+	}
+	c.proposals = proposals
+
+	sort.Slice(duties, func(i, j int) bool {
+		if duties[i].slot != duties[j].slot {
+			return duties[i].slot < duties[j].slot
+		}
+		if duties[i].proposal != duties[j].proposal {
+			return duties[i].proposal
+		}
+		return duties[i].validator < duties[j].validator
+	})
+	if len(duties) > maxUpcomingDuties {
+		duties = duties[:maxUpcomingDuties]
+	}
+	c.dashboard.duties = duties
+}
+
+// fetchBalances fetches the balances of our validators, and calculates their changes.
+func (c *command) fetchBalances(ctx context.Context, epoch phase0.Epoch) error {
+	balances, err := c.balancesProvider.ValidatorBalances(ctx, "head", c.indices)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator balances")
+	}
+	if c.startBalances == nil {
+		c.startBalances = balances
+	}
+	if c.previousBalances == nil {
+		c.previousBalances = balances
+	}
+
+	c.dashboard.balances = make([]*validatorBalance, 0, len(c.indices))
+	for _, index := range c.indices {
+		balance, exists := balances[index]
+		if !exists {
+			continue
+		}
+		c.dashboard.balances = append(c.dashboard.balances, &validatorBalance{
+			index:         index,
+			balance:       balance,
+			epochChange:   int64(balance) - int64(c.previousBalances[index]),
+			sessionChange: int64(balance) - int64(c.startBalances[index]),
+		})
+	}
+	c.previousBalances = balances
+	c.balancesEpoch = epoch
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.consensusClient.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.consensusClient.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide validator information")
+	}
+	c.balancesProvider, isProvider = c.consensusClient.(eth2client.ValidatorBalancesProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide validator balances")
+	}
+	c.attesterDutiesProvider, isProvider = c.consensusClient.(eth2client.AttesterDutiesProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide attester duties")
+	}
+	c.proposerDutiesProvider, isProvider = c.consensusClient.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide proposer duties")
+	}
+	c.blocksProvider, isProvider = c.consensusClient.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide signed beacon blocks")
+	}
+	c.finalityProvider, isProvider = c.consensusClient.(eth2client.FinalityProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide finality")
+	}
+	c.eventsProvider, isProvider = c.consensusClient.(eth2client.EventsProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide events")
+	}
+
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+	if len(validators) == 0 {
+		return errors.New("no validators found")
+	}
+	c.indices = make([]phase0.ValidatorIndex, 0, len(validators))
+	for _, validator := range validators {
+		c.indices = append(c.indices, validator.Index)
+	}
+	sort.Slice(c.indices, func(i, j int) bool {
+		return c.indices[i] < c.indices[j]
+	})
+
+	return nil
+}
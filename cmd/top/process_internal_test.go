@@ -0,0 +1,162 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/testing/mock"
+)
+
+func newTestCommand(t *testing.T) *command {
+	t.Helper()
+
+	chainTime, err := standardchaintime.New(context.Background(),
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+	)
+	require.NoError(t, err)
+
+	return &command{
+		chainTime:     chainTime,
+		indices:       []phase0.ValidatorIndex{1, 2},
+		dutiesEpochs:  make(map[phase0.Epoch]bool),
+		pendingDuties: make(map[phase0.Slot][]*apiv1.AttesterDuty),
+		dashboard: &dashboard{
+			attestations: make(map[phase0.Epoch]*epochAttestations),
+		},
+	}
+}
+
+func TestAttestations(t *testing.T) {
+	c := newTestCommand(t)
+	c.pendingDuties[100] = []*apiv1.AttesterDuty{
+		{
+			ValidatorIndex:          1,
+			Slot:                    100,
+			CommitteeIndex:          2,
+			ValidatorCommitteeIndex: 0,
+		},
+		{
+			ValidatorIndex:          2,
+			Slot:                    100,
+			CommitteeIndex:          2,
+			ValidatorCommitteeIndex: 3,
+		},
+	}
+	c.epochAttestations(3).expected = 2
+
+	aggregationBits := bitfield.NewBitlist(4)
+	aggregationBits.SetBitAt(0, true)
+	c.recordAttestations(102, []*phase0.Attestation{
+		{
+			// Different committee.
+			AggregationBits: bitfield.NewBitlist(4),
+			Data: &phase0.AttestationData{
+				Slot:  100,
+				Index: 1,
+			},
+		},
+		{
+			// No duties at this slot.
+			AggregationBits: aggregationBits,
+			Data: &phase0.AttestationData{
+				Slot:  101,
+				Index: 2,
+			},
+		},
+		{
+			AggregationBits: aggregationBits,
+			Data: &phase0.AttestationData{
+				Slot:  100,
+				Index: 2,
+			},
+		},
+	})
+	require.Len(t, c.pendingDuties[100], 1)
+	require.Equal(t, "1/2 included, average inclusion distance 2.00, 1 pending", c.dashboard.attestations[3].summary())
+
+	// Still within the inclusion window.
+	c.expireDuties(132)
+	require.Len(t, c.pendingDuties[100], 1)
+
+	c.expireDuties(133)
+	require.Empty(t, c.pendingDuties)
+	require.Equal(t, "1/2 included, average inclusion distance 2.00, missed by 2", c.dashboard.attestations[3].summary())
+}
+
+type balancesProvider struct {
+	balances []map[phase0.ValidatorIndex]phase0.Gwei
+}
+
+func (p *balancesProvider) ValidatorBalances(_ context.Context, _ string, _ []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]phase0.Gwei, error) {
+	balances := p.balances[0]
+	p.balances = p.balances[1:]
+
+	return balances, nil
+}
+
+func TestFetchBalances(t *testing.T) {
+	c := newTestCommand(t)
+	c.balancesProvider = &balancesProvider{
+		balances: []map[phase0.ValidatorIndex]phase0.Gwei{
+			{1: 32000000000, 2: 32000000000},
+			{1: 32000010000, 2: 31999990000},
+			{1: 32000020000, 2: 31999995000},
+		},
+	}
+
+	require.NoError(t, c.fetchBalances(context.Background(), 10))
+	require.Equal(t, []*validatorBalance{
+		{index: 1, balance: 32000000000},
+		{index: 2, balance: 32000000000},
+	}, c.dashboard.balances)
+
+	require.NoError(t, c.fetchBalances(context.Background(), 11))
+	require.NoError(t, c.fetchBalances(context.Background(), 12))
+	require.Equal(t, []*validatorBalance{
+		{index: 1, balance: 32000020000, epochChange: 10000, sessionChange: 20000},
+		{index: 2, balance: 31999995000, epochChange: 5000, sessionChange: -5000},
+	}, c.dashboard.balances)
+	require.Equal(t, phase0.Epoch(12), c.balancesEpoch)
+}
+
+func TestUpdateDuties(t *testing.T) {
+	c := newTestCommand(t)
+	c.dashboard.headSlot = 100
+	c.pendingDuties[100] = []*apiv1.AttesterDuty{{ValidatorIndex: 1, Slot: 100}}
+	c.pendingDuties[105] = []*apiv1.AttesterDuty{{ValidatorIndex: 2, Slot: 105}, {ValidatorIndex: 1, Slot: 105}}
+	c.proposals = []*apiv1.ProposerDuty{
+		{ValidatorIndex: 2, Slot: 99},
+		{ValidatorIndex: 2, Slot: 105},
+	}
+
+	c.updateDuties()
+	require.Len(t, c.dashboard.duties, 3)
+	require.Equal(t, phase0.Slot(105), c.dashboard.duties[0].slot)
+	require.True(t, c.dashboard.duties[0].proposal)
+	require.Equal(t, phase0.ValidatorIndex(1), c.dashboard.duties[1].validator)
+	require.Equal(t, phase0.ValidatorIndex(2), c.dashboard.duties[2].validator)
+	// Past proposals are removed.
+	require.Len(t, c.proposals, 1)
+}
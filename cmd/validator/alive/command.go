@@ -0,0 +1,94 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatoralive
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	validator  string
+	maxMissed  uint64
+	jsonOutput bool
+
+	// Data access.
+	eth2Client             eth2client.Service
+	chainTime              chaintime.Service
+	validatorsProvider     eth2client.ValidatorsProvider
+	attesterDutiesProvider eth2client.AttesterDutiesProvider
+	blocksProvider         eth2client.SignedBeaconBlockProvider
+
+	// Results.
+	result *result
+}
+
+type result struct {
+	Validator      phase0.ValidatorIndex `json:"validator_index"`
+	Alive          bool                  `json:"alive"`
+	Reason         string                `json:"reason,omitempty"`
+	FirstEpoch     phase0.Epoch          `json:"first_epoch"`
+	LastEpoch      phase0.Epoch          `json:"last_epoch"`
+	AttestedEpoch  *phase0.Epoch         `json:"attested_epoch,omitempty"`
+	InclusionSlot  *phase0.Slot          `json:"inclusion_slot,omitempty"`
+	EpochsSearched int                   `json:"epochs_searched"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		result:  &result{},
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validator = viper.GetString("validator")
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+
+	c.maxMissed = viper.GetUint64("max-missed")
+	if c.maxMissed == 0 {
+		return nil, errors.New("max missed must be at least 1")
+	}
+
+	c.jsonOutput = viper.GetBool("json")
+
+	return c, nil
+}
@@ -0,0 +1,59 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatoralive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.result)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.result.Alive {
+		builder.WriteString(fmt.Sprintf("Validator %d is alive", c.result.Validator))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf(": attested in epoch %d, included in slot %d", *c.result.AttestedEpoch, *c.result.InclusionSlot))
+		}
+	} else {
+		builder.WriteString(fmt.Sprintf("Validator %d is not alive: %s", c.result.Validator, c.result.Reason))
+	}
+	if c.verbose && c.result.EpochsSearched > 0 {
+		builder.WriteString(fmt.Sprintf("\nEpochs searched: %d", c.result.EpochsSearched))
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,182 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatoralive
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	validator, err := util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validator")
+	}
+	c.result.Validator = validator.Index
+
+	if !validator.Status.IsAttesting() {
+		c.result.Reason = fmt.Sprintf("validator is in state %v", validator.Status)
+		return nil
+	}
+
+	// The current epoch is searched alongside the previous epochs, as its
+	// attestation may already have been included.
+	currentSlot := c.chainTime.CurrentSlot()
+	c.result.LastEpoch = c.chainTime.SlotToEpoch(currentSlot)
+	c.result.FirstEpoch = epochsBack(c.result.LastEpoch, c.maxMissed)
+	if c.result.FirstEpoch < validator.Validator.ActivationEpoch {
+		c.result.FirstEpoch = validator.Validator.ActivationEpoch
+	}
+
+	// Search from the most recent epoch, as that is where an attestation
+	// from a live validator will be found.
+	for epoch := c.result.LastEpoch; ; epoch-- {
+		c.result.EpochsSearched++
+		inclusionSlot, err := c.findAttestation(ctx, validator.Index, epoch, currentSlot)
+		if err != nil {
+			return err
+		}
+		if inclusionSlot != nil {
+			c.result.Alive = true
+			attestedEpoch := epoch
+			c.result.AttestedEpoch = &attestedEpoch
+			c.result.InclusionSlot = inclusionSlot
+			return nil
+		}
+		if epoch == c.result.FirstEpoch {
+			break
+		}
+	}
+
+	c.result.Reason = fmt.Sprintf("no attestation found for epochs %d to %d", c.result.FirstEpoch, c.result.LastEpoch)
+
+	return nil
+}
+
+// findAttestation returns the slot in which the validator's attestation for
+// the given epoch was included, or nil if it has not been included.
+func (c *command) findAttestation(ctx context.Context,
+	validatorIndex phase0.ValidatorIndex,
+	epoch phase0.Epoch,
+	currentSlot phase0.Slot,
+) (
+	*phase0.Slot,
+	error,
+) {
+	duties, err := c.attesterDutiesProvider.AttesterDuties(ctx, epoch, []phase0.ValidatorIndex{validatorIndex})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain attester duties for epoch %d", epoch))
+	}
+	if len(duties) == 0 {
+		// No duty in this epoch.
+		return nil, nil
+	}
+	duty := duties[0]
+
+	// Attestations are usually included in the slot after that for which
+	// they were made, so stop at the first block that contains it.
+	lastSlot := duty.Slot + phase0.Slot(c.chainTime.SlotsPerEpoch())
+	if lastSlot > currentSlot {
+		lastSlot = currentSlot
+	}
+	for slot := duty.Slot + 1; slot <= lastSlot; slot++ {
+		block, err := c.blocksProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if block == nil {
+			// No block at this slot; that's fine.
+			continue
+		}
+		attestations, err := block.Attestations()
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain attestations for slot %d", slot))
+		}
+		if attestationsInclude(attestations, duty) {
+			inclusionSlot := slot
+			return &inclusionSlot, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// attestationsInclude returns true if the attestations include a vote for the duty.
+func attestationsInclude(attestations []*phase0.Attestation, duty *apiv1.AttesterDuty) bool {
+	for _, attestation := range attestations {
+		if attestation.Data.Slot != duty.Slot || attestation.Data.Index != duty.CommitteeIndex {
+			continue
+		}
+		if attestation.AggregationBits.BitAt(duty.ValidatorCommitteeIndex) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// epochsBack returns the epoch the given number of epochs before the
+// given epoch, stopping at genesis.
+func epochsBack(epoch phase0.Epoch, epochs uint64) phase0.Epoch {
+	if uint64(epoch) < epochs {
+		return 0
+	}
+	return epoch - phase0.Epoch(epochs)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.attesterDutiesProvider, isProvider = c.eth2Client.(eth2client.AttesterDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide attester duties")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	return nil
+}
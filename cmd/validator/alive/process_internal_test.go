@@ -0,0 +1,92 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatoralive
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationsInclude(t *testing.T) {
+	duty := &apiv1.AttesterDuty{
+		Slot:                    100,
+		CommitteeIndex:          3,
+		ValidatorCommitteeIndex: 5,
+	}
+
+	// attestation creates an attestation with a single aggregation bit set.
+	attestation := func(slot phase0.Slot, index phase0.CommitteeIndex, bit uint64) *phase0.Attestation {
+		bits := bitfield.NewBitlist(64)
+		bits.SetBitAt(bit, true)
+		return &phase0.Attestation{
+			AggregationBits: bits,
+			Data: &phase0.AttestationData{
+				Slot:  slot,
+				Index: index,
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		attestations []*phase0.Attestation
+		included     bool
+	}{
+		{
+			name:         "Empty",
+			attestations: []*phase0.Attestation{},
+		},
+		{
+			name:         "Included",
+			attestations: []*phase0.Attestation{attestation(100, 3, 5)},
+			included:     true,
+		},
+		{
+			name: "IncludedInLaterAttestation",
+			attestations: []*phase0.Attestation{
+				attestation(100, 3, 4),
+				attestation(100, 3, 5),
+			},
+			included: true,
+		},
+		{
+			name:         "WrongSlot",
+			attestations: []*phase0.Attestation{attestation(99, 3, 5)},
+		},
+		{
+			name:         "WrongCommittee",
+			attestations: []*phase0.Attestation{attestation(100, 2, 5)},
+		},
+		{
+			name:         "WrongBit",
+			attestations: []*phase0.Attestation{attestation(100, 3, 6)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.included, attestationsInclude(test.attestations, duty))
+		})
+	}
+}
+
+func TestEpochsBack(t *testing.T) {
+	require.Equal(t, phase0.Epoch(8), epochsBack(10, 2))
+	require.Equal(t, phase0.Epoch(0), epochsBack(2, 2))
+	require.Equal(t, phase0.Epoch(0), epochsBack(1, 2))
+}
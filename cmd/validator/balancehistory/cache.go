@@ -0,0 +1,81 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorbalancehistory
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// balanceCache holds the balances of a validator at finalized epochs.  These
+// cannot change, so are stored to avoid fetching them again when the history
+// is next exported.
+type balanceCache struct {
+	Validator phase0.ValidatorIndex        `json:"validator"`
+	Balances  map[phase0.Epoch]phase0.Gwei `json:"balances"`
+}
+
+// readBalanceCache reads the cached balances for a validator from a file.  A
+// missing file is treated as an empty cache.
+func readBalanceCache(path string, validator phase0.ValidatorIndex) (*balanceCache, error) {
+	cache := &balanceCache{
+		Validator: validator,
+		Balances:  make(map[phase0.Epoch]phase0.Gwei),
+	}
+	if path == "" {
+		return cache, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, errors.Wrap(err, "failed to read cache file")
+	}
+	if err := json.Unmarshal(data, cache); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse cache file %s", path))
+	}
+	if cache.Validator != validator {
+		return nil, fmt.Errorf("cache file %s holds balances for validator %d", path, cache.Validator)
+	}
+	if cache.Balances == nil {
+		cache.Balances = make(map[phase0.Epoch]phase0.Gwei)
+	}
+
+	return cache, nil
+}
+
+// write writes the cached balances to a file.  The file is replaced
+// atomically, so an interruption never leaves a partial cache.
+func (b *balanceCache) write(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode cache")
+	}
+	if err := util.WriteFileAtomically(path, data); err != nil {
+		return errors.Wrap(err, "failed to write cache file")
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorbalancehistory
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+	csv     bool
+
+	// Input.
+	validator string
+	fromEpoch *phase0.Epoch
+	toEpoch   *phase0.Epoch
+	cacheFile string
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	eth2Client         eth2client.Service
+	chainTime          chaintime.Service
+	validatorsProvider eth2client.ValidatorsProvider
+	balancesProvider   eth2client.ValidatorBalancesProvider
+	finalityProvider   eth2client.FinalityProvider
+
+	// Output.
+	validatorInfo *apiv1.Validator
+	first         phase0.Epoch
+	last          phase0.Epoch
+	balances      []*epochBalance
+}
+
+// epochBalance is the balance of the validator at the start of an epoch.
+type epochBalance struct {
+	Epoch     phase0.Epoch `json:"epoch"`
+	Slot      phase0.Slot  `json:"slot"`
+	Timestamp time.Time    `json:"timestamp"`
+	Balance   phase0.Gwei  `json:"balance"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:     viper.GetBool("quiet"),
+		verbose:   viper.GetBool("verbose"),
+		debug:     viper.GetBool("debug"),
+		json:      viper.GetBool("json"),
+		csv:       viper.GetBool("csv"),
+		cacheFile: viper.GetString("cache-file"),
+	}
+
+	if c.json && c.csv {
+		return nil, errors.New("only one of json and csv output allowed")
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validator = viper.GetString("validator")
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+
+	var err error
+	c.fromEpoch, err = parseEpoch(viper.GetString("from-epoch"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid from epoch")
+	}
+	c.toEpoch, err = parseEpoch(viper.GetString("to-epoch"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid to epoch")
+	}
+	if c.fromEpoch != nil && c.toEpoch != nil && *c.fromEpoch > *c.toEpoch {
+		return nil, errors.New("from epoch must not be after to epoch")
+	}
+
+	return c, nil
+}
+
+// parseEpoch parses an optional epoch.
+func parseEpoch(input string) (*phase0.Epoch, error) {
+	if input == "" {
+		return nil, nil
+	}
+	val, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	epoch := phase0.Epoch(val)
+
+	return &epoch, nil
+}
@@ -0,0 +1,102 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorbalancehistory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type jsonOutput struct {
+	Validator  phase0.ValidatorIndex `json:"validator_index"`
+	FirstEpoch phase0.Epoch          `json:"first_epoch"`
+	LastEpoch  phase0.Epoch          `json:"last_epoch"`
+	Balances   []*epochBalance       `json:"balances"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	if c.csv {
+		return c.outputCSV(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		Validator:  c.validatorInfo.Index,
+		FirstEpoch: c.first,
+		LastEpoch:  c.last,
+		Balances:   c.balances,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Validator %d balances for epochs %d to %d", c.validatorInfo.Index, c.first, c.last))
+	if len(c.balances) == 0 {
+		builder.WriteString(": none")
+		return builder.String(), nil
+	}
+	first := c.balances[0].Balance
+	last := c.balances[len(c.balances)-1].Balance
+	builder.WriteString(fmt.Sprintf(": change %s", balanceChange(first, last)))
+
+	for _, balance := range c.balances {
+		builder.WriteString(fmt.Sprintf("\n  Epoch %d: %s", balance.Epoch, string2eth.GWeiToString(uint64(balance.Balance), true)))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf(" (%s)", balance.Timestamp.Format(time.RFC3339)))
+		}
+	}
+
+	return builder.String(), nil
+}
+
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString("epoch,slot,timestamp,balance_gwei\n")
+	for _, balance := range c.balances {
+		builder.WriteString(fmt.Sprintf("%d,%d,%s,%d\n", balance.Epoch, balance.Slot, balance.Timestamp.Format(time.RFC3339), balance.Balance))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// balanceChange returns a printable version of the change between two balances.
+func balanceChange(from phase0.Gwei, to phase0.Gwei) string {
+	if to < from {
+		return fmt.Sprintf("-%s", string2eth.GWeiToString(uint64(from-to), true))
+	}
+
+	return fmt.Sprintf("+%s", string2eth.GWeiToString(uint64(to-from), true))
+}
@@ -0,0 +1,100 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorbalancehistory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	balances := []*epochBalance{
+		{
+			Epoch:     200000,
+			Slot:      6400000,
+			Timestamp: time.Unix(1683624023, 0).UTC(),
+			Balance:   32010000000,
+		},
+		{
+			Epoch:     200001,
+			Slot:      6400032,
+			Timestamp: time.Unix(1683624407, 0).UTC(),
+			Balance:   32010012345,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		json     bool
+		csv      bool
+		verbose  bool
+		balances []*epochBalance
+		res      string
+	}{
+		{
+			name:     "None",
+			balances: []*epochBalance{},
+			res:      `Validator 1 balances for epochs 200000 to 200001: none`,
+		},
+		{
+			name:     "Text",
+			balances: balances,
+			res: `Validator 1 balances for epochs 200000 to 200001: change +0.000012345 Ether
+  Epoch 200000: 32.01 Ether
+  Epoch 200001: 32.010012345 Ether`,
+		},
+		{
+			name:     "Verbose",
+			verbose:  true,
+			balances: balances[:1],
+			res: `Validator 1 balances for epochs 200000 to 200001: change +0
+  Epoch 200000: 32.01 Ether (2023-05-09T09:20:23Z)`,
+		},
+		{
+			name:     "CSV",
+			csv:      true,
+			balances: balances,
+			res: `epoch,slot,timestamp,balance_gwei
+200000,6400000,2023-05-09T09:20:23Z,32010000000
+200001,6400032,2023-05-09T09:26:47Z,32010012345`,
+		},
+		{
+			name:     "JSON",
+			json:     true,
+			balances: balances[:1],
+			res:      `{"validator_index":1,"first_epoch":200000,"last_epoch":200001,"balances":[{"epoch":200000,"slot":6400000,"timestamp":"2023-05-09T09:20:23Z","balance":32010000000}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				json:          test.json,
+				csv:           test.csv,
+				verbose:       test.verbose,
+				validatorInfo: &apiv1.Validator{Index: 1},
+				first:         200000,
+				last:          200001,
+				balances:      test.balances,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorbalancehistory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// defaultEpochs is the number of epochs covered if no from epoch is supplied,
+// which is approximately one day on mainnet.
+const defaultEpochs = 225
+
+// fetchConcurrency is the number of concurrent requests used to obtain
+// balances.
+var fetchConcurrency = 4
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	c.validatorInfo, err = util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator information")
+	}
+
+	c.last = c.chainTime.CurrentEpoch()
+	if c.toEpoch != nil {
+		if *c.toEpoch > c.last {
+			return fmt.Errorf("to epoch cannot be after current epoch %d", c.last)
+		}
+		c.last = *c.toEpoch
+	}
+	c.first = 0
+	if c.fromEpoch != nil {
+		c.first = *c.fromEpoch
+	} else if c.last >= defaultEpochs {
+		c.first = c.last - defaultEpochs
+	}
+	if c.first > c.last {
+		return errors.New("from epoch must not be after to epoch")
+	}
+
+	finality, err := c.finalityProvider.Finality(ctx, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain finality")
+	}
+
+	cache, err := readBalanceCache(c.cacheFile, c.validatorInfo.Index)
+	if err != nil {
+		return err
+	}
+
+	return c.obtainBalances(ctx, cache, finality.Finalized.Epoch)
+}
+
+// obtainBalances obtains the balances for the range of epochs, using cached
+// balances where available and caching newly-obtained finalized balances.
+func (c *command) obtainBalances(ctx context.Context, cache *balanceCache, finalizedEpoch phase0.Epoch) error {
+	epochs := make([]phase0.Epoch, 0)
+	for epoch := c.first; epoch <= c.last; epoch++ {
+		if _, exists := cache.Balances[epoch]; !exists {
+			epochs = append(epochs, epoch)
+		}
+		if epoch == c.last {
+			// Avoid overflow.
+			break
+		}
+	}
+	util.Log.Debug().Int("cached", int(c.last-c.first)+1-len(epochs)).Int("required", len(epochs)).Msg("Obtaining balances")
+
+	balances, err := c.fetchBalances(ctx, epochs)
+	if err != nil {
+		return err
+	}
+
+	for epoch, balance := range balances {
+		if epoch <= finalizedEpoch {
+			cache.Balances[epoch] = balance
+		}
+	}
+	if len(balances) > 0 {
+		if err := cache.write(c.cacheFile); err != nil {
+			return err
+		}
+	}
+
+	c.balances = make([]*epochBalance, 0, int(c.last-c.first)+1)
+	for epoch := c.first; epoch <= c.last; epoch++ {
+		balance, exists := balances[epoch]
+		if !exists {
+			balance, exists = cache.Balances[epoch]
+		}
+		if exists {
+			slot := c.chainTime.FirstSlotOfEpoch(epoch)
+			c.balances = append(c.balances, &epochBalance{
+				Epoch:     epoch,
+				Slot:      slot,
+				Timestamp: c.chainTime.StartOfSlot(slot).UTC(),
+				Balance:   balance,
+			})
+		}
+		if epoch == c.last {
+			// Avoid overflow.
+			break
+		}
+	}
+
+	return nil
+}
+
+// fetchBalances fetches the validator's balance at the start of each of the
+// given epochs, using concurrent requests.  Epochs at which the validator
+// did not exist are omitted.
+func (c *command) fetchBalances(ctx context.Context,
+	epochs []phase0.Epoch,
+) (
+	map[phase0.Epoch]phase0.Gwei,
+	error,
+) {
+	res := make(map[phase0.Epoch]phase0.Gwei, len(epochs))
+	if len(epochs) == 0 {
+		return res, nil
+	}
+
+	progress := util.NewProgress("Obtaining balances", len(epochs))
+	defer progress.Finish()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var firstErr error
+	next := 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < fetchConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				if firstErr != nil || next >= len(epochs) {
+					mu.Unlock()
+					return
+				}
+				epoch := epochs[next]
+				next++
+				mu.Unlock()
+
+				stateID := fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch))
+				balances, err := c.balancesProvider.ValidatorBalances(ctx, stateID, []phase0.ValidatorIndex{c.validatorInfo.Index})
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = errors.Wrap(err, fmt.Sprintf("failed to obtain balance for epoch %d", epoch))
+						cancel()
+					}
+					mu.Unlock()
+					return
+				}
+				if balance, exists := balances[c.validatorInfo.Index]; exists {
+					res[epoch] = balance
+				}
+				mu.Unlock()
+				progress.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return res, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.balancesProvider, isProvider = c.eth2Client.(eth2client.ValidatorBalancesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator balances")
+	}
+	c.finalityProvider, isProvider = c.eth2Client.(eth2client.FinalityProvider)
+	if !isProvider {
+		return errors.New("connection does not provide finality")
+	}
+
+	return nil
+}
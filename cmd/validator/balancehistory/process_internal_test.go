@@ -0,0 +1,154 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorbalancehistory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/testing/mock"
+)
+
+// balancesProvider provides balances that increase by 1 Gwei each slot,
+// recording the states requested.
+type balancesProvider struct {
+	eth2client.ValidatorBalancesProvider
+	mu        sync.Mutex
+	requested []string
+	// firstSlot is the first slot at which the validator exists.
+	firstSlot uint64
+	// failSlot is a slot for which requests fail, if not 0.
+	failSlot uint64
+}
+
+func (p *balancesProvider) ValidatorBalances(_ context.Context, stateID string, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]phase0.Gwei, error) {
+	p.mu.Lock()
+	p.requested = append(p.requested, stateID)
+	p.mu.Unlock()
+
+	var slot uint64
+	if _, err := fmt.Sscanf(stateID, "%d", &slot); err != nil {
+		return nil, err
+	}
+	if p.failSlot != 0 && slot == p.failSlot {
+		return nil, errors.New("mock error")
+	}
+	res := make(map[phase0.ValidatorIndex]phase0.Gwei)
+	if slot >= p.firstSlot {
+		for _, index := range validatorIndices {
+			res[index] = phase0.Gwei(32000000000 + slot)
+		}
+	}
+
+	return res, nil
+}
+
+func newTestCommand(t *testing.T, provider *balancesProvider) *command {
+	t.Helper()
+
+	chainTime, err := standardchaintime.New(context.Background(),
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Unix(1606824023, 0))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+	)
+	require.NoError(t, err)
+
+	return &command{
+		chainTime:        chainTime,
+		balancesProvider: provider,
+		validatorInfo:    &apiv1.Validator{Index: 1},
+	}
+}
+
+func TestObtainBalances(t *testing.T) {
+	provider := &balancesProvider{}
+	c := newTestCommand(t, provider)
+	c.first = 10
+	c.last = 19
+	c.cacheFile = filepath.Join(t.TempDir(), "cache.json")
+
+	cache, err := readBalanceCache(c.cacheFile, 1)
+	require.NoError(t, err)
+	require.NoError(t, c.obtainBalances(context.Background(), cache, 15))
+	require.Len(t, c.balances, 10)
+	require.Len(t, provider.requested, 10)
+	for i, balance := range c.balances {
+		require.Equal(t, phase0.Epoch(10+i), balance.Epoch)
+		require.Equal(t, phase0.Slot(32*(10+i)), balance.Slot)
+		require.Equal(t, phase0.Gwei(32000000000+32*(10+i)), balance.Balance)
+	}
+
+	// Finalized balances should have been cached, so only unfinalized
+	// balances are obtained again.
+	provider.requested = nil
+	cache, err = readBalanceCache(c.cacheFile, 1)
+	require.NoError(t, err)
+	require.Len(t, cache.Balances, 6)
+	require.NoError(t, c.obtainBalances(context.Background(), cache, 15))
+	require.Len(t, c.balances, 10)
+	require.ElementsMatch(t, []string{"512", "544", "576", "608"}, provider.requested)
+}
+
+func TestObtainBalancesBeforeDeposit(t *testing.T) {
+	provider := &balancesProvider{firstSlot: 480}
+	c := newTestCommand(t, provider)
+	c.first = 10
+	c.last = 19
+
+	cache, err := readBalanceCache("", 1)
+	require.NoError(t, err)
+	require.NoError(t, c.obtainBalances(context.Background(), cache, 15))
+	require.Len(t, c.balances, 5)
+	require.Equal(t, phase0.Epoch(15), c.balances[0].Epoch)
+}
+
+func TestObtainBalancesError(t *testing.T) {
+	provider := &balancesProvider{failSlot: 384}
+	c := newTestCommand(t, provider)
+	c.first = 10
+	c.last = 19
+
+	cache, err := readBalanceCache("", 1)
+	require.NoError(t, err)
+	require.EqualError(t, c.obtainBalances(context.Background(), cache, 15), "failed to obtain balance for epoch 12: mock error")
+}
+
+func TestBalanceCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+
+	cache, err := readBalanceCache(path, 1)
+	require.NoError(t, err)
+	require.Empty(t, cache.Balances)
+
+	cache.Balances[5] = 32000000000
+	require.NoError(t, cache.write(path))
+
+	cache, err = readBalanceCache(path, 1)
+	require.NoError(t, err)
+	require.Equal(t, map[phase0.Epoch]phase0.Gwei{5: 32000000000}, cache.Balances)
+
+	_, err = readBalanceCache(path, 2)
+	require.EqualError(t, err, fmt.Sprintf("cache file %s holds balances for validator 1", path))
+}
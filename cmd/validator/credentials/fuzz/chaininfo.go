@@ -21,6 +21,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/util"
 )
 
 // obtainChainInfo obtains the chain information required to create a withdrawal credentials change operation.
@@ -48,26 +49,18 @@ func (c *command) obtainChainInfo(ctx context.Context) error {
 func (c *command) obtainChainInfoFromFile(_ context.Context) error {
 	_, err := os.Stat(offlinePreparationFilename)
 	if err != nil {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Failed to read offline preparation file: %v\n", err)
-		}
+		util.Log.Debug().Err(err).Msg("Failed to read offline preparation file")
 		return errors.Wrap(err, fmt.Sprintf("cannot find %s", offlinePreparationFilename))
 	}
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "%s found; loading chain state\n", offlinePreparationFilename)
-	}
+	util.Log.Debug().Str("file", offlinePreparationFilename).Msg("Loading chain state")
 	data, err := os.ReadFile(offlinePreparationFilename)
 	if err != nil {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "failed to load chain state: %v\n", err)
-		}
+		util.Log.Debug().Err(err).Msg("Failed to load chain state")
 		return errors.Wrap(err, "failed to read offline preparation file")
 	}
 	c.chainInfo = &beacon.ChainInfo{}
 	if err := json.Unmarshal(data, c.chainInfo); err != nil {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "chain state invalid: %v\n", err)
-		}
+		util.Log.Debug().Err(err).Msg("Chain state invalid")
 		return errors.Wrap(err, "failed to parse offline preparation file")
 	}
 
@@ -76,9 +69,7 @@ func (c *command) obtainChainInfoFromFile(_ context.Context) error {
 
 // obtainChainInfoFromNode obtains chain info from a beacon node.
 func (c *command) obtainChainInfoFromNode(ctx context.Context) error {
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Populating chain info from beacon node\n")
-	}
+	util.Log.Debug().Msg("Populating chain info from beacon node")
 
 	var err error
 	c.chainInfo, err = beacon.ObtainChainInfoFromNode(ctx, c.consensusClient, c.chainTime)
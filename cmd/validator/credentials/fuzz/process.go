@@ -69,9 +69,7 @@ func (c *command) process(ctx context.Context) error {
 	}
 
 	if c.json || c.offline {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Not broadcasting credentials change operations\n")
-		}
+		util.Log.Debug().Msg("Not broadcasting credentials change operations")
 		// Want JSON output, or cannot broadcast.
 		return nil
 	}
@@ -171,9 +169,7 @@ func (c *command) generateOperationFromMnemonicAndValidator(ctx context.Context)
 	var withdrawalAccount e2wtypes.Account
 	for i := 0; ; i++ {
 		if i == maxDistance {
-			if c.debug {
-				fmt.Fprintf(os.Stderr, "Gone %d indices without finding the validator, not scanning any further\n", maxDistance)
-			}
+			util.Log.Debug().Int("distance", maxDistance).Msg("Validator not found, not scanning any further")
 			break
 		}
 		validatorKeyPath := fmt.Sprintf("m/12381/3600/%d/0/0", i)
@@ -222,9 +218,7 @@ func (c *command) generateOperationsFromMnemonic(ctx context.Context) error {
 	lastFoundIndex := 0
 	for i := 0; ; i++ {
 		if i-lastFoundIndex > maxDistance {
-			if c.debug {
-				fmt.Fprintf(os.Stderr, "Gone %d indices without finding a validator, not scanning any further\n", maxDistance)
-			}
+			util.Log.Debug().Int("distance", maxDistance).Msg("No validators found, not scanning any further")
 			break
 		}
 		validatorKeyPath := fmt.Sprintf("m/12381/3600/%d/0/0", i)
@@ -326,9 +320,7 @@ func (c *command) obtainOperationsFromFile(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to read change operations file")
 	}
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "%s found; loading operations\n", changeOperationsFilename)
-	}
+	util.Log.Debug().Str("file", changeOperationsFilename).Msg("Loading operations")
 	data, err := os.ReadFile(changeOperationsFilename)
 	if err != nil {
 		return errors.Wrap(err, "failed to read change operations file")
@@ -388,9 +380,7 @@ func (c *command) generateOperationFromSeedAndPath(ctx context.Context,
 	validatorPubkey := fmt.Sprintf("%#x", validatorPrivkey.PublicKey().Marshal())
 	validator, exists := validators[validatorPubkey]
 	if !exists {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "No validator found with public key %s at path %s\n", validatorPubkey, path)
-		}
+		util.Log.Debug().Str("pubkey", validatorPubkey).Str("path", path).Msg("No validator found")
 		return false, nil
 	}
 
@@ -400,9 +390,7 @@ func (c *command) generateOperationFromSeedAndPath(ctx context.Context,
 
 	//TODO: add flag to override this check
 	if validator.WithdrawalCredentials[0] != byte(0) {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Validator %s has non-BLS withdrawal credentials %#x\n", validatorPubkey, validator.WithdrawalCredentials)
-		}
+		util.Log.Debug().Str("pubkey", validatorPubkey).Str("withdrawal_credentials", fmt.Sprintf("%#x", validator.WithdrawalCredentials)).Msg("Validator has non-BLS withdrawal credentials")
 		return false, nil
 	}
 
@@ -438,9 +426,7 @@ func (c *command) generateOperationFromSeedAndPath(ctx context.Context,
 		return false, nil
 	}
 
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Validator %s eligible for setting credentials\n", validatorPubkey)
-	}
+	util.Log.Debug().Str("pubkey", validatorPubkey).Msg("Validator eligible for setting credentials")
 
 	err = c.generateOperationFromAccount(ctx, validator, withdrawalAccount)
 	if err != nil {
@@ -463,7 +449,22 @@ func (c *command) generateOperationFromAccount(ctx context.Context,
 	return nil
 }
 
-func FuzzinessAct() bool {
+// FuzzinessAct returns true if the given fuzzing strategy should be applied.
+// Strategies are "message", "root" and "signature"; if no strategies are
+// specified then all are enabled.
+func FuzzinessAct(strategy string) bool {
+	if strategies := viper.GetStringSlice("fuzz-strategies"); len(strategies) > 0 {
+		enabled := false
+		for _, s := range strategies {
+			if s == strategy {
+				enabled = true
+				break
+			}
+		}
+		if !enabled {
+			return false
+		}
+	}
 	fuzziness := viper.GetInt("fuzziness")
 	return fuzziness > rand.Intn(100)
 }
@@ -471,19 +472,19 @@ func FuzzinessAct() bool {
 func (c *command) fuzzBlsChangeMessage(operation *capella.BLSToExecutionChange) *capella.BLSToExecutionChange {
 
 	// fuzz validator idx
-	if FuzzinessAct() {
+	if FuzzinessAct("message") {
 		operation.ValidatorIndex = phase0.ValidatorIndex(rand.Intn(1000000))
 	}
 
 	// fuzz pubkey
-	if FuzzinessAct() {
+	if FuzzinessAct("message") {
 		testcase := make([]byte, 48)
 		rand.Read(testcase)
 		copy(operation.FromBLSPubkey[:], testcase)
 	}
 
 	// fuzz ToExecutionAddress
-	if FuzzinessAct() {
+	if FuzzinessAct("message") {
 		testcase := make([]byte, 20)
 		rand.Read(testcase)
 		copy(operation.ToExecutionAddress[:], testcase)
@@ -498,7 +499,7 @@ func (c *command) fuzzBlsChangeMessageWithRoot(operation *capella.BLSToExecution
 	operation = c.fuzzBlsChangeMessage(operation)
 
 	// fuzz root
-	if FuzzinessAct() {
+	if FuzzinessAct("root") {
 		testcase := make([]byte, 32)
 		rand.Read(testcase)
 		copy(root[:], testcase)
@@ -513,7 +514,7 @@ func (c *command) fuzzBlsChangeMessageWithSignature(operation *capella.BLSToExec
 	operation = c.fuzzBlsChangeMessage(operation)
 
 	// fuzz signature
-	if FuzzinessAct() {
+	if FuzzinessAct("signature") {
 		testcase := make([]byte, 96)
 		rand.Read(testcase)
 		copy(signature[:], testcase)
@@ -544,9 +545,7 @@ func (c *command) createSignedOperation(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Using %#x as best public key for %s\n", pubkey.Marshal(), withdrawalAccount.Name())
-	}
+	util.Log.Debug().Str("account", withdrawalAccount.Name()).Str("pubkey", fmt.Sprintf("%#x", pubkey.Marshal())).Msg("Obtained best public key")
 	blsPubkey := phase0.BLSPubKey{}
 	copy(blsPubkey[:], pubkey.Marshal())
 
@@ -569,9 +568,7 @@ func (c *command) createSignedOperation(ctx context.Context,
 	}
 
 	// Sign the operation.
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Signing %#x with domain %#x by public key %#x\n", root, c.domain, withdrawalAccount.PublicKey().Marshal())
-	}
+	util.Log.Debug().Str("root", fmt.Sprintf("%#x", root)).Str("domain", fmt.Sprintf("%#x", c.domain)).Str("pubkey", fmt.Sprintf("%#x", pubkey.Marshal())).Msg("Signing")
 	// fuzz before signature
 	operation, root = c.fuzzBlsChangeMessageWithRoot(operation, root)
 
@@ -669,10 +666,8 @@ func (c *command) validateOperation(_ context.Context,
 		if !exists {
 			return false, "validator not known on chain"
 		}
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Credentials change operation: %v", signedOperation)
-			fmt.Fprintf(os.Stderr, "On-chain validator info: %v\n", validator)
-		}
+		util.Log.Debug().Interface("operation", signedOperation).Msg("Generated credentials change operation")
+		util.Log.Debug().Interface("validator", validator).Msg("Obtained on-chain validator info")
 
 		if validator.WithdrawalCredentials[0] != byte(0) {
 			return false, "validator is not using BLS withdrawal credentials"
@@ -681,9 +676,7 @@ func (c *command) validateOperation(_ context.Context,
 		withdrawalCredentials := ethutil.SHA256(signedOperation.Message.FromBLSPubkey[:])
 		withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
 		if !bytes.Equal(withdrawalCredentials, validator.WithdrawalCredentials) {
-			if c.debug {
-				fmt.Fprintf(os.Stderr, "validator withdrawal credentials %#x do not match calculated operation withdrawal credentials %#x\n", validator.WithdrawalCredentials, withdrawalCredentials)
-			}
+			util.Log.Debug().Str("validator_credentials", fmt.Sprintf("%#x", validator.WithdrawalCredentials)).Str("operation_credentials", fmt.Sprintf("%#x", withdrawalCredentials)).Msg("Validator withdrawal credentials do not match calculated operation withdrawal credentials")
 			return false, "validator withdrawal credentials do not match those in the operation"
 		}
 	*/
@@ -709,6 +702,7 @@ func (c *command) setup(ctx context.Context) error {
 	c.chainTime, err = standardchaintime.New(ctx,
 		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
 		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.consensusClient.(consensusclient.ForkScheduleProvider)),
 	)
 	if err != nil {
 		return errors.Wrap(err, "failed to create chaintime service")
@@ -737,9 +731,7 @@ func (c *command) generateDomain(ctx context.Context) error {
 
 	copy(c.domain[:], c.chainInfo.BLSToExecutionChangeDomainType[:])
 	copy(c.domain[4:], root[:])
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Domain is %#x\n", c.domain)
-	}
+	util.Log.Debug().Str("domain", fmt.Sprintf("%#x", c.domain)).Msg("Obtained domain")
 
 	return nil
 }
@@ -748,9 +740,7 @@ func (c *command) obtainGenesisValidatorsRoot(ctx context.Context) (phase0.Root,
 	genesisValidatorsRoot := phase0.Root{}
 
 	if c.genesisValidatorsRoot != "" {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Genesis validators root supplied on the command line\n")
-		}
+		util.Log.Debug().Msg("Genesis validators root supplied on the command line")
 		root, err := hex.DecodeString(strings.TrimPrefix(c.genesisValidatorsRoot, "0x"))
 		if err != nil {
 			return phase0.Root{}, errors.Wrap(err, "invalid genesis validators root supplied")
@@ -760,15 +750,11 @@ func (c *command) obtainGenesisValidatorsRoot(ctx context.Context) (phase0.Root,
 		}
 		copy(genesisValidatorsRoot[:], root)
 	} else {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Genesis validators root obtained from chain info\n")
-		}
+		util.Log.Debug().Msg("Genesis validators root obtained from chain info")
 		copy(genesisValidatorsRoot[:], c.chainInfo.GenesisValidatorsRoot[:])
 	}
 
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Using genesis validators root %#x\n", genesisValidatorsRoot)
-	}
+	util.Log.Debug().Str("genesis_validators_root", fmt.Sprintf("%#x", genesisValidatorsRoot)).Msg("Using genesis validators root")
 	return genesisValidatorsRoot, nil
 }
 
@@ -776,9 +762,7 @@ func (c *command) obtainForkVersion(ctx context.Context) (phase0.Version, error)
 	forkVersion := phase0.Version{}
 
 	if c.forkVersion != "" {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Fork version supplied on the command line\n")
-		}
+		util.Log.Debug().Msg("Fork version supplied on the command line")
 		version, err := hex.DecodeString(strings.TrimPrefix(c.forkVersion, "0x"))
 		if err != nil {
 			return phase0.Version{}, errors.Wrap(err, "invalid fork version supplied")
@@ -788,16 +772,12 @@ func (c *command) obtainForkVersion(ctx context.Context) (phase0.Version, error)
 		}
 		copy(forkVersion[:], version)
 	} else {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Fork version obtained from chain info\n")
-		}
+		util.Log.Debug().Msg("Fork version obtained from chain info")
 		// Use the genesis fork version for setting credentials as per the spec.
 		copy(forkVersion[:], c.chainInfo.GenesisForkVersion[:])
 	}
 
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Using fork version %#x\n", forkVersion)
-	}
+	util.Log.Debug().Str("fork_version", fmt.Sprintf("%#x", forkVersion)).Msg("Using fork version")
 	return forkVersion, nil
 }
 
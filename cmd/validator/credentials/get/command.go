@@ -29,7 +29,10 @@ type command struct {
 	debug   bool
 
 	// Input.
-	validator string
+	validator      string
+	validators     []string
+	validatorsFile string
+	json           bool
 
 	// Beacon node connection.
 	timeout                  time.Duration
@@ -41,7 +44,8 @@ type command struct {
 	validatorsProvider eth2client.ValidatorsProvider
 
 	// Output.
-	validatorInfo *apiv1.Validator
+	validatorInfo  *apiv1.Validator
+	validatorInfos []*apiv1.Validator
 }
 
 func newCommand(ctx context.Context) (*command, error) {
@@ -60,10 +64,28 @@ func newCommand(ctx context.Context) (*command, error) {
 	c.connection = viper.GetString("connection")
 	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
 
-	if viper.GetString("validator") == "" {
-		return nil, errors.New("validator is required")
-	}
 	c.validator = viper.GetString("validator")
+	c.validators = viper.GetStringSlice("validators")
+	c.validatorsFile = viper.GetString("validators-file")
+	c.json = viper.GetBool("json")
+
+	inputs := 0
+	if c.validator != "" {
+		inputs++
+	}
+	if len(c.validators) > 0 {
+		inputs++
+	}
+	if c.validatorsFile != "" {
+		inputs++
+	}
+	switch inputs {
+	case 0:
+		return nil, errors.New("one of validator, validators or validators-file is required")
+	case 1:
+	default:
+		return nil, errors.New("only one of validator, validators and validators-file allowed")
+	}
 
 	return c, nil
 }
@@ -43,24 +43,32 @@ func TestInput(t *testing.T) {
 				"timeout":    "5s",
 				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
 			},
-			err: "one of account, index or pubkey required",
+			err: "one of validator, validators or validators-file is required",
 		},
 		{
 			name: "MultipleValidatorInfo",
 			vars: map[string]interface{}{
 				"timeout":    "5s",
 				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
-				"index":      "1",
-				"pubkey":     "0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+				"validator":  "1",
+				"validators": []string{"1", "2"},
 			},
-			err: "only one of account, index and pubkey allowed",
+			err: "only one of validator, validators and validators-file allowed",
 		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
 				"timeout":    "5s",
 				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
-				"index":      "1",
+				"validator":  "1",
+			},
+		},
+		{
+			name: "GoodValidators",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"validators": []string{"1", "2-5"},
 			},
 		},
 	}
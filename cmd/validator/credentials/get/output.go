@@ -15,24 +15,48 @@ package validatorcredentialsget
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
 	ethutil "github.com/wealdtech/go-eth2-util"
 )
 
+type validatorCredentialsJSON struct {
+	Index                 phase0.ValidatorIndex `json:"index"`
+	Pubkey                string                `json:"pubkey"`
+	Type                  string                `json:"type"`
+	WithdrawalCredentials string                `json:"withdrawal_credentials"`
+	ExecutionAddress      string                `json:"execution_address,omitempty"`
+}
+
+type summaryJSON struct {
+	Validators []*validatorCredentialsJSON `json:"validators"`
+	Counts     map[string]int              `json:"counts"`
+}
+
 func (c *command) output(ctx context.Context) (string, error) {
 	if c.quiet {
 		return "", nil
 	}
 
+	if c.validatorInfo == nil {
+		if c.json {
+			return c.outputBulkJSON(ctx)
+		}
+		return c.outputBulkText(ctx)
+	}
+
 	builder := strings.Builder{}
 
 	switch c.validatorInfo.Validator.WithdrawalCredentials[0] {
 	case 0:
 		builder.WriteString("BLS credentials: ")
 		builder.WriteString(fmt.Sprintf("%#x", c.validatorInfo.Validator.WithdrawalCredentials))
-	case 1:
+	case 1, 2:
 		builder.WriteString("Ethereum execution address: ")
 		builder.WriteString(addressBytesToEIP55(c.validatorInfo.Validator.WithdrawalCredentials[12:]))
 		if c.verbose {
@@ -45,6 +69,83 @@ func (c *command) output(ctx context.Context) (string, error) {
 	return builder.String(), nil
 }
 
+func (c *command) outputBulkJSON(_ context.Context) (string, error) {
+	res := &summaryJSON{
+		Validators: make([]*validatorCredentialsJSON, 0, len(c.validatorInfos)),
+		Counts:     credentialsCounts(c.validatorInfos),
+	}
+	for _, validator := range c.validatorInfos {
+		res.Validators = append(res.Validators, &validatorCredentialsJSON{
+			Index:                 validator.Index,
+			Pubkey:                fmt.Sprintf("%#x", validator.Validator.PublicKey),
+			Type:                  util.WithdrawalCredentialsType(validator.Validator.WithdrawalCredentials),
+			WithdrawalCredentials: fmt.Sprintf("%#x", validator.Validator.WithdrawalCredentials),
+			ExecutionAddress:      executionAddress(validator.Validator.WithdrawalCredentials),
+		})
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputBulkText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("%-10s %-6s %s\n", "Index", "Type", "Execution address"))
+	for _, validator := range c.validatorInfos {
+		address := executionAddress(validator.Validator.WithdrawalCredentials)
+		if address == "" {
+			address = "-"
+		}
+		builder.WriteString(fmt.Sprintf("%-10d %-6s %s\n", validator.Index, util.WithdrawalCredentialsType(validator.Validator.WithdrawalCredentials), address))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("  Withdrawal credentials: %#x\n", validator.Validator.WithdrawalCredentials))
+		}
+	}
+
+	counts := credentialsCounts(c.validatorInfos)
+	builder.WriteString(fmt.Sprintf("BLS credentials (0x00): %d\n", counts["0x00"]))
+	builder.WriteString(fmt.Sprintf("Execution credentials (0x01): %d\n", counts["0x01"]))
+	builder.WriteString(fmt.Sprintf("Compounding credentials (0x02): %d", counts["0x02"]))
+	if counts["unknown"] > 0 {
+		builder.WriteString(fmt.Sprintf("\nUnknown credentials: %d", counts["unknown"]))
+	}
+
+	return builder.String(), nil
+}
+
+// executionAddress returns the execution address of the withdrawal credentials,
+// or an empty string if the credentials do not contain one.
+func executionAddress(credentials []byte) string {
+	if len(credentials) != phase0.HashLength {
+		return ""
+	}
+	switch credentials[0] {
+	case 1, 2:
+		return addressBytesToEIP55(credentials[12:])
+	default:
+		return ""
+	}
+}
+
+// credentialsCounts returns the number of validators with each credentials type.
+func credentialsCounts(validators []*apiv1.Validator) map[string]int {
+	counts := map[string]int{
+		"0x00": 0,
+		"0x01": 0,
+		"0x02": 0,
+	}
+	for _, validator := range validators {
+		counts[util.WithdrawalCredentialsType(validator.Validator.WithdrawalCredentials)]++
+	}
+
+	return counts
+}
+
 // addressBytesToEIP55 converts a byte array in to an EIP-55 string format.
 func addressBytesToEIP55(address []byte) string {
 	bytes := []byte(fmt.Sprintf("%x", address))
@@ -0,0 +1,83 @@
+// Copyright © 2022 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialsget
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func hexToBytes(input string) []byte {
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func TestOutputBulk(t *testing.T) {
+	validators := []*apiv1.Validator{
+		{
+			Index: 1,
+			Validator: &phase0.Validator{
+				WithdrawalCredentials: hexToBytes("0x0100000000000000000000008f0844fd51e31ff6bf5babe21dccf7328e19fd9f"),
+			},
+		},
+		{
+			Index: 2,
+			Validator: &phase0.Validator{
+				WithdrawalCredentials: hexToBytes("0x00ebea3eb5c1a5f0c6d2b4a8e7f4e1c2d3b4a5968778695a4b3c2d1e0f1a2b3c"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		json bool
+		res  string
+	}{
+		{
+			name: "Text",
+			res: `Index      Type   Execution address
+1          0x01   0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F
+2          0x00   -
+BLS credentials (0x00): 1
+Execution credentials (0x01): 1
+Compounding credentials (0x02): 0`,
+		},
+		{
+			name: "JSON",
+			json: true,
+			res:  `{"validators":[{"index":1,"pubkey":"0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","type":"0x01","withdrawal_credentials":"0x0100000000000000000000008f0844fd51e31ff6bf5babe21dccf7328e19fd9f","execution_address":"0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F"},{"index":2,"pubkey":"0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","type":"0x00","withdrawal_credentials":"0x00ebea3eb5c1a5f0c6d2b4a8e7f4e1c2d3b4a5968778695a4b3c2d1e0f1a2b3c"}],"counts":{"0x00":1,"0x01":1,"0x02":0}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				json:           test.json,
+				validatorInfos: validators,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
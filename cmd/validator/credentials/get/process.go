@@ -15,8 +15,6 @@ package validatorcredentialsget
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/pkg/errors"
@@ -29,16 +27,20 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	// Work out which validator we are dealing with.
-	if err := c.fetchValidator(ctx); err != nil {
-		return err
+	if c.validator != "" {
+		// Work out which validator we are dealing with.
+		if err := c.fetchValidator(ctx); err != nil {
+			return err
+		}
+
+		util.Log.Debug().Interface("validator", c.validatorInfo).Msg("Obtained validator information")
+
+		return nil
 	}
 
-	if c.debug {
-		data, err := json.Marshal(c.validator)
-		if err == nil {
-			fmt.Println(string(data))
-		}
+	// Work out which validators we are dealing with.
+	if err := c.fetchValidators(ctx); err != nil {
+		return err
 	}
 
 	return nil
@@ -57,7 +59,7 @@ func (c *command) setup(ctx context.Context) error {
 	var isProvider bool
 	c.validatorsProvider, isProvider = c.consensusClient.(eth2client.ValidatorsProvider)
 	if !isProvider {
-		return errors.New("consensu node does not provide validator information")
+		return errors.New("consensus node does not provide validator information")
 	}
 
 	return nil
@@ -72,3 +74,26 @@ func (c *command) fetchValidator(ctx context.Context) error {
 
 	return nil
 }
+
+func (c *command) fetchValidators(ctx context.Context) error {
+	validators := c.validators
+	if c.validatorsFile != "" {
+		var err error
+		validators, err = util.ReadValidatorsFile(c.validatorsFile)
+		if err != nil {
+			return err
+		}
+	}
+	if len(validators) == 0 {
+		return errors.New("no validators supplied")
+	}
+
+	var err error
+	c.validatorInfos, err = util.ParseValidators(ctx, c.validatorsProvider, validators, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators information")
+	}
+	util.Log.Debug().Int("validators", len(c.validatorInfos)).Msg("Obtained validator information")
+
+	return nil
+}
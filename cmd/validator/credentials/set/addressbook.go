@@ -0,0 +1,133 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialsset
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// loadAddressBook loads the address book mapping validator indices to execution addresses.
+func (c *command) loadAddressBook(_ context.Context) error {
+	f, err := os.Open(c.addressBookFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to open address book")
+	}
+	defer f.Close()
+
+	addressBook, err := parseAddressBook(f)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse address book")
+	}
+	c.addressBook = addressBook
+
+	util.Log.Debug().Int("entries", len(c.addressBook)).Msg("Loaded address book")
+
+	return nil
+}
+
+// parseAddressBook parses CSV data of the form "validator index,execution address".
+// A header line is permitted, and each validator may be listed only once.
+func parseAddressBook(input io.Reader) (map[phase0.ValidatorIndex]bellatrix.ExecutionAddress, error) {
+	reader := csv.NewReader(input)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	addressBook := make(map[phase0.ValidatorIndex]bellatrix.ExecutionAddress)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		index, err := strconv.ParseUint(strings.TrimSpace(record[0]), 10, 64)
+		if err != nil {
+			if first {
+				// Assume this is a header.
+				continue
+			}
+			return nil, fmt.Errorf("line %d: invalid validator index %q", line, record[0])
+		}
+		validatorIndex := phase0.ValidatorIndex(index)
+		if _, exists := addressBook[validatorIndex]; exists {
+			return nil, fmt.Errorf("line %d: validator %d listed more than once", line, validatorIndex)
+		}
+
+		address, err := parseExecutionAddress(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("line %d", line))
+		}
+		addressBook[validatorIndex] = address
+	}
+
+	if len(addressBook) == 0 {
+		return nil, errors.New("no validators listed")
+	}
+
+	return addressBook, nil
+}
+
+// checkAddressBookCoverage ensures that there is exactly one operation for each validator
+// in the address book, and that each operation sets the address listed for its validator.
+func (c *command) checkAddressBookCoverage(_ context.Context) error {
+	operations := make(map[phase0.ValidatorIndex]int)
+	for _, op := range c.signedOperations {
+		address, exists := c.addressBook[op.Message.ValidatorIndex]
+		if !exists {
+			return fmt.Errorf("operation for validator %d is not in the address book", op.Message.ValidatorIndex)
+		}
+		if op.Message.ToExecutionAddress != address {
+			return fmt.Errorf("operation for validator %d sets execution address %s rather than %s", op.Message.ValidatorIndex, addressBytesToEIP55(op.Message.ToExecutionAddress[:]), addressBytesToEIP55(address[:]))
+		}
+		operations[op.Message.ValidatorIndex]++
+		if operations[op.Message.ValidatorIndex] > 1 {
+			return fmt.Errorf("multiple operations for validator %d", op.Message.ValidatorIndex)
+		}
+	}
+
+	missing := make([]phase0.ValidatorIndex, 0)
+	for index := range c.addressBook {
+		if operations[index] == 0 {
+			missing = append(missing, index)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Slice(missing, func(i, j int) bool {
+			return missing[i] < missing[j]
+		})
+		indices := make([]string, len(missing))
+		for i := range missing {
+			indices[i] = fmt.Sprintf("%d", missing[i])
+		}
+		return fmt.Errorf("no operation generated for validators in the address book: %s", strings.Join(indices, ", "))
+	}
+
+	return nil
+}
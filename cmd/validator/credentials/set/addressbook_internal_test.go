@@ -0,0 +1,160 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialsset
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	capella "github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddressBook(t *testing.T) {
+	address1 := bellatrix.ExecutionAddress{0x8c, 0x1f, 0xf9, 0x78, 0x03, 0x6f, 0x2e, 0x9d, 0x7c, 0xc3, 0x82, 0xef, 0xf7, 0xb4, 0xc8, 0xc5, 0x3c, 0x22, 0xac, 0x15}
+	address2 := bellatrix.ExecutionAddress{0x8f, 0x08, 0x44, 0xfd, 0x51, 0xe3, 0x1f, 0xf6, 0xbf, 0x5b, 0xab, 0xe2, 0x1d, 0xcc, 0xf7, 0x32, 0x8e, 0x19, 0xfd, 0x9f}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected map[phase0.ValidatorIndex]bellatrix.ExecutionAddress
+		err      string
+	}{
+		{
+			name:  "Empty",
+			input: "",
+			err:   "no validators listed",
+		},
+		{
+			name:  "HeaderOnly",
+			input: "validator,address\n",
+			err:   "no validators listed",
+		},
+		{
+			name:  "IndexInvalid",
+			input: "1,0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15\nbad,0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F\n",
+			err:   `line 2: invalid validator index "bad"`,
+		},
+		{
+			name:  "AddressChecksumInvalid",
+			input: "1,0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15\n",
+			err:   "line 1: withdrawal address checksum does not match (expected 0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15)",
+		},
+		{
+			name:  "AddressShort",
+			input: "1,0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac\n",
+			err:   "line 1: withdrawal address must be exactly 20 bytes in length",
+		},
+		{
+			name:  "Duplicate",
+			input: "1,0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15\n1,0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F\n",
+			err:   "line 2: validator 1 listed more than once",
+		},
+		{
+			name:  "Good",
+			input: "1,0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15\n2,0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F\n",
+			expected: map[phase0.ValidatorIndex]bellatrix.ExecutionAddress{
+				1: address1,
+				2: address2,
+			},
+		},
+		{
+			name:  "GoodHeaderAndComments",
+			input: "validator,address\n# Staking pool A\n1, 0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15\n# Staking pool B\n2, 0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F\n",
+			expected: map[phase0.ValidatorIndex]bellatrix.ExecutionAddress{
+				1: address1,
+				2: address2,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseAddressBook(strings.NewReader(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
+
+func TestCheckAddressBookCoverage(t *testing.T) {
+	address1 := bellatrix.ExecutionAddress{0x01}
+	address2 := bellatrix.ExecutionAddress{0x02}
+	addressBook := map[phase0.ValidatorIndex]bellatrix.ExecutionAddress{
+		1: address1,
+		2: address2,
+		3: address2,
+	}
+	op := func(index phase0.ValidatorIndex, address bellatrix.ExecutionAddress) *capella.SignedBLSToExecutionChange {
+		return &capella.SignedBLSToExecutionChange{
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex:     index,
+				ToExecutionAddress: address,
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		operations []*capella.SignedBLSToExecutionChange
+		err        string
+	}{
+		{
+			name:       "Unlisted",
+			operations: []*capella.SignedBLSToExecutionChange{op(1, address1), op(2, address2), op(3, address2), op(4, address1)},
+			err:        "operation for validator 4 is not in the address book",
+		},
+		{
+			name:       "WrongAddress",
+			operations: []*capella.SignedBLSToExecutionChange{op(1, address2)},
+			err:        "operation for validator 1 sets execution address 0x0200000000000000000000000000000000000000 rather than 0x0100000000000000000000000000000000000000",
+		},
+		{
+			name:       "Duplicate",
+			operations: []*capella.SignedBLSToExecutionChange{op(1, address1), op(1, address1)},
+			err:        "multiple operations for validator 1",
+		},
+		{
+			name:       "Missing",
+			operations: []*capella.SignedBLSToExecutionChange{op(2, address2)},
+			err:        "no operation generated for validators in the address book: 1, 3",
+		},
+		{
+			name:       "Good",
+			operations: []*capella.SignedBLSToExecutionChange{op(3, address2), op(1, address1), op(2, address2)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				addressBook:      addressBook,
+				signedOperations: test.operations,
+			}
+			err := c.checkAddressBookCoverage(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
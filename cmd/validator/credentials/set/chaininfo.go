@@ -19,8 +19,10 @@ import (
 	"fmt"
 	"os"
 
+	consensusclient "github.com/attestantio/go-eth2-client"
 	"github.com/pkg/errors"
 	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/util"
 )
 
 // obtainChainInfo obtains the chain information required to create a withdrawal credentials change operation.
@@ -41,6 +43,12 @@ func (c *command) obtainChainInfo(ctx context.Context) error {
 		return err
 	}
 
+	if c.verifyLightClient {
+		if err := c.verifyChainInfo(ctx); err != nil {
+			return util.NewValidationError(err)
+		}
+	}
+
 	return nil
 }
 
@@ -48,27 +56,19 @@ func (c *command) obtainChainInfo(ctx context.Context) error {
 func (c *command) obtainChainInfoFromFile(_ context.Context) error {
 	_, err := os.Stat(offlinePreparationFilename)
 	if err != nil {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Failed to read offline preparation file: %v\n", err)
-		}
+		util.Log.Debug().Err(err).Msg("Failed to read offline preparation file")
 		return errors.Wrap(err, fmt.Sprintf("cannot find %s", offlinePreparationFilename))
 	}
 
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "%s found; loading chain state\n", offlinePreparationFilename)
-	}
+	util.Log.Debug().Str("file", offlinePreparationFilename).Msg("Loading chain state")
 	data, err := os.ReadFile(offlinePreparationFilename)
 	if err != nil {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "failed to load chain state: %v\n", err)
-		}
+		util.Log.Debug().Err(err).Msg("Failed to load chain state")
 		return errors.Wrap(err, "failed to read offline preparation file")
 	}
 	c.chainInfo = &beacon.ChainInfo{}
 	if err := json.Unmarshal(data, c.chainInfo); err != nil {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "chain state invalid: %v\n", err)
-		}
+		util.Log.Debug().Err(err).Msg("Chain state invalid")
 		return errors.Wrap(err, "failed to parse offline preparation file")
 	}
 
@@ -77,19 +77,108 @@ func (c *command) obtainChainInfoFromFile(_ context.Context) error {
 
 // obtainChainInfoFromNode obtains chain info from a beacon node.
 func (c *command) obtainChainInfoFromNode(ctx context.Context) error {
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Populating chain info from beacon node\n")
+	util.Log.Debug().Msg("Populating chain info from beacon node")
+
+	validators, err := c.chainInfoValidators(ctx)
+	if err != nil {
+		return err
+	}
+	if validators == nil {
+		// Information for all validators takes a while to obtain, so is checkpointed.
+		chainInfo := &beacon.ChainInfo{}
+		found, err := c.checkpoint.Load("chaininfo", chainInfo)
+		if err != nil {
+			return err
+		}
+		if found {
+			util.Log.Debug().Msg("Using chain info from checkpoint")
+			c.chainInfo = chainInfo
+			return nil
+		}
 	}
 
-	var err error
-	c.chainInfo, err = beacon.ObtainChainInfoFromNode(ctx, c.consensusClient, c.chainTime)
+	c.chainInfo, err = beacon.ObtainChainInfoFromNodeForValidators(ctx, c.consensusClient, c.chainTime, validators)
 	if err != nil {
 		return err
 	}
 
+	if validators == nil {
+		if err := c.checkpoint.Save("chaininfo", c.chainInfo); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// chainInfoValidators provides the validators for which chain information is
+// required, or nil if information for all validators is required.
+func (c *command) chainInfoValidators(ctx context.Context) ([]string, error) {
+	switch {
+	case len(c.offlineValidators) > 0:
+		return c.offlineValidators, nil
+	case c.prepareOffline && c.validator != "":
+		return []string{c.validator}, nil
+	case c.mnemonic != "" && c.path != "":
+		return accountPubKeys(ctx, c.mnemonic, c.path), nil
+	case c.mnemonic != "" && c.validator != "":
+		return []string{c.validator}, nil
+	case c.prepareOffline && c.mnemonic != "":
+		// Only the validators that a scan of the mnemonic would find are required.
+		return c.mnemonicValidators(ctx)
+	case c.mnemonic != "":
+		// Scanning the mnemonic requires all validators.
+		return nil, nil
+	case c.account != "" && (c.withdrawalAccount != "" || c.privateKey != ""):
+		return accountPubKeys(ctx, c.account, ""), nil
+	case c.validator != "" && c.privateKey != "":
+		return []string{c.validator}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// mnemonicValidators provides the public keys of the validators derived from
+// the mnemonic.
+func (c *command) mnemonicValidators(ctx context.Context) ([]string, error) {
+	seed, err := util.SeedFromMnemonic(c.mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := beacon.ObtainSeedValidators(ctx, c.consensusClient.(consensusclient.ValidatorsProvider), seed, maxDistance)
+	if err != nil {
+		return nil, err
+	}
+	if len(validators) == 0 {
+		return nil, errors.New("no validators found for mnemonic")
+	}
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "Found %d validators for mnemonic\n", len(validators))
+	}
+
+	return validators, nil
+}
+
+// accountPubKeys provides the public key of the account obtained from the
+// given input, or nil if it cannot be obtained.
+func accountPubKeys(ctx context.Context, input string, path string) []string {
+	var paths []string
+	if path != "" {
+		paths = []string{path}
+	}
+	account, err := util.ParseAccount(ctx, input, paths, false)
+	if err != nil {
+		// The error will be reported when the operation is generated.
+		return nil
+	}
+	pubKey, err := util.BestPublicKey(account)
+	if err != nil {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("%#x", pubKey.Marshal())}
+}
+
 // writeChainInfoToFile prepares for an offline run of this command by dumping
 // the chain information to a file.
 func (c *command) writeChainInfoToFile(_ context.Context) error {
@@ -103,3 +192,17 @@ func (c *command) writeChainInfoToFile(_ context.Context) error {
 
 	return nil
 }
+
+// verifyChainInfo verifies the chain information obtained from the beacon node
+// using light client data, starting from a trusted block root.
+func (c *command) verifyChainInfo(ctx context.Context) error {
+	slot, err := c.chainInfo.VerifyWithLightClient(ctx, c.consensusClient.Address(), c.timeout, c.chainTime, c.trustedBlockRoot)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify chain information with light client")
+	}
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "Chain information verified by light client to finalized slot %d\n", slot)
+	}
+
+	return nil
+}
@@ -15,6 +15,9 @@ package validatorcredentialsset
 
 import (
 	"context"
+	"encoding/hex"
+	"os"
+	"strings"
 	"time"
 
 	consensusclient "github.com/attestantio/go-eth2-client"
@@ -34,6 +37,7 @@ type command struct {
 	debug   bool
 	offline bool
 	json    bool
+	ssz     bool
 
 	// Input.
 	account               string
@@ -44,27 +48,44 @@ type command struct {
 	privateKey            string
 	validator             string
 	withdrawalAddressStr  string
+	addressBookFile       string
 	forkVersion           string
 	genesisValidatorsRoot string
+	network               *beacon.Network
 	prepareOffline        bool
+	offlineValidators     []string
 	signedOperationsInput string
+	allowContractAddress  bool
+	yes                   bool
+	provenance            bool
+	resume                bool
+	verifyLightClient     bool
+	trustedBlockRoot      phase0.Root
 
 	// Beacon node connection.
 	timeout                  time.Duration
 	connection               string
+	executionConnection      string
 	allowInsecureConnections bool
 
 	// Information required to generate the operations.
-	withdrawalAddress bellatrix.ExecutionAddress
-	chainInfo         *beacon.ChainInfo
-	domain            phase0.Domain
+	withdrawalAddress  bellatrix.ExecutionAddress
+	addressBook        map[phase0.ValidatorIndex]bellatrix.ExecutionAddress
+	chainInfo          *beacon.ChainInfo
+	domain             phase0.Domain
+	signingForkVersion phase0.Version
+	signingGenesisRoot phase0.Root
 
 	// Processing.
 	consensusClient consensusclient.Service
 	chainTime       chaintime.Service
+	prompter        *util.Prompter
+	operationsFile  string
+	checkpoint      *util.Checkpoint
 
 	// Output.
 	signedOperations []*capella.SignedBLSToExecutionChange
+	knownOperations  map[phase0.ValidatorIndex]*util.OperationStatus
 }
 
 func newCommand(_ context.Context) (*command, error) {
@@ -76,6 +97,7 @@ func newCommand(_ context.Context) (*command, error) {
 		json:                     viper.GetBool("json"),
 		timeout:                  viper.GetDuration("timeout"),
 		connection:               viper.GetString("connection"),
+		executionConnection:      viper.GetString("execution-connection"),
 		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
 		prepareOffline:           viper.GetBool("prepare-offline"),
 		account:                  viper.GetString("account"),
@@ -85,9 +107,16 @@ func newCommand(_ context.Context) (*command, error) {
 		path:                     viper.GetString("path"),
 		privateKey:               viper.GetString("private-key"),
 		signedOperationsInput:    viper.GetString("signed-operations"),
+		allowContractAddress:     viper.GetBool("allow-contract-address"),
+		yes:                      viper.GetBool("yes"),
+		provenance:               viper.GetBool("provenance"),
+		resume:                   viper.GetBool("resume"),
+		verifyLightClient:        viper.GetBool("verify-light-client"),
+		prompter:                 util.NewPrompter(os.Stdin, os.Stderr),
 
 		validator:             viper.GetString("validator"),
 		withdrawalAddressStr:  viper.GetString("withdrawal-address"),
+		addressBookFile:       viper.GetString("address-book"),
 		forkVersion:           viper.GetString("fork-version"),
 		genesisValidatorsRoot: viper.GetString("genesis-validators-root"),
 	}
@@ -97,12 +126,64 @@ func newCommand(_ context.Context) (*command, error) {
 		return nil, errors.New("timeout is required")
 	}
 
+	if viper.GetString("network") != "" {
+		var err error
+		c.network, err = beacon.NetworkByName(viper.GetString("network"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.verifyLightClient {
+		if c.offline {
+			return nil, errors.New("verify-light-client requires a connection to a beacon node")
+		}
+		if viper.GetString("trusted-block-root") == "" {
+			return nil, errors.New("trusted-block-root is required with verify-light-client")
+		}
+		root, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("trusted-block-root"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid trusted block root")
+		}
+		if len(root) != phase0.RootLength {
+			return nil, errors.New("trusted block root must be 32 bytes")
+		}
+		copy(c.trustedBlockRoot[:], root)
+	}
+
+	switch strings.ToLower(viper.GetString("output-format")) {
+	case "":
+	case "json":
+		c.json = true
+	case "ssz":
+		c.ssz = true
+	default:
+		return nil, errors.New("output format must be json or ssz")
+	}
+
+	// Validators to include in offline preparation.
+	c.offlineValidators = viper.GetStringSlice("validators")
+	if viper.GetString("pubkeys-file") != "" {
+		pubKeys, err := util.ReadPubKeysFile(viper.GetString("pubkeys-file"))
+		if err != nil {
+			return nil, err
+		}
+		c.offlineValidators = append(c.offlineValidators, pubKeys...)
+	}
+	if len(c.offlineValidators) > 0 && !c.prepareOffline {
+		return nil, errors.New("validators and pubkeys-file can only be supplied with prepare-offline")
+	}
+
 	// We are generating information for offline use, we don't need any information
 	// related to the accounts or signing.
 	if c.prepareOffline {
 		return c, nil
 	}
 
+	if c.addressBookFile != "" && c.withdrawalAddressStr != "" {
+		return nil, errors.New("only one of withdrawal-address and address-book can be supplied")
+	}
+
 	if c.withdrawalAccount != "" && len(c.passphrases) == 0 {
 		return nil, errors.New("passphrase required with withdrawal-account")
 	}
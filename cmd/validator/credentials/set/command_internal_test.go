@@ -55,6 +55,85 @@ func TestInput(t *testing.T) {
 			},
 			err: "only one of account, index and pubkey allowed",
 		},
+		{
+			name: "OutputFormatInvalid",
+			vars: map[string]interface{}{
+				"timeout":       "5s",
+				"connection":    os.Getenv("ETHDO_TEST_CONNECTION"),
+				"index":         "1",
+				"output-format": "yaml",
+			},
+			err: "output format must be json or ssz",
+		},
+		{
+			name: "AddressBookAndWithdrawalAddress",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"connection":         os.Getenv("ETHDO_TEST_CONNECTION"),
+				"index":              "1",
+				"address-book":       "addresses.csv",
+				"withdrawal-address": "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+			},
+			err: "only one of withdrawal-address and address-book can be supplied",
+		},
+		{
+			name: "VerifyLightClientOffline",
+			vars: map[string]interface{}{
+				"timeout":             "5s",
+				"offline":             true,
+				"verify-light-client": true,
+				"trusted-block-root":  "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20",
+			},
+			err: "verify-light-client requires a connection to a beacon node",
+		},
+		{
+			name: "TrustedBlockRootMissing",
+			vars: map[string]interface{}{
+				"timeout":             "5s",
+				"connection":          os.Getenv("ETHDO_TEST_CONNECTION"),
+				"verify-light-client": true,
+			},
+			err: "trusted-block-root is required with verify-light-client",
+		},
+		{
+			name: "TrustedBlockRootShort",
+			vars: map[string]interface{}{
+				"timeout":             "5s",
+				"connection":          os.Getenv("ETHDO_TEST_CONNECTION"),
+				"verify-light-client": true,
+				"trusted-block-root":  "0x0102",
+			},
+			err: "trusted block root must be 32 bytes",
+		},
+		{
+			name: "ValidatorsWithoutPrepareOffline",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"index":      "1",
+				"validators": []string{"1", "2"},
+			},
+			err: "validators and pubkeys-file can only be supplied with prepare-offline",
+		},
+		{
+			name: "PubKeysFileMissing",
+			vars: map[string]interface{}{
+				"timeout":         "5s",
+				"connection":      os.Getenv("ETHDO_TEST_CONNECTION"),
+				"prepare-offline": true,
+				"pubkeys-file":    "/nonexistent/pubkeys.txt",
+			},
+			err: "failed to read public keys file: open /nonexistent/pubkeys.txt: no such file or directory",
+		},
+		{
+			name: "PrepareOfflineValidators",
+			vars: map[string]interface{}{
+				"timeout":         "5s",
+				"connection":      os.Getenv("ETHDO_TEST_CONNECTION"),
+				"prepare-offline": true,
+				"validators":      []string{"1", "2"},
+			},
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
@@ -0,0 +1,92 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialsset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	capella "github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckWithdrawalAddresses(t *testing.T) {
+	eoaAddress := bellatrix.ExecutionAddress{0x8c, 0x1f, 0xf9, 0x78, 0x03, 0x6f, 0x2e, 0x9d, 0x7c, 0xc3, 0x82, 0xef, 0xf7, 0xb4, 0xc8, 0xc5, 0x3c, 0x22, 0xac, 0x15}
+	contractAddress := bellatrix.ExecutionAddress{0x8f, 0x08, 0x44, 0xfd, 0x51, 0xe3, 0x1f, 0xf6, 0xbf, 0x5b, 0xab, 0xe2, 0x1d, 0xcc, 0xf7, 0x32, 0x8e, 0x19, 0xfd, 0x9f}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			ID     uint64        `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch {
+		case req.Method == "eth_chainId":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":"0x1"}`, req.ID)
+		case req.Method == "eth_getCode" && req.Params[0] == fmt.Sprintf("%#x", contractAddress):
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":"0x6080"}`, req.ID)
+		case req.Method == "eth_getCode":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":"0x"}`, req.ID)
+		}
+	}))
+	defer server.Close()
+
+	op := func(address bellatrix.ExecutionAddress) *capella.SignedBLSToExecutionChange {
+		return &capella.SignedBLSToExecutionChange{
+			Message: &capella.BLSToExecutionChange{
+				ToExecutionAddress: address,
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		operations []*capella.SignedBLSToExecutionChange
+		err        string
+	}{
+		{
+			name:       "Contract",
+			operations: []*capella.SignedBLSToExecutionChange{op(eoaAddress), op(contractAddress)},
+			err:        "withdrawal address 0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F is a contract; use --allow-contract-address if this is intended",
+		},
+		{
+			name:       "Good",
+			operations: []*capella.SignedBLSToExecutionChange{op(eoaAddress), op(eoaAddress)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				timeout:                  time.Second,
+				executionConnection:      server.URL,
+				allowInsecureConnections: true,
+				signedOperations:         test.operations,
+			}
+			err := c.checkWithdrawalAddresses(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -18,8 +18,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
 )
 
 //nolint:unparam
@@ -32,11 +36,33 @@ func (c *command) output(_ context.Context) (string, error) {
 		return fmt.Sprintf("%s generated", offlinePreparationFilename), nil
 	}
 
+	if c.ssz {
+		// Operations are fixed size, so the SSZ encoding of the list is their concatenation.
+		data := make([]byte, 0)
+		for _, signedOperation := range c.signedOperations {
+			operationData, err := signedOperation.MarshalSSZ()
+			if err != nil {
+				return "", errors.Wrap(err, "failed to marshal signed operation")
+			}
+			data = append(data, operationData...)
+		}
+		if err := os.WriteFile(changeOperationsSSZFilename, data, 0600); err != nil {
+			return "", errors.Wrap(err, fmt.Sprintf("failed to write %s", changeOperationsSSZFilename))
+		}
+		if err := c.writeProvenance(changeOperationsSSZFilename); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%#x", data), nil
+	}
+
 	if c.json || c.offline {
 		data, err := json.Marshal(c.signedOperations)
 		if err != nil {
 			return "", errors.Wrap(err, "failed to marshal signed operations")
 		}
+		if err := c.writeProvenance(changeOperationsFilename); err != nil {
+			return "", err
+		}
 		if c.json {
 			return string(data), nil
 		}
@@ -46,5 +72,34 @@ func (c *command) output(_ context.Context) (string, error) {
 		return "", nil
 	}
 
-	return "", nil
+	return c.knownOperationsOutput(), nil
+}
+
+// knownOperationsOutput describes the operations that were not broadcast
+// because they are already known.
+func (c *command) knownOperationsOutput() string {
+	indices := make([]phase0.ValidatorIndex, 0, len(c.knownOperations))
+	for index := range c.knownOperations {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	lines := make([]string, 0, len(indices))
+	for _, index := range indices {
+		lines = append(lines, fmt.Sprintf("Credentials change operation for validator %d %s; not broadcasting", index, c.knownOperations[index]))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// writeProvenance writes provenance for newly-generated operations alongside
+// the given operations file, if requested.
+func (c *command) writeProvenance(filename string) error {
+	if !c.provenance || c.operationsFile != "" {
+		return nil
+	}
+
+	return util.WriteProvenance(filename, util.NewProvenance(c.signingForkVersion, c.signingGenesisRoot))
 }
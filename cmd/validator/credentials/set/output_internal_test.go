@@ -0,0 +1,44 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialsset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestOutputKnownOperations(t *testing.T) {
+	c := &command{
+		knownOperations: map[phase0.ValidatorIndex]*util.OperationStatus{
+			12: {},
+			3: {
+				Included: true,
+				Slot:     12345,
+			},
+		},
+	}
+
+	res, err := c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Credentials change operation for validator 3 already included in block at slot 12345; not broadcasting\nCredentials change operation for validator 12 already in node's pool; not broadcasting", res)
+
+	c.knownOperations = nil
+	res, err = c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "", res)
+}
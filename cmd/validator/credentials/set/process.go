@@ -29,8 +29,8 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/ops"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
-	"github.com/wealdtech/ethdo/signing"
 	"github.com/wealdtech/ethdo/util"
 	ethutil "github.com/wealdtech/go-eth2-util"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
@@ -39,14 +39,36 @@ import (
 // validatorPath is the regular expression that matches a validator  path.
 var validatorPath = regexp.MustCompile("^m/12381/3600/[0-9]+/0/0$")
 
+// maxDistance is the number of consecutive indices of a mnemonic without a
+// validator after which the mnemonic is not scanned any further.
+const maxDistance = 1024
+
 var offlinePreparationFilename = "offline-preparation.json"
 var changeOperationsFilename = "change-operations.json"
+var changeOperationsSSZFilename = "change-operations.ssz"
+var checkpointFilename = "credentials-set-checkpoint.json"
+
+// checkpointInterval is the number of keys scanned between checkpoints.
+var checkpointInterval = 100
+
+// scanCheckpoint is the checkpoint of a scan of the keys of a mnemonic.
+type scanCheckpoint struct {
+	NextIndex      int                                   `json:"next_index"`
+	LastFoundIndex int                                   `json:"last_found_index"`
+	Operations     []*capella.SignedBLSToExecutionChange `json:"operations"`
+}
 
 func (c *command) process(ctx context.Context) error {
 	if err := c.setup(ctx); err != nil {
 		return err
 	}
 
+	var err error
+	c.checkpoint, err = util.NewCheckpoint(checkpointFilename, c.checkpointParameters(), c.resume)
+	if err != nil {
+		return err
+	}
+
 	if err := c.obtainChainInfo(ctx); err != nil {
 		return err
 	}
@@ -55,27 +77,72 @@ func (c *command) process(ctx context.Context) error {
 		return c.writeChainInfoToFile(ctx)
 	}
 
+	if c.network != nil {
+		if err := c.chainInfo.ApplyNetwork(c.network); err != nil {
+			return err
+		}
+		util.Log.Debug().Str("network", c.network.Name).Msg("Using network parameters")
+	}
+
 	if err := c.generateDomain(ctx); err != nil {
 		return err
 	}
 
+	if c.addressBookFile != "" {
+		if err := c.loadAddressBook(ctx); err != nil {
+			return err
+		}
+	}
+
 	if err := c.obtainOperations(ctx); err != nil {
 		return err
 	}
 
-	if validated, reason := c.validateOperations(ctx); !validated {
-		return fmt.Errorf("operation failed validation: %s", reason)
+	if !c.json && !c.ssz && !c.offline {
+		c.removeKnownOperations(ctx)
+		if len(c.signedOperations) == 0 {
+			// All operations are already known, so there is nothing to broadcast.
+			return nil
+		}
 	}
 
-	if c.json || c.offline {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Not broadcasting credentials change operations\n")
+	if c.addressBook != nil {
+		if err := c.checkAddressBookCoverage(ctx); err != nil {
+			return util.NewValidationError(err)
 		}
-		// Want JSON output, or cannot broadcast.
+	}
+
+	if c.executionConnection != "" && !c.allowContractAddress {
+		if err := c.checkWithdrawalAddresses(ctx); err != nil {
+			return util.NewValidationError(err)
+		}
+	}
+
+	if validated, reason := c.validateOperations(ctx); !validated {
+		return util.NewValidationError(fmt.Errorf("operation failed validation: %s", reason))
+	}
+
+	if c.json || c.ssz || c.offline {
+		util.Log.Debug().Msg("Not broadcasting credentials change operations")
+		// Want JSON or SSZ output, or cannot broadcast.
 		return nil
 	}
 
-	return c.broadcastOperations(ctx)
+	if c.operationsFile != "" {
+		if err := util.CheckProvenance(ctx, c.consensusClient, c.operationsFile); err != nil {
+			return util.NewValidationError(err)
+		}
+	}
+
+	if err := c.confirmOperations(ctx); err != nil {
+		return err
+	}
+
+	if err := c.broadcastOperations(ctx); err != nil {
+		return util.NewBroadcastError(errors.Wrap(err, "node rejected operation"))
+	}
+
+	return nil
 }
 
 func (c *command) obtainOperations(ctx context.Context) error {
@@ -166,14 +233,11 @@ func (c *command) generateOperationFromMnemonicAndValidator(ctx context.Context)
 	}
 
 	// Scan the keys from the seed to find the path.
-	maxDistance := 1024
 	// Start scanning the validator keys.
 	var withdrawalAccount e2wtypes.Account
 	for i := 0; ; i++ {
 		if i == maxDistance {
-			if c.debug {
-				fmt.Fprintf(os.Stderr, "Gone %d indices without finding the validator, not scanning any further\n", maxDistance)
-			}
+			util.Log.Debug().Int("distance", maxDistance).Msg("Validator not found, not scanning any further")
 			break
 		}
 		validatorKeyPath := fmt.Sprintf("m/12381/3600/%d/0/0", i)
@@ -217,18 +281,47 @@ func (c *command) generateOperationsFromMnemonic(ctx context.Context) error {
 		validators[fmt.Sprintf("%#x", validator.Pubkey)] = validator
 	}
 
-	maxDistance := 1024
+	// Carry on from a previous scan if one was checkpointed.
+	scan := &scanCheckpoint{}
+	resumed, err := c.checkpoint.Load("scan", scan)
+	if err != nil {
+		return err
+	}
+	if resumed {
+		if c.verbose {
+			fmt.Fprintf(os.Stderr, "Resuming scan at index %d with %d operations\n", scan.NextIndex, len(scan.Operations))
+		}
+		c.signedOperations = append(c.signedOperations, scan.Operations...)
+	}
+
+	// The number of operations is only known in advance if there is an address book.
+	total := 0
+	if c.addressBook != nil {
+		total = len(c.addressBook)
+	}
+	progress := util.NewProgress("Generating operations", total)
+	defer progress.Finish()
+	progress.Add(len(c.signedOperations))
+
 	// Start scanning the validator keys.
-	lastFoundIndex := 0
-	for i := 0; ; i++ {
+	lastFoundIndex := scan.LastFoundIndex
+	for i := scan.NextIndex; ; i++ {
 		if i-lastFoundIndex > maxDistance {
-			if c.debug {
-				fmt.Fprintf(os.Stderr, "Gone %d indices without finding a validator, not scanning any further\n", maxDistance)
-			}
+			util.Log.Debug().Int("distance", maxDistance).Msg("No validators found, not scanning any further")
 			break
 		}
+		if i > scan.NextIndex && i%checkpointInterval == 0 {
+			if err := c.checkpoint.Save("scan", &scanCheckpoint{
+				NextIndex:      i,
+				LastFoundIndex: lastFoundIndex,
+				Operations:     c.signedOperations,
+			}); err != nil {
+				return err
+			}
+		}
 		validatorKeyPath := fmt.Sprintf("m/12381/3600/%d/0/0", i)
 
+		operations := len(c.signedOperations)
 		found, err := c.generateOperationFromSeedAndPath(ctx, validators, seed, validatorKeyPath)
 		if err != nil {
 			return errors.Wrap(err, "failed to generate operation from seed and path")
@@ -236,6 +329,7 @@ func (c *command) generateOperationsFromMnemonic(ctx context.Context) error {
 		if found {
 			lastFoundIndex = i
 		}
+		progress.Add(len(c.signedOperations) - operations)
 	}
 	return nil
 }
@@ -326,9 +420,7 @@ func (c *command) obtainOperationsFromFile(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to read change operations file")
 	}
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "%s found; loading operations\n", changeOperationsFilename)
-	}
+	util.Log.Debug().Str("file", changeOperationsFilename).Msg("Loading operations")
 	data, err := os.ReadFile(changeOperationsFilename)
 	if err != nil {
 		return errors.Wrap(err, "failed to read change operations file")
@@ -336,6 +428,7 @@ func (c *command) obtainOperationsFromFile(ctx context.Context) error {
 	if err := json.Unmarshal(data, &c.signedOperations); err != nil {
 		return errors.Wrap(err, "failed to parse change operations file")
 	}
+	c.operationsFile = changeOperationsFilename
 
 	for _, op := range c.signedOperations {
 		if err := c.verifyOperation(ctx, op); err != nil {
@@ -358,6 +451,7 @@ func (c *command) obtainOperationsFromInput(ctx context.Context) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to read input file")
 		}
+		c.operationsFile = c.signedOperationsInput
 		c.signedOperationsInput = string(data)
 	}
 
@@ -388,9 +482,7 @@ func (c *command) generateOperationFromSeedAndPath(ctx context.Context,
 	validatorPubkey := fmt.Sprintf("%#x", validatorPrivkey.PublicKey().Marshal())
 	validator, exists := validators[validatorPubkey]
 	if !exists {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "No validator found with public key %s at path %s\n", validatorPubkey, path)
-		}
+		util.Log.Debug().Str("pubkey", validatorPubkey).Str("path", path).Msg("No validator found")
 		return false, nil
 	}
 
@@ -399,9 +491,7 @@ func (c *command) generateOperationFromSeedAndPath(ctx context.Context,
 	}
 
 	if validator.WithdrawalCredentials[0] != byte(0) {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Validator %s has non-BLS withdrawal credentials %#x\n", validatorPubkey, validator.WithdrawalCredentials)
-		}
+		util.Log.Debug().Str("pubkey", validatorPubkey).Str("withdrawal_credentials", fmt.Sprintf("%#x", validator.WithdrawalCredentials)).Msg("Validator has non-BLS withdrawal credentials")
 		return false, nil
 	}
 
@@ -437,9 +527,7 @@ func (c *command) generateOperationFromSeedAndPath(ctx context.Context,
 		return false, nil
 	}
 
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Validator %s eligible for setting credentials\n", validatorPubkey)
-	}
+	util.Log.Debug().Str("pubkey", validatorPubkey).Msg("Validator eligible for setting credentials")
 
 	err = c.generateOperationFromAccount(ctx, validator, withdrawalAccount)
 	if err != nil {
@@ -453,6 +541,15 @@ func (c *command) generateOperationFromAccount(ctx context.Context,
 	validator *beacon.ValidatorInfo,
 	withdrawalAccount e2wtypes.Account,
 ) error {
+	if c.addressBook != nil {
+		if _, exists := c.addressBook[validator.Index]; !exists {
+			if c.verbose {
+				fmt.Fprintf(os.Stderr, "Validator %d is not in the address book, skipping\n", validator.Index)
+			}
+			return nil
+		}
+	}
+
 	signedOperation, err := c.createSignedOperation(ctx, validator, withdrawalAccount)
 	if err != nil {
 		return err
@@ -468,59 +565,75 @@ func (c *command) createSignedOperation(ctx context.Context,
 	*capella.SignedBLSToExecutionChange,
 	error,
 ) {
-	pubkey, err := util.BestPublicKey(withdrawalAccount)
-	if err != nil {
-		return nil, err
-	}
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Using %#x as best public key for %s\n", pubkey.Marshal(), withdrawalAccount.Name())
+	withdrawalAddress, exists := c.addressBook[validator.Index]
+	if !exists {
+		if err := c.parseWithdrawalAddress(ctx); err != nil {
+			return nil, errors.Wrap(err, "invalid withdrawal address")
+		}
+		withdrawalAddress = c.withdrawalAddress
 	}
-	blsPubkey := phase0.BLSPubKey{}
-	copy(blsPubkey[:], pubkey.Marshal())
 
-	if err := c.parseWithdrawalAddress(ctx); err != nil {
-		return nil, errors.Wrap(err, "invalid withdrawal address")
-	}
+	util.Log.Debug().Str("account", withdrawalAccount.Name()).Uint64("validator", uint64(validator.Index)).Str("domain", fmt.Sprintf("%#x", c.domain)).Msg("Signing credentials change operation")
 
-	operation := &capella.BLSToExecutionChange{
-		ValidatorIndex:     validator.Index,
-		FromBLSPubkey:      blsPubkey,
-		ToExecutionAddress: c.withdrawalAddress,
-	}
-	root, err := operation.HashTreeRoot()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to generate root for credentials change operation")
-	}
+	return ops.BuildBLSToExecutionChange(ctx, withdrawalAccount, nil, validator.Index, withdrawalAddress, c.domain)
+}
 
-	// Sign the operation.
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Signing %#x with domain %#x by public key %#x\n", root, c.domain, withdrawalAccount.PublicKey().Marshal())
-	}
-	signature, err := signing.SignRoot(ctx, withdrawalAccount, nil, root, c.domain)
+func (c *command) parseWithdrawalAddress(_ context.Context) error {
+	withdrawalAddress, err := parseExecutionAddress(c.withdrawalAddressStr)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to sign credentials change operation")
+		return err
 	}
+	c.withdrawalAddress = withdrawalAddress
 
-	return &capella.SignedBLSToExecutionChange{
-		Message:   operation,
-		Signature: signature,
-	}, nil
+	return nil
 }
 
-func (c *command) parseWithdrawalAddress(_ context.Context) error {
-	withdrawalAddressBytes, err := hex.DecodeString(strings.TrimPrefix(c.withdrawalAddressStr, "0x"))
+// parseExecutionAddress parses an EIP-55 checksummed execution address.
+func parseExecutionAddress(input string) (bellatrix.ExecutionAddress, error) {
+	address := bellatrix.ExecutionAddress{}
+
+	addressBytes, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
 	if err != nil {
-		return errors.Wrap(err, "failed to obtain execution address")
+		return address, errors.Wrap(err, "failed to obtain execution address")
 	}
-	if len(withdrawalAddressBytes) != bellatrix.ExecutionAddressLength {
-		return errors.New("withdrawal address must be exactly 20 bytes in length")
+	if len(addressBytes) != bellatrix.ExecutionAddressLength {
+		return address, errors.New("withdrawal address must be exactly 20 bytes in length")
 	}
 	// Ensure the address is properly checksummed.
-	checksummedAddress := addressBytesToEIP55(withdrawalAddressBytes)
-	if checksummedAddress != c.withdrawalAddressStr {
-		return fmt.Errorf("withdrawal address checksum does not match (expected %s)", checksummedAddress)
+	checksummedAddress := addressBytesToEIP55(addressBytes)
+	if checksummedAddress != input {
+		return address, fmt.Errorf("withdrawal address checksum does not match (expected %s)", checksummedAddress)
+	}
+	copy(address[:], addressBytes)
+
+	return address, nil
+}
+
+// checkWithdrawalAddresses ensures that the withdrawal addresses of the operations are not
+// contracts, as a contract may be unable to access the funds it receives from withdrawals.
+func (c *command) checkWithdrawalAddresses(ctx context.Context) error {
+	executionClient, err := util.ConnectToExecutionNode(ctx, c.executionConnection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to execution node")
+	}
+
+	checked := make(map[bellatrix.ExecutionAddress]bool)
+	for _, op := range c.signedOperations {
+		address := op.Message.ToExecutionAddress
+		if checked[address] {
+			continue
+		}
+		checked[address] = true
+
+		code, err := executionClient.Code(ctx, address[:])
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain code for withdrawal address")
+		}
+		if len(code) > 0 {
+			return fmt.Errorf("withdrawal address %s is a contract; use --allow-contract-address if this is intended", addressBytesToEIP55(address[:]))
+		}
+		util.Log.Debug().Str("address", addressBytesToEIP55(address[:])).Msg("Withdrawal address is not a contract")
 	}
-	copy(c.withdrawalAddress[:], withdrawalAddressBytes)
 
 	return nil
 }
@@ -546,10 +659,8 @@ func (c *command) validateOperation(_ context.Context,
 	if !exists {
 		return false, "validator not known on chain"
 	}
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Credentials change operation: %v", signedOperation)
-		fmt.Fprintf(os.Stderr, "On-chain validator info: %v\n", validator)
-	}
+	util.Log.Debug().Interface("operation", signedOperation).Msg("Generated credentials change operation")
+	util.Log.Debug().Interface("validator", validator).Msg("Obtained on-chain validator info")
 
 	if validator.WithdrawalCredentials[0] != byte(0) {
 		return false, "validator is not using BLS withdrawal credentials"
@@ -558,19 +669,94 @@ func (c *command) validateOperation(_ context.Context,
 	withdrawalCredentials := ethutil.SHA256(signedOperation.Message.FromBLSPubkey[:])
 	withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
 	if !bytes.Equal(withdrawalCredentials, validator.WithdrawalCredentials) {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "validator withdrawal credentials %#x do not match calculated operation withdrawal credentials %#x\n", validator.WithdrawalCredentials, withdrawalCredentials)
-		}
+		util.Log.Debug().Str("validator_credentials", fmt.Sprintf("%#x", validator.WithdrawalCredentials)).Str("operation_credentials", fmt.Sprintf("%#x", withdrawalCredentials)).Msg("Validator withdrawal credentials do not match calculated operation withdrawal credentials")
 		return false, "validator withdrawal credentials do not match those in the operation"
 	}
 
 	return true, ""
 }
 
+// confirmOperations summarises the credentials changes and requires the user
+// to confirm them before they are broadcast, as they cannot be undone.
+func (c *command) confirmOperations(ctx context.Context) error {
+	if c.yes {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "About to broadcast %d credentials change operation(s); once accepted by the network these cannot be undone.\n", len(c.signedOperations))
+	for _, op := range c.signedOperations {
+		index := fmt.Sprintf("%d", op.Message.ValidatorIndex)
+		validatorInfo, err := c.chainInfo.FetchValidatorInfo(ctx, index)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Validator index: %s\n", index)
+		fmt.Fprintf(os.Stderr, "  Validator public key: %#x\n", validatorInfo.Pubkey)
+		fmt.Fprintf(os.Stderr, "  Withdrawal address: %s\n", addressBytesToEIP55(op.Message.ToExecutionAddress[:]))
+	}
+
+	// A single operation is confirmed by its validator index, multiple
+	// operations by their count.
+	question := "Type the validator index to confirm"
+	expected := fmt.Sprintf("%d", c.signedOperations[0].Message.ValidatorIndex)
+	if len(c.signedOperations) > 1 {
+		question = "Type the number of operations to confirm"
+		expected = fmt.Sprintf("%d", len(c.signedOperations))
+	}
+	confirmed, err := c.prompter.ConfirmTyped(question, expected)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return errors.New("credentials change not confirmed; not broadcasting")
+	}
+
+	return nil
+}
+
+// removeKnownOperations removes operations that are already in the node's
+// pool or included in a recent block, recording them as known.  Failure to
+// check is not fatal, as the operations can still be broadcast.
+func (c *command) removeKnownOperations(ctx context.Context) {
+	statuses, err := util.BLSToExecutionChangeStatuses(ctx, c.consensusClient, c.timeout, c.signedOperations)
+	if err != nil {
+		util.Log.Debug().Err(err).Msg("Failed to check if credentials change operations are already known")
+		return
+	}
+
+	c.knownOperations = make(map[phase0.ValidatorIndex]*util.OperationStatus)
+	unknown := make([]*capella.SignedBLSToExecutionChange, 0, len(c.signedOperations))
+	for i, status := range statuses {
+		if status == nil {
+			unknown = append(unknown, c.signedOperations[i])
+			continue
+		}
+		c.knownOperations[c.signedOperations[i].Message.ValidatorIndex] = status
+	}
+	c.signedOperations = unknown
+}
+
 func (c *command) broadcastOperations(ctx context.Context) error {
 	return c.consensusClient.(consensusclient.BLSToExecutionChangesSubmitter).SubmitBLSToExecutionChanges(ctx, c.signedOperations)
 }
 
+// checkpointParameters provides the parameters that identify the operations
+// generated by the command, so that a checkpoint is only resumed by a command
+// generating the same operations.  Secrets are not included.
+func (c *command) checkpointParameters() string {
+	return strings.Join([]string{
+		c.account,
+		c.withdrawalAccount,
+		c.path,
+		c.validator,
+		c.withdrawalAddressStr,
+		c.addressBookFile,
+		c.forkVersion,
+		c.genesisValidatorsRoot,
+		fmt.Sprintf("%t", c.prepareOffline),
+	}, ",")
+}
+
 func (c *command) setup(ctx context.Context) error {
 	if c.offline {
 		return nil
@@ -587,6 +773,7 @@ func (c *command) setup(ctx context.Context) error {
 	c.chainTime, err = standardchaintime.New(ctx,
 		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
 		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.consensusClient.(consensusclient.ForkScheduleProvider)),
 	)
 	if err != nil {
 		return errors.Wrap(err, "failed to create chaintime service")
@@ -605,19 +792,13 @@ func (c *command) generateDomain(ctx context.Context) error {
 		return err
 	}
 
-	root, err := (&phase0.ForkData{
-		CurrentVersion:        forkVersion,
-		GenesisValidatorsRoot: genesisValidatorsRoot,
-	}).HashTreeRoot()
+	c.domain, err = ops.ComputeDomain(c.chainInfo.BLSToExecutionChangeDomainType, forkVersion, genesisValidatorsRoot)
 	if err != nil {
-		return errors.Wrap(err, "failed to calculate signature domain")
-	}
-
-	copy(c.domain[:], c.chainInfo.BLSToExecutionChangeDomainType[:])
-	copy(c.domain[4:], root[:])
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Domain is %#x\n", c.domain)
+		return err
 	}
+	c.signingForkVersion = forkVersion
+	c.signingGenesisRoot = genesisValidatorsRoot
+	util.Log.Debug().Str("domain", fmt.Sprintf("%#x", c.domain)).Msg("Obtained domain")
 
 	return nil
 }
@@ -626,9 +807,7 @@ func (c *command) obtainGenesisValidatorsRoot(ctx context.Context) (phase0.Root,
 	genesisValidatorsRoot := phase0.Root{}
 
 	if c.genesisValidatorsRoot != "" {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Genesis validators root supplied on the command line\n")
-		}
+		util.Log.Debug().Msg("Genesis validators root supplied on the command line")
 		root, err := hex.DecodeString(strings.TrimPrefix(c.genesisValidatorsRoot, "0x"))
 		if err != nil {
 			return phase0.Root{}, errors.Wrap(err, "invalid genesis validators root supplied")
@@ -638,15 +817,11 @@ func (c *command) obtainGenesisValidatorsRoot(ctx context.Context) (phase0.Root,
 		}
 		copy(genesisValidatorsRoot[:], root)
 	} else {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Genesis validators root obtained from chain info\n")
-		}
+		util.Log.Debug().Msg("Genesis validators root obtained from chain info")
 		copy(genesisValidatorsRoot[:], c.chainInfo.GenesisValidatorsRoot[:])
 	}
 
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Using genesis validators root %#x\n", genesisValidatorsRoot)
-	}
+	util.Log.Debug().Str("genesis_validators_root", fmt.Sprintf("%#x", genesisValidatorsRoot)).Msg("Using genesis validators root")
 	return genesisValidatorsRoot, nil
 }
 
@@ -654,9 +829,7 @@ func (c *command) obtainForkVersion(ctx context.Context) (phase0.Version, error)
 	forkVersion := phase0.Version{}
 
 	if c.forkVersion != "" {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Fork version supplied on the command line\n")
-		}
+		util.Log.Debug().Msg("Fork version supplied on the command line")
 		version, err := hex.DecodeString(strings.TrimPrefix(c.forkVersion, "0x"))
 		if err != nil {
 			return phase0.Version{}, errors.Wrap(err, "invalid fork version supplied")
@@ -666,16 +839,12 @@ func (c *command) obtainForkVersion(ctx context.Context) (phase0.Version, error)
 		}
 		copy(forkVersion[:], version)
 	} else {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Fork version obtained from chain info\n")
-		}
+		util.Log.Debug().Msg("Fork version obtained from chain info")
 		// Use the genesis fork version for setting credentials as per the spec.
 		copy(forkVersion[:], c.chainInfo.GenesisForkVersion[:])
 	}
 
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Using fork version %#x\n", forkVersion)
-	}
+	util.Log.Debug().Str("fork_version", fmt.Sprintf("%#x", forkVersion)).Msg("Using fork version")
 	return forkVersion, nil
 }
 
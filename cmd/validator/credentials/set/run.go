@@ -19,6 +19,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 )
 
 // Run runs the command.
@@ -27,7 +28,7 @@ func Run(cmd *cobra.Command) (string, error) {
 
 	c, err := newCommand(ctx)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to set up command")
+		return "", util.NewValidationError(errors.Wrap(err, "failed to set up command"))
 	}
 
 	// Further errors do not need a usage report.
@@ -37,6 +38,11 @@ func Run(cmd *cobra.Command) (string, error) {
 		return "", errors.Wrap(err, "failed to process")
 	}
 
+	// The command has completed, so its checkpoint is no longer required.
+	if err := c.checkpoint.Remove(); err != nil {
+		return "", err
+	}
+
 	if viper.GetBool("quiet") {
 		return "", nil
 	}
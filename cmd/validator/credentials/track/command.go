@@ -0,0 +1,155 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialstrack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	file     string
+	fromSlot *phase0.Slot
+	webhooks []string
+	json     bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	consensusClient    eth2client.Service
+	chainTime          chaintime.Service
+	blocksProvider     eth2client.SignedBeaconBlockProvider
+	validatorsProvider eth2client.ValidatorsProvider
+	httpClient         *http.Client
+
+	// Processing.
+	operations map[phase0.ValidatorIndex]*trackedOperation
+
+	// Output.
+	tracked []*trackedOperation
+	alerts  []*alert
+}
+
+const (
+	statusPending     = "pending"
+	statusIncluded    = "included"
+	statusConflicting = "conflicting"
+)
+
+// trackedOperation is the state of a submitted credentials change operation.
+type trackedOperation struct {
+	ValidatorIndex  phase0.ValidatorIndex `json:"validator_index"`
+	ExpectedAddress string                `json:"expected_address"`
+	Status          string                `json:"status"`
+	Slot            *phase0.Slot          `json:"slot,omitempty"`
+	ActualAddress   string                `json:"actual_address,omitempty"`
+
+	operation *capella.SignedBLSToExecutionChange
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:      viper.GetBool("quiet"),
+		verbose:    viper.GetBool("verbose"),
+		debug:      viper.GetBool("debug"),
+		operations: make(map[phase0.ValidatorIndex]*trackedOperation),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+	c.json = viper.GetBool("json")
+	c.httpClient = &http.Client{
+		Timeout: c.timeout,
+	}
+
+	if viper.GetString("from-slot") != "" {
+		slot, err := strconv.ParseUint(viper.GetString("from-slot"), 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid from slot")
+		}
+		fromSlot := phase0.Slot(slot)
+		c.fromSlot = &fromSlot
+	}
+
+	c.webhooks = viper.GetStringSlice("webhooks")
+	for _, webhook := range c.webhooks {
+		webhookURL, err := url.Parse(webhook)
+		if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+			return nil, fmt.Errorf("invalid webhook %s", webhook)
+		}
+	}
+
+	c.file = viper.GetString("file")
+	if c.file == "" {
+		return nil, errors.New("file is required")
+	}
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read operations file")
+	}
+	operations := make([]*capella.SignedBLSToExecutionChange, 0)
+	if err := json.Unmarshal(data, &operations); err != nil {
+		return nil, errors.Wrap(err, "failed to parse operations file")
+	}
+	if len(operations) == 0 {
+		return nil, errors.New("no operations in file")
+	}
+	c.tracked = make([]*trackedOperation, 0, len(operations))
+	for _, operation := range operations {
+		if operation == nil || operation.Message == nil {
+			return nil, errors.New("operations file contains an empty operation")
+		}
+		index := operation.Message.ValidatorIndex
+		if _, exists := c.operations[index]; exists {
+			return nil, fmt.Errorf("multiple operations for validator %d", index)
+		}
+		tracked := &trackedOperation{
+			ValidatorIndex:  index,
+			ExpectedAddress: operation.Message.ToExecutionAddress.String(),
+			Status:          statusPending,
+			operation:       operation,
+		}
+		c.operations[index] = tracked
+		c.tracked = append(c.tracked, tracked)
+	}
+
+	return c, nil
+}
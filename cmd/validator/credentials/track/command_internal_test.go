@@ -0,0 +1,166 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialstrack
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func writeOperations(t *testing.T, dir string, name string, operations []*capella.SignedBLSToExecutionChange) string {
+	t.Helper()
+	data, err := json.Marshal(operations)
+	require.NoError(t, err)
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	return path
+}
+
+func TestInput(t *testing.T) {
+	dir := t.TempDir()
+	goodFile := writeOperations(t, dir, "good.json", []*capella.SignedBLSToExecutionChange{
+		{
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex:     1,
+				ToExecutionAddress: bellatrix.ExecutionAddress{0x01},
+			},
+		},
+		{
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex:     2,
+				ToExecutionAddress: bellatrix.ExecutionAddress{0x02},
+			},
+		},
+	})
+	duplicateFile := writeOperations(t, dir, "duplicate.json", []*capella.SignedBLSToExecutionChange{
+		{
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex: 1,
+			},
+		},
+		{
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex: 1,
+			},
+		},
+	})
+	emptyFile := writeOperations(t, dir, "empty.json", []*capella.SignedBLSToExecutionChange{})
+	invalidFile := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("[[["), 0o600))
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"file": goodFile,
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "FileMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "file is required",
+		},
+		{
+			name: "FileNotFound",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    filepath.Join(dir, "missing.json"),
+			},
+			err: "failed to read operations file: open " + filepath.Join(dir, "missing.json") + ": no such file or directory",
+		},
+		{
+			name: "FileInvalid",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    invalidFile,
+			},
+			err: "failed to parse operations file: unexpected end of JSON input",
+		},
+		{
+			name: "FileEmpty",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    emptyFile,
+			},
+			err: "no operations in file",
+		},
+		{
+			name: "DuplicateValidator",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    duplicateFile,
+			},
+			err: "multiple operations for validator 1",
+		},
+		{
+			name: "FromSlotInvalid",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"file":      goodFile,
+				"from-slot": "bad",
+			},
+			err: "invalid from slot: strconv.ParseUint: parsing \"bad\": invalid syntax",
+		},
+		{
+			name: "WebhookInvalid",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"file":     goodFile,
+				"webhooks": []string{"ftp://example.com/"},
+			},
+			err: "invalid webhook ftp://example.com/",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"file":      goodFile,
+				"from-slot": "100",
+				"webhooks":  []string{"https://example.com/alerts"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialstrack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type jsonOutput struct {
+	Operations []*trackedOperation `json:"operations"`
+	Alerts     []*alert            `json:"alerts"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	alerts := c.alerts
+	if alerts == nil {
+		alerts = make([]*alert, 0)
+	}
+	data, err := json.Marshal(&jsonOutput{
+		Operations: c.tracked,
+		Alerts:     alerts,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	for _, tracked := range c.tracked {
+		builder.WriteString(fmt.Sprintf("Validator %d: ", tracked.ValidatorIndex))
+		switch {
+		case tracked.Status == statusIncluded && tracked.Slot == nil:
+			builder.WriteString("included before tracking started")
+		case tracked.Status == statusIncluded:
+			builder.WriteString(fmt.Sprintf("included in slot %d", *tracked.Slot))
+		case tracked.Status == statusConflicting && tracked.Slot == nil:
+			builder.WriteString(fmt.Sprintf("credentials changed to %s before tracking started (expected %s)", tracked.ActualAddress, tracked.ExpectedAddress))
+		case tracked.Status == statusConflicting:
+			builder.WriteString(fmt.Sprintf("credentials changed to %s in slot %d (expected %s)", tracked.ActualAddress, *tracked.Slot, tracked.ExpectedAddress))
+		default:
+			builder.WriteString("not included")
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
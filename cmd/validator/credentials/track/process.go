@@ -0,0 +1,249 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialstrack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// alert is raised when a credentials change for one of our validators does
+// not match the submitted operation.
+type alert struct {
+	ValidatorIndex  phase0.ValidatorIndex `json:"validator_index"`
+	Slot            *phase0.Slot          `json:"slot,omitempty"`
+	ExpectedAddress string                `json:"expected_address"`
+	ActualAddress   string                `json:"actual_address"`
+	Message         string                `json:"message"`
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	// Operations may have been included before tracking starts.
+	if err := c.checkValidators(ctx); err != nil {
+		return err
+	}
+
+	slot := c.chainTime.CurrentSlot()
+	if c.fromSlot != nil {
+		slot = *c.fromSlot
+	}
+	for ; c.pending() > 0; slot++ {
+		// Wait for the block at this slot to be available.
+		if wait := time.Until(c.chainTime.StartOfSlot(slot + 1)); wait > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+		if err := c.processSlot(ctx, slot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkValidators checks the current withdrawal credentials of the validators.
+func (c *command) checkValidators(ctx context.Context) error {
+	indices := make([]phase0.ValidatorIndex, 0, len(c.tracked))
+	for _, tracked := range c.tracked {
+		indices = append(indices, tracked.ValidatorIndex)
+	}
+	validators, err := c.validatorsProvider.Validators(ctx, "head", indices)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+
+	for _, tracked := range c.tracked {
+		validator, exists := validators[tracked.ValidatorIndex]
+		if !exists {
+			return fmt.Errorf("validator %d not found", tracked.ValidatorIndex)
+		}
+		credentials := validator.Validator.WithdrawalCredentials
+		if len(credentials) != 32 || credentials[0] != 0x01 {
+			// Still BLS credentials.
+			continue
+		}
+		address := fmt.Sprintf("%#x", credentials[12:])
+		c.resolve(ctx, tracked, nil, address)
+	}
+
+	return nil
+}
+
+// processSlot processes the credentials changes in the block at the given slot.
+func (c *command) processSlot(ctx context.Context, slot phase0.Slot) error {
+	block, err := c.blocksProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+	}
+	if block == nil {
+		// No block at this slot; that's fine.
+		return nil
+	}
+
+	var changes []*capella.SignedBLSToExecutionChange
+	switch block.Version {
+	case spec.DataVersionPhase0, spec.DataVersionAltair, spec.DataVersionBellatrix:
+		// No credentials changes in these forks.
+	case spec.DataVersionCapella:
+		changes = block.Capella.Message.Body.BLSToExecutionChanges
+	default:
+		return fmt.Errorf("unhandled block version %v", block.Version)
+	}
+	util.Log.Debug().Uint64("slot", uint64(slot)).Int("changes", len(changes)).Msg("Obtained credentials changes")
+
+	c.processChanges(ctx, slot, changes)
+
+	return nil
+}
+
+// processChanges processes the credentials changes included at the given slot.
+func (c *command) processChanges(ctx context.Context, slot phase0.Slot, changes []*capella.SignedBLSToExecutionChange) {
+	for _, change := range changes {
+		tracked, exists := c.operations[change.Message.ValidatorIndex]
+		if !exists || tracked.Status != statusPending {
+			continue
+		}
+		inclusionSlot := slot
+		c.resolve(ctx, tracked, &inclusionSlot, change.Message.ToExecutionAddress.String())
+	}
+}
+
+// resolve resolves a tracked operation given the address to which the
+// validator's credentials have been changed, raising an alert if it does not
+// match the submitted operation.
+func (c *command) resolve(ctx context.Context, tracked *trackedOperation, slot *phase0.Slot, address string) {
+	tracked.Slot = slot
+	if address == tracked.ExpectedAddress {
+		tracked.Status = statusIncluded
+		if c.verbose {
+			if slot == nil {
+				fmt.Fprintf(os.Stderr, "Credentials change for validator %d already included\n", tracked.ValidatorIndex)
+			} else {
+				fmt.Fprintf(os.Stderr, "Credentials change for validator %d included in slot %d\n", tracked.ValidatorIndex, *slot)
+			}
+		}
+		return
+	}
+
+	tracked.Status = statusConflicting
+	tracked.ActualAddress = address
+	c.alert(ctx, &alert{
+		ValidatorIndex:  tracked.ValidatorIndex,
+		Slot:            slot,
+		ExpectedAddress: tracked.ExpectedAddress,
+		ActualAddress:   address,
+		Message:         fmt.Sprintf("validator %d credentials changed to %s rather than %s; withdrawal key may be compromised", tracked.ValidatorIndex, address, tracked.ExpectedAddress),
+	})
+}
+
+// alert raises an alert on all notification channels.
+func (c *command) alert(ctx context.Context, alert *alert) {
+	c.alerts = append(c.alerts, alert)
+
+	fmt.Fprintf(os.Stderr, "ALERT: %s\n", alert.Message)
+
+	data, err := json.Marshal(alert)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate alert notification: %v\n", err)
+		return
+	}
+	for _, webhook := range c.webhooks {
+		if err := c.notifyWebhook(ctx, webhook, data); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to send alert to %s: %v\n", webhook, err)
+		}
+	}
+}
+
+// notifyWebhook sends alert data to a webhook.
+func (c *command) notifyWebhook(ctx context.Context, webhook string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// pending returns the number of operations that are yet to be resolved.
+func (c *command) pending() int {
+	pending := 0
+	for _, tracked := range c.tracked {
+		if tracked.Status == statusPending {
+			pending++
+		}
+	}
+
+	return pending
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.consensusClient.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.blocksProvider, isProvider = c.consensusClient.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide signed beacon blocks")
+	}
+	c.validatorsProvider, isProvider = c.consensusClient.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide validator information")
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialstrack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessChanges(t *testing.T) {
+	received := make([]*alert, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		alert := &alert{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(alert))
+		received = append(received, alert)
+	}))
+	defer server.Close()
+
+	viper.Reset()
+	viper.Set("timeout", "5s")
+	viper.Set("file", writeOperations(t, t.TempDir(), "operations.json", []*capella.SignedBLSToExecutionChange{
+		{
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex:     1,
+				ToExecutionAddress: bellatrix.ExecutionAddress{0x01},
+			},
+		},
+		{
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex:     2,
+				ToExecutionAddress: bellatrix.ExecutionAddress{0x02},
+			},
+		},
+		{
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex:     3,
+				ToExecutionAddress: bellatrix.ExecutionAddress{0x03},
+			},
+		},
+	}))
+	viper.Set("webhooks", []string{server.URL})
+	c, err := newCommand(context.Background())
+	require.NoError(t, err)
+
+	c.processChanges(context.Background(), 100, []*capella.SignedBLSToExecutionChange{
+		{
+			// Not one of ours.
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex:     4,
+				ToExecutionAddress: bellatrix.ExecutionAddress{0x04},
+			},
+		},
+		{
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex:     1,
+				ToExecutionAddress: bellatrix.ExecutionAddress{0x01},
+			},
+		},
+	})
+	require.Equal(t, 2, c.pending())
+	require.Empty(t, received)
+
+	c.processChanges(context.Background(), 101, []*capella.SignedBLSToExecutionChange{
+		{
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex:     2,
+				ToExecutionAddress: bellatrix.ExecutionAddress{0xff},
+			},
+		},
+		{
+			// Already resolved, so ignored.
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex:     1,
+				ToExecutionAddress: bellatrix.ExecutionAddress{0xff},
+			},
+		},
+	})
+	require.Equal(t, 1, c.pending())
+
+	require.Equal(t, statusIncluded, c.operations[1].Status)
+	require.Equal(t, phase0.Slot(100), *c.operations[1].Slot)
+	require.Equal(t, statusConflicting, c.operations[2].Status)
+	require.Equal(t, phase0.Slot(101), *c.operations[2].Slot)
+	require.Equal(t, "0xff00000000000000000000000000000000000000", c.operations[2].ActualAddress)
+	require.Equal(t, statusPending, c.operations[3].Status)
+
+	require.Len(t, c.alerts, 1)
+	require.Len(t, received, 1)
+	require.Equal(t, phase0.ValidatorIndex(2), received[0].ValidatorIndex)
+	require.Equal(t, "0x0200000000000000000000000000000000000000", received[0].ExpectedAddress)
+	require.Equal(t, "0xff00000000000000000000000000000000000000", received[0].ActualAddress)
+
+	res, err := c.outputText(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, `Validator 1: included in slot 100
+Validator 2: credentials changed to 0xff00000000000000000000000000000000000000 in slot 101 (expected 0x0200000000000000000000000000000000000000)
+Validator 3: not included`, res)
+}
@@ -0,0 +1,54 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositdata
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// feeAnnotation estimates the fees for the deposit transactions using the execution node.
+func feeAnnotation(ctx context.Context, data []*dataOut) (string, error) {
+	deposits := 0
+	var amount *big.Int
+	for _, datum := range data {
+		if datum == nil {
+			continue
+		}
+		deposits++
+		// Deposits may have different amounts; estimate using the largest.
+		value := new(big.Int).Mul(new(big.Int).SetUint64(uint64(datum.amount)), big.NewInt(1000000000))
+		if amount == nil || value.Cmp(amount) > 0 {
+			amount = value
+		}
+	}
+	if deposits == 0 {
+		return "", nil
+	}
+
+	executionClient, err := util.ConnectToExecutionNode(ctx, viper.GetString("execution-connection"), viper.GetDuration("timeout"), viper.GetBool("allow-insecure-connections"))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to connect to execution node")
+	}
+	estimate, err := executionClient.EstimateFees(ctx, util.DepositGasLimit, amount)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to estimate fees")
+	}
+
+	return estimate.Annotation(deposits), nil
+}
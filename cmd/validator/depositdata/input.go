@@ -16,6 +16,7 @@ package depositdata
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"strings"
 	"time"
 
@@ -24,12 +25,14 @@ import (
 	"github.com/spf13/viper"
 	ethdoutil "github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 	string2eth "github.com/wealdtech/go-string2eth"
 )
 
 type dataIn struct {
 	format            string
+	depositCLIVersion string
 	timeout           time.Duration
 	withdrawalAccount string
 	withdrawalPubKey  string
@@ -48,8 +51,11 @@ func input() (*dataIn, error) {
 		domain:      &spec.Domain{},
 	}
 
-	if viper.GetString("validatoraccount") == "" {
-		return nil, errors.New("validator account is required")
+	if viper.GetString("validatoraccount") == "" && viper.GetString("mnemonic") == "" {
+		return nil, errors.New("validator account or mnemonic is required")
+	}
+	if viper.GetString("validatoraccount") != "" && viper.GetString("mnemonic") != "" {
+		return nil, errors.New("only one of validator account and mnemonic is allowed")
 	}
 
 	if viper.GetDuration("timeout") == 0 {
@@ -59,15 +65,34 @@ func input() (*dataIn, error) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 	defer cancel()
-	_, data.validatorAccounts, err = ethdoutil.WalletAndAccountsFromPath(ctx, viper.GetString("validatoraccount"))
-	if err != nil {
-		return nil, errors.New("failed to obtain validator account")
-	}
-	if len(data.validatorAccounts) == 0 {
-		return nil, errors.New("unknown validator account")
+	if viper.GetString("mnemonic") != "" {
+		data.validatorAccounts, err = inputMnemonicAccounts(viper.GetString("mnemonic"), viper.GetUint64("start-index"), viper.GetUint64("count"))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if viper.GetUint64("count") > 1 {
+			return nil, errors.New("count can only be used with a mnemonic")
+		}
+		_, data.validatorAccounts, err = ethdoutil.WalletAndAccountsFromPath(ctx, viper.GetString("validatoraccount"))
+		if err != nil {
+			return nil, errors.New("failed to obtain validator account")
+		}
+		if len(data.validatorAccounts) == 0 {
+			return nil, errors.New("unknown validator account")
+		}
 	}
 
+	if viper.GetBool("launchpad") && viper.GetBool("deposit-cli") {
+		return nil, errors.New("only one of launchpad and deposit-cli is allowed")
+	}
 	switch {
+	case viper.GetBool("deposit-cli"):
+		data.format = "depositcli"
+		data.depositCLIVersion = viper.GetString("deposit-cli-version")
+		if data.depositCLIVersion == "" {
+			return nil, errors.New("deposit CLI version is required")
+		}
 	case viper.GetBool("launchpad"):
 		data.format = "launchpad"
 	case viper.GetBool("raw"):
@@ -121,6 +146,55 @@ func input() (*dataIn, error) {
 	return data, nil
 }
 
+// mnemonicAccount is a validator account derived from a mnemonic, named by
+// its derivation path.
+type mnemonicAccount struct {
+	*ethdoutil.ScratchAccount
+	path string
+}
+
+// Name returns the account name.
+func (a *mnemonicAccount) Name() string {
+	return a.path
+}
+
+// Path returns the account path.
+func (a *mnemonicAccount) Path() string {
+	return a.path
+}
+
+// inputMnemonicAccounts derives the validator accounts from a mnemonic, using
+// the same paths as the staking deposit CLI.
+func inputMnemonicAccounts(mnemonic string, startIndex uint64, count uint64) ([]e2wtypes.Account, error) {
+	if count == 0 {
+		return nil, errors.New("count must be at least 1")
+	}
+
+	seed, err := ethdoutil.SeedFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]e2wtypes.Account, 0, count)
+	for i := startIndex; i < startIndex+count; i++ {
+		path := fmt.Sprintf("m/12381/3600/%d/0/0", i)
+		key, err := util.PrivateKeyFromSeedAndPath(seed, path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate validator private key")
+		}
+		account, err := ethdoutil.NewScratchAccount(key.Marshal(), nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create validator account")
+		}
+		accounts = append(accounts, &mnemonicAccount{
+			ScratchAccount: account,
+			path:           path,
+		})
+	}
+
+	return accounts, nil
+}
+
 func inputForkVersion(ctx context.Context) (*spec.Version, error) {
 	// Default to mainnet.
 	forkVersion := &spec.Version{0x00, 0x00, 0x00, 0x00}
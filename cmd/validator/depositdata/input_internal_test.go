@@ -15,13 +15,16 @@ package depositdata
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	"github.com/wealdtech/ethdo/testutil"
+	ethdoutil "github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
@@ -82,7 +85,7 @@ func TestInput(t *testing.T) {
 	}{
 		{
 			name: "Nil",
-			err:  "validator account is required",
+			err:  "validator account or mnemonic is required",
 		},
 		{
 			name: "TimeoutMissing",
@@ -102,7 +105,74 @@ func TestInput(t *testing.T) {
 				"depositvalue":      "32 Ether",
 				"forkversion":       "0x01020304",
 			},
-			err: "validator account is required",
+			err: "validator account or mnemonic is required",
+		},
+		{
+			name: "ValidatorAccountAndMnemonic",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"mnemonic":          "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"withdrawalaccount": "Test/Interop 0",
+				"depositvalue":      "32 Ether",
+			},
+			err: "only one of validator account and mnemonic is allowed",
+		},
+		{
+			name: "CountWithoutMnemonic",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"count":             2,
+				"withdrawalaccount": "Test/Interop 0",
+				"depositvalue":      "32 Ether",
+			},
+			err: "count can only be used with a mnemonic",
+		},
+		{
+			name: "MnemonicInvalid",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"mnemonic":          "abandon abandon abandon",
+				"count":             2,
+				"withdrawalaccount": "Test/Interop 0",
+				"depositvalue":      "32 Ether",
+			},
+			err: "mnemonic is invalid",
+		},
+		{
+			name: "MnemonicCountZero",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"mnemonic":          "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"count":             0,
+				"withdrawalaccount": "Test/Interop 0",
+				"depositvalue":      "32 Ether",
+			},
+			err: "count must be at least 1",
+		},
+		{
+			name: "LaunchpadAndDepositCLI",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawalaccount": "Test/Interop 0",
+				"depositvalue":      "32 Ether",
+				"launchpad":         true,
+				"deposit-cli":       true,
+			},
+			err: "only one of launchpad and deposit-cli is allowed",
+		},
+		{
+			name: "DepositCLIVersionMissing",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawalaccount": "Test/Interop 0",
+				"depositvalue":      "32 Ether",
+				"deposit-cli":       true,
+			},
+			err: "deposit CLI version is required",
 		},
 		{
 			name: "ValidatorAccountUnknown",
@@ -251,6 +321,26 @@ func TestInput(t *testing.T) {
 				domain:            mainnetDomain,
 			},
 		},
+		{
+			name: "GoodDepositCLI",
+			vars: map[string]interface{}{
+				"timeout":             "10s",
+				"validatoraccount":    "Test/Interop 0",
+				"withdrawalaccount":   "Test/Interop 0",
+				"depositvalue":        "32 Ether",
+				"deposit-cli":         true,
+				"deposit-cli-version": "2.7.0",
+			},
+			res: &dataIn{
+				format:            "depositcli",
+				depositCLIVersion: "2.7.0",
+				withdrawalAccount: "Test/Interop 0",
+				amount:            32000000000,
+				validatorAccounts: []e2wtypes.Account{interop0},
+				forkVersion:       mainnetForkVersion,
+				domain:            mainnetDomain,
+			},
+		},
 		{
 			name: "GoodForkVersionOverride",
 			vars: map[string]interface{}{
@@ -302,6 +392,7 @@ func TestInput(t *testing.T) {
 				require.NoError(t, err)
 				// Cannot compare accounts directly, so need to check each element individually.
 				require.Equal(t, test.res.format, res.format)
+				require.Equal(t, test.res.depositCLIVersion, res.depositCLIVersion)
 				require.Equal(t, test.res.withdrawalAccount, res.withdrawalAccount)
 				require.Equal(t, test.res.withdrawalAddress, res.withdrawalAddress)
 				require.Equal(t, test.res.withdrawalPubKey, res.withdrawalPubKey)
@@ -316,3 +407,22 @@ func TestInput(t *testing.T) {
 		})
 	}
 }
+
+func TestInputMnemonicAccounts(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"
+	seed, err := ethdoutil.SeedFromMnemonic(mnemonic)
+	require.NoError(t, err)
+
+	accounts, err := inputMnemonicAccounts(mnemonic, 5, 3)
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+	for i, account := range accounts {
+		path := fmt.Sprintf("m/12381/3600/%d/0/0", i+5)
+		require.Equal(t, path, account.Name())
+		key, err := util.PrivateKeyFromSeedAndPath(seed, path)
+		require.NoError(t, err)
+		require.Equal(t, key.PublicKey().Marshal(), account.PublicKey().Marshal())
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 type dataOut struct {
 	format                string
+	depositCLIVersion     string
 	account               string
 	validatorPubKey       *spec.BLSPubKey
 	withdrawalCredentials []byte
@@ -33,8 +34,20 @@ type dataOut struct {
 	depositMessageRoot    *spec.Root
 }
 
+// depositCLINetworks maps fork versions to the network names used by the
+// staking deposit CLI.
+var depositCLINetworks = map[spec.Version]string{
+	{0x00, 0x00, 0x00, 0x00}: "mainnet",
+	{0x00, 0x00, 0x10, 0x20}: "goerli",
+	{0x90, 0x00, 0x00, 0x69}: "sepolia",
+	{0x01, 0x01, 0x70, 0x00}: "holesky",
+}
+
 func output(data []*dataOut) (string, error) {
 	outputs := make([]string, 0)
+	// The staking deposit CLI writes its file with Python's default JSON
+	// separators, which are followed by a space.
+	separator := ","
 	for _, datum := range data {
 		if datum == nil {
 			continue
@@ -42,6 +55,9 @@ func output(data []*dataOut) (string, error) {
 		var output string
 		var err error
 		switch datum.format {
+		case "depositcli":
+			output, err = validatorDepositDataOutputDepositCLI(datum)
+			separator = ", "
 		case "raw":
 			output, err = validatorDepositDataOutputRaw(datum)
 		case "launchpad":
@@ -54,7 +70,7 @@ func output(data []*dataOut) (string, error) {
 		}
 		outputs = append(outputs, output)
 	}
-	return fmt.Sprintf("[%s]", strings.Join(outputs, ",")), nil
+	return fmt.Sprintf("[%s]", strings.Join(outputs, separator)), nil
 }
 
 func validatorDepositDataOutputRaw(datum *dataOut) (string, error) {
@@ -150,6 +166,50 @@ func validatorDepositDataOutputLaunchpad(datum *dataOut) (string, error) {
 	return output, nil
 }
 
+func validatorDepositDataOutputDepositCLI(datum *dataOut) (string, error) {
+	if datum.validatorPubKey == nil {
+		return "", errors.New("validator public key required")
+	}
+	if len(datum.withdrawalCredentials) != 32 {
+		return "", errors.New("withdrawal credentials must be 32 bytes")
+	}
+	if datum.amount == 0 {
+		return "", errors.New("missing amount")
+	}
+	if datum.signature == nil {
+		return "", errors.New("signature required")
+	}
+	if datum.depositMessageRoot == nil {
+		return "", errors.New("deposit message root required")
+	}
+	if datum.depositDataRoot == nil {
+		return "", errors.New("deposit data root required")
+	}
+	if datum.forkVersion == nil {
+		return "", errors.New("fork version required")
+	}
+	if datum.depositCLIVersion == "" {
+		return "", errors.New("deposit CLI version required")
+	}
+	networkName, exists := depositCLINetworks[*datum.forkVersion]
+	if !exists {
+		return "", fmt.Errorf("fork version %#x is not that of a network supported by the staking deposit CLI", *datum.forkVersion)
+	}
+
+	output := fmt.Sprintf(`{"pubkey": "%x", "withdrawal_credentials": "%x", "amount": %d, "signature": "%x", "deposit_message_root": "%x", "deposit_data_root": "%x", "fork_version": "%x", "network_name": "%s", "deposit_cli_version": "%s"}`,
+		*datum.validatorPubKey,
+		datum.withdrawalCredentials,
+		datum.amount,
+		*datum.signature,
+		*datum.depositMessageRoot,
+		*datum.depositDataRoot,
+		*datum.forkVersion,
+		networkName,
+		datum.depositCLIVersion,
+	)
+	return output, nil
+}
+
 func validatorDepositDataOutputJSON(datum *dataOut) (string, error) {
 	if datum.account == "" {
 		return "", errors.New("missing account")
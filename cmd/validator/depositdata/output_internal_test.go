@@ -689,3 +689,115 @@ func TestOutputRaw(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputDepositCLI(t *testing.T) {
+	validatorPubKey := testutil.HexToPubKey("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c")
+	signature := testutil.HexToSignature("0xb7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2")
+	depositDataRoot := testutil.HexToRoot("0x9e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a3554")
+	depositMessageRoot := testutil.HexToRoot("0x139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6")
+	validatorPubKey2 := testutil.HexToPubKey("0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b")
+	signature2 := testutil.HexToSignature("0x911fe0766e8b79d711dde46bc2142eb51e35be99e5f7da505af9eaad85707bbb8013f0dea35e30403b3e57bb13054c1d0d389aceeba1d4160a148026212c7e017044e3ea69cd96fbd23b6aa9fd1e6f7e82494fbd5f8fc75856711a6b8998926e")
+	depositDataRoot2 := testutil.HexToRoot("0x3b51670e9f266d44c879682a230d60f0d534c64ab25ee68700fe3adb17ddfcab")
+	depositMessageRoot2 := testutil.HexToRoot("0xbb4b6184b25873cdf430df3838c8d3e3d16cf3dc3b214e2f3ab7df9e6d5a9b52")
+	forkVersionMainnet := testutil.HexToVersion("0x00000000")
+	forkVersionHolesky := testutil.HexToVersion("0x01017000")
+	forkVersionPyrmont := testutil.HexToVersion("0x00002009")
+
+	tests := []struct {
+		name    string
+		dataOut []*dataOut
+		res     string
+		err     string
+	}{
+		{
+			name: "DepositCLIVersionMissing",
+			dataOut: []*dataOut{
+				{
+					format:                "depositcli",
+					validatorPubKey:       &validatorPubKey,
+					withdrawalCredentials: testutil.HexToBytes("0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b"),
+					amount:                32000000000,
+					signature:             &signature,
+					forkVersion:           &forkVersionMainnet,
+					depositDataRoot:       &depositDataRoot,
+					depositMessageRoot:    &depositMessageRoot,
+				},
+			},
+			err: "deposit CLI version required",
+		},
+		{
+			name: "UnsupportedNetwork",
+			dataOut: []*dataOut{
+				{
+					format:                "depositcli",
+					depositCLIVersion:     "2.7.0",
+					validatorPubKey:       &validatorPubKey,
+					withdrawalCredentials: testutil.HexToBytes("0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b"),
+					amount:                32000000000,
+					signature:             &signature,
+					forkVersion:           &forkVersionPyrmont,
+					depositDataRoot:       &depositDataRoot,
+					depositMessageRoot:    &depositMessageRoot,
+				},
+			},
+			err: "fork version 0x00002009 is not that of a network supported by the staking deposit CLI",
+		},
+		{
+			name: "SingleHolesky",
+			dataOut: []*dataOut{
+				{
+					format:                "depositcli",
+					depositCLIVersion:     "2.7.0",
+					validatorPubKey:       &validatorPubKey,
+					withdrawalCredentials: testutil.HexToBytes("0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b"),
+					amount:                32000000000,
+					signature:             &signature,
+					forkVersion:           &forkVersionHolesky,
+					depositDataRoot:       &depositDataRoot,
+					depositMessageRoot:    &depositMessageRoot,
+				},
+			},
+			res: `[{"pubkey": "a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c", "withdrawal_credentials": "00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b", "amount": 32000000000, "signature": "b7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2", "deposit_message_root": "139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6", "deposit_data_root": "9e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a3554", "fork_version": "01017000", "network_name": "holesky", "deposit_cli_version": "2.7.0"}]`,
+		},
+		{
+			name: "DoubleMainnet",
+			dataOut: []*dataOut{
+				{
+					format:                "depositcli",
+					depositCLIVersion:     "2.7.0",
+					validatorPubKey:       &validatorPubKey,
+					withdrawalCredentials: testutil.HexToBytes("0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b"),
+					amount:                32000000000,
+					signature:             &signature,
+					forkVersion:           &forkVersionMainnet,
+					depositDataRoot:       &depositDataRoot,
+					depositMessageRoot:    &depositMessageRoot,
+				},
+				{
+					format:                "depositcli",
+					depositCLIVersion:     "2.7.0",
+					validatorPubKey:       &validatorPubKey2,
+					withdrawalCredentials: testutil.HexToBytes("0x00ec7ef7780c9d151597924036262dd28dc60e1228f4da6fecf9d402cb3f3594"),
+					amount:                32000000000,
+					signature:             &signature2,
+					forkVersion:           &forkVersionMainnet,
+					depositDataRoot:       &depositDataRoot2,
+					depositMessageRoot:    &depositMessageRoot2,
+				},
+			},
+			res: `[{"pubkey": "a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c", "withdrawal_credentials": "00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b", "amount": 32000000000, "signature": "b7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2", "deposit_message_root": "139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6", "deposit_data_root": "9e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a3554", "fork_version": "00000000", "network_name": "mainnet", "deposit_cli_version": "2.7.0"}, {"pubkey": "b89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b", "withdrawal_credentials": "00ec7ef7780c9d151597924036262dd28dc60e1228f4da6fecf9d402cb3f3594", "amount": 32000000000, "signature": "911fe0766e8b79d711dde46bc2142eb51e35be99e5f7da505af9eaad85707bbb8013f0dea35e30403b3e57bb13054c1d0d389aceeba1d4160a148026212c7e017044e3ea69cd96fbd23b6aa9fd1e6f7e82494fbd5f8fc75856711a6b8998926e", "deposit_message_root": "bb4b6184b25873cdf430df3838c8d3e3d16cf3dc3b214e2f3ab7df9e6d5a9b52", "deposit_data_root": "3b51670e9f266d44c879682a230d60f0d534c64ab25ee68700fe3adb17ddfcab", "fork_version": "00000000", "network_name": "mainnet", "deposit_cli_version": "2.7.0"}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := output(test.dataOut)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -19,9 +19,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
-	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/ops"
 	ethdoutil "github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	util "github.com/wealdtech/go-eth2-util"
@@ -41,37 +42,24 @@ func process(data *dataIn) ([]*dataOut, error) {
 	}
 
 	for _, validatorAccount := range data.validatorAccounts {
-		validatorPubKey, err := ethdoutil.BestPublicKey(validatorAccount)
+		depositData, err := ops.BuildDepositData(context.Background(), validatorAccount, data.passphrases, withdrawalCredentials, data.amount, *data.domain)
 		if err != nil {
-			return nil, errors.Wrap(err, "validator account does not provide a public key")
+			return nil, err
 		}
+		pubKey := depositData.PublicKey
+		sig := depositData.Signature
 
-		var pubKey spec.BLSPubKey
-		copy(pubKey[:], validatorPubKey.Marshal())
-		depositMessage := &spec.DepositMessage{
-			PublicKey:             pubKey,
-			WithdrawalCredentials: withdrawalCredentials,
-			Amount:                data.amount,
-		}
-		root, err := depositMessage.HashTreeRoot()
+		root, err := (&spec.DepositMessage{
+			PublicKey:             depositData.PublicKey,
+			WithdrawalCredentials: depositData.WithdrawalCredentials,
+			Amount:                depositData.Amount,
+		}).HashTreeRoot()
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to generate deposit message root")
 		}
 		var depositMessageRoot spec.Root
 		copy(depositMessageRoot[:], root[:])
 
-		sig, err := signing.SignRoot(context.Background(), validatorAccount, data.passphrases, depositMessageRoot, *data.domain)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to sign deposit message")
-		}
-
-		depositData := &spec.DepositData{
-			PublicKey:             pubKey,
-			WithdrawalCredentials: withdrawalCredentials,
-			Amount:                data.amount,
-			Signature:             sig,
-		}
-
 		root, err = depositData.HashTreeRoot()
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to generate deposit data root")
@@ -79,10 +67,14 @@ func process(data *dataIn) ([]*dataOut, error) {
 		var depositDataRoot spec.Root
 		copy(depositDataRoot[:], root[:])
 
-		validatorWallet := validatorAccount.(e2wtypes.AccountWalletProvider).Wallet()
+		account := validatorAccount.Name()
+		if walletProvider, isProvider := validatorAccount.(e2wtypes.AccountWalletProvider); isProvider {
+			account = fmt.Sprintf("%s/%s", walletProvider.Wallet().Name(), validatorAccount.Name())
+		}
 		results = append(results, &dataOut{
 			format:                data.format,
-			account:               fmt.Sprintf("%s/%s", validatorWallet.Name(), validatorAccount.Name()),
+			depositCLIVersion:     data.depositCLIVersion,
+			account:               account,
 			validatorPubKey:       &pubKey,
 			withdrawalCredentials: withdrawalCredentials,
 			amount:                data.amount,
@@ -111,9 +103,9 @@ func createWithdrawalCredentials(data *dataIn) ([]byte, error) {
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to obtain public key for withdrawal account")
 		}
-		withdrawalCredentials = util.SHA256(pubKey.Marshal())
-		// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
-		withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
+		var blsPubKey spec.BLSPubKey
+		copy(blsPubKey[:], pubKey.Marshal())
+		withdrawalCredentials = ops.BLSWithdrawalCredentials(blsPubKey)
 	case data.withdrawalPubKey != "":
 		withdrawalPubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(data.withdrawalPubKey, "0x"))
 		if err != nil {
@@ -126,9 +118,9 @@ func createWithdrawalCredentials(data *dataIn) ([]byte, error) {
 		if err != nil {
 			return nil, errors.Wrap(err, "withdrawal public key is not valid")
 		}
-		withdrawalCredentials = util.SHA256(pubKey.Marshal())
-		// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
-		withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
+		var blsPubKey spec.BLSPubKey
+		copy(blsPubKey[:], pubKey.Marshal())
+		withdrawalCredentials = ops.BLSWithdrawalCredentials(blsPubKey)
 	case data.withdrawalAddress != "":
 		withdrawalAddressBytes, err := hex.DecodeString(strings.TrimPrefix(data.withdrawalAddress, "0x"))
 		if err != nil {
@@ -142,10 +134,9 @@ func createWithdrawalCredentials(data *dataIn) ([]byte, error) {
 		if checksummedAddress != data.withdrawalAddress {
 			return nil, fmt.Errorf("withdrawal address checksum does not match (expected %s)", checksummedAddress)
 		}
-		withdrawalCredentials = make([]byte, 32)
-		copy(withdrawalCredentials[12:32], withdrawalAddressBytes)
-		// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
-		withdrawalCredentials[0] = byte(1) // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+		var withdrawalAddress bellatrix.ExecutionAddress
+		copy(withdrawalAddress[:], withdrawalAddressBytes)
+		withdrawalCredentials = ops.ExecutionWithdrawalCredentials(withdrawalAddress)
 	default:
 		return nil, errors.New("withdrawal account, public key or address is required")
 	}
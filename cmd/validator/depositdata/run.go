@@ -14,16 +14,21 @@
 package depositdata
 
 import (
+	"context"
+	"fmt"
+	"os"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 )
 
 // Run runs the validator deposit data command.
 func Run(cmd *cobra.Command) (string, error) {
 	dataIn, err := input()
 	if err != nil {
-		return "", errors.Wrap(err, "failed to obtain input")
+		return "", util.NewValidationError(errors.Wrap(err, "failed to obtain input"))
 	}
 
 	// Further errors do not need a usage report.
@@ -43,5 +48,16 @@ func Run(cmd *cobra.Command) (string, error) {
 		return "", errors.Wrap(err, "failed to obtain output")
 	}
 
+	if viper.GetString("execution-connection") != "" {
+		// Fee information is written separately so as not to alter the deposit data.
+		annotation, err := feeAnnotation(context.Background(), dataOut)
+		if err != nil {
+			return "", err
+		}
+		if annotation != "" {
+			fmt.Fprintln(os.Stderr, annotation)
+		}
+	}
+
 	return results, nil
 }
@@ -31,9 +31,11 @@ type dataIn struct {
 	eth2Client    string
 	allowInsecure bool
 	// Operation.
-	account string
-	pubKey  string
-	index   string
+	account    string
+	pubKey     string
+	index      string
+	validators []string
+	json       bool
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -60,9 +62,14 @@ func input(ctx context.Context) (*dataIn, error) {
 	// ID.
 	data.index = viper.GetString("index")
 
-	if data.account == "" && data.pubKey == "" && data.index == "" {
-		return nil, errors.New("account, pubkey or index required")
+	// Validators.
+	data.validators = viper.GetStringSlice("validators")
+
+	if data.account == "" && data.pubKey == "" && data.index == "" && len(data.validators) == 0 {
+		return nil, errors.New("account, pubkey, index or validators required")
 	}
 
+	data.json = viper.GetBool("json")
+
 	return data, nil
 }
@@ -69,7 +69,7 @@ func TestInput(t *testing.T) {
 				"timeout":    "5s",
 				"connection": "http://locahost:4000",
 			},
-			err: "account, pubkey or index required",
+			err: "account, pubkey, index or validators required",
 		},
 	}
 
@@ -15,24 +15,64 @@ package validatorduties
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	api "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
 
 type dataOut struct {
-	debug                   bool
-	quiet                   bool
-	verbose                 bool
-	genesisTime             time.Time
-	slotDuration            time.Duration
-	slotsPerEpoch           uint64
+	debug                        bool
+	quiet                        bool
+	verbose                      bool
+	json                         bool
+	genesisTime                  time.Time
+	slotDuration                 time.Duration
+	slotsPerEpoch                uint64
+	epochsPerSyncCommitteePeriod uint64
+	thisEpoch                    spec.Epoch
+	validators                   []*validatorDuties
+}
+
+type validatorDuties struct {
+	index                   spec.ValidatorIndex
 	thisEpochAttesterDuty   *api.AttesterDuty
 	thisEpochProposerDuties []*api.ProposerDuty
 	nextEpochAttesterDuty   *api.AttesterDuty
+	nextEpochProposerDuties []*api.ProposerDuty
+	thisPeriodSyncCommittee bool
+	nextPeriodSyncCommittee bool
+}
+
+type jsonOutput struct {
+	CurrentTime    time.Time                    `json:"current_time"`
+	Epoch          spec.Epoch                   `json:"epoch"`
+	NextEpochStart *jsonTime                    `json:"next_epoch_start,omitempty"`
+	Validators     []*jsonValidatorDutiesOutput `json:"validators"`
+}
+
+type jsonValidatorDutiesOutput struct {
+	Index                    spec.ValidatorIndex `json:"index"`
+	AttesterDuties           []*jsonDuty         `json:"attester_duties"`
+	ProposerDuties           []*jsonDuty         `json:"proposer_duties"`
+	CurrentSyncCommittee     bool                `json:"current_sync_committee"`
+	NextSyncCommittee        bool                `json:"next_sync_committee"`
+	CurrentSyncCommitteeEnds *jsonTime           `json:"current_sync_committee_ends,omitempty"`
+	NextSyncCommitteeStarts  *jsonTime           `json:"next_sync_committee_starts,omitempty"`
+}
+
+type jsonDuty struct {
+	Slot  spec.Slot `json:"slot"`
+	Start *jsonTime `json:"start"`
+}
+
+type jsonTime struct {
+	Time         time.Time `json:"time"`
+	SecondsUntil int64     `json:"seconds_until"`
 }
 
 func output(ctx context.Context, data *dataOut) (string, error) {
@@ -44,69 +84,176 @@ func output(ctx context.Context, data *dataOut) (string, error) {
 		return "", nil
 	}
 
+	if data.json {
+		return outputJSON(ctx, data)
+	}
+
+	return outputText(ctx, data)
+}
+
+func outputJSON(_ context.Context, data *dataOut) (string, error) {
+	now := time.Now()
+
+	res := &jsonOutput{
+		CurrentTime: now,
+		Epoch:       data.thisEpoch,
+		Validators:  make([]*jsonValidatorDutiesOutput, 0, len(data.validators)),
+	}
+	if data.slotsPerEpoch > 0 {
+		res.NextEpochStart = newJSONTime(data.epochStart(data.thisEpoch+1), now)
+	}
+
+	for _, validator := range data.validators {
+		validatorRes := &jsonValidatorDutiesOutput{
+			Index:                validator.index,
+			AttesterDuties:       make([]*jsonDuty, 0, 2),
+			ProposerDuties:       make([]*jsonDuty, 0),
+			CurrentSyncCommittee: validator.thisPeriodSyncCommittee,
+			NextSyncCommittee:    validator.nextPeriodSyncCommittee,
+		}
+		for _, duty := range []*api.AttesterDuty{validator.thisEpochAttesterDuty, validator.nextEpochAttesterDuty} {
+			if duty != nil {
+				validatorRes.AttesterDuties = append(validatorRes.AttesterDuties, &jsonDuty{
+					Slot:  duty.Slot,
+					Start: newJSONTime(data.slotStart(duty.Slot), now),
+				})
+			}
+		}
+		for _, duty := range append(validator.thisEpochProposerDuties, validator.nextEpochProposerDuties...) {
+			validatorRes.ProposerDuties = append(validatorRes.ProposerDuties, &jsonDuty{
+				Slot:  duty.Slot,
+				Start: newJSONTime(data.slotStart(duty.Slot), now),
+			})
+		}
+		if data.epochsPerSyncCommitteePeriod > 0 {
+			if validator.thisPeriodSyncCommittee {
+				validatorRes.CurrentSyncCommitteeEnds = newJSONTime(data.epochStart(data.nextSyncCommitteePeriodStartEpoch()), now)
+			}
+			if validator.nextPeriodSyncCommittee {
+				validatorRes.NextSyncCommitteeStarts = newJSONTime(data.epochStart(data.nextSyncCommitteePeriodStartEpoch()), now)
+			}
+		}
+		res.Validators = append(res.Validators, validatorRes)
+	}
+
+	output, err := json.Marshal(res)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal JSON")
+	}
+
+	return fmt.Sprintf("%s\n", string(output)), nil
+}
+
+func outputText(_ context.Context, data *dataOut) (string, error) {
 	builder := strings.Builder{}
 
 	now := time.Now()
 	builder.WriteString("Current time: ")
 	builder.WriteString(now.Format("15:04:05\n"))
 
-	if data.thisEpochAttesterDuty != nil {
-		thisEpochAttesterSlot := data.thisEpochAttesterDuty.Slot
-		thisSlotStart := data.genesisTime.Add(time.Duration(thisEpochAttesterSlot) * data.slotDuration)
-		thisSlotEnd := thisSlotStart.Add(data.slotDuration)
-		if thisSlotEnd.After(now) {
-			builder.WriteString("Upcoming attestation slot this epoch: ")
-			builder.WriteString(thisSlotStart.Format("15:04:05"))
-			builder.WriteString(" - ")
-			builder.WriteString(thisSlotEnd.Format("15:04:05 ("))
-			until := thisSlotStart.Sub(now)
-			if until > 0 {
-				builder.WriteString(fmt.Sprintf("%ds until start of slot)\n", int(until.Seconds())))
-			} else {
-				builder.WriteString("\n")
+	// Only label and indent the duties if there is more than one validator.
+	prefix := ""
+	multiple := len(data.validators) > 1
+	if multiple {
+		prefix = "  "
+	}
+
+	for _, validator := range data.validators {
+		if multiple {
+			builder.WriteString(fmt.Sprintf("Validator %d:\n", validator.index))
+		}
+
+		if validator.thisEpochAttesterDuty != nil {
+			thisSlotStart := data.slotStart(validator.thisEpochAttesterDuty.Slot)
+			thisSlotEnd := thisSlotStart.Add(data.slotDuration)
+			if thisSlotEnd.After(now) {
+				builder.WriteString(prefix)
+				builder.WriteString("Upcoming attestation slot this epoch: ")
+				writeSlotTimes(&builder, thisSlotStart, thisSlotEnd, now)
 			}
+		}
 
+		for _, proposerDuty := range validator.thisEpochProposerDuties {
+			proposerSlotStart := data.slotStart(proposerDuty.Slot)
+			builder.WriteString(prefix)
+			builder.WriteString("Upcoming proposer slot this epoch: ")
+			writeSlotTimes(&builder, proposerSlotStart, proposerSlotStart.Add(data.slotDuration), now)
 		}
-	}
 
-	for _, proposerDuty := range data.thisEpochProposerDuties {
-		proposerSlot := proposerDuty.Slot
-		proposerSlotStart := data.genesisTime.Add(time.Duration(proposerSlot) * data.slotDuration)
-		proposerSlotEnd := proposerSlotStart.Add(data.slotDuration)
-		builder.WriteString("Upcoming proposer slot this epoch: ")
-		builder.WriteString(proposerSlotStart.Format("15:04:05"))
-		builder.WriteString(" - ")
-		builder.WriteString(proposerSlotEnd.Format("15:04:05 ("))
-		until := proposerSlotStart.Sub(now)
-		if until > 0 {
-			builder.WriteString(fmt.Sprintf("%ds until start of slot)\n", int(until.Seconds())))
-		} else {
-			builder.WriteString("\n")
+		if validator.nextEpochAttesterDuty != nil {
+			nextSlotStart := data.slotStart(validator.nextEpochAttesterDuty.Slot)
+			builder.WriteString(prefix)
+			builder.WriteString("Upcoming attestation slot next epoch: ")
+			writeSlotTimes(&builder, nextSlotStart, nextSlotStart.Add(data.slotDuration), now)
+		}
+
+		for _, proposerDuty := range validator.nextEpochProposerDuties {
+			proposerSlotStart := data.slotStart(proposerDuty.Slot)
+			builder.WriteString(prefix)
+			builder.WriteString("Upcoming proposer slot next epoch: ")
+			writeSlotTimes(&builder, proposerSlotStart, proposerSlotStart.Add(data.slotDuration), now)
+		}
+
+		if data.epochsPerSyncCommitteePeriod > 0 {
+			periodChange := data.epochStart(data.nextSyncCommitteePeriodStartEpoch())
+			if validator.thisPeriodSyncCommittee {
+				builder.WriteString(prefix)
+				builder.WriteString("Sync committee member this period, ending ")
+				writeTime(&builder, periodChange, now, "end of period")
+			}
+			if validator.nextPeriodSyncCommittee {
+				builder.WriteString(prefix)
+				builder.WriteString("Sync committee member next period, starting ")
+				writeTime(&builder, periodChange, now, "start of period")
+			}
 		}
 	}
 
-	if data.nextEpochAttesterDuty != nil {
-		nextEpochAttesterSlot := data.nextEpochAttesterDuty.Slot
-		nextSlotStart := data.genesisTime.Add(time.Duration(nextEpochAttesterSlot) * data.slotDuration)
-		nextSlotEnd := nextSlotStart.Add(data.slotDuration)
-		builder.WriteString("Upcoming attestation slot next epoch: ")
-		builder.WriteString(nextSlotStart.Format("15:04:05"))
-		builder.WriteString(" - ")
-		builder.WriteString(nextSlotEnd.Format("15:04:05 ("))
-		until := nextSlotStart.Sub(now)
-		builder.WriteString(fmt.Sprintf("%ds until start of slot)\n", int(until.Seconds())))
-
-		nextEpoch := uint64(data.nextEpochAttesterDuty.Slot) / data.slotsPerEpoch
-		nextEpochStart := data.genesisTime.Add(time.Duration(nextEpoch*data.slotsPerEpoch) * data.slotDuration)
+	if data.slotsPerEpoch > 0 && data.slotDuration > 0 {
 		builder.WriteString("Next epoch starts ")
-		builder.WriteString(nextEpochStart.Format("15:04:05 ("))
-		until = nextEpochStart.Sub(now)
-		if until > 0 {
-			builder.WriteString(fmt.Sprintf("%ds until start of epoch)\n", int(until.Seconds())))
-		} else {
-			builder.WriteString("\n")
-		}
+		writeTime(&builder, data.epochStart(data.thisEpoch+1), now, "start of epoch")
 	}
 
 	return builder.String(), nil
 }
+
+// writeSlotTimes writes the start and end times of a slot, along with a countdown to its start.
+func writeSlotTimes(builder *strings.Builder, start time.Time, end time.Time, now time.Time) {
+	builder.WriteString(start.Format("15:04:05"))
+	builder.WriteString(" - ")
+	builder.WriteString(end.Format("15:04:05"))
+	until := start.Sub(now)
+	if until > 0 {
+		builder.WriteString(fmt.Sprintf(" (%ds until start of slot)", int(until.Seconds())))
+	}
+	builder.WriteString("\n")
+}
+
+// writeTime writes a time, along with a countdown to it.
+func writeTime(builder *strings.Builder, t time.Time, now time.Time, event string) {
+	builder.WriteString(t.Format("15:04:05"))
+	until := t.Sub(now)
+	if until > 0 {
+		builder.WriteString(fmt.Sprintf(" (%s until %s)", until.Round(time.Second), event))
+	}
+	builder.WriteString("\n")
+}
+
+func newJSONTime(t time.Time, now time.Time) *jsonTime {
+	return &jsonTime{
+		Time:         t,
+		SecondsUntil: int64(t.Sub(now).Seconds()),
+	}
+}
+
+func (d *dataOut) slotStart(slot spec.Slot) time.Time {
+	return d.genesisTime.Add(time.Duration(slot) * d.slotDuration)
+}
+
+func (d *dataOut) epochStart(epoch spec.Epoch) time.Time {
+	return d.slotStart(spec.Slot(uint64(epoch) * d.slotsPerEpoch))
+}
+
+func (d *dataOut) nextSyncCommitteePeriodStartEpoch() spec.Epoch {
+	return spec.Epoch((uint64(d.thisEpoch)/d.epochsPerSyncCommitteePeriod + 1) * d.epochsPerSyncCommitteePeriod)
+}
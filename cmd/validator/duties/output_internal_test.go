@@ -46,23 +46,95 @@ func TestOutput(t *testing.T) {
 				genesisTime:   time.Unix(16000000000, 0),
 				slotDuration:  12 * time.Second,
 				slotsPerEpoch: 32,
-				thisEpochAttesterDuty: &api.AttesterDuty{
-					Slot: spec.Slot(1),
-				},
-				thisEpochProposerDuties: []*api.ProposerDuty{
+				validators: []*validatorDuties{
 					{
-						Slot: spec.Slot(2),
+						index: 1,
+						thisEpochAttesterDuty: &api.AttesterDuty{
+							Slot: spec.Slot(1),
+						},
+						thisEpochProposerDuties: []*api.ProposerDuty{
+							{
+								Slot: spec.Slot(2),
+							},
+						},
+						nextEpochAttesterDuty: &api.AttesterDuty{
+							Slot: spec.Slot(40),
+						},
 					},
 				},
-				nextEpochAttesterDuty: &api.AttesterDuty{
-					Slot: spec.Slot(40),
-				},
 			},
 			expected: []string{
 				"Current time",
 				"Upcoming attestation slot this epoch",
 				"Upcoming proposer slot this epoch",
 				"Upcoming attestation slot next epoch",
+				"Next epoch starts",
+			},
+		},
+		{
+			name: "Multiple",
+			dataOut: &dataOut{
+				genesisTime:                  time.Unix(16000000000, 0),
+				slotDuration:                 12 * time.Second,
+				slotsPerEpoch:                32,
+				epochsPerSyncCommitteePeriod: 256,
+				validators: []*validatorDuties{
+					{
+						index: 1,
+						thisEpochAttesterDuty: &api.AttesterDuty{
+							Slot: spec.Slot(1),
+						},
+						thisPeriodSyncCommittee: true,
+					},
+					{
+						index: 2,
+						nextEpochAttesterDuty: &api.AttesterDuty{
+							Slot: spec.Slot(40),
+						},
+						nextEpochProposerDuties: []*api.ProposerDuty{
+							{
+								Slot: spec.Slot(41),
+							},
+						},
+						nextPeriodSyncCommittee: true,
+					},
+				},
+			},
+			expected: []string{
+				"Validator 1:\n  Upcoming attestation slot this epoch",
+				"  Sync committee member this period, ending ",
+				"Validator 2:\n  Upcoming attestation slot next epoch",
+				"  Upcoming proposer slot next epoch",
+				"  Sync committee member next period, starting ",
+			},
+		},
+		{
+			name: "JSON",
+			dataOut: &dataOut{
+				json:                         true,
+				genesisTime:                  time.Unix(16000000000, 0),
+				slotDuration:                 12 * time.Second,
+				slotsPerEpoch:                32,
+				epochsPerSyncCommitteePeriod: 256,
+				validators: []*validatorDuties{
+					{
+						index: 1,
+						thisEpochAttesterDuty: &api.AttesterDuty{
+							Slot: spec.Slot(1),
+						},
+						thisEpochProposerDuties: []*api.ProposerDuty{
+							{
+								Slot: spec.Slot(2),
+							},
+						},
+						nextPeriodSyncCommittee: true,
+					},
+				},
+			},
+			expected: []string{
+				`"validators":[{"index":1,"attester_duties":[{"slot":1,"start":{"time":`,
+				`"proposer_duties":[{"slot":2,"start":{"time":`,
+				`"current_sync_committee":false,"next_sync_committee":true,"next_sync_committee_starts":{"time":`,
 			},
 		},
 	}
@@ -15,6 +15,7 @@ package validatorduties
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -39,11 +40,21 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		debug:   data.debug,
 		quiet:   data.quiet,
 		verbose: data.verbose,
+		json:    data.json,
 	}
 
-	validatorIndex, err := util.ValidatorIndex(ctx, eth2Client, data.account, data.pubKey, data.index)
+	indices, err := validatorIndices(ctx, eth2Client, data)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to obtain validator index")
+		return nil, err
+	}
+	results.validators = make([]*validatorDuties, 0, len(indices))
+	validators := make(map[spec.ValidatorIndex]*validatorDuties, len(indices))
+	for _, validatorIndex := range indices {
+		duties := &validatorDuties{
+			index: validatorIndex,
+		}
+		results.validators = append(results.validators, duties)
+		validators[validatorIndex] = duties
 	}
 
 	// Fetch duties for this and next epoch.
@@ -51,24 +62,49 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to calculate current epoch")
 	}
-	thisEpochAttesterDuty, err := attesterDuty(ctx, eth2Client, validatorIndex, thisEpoch)
+	results.thisEpoch = thisEpoch
+	thisEpochAttesterDuties, err := attesterDuties(ctx, eth2Client, indices, thisEpoch)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain this epoch attester duty for validator")
 	}
-	results.thisEpochAttesterDuty = thisEpochAttesterDuty
+	for _, duty := range thisEpochAttesterDuties {
+		if validator, exists := validators[duty.ValidatorIndex]; exists {
+			validator.thisEpochAttesterDuty = duty
+		}
+	}
 
-	thisEpochProposerDuties, err := proposerDuties(ctx, eth2Client, validatorIndex, thisEpoch)
+	thisEpochProposerDuties, err := proposerDuties(ctx, eth2Client, indices, thisEpoch)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain this epoch proposer duties for validator")
 	}
-	results.thisEpochProposerDuties = thisEpochProposerDuties
+	for _, duty := range thisEpochProposerDuties {
+		if validator, exists := validators[duty.ValidatorIndex]; exists {
+			validator.thisEpochProposerDuties = append(validator.thisEpochProposerDuties, duty)
+		}
+	}
 
 	nextEpoch := thisEpoch + 1
-	nextEpochAttesterDuty, err := attesterDuty(ctx, eth2Client, validatorIndex, nextEpoch)
+	nextEpochAttesterDuties, err := attesterDuties(ctx, eth2Client, indices, nextEpoch)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain next epoch attester duty for validator")
 	}
-	results.nextEpochAttesterDuty = nextEpochAttesterDuty
+	for _, duty := range nextEpochAttesterDuties {
+		if validator, exists := validators[duty.ValidatorIndex]; exists {
+			validator.nextEpochAttesterDuty = duty
+		}
+	}
+
+	// Not all beacon nodes provide proposer duties for the next epoch, so failure is not fatal.
+	nextEpochProposerDuties, err := proposerDuties(ctx, eth2Client, indices, nextEpoch)
+	if err != nil {
+		util.Log.Debug().Err(err).Msg("Next epoch proposer duties not available")
+	} else {
+		for _, duty := range nextEpochProposerDuties {
+			if validator, exists := validators[duty.ValidatorIndex]; exists {
+				validator.nextEpochProposerDuties = append(validator.nextEpochProposerDuties, duty)
+			}
+		}
+	}
 
 	genesis, err := eth2Client.(eth2client.GenesisProvider).Genesis(ctx)
 	if err != nil {
@@ -83,12 +119,84 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	results.slotsPerEpoch = config["SLOTS_PER_EPOCH"].(uint64)
 	results.slotDuration = config["SECONDS_PER_SLOT"].(time.Duration)
 
+	if epochsPerSyncCommitteePeriod, exists := config["EPOCHS_PER_SYNC_COMMITTEE_PERIOD"].(uint64); exists {
+		results.epochsPerSyncCommitteePeriod = epochsPerSyncCommitteePeriod
+		if err := syncCommitteeMembership(ctx, eth2Client, results, validators, indices); err != nil {
+			return nil, err
+		}
+	}
+
 	return results, nil
 }
 
-func attesterDuty(ctx context.Context, eth2Client eth2client.Service, validatorIndex spec.ValidatorIndex, epoch spec.Epoch) (*api.AttesterDuty, error) {
-	// Find the attesting slot for the given epoch.
-	duties, err := eth2Client.(eth2client.AttesterDutiesProvider).AttesterDuties(ctx, epoch, []spec.ValidatorIndex{validatorIndex})
+// validatorIndices obtains the indices of the validators for which to fetch duties.
+func validatorIndices(ctx context.Context, eth2Client eth2client.Service, data *dataIn) ([]spec.ValidatorIndex, error) {
+	if len(data.validators) == 0 {
+		validatorIndex, err := util.ValidatorIndex(ctx, eth2Client, data.account, data.pubKey, data.index)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validator index")
+		}
+		return []spec.ValidatorIndex{validatorIndex}, nil
+	}
+
+	validatorsProvider, isProvider := eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("beacon node does not provide validator information")
+	}
+	validators, err := util.ParseValidators(ctx, validatorsProvider, data.validators, "head")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validators")
+	}
+	indices := make([]spec.ValidatorIndex, 0, len(validators))
+	for _, validator := range validators {
+		indices = append(indices, validator.Index)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	return indices, nil
+}
+
+// syncCommitteeMembership obtains sync committee membership for the current and next periods.
+func syncCommitteeMembership(ctx context.Context,
+	eth2Client eth2client.Service,
+	results *dataOut,
+	validators map[spec.ValidatorIndex]*validatorDuties,
+	validatorIndices []spec.ValidatorIndex,
+) error {
+	provider, isProvider := eth2Client.(eth2client.SyncCommitteeDutiesProvider)
+	if !isProvider {
+		return nil
+	}
+
+	thisPeriodDuties, err := provider.SyncCommitteeDuties(ctx, results.thisEpoch, validatorIndices)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain this period sync committee duties")
+	}
+	for _, duty := range thisPeriodDuties {
+		if validator, exists := validators[duty.ValidatorIndex]; exists {
+			validator.thisPeriodSyncCommittee = true
+		}
+	}
+
+	nextPeriodStartEpoch := results.nextSyncCommitteePeriodStartEpoch()
+	nextPeriodDuties, err := provider.SyncCommitteeDuties(ctx, nextPeriodStartEpoch, validatorIndices)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain next period sync committee duties")
+	}
+	for _, duty := range nextPeriodDuties {
+		if validator, exists := validators[duty.ValidatorIndex]; exists {
+			validator.nextPeriodSyncCommittee = true
+		}
+	}
+
+	return nil
+}
+
+func attesterDuties(ctx context.Context, eth2Client eth2client.Service, validatorIndices []spec.ValidatorIndex, epoch spec.Epoch) ([]*api.AttesterDuty, error) {
+	// Find the attesting slots for the given epoch.
+	duties, err := eth2Client.(eth2client.AttesterDutiesProvider).AttesterDuties(ctx, epoch, validatorIndices)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain attester duties")
 	}
@@ -97,19 +205,18 @@ func attesterDuty(ctx context.Context, eth2Client eth2client.Service, validatorI
 		return nil, errors.New("validator does not have duty for that epoch")
 	}
 
-	return duties[0], nil
+	return duties, nil
 }
 
-func proposerDuties(ctx context.Context, eth2Client eth2client.Service, validatorIndex spec.ValidatorIndex, epoch spec.Epoch) ([]*api.ProposerDuty, error) {
+func proposerDuties(ctx context.Context, eth2Client eth2client.Service, validatorIndices []spec.ValidatorIndex, epoch spec.Epoch) ([]*api.ProposerDuty, error) {
 	// Fetch the proposer duties for this epoch.
-	proposerDuties, err := eth2Client.(eth2client.ProposerDutiesProvider).ProposerDuties(ctx, epoch, []spec.ValidatorIndex{validatorIndex})
+	proposerDuties, err := eth2Client.(eth2client.ProposerDutiesProvider).ProposerDuties(ctx, epoch, validatorIndices)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain proposer duties")
 	}
 
 	return proposerDuties, nil
 }
-
 func currentEpoch(ctx context.Context, eth2Client eth2client.Service) (spec.Epoch, error) {
 	config, err := eth2Client.(eth2client.SpecProvider).Spec(ctx)
 	if err != nil {
@@ -0,0 +1,198 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// broadcastSection is the checkpoint section that records the exits of a batch
+// that have been broadcast, by validator index.
+const broadcastSection = "broadcast"
+
+// batchCheckpoint returns the checkpoint that records the progress of a batch,
+// allowing an interrupted batch to be resumed.  The checkpoint is nil if no
+// progress file was supplied.
+func (c *command) batchCheckpoint() (*util.Checkpoint, error) {
+	if c.progressFile == "" {
+		return nil, nil
+	}
+
+	// The checkpoint is identified by the validators in the batch, so that a
+	// progress file cannot be resumed by a different batch.
+	indices := make([]string, 0, len(c.signedOperations))
+	for _, op := range c.signedOperations {
+		indices = append(indices, fmt.Sprintf("%d", op.Message.ValidatorIndex))
+	}
+	sort.Strings(indices)
+
+	return util.NewCheckpoint(c.progressFile, strings.Join(indices, ","), true)
+}
+
+// processBatch validates, confirms and broadcasts multiple exit operations,
+// spreading the broadcasts over time if requested.
+func (c *command) processBatch(ctx context.Context) error {
+	if c.json || c.ssz || c.offline {
+		return errors.New("multiple exit operations can only be broadcast, not output")
+	}
+
+	checkpoint, err := c.batchCheckpoint()
+	if err != nil {
+		return err
+	}
+	broadcast := make(map[phase0.ValidatorIndex]time.Time)
+	if _, err := checkpoint.Load(broadcastSection, &broadcast); err != nil {
+		return err
+	}
+
+	pending, err := c.pendingOperations(ctx, broadcast)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		if !c.quiet {
+			fmt.Fprintf(os.Stdout, "All %d exit operations already broadcast\n", len(c.signedOperations))
+		}
+		return nil
+	}
+
+	if err := c.reportExitQueue(ctx, pending); err != nil {
+		return err
+	}
+
+	if c.operationFile != "" {
+		if err := util.CheckProvenance(ctx, c.consensusClient, c.operationFile); err != nil {
+			return util.NewValidationError(err)
+		}
+	}
+
+	if err := c.confirmOperations(ctx, pending); err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	stagger := c.staggerDuration()
+	for i, op := range pending {
+		if i > 0 && stagger > 0 {
+			util.Log.Debug().Dur("stagger", stagger).Msg("Waiting before next broadcast")
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("interrupted after broadcasting %d of %d exit operations", i, len(pending))
+			case <-time.After(stagger):
+			}
+		}
+
+		if err := c.consensusClient.(consensusclient.VoluntaryExitSubmitter).SubmitVoluntaryExit(ctx, op); err != nil {
+			return util.NewBroadcastError(errors.Wrap(err, fmt.Sprintf("node rejected operation for validator %d", op.Message.ValidatorIndex)))
+		}
+		broadcast[op.Message.ValidatorIndex] = time.Now().UTC().Truncate(time.Second)
+		if err := checkpoint.Save(broadcastSection, broadcast); err != nil {
+			return err
+		}
+		if !c.quiet {
+			fmt.Fprintf(os.Stdout, "Broadcast exit operation for validator %d (%d of %d)\n", op.Message.ValidatorIndex, i+1, len(pending))
+		}
+	}
+
+	return nil
+}
+
+// pendingOperations returns the operations of a batch that have yet to be
+// broadcast, and ensures that they are valid.
+func (c *command) pendingOperations(ctx context.Context,
+	broadcast map[phase0.ValidatorIndex]time.Time,
+) (
+	[]*phase0.SignedVoluntaryExit,
+	error,
+) {
+	pending := make([]*phase0.SignedVoluntaryExit, 0, len(c.signedOperations))
+	for _, op := range c.signedOperations {
+		if _, exists := broadcast[op.Message.ValidatorIndex]; exists {
+			util.Log.Debug().Uint64("validator", uint64(op.Message.ValidatorIndex)).Msg("Exit operation already broadcast according to progress file")
+			continue
+		}
+		status, err := util.VoluntaryExitStatus(ctx, c.consensusClient, c.timeout, op)
+		if err != nil {
+			util.Log.Debug().Err(err).Msg("Failed to check if exit operation is already known")
+		}
+		if status != nil {
+			if c.verbose {
+				fmt.Fprintf(os.Stderr, "Exit operation for validator %d %s; not broadcasting\n", op.Message.ValidatorIndex, status)
+			}
+			continue
+		}
+		if validated, reason := c.validateOperation(ctx, op); !validated {
+			return nil, util.NewValidationError(fmt.Errorf("operation for validator %d failed validation: %s", op.Message.ValidatorIndex, reason))
+		}
+		pending = append(pending, op)
+	}
+
+	return pending, nil
+}
+
+// staggerDuration returns the time to wait between broadcasts.
+func (c *command) staggerDuration() time.Duration {
+	if c.staggerEpochs > 0 && c.chainTime != nil {
+		return c.chainTime.StartOfEpoch(phase0.Epoch(c.staggerEpochs)).Sub(c.chainTime.StartOfEpoch(0))
+	}
+
+	return c.stagger
+}
+
+// confirmOperations summarises the exits and requires the user to confirm them
+// before they are broadcast, as they cannot be undone.
+func (c *command) confirmOperations(ctx context.Context, ops []*phase0.SignedVoluntaryExit) error {
+	if c.yes {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "About to broadcast %d voluntary exits; once accepted by the network these cannot be undone.\n", len(ops))
+	for _, op := range ops {
+		index := fmt.Sprintf("%d", op.Message.ValidatorIndex)
+		validatorInfo, err := c.chainInfo.FetchValidatorInfo(ctx, index)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Validator index: %s\n", index)
+		fmt.Fprintf(os.Stderr, "  Validator public key: %#x\n", validatorInfo.Pubkey)
+		fmt.Fprintf(os.Stderr, "  Exit epoch: %d\n", op.Message.Epoch)
+	}
+	if stagger := c.staggerDuration(); stagger > 0 {
+		fmt.Fprintf(os.Stderr, "Broadcasts will be %v apart, taking %v in total.\n", stagger, stagger*time.Duration(len(ops)-1))
+	}
+
+	confirmed, err := c.prompter.ConfirmTyped("Type the number of exits to confirm", fmt.Sprintf("%d", len(ops)))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return errors.New("exits not confirmed; not broadcasting")
+	}
+
+	return nil
+}
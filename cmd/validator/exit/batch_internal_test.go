@@ -0,0 +1,113 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/testing/mock"
+)
+
+func TestBatchCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progress.json")
+	ops := []*phase0.SignedVoluntaryExit{
+		{Message: &phase0.VoluntaryExit{ValidatorIndex: 12345}},
+		{Message: &phase0.VoluntaryExit{ValidatorIndex: 2}},
+	}
+
+	// No progress file.
+	c := &command{
+		signedOperations: ops,
+	}
+	checkpoint, err := c.batchCheckpoint()
+	require.NoError(t, err)
+	require.Nil(t, checkpoint)
+
+	// Missing file.
+	c.progressFile = path
+	checkpoint, err = c.batchCheckpoint()
+	require.NoError(t, err)
+	broadcast := make(map[phase0.ValidatorIndex]time.Time)
+	found, err := checkpoint.Load(broadcastSection, &broadcast)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Round trip, with the operations in a different order.
+	broadcastTime := time.Date(2023, 5, 2, 10, 21, 32, 0, time.UTC)
+	broadcast[12345] = broadcastTime
+	require.NoError(t, checkpoint.Save(broadcastSection, broadcast))
+	c.signedOperations = []*phase0.SignedVoluntaryExit{ops[1], ops[0]}
+	checkpoint, err = c.batchCheckpoint()
+	require.NoError(t, err)
+	broadcast = make(map[phase0.ValidatorIndex]time.Time)
+	found, err = checkpoint.Load(broadcastSection, &broadcast)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, map[phase0.ValidatorIndex]time.Time{12345: broadcastTime}, broadcast)
+
+	// Different batch.
+	c.signedOperations = ops[:1]
+	_, err = c.batchCheckpoint()
+	require.EqualError(t, err, "checkpoint "+path+" was created with different parameters; remove it to start afresh")
+}
+
+func TestStaggerDuration(t *testing.T) {
+	chainTime, err := standardchaintime.New(context.Background(),
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		command  *command
+		expected time.Duration
+	}{
+		{
+			name:     "None",
+			command:  &command{},
+			expected: 0,
+		},
+		{
+			name: "Duration",
+			command: &command{
+				stagger: 10 * time.Minute,
+			},
+			expected: 10 * time.Minute,
+		},
+		{
+			name: "Epochs",
+			command: &command{
+				staggerEpochs: 2,
+				chainTime:     chainTime,
+			},
+			expected: 2 * 32 * 12 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, test.command.staggerDuration())
+		})
+	}
+}
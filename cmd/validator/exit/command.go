@@ -15,6 +15,10 @@ package validatorexit
 
 import (
 	"context"
+	"encoding/hex"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	consensusclient "github.com/attestantio/go-eth2-client"
@@ -32,6 +36,7 @@ type command struct {
 	debug   bool
 	offline bool
 	json    bool
+	ssz     bool
 
 	// Input.
 	passphrases           []string
@@ -40,9 +45,20 @@ type command struct {
 	privateKey            string
 	validator             string
 	forkVersion           string
+	domainFork            string
 	genesisValidatorsRoot string
+	network               *beacon.Network
 	prepareOffline        bool
+	offlineValidators     []string
 	signedOperationInput  string
+	yes                   bool
+	provenance            bool
+	verifyLightClient     bool
+	trustedBlockRoot      phase0.Root
+	stagger               time.Duration
+	staggerEpochs         uint64
+	progressFile          string
+	estimateExitQueue     bool
 
 	// Beacon node connection.
 	timeout                  time.Duration
@@ -50,15 +66,21 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Information required to generate the operations.
-	chainInfo *beacon.ChainInfo
-	domain    phase0.Domain
+	chainInfo          *beacon.ChainInfo
+	domain             phase0.Domain
+	signingForkVersion phase0.Version
+	signingGenesisRoot phase0.Root
 
 	// Processing.
 	consensusClient consensusclient.Service
 	chainTime       chaintime.Service
+	prompter        *util.Prompter
+	operationFile   string
 
 	// Output.
-	signedOperation *phase0.SignedVoluntaryExit
+	signedOperation  *phase0.SignedVoluntaryExit
+	signedOperations []*phase0.SignedVoluntaryExit
+	operationStatus  *util.OperationStatus
 }
 
 func newCommand(_ context.Context) (*command, error) {
@@ -79,7 +101,14 @@ func newCommand(_ context.Context) (*command, error) {
 		signedOperationInput:     viper.GetString("signed-operation"),
 		validator:                viper.GetString("validator"),
 		forkVersion:              viper.GetString("fork-version"),
+		domainFork:               viper.GetString("domain-fork"),
 		genesisValidatorsRoot:    viper.GetString("genesis-validators-root"),
+		yes:                      viper.GetBool("yes"),
+		provenance:               viper.GetBool("provenance"),
+		verifyLightClient:        viper.GetBool("verify-light-client"),
+		progressFile:             viper.GetString("progress-file"),
+		estimateExitQueue:        viper.GetBool("estimate-exit-queue"),
+		prompter:                 util.NewPrompter(os.Stdin, os.Stderr),
 	}
 
 	// Timeout is required.
@@ -87,6 +116,91 @@ func newCommand(_ context.Context) (*command, error) {
 		return nil, errors.New("timeout is required")
 	}
 
+	// A validator held in a wallet, including a remote wallet, can also be
+	// specified with the account flag.
+	if viper.GetString("account") != "" {
+		if c.validator != "" {
+			return nil, errors.New("only one of account and validator is allowed")
+		}
+		if !strings.Contains(viper.GetString("account"), "/") {
+			return nil, errors.New("account must be in the format wallet/account")
+		}
+		c.validator = viper.GetString("account")
+	}
+
+	// Validators to include in offline preparation.
+	c.offlineValidators = viper.GetStringSlice("validators")
+	if viper.GetString("pubkeys-file") != "" {
+		pubKeys, err := util.ReadPubKeysFile(viper.GetString("pubkeys-file"))
+		if err != nil {
+			return nil, err
+		}
+		c.offlineValidators = append(c.offlineValidators, pubKeys...)
+	}
+	if len(c.offlineValidators) > 0 && !c.prepareOffline {
+		return nil, errors.New("validators and pubkeys-file can only be supplied with prepare-offline")
+	}
+
+	if viper.GetString("network") != "" {
+		var err error
+		c.network, err = beacon.NetworkByName(viper.GetString("network"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.verifyLightClient {
+		if c.offline {
+			return nil, errors.New("verify-light-client requires a connection to a beacon node")
+		}
+		if viper.GetString("trusted-block-root") == "" {
+			return nil, errors.New("trusted-block-root is required with verify-light-client")
+		}
+		root, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("trusted-block-root"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid trusted block root")
+		}
+		if len(root) != phase0.RootLength {
+			return nil, errors.New("trusted block root must be 32 bytes")
+		}
+		copy(c.trustedBlockRoot[:], root)
+	}
+
+	if c.estimateExitQueue && c.offline {
+		return nil, errors.New("estimate-exit-queue requires a connection to a beacon node")
+	}
+
+	// Stagger is either a duration or a number of epochs.
+	if viper.GetString("stagger") != "" {
+		if epochs, err := strconv.ParseUint(viper.GetString("stagger"), 10, 64); err == nil {
+			c.staggerEpochs = epochs
+		} else {
+			c.stagger, err = time.ParseDuration(viper.GetString("stagger"))
+			if err != nil || c.stagger < 0 {
+				return nil, errors.New("stagger must be a duration or a number of epochs")
+			}
+		}
+	}
+
+	switch strings.ToLower(viper.GetString("output-format")) {
+	case "":
+	case "json":
+		c.json = true
+	case "ssz":
+		c.ssz = true
+	default:
+		return nil, errors.New("output format must be json or ssz")
+	}
+
+	switch c.domainFork {
+	case "", "genesis", "current", "capella":
+	default:
+		return nil, errors.New("domain fork must be one of genesis, current or capella")
+	}
+	if c.domainFork != "" && c.forkVersion != "" {
+		return nil, errors.New("only one of fork version and domain fork allowed")
+	}
+
 	// We are generating information for offline use, we don't need any information
 	// related to the accounts or signing.
 	if c.prepareOffline {
@@ -20,6 +20,7 @@ import (
 	"os"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
 )
 
 //nolint:unparam
@@ -32,11 +33,32 @@ func (c *command) output(_ context.Context) (string, error) {
 		return fmt.Sprintf("%s generated", offlinePreparationFilename), nil
 	}
 
+	if c.operationStatus != nil {
+		return fmt.Sprintf("Exit operation %s; not broadcasting", c.operationStatus), nil
+	}
+
+	if c.ssz {
+		data, err := c.signedOperation.MarshalSSZ()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal signed operation")
+		}
+		if err := os.WriteFile(exitOperationSSZFilename, data, 0600); err != nil {
+			return "", errors.Wrap(err, fmt.Sprintf("failed to write %s", exitOperationSSZFilename))
+		}
+		if err := c.writeProvenance(exitOperationSSZFilename); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%#x", data), nil
+	}
+
 	if c.json || c.offline {
 		data, err := json.Marshal(c.signedOperation)
 		if err != nil {
 			return "", errors.Wrap(err, "failed to marshal signed operation")
 		}
+		if err := c.writeProvenance(exitOperationFilename); err != nil {
+			return "", err
+		}
 		if c.json {
 			return string(data), nil
 		}
@@ -48,3 +70,13 @@ func (c *command) output(_ context.Context) (string, error) {
 
 	return "", nil
 }
+
+// writeProvenance writes provenance for a newly-generated operation alongside
+// the given operation file, if requested.
+func (c *command) writeProvenance(filename string) error {
+	if !c.provenance || c.operationFile != "" {
+		return nil
+	}
+
+	return util.WriteProvenance(filename, util.NewProvenance(c.signingForkVersion, c.signingGenesisRoot))
+}
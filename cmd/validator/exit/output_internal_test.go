@@ -0,0 +1,72 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestOutputSSZ(t *testing.T) {
+	exitOperationSSZFilename = filepath.Join(t.TempDir(), "exit-operation.ssz")
+
+	c := &command{
+		ssz: true,
+		signedOperation: &phase0.SignedVoluntaryExit{
+			Message: &phase0.VoluntaryExit{
+				Epoch:          1,
+				ValidatorIndex: 2,
+			},
+			Signature: phase0.BLSSignature{0x01},
+		},
+	}
+
+	res, err := c.output(context.Background())
+	require.NoError(t, err)
+	expected := "0x" +
+		"0100000000000000" + // Epoch.
+		"0200000000000000" + // Validator index.
+		"01" + strings.Repeat("00", 95) // Signature.
+	require.Equal(t, expected, res)
+
+	data, err := os.ReadFile(exitOperationSSZFilename)
+	require.NoError(t, err)
+	require.Equal(t, expected, fmt.Sprintf("%#x", data))
+}
+
+func TestOutputOperationKnown(t *testing.T) {
+	c := &command{
+		operationStatus: &util.OperationStatus{
+			Included: true,
+			Slot:     12345,
+		},
+	}
+
+	res, err := c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Exit operation already included in block at slot 12345; not broadcasting", res)
+
+	c.operationStatus = &util.OperationStatus{}
+	res, err = c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Exit operation already in node's pool; not broadcasting", res)
+}
@@ -29,8 +29,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/ops"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
-	"github.com/wealdtech/ethdo/signing"
 	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	ethutil "github.com/wealdtech/go-eth2-util"
@@ -40,8 +40,13 @@ import (
 // validatorPath is the regular expression that matches a validator  path.
 var validatorPath = regexp.MustCompile("^m/12381/3600/[0-9]+/0/0$")
 
+// maxDistance is the number of consecutive indices of a mnemonic without a
+// validator after which the mnemonic is not scanned any further.
+const maxDistance = 1024
+
 var offlinePreparationFilename = "offline-preparation.json"
 var exitOperationFilename = "exit-operation.json"
+var exitOperationSSZFilename = "exit-operation.ssz"
 
 func (c *command) process(ctx context.Context) error {
 	if err := c.setup(ctx); err != nil {
@@ -56,6 +61,13 @@ func (c *command) process(ctx context.Context) error {
 		return c.writeChainInfoToFile(ctx)
 	}
 
+	if c.network != nil {
+		if err := c.chainInfo.ApplyNetwork(c.network); err != nil {
+			return err
+		}
+		util.Log.Debug().Str("network", c.network.Name).Msg("Using network parameters")
+	}
+
 	if err := c.generateDomain(ctx); err != nil {
 		return err
 	}
@@ -64,19 +76,45 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	if validated, reason := c.validateOperation(ctx); !validated {
-		return fmt.Errorf("operation failed validation: %s", reason)
+	if len(c.signedOperations) > 0 {
+		return c.processBatch(ctx)
 	}
 
-	if c.json || c.offline {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Not broadcasting exit operation\n")
-		}
-		// Want JSON output, or cannot broadcast.
+	if !c.json && !c.ssz && !c.offline && c.operationKnown(ctx) {
+		// Broadcasting again would achieve nothing, and the validator may
+		// no longer be in a state to pass validation.
+		return nil
+	}
+
+	if validated, reason := c.validateOperation(ctx, c.signedOperation); !validated {
+		return util.NewValidationError(fmt.Errorf("operation failed validation: %s", reason))
+	}
+
+	if err := c.reportExitQueue(ctx, []*phase0.SignedVoluntaryExit{c.signedOperation}); err != nil {
+		return err
+	}
+
+	if c.json || c.ssz || c.offline {
+		util.Log.Debug().Msg("Not broadcasting exit operation")
+		// Want JSON or SSZ output, or cannot broadcast.
 		return nil
 	}
 
-	return c.broadcastOperation(ctx)
+	if c.operationFile != "" {
+		if err := util.CheckProvenance(ctx, c.consensusClient, c.operationFile); err != nil {
+			return util.NewValidationError(err)
+		}
+	}
+
+	if err := c.confirmOperation(ctx); err != nil {
+		return err
+	}
+
+	if err := c.broadcastOperation(ctx); err != nil {
+		return util.NewBroadcastError(errors.Wrap(err, "node rejected operation"))
+	}
+
+	return nil
 }
 
 func (c *command) obtainOperation(ctx context.Context) error {
@@ -154,13 +192,10 @@ func (c *command) generateOperationFromMnemonicAndValidator(ctx context.Context)
 	}
 
 	// Scan the keys from the seed to find the path.
-	maxDistance := 1024
 	// Start scanning the validator keys.
 	for i := 0; ; i++ {
 		if i == maxDistance {
-			if c.debug {
-				fmt.Fprintf(os.Stderr, "Gone %d indices without finding the validator, not scanning any further\n", maxDistance)
-			}
+			util.Log.Debug().Int("distance", maxDistance).Msg("Validator not found, not scanning any further")
 			break
 		}
 		validatorKeyPath := fmt.Sprintf("m/12381/3600/%d/0/0", i)
@@ -214,16 +249,27 @@ func (c *command) generateOperationFromPrivateKey(ctx context.Context) error {
 }
 
 func (c *command) generateOperationFromValidator(ctx context.Context) error {
-	validatorInfo, err := c.chainInfo.FetchValidatorInfo(ctx, c.validator)
+	// Obtain the account first, and find the validator from its public key,
+	// to avoid opening the wallet again; this can be slow for remote wallets.
+	validatorAccount, err := util.ParseAccount(ctx, c.validator, c.passphrases, true)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator account")
+	}
+
+	validatorPubkey, err := util.BestPublicKey(validatorAccount)
 	if err != nil {
 		return err
 	}
 
-	validatorAccount, err := util.ParseAccount(ctx, c.validator, nil, true)
+	validatorInfo, err := c.chainInfo.FetchValidatorInfo(ctx, fmt.Sprintf("%#x", validatorPubkey.Marshal()))
 	if err != nil {
 		return err
 	}
 
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "Validator %d found with public key %s\n", validatorInfo.Index, validatorPubkey)
+	}
+
 	if err := c.generateOperationFromAccount(ctx, validatorInfo, validatorAccount, c.chainInfo.Epoch); err != nil {
 		return err
 	}
@@ -245,41 +291,73 @@ func (c *command) obtainOperationFromFile(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to read exit operation file")
 	}
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "%s found; loading operation\n", exitOperationFilename)
-	}
+	util.Log.Debug().Str("file", exitOperationFilename).Msg("Loading operation")
 	data, err := os.ReadFile(exitOperationFilename)
 	if err != nil {
 		return errors.Wrap(err, "failed to read exit operation file")
 	}
-	if err := json.Unmarshal(data, &c.signedOperation); err != nil {
-		return errors.Wrap(err, "failed to parse exit operation file")
-	}
+	c.operationFile = exitOperationFilename
 
-	if err := c.verifySignedOperation(ctx, c.signedOperation); err != nil {
-		return err
+	if err := c.parseOperations(data); err != nil {
+		return errors.Wrap(err, "failed to parse exit operation file")
 	}
 
-	return nil
+	return c.verifyOperations(ctx)
 }
 
 func (c *command) obtainOperationFromInput(ctx context.Context) error {
-	if !strings.HasPrefix(c.signedOperationInput, "{") {
+	if !strings.HasPrefix(c.signedOperationInput, "{") && !strings.HasPrefix(c.signedOperationInput, "[") {
 		// This looks like a file; read it in.
 		data, err := os.ReadFile(c.signedOperationInput)
 		if err != nil {
 			return errors.Wrap(err, "failed to read input file")
 		}
+		c.operationFile = c.signedOperationInput
 		c.signedOperationInput = string(data)
 	}
 
-	if err := json.Unmarshal([]byte(c.signedOperationInput), &c.signedOperation); err != nil {
+	if err := c.parseOperations([]byte(c.signedOperationInput)); err != nil {
 		return errors.Wrap(err, "failed to parse exit operation input")
 	}
 
-	if err := c.verifySignedOperation(ctx, c.signedOperation); err != nil {
+	return c.verifyOperations(ctx)
+}
+
+// parseOperations parses either a single signed exit operation or, for
+// batch mode, an array of them.
+func (c *command) parseOperations(data []byte) error {
+	if !strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		return json.Unmarshal(data, &c.signedOperation)
+	}
+
+	var ops []*phase0.SignedVoluntaryExit
+	if err := json.Unmarshal(data, &ops); err != nil {
 		return err
 	}
+	switch len(ops) {
+	case 0:
+		return errors.New("no exit operations supplied")
+	case 1:
+		// A single operation is handled as normal.
+		c.signedOperation = ops[0]
+	default:
+		c.signedOperations = ops
+	}
+
+	return nil
+}
+
+// verifyOperations verifies the signed exit operations.
+func (c *command) verifyOperations(ctx context.Context) error {
+	if len(c.signedOperations) == 0 {
+		return c.verifySignedOperation(ctx, c.signedOperation)
+	}
+
+	for _, op := range c.signedOperations {
+		if err := c.verifySignedOperation(ctx, op); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("exit operation for validator %d", op.Message.ValidatorIndex))
+		}
+	}
 
 	return nil
 }
@@ -316,38 +394,9 @@ func (c *command) createSignedOperation(ctx context.Context,
 	*phase0.SignedVoluntaryExit,
 	error,
 ) {
-	pubkey, err := util.BestPublicKey(account)
-	if err != nil {
-		return nil, err
-	}
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Using %#x as best public key for %s\n", pubkey.Marshal(), account.Name())
-	}
-	blsPubkey := phase0.BLSPubKey{}
-	copy(blsPubkey[:], pubkey.Marshal())
-
-	operation := &phase0.VoluntaryExit{
-		Epoch:          epoch,
-		ValidatorIndex: validator.Index,
-	}
-	root, err := operation.HashTreeRoot()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to generate root for exit operation")
-	}
-
-	// Sign the operation.
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Signing %#x with domain %#x by public key %#x\n", root, c.domain, account.PublicKey().Marshal())
-	}
-	signature, err := signing.SignRoot(ctx, account, nil, root, c.domain)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to sign exit operation")
-	}
+	util.Log.Debug().Str("account", account.Name()).Uint64("validator", uint64(validator.Index)).Str("domain", fmt.Sprintf("%#x", c.domain)).Msg("Signing exit operation")
 
-	return &phase0.SignedVoluntaryExit{
-		Message:   operation,
-		Signature: signature,
-	}, nil
+	return ops.BuildSignedVoluntaryExit(ctx, account, nil, validator.Index, epoch, c.domain)
 }
 
 func (c *command) verifySignedOperation(ctx context.Context, op *phase0.SignedVoluntaryExit) error {
@@ -395,13 +444,14 @@ func (c *command) verifySignedOperation(ctx context.Context, op *phase0.SignedVo
 }
 
 func (c *command) validateOperation(_ context.Context,
+	op *phase0.SignedVoluntaryExit,
 ) (
 	bool,
 	string,
 ) {
 	var validatorInfo *beacon.ValidatorInfo
 	for _, chainValidatorInfo := range c.chainInfo.Validators {
-		if chainValidatorInfo.Index == c.signedOperation.Message.ValidatorIndex {
+		if chainValidatorInfo.Index == op.Message.ValidatorIndex {
 			validatorInfo = chainValidatorInfo
 			break
 		}
@@ -409,10 +459,8 @@ func (c *command) validateOperation(_ context.Context,
 	if validatorInfo == nil {
 		return false, "validator not known on chain"
 	}
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Validator exit operation: %v", c.signedOperation)
-		fmt.Fprintf(os.Stderr, "On-chain validator info: %v\n", validatorInfo)
-	}
+	util.Log.Debug().Interface("operation", op).Msg("Generated validator exit operation")
+	util.Log.Debug().Interface("validator", validatorInfo).Msg("Obtained on-chain validator info")
 
 	if validatorInfo.State == apiv1.ValidatorStateActiveExiting ||
 		validatorInfo.State == apiv1.ValidatorStateActiveSlashed ||
@@ -426,6 +474,49 @@ func (c *command) validateOperation(_ context.Context,
 	return true, ""
 }
 
+// confirmOperation summarises the exit and requires the user to confirm it
+// before it is broadcast, as it cannot be undone.
+func (c *command) confirmOperation(ctx context.Context) error {
+	if c.yes {
+		return nil
+	}
+
+	index := fmt.Sprintf("%d", c.signedOperation.Message.ValidatorIndex)
+	validatorInfo, err := c.chainInfo.FetchValidatorInfo(ctx, index)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "About to broadcast a voluntary exit; once accepted by the network this cannot be undone.\n")
+	fmt.Fprintf(os.Stderr, "Validator index: %s\n", index)
+	fmt.Fprintf(os.Stderr, "Validator public key: %#x\n", validatorInfo.Pubkey)
+	fmt.Fprintf(os.Stderr, "Exit epoch: %d\n", c.signedOperation.Message.Epoch)
+
+	confirmed, err := c.prompter.ConfirmTyped("Type the validator index to confirm", index)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return errors.New("exit not confirmed; not broadcasting")
+	}
+
+	return nil
+}
+
+// operationKnown returns true if the operation is already in the node's pool
+// or included in a recent block.  Failure to check is not fatal, as the
+// operation can still be broadcast.
+func (c *command) operationKnown(ctx context.Context) bool {
+	status, err := util.VoluntaryExitStatus(ctx, c.consensusClient, c.timeout, c.signedOperation)
+	if err != nil {
+		util.Log.Debug().Err(err).Msg("Failed to check if exit operation is already known")
+		return false
+	}
+	c.operationStatus = status
+
+	return status != nil
+}
+
 func (c *command) broadcastOperation(ctx context.Context) error {
 	return c.consensusClient.(consensusclient.VoluntaryExitSubmitter).SubmitVoluntaryExit(ctx, c.signedOperation)
 }
@@ -446,6 +537,7 @@ func (c *command) setup(ctx context.Context) error {
 	c.chainTime, err = standardchaintime.New(ctx,
 		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
 		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.consensusClient.(consensusclient.ForkScheduleProvider)),
 	)
 	if err != nil {
 		return errors.Wrap(err, "failed to create chaintime service")
@@ -464,19 +556,13 @@ func (c *command) generateDomain(ctx context.Context) error {
 		return err
 	}
 
-	root, err := (&phase0.ForkData{
-		CurrentVersion:        forkVersion,
-		GenesisValidatorsRoot: genesisValidatorsRoot,
-	}).HashTreeRoot()
+	c.domain, err = ops.ComputeDomain(c.chainInfo.VoluntaryExitDomainType, forkVersion, genesisValidatorsRoot)
 	if err != nil {
-		return errors.Wrap(err, "failed to calculate signature domain")
-	}
-
-	copy(c.domain[:], c.chainInfo.VoluntaryExitDomainType[:])
-	copy(c.domain[4:], root[:])
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Domain is %#x\n", c.domain)
+		return err
 	}
+	c.signingForkVersion = forkVersion
+	c.signingGenesisRoot = genesisValidatorsRoot
+	util.Log.Debug().Str("domain", fmt.Sprintf("%#x", c.domain)).Msg("Obtained domain")
 
 	return nil
 }
@@ -485,9 +571,7 @@ func (c *command) obtainGenesisValidatorsRoot(ctx context.Context) (phase0.Root,
 	genesisValidatorsRoot := phase0.Root{}
 
 	if c.genesisValidatorsRoot != "" {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Genesis validators root supplied on the command line\n")
-		}
+		util.Log.Debug().Msg("Genesis validators root supplied on the command line")
 		root, err := hex.DecodeString(strings.TrimPrefix(c.genesisValidatorsRoot, "0x"))
 		if err != nil {
 			return phase0.Root{}, errors.Wrap(err, "invalid genesis validators root supplied")
@@ -497,15 +581,11 @@ func (c *command) obtainGenesisValidatorsRoot(ctx context.Context) (phase0.Root,
 		}
 		copy(genesisValidatorsRoot[:], root)
 	} else {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Genesis validators root obtained from chain info\n")
-		}
+		util.Log.Debug().Msg("Genesis validators root obtained from chain info")
 		copy(genesisValidatorsRoot[:], c.chainInfo.GenesisValidatorsRoot[:])
 	}
 
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Using genesis validators root %#x\n", genesisValidatorsRoot)
-	}
+	util.Log.Debug().Str("genesis_validators_root", fmt.Sprintf("%#x", genesisValidatorsRoot)).Msg("Using genesis validators root")
 	return genesisValidatorsRoot, nil
 }
 
@@ -513,9 +593,7 @@ func (c *command) obtainForkVersion(ctx context.Context) (phase0.Version, error)
 	forkVersion := phase0.Version{}
 
 	if c.forkVersion != "" {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Fork version supplied on the command line\n")
-		}
+		util.Log.Debug().Msg("Fork version supplied on the command line")
 		version, err := hex.DecodeString(strings.TrimPrefix(c.forkVersion, "0x"))
 		if err != nil {
 			return phase0.Version{}, errors.Wrap(err, "invalid fork version supplied")
@@ -525,15 +603,33 @@ func (c *command) obtainForkVersion(ctx context.Context) (phase0.Version, error)
 		}
 		copy(forkVersion[:], version)
 	} else {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Fork version obtained from chain info\n")
+		var fork string
+		switch c.domainFork {
+		case "genesis":
+			fork = "genesis"
+			copy(forkVersion[:], c.chainInfo.GenesisForkVersion[:])
+		case "current":
+			fork = "current"
+			copy(forkVersion[:], c.chainInfo.CurrentForkVersion[:])
+		case "capella":
+			if c.chainInfo.CapellaForkVersion == (phase0.Version{}) {
+				return phase0.Version{}, errors.New("capella fork version not known; please regenerate offline data or supply the fork version")
+			}
+			fork = "capella"
+			copy(forkVersion[:], c.chainInfo.CapellaForkVersion[:])
+		default:
+			// Use the fork version as defined by the spec for generating an exit.
+			var version phase0.Version
+			var err error
+			version, fork, err = c.chainInfo.VoluntaryExitForkVersion()
+			if err != nil {
+				return phase0.Version{}, errors.Wrap(err, "failed to obtain fork version; please regenerate offline data or supply the fork version")
+			}
+			copy(forkVersion[:], version[:])
 		}
-		// Use the current fork version for generating an exit as per the spec.
-		copy(forkVersion[:], c.chainInfo.CurrentForkVersion[:])
+		util.Log.Debug().Str("fork", fork).Msg("Fork version obtained from chain info")
 	}
 
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Using fork version %#x\n", forkVersion)
-	}
+	util.Log.Debug().Str("fork_version", fmt.Sprintf("%#x", forkVersion)).Msg("Using fork version")
 	return forkVersion, nil
 }
@@ -15,6 +15,7 @@ package validatorexit
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"testing"
 
@@ -22,6 +23,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/wealdtech/ethdo/beacon"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 func TestGenerateOperationFromMnemonicAndPath(t *testing.T) {
@@ -46,6 +52,8 @@ func TestGenerateOperationFromMnemonicAndPath(t *testing.T) {
 		GenesisValidatorsRoot: phase0.Root{},
 		Epoch:                 1,
 		CurrentForkVersion:    phase0.Version{},
+		CapellaForkVersion:    phase0.Version{0x03, 0x00, 0x00, 0x00},
+		CapellaForkEpoch:      0xffffffffffffffff,
 	}
 
 	tests := []struct {
@@ -124,6 +132,8 @@ func TestGenerateOperationFromMnemonicAndValidator(t *testing.T) {
 		GenesisValidatorsRoot: phase0.Root{},
 		Epoch:                 1,
 		CurrentForkVersion:    phase0.Version{},
+		CapellaForkVersion:    phase0.Version{0x03, 0x00, 0x00, 0x00},
+		CapellaForkEpoch:      0xffffffffffffffff,
 	}
 
 	tests := []struct {
@@ -226,6 +236,8 @@ func TestGenerateOperationFromSeedAndPath(t *testing.T) {
 		GenesisValidatorsRoot: phase0.Root{},
 		Epoch:                 1,
 		CurrentForkVersion:    phase0.Version{},
+		CapellaForkVersion:    phase0.Version{0x03, 0x00, 0x00, 0x00},
+		CapellaForkEpoch:      0xffffffffffffffff,
 	}
 	validators := make(map[string]*beacon.ValidatorInfo, len(chainInfo.Validators))
 	for i := range chainInfo.Validators {
@@ -334,6 +346,8 @@ func TestVerifyOperation(t *testing.T) {
 		GenesisValidatorsRoot: phase0.Root{},
 		Epoch:                 1,
 		CurrentForkVersion:    phase0.Version{},
+		CapellaForkVersion:    phase0.Version{0x03, 0x00, 0x00, 0x00},
+		CapellaForkEpoch:      0xffffffffffffffff,
 	}
 
 	tests := []struct {
@@ -433,20 +447,23 @@ func TestObtainOperationFromInput(t *testing.T) {
 		GenesisValidatorsRoot: phase0.Root{},
 		Epoch:                 1,
 		CurrentForkVersion:    phase0.Version{},
+		CapellaForkVersion:    phase0.Version{0x03, 0x00, 0x00, 0x00},
+		CapellaForkEpoch:      0xffffffffffffffff,
 	}
 
 	tests := []struct {
-		name    string
-		command *command
-		err     string
+		name       string
+		command    *command
+		operations int
+		err        string
 	}{
 		{
 			name: "InvalidFilename",
 			command: &command{
-				signedOperationInput: `[]`,
+				signedOperationInput: `missing.json`,
 				chainInfo:            chainInfo,
 			},
-			err: "failed to read input file: open []: no such file or directory",
+			err: "failed to read input file: open missing.json: no such file or directory",
 		},
 		{
 			name: "InvalidJSON",
@@ -471,6 +488,37 @@ func TestObtainOperationFromInput(t *testing.T) {
 				chainInfo:            chainInfo,
 			},
 		},
+		{
+			name: "BatchEmpty",
+			command: &command{
+				signedOperationInput: `[]`,
+				chainInfo:            chainInfo,
+			},
+			err: "failed to parse exit operation input: no exit operations supplied",
+		},
+		{
+			name: "BatchUnverifiable",
+			command: &command{
+				signedOperationInput: `[{"message":{"epoch":"1","validator_index":"0"},"signature":"0x89f5c44288f95e19b6c139f2623005665b983462a228120977d81f2ef547560be22446de21a8a937d9dda4e2d2ec4175196496cdd1306dec4a125f8c861f806171504a9d6a610ec4e135047e4fb67052ecc4561360d0c3de04b6fbc4474223ff"},{"message":{"epoch":"1","validator_index":"0"},"signature":"0x9978b49c21603f04a3044e4c490cb4687c6e14c2daed2592e0022dcd63ebe74af11acabaae50e18a1dae96d9d256bf9f02488505c1fbb34a0b68ecc5b5f5ea53dbd00908e31ea8ca9d02083b9ef1c7d232f4bad9ea564bc587d527b774978aee"}]`,
+				chainInfo:            chainInfo,
+			},
+			err: "exit operation for validator 0: signature does not verify",
+		},
+		{
+			name: "BatchSingle",
+			command: &command{
+				signedOperationInput: `[{"message":{"epoch":"1","validator_index":"0"},"signature":"0x89f5c44288f95e19b6c139f2623005665b983462a228120977d81f2ef547560be22446de21a8a937d9dda4e2d2ec4175196496cdd1306dec4a125f8c861f806171504a9d6a610ec4e135047e4fb67052ecc4561360d0c3de04b6fbc4474223ff"}]`,
+				chainInfo:            chainInfo,
+			},
+		},
+		{
+			name: "Batch",
+			command: &command{
+				signedOperationInput: `[{"message":{"epoch":"1","validator_index":"0"},"signature":"0x89f5c44288f95e19b6c139f2623005665b983462a228120977d81f2ef547560be22446de21a8a937d9dda4e2d2ec4175196496cdd1306dec4a125f8c861f806171504a9d6a610ec4e135047e4fb67052ecc4561360d0c3de04b6fbc4474223ff"},{"message":{"epoch":"1","validator_index":"0"},"signature":"0x89f5c44288f95e19b6c139f2623005665b983462a228120977d81f2ef547560be22446de21a8a937d9dda4e2d2ec4175196496cdd1306dec4a125f8c861f806171504a9d6a610ec4e135047e4fb67052ecc4561360d0c3de04b6fbc4474223ff"}]`,
+				chainInfo:            chainInfo,
+			},
+			operations: 2,
+		},
 	}
 
 	for _, test := range tests {
@@ -480,6 +528,92 @@ func TestObtainOperationFromInput(t *testing.T) {
 				require.EqualError(t, err, test.err)
 			} else {
 				require.NoError(t, err)
+				require.Len(t, test.command.signedOperations, test.operations)
+			}
+		})
+	}
+}
+
+func TestGenerateOperationFromValidator(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+
+	store := scratch.New()
+	require.NoError(t, e2wallet.UseStore(store))
+	testWallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, testWallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	privKey, err := hex.DecodeString("25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866")
+	require.NoError(t, err)
+	_, err = testWallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, "Interop 0", privKey, []byte("pass"))
+	require.NoError(t, err)
+	require.NoError(t, testWallet.(e2wtypes.WalletLocker).Lock(ctx))
+
+	pubKey, err := hex.DecodeString("a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c")
+	require.NoError(t, err)
+	chainInfo := &beacon.ChainInfo{
+		Version: 1,
+		Validators: []*beacon.ValidatorInfo{
+			{
+				Index: 5,
+			},
+		},
+		GenesisValidatorsRoot: phase0.Root{},
+		Epoch:                 1,
+		CurrentForkVersion:    phase0.Version{},
+		CapellaForkVersion:    phase0.Version{0x03, 0x00, 0x00, 0x00},
+		CapellaForkEpoch:      0xffffffffffffffff,
+	}
+	copy(chainInfo.Validators[0].Pubkey[:], pubKey)
+
+	tests := []struct {
+		name     string
+		command  *command
+		expected *phase0.VoluntaryExit
+		err      string
+	}{
+		{
+			name: "AccountUnknown",
+			command: &command{
+				validator:   "Test wallet/Unknown",
+				passphrases: []string{"pass"},
+				chainInfo:   chainInfo,
+			},
+			err: "failed to obtain validator account: unable to obtain account: failed to obtain account",
+		},
+		{
+			name: "PassphraseIncorrect",
+			command: &command{
+				validator:   "Test wallet/Interop 0",
+				passphrases: []string{"bad"},
+				chainInfo:   chainInfo,
+			},
+			err: "failed to obtain validator account: failed to unlock account: failed to unlock account",
+		},
+		{
+			name: "Good",
+			command: &command{
+				validator:   "Test wallet/Interop 0",
+				passphrases: []string{"pass"},
+				chainInfo:   chainInfo,
+			},
+			expected: &phase0.VoluntaryExit{
+				Epoch:          1,
+				ValidatorIndex: 5,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.generateOperationFromValidator(ctx)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, test.command.signedOperation.Message)
+				require.NotEqual(t, phase0.BLSSignature{}, test.command.signedOperation.Signature)
 			}
 		})
 	}
@@ -0,0 +1,64 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// reportExitQueue estimates when each of the exits would take effect given
+// the current exit queue, so that the timing can be checked before the exits
+// are broadcast.
+func (c *command) reportExitQueue(ctx context.Context, ops []*phase0.SignedVoluntaryExit) error {
+	if !c.estimateExitQueue || c.quiet {
+		return nil
+	}
+
+	queue, err := beacon.ObtainExitQueue(ctx, c.consensusClient, c.chainTime)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain exit queue")
+	}
+
+	if queue.Electra {
+		fmt.Fprintf(os.Stderr, "Exit queue: %d validators (%s) exiting, at most %s per epoch\n",
+			queue.Length,
+			string2eth.GWeiToString(uint64(queue.Balance), true),
+			string2eth.GWeiToString(uint64(queue.BalanceChurnLimit), true),
+		)
+	} else {
+		fmt.Fprintf(os.Stderr, "Exit queue: %d validators exiting, at most %d per epoch\n", queue.Length, queue.ChurnLimit)
+	}
+	for _, op := range ops {
+		exitEpoch, withdrawableEpoch, err := queue.Add(op.Message.ValidatorIndex)
+		if err != nil {
+			return errors.Wrap(err, "failed to estimate exit")
+		}
+		fmt.Fprintf(os.Stderr, "Validator %d: estimated exit epoch %d (%s), withdrawable epoch %d (%s)\n",
+			op.Message.ValidatorIndex,
+			exitEpoch,
+			c.chainTime.StartOfEpoch(exitEpoch).Format("2006-01-02 15:04:05"),
+			withdrawableEpoch,
+			c.chainTime.StartOfEpoch(withdrawableEpoch).Format("2006-01-02 15:04:05"),
+		)
+	}
+
+	return nil
+}
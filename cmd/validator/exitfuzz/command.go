@@ -15,6 +15,7 @@ package validatorexit
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	consensusclient "github.com/attestantio/go-eth2-client"
@@ -87,6 +88,18 @@ func newCommand(_ context.Context) (*command, error) {
 		return nil, errors.New("timeout is required")
 	}
 
+	// A validator held in a wallet, including a remote wallet, can also be
+	// specified with the account flag.
+	if viper.GetString("account") != "" {
+		if c.validator != "" {
+			return nil, errors.New("only one of account and validator is allowed")
+		}
+		if !strings.Contains(viper.GetString("account"), "/") {
+			return nil, errors.New("account must be in the format wallet/account")
+		}
+		c.validator = viper.GetString("account")
+	}
+
 	// We are generating information for offline use, we don't need any information
 	// related to the accounts or signing.
 	if c.prepareOffline {
@@ -68,9 +68,7 @@ func (c *command) process(ctx context.Context) error {
 	// }
 
 	if c.json || c.offline {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Not broadcasting credentials change operations\n")
-		}
+		util.Log.Debug().Msg("Not broadcasting credentials change operations")
 		// Want JSON output, or cannot broadcast.
 		return nil
 	}
@@ -161,9 +159,7 @@ func (c *command) generateOperationFromMnemonicAndValidator(ctx context.Context)
 	// Start scanning the validator keys.
 	for i := 0; ; i++ {
 		if i == maxDistance {
-			if c.debug {
-				fmt.Fprintf(os.Stderr, "Gone %d indices without finding the validator, not scanning any further\n", maxDistance)
-			}
+			util.Log.Debug().Int("distance", maxDistance).Msg("Validator not found, not scanning any further")
 			break
 		}
 		validatorKeyPath := fmt.Sprintf("m/12381/3600/%d/0/0", i)
@@ -211,9 +207,7 @@ func (c *command) generateOperationFromMnemonic(ctx context.Context) error {
 	lastFoundIndex := 0
 	for i := 0; ; i++ {
 		if i-lastFoundIndex > maxDistance {
-			if c.debug {
-				fmt.Fprintf(os.Stderr, "Gone %d indices without finding a validator, not scanning any further\n", maxDistance)
-			}
+			util.Log.Debug().Int("distance", maxDistance).Msg("No validators found, not scanning any further")
 			break
 		}
 		validatorKeyPath := fmt.Sprintf("m/12381/3600/%d/0/0", i)
@@ -259,16 +253,27 @@ func (c *command) generateOperationFromPrivateKey(ctx context.Context) error {
 }
 
 func (c *command) generateOperationFromValidator(ctx context.Context) error {
-	validatorInfo, err := c.chainInfo.FetchValidatorInfo(ctx, c.validator)
+	// Obtain the account first, and find the validator from its public key,
+	// to avoid opening the wallet again; this can be slow for remote wallets.
+	validatorAccount, err := util.ParseAccount(ctx, c.validator, c.passphrases, true)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator account")
+	}
+
+	validatorPubkey, err := util.BestPublicKey(validatorAccount)
 	if err != nil {
 		return err
 	}
 
-	validatorAccount, err := util.ParseAccount(ctx, c.validator, nil, true)
+	validatorInfo, err := c.chainInfo.FetchValidatorInfo(ctx, fmt.Sprintf("%#x", validatorPubkey.Marshal()))
 	if err != nil {
 		return err
 	}
 
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "Validator %d found with public key %s\n", validatorInfo.Index, validatorPubkey)
+	}
+
 	if err := c.generateOperationFromAccount(ctx, validatorInfo, validatorAccount, c.chainInfo.Epoch); err != nil {
 		return err
 	}
@@ -290,9 +295,7 @@ func (c *command) obtainOperationFromFile(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to read exit operation file")
 	}
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "%s found; loading operation\n", exitOperationFilename)
-	}
+	util.Log.Debug().Str("file", exitOperationFilename).Msg("Loading operation")
 	data, err := os.ReadFile(exitOperationFilename)
 	if err != nil {
 		return errors.Wrap(err, "failed to read exit operation file")
@@ -353,29 +356,38 @@ func (c *command) generateOperationFromAccount(ctx context.Context,
 	return err
 }
 
-func FuzzinessAct() bool {
+// FuzzinessAct returns true if the given fuzzing strategy should be applied.
+// Strategies are "message", "root" and "signature"; if no strategies are
+// specified then all are enabled.
+func FuzzinessAct(strategy string) bool {
+	if strategies := viper.GetStringSlice("fuzz-strategies"); len(strategies) > 0 {
+		enabled := false
+		for _, s := range strategies {
+			if s == strategy {
+				enabled = true
+				break
+			}
+		}
+		if !enabled {
+			return false
+		}
+	}
 	fuzziness := viper.GetInt("fuzziness")
 	return fuzziness > rand.Intn(100)
 }
 
 func (c *command) fuzzExitMessage(operation *phase0.VoluntaryExit) *phase0.VoluntaryExit {
-	if c.debug {
-		fmt.Println("before fuzzing: ", operation)
-		fmt.Println()
-	}
+	util.Log.Debug().Interface("operation", operation).Msg("Before fuzzing")
 	// fuzz validator index
-	if FuzzinessAct() {
+	if FuzzinessAct("message") {
 		operation.ValidatorIndex = phase0.ValidatorIndex(rand.Intn(1000000))
 	}
 
 	// fuzz Epoch
-	if FuzzinessAct() {
+	if FuzzinessAct("message") {
 		operation.Epoch = phase0.Epoch(rand.Intn(1000000))
 	}
-	if c.debug {
-		fmt.Println("after fuzzing: ", operation)
-		fmt.Println()
-	}
+	util.Log.Debug().Interface("operation", operation).Msg("After fuzzing")
 
 	return operation
 }
@@ -386,7 +398,7 @@ func (c *command) fuzzExitMessageWithRoot(operation *phase0.VoluntaryExit, root
 	operation = c.fuzzExitMessage(operation)
 
 	// fuzz root
-	if FuzzinessAct() {
+	if FuzzinessAct("root") {
 		testcase := make([]byte, 32)
 		rand.Read(testcase)
 		copy(root[:], testcase)
@@ -401,7 +413,7 @@ func (c *command) fuzzExitMessageWithSignature(operation *phase0.VoluntaryExit,
 	operation = c.fuzzExitMessage(operation)
 
 	// fuzz signature
-	if FuzzinessAct() {
+	if FuzzinessAct("signature") {
 		testcase := make([]byte, 96)
 		rand.Read(testcase)
 		copy(signature[:], testcase)
@@ -415,9 +427,7 @@ func (c *command) InitializeFuzzingSeed() int64 {
 		seed = rand.Int63()
 	}
 	rand.Seed(seed)
-	if c.debug {
-		fmt.Println("fuzzing with seed", seed)
-	}
+	util.Log.Debug().Int64("seed", seed).Msg("Fuzzing")
 	return seed
 }
 
@@ -435,9 +445,7 @@ func (c *command) createSignedOperation(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Using %#x as best public key for %s\n", pubkey.Marshal(), account.Name())
-	}
+	util.Log.Debug().Str("account", account.Name()).Str("pubkey", fmt.Sprintf("%#x", pubkey.Marshal())).Msg("Obtained best public key")
 	blsPubkey := phase0.BLSPubKey{}
 	copy(blsPubkey[:], pubkey.Marshal())
 
@@ -455,9 +463,7 @@ func (c *command) createSignedOperation(ctx context.Context,
 	}
 
 	// Sign the operation.
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Signing %#x with domain %#x by public key %#x\n", root, c.domain, account.PublicKey().Marshal())
-	}
+	util.Log.Debug().Str("root", fmt.Sprintf("%#x", root)).Str("domain", fmt.Sprintf("%#x", c.domain)).Str("pubkey", fmt.Sprintf("%#x", pubkey.Marshal())).Msg("Signing")
 	// fuzz before signature
 	operation, root = c.fuzzExitMessageWithRoot(operation, root)
 
@@ -571,6 +577,7 @@ func (c *command) setup(ctx context.Context) error {
 	c.chainTime, err = standardchaintime.New(ctx,
 		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
 		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.consensusClient.(consensusclient.ForkScheduleProvider)),
 	)
 	if err != nil {
 		return errors.Wrap(err, "failed to create chaintime service")
@@ -599,9 +606,7 @@ func (c *command) generateDomain(ctx context.Context) error {
 
 	copy(c.domain[:], c.chainInfo.VoluntaryExitDomainType[:])
 	copy(c.domain[4:], root[:])
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Domain is %#x\n", c.domain)
-	}
+	util.Log.Debug().Str("domain", fmt.Sprintf("%#x", c.domain)).Msg("Obtained domain")
 
 	return nil
 }
@@ -610,9 +615,7 @@ func (c *command) obtainGenesisValidatorsRoot(ctx context.Context) (phase0.Root,
 	genesisValidatorsRoot := phase0.Root{}
 
 	if c.genesisValidatorsRoot != "" {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Genesis validators root supplied on the command line\n")
-		}
+		util.Log.Debug().Msg("Genesis validators root supplied on the command line")
 		root, err := hex.DecodeString(strings.TrimPrefix(c.genesisValidatorsRoot, "0x"))
 		if err != nil {
 			return phase0.Root{}, errors.Wrap(err, "invalid genesis validators root supplied")
@@ -622,15 +625,11 @@ func (c *command) obtainGenesisValidatorsRoot(ctx context.Context) (phase0.Root,
 		}
 		copy(genesisValidatorsRoot[:], root)
 	} else {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Genesis validators root obtained from chain info\n")
-		}
+		util.Log.Debug().Msg("Genesis validators root obtained from chain info")
 		copy(genesisValidatorsRoot[:], c.chainInfo.GenesisValidatorsRoot[:])
 	}
 
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Using genesis validators root %#x\n", genesisValidatorsRoot)
-	}
+	util.Log.Debug().Str("genesis_validators_root", fmt.Sprintf("%#x", genesisValidatorsRoot)).Msg("Using genesis validators root")
 	return genesisValidatorsRoot, nil
 }
 
@@ -638,9 +637,7 @@ func (c *command) obtainForkVersion(ctx context.Context) (phase0.Version, error)
 	forkVersion := phase0.Version{}
 
 	if c.forkVersion != "" {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Fork version supplied on the command line\n")
-		}
+		util.Log.Debug().Msg("Fork version supplied on the command line")
 		version, err := hex.DecodeString(strings.TrimPrefix(c.forkVersion, "0x"))
 		if err != nil {
 			return phase0.Version{}, errors.Wrap(err, "invalid fork version supplied")
@@ -650,15 +647,15 @@ func (c *command) obtainForkVersion(ctx context.Context) (phase0.Version, error)
 		}
 		copy(forkVersion[:], version)
 	} else {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "Fork version obtained from chain info\n")
+		// Use the fork version as defined by the spec for generating an exit.
+		version, fork, err := c.chainInfo.VoluntaryExitForkVersion()
+		if err != nil {
+			return phase0.Version{}, err
 		}
-		// Use the current fork version for generating an exit as per the spec.
-		copy(forkVersion[:], c.chainInfo.CurrentForkVersion[:])
+		util.Log.Debug().Str("fork", fork).Msg("Fork version obtained from chain info")
+		copy(forkVersion[:], version[:])
 	}
 
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Using fork version %#x\n", forkVersion)
-	}
+	util.Log.Debug().Str("fork_version", fmt.Sprintf("%#x", forkVersion)).Msg("Using fork version")
 	return forkVersion, nil
 }
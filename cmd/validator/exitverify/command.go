@@ -0,0 +1,90 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexitverify
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	offline bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	signedOperation string
+	stdin           io.Reader
+
+	// Processing.
+	consensusClient consensusclient.Service
+	chainTime       chaintime.Service
+	chainInfo       *beacon.ChainInfo
+	operationFile   string
+	provenance      *util.Provenance
+
+	// Output.
+	checks []*beacon.ExitCheck
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:           viper.GetBool("quiet"),
+		verbose:         viper.GetBool("verbose"),
+		debug:           viper.GetBool("debug"),
+		offline:         viper.GetBool("offline"),
+		json:            viper.GetBool("json"),
+		signedOperation: viper.GetString("signed-operation"),
+		stdin:           os.Stdin,
+		checks:          make([]*beacon.ExitCheck, 0),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	return c, nil
+}
+
+// passed returns true if all checks passed.
+func (c *command) passed() bool {
+	for _, check := range c.checks {
+		if !check.Passed {
+			return false
+		}
+	}
+
+	return true
+}
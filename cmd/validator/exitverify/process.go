@@ -0,0 +1,183 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexitverify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+var offlinePreparationFilename = "offline-preparation.json"
+var exitOperationFilename = "exit-operation.json"
+
+func (c *command) process(ctx context.Context) error {
+	data, err := c.obtainExitData()
+	if err != nil {
+		return err
+	}
+
+	exit, err := beacon.ParseSignedVoluntaryExit(data)
+	if err != nil {
+		c.addCheck("Exit contains only voluntary exit data", false, err.Error())
+		// Nothing more can be checked.
+		return nil
+	}
+	c.addCheck("Exit contains only voluntary exit data", true, "")
+
+	if err := c.obtainChainInfo(ctx, []string{fmt.Sprintf("%d", exit.Message.ValidatorIndex)}); err != nil {
+		return err
+	}
+
+	if c.operationFile != "" {
+		c.provenance, err = util.ReadProvenance(c.operationFile)
+		if err != nil {
+			c.addCheck("Operation created for this network", false, err.Error())
+		}
+	}
+
+	c.verify(ctx, exit)
+
+	return nil
+}
+
+// verify verifies the exit against the chain information.
+func (c *command) verify(ctx context.Context, exit *phase0.SignedVoluntaryExit) {
+	if c.provenance != nil {
+		c.checkProvenance(ctx)
+	}
+	validatorInfo := c.checkValidator(ctx, exit)
+	if validatorInfo != nil {
+		c.checks = append(c.checks, c.chainInfo.CheckVoluntaryExitSignature(exit, validatorInfo.Pubkey, c.chainInfo.GenesisValidatorsRoot)...)
+	}
+	c.checks = append(c.checks, c.chainInfo.CheckVoluntaryExitEpoch(exit))
+}
+
+// obtainExitData obtains the signed exit, which can be supplied directly, as
+// a path to a file, or on standard input.
+func (c *command) obtainExitData() ([]byte, error) {
+	switch {
+	case c.signedOperation == "-":
+		data, err := io.ReadAll(c.stdin)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read exit from standard input")
+		}
+		return data, nil
+	case strings.HasPrefix(strings.TrimSpace(c.signedOperation), "{"):
+		return []byte(c.signedOperation), nil
+	case c.signedOperation != "":
+		data, err := os.ReadFile(c.signedOperation)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read exit file")
+		}
+		c.operationFile = c.signedOperation
+		return data, nil
+	default:
+		data, err := os.ReadFile(exitOperationFilename)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("no signed operation supplied, and failed to read %s", exitOperationFilename))
+		}
+		c.operationFile = exitOperationFilename
+		return data, nil
+	}
+}
+
+// obtainChainInfo obtains chain information from the offline preparation
+// file if offline, otherwise from the beacon node.
+func (c *command) obtainChainInfo(ctx context.Context, validators []string) error {
+	if c.offline {
+		data, err := os.ReadFile(offlinePreparationFilename)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to read %s", offlinePreparationFilename))
+		}
+		c.chainInfo = &beacon.ChainInfo{}
+		if err := json.Unmarshal(data, c.chainInfo); err != nil {
+			return errors.Wrap(err, "failed to parse offline preparation file")
+		}
+		return nil
+	}
+
+	var err error
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+		standardchaintime.WithForkScheduleProvider(c.consensusClient.(consensusclient.ForkScheduleProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	c.chainInfo, err = beacon.ObtainChainInfoFromNodeForValidators(ctx, c.consensusClient, c.chainTime, validators)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain chain information")
+	}
+
+	return nil
+}
+
+func (c *command) addCheck(name string, passed bool, detail string) {
+	c.checks = append(c.checks, &beacon.ExitCheck{
+		Name:   name,
+		Passed: passed,
+		Detail: detail,
+	})
+}
+
+// checkProvenance checks that the exit was created for the network of the chain.
+func (c *command) checkProvenance(_ context.Context) {
+	name := "Operation created for this network"
+	if err := c.provenance.CheckGenesisValidatorsRoot(c.chainInfo.GenesisValidatorsRoot); err != nil {
+		c.addCheck(name, false, err.Error())
+		return
+	}
+	c.addCheck(name, true, fmt.Sprintf("created by ethdo %s for %s with fork version %#x", c.provenance.EthdoVersion, c.provenance.Network, c.provenance.ForkVersion))
+}
+
+// checkValidator checks that the validator in the exit is known and able to exit,
+// returning the validator's information if it is known.
+func (c *command) checkValidator(ctx context.Context, exit *phase0.SignedVoluntaryExit) *beacon.ValidatorInfo {
+	name := "Validator is known"
+	validatorInfo, err := c.chainInfo.FetchValidatorInfo(ctx, fmt.Sprintf("%d", exit.Message.ValidatorIndex))
+	if err != nil {
+		c.addCheck(name, false, fmt.Sprintf("validator %d: %v", exit.Message.ValidatorIndex, err))
+		return nil
+	}
+	c.addCheck(name, true, fmt.Sprintf("validator %d with public key %#x", validatorInfo.Index, validatorInfo.Pubkey))
+
+	name = "Validator is able to exit"
+	switch validatorInfo.State {
+	case apiv1.ValidatorStateActiveOngoing:
+		c.addCheck(name, true, "")
+	default:
+		c.addCheck(name, false, fmt.Sprintf("validator is in state %v", validatorInfo.State))
+	}
+
+	return validatorInfo
+}
@@ -0,0 +1,197 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexitverify
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestObtainExitData(t *testing.T) {
+	exit := `{"message":{"epoch":"1","validator_index":"2"},"signature":"0x01"}`
+
+	tests := []struct {
+		name            string
+		signedOperation string
+		stdin           string
+		res             string
+		err             string
+	}{
+		{
+			name:            "JSON",
+			signedOperation: exit,
+			res:             exit,
+		},
+		{
+			name:            "Stdin",
+			signedOperation: "-",
+			stdin:           exit,
+			res:             exit,
+		},
+		{
+			name:            "FileMissing",
+			signedOperation: "missing.json",
+			err:             "failed to read exit file: open missing.json: no such file or directory",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				signedOperation: test.signedOperation,
+				stdin:           strings.NewReader(test.stdin),
+			}
+			res, err := c.obtainExitData()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, string(res))
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	privateKey, err := e2types.BLSPrivateKeyFromBytes([]byte{
+		0x25, 0x29, 0x5f, 0x0d, 0x1d, 0x59, 0x2a, 0x90, 0xb3, 0x33, 0xe2, 0x6e, 0x85, 0x14, 0x97, 0x08,
+		0x20, 0x8e, 0x9f, 0x8e, 0x8b, 0xc1, 0x8f, 0x6c, 0x77, 0xbd, 0x62, 0xf8, 0xad, 0x7a, 0x68, 0x66,
+	})
+	require.NoError(t, err)
+	var pubkey phase0.BLSPubKey
+	copy(pubkey[:], privateKey.PublicKey().Marshal())
+
+	chainInfo := &beacon.ChainInfo{
+		Version: 3,
+		Validators: []*beacon.ValidatorInfo{
+			{
+				Index:  2,
+				Pubkey: pubkey,
+				State:  apiv1.ValidatorStateActiveOngoing,
+			},
+			{
+				Index:  3,
+				Pubkey: pubkey,
+				State:  apiv1.ValidatorStateExitedUnslashed,
+			},
+		},
+		GenesisValidatorsRoot:   phase0.Root{0x01},
+		Epoch:                   200,
+		GenesisForkVersion:      phase0.Version{0x00, 0x00, 0x00, 0x00},
+		CurrentForkVersion:      phase0.Version{0x04, 0x00, 0x00, 0x00},
+		CapellaForkVersion:      phase0.Version{0x03, 0x00, 0x00, 0x00},
+		CapellaForkEpoch:        100,
+		VoluntaryExitDomainType: phase0.DomainType{0x04, 0x00, 0x00, 0x00},
+	}
+
+	// sign signs an exit with the given fork version.
+	sign := func(index phase0.ValidatorIndex, epoch phase0.Epoch, forkVersion phase0.Version) *phase0.SignedVoluntaryExit {
+		exit := &phase0.SignedVoluntaryExit{
+			Message: &phase0.VoluntaryExit{
+				Epoch:          epoch,
+				ValidatorIndex: index,
+			},
+		}
+		signingRoot, err := chainInfo.VoluntaryExitSigningRoot(exit.Message, forkVersion, chainInfo.GenesisValidatorsRoot)
+		require.NoError(t, err)
+		copy(exit.Signature[:], privateKey.Sign(signingRoot[:]).Marshal())
+		return exit
+	}
+
+	tests := []struct {
+		name       string
+		exit       *phase0.SignedVoluntaryExit
+		provenance *util.Provenance
+		failed     []string
+	}{
+		{
+			name: "Good",
+			exit: sign(2, 150, chainInfo.CapellaForkVersion),
+		},
+		{
+			name:   "ValidatorUnknown",
+			exit:   sign(4, 150, chainInfo.CapellaForkVersion),
+			failed: []string{"Validator is known"},
+		},
+		{
+			name:   "ValidatorExited",
+			exit:   sign(3, 150, chainInfo.CapellaForkVersion),
+			failed: []string{"Validator is able to exit"},
+		},
+		{
+			name:   "WrongDomain",
+			exit:   sign(2, 150, chainInfo.CurrentForkVersion),
+			failed: []string{"Exit signed with expected domain"},
+		},
+		{
+			name:   "UnknownDomain",
+			exit:   sign(2, 150, phase0.Version{0x99}),
+			failed: []string{"Exit signature is valid", "Exit signed with expected domain"},
+		},
+		{
+			name:   "FutureEpoch",
+			exit:   sign(2, 250, chainInfo.CapellaForkVersion),
+			failed: []string{"Exit epoch is not in the future"},
+		},
+		{
+			name: "ProvenanceGood",
+			exit: sign(2, 150, chainInfo.CapellaForkVersion),
+			provenance: &util.Provenance{
+				ForkVersion:           chainInfo.CapellaForkVersion,
+				GenesisValidatorsRoot: chainInfo.GenesisValidatorsRoot,
+			},
+		},
+		{
+			name: "ProvenanceWrongNetwork",
+			exit: sign(2, 150, chainInfo.CapellaForkVersion),
+			provenance: &util.Provenance{
+				ForkVersion:           chainInfo.CapellaForkVersion,
+				GenesisValidatorsRoot: phase0.Root{0x02},
+			},
+			failed: []string{"Operation created for this network"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				chainInfo:  chainInfo,
+				provenance: test.provenance,
+				checks:     make([]*beacon.ExitCheck, 0),
+			}
+			c.verify(context.Background(), test.exit)
+			failed := make([]string, 0)
+			for _, check := range c.checks {
+				if !check.Passed {
+					failed = append(failed, check.Name)
+				}
+			}
+			if len(test.failed) == 0 {
+				require.True(t, c.passed())
+			} else {
+				require.Equal(t, test.failed, failed)
+			}
+		})
+	}
+}
@@ -18,8 +18,11 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
+	string2eth "github.com/wealdtech/go-string2eth"
 )
 
 type command struct {
@@ -34,13 +37,33 @@ type command struct {
 
 	// Input.
 	validators int64
+	balance    phase0.Gwei
 
 	// Data access.
-	eth2Client         eth2client.Service
-	validatorsProvider eth2client.ValidatorsProvider
-	activeValidators   int
+	eth2Client             eth2client.Service
+	validatorsProvider     eth2client.ValidatorsProvider
+	epoch                  phase0.Epoch
+	activeValidators       int
+	totalActiveBalance     phase0.Gwei
+	activationQueue        int
+	exitQueue              int
+	exitQueueBalance       phase0.Gwei
+	pendingDeposits        int
+	pendingDepositsBalance phase0.Gwei
+
+	// Chain parameters.
+	spec                map[string]interface{}
+	slotDuration        time.Duration
+	slotsPerEpoch       uint64
+	epochsPerPeriod     uint64
+	syncCommitteeSize   uint64
+	maxSeedLookahead    uint64
+	maxEffectiveBalance phase0.Gwei
 
 	// Output.
+	churn                     *beacon.Churn
+	activationWait            time.Duration
+	exitWait                  time.Duration
 	timeBetweenProposals      time.Duration
 	timeBetweenSyncCommittees time.Duration
 }
@@ -66,5 +89,17 @@ func newCommand(ctx context.Context) (*command, error) {
 		return nil, errors.New("validators must be at least 1")
 	}
 
+	if viper.GetString("balance") == "" {
+		return nil, errors.New("balance is required")
+	}
+	balance, err := string2eth.StringToGWei(viper.GetString("balance"))
+	if err != nil {
+		return nil, errors.Wrap(err, "balance is invalid")
+	}
+	c.balance = phase0.Gwei(balance)
+	if c.balance < 1000000000 {
+		return nil, errors.New("balance must be at least 1 Ether")
+	}
+
 	return c, nil
 }
@@ -46,10 +46,30 @@ func TestInput(t *testing.T) {
 			},
 			err: "validators must be at least 1",
 		},
+		{
+			name: "BalanceMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": "1",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+			},
+			err: "balance is required",
+		},
+		{
+			name: "BalanceLow",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": "1",
+				"balance":    "0.5 Ether",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+			},
+			err: "balance must be at least 1 Ether",
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
 				"validators": "1",
+				"balance":    "32 Ether",
 				"timeout":    "5s",
 				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
 			},
@@ -15,9 +15,11 @@ package validatorexpectation
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/hako/durafmt"
+	string2eth "github.com/wealdtech/go-string2eth"
 )
 
 func (c *command) output(ctx context.Context) (string, error) {
@@ -27,6 +29,33 @@ func (c *command) output(ctx context.Context) (string, error) {
 
 	builder := strings.Builder{}
 
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Active validators: %d\n", c.activeValidators))
+		if c.churn.Electra {
+			builder.WriteString(fmt.Sprintf("Pending deposits: %d (%s; churn %s per epoch)\n",
+				c.pendingDeposits,
+				string2eth.GWeiToString(uint64(c.pendingDepositsBalance), true),
+				string2eth.GWeiToString(uint64(c.churn.ActivationBalanceChurn), true),
+			))
+			builder.WriteString(fmt.Sprintf("Exit queue: %d (%s; churn %s per epoch)\n",
+				c.exitQueue,
+				string2eth.GWeiToString(uint64(c.exitQueueBalance), true),
+				string2eth.GWeiToString(uint64(c.churn.ExitBalanceChurn), true),
+			))
+		} else {
+			builder.WriteString(fmt.Sprintf("Activation queue: %d (churn %d per epoch)\n", c.activationQueue, c.churn.ActivationChurn))
+			builder.WriteString(fmt.Sprintf("Exit queue: %d (churn %d per epoch)\n", c.exitQueue, c.churn.ExitChurn))
+		}
+	}
+
+	builder.WriteString("Expected activation wait: ")
+	builder.WriteString(durafmt.Parse(c.activationWait).LimitFirstN(2).String())
+	builder.WriteString("\n")
+
+	builder.WriteString("Expected exit wait: ")
+	builder.WriteString(durafmt.Parse(c.exitWait).LimitFirstN(2).String())
+	builder.WriteString("\n")
+
 	builder.WriteString("Expected time between block proposals: ")
 	builder.WriteString(durafmt.Parse(c.timeBetweenProposals).LimitFirstN(2).String())
 	builder.WriteString("\n")
@@ -16,10 +16,13 @@ package validatorexpectation
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
 )
@@ -30,8 +33,17 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	if c.debug {
-		fmt.Printf("Active validators: %d\n", c.activeValidators)
+	util.Log.Debug().Int("active_validators", c.activeValidators).Msg("Obtained active validators")
+	util.Log.Debug().Uint64("total_active_balance", uint64(c.totalActiveBalance)).Msg("Obtained total active balance")
+	util.Log.Debug().Int("activation_queue", c.activationQueue).Msg("Obtained activation queue")
+	util.Log.Debug().Int("exit_queue", c.exitQueue).Msg("Obtained exit queue")
+
+	if err := c.obtainSpec(ctx); err != nil {
+		return err
+	}
+
+	if err := c.calculateQueueWaits(ctx); err != nil {
+		return err
 	}
 
 	if err := c.calculateProposalChance(ctx); err != nil {
@@ -41,81 +53,126 @@ func (c *command) process(ctx context.Context) error {
 	return c.calculateSyncCommitteeChance(ctx)
 }
 
-func (c *command) calculateProposalChance(ctx context.Context) error {
-	// Chance of proposing a block is 1/activeValidators.
-	// Expectation of number of slots before proposing a block is 1/p, == activeValidators slots.
-
-	spec, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx)
+func (c *command) calculateQueueWaits(_ context.Context) error {
+	var err error
+	c.churn, err = beacon.CalculateChurn(c.spec, c.epoch, uint64(c.activeValidators), c.totalActiveBalance)
 	if err != nil {
-		return err
+		return errors.Wrap(err, "failed to calculate churn")
+	}
+	util.Log.Debug().Uint64("activation_churn", c.churn.ActivationChurn).Uint64("activation_balance_churn", uint64(c.churn.ActivationBalanceChurn)).Msg("Obtained activation churn")
+	util.Log.Debug().Uint64("exit_churn", c.churn.ExitChurn).Uint64("exit_balance_churn", uint64(c.churn.ExitBalanceChurn)).Msg("Obtained exit churn")
+
+	// Validators are processed after those already in the queue, and then have to wait
+	// for the activation or exit epoch.
+	delayEpochs := 1 + c.maxSeedLookahead
+	epochDuration := c.slotDuration * time.Duration(c.slotsPerEpoch)
+
+	var activationEpochs, exitEpochs uint64
+	if c.churn.Electra {
+		// From Electra onwards deposits are processed from the pending deposits
+		// queue, limited by both balance and number, and exits are limited by
+		// balance.
+		depositBalance := c.pendingDepositsBalance + c.balance*phase0.Gwei(c.validators)
+		activationEpochs = beacon.QueueEpochs(uint64(depositBalance), uint64(c.churn.ActivationBalanceChurn))
+		depositEpochs := beacon.QueueEpochs(uint64(c.pendingDeposits)+uint64(c.validators), c.churn.MaxDepositsPerEpoch)
+		if depositEpochs > activationEpochs {
+			activationEpochs = depositEpochs
+		}
+		exitBalance := c.exitQueueBalance + c.effectiveBalance()*phase0.Gwei(c.validators)
+		exitEpochs = beacon.QueueEpochs(uint64(exitBalance), uint64(c.churn.ExitBalanceChurn))
+	} else {
+		activationEpochs = beacon.QueueEpochs(uint64(c.activationQueue)+uint64(c.validators), c.churn.ActivationChurn)
+		exitEpochs = beacon.QueueEpochs(uint64(c.exitQueue)+uint64(c.validators), c.churn.ExitChurn)
 	}
+	c.activationWait = epochDuration * time.Duration(activationEpochs+delayEpochs)
+	c.exitWait = epochDuration * time.Duration(exitEpochs+delayEpochs)
 
-	tmp, exists := spec["SECONDS_PER_SLOT"]
-	if !exists {
-		return errors.New("spec missing SECONDS_PER_SLOT")
-	}
-	slotDuration, isType := tmp.(time.Duration)
-	if !isType {
-		return errors.New("SECONDS_PER_SLOT of incorrect type")
-	}
+	return nil
+}
+
+func (c *command) calculateProposalChance(_ context.Context) error {
+	// Chance of proposing a block is balance/totalActiveBalance.
+	// Expectation of number of slots before proposing a block is 1/p, == totalActiveBalance/balance slots.
+	slotsBetweenProposals := float64(c.totalActiveBalance) / float64(c.effectiveBalance()) / float64(c.validators)
+	util.Log.Debug().Float64("slots", slotsBetweenProposals).Msg("Calculated slots between proposals")
+
+	c.timeBetweenProposals = time.Duration(math.Round(float64(c.slotDuration) * slotsBetweenProposals))
 
-	c.timeBetweenProposals = slotDuration * time.Duration(c.activeValidators) / time.Duration(c.validators)
+	return nil
+}
+
+func (c *command) calculateSyncCommitteeChance(_ context.Context) error {
+	// Chance of being in a sync committee is SYNC_COMMITTEE_SIZE*balance/totalActiveBalance.
+	// Expectation of number of periods before being in a sync committee is 1/p, totalActiveBalance/(SYNC_COMMITTEE_SIZE*balance) periods.
+	periodsBetweenSyncCommittees := float64(c.totalActiveBalance) / float64(c.syncCommitteeSize) / float64(c.effectiveBalance()) / float64(c.validators)
+	util.Log.Debug().Float64("periods", periodsBetweenSyncCommittees).Msg("Calculated sync committee periods between inclusion")
+
+	periodDuration := c.slotDuration * time.Duration(c.slotsPerEpoch*c.epochsPerPeriod)
+	c.timeBetweenSyncCommittees = time.Duration(math.Round(float64(periodDuration) * periodsBetweenSyncCommittees))
 
 	return nil
 }
 
-func (c *command) calculateSyncCommitteeChance(ctx context.Context) error {
-	// Chance of being in a sync committee is SYNC_COMMITTEE_SIZE/activeValidators.
-	// Expectation of number of periods before being in a sync committee is 1/p, activeValidators/SYNC_COMMITTEE_SIZE periods.
+// effectiveBalance provides the effective balance of the validators, capped by the chain's maximum.
+func (c *command) effectiveBalance() phase0.Gwei {
+	if c.maxEffectiveBalance != 0 && c.balance > c.maxEffectiveBalance {
+		return c.maxEffectiveBalance
+	}
+	return c.balance
+}
 
+func (c *command) obtainSpec(ctx context.Context) error {
 	spec, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx)
 	if err != nil {
-		return err
+		return errors.Wrap(err, "failed to obtain spec")
 	}
 
 	tmp, exists := spec["SECONDS_PER_SLOT"]
 	if !exists {
 		return errors.New("spec missing SECONDS_PER_SLOT")
 	}
-	slotDuration, isType := tmp.(time.Duration)
+	var isType bool
+	c.slotDuration, isType = tmp.(time.Duration)
 	if !isType {
 		return errors.New("SECONDS_PER_SLOT of incorrect type")
 	}
 
-	tmp, exists = spec["SYNC_COMMITTEE_SIZE"]
-	if !exists {
-		return errors.New("spec missing SYNC_COMMITTEE_SIZE")
+	values := map[string]*uint64{
+		"SLOTS_PER_EPOCH":                  &c.slotsPerEpoch,
+		"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": &c.epochsPerPeriod,
+		"SYNC_COMMITTEE_SIZE":              &c.syncCommitteeSize,
+		"MAX_SEED_LOOKAHEAD":               &c.maxSeedLookahead,
 	}
-	syncCommitteeSize, isType := tmp.(uint64)
-	if !isType {
-		return errors.New("SYNC_COMMITTEE_SIZE of incorrect type")
+	for key, value := range values {
+		tmp, exists := spec[key]
+		if !exists {
+			return fmt.Errorf("spec missing %s", key)
+		}
+		*value, isType = tmp.(uint64)
+		if !isType {
+			return fmt.Errorf("%s of incorrect type", key)
+		}
 	}
 
-	tmp, exists = spec["SLOTS_PER_EPOCH"]
-	if !exists {
-		return errors.New("spec missing SLOTS_PER_EPOCH")
-	}
-	slotsPerEpoch, isType := tmp.(uint64)
-	if !isType {
-		return errors.New("SLOTS_PER_EPOCH of incorrect type")
-	}
+	// Churn values are obtained from the spec when calculating the churn.
+	c.spec = spec
 
-	tmp, exists = spec["EPOCHS_PER_SYNC_COMMITTEE_PERIOD"]
-	if !exists {
-		return errors.New("spec missing EPOCHS_PER_SYNC_COMMITTEE_PERIOD")
+	// Maximum effective balance is increased from Electra onwards.
+	maxEffectiveBalanceKey := "MAX_EFFECTIVE_BALANCE"
+	if _, exists := spec["MAX_EFFECTIVE_BALANCE_ELECTRA"]; exists {
+		maxEffectiveBalanceKey = "MAX_EFFECTIVE_BALANCE_ELECTRA"
 	}
-	epochsPerPeriod, isType := tmp.(uint64)
-	if !isType {
-		return errors.New("EPOCHS_PER_SYNC_COMMITTEE_PERIOD of incorrect type")
+	if tmp, exists := spec[maxEffectiveBalanceKey]; exists {
+		maxEffectiveBalance, isType := tmp.(uint64)
+		if !isType {
+			return fmt.Errorf("%s of incorrect type", maxEffectiveBalanceKey)
+		}
+		c.maxEffectiveBalance = phase0.Gwei(maxEffectiveBalance)
 	}
-
-	periodsBetweenSyncCommittees := uint64(c.activeValidators) / syncCommitteeSize
-	if c.debug {
-		fmt.Printf("Sync committee periods between inclusion: %d\n", periodsBetweenSyncCommittees)
+	if c.balance > c.effectiveBalance() {
+		util.Log.Debug().Uint64("balance", uint64(c.effectiveBalance())).Msg("Balance capped")
 	}
 
-	c.timeBetweenSyncCommittees = slotDuration * time.Duration(slotsPerEpoch*epochsPerPeriod) * time.Duration(periodsBetweenSyncCommittees) / time.Duration(c.validators)
-
 	return nil
 }
 
@@ -136,7 +193,7 @@ func (c *command) setup(ctx context.Context) error {
 		return errors.Wrap(err, "failed to set up chaintime service")
 	}
 
-	// Obtain the number of active validators.
+	// Obtain the live validator set.
 	var isProvider bool
 	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
 	if !isProvider {
@@ -149,10 +206,44 @@ func (c *command) setup(ctx context.Context) error {
 	}
 
 	currentEpoch := chainTime.CurrentEpoch()
+	c.epoch = currentEpoch
 	for _, validator := range validators {
+		if validator.Validator == nil {
+			continue
+		}
 		if validator.Validator.ActivationEpoch <= currentEpoch &&
 			validator.Validator.ExitEpoch > currentEpoch {
 			c.activeValidators++
+			c.totalActiveBalance += validator.Validator.EffectiveBalance
+		}
+		if validator.Validator.ActivationEligibilityEpoch <= currentEpoch &&
+			validator.Validator.ActivationEpoch > currentEpoch {
+			c.activationQueue++
+		}
+		if validator.Validator.ExitEpoch != 0xffffffffffffffff &&
+			validator.Validator.ExitEpoch > currentEpoch {
+			c.exitQueue++
+			c.exitQueueBalance += validator.Validator.EffectiveBalance
+		}
+	}
+
+	spec, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	electra, err := beacon.IsElectra(spec, currentEpoch)
+	if err != nil {
+		return err
+	}
+	if electra {
+		// Activations are limited by the pending deposits queue from Electra onwards.
+		pendingDeposits, err := beacon.ObtainPendingDeposits(ctx, c.eth2Client.Address(), c.timeout, "head")
+		if err != nil {
+			return err
+		}
+		c.pendingDeposits = len(pendingDeposits)
+		for _, pendingDeposit := range pendingDeposits {
+			c.pendingDepositsBalance += pendingDeposit.Amount
 		}
 	}
 
@@ -17,7 +17,9 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 )
@@ -61,3 +63,113 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculations(t *testing.T) {
+	spec := map[string]interface{}{
+		"MIN_PER_EPOCH_CHURN_LIMIT":            uint64(4),
+		"CHURN_LIMIT_QUOTIENT":                 uint64(65536),
+		"MAX_EFFECTIVE_BALANCE":                uint64(32000000000),
+		"MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT": uint64(8),
+	}
+	electraSpec := map[string]interface{}{
+		"CHURN_LIMIT_QUOTIENT":                      uint64(65536),
+		"ELECTRA_FORK_EPOCH":                        uint64(0),
+		"MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA":         uint64(128000000000),
+		"MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT": uint64(256000000000),
+		"EFFECTIVE_BALANCE_INCREMENT":               uint64(1000000000),
+		"MIN_ACTIVATION_BALANCE":                    uint64(32000000000),
+		"MAX_PENDING_DEPOSITS_PER_EPOCH":            uint64(16),
+	}
+
+	tests := []struct {
+		name                      string
+		spec                      map[string]interface{}
+		balance                   phase0.Gwei
+		maxEffectiveBalance       phase0.Gwei
+		activationWait            time.Duration
+		exitWait                  time.Duration
+		timeBetweenProposals      time.Duration
+		timeBetweenSyncCommittees time.Duration
+	}{
+		{
+			name:                      "Standard",
+			spec:                      spec,
+			balance:                   32000000000,
+			maxEffectiveBalance:       32000000000,
+			activationWait:            7680 * time.Second,
+			exitWait:                  2304 * time.Second,
+			timeBetweenProposals:      6000000 * time.Second,
+			timeBetweenSyncCommittees: 96000000 * time.Second,
+		},
+		{
+			name:                      "BalanceCapped",
+			spec:                      spec,
+			balance:                   64000000000,
+			maxEffectiveBalance:       32000000000,
+			activationWait:            7680 * time.Second,
+			exitWait:                  2304 * time.Second,
+			timeBetweenProposals:      6000000 * time.Second,
+			timeBetweenSyncCommittees: 96000000 * time.Second,
+		},
+		{
+			name:                      "BalanceIncreased",
+			spec:                      spec,
+			balance:                   64000000000,
+			maxEffectiveBalance:       2048000000000,
+			activationWait:            7680 * time.Second,
+			exitWait:                  2304 * time.Second,
+			timeBetweenProposals:      3000000 * time.Second,
+			timeBetweenSyncCommittees: 48000000 * time.Second,
+		},
+		{
+			name:                      "Electra",
+			spec:                      electraSpec,
+			balance:                   32000000000,
+			maxEffectiveBalance:       2048000000000,
+			activationWait:            7296 * time.Second,
+			exitWait:                  2304 * time.Second,
+			timeBetweenProposals:      6000000 * time.Second,
+			timeBetweenSyncCommittees: 96000000 * time.Second,
+		},
+		{
+			name:                      "ElectraBalanceIncreased",
+			spec:                      electraSpec,
+			balance:                   2048000000000,
+			maxEffectiveBalance:       2048000000000,
+			activationWait:            10368 * time.Second,
+			exitWait:                  5376 * time.Second,
+			timeBetweenProposals:      93750 * time.Second,
+			timeBetweenSyncCommittees: 1500000 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				validators:             1,
+				balance:                test.balance,
+				epoch:                  1000,
+				activeValidators:       500000,
+				totalActiveBalance:     500000 * 32000000000,
+				activationQueue:        100,
+				pendingDeposits:        100,
+				pendingDepositsBalance: 100 * 32000000000,
+				spec:                   test.spec,
+				slotDuration:           12 * time.Second,
+				slotsPerEpoch:          32,
+				epochsPerPeriod:        256,
+				syncCommitteeSize:      512,
+				maxSeedLookahead:       4,
+				maxEffectiveBalance:    test.maxEffectiveBalance,
+			}
+			ctx := context.Background()
+			require.NoError(t, c.calculateQueueWaits(ctx))
+			require.NoError(t, c.calculateProposalChance(ctx))
+			require.NoError(t, c.calculateSyncCommitteeChance(ctx))
+			require.Equal(t, test.activationWait, c.activationWait)
+			require.Equal(t, test.exitWait, c.exitWait)
+			require.Equal(t, test.timeBetweenProposals, c.timeBetweenProposals)
+			require.Equal(t, test.timeBetweenSyncCommittees, c.timeBetweenSyncCommittees)
+		})
+	}
+}
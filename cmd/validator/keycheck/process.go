@@ -22,8 +22,9 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/tyler-smith/go-bip39"
+	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
-	util "github.com/wealdtech/go-eth2-util"
+	ethutil "github.com/wealdtech/go-eth2-util"
 	"golang.org/x/text/unicode/norm"
 )
 
@@ -76,7 +77,7 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 func checkPrivKey(ctx context.Context, debug bool, validatorWithdrawalCredentials []byte, key *e2types.BLSPrivateKey) (bool, error) {
 	pubKey := key.PublicKey()
 
-	withdrawalCredentials := util.SHA256(pubKey.Marshal())
+	withdrawalCredentials := ethutil.SHA256(pubKey.Marshal())
 	withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
 
 	return bytes.Equal(withdrawalCredentials, validatorWithdrawalCredentials), nil
@@ -103,10 +104,8 @@ func checkMnemonic(ctx context.Context, debug bool, validatorWithdrawalCredentia
 	// Check first 1024 indices.
 	for i := 0; i < 1024; i++ {
 		path := fmt.Sprintf("m/12381/3600/%d/0", i)
-		if debug {
-			fmt.Printf("Checking path %s\n", path)
-		}
-		key, err := util.PrivateKeyFromSeedAndPath(seed, path)
+		util.Log.Debug().Str("path", path).Msg("Checking path")
+		key, err := ethutil.PrivateKeyFromSeedAndPath(seed, path)
 		if err != nil {
 			return false, "", errors.Wrap(err, "failed to generate key")
 		}
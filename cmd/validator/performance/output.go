@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.outputFormat != "" {
+		return util.RenderOutput(c.outputFormat, c.results)
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.results.FirstEpoch == c.results.LastEpoch {
+		builder.WriteString(fmt.Sprintf("Epoch %d:\n", c.results.FirstEpoch))
+	} else {
+		builder.WriteString(fmt.Sprintf("Epochs %d-%d:\n", c.results.FirstEpoch, c.results.LastEpoch))
+	}
+
+	table, err := util.RenderOutput(util.OutputFormatTable, c.results)
+	if err != nil {
+		return "", err
+	}
+	builder.WriteString(table)
+
+	return builder.String(), nil
+}
@@ -0,0 +1,89 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	results := &results{
+		FirstEpoch: 100,
+		LastEpoch:  109,
+		Validators: []*validatorPerformance{
+			{
+				Rank:               1,
+				Index:              2,
+				Score:              100,
+				AttestationScore:   100,
+				AttestationDuties:  10,
+				Attestations:       10,
+				CorrectHeads:       10,
+				CorrectTargets:     10,
+				InclusionDelay:     1,
+				ProposalDuties:     1,
+				Proposals:          1,
+				SyncDuties:         64,
+				SyncParticipations: 64,
+			},
+			{
+				Rank:              2,
+				Index:             1,
+				Score:             80,
+				AttestationScore:  80,
+				AttestationDuties: 10,
+				Attestations:      9,
+				CorrectHeads:      8,
+				CorrectTargets:    9,
+				InclusionDelay:    1.25,
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		jsonOutput   bool
+		outputFormat string
+		res          string
+	}{
+		{
+			name: "Text",
+			res: `Epochs 100-109:
+Rank  Validator  Score    Attestations  Head  Target  Delay  Proposals  Sync
+1     2          100.00%  10/10         10    10      1.00   1/1        64/64
+2     1          80.00%   9/10          8     9       1.25   -          -`,
+		},
+		{
+			name:       "JSON",
+			jsonOutput: true,
+			res:        `{"first_epoch":100,"last_epoch":109,"validators":[{"rank":1,"index":2,"score":100,"attestation_score":100,"attestation_duties":10,"attestations":10,"correct_heads":10,"correct_targets":10,"inclusion_delay":1,"proposal_duties":1,"proposals":1,"sync_duties":64,"sync_participations":64},{"rank":2,"index":1,"score":80,"attestation_score":80,"attestation_duties":10,"attestations":9,"correct_heads":8,"correct_targets":9,"inclusion_delay":1.25,"proposal_duties":0,"proposals":0,"sync_duties":0,"sync_participations":0}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				jsonOutput:   test.jsonOutput,
+				outputFormat: test.outputFormat,
+				results:      results,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// duties are the duties of the validators over the epochs.
+type duties struct {
+	// attesters are the attester duties, by slot and committee index.
+	attesters map[phase0.Slot]map[phase0.CommitteeIndex][]*apiv1.AttesterDuty
+	// proposers are the proposer duties, by slot.
+	proposers map[phase0.Slot]phase0.ValidatorIndex
+	// syncCommittees are the positions of the validators in the sync
+	// committee, by epoch.  A validator can appear in the sync committee
+	// more than once.
+	syncCommittees map[phase0.Epoch]map[int]phase0.ValidatorIndex
+}
+
+// vote identifies the attestation of a validator for a slot.
+type vote struct {
+	validator phase0.ValidatorIndex
+	slot      phase0.Slot
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	// By default use the most recent epoch for which all attestations can
+	// have been included.
+	epoch := c.epoch
+	if epoch == "" {
+		epoch = "-2"
+	}
+	var err error
+	c.results.LastEpoch, err = util.ParseEpoch(ctx, c.chainTime, epoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse epoch")
+	}
+	if uint64(c.results.LastEpoch) >= c.epochs {
+		c.results.FirstEpoch = c.results.LastEpoch + 1 - phase0.Epoch(c.epochs)
+	}
+
+	validators, err := util.ParseValidators(ctx, c.eth2Client.(eth2client.ValidatorsProvider), c.validators, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validators")
+	}
+	performances := make(map[phase0.ValidatorIndex]*validatorPerformance, len(validators))
+	indices := make([]phase0.ValidatorIndex, 0, len(validators))
+	c.results.Validators = make([]*validatorPerformance, 0, len(validators))
+	for _, validator := range validators {
+		if _, exists := performances[validator.Index]; exists {
+			// Duplicate.
+			continue
+		}
+		performances[validator.Index] = &validatorPerformance{
+			Index: validator.Index,
+		}
+		indices = append(indices, validator.Index)
+		c.results.Validators = append(c.results.Validators, performances[validator.Index])
+	}
+
+	duties, err := c.obtainDuties(ctx, indices, performances)
+	if err != nil {
+		return err
+	}
+
+	if err := c.processSlots(ctx, duties, performances); err != nil {
+		return err
+	}
+
+	rank(c.results.Validators)
+
+	return nil
+}
+
+// obtainDuties obtains the duties of the validators over the epochs.
+func (c *command) obtainDuties(ctx context.Context,
+	indices []phase0.ValidatorIndex,
+	performances map[phase0.ValidatorIndex]*validatorPerformance,
+) (
+	*duties,
+	error,
+) {
+	res := &duties{
+		attesters:      make(map[phase0.Slot]map[phase0.CommitteeIndex][]*apiv1.AttesterDuty),
+		proposers:      make(map[phase0.Slot]phase0.ValidatorIndex),
+		syncCommittees: make(map[phase0.Epoch]map[int]phase0.ValidatorIndex),
+	}
+
+	for epoch := c.results.FirstEpoch; epoch <= c.results.LastEpoch; epoch++ {
+		attesterDuties, err := c.eth2Client.(eth2client.AttesterDutiesProvider).AttesterDuties(ctx, epoch, indices)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain attester duties for epoch %d", epoch))
+		}
+		for _, duty := range attesterDuties {
+			performance, exists := performances[duty.ValidatorIndex]
+			if !exists {
+				continue
+			}
+			performance.AttestationDuties++
+			if _, exists := res.attesters[duty.Slot]; !exists {
+				res.attesters[duty.Slot] = make(map[phase0.CommitteeIndex][]*apiv1.AttesterDuty)
+			}
+			res.attesters[duty.Slot][duty.CommitteeIndex] = append(res.attesters[duty.Slot][duty.CommitteeIndex], duty)
+		}
+
+		proposerDuties, err := c.eth2Client.(eth2client.ProposerDutiesProvider).ProposerDuties(ctx, epoch, indices)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain proposer duties for epoch %d", epoch))
+		}
+		for _, duty := range proposerDuties {
+			performance, exists := performances[duty.ValidatorIndex]
+			if !exists {
+				continue
+			}
+			performance.ProposalDuties++
+			res.proposers[duty.Slot] = duty.ValidatorIndex
+		}
+
+		if epoch < c.chainTime.AltairInitialEpoch() {
+			// No sync committees prior to Altair.
+			continue
+		}
+		syncCommittee, err := c.eth2Client.(eth2client.SyncCommitteesProvider).SyncCommitteeAtEpoch(ctx, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch)), epoch)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain sync committee for epoch %d", epoch))
+		}
+		if syncCommittee == nil {
+			return nil, fmt.Errorf("no sync committee returned for epoch %d", epoch)
+		}
+		positions := make(map[int]phase0.ValidatorIndex)
+		for i, index := range syncCommittee.Validators {
+			if _, exists := performances[index]; exists {
+				positions[i] = index
+			}
+		}
+		res.syncCommittees[epoch] = positions
+	}
+
+	return res, nil
+}
+
+// processSlots processes the blocks that contain the results of the duties.
+func (c *command) processSlots(ctx context.Context,
+	duties *duties,
+	performances map[phase0.ValidatorIndex]*validatorPerformance,
+) error {
+	firstSlot := c.chainTime.FirstSlotOfEpoch(c.results.FirstEpoch)
+	lastDutySlot := c.chainTime.FirstSlotOfEpoch(c.results.LastEpoch+1) - 1
+	// Attestations can be included up to the end of the following epoch.
+	lastSlot := c.chainTime.FirstSlotOfEpoch(c.results.LastEpoch+2) - 1
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+
+	headersCache := util.NewBeaconBlockHeaderCache(c.eth2Client.(eth2client.BeaconBlockHeadersProvider))
+	blockSlots := make(map[phase0.Slot]bool)
+	votes := make(map[vote]struct{})
+
+	progress := util.NewProgress("Processing blocks", int(lastSlot-firstSlot+1))
+	defer progress.Finish()
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		progress.Add(1)
+		block, err := c.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if block == nil {
+			// No block at this slot; that's fine.
+			continue
+		}
+		blockSlots[slot] = true
+
+		if slot <= lastDutySlot {
+			if proposer, exists := duties.proposers[slot]; exists {
+				performances[proposer].Proposals++
+			}
+			if err := c.processSyncAggregate(block, slot, duties, performances); err != nil {
+				return err
+			}
+		}
+
+		attestations, err := block.Attestations()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain attestations for slot %d", slot))
+		}
+		for _, attestation := range attestations {
+			slotDuties, exists := duties.attesters[attestation.Data.Slot]
+			if !exists {
+				continue
+			}
+			committeeDuties, exists := slotDuties[attestation.Data.Index]
+			if !exists {
+				continue
+			}
+			for _, duty := range committeeDuties {
+				if !attestation.AggregationBits.BitAt(duty.ValidatorCommitteeIndex) {
+					continue
+				}
+				key := vote{validator: duty.ValidatorIndex, slot: duty.Slot}
+				if _, exists := votes[key]; exists {
+					// Already included; only the first inclusion counts.
+					continue
+				}
+				votes[key] = struct{}{}
+
+				headCorrect, err := util.AttestationHeadCorrect(ctx, headersCache, attestation)
+				if err != nil {
+					return errors.Wrap(err, "failed to calculate if attestation had correct head vote")
+				}
+				targetCorrect, err := util.AttestationTargetCorrect(ctx, headersCache, c.chainTime, attestation)
+				if err != nil {
+					return errors.Wrap(err, "failed to calculate if attestation had correct target vote")
+				}
+				performances[duty.ValidatorIndex].addAttestation(headCorrect,
+					targetCorrect,
+					slot-duty.Slot,
+					minInclusionDelay(blockSlots, duty.Slot, slot),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// processSyncAggregate processes the sync aggregate of a block.  Slots without
+// blocks are not counted against sync committee members.
+func (c *command) processSyncAggregate(block *spec.VersionedSignedBeaconBlock,
+	slot phase0.Slot,
+	duties *duties,
+	performances map[phase0.ValidatorIndex]*validatorPerformance,
+) error {
+	positions, exists := duties.syncCommittees[c.chainTime.SlotToEpoch(slot)]
+	if !exists || len(positions) == 0 {
+		return nil
+	}
+
+	aggregate, err := beacon.SyncAggregate(block)
+	if err != nil {
+		return err
+	}
+	for position, index := range positions {
+		performances[index].SyncDuties++
+		if aggregate.SyncCommitteeBits.BitAt(uint64(position)) {
+			performances[index].SyncParticipations++
+		}
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	if _, isProvider := c.eth2Client.(eth2client.AttesterDutiesProvider); !isProvider {
+		return errors.New("connection does not provide attester duties")
+	}
+	if _, isProvider := c.eth2Client.(eth2client.ProposerDutiesProvider); !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	if _, isProvider := c.eth2Client.(eth2client.SyncCommitteesProvider); !isProvider {
+		return errors.New("connection does not provide sync committees")
+	}
+	if _, isProvider := c.eth2Client.(eth2client.SignedBeaconBlockProvider); !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	if _, isProvider := c.eth2Client.(eth2client.BeaconBlockHeadersProvider); !isProvider {
+		return errors.New("connection does not provide beacon block headers")
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Weights from the Altair specification, used to combine the components of
+// the score in proportion to the rewards available for each duty.
+const (
+	timelySourceWeight = 14
+	timelyTargetWeight = 26
+	timelyHeadWeight   = 14
+	syncRewardWeight   = 2
+	proposerWeight     = 8
+	attestationWeight  = timelySourceWeight + timelyTargetWeight + timelyHeadWeight
+)
+
+type results struct {
+	FirstEpoch phase0.Epoch            `json:"first_epoch"`
+	LastEpoch  phase0.Epoch            `json:"last_epoch"`
+	Validators []*validatorPerformance `json:"validators"`
+}
+
+type validatorPerformance struct {
+	Rank               int                   `json:"rank"`
+	Index              phase0.ValidatorIndex `json:"index"`
+	Score              float64               `json:"score"`
+	AttestationScore   float64               `json:"attestation_score"`
+	AttestationDuties  int                   `json:"attestation_duties"`
+	Attestations       int                   `json:"attestations"`
+	CorrectHeads       int                   `json:"correct_heads"`
+	CorrectTargets     int                   `json:"correct_targets"`
+	InclusionDelay     float64               `json:"inclusion_delay"`
+	ProposalDuties     int                   `json:"proposal_duties"`
+	Proposals          int                   `json:"proposals"`
+	SyncDuties         int                   `json:"sync_duties"`
+	SyncParticipations int                   `json:"sync_participations"`
+
+	// Running totals, used to calculate the averages.
+	attestationScores float64
+	inclusionDelays   uint64
+}
+
+// addAttestation adds an included attestation to the validator's performance.
+// The minimum inclusion delay is the earliest that the attestation could have
+// been included, given the blocks that were produced after its slot.
+func (p *validatorPerformance) addAttestation(headCorrect bool,
+	targetCorrect bool,
+	inclusionDelay phase0.Slot,
+	minInclusionDelay phase0.Slot,
+) {
+	p.Attestations++
+	if headCorrect {
+		p.CorrectHeads++
+	}
+	if targetCorrect {
+		p.CorrectTargets++
+	}
+	p.inclusionDelays += uint64(inclusionDelay)
+	p.attestationScores += attestationScore(headCorrect, targetCorrect, inclusionDelay, minInclusionDelay)
+}
+
+// attestationScore scores a single included attestation.  The correctness of
+// the attestation is weighted by the rewards for each of its votes, and this
+// is scaled by the delay in inclusion beyond the earliest possible, so that a
+// validator is not penalised for slots without blocks.
+func attestationScore(headCorrect bool,
+	targetCorrect bool,
+	inclusionDelay phase0.Slot,
+	minInclusionDelay phase0.Slot,
+) float64 {
+	correctness := timelySourceWeight
+	if targetCorrect {
+		correctness += timelyTargetWeight
+	}
+	if headCorrect {
+		correctness += timelyHeadWeight
+	}
+
+	excessDelay := phase0.Slot(0)
+	if inclusionDelay > minInclusionDelay {
+		excessDelay = inclusionDelay - minInclusionDelay
+	}
+
+	return float64(correctness) / float64(attestationWeight) / float64(1+excessDelay)
+}
+
+// minInclusionDelay calculates the minimum possible inclusion delay for an
+// attestation, being the distance to the first block after its slot.
+func minInclusionDelay(blockSlots map[phase0.Slot]bool, attestationSlot phase0.Slot, inclusionSlot phase0.Slot) phase0.Slot {
+	for slot := attestationSlot + 1; slot < inclusionSlot; slot++ {
+		if blockSlots[slot] {
+			return slot - attestationSlot
+		}
+	}
+
+	return inclusionSlot - attestationSlot
+}
+
+// calculateScore calculates the overall score of the validator, as a
+// percentage.  Only components for which the validator had duties contribute
+// to the score.
+func (p *validatorPerformance) calculateScore() {
+	p.AttestationScore = 0
+	p.InclusionDelay = 0
+	p.Score = 0
+
+	total := 0.0
+	weights := 0.0
+	if p.AttestationDuties > 0 {
+		p.AttestationScore = 100.0 * p.attestationScores / float64(p.AttestationDuties)
+		total += attestationWeight * p.AttestationScore
+		weights += attestationWeight
+	}
+	if p.Attestations > 0 {
+		p.InclusionDelay = float64(p.inclusionDelays) / float64(p.Attestations)
+	}
+	if p.ProposalDuties > 0 {
+		total += proposerWeight * 100.0 * float64(p.Proposals) / float64(p.ProposalDuties)
+		weights += proposerWeight
+	}
+	if p.SyncDuties > 0 {
+		total += syncRewardWeight * 100.0 * float64(p.SyncParticipations) / float64(p.SyncDuties)
+		weights += syncRewardWeight
+	}
+	if weights > 0 {
+		p.Score = total / weights
+	}
+}
+
+// rank scores the validators and orders them by score, highest first.
+// Validators with the same score share a rank.
+func rank(validators []*validatorPerformance) {
+	for _, validator := range validators {
+		validator.calculateScore()
+	}
+	sort.SliceStable(validators, func(i int, j int) bool {
+		if validators[i].Score != validators[j].Score {
+			return validators[i].Score > validators[j].Score
+		}
+		return validators[i].Index < validators[j].Index
+	})
+	for i, validator := range validators {
+		if i > 0 && validator.Score == validators[i-1].Score {
+			validator.Rank = validators[i-1].Rank
+		} else {
+			validator.Rank = i + 1
+		}
+	}
+}
+
+// TableHeaders provides the headers for table output.
+func (r *results) TableHeaders() []string {
+	return []string{"Rank", "Validator", "Score", "Attestations", "Head", "Target", "Delay", "Proposals", "Sync"}
+}
+
+// TableRows provides the rows for table output.
+func (r *results) TableRows() [][]string {
+	rows := make([][]string, 0, len(r.Validators))
+	for _, validator := range r.Validators {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", validator.Rank),
+			fmt.Sprintf("%d", validator.Index),
+			fmt.Sprintf("%0.2f%%", validator.Score),
+			ratio(validator.Attestations, validator.AttestationDuties),
+			fmt.Sprintf("%d", validator.CorrectHeads),
+			fmt.Sprintf("%d", validator.CorrectTargets),
+			fmt.Sprintf("%0.2f", validator.InclusionDelay),
+			ratio(validator.Proposals, validator.ProposalDuties),
+			ratio(validator.SyncParticipations, validator.SyncDuties),
+		})
+	}
+
+	return rows
+}
+
+// ratio provides a ratio for table output, or "-" if there were no duties.
+func ratio(count int, total int) string {
+	if total == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%d/%d", count, total)
+}
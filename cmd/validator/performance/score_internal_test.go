@@ -0,0 +1,173 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationScore(t *testing.T) {
+	tests := []struct {
+		name              string
+		headCorrect       bool
+		targetCorrect     bool
+		inclusionDelay    phase0.Slot
+		minInclusionDelay phase0.Slot
+		res               float64
+	}{
+		{
+			name:              "Perfect",
+			headCorrect:       true,
+			targetCorrect:     true,
+			inclusionDelay:    1,
+			minInclusionDelay: 1,
+			res:               1,
+		},
+		{
+			name:              "HeadIncorrect",
+			targetCorrect:     true,
+			inclusionDelay:    1,
+			minInclusionDelay: 1,
+			res:               40.0 / 54.0,
+		},
+		{
+			name:              "SourceOnly",
+			inclusionDelay:    1,
+			minInclusionDelay: 1,
+			res:               14.0 / 54.0,
+		},
+		{
+			name:              "MissedSlotNotPenalised",
+			headCorrect:       true,
+			targetCorrect:     true,
+			inclusionDelay:    3,
+			minInclusionDelay: 3,
+			res:               1,
+		},
+		{
+			name:              "Late",
+			headCorrect:       true,
+			targetCorrect:     true,
+			inclusionDelay:    3,
+			minInclusionDelay: 1,
+			res:               1.0 / 3.0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := attestationScore(test.headCorrect, test.targetCorrect, test.inclusionDelay, test.minInclusionDelay)
+			require.InDelta(t, test.res, res, 1e-9)
+		})
+	}
+}
+
+func TestMinInclusionDelay(t *testing.T) {
+	blockSlots := map[phase0.Slot]bool{
+		100: true,
+		103: true,
+		104: true,
+	}
+	require.Equal(t, phase0.Slot(3), minInclusionDelay(blockSlots, 100, 104))
+	require.Equal(t, phase0.Slot(1), minInclusionDelay(blockSlots, 102, 104))
+	require.Equal(t, phase0.Slot(4), minInclusionDelay(map[phase0.Slot]bool{}, 100, 104))
+	require.Equal(t, phase0.Slot(1), minInclusionDelay(blockSlots, 103, 104))
+}
+
+func TestCalculateScore(t *testing.T) {
+	tests := []struct {
+		name        string
+		performance *validatorPerformance
+		score       float64
+		attestation float64
+		delay       float64
+	}{
+		{
+			name:        "NoDuties",
+			performance: &validatorPerformance{},
+		},
+		{
+			name: "AttestationsOnly",
+			performance: &validatorPerformance{
+				AttestationDuties: 2,
+				Attestations:      1,
+				attestationScores: 1,
+				inclusionDelays:   1,
+			},
+			score:       50,
+			attestation: 50,
+			delay:       1,
+		},
+		{
+			name: "MissedProposal",
+			performance: &validatorPerformance{
+				AttestationDuties: 1,
+				Attestations:      1,
+				attestationScores: 1,
+				inclusionDelays:   2,
+				ProposalDuties:    1,
+			},
+			score:       100.0 * 54.0 / 62.0,
+			attestation: 100,
+			delay:       2,
+		},
+		{
+			name: "All",
+			performance: &validatorPerformance{
+				AttestationDuties:  1,
+				Attestations:       1,
+				attestationScores:  1,
+				inclusionDelays:    1,
+				ProposalDuties:     1,
+				Proposals:          1,
+				SyncDuties:         4,
+				SyncParticipations: 2,
+			},
+			score:       100.0 * 63.0 / 64.0,
+			attestation: 100,
+			delay:       1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.performance.calculateScore()
+			require.InDelta(t, test.score, test.performance.Score, 1e-9)
+			require.InDelta(t, test.attestation, test.performance.AttestationScore, 1e-9)
+			require.InDelta(t, test.delay, test.performance.InclusionDelay, 1e-9)
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	validators := []*validatorPerformance{
+		{Index: 1, AttestationDuties: 1, Attestations: 1, attestationScores: 0.5},
+		{Index: 2, AttestationDuties: 1, Attestations: 1, attestationScores: 1},
+		{Index: 3},
+		{Index: 4, AttestationDuties: 1, Attestations: 1, attestationScores: 0.5},
+	}
+	rank(validators)
+
+	indices := make([]phase0.ValidatorIndex, 0, len(validators))
+	ranks := make([]int, 0, len(validators))
+	for _, validator := range validators {
+		indices = append(indices, validator.Index)
+		ranks = append(ranks, validator.Rank)
+	}
+	require.Equal(t, []phase0.ValidatorIndex{2, 1, 4, 3}, indices)
+	require.Equal(t, []int{1, 2, 2, 4}, ranks)
+}
@@ -0,0 +1,98 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorproposals
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"validator": "1",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ValidatorMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "validator is required",
+		},
+		{
+			name: "FromSlotInvalid",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"validator": "1",
+				"from-slot": "bad",
+			},
+			err: "invalid from slot: strconv.ParseUint: parsing \"bad\": invalid syntax",
+		},
+		{
+			name: "ToSlotInvalid",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"validator": "1",
+				"to-slot":   "-1",
+			},
+			err: "invalid to slot: strconv.ParseUint: parsing \"-1\": invalid syntax",
+		},
+		{
+			name: "FromAfterTo",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"validator": "1",
+				"from-slot": "200",
+				"to-slot":   "100",
+			},
+			err: "from slot must not be after to slot",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"validator": "1",
+				"from-slot": "100",
+				"to-slot":   "200",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
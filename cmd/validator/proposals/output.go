@@ -0,0 +1,90 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorproposals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+type jsonOutput struct {
+	Validator phase0.ValidatorIndex `json:"validator_index"`
+	FirstSlot phase0.Slot           `json:"first_slot"`
+	LastSlot  phase0.Slot           `json:"last_slot"`
+	Proposals []*proposal           `json:"proposals"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		Validator: c.validatorInfo.Index,
+		FirstSlot: c.first,
+		LastSlot:  c.last,
+		Proposals: c.proposals,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	missed := 0
+	for _, proposal := range c.proposals {
+		if proposal.Missed {
+			missed++
+		}
+	}
+	builder.WriteString(fmt.Sprintf("Validator %d proposals for slots %d to %d: %d/%d proposed", c.validatorInfo.Index, c.first, c.last, len(c.proposals)-missed, len(c.proposals)))
+
+	for _, proposal := range c.proposals {
+		builder.WriteString(fmt.Sprintf("\n  Slot %d: ", proposal.Slot))
+		if proposal.Missed {
+			builder.WriteString("MISSED")
+			continue
+		}
+		builder.WriteString("proposed")
+		if proposal.Graffiti != "" {
+			builder.WriteString(fmt.Sprintf(", graffiti %q", proposal.Graffiti))
+		}
+		if proposal.Builder != "" {
+			builder.WriteString(fmt.Sprintf(", built by %s", proposal.Builder))
+		}
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("\n    Block root: %s", proposal.Root))
+			if proposal.FeeRecipient != "" {
+				builder.WriteString(fmt.Sprintf("\n    Fee recipient: %s", proposal.FeeRecipient))
+			}
+		}
+	}
+
+	return builder.String(), nil
+}
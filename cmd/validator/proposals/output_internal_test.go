@@ -0,0 +1,81 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorproposals
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	proposals := []*proposal{
+		{
+			Slot:         100,
+			Root:         "0x0101010101010101010101010101010101010101010101010101010101010101",
+			Graffiti:     "hello",
+			FeeRecipient: "0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5",
+			Builder:      "Flashbots",
+		},
+		{
+			Slot:   200,
+			Missed: true,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		json    bool
+		verbose bool
+		res     string
+	}{
+		{
+			name: "Text",
+			res: `Validator 1 proposals for slots 0 to 300: 1/2 proposed
+  Slot 100: proposed, graffiti "hello", built by Flashbots
+  Slot 200: MISSED`,
+		},
+		{
+			name:    "Verbose",
+			verbose: true,
+			res: `Validator 1 proposals for slots 0 to 300: 1/2 proposed
+  Slot 100: proposed, graffiti "hello", built by Flashbots
+    Block root: 0x0101010101010101010101010101010101010101010101010101010101010101
+    Fee recipient: 0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5
+  Slot 200: MISSED`,
+		},
+		{
+			name: "JSON",
+			json: true,
+			res:  `{"validator_index":1,"first_slot":0,"last_slot":300,"proposals":[{"slot":100,"missed":false,"root":"0x0101010101010101010101010101010101010101010101010101010101010101","graffiti":"hello","fee_recipient":"0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5","builder":"Flashbots"},{"slot":200,"missed":true}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				json:          test.json,
+				verbose:       test.verbose,
+				validatorInfo: &apiv1.Validator{Index: 1},
+				last:          300,
+				proposals:     proposals,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorproposals
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// defaultEpochs is the number of epochs covered if no from slot is supplied,
+// which is approximately one day on mainnet.
+const defaultEpochs = 225
+
+// knownBuilders are the fee recipients of well-known block builders.  Blocks
+// from builders use the builder's address as the fee recipient, with the
+// proposer paid by a transaction in the block.
+var knownBuilders = map[string]string{
+	"0x1f9090aae28b8a3dceadf281b0f12828e676c326": "rsync-builder",
+	"0x4838b106fce9647bdf1e7877bf73ce8b0bad5f97": "Titan",
+	"0x690b9a9e9aa1c9db991c7721a92d351db4fac990": "builder0x69",
+	"0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5": "beaverbuild",
+	"0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5": "Flashbots",
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	c.validatorInfo, err = util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator information")
+	}
+
+	c.last = c.chainTime.CurrentSlot()
+	if c.toSlot != nil {
+		c.last = *c.toSlot
+	}
+	c.first = 0
+	if c.fromSlot != nil {
+		c.first = *c.fromSlot
+	} else if lastEpoch := c.chainTime.SlotToEpoch(c.last); lastEpoch >= defaultEpochs {
+		c.first = c.chainTime.FirstSlotOfEpoch(lastEpoch - defaultEpochs)
+	}
+	if c.first > c.last {
+		return errors.New("from slot must not be after to slot")
+	}
+
+	c.proposals = make([]*proposal, 0)
+	for epoch := c.chainTime.SlotToEpoch(c.first); epoch <= c.chainTime.SlotToEpoch(c.last); epoch++ {
+		duties, err := c.proposerDutiesProvider.ProposerDuties(ctx, epoch, []phase0.ValidatorIndex{c.validatorInfo.Index})
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain proposer duties for epoch %d", epoch))
+		}
+		for _, duty := range duties {
+			// Not all nodes filter duties by validator.
+			if duty.ValidatorIndex != c.validatorInfo.Index || duty.Slot < c.first || duty.Slot > c.last {
+				continue
+			}
+			proposal, err := c.obtainProposal(ctx, duty.Slot)
+			if err != nil {
+				return err
+			}
+			c.proposals = append(c.proposals, proposal)
+		}
+	}
+
+	return nil
+}
+
+// obtainProposal obtains the details of the proposal at the given slot.
+func (c *command) obtainProposal(ctx context.Context, slot phase0.Slot) (*proposal, error) {
+	block, err := c.blocksProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+	}
+	if block == nil {
+		util.Log.Debug().Uint64("slot", uint64(slot)).Msg("No block")
+		return &proposal{
+			Slot:   slot,
+			Missed: true,
+		}, nil
+	}
+
+	root, err := block.Root()
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain root of block at slot %d", slot))
+	}
+
+	var graffiti [32]byte
+	var feeRecipient *bellatrix.ExecutionAddress
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		graffiti = block.Phase0.Message.Body.Graffiti
+	case spec.DataVersionAltair:
+		graffiti = block.Altair.Message.Body.Graffiti
+	case spec.DataVersionBellatrix:
+		graffiti = block.Bellatrix.Message.Body.Graffiti
+		feeRecipient = &block.Bellatrix.Message.Body.ExecutionPayload.FeeRecipient
+	case spec.DataVersionCapella:
+		graffiti = block.Capella.Message.Body.Graffiti
+		feeRecipient = &block.Capella.Message.Body.ExecutionPayload.FeeRecipient
+	default:
+		return nil, fmt.Errorf("unhandled block version %v", block.Version)
+	}
+
+	res := &proposal{
+		Slot:     slot,
+		Root:     fmt.Sprintf("%#x", root),
+		Graffiti: graffitiString(graffiti[:]),
+	}
+	if feeRecipient != nil {
+		res.FeeRecipient = feeRecipient.String()
+		res.Builder = knownBuilders[strings.ToLower(res.FeeRecipient)]
+	}
+
+	return res, nil
+}
+
+// graffitiString returns a printable version of the graffiti.
+func graffitiString(graffiti []byte) string {
+	graffiti = bytes.TrimRight(graffiti, "\u0000")
+	if len(graffiti) == 0 {
+		return ""
+	}
+	if utf8.Valid(graffiti) {
+		return string(graffiti)
+	}
+
+	return fmt.Sprintf("%#x", graffiti)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	return nil
+}
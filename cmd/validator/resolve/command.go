@@ -0,0 +1,94 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorresolve
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+	csv     bool
+
+	// Input.
+	validators     []string
+	validatorsFile string
+	stdin          io.Reader
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	eth2Client         eth2client.Service
+	validatorsProvider eth2client.ValidatorsProvider
+
+	// Output.
+	resolutions []*resolution
+}
+
+// resolution is the mapping of a supplied validator identifier to its
+// index and public key.  Index is nil if the validator is unknown.
+type resolution struct {
+	Input  string                 `json:"input"`
+	Index  *phase0.ValidatorIndex `json:"index,omitempty"`
+	PubKey string                 `json:"pubkey,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:          viper.GetBool("quiet"),
+		verbose:        viper.GetBool("verbose"),
+		debug:          viper.GetBool("debug"),
+		json:           viper.GetBool("json"),
+		csv:            viper.GetBool("csv"),
+		validators:     viper.GetStringSlice("validators"),
+		validatorsFile: viper.GetString("validators-file"),
+		stdin:          os.Stdin,
+	}
+
+	if c.json && c.csv {
+		return nil, errors.New("only one of json and csv output allowed")
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if len(c.validators) == 0 && c.validatorsFile == "" {
+		return nil, errors.New("validators or validators-file is required")
+	}
+	if len(c.validators) > 0 && c.validatorsFile != "" {
+		return nil, errors.New("only one of validators and validators-file allowed")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,77 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorresolve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	switch {
+	case c.json:
+		return c.outputJSON(ctx)
+	case c.csv:
+		return c.outputCSV(ctx)
+	default:
+		return c.outputText(ctx)
+	}
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.resolutions)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	for _, resolution := range c.resolutions {
+		if resolution.Index == nil {
+			builder.WriteString(fmt.Sprintf("%s: unknown validator\n", resolution.Input))
+			continue
+		}
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("%s: ", resolution.Input))
+		}
+		builder.WriteString(fmt.Sprintf("%d %s\n", *resolution.Index, resolution.PubKey))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString("input,index,pubkey\n")
+	for _, resolution := range c.resolutions {
+		if resolution.Index == nil {
+			builder.WriteString(fmt.Sprintf("%s,,\n", resolution.Input))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("%s,%d,%s\n", resolution.Input, *resolution.Index, resolution.PubKey))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorresolve
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	index := phase0.ValidatorIndex(1)
+	resolutions := []*resolution{
+		{Input: "1", Index: &index, PubKey: "0x01"},
+		{Input: "2"},
+	}
+
+	tests := []struct {
+		name     string
+		command  *command
+		expected string
+	}{
+		{
+			name:     "Text",
+			command:  &command{resolutions: resolutions},
+			expected: "1 0x01\n2: unknown validator",
+		},
+		{
+			name:     "TextVerbose",
+			command:  &command{verbose: true, resolutions: resolutions},
+			expected: "1: 1 0x01\n2: unknown validator",
+		},
+		{
+			name:     "CSV",
+			command:  &command{csv: true, resolutions: resolutions},
+			expected: "input,index,pubkey\n1,1,0x01\n2,,",
+		},
+		{
+			name:     "JSON",
+			command:  &command{json: true, resolutions: resolutions},
+			expected: `[{"input":"1","index":1,"pubkey":"0x01"},{"input":"2"}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
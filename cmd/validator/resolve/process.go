@@ -0,0 +1,232 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorresolve
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// identifier is a validator identifier supplied by the user, parsed to
+// either an index or a public key.
+type identifier struct {
+	input  string
+	index  *phase0.ValidatorIndex
+	pubKey *phase0.BLSPubKey
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain the validators before connecting, to fail fast on bad input.
+	validators := c.validators
+	if c.validatorsFile != "" {
+		var err error
+		if c.validatorsFile == "-" {
+			validators, err = util.ReadValidators(c.stdin)
+		} else {
+			validators, err = util.ReadValidatorsFile(c.validatorsFile)
+		}
+		if err != nil {
+			return err
+		}
+		if len(validators) == 0 {
+			return errors.New("no validators supplied")
+		}
+	}
+
+	identifiers, err := parseIdentifiers(ctx, validators)
+	if err != nil {
+		return err
+	}
+
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.resolutions, err = resolve(ctx, c.validatorsProvider, identifiers)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	return nil
+}
+
+// parseIdentifiers parses validator indices, ranges of indices, public keys
+// and accounts in to identifiers.
+func parseIdentifiers(ctx context.Context, validators []string) ([]*identifier, error) {
+	identifiers := make([]*identifier, 0, len(validators))
+	for _, validator := range validators {
+		switch {
+		case strings.HasPrefix(validator, "0x"):
+			// A public key.
+			data, err := hex.DecodeString(strings.TrimPrefix(validator, "0x"))
+			if err != nil || len(data) != phase0.PublicKeyLength {
+				return nil, fmt.Errorf("invalid public key %s", validator)
+			}
+			pubKey := phase0.BLSPubKey{}
+			copy(pubKey[:], data)
+			identifiers = append(identifiers, &identifier{
+				input:  validator,
+				pubKey: &pubKey,
+			})
+		case strings.Contains(validator, "/"):
+			// An account.
+			_, account, err := util.WalletAndAccountFromPath(ctx, validator)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("unable to obtain account %s", validator))
+			}
+			accPubKey, err := util.BestPublicKey(account)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("unable to obtain public key for account %s", validator))
+			}
+			pubKey := phase0.BLSPubKey{}
+			copy(pubKey[:], accPubKey.Marshal())
+			identifiers = append(identifiers, &identifier{
+				input:  validator,
+				pubKey: &pubKey,
+			})
+		case strings.Contains(validator, "-"):
+			// A range of indices.
+			bits := strings.Split(validator, "-")
+			if len(bits) != 2 {
+				return nil, fmt.Errorf("invalid range %s", validator)
+			}
+			low, err := strconv.ParseUint(bits[0], 10, 64)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid range start")
+			}
+			high, err := strconv.ParseUint(bits[1], 10, 64)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid range end")
+			}
+			if low > high {
+				return nil, fmt.Errorf("invalid range %s", validator)
+			}
+			for i := low; i <= high; i++ {
+				index := phase0.ValidatorIndex(i)
+				identifiers = append(identifiers, &identifier{
+					input: strconv.FormatUint(i, 10),
+					index: &index,
+				})
+			}
+		default:
+			// An index.
+			val, err := strconv.ParseUint(validator, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid validator %s", validator)
+			}
+			index := phase0.ValidatorIndex(val)
+			identifiers = append(identifiers, &identifier{
+				input: validator,
+				index: &index,
+			})
+		}
+	}
+
+	return identifiers, nil
+}
+
+// resolve resolves the identifiers to validators.  All indices are obtained
+// in a single request, as are all public keys, regardless of the number of
+// identifiers.
+func resolve(ctx context.Context,
+	validatorsProvider eth2client.ValidatorsProvider,
+	identifiers []*identifier,
+) (
+	[]*resolution,
+	error,
+) {
+	indices := make([]phase0.ValidatorIndex, 0)
+	pubKeys := make([]phase0.BLSPubKey, 0)
+	seenIndices := make(map[phase0.ValidatorIndex]bool)
+	seenPubKeys := make(map[phase0.BLSPubKey]bool)
+	for _, identifier := range identifiers {
+		switch {
+		case identifier.index != nil && !seenIndices[*identifier.index]:
+			seenIndices[*identifier.index] = true
+			indices = append(indices, *identifier.index)
+		case identifier.pubKey != nil && !seenPubKeys[*identifier.pubKey]:
+			seenPubKeys[*identifier.pubKey] = true
+			pubKeys = append(pubKeys, *identifier.pubKey)
+		}
+	}
+
+	validatorsByIndex := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+	validatorsByPubKey := make(map[phase0.BLSPubKey]*apiv1.Validator)
+	if len(indices) > 0 {
+		validators, err := validatorsProvider.Validators(ctx, "head", indices)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators by index")
+		}
+		for _, validator := range validators {
+			validatorsByIndex[validator.Index] = validator
+		}
+	}
+	if len(pubKeys) > 0 {
+		validators, err := validatorsProvider.ValidatorsByPubKey(ctx, "head", pubKeys)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators by public key")
+		}
+		for _, validator := range validators {
+			validatorsByPubKey[validator.Validator.PublicKey] = validator
+		}
+	}
+
+	resolutions := make([]*resolution, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		var validator *apiv1.Validator
+		if identifier.index != nil {
+			validator = validatorsByIndex[*identifier.index]
+		} else {
+			validator = validatorsByPubKey[*identifier.pubKey]
+		}
+		resolution := &resolution{
+			Input: identifier.input,
+		}
+		if validator != nil {
+			index := validator.Index
+			resolution.Index = &index
+			resolution.PubKey = fmt.Sprintf("%#x", validator.Validator.PublicKey)
+		}
+		resolutions = append(resolutions, resolution)
+	}
+
+	return resolutions, nil
+}
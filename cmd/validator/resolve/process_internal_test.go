@@ -0,0 +1,143 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorresolve
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testing/mock"
+)
+
+func TestParseIdentifiers(t *testing.T) {
+	tests := []struct {
+		name       string
+		validators []string
+		inputs     []string
+		err        string
+	}{
+		{
+			name:       "InvalidPubKey",
+			validators: []string{"0x0102"},
+			err:        "invalid public key 0x0102",
+		},
+		{
+			name:       "InvalidIndex",
+			validators: []string{"one"},
+			err:        "invalid validator one",
+		},
+		{
+			name:       "InvalidRange",
+			validators: []string{"1-2-3"},
+			err:        "invalid range 1-2-3",
+		},
+		{
+			name:       "InvertedRange",
+			validators: []string{"3-1"},
+			err:        "invalid range 3-1",
+		},
+		{
+			name:       "Good",
+			validators: []string{"5", "1-3", "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"},
+			inputs:     []string{"5", "1", "2", "3", "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identifiers, err := parseIdentifiers(context.Background(), test.validators)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				inputs := make([]string, 0, len(identifiers))
+				for _, identifier := range identifiers {
+					inputs = append(inputs, identifier.input)
+				}
+				require.Equal(t, test.inputs, inputs)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	validators := make([]*apiv1.Validator, 0)
+	for i := 0; i < 4; i++ {
+		pubKey := phase0.BLSPubKey{}
+		pubKey[0] = byte(i)
+		validators = append(validators, &apiv1.Validator{
+			Index: phase0.ValidatorIndex(i),
+			Validator: &phase0.Validator{
+				PublicKey: pubKey,
+			},
+		})
+	}
+	pubKeyStr := func(i byte) string {
+		pubKey := phase0.BLSPubKey{}
+		pubKey[0] = i
+		return fmt.Sprintf("%#x", pubKey)
+	}
+	index := func(i phase0.ValidatorIndex) *phase0.ValidatorIndex {
+		return &i
+	}
+
+	tests := []struct {
+		name       string
+		validators []string
+		expected   []*resolution
+	}{
+		{
+			name:       "Indices",
+			validators: []string{"2", "0-1"},
+			expected: []*resolution{
+				{Input: "2", Index: index(2), PubKey: pubKeyStr(2)},
+				{Input: "0", Index: index(0), PubKey: pubKeyStr(0)},
+				{Input: "1", Index: index(1), PubKey: pubKeyStr(1)},
+			},
+		},
+		{
+			name:       "Mixed",
+			validators: []string{pubKeyStr(3), "1", pubKeyStr(3)},
+			expected: []*resolution{
+				{Input: pubKeyStr(3), Index: index(3), PubKey: pubKeyStr(3)},
+				{Input: "1", Index: index(1), PubKey: pubKeyStr(1)},
+				{Input: pubKeyStr(3), Index: index(3), PubKey: pubKeyStr(3)},
+			},
+		},
+		{
+			name:       "Unknown",
+			validators: []string{"10", pubKeyStr(9), "0"},
+			expected: []*resolution{
+				{Input: "10"},
+				{Input: pubKeyStr(9)},
+				{Input: "0", Index: index(0), PubKey: pubKeyStr(0)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			identifiers, err := parseIdentifiers(ctx, test.validators)
+			require.NoError(t, err)
+			resolutions, err := resolve(ctx, mock.NewValidatorsProvider(validators), identifiers)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, resolutions)
+		})
+	}
+}
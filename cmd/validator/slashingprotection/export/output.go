@@ -0,0 +1,39 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.file != "" {
+		// Data has already been written to the file.
+		if c.verbose {
+			return fmt.Sprintf("Slashing protection data for %d validators written to %s", len(c.slashingProtection.Data), c.file), nil
+		}
+		return "", nil
+	}
+
+	data, err := json.Marshal(c.slashingProtection)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal slashing protection data")
+	}
+
+	return string(data), nil
+}
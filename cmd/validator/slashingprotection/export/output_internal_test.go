@@ -0,0 +1,80 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionexport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestOutput(t *testing.T) {
+	slashingProtection := &util.SlashingProtection{
+		GenesisValidatorsRoot: phase0.Root{0x01},
+		Data: []*util.SlashingProtectionData{
+			{
+				PubKey: phase0.BLSPubKey{0x02},
+				SignedBlocks: []*util.SlashingProtectionBlock{
+					{
+						Slot: 1000,
+					},
+				},
+				SignedAttestations: []*util.SlashingProtectionAttestation{
+					{
+						SourceEpoch: 29,
+						TargetEpoch: 31,
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		verbose bool
+		res     string
+	}{
+		{
+			name: "Stdout",
+			res:  `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x0100000000000000000000000000000000000000000000000000000000000000"},"data":[{"pubkey":"0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","signed_blocks":[{"slot":"1000"}],"signed_attestations":[{"source_epoch":"29","target_epoch":"31"}]}]}`,
+		},
+		{
+			name: "File",
+			file: "slashing-protection.json",
+		},
+		{
+			name:    "FileVerbose",
+			file:    "slashing-protection.json",
+			verbose: true,
+			res:     "Slashing protection data for 1 validators written to slashing-protection.json",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				verbose:            test.verbose,
+				file:               test.file,
+				slashingProtection: slashingProtection,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
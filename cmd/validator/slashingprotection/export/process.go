@@ -0,0 +1,123 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	genesis, err := c.consensusClient.(eth2client.GenesisProvider).Genesis(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain genesis")
+	}
+
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators information")
+	}
+
+	finality, err := c.finalityProvider.Finality(ctx, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain finality")
+	}
+	if finality == nil || finality.Justified == nil {
+		return errors.New("finality not returned")
+	}
+
+	// The data marks the current slot and epoch as signed, which stops a validator
+	// client that imports it from signing anything at or before the current position
+	// of the chain.  Attestations with sources prior to the current justified epoch
+	// cannot be included on chain, so this is used as the source.
+	slot := c.chainTime.CurrentSlot()
+	epoch := c.chainTime.CurrentEpoch()
+	util.Log.Debug().Uint64("slot", uint64(slot)).Uint64("source_epoch", uint64(finality.Justified.Epoch)).Uint64("target_epoch", uint64(epoch)).Msg("Exporting slashing protection")
+
+	c.slashingProtection = &util.SlashingProtection{
+		GenesisValidatorsRoot: genesis.GenesisValidatorsRoot,
+		Data:                  make([]*util.SlashingProtectionData, 0, len(validators)),
+	}
+	for _, validator := range validators {
+		c.slashingProtection.Data = append(c.slashingProtection.Data, &util.SlashingProtectionData{
+			PubKey: validator.Validator.PublicKey,
+			SignedBlocks: []*util.SlashingProtectionBlock{
+				{
+					Slot: slot,
+				},
+			},
+			SignedAttestations: []*util.SlashingProtectionAttestation{
+				{
+					SourceEpoch: finality.Justified.Epoch,
+					TargetEpoch: epoch,
+				},
+			},
+		})
+	}
+
+	if c.file != "" {
+		data, err := json.Marshal(c.slashingProtection)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal slashing protection data")
+		}
+		if err := os.WriteFile(c.file, data, 0600); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to write %s", c.file))
+		}
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(eth2client.GenesisTimeProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(eth2client.SpecProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.consensusClient.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide validator information")
+	}
+	c.finalityProvider, isProvider = c.consensusClient.(eth2client.FinalityProvider)
+	if !isProvider {
+		return errors.New("consensus node does not provide finality information")
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionimport
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	files                 []string
+	genesisValidatorsRoot *phase0.Root
+	minify                bool
+	file                  string
+
+	// Output.
+	slashingProtection *util.SlashingProtection
+	blocks             int
+	attestations       int
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		minify:  viper.GetBool("minify"),
+		file:    viper.GetString("file"),
+	}
+
+	c.files = viper.GetStringSlice("files")
+	if len(c.files) == 0 {
+		return nil, errors.New("files are required")
+	}
+
+	if viper.GetString("genesis-validators-root") != "" {
+		data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("genesis-validators-root"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode genesis validators root")
+		}
+		if len(data) != phase0.RootLength {
+			return nil, errors.New("genesis validators root has incorrect length")
+		}
+		c.genesisValidatorsRoot = &phase0.Root{}
+		copy(c.genesisValidatorsRoot[:], data)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,75 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionimport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "FilesMissing",
+			vars: map[string]interface{}{},
+			err:  "files are required",
+		},
+		{
+			name: "GenesisValidatorsRootInvalid",
+			vars: map[string]interface{}{
+				"files":                   []string{"a.json"},
+				"genesis-validators-root": "invalid",
+			},
+			err: "failed to decode genesis validators root: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "GenesisValidatorsRootShort",
+			vars: map[string]interface{}{
+				"files":                   []string{"a.json"},
+				"genesis-validators-root": "0x0470",
+			},
+			err: "genesis validators root has incorrect length",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"files":                   []string{"a.json", "b.json"},
+				"genesis-validators-root": "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionimport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.file == "" {
+		data, err := json.Marshal(c.slashingProtection)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal slashing protection data")
+		}
+		return string(data), nil
+	}
+
+	// Data has already been written to the file, so provide a summary.
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Genesis validators root: %#x\n", c.slashingProtection.GenesisValidatorsRoot))
+	builder.WriteString(fmt.Sprintf("Validators: %d\n", len(c.slashingProtection.Data)))
+	builder.WriteString(fmt.Sprintf("Signed blocks: %d\n", c.blocks))
+	builder.WriteString(fmt.Sprintf("Signed attestations: %d", c.attestations))
+
+	return builder.String(), nil
+}
@@ -0,0 +1,70 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionimport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(_ context.Context) error {
+	items := make([]*util.SlashingProtection, 0, len(c.files))
+	for _, file := range c.files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to read %s", file))
+		}
+		item := &util.SlashingProtection{}
+		if err := json.Unmarshal(data, item); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("invalid slashing protection data in %s", file))
+		}
+		if c.genesisValidatorsRoot != nil && !bytes.Equal(item.GenesisValidatorsRoot[:], c.genesisValidatorsRoot[:]) {
+			return fmt.Errorf("genesis validators root %#x in %s does not match %#x", item.GenesisValidatorsRoot, file, *c.genesisValidatorsRoot)
+		}
+		util.Log.Debug().Str("file", file).Int("validators", len(item.Data)).Msg("Read slashing protection data")
+		items = append(items, item)
+	}
+
+	var err error
+	c.slashingProtection, err = util.MergeSlashingProtection(items)
+	if err != nil {
+		return errors.Wrap(err, "failed to merge slashing protection data")
+	}
+	if c.minify {
+		c.slashingProtection.Minify()
+	}
+
+	for _, data := range c.slashingProtection.Data {
+		c.blocks += len(data.SignedBlocks)
+		c.attestations += len(data.SignedAttestations)
+	}
+
+	if c.file != "" {
+		data, err := json.Marshal(c.slashingProtection)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal slashing protection data")
+		}
+		if err := os.WriteFile(c.file, data, 0600); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to write %s", c.file))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionimport
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	dir := t.TempDir()
+	lighthouse := filepath.Join(dir, "lighthouse.json")
+	require.NoError(t, os.WriteFile(lighthouse, []byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[{"slot":"81952","signing_root":"0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"}],"signed_attestations":[{"source_epoch":"2290","target_epoch":"3007","signing_root":"0x587d6a4f59a58fe24f406e0502413e77fe1babddee641fda30034ed37ecc884d"}]}]}`), 0600))
+	teku := filepath.Join(dir, "teku.json")
+	require.NoError(t, os.WriteFile(teku, []byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[{"slot":"81953"}],"signed_attestations":[{"source_epoch":"2290","target_epoch":"3008"}]}]}`), 0600))
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"metadata":{"interchange_format_version":"4"},"data":[]}`), 0600))
+
+	tests := []struct {
+		name                  string
+		files                 []string
+		genesisValidatorsRoot *phase0.Root
+		minify                bool
+		blocks                int
+		attestations          int
+		err                   string
+	}{
+		{
+			name:  "Missing",
+			files: []string{filepath.Join(dir, "missing.json")},
+			err:   "failed to read " + filepath.Join(dir, "missing.json") + ": open " + filepath.Join(dir, "missing.json") + ": no such file or directory",
+		},
+		{
+			name:  "Invalid",
+			files: []string{lighthouse, invalid},
+			err:   "invalid slashing protection data in " + invalid + ": unsupported interchange format version 4",
+		},
+		{
+			name:                  "GenesisValidatorsRootMismatch",
+			files:                 []string{lighthouse},
+			genesisValidatorsRoot: &phase0.Root{},
+			err:                   "genesis validators root 0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673 in " + lighthouse + " does not match 0x0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:         "Merged",
+			files:        []string{lighthouse, teku},
+			blocks:       2,
+			attestations: 2,
+		},
+		{
+			name:         "Minified",
+			files:        []string{lighthouse, teku},
+			minify:       true,
+			blocks:       1,
+			attestations: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				files:                 test.files,
+				genesisValidatorsRoot: test.genesisValidatorsRoot,
+				minify:                test.minify,
+			}
+			err := c.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, c.slashingProtection.Data, 1)
+				require.Equal(t, test.blocks, c.blocks)
+				require.Equal(t, test.attestations, c.attestations)
+			}
+		})
+	}
+}
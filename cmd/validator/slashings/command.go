@@ -0,0 +1,99 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashings
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	fromSlot *phase0.Slot
+	toSlot   *phase0.Slot
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	eth2Client     eth2client.Service
+	chainTime      chaintime.Service
+	blocksProvider eth2client.SignedBeaconBlockProvider
+
+	// Output.
+	first     phase0.Slot
+	last      phase0.Slot
+	slashings []*slashing
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	var err error
+	c.fromSlot, err = parseSlot(viper.GetString("from-slot"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid from slot")
+	}
+	c.toSlot, err = parseSlot(viper.GetString("to-slot"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid to slot")
+	}
+	if c.fromSlot != nil && c.toSlot != nil && *c.fromSlot > *c.toSlot {
+		return nil, errors.New("from slot must not be after to slot")
+	}
+
+	return c, nil
+}
+
+// parseSlot parses an optional slot.
+func parseSlot(input string) (*phase0.Slot, error) {
+	if input == "" {
+		return nil, nil
+	}
+	val, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	slot := phase0.Slot(val)
+
+	return &slot, nil
+}
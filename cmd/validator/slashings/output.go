@@ -0,0 +1,100 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+type jsonOutput struct {
+	FirstSlot phase0.Slot `json:"first_slot"`
+	LastSlot  phase0.Slot `json:"last_slot"`
+	Slashings []*slashing `json:"slashings"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		FirstSlot: c.first,
+		LastSlot:  c.last,
+		Slashings: c.slashings,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Slashings for slots %d to %d: %d", c.first, c.last, len(c.slashings)))
+
+	for _, slashing := range c.slashings {
+		builder.WriteString(fmt.Sprintf("\n  Slot %d: %s slashing of %s for %s", slashing.Slot, slashing.Type, validatorsString(slashing.Validators), slashing.Reason))
+		if !c.verbose {
+			continue
+		}
+		if slashing.ProposerSlashing != nil {
+			writeHeader(&builder, 1, slashing.ProposerSlashing.SignedHeader1.Message)
+			writeHeader(&builder, 2, slashing.ProposerSlashing.SignedHeader2.Message)
+		}
+		if slashing.AttesterSlashing != nil {
+			writeAttestationData(&builder, 1, slashing.AttesterSlashing.Attestation1.Data)
+			writeAttestationData(&builder, 2, slashing.AttesterSlashing.Attestation2.Data)
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// validatorsString returns a readable list of validators.
+func validatorsString(validators []phase0.ValidatorIndex) string {
+	switch len(validators) {
+	case 0:
+		return "no validators"
+	case 1:
+		return fmt.Sprintf("validator %d", validators[0])
+	default:
+		indices := make([]string, len(validators))
+		for i := range validators {
+			indices[i] = fmt.Sprintf("%d", validators[i])
+		}
+		return fmt.Sprintf("validators %s", strings.Join(indices, ", "))
+	}
+}
+
+func writeHeader(builder *strings.Builder, num int, header *phase0.BeaconBlockHeader) {
+	builder.WriteString(fmt.Sprintf("\n    Block %d: slot %d, parent root %#x, state root %#x, body root %#x", num, header.Slot, header.ParentRoot, header.StateRoot, header.BodyRoot))
+}
+
+func writeAttestationData(builder *strings.Builder, num int, data *phase0.AttestationData) {
+	builder.WriteString(fmt.Sprintf("\n    Attestation %d: slot %d, committee %d, head %#x, source %d (%#x), target %d (%#x)", num, data.Slot, data.Index, data.BeaconBlockRoot, data.Source.Epoch, data.Source.Root, data.Target.Epoch, data.Target.Root))
+}
@@ -0,0 +1,71 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	proposerSlashing := decodeProposerSlashing(110, &phase0.ProposerSlashing{
+		SignedHeader1: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{Slot: 100, ProposerIndex: 5, BodyRoot: phase0.Root{0x01}}},
+		SignedHeader2: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{Slot: 100, ProposerIndex: 5, BodyRoot: phase0.Root{0x02}}},
+	})
+	attesterSlashing := decodeAttesterSlashing(120, &phase0.AttesterSlashing{
+		Attestation1: attestation([]uint64{1, 2, 3}, 5, 10, 0x01),
+		Attestation2: attestation([]uint64{2, 3}, 6, 9, 0x01),
+	})
+
+	tests := []struct {
+		name      string
+		json      bool
+		slashings []*slashing
+		res       string
+	}{
+		{
+			name:      "None",
+			slashings: []*slashing{},
+			res:       "Slashings for slots 100 to 200: 0",
+		},
+		{
+			name:      "Text",
+			slashings: []*slashing{proposerSlashing, attesterSlashing},
+			res:       "Slashings for slots 100 to 200: 2\n  Slot 110: proposer slashing of validator 5 for double proposal\n  Slot 120: attester slashing of validators 2, 3 for surround vote",
+		},
+		{
+			name:      "JSON",
+			json:      true,
+			slashings: []*slashing{},
+			res:       `{"first_slot":100,"last_slot":200,"slashings":[]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				json:      test.json,
+				first:     100,
+				last:      200,
+				slashings: test.slashings,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashings
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// defaultEpochs is the number of epochs covered if no from slot is supplied,
+// which is approximately one day on mainnet.
+const defaultEpochs = 225
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.last = c.chainTime.CurrentSlot()
+	if c.toSlot != nil {
+		c.last = *c.toSlot
+	}
+	c.first = 0
+	if c.fromSlot != nil {
+		c.first = *c.fromSlot
+	} else if lastEpoch := c.chainTime.SlotToEpoch(c.last); lastEpoch >= defaultEpochs {
+		c.first = c.chainTime.FirstSlotOfEpoch(lastEpoch - defaultEpochs)
+	}
+	if c.first > c.last {
+		return errors.New("from slot must not be after to slot")
+	}
+
+	c.slashings = make([]*slashing, 0)
+	progress := util.NewProgress("Scanning blocks", int(c.last-c.first+1))
+	defer progress.Finish()
+	for slot := c.first; slot <= c.last; slot++ {
+		progress.Add(1)
+		block, err := c.blocksProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if block == nil {
+			util.Log.Debug().Uint64("slot", uint64(slot)).Msg("No block")
+			continue
+		}
+
+		proposerSlashings, err := block.ProposerSlashings()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain proposer slashings for slot %d", slot))
+		}
+		for _, proposerSlashing := range proposerSlashings {
+			c.slashings = append(c.slashings, decodeProposerSlashing(slot, proposerSlashing))
+		}
+
+		attesterSlashings, err := block.AttesterSlashings()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain attester slashings for slot %d", slot))
+		}
+		for _, attesterSlashing := range attesterSlashings {
+			c.slashings = append(c.slashings, decodeAttesterSlashing(slot, attesterSlashing))
+		}
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashings
+
+import (
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	typeProposer = "proposer"
+	typeAttester = "attester"
+
+	reasonDoubleProposal = "double proposal"
+	reasonDoubleVote     = "double vote"
+	reasonSurroundVote   = "surround vote"
+	reasonUnknown        = "unknown"
+)
+
+// slashing is a slashing operation included in a block, along with the
+// validators it slashed and the evidence for doing so.
+type slashing struct {
+	Slot       phase0.Slot             `json:"slot"`
+	Type       string                  `json:"type"`
+	Reason     string                  `json:"reason"`
+	Validators []phase0.ValidatorIndex `json:"validators"`
+	// Evidence is the slashing operation as included in the block.
+	ProposerSlashing *phase0.ProposerSlashing `json:"proposer_slashing,omitempty"`
+	AttesterSlashing *phase0.AttesterSlashing `json:"attester_slashing,omitempty"`
+}
+
+// decodeProposerSlashing decodes a proposer slashing included at the given slot.
+func decodeProposerSlashing(slot phase0.Slot, proposerSlashing *phase0.ProposerSlashing) *slashing {
+	res := &slashing{
+		Slot:             slot,
+		Type:             typeProposer,
+		Reason:           reasonUnknown,
+		Validators:       make([]phase0.ValidatorIndex, 0, 1),
+		ProposerSlashing: proposerSlashing,
+	}
+	header1 := proposerSlashing.SignedHeader1.Message
+	header2 := proposerSlashing.SignedHeader2.Message
+	res.Validators = append(res.Validators, header1.ProposerIndex)
+	if header1.Slot == header2.Slot && header1.ProposerIndex == header2.ProposerIndex {
+		res.Reason = reasonDoubleProposal
+	}
+
+	return res
+}
+
+// decodeAttesterSlashing decodes an attester slashing included at the given slot.
+func decodeAttesterSlashing(slot phase0.Slot, attesterSlashing *phase0.AttesterSlashing) *slashing {
+	data1 := attesterSlashing.Attestation1.Data
+	data2 := attesterSlashing.Attestation2.Data
+
+	res := &slashing{
+		Slot:             slot,
+		Type:             typeAttester,
+		Reason:           reasonUnknown,
+		Validators:       intersection(attesterSlashing.Attestation1.AttestingIndices, attesterSlashing.Attestation2.AttestingIndices),
+		AttesterSlashing: attesterSlashing,
+	}
+	switch {
+	case data1.Target.Epoch == data2.Target.Epoch:
+		res.Reason = reasonDoubleVote
+	case data1.Source.Epoch < data2.Source.Epoch && data2.Target.Epoch < data1.Target.Epoch,
+		data2.Source.Epoch < data1.Source.Epoch && data1.Target.Epoch < data2.Target.Epoch:
+		res.Reason = reasonSurroundVote
+	}
+
+	return res
+}
+
+// intersection returns the sorted validator indices present in both lists.
+func intersection(indices1 []uint64, indices2 []uint64) []phase0.ValidatorIndex {
+	present := make(map[uint64]bool, len(indices1))
+	for _, index := range indices1 {
+		present[index] = true
+	}
+	res := make([]phase0.ValidatorIndex, 0)
+	for _, index := range indices2 {
+		if present[index] {
+			res = append(res, phase0.ValidatorIndex(index))
+			// Avoid duplicates.
+			present[index] = false
+		}
+	}
+	sort.Slice(res, func(i int, j int) bool {
+		return res[i] < res[j]
+	})
+
+	return res
+}
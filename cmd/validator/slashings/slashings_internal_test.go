@@ -0,0 +1,166 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashings
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func attestation(indices []uint64, source phase0.Epoch, target phase0.Epoch, head byte) *phase0.IndexedAttestation {
+	return &phase0.IndexedAttestation{
+		AttestingIndices: indices,
+		Data: &phase0.AttestationData{
+			Slot:            phase0.Slot(target * 32),
+			BeaconBlockRoot: phase0.Root{head},
+			Source:          &phase0.Checkpoint{Epoch: source},
+			Target:          &phase0.Checkpoint{Epoch: target},
+		},
+	}
+}
+
+func TestDecodeProposerSlashing(t *testing.T) {
+	tests := []struct {
+		name       string
+		header1    *phase0.BeaconBlockHeader
+		header2    *phase0.BeaconBlockHeader
+		validators []phase0.ValidatorIndex
+		reason     string
+	}{
+		{
+			name:       "DoubleProposal",
+			header1:    &phase0.BeaconBlockHeader{Slot: 100, ProposerIndex: 5, BodyRoot: phase0.Root{0x01}},
+			header2:    &phase0.BeaconBlockHeader{Slot: 100, ProposerIndex: 5, BodyRoot: phase0.Root{0x02}},
+			validators: []phase0.ValidatorIndex{5},
+			reason:     reasonDoubleProposal,
+		},
+		{
+			name:       "DifferentSlots",
+			header1:    &phase0.BeaconBlockHeader{Slot: 100, ProposerIndex: 5},
+			header2:    &phase0.BeaconBlockHeader{Slot: 101, ProposerIndex: 5},
+			validators: []phase0.ValidatorIndex{5},
+			reason:     reasonUnknown,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := decodeProposerSlashing(200, &phase0.ProposerSlashing{
+				SignedHeader1: &phase0.SignedBeaconBlockHeader{Message: test.header1},
+				SignedHeader2: &phase0.SignedBeaconBlockHeader{Message: test.header2},
+			})
+			require.Equal(t, phase0.Slot(200), res.Slot)
+			require.Equal(t, typeProposer, res.Type)
+			require.Equal(t, test.reason, res.Reason)
+			require.Equal(t, test.validators, res.Validators)
+			require.NotNil(t, res.ProposerSlashing)
+		})
+	}
+}
+
+func TestDecodeAttesterSlashing(t *testing.T) {
+	tests := []struct {
+		name         string
+		attestation1 *phase0.IndexedAttestation
+		attestation2 *phase0.IndexedAttestation
+		validators   []phase0.ValidatorIndex
+		reason       string
+	}{
+		{
+			name:         "DoubleVote",
+			attestation1: attestation([]uint64{1, 2, 3}, 9, 10, 0x01),
+			attestation2: attestation([]uint64{2, 3, 4}, 9, 10, 0x02),
+			validators:   []phase0.ValidatorIndex{2, 3},
+			reason:       reasonDoubleVote,
+		},
+		{
+			name:         "SurroundVote",
+			attestation1: attestation([]uint64{7}, 5, 10, 0x01),
+			attestation2: attestation([]uint64{7}, 6, 9, 0x01),
+			validators:   []phase0.ValidatorIndex{7},
+			reason:       reasonSurroundVote,
+		},
+		{
+			name:         "SurroundedVote",
+			attestation1: attestation([]uint64{7}, 6, 9, 0x01),
+			attestation2: attestation([]uint64{7}, 5, 10, 0x01),
+			validators:   []phase0.ValidatorIndex{7},
+			reason:       reasonSurroundVote,
+		},
+		{
+			name:         "NotSlashable",
+			attestation1: attestation([]uint64{7}, 5, 6, 0x01),
+			attestation2: attestation([]uint64{7}, 6, 7, 0x01),
+			validators:   []phase0.ValidatorIndex{7},
+			reason:       reasonUnknown,
+		},
+		{
+			name:         "NoOverlap",
+			attestation1: attestation([]uint64{1, 2}, 9, 10, 0x01),
+			attestation2: attestation([]uint64{3, 4}, 9, 10, 0x02),
+			validators:   []phase0.ValidatorIndex{},
+			reason:       reasonDoubleVote,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := decodeAttesterSlashing(200, &phase0.AttesterSlashing{
+				Attestation1: test.attestation1,
+				Attestation2: test.attestation2,
+			})
+			require.Equal(t, phase0.Slot(200), res.Slot)
+			require.Equal(t, typeAttester, res.Type)
+			require.Equal(t, test.reason, res.Reason)
+			require.Equal(t, test.validators, res.Validators)
+			require.NotNil(t, res.AttesterSlashing)
+		})
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	tests := []struct {
+		name     string
+		indices1 []uint64
+		indices2 []uint64
+		res      []phase0.ValidatorIndex
+	}{
+		{
+			name:     "Empty",
+			indices1: []uint64{},
+			indices2: []uint64{1, 2},
+			res:      []phase0.ValidatorIndex{},
+		},
+		{
+			name:     "Unsorted",
+			indices1: []uint64{9, 3, 5, 1},
+			indices2: []uint64{5, 1, 9},
+			res:      []phase0.ValidatorIndex{1, 5, 9},
+		},
+		{
+			name:     "Duplicates",
+			indices1: []uint64{1, 2},
+			indices2: []uint64{2, 2, 1},
+			res:      []phase0.ValidatorIndex{1, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, intersection(test.indices1, test.indices2))
+		})
+	}
+}
@@ -36,9 +36,10 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Operation.
-	epoch      string
-	validators []string
-	jsonOutput bool
+	epoch          string
+	validators     []string
+	validatorsFile string
+	jsonOutput     bool
 
 	// Data access.
 	eth2Client                 eth2client.Service
@@ -74,6 +75,19 @@ type validatorSummary struct {
 	Slots                      []*slot                      `json:"slots"`
 	Proposals                  []*epochProposal             `json:"-"`
 	SyncCommittee              []*epochSyncCommittee        `json:"-"`
+	Fleet                      *fleetSummary                `json:"fleet,omitempty"`
+}
+
+// fleetSummary holds aggregate information about the validators, for
+// operators of large numbers of validators.
+type fleetSummary struct {
+	Validators                int            `json:"validators"`
+	TotalBalance              phase0.Gwei    `json:"total_balance"`
+	TotalEffectiveBalance     phase0.Gwei    `json:"total_effective_balance"`
+	Statuses                  map[string]int `json:"statuses"`
+	Credentials               map[string]int `json:"credentials"`
+	ExpectedDailyRewards      phase0.Gwei    `json:"expected_daily_rewards"`
+	UnderperformingValidators int            `json:"underperforming_validators"`
 }
 
 type slot struct {
@@ -134,7 +148,12 @@ func newCommand(ctx context.Context) (*command, error) {
 
 	c.epoch = viper.GetString("epoch")
 	c.validators = viper.GetStringSlice("validators")
+	c.validatorsFile = viper.GetString("validators-file")
 	c.jsonOutput = viper.GetBool("json")
 
+	if len(c.validators) > 0 && c.validatorsFile != "" {
+		return nil, errors.New("only one of validators and validators-file allowed")
+	}
+
 	return c, nil
 }
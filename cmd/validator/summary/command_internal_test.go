@@ -37,6 +37,15 @@ func TestInput(t *testing.T) {
 			vars: map[string]interface{}{},
 			err:  "timeout is required",
 		},
+		{
+			name: "ValidatorsAndValidatorsFile",
+			vars: map[string]interface{}{
+				"timeout":         "5s",
+				"validators":      []string{"1"},
+				"validators-file": "validators.txt",
+			},
+			err: "only one of validators and validators-file allowed",
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
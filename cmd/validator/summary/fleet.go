@@ -0,0 +1,145 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsummary
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// Expected rewards assume that there is no proposal and no sync committee
+// participation, but that head, source and target votes are correct and
+// timely; this gives 54/64 of the base reward.  These values are obtained from
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/beacon-chain.md#incentivization-weights
+const (
+	attestationWeight = 54
+	weightDenominator = 64
+)
+
+// processFleet calculates aggregate information about the validators.
+func (c *command) processFleet(ctx context.Context) error {
+	spec, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+
+	// Rewards depend on the total active balance of the chain.
+	validators, err := c.validatorsProvider.Validators(ctx, fmt.Sprintf("%d", c.summary.FirstSlot), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+	totalActiveBalance := phase0.Gwei(0)
+	for _, validator := range validators {
+		if validator.Validator == nil {
+			continue
+		}
+		if validator.Validator.ActivationEpoch <= c.summary.Epoch && validator.Validator.ExitEpoch > c.summary.Epoch {
+			totalActiveBalance += validator.Validator.EffectiveBalance
+		}
+	}
+
+	epochDuration := c.chainTime.SlotDuration() * time.Duration(c.chainTime.SlotsPerEpoch())
+
+	return c.calculateFleet(spec, totalActiveBalance, uint64(24*time.Hour/epochDuration))
+}
+
+// calculateFleet calculates the aggregate information from the validators
+// and the results of the epoch summary.
+func (c *command) calculateFleet(spec map[string]interface{},
+	totalActiveBalance phase0.Gwei,
+	epochsPerDay uint64,
+) error {
+	baseRewardFactor, err := specUint64(spec, "BASE_REWARD_FACTOR")
+	if err != nil {
+		return err
+	}
+	effectiveBalanceIncrement, err := specUint64(spec, "EFFECTIVE_BALANCE_INCREMENT")
+	if err != nil {
+		return err
+	}
+	if totalActiveBalance == 0 || effectiveBalanceIncrement == 0 {
+		return errors.New("cannot calculate rewards without active balance")
+	}
+	baseRewardPerIncrement := effectiveBalanceIncrement * baseRewardFactor / new(big.Int).Sqrt(new(big.Int).SetUint64(uint64(totalActiveBalance))).Uint64()
+
+	fleet := &fleetSummary{
+		Validators:  len(c.summary.Validators),
+		Statuses:    make(map[string]int),
+		Credentials: make(map[string]int),
+	}
+	for _, validator := range c.summary.Validators {
+		fleet.TotalBalance += validator.Balance
+		fleet.Statuses[validator.Status.String()]++
+		if validator.Validator == nil {
+			continue
+		}
+		fleet.TotalEffectiveBalance += validator.Validator.EffectiveBalance
+		fleet.Credentials[util.WithdrawalCredentialsType(validator.Validator.WithdrawalCredentials)]++
+		if validator.Validator.ActivationEpoch <= c.summary.Epoch && validator.Validator.ExitEpoch > c.summary.Epoch {
+			increments := uint64(validator.Validator.EffectiveBalance) / effectiveBalanceIncrement
+			fleet.ExpectedDailyRewards += phase0.Gwei(increments * baseRewardPerIncrement * attestationWeight / weightDenominator * epochsPerDay)
+		}
+	}
+	fleet.UnderperformingValidators = len(c.underperformingValidators())
+
+	c.summary.Fleet = fleet
+
+	return nil
+}
+
+func specUint64(spec map[string]interface{}, key string) (uint64, error) {
+	tmp, exists := spec[key]
+	if !exists {
+		return 0, fmt.Errorf("spec missing %s", key)
+	}
+	value, isType := tmp.(uint64)
+	if !isType {
+		return 0, fmt.Errorf("%s of incorrect type", key)
+	}
+
+	return value, nil
+}
+
+// underperformingValidators returns the validators that lost rewards in the
+// epoch, by failing to attest, failing to obtain the source or target reward
+// for their attestation, or missing a proposal.
+func (c *command) underperformingValidators() map[phase0.ValidatorIndex]struct{} {
+	res := make(map[phase0.ValidatorIndex]struct{})
+	for _, validator := range c.summary.NonParticipatingValidators {
+		res[validator.Validator] = struct{}{}
+	}
+	for _, faults := range [][]*validatorFault{
+		c.summary.UntimelySourceValidators,
+		c.summary.IncorrectTargetValidators,
+		c.summary.UntimelyTargetValidators,
+	} {
+		for _, fault := range faults {
+			res[fault.Validator] = struct{}{}
+		}
+	}
+	for _, proposal := range c.summary.Proposals {
+		if !proposal.Block {
+			res[proposal.Proposer] = struct{}{}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,149 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsummary
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateFleet(t *testing.T) {
+	spec := map[string]interface{}{
+		"BASE_REWARD_FACTOR":          uint64(64),
+		"EFFECTIVE_BALANCE_INCREMENT": uint64(1000000000),
+	}
+
+	validators := []*apiv1.Validator{
+		{
+			Index:   1,
+			Balance: 32000000000,
+			Status:  apiv1.ValidatorStateActiveOngoing,
+			Validator: &phase0.Validator{
+				WithdrawalCredentials: append([]byte{0x01}, make([]byte, 31)...),
+				EffectiveBalance:      32000000000,
+				ActivationEpoch:       0,
+				ExitEpoch:             0xffffffffffffffff,
+			},
+		},
+		{
+			Index:   2,
+			Balance: 31500000000,
+			Status:  apiv1.ValidatorStateActiveOngoing,
+			Validator: &phase0.Validator{
+				WithdrawalCredentials: make([]byte, 32),
+				EffectiveBalance:      31000000000,
+				ActivationEpoch:       0,
+				ExitEpoch:             0xffffffffffffffff,
+			},
+		},
+		{
+			Index:   3,
+			Balance: 32000000000,
+			Status:  apiv1.ValidatorStatePendingQueued,
+			Validator: &phase0.Validator{
+				WithdrawalCredentials: append([]byte{0x01}, make([]byte, 31)...),
+				EffectiveBalance:      32000000000,
+				ActivationEpoch:       200,
+				ExitEpoch:             0xffffffffffffffff,
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		spec               map[string]interface{}
+		totalActiveBalance phase0.Gwei
+		summary            *validatorSummary
+		expected           *fleetSummary
+		err                string
+	}{
+		{
+			name:               "SpecMissing",
+			spec:               map[string]interface{}{},
+			totalActiveBalance: 1000000000000000,
+			summary:            &validatorSummary{},
+			err:                "spec missing BASE_REWARD_FACTOR",
+		},
+		{
+			name: "SpecIncorrectType",
+			spec: map[string]interface{}{
+				"BASE_REWARD_FACTOR":          "64",
+				"EFFECTIVE_BALANCE_INCREMENT": uint64(1000000000),
+			},
+			totalActiveBalance: 1000000000000000,
+			summary:            &validatorSummary{},
+			err:                "BASE_REWARD_FACTOR of incorrect type",
+		},
+		{
+			name:               "NoActiveBalance",
+			spec:               spec,
+			totalActiveBalance: 0,
+			summary:            &validatorSummary{},
+			err:                "cannot calculate rewards without active balance",
+		},
+		{
+			name:               "Good",
+			spec:               spec,
+			totalActiveBalance: 1000000000000000,
+			summary: &validatorSummary{
+				Epoch:      100,
+				Validators: validators,
+				NonParticipatingValidators: []*nonParticipatingValidator{
+					{Validator: 1},
+				},
+				UntimelySourceValidators: []*validatorFault{
+					{Validator: 1},
+				},
+				Proposals: []*epochProposal{
+					{Proposer: 2, Block: true},
+				},
+			},
+			expected: &fleetSummary{
+				Validators:            3,
+				TotalBalance:          95500000000,
+				TotalEffectiveBalance: 95000000000,
+				Statuses: map[string]int{
+					"active_ongoing": 2,
+					"pending_queued": 1,
+				},
+				Credentials: map[string]int{
+					"0x00": 1,
+					"0x01": 2,
+				},
+				// Base reward per increment is 1e9*64/sqrt(1e15) = 2023, so
+				// (32*2023*54/64 + 31*2023*54/64) * 225.
+				ExpectedDailyRewards:      12289725 + 11905650,
+				UnderperformingValidators: 1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				summary: test.summary,
+			}
+			err := c.calculateFleet(test.spec, test.totalActiveBalance, 225)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, c.summary.Fleet)
+			}
+		})
+	}
+}
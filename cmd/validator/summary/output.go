@@ -17,7 +17,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
 )
 
 func (c *command) output(ctx context.Context) (string, error) {
@@ -43,6 +46,10 @@ func (c *command) outputJSON(_ context.Context) (string, error) {
 func (c *command) outputTxt(_ context.Context) (string, error) {
 	builder := strings.Builder{}
 
+	if c.summary.Fleet != nil {
+		c.outputFleetTxt(&builder)
+	}
+
 	builder.WriteString("Epoch ")
 	builder.WriteString(fmt.Sprintf("%d:\n", c.summary.Epoch))
 	if len(c.summary.NonParticipatingValidators) > 0 {
@@ -84,3 +91,27 @@ func (c *command) outputTxt(_ context.Context) (string, error) {
 
 	return builder.String(), nil
 }
+
+func (c *command) outputFleetTxt(builder *strings.Builder) {
+	fleet := c.summary.Fleet
+	builder.WriteString(fmt.Sprintf("Validators: %d\n", fleet.Validators))
+	builder.WriteString(fmt.Sprintf("Total balance: %s\n", string2eth.GWeiToString(uint64(fleet.TotalBalance), true)))
+	builder.WriteString(fmt.Sprintf("Total effective balance: %s\n", string2eth.GWeiToString(uint64(fleet.TotalEffectiveBalance), true)))
+	builder.WriteString("Statuses:\n")
+	outputCounts(builder, fleet.Statuses)
+	builder.WriteString("Withdrawal credentials:\n")
+	outputCounts(builder, fleet.Credentials)
+	builder.WriteString(fmt.Sprintf("Expected daily rewards: %s\n", string2eth.GWeiToString(uint64(fleet.ExpectedDailyRewards), true)))
+	builder.WriteString(fmt.Sprintf("Underperforming validators: %d\n", fleet.UnderperformingValidators))
+}
+
+func outputCounts(builder *strings.Builder, counts map[string]int) {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		builder.WriteString(fmt.Sprintf("  %s: %d\n", key, counts[key]))
+	}
+}
@@ -46,6 +46,16 @@ func (c *command) process(ctx context.Context) error {
 		}
 	}
 
+	if c.validatorsFile != "" {
+		c.validators, err = util.ReadValidatorsFile(c.validatorsFile)
+		if err != nil {
+			return err
+		}
+		if len(c.validators) == 0 {
+			return errors.New("no validators in validators file")
+		}
+	}
+
 	c.summary.Validators, err = util.ParseValidators(ctx, c.validatorsProvider, c.validators, fmt.Sprintf("%d", c.summary.FirstSlot))
 	if err != nil {
 		return errors.Wrap(err, "failed to parse validators")
@@ -69,6 +79,12 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
+	if c.validatorsFile != "" {
+		if err := c.processFleet(ctx); err != nil {
+			return err
+		}
+	}
+
 	// if err := c.processSyncCommitteeDuties(ctx); err != nil {
 	// 	return err
 	// }
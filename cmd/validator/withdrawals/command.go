@@ -0,0 +1,124 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawals
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+	csv     bool
+
+	// Input.
+	validator string
+	fromSlot  *phase0.Slot
+	toSlot    *phase0.Slot
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	eth2Client         eth2client.Service
+	chainTime          chaintime.Service
+	blocksProvider     eth2client.SignedBeaconBlockProvider
+	validatorsProvider eth2client.ValidatorsProvider
+
+	// Output.
+	validatorInfo *apiv1.Validator
+	first         phase0.Slot
+	last          phase0.Slot
+	withdrawals   []*withdrawal
+}
+
+// withdrawal is a withdrawal for the validator.
+type withdrawal struct {
+	Slot      phase0.Slot  `json:"slot"`
+	Epoch     phase0.Epoch `json:"epoch"`
+	Timestamp time.Time    `json:"timestamp"`
+	Index     uint64       `json:"index"`
+	Address   string       `json:"address"`
+	Amount    phase0.Gwei  `json:"amount"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+		csv:     viper.GetBool("csv"),
+	}
+
+	if c.json && c.csv {
+		return nil, errors.New("only one of json and csv output allowed")
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validator = viper.GetString("validator")
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+
+	var err error
+	c.fromSlot, err = parseSlot(viper.GetString("from-slot"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid from slot")
+	}
+	c.toSlot, err = parseSlot(viper.GetString("to-slot"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid to slot")
+	}
+	if c.fromSlot != nil && c.toSlot != nil && *c.fromSlot > *c.toSlot {
+		return nil, errors.New("from slot must not be after to slot")
+	}
+
+	return c, nil
+}
+
+// parseSlot parses an optional slot.
+func parseSlot(input string) (*phase0.Slot, error) {
+	if input == "" {
+		return nil, nil
+	}
+	val, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	slot := phase0.Slot(val)
+
+	return &slot, nil
+}
@@ -0,0 +1,94 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type jsonOutput struct {
+	Validator   phase0.ValidatorIndex `json:"validator_index"`
+	FirstSlot   phase0.Slot           `json:"first_slot"`
+	LastSlot    phase0.Slot           `json:"last_slot"`
+	Withdrawals []*withdrawal         `json:"withdrawals"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	if c.csv {
+		return c.outputCSV(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		Validator:   c.validatorInfo.Index,
+		FirstSlot:   c.first,
+		LastSlot:    c.last,
+		Withdrawals: c.withdrawals,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	total := phase0.Gwei(0)
+	for _, withdrawal := range c.withdrawals {
+		total += withdrawal.Amount
+	}
+	builder.WriteString(fmt.Sprintf("Validator %d withdrawals for slots %d to %d: %d", c.validatorInfo.Index, c.first, c.last, len(c.withdrawals)))
+	if len(c.withdrawals) > 0 {
+		builder.WriteString(fmt.Sprintf(" totalling %s", string2eth.GWeiToString(uint64(total), true)))
+	}
+
+	for _, withdrawal := range c.withdrawals {
+		builder.WriteString(fmt.Sprintf("\n  Slot %d (epoch %d): %s to %s", withdrawal.Slot, withdrawal.Epoch, string2eth.GWeiToString(uint64(withdrawal.Amount), true), withdrawal.Address))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("\n    Withdrawal index: %d", withdrawal.Index))
+			builder.WriteString(fmt.Sprintf("\n    Time: %s", withdrawal.Timestamp.Format(time.RFC3339)))
+		}
+	}
+
+	return builder.String(), nil
+}
+
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString("slot,epoch,timestamp,withdrawal_index,address,amount_gwei\n")
+	for _, withdrawal := range c.withdrawals {
+		builder.WriteString(fmt.Sprintf("%d,%d,%s,%d,%s,%d\n", withdrawal.Slot, withdrawal.Epoch, withdrawal.Timestamp.Format(time.RFC3339), withdrawal.Index, withdrawal.Address, withdrawal.Amount))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,106 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawals
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	withdrawals := []*withdrawal{
+		{
+			Slot:      6300000,
+			Epoch:     196875,
+			Timestamp: time.Unix(1681338455, 0).UTC(),
+			Index:     100,
+			Address:   "0x8c1cc7e6bdb6df9ba0e8cff2d6d6e1cf3d8ad7b6",
+			Amount:    1000000000,
+		},
+		{
+			Slot:      6350000,
+			Epoch:     198437,
+			Timestamp: time.Unix(1681938455, 0).UTC(),
+			Index:     200,
+			Address:   "0x8c1cc7e6bdb6df9ba0e8cff2d6d6e1cf3d8ad7b6",
+			Amount:    32000000000,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		json        bool
+		csv         bool
+		verbose     bool
+		withdrawals []*withdrawal
+		res         string
+	}{
+		{
+			name:        "None",
+			withdrawals: []*withdrawal{},
+			res:         `Validator 1 withdrawals for slots 6000000 to 6400000: 0`,
+		},
+		{
+			name:        "Text",
+			withdrawals: withdrawals,
+			res: `Validator 1 withdrawals for slots 6000000 to 6400000: 2 totalling 33 Ether
+  Slot 6300000 (epoch 196875): 1 Ether to 0x8c1cc7e6bdb6df9ba0e8cff2d6d6e1cf3d8ad7b6
+  Slot 6350000 (epoch 198437): 32 Ether to 0x8c1cc7e6bdb6df9ba0e8cff2d6d6e1cf3d8ad7b6`,
+		},
+		{
+			name:        "Verbose",
+			verbose:     true,
+			withdrawals: withdrawals[:1],
+			res: `Validator 1 withdrawals for slots 6000000 to 6400000: 1 totalling 1 Ether
+  Slot 6300000 (epoch 196875): 1 Ether to 0x8c1cc7e6bdb6df9ba0e8cff2d6d6e1cf3d8ad7b6
+    Withdrawal index: 100
+    Time: 2023-04-12T22:27:35Z`,
+		},
+		{
+			name:        "CSV",
+			csv:         true,
+			withdrawals: withdrawals,
+			res: `slot,epoch,timestamp,withdrawal_index,address,amount_gwei
+6300000,196875,2023-04-12T22:27:35Z,100,0x8c1cc7e6bdb6df9ba0e8cff2d6d6e1cf3d8ad7b6,1000000000
+6350000,198437,2023-04-19T21:07:35Z,200,0x8c1cc7e6bdb6df9ba0e8cff2d6d6e1cf3d8ad7b6,32000000000`,
+		},
+		{
+			name:        "JSON",
+			json:        true,
+			withdrawals: withdrawals[:1],
+			res:         `{"validator_index":1,"first_slot":6000000,"last_slot":6400000,"withdrawals":[{"slot":6300000,"epoch":196875,"timestamp":"2023-04-12T22:27:35Z","index":100,"address":"0x8c1cc7e6bdb6df9ba0e8cff2d6d6e1cf3d8ad7b6","amount":1000000000}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				json:          test.json,
+				csv:           test.csv,
+				verbose:       test.verbose,
+				validatorInfo: &apiv1.Validator{Index: 1},
+				first:         6000000,
+				last:          6400000,
+				withdrawals:   test.withdrawals,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawals
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// defaultEpochs is the number of epochs covered if no from slot is supplied,
+// which is approximately one day on mainnet.
+const defaultEpochs = 225
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	c.validatorInfo, err = util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator information")
+	}
+
+	c.last = c.chainTime.CurrentSlot()
+	if c.toSlot != nil {
+		c.last = *c.toSlot
+	}
+	c.first = 0
+	if c.fromSlot != nil {
+		c.first = *c.fromSlot
+	} else if lastEpoch := c.chainTime.SlotToEpoch(c.last); lastEpoch >= defaultEpochs {
+		c.first = c.chainTime.FirstSlotOfEpoch(lastEpoch - defaultEpochs)
+	}
+	if c.first > c.last {
+		return errors.New("from slot must not be after to slot")
+	}
+
+	// Withdrawals only exist from Capella onwards.
+	startSlot := c.first
+	if capellaSlot := c.chainTime.FirstSlotOfEpoch(c.chainTime.CapellaInitialEpoch()); startSlot < capellaSlot {
+		startSlot = capellaSlot
+	}
+
+	c.withdrawals = make([]*withdrawal, 0)
+	for slot := startSlot; slot <= c.last; slot++ {
+		withdrawals, err := c.obtainWithdrawals(ctx, slot)
+		if err != nil {
+			return err
+		}
+		for _, blockWithdrawal := range withdrawals {
+			if blockWithdrawal.ValidatorIndex != c.validatorInfo.Index {
+				continue
+			}
+			c.withdrawals = append(c.withdrawals, &withdrawal{
+				Slot:      slot,
+				Epoch:     c.chainTime.SlotToEpoch(slot),
+				Timestamp: c.chainTime.StartOfSlot(slot).UTC(),
+				Index:     uint64(blockWithdrawal.Index),
+				Address:   blockWithdrawal.Address.String(),
+				Amount:    blockWithdrawal.Amount,
+			})
+		}
+	}
+
+	return nil
+}
+
+// obtainWithdrawals obtains the withdrawals in the block at the given slot.
+func (c *command) obtainWithdrawals(ctx context.Context, slot phase0.Slot) ([]*capella.Withdrawal, error) {
+	block, err := c.blocksProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+	}
+	if block == nil {
+		util.Log.Debug().Uint64("slot", uint64(slot)).Msg("No block")
+		return nil, nil
+	}
+
+	switch block.Version {
+	case spec.DataVersionPhase0, spec.DataVersionAltair, spec.DataVersionBellatrix:
+		return nil, nil
+	case spec.DataVersionCapella:
+		return block.Capella.Message.Body.ExecutionPayload.Withdrawals, nil
+	default:
+		return nil, fmt.Errorf("unhandled block version %v", block.Version)
+	}
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	return nil
+}
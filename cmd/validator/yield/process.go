@@ -15,7 +15,6 @@ package validatoryield
 
 import (
 	"context"
-	"fmt"
 	"math/big"
 	"strconv"
 
@@ -32,10 +31,8 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	if c.debug {
-		fmt.Printf("Active validators: %v\n", c.results.ActiveValidators)
-		fmt.Printf("Active validator balance: %v\n", c.results.ActiveValidatorBalance)
-	}
+	util.Log.Debug().Stringer("active_validators", c.results.ActiveValidators).Msg("Obtained active validators")
+	util.Log.Debug().Stringer("active_validator_balance", c.results.ActiveValidatorBalance).Msg("Obtained active validator balance")
 
 	return c.calculateYield(ctx)
 }
@@ -60,49 +57,31 @@ func (c *command) calculateYield(ctx context.Context) error {
 	if !isType {
 		return errors.New("BASE_REWARD_FACTOR of incorrect type")
 	}
-	if c.debug {
-		fmt.Printf("Base reward: %v\n", baseReward)
-	}
+	util.Log.Debug().Uint64("base_reward", baseReward).Msg("Calculated base reward")
 	c.results.BaseReward = decimal.New(int64(baseReward), 0)
 
 	numerator := decimal.New(32, 0).Mul(weiPerGwei).Mul(c.results.BaseReward)
-	if c.debug {
-		fmt.Printf("Numerator: %v\n", numerator)
-	}
+	util.Log.Debug().Stringer("numerator", numerator).Msg("Calculated numerator")
 	activeValidatorsBalanceInGwei := c.results.ActiveValidatorBalance.Div(weiPerGwei)
 	denominator := decimal.NewFromBigInt(new(big.Int).Sqrt(activeValidatorsBalanceInGwei.BigInt()), 0)
-	if c.debug {
-		fmt.Printf("Denominator: %v\n", denominator)
-	}
+	util.Log.Debug().Stringer("denominator", denominator).Msg("Calculated denominator")
 	c.results.ValidatorRewardsPerEpoch = numerator.Div(denominator).RoundDown(0).Mul(weiPerGwei)
-	if c.debug {
-		fmt.Printf("Validator rewards per epoch: %v\n", c.results.ValidatorRewardsPerEpoch)
-	}
+	util.Log.Debug().Stringer("rewards", c.results.ValidatorRewardsPerEpoch).Msg("Calculated validator rewards per epoch")
 	c.results.ValidatorRewardsPerYear = c.results.ValidatorRewardsPerEpoch.Mul(epochsPerYear)
-	if c.debug {
-		fmt.Printf("Validator rewards per year: %v\n", c.results.ValidatorRewardsPerYear)
-	}
+	util.Log.Debug().Stringer("rewards", c.results.ValidatorRewardsPerYear).Msg("Calculated validator rewards per year")
 	// Expected validator rewards assume that there is no proposal and no sync committee participation,
 	// but that head/source/target are correct and timely: this gives 54/64 of the reward.
 	// These values are obtained from https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/beacon-chain.md#incentivization-weights
 	c.results.ExpectedValidatorRewardsPerEpoch = c.results.ValidatorRewardsPerEpoch.Mul(decimal.New(54, 0)).Div(decimal.New(64, 0)).Div(weiPerGwei).RoundDown(0).Mul(weiPerGwei)
-	if c.debug {
-		fmt.Printf("Expected validator rewards per epoch: %v\n", c.results.ExpectedValidatorRewardsPerEpoch)
-	}
+	util.Log.Debug().Stringer("rewards", c.results.ExpectedValidatorRewardsPerEpoch).Msg("Calculated expected validator rewards per epoch")
 
 	c.results.MaxIssuancePerEpoch = c.results.ValidatorRewardsPerEpoch.Mul(c.results.ActiveValidators)
-	if c.debug {
-		fmt.Printf("Chain rewards per epoch: %v\n", c.results.MaxIssuancePerEpoch)
-	}
+	util.Log.Debug().Stringer("rewards", c.results.MaxIssuancePerEpoch).Msg("Calculated chain rewards per epoch")
 	c.results.MaxIssuancePerYear = c.results.MaxIssuancePerEpoch.Mul(epochsPerYear)
-	if c.debug {
-		fmt.Printf("Chain rewards per year: %v\n", c.results.MaxIssuancePerYear)
-	}
+	util.Log.Debug().Stringer("rewards", c.results.MaxIssuancePerYear).Msg("Calculated chain rewards per year")
 
 	c.results.Yield = c.results.ValidatorRewardsPerYear.Div(weiPerGwei).Div(weiPerGwei).Div(decimal.New(32, 0))
-	if c.debug {
-		fmt.Printf("Yield: %v\n", c.results.Yield)
-	}
+	util.Log.Debug().Stringer("yield", c.results.Yield).Msg("Calculated yield")
 
 	return nil
 }
@@ -160,9 +139,7 @@ func (c *command) setup(ctx context.Context) error {
 
 		c.results.ActiveValidators = decimal.New(activeValidators, 0)
 		c.results.ActiveValidatorBalance = decimal.New(32, 0).Mul(c.results.ActiveValidators).Mul(weiPerGwei).Mul(weiPerGwei)
-		if c.debug {
-			fmt.Println("Assuming 32Ξ per validator")
-		}
+		util.Log.Debug().Msg("Assuming 32Ξ per validator")
 	}
 
 	return nil
@@ -0,0 +1,62 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatoralive "github.com/wealdtech/ethdo/cmd/validator/alive"
+)
+
+var validatorAliveCmd = &cobra.Command{
+	Use:   "alive",
+	Short: "Check if a validator has recently attested",
+	Long: `Check if a validator has recently attested.  For example:
+
+    ethdo validator alive --validator=12345 --max-missed=2
+
+The validator is alive if its attestation for the current epoch or any of the previous max-missed epochs has been included on the chain.  Epochs are searched from the most recent, and the search stops as soon as an attestation is found, so a live validator usually requires only a handful of calls to the beacon node.  This makes the command suitable for use in health checks and failover automation.
+
+This will return 0 if the validator is alive, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatoralive.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorAliveCmd)
+	validatorFlags(validatorAliveCmd)
+	validatorAliveCmd.Flags().String("validator", "", "the validator to check")
+	validatorAliveCmd.Flags().Uint64("max-missed", 2, "the number of previous epochs to search for an attestation")
+	validatorAliveCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func validatorAliveBindings() {
+	validatorBindings()
+	if err := viper.BindPFlag("validator", validatorAliveCmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("max-missed", validatorAliveCmd.Flags().Lookup("max-missed")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", validatorAliveCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorbalancehistory "github.com/wealdtech/ethdo/cmd/validator/balancehistory"
+)
+
+var validatorBalanceHistoryCmd = &cobra.Command{
+	Use:   "balancehistory",
+	Short: "List the balances of a validator over a range of epochs",
+	Long: `List the balance of a validator at the start of each epoch in a range.  For example:
+
+    ethdo validator balancehistory --validator=primary/validator --from-epoch=200000 --to-epoch=201000 --csv
+
+Balances are obtained from the state at the start of each epoch, so historical balances require a beacon node that holds historical states.  Balances of finalized epochs can be cached in a file with --cache-file, in which case only new epochs are obtained the next time the history is exported.
+
+In quiet mode this will return 0 if the validator exists, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorbalancehistory.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorBalanceHistoryCmd)
+	validatorFlags(validatorBalanceHistoryCmd)
+	validatorBalanceHistoryCmd.Flags().String("validator", "", "Validator for which to list balances")
+	validatorBalanceHistoryCmd.Flags().String("from-epoch", "", "First epoch for which to list balances (defaults to approximately one day before the to epoch)")
+	validatorBalanceHistoryCmd.Flags().String("to-epoch", "", "Last epoch for which to list balances (defaults to current epoch)")
+	validatorBalanceHistoryCmd.Flags().String("cache-file", "", "File in which to cache balances of finalized epochs")
+	validatorBalanceHistoryCmd.Flags().Bool("json", false, "output data in JSON format")
+	validatorBalanceHistoryCmd.Flags().Bool("csv", false, "output data in CSV format")
+}
+
+func validatorBalanceHistoryBindings() {
+	if err := viper.BindPFlag("validator", validatorBalanceHistoryCmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-epoch", validatorBalanceHistoryCmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", validatorBalanceHistoryCmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("cache-file", validatorBalanceHistoryCmd.Flags().Lookup("cache-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", validatorBalanceHistoryCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", validatorBalanceHistoryCmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
+}
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	validatorcredentialsfuzz "github.com/wealdtech/ethdo/cmd/validator/credentials/fuzz"
@@ -47,7 +45,9 @@ In quiet mode this will return 0 if the credentials operation has been generated
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -67,6 +67,7 @@ func init() {
 	validatorCredentialsFuzzCmd.Flags().String("genesis-validators-root", "", "Genesis validators root to use for signing (overrides fetching from beacon node)")
 	validatorCredentialsFuzzCmd.Flags().Uint("fuzziness", 5, "Fuzziness of the withdrawal credentials; 0 is no fuzziness, 100 is max")
 	validatorCredentialsFuzzCmd.Flags().Int64("seed", 0, "Seed for the fuzzing; 0 is random")
+	validatorCredentialsFuzzCmd.Flags().StringSlice("strategies", nil, "Fuzzing strategies to apply, from \"message\", \"root\" and \"signature\" (defaults to all)")
 }
 
 func validatorCredentialsFuzzBindings() {
@@ -103,4 +104,7 @@ func validatorCredentialsFuzzBindings() {
 	if err := viper.BindPFlag("seed", validatorCredentialsFuzzCmd.Flags().Lookup("seed")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("fuzz-strategies", validatorCredentialsFuzzCmd.Flags().Lookup("strategies")); err != nil {
+		panic(err)
+	}
 }
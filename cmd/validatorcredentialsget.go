@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	validatorcredentialsget "github.com/wealdtech/ethdo/cmd/validator/credentials/get"
@@ -28,6 +26,10 @@ var validatorCredentialsGetCmd = &cobra.Command{
 
     ethdo validator credentials get --validator=primary/validator
 
+Multiple validators can be queried at once with --validators or --validators-file, in which case a summary of the number of validators with each type of credentials is also provided.  For example:
+
+    ethdo validator credentials get --validators=1,2,100-200
+
 In quiet mode this will return 0 if the validator exists, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := validatorcredentialsget.Run(cmd)
@@ -38,7 +40,9 @@ In quiet mode this will return 0 if the validator exists, otherwise 1.`,
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -48,10 +52,22 @@ func init() {
 	validatorCredentialsCmd.AddCommand(validatorCredentialsGetCmd)
 	validatorCredentialsFlags(validatorCredentialsGetCmd)
 	validatorCredentialsGetCmd.Flags().String("validator", "", "Validator for which to get validator credentials")
+	validatorCredentialsGetCmd.Flags().StringSlice("validators", nil, "Validators for which to get validator credentials")
+	validatorCredentialsGetCmd.Flags().String("validators-file", "", "File containing validators for which to get validator credentials, one per line")
+	validatorCredentialsGetCmd.Flags().Bool("json", false, "output data in JSON format")
 }
 
 func validatorCredentialsGetBindings() {
 	if err := viper.BindPFlag("validator", validatorCredentialsGetCmd.Flags().Lookup("validator")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("validators", validatorCredentialsGetCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators-file", validatorCredentialsGetCmd.Flags().Lookup("validators-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", validatorCredentialsGetCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
 }
@@ -14,10 +14,9 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
 	validatorcredentialsset "github.com/wealdtech/ethdo/cmd/validator/credentials/set"
 )
 
@@ -37,6 +36,10 @@ The validator account can be specified in one of a number of ways:
   - validator and withdrawal private key using --validator and --private-key; this will generate a single operation
   - account and withdrawal account using --account and --withdrawal-account; this will generate a single operation
 
+Rather than sending all withdrawals to the address given by --withdrawal-address, --address-book can supply a CSV file of validator index and execution address pairs.  Operations are only generated for validators in the file, and every validator in the file must have exactly one operation.
+
+With --prepare-offline, the offline preparation file can be restricted to a number of validators with --validator, --validators or --pubkeys-file, or to the validators generated by a mnemonic with --mnemonic.
+
 In quiet mode this will return 0 if the credentials operation has been generated (and successfully broadcast if online), otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := validatorcredentialsset.Run(cmd)
@@ -47,7 +50,9 @@ In quiet mode this will return 0 if the credentials operation has been generated
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -57,20 +62,40 @@ func init() {
 	validatorCredentialsCmd.AddCommand(validatorCredentialsSetCmd)
 	validatorCredentialsFlags(validatorCredentialsSetCmd)
 	validatorCredentialsSetCmd.Flags().Bool("prepare-offline", false, "Create files for offline use")
+	validatorCredentialsSetCmd.Flags().StringSlice("validators", nil, "Validators to include when creating files for offline use, as indices, public keys or accounts (default all)")
+	validatorCredentialsSetCmd.Flags().String("pubkeys-file", "", "File containing public keys of validators, one per line, to include when creating files for offline use")
 	validatorCredentialsSetCmd.Flags().String("validator", "", "Validator for which to set validator credentials")
 	validatorCredentialsSetCmd.Flags().String("withdrawal-account", "", "Account with which the validator's withdrawal credentials were set")
 	validatorCredentialsSetCmd.Flags().String("withdrawal-address", "", "Execution address to which to direct withdrawals")
+	validatorCredentialsSetCmd.Flags().String("address-book", "", "CSV file of validator index and execution address pairs, to direct each validator's withdrawals to its own address")
+	validatorCredentialsSetCmd.Flags().Bool("allow-contract-address", false, "Allow withdrawal addresses that are contracts when checking with --execution-connection")
 	validatorCredentialsSetCmd.Flags().String("signed-operations", "", "Use pre-defined JSON signed operation as created by --json to transmit the credentials change operation (reads from change-operations.json if not present)")
 	validatorCredentialsSetCmd.Flags().Bool("json", false, "Generate JSON data containing a signed operation rather than broadcast it to the network (implied when offline)")
+	validatorCredentialsSetCmd.Flags().String("output-format", "", "Format of generated signed operations rather than broadcast them to the network: json or ssz (ssz also writes the raw encoding to a file)")
 	validatorCredentialsSetCmd.Flags().Bool("offline", false, "Do not attempt to connect to a beacon node to obtain information for the operation")
 	validatorCredentialsSetCmd.Flags().String("fork-version", "", "Fork version to use for signing (overrides fetching from beacon node)")
 	validatorCredentialsSetCmd.Flags().String("genesis-validators-root", "", "Genesis validators root to use for signing (overrides fetching from beacon node)")
+	validatorCredentialsSetCmd.Flags().String("network", "", "Well-known network whose genesis validators root and fork versions to use for signing (mainnet, holesky, sepolia or gnosis)")
+	if err := validatorCredentialsSetCmd.RegisterFlagCompletionFunc("network", completeNetworks(beacon.NetworkNames())); err != nil {
+		panic(err)
+	}
+	validatorCredentialsSetCmd.Flags().Bool("yes", false, "Broadcast without asking for confirmation")
+	validatorCredentialsSetCmd.Flags().Bool("provenance", false, "Write network provenance alongside generated signed operations")
+	validatorCredentialsSetCmd.Flags().Bool("resume", false, "Resume from the checkpoint left by an interrupted run with the same parameters")
+	validatorCredentialsSetCmd.Flags().Bool("verify-light-client", false, "Verify the genesis validators root and fork versions obtained from the beacon node using light client data from a trusted block root; validator details are not verified")
+	validatorCredentialsSetCmd.Flags().String("trusted-block-root", "", "Root of a recent finalized block from a trusted source, used with --verify-light-client")
 }
 
 func validatorCredentialsSetBindings() {
 	if err := viper.BindPFlag("prepare-offline", validatorCredentialsSetCmd.Flags().Lookup("prepare-offline")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("validators", validatorCredentialsSetCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("pubkeys-file", validatorCredentialsSetCmd.Flags().Lookup("pubkeys-file")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("validator", validatorCredentialsSetCmd.Flags().Lookup("validator")); err != nil {
 		panic(err)
 	}
@@ -83,9 +108,18 @@ func validatorCredentialsSetBindings() {
 	if err := viper.BindPFlag("withdrawal-address", validatorCredentialsSetCmd.Flags().Lookup("withdrawal-address")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("address-book", validatorCredentialsSetCmd.Flags().Lookup("address-book")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("allow-contract-address", validatorCredentialsSetCmd.Flags().Lookup("allow-contract-address")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("json", validatorCredentialsSetCmd.Flags().Lookup("json")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("output-format", validatorCredentialsSetCmd.Flags().Lookup("output-format")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("offline", validatorCredentialsSetCmd.Flags().Lookup("offline")); err != nil {
 		panic(err)
 	}
@@ -95,4 +129,22 @@ func validatorCredentialsSetBindings() {
 	if err := viper.BindPFlag("genesis-validators-root", validatorCredentialsSetCmd.Flags().Lookup("genesis-validators-root")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("network", validatorCredentialsSetCmd.Flags().Lookup("network")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("yes", validatorCredentialsSetCmd.Flags().Lookup("yes")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("provenance", validatorCredentialsSetCmd.Flags().Lookup("provenance")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("resume", validatorCredentialsSetCmd.Flags().Lookup("resume")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("verify-light-client", validatorCredentialsSetCmd.Flags().Lookup("verify-light-client")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("trusted-block-root", validatorCredentialsSetCmd.Flags().Lookup("trusted-block-root")); err != nil {
+		panic(err)
+	}
 }
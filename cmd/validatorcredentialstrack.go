@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorcredentialstrack "github.com/wealdtech/ethdo/cmd/validator/credentials/track"
+)
+
+var validatorCredentialsTrackCmd = &cobra.Command{
+	Use:   "track",
+	Short: "Track inclusion of submitted credentials change operations",
+	Long: `Track inclusion of submitted credentials change operations.  For example:
+
+    ethdo validator credentials track --file=change-operations.json
+
+Blocks are monitored until all operations in the file have been resolved.  If a credentials change for one of the validators is included with a different withdrawal address to that submitted, which indicates that the withdrawal key may have been compromised, an alert is raised on stderr and sent to any webhooks supplied with --webhooks.
+
+In quiet mode this will return 0 if all operations are included as submitted, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorcredentialstrack.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	validatorCredentialsCmd.AddCommand(validatorCredentialsTrackCmd)
+	validatorCredentialsFlags(validatorCredentialsTrackCmd)
+	validatorCredentialsTrackCmd.Flags().String("file", "", "File containing the submitted credentials change operations, as created by validator credentials set")
+	validatorCredentialsTrackCmd.Flags().String("from-slot", "", "Slot from which to search for the operations (defaults to current slot)")
+	validatorCredentialsTrackCmd.Flags().StringSlice("webhooks", nil, "URLs to which alerts are sent as JSON")
+	validatorCredentialsTrackCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func validatorCredentialsTrackBindings() {
+	if err := viper.BindPFlag("file", validatorCredentialsTrackCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-slot", validatorCredentialsTrackCmd.Flags().Lookup("from-slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("webhooks", validatorCredentialsTrackCmd.Flags().Lookup("webhooks")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", validatorCredentialsTrackCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
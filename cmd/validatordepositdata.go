@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	validatordepositdata "github.com/wealdtech/ethdo/cmd/validator/depositdata"
@@ -30,6 +28,12 @@ var validatorDepositDataCmd = &cobra.Command{
 
 If validatoraccount is provided with an account path it will generate deposit data for all matching accounts.
 
+Deposit data can also be generated for a number of validators derived from a mnemonic, using the same paths as the staking deposit CLI.  For example:
+
+    ethdo validator depositdata --mnemonic="abandon … art" --start-index=0 --count=10 --withdrawaladdress=0x… --depositvalue="32 Ether" --deposit-cli
+
+With --deposit-cli the output is in the same format as the deposit_data-*.json file generated by the staking deposit CLI, and can be uploaded to the launchpad.
+
 The information generated can be passed to ethereal to create a deposit from the Ethereum 1 chain.
 
 In quiet mode this will return 0 if the the data can be generated correctly, otherwise 1.`,
@@ -41,7 +45,9 @@ In quiet mode this will return 0 if the the data can be generated correctly, oth
 		if viper.GetBool("quiet") {
 			return nil
 		}
-		fmt.Println(res)
+		if err := outputResult(res); err != nil {
+			return err
+		}
 		return nil
 	},
 }
@@ -57,6 +63,10 @@ func init() {
 	validatorDepositDataCmd.Flags().Bool("raw", false, "Print raw deposit data transaction data")
 	validatorDepositDataCmd.Flags().String("forkversion", "", "Use a hard-coded fork version (default is to use mainnet value)")
 	validatorDepositDataCmd.Flags().Bool("launchpad", false, "Print launchpad-compatible JSON")
+	validatorDepositDataCmd.Flags().Bool("deposit-cli", false, "Print JSON in the format of the staking deposit CLI's deposit data file")
+	validatorDepositDataCmd.Flags().String("deposit-cli-version", "2.7.0", "Value of deposit_cli_version when printing JSON in the format of the staking deposit CLI")
+	validatorDepositDataCmd.Flags().Uint64("start-index", 0, "Index of the first validator key to derive from the mnemonic")
+	validatorDepositDataCmd.Flags().Uint64("count", 1, "Number of validator keys to derive from the mnemonic")
 }
 
 func validatorDepositdataBindings() {
@@ -84,4 +94,16 @@ func validatorDepositdataBindings() {
 	if err := viper.BindPFlag("launchpad", validatorDepositDataCmd.Flags().Lookup("launchpad")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("deposit-cli", validatorDepositDataCmd.Flags().Lookup("deposit-cli")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("deposit-cli-version", validatorDepositDataCmd.Flags().Lookup("deposit-cli-version")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("start-index", validatorDepositDataCmd.Flags().Lookup("start-index")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("count", validatorDepositDataCmd.Flags().Lookup("count")); err != nil {
+		panic(err)
+	}
 }
@@ -14,7 +14,7 @@
 package cmd
 
 import (
-	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -23,12 +23,16 @@ import (
 
 var validatorDutiesCmd = &cobra.Command{
 	Use:   "duties",
-	Short: "List known duties for a validator",
-	Long: `List known duties for a validator. For example:
+	Short: "List known duties for one or more validators",
+	Long: `List known duties for one or more validators. For example:
 
     ethdo validator duties --account=Validators/One
 
-Attester duties are known for the current and next epoch.  Proposer duties are known for the current epoch.
+Multiple validators can be supplied with --validators.  For example:
+
+    ethdo validator duties --validators=1,2,100-200
+
+Attester duties are known for the current and next epoch.  Proposer duties are known for the current epoch, and for the next epoch if the beacon node supplies them.  Sync committee membership is shown for the current and next sync committee period.
 
 In quiet mode this will return 0 if the the duties have been obtained, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -39,7 +43,9 @@ In quiet mode this will return 0 if the the duties have been obtained, otherwise
 		if viper.GetBool("quiet") {
 			return nil
 		}
-		fmt.Print(res)
+		if err := outputResult(strings.TrimSuffix(res, "\n")); err != nil {
+			return err
+		}
 		return nil
 	},
 }
@@ -49,6 +55,8 @@ func init() {
 	validatorFlags(validatorDutiesCmd)
 	validatorDutiesCmd.Flags().String("pubkey", "", "validator public key for duties")
 	validatorDutiesCmd.Flags().String("index", "", "validator index for duties")
+	validatorDutiesCmd.Flags().StringSlice("validators", nil, "validators for duties")
+	validatorDutiesCmd.Flags().Bool("json", false, "output data in JSON format")
 }
 
 func validatorDutiesBindings() {
@@ -58,4 +66,10 @@ func validatorDutiesBindings() {
 	if err := viper.BindPFlag("index", validatorDutiesCmd.Flags().Lookup("index")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("validators", validatorDutiesCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", validatorDutiesCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
 }
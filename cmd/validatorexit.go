@@ -14,10 +14,9 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
 	validatorexit "github.com/wealdtech/ethdo/cmd/validator/exit"
 )
 
@@ -33,7 +32,17 @@ The validator and key can be specified in one of a number of ways:
   - mnemonic and path to the validator using --mnemonic and --path
   - mnemonic and validator index or public key using --mnemonic and --validator
   - validator private key using --private-key
-  - validator account using --validator
+  - validator account using --account or --validator, including accounts held by Dirk when --remote is supplied
+
+When the validator is known, only information about that validator is obtained from the beacon node.  This includes --prepare-offline, in which case the resulting offline preparation file can only be used to exit that validator.
+
+The offline preparation file can also be restricted to a number of validators with --validators or --pubkeys-file, or to the validators generated by a mnemonic with --mnemonic.
+
+Multiple exit operations can be broadcast by supplying a JSON array of signed operations with --signed-operation.  The broadcasts can be spread over time with --stagger, and recorded with --progress-file so that an interrupted broadcast can be resumed without resubmitting exits.  For example:
+
+    ethdo validator exit --signed-operation=exits.json --stagger=2 --progress-file=exit-progress.json
+
+The epochs at which the exits would take effect, given the current exit queue, can be shown before they are broadcast or output with --estimate-exit-queue.  This requires information about all validators, so can take a while on large chains.
 
 In quiet mode this will return 0 if the exit operation has been generated (and successfully broadcast if online), otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -45,7 +54,9 @@ In quiet mode this will return 0 if the exit operation has been generated (and s
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -56,12 +67,27 @@ func init() {
 	validatorFlags(validatorExitCmd)
 	validatorExitCmd.Flags().Int64("epoch", -1, "Epoch at which to exit (defaults to current epoch)")
 	validatorExitCmd.Flags().Bool("prepare-offline", false, "Create files for offline use")
+	validatorExitCmd.Flags().StringSlice("validators", nil, "Validators to include when creating files for offline use, as indices, public keys or accounts (default all)")
+	validatorExitCmd.Flags().String("pubkeys-file", "", "File containing public keys of validators, one per line, to include when creating files for offline use")
 	validatorExitCmd.Flags().String("validator", "", "Validator to exit")
 	validatorExitCmd.Flags().String("signed-operation", "", "Use pre-defined JSON signed operation as created by --json to transmit the exit operation (reads from exit-operations.json if not present)")
 	validatorExitCmd.Flags().Bool("json", false, "Generate JSON data containing a signed operation rather than broadcast it to the network (implied when offline)")
+	validatorExitCmd.Flags().String("output-format", "", "Format of generated signed operations rather than broadcast them to the network: json or ssz (ssz also writes the raw encoding to a file)")
 	validatorExitCmd.Flags().Bool("offline", false, "Do not attempt to connect to a beacon node to obtain information for the operation")
 	validatorExitCmd.Flags().String("fork-version", "", "Fork version to use for signing (overrides fetching from beacon node)")
 	validatorExitCmd.Flags().String("genesis-validators-root", "", "Genesis validators root to use for signing (overrides fetching from beacon node)")
+	validatorExitCmd.Flags().String("network", "", "Well-known network whose genesis validators root and fork versions to use for signing (mainnet, holesky, sepolia or gnosis)")
+	if err := validatorExitCmd.RegisterFlagCompletionFunc("network", completeNetworks(beacon.NetworkNames())); err != nil {
+		panic(err)
+	}
+	validatorExitCmd.Flags().Bool("yes", false, "Broadcast without asking for confirmation")
+	validatorExitCmd.Flags().Bool("provenance", false, "Write network provenance alongside generated signed operations")
+	validatorExitCmd.Flags().String("domain-fork", "", "Fork whose version is used for signing: genesis, current or capella (defaults to the fork defined by the spec)")
+	validatorExitCmd.Flags().Bool("verify-light-client", false, "Verify the genesis validators root and fork versions obtained from the beacon node using light client data from a trusted block root; validator details are not verified")
+	validatorExitCmd.Flags().String("trusted-block-root", "", "Root of a recent finalized block from a trusted source, used with --verify-light-client")
+	validatorExitCmd.Flags().String("stagger", "", "Time to wait between broadcasts when broadcasting multiple exit operations, as a duration (e.g. 10m) or a number of epochs")
+	validatorExitCmd.Flags().String("progress-file", "", "File in which to record the exit operations broadcast when broadcasting multiple exit operations, allowing an interrupted broadcast to be resumed")
+	validatorExitCmd.Flags().Bool("estimate-exit-queue", false, "Show the estimated exit and withdrawable epochs of the exits given the current exit queue")
 }
 
 func validatorExitBindings() {
@@ -71,6 +97,12 @@ func validatorExitBindings() {
 	if err := viper.BindPFlag("prepare-offline", validatorExitCmd.Flags().Lookup("prepare-offline")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("validators", validatorExitCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("pubkeys-file", validatorExitCmd.Flags().Lookup("pubkeys-file")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("validator", validatorExitCmd.Flags().Lookup("validator")); err != nil {
 		panic(err)
 	}
@@ -80,6 +112,9 @@ func validatorExitBindings() {
 	if err := viper.BindPFlag("json", validatorExitCmd.Flags().Lookup("json")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("output-format", validatorExitCmd.Flags().Lookup("output-format")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("offline", validatorExitCmd.Flags().Lookup("offline")); err != nil {
 		panic(err)
 	}
@@ -89,4 +124,31 @@ func validatorExitBindings() {
 	if err := viper.BindPFlag("genesis-validators-root", validatorExitCmd.Flags().Lookup("genesis-validators-root")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("network", validatorExitCmd.Flags().Lookup("network")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("yes", validatorExitCmd.Flags().Lookup("yes")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("provenance", validatorExitCmd.Flags().Lookup("provenance")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("domain-fork", validatorExitCmd.Flags().Lookup("domain-fork")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("verify-light-client", validatorExitCmd.Flags().Lookup("verify-light-client")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("trusted-block-root", validatorExitCmd.Flags().Lookup("trusted-block-root")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("stagger", validatorExitCmd.Flags().Lookup("stagger")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("progress-file", validatorExitCmd.Flags().Lookup("progress-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("estimate-exit-queue", validatorExitCmd.Flags().Lookup("estimate-exit-queue")); err != nil {
+		panic(err)
+	}
 }
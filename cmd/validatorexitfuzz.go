@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	validatorexitfuzz "github.com/wealdtech/ethdo/cmd/validator/exitfuzz"
@@ -33,7 +31,7 @@ The validator and key can be specified in one of a number of ways:
   - mnemonic and path to the validator using --mnemonic and --path
   - mnemonic and validator index or public key using --mnemonic and --validator
   - validator private key using --private-key
-  - validator account using --validator
+  - validator account using --account or --validator, including accounts held by Dirk when --remote is supplied
 
 In quiet mode this will return 0 if the fuzz operation has been generated (and successfully broadcast if online), otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -45,7 +43,9 @@ In quiet mode this will return 0 if the fuzz operation has been generated (and s
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -64,6 +64,7 @@ func init() {
 	validatorExitFuzzCmd.Flags().String("genesis-validators-root", "", "Genesis validators root to use for signing (overrides fetching from beacon node)")
 	validatorExitFuzzCmd.Flags().Uint("fuzziness", 5, "Fuzziness of the exit request")
 	validatorExitFuzzCmd.Flags().Int64("seed", 0, "Seed for the fuzzing; 0 is random")
+	validatorExitFuzzCmd.Flags().StringSlice("strategies", nil, "Fuzzing strategies to apply, from \"message\", \"root\" and \"signature\" (defaults to all)")
 }
 
 func validatorExitFuzzBindings() {
@@ -97,4 +98,7 @@ func validatorExitFuzzBindings() {
 	if err := viper.BindPFlag("seed", validatorExitFuzzCmd.Flags().Lookup("seed")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("fuzz-strategies", validatorExitFuzzCmd.Flags().Lookup("strategies")); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorexitverify "github.com/wealdtech/ethdo/cmd/validator/exitverify"
+)
+
+var validatorExitVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify a signed exit without broadcasting it",
+	Long: `Verify a signed exit without broadcasting it.  For example:
+
+    ethdo validator exit verify --signed-operation=exit.json
+
+The signed exit can be supplied as JSON, as the path to a file containing the JSON, or as "-" to read it from standard input.  If not supplied it is read from exit-operation.json.
+
+Checks include that the validator is known and able to exit, that the exit is signed by the validator, that it is signed with the domain required by the chain, and that its epoch is not in the future.  Chain information is obtained from the beacon node, or from offline-preparation.json if --offline is supplied.
+
+The exit is never broadcast, regardless of the outcome of the checks.
+
+In quiet mode this will return 0 if all checks pass, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorexitverify.Run(cmd)
+		if !viper.GetBool("quiet") && res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	validatorExitCmd.AddCommand(validatorExitVerifyCmd)
+	validatorFlags(validatorExitVerifyCmd)
+	validatorExitVerifyCmd.Flags().String("signed-operation", "", "Signed exit to verify, as JSON, a path to a file containing the JSON, or - for standard input (reads from exit-operation.json if not present)")
+	validatorExitVerifyCmd.Flags().Bool("offline", false, "Obtain chain information from offline-preparation.json rather than a beacon node")
+	validatorExitVerifyCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func validatorExitVerifyBindings() {
+	if err := viper.BindPFlag("signed-operation", validatorExitVerifyCmd.Flags().Lookup("signed-operation")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("offline", validatorExitVerifyCmd.Flags().Lookup("offline")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", validatorExitVerifyCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -14,7 +14,6 @@
 package cmd
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -27,7 +26,9 @@ var validatorExpectationCmd = &cobra.Command{
 	Short: "Calculate expectation for individual validators",
 	Long: `Calculate expectation for individual validators.  For example:
 
-    ethdo validator expectation`,
+    ethdo validator expectation --validators=2 --balance="64 Ether"
+
+This uses the current validator set size, churn limit and queue lengths to provide the expected wait for activation and exit, along with the expected time between block proposals and sync committee memberships.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := validatorexpectation.Run(cmd)
 		if err != nil {
@@ -37,7 +38,9 @@ var validatorExpectationCmd = &cobra.Command{
 			return nil
 		}
 		res = strings.TrimRight(res, "\n")
-		fmt.Println(res)
+		if err := outputResult(res); err != nil {
+			return err
+		}
 		return nil
 	},
 }
@@ -46,10 +49,14 @@ func init() {
 	validatorCmd.AddCommand(validatorExpectationCmd)
 	validatorFlags(validatorExpectationCmd)
 	validatorExpectationCmd.Flags().Int64("validators", 1, "Number of validators")
+	validatorExpectationCmd.Flags().String("balance", "32 Ether", "Effective balance of each validator")
 }
 
 func validatorExpectationBindings() {
 	if err := viper.BindPFlag("validators", validatorExpectationCmd.Flags().Lookup("validators")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("balance", validatorExpectationCmd.Flags().Lookup("balance")); err != nil {
+		panic(err)
+	}
 }
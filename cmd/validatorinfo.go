@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 
@@ -30,6 +29,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
 	string2eth "github.com/wealdtech/go-string2eth"
 )
@@ -41,6 +41,14 @@ var validatorInfoCmd = &cobra.Command{
 
     ethdo validator info --validator=primary/validator
 
+Information can be obtained as of a historical state with --state, which can be a slot, a state root, "epoch:<epoch>" or "fork:<name>", for example:
+
+    ethdo validator info --validator=primary/validator --state=fork:capella
+
+When output is JSON or YAML it can be enriched with external data about the validator, for example:
+
+    ethdo validator info --validator=primary/validator --output=json --enrich=beaconchain,rated --api-key=rated=my-rated-key
+
 In quiet mode this will return 0 if the validator information can be obtained, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
@@ -54,16 +62,50 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 
 		if viper.GetString("validator") == "" {
 			fmt.Println("validator is required")
-			os.Exit(_exitFailure)
+			exit(_exitFailure)
 		}
 
-		validator, err := util.ParseValidator(ctx, eth2Client.(eth2client.ValidatorsProvider), viper.GetString("validator"), "head")
+		stateID := "head"
+		if viper.GetString("state") != "" {
+			chainTime, err := standardchaintime.New(ctx,
+				standardchaintime.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+				standardchaintime.WithGenesisTimeProvider(eth2Client.(eth2client.GenesisTimeProvider)),
+				standardchaintime.WithForkScheduleProvider(eth2Client.(eth2client.ForkScheduleProvider)),
+			)
+			errCheck(err, "Failed to set up chaintime service")
+			stateID, err = util.ParseStateID(ctx, chainTime, viper.GetString("state"))
+			errCheck(err, "Failed to parse state")
+			util.Log.Debug().Str("state", stateID).Msg("Obtained state ID")
+		}
+
+		validator, err := util.ParseValidator(ctx, eth2Client.(eth2client.ValidatorsProvider), viper.GetString("validator"), stateID)
+		if err != nil && stateID != "head" {
+			// Historical states are often only available from archive nodes.
+			errCheck(err, fmt.Sprintf("Failed to obtain validator at state %s (historical states may require an archive node)", stateID))
+		}
 		errCheck(err, "Failed to obtain validator")
 
+		if outputFormat := viper.GetString("output"); outputFormat != "" {
+			if !quiet {
+				info := newValidatorInfo(validator)
+				if enrich := viper.GetStringSlice("enrich"); len(enrich) > 0 {
+					network, err := util.Network(ctx, eth2Client)
+					errCheck(err, "Failed to obtain network")
+					enrichers, err := util.NewEnrichers(enrich, viper.GetStringSlice("api-key"), network, viper.GetDuration("timeout"))
+					errCheck(err, "Failed to set up enrichment")
+					info.Enrichment = util.EnrichValidator(ctx, enrichers, validator.Index)
+				}
+				res, err := util.RenderOutput(outputFormat, info)
+				errCheck(err, "Failed to generate output")
+				errCheck(outputResult(res), "Failed to output result")
+			}
+			exit(_exitSuccess)
+		}
+
 		if verbose {
 			network, err := util.Network(ctx, eth2Client)
 			errCheck(err, "Failed to obtain network")
-			outputIf(debug, fmt.Sprintf("Network is %s", network))
+			util.Log.Debug().Str("network", network).Msg("Obtained network")
 			pubKey, err := validator.PubKey(ctx)
 			if err == nil {
 				deposits, totalDeposited, err := graphData(network, pubKey[:])
@@ -75,9 +117,12 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 		}
 
 		if quiet {
-			os.Exit(_exitSuccess)
+			exit(_exitSuccess)
 		}
 
+		if verbose && stateID != "head" {
+			fmt.Printf("State: %s\n", stateID)
+		}
 		if validator.Status.IsPending() || validator.Status.HasActivated() {
 			fmt.Printf("Index: %d\n", validator.Index)
 		}
@@ -105,10 +150,55 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 			fmt.Printf("Withdrawal credentials: %#x\n", validator.Validator.WithdrawalCredentials)
 		}
 
-		os.Exit(_exitSuccess)
+		exit(_exitSuccess)
 	},
 }
 
+type validatorInfo struct {
+	Index                      spec.ValidatorIndex                  `json:"index"`
+	PublicKey                  string                               `json:"public_key"`
+	Status                     string                               `json:"status"`
+	Balance                    spec.Gwei                            `json:"balance"`
+	EffectiveBalance           spec.Gwei                            `json:"effective_balance"`
+	ActivationEligibilityEpoch spec.Epoch                           `json:"activation_eligibility_epoch"`
+	ActivationEpoch            spec.Epoch                           `json:"activation_epoch"`
+	ExitEpoch                  spec.Epoch                           `json:"exit_epoch"`
+	WithdrawableEpoch          spec.Epoch                           `json:"withdrawable_epoch"`
+	WithdrawalCredentials      string                               `json:"withdrawal_credentials"`
+	Enrichment                 map[string]*util.ValidatorEnrichment `json:"enrichment,omitempty"`
+}
+
+func newValidatorInfo(validator *api.Validator) *validatorInfo {
+	return &validatorInfo{
+		Index:                      validator.Index,
+		PublicKey:                  fmt.Sprintf("%#x", validator.Validator.PublicKey),
+		Status:                     validator.Status.String(),
+		Balance:                    validator.Balance,
+		EffectiveBalance:           validator.Validator.EffectiveBalance,
+		ActivationEligibilityEpoch: validator.Validator.ActivationEligibilityEpoch,
+		ActivationEpoch:            validator.Validator.ActivationEpoch,
+		ExitEpoch:                  validator.Validator.ExitEpoch,
+		WithdrawableEpoch:          validator.Validator.WithdrawableEpoch,
+		WithdrawalCredentials:      fmt.Sprintf("%#x", validator.Validator.WithdrawalCredentials),
+	}
+}
+
+// TableHeaders provides the headers for table output.
+func (v *validatorInfo) TableHeaders() []string {
+	return []string{"Index", "Public key", "Status", "Balance", "Effective balance"}
+}
+
+// TableRows provides the rows for table output.
+func (v *validatorInfo) TableRows() [][]string {
+	return [][]string{{
+		fmt.Sprintf("%d", v.Index),
+		v.PublicKey,
+		v.Status,
+		string2eth.GWeiToString(uint64(v.Balance), true),
+		string2eth.GWeiToString(uint64(v.EffectiveBalance), true),
+	}}
+}
+
 // graphData returns data from the graph about number and amount of deposits
 func graphData(network string, validatorPubKey []byte) (uint64, spec.Gwei, error) {
 	subgraph := ""
@@ -164,6 +254,9 @@ func graphData(network string, validatorPubKey []byte) (uint64, spec.Gwei, error
 func init() {
 	validatorCmd.AddCommand(validatorInfoCmd)
 	validatorInfoCmd.Flags().String("validator", "", "Public key for which to obtain status")
+	validatorInfoCmd.Flags().String("state", "", "State at which to obtain information: a slot, a state root, epoch:<epoch> or fork:<name> (default head)")
+	validatorInfoCmd.Flags().StringSlice("enrich", nil, "External providers with which to enrich JSON and YAML output (beaconchain, rated)")
+	validatorInfoCmd.Flags().StringSlice("api-key", nil, "API keys for enrichment providers, as provider=key or a single key for all providers")
 	validatorFlags(validatorInfoCmd)
 }
 
@@ -171,4 +264,13 @@ func validatorInfoBindings() {
 	if err := viper.BindPFlag("validator", validatorInfoCmd.Flags().Lookup("validator")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("state", validatorInfoCmd.Flags().Lookup("state")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("enrich", validatorInfoCmd.Flags().Lookup("enrich")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("api-key", validatorInfoCmd.Flags().Lookup("api-key")); err != nil {
+		panic(err)
+	}
 }
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	validatorkeycheck "github.com/wealdtech/ethdo/cmd/validator/keycheck"
@@ -40,7 +38,9 @@ In quiet mode this will return 0 if the withdrawal credentials match the key, ot
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -0,0 +1,74 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorperformance "github.com/wealdtech/ethdo/cmd/validator/performance"
+)
+
+var validatorPerformanceCmd = &cobra.Command{
+	Use:   "performance",
+	Short: "Score the performance of validators over a number of epochs",
+	Long: `Score the performance of validators over a number of epochs, ranking them by their score.  For example:
+
+    ethdo validator performance --validators=1,2,3 --epochs=225
+
+The score of each validator is a percentage, combining its attestations, block proposals and sync committee participation in proportion to the rewards available for each.  Each attestation is scored on the correctness of its votes and the delay in its inclusion, ignoring delays due to slots without blocks.
+
+By default the window ends with the most recent epoch for which all attestations can have been included; this can be changed with --epoch.
+
+In quiet mode this will return 0 if the validators' performance is obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorperformance.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorPerformanceCmd)
+	validatorFlags(validatorPerformanceCmd)
+	validatorPerformanceCmd.Flags().StringSlice("validators", nil, "the list of validators for which to obtain performance")
+	validatorPerformanceCmd.Flags().String("epoch", "", "the last epoch of the window (defaults to the most recent epoch whose attestations are complete)")
+	validatorPerformanceCmd.Flags().Uint64("epochs", 10, "the number of epochs in the window")
+	validatorPerformanceCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func validatorPerformanceBindings() {
+	validatorBindings()
+	if err := viper.BindPFlag("validators", validatorPerformanceCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epoch", validatorPerformanceCmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epochs", validatorPerformanceCmd.Flags().Lookup("epochs")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", validatorPerformanceCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorproposals "github.com/wealdtech/ethdo/cmd/validator/proposals"
+)
+
+var validatorProposalsCmd = &cobra.Command{
+	Use:   "proposals",
+	Short: "List the block proposals of a validator",
+	Long: `List the block proposals of a validator over a range of slots.  For example:
+
+    ethdo validator proposals --validator=primary/validator --from-slot=6000000 --to-slot=6100000
+
+Each proposal shows if it was missed and, if not, the graffiti of the block and the builder that created it where the block's fee recipient is that of a known builder.
+
+In quiet mode this will return 0 if the validator exists, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorproposals.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorProposalsCmd)
+	validatorFlags(validatorProposalsCmd)
+	validatorProposalsCmd.Flags().String("validator", "", "Validator for which to list proposals")
+	validatorProposalsCmd.Flags().String("from-slot", "", "First slot for which to list proposals (defaults to approximately one day before the to slot)")
+	validatorProposalsCmd.Flags().String("to-slot", "", "Last slot for which to list proposals (defaults to current slot)")
+	validatorProposalsCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func validatorProposalsBindings() {
+	if err := viper.BindPFlag("validator", validatorProposalsCmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-slot", validatorProposalsCmd.Flags().Lookup("from-slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-slot", validatorProposalsCmd.Flags().Lookup("to-slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", validatorProposalsCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorresolve "github.com/wealdtech/ethdo/cmd/validator/resolve"
+)
+
+var validatorResolveCmd = &cobra.Command{
+	Use:   "resolve",
+	Short: "Resolve validators to their indices and public keys",
+	Long: `Resolve a list of validators, supplied as indices, ranges of indices, public keys or accounts, to their indices and public keys.  For example:
+
+    ethdo validator resolve --validators-file=validators.txt --csv
+
+Validators can be read from standard input by supplying --validators-file=-.  All validators are obtained from the beacon node in bulk, rather than one request per validator.
+
+In quiet mode this will return 0 if all validators are resolved, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorresolve.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorResolveCmd)
+	validatorFlags(validatorResolveCmd)
+	validatorResolveCmd.Flags().StringSlice("validators", nil, "Validators to resolve")
+	validatorResolveCmd.Flags().String("validators-file", "", "File containing validators to resolve, one per line, or - for standard input")
+	validatorResolveCmd.Flags().Bool("json", false, "output data in JSON format")
+	validatorResolveCmd.Flags().Bool("csv", false, "output data in CSV format")
+}
+
+func validatorResolveBindings() {
+	if err := viper.BindPFlag("validators", validatorResolveCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators-file", validatorResolveCmd.Flags().Lookup("validators-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", validatorResolveCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", validatorResolveCmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
+}
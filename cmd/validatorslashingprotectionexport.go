@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorslashingprotectionexport "github.com/wealdtech/ethdo/cmd/validator/slashingprotection/export"
+)
+
+var validatorSlashingProtectionExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export minimal slashing protection data for validators",
+	Long: `Export minimal slashing protection data for validators in EIP-3076 interchange format.  For example:
+
+    ethdo validator slashingprotection export --validators=1,2,100-200 --file=slashing-protection.json
+
+The data marks the current slot and epoch of the chain as signed, so a validator client that imports it will refuse to sign any block or attestation at or before the time of export.  This is suitable for validators whose previous client did not provide its own slashing protection data.
+
+In quiet mode this will return 0 if the data is exported, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorslashingprotectionexport.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorSlashingProtectionCmd.AddCommand(validatorSlashingProtectionExportCmd)
+	validatorSlashingProtectionFlags(validatorSlashingProtectionExportCmd)
+	validatorSlashingProtectionExportCmd.Flags().StringSlice("validators", nil, "Validators for which to export slashing protection data")
+	validatorSlashingProtectionExportCmd.Flags().String("file", "", "Name of the file to which to write the slashing protection data (defaults to standard output)")
+}
+
+func validatorSlashingProtectionExportBindings() {
+	if err := viper.BindPFlag("validators", validatorSlashingProtectionExportCmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("file", validatorSlashingProtectionExportCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
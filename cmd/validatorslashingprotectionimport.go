@@ -0,0 +1,71 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorslashingprotectionimport "github.com/wealdtech/ethdo/cmd/validator/slashingprotection/import"
+)
+
+var validatorSlashingProtectionImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import, validate and merge slashing protection data",
+	Long: `Import, validate and merge slashing protection data in EIP-3076 interchange format, as exported by validator clients such as Lighthouse, Prysm and Teku.  For example:
+
+    ethdo validator slashingprotection import --files=lighthouse.json,teku.json --file=merged.json
+
+All files must be for the same chain.  The merged data is written to the file supplied, or to standard output if no file is supplied.
+
+In quiet mode this will return 0 if the data is valid and merged, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorslashingprotectionimport.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorSlashingProtectionCmd.AddCommand(validatorSlashingProtectionImportCmd)
+	validatorSlashingProtectionFlags(validatorSlashingProtectionImportCmd)
+	validatorSlashingProtectionImportCmd.Flags().StringSlice("files", nil, "Files containing slashing protection data to import")
+	validatorSlashingProtectionImportCmd.Flags().String("genesis-validators-root", "", "Genesis validators root that the slashing protection data must match")
+	validatorSlashingProtectionImportCmd.Flags().Bool("minify", false, "Reduce the data for each validator to its highest block and attestation")
+	validatorSlashingProtectionImportCmd.Flags().String("file", "", "Name of the file to which to write the merged slashing protection data (defaults to standard output)")
+}
+
+func validatorSlashingProtectionImportBindings() {
+	if err := viper.BindPFlag("files", validatorSlashingProtectionImportCmd.Flags().Lookup("files")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("genesis-validators-root", validatorSlashingProtectionImportCmd.Flags().Lookup("genesis-validators-root")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("minify", validatorSlashingProtectionImportCmd.Flags().Lookup("minify")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("file", validatorSlashingProtectionImportCmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
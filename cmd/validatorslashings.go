@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorslashings "github.com/wealdtech/ethdo/cmd/validator/slashings"
+)
+
+var validatorSlashingsCmd = &cobra.Command{
+	Use:   "slashings",
+	Short: "List the slashings included in blocks over a range of slots",
+	Long: `List the proposer and attester slashings included in blocks over a range of slots, along with the validators slashed and the reason.  For example:
+
+    ethdo validator slashings --from-slot=6000000 --to-slot=6100000
+
+Each slashing is reported as a double proposal, a double vote or a surround vote.  In verbose mode the conflicting messages are shown, and in JSON mode the full slashing operations are provided as evidence.
+
+In quiet mode this will return 0 if the slots can be scanned, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorslashings.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorSlashingsCmd)
+	validatorFlags(validatorSlashingsCmd)
+	validatorSlashingsCmd.Flags().String("from-slot", "", "First slot to scan for slashings (defaults to approximately one day before the to slot)")
+	validatorSlashingsCmd.Flags().String("to-slot", "", "Last slot to scan for slashings (defaults to current slot)")
+	validatorSlashingsCmd.Flags().Bool("json", false, "output data in JSON format")
+}
+
+func validatorSlashingsBindings() {
+	if err := viper.BindPFlag("from-slot", validatorSlashingsCmd.Flags().Lookup("from-slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-slot", validatorSlashingsCmd.Flags().Lookup("to-slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", validatorSlashingsCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+}
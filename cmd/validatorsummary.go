@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	validatorsummary "github.com/wealdtech/ethdo/cmd/validator/summary"
@@ -28,6 +26,8 @@ var validatorSummaryCmd = &cobra.Command{
 
     ethdo validator summary --validators=1,2,3 --epoch=12345
 
+If validators are supplied with --validators-file then aggregate information about the validators is also provided, including total balances, status and withdrawal credential breakdowns, and expected daily rewards.
+
 In quiet mode this will return 0 if information for the epoch is found, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := validatorsummary.Run(cmd)
@@ -38,7 +38,9 @@ In quiet mode this will return 0 if information for the epoch is found, otherwis
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -49,6 +51,7 @@ func init() {
 	validatorFlags(validatorSummaryCmd)
 	validatorSummaryCmd.Flags().String("epoch", "", "the epoch for which to obtain information ()")
 	validatorSummaryCmd.Flags().StringSlice("validators", nil, "the list of validators for which to obtain information")
+	validatorSummaryCmd.Flags().String("validators-file", "", "file containing validators for which to obtain information, one per line")
 	validatorSummaryCmd.Flags().Bool("json", false, "output data in JSON format")
 }
 
@@ -60,6 +63,9 @@ func validatorSummaryBindings() {
 	if err := viper.BindPFlag("validators", validatorSummaryCmd.Flags().Lookup("validators")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("validators-file", validatorSummaryCmd.Flags().Lookup("validators-file")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("json", validatorSummaryCmd.Flags().Lookup("json")); err != nil {
 		panic(err)
 	}
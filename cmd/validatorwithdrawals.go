@@ -0,0 +1,75 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorwithdrawals "github.com/wealdtech/ethdo/cmd/validator/withdrawals"
+)
+
+var validatorWithdrawalsCmd = &cobra.Command{
+	Use:   "withdrawals",
+	Short: "List the withdrawals of a validator",
+	Long: `List the withdrawals of a validator over a range of slots.  For example:
+
+    ethdo validator withdrawals --validator=primary/validator --from-slot=6300000 --to-slot=6400000 --csv
+
+Each withdrawal shows its slot, epoch, amount and destination address.  Withdrawals are found by examining every block in the range, so large ranges can take some time.
+
+In quiet mode this will return 0 if the validator exists, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorwithdrawals.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorWithdrawalsCmd)
+	validatorFlags(validatorWithdrawalsCmd)
+	validatorWithdrawalsCmd.Flags().String("validator", "", "Validator for which to list withdrawals")
+	validatorWithdrawalsCmd.Flags().String("from-slot", "", "First slot for which to list withdrawals (defaults to approximately one day before the to slot)")
+	validatorWithdrawalsCmd.Flags().String("to-slot", "", "Last slot for which to list withdrawals (defaults to current slot)")
+	validatorWithdrawalsCmd.Flags().Bool("json", false, "output data in JSON format")
+	validatorWithdrawalsCmd.Flags().Bool("csv", false, "output data in CSV format")
+}
+
+func validatorWithdrawalsBindings() {
+	if err := viper.BindPFlag("validator", validatorWithdrawalsCmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-slot", validatorWithdrawalsCmd.Flags().Lookup("from-slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-slot", validatorWithdrawalsCmd.Flags().Lookup("to-slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("json", validatorWithdrawalsCmd.Flags().Lookup("json")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", validatorWithdrawalsCmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
+}
@@ -14,7 +14,6 @@
 package cmd
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -39,7 +38,9 @@ It is important to understand the yield is both probabilistic and dependent on n
 			return nil
 		}
 		res = strings.TrimRight(res, "\n")
-		fmt.Println(res)
+		if err := outputResult(res); err != nil {
+			return err
+		}
 		return nil
 	},
 }
@@ -39,6 +39,9 @@ func walletFlags(cmd *cobra.Command) {
 		if err := viper.BindPFlag("wallet", walletFlag); err != nil {
 			panic(err)
 		}
+		if err := cmd.RegisterFlagCompletionFunc("wallet", completeWallets); err != nil {
+			panic(err)
+		}
 	} else {
 		cmd.Flags().AddFlag(walletFlag)
 	}
@@ -0,0 +1,46 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletcompact
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type dataOut struct {
+	verbose          bool
+	recordsBefore    int
+	recordsAfter     int
+	sizeBefore       int
+	sizeAfter        int
+	tempFilesRemoved int
+}
+
+func output(_ context.Context, data *dataOut) (string, error) {
+	if data == nil {
+		return "", errors.New("no data")
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Compacted archive from %d to %d records", data.recordsBefore, data.recordsAfter))
+	if data.verbose {
+		builder.WriteString(fmt.Sprintf("\nSize reduced from %d to %d bytes", data.sizeBefore, data.sizeAfter))
+		builder.WriteString(fmt.Sprintf("\nRemoved %d stale temporary file(s)", data.tempFilesRemoved))
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,43 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletcompact
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+func process(_ context.Context, data *dataIn) (*dataOut, error) {
+	if data == nil {
+		return nil, errors.New("no data")
+	}
+	if data.store == nil {
+		return nil, errors.New("store is required")
+	}
+
+	res, err := data.store.Compact()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compact archive")
+	}
+
+	return &dataOut{
+		verbose:          data.verbose,
+		recordsBefore:    res.RecordsBefore,
+		recordsAfter:     res.RecordsAfter,
+		sizeBefore:       res.SizeBefore,
+		sizeAfter:        res.SizeAfter,
+		tempFilesRemoved: res.TempFilesRemoved,
+	}, nil
+}
@@ -0,0 +1,74 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletcompact
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/archivestore"
+)
+
+func TestProcess(t *testing.T) {
+	store, err := archivestore.New(archivestore.WithPath(filepath.Join(t.TempDir(), "wallets.archive")),
+		archivestore.WithPassphrase([]byte("secret")),
+	)
+	require.NoError(t, err)
+	walletID := uuid.New()
+	require.NoError(t, store.StoreWallet(walletID, "Test wallet", []byte("wallet")))
+	require.NoError(t, store.StoreWallet(walletID, "Test wallet", []byte("updated wallet")))
+
+	tests := []struct {
+		name   string
+		dataIn *dataIn
+		res    *dataOut
+		err    string
+	}{
+		{
+			name: "Nil",
+			err:  "no data",
+		},
+		{
+			name:   "StoreMissing",
+			dataIn: &dataIn{},
+			err:    "store is required",
+		},
+		{
+			name: "Good",
+			dataIn: &dataIn{
+				store: store,
+			},
+			res: &dataOut{
+				recordsBefore: 2,
+				recordsAfter:  1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(context.Background(), test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res.recordsBefore, res.recordsBefore)
+				require.Equal(t, test.res.recordsAfter, res.recordsAfter)
+			}
+		})
+	}
+}
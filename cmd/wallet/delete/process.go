@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/archivestore"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
@@ -37,6 +38,13 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	}
 	store := storeProvider.Store()
 
+	if archiveStore, isArchive := store.(*archivestore.Service); isArchive {
+		if err := archiveStore.DeleteWallet(data.wallet.ID()); err != nil {
+			return nil, errors.Wrap(err, "failed to delete wallet")
+		}
+		return &dataOut{}, nil
+	}
+
 	if store.Name() != "filesystem" {
 		return nil, fmt.Errorf("cannot delete %s wallet automatically, please remove manually", store.Name())
 	}
@@ -16,10 +16,12 @@ package walletdelete
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/archivestore"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
@@ -74,3 +76,24 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessArchive(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	store, err := archivestore.New(archivestore.WithPath(filepath.Join(t.TempDir(), "wallets.archive")),
+		archivestore.WithPassphrase([]byte("secret")),
+	)
+	require.NoError(t, err)
+	require.NoError(t, e2wallet.UseStore(store))
+	wallet, err := nd.CreateWallet(context.Background(), "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+
+	_, err = process(context.Background(), &dataIn{
+		timeout: 5 * time.Second,
+		wallet:  wallet,
+	})
+	require.NoError(t, err)
+
+	_, err = store.RetrieveWallet("Test wallet")
+	require.EqualError(t, err, "wallet not found")
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/auditlog"
 	"github.com/wealdtech/ethdo/util"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
@@ -41,6 +42,12 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to export wallet")
 	}
+	if err := auditlog.RecordEntry(&auditlog.Entry{
+		Action:  auditlog.ActionExport,
+		Account: data.wallet.Name(),
+	}); err != nil {
+		return nil, err
+	}
 
 	results := &dataOut{
 		export: export,
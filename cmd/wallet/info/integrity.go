@@ -0,0 +1,217 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletinfo
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// validatorPath matches the EIP-2334 path of a validator key.
+var validatorPath = regexp.MustCompile(`^m/12381/3600/([0-9]+)/0/0$`)
+
+// integrity is the result of checking the accounts in a wallet's store.
+type integrity struct {
+	// entries is the number of account entries in the store.
+	entries int
+	// corrupted are the identifiers of entries that cannot be decoded.
+	corrupted []string
+	// duplicates are the names of accounts sharing a public key, by public key.
+	duplicates map[string][]string
+	// hd is true if the wallet is hierarchical deterministic.
+	hd bool
+	// gaps are the validator derivation indices missing below the highest index.
+	gaps []uint64
+	// indexPresent is true if the store holds an accounts index.
+	indexPresent bool
+	// unindexed are the names of accounts that are not in the index.
+	unindexed []string
+	// orphaned are the names of index entries without an account.
+	orphaned []string
+}
+
+// accountEntry is the subset of a stored account checked for integrity.
+type accountEntry struct {
+	UUID   string                 `json:"uuid"`
+	Name   string                 `json:"name"`
+	PubKey string                 `json:"pubkey"`
+	Path   string                 `json:"path"`
+	Crypto map[string]interface{} `json:"crypto"`
+}
+
+// indexEntry is an entry in the accounts index.
+type indexEntry struct {
+	UUID uuid.UUID `json:"uuid"`
+	Name string    `json:"name"`
+}
+
+// problems returns true if the integrity check found problems.
+func (i *integrity) problems() bool {
+	return len(i.corrupted) > 0 ||
+		len(i.duplicates) > 0 ||
+		len(i.unindexed) > 0 ||
+		len(i.orphaned) > 0
+}
+
+// checkIntegrity checks the accounts held in the store for a wallet.
+func checkIntegrity(store e2wtypes.Store, walletID uuid.UUID, hd bool) *integrity {
+	res := &integrity{
+		corrupted:  make([]string, 0),
+		duplicates: make(map[string][]string),
+		hd:         hd,
+	}
+
+	names := make(map[uuid.UUID]string)
+	pubKeys := make(map[string][]string)
+	indices := make(map[uint64]bool)
+	for data := range store.RetrieveAccounts(walletID) {
+		res.entries++
+		entry, id, err := decodeAccountEntry(data)
+		if err != nil {
+			identifier := fmt.Sprintf("entry %d", res.entries)
+			if entry != nil && entry.UUID != "" {
+				identifier = entry.UUID
+			}
+			res.corrupted = append(res.corrupted, fmt.Sprintf("%s (%v)", identifier, err))
+			continue
+		}
+		names[id] = entry.Name
+		pubKey := strings.ToLower(strings.TrimPrefix(entry.PubKey, "0x"))
+		pubKeys[pubKey] = append(pubKeys[pubKey], entry.Name)
+		if match := validatorPath.FindStringSubmatch(entry.Path); match != nil {
+			index, err := strconv.ParseUint(match[1], 10, 64)
+			if err == nil {
+				indices[index] = true
+			}
+		}
+	}
+
+	for pubKey, accountNames := range pubKeys {
+		if len(accountNames) > 1 {
+			sort.Strings(accountNames)
+			res.duplicates[fmt.Sprintf("0x%s", pubKey)] = accountNames
+		}
+	}
+
+	if hd {
+		res.gaps = derivationGaps(indices)
+	}
+
+	res.checkIndex(store, walletID, names)
+
+	return res
+}
+
+// checkIndex compares the accounts index with the accounts in the store.
+func (i *integrity) checkIndex(store e2wtypes.Store, walletID uuid.UUID, names map[uuid.UUID]string) {
+	data, err := store.RetrieveAccountsIndex(walletID)
+	if err != nil || len(data) == 0 {
+		return
+	}
+	entries := make([]*indexEntry, 0)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return
+	}
+	i.indexPresent = true
+
+	i.unindexed = make([]string, 0)
+	i.orphaned = make([]string, 0)
+	indexed := make(map[uuid.UUID]bool, len(entries))
+	for _, entry := range entries {
+		indexed[entry.UUID] = true
+		if _, exists := names[entry.UUID]; !exists {
+			i.orphaned = append(i.orphaned, entry.Name)
+		}
+	}
+	for id, name := range names {
+		if !indexed[id] {
+			i.unindexed = append(i.unindexed, name)
+		}
+	}
+	sort.Strings(i.unindexed)
+	sort.Strings(i.orphaned)
+}
+
+// decodeAccountEntry decodes a stored account, returning an error if it is
+// incomplete or malformed.
+func decodeAccountEntry(data []byte) (*accountEntry, uuid.UUID, error) {
+	entry := &accountEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, uuid.UUID{}, errors.New("invalid JSON")
+	}
+	id, err := uuid.Parse(entry.UUID)
+	if err != nil {
+		return entry, uuid.UUID{}, errors.New("invalid UUID")
+	}
+	if entry.Name == "" {
+		return entry, id, errors.New("name missing")
+	}
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(entry.PubKey, "0x"))
+	if err != nil || len(pubKey) != 48 {
+		return entry, id, errors.New("invalid public key")
+	}
+	if len(entry.Crypto) == 0 {
+		return entry, id, errors.New("key data missing")
+	}
+
+	return entry, id, nil
+}
+
+// derivationGaps provides the indices missing below the highest index.
+func derivationGaps(indices map[uint64]bool) []uint64 {
+	gaps := make([]uint64, 0)
+	if len(indices) == 0 {
+		return gaps
+	}
+	highest := uint64(0)
+	for index := range indices {
+		if index > highest {
+			highest = index
+		}
+	}
+	for index := uint64(0); index < highest; index++ {
+		if !indices[index] {
+			gaps = append(gaps, index)
+		}
+	}
+
+	return gaps
+}
+
+// formatRanges formats indices as a list of ranges, for example "1, 3-5".
+func formatRanges(indices []uint64) string {
+	ranges := make([]string, 0)
+	for i := 0; i < len(indices); i++ {
+		start := indices[i]
+		for i+1 < len(indices) && indices[i+1] == indices[i]+1 {
+			i++
+		}
+		if indices[i] == start {
+			ranges = append(ranges, fmt.Sprintf("%d", start))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", start, indices[i]))
+		}
+	}
+
+	return strings.Join(ranges, ", ")
+}
@@ -0,0 +1,143 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletinfo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+)
+
+func accountJSON(id uuid.UUID, name string, pubKey string, path string) []byte {
+	return []byte(fmt.Sprintf(`{"uuid":"%s","name":"%s","pubkey":"%s","path":"%s","crypto":{"kdf":{}},"version":4}`, id, name, pubKey, path))
+}
+
+func TestCheckIntegrity(t *testing.T) {
+	pubKey1 := strings.Repeat("a1", 48)
+	pubKey2 := strings.Repeat("b2", 48)
+	pubKey3 := strings.Repeat("c3", 48)
+
+	walletID := uuid.New()
+	store := scratch.New()
+	require.NoError(t, store.StoreWallet(walletID, "Test wallet", []byte(fmt.Sprintf(`{"uuid":"%s","name":"Test wallet"}`, walletID))))
+	id1 := uuid.New()
+	require.NoError(t, store.StoreAccount(walletID, id1, accountJSON(id1, "Account 1", pubKey1, "m/12381/3600/0/0/0")))
+	id2 := uuid.New()
+	require.NoError(t, store.StoreAccount(walletID, id2, accountJSON(id2, "Account 2", pubKey2, "m/12381/3600/3/0/0")))
+	id3 := uuid.New()
+	require.NoError(t, store.StoreAccount(walletID, id3, accountJSON(id3, "Account 3", pubKey1, "m/12381/3600/4/0/0")))
+	id4 := uuid.New()
+	require.NoError(t, store.StoreAccount(walletID, id4, []byte(fmt.Sprintf(`{"uuid":"%s","name":"Account 4","pubkey":"%s"}`, id4, pubKey3))))
+	id5 := uuid.New()
+	require.NoError(t, store.StoreAccount(walletID, id5, []byte("bad")))
+	orphanID := uuid.New()
+	require.NoError(t, store.StoreAccountsIndex(walletID, []byte(fmt.Sprintf(`[{"uuid":"%s","name":"Account 1"},{"uuid":"%s","name":"Account 2"},{"uuid":"%s","name":"Orphan"}]`, id1, id2, orphanID))))
+
+	res := checkIntegrity(store, walletID, true)
+	require.Equal(t, 5, res.entries)
+	require.Len(t, res.corrupted, 2)
+	require.Contains(t, res.corrupted, fmt.Sprintf("%s (key data missing)", id4))
+	require.Equal(t, map[string][]string{fmt.Sprintf("0x%s", pubKey1): {"Account 1", "Account 3"}}, res.duplicates)
+	require.Equal(t, []uint64{1, 2}, res.gaps)
+	require.True(t, res.indexPresent)
+	require.Equal(t, []string{"Account 3"}, res.unindexed)
+	require.Equal(t, []string{"Orphan"}, res.orphaned)
+	require.True(t, res.problems())
+
+	// Non-HD wallets do not report gaps.
+	res = checkIntegrity(store, walletID, false)
+	require.Nil(t, res.gaps)
+}
+
+func TestCheckIntegrityClean(t *testing.T) {
+	walletID := uuid.New()
+	store := scratch.New()
+	require.NoError(t, store.StoreWallet(walletID, "Test wallet", []byte(fmt.Sprintf(`{"uuid":"%s","name":"Test wallet"}`, walletID))))
+	id1 := uuid.New()
+	require.NoError(t, store.StoreAccount(walletID, id1, accountJSON(id1, "Account 1", strings.Repeat("a1", 48), "m/12381/3600/0/0/0")))
+	id2 := uuid.New()
+	require.NoError(t, store.StoreAccount(walletID, id2, accountJSON(id2, "Account 2", strings.Repeat("b2", 48), "m/12381/3600/1/0/0")))
+
+	res := checkIntegrity(store, walletID, true)
+	require.Equal(t, 2, res.entries)
+	require.Empty(t, res.corrupted)
+	require.Empty(t, res.duplicates)
+	require.Empty(t, res.gaps)
+	require.False(t, res.indexPresent)
+	require.False(t, res.problems())
+}
+
+func TestDerivationGaps(t *testing.T) {
+	tests := []struct {
+		name    string
+		indices map[uint64]bool
+		res     []uint64
+	}{
+		{
+			name:    "Empty",
+			indices: map[uint64]bool{},
+			res:     []uint64{},
+		},
+		{
+			name:    "Contiguous",
+			indices: map[uint64]bool{0: true, 1: true, 2: true},
+			res:     []uint64{},
+		},
+		{
+			name:    "Gaps",
+			indices: map[uint64]bool{2: true, 3: true, 6: true},
+			res:     []uint64{0, 1, 4, 5},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, derivationGaps(test.indices))
+		})
+	}
+}
+
+func TestFormatRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		indices []uint64
+		res     string
+	}{
+		{
+			name:    "Empty",
+			indices: []uint64{},
+			res:     "",
+		},
+		{
+			name:    "Single",
+			indices: []uint64{5},
+			res:     "5",
+		},
+		{
+			name:    "Mixed",
+			indices: []uint64{1, 3, 4, 5, 9, 10},
+			res:     "1, 3-5, 9-10",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, formatRanges(test.indices))
+		})
+	}
+}
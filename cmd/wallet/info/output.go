@@ -0,0 +1,117 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletinfo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+type dataOut struct {
+	verbose    bool
+	id         uuid.UUID
+	walletType string
+	storeName  string
+	location   string
+	accounts   int
+	integrity  *integrity
+}
+
+func output(_ context.Context, data *dataOut) (string, error) {
+	if data == nil {
+		return "", errors.New("no data")
+	}
+
+	builder := strings.Builder{}
+	if data.verbose {
+		builder.WriteString(fmt.Sprintf("UUID: %v\n", data.id))
+	}
+	builder.WriteString(fmt.Sprintf("Type: %s\n", data.walletType))
+	if data.verbose {
+		if data.storeName != "" {
+			builder.WriteString(fmt.Sprintf("Store: %s\n", data.storeName))
+		}
+		if data.location != "" {
+			builder.WriteString(fmt.Sprintf("Location: %s\n", data.location))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("Accounts: %d", data.accounts))
+
+	if data.verbose && data.integrity != nil {
+		outputIntegrity(&builder, data.integrity)
+	}
+
+	return builder.String(), nil
+}
+
+func outputIntegrity(builder *strings.Builder, integrity *integrity) {
+	builder.WriteString(fmt.Sprintf("\nStored entries: %d", integrity.entries))
+
+	if integrity.hd {
+		if len(integrity.gaps) == 0 {
+			builder.WriteString("\nDerivation gaps: none")
+		} else {
+			builder.WriteString(fmt.Sprintf("\nDerivation gaps: %s", formatRanges(integrity.gaps)))
+		}
+	}
+
+	if len(integrity.duplicates) == 0 {
+		builder.WriteString("\nDuplicate public keys: none")
+	} else {
+		builder.WriteString(fmt.Sprintf("\nDuplicate public keys: %d", len(integrity.duplicates)))
+		pubKeys := make([]string, 0, len(integrity.duplicates))
+		for pubKey := range integrity.duplicates {
+			pubKeys = append(pubKeys, pubKey)
+		}
+		sort.Strings(pubKeys)
+		for _, pubKey := range pubKeys {
+			builder.WriteString(fmt.Sprintf("\n  %s: %s", pubKey, strings.Join(integrity.duplicates[pubKey], ", ")))
+		}
+	}
+
+	if len(integrity.corrupted) == 0 {
+		builder.WriteString("\nCorrupted entries: none")
+	} else {
+		builder.WriteString(fmt.Sprintf("\nCorrupted entries: %d", len(integrity.corrupted)))
+		for _, entry := range integrity.corrupted {
+			builder.WriteString(fmt.Sprintf("\n  %s", entry))
+		}
+	}
+
+	switch {
+	case !integrity.indexPresent:
+		builder.WriteString("\nAccount index: not present")
+	case len(integrity.unindexed) == 0 && len(integrity.orphaned) == 0:
+		builder.WriteString("\nAccount index: consistent")
+	default:
+		builder.WriteString("\nAccount index: inconsistent")
+		if len(integrity.unindexed) > 0 {
+			builder.WriteString(fmt.Sprintf("\n  Accounts missing from index: %s", strings.Join(integrity.unindexed, ", ")))
+		}
+		if len(integrity.orphaned) > 0 {
+			builder.WriteString(fmt.Sprintf("\n  Index entries without account: %s", strings.Join(integrity.orphaned, ", ")))
+		}
+	}
+
+	if integrity.problems() {
+		builder.WriteString("\nIntegrity: problems found")
+	} else {
+		builder.WriteString("\nIntegrity: ok")
+	}
+}
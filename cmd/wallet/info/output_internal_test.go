@@ -0,0 +1,103 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletinfo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	id := uuid.MustParse("7a8b6c1e-2f3d-4e5a-9b0c-1d2e3f4a5b6c")
+
+	tests := []struct {
+		name    string
+		dataOut *dataOut
+		res     string
+		err     string
+	}{
+		{
+			name: "Nil",
+			err:  "no data",
+		},
+		{
+			name: "Good",
+			dataOut: &dataOut{
+				id:         id,
+				walletType: "non-deterministic",
+				accounts:   2,
+			},
+			res: "Type: non-deterministic\nAccounts: 2",
+		},
+		{
+			name: "Verbose",
+			dataOut: &dataOut{
+				verbose:    true,
+				id:         id,
+				walletType: "hierarchical deterministic",
+				storeName:  "filesystem",
+				location:   "/tmp/wallets/7a8b6c1e-2f3d-4e5a-9b0c-1d2e3f4a5b6c",
+				accounts:   2,
+				integrity: &integrity{
+					entries:      2,
+					corrupted:    []string{},
+					duplicates:   map[string][]string{},
+					hd:           true,
+					gaps:         []uint64{},
+					indexPresent: true,
+					unindexed:    []string{},
+					orphaned:     []string{},
+				},
+			},
+			res: "UUID: 7a8b6c1e-2f3d-4e5a-9b0c-1d2e3f4a5b6c\nType: hierarchical deterministic\nStore: filesystem\nLocation: /tmp/wallets/7a8b6c1e-2f3d-4e5a-9b0c-1d2e3f4a5b6c\nAccounts: 2\nStored entries: 2\nDerivation gaps: none\nDuplicate public keys: none\nCorrupted entries: none\nAccount index: consistent\nIntegrity: ok",
+		},
+		{
+			name: "Problems",
+			dataOut: &dataOut{
+				verbose:    true,
+				id:         id,
+				walletType: "hierarchical deterministic",
+				accounts:   3,
+				integrity: &integrity{
+					entries:   4,
+					corrupted: []string{"entry 4 (invalid JSON)"},
+					duplicates: map[string][]string{
+						"0xa1a1": {"Account 1", "Account 3"},
+					},
+					hd:           true,
+					gaps:         []uint64{1, 2, 5},
+					indexPresent: true,
+					unindexed:    []string{"Account 3"},
+					orphaned:     []string{},
+				},
+			},
+			res: "UUID: 7a8b6c1e-2f3d-4e5a-9b0c-1d2e3f4a5b6c\nType: hierarchical deterministic\nAccounts: 3\nStored entries: 4\nDerivation gaps: 1-2, 5\nDuplicate public keys: 1\n  0xa1a1: Account 1, Account 3\nCorrupted entries: 1\n  entry 4 (invalid JSON)\nAccount index: inconsistent\n  Accounts missing from index: Account 3\nIntegrity: problems found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := output(context.Background(), test.dataOut)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletinfo
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func process(ctx context.Context, data *dataIn) (*dataOut, error) {
+	if data == nil {
+		return nil, errors.New("no data")
+	}
+	if data.wallet == nil {
+		return nil, errors.New("wallet is required")
+	}
+
+	results := &dataOut{
+		verbose:    data.verbose,
+		id:         data.wallet.ID(),
+		walletType: data.wallet.Type(),
+	}
+
+	for range data.wallet.Accounts(ctx) {
+		results.accounts++
+	}
+
+	if storeProvider, isProvider := data.wallet.(e2wtypes.StoreProvider); isProvider {
+		store := storeProvider.Store()
+		results.storeName = store.Name()
+		if storeLocationProvider, isProvider := store.(e2wtypes.StoreLocationProvider); isProvider {
+			results.location = filepath.Join(storeLocationProvider.Location(), data.wallet.ID().String())
+		}
+		if data.verbose {
+			results.integrity = checkIntegrity(store, data.wallet.ID(), data.wallet.Type() == "hierarchical deterministic")
+		}
+	}
+
+	return results, nil
+}
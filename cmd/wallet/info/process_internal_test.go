@@ -0,0 +1,98 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	store := filesystem.New(filesystem.WithLocation(t.TempDir()))
+	require.NoError(t, e2wallet.UseStore(store))
+	wallet, err := nd.CreateWallet(context.Background(), "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	_, err = wallet.(e2wtypes.WalletAccountCreator).CreateAccount(context.Background(), "Account 1", []byte("pass"))
+	require.NoError(t, err)
+	_, err = wallet.(e2wtypes.WalletAccountCreator).CreateAccount(context.Background(), "Account 2", []byte("pass"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		dataIn    *dataIn
+		accounts  int
+		integrity bool
+		err       string
+	}{
+		{
+			name: "Nil",
+			err:  "no data",
+		},
+		{
+			name: "WalletMissing",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+			},
+			err: "wallet is required",
+		},
+		{
+			name: "Good",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+				wallet:  wallet,
+			},
+			accounts: 2,
+		},
+		{
+			name: "Verbose",
+			dataIn: &dataIn{
+				timeout: 5 * time.Second,
+				verbose: true,
+				wallet:  wallet,
+			},
+			accounts:  2,
+			integrity: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(context.Background(), test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.accounts, res.accounts)
+				require.Equal(t, "filesystem", res.storeName)
+				require.Equal(t, test.integrity, res.integrity != nil)
+				if test.integrity {
+					require.Equal(t, 2, res.integrity.entries)
+					require.False(t, res.integrity.problems())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletmerge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+)
+
+func TestInput(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	store := scratch.New()
+	require.NoError(t, e2wallet.UseStore(store))
+	_, err := nd.CreateWallet(context.Background(), "Source", store, keystorev4.New())
+	require.NoError(t, err)
+	_, err = nd.CreateWallet(context.Background(), "Destination", store, keystorev4.New())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "Remote",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"remote":             "remoteaddress",
+				"wallet":             "Source",
+				"destination-wallet": "Destination",
+				"passphrase":         []string{"pass"},
+			},
+			err: "wallet merge not available for remote wallets",
+		},
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"wallet":             "Source",
+				"destination-wallet": "Destination",
+				"passphrase":         []string{"pass"},
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "PassphraseMissing",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"wallet":             "Source",
+				"destination-wallet": "Destination",
+			},
+			err: "passphrase is required",
+		},
+		{
+			name: "NewPassphraseWeak",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"wallet":             "Source",
+				"destination-wallet": "Destination",
+				"passphrase":         []string{"pass"},
+				"new-passphrase":     "weak",
+			},
+			err: "supplied new passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag",
+		},
+		{
+			name: "WalletMissing",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"destination-wallet": "Destination",
+				"passphrase":         []string{"pass"},
+			},
+			err: "failed to access wallet: cannot determine wallet",
+		},
+		{
+			name: "DestinationWalletMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"wallet":     "Source",
+				"passphrase": []string{"pass"},
+			},
+			err: "destination-wallet is required",
+		},
+		{
+			name: "DestinationWalletUnknown",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"wallet":             "Source",
+				"destination-wallet": "Unknown",
+				"passphrase":         []string{"pass"},
+			},
+			err: "failed to access destination wallet: wallet not found",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"wallet":             "Source",
+				"destination-wallet": "Destination",
+				"passphrase":         []string{"pass"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := input(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "Source", res.wallet.Name())
+				require.Equal(t, "Destination", res.destination.Name())
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletmerge
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func process(ctx context.Context, data *dataIn) (*dataOut, error) {
+	if data == nil {
+		return nil, errors.New("no data")
+	}
+	if data.wallet == nil {
+		return nil, errors.New("wallet is required")
+	}
+	if data.destination == nil {
+		return nil, errors.New("destination wallet is required")
+	}
+	if data.destination.ID() == data.wallet.ID() {
+		return nil, errors.New("destination wallet is the same as the source wallet")
+	}
+
+	store, err := util.WalletStore(data.wallet)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckAccountRemovable(store); err != nil {
+		return nil, err
+	}
+
+	accounts := make(map[string]e2wtypes.Account)
+	for account := range data.wallet.Accounts(ctx) {
+		accounts[account.Name()] = account
+	}
+	if len(accounts) == 0 {
+		return nil, errors.New("wallet has no accounts to merge")
+	}
+	names := make([]string, 0, len(accounts))
+	for name := range accounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Check all accounts before changing anything, so that a problem with
+	// any account leaves both wallets untouched.
+	collisions, err := util.AccountCollisions(ctx, data.destination, accounts)
+	if err != nil {
+		return nil, err
+	}
+	if len(collisions) > 0 {
+		return nil, fmt.Errorf("accounts collide with existing accounts in %s: %s", data.destination.Name(), strings.Join(collisions, ", "))
+	}
+
+	passphrases := make(map[string]string, len(accounts))
+	defer func() {
+		for _, account := range accounts {
+			if err := util.LockAccount(ctx, account); err != nil {
+				util.Log.Trace().Err(err).Msg("Failed to lock account")
+			}
+		}
+	}()
+	for _, name := range names {
+		passphrase, err := util.UnlockAccountWithPassphrase(ctx, accounts[name], data.passphrases)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to unlock account %s", name))
+		}
+		passphrases[name] = passphrase
+		if data.newPassphrase != "" {
+			passphrases[name] = data.newPassphrase
+		}
+	}
+
+	if locker, isLocker := data.destination.(e2wtypes.WalletLocker); isLocker {
+		if err := locker.Unlock(ctx, []byte(data.walletPassphrase)); err != nil {
+			return nil, errors.Wrap(err, "failed to unlock destination wallet")
+		}
+		defer func() {
+			if err := locker.Lock(ctx); err != nil {
+				util.Log.Trace().Err(err).Msg("Failed to lock wallet")
+			}
+		}()
+	}
+
+	results := &dataOut{
+		source:      data.wallet.Name(),
+		destination: data.destination.Name(),
+		moved:       make([]string, 0, len(names)),
+	}
+	for _, name := range names {
+		if _, err := util.MoveAccount(ctx, data.wallet, accounts[name], data.destination, name, []byte(passphrases[name])); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to move account %s after moving %d accounts", name, len(results.moved)))
+		}
+		results.moved = append(results.moved, name)
+	}
+
+	return results, nil
+}
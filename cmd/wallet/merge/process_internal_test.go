@@ -0,0 +1,145 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletmerge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	store := filesystem.New(filesystem.WithLocation(t.TempDir()))
+	require.NoError(t, e2wallet.UseStore(store))
+	source, err := nd.CreateWallet(ctx, "Source", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, source.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	_, err = source.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 1", []byte("pass1"))
+	require.NoError(t, err)
+	_, err = source.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 2", []byte("pass2"))
+	require.NoError(t, err)
+
+	destination, err := nd.CreateWallet(ctx, "Destination", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, destination.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	_, err = destination.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 3", []byte("pass3"))
+	require.NoError(t, err)
+
+	colliding, err := nd.CreateWallet(ctx, "Colliding", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, colliding.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	_, err = colliding.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 2", []byte("pass"))
+	require.NoError(t, err)
+
+	empty, err := nd.CreateWallet(ctx, "Empty", store, keystorev4.New())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		dataIn *dataIn
+		moved  []string
+		err    string
+	}{
+		{
+			name: "Nil",
+			err:  "no data",
+		},
+		{
+			name: "SameWallet",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				wallet:      source,
+				destination: source,
+				passphrases: []string{"pass1", "pass2"},
+			},
+			err: "destination wallet is the same as the source wallet",
+		},
+		{
+			name: "NoAccounts",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				wallet:      empty,
+				destination: destination,
+				passphrases: []string{"pass1", "pass2"},
+			},
+			err: "wallet has no accounts to merge",
+		},
+		{
+			name: "Collision",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				wallet:      source,
+				destination: colliding,
+				passphrases: []string{"pass1", "pass2"},
+			},
+			err: "accounts collide with existing accounts in Colliding: Account 2 (name already in use)",
+		},
+		{
+			name: "PassphraseMissing",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				wallet:      source,
+				destination: destination,
+				passphrases: []string{"pass1"},
+			},
+			err: "failed to unlock account Account 2: failed to unlock account",
+		},
+		{
+			name: "Good",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				wallet:      source,
+				destination: destination,
+				passphrases: []string{"pass1", "pass2"},
+			},
+			moved: []string{"Account 1", "Account 2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(ctx, test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.moved, res.moved)
+			}
+		})
+	}
+
+	// Reopen the wallets to confirm the merge.
+	source, err = e2wallet.OpenWallet("Source")
+	require.NoError(t, err)
+	for range source.Accounts(ctx) {
+		require.Fail(t, "source wallet still has accounts")
+	}
+	destination, err = e2wallet.OpenWallet("Destination")
+	require.NoError(t, err)
+	account, err := destination.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, "Account 2")
+	require.NoError(t, err)
+	// The account keeps its passphrase.
+	require.NoError(t, account.(e2wtypes.AccountLocker).Unlock(ctx, []byte("pass2")))
+}
@@ -21,6 +21,7 @@ import (
 	"os"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/auditlog"
 	"github.com/wealdtech/ethdo/shamir"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
@@ -57,6 +58,12 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to export wallet")
 	}
+	if err := auditlog.RecordEntry(&auditlog.Entry{
+		Action:  auditlog.ActionExport,
+		Account: data.wallet.Name(),
+	}); err != nil {
+		return nil, err
+	}
 
 	shares, err := shamir.Split(passphrase, int(data.participants), int(data.threshold))
 	if err != nil {
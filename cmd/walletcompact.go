@@ -0,0 +1,45 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	walletcompact "github.com/wealdtech/ethdo/cmd/wallet/compact"
+)
+
+var walletCompactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Compact an archive wallet store",
+	Long: `Compact an archive wallet store, discarding superseded and deleted data.  For example:
+
+    ethdo wallet compact --store=archive
+
+In quiet mode this will return 0 if the archive has been compacted, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := walletcompact.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	walletCmd.AddCommand(walletCompactCmd)
+}
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	walletcreate "github.com/wealdtech/ethdo/cmd/wallet/create"
@@ -35,7 +33,9 @@ In quiet mode this will return 0 if the wallet is created successfully, otherwis
 			return err
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	walletdelete "github.com/wealdtech/ethdo/cmd/wallet/delete"
 )
@@ -34,7 +32,9 @@ In quiet mode this will return 0 if the wallet has been deleted, otherwise 1.`,
 			return err
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
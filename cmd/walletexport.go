@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	walletexport "github.com/wealdtech/ethdo/cmd/wallet/export"
 )
@@ -34,7 +32,9 @@ In quiet mode this will return 0 if the wallet is able to be exported, otherwise
 			return err
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -14,14 +14,8 @@
 package cmd
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"path/filepath"
-
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	walletinfo "github.com/wealdtech/ethdo/cmd/wallet/info"
 )
 
 var walletInfoCmd = &cobra.Command{
@@ -31,39 +25,20 @@ var walletInfoCmd = &cobra.Command{
 
     ethdo wallet info --wallet=primary
 
-In quiet mode this will return 0 if the wallet exists, otherwise 1.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
-		defer cancel()
-
-		assert(viper.GetString("remote") == "", "wallet info not available with remote wallets")
-		assert(viper.GetString("wallet") != "", "--wallet is required")
-
-		wallet, err := walletFromPath(ctx, viper.GetString("wallet"))
-		errCheck(err, "unknown wallet")
-
-		if quiet {
-			os.Exit(0)
-		}
-
-		outputIf(verbose, fmt.Sprintf("UUID: %v", wallet.ID()))
-		fmt.Printf("Type: %s\n", wallet.Type())
-		if verbose {
-			if storeProvider, ok := wallet.(wtypes.StoreProvider); ok {
-				store := storeProvider.Store()
-				fmt.Printf("Store: %s\n", store.Name())
-				if storeLocationProvider, ok := store.(wtypes.StoreLocationProvider); ok {
-					fmt.Printf("Location: %s\n", filepath.Join(storeLocationProvider.Location(), wallet.ID().String()))
-				}
+With --verbose the wallet's store is also checked for integrity, reporting derivation gaps for
+hierarchical deterministic wallets, duplicate public keys, corrupted account entries and the
+consistency of the account index.
+
+In quiet mode this will return 0 if the wallet exists and, if --verbose is supplied, passes its
+integrity check, otherwise 1.  Derivation gaps are reported but are not considered a failure.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := walletinfo.Run(cmd)
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
 			}
 		}
-
-		// Count the accounts.
-		accounts := 0
-		for range wallet.Accounts(ctx) {
-			accounts++
-		}
-		fmt.Printf("Accounts: %d\n", accounts)
+		return err
 	},
 }
 
@@ -0,0 +1,60 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	walletmerge "github.com/wealdtech/ethdo/cmd/wallet/merge"
+)
+
+var walletMergeCmd = &cobra.Command{
+	Use:   "merge",
+	Short: "Merge a wallet's accounts in to another wallet",
+	Long: `Move all of the accounts in a wallet to another wallet.  For example:
+
+    ethdo wallet merge --wallet="Old validators" --destination-wallet="Validators" --passphrase=secret
+
+Each account is encrypted with the new passphrase if supplied, or the passphrase that unlocked it if not.  All accounts are checked before any are moved; if any account has a name or public key already present in the destination wallet, or cannot be unlocked, no accounts are moved.  The source wallet is left in place, empty, and can be removed with "wallet delete".
+
+In quiet mode this will return 0 if the accounts have been moved, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := walletmerge.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	walletCmd.AddCommand(walletMergeCmd)
+	walletFlags(walletMergeCmd)
+	walletMergeCmd.Flags().String("destination-wallet", "", "Wallet in to which to move the accounts")
+	walletMergeCmd.Flags().String("new-passphrase", "", "Passphrase with which to encrypt the accounts in the destination wallet (defaults to their existing passphrases)")
+}
+
+func walletMergeBindings() {
+	if err := viper.BindPFlag("destination-wallet", walletMergeCmd.Flags().Lookup("destination-wallet")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("new-passphrase", walletMergeCmd.Flags().Lookup("new-passphrase")); err != nil {
+		panic(err)
+	}
+}
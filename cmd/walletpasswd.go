@@ -0,0 +1,64 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountpasswd "github.com/wealdtech/ethdo/cmd/account/passwd"
+)
+
+var walletPasswdCmd = &cobra.Command{
+	Use:   "passwd",
+	Short: "Change the passphrase of all accounts in a wallet",
+	Long: `Change the passphrase of all accounts in a wallet.  For example:
+
+    ethdo wallet passwd --wallet="Validators" --passphrase=secret --new-passphrase=newsecret
+
+This is equivalent to "account passwd --all".  The wallet's own passphrase, as used by hierarchical deterministic wallets, is not changed.
+
+In quiet mode this will return 0 if the passphrases have been changed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		viper.Set("all", true)
+		res, err := accountpasswd.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	walletCmd.AddCommand(walletPasswdCmd)
+	walletFlags(walletPasswdCmd)
+	walletPasswdCmd.Flags().String("new-passphrase", "", "Passphrase with which to encrypt the accounts")
+	walletPasswdCmd.Flags().Int("workers", 0, "Number of accounts to re-encrypt in parallel (defaults to the number of CPUs)")
+}
+
+func walletPasswdBindings() {
+	if err := viper.BindPFlag("new-passphrase", walletPasswdCmd.Flags().Lookup("new-passphrase")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("workers", walletPasswdCmd.Flags().Lookup("workers")); err != nil {
+		panic(err)
+	}
+}
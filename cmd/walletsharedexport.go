@@ -14,8 +14,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	walletsharedexport "github.com/wealdtech/ethdo/cmd/wallet/sharedexport"
@@ -33,7 +31,9 @@ var walletSharedExportCmd = &cobra.Command{
 			return err
 		}
 		if res != "" {
-			fmt.Println(res)
+			if err := outputResult(res); err != nil {
+				return err
+			}
 		}
 		return nil
 	},
@@ -0,0 +1,256 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wizardcredentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// executionAddress is the regular expression that matches an execution address.
+var executionAddress = regexp.MustCompile("^0x[0-9a-fA-F]{40}$")
+
+var offlinePreparationFilename = "offline-preparation.json"
+
+func (c *command) process(ctx context.Context) error {
+	fmt.Fprintf(c.out, "This wizard will guide you through changing validator withdrawal credentials to an execution address.\n\n")
+
+	if err := c.askConnectivity(ctx); err != nil {
+		return err
+	}
+
+	if err := c.askWithdrawalAddress(ctx); err != nil {
+		return err
+	}
+
+	if err := c.askKeySource(ctx); err != nil {
+		return err
+	}
+
+	if err := c.askOutput(ctx); err != nil {
+		return err
+	}
+
+	c.summarise(ctx)
+
+	var err error
+	c.confirmed, err = c.prompter.Confirm("Generate the credentials change operations?", false)
+	if err != nil {
+		return err
+	}
+	if !c.confirmed {
+		return nil
+	}
+
+	c.apply(ctx)
+
+	return nil
+}
+
+// askConnectivity finds out if we are online or air-gapped, and checks the
+// requirements of each.
+func (c *command) askConnectivity(ctx context.Context) error {
+	mode, err := c.prompter.Choose("Is this machine connected to a beacon node, or air-gapped?", []string{"online", "air-gapped"}, "online")
+	if err != nil {
+		return err
+	}
+
+	if mode == "air-gapped" {
+		c.offline = true
+		if _, err := os.Stat(offlinePreparationFilename); err != nil {
+			return fmt.Errorf("%s not found in the current directory; generate it on an online machine with \"ethdo validator credentials set --prepare-offline\" and copy it here", offlinePreparationFilename)
+		}
+		fmt.Fprintf(c.out, "Found %s\n\n", offlinePreparationFilename)
+		return nil
+	}
+
+	c.connection, err = c.prompter.Ask("Beacon node address (leave empty to try the defaults)", c.connection)
+	if err != nil {
+		return err
+	}
+	if _, err := util.ConnectToBeaconNode(ctx, c.connection, c.timeout, c.allowInsecureConnections); err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+	fmt.Fprintf(c.out, "Connected to beacon node\n\n")
+
+	return nil
+}
+
+// askWithdrawalAddress finds out the address to which withdrawals will go.
+func (c *command) askWithdrawalAddress(_ context.Context) error {
+	fmt.Fprintf(c.out, "The withdrawal address receives all funds from the validator and cannot be changed once set.\n")
+
+	var err error
+	c.withdrawalAddress, err = c.prompter.Ask("Withdrawal address", "")
+	if err != nil {
+		return err
+	}
+	if !executionAddress.MatchString(c.withdrawalAddress) {
+		return errors.New("withdrawal address must be 20 bytes in hexadecimal format, starting with 0x")
+	}
+	confirmation, err := c.prompter.Ask("Please re-enter the withdrawal address to confirm", "")
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(confirmation, c.withdrawalAddress) {
+		return errors.New("withdrawal addresses do not match")
+	}
+	fmt.Fprintf(c.out, "\n")
+
+	return nil
+}
+
+// askKeySource finds out how the keys are provided.
+func (c *command) askKeySource(_ context.Context) error {
+	var err error
+	c.keySource, err = c.prompter.Choose("How will you provide the keys?", []string{"mnemonic", "private-key", "keystore"}, "")
+	if err != nil {
+		return err
+	}
+
+	switch c.keySource {
+	case "mnemonic":
+		c.mnemonic, err = c.prompter.AskSecret("Mnemonic")
+		if err != nil {
+			return err
+		}
+		if _, err := util.SeedFromMnemonic(c.mnemonic); err != nil {
+			return err
+		}
+		c.validator, err = c.prompter.Ask("Validator index or public key (leave empty to change all validators for the mnemonic)", "")
+		if err != nil {
+			return err
+		}
+	case "private-key":
+		c.validator, err = c.prompter.Ask("Validator index or public key", "")
+		if err != nil {
+			return err
+		}
+		if c.validator == "" {
+			return errors.New("validator is required")
+		}
+		c.privateKey, err = c.prompter.AskSecret("Withdrawal private key")
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(c.privateKey, "0x") || len(c.privateKey) != 66 {
+			return errors.New("private key must be 32 bytes in hexadecimal format, starting with 0x")
+		}
+	case "keystore":
+		c.account, err = c.prompter.Ask("Validator account (in format \"<wallet>/<account>\")", "")
+		if err != nil {
+			return err
+		}
+		c.withdrawalAccount, err = c.prompter.Ask("Withdrawal account (in format \"<wallet>/<account>\")", "")
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(c.account, "/") || !strings.Contains(c.withdrawalAccount, "/") {
+			return errors.New("accounts must be in format \"<wallet>/<account>\"")
+		}
+		c.passphrase, err = c.prompter.AskSecret("Withdrawal account passphrase")
+		if err != nil {
+			return err
+		}
+		if c.passphrase == "" {
+			return errors.New("passphrase required with withdrawal account")
+		}
+	}
+	fmt.Fprintf(c.out, "\n")
+
+	return nil
+}
+
+// askOutput finds out what to do with the operations.
+func (c *command) askOutput(_ context.Context) error {
+	if c.offline {
+		// Offline operations are always written to a file.
+		return nil
+	}
+
+	action, err := c.prompter.Choose("Broadcast the operations to the network, or output them as JSON?", []string{"broadcast", "json"}, "broadcast")
+	if err != nil {
+		return err
+	}
+	c.json = action == "json"
+	fmt.Fprintf(c.out, "\n")
+
+	return nil
+}
+
+// summarise outputs a summary of the operation to be carried out.
+func (c *command) summarise(_ context.Context) {
+	fmt.Fprintf(c.out, "Summary:\n")
+	if c.offline {
+		fmt.Fprintf(c.out, "  Mode: air-gapped, using %s\n", offlinePreparationFilename)
+	} else {
+		connection := c.connection
+		if connection == "" {
+			connection = "default"
+		}
+		fmt.Fprintf(c.out, "  Mode: online, using %s beacon node\n", connection)
+	}
+	fmt.Fprintf(c.out, "  Withdrawal address: %s\n", c.withdrawalAddress)
+	switch c.keySource {
+	case "mnemonic":
+		fmt.Fprintf(c.out, "  Keys: from mnemonic\n")
+		if c.validator == "" {
+			fmt.Fprintf(c.out, "  Validators: all validators with withdrawal credentials from the mnemonic\n")
+		} else {
+			fmt.Fprintf(c.out, "  Validator: %s\n", c.validator)
+		}
+	case "private-key":
+		fmt.Fprintf(c.out, "  Keys: withdrawal private key\n")
+		fmt.Fprintf(c.out, "  Validator: %s\n", c.validator)
+	case "keystore":
+		fmt.Fprintf(c.out, "  Keys: from accounts\n")
+		fmt.Fprintf(c.out, "  Validator: %s\n", c.account)
+		fmt.Fprintf(c.out, "  Withdrawal account: %s\n", c.withdrawalAccount)
+	}
+	switch {
+	case c.offline:
+		fmt.Fprintf(c.out, "  Output: written to change-operations.json for later broadcast\n")
+	case c.json:
+		fmt.Fprintf(c.out, "  Output: JSON, not broadcast\n")
+	default:
+		fmt.Fprintf(c.out, "  Output: broadcast to the network; this cannot be undone\n")
+	}
+	fmt.Fprintf(c.out, "\n")
+}
+
+// apply sets the configuration for the credentials set command from the answers.
+func (c *command) apply(_ context.Context) {
+	viper.Set("offline", c.offline)
+	viper.Set("json", c.json)
+	viper.Set("prepare-offline", false)
+	// Already confirmed by the wizard.
+	viper.Set("yes", true)
+	viper.Set("connection", c.connection)
+	viper.Set("withdrawal-address", c.withdrawalAddress)
+	viper.Set("mnemonic", c.mnemonic)
+	viper.Set("validator", c.validator)
+	viper.Set("private-key", c.privateKey)
+	viper.Set("account", c.account)
+	viper.Set("withdrawal-account", c.withdrawalAccount)
+	if c.passphrase != "" {
+		viper.Set("passphrase", []string{c.passphrase})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wizardcredentials
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestAskWithdrawalAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		address string
+		err     string
+	}{
+		{
+			name:  "Invalid",
+			input: "0x1234\n",
+			err:   "withdrawal address must be 20 bytes in hexadecimal format, starting with 0x",
+		},
+		{
+			name:  "Mismatch",
+			input: "0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F\n0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9E\n",
+			err:   "withdrawal addresses do not match",
+		},
+		{
+			name:    "Good",
+			input:   "0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F\n0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f\n",
+			address: "0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			c := &command{
+				prompter: util.NewPrompter(strings.NewReader(test.input), out),
+				out:      out,
+			}
+			err := c.askWithdrawalAddress(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.address, c.withdrawalAddress)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wizardexit
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestAskKeySource(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		validator  string
+		path       string
+		privateKey string
+		err        string
+	}{
+		{
+			name:  "MnemonicInvalid",
+			input: "mnemonic\nbad mnemonic\n",
+			err:   "mnemonic is invalid",
+		},
+		{
+			name:      "MnemonicValidator",
+			input:     "mnemonic\nabandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art\n12345\n",
+			validator: "12345",
+		},
+		{
+			name:  "MnemonicPath",
+			input: "mnemonic\nabandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art\nm/12381/3600/1/0/0\n",
+			path:  "m/12381/3600/1/0/0",
+		},
+		{
+			name:  "MnemonicPathInvalid",
+			input: "mnemonic\nabandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art\nm/12381/3600/1/0\n",
+			err:   "path m/12381/3600/1/0 does not match EIP-2334 format for a validator",
+		},
+		{
+			name:  "KeystoreInvalid",
+			input: "keystore\nvalidator\n",
+			err:   "validator account must be in format \"<wallet>/<account>\"",
+		},
+		{
+			name:      "Keystore",
+			input:     "keystore\nwallet/account\nsecret\n",
+			validator: "wallet/account",
+		},
+		{
+			name:  "PrivateKeyInvalid",
+			input: "private-key\n0x1234\n",
+			err:   "private key must be 32 bytes in hexadecimal format, starting with 0x",
+		},
+		{
+			name:       "PrivateKey",
+			input:      "private-key\n0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866\n",
+			privateKey: "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			c := &command{
+				prompter: util.NewPrompter(strings.NewReader(test.input), out),
+				out:      out,
+			}
+			err := c.askKeySource(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.validator, c.validator)
+				require.Equal(t, test.path, c.path)
+				require.Equal(t, test.privateKey, c.privateKey)
+			}
+		})
+	}
+}
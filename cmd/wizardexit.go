@@ -0,0 +1,52 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	wizardexit "github.com/wealdtech/ethdo/cmd/wizard/exit"
+)
+
+var wizardExitCmd = &cobra.Command{
+	Use:   "exit",
+	Short: "Guided walkthrough to exit a validator",
+	Long: `Guided walkthrough to exit a validator.  For example:
+
+    ethdo wizard exit
+
+The wizard asks a series of questions to build up the exit operation, shows a summary of the operation, and only generates the operation after confirmation.  Note that answers, including mnemonics and private keys, are echoed to the terminal.
+
+In quiet mode this will return 0 if the exit operation has been generated (and successfully broadcast if online), otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := wizardexit.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			if err := outputResult(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	wizardCmd.AddCommand(wizardExitCmd)
+	wizardFlags(wizardExitCmd)
+}
@@ -0,0 +1,71 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ops
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// BuildBLSToExecutionChange builds a change of withdrawal credentials for the
+// validator with the given index to the given execution address, and signs it
+// with the validator's withdrawal account.
+// Passphrases are used to unlock the account if it is not already unlocked.
+func BuildBLSToExecutionChange(ctx context.Context,
+	withdrawalAccount e2wtypes.Account,
+	passphrases []string,
+	validatorIndex phase0.ValidatorIndex,
+	withdrawalAddress bellatrix.ExecutionAddress,
+	domain phase0.Domain,
+) (
+	*capella.SignedBLSToExecutionChange,
+	error,
+) {
+	if withdrawalAccount == nil {
+		return nil, errors.New("no withdrawal account specified")
+	}
+	pubkey, err := util.BestPublicKey(withdrawalAccount)
+	if err != nil {
+		return nil, err
+	}
+	blsPubkey := phase0.BLSPubKey{}
+	copy(blsPubkey[:], pubkey.Marshal())
+
+	operation := &capella.BLSToExecutionChange{
+		ValidatorIndex:     validatorIndex,
+		FromBLSPubkey:      blsPubkey,
+		ToExecutionAddress: withdrawalAddress,
+	}
+	root, err := operation.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate root for credentials change operation")
+	}
+
+	signature, err := signing.SignRoot(ctx, withdrawalAccount, passphrases, root, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign credentials change operation")
+	}
+
+	return &capella.SignedBLSToExecutionChange{
+		Message:   operation,
+		Signature: signature,
+	}, nil
+}
@@ -0,0 +1,97 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ops
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/util"
+	ethutil "github.com/wealdtech/go-eth2-util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// BLSWithdrawalCredentials returns withdrawal credentials for the given BLS
+// withdrawal public key.
+func BLSWithdrawalCredentials(pubKey phase0.BLSPubKey) []byte {
+	withdrawalCredentials := ethutil.SHA256(pubKey[:])
+	// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
+	withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
+
+	return withdrawalCredentials
+}
+
+// ExecutionWithdrawalCredentials returns withdrawal credentials for the given
+// execution address.
+func ExecutionWithdrawalCredentials(address bellatrix.ExecutionAddress) []byte {
+	withdrawalCredentials := make([]byte, 32)
+	copy(withdrawalCredentials[12:32], address[:])
+	// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
+	withdrawalCredentials[0] = byte(1) // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+
+	return withdrawalCredentials
+}
+
+// BuildDepositData builds deposit data for the validator account with the given
+// withdrawal credentials and amount, and signs it with the validator's account.
+// Passphrases are used to unlock the account if it is not already unlocked.
+func BuildDepositData(ctx context.Context,
+	account e2wtypes.Account,
+	passphrases []string,
+	withdrawalCredentials []byte,
+	amount phase0.Gwei,
+	domain phase0.Domain,
+) (
+	*phase0.DepositData,
+	error,
+) {
+	if account == nil {
+		return nil, errors.New("no validator account specified")
+	}
+	if len(withdrawalCredentials) != 32 {
+		return nil, errors.New("withdrawal credentials must be 32 bytes")
+	}
+
+	validatorPubKey, err := util.BestPublicKey(account)
+	if err != nil {
+		return nil, errors.Wrap(err, "validator account does not provide a public key")
+	}
+	var pubKey phase0.BLSPubKey
+	copy(pubKey[:], validatorPubKey.Marshal())
+
+	depositMessage := &phase0.DepositMessage{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                amount,
+	}
+	root, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate deposit message root")
+	}
+
+	signature, err := signing.SignRoot(ctx, account, passphrases, root, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign deposit message")
+	}
+
+	return &phase0.DepositData{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                amount,
+		Signature:             signature,
+	}, nil
+}
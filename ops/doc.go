@@ -0,0 +1,22 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ops provides functions to build signed Ethereum consensus
+// operations, allowing other Go programs to use the same logic as ethdo
+// without calling the command-line tool.
+//
+// Functions in this package take explicit inputs and carry out no network
+// access; the caller is responsible for obtaining items such as validator
+// indices and signature domains.  Accounts can be any go-eth2-wallet
+// account, including those held remotely by Dirk.
+package ops
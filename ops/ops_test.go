@@ -0,0 +1,143 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ops_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/ops"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// verifySignature confirms that the signature is valid for the given root,
+// domain and account.
+func verifySignature(t *testing.T,
+	account e2wtypes.AccountPublicKeyProvider,
+	root phase0.Root,
+	domain phase0.Domain,
+	signature phase0.BLSSignature,
+) {
+	t.Helper()
+
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	}).HashTreeRoot()
+	require.NoError(t, err)
+	sig, err := e2types.BLSSignatureFromBytes(signature[:])
+	require.NoError(t, err)
+	require.True(t, sig.Verify(signingRoot[:], account.PublicKey()))
+}
+
+func testAccount(t *testing.T) *util.ScratchAccount {
+	t.Helper()
+
+	account, err := util.NewScratchAccount(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), nil)
+	require.NoError(t, err)
+	require.NoError(t, account.Unlock(context.Background(), nil))
+
+	return account
+}
+
+func TestComputeDomain(t *testing.T) {
+	domain, err := ops.ComputeDomain(phase0.DomainType{0x04, 0x00, 0x00, 0x00},
+		phase0.Version{0x00, 0x00, 0x00, 0x00},
+		phase0.Root{},
+	)
+	require.NoError(t, err)
+	require.Equal(t, testutil.HexToDomain("0x04000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9"), domain)
+}
+
+func TestBuildSignedVoluntaryExit(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+	account := testAccount(t)
+	domain := testutil.HexToDomain("0x04000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9")
+
+	_, err := ops.BuildSignedVoluntaryExit(ctx, nil, nil, 1, 2, domain)
+	require.EqualError(t, err, "failed to sign exit operation: account not specified")
+
+	op, err := ops.BuildSignedVoluntaryExit(ctx, account, nil, 1, 2, domain)
+	require.NoError(t, err)
+	require.Equal(t, phase0.ValidatorIndex(1), op.Message.ValidatorIndex)
+	require.Equal(t, phase0.Epoch(2), op.Message.Epoch)
+	root, err := op.Message.HashTreeRoot()
+	require.NoError(t, err)
+	verifySignature(t, account, root, domain, op.Signature)
+}
+
+func TestBuildBLSToExecutionChange(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+	account := testAccount(t)
+	domain := testutil.HexToDomain("0x0a000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9")
+	address := bellatrix.ExecutionAddress{0x01, 0x02, 0x03}
+
+	_, err := ops.BuildBLSToExecutionChange(ctx, nil, nil, 1, address, domain)
+	require.EqualError(t, err, "no withdrawal account specified")
+
+	op, err := ops.BuildBLSToExecutionChange(ctx, account, nil, 1, address, domain)
+	require.NoError(t, err)
+	require.Equal(t, phase0.ValidatorIndex(1), op.Message.ValidatorIndex)
+	require.Equal(t, address, op.Message.ToExecutionAddress)
+	require.Equal(t, account.PublicKey().Marshal(), op.Message.FromBLSPubkey[:])
+	root, err := op.Message.HashTreeRoot()
+	require.NoError(t, err)
+	verifySignature(t, account, root, domain, op.Signature)
+}
+
+func TestBuildDepositData(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+	account := testAccount(t)
+	domain := testutil.HexToDomain("0x03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9")
+	withdrawalCredentials := ops.ExecutionWithdrawalCredentials(bellatrix.ExecutionAddress{0x01, 0x02, 0x03})
+
+	_, err := ops.BuildDepositData(ctx, nil, nil, withdrawalCredentials, 32000000000, domain)
+	require.EqualError(t, err, "no validator account specified")
+
+	_, err = ops.BuildDepositData(ctx, account, nil, withdrawalCredentials[1:], 32000000000, domain)
+	require.EqualError(t, err, "withdrawal credentials must be 32 bytes")
+
+	depositData, err := ops.BuildDepositData(ctx, account, nil, withdrawalCredentials, 32000000000, domain)
+	require.NoError(t, err)
+	require.Equal(t, account.PublicKey().Marshal(), depositData.PublicKey[:])
+	require.Equal(t, withdrawalCredentials, depositData.WithdrawalCredentials)
+	require.Equal(t, phase0.Gwei(32000000000), depositData.Amount)
+	root, err := (&phase0.DepositMessage{
+		PublicKey:             depositData.PublicKey,
+		WithdrawalCredentials: depositData.WithdrawalCredentials,
+		Amount:                depositData.Amount,
+	}).HashTreeRoot()
+	require.NoError(t, err)
+	verifySignature(t, account, root, domain, depositData.Signature)
+}
+
+func TestWithdrawalCredentials(t *testing.T) {
+	require.Equal(t,
+		testutil.HexToBytes("0x0100000000000000000000000102030000000000000000000000000000000000"),
+		ops.ExecutionWithdrawalCredentials(bellatrix.ExecutionAddress{0x01, 0x02, 0x03}),
+	)
+
+	credentials := ops.BLSWithdrawalCredentials(phase0.BLSPubKey{0x01})
+	require.Len(t, credentials, 32)
+	require.Equal(t, byte(0), credentials[0])
+}
@@ -0,0 +1,56 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ops
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/signing"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// BuildSignedVoluntaryExit builds a voluntary exit for the validator with the
+// given index and signs it with the validator's account.
+// Passphrases are used to unlock the account if it is not already unlocked.
+func BuildSignedVoluntaryExit(ctx context.Context,
+	account e2wtypes.Account,
+	passphrases []string,
+	validatorIndex phase0.ValidatorIndex,
+	epoch phase0.Epoch,
+	domain phase0.Domain,
+) (
+	*phase0.SignedVoluntaryExit,
+	error,
+) {
+	operation := &phase0.VoluntaryExit{
+		Epoch:          epoch,
+		ValidatorIndex: validatorIndex,
+	}
+	root, err := operation.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate root for exit operation")
+	}
+
+	signature, err := signing.SignRoot(ctx, account, passphrases, root, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign exit operation")
+	}
+
+	return &phase0.SignedVoluntaryExit{
+		Message:   operation,
+		Signature: signature,
+	}, nil
+}
@@ -19,6 +19,16 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
+// Fork is a fork in the chain's fork schedule.
+type Fork struct {
+	// Name is the name of the fork, for example "capella".
+	Name string
+	// Version is the fork version.
+	Version phase0.Version
+	// Epoch is the epoch at which the fork takes place.
+	Epoch phase0.Epoch
+}
+
 // Service provides a number of functions for calculating chain-related times.
 type Service interface {
 	// GenesisTime provides the time of the chain's genesis.
@@ -56,4 +66,9 @@ type Service interface {
 	AltairInitialSyncCommitteePeriod() uint64
 	// CapellaInitialEpoch provides the epoch at which the Capella hard fork takes place.
 	CapellaInitialEpoch() phase0.Epoch
+	// ForkSchedule provides the chain's fork schedule, ordered by epoch.
+	// Forks that are not scheduled have an epoch of 0xffffffffffffffff.
+	ForkSchedule() []*Fork
+	// ForkAtEpoch provides the fork that is active at the given epoch.
+	ForkAtEpoch(epoch phase0.Epoch) *Fork
 }
@@ -0,0 +1,168 @@
+// Copyright © 2021 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+// knownForks are the forks of which we are aware, in the order in which they
+// take place.  The genesis fork is known as phase0.
+var knownForks = []string{
+	"GENESIS",
+	"ALTAIR",
+	"BELLATRIX",
+	"CAPELLA",
+	"DENEB",
+	"ELECTRA",
+	"FULU",
+}
+
+// buildForkSchedule builds the fork schedule from the spec and, if available,
+// the node's fork schedule.
+func buildForkSchedule(ctx context.Context,
+	spec map[string]interface{},
+	forkScheduleProvider eth2client.ForkScheduleProvider,
+) (
+	[]*chaintime.Fork,
+	error,
+) {
+	// Find the forks defined in the spec, known forks first to retain
+	// their ordering when sharing an epoch.
+	prefixes := make([]string, 0, len(knownForks))
+	prefixes = append(prefixes, knownForks...)
+	extraPrefixes := make([]string, 0)
+	for k := range spec {
+		if !strings.HasSuffix(k, "_FORK_VERSION") {
+			continue
+		}
+		prefix := strings.TrimSuffix(k, "_FORK_VERSION")
+		known := false
+		for _, knownFork := range knownForks {
+			if prefix == knownFork {
+				known = true
+				break
+			}
+		}
+		if !known {
+			extraPrefixes = append(extraPrefixes, prefix)
+		}
+	}
+	sort.Strings(extraPrefixes)
+	prefixes = append(prefixes, extraPrefixes...)
+
+	forks := make([]*chaintime.Fork, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		tmp, exists := spec[prefix+"_FORK_VERSION"]
+		if !exists {
+			continue
+		}
+		version, isVersion := tmp.(phase0.Version)
+		if !isVersion {
+			return nil, errors.Errorf("%s_FORK_VERSION of unexpected type", prefix)
+		}
+
+		fork := &chaintime.Fork{
+			Name:    strings.ToLower(prefix),
+			Version: version,
+			Epoch:   0xffffffffffffffff,
+		}
+		if prefix == "GENESIS" {
+			fork.Name = "phase0"
+			fork.Epoch = 0
+		} else if tmp, exists := spec[prefix+"_FORK_EPOCH"]; exists {
+			epoch, isEpoch := tmp.(uint64)
+			if !isEpoch {
+				return nil, errors.Errorf("%s_FORK_EPOCH of unexpected type", prefix)
+			}
+			fork.Epoch = phase0.Epoch(epoch)
+		}
+		forks = append(forks, fork)
+	}
+
+	if forkScheduleProvider != nil {
+		var err error
+		forks, err = mergeNodeForkSchedule(ctx, forks, forkScheduleProvider)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(forks, func(i int, j int) bool {
+		return forks[i].Epoch < forks[j].Epoch
+	})
+
+	return forks, nil
+}
+
+// mergeNodeForkSchedule merges the node's fork schedule with that obtained
+// from the spec.  The node's epochs take precedence; forks that are only known
+// to the node are added without a name.
+func mergeNodeForkSchedule(ctx context.Context,
+	forks []*chaintime.Fork,
+	forkScheduleProvider eth2client.ForkScheduleProvider,
+) (
+	[]*chaintime.Fork,
+	error,
+) {
+	nodeForks, err := forkScheduleProvider.ForkSchedule(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain fork schedule")
+	}
+
+	for _, nodeFork := range nodeForks {
+		found := false
+		for _, fork := range forks {
+			if fork.Version == nodeFork.CurrentVersion {
+				fork.Epoch = nodeFork.Epoch
+				found = true
+				break
+			}
+		}
+		if !found {
+			forks = append(forks, &chaintime.Fork{
+				Version: nodeFork.CurrentVersion,
+				Epoch:   nodeFork.Epoch,
+			})
+		}
+	}
+
+	return forks, nil
+}
+
+// ForkSchedule provides the chain's fork schedule, ordered by epoch.
+// Forks that are not scheduled have an epoch of 0xffffffffffffffff.
+func (s *Service) ForkSchedule() []*chaintime.Fork {
+	return s.forkSchedule
+}
+
+// ForkAtEpoch provides the fork that is active at the given epoch.
+func (s *Service) ForkAtEpoch(epoch phase0.Epoch) *chaintime.Fork {
+	var res *chaintime.Fork
+	for _, fork := range s.forkSchedule {
+		if fork.Epoch > epoch {
+			break
+		}
+		res = fork
+	}
+
+	return res
+}
@@ -20,9 +20,10 @@ import (
 )
 
 type parameters struct {
-	logLevel            zerolog.Level
-	genesisTimeProvider eth2client.GenesisTimeProvider
-	specProvider        eth2client.SpecProvider
+	logLevel             zerolog.Level
+	genesisTimeProvider  eth2client.GenesisTimeProvider
+	specProvider         eth2client.SpecProvider
+	forkScheduleProvider eth2client.ForkScheduleProvider
 }
 
 // Parameter is the interface for service parameters.
@@ -57,6 +58,15 @@ func WithSpecProvider(provider eth2client.SpecProvider) Parameter {
 	})
 }
 
+// WithForkScheduleProvider sets the fork schedule provider.
+// This is optional; if supplied the fork epochs provided by the node take
+// precedence over those in the spec.
+func WithForkScheduleProvider(provider eth2client.ForkScheduleProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.forkScheduleProvider = provider
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -22,6 +22,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/ethdo/services/chaintime"
 )
 
 // Service provides chain time services.
@@ -33,6 +34,7 @@ type Service struct {
 	altairForkEpoch              phase0.Epoch
 	bellatrixForkEpoch           phase0.Epoch
 	capellaForkEpoch             phase0.Epoch
+	forkSchedule                 []*chaintime.Fork
 }
 
 // module-wide log.
@@ -107,6 +109,12 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	}
 	log.Trace().Uint64("epoch", uint64(capellaForkEpoch)).Msg("Obtained Capella fork epoch")
 
+	forkSchedule, err := buildForkSchedule(ctx, spec, parameters.forkScheduleProvider)
+	if err != nil {
+		return nil, err
+	}
+	log.Trace().Int("forks", len(forkSchedule)).Msg("Obtained fork schedule")
+
 	s := &Service{
 		genesisTime:                  genesisTime,
 		slotDuration:                 slotDuration,
@@ -115,6 +123,7 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		altairForkEpoch:              altairForkEpoch,
 		bellatrixForkEpoch:           bellatrixForkEpoch,
 		capellaForkEpoch:             capellaForkEpoch,
+		forkSchedule:                 forkSchedule,
 	}
 
 	return s, nil
@@ -285,14 +294,14 @@ func fetchCapellaForkEpoch(ctx context.Context,
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to obtain spec")
 	}
-	tmp, exists := spec["CAPELLAELLATRIX_FORK_EPOCH"]
+	tmp, exists := spec["CAPELLA_FORK_EPOCH"]
 	if !exists {
 		return 0, errors.New("capella fork version not known by chain")
 	}
 	epoch, isEpoch := tmp.(uint64)
 	if !isEpoch {
 		//nolint:revive
-		return 0, errors.New("CAPELLAELLATRIX_FORK_EPOCH is not a uint64!")
+		return 0, errors.New("CAPELLA_FORK_EPOCH is not a uint64!")
 	}
 
 	return phase0.Epoch(epoch), nil
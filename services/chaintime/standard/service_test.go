@@ -101,6 +101,7 @@ func createService(genesisTime time.Time) (chaintime.Service, time.Duration, uin
 	s, err := standard.New(context.Background(),
 		standard.WithGenesisTimeProvider(mockGenesisTimeProvider),
 		standard.WithSpecProvider(mockSpecProvider),
+		standard.WithForkScheduleProvider(mock.NewForkScheduleProvider(forkSchedule)),
 	)
 	return s, slotDuration, slotsPerEpoch, epochsPerSyncCommitteePeriod, forkSchedule, err
 }
@@ -229,3 +230,63 @@ func TestTimestampToEpoch(t *testing.T) {
 		})
 	}
 }
+
+func TestForkAtEpoch(t *testing.T) {
+	genesisTime := time.Now()
+	s, _, _, _, forkSchedule, err := createService(genesisTime)
+	require.NoError(t, err)
+
+	require.Len(t, s.ForkSchedule(), 2)
+	require.Equal(t, forkSchedule[0].CurrentVersion, s.ForkAtEpoch(0).Version)
+	require.Equal(t, forkSchedule[0].CurrentVersion, s.ForkAtEpoch(9).Version)
+	require.Equal(t, forkSchedule[1].CurrentVersion, s.ForkAtEpoch(10).Version)
+	require.Equal(t, forkSchedule[1].CurrentVersion, s.ForkAtEpoch(1000).Version)
+}
+
+// forkSpecProvider provides a spec with fork information.
+type forkSpecProvider struct {
+	spec map[string]interface{}
+}
+
+func (p *forkSpecProvider) Spec(_ context.Context) (map[string]interface{}, error) {
+	return p.spec, nil
+}
+
+func TestForkScheduleFromSpec(t *testing.T) {
+	specProvider := &forkSpecProvider{
+		spec: map[string]interface{}{
+			"SECONDS_PER_SLOT":                 12 * time.Second,
+			"SLOTS_PER_EPOCH":                  uint64(32),
+			"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": uint64(256),
+			"GENESIS_FORK_VERSION":             phase0.Version{0x00, 0x00, 0x00, 0x01},
+			"ALTAIR_FORK_VERSION":              phase0.Version{0x01, 0x00, 0x00, 0x01},
+			"ALTAIR_FORK_EPOCH":                uint64(0),
+			"BELLATRIX_FORK_VERSION":           phase0.Version{0x02, 0x00, 0x00, 0x01},
+			"BELLATRIX_FORK_EPOCH":             uint64(0),
+			"CAPELLA_FORK_VERSION":             phase0.Version{0x03, 0x00, 0x00, 0x01},
+			"CAPELLA_FORK_EPOCH":               uint64(256),
+			"DENEB_FORK_VERSION":               phase0.Version{0x04, 0x00, 0x00, 0x01},
+		},
+	}
+
+	s, err := standard.New(context.Background(),
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now())),
+		standard.WithSpecProvider(specProvider),
+	)
+	require.NoError(t, err)
+
+	forks := s.ForkSchedule()
+	require.Len(t, forks, 5)
+	names := make([]string, 0, len(forks))
+	for _, fork := range forks {
+		names = append(names, fork.Name)
+	}
+	require.Equal(t, []string{"phase0", "altair", "bellatrix", "capella", "deneb"}, names)
+	require.Equal(t, phase0.Epoch(0xffffffffffffffff), forks[4].Epoch)
+
+	require.Equal(t, "bellatrix", s.ForkAtEpoch(0).Name)
+	require.Equal(t, "bellatrix", s.ForkAtEpoch(255).Name)
+	require.Equal(t, "capella", s.ForkAtEpoch(256).Name)
+	require.Equal(t, phase0.Epoch(256), s.CapellaInitialEpoch())
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/auditlog"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
@@ -43,7 +44,7 @@ func Unlock(ctx context.Context, account e2wtypes.Account, passphrases []string)
 		err = locker.Unlock(ctx, []byte(passphrase))
 		if err == nil {
 			// Unlocked.
-			return false, nil
+			return false, auditlog.Record(auditlog.ActionUnlock, account, nil)
 		}
 	}
 
@@ -15,15 +15,38 @@ package signing
 
 import (
 	"context"
+	"fmt"
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/auditlog"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 // SignRoot signs a root with a domain.
 func SignRoot(ctx context.Context, account e2wtypes.Account, passphrases []string, root spec.Root, domain spec.Domain) (spec.BLSSignature, error) {
+	return signRoot(ctx, account, passphrases, root, domain, true)
+}
+
+// SignRootShare signs a root with a domain using the key held by the
+// account.  For distributed accounts this is the account's key share, and
+// the resultant partial signature is not checked against the composite
+// public key.
+func SignRootShare(ctx context.Context, account e2wtypes.Account, passphrases []string, root spec.Root, domain spec.Domain) (spec.BLSSignature, error) {
+	return signRoot(ctx, account, passphrases, root, domain, false)
+}
+
+func signRoot(ctx context.Context,
+	account e2wtypes.Account,
+	passphrases []string,
+	root spec.Root,
+	domain spec.Domain,
+	composite bool,
+) (
+	spec.BLSSignature,
+	error,
+) {
 	// Ensure input is as expected.
 	if account == nil {
 		return spec.BLSSignature{}, errors.New("account not specified")
@@ -48,12 +71,24 @@ func SignRoot(ctx context.Context, account e2wtypes.Account, passphrases []strin
 		return spec.BLSSignature{}, err
 	}
 
+	if distributedAccount, isDistributed := account.(e2wtypes.DistributedAccount); isDistributed && composite {
+		// Distributed accounts gather signatures from their participants and
+		// combine them, so confirm the result is valid for the composite key.
+		if err := verifyComposite(distributedAccount, signature, root, domain); err != nil {
+			return spec.BLSSignature{}, err
+		}
+	}
+
 	if !alreadyUnlocked {
 		if err := Lock(ctx, account); err != nil {
 			return spec.BLSSignature{}, errors.Wrap(err, "failed to lock account")
 		}
 	}
 
+	if err := auditlog.Record(auditlog.ActionSign, account, root[:]); err != nil {
+		return spec.BLSSignature{}, err
+	}
+
 	var sig spec.BLSSignature
 	copy(sig[:], signature.Marshal())
 	return sig, nil
@@ -77,6 +112,56 @@ func sign(ctx context.Context, account e2wtypes.AccountSigner, root spec.Root, d
 	return signature, err
 }
 
+func verifyComposite(account e2wtypes.DistributedAccount, signature e2types.Signature, root spec.Root, domain spec.Domain) error {
+	if err := verifyDistributedAccount(account); err != nil {
+		return err
+	}
+
+	container := &Container{
+		Root:   root[:],
+		Domain: domain[:],
+	}
+	signingRoot, err := container.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate hash tree root")
+	}
+
+	if !signature.Verify(signingRoot[:], account.CompositePublicKey()) {
+		return fmt.Errorf("threshold signature does not verify against composite public key (threshold %d/%d)", account.SigningThreshold(), len(account.Participants()))
+	}
+
+	return nil
+}
+
+// verifyDistributedAccount ensures that the threshold and participants of a
+// distributed account are able to generate a composite signature.
+func verifyDistributedAccount(account e2wtypes.DistributedAccount) error {
+	threshold := account.SigningThreshold()
+	if threshold == 0 {
+		return errors.New("distributed account has no signing threshold")
+	}
+	participants := account.Participants()
+	if len(participants) == 0 {
+		return errors.New("distributed account has no participants")
+	}
+	if int(threshold) > len(participants) {
+		return fmt.Errorf("distributed account signing threshold %d exceeds its %d participants", threshold, len(participants))
+	}
+	for id, endpoint := range participants {
+		if id == 0 {
+			return errors.New("distributed account has a participant with ID 0")
+		}
+		if endpoint == "" {
+			return fmt.Errorf("distributed account participant %d has no endpoint", id)
+		}
+	}
+	if account.CompositePublicKey() == nil {
+		return errors.New("distributed account has no composite public key")
+	}
+
+	return nil
+}
+
 func signProtected(ctx context.Context, account e2wtypes.AccountProtectingSigner, root spec.Root, domain spec.Domain) (e2types.Signature, error) {
 	signature, err := account.SignGeneric(ctx, root[:], domain[:])
 	if err != nil {
@@ -0,0 +1,200 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	distributed "github.com/wealdtech/go-eth2-wallet-distributed"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func hexToBytes(input string) []byte {
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// testDistributedAccount overrides the distributed properties of an account.
+type testDistributedAccount struct {
+	e2wtypes.DistributedAccount
+	threshold          uint32
+	participants       map[uint64]string
+	compositePublicKey e2types.PublicKey
+}
+
+func (a *testDistributedAccount) SigningThreshold() uint32 {
+	return a.threshold
+}
+
+func (a *testDistributedAccount) Participants() map[uint64]string {
+	return a.participants
+}
+
+func (a *testDistributedAccount) CompositePublicKey() e2types.PublicKey {
+	return a.compositePublicKey
+}
+
+func TestVerifyComposite(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	distributedWallet, err := distributed.CreateWallet(ctx, "Test distributed", scratch.New(), keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, distributedWallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	account, err := distributedWallet.(e2wtypes.WalletDistributedAccountImporter).ImportDistributedAccount(ctx,
+		"Distributed 0",
+		hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+		2,
+		[][]byte{
+			hexToBytes("0x876dd4705157eb66dc71bc2e07fb151ea53e1a62a0bb980a7ce72d15f58944a8a3752d754f52f4a60dbfc7b18169f268"),
+			hexToBytes("0xaec922bd7a9b7b1dc21993133b586b0c3041c1e2e04b513e862227b9d7aecaf9444222f7e78282a449622ffc6278915d"),
+		},
+		map[uint64]string{
+			1: "localhost-1:12345",
+			2: "localhost-2:12345",
+			3: "localhost-3:12345",
+		},
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+	fixture := account.(e2wtypes.DistributedAccount)
+
+	root := spec.Root{0x01}
+	domain := spec.Domain{0x02}
+	container := &Container{
+		Root:   root[:],
+		Domain: domain[:],
+	}
+	signingRoot, err := container.HashTreeRoot()
+	require.NoError(t, err)
+	// The fixture's key share signs alone, so its signature does not match the composite public key.
+	shareKey, err := e2types.BLSPrivateKeyFromBytes(hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"))
+	require.NoError(t, err)
+	shareSignature := shareKey.Sign(signingRoot[:])
+
+	tests := []struct {
+		name      string
+		account   e2wtypes.DistributedAccount
+		signature e2types.Signature
+		err       string
+	}{
+		{
+			name: "ThresholdZero",
+			account: &testDistributedAccount{
+				DistributedAccount: fixture,
+				participants:       fixture.Participants(),
+				compositePublicKey: fixture.CompositePublicKey(),
+			},
+			signature: shareSignature,
+			err:       "distributed account has no signing threshold",
+		},
+		{
+			name: "ParticipantsMissing",
+			account: &testDistributedAccount{
+				DistributedAccount: fixture,
+				threshold:          fixture.SigningThreshold(),
+				compositePublicKey: fixture.CompositePublicKey(),
+			},
+			signature: shareSignature,
+			err:       "distributed account has no participants",
+		},
+		{
+			name: "ThresholdTooHigh",
+			account: &testDistributedAccount{
+				DistributedAccount: fixture,
+				threshold:          4,
+				participants:       fixture.Participants(),
+				compositePublicKey: fixture.CompositePublicKey(),
+			},
+			signature: shareSignature,
+			err:       "distributed account signing threshold 4 exceeds its 3 participants",
+		},
+		{
+			name: "ParticipantIDZero",
+			account: &testDistributedAccount{
+				DistributedAccount: fixture,
+				threshold:          fixture.SigningThreshold(),
+				participants: map[uint64]string{
+					0: "localhost-0:12345",
+					1: "localhost-1:12345",
+				},
+				compositePublicKey: fixture.CompositePublicKey(),
+			},
+			signature: shareSignature,
+			err:       "distributed account has a participant with ID 0",
+		},
+		{
+			name: "ParticipantEndpointMissing",
+			account: &testDistributedAccount{
+				DistributedAccount: fixture,
+				threshold:          fixture.SigningThreshold(),
+				participants: map[uint64]string{
+					1: "localhost-1:12345",
+					2: "",
+				},
+				compositePublicKey: fixture.CompositePublicKey(),
+			},
+			signature: shareSignature,
+			err:       "distributed account participant 2 has no endpoint",
+		},
+		{
+			name: "CompositePublicKeyMissing",
+			account: &testDistributedAccount{
+				DistributedAccount: fixture,
+				threshold:          fixture.SigningThreshold(),
+				participants:       fixture.Participants(),
+			},
+			signature: shareSignature,
+			err:       "distributed account has no composite public key",
+		},
+		{
+			name:      "SignatureMismatch",
+			account:   fixture,
+			signature: shareSignature,
+			err:       "threshold signature does not verify against composite public key (threshold 2/3)",
+		},
+		{
+			name: "Good",
+			account: &testDistributedAccount{
+				DistributedAccount: fixture,
+				threshold:          fixture.SigningThreshold(),
+				participants:       fixture.Participants(),
+				compositePublicKey: shareKey.PublicKey(),
+			},
+			signature: shareSignature,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := verifyComposite(test.account, test.signature, root, domain)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
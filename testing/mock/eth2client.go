@@ -149,3 +149,59 @@ func NewBeaconCommitteeSubscriptionsSubmitter() eth2client.BeaconCommitteeSubscr
 func (m *BeaconCommitteeSubscriptionsSubmitter) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscriptions []*api.BeaconCommitteeSubscription) error {
 	return nil
 }
+
+// ValidatorsProvider is a mock for eth2client.ValidatorsProvider.
+type ValidatorsProvider struct {
+	validators []*api.Validator
+	// FullFetches is the number of requests made for all validators.
+	FullFetches int
+}
+
+// NewValidatorsProvider returns a mock validators provider with the provided validators.
+func NewValidatorsProvider(validators []*api.Validator) eth2client.ValidatorsProvider {
+	return &ValidatorsProvider{
+		validators: validators,
+	}
+}
+
+// Validators is a mock.
+func (m *ValidatorsProvider) Validators(ctx context.Context, stateID string, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]*api.Validator, error) {
+	if len(validatorIndices) == 0 {
+		m.FullFetches++
+	}
+	res := make(map[phase0.ValidatorIndex]*api.Validator)
+	for _, validator := range m.validators {
+		if len(validatorIndices) == 0 {
+			res[validator.Index] = validator
+			continue
+		}
+		for _, index := range validatorIndices {
+			if validator.Index == index {
+				res[validator.Index] = validator
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+// ValidatorsByPubKey is a mock.
+func (m *ValidatorsProvider) ValidatorsByPubKey(ctx context.Context, stateID string, validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*api.Validator, error) {
+	if len(validatorPubKeys) == 0 {
+		m.FullFetches++
+	}
+	res := make(map[phase0.ValidatorIndex]*api.Validator)
+	for _, validator := range m.validators {
+		if len(validatorPubKeys) == 0 {
+			res[validator.Index] = validator
+			continue
+		}
+		for _, pubKey := range validatorPubKeys {
+			if validator.Validator.PublicKey == pubKey {
+				res[validator.Index] = validator
+				break
+			}
+		}
+	}
+	return res, nil
+}
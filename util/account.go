@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/auditlog"
 	util "github.com/wealdtech/go-eth2-util"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
@@ -80,7 +81,7 @@ func ParseAccount(ctx context.Context,
 		}
 		if unlock {
 			// Supplementary will be the unlock passphrase(s).
-			_, err = UnlockAccount(ctx, account, supplementary)
+			_, err = UnlockAccount(ctx, account, manifestPassphrases(accountStr, account, supplementary))
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to unlock account")
 			}
@@ -100,6 +101,9 @@ func ParseAccount(ctx context.Context,
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to unlock account")
 			}
+			if err := auditlog.Record(auditlog.ActionUnlock, account, nil); err != nil {
+				return nil, err
+			}
 		}
 	default:
 		return nil, fmt.Errorf("unknown account specifier %s", accountStr)
@@ -157,7 +161,7 @@ func UnlockAccount(ctx context.Context, account e2wtypes.Account, passphrases []
 		err = locker.Unlock(ctx, []byte(passphrase))
 		if err == nil {
 			// Unlocked.
-			return false, nil
+			return false, auditlog.Record(auditlog.ActionUnlock, account, nil)
 		}
 	}
 
@@ -165,13 +169,35 @@ func UnlockAccount(ctx context.Context, account e2wtypes.Account, passphrases []
 	err = locker.Unlock(ctx, nil)
 	if err == nil {
 		// Unlocked.
-		return false, nil
+		return false, auditlog.Record(auditlog.ActionUnlock, account, nil)
 	}
 
 	// Failed to unlock it.
 	return false, errors.New("failed to unlock account")
 }
 
+// UnlockAccountWithPassphrase attempts to unlock an account with each of the
+// supplied passphrases in turn, returning the passphrase that unlocked it.
+// Accounts that are already unlocked are locked first, so that the passphrase
+// can be determined.
+func UnlockAccountWithPassphrase(ctx context.Context, account e2wtypes.Account, passphrases []string) (string, error) {
+	locker, isAccountLocker := account.(e2wtypes.AccountLocker)
+	if !isAccountLocker {
+		return "", errors.New("account does not support unlocking")
+	}
+
+	if err := locker.Lock(ctx); err != nil {
+		return "", errors.Wrap(err, "failed to lock account")
+	}
+	for _, passphrase := range passphrases {
+		if err := locker.Unlock(ctx, []byte(passphrase)); err == nil {
+			return passphrase, auditlog.Record(auditlog.ActionUnlock, account, nil)
+		}
+	}
+
+	return "", errors.New("failed to unlock account")
+}
+
 // LockAccount attempts to lock an account.
 func LockAccount(ctx context.Context, account e2wtypes.Account) error {
 	locker, isAccountLocker := account.(e2wtypes.AccountLocker)
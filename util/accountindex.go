@@ -0,0 +1,229 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// accountIndexVersion is the version of the persisted account index format.
+const accountIndexVersion = 1
+
+// accountIndexFile is the name of the file in the wallet's directory in which
+// the account index is persisted.  The filesystem store ignores files in the
+// wallet's directory that are not named for an account.
+const accountIndexFile = "ethdo-accounts.json"
+
+// AccountIndex provides lookups of accounts in a wallet by name or public key
+// without reading every account in the wallet.  Accounts are only loaded from
+// the wallet when they are requested.
+//
+// For wallets in an unencrypted filesystem store the index is persisted in the
+// wallet's directory, readable only by its owner, and is rebuilt when the
+// wallet's own index of accounts changes.
+type AccountIndex struct {
+	wallet    e2wtypes.Wallet
+	cacheFile string
+	source    string
+	entries   []*accountIndexEntry
+	byName    map[string]*accountIndexEntry
+	byPubKey  map[string]*accountIndexEntry
+}
+
+type accountIndexEntry struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	PublicKey string    `json:"public_key,omitempty"`
+}
+
+type accountIndexJSON struct {
+	Version  uint64               `json:"version"`
+	Source   string               `json:"source"`
+	Accounts []*accountIndexEntry `json:"accounts"`
+}
+
+// NewAccountIndex creates an account index for the given wallet.
+func NewAccountIndex(ctx context.Context, wallet e2wtypes.Wallet) (*AccountIndex, error) {
+	if _, isProvider := wallet.(e2wtypes.WalletAccountByIDProvider); !isProvider {
+		return nil, errors.New("wallet cannot obtain accounts by ID")
+	}
+
+	index := &AccountIndex{
+		wallet: wallet,
+	}
+
+	if index.load() {
+		return index, nil
+	}
+	index.rebuild(ctx)
+
+	return index, nil
+}
+
+// Names returns the names of all accounts in the index, sorted.
+func (a *AccountIndex) Names() []string {
+	names := make([]string, 0, len(a.entries))
+	for _, entry := range a.entries {
+		names = append(names, entry.Name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// AccountByName returns the account with the given name.
+func (a *AccountIndex) AccountByName(ctx context.Context, name string) (e2wtypes.Account, error) {
+	return a.fetch(ctx, func() *accountIndexEntry { return a.byName[name] }, fmt.Sprintf("no account with name %q", name))
+}
+
+// AccountByPublicKey returns the account with the given public key.
+func (a *AccountIndex) AccountByPublicKey(ctx context.Context, pubKey []byte) (e2wtypes.Account, error) {
+	key := hex.EncodeToString(pubKey)
+	return a.fetch(ctx, func() *accountIndexEntry { return a.byPubKey[key] }, fmt.Sprintf("no account with public key %#x", pubKey))
+}
+
+// fetch fetches an account from the wallet given a lookup function.
+// If the lookup fails, or the index does not match the account it references,
+// the index is rebuilt and the lookup retried.
+func (a *AccountIndex) fetch(ctx context.Context, lookup func() *accountIndexEntry, notFound string) (e2wtypes.Account, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		entry := lookup()
+		if entry != nil {
+			account, err := a.wallet.(e2wtypes.WalletAccountByIDProvider).AccountByID(ctx, entry.ID)
+			if err == nil && account.Name() == entry.Name {
+				return account, nil
+			}
+		}
+		if attempt == 0 {
+			a.rebuild(ctx)
+		}
+	}
+
+	return nil, errors.New(notFound)
+}
+
+// rebuild rebuilds the index from the accounts in the wallet.
+func (a *AccountIndex) rebuild(ctx context.Context) {
+	a.cacheFile, a.source = accountIndexSource(a.wallet)
+
+	entries := make([]*accountIndexEntry, 0)
+	for account := range a.wallet.Accounts(ctx) {
+		entry := &accountIndexEntry{
+			ID:   account.ID(),
+			Name: account.Name(),
+		}
+		// Not all accounts can provide a public key without being unlocked.
+		if pubKey, err := BestPublicKey(account); err == nil {
+			entry.PublicKey = hex.EncodeToString(pubKey.Marshal())
+		}
+		entries = append(entries, entry)
+	}
+	a.setEntries(entries)
+
+	if a.cacheFile != "" {
+		// Persisting the index is an optimisation, so failure is not an error.
+		_ = a.save()
+	}
+}
+
+func (a *AccountIndex) setEntries(entries []*accountIndexEntry) {
+	a.entries = entries
+	a.byName = make(map[string]*accountIndexEntry, len(entries))
+	a.byPubKey = make(map[string]*accountIndexEntry, len(entries))
+	for _, entry := range entries {
+		a.byName[entry.Name] = entry
+		if entry.PublicKey != "" {
+			a.byPubKey[entry.PublicKey] = entry
+		}
+	}
+}
+
+// load loads the persisted index, returning true if it is present and current.
+func (a *AccountIndex) load() bool {
+	a.cacheFile, a.source = accountIndexSource(a.wallet)
+	if a.cacheFile == "" {
+		return false
+	}
+	data, err := os.ReadFile(a.cacheFile)
+	if err != nil {
+		return false
+	}
+	var persisted accountIndexJSON
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		return false
+	}
+	if persisted.Version != accountIndexVersion || persisted.Source != a.source {
+		return false
+	}
+	a.setEntries(persisted.Accounts)
+
+	return true
+}
+
+// save persists the index.
+func (a *AccountIndex) save() error {
+	data, err := json.Marshal(&accountIndexJSON{
+		Version:  accountIndexVersion,
+		Source:   a.source,
+		Accounts: a.entries,
+	})
+	if err != nil {
+		return err
+	}
+
+	return WriteFileAtomically(a.cacheFile, data)
+}
+
+// accountIndexSource returns the file in which to persist the index for a wallet,
+// and a hash of the wallet's own index of accounts, which changes whenever an
+// account is added or removed.  If the index cannot be persisted for the
+// wallet an empty file name is returned.
+func accountIndexSource(wallet e2wtypes.Wallet) (string, string) {
+	storeProvider, isProvider := wallet.(e2wtypes.StoreProvider)
+	if !isProvider {
+		return "", ""
+	}
+	store := storeProvider.Store()
+	if store.Name() != "filesystem" {
+		return "", ""
+	}
+	if GetStorePassphrase(store.Name()) != "" {
+		// The store is encrypted, so account names and public keys must not be
+		// persisted in plaintext.
+		return "", ""
+	}
+	storeLocationProvider, isProvider := store.(e2wtypes.StoreLocationProvider)
+	if !isProvider {
+		return "", ""
+	}
+	walletDir := filepath.Join(storeLocationProvider.Location(), wallet.ID().String())
+	walletIndex, err := os.ReadFile(filepath.Join(walletDir, "index"))
+	if err != nil {
+		return "", ""
+	}
+	hash := sha256.Sum256(walletIndex)
+
+	return filepath.Join(walletDir, accountIndexFile), hex.EncodeToString(hash[:])
+}
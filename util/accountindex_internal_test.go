@@ -0,0 +1,180 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestAccountIndex(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	viper.Reset()
+	location := t.TempDir()
+	store := filesystem.New(filesystem.WithLocation(location))
+	wallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	account1, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 1", []byte("pass"))
+	require.NoError(t, err)
+	account2, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 2", []byte("pass"))
+	require.NoError(t, err)
+
+	index, err := NewAccountIndex(ctx, wallet)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Account 1", "Account 2"}, index.Names())
+
+	// Index should have been persisted in the wallet's directory, readable
+	// only by its owner.
+	cacheFile := filepath.Join(location, wallet.ID().String(), accountIndexFile)
+	info, err := os.Stat(cacheFile)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// The persisted index should not be seen as an account by the store.
+	accounts := 0
+	for range wallet.Accounts(ctx) {
+		accounts++
+	}
+	require.Equal(t, 2, accounts)
+
+	account, err := index.AccountByName(ctx, "Account 2")
+	require.NoError(t, err)
+	require.Equal(t, account2.ID(), account.ID())
+
+	pubKey, err := BestPublicKey(account1)
+	require.NoError(t, err)
+	account, err = index.AccountByPublicKey(ctx, pubKey.Marshal())
+	require.NoError(t, err)
+	require.Equal(t, account1.ID(), account.ID())
+
+	_, err = index.AccountByName(ctx, "Unknown")
+	require.EqualError(t, err, `no account with name "Unknown"`)
+	_, err = index.AccountByPublicKey(ctx, []byte{0x01, 0x02})
+	require.EqualError(t, err, "no account with public key 0x0102")
+
+	// Persisted index should be used by a new index.
+	index, err = NewAccountIndex(ctx, wallet)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Account 1", "Account 2"}, index.Names())
+	require.True(t, (&AccountIndex{wallet: wallet}).load())
+
+	// A change to the wallet's own index should invalidate the persisted index.
+	walletIndexFile := filepath.Join(location, wallet.ID().String(), "index")
+	walletIndex, err := os.ReadFile(walletIndexFile)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(walletIndexFile, append(walletIndex, ' '), 0o600))
+	require.False(t, (&AccountIndex{wallet: wallet}).load())
+	require.NoError(t, os.WriteFile(walletIndexFile, walletIndex, 0o600))
+	require.True(t, (&AccountIndex{wallet: wallet}).load())
+
+	// Accounts added after the index is built should be found.
+	account3, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 3", []byte("pass"))
+	require.NoError(t, err)
+	account, err = index.AccountByName(ctx, "Account 3")
+	require.NoError(t, err)
+	require.Equal(t, account3.ID(), account.ID())
+	require.Equal(t, []string{"Account 1", "Account 2", "Account 3"}, index.Names())
+
+	// A corrupt persisted index should be rebuilt.
+	require.NoError(t, os.WriteFile(cacheFile, []byte("bad"), 0o600))
+	index, err = NewAccountIndex(ctx, wallet)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Account 1", "Account 2", "Account 3"}, index.Names())
+}
+
+func TestAccountIndexNotPersisted(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	viper.Reset()
+	wallet, err := nd.CreateWallet(ctx, "Test wallet", scratch.New(), keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	_, err = wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 1", []byte("pass"))
+	require.NoError(t, err)
+
+	index, err := NewAccountIndex(ctx, wallet)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Account 1"}, index.Names())
+	require.Empty(t, index.cacheFile)
+}
+
+func TestAccountIndexEncryptedStore(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	viper.Reset()
+	viper.Set("store-passphrase", "store secret")
+	defer viper.Reset()
+	location := t.TempDir()
+	store := filesystem.New(filesystem.WithLocation(location), filesystem.WithPassphrase([]byte("store secret")))
+	wallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	_, err = wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 1", []byte("pass"))
+	require.NoError(t, err)
+
+	index, err := NewAccountIndex(ctx, wallet)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Account 1"}, index.Names())
+	require.NoFileExists(t, filepath.Join(location, wallet.ID().String(), accountIndexFile))
+}
+
+func TestAccountFromWallet(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	viper.Reset()
+	location := t.TempDir()
+	store := filesystem.New(filesystem.WithLocation(location))
+	wallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	account1, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 1", []byte("pass"))
+	require.NoError(t, err)
+	pubKey, err := BestPublicKey(account1)
+	require.NoError(t, err)
+
+	account, err := accountFromWallet(ctx, wallet, "Account 1")
+	require.NoError(t, err)
+	require.Equal(t, account1.ID(), account.ID())
+
+	// The lookup should have built the index.
+	require.FileExists(t, filepath.Join(location, wallet.ID().String(), accountIndexFile))
+
+	account, err = accountFromWallet(ctx, wallet, fmt.Sprintf("%#x", pubKey.Marshal()))
+	require.NoError(t, err)
+	require.Equal(t, account1.ID(), account.ID())
+
+	_, err = accountFromWallet(ctx, wallet, "Unknown")
+	require.EqualError(t, err, `no account with name "Unknown"`)
+	unknownPubKey := fmt.Sprintf("%#x", make([]byte, 48))
+	_, err = accountFromWallet(ctx, wallet, unknownPubKey)
+	require.EqualError(t, err, fmt.Sprintf("no account with name %q", unknownPubKey))
+}
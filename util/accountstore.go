@@ -0,0 +1,335 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/archivestore"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// storedIndexEntry is an entry in the accounts index held by a wallet's store.
+type storedIndexEntry struct {
+	UUID uuid.UUID `json:"uuid"`
+	Name string    `json:"name"`
+}
+
+// CheckAccountName checks that a name is valid for an account.
+func CheckAccountName(name string) error {
+	if name == "" {
+		return errors.New("account name is required")
+	}
+	if strings.HasPrefix(name, "_") {
+		return errors.New("account name cannot start with '_'")
+	}
+	if strings.Contains(name, "/") {
+		return errors.New("account name cannot contain '/'")
+	}
+
+	return nil
+}
+
+// WalletStore returns the store that holds a wallet.
+func WalletStore(wallet e2wtypes.Wallet) (e2wtypes.Store, error) {
+	storeProvider, isProvider := wallet.(e2wtypes.StoreProvider)
+	if !isProvider {
+		return nil, errors.New("cannot obtain store for the wallet")
+	}
+
+	return storeProvider.Store(), nil
+}
+
+// CheckAccountRemovable checks that accounts can be removed from the store.
+func CheckAccountRemovable(store e2wtypes.Store) error {
+	if _, isArchive := store.(*archivestore.Service); isArchive {
+		return nil
+	}
+	if store.Name() != "filesystem" {
+		return fmt.Errorf("cannot remove accounts from %s store automatically", store.Name())
+	}
+	if _, isProvider := store.(e2wtypes.StoreLocationProvider); !isProvider {
+		return errors.New("cannot obtain store location for the wallet")
+	}
+
+	return nil
+}
+
+// RemoveStoredAccount removes an account from its wallet's store.
+// The wallet's accounts index should be rebuilt afterwards.
+func RemoveStoredAccount(store e2wtypes.Store, walletID uuid.UUID, accountID uuid.UUID) error {
+	if err := CheckAccountRemovable(store); err != nil {
+		return err
+	}
+
+	if archiveStore, isArchive := store.(*archivestore.Service); isArchive {
+		return archiveStore.DeleteAccount(walletID, accountID)
+	}
+
+	location := store.(e2wtypes.StoreLocationProvider).Location()
+	if err := os.Remove(filepath.Join(location, walletID.String(), accountID.String())); err != nil {
+		return errors.Wrap(err, "failed to remove account file")
+	}
+
+	return nil
+}
+
+// RenameStoredAccount renames an account in its wallet's store.  The
+// account's key data is left untouched.
+// The wallet's accounts index should be rebuilt afterwards.
+func RenameStoredAccount(store e2wtypes.Store, walletID uuid.UUID, accountID uuid.UUID, name string) error {
+	data, err := store.RetrieveAccount(walletID, accountID)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve account")
+	}
+
+	// Decode to raw messages so that fields are not lost or reformatted.
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return errors.Wrap(err, "failed to decode account")
+	}
+	if _, exists := fields["name"]; !exists {
+		return errors.New("account does not contain a name")
+	}
+	fields["name"], err = json.Marshal(name)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode name")
+	}
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode account")
+	}
+
+	if err := store.StoreAccount(walletID, accountID, data); err != nil {
+		return errors.Wrap(err, "failed to store account")
+	}
+
+	return nil
+}
+
+// ReencryptStoredAccount replaces the encrypted key of an account in its
+// wallet's store with the supplied crypto.  Other fields of the account are
+// left untouched.
+func ReencryptStoredAccount(store e2wtypes.Store, walletID uuid.UUID, accountID uuid.UUID, crypto map[string]interface{}) error {
+	data, err := store.RetrieveAccount(walletID, accountID)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve account")
+	}
+
+	// Decode to raw messages so that fields are not lost or reformatted.
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return errors.Wrap(err, "failed to decode account")
+	}
+	if _, exists := fields["crypto"]; !exists {
+		return errors.New("account does not contain crypto")
+	}
+	fields["crypto"], err = json.Marshal(crypto)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode crypto")
+	}
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode account")
+	}
+
+	if err := replaceStoredAccount(store, walletID, accountID, data); err != nil {
+		return errors.Wrap(err, "failed to store account")
+	}
+
+	return nil
+}
+
+// replaceStoredAccount replaces an account in its wallet's store.  Filesystem
+// stores write account files in place, so the account is written under a
+// temporary ID and renamed over the original; this ensures that an interrupted
+// replacement leaves either the old or the new account, rather than a partial
+// file.  Other stores replace accounts atomically themselves.
+func replaceStoredAccount(store e2wtypes.Store, walletID uuid.UUID, accountID uuid.UUID, data []byte) error {
+	locationProvider, isProvider := store.(e2wtypes.StoreLocationProvider)
+	if store.Name() != "filesystem" || !isProvider {
+		return store.StoreAccount(walletID, accountID, data)
+	}
+
+	// Write through the store so that any store encryption is applied.
+	tmpID := uuid.New()
+	if err := store.StoreAccount(walletID, tmpID, data); err != nil {
+		return err
+	}
+	walletDir := filepath.Join(locationProvider.Location(), walletID.String())
+	if err := os.Rename(filepath.Join(walletDir, tmpID.String()), filepath.Join(walletDir, accountID.String())); err != nil {
+		if err := os.Remove(filepath.Join(walletDir, tmpID.String())); err != nil {
+			Log.Trace().Err(err).Msg("Failed to remove temporary account file")
+		}
+		return errors.Wrap(err, "failed to replace account file")
+	}
+
+	return nil
+}
+
+// RebuildAccountsIndex rebuilds a wallet's accounts index from the accounts
+// held in its store.
+func RebuildAccountsIndex(store e2wtypes.Store, walletID uuid.UUID) error {
+	entries := make([]*storedIndexEntry, 0)
+	for data := range store.RetrieveAccounts(walletID) {
+		entry := &storedIndexEntry{}
+		if err := json.Unmarshal(data, entry); err != nil {
+			return errors.Wrap(err, "failed to decode account")
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode index")
+	}
+	if err := store.StoreAccountsIndex(walletID, data); err != nil {
+		return errors.Wrap(err, "failed to store index")
+	}
+
+	return nil
+}
+
+// AccountCollisions checks accounts that are to be added to a wallet, keyed by
+// the name they will have in the wallet, against the accounts already present.
+// It returns a description of each account whose name or public key is already
+// present.
+func AccountCollisions(ctx context.Context,
+	destination e2wtypes.Wallet,
+	accounts map[string]e2wtypes.Account,
+) (
+	[]string,
+	error,
+) {
+	names := make(map[string]bool)
+	pubKeys := make(map[string]string)
+	for account := range destination.Accounts(ctx) {
+		names[account.Name()] = true
+		pubKey, err := BestPublicKey(account)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain public key for account %s", account.Name()))
+		}
+		pubKeys[fmt.Sprintf("%#x", pubKey.Marshal())] = account.Name()
+	}
+
+	targetNames := make([]string, 0, len(accounts))
+	for name := range accounts {
+		targetNames = append(targetNames, name)
+	}
+	sort.Strings(targetNames)
+
+	collisions := make([]string, 0)
+	for _, name := range targetNames {
+		pubKey, err := BestPublicKey(accounts[name])
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain public key for account %s", accounts[name].Name()))
+		}
+		key := fmt.Sprintf("%#x", pubKey.Marshal())
+		switch {
+		case names[name]:
+			collisions = append(collisions, fmt.Sprintf("%s (name already in use)", name))
+		case pubKeys[key] != "":
+			collisions = append(collisions, fmt.Sprintf("%s (public key already present as %s)", name, pubKeys[key]))
+		default:
+			// Also catch collisions between the accounts being added.
+			pubKeys[key] = name
+		}
+	}
+
+	return collisions, nil
+}
+
+// MoveAccount moves an account to another wallet, encrypting it with the
+// supplied passphrase.  The account must be unlocked, as must the destination
+// wallet if it is lockable.
+func MoveAccount(ctx context.Context,
+	source e2wtypes.Wallet,
+	account e2wtypes.Account,
+	destination e2wtypes.Wallet,
+	name string,
+	passphrase []byte,
+) (
+	e2wtypes.Account,
+	error,
+) {
+	sourceStore, err := WalletStore(source)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckAccountRemovable(sourceStore); err != nil {
+		return nil, err
+	}
+
+	privateKeyProvider, isProvider := account.(e2wtypes.AccountPrivateKeyProvider)
+	if !isProvider {
+		return nil, errors.New("account does not provide its private key")
+	}
+	key, err := privateKeyProvider.PrivateKey(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain private key")
+	}
+
+	var moved e2wtypes.Account
+	if distributedAccount, isDistributed := account.(e2wtypes.DistributedAccount); isDistributed {
+		importer, isImporter := destination.(e2wtypes.WalletDistributedAccountImporter)
+		if !isImporter {
+			return nil, fmt.Errorf("%s wallets do not support importing distributed accounts", destination.Type())
+		}
+		verificationVectorProvider, isProvider := account.(e2wtypes.AccountVerificationVectorProvider)
+		if !isProvider {
+			return nil, errors.New("account does not provide its verification vector")
+		}
+		verificationVector := make([][]byte, 0, len(verificationVectorProvider.VerificationVector()))
+		for _, pubKey := range verificationVectorProvider.VerificationVector() {
+			verificationVector = append(verificationVector, pubKey.Marshal())
+		}
+		moved, err = importer.ImportDistributedAccount(ctx,
+			name,
+			key.Marshal(),
+			distributedAccount.SigningThreshold(),
+			verificationVector,
+			distributedAccount.Participants(),
+			passphrase,
+		)
+	} else {
+		importer, isImporter := destination.(e2wtypes.WalletAccountImporter)
+		if !isImporter {
+			return nil, fmt.Errorf("%s wallets do not support importing accounts", destination.Type())
+		}
+		moved, err = importer.ImportAccount(ctx, name, key.Marshal(), passphrase)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to add account to destination wallet")
+	}
+
+	if err := RemoveStoredAccount(sourceStore, source.ID(), account.ID()); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("account added to %s but failed to remove it from %s", destination.Name(), source.Name()))
+	}
+	if err := RebuildAccountsIndex(sourceStore, source.ID()); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to rebuild index for %s", source.Name()))
+	}
+
+	return moved, nil
+}
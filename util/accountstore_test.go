@@ -0,0 +1,152 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/archivestore"
+	"github.com/wealdtech/ethdo/util"
+	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+)
+
+func TestCheckAccountName(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountName string
+		err         string
+	}{
+		{
+			name: "Empty",
+			err:  "account name is required",
+		},
+		{
+			name:        "Underscore",
+			accountName: "_Account",
+			err:         "account name cannot start with '_'",
+		},
+		{
+			name:        "Slash",
+			accountName: "Wallet/Account",
+			err:         "account name cannot contain '/'",
+		},
+		{
+			name:        "Good",
+			accountName: "Account 1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := util.CheckAccountName(test.accountName)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRenameStoredAccount(t *testing.T) {
+	store := scratch.New()
+	walletID := uuid.New()
+	accountID := uuid.New()
+	require.NoError(t, store.StoreWallet(walletID, "Test wallet", []byte(`{}`)))
+	require.NoError(t, store.StoreAccount(walletID, accountID, []byte(`{"uuid":"`+accountID.String()+`","name":"Old","crypto":{"kdf":"scrypt"},"version":4}`)))
+
+	require.EqualError(t, util.RenameStoredAccount(store, walletID, uuid.New(), "New"), "failed to retrieve account: account not found")
+	require.NoError(t, util.RenameStoredAccount(store, walletID, accountID, "New"))
+
+	data, err := store.RetrieveAccount(walletID, accountID)
+	require.NoError(t, err)
+	fields := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(data, &fields))
+	require.Equal(t, "New", fields["name"])
+	require.Equal(t, map[string]interface{}{"kdf": "scrypt"}, fields["crypto"])
+	require.Equal(t, float64(4), fields["version"])
+
+	require.NoError(t, util.RebuildAccountsIndex(store, walletID))
+	data, err = store.RetrieveAccountsIndex(walletID)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"uuid":"`+accountID.String()+`","name":"New"}]`, string(data))
+}
+
+func TestReencryptStoredAccount(t *testing.T) {
+	walletID := uuid.New()
+	accountID := uuid.New()
+	crypto := map[string]interface{}{"kdf": "pbkdf2"}
+
+	store := scratch.New()
+	require.NoError(t, store.StoreWallet(walletID, "Test wallet", []byte(`{}`)))
+	require.NoError(t, store.StoreAccount(walletID, accountID, []byte(`{"uuid":"`+accountID.String()+`","name":"Account"}`)))
+	require.EqualError(t, util.ReencryptStoredAccount(store, walletID, uuid.New(), crypto), "failed to retrieve account: account not found")
+	require.EqualError(t, util.ReencryptStoredAccount(store, walletID, accountID, crypto), "account does not contain crypto")
+
+	base := t.TempDir()
+	fsStore := filesystem.New(filesystem.WithLocation(base))
+	require.NoError(t, fsStore.StoreWallet(walletID, "Test wallet", []byte(`{"uuid":"`+walletID.String()+`","name":"Test wallet"}`)))
+	require.NoError(t, fsStore.StoreAccount(walletID, accountID, []byte(`{"uuid":"`+accountID.String()+`","name":"Account","crypto":{"kdf":"scrypt"},"version":4}`)))
+	require.NoError(t, util.ReencryptStoredAccount(fsStore, walletID, accountID, crypto))
+
+	data, err := fsStore.RetrieveAccount(walletID, accountID)
+	require.NoError(t, err)
+	fields := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(data, &fields))
+	require.Equal(t, "Account", fields["name"])
+	require.Equal(t, crypto, fields["crypto"])
+	require.Equal(t, float64(4), fields["version"])
+
+	// The temporary file used for the replacement is not left behind.
+	entries, err := os.ReadDir(filepath.Join(base, walletID.String()))
+	require.NoError(t, err)
+	for _, entry := range entries {
+		if _, err := uuid.Parse(entry.Name()); err == nil && entry.Name() != walletID.String() {
+			require.Equal(t, accountID.String(), entry.Name())
+		}
+	}
+}
+
+func TestRemoveStoredAccount(t *testing.T) {
+	walletID := uuid.New()
+	accountID := uuid.New()
+
+	// Scratch stores cannot remove accounts.
+	require.EqualError(t, util.RemoveStoredAccount(scratch.New(), walletID, accountID), "cannot remove accounts from scratch store automatically")
+
+	base := t.TempDir()
+	fsStore := filesystem.New(filesystem.WithLocation(base))
+	// The filesystem store requires a wallet with its ID, and data of at least 16 bytes.
+	require.NoError(t, fsStore.StoreWallet(walletID, "Test wallet", []byte(`{"uuid":"`+walletID.String()+`","name":"Test wallet"}`)))
+	require.NoError(t, fsStore.StoreAccount(walletID, accountID, []byte(`{"uuid":"`+accountID.String()+`"}`)))
+	require.NoError(t, util.RemoveStoredAccount(fsStore, walletID, accountID))
+	_, err := os.Stat(filepath.Join(base, walletID.String(), accountID.String()))
+	require.True(t, os.IsNotExist(err))
+
+	archiveStore, err := archivestore.New(archivestore.WithPath(filepath.Join(t.TempDir(), "wallets.archive")),
+		archivestore.WithPassphrase([]byte("secret")),
+	)
+	require.NoError(t, err)
+	require.NoError(t, archiveStore.StoreWallet(walletID, "Test wallet", []byte(`{}`)))
+	require.NoError(t, archiveStore.StoreAccount(walletID, accountID, []byte(`{}`)))
+	require.NoError(t, util.RemoveStoredAccount(archiveStore, walletID, accountID))
+	_, err = archiveStore.RetrieveAccount(walletID, accountID)
+	require.EqualError(t, err, "account not found")
+}
@@ -14,9 +14,24 @@
 package util
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
+// RemoteBaseDir is a base directory for wallets held in an object store.
+type RemoteBaseDir struct {
+	// Scheme is the scheme of the object store: "s3" or "gs".
+	Scheme string
+	// Bucket is the bucket holding the wallets.
+	Bucket string
+	// Path is the path to the wallets within the bucket.
+	Path string
+}
+
 // GetBaseDir fetches the base directory for wallets.
 func GetBaseDir() string {
 	baseDir := viper.GetString("base-dir")
@@ -26,3 +41,31 @@ func GetBaseDir() string {
 	}
 	return baseDir
 }
+
+// GetRemoteBaseDir fetches the base directory for wallets if it refers to an
+// object store, for example "s3://bucket/path".  It returns nil if the base
+// directory is local.
+func GetRemoteBaseDir() (*RemoteBaseDir, error) {
+	baseDir := GetBaseDir()
+	if !strings.Contains(baseDir, "://") {
+		return nil, nil
+	}
+
+	location, err := url.Parse(baseDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid base directory")
+	}
+	scheme := strings.ToLower(location.Scheme)
+	if scheme != "s3" && scheme != "gs" {
+		return nil, fmt.Errorf("unsupported base directory scheme %s", location.Scheme)
+	}
+	if location.Host == "" {
+		return nil, errors.New("base directory does not specify a bucket")
+	}
+
+	return &RemoteBaseDir{
+		Scheme: scheme,
+		Bucket: location.Host,
+		Path:   strings.Trim(location.Path, "/"),
+	}, nil
+}
@@ -65,3 +65,61 @@ func TestBaseDir(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoteBaseDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseDir  string
+		expected *util.RemoteBaseDir
+		err      string
+	}{
+		{
+			name: "None",
+		},
+		{
+			name:    "Local",
+			baseDir: "/tmp/wallets",
+		},
+		{
+			name:    "S3",
+			baseDir: "s3://mybucket/path/to/wallets/",
+			expected: &util.RemoteBaseDir{
+				Scheme: "s3",
+				Bucket: "mybucket",
+				Path:   "path/to/wallets",
+			},
+		},
+		{
+			name:    "GCSNoPath",
+			baseDir: "GS://mybucket",
+			expected: &util.RemoteBaseDir{
+				Scheme: "gs",
+				Bucket: "mybucket",
+			},
+		},
+		{
+			name:    "UnsupportedScheme",
+			baseDir: "ftp://mybucket/path",
+			err:     "unsupported base directory scheme ftp",
+		},
+		{
+			name:    "BucketMissing",
+			baseDir: "s3:///path",
+			err:     "base directory does not specify a bucket",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("base-dir", test.baseDir)
+			res, err := util.GetRemoteBaseDir()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
@@ -49,7 +49,10 @@ func (b *BeaconBlockHeaderCache) Fetch(ctx context.Context,
 	error,
 ) {
 	entry, exists := b.entries[slot]
-	if !exists {
+	if exists {
+		RecordCacheHit("beacon block headers")
+	} else {
+		RecordCacheMiss("beacon block headers")
 		header, err := b.beaconBlockHeadersProvider.BeaconBlockHeader(ctx, fmt.Sprintf("%d", slot))
 		if err != nil {
 			return nil, err
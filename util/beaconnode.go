@@ -39,6 +39,9 @@ func ConnectToBeaconNode(ctx context.Context, address string, timeout time.Durat
 		return nil, errors.New("no timeout specified")
 	}
 
+	StartTelemetryPhase("connect")
+	defer StartTelemetryPhase("process")
+
 	if address != "" {
 		// We have an explicit address; use it.
 		return connectToBeaconNode(ctx, address, timeout, allowInsecure)
@@ -52,7 +55,7 @@ func ConnectToBeaconNode(ctx context.Context, address string, timeout time.Durat
 		}
 	}
 
-	return nil, errors.New("failed to connect to any beacon node")
+	return nil, NewConnectionError(errors.New("failed to connect to any beacon node"))
 }
 
 func connectToBeaconNode(ctx context.Context, address string, timeout time.Duration, allowInsecure bool) (eth2client.Service, error) {
@@ -73,13 +76,42 @@ func connectToBeaconNode(ctx context.Context, address string, timeout time.Durat
 			fmt.Println("Connections to remote beacon nodes should be secure.  This warning can be silenced with --allow-insecure-connections")
 		}
 	}
-	eth2Client, err := http.New(ctx,
+	clientAddress := address
+	var proxy *beaconNodeProxy
+	if BeaconNodeHeadersConfigured() {
+		// go-eth2-client cannot add headers per request, so its requests are
+		// passed through a local proxy that does.
+		var err error
+		proxy, err = startBeaconNodeProxy(ctx, address, timeout)
+		if err != nil {
+			return nil, NewConnectionError(errors.Wrap(err, "failed to start beacon node proxy"))
+		}
+		clientAddress = proxy.address
+	}
+	params := []http.Parameter{
 		http.WithLogLevel(zerolog.Disabled),
-		http.WithAddress(address),
+		http.WithAddress(clientAddress),
 		http.WithTimeout(timeout),
-	)
+	}
+	var eth2Client eth2client.Service
+	var err error
+	for attempt := 0; ; attempt++ {
+		eth2Client, err = http.New(ctx, params...)
+		if err == nil || attempt == beaconNodeRetries || !retryable(ctx, nil, err) {
+			break
+		}
+		// If the context is done the next attempt fails immediately and is
+		// not retried.
+		select {
+		case <-ctx.Done():
+		case <-time.After(retryDelay(beaconNodeRetryBackoff, attempt)):
+		}
+	}
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to connect to beacon node")
+		if proxy != nil {
+			proxy.stop()
+		}
+		return nil, NewConnectionError(errors.Wrap(err, "failed to connect to beacon node"))
 	}
 
 	return eth2Client, nil
@@ -0,0 +1,182 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// beaconNodeClients are the HTTP clients for beacon node API calls, by timeout.
+var (
+	beaconNodeClients   = make(map[time.Duration]*http.Client)
+	beaconNodeClientsMu sync.Mutex
+)
+
+// beaconNodeClient returns an HTTP client for beacon node API calls that are
+// not available through go-eth2-client.  The client applies the timeout and
+// the configured headers, retries and telemetry.
+func beaconNodeClient(timeout time.Duration) *http.Client {
+	beaconNodeClientsMu.Lock()
+	defer beaconNodeClientsMu.Unlock()
+
+	if client, exists := beaconNodeClients[timeout]; exists {
+		return client
+	}
+
+	transport := beaconNodeTransport(timeout)
+	if transport == nil {
+		transport = baseTransport(timeout)
+	}
+	clientTimeout := timeout
+	if beaconNodeRetries > 0 {
+		// Each attempt is subject to the timeout, so allow time for all of them.
+		clientTimeout = retryTimeout(timeout)
+	}
+	client := &http.Client{
+		Timeout:   clientTimeout,
+		Transport: transport,
+	}
+	beaconNodeClients[timeout] = client
+
+	return client
+}
+
+// beaconNodeTransport returns the transport for beacon node API calls, or nil
+// if the default transport is sufficient.  The transport adds headers, retries
+// calls that fail with a transient error and records telemetry, as configured.
+func beaconNodeTransport(timeout time.Duration) http.RoundTripper {
+	if beaconNodeHeaders == nil && beaconNodeJWTSecret == nil && beaconNodeRetries == 0 && !TelemetryEnabled() {
+		return nil
+	}
+
+	var transport http.RoundTripper = baseTransport(timeout)
+	if beaconNodeHeaders != nil || beaconNodeJWTSecret != nil {
+		// Headers are added nearest the beacon node, so that each retry
+		// obtains a fresh token.
+		transport = &headersTransport{
+			next:      transport,
+			headers:   beaconNodeHeaders,
+			jwtSecret: beaconNodeJWTSecret,
+		}
+	}
+	if beaconNodeRetries > 0 {
+		transport = &retryTransport{
+			next:    transport,
+			retries: beaconNodeRetries,
+			backoff: beaconNodeRetryBackoff,
+			timeout: timeout,
+		}
+	}
+	if TelemetryEnabled() {
+		transport = &telemetryTransport{
+			next: transport,
+		}
+	}
+
+	return transport
+}
+
+// baseTransport returns a transport with the same settings as that used
+// by the go-eth2-client HTTP service.
+func baseTransport(timeout time.Duration) *http.Transport {
+	return &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   timeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:        64,
+		MaxConnsPerHost:     64,
+		MaxIdleConnsPerHost: 64,
+		IdleConnTimeout:     600 * time.Second,
+	}
+}
+
+// resetBeaconNodeClients removes existing beacon node clients, so that
+// changes to their configuration take effect.
+func resetBeaconNodeClients() {
+	beaconNodeClientsMu.Lock()
+	beaconNodeClients = make(map[time.Duration]*http.Client)
+	beaconNodeClientsMu.Unlock()
+}
+
+// FetchBeaconNodeJSON fetches JSON from an endpoint of the beacon node at the
+// given address, for endpoints that are not available through the client.
+// It returns false if the item is not found.  If body is present the request
+// is a POST.
+func FetchBeaconNodeJSON(ctx context.Context,
+	address string,
+	timeout time.Duration,
+	path string,
+	body interface{},
+	res interface{},
+) (
+	bool,
+	error,
+) {
+	if timeout == 0 {
+		return false, errors.New("no timeout specified")
+	}
+	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+		address = fmt.Sprintf("http://%s", address)
+	}
+
+	method := http.MethodGet
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to create request body")
+		}
+		method = http.MethodPost
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", strings.TrimSuffix(address, "/"), path), reqBody)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := beaconNodeClient(timeout).Do(req)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("request for %s returned status %d", path, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return false, errors.Wrap(err, "failed to parse response")
+	}
+
+	return true, nil
+}
@@ -0,0 +1,128 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchBeaconNodeJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/item":
+			body, _ := io.ReadAll(r.Body)
+			if len(body) > 0 {
+				_, _ = w.Write([]byte(`{"data":` + string(body) + `}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":"item"}`))
+		case "/slow":
+			time.Sleep(500 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"data":"slow"}`))
+		case "/bad":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		address string
+		timeout time.Duration
+		path    string
+		body    interface{}
+		found   bool
+		data    interface{}
+		err     string
+	}{
+		{
+			name:    "TimeoutMissing",
+			address: server.URL,
+			path:    "/item",
+			err:     "no timeout specified",
+		},
+		{
+			name:    "Get",
+			address: server.URL,
+			timeout: time.Second,
+			path:    "/item",
+			found:   true,
+			data:    "item",
+		},
+		{
+			name:    "NoScheme",
+			address: strings.TrimPrefix(server.URL, "http://"),
+			timeout: time.Second,
+			path:    "/item",
+			found:   true,
+			data:    "item",
+		},
+		{
+			name:    "Post",
+			address: server.URL,
+			timeout: time.Second,
+			path:    "/item",
+			body:    []string{"1"},
+			found:   true,
+			data:    []interface{}{"1"},
+		},
+		{
+			name:    "NotFound",
+			address: server.URL,
+			timeout: time.Second,
+			path:    "/missing",
+		},
+		{
+			name:    "BadRequest",
+			address: server.URL,
+			timeout: time.Second,
+			path:    "/bad",
+			err:     "request for /bad returned status 400",
+		},
+		{
+			name:    "Timeout",
+			address: server.URL,
+			timeout: 50 * time.Millisecond,
+			path:    "/slow",
+			err:     "failed to send request",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := &struct {
+				Data interface{} `json:"data"`
+			}{}
+			found, err := FetchBeaconNodeJSON(context.Background(), test.address, test.timeout, test.path, test.body, res)
+			if test.err != "" {
+				require.Error(t, err)
+				require.True(t, strings.HasPrefix(err.Error(), test.err))
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.found, found)
+				require.Equal(t, test.data, res.Data)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// beaconNodeProxy passes requests from go-eth2-client to a beacon node,
+// adding the configured headers to each request.  go-eth2-client sends the
+// same headers with every request and does not send them with event streams,
+// so without the proxy a JWT would not be refreshed and events would not be
+// authenticated.
+type beaconNodeProxy struct {
+	address  string
+	server   *http.Server
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// startBeaconNodeProxy starts a proxy on the loopback interface for the beacon
+// node at the given address.  The proxy runs until it is stopped or the
+// context is done.
+func startBeaconNodeProxy(ctx context.Context, address string, timeout time.Duration) (*beaconNodeProxy, error) {
+	target, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse connection")
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to listen")
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	director := proxy.Director
+	proxy.Director = func(req *http.Request) {
+		director(req)
+		req.Host = target.Host
+		// Do not reveal that the request has been proxied.
+		req.Header["X-Forwarded-For"] = nil
+	}
+	proxy.Transport = &headersTransport{
+		next:      baseTransport(timeout),
+		headers:   beaconNodeHeaders,
+		jwtSecret: beaconNodeJWTSecret,
+	}
+	// Event streams are passed on as they arrive.
+	proxy.FlushInterval = -1
+	proxy.ErrorHandler = func(w http.ResponseWriter, _ *http.Request, err error) {
+		status := http.StatusBadGateway
+		if transientError(err) {
+			status = http.StatusGatewayTimeout
+		}
+		http.Error(w, err.Error(), status)
+	}
+
+	p := &beaconNodeProxy{
+		address: fmt.Sprintf("http://%s", listener.Addr().String()),
+		server: &http.Server{
+			Handler:           proxy,
+			ReadHeaderTimeout: timeout,
+		},
+		stopped: make(chan struct{}),
+	}
+	go func() {
+		_ = p.server.Serve(listener)
+	}()
+	go func() {
+		select {
+		case <-ctx.Done():
+			p.stop()
+		case <-p.stopped:
+		}
+	}()
+
+	return p, nil
+}
+
+// stop stops the proxy.
+func (p *beaconNodeProxy) stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopped)
+		_ = p.server.Close()
+	})
+}
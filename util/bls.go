@@ -14,9 +14,12 @@
 package util
 
 import (
+	"bytes"
 	"encoding/binary"
 
 	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 // BLSID turns a uint64 in to a BLS identifier.
@@ -29,3 +32,37 @@ func BLSID(id uint64) *bls.ID {
 	}
 	return &res
 }
+
+// ShareID provides the ID of the participant whose key share is held by a
+// distributed account, found by matching the public key of the account
+// against the share public keys generated from its verification vector.
+func ShareID(account e2wtypes.Account) (uint64, error) {
+	participantsProvider, isProvider := account.(e2wtypes.AccountParticipantsProvider)
+	if !isProvider {
+		return 0, errors.New("account is not a distributed account")
+	}
+	vectorProvider, isProvider := account.(e2wtypes.AccountVerificationVectorProvider)
+	if !isProvider {
+		return 0, errors.New("account does not provide a verification vector")
+	}
+
+	vector := make([]bls.PublicKey, len(vectorProvider.VerificationVector()))
+	for i, key := range vectorProvider.VerificationVector() {
+		if err := vector[i].Deserialize(key.Marshal()); err != nil {
+			return 0, errors.Wrap(err, "invalid verification vector")
+		}
+	}
+
+	pubKey := account.PublicKey().Marshal()
+	for id := range participantsProvider.Participants() {
+		var sharePubKey bls.PublicKey
+		if err := sharePubKey.Set(vector, BLSID(id)); err != nil {
+			return 0, errors.Wrap(err, "failed to generate share public key")
+		}
+		if bytes.Equal(sharePubKey.Serialize(), pubKey) {
+			return id, nil
+		}
+	}
+
+	return 0, errors.New("account public key does not match any participant")
+}
@@ -0,0 +1,127 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Checkpoint holds the state of a long-running command in a file, allowing the
+// command to resume where it left off if it is interrupted.  The state is held
+// in named sections, so that separate stages of a command can be saved
+// independently.  A nil checkpoint is valid, and neither loads nor saves state.
+type Checkpoint struct {
+	path  string
+	state *checkpointState
+}
+
+type checkpointState struct {
+	Parameters string                     `json:"parameters"`
+	Sections   map[string]json.RawMessage `json:"sections"`
+}
+
+// NewCheckpoint creates a checkpoint stored in the file at the given path.
+// The parameters identify the input to the command; a checkpoint can only be
+// resumed by a command with the same parameters.  If resume is true and the
+// file exists its state is loaded, otherwise the checkpoint starts empty and
+// any existing file is replaced when the checkpoint is first saved.
+func NewCheckpoint(path string, parameters string, resume bool) (*Checkpoint, error) {
+	c := &Checkpoint{
+		path: path,
+		state: &checkpointState{
+			Parameters: parameters,
+			Sections:   make(map[string]json.RawMessage),
+		},
+	}
+	if !resume {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// Nothing to resume.
+			return c, nil
+		}
+		return nil, errors.Wrap(err, "failed to read checkpoint")
+	}
+	state := &checkpointState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, errors.Wrap(err, "failed to parse checkpoint")
+	}
+	if state.Parameters != parameters {
+		return nil, fmt.Errorf("checkpoint %s was created with different parameters; remove it to start afresh", path)
+	}
+	if state.Sections != nil {
+		c.state.Sections = state.Sections
+	}
+
+	return c, nil
+}
+
+// Load loads the named section of the checkpoint in to data, returning false
+// if the section is not present.
+func (c *Checkpoint) Load(section string, data interface{}) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+	raw, exists := c.state.Sections[section]
+	if !exists {
+		return false, nil
+	}
+	if err := json.Unmarshal(raw, data); err != nil {
+		return false, errors.Wrap(err, fmt.Sprintf("failed to parse checkpoint section %s", section))
+	}
+
+	return true, nil
+}
+
+// Save saves data as the named section of the checkpoint, and writes the
+// checkpoint to its file.
+func (c *Checkpoint) Save(section string, data interface{}) error {
+	if c == nil {
+		return nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to encode checkpoint section %s", section))
+	}
+	c.state.Sections[section] = raw
+
+	encoded, err := json.Marshal(c.state)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode checkpoint")
+	}
+	if err := WriteFileAtomically(c.path, encoded); err != nil {
+		return errors.Wrap(err, "failed to write checkpoint")
+	}
+
+	return nil
+}
+
+// Remove removes the checkpoint file, once the command has completed.
+func (c *Checkpoint) Remove() error {
+	if c == nil {
+		return nil
+	}
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to remove checkpoint")
+	}
+
+	return nil
+}
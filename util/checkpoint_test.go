@@ -0,0 +1,90 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestCheckpoint(t *testing.T) {
+	type state struct {
+		Next  int      `json:"next"`
+		Items []string `json:"items"`
+	}
+
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	// A nil checkpoint does nothing.
+	var nilCheckpoint *util.Checkpoint
+	found, err := nilCheckpoint.Load("scan", &state{})
+	require.NoError(t, err)
+	require.False(t, found)
+	require.NoError(t, nilCheckpoint.Save("scan", &state{}))
+	require.NoError(t, nilCheckpoint.Remove())
+
+	// Resuming without a checkpoint file starts afresh.
+	checkpoint, err := util.NewCheckpoint(path, "a,b", true)
+	require.NoError(t, err)
+	found, err = checkpoint.Load("scan", &state{})
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, checkpoint.Save("scan", &state{Next: 5, Items: []string{"x", "y"}}))
+	require.NoError(t, checkpoint.Save("other", 12))
+	require.FileExists(t, path)
+
+	// Resume with the same parameters.
+	resumed, err := util.NewCheckpoint(path, "a,b", true)
+	require.NoError(t, err)
+	loaded := &state{}
+	found, err = resumed.Load("scan", loaded)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, &state{Next: 5, Items: []string{"x", "y"}}, loaded)
+	var other int
+	found, err = resumed.Load("other", &other)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, 12, other)
+
+	// Mismatched types fail to load.
+	_, err = resumed.Load("other", loaded)
+	require.EqualError(t, err, "failed to parse checkpoint section other: json: cannot unmarshal number into Go value of type util_test.state")
+
+	// Resume with different parameters.
+	_, err = util.NewCheckpoint(path, "a,c", true)
+	require.EqualError(t, err, "checkpoint "+path+" was created with different parameters; remove it to start afresh")
+
+	// Not resuming ignores the existing checkpoint.
+	fresh, err := util.NewCheckpoint(path, "a,c", false)
+	require.NoError(t, err)
+	found, err = fresh.Load("scan", loaded)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Corrupt checkpoint.
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = util.NewCheckpoint(path, "a,b", true)
+	require.EqualError(t, err, "failed to parse checkpoint: unexpected end of JSON input")
+
+	require.NoError(t, fresh.Remove())
+	require.NoFileExists(t, path)
+	// Removing a missing checkpoint is not an error.
+	require.NoError(t, fresh.Remove())
+}
@@ -0,0 +1,129 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// beaconNodeHeaders are additional headers sent with each beacon node API call.
+var beaconNodeHeaders http.Header
+
+// beaconNodeJWTSecret is the secret used to create a JSON web token that is
+// sent with each beacon node API call.
+var beaconNodeJWTSecret []byte
+
+// SetBeaconNodeHeaders sets the additional headers, each in the form
+// "name: value", and the JWT secret used for beacon node API calls.  The JWT
+// secret can be supplied in hex or as the name of a file containing it.
+func SetBeaconNodeHeaders(headers []string, jwtSecret string) error {
+	parsedHeaders := make(http.Header)
+	for _, header := range headers {
+		parts := strings.SplitN(header, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return fmt.Errorf("invalid connection header %q; must be in the form name: value", header)
+		}
+		parsedHeaders.Add(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+	}
+
+	var secret []byte
+	if jwtSecret != "" {
+		if parsedHeaders.Get("Authorization") != "" {
+			return errors.New("only one of jwt-secret and an Authorization connection header is allowed")
+		}
+		var err error
+		secret, err = parseJWTSecret(jwtSecret)
+		if err != nil {
+			return err
+		}
+	}
+
+	beaconNodeHeaders = nil
+	if len(parsedHeaders) > 0 {
+		beaconNodeHeaders = parsedHeaders
+	}
+	beaconNodeJWTSecret = secret
+	resetBeaconNodeClients()
+
+	return nil
+}
+
+// parseJWTSecret parses a JWT secret, either directly or from a file.
+func parseJWTSecret(input string) ([]byte, error) {
+	if data, err := os.ReadFile(input); err == nil {
+		input = string(data)
+	}
+	input = strings.TrimPrefix(strings.TrimSpace(input), "0x")
+	secret, err := hex.DecodeString(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid JWT secret")
+	}
+	if len(secret) == 0 {
+		return nil, errors.New("JWT secret is empty")
+	}
+
+	return secret, nil
+}
+
+// jwtToken creates a JSON web token signed with the secret, as used by the
+// engine API.  The token contains only the time at which it was issued.
+func jwtToken(secret []byte, issued time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"iat":%d}`, issued.Unix())))
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(header + "." + claims))
+
+	return fmt.Sprintf("%s.%s.%s", header, claims, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)))
+}
+
+// BeaconNodeHeadersConfigured returns true if additional headers or a JWT
+// secret have been configured for beacon node API calls.
+func BeaconNodeHeadersConfigured() bool {
+	return beaconNodeHeaders != nil || beaconNodeJWTSecret != nil
+}
+
+// headersTransport adds headers to each request.
+type headersTransport struct {
+	next      http.RoundTripper
+	headers   http.Header
+	jwtSecret []byte
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *headersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range t.headers {
+		req.Header.Del(name)
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if t.jwtSecret != nil {
+		// A new token is created for each request, as servers reject tokens
+		// whose issued time is not recent.
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwtToken(t.jwtSecret, time.Now())))
+	}
+
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,283 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// ValidatorEnrichment is external data about a validator obtained from an enrichment provider.
+type ValidatorEnrichment struct {
+	Effectiveness *float64 `json:"effectiveness,omitempty"`
+	Entity        string   `json:"entity,omitempty"`
+	Incidents     []string `json:"incidents,omitempty"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// Enricher obtains external data about validators.
+type Enricher interface {
+	// Name returns the name of the enrichment provider.
+	Name() string
+	// Enrich obtains external data about a validator.
+	Enrich(ctx context.Context, index phase0.ValidatorIndex) (*ValidatorEnrichment, error)
+}
+
+// beaconchainHosts are the beaconcha.in hosts for each network.
+var beaconchainHosts = map[string]string{
+	"Mainnet": "https://beaconcha.in",
+	"Prater":  "https://prater.beaconcha.in",
+	"Sepolia": "https://sepolia.beaconcha.in",
+	"Holesky": "https://holesky.beaconcha.in",
+}
+
+// ratedNetworks are the Rated network names for each network.
+var ratedNetworks = map[string]string{
+	"Mainnet": "mainnet",
+	"Prater":  "prater",
+	"Holesky": "holesky",
+}
+
+// NewEnrichers creates the named enrichment providers for the given network.
+// API keys are either of the form "provider=key", or a bare key used for all providers.
+// Each request to a provider is subject to the timeout.
+func NewEnrichers(names []string, apiKeys []string, network string, timeout time.Duration) ([]Enricher, error) {
+	if timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+
+	keys := make(map[string]string)
+	defaultKey := ""
+	for _, apiKey := range apiKeys {
+		parts := strings.SplitN(apiKey, "=", 2)
+		if len(parts) == 2 {
+			keys[parts[0]] = parts[1]
+		} else {
+			defaultKey = apiKey
+		}
+	}
+
+	enrichers := make([]Enricher, 0, len(names))
+	for _, name := range names {
+		key, exists := keys[name]
+		if !exists {
+			key = defaultKey
+		}
+		switch name {
+		case "beaconchain":
+			host, exists := beaconchainHosts[network]
+			if !exists {
+				return nil, fmt.Errorf("beaconchain enrichment not available for %s", network)
+			}
+			enrichers = append(enrichers, &beaconchainEnricher{
+				baseURL: host,
+				apiKey:  key,
+				timeout: timeout,
+			})
+		case "rated":
+			ratedNetwork, exists := ratedNetworks[network]
+			if !exists {
+				return nil, fmt.Errorf("rated enrichment not available for %s", network)
+			}
+			if key == "" {
+				return nil, errors.New("rated enrichment requires an API key")
+			}
+			enrichers = append(enrichers, &ratedEnricher{
+				baseURL: "https://api.rated.network",
+				apiKey:  key,
+				network: ratedNetwork,
+				timeout: timeout,
+			})
+		default:
+			return nil, fmt.Errorf("unknown enrichment provider %q", name)
+		}
+	}
+
+	return enrichers, nil
+}
+
+// EnrichValidator obtains external data about a validator from each provider, keyed by provider name.
+// A provider that fails to supply data has its error recorded rather than failing the whole enrichment.
+func EnrichValidator(ctx context.Context, enrichers []Enricher, index phase0.ValidatorIndex) map[string]*ValidatorEnrichment {
+	if len(enrichers) == 0 {
+		return nil
+	}
+
+	res := make(map[string]*ValidatorEnrichment, len(enrichers))
+	for _, enricher := range enrichers {
+		enrichment, err := enricher.Enrich(ctx, index)
+		if err != nil {
+			Log.Debug().Str("provider", enricher.Name()).Err(err).Msg("Failed to enrich validator")
+			enrichment = &ValidatorEnrichment{
+				Error: err.Error(),
+			}
+		}
+		res[enricher.Name()] = enrichment
+	}
+
+	return res
+}
+
+type beaconchainEnricher struct {
+	baseURL string
+	apiKey  string
+	timeout time.Duration
+}
+
+// Name returns the name of the enrichment provider.
+func (*beaconchainEnricher) Name() string {
+	return "beaconchain"
+}
+
+// Enrich obtains external data about a validator.
+func (e *beaconchainEnricher) Enrich(ctx context.Context, index phase0.ValidatorIndex) (*ValidatorEnrichment, error) {
+	headers := make(map[string]string)
+	if e.apiKey != "" {
+		headers["apikey"] = e.apiKey
+	}
+
+	validator := &struct {
+		Data struct {
+			Name    string `json:"name"`
+			Slashed bool   `json:"slashed"`
+		} `json:"data"`
+	}{}
+	if err := fetchEnrichment(ctx, e.timeout, fmt.Sprintf("%s/api/v1/validator/%d", e.baseURL, index), headers, validator); err != nil {
+		return nil, err
+	}
+
+	effectiveness := &struct {
+		Data []struct {
+			AttestationEffectiveness float64 `json:"attestation_effectiveness"`
+		} `json:"data"`
+	}{}
+	if err := fetchEnrichment(ctx, e.timeout, fmt.Sprintf("%s/api/v1/validator/%d/attestationeffectiveness", e.baseURL, index), headers, effectiveness); err != nil {
+		return nil, err
+	}
+
+	res := &ValidatorEnrichment{
+		Entity: validator.Data.Name,
+	}
+	if len(effectiveness.Data) > 0 {
+		res.Effectiveness = &effectiveness.Data[0].AttestationEffectiveness
+	}
+	if validator.Data.Slashed {
+		res.Incidents = append(res.Incidents, "slashed")
+	}
+
+	return res, nil
+}
+
+type ratedEnricher struct {
+	baseURL string
+	apiKey  string
+	network string
+	timeout time.Duration
+}
+
+// Name returns the name of the enrichment provider.
+func (*ratedEnricher) Name() string {
+	return "rated"
+}
+
+// Enrich obtains external data about a validator.
+func (e *ratedEnricher) Enrich(ctx context.Context, index phase0.ValidatorIndex) (*ValidatorEnrichment, error) {
+	headers := map[string]string{
+		"Authorization":   fmt.Sprintf("Bearer %s", e.apiKey),
+		"X-Rated-Network": e.network,
+	}
+
+	validator := &struct {
+		Pool json.RawMessage `json:"pool"`
+	}{}
+	if err := fetchEnrichment(ctx, e.timeout, fmt.Sprintf("%s/v0/eth/validators/%d", e.baseURL, index), headers, validator); err != nil {
+		return nil, err
+	}
+
+	effectiveness := &struct {
+		Data []struct {
+			ValidatorEffectiveness float64 `json:"validatorEffectiveness"`
+			SlashesReceived        uint64  `json:"slashesReceived"`
+			ProposerSlashed        bool    `json:"proposerSlashed"`
+		} `json:"data"`
+	}{}
+	if err := fetchEnrichment(ctx, e.timeout, fmt.Sprintf("%s/v0/eth/validators/%d/effectiveness?size=1", e.baseURL, index), headers, effectiveness); err != nil {
+		return nil, err
+	}
+
+	res := &ValidatorEnrichment{
+		Entity: ratedPool(validator.Pool),
+	}
+	if len(effectiveness.Data) > 0 {
+		res.Effectiveness = &effectiveness.Data[0].ValidatorEffectiveness
+		if effectiveness.Data[0].SlashesReceived > 0 || effectiveness.Data[0].ProposerSlashed {
+			res.Incidents = append(res.Incidents, "slashed")
+		}
+	}
+
+	return res, nil
+}
+
+// ratedPool returns the pool of a validator, which Rated supplies as either a single value or a list.
+func ratedPool(data json.RawMessage) string {
+	var pool string
+	if err := json.Unmarshal(data, &pool); err == nil {
+		return pool
+	}
+	var pools []string
+	if err := json.Unmarshal(data, &pools); err == nil {
+		sort.Strings(pools)
+		return strings.Join(pools, ", ")
+	}
+
+	return ""
+}
+
+// fetchEnrichment fetches and decodes JSON data from an enrichment provider.
+func fetchEnrichment(ctx context.Context, timeout time.Duration, url string, headers map[string]string, res interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create enrichment request")
+	}
+	req.Header.Set("Accept", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	client := &http.Client{
+		Timeout: timeout,
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain enrichment")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("enrichment request returned status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return errors.Wrap(err, "failed to parse enrichment")
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEnrichers(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		apiKeys []string
+		network string
+		timeout time.Duration
+		res     []Enricher
+		err     string
+	}{
+		{
+			name:    "TimeoutMissing",
+			network: "Mainnet",
+			err:     "no timeout specified",
+		},
+		{
+			name:    "Empty",
+			network: "Mainnet",
+			timeout: time.Second,
+			res:     []Enricher{},
+		},
+		{
+			name:    "Unknown",
+			names:   []string{"unknown"},
+			network: "Mainnet",
+			timeout: time.Second,
+			err:     `unknown enrichment provider "unknown"`,
+		},
+		{
+			name:    "BeaconchainNetworkUnknown",
+			names:   []string{"beaconchain"},
+			network: "Unknown",
+			timeout: time.Second,
+			err:     "beaconchain enrichment not available for Unknown",
+		},
+		{
+			name:    "RatedNetworkUnknown",
+			names:   []string{"rated"},
+			apiKeys: []string{"key"},
+			network: "Sepolia",
+			timeout: time.Second,
+			err:     "rated enrichment not available for Sepolia",
+		},
+		{
+			name:    "RatedKeyMissing",
+			names:   []string{"rated"},
+			apiKeys: []string{"beaconchain=key"},
+			network: "Mainnet",
+			timeout: time.Second,
+			err:     "rated enrichment requires an API key",
+		},
+		{
+			name:    "Good",
+			names:   []string{"beaconchain", "rated"},
+			apiKeys: []string{"default", "rated=ratedkey"},
+			network: "Holesky",
+			timeout: time.Second,
+			res: []Enricher{
+				&beaconchainEnricher{
+					baseURL: "https://holesky.beaconcha.in",
+					apiKey:  "default",
+					timeout: time.Second,
+				},
+				&ratedEnricher{
+					baseURL: "https://api.rated.network",
+					apiKey:  "ratedkey",
+					network: "holesky",
+					timeout: time.Second,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := NewEnrichers(test.names, test.apiKeys, test.network, test.timeout)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestEnrichValidator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/validator/1":
+			require.Equal(t, "bckey", r.Header.Get("apikey"))
+			_, _ = w.Write([]byte(`{"status":"OK","data":{"name":"Staker","slashed":true}}`))
+		case "/api/v1/validator/1/attestationeffectiveness":
+			_, _ = w.Write([]byte(`{"status":"OK","data":[{"attestation_effectiveness":97.5,"validatorindex":1}]}`))
+		case "/v0/eth/validators/1":
+			require.Equal(t, "Bearer ratedkey", r.Header.Get("Authorization"))
+			require.Equal(t, "mainnet", r.Header.Get("X-Rated-Network"))
+			_, _ = w.Write([]byte(`{"validatorIndex":1,"pool":["Pool B","Pool A"]}`))
+		case "/v0/eth/validators/1/effectiveness":
+			_, _ = w.Write([]byte(`{"data":[{"validatorEffectiveness":95.25,"slashesReceived":0}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	bcEffectiveness := 97.5
+	ratedEffectiveness := 95.25
+
+	tests := []struct {
+		name      string
+		enrichers []Enricher
+		index     phase0.ValidatorIndex
+		res       map[string]*ValidatorEnrichment
+	}{
+		{
+			name: "None",
+		},
+		{
+			name: "Good",
+			enrichers: []Enricher{
+				&beaconchainEnricher{baseURL: server.URL, apiKey: "bckey", timeout: time.Second},
+				&ratedEnricher{baseURL: server.URL, apiKey: "ratedkey", network: "mainnet", timeout: time.Second},
+			},
+			index: 1,
+			res: map[string]*ValidatorEnrichment{
+				"beaconchain": {
+					Effectiveness: &bcEffectiveness,
+					Entity:        "Staker",
+					Incidents:     []string{"slashed"},
+				},
+				"rated": {
+					Effectiveness: &ratedEffectiveness,
+					Entity:        "Pool A, Pool B",
+				},
+			},
+		},
+		{
+			name: "ProviderFails",
+			enrichers: []Enricher{
+				&beaconchainEnricher{baseURL: server.URL, apiKey: "bckey", timeout: time.Second},
+			},
+			index: 2,
+			res: map[string]*ValidatorEnrichment{
+				"beaconchain": {
+					Error: "enrichment request returned status 404",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := EnrichValidator(context.Background(), test.enrichers, test.index)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+)
+
+// Error classes, allowing the cause of a failure to be identified without
+// parsing the error message.
+const (
+	// ErrorClassGeneral is the class of errors that do not fall into any other class.
+	ErrorClassGeneral = "general"
+	// ErrorClassConnection is the class of errors connecting to a node.
+	ErrorClassConnection = "connection"
+	// ErrorClassValidation is the class of errors in the input supplied to a command.
+	ErrorClassValidation = "validation"
+	// ErrorClassSigning is the class of errors signing data.
+	ErrorClassSigning = "signing"
+	// ErrorClassBroadcast is the class of errors where a node rejected a submitted operation.
+	ErrorClassBroadcast = "broadcast"
+)
+
+// classifiedError is an error with a class.
+type classifiedError struct {
+	class string
+	err   error
+}
+
+// Error returns the message of the underlying error.
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *classifiedError) Unwrap() error {
+	return e.err
+}
+
+// NewConnectionError marks an error as a failure to connect to a node.
+func NewConnectionError(err error) error {
+	return classify(ErrorClassConnection, err)
+}
+
+// NewValidationError marks an error as a failure of the input to a command.
+func NewValidationError(err error) error {
+	return classify(ErrorClassValidation, err)
+}
+
+// NewSigningError marks an error as a failure to sign data.
+func NewSigningError(err error) error {
+	return classify(ErrorClassSigning, err)
+}
+
+// NewBroadcastError marks an error as the rejection of an operation by a node.
+func NewBroadcastError(err error) error {
+	return classify(ErrorClassBroadcast, err)
+}
+
+func classify(class string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &classifiedError{
+		class: class,
+		err:   err,
+	}
+}
+
+// ErrorClass returns the class of the error.
+// If an error has been classified more than once, for example a connection
+// failure that occurs while validating input, the innermost class is returned
+// as it is the most specific.
+func ErrorClass(err error) string {
+	class := ErrorClassGeneral
+	for err != nil {
+		var classified *classifiedError
+		if !errors.As(err, &classified) {
+			break
+		}
+		class = classified.class
+		err = classified.err
+	}
+
+	return class
+}
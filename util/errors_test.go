@@ -0,0 +1,77 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		class string
+		msg   string
+	}{
+		{
+			name:  "Nil",
+			class: "general",
+		},
+		{
+			name:  "Unclassified",
+			err:   errors.New("failed"),
+			class: "general",
+			msg:   "failed",
+		},
+		{
+			name:  "Connection",
+			err:   util.NewConnectionError(errors.New("failed")),
+			class: "connection",
+			msg:   "failed",
+		},
+		{
+			name:  "Wrapped",
+			err:   errors.Wrap(util.NewSigningError(errors.New("failed")), "outer"),
+			class: "signing",
+			msg:   "outer: failed",
+		},
+		{
+			name:  "Innermost",
+			err:   util.NewValidationError(errors.Wrap(util.NewConnectionError(errors.New("failed")), "inner")),
+			class: "connection",
+			msg:   "inner: failed",
+		},
+		{
+			name:  "Broadcast",
+			err:   util.NewBroadcastError(errors.New("rejected")),
+			class: "broadcast",
+			msg:   "rejected",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.class, util.ErrorClass(test.err))
+			if test.err != nil {
+				require.Equal(t, test.msg, test.err.Error())
+			}
+		})
+	}
+
+	require.NoError(t, util.NewConnectionError(nil))
+}
@@ -0,0 +1,157 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+// depositLogsBatchSize is the number of blocks for which to fetch deposit logs in a single request.
+const depositLogsBatchSize = 10000
+
+// depositEventTopic is the topic of the deposit contract's DepositEvent log.
+var depositEventTopic = ethutil.Keccak256([]byte("DepositEvent(bytes,bytes,bytes,bytes,bytes)"))
+
+// depositContract is the deposit contract for an execution chain.
+type depositContract struct {
+	address     string
+	deployBlock uint64
+}
+
+// depositContracts is a map of execution chain IDs to deposit contracts.
+var depositContracts = map[uint64]*depositContract{
+	1:        {address: "00000000219ab540356cbb839cbe05303d7705fa", deployBlock: 11052984},
+	5:        {address: "ff50ed3d0ec03ac01d4c79aad74928bff48a7b2b", deployBlock: 4367322},
+	17000:    {address: "4242424242424242424242424242424242424242", deployBlock: 0},
+	11155111: {address: "7f02c3e3c98b133055b8b348b2ac625669ed295d", deployBlock: 1273020},
+}
+
+// DepositContractAddress returns the address of the deposit contract for the given execution chain ID.
+func DepositContractAddress(chainID uint64) ([]byte, error) {
+	contract, exists := depositContracts[chainID]
+	if !exists {
+		return nil, fmt.Errorf("deposit contract unknown for chain ID %d", chainID)
+	}
+
+	return parseData(fmt.Sprintf("0x%s", contract.address))
+}
+
+// ExecutionDeposit is a deposit made to the deposit contract.
+type ExecutionDeposit struct {
+	BlockNumber           uint64
+	TransactionHash       []byte
+	PublicKey             []byte
+	WithdrawalCredentials []byte
+	Amount                uint64
+	Signature             []byte
+	Index                 uint64
+}
+
+// Deposits returns the deposits made to the deposit contract from the given block onwards.
+func (c *ExecutionClient) Deposits(ctx context.Context, fromBlock uint64) ([]*ExecutionDeposit, error) {
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain chain ID")
+	}
+	contract, exists := depositContracts[chainID]
+	if !exists {
+		return nil, fmt.Errorf("deposit contract unknown for chain ID %d", chainID)
+	}
+	address, err := parseData(fmt.Sprintf("0x%s", contract.address))
+	if err != nil {
+		return nil, err
+	}
+	if fromBlock < contract.deployBlock {
+		fromBlock = contract.deployBlock
+	}
+
+	latestBlock, err := c.BlockNumber(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain latest block")
+	}
+
+	deposits := make([]*ExecutionDeposit, 0)
+	for start := fromBlock; start <= latestBlock; start += depositLogsBatchSize {
+		end := start + depositLogsBatchSize - 1
+		if end > latestBlock {
+			end = latestBlock
+		}
+		logs, err := c.Logs(ctx, address, depositEventTopic, start, end)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain deposit logs for blocks %d to %d", start, end))
+		}
+		for _, log := range logs {
+			deposit, err := parseDepositEvent(log.Data)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("invalid deposit event in transaction %#x", log.TransactionHash))
+			}
+			deposit.BlockNumber = log.BlockNumber
+			deposit.TransactionHash = log.TransactionHash
+			deposits = append(deposits, deposit)
+		}
+	}
+
+	return deposits, nil
+}
+
+// parseDepositEvent parses the ABI-encoded data of a DepositEvent log.
+func parseDepositEvent(data []byte) (*ExecutionDeposit, error) {
+	fields := make([][]byte, 5)
+	lengths := []int{48, 32, 8, 96, 8}
+	for i := range fields {
+		offset, err := abiUint(data, i*32)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid offset for field %d", i))
+		}
+		length, err := abiUint(data, int(offset))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid length for field %d", i))
+		}
+		if length != uint64(lengths[i]) {
+			return nil, fmt.Errorf("incorrect length %d for field %d", length, i)
+		}
+		start := int(offset) + 32
+		if start+lengths[i] > len(data) {
+			return nil, fmt.Errorf("data too short for field %d", i)
+		}
+		fields[i] = data[start : start+lengths[i]]
+	}
+
+	return &ExecutionDeposit{
+		PublicKey:             fields[0],
+		WithdrawalCredentials: fields[1],
+		Amount:                binary.LittleEndian.Uint64(fields[2]),
+		Signature:             fields[3],
+		Index:                 binary.LittleEndian.Uint64(fields[4]),
+	}, nil
+}
+
+// abiUint reads an ABI-encoded unsigned integer that must fit in to 32 bits.
+func abiUint(data []byte, position int) (uint64, error) {
+	if position < 0 || position+32 > len(data) {
+		return 0, errors.New("data too short")
+	}
+	for _, b := range data[position : position+28] {
+		if b != 0 {
+			return 0, errors.New("value too large")
+		}
+	}
+
+	return uint64(binary.BigEndian.Uint32(data[position+28 : position+32])), nil
+}
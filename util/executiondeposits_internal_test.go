@@ -0,0 +1,112 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// abiDepositEvent creates ABI-encoded DepositEvent log data.
+func abiDepositEvent(fields ...[]byte) []byte {
+	head := make([]byte, 0)
+	tail := make([]byte, 0)
+	for _, field := range fields {
+		head = append(head, abiWord(uint64(len(fields)*32+len(tail)))...)
+		tail = append(tail, abiWord(uint64(len(field)))...)
+		padded := make([]byte, (len(field)+31)/32*32)
+		copy(padded, field)
+		tail = append(tail, padded...)
+	}
+
+	return append(head, tail...)
+}
+
+func abiWord(value uint64) []byte {
+	res := make([]byte, 32)
+	for i := 0; i < 8; i++ {
+		res[31-i] = byte(value >> (8 * i))
+	}
+	return res
+}
+
+func TestParseDepositEvent(t *testing.T) {
+	pubkey := bytes.Repeat([]byte{0x01}, 48)
+	withdrawalCredentials := bytes.Repeat([]byte{0x02}, 32)
+	amount := []byte{0x00, 0x40, 0x59, 0x73, 0x07, 0x00, 0x00, 0x00}
+	signature := bytes.Repeat([]byte{0x03}, 96)
+	index := []byte{0x05, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected *ExecutionDeposit
+		err      string
+	}{
+		{
+			name: "Empty",
+			data: []byte{},
+			err:  "invalid offset for field 0: data too short",
+		},
+		{
+			name: "PubKeyShort",
+			data: abiDepositEvent(pubkey[:47], withdrawalCredentials, amount, signature, index),
+			err:  "incorrect length 47 for field 0",
+		},
+		{
+			name: "Truncated",
+			data: abiDepositEvent(pubkey, withdrawalCredentials, amount, signature, index)[:530],
+			err:  "invalid length for field 4: data too short",
+		},
+		{
+			name: "Good",
+			data: abiDepositEvent(pubkey, withdrawalCredentials, amount, signature, index),
+			expected: &ExecutionDeposit{
+				PublicKey:             pubkey,
+				WithdrawalCredentials: withdrawalCredentials,
+				Amount:                32000000000,
+				Signature:             signature,
+				Index:                 261,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseDepositEvent(test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
+
+func TestDepositEventTopic(t *testing.T) {
+	require.Equal(t, "649bbc62d0e31342afea4e5cd82d4049e7e1ee912fc0889aa790803be39038c5", fmt.Sprintf("%x", depositEventTopic))
+}
+
+func TestDepositContractAddress(t *testing.T) {
+	address, err := DepositContractAddress(1)
+	require.NoError(t, err)
+	require.Equal(t, "00000000219ab540356cbb839cbe05303d7705fa", fmt.Sprintf("%x", address))
+
+	_, err = DepositContractAddress(12345)
+	require.EqualError(t, err, "deposit contract unknown for chain ID 12345")
+}
@@ -0,0 +1,248 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// DepositGasLimit is the gas limit suggested for a transaction to the deposit contract.
+const DepositGasLimit = 100000
+
+// RequestGasLimit is the gas limit suggested for a transaction to an EIP-7002 or EIP-7251 predeploy.
+const RequestGasLimit = 200000
+
+// WithdrawalRequestPredeployAddress is the address of the EIP-7002 withdrawal request predeploy.
+var WithdrawalRequestPredeployAddress = []byte{0x00, 0x00, 0x09, 0x61, 0xef, 0x48, 0x0e, 0xb5, 0x5e, 0x80, 0xd1, 0x9a, 0xd8, 0x35, 0x79, 0xa6, 0x4c, 0x00, 0x70, 0x02}
+
+// ConsolidationRequestPredeployAddress is the address of the EIP-7251 consolidation request predeploy.
+var ConsolidationRequestPredeployAddress = []byte{0x00, 0x00, 0xbb, 0xdd, 0xc7, 0xce, 0x48, 0x86, 0x42, 0xfb, 0x57, 0x9f, 0x8b, 0x00, 0xf3, 0xa5, 0x90, 0x00, 0x72, 0x51}
+
+// Request fee parameters, common to EIP-7002 and EIP-7251.
+const (
+	minRequestFee            = 1
+	requestFeeUpdateFraction = 17
+)
+
+// requestTargets are the target number of requests per block for each predeploy.
+var requestTargets = map[string]uint64{
+	fmt.Sprintf("%x", WithdrawalRequestPredeployAddress):    2,
+	fmt.Sprintf("%x", ConsolidationRequestPredeployAddress): 1,
+}
+
+// FeeEstimate is an estimate of the fees for an execution transaction.
+type FeeEstimate struct {
+	// BaseFeePerGas is the forecast base fee for the next block.
+	BaseFeePerGas *big.Int
+	// MaxPriorityFeePerGas is the suggested priority fee.
+	MaxPriorityFeePerGas *big.Int
+	// MaxFeePerGas is the suggested maximum fee, allowing for the base fee to
+	// double before the transaction is included.
+	MaxFeePerGas *big.Int
+	// GasLimit is the gas limit of the transaction.
+	GasLimit uint64
+	// Value is the value sent with the transaction, including any request fee.
+	Value *big.Int
+	// RequestFee is the fee for an EIP-7002 or EIP-7251 request, if applicable.
+	RequestFee *big.Int
+	// TotalCost is the maximum total cost of the transaction.
+	TotalCost *big.Int
+}
+
+type executionBlockJSON struct {
+	BaseFeePerGas string `json:"baseFeePerGas"`
+	GasUsed       string `json:"gasUsed"`
+	GasLimit      string `json:"gasLimit"`
+}
+
+// EstimateFees estimates the fees for a transaction with the given gas limit and value.
+func (c *ExecutionClient) EstimateFees(ctx context.Context, gasLimit uint64, value *big.Int) (*FeeEstimate, error) {
+	block := &executionBlockJSON{}
+	if err := c.call(ctx, "eth_getBlockByNumber", []interface{}{"latest", false}, block); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain latest block")
+	}
+	if block.BaseFeePerGas == "" {
+		return nil, errors.New("latest block does not have a base fee")
+	}
+	baseFee, err := parseBigQuantity(block.BaseFeePerGas)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid base fee")
+	}
+	gasUsed, err := parseQuantity(block.GasUsed)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid gas used")
+	}
+	blockGasLimit, err := parseQuantity(block.GasLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid gas limit")
+	}
+
+	var priorityFeeStr string
+	if err := c.call(ctx, "eth_maxPriorityFeePerGas", nil, &priorityFeeStr); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain priority fee")
+	}
+	priorityFee, err := parseBigQuantity(priorityFeeStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid priority fee")
+	}
+
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	return newFeeEstimate(nextBaseFee(baseFee, gasUsed, blockGasLimit), priorityFee, gasLimit, value), nil
+}
+
+// EstimateRequestFees estimates the fees for a transaction making one of a number of
+// requests to an EIP-7002 or EIP-7251 predeploy.  The value sent with the transaction
+// allows for the fee rising if the requests are not all included in the next block.
+func (c *ExecutionClient) EstimateRequestFees(ctx context.Context, predeploy []byte, requests uint64) (*FeeEstimate, error) {
+	target, exists := requestTargets[fmt.Sprintf("%x", predeploy)]
+	if !exists {
+		return nil, errors.New("unknown request predeploy")
+	}
+
+	// The excess number of requests is held in the first storage slot.
+	var excessStr string
+	if err := c.call(ctx, "eth_getStorageAt", []interface{}{fmt.Sprintf("%#x", predeploy), "0x0", "latest"}, &excessStr); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain excess requests")
+	}
+	excess, err := parseBigQuantity(excessStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid excess requests")
+	}
+	if !excess.IsUint64() {
+		// The excess is set to its maximum value until the predeploy is activated.
+		return nil, errors.New("predeploy is not active")
+	}
+
+	requestFee := PredictRequestFee(excess.Uint64(), 0, target)
+	value := PredictRequestFee(excess.Uint64(), requests, target)
+	if value.Cmp(requestFee) < 0 {
+		value = requestFee
+	}
+	estimate, err := c.EstimateFees(ctx, RequestGasLimit, value)
+	if err != nil {
+		return nil, err
+	}
+	estimate.RequestFee = requestFee
+
+	return estimate, nil
+}
+
+// PredictRequestFee predicts the fee for a request given the current excess of requests,
+// after the given number of requests have been included in the next block.  If no
+// requests are supplied this is the fee for the next block.
+func PredictRequestFee(excess uint64, requests uint64, target uint64) *big.Int {
+	if requests > 0 {
+		if excess+requests > target {
+			excess = excess + requests - target
+		} else {
+			excess = 0
+		}
+	}
+
+	return fakeExponential(big.NewInt(minRequestFee), new(big.Int).SetUint64(excess), big.NewInt(requestFeeUpdateFraction))
+}
+
+// newFeeEstimate creates a fee estimate from its components.
+func newFeeEstimate(baseFee *big.Int, priorityFee *big.Int, gasLimit uint64, value *big.Int) *FeeEstimate {
+	maxFee := new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), priorityFee)
+	totalCost := new(big.Int).Mul(maxFee, new(big.Int).SetUint64(gasLimit))
+	totalCost.Add(totalCost, value)
+
+	return &FeeEstimate{
+		BaseFeePerGas:        baseFee,
+		MaxPriorityFeePerGas: priorityFee,
+		MaxFeePerGas:         maxFee,
+		GasLimit:             gasLimit,
+		Value:                value,
+		TotalCost:            totalCost,
+	}
+}
+
+// Annotation returns a summary of the estimate suitable for annotating the given number of transactions.
+func (e *FeeEstimate) Annotation(transactions int) string {
+	totalCost := new(big.Int).Mul(e.TotalCost, big.NewInt(int64(transactions)))
+
+	res := fmt.Sprintf("Suggested maxFeePerGas: %s\nSuggested maxPriorityFeePerGas: %s\nGas limit: %d\n",
+		string2eth.WeiToGWeiString(e.MaxFeePerGas),
+		string2eth.WeiToGWeiString(e.MaxPriorityFeePerGas),
+		e.GasLimit,
+	)
+	if e.RequestFee != nil {
+		res += fmt.Sprintf("Request fee: %s (send %s to allow for increases)\n", string2eth.WeiToString(e.RequestFee, true), string2eth.WeiToString(e.Value, true))
+	}
+	if transactions == 1 {
+		res += fmt.Sprintf("Maximum total cost: %s", string2eth.WeiToString(totalCost, true))
+	} else {
+		res += fmt.Sprintf("Maximum total cost of %d transactions: %s", transactions, string2eth.WeiToString(totalCost, true))
+	}
+
+	return res
+}
+
+// nextBaseFee forecasts the base fee of the next block as per EIP-1559.
+func nextBaseFee(baseFee *big.Int, gasUsed uint64, gasLimit uint64) *big.Int {
+	gasTarget := gasLimit / 2
+	if gasTarget == 0 || gasUsed == gasTarget {
+		return new(big.Int).Set(baseFee)
+	}
+
+	if gasUsed > gasTarget {
+		delta := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(gasUsed-gasTarget))
+		delta.Div(delta, new(big.Int).SetUint64(gasTarget))
+		delta.Div(delta, big.NewInt(8))
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return delta.Add(delta, baseFee)
+	}
+
+	delta := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(gasTarget-gasUsed))
+	delta.Div(delta, new(big.Int).SetUint64(gasTarget))
+	delta.Div(delta, big.NewInt(8))
+	return delta.Sub(baseFee, delta)
+}
+
+// fakeExponential approximates factor * e ** (numerator / denominator) as per EIP-4844.
+func fakeExponential(factor *big.Int, numerator *big.Int, denominator *big.Int) *big.Int {
+	output := big.NewInt(0)
+	accum := new(big.Int).Mul(factor, denominator)
+	for i := int64(1); accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+		accum.Mul(accum, numerator)
+		accum.Div(accum, new(big.Int).Mul(denominator, big.NewInt(i)))
+	}
+
+	return output.Div(output, denominator)
+}
+
+// parseBigQuantity parses a JSON-RPC hex-encoded quantity that may exceed 64 bits.
+func parseBigQuantity(input string) (*big.Int, error) {
+	if len(input) < 3 || input[:2] != "0x" {
+		return nil, fmt.Errorf("invalid quantity %q", input)
+	}
+	res, success := new(big.Int).SetString(input[2:], 16)
+	if !success {
+		return nil, fmt.Errorf("invalid quantity %q", input)
+	}
+
+	return res, nil
+}
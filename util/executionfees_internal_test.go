@@ -0,0 +1,155 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextBaseFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseFee  *big.Int
+		gasUsed  uint64
+		gasLimit uint64
+		expected *big.Int
+	}{
+		{
+			name:     "AtTarget",
+			baseFee:  big.NewInt(1000000000),
+			gasUsed:  15000000,
+			gasLimit: 30000000,
+			expected: big.NewInt(1000000000),
+		},
+		{
+			name:     "Full",
+			baseFee:  big.NewInt(1000000000),
+			gasUsed:  30000000,
+			gasLimit: 30000000,
+			expected: big.NewInt(1125000000),
+		},
+		{
+			name:     "Empty",
+			baseFee:  big.NewInt(1000000000),
+			gasUsed:  0,
+			gasLimit: 30000000,
+			expected: big.NewInt(875000000),
+		},
+		{
+			name:     "MinimumIncrease",
+			baseFee:  big.NewInt(7),
+			gasUsed:  15000001,
+			gasLimit: 30000000,
+			expected: big.NewInt(8),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, nextBaseFee(test.baseFee, test.gasUsed, test.gasLimit))
+		})
+	}
+}
+
+func TestPredictRequestFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		excess   uint64
+		requests uint64
+		target   uint64
+		expected *big.Int
+	}{
+		{
+			name:     "Zero",
+			excess:   0,
+			target:   2,
+			expected: big.NewInt(1),
+		},
+		{
+			name:     "Current",
+			excess:   17,
+			target:   2,
+			expected: big.NewInt(2),
+		},
+		{
+			name:     "BelowTarget",
+			excess:   17,
+			requests: 1,
+			target:   2,
+			expected: big.NewInt(2),
+		},
+		{
+			name:     "AboveTarget",
+			excess:   100,
+			requests: 20,
+			target:   2,
+			expected: big.NewInt(1030),
+		},
+		{
+			name:     "Drained",
+			excess:   1,
+			requests: 1,
+			target:   2,
+			expected: big.NewInt(1),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, PredictRequestFee(test.excess, test.requests, test.target))
+		})
+	}
+}
+
+func TestEstimateFees(t *testing.T) {
+	ctx := context.Background()
+
+	server := newExecutionServer(t, map[string]string{
+		"eth_chainId":              `"0x1"`,
+		"eth_getBlockByNumber":     `{"baseFeePerGas":"0x3b9aca00","gasUsed":"0x1c9c380","gasLimit":"0x1c9c380"}`,
+		"eth_maxPriorityFeePerGas": `"0x77359400"`,
+		"eth_getStorageAt":         `"0x0000000000000000000000000000000000000000000000000000000000000064"`,
+	})
+	defer server.Close()
+
+	client, err := ConnectToExecutionNode(ctx, server.URL, time.Second, true)
+	require.NoError(t, err)
+
+	value, _ := new(big.Int).SetString("32000000000000000000", 10)
+	totalCost, _ := new(big.Int).SetString("32000425000000000000", 10)
+	estimate, err := client.EstimateFees(ctx, DepositGasLimit, value)
+	require.NoError(t, err)
+	require.Equal(t, &FeeEstimate{
+		BaseFeePerGas:        big.NewInt(1125000000),
+		MaxPriorityFeePerGas: big.NewInt(2000000000),
+		MaxFeePerGas:         big.NewInt(4250000000),
+		GasLimit:             DepositGasLimit,
+		Value:                value,
+		TotalCost:            totalCost,
+	}, estimate)
+
+	estimate, err = client.EstimateRequestFees(ctx, WithdrawalRequestPredeployAddress, 20)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(357), estimate.RequestFee)
+	require.Equal(t, big.NewInt(1030), estimate.Value)
+	require.Equal(t, big.NewInt(850000000001030), estimate.TotalCost)
+
+	_, err = client.EstimateRequestFees(ctx, make([]byte, 20), 1)
+	require.EqualError(t, err, "unknown request predeploy")
+}
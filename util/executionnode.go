@@ -0,0 +1,272 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ExecutionClient is a minimal JSON-RPC client for an execution node.
+type ExecutionClient struct {
+	address    string
+	httpClient *http.Client
+	nextID     uint64
+}
+
+// ExecutionLog is a log emitted by a contract on the execution chain.
+type ExecutionLog struct {
+	BlockNumber     uint64
+	TransactionHash []byte
+	Topics          [][]byte
+	Data            []byte
+}
+
+type executionRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type executionResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type executionLogJSON struct {
+	BlockNumber     string   `json:"blockNumber"`
+	TransactionHash string   `json:"transactionHash"`
+	Topics          []string `json:"topics"`
+	Data            string   `json:"data"`
+}
+
+type executionTransactionJSON struct {
+	From string `json:"from"`
+}
+
+// ConnectToExecutionNode connects to an execution node at the given address.
+func ConnectToExecutionNode(ctx context.Context, address string, timeout time.Duration, allowInsecure bool) (*ExecutionClient, error) {
+	if timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+	if address == "" {
+		return nil, errors.New("no execution connection specified")
+	}
+
+	if !strings.HasPrefix(address, "http") {
+		address = fmt.Sprintf("http://%s", address)
+	}
+	if !allowInsecure {
+		// Ensure the connection is either secure or local.
+		connectionURL, err := url.Parse(address)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse execution connection")
+		}
+		if connectionURL.Scheme == "http" &&
+			connectionURL.Host != "localhost" &&
+			!strings.HasPrefix(connectionURL.Host, "localhost:") &&
+			connectionURL.Host != "127.0.0.1" &&
+			!strings.HasPrefix(connectionURL.Host, "127.0.0.1:") {
+			fmt.Println("Connections to remote execution nodes should be secure.  This warning can be silenced with --allow-insecure-connections")
+		}
+	}
+
+	client := &ExecutionClient{
+		address: address,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+
+	// Confirm that the node is responding.
+	if _, err := client.ChainID(ctx); err != nil {
+		return nil, NewConnectionError(errors.Wrap(err, "failed to connect to execution node"))
+	}
+
+	return client, nil
+}
+
+// ChainID returns the chain ID of the execution chain.
+func (c *ExecutionClient) ChainID(ctx context.Context) (uint64, error) {
+	var res string
+	if err := c.call(ctx, "eth_chainId", nil, &res); err != nil {
+		return 0, err
+	}
+
+	return parseQuantity(res)
+}
+
+// BlockNumber returns the number of the latest block on the execution chain.
+func (c *ExecutionClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var res string
+	if err := c.call(ctx, "eth_blockNumber", nil, &res); err != nil {
+		return 0, err
+	}
+
+	return parseQuantity(res)
+}
+
+// Code returns the code at the given address in the latest block.
+// Addresses without code, such as those of externally owned accounts, return empty code.
+func (c *ExecutionClient) Code(ctx context.Context, address []byte) ([]byte, error) {
+	var res string
+	if err := c.call(ctx, "eth_getCode", []interface{}{fmt.Sprintf("%#x", address), "latest"}, &res); err != nil {
+		return nil, err
+	}
+
+	return parseData(res)
+}
+
+// Logs returns the logs emitted by the given address between two blocks, inclusive,
+// where the first topic matches the supplied topic.
+func (c *ExecutionClient) Logs(ctx context.Context, address []byte, topic []byte, fromBlock uint64, toBlock uint64) ([]*ExecutionLog, error) {
+	filter := map[string]interface{}{
+		"address":   fmt.Sprintf("%#x", address),
+		"topics":    []string{fmt.Sprintf("%#x", topic)},
+		"fromBlock": fmt.Sprintf("%#x", fromBlock),
+		"toBlock":   fmt.Sprintf("%#x", toBlock),
+	}
+	res := make([]*executionLogJSON, 0)
+	if err := c.call(ctx, "eth_getLogs", []interface{}{filter}, &res); err != nil {
+		return nil, err
+	}
+
+	logs := make([]*ExecutionLog, 0, len(res))
+	for _, logJSON := range res {
+		entry := &ExecutionLog{
+			Topics: make([][]byte, 0, len(logJSON.Topics)),
+		}
+		var err error
+		entry.BlockNumber, err = parseQuantity(logJSON.BlockNumber)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid log block number")
+		}
+		entry.TransactionHash, err = parseData(logJSON.TransactionHash)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid log transaction hash")
+		}
+		for _, topicStr := range logJSON.Topics {
+			topic, err := parseData(topicStr)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid log topic")
+			}
+			entry.Topics = append(entry.Topics, topic)
+		}
+		entry.Data, err = parseData(logJSON.Data)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid log data")
+		}
+		logs = append(logs, entry)
+	}
+
+	return logs, nil
+}
+
+// TransactionSender returns the address of the sender of the transaction with the given hash.
+func (c *ExecutionClient) TransactionSender(ctx context.Context, hash []byte) ([]byte, error) {
+	res := &executionTransactionJSON{}
+	if err := c.call(ctx, "eth_getTransactionByHash", []interface{}{fmt.Sprintf("%#x", hash)}, res); err != nil {
+		return nil, err
+	}
+	if res.From == "" {
+		return nil, fmt.Errorf("transaction %#x not found", hash)
+	}
+
+	return parseData(res.From)
+}
+
+// call makes a JSON-RPC call to the execution node.
+func (c *ExecutionClient) call(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	if params == nil {
+		params = make([]interface{}, 0)
+	}
+	reqBody, err := json.Marshal(&executionRequest{
+		JSONRPC: "2.0",
+		ID:      atomic.AddUint64(&c.nextID, 1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.address, bytes.NewReader(reqBody))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to call %s", method))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s call returned status %d", method, resp.StatusCode)
+	}
+
+	res := &executionResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to parse %s response", method))
+	}
+	if res.Error != nil {
+		return fmt.Errorf("%s call failed: %s", method, res.Error.Message)
+	}
+	if err := json.Unmarshal(res.Result, result); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to parse %s result", method))
+	}
+
+	return nil
+}
+
+// parseQuantity parses a JSON-RPC hex-encoded quantity.
+func parseQuantity(input string) (uint64, error) {
+	if !strings.HasPrefix(input, "0x") {
+		return 0, fmt.Errorf("invalid quantity %q", input)
+	}
+	res, err := strconv.ParseUint(strings.TrimPrefix(input, "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q", input)
+	}
+
+	return res, nil
+}
+
+// parseData parses JSON-RPC hex-encoded data.
+func parseData(input string) ([]byte, error) {
+	if !strings.HasPrefix(input, "0x") {
+		return nil, fmt.Errorf("invalid data %q", input)
+	}
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid data %q", input)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,169 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newExecutionServer creates a JSON-RPC server that returns the given results by method.
+func newExecutionServer(t *testing.T, results map[string]string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &executionRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		result, exists := results[req.Method]
+		if !exists {
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"error":{"code":-32601,"message":"method not found"}}`, req.ID)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":%s}`, req.ID, result)
+	}))
+}
+
+func TestConnectToExecutionNode(t *testing.T) {
+	ctx := context.Background()
+
+	server := newExecutionServer(t, map[string]string{
+		"eth_chainId": `"0x1"`,
+	})
+	defer server.Close()
+	badServer := newExecutionServer(t, map[string]string{})
+	defer badServer.Close()
+
+	_, err := ConnectToExecutionNode(ctx, server.URL, 0, true)
+	require.EqualError(t, err, "no timeout specified")
+
+	_, err = ConnectToExecutionNode(ctx, "", time.Second, true)
+	require.EqualError(t, err, "no execution connection specified")
+
+	_, err = ConnectToExecutionNode(ctx, badServer.URL, time.Second, true)
+	require.EqualError(t, err, "failed to connect to execution node: eth_chainId call failed: method not found")
+
+	client, err := ConnectToExecutionNode(ctx, server.URL, time.Second, true)
+	require.NoError(t, err)
+	chainID, err := client.ChainID(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), chainID)
+}
+
+func TestExecutionClientCode(t *testing.T) {
+	ctx := context.Background()
+
+	server := newExecutionServer(t, map[string]string{
+		"eth_chainId": `"0x1"`,
+		"eth_getCode": `"0x6080"`,
+	})
+	defer server.Close()
+
+	client, err := ConnectToExecutionNode(ctx, server.URL, time.Second, true)
+	require.NoError(t, err)
+	code, err := client.Code(ctx, make([]byte, 20))
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x60, 0x80}, code)
+}
+
+func TestExecutionClientTransactionSender(t *testing.T) {
+	ctx := context.Background()
+
+	server := newExecutionServer(t, map[string]string{
+		"eth_chainId":              `"0x1"`,
+		"eth_getTransactionByHash": `{"hash":"0x01","from":"0x0102030405060708090a0b0c0d0e0f1011121314"}`,
+	})
+	defer server.Close()
+	missingServer := newExecutionServer(t, map[string]string{
+		"eth_chainId":              `"0x1"`,
+		"eth_getTransactionByHash": `null`,
+	})
+	defer missingServer.Close()
+
+	client, err := ConnectToExecutionNode(ctx, server.URL, time.Second, true)
+	require.NoError(t, err)
+	sender, err := client.TransactionSender(ctx, []byte{0x01})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14}, sender)
+
+	client, err = ConnectToExecutionNode(ctx, missingServer.URL, time.Second, true)
+	require.NoError(t, err)
+	_, err = client.TransactionSender(ctx, []byte{0x01})
+	require.EqualError(t, err, "transaction 0x01 not found")
+}
+
+func TestExecutionClientLogs(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		logs     string
+		expected []*ExecutionLog
+		err      string
+	}{
+		{
+			name:     "Empty",
+			logs:     `[]`,
+			expected: []*ExecutionLog{},
+		},
+		{
+			name: "BlockNumberInvalid",
+			logs: `[{"blockNumber":"10","transactionHash":"0x01","topics":[],"data":"0x"}]`,
+			err:  `invalid log block number: invalid quantity "10"`,
+		},
+		{
+			name: "DataInvalid",
+			logs: `[{"blockNumber":"0x10","transactionHash":"0x01","topics":[],"data":"0xzz"}]`,
+			err:  `invalid log data: invalid data "0xzz"`,
+		},
+		{
+			name: "Good",
+			logs: `[{"blockNumber":"0x10","transactionHash":"0x01","topics":["0x02"],"data":"0x0304"}]`,
+			expected: []*ExecutionLog{
+				{
+					BlockNumber:     16,
+					TransactionHash: []byte{0x01},
+					Topics:          [][]byte{{0x02}},
+					Data:            []byte{0x03, 0x04},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newExecutionServer(t, map[string]string{
+				"eth_chainId": `"0x1"`,
+				"eth_getLogs": test.logs,
+			})
+			defer server.Close()
+
+			client, err := ConnectToExecutionNode(ctx, server.URL, time.Second, true)
+			require.NoError(t, err)
+			logs, err := client.Logs(ctx, make([]byte, 20), make([]byte, 32), 0, 100)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, logs)
+			}
+		})
+	}
+}
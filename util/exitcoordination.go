@@ -0,0 +1,302 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// ExitCoordination is a voluntary exit for a validator whose key is split
+// between multiple operators, along with the partial signatures generated
+// by the operators' key shares.
+type ExitCoordination struct {
+	Message   *phase0.VoluntaryExit
+	PublicKey phase0.BLSPubKey
+	Domain    phase0.Domain
+	// Threshold is the number of partial signatures required to
+	// combine, or 0 if not known.
+	Threshold  uint32
+	Signatures []*PartialSignature
+}
+
+// PartialSignature is a signature generated by a single key share.
+type PartialSignature struct {
+	ID        uint64
+	PublicKey phase0.BLSPubKey
+	Signature phase0.BLSSignature
+}
+
+type exitCoordinationJSON struct {
+	Message    *phase0.VoluntaryExit   `json:"message"`
+	PublicKey  string                  `json:"public_key"`
+	Domain     string                  `json:"domain"`
+	Threshold  uint32                  `json:"threshold,omitempty"`
+	Signatures []*partialSignatureJSON `json:"partial_signatures"`
+}
+
+type partialSignatureJSON struct {
+	ID        uint64 `json:"id"`
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e *ExitCoordination) MarshalJSON() ([]byte, error) {
+	signatures := make([]*partialSignatureJSON, 0, len(e.Signatures))
+	for _, signature := range e.Signatures {
+		signatures = append(signatures, &partialSignatureJSON{
+			ID:        signature.ID,
+			PublicKey: fmt.Sprintf("%#x", signature.PublicKey),
+			Signature: fmt.Sprintf("%#x", signature.Signature),
+		})
+	}
+
+	return json.Marshal(&exitCoordinationJSON{
+		Message:    e.Message,
+		PublicKey:  fmt.Sprintf("%#x", e.PublicKey),
+		Domain:     fmt.Sprintf("%#x", e.Domain),
+		Threshold:  e.Threshold,
+		Signatures: signatures,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ExitCoordination) UnmarshalJSON(input []byte) error {
+	var data exitCoordinationJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	if data.Message == nil {
+		return errors.New("message missing")
+	}
+	e.Message = data.Message
+	if err := decodeFixedHex("public key", data.PublicKey, e.PublicKey[:]); err != nil {
+		return err
+	}
+	if err := decodeFixedHex("domain", data.Domain, e.Domain[:]); err != nil {
+		return err
+	}
+	e.Threshold = data.Threshold
+
+	e.Signatures = make([]*PartialSignature, 0, len(data.Signatures))
+	for _, signatureData := range data.Signatures {
+		signature := &PartialSignature{
+			ID: signatureData.ID,
+		}
+		if signature.ID == 0 {
+			return errors.New("partial signature ID missing")
+		}
+		if err := decodeFixedHex("partial signature public key", signatureData.PublicKey, signature.PublicKey[:]); err != nil {
+			return err
+		}
+		if err := decodeFixedHex("partial signature", signatureData.Signature, signature.Signature[:]); err != nil {
+			return err
+		}
+		e.Signatures = append(e.Signatures, signature)
+	}
+
+	return nil
+}
+
+// NewExitCoordination creates an exit coordination for the given exit,
+// to be signed by the shares of the given public key in the given domain.
+func NewExitCoordination(message *phase0.VoluntaryExit, pubKey phase0.BLSPubKey, domain phase0.Domain, threshold uint32) *ExitCoordination {
+	return &ExitCoordination{
+		Message:    message,
+		PublicKey:  pubKey,
+		Domain:     domain,
+		Threshold:  threshold,
+		Signatures: make([]*PartialSignature, 0),
+	}
+}
+
+// ReadExitCoordination reads an exit coordination from a file.
+func ReadExitCoordination(path string) (*ExitCoordination, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read exit coordination file")
+	}
+	coordination := &ExitCoordination{}
+	if err := json.Unmarshal(data, coordination); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse exit coordination file %s", path))
+	}
+
+	return coordination, nil
+}
+
+// Write writes the exit coordination to a file.
+func (e *ExitCoordination) Write(path string) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode exit coordination")
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return errors.Wrap(err, "failed to write exit coordination file")
+	}
+
+	return nil
+}
+
+// SigningRoot provides the root that each key share signs.
+func (e *ExitCoordination) SigningRoot() (phase0.Root, error) {
+	root, err := e.Message.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to obtain exit root")
+	}
+
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     e.Domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to obtain signing root")
+	}
+
+	return signingRoot, nil
+}
+
+// AddSignature adds a partial signature, replacing any existing partial
+// signature with the same ID.  The signature must verify against the
+// public key of the share.
+func (e *ExitCoordination) AddSignature(signature *PartialSignature) error {
+	if signature.ID == 0 {
+		return errors.New("partial signature ID must be greater than 0")
+	}
+	if err := e.verifySignature(signature); err != nil {
+		return err
+	}
+
+	for i := range e.Signatures {
+		if e.Signatures[i].ID == signature.ID {
+			e.Signatures[i] = signature
+			return nil
+		}
+	}
+	e.Signatures = append(e.Signatures, signature)
+	sort.Slice(e.Signatures, func(i int, j int) bool {
+		return e.Signatures[i].ID < e.Signatures[j].ID
+	})
+
+	return nil
+}
+
+// Merge adds the partial signatures of another exit coordination, which
+// must be for the same exit.
+func (e *ExitCoordination) Merge(other *ExitCoordination) error {
+	if *e.Message != *other.Message || e.PublicKey != other.PublicKey || e.Domain != other.Domain {
+		return errors.New("exit coordinations are for different exits")
+	}
+	if e.Threshold == 0 {
+		e.Threshold = other.Threshold
+	}
+	if other.Threshold != 0 && other.Threshold != e.Threshold {
+		return fmt.Errorf("exit coordinations have different thresholds (%d and %d)", e.Threshold, other.Threshold)
+	}
+
+	for _, signature := range other.Signatures {
+		if err := e.AddSignature(signature); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Combine combines the partial signatures in to a signature for the
+// validator, returning the signed exit.
+func (e *ExitCoordination) Combine() (*phase0.SignedVoluntaryExit, error) {
+	if len(e.Signatures) == 0 {
+		return nil, errors.New("no partial signatures")
+	}
+	if e.Threshold != 0 && uint32(len(e.Signatures)) < e.Threshold {
+		return nil, fmt.Errorf("%d partial signatures present but %d required", len(e.Signatures), e.Threshold)
+	}
+
+	ids := make([]bls.ID, len(e.Signatures))
+	sigs := make([]bls.Sign, len(e.Signatures))
+	for i, signature := range e.Signatures {
+		if err := e.verifySignature(signature); err != nil {
+			return nil, err
+		}
+		ids[i] = *BLSID(signature.ID)
+		sigBytes := make([]byte, len(signature.Signature))
+		copy(sigBytes, signature.Signature[:])
+		if err := sigs[i].Deserialize(sigBytes); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid partial signature %d", signature.ID))
+		}
+	}
+
+	var combined bls.Sign
+	if err := combined.Recover(sigs, ids); err != nil {
+		return nil, errors.Wrap(err, "failed to combine partial signatures")
+	}
+
+	signedExit := &phase0.SignedVoluntaryExit{
+		Message: e.Message,
+	}
+	copy(signedExit.Signature[:], combined.Serialize())
+
+	if err := e.verify(e.PublicKey, signedExit.Signature); err != nil {
+		if e.Threshold == 0 {
+			return nil, errors.New("combined signature does not verify; more partial signatures may be required")
+		}
+		return nil, errors.New("combined signature does not verify against validator public key")
+	}
+
+	return signedExit, nil
+}
+
+// verifySignature verifies a partial signature against its share.
+func (e *ExitCoordination) verifySignature(signature *PartialSignature) error {
+	if err := e.verify(signature.PublicKey, signature.Signature); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("partial signature %d", signature.ID))
+	}
+
+	return nil
+}
+
+// verify verifies a signature of the exit against a public key.
+func (e *ExitCoordination) verify(pubKey phase0.BLSPubKey, signature phase0.BLSSignature) error {
+	signingRoot, err := e.SigningRoot()
+	if err != nil {
+		return err
+	}
+
+	pubKeyBytes := make([]byte, len(pubKey))
+	copy(pubKeyBytes, pubKey[:])
+	key, err := e2types.BLSPublicKeyFromBytes(pubKeyBytes)
+	if err != nil {
+		return errors.Wrap(err, "invalid public key")
+	}
+	sigBytes := make([]byte, len(signature))
+	copy(sigBytes, signature[:])
+	sig, err := e2types.BLSSignatureFromBytes(sigBytes)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	if !sig.Verify(signingRoot[:], key) {
+		return errors.New("signature does not verify")
+	}
+
+	return nil
+}
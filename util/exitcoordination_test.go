@@ -0,0 +1,222 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// thresholdShares generates a validator public key and key shares with the
+// given IDs, any threshold of which can sign for the validator.
+func thresholdShares(t *testing.T, threshold int, ids []uint64) (phase0.BLSPubKey, map[uint64]*bls.SecretKey) {
+	t.Helper()
+
+	var key bls.SecretKey
+	require.NoError(t, key.SetHexString("25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"))
+	pubKey := phase0.BLSPubKey{}
+	copy(pubKey[:], key.GetPublicKey().Serialize())
+
+	masterKeys := key.GetMasterSecretKey(threshold)
+	// Use fixed coefficients so that the test is deterministic.
+	for i := 1; i < len(masterKeys); i++ {
+		require.NoError(t, masterKeys[i].SetHexString("1a2b3c4d5e6f"))
+	}
+
+	shares := make(map[uint64]*bls.SecretKey, len(ids))
+	for _, id := range ids {
+		share := &bls.SecretKey{}
+		require.NoError(t, share.Set(masterKeys, util.BLSID(id)))
+		shares[id] = share
+	}
+
+	return pubKey, shares
+}
+
+func partialSignature(t *testing.T, coordination *util.ExitCoordination, id uint64, share *bls.SecretKey) *util.PartialSignature {
+	t.Helper()
+
+	signingRoot, err := coordination.SigningRoot()
+	require.NoError(t, err)
+	signature := &util.PartialSignature{
+		ID: id,
+	}
+	copy(signature.PublicKey[:], share.GetPublicKey().Serialize())
+	copy(signature.Signature[:], share.SignByte(signingRoot[:]).Serialize())
+
+	return signature
+}
+
+func TestExitCoordination(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	pubKey, shares := thresholdShares(t, 2, []uint64{1, 2, 3})
+	domain := phase0.Domain{0x04, 0x00, 0x00, 0x00, 0x01}
+	exit := &phase0.VoluntaryExit{
+		Epoch:          194048,
+		ValidatorIndex: 12345,
+	}
+
+	tests := []struct {
+		name      string
+		threshold uint32
+		ids       []uint64
+		alter     func(signature *util.PartialSignature)
+		addErr    string
+		err       string
+	}{
+		{
+			name:      "NoSignatures",
+			threshold: 2,
+			err:       "no partial signatures",
+		},
+		{
+			name:      "BelowThreshold",
+			threshold: 2,
+			ids:       []uint64{1},
+			err:       "1 partial signatures present but 2 required",
+		},
+		{
+			name: "BelowUnknownThreshold",
+			ids:  []uint64{3},
+			err:  "combined signature does not verify; more partial signatures may be required",
+		},
+		{
+			name: "IDMissing",
+			ids:  []uint64{1},
+			alter: func(signature *util.PartialSignature) {
+				signature.ID = 0
+			},
+			addErr: "partial signature ID must be greater than 0",
+		},
+		{
+			name: "SignatureWrongShare",
+			ids:  []uint64{1},
+			alter: func(signature *util.PartialSignature) {
+				copy(signature.PublicKey[:], shares[2].GetPublicKey().Serialize())
+			},
+			addErr: "partial signature 1: signature does not verify",
+		},
+		{
+			name:      "Threshold",
+			threshold: 2,
+			ids:       []uint64{1, 3},
+		},
+		{
+			name:      "AllShares",
+			threshold: 2,
+			ids:       []uint64{3, 2, 1},
+		},
+		{
+			name: "UnknownThreshold",
+			ids:  []uint64{2, 3},
+		},
+		{
+			name:      "Duplicate",
+			threshold: 2,
+			ids:       []uint64{2, 2},
+			err:       "1 partial signatures present but 2 required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			coordination := util.NewExitCoordination(exit, pubKey, domain, test.threshold)
+			for _, id := range test.ids {
+				signature := partialSignature(t, coordination, id, shares[id])
+				if test.alter != nil {
+					test.alter(signature)
+				}
+				err := coordination.AddSignature(signature)
+				if test.addErr != "" {
+					require.EqualError(t, err, test.addErr)
+					return
+				}
+				require.NoError(t, err)
+			}
+
+			signedExit, err := coordination.Combine()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, exit, signedExit.Message)
+
+			// Confirm that the combined signature is that of the validator key.
+			unsigned, err := util.NewUnsignedOperation(exit, pubKey, domain)
+			require.NoError(t, err)
+			_, err = unsigned.Assemble(signedExit.Signature)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestExitCoordinationFile(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	pubKey, shares := thresholdShares(t, 2, []uint64{1, 2, 3})
+	domain := phase0.Domain{0x04, 0x00, 0x00, 0x00, 0x01}
+	exit := &phase0.VoluntaryExit{
+		Epoch:          194048,
+		ValidatorIndex: 12345,
+	}
+
+	// Each operator signs their own copy of the coordination.
+	path1 := filepath.Join(t.TempDir(), "exit-coordination-1.json")
+	coordination1 := util.NewExitCoordination(exit, pubKey, domain, 2)
+	require.NoError(t, coordination1.AddSignature(partialSignature(t, coordination1, 1, shares[1])))
+	require.NoError(t, coordination1.Write(path1))
+
+	path2 := filepath.Join(t.TempDir(), "exit-coordination-2.json")
+	coordination2 := util.NewExitCoordination(exit, pubKey, domain, 0)
+	require.NoError(t, coordination2.AddSignature(partialSignature(t, coordination2, 2, shares[2])))
+	require.NoError(t, coordination2.Write(path2))
+
+	read1, err := util.ReadExitCoordination(path1)
+	require.NoError(t, err)
+	require.Equal(t, coordination1, read1)
+	read2, err := util.ReadExitCoordination(path2)
+	require.NoError(t, err)
+
+	require.NoError(t, read1.Merge(read2))
+	require.Len(t, read1.Signatures, 2)
+	_, err = read1.Combine()
+	require.NoError(t, err)
+
+	// Coordinations for different exits cannot be merged.
+	other := util.NewExitCoordination(&phase0.VoluntaryExit{
+		Epoch:          194049,
+		ValidatorIndex: 12345,
+	}, pubKey, domain, 2)
+	require.EqualError(t, read1.Merge(other), "exit coordinations are for different exits")
+
+	// Altered signatures are rejected.
+	altered := util.NewExitCoordination(exit, pubKey, domain, 2)
+	altered.Signatures = append(altered.Signatures, partialSignature(t, altered, 3, shares[2]))
+	copy(altered.Signatures[0].PublicKey[:], shares[3].GetPublicKey().Serialize())
+	require.EqualError(t, read1.Merge(altered), "partial signature 3: signature does not verify")
+
+	require.EqualError(t, json.Unmarshal([]byte(`{"public_key":"0x01"}`), altered), "message missing")
+
+	_, err = util.ReadExitCoordination(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
@@ -0,0 +1,58 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// ParseFormatTemplate parses a user-supplied output template.
+func ParseFormatTemplate(input string) (*template.Template, error) {
+	tmpl, err := template.New("format").Option("missingkey=error").Parse(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid format template")
+	}
+
+	return tmpl, nil
+}
+
+// ApplyFormatTemplate applies a user-supplied output template to the JSON
+// output of a command.  Fields are referenced by their JSON names, for
+// example {{.exit_epoch}}.
+func ApplyFormatTemplate(input string, data string) (string, error) {
+	tmpl, err := ParseFormatTemplate(input)
+	if err != nil {
+		return "", err
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(data))
+	// Retain the exact representation of numbers.
+	decoder.UseNumber()
+	var result interface{}
+	if err := decoder.Decode(&result); err != nil {
+		return "", errors.Wrap(err, "failed to decode command output")
+	}
+
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, result); err != nil {
+		return "", errors.Wrap(err, "failed to apply format template")
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,82 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestApplyFormatTemplate(t *testing.T) {
+	data := `{"validator_index":12345,"exit_epoch":200000,"balance":"32000000000","checks":[{"name":"a","passed":true},{"name":"b","passed":false}]}`
+
+	tests := []struct {
+		name     string
+		template string
+		data     string
+		expected string
+		err      string
+	}{
+		{
+			name:     "TemplateInvalid",
+			template: "{{.exit_epoch",
+			data:     data,
+			err:      `invalid format template: template: format:1: unclosed action`,
+		},
+		{
+			name:     "DataInvalid",
+			template: "{{.exit_epoch}}",
+			data:     "Exit epoch: 200000",
+			err:      "failed to decode command output: invalid character 'E' looking for beginning of value",
+		},
+		{
+			name:     "FieldMissing",
+			template: "{{.missing}}",
+			data:     data,
+			err:      `failed to apply format template: template: format:1:2: executing "format" at <.missing>: map has no entry for key "missing"`,
+		},
+		{
+			name:     "Field",
+			template: "{{.exit_epoch}}",
+			data:     data,
+			expected: "200000",
+		},
+		{
+			name:     "Summary",
+			template: "{{.validator_index}} exits at {{.exit_epoch}} with {{.balance}} Gwei",
+			data:     data,
+			expected: "12345 exits at 200000 with 32000000000 Gwei",
+		},
+		{
+			name:     "Range",
+			template: "{{range .checks}}{{if not .passed}}{{.name}} failed{{end}}{{end}}",
+			data:     data,
+			expected: "b failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.ApplyFormatTemplate(test.template, test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
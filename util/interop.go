@@ -0,0 +1,43 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+)
+
+// curveOrder is the order of the BLS12-381 curve.
+var curveOrder, _ = new(big.Int).SetString("52435875175126190479447740508185965837690552500527637822603658699938581184513", 10)
+
+// InteropPrivateKey returns the deterministic private key for the given index,
+// as used by client interop testing and devnets.
+func InteropPrivateKey(index uint64) []byte {
+	input := make([]byte, 32)
+	binary.LittleEndian.PutUint64(input, index)
+	hash := sha256.Sum256(input)
+
+	// The hash is interpreted as a little-endian integer.
+	for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+		hash[i], hash[j] = hash[j], hash[i]
+	}
+	key := new(big.Int).SetBytes(hash[:])
+	key.Mod(key, curveOrder)
+
+	res := make([]byte, 32)
+	key.FillBytes(res)
+
+	return res
+}
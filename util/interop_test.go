@@ -0,0 +1,48 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestInteropPrivateKey(t *testing.T) {
+	tests := []struct {
+		index uint64
+		res   string
+	}{
+		{
+			index: 0,
+			res:   "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+		},
+		{
+			index: 1,
+			res:   "0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000",
+		},
+		{
+			index: 2,
+			res:   "0x315ed405fafe339603932eebe8dbfd650ce5dafa561f6928664c75db85f97857",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d", test.index), func(t *testing.T) {
+			require.Equal(t, test.res, fmt.Sprintf("%#x", util.InteropPrivateKey(test.index)))
+		})
+	}
+}
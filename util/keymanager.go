@@ -0,0 +1,282 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// KeymanagerClient is a minimal client for the keymanager API of a validator client.
+type KeymanagerClient struct {
+	address    string
+	token      string
+	httpClient *http.Client
+}
+
+type keymanagerErrorJSON struct {
+	Message string `json:"message"`
+}
+
+type keymanagerKeystoresJSON struct {
+	Data []*struct {
+		ValidatingPubkey string `json:"validating_pubkey"`
+	} `json:"data"`
+}
+
+type keymanagerGraffitiJSON struct {
+	Graffiti string `json:"graffiti"`
+}
+
+type keymanagerGasLimitJSON struct {
+	GasLimit string `json:"gas_limit"`
+}
+
+// KeymanagerToken fetches the keymanager token supplied by the user, either
+// directly or in a file as written by validator clients.
+func KeymanagerToken() (string, error) {
+	token := viper.GetString("keymanager-token")
+	tokenFile := viper.GetString("keymanager-token-file")
+	if token != "" && tokenFile != "" {
+		return "", errors.New("only one of keymanager-token and keymanager-token-file allowed")
+	}
+	if tokenFile != "" {
+		data, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read keymanager token file")
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token == "" {
+		return "", errors.New("keymanager-token or keymanager-token-file is required")
+	}
+
+	return token, nil
+}
+
+// ConnectToKeymanager connects to the keymanager API at the given address,
+// authenticating with the given bearer token.
+func ConnectToKeymanager(ctx context.Context, address string, token string, timeout time.Duration, allowInsecure bool) (*KeymanagerClient, error) {
+	if timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+	if address == "" {
+		return nil, errors.New("no keymanager connection specified")
+	}
+	if token == "" {
+		return nil, errors.New("no keymanager token specified")
+	}
+
+	if !strings.HasPrefix(address, "http") {
+		address = fmt.Sprintf("http://%s", address)
+	}
+	address = strings.TrimSuffix(address, "/")
+	if !allowInsecure {
+		// Ensure the connection is either secure or local.
+		connectionURL, err := url.Parse(address)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse keymanager connection")
+		}
+		if connectionURL.Scheme == "http" &&
+			connectionURL.Hostname() != "localhost" &&
+			connectionURL.Hostname() != "127.0.0.1" {
+			fmt.Println("Connections to remote keymanagers should be secure, as the token grants control of the validators.  This warning can be silenced with --allow-insecure-connections")
+		}
+	}
+
+	client := &KeymanagerClient{
+		address: address,
+		token:   token,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+
+	// Confirm that the keymanager is responding and accepts the token.
+	if _, err := client.Keystores(ctx); err != nil {
+		return nil, NewConnectionError(errors.Wrap(err, "failed to connect to keymanager"))
+	}
+
+	return client, nil
+}
+
+// Keystores returns the public keys of the validators managed by the keymanager.
+func (c *KeymanagerClient) Keystores(ctx context.Context) ([][]byte, error) {
+	res := &keymanagerKeystoresJSON{}
+	if err := c.call(ctx, http.MethodGet, "/eth/v1/keystores", nil, res); err != nil {
+		return nil, err
+	}
+
+	pubKeys := make([][]byte, 0, len(res.Data))
+	for _, keystore := range res.Data {
+		pubKey, err := parseData(keystore.ValidatingPubkey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid keystore public key")
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	return pubKeys, nil
+}
+
+// Validators returns the public keys of the given validators, which can be
+// public keys or accounts.  If no validators are given, the public keys of all
+// validators managed by the keymanager are returned.
+func (c *KeymanagerClient) Validators(ctx context.Context, validators []string) ([][]byte, error) {
+	if len(validators) == 0 {
+		return c.Keystores(ctx)
+	}
+
+	pubKeys := make([][]byte, 0, len(validators))
+	for _, validator := range validators {
+		pubKey, err := KeymanagerPubKey(ctx, validator)
+		if err != nil {
+			return nil, err
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	return pubKeys, nil
+}
+
+// KeymanagerPubKey returns the public key of a validator, which can be a
+// public key or an account.
+func KeymanagerPubKey(ctx context.Context, validator string) ([]byte, error) {
+	if strings.HasPrefix(validator, "0x") {
+		pubKey, err := parseData(strings.ToLower(validator))
+		if err != nil || len(pubKey) != 48 {
+			return nil, fmt.Errorf("invalid validator public key %s", validator)
+		}
+		return pubKey, nil
+	}
+
+	account, err := ParseAccount(ctx, validator, nil, false)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain account %s", validator))
+	}
+	pubKey, err := BestPublicKey(account)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain public key for account %s", validator))
+	}
+
+	return pubKey.Marshal(), nil
+}
+
+// Graffiti returns the graffiti for the validator with the given public key.
+func (c *KeymanagerClient) Graffiti(ctx context.Context, pubKey []byte) (string, error) {
+	res := &struct {
+		Data *keymanagerGraffitiJSON `json:"data"`
+	}{}
+	if err := c.call(ctx, http.MethodGet, validatorEndpoint(pubKey, "graffiti"), nil, res); err != nil {
+		return "", err
+	}
+	if res.Data == nil {
+		return "", errors.New("graffiti response missing data")
+	}
+
+	return res.Data.Graffiti, nil
+}
+
+// SetGraffiti sets the graffiti for the validator with the given public key.
+func (c *KeymanagerClient) SetGraffiti(ctx context.Context, pubKey []byte, graffiti string) error {
+	if len(graffiti) > 32 {
+		return errors.New("graffiti cannot be longer than 32 bytes")
+	}
+
+	return c.call(ctx, http.MethodPost, validatorEndpoint(pubKey, "graffiti"), &keymanagerGraffitiJSON{Graffiti: graffiti}, nil)
+}
+
+// GasLimit returns the gas limit for the validator with the given public key.
+func (c *KeymanagerClient) GasLimit(ctx context.Context, pubKey []byte) (uint64, error) {
+	res := &struct {
+		Data *keymanagerGasLimitJSON `json:"data"`
+	}{}
+	if err := c.call(ctx, http.MethodGet, validatorEndpoint(pubKey, "gas_limit"), nil, res); err != nil {
+		return 0, err
+	}
+	if res.Data == nil {
+		return 0, errors.New("gas limit response missing data")
+	}
+	gasLimit, err := strconv.ParseUint(res.Data.GasLimit, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid gas limit")
+	}
+
+	return gasLimit, nil
+}
+
+// SetGasLimit sets the gas limit for the validator with the given public key.
+func (c *KeymanagerClient) SetGasLimit(ctx context.Context, pubKey []byte, gasLimit uint64) error {
+	return c.call(ctx, http.MethodPost, validatorEndpoint(pubKey, "gas_limit"), &keymanagerGasLimitJSON{GasLimit: strconv.FormatUint(gasLimit, 10)}, nil)
+}
+
+func validatorEndpoint(pubKey []byte, item string) string {
+	return fmt.Sprintf("/eth/v1/validator/%#x/%s", pubKey, item)
+}
+
+// call makes a call to the keymanager, decoding the response in to result if supplied.
+func (c *KeymanagerClient) call(ctx context.Context, method string, endpoint string, body interface{}, result interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return errors.Wrap(err, "failed to create request")
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.address, endpoint), reqBody)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to call %s", endpoint))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		errRes := &keymanagerErrorJSON{}
+		if err := json.NewDecoder(resp.Body).Decode(errRes); err == nil && errRes.Message != "" {
+			return fmt.Errorf("%s call returned status %d: %s", endpoint, resp.StatusCode, errRes.Message)
+		}
+		return fmt.Errorf("%s call returned status %d", endpoint, resp.StatusCode)
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to parse %s response", endpoint))
+	}
+
+	return nil
+}
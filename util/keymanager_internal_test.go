@@ -0,0 +1,176 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testKeymanagerToken = "api-token"
+
+// testKeymanager is a keymanager holding graffiti and gas limits by public key.
+type testKeymanager struct {
+	mu        sync.Mutex
+	pubKeys   []string
+	graffiti  map[string]string
+	gasLimits map[string]string
+}
+
+func (k *testKeymanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if r.Header.Get("Authorization") != fmt.Sprintf("Bearer %s", testKeymanagerToken) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message":"invalid token"}`)
+		return
+	}
+
+	if r.URL.Path == "/eth/v1/keystores" {
+		keystores := make([]string, 0, len(k.pubKeys))
+		for _, pubKey := range k.pubKeys {
+			keystores = append(keystores, fmt.Sprintf(`{"validating_pubkey":%q,"readonly":false}`, pubKey))
+		}
+		fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(keystores, ","))
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/eth/v1/validator/"), "/")
+	if len(parts) != 2 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	pubKey, item := parts[0], parts[1]
+	values := k.graffiti
+	key := "graffiti"
+	if item == "gas_limit" {
+		values = k.gasLimits
+		key = "gas_limit"
+	}
+	value, exists := values[pubKey]
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"validator not found"}`)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		fmt.Fprintf(w, `{"data":{"pubkey":%q,%q:%q}}`, pubKey, key, value)
+	case http.MethodPost:
+		body := make(map[string]string)
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		values[pubKey] = body[key]
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestKeymanager(pubKeys ...string) *testKeymanager {
+	k := &testKeymanager{
+		pubKeys:   pubKeys,
+		graffiti:  make(map[string]string),
+		gasLimits: make(map[string]string),
+	}
+	for _, pubKey := range pubKeys {
+		k.graffiti[pubKey] = ""
+		k.gasLimits[pubKey] = "30000000"
+	}
+
+	return k
+}
+
+func TestConnectToKeymanager(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(newTestKeymanager())
+	defer server.Close()
+
+	_, err := ConnectToKeymanager(ctx, server.URL, testKeymanagerToken, 0, true)
+	require.EqualError(t, err, "no timeout specified")
+
+	_, err = ConnectToKeymanager(ctx, "", testKeymanagerToken, time.Second, true)
+	require.EqualError(t, err, "no keymanager connection specified")
+
+	_, err = ConnectToKeymanager(ctx, server.URL, "", time.Second, true)
+	require.EqualError(t, err, "no keymanager token specified")
+
+	_, err = ConnectToKeymanager(ctx, server.URL, "bad", time.Second, true)
+	require.EqualError(t, err, "failed to connect to keymanager: /eth/v1/keystores call returned status 401: invalid token")
+
+	_, err = ConnectToKeymanager(ctx, server.URL, testKeymanagerToken, time.Second, true)
+	require.NoError(t, err)
+}
+
+func TestKeymanagerClient(t *testing.T) {
+	ctx := context.Background()
+
+	pubKey := make([]byte, 48)
+	pubKey[0] = 0x01
+	unknownPubKey := make([]byte, 48)
+	unknownPubKey[0] = 0x02
+
+	server := httptest.NewServer(newTestKeymanager(fmt.Sprintf("%#x", pubKey)))
+	defer server.Close()
+
+	client, err := ConnectToKeymanager(ctx, server.URL, testKeymanagerToken, time.Second, true)
+	require.NoError(t, err)
+
+	pubKeys, err := client.Keystores(ctx)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{pubKey}, pubKeys)
+
+	pubKeys, err = client.Validators(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{pubKey}, pubKeys)
+	pubKeys, err = client.Validators(ctx, []string{fmt.Sprintf("%#x", unknownPubKey)})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{unknownPubKey}, pubKeys)
+	_, err = client.Validators(ctx, []string{"0x0102"})
+	require.EqualError(t, err, "invalid validator public key 0x0102")
+
+	graffiti, err := client.Graffiti(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, "", graffiti)
+	require.NoError(t, client.SetGraffiti(ctx, pubKey, "my graffiti"))
+	graffiti, err = client.Graffiti(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, "my graffiti", graffiti)
+	require.EqualError(t, client.SetGraffiti(ctx, pubKey, strings.Repeat("x", 33)), "graffiti cannot be longer than 32 bytes")
+
+	gasLimit, err := client.GasLimit(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30000000), gasLimit)
+	require.NoError(t, client.SetGasLimit(ctx, pubKey, 36000000))
+	gasLimit, err = client.GasLimit(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, uint64(36000000), gasLimit)
+
+	_, err = client.Graffiti(ctx, unknownPubKey)
+	require.EqualError(t, err, fmt.Sprintf("/eth/v1/validator/%#x/graffiti call returned status 404: validator not found", unknownPubKey))
+}
@@ -14,8 +14,10 @@
 package util
 
 import (
+	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -29,16 +31,37 @@ var Log zerolog.Logger
 // InitLogging initialises logging.
 func InitLogging() error {
 	// Change the output file.
+	var out io.Writer = os.Stderr
 	if viper.GetString("log-file") != "" {
 		f, err := os.OpenFile(viper.GetString("log-file"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
 		if err != nil {
 			return errors.Wrap(err, "failed to open log file")
 		}
-		zerologger.Logger = zerologger.Logger.Output(f)
+		out = f
 	}
 
-	// Set the log level.
-	Log = zerologger.Logger.With().Logger().Level(logLevel(viper.GetString("log-level")))
+	// Change the output format.
+	switch strings.ToLower(viper.GetString("log-format")) {
+	case "", "text":
+		out = zerolog.ConsoleWriter{Out: out, TimeFormat: time.RFC3339, NoColor: viper.GetString("log-file") != ""}
+	case "json":
+	default:
+		return errors.New("log format must be text or json")
+	}
+	zerologger.Logger = zerolog.New(out).With().Timestamp().Logger()
+
+	// Set the log level.  Logging is disabled unless requested, either with
+	// the debug flag or an explicit log level.
+	Log = zerologger.Logger.Level(zerolog.Disabled)
+	if viper.GetBool("debug") {
+		Log = Log.Level(zerolog.DebugLevel)
+	}
+	if viper.GetString("log-level") != "" {
+		Log = Log.Level(logLevel(viper.GetString("log-level")))
+	}
+
+	// Services use the global logger, so keep them in line with ethdo.
+	zerolog.SetGlobalLevel(Log.GetLevel())
 
 	return nil
 }
@@ -17,6 +17,7 @@ import (
 	"testing"
 
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 )
@@ -78,3 +79,72 @@ func TestLogLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestInitLogging(t *testing.T) {
+	tests := []struct {
+		name  string
+		vars  map[string]interface{}
+		level zerolog.Level
+		err   string
+	}{
+		{
+			name:  "Default",
+			vars:  map[string]interface{}{},
+			level: zerolog.Disabled,
+		},
+		{
+			name: "Debug",
+			vars: map[string]interface{}{
+				"debug": true,
+			},
+			level: zerolog.DebugLevel,
+		},
+		{
+			name: "LogLevelOverridesDebug",
+			vars: map[string]interface{}{
+				"debug":     true,
+				"log-level": "warn",
+			},
+			level: zerolog.WarnLevel,
+		},
+		{
+			name: "LogLevelUnknown",
+			vars: map[string]interface{}{
+				"log-level": "unknown",
+			},
+			level: zerolog.Disabled,
+		},
+		{
+			name: "JSON",
+			vars: map[string]interface{}{
+				"log-level":  "info",
+				"log-format": "json",
+			},
+			level: zerolog.InfoLevel,
+		},
+		{
+			name: "FormatInvalid",
+			vars: map[string]interface{}{
+				"log-format": "xml",
+			},
+			err: "log format must be text or json",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			err := InitLogging()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.level, Log.GetLevel())
+			}
+		})
+	}
+	zerolog.SetGlobalLevel(zerolog.DebugLevel)
+}
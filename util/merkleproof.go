@@ -0,0 +1,175 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// MerkleProof is a proof that a leaf is present at a generalized index of
+// the SSZ Merkle tree of a beacon state.
+type MerkleProof struct {
+	StateRoot        phase0.Root
+	Slot             phase0.Slot
+	Field            string
+	Index            *uint64
+	GeneralizedIndex uint64
+	Leaf             phase0.Root
+	Branch           []phase0.Root
+}
+
+type merkleProofJSON struct {
+	StateRoot        string   `json:"state_root"`
+	Slot             string   `json:"slot"`
+	Field            string   `json:"field"`
+	Index            string   `json:"index,omitempty"`
+	GeneralizedIndex string   `json:"gindex"`
+	Leaf             string   `json:"leaf"`
+	Branch           []string `json:"branch"`
+}
+
+// MarshalJSON implements custom JSON marshaller.
+func (p *MerkleProof) MarshalJSON() ([]byte, error) {
+	data := &merkleProofJSON{
+		StateRoot:        fmt.Sprintf("%#x", p.StateRoot),
+		Slot:             fmt.Sprintf("%d", p.Slot),
+		Field:            p.Field,
+		GeneralizedIndex: fmt.Sprintf("%d", p.GeneralizedIndex),
+		Leaf:             fmt.Sprintf("%#x", p.Leaf),
+		Branch:           make([]string, len(p.Branch)),
+	}
+	if p.Index != nil {
+		data.Index = fmt.Sprintf("%d", *p.Index)
+	}
+	for i := range p.Branch {
+		data.Branch[i] = fmt.Sprintf("%#x", p.Branch[i])
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements custom JSON unmarshaller.
+func (p *MerkleProof) UnmarshalJSON(input []byte) error {
+	var data merkleProofJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	var err error
+	if data.StateRoot == "" {
+		return errors.New("state root missing")
+	}
+	if p.StateRoot, err = parseMerkleRoot(data.StateRoot); err != nil {
+		return errors.Wrap(err, "state root invalid")
+	}
+
+	if data.Slot != "" {
+		slot, err := strconv.ParseUint(data.Slot, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "slot invalid")
+		}
+		p.Slot = phase0.Slot(slot)
+	}
+
+	p.Field = data.Field
+
+	p.Index = nil
+	if data.Index != "" {
+		index, err := strconv.ParseUint(data.Index, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "index invalid")
+		}
+		p.Index = &index
+	}
+
+	if data.GeneralizedIndex == "" {
+		return errors.New("gindex missing")
+	}
+	if p.GeneralizedIndex, err = strconv.ParseUint(data.GeneralizedIndex, 10, 64); err != nil {
+		return errors.Wrap(err, "gindex invalid")
+	}
+	if p.GeneralizedIndex == 0 {
+		return errors.New("gindex must be greater than 0")
+	}
+
+	if data.Leaf == "" {
+		return errors.New("leaf missing")
+	}
+	if p.Leaf, err = parseMerkleRoot(data.Leaf); err != nil {
+		return errors.Wrap(err, "leaf invalid")
+	}
+
+	p.Branch = make([]phase0.Root, len(data.Branch))
+	for i := range data.Branch {
+		if p.Branch[i], err = parseMerkleRoot(data.Branch[i]); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("branch item %d invalid", i))
+		}
+	}
+
+	return nil
+}
+
+// Verify verifies the proof against the supplied state root.
+func (p *MerkleProof) Verify(root phase0.Root) error {
+	if p.GeneralizedIndex == 0 {
+		return errors.New("gindex must be greater than 0")
+	}
+	depth := bits.Len64(p.GeneralizedIndex) - 1
+	if len(p.Branch) != depth {
+		return fmt.Errorf("branch has %d items, expected %d for gindex %d", len(p.Branch), depth, p.GeneralizedIndex)
+	}
+
+	value := p.Leaf[:]
+	for i := range p.Branch {
+		hash := sha256.New()
+		if (p.GeneralizedIndex>>i)&1 == 1 {
+			hash.Write(p.Branch[i][:])
+			hash.Write(value)
+		} else {
+			hash.Write(value)
+			hash.Write(p.Branch[i][:])
+		}
+		value = hash.Sum(nil)
+	}
+	if !bytes.Equal(value, root[:]) {
+		return errors.New("proof does not match root")
+	}
+
+	return nil
+}
+
+func parseMerkleRoot(input string) (phase0.Root, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return phase0.Root{}, err
+	}
+	if len(data) != phase0.RootLength {
+		return phase0.Root{}, errors.New("incorrect length")
+	}
+
+	var root phase0.Root
+	copy(root[:], data)
+
+	return root, nil
+}
@@ -0,0 +1,173 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func hashPair(left phase0.Root, right phase0.Root) phase0.Root {
+	return sha256.Sum256(append(left[:], right[:]...))
+}
+
+func TestMerkleProofVerify(t *testing.T) {
+	leaves := []phase0.Root{{0x01}, {0x02}, {0x03}, {0x04}}
+	root := hashPair(hashPair(leaves[0], leaves[1]), hashPair(leaves[2], leaves[3]))
+
+	tests := []struct {
+		name  string
+		proof *util.MerkleProof
+		err   string
+	}{
+		{
+			name: "GindexZero",
+			proof: &util.MerkleProof{
+				Leaf: leaves[0],
+			},
+			err: "gindex must be greater than 0",
+		},
+		{
+			name: "BranchShort",
+			proof: &util.MerkleProof{
+				GeneralizedIndex: 6,
+				Leaf:             leaves[2],
+				Branch:           []phase0.Root{leaves[3]},
+			},
+			err: "branch has 1 items, expected 2 for gindex 6",
+		},
+		{
+			name: "LeafIncorrect",
+			proof: &util.MerkleProof{
+				GeneralizedIndex: 6,
+				Leaf:             leaves[3],
+				Branch:           []phase0.Root{leaves[3], hashPair(leaves[0], leaves[1])},
+			},
+			err: "proof does not match root",
+		},
+		{
+			name: "GindexIncorrect",
+			proof: &util.MerkleProof{
+				GeneralizedIndex: 7,
+				Leaf:             leaves[2],
+				Branch:           []phase0.Root{leaves[3], hashPair(leaves[0], leaves[1])},
+			},
+			err: "proof does not match root",
+		},
+		{
+			name: "Good",
+			proof: &util.MerkleProof{
+				GeneralizedIndex: 6,
+				Leaf:             leaves[2],
+				Branch:           []phase0.Root{leaves[3], hashPair(leaves[0], leaves[1])},
+			},
+		},
+		{
+			name: "GoodRight",
+			proof: &util.MerkleProof{
+				GeneralizedIndex: 5,
+				Leaf:             leaves[1],
+				Branch:           []phase0.Root{leaves[0], hashPair(leaves[2], leaves[3])},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.proof.Verify(root)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMerkleProofJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "Invalid",
+			input: `[]`,
+			err:   "invalid JSON: json: cannot unmarshal array into Go value of type util.merkleProofJSON",
+		},
+		{
+			name:  "StateRootMissing",
+			input: `{"slot":"1","field":"slot","gindex":"34","leaf":"0x0100000000000000000000000000000000000000000000000000000000000000","branch":[]}`,
+			err:   "state root missing",
+		},
+		{
+			name:  "StateRootShort",
+			input: `{"state_root":"0x0102","slot":"1","field":"slot","gindex":"34","leaf":"0x0100000000000000000000000000000000000000000000000000000000000000","branch":[]}`,
+			err:   "state root invalid: incorrect length",
+		},
+		{
+			name:  "IndexInvalid",
+			input: `{"state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","slot":"1","field":"validators","index":"-1","gindex":"34","leaf":"0x0100000000000000000000000000000000000000000000000000000000000000","branch":[]}`,
+			err:   "index invalid: strconv.ParseUint: parsing \"-1\": invalid syntax",
+		},
+		{
+			name:  "GindexMissing",
+			input: `{"state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","slot":"1","field":"slot","leaf":"0x0100000000000000000000000000000000000000000000000000000000000000","branch":[]}`,
+			err:   "gindex missing",
+		},
+		{
+			name:  "GindexZero",
+			input: `{"state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","slot":"1","field":"slot","gindex":"0","leaf":"0x0100000000000000000000000000000000000000000000000000000000000000","branch":[]}`,
+			err:   "gindex must be greater than 0",
+		},
+		{
+			name:  "LeafMissing",
+			input: `{"state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","slot":"1","field":"slot","gindex":"34","branch":[]}`,
+			err:   "leaf missing",
+		},
+		{
+			name:  "BranchInvalid",
+			input: `{"state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","slot":"1","field":"slot","gindex":"34","leaf":"0x0100000000000000000000000000000000000000000000000000000000000000","branch":["0xzz"]}`,
+			err:   "branch item 0 invalid: encoding/hex: invalid byte: U+007A 'z'",
+		},
+		{
+			name:  "Good",
+			input: `{"state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","slot":"1","field":"slot","gindex":"2","leaf":"0x0100000000000000000000000000000000000000000000000000000000000000","branch":["0x0200000000000000000000000000000000000000000000000000000000000000"]}`,
+		},
+		{
+			name:  "GoodIndex",
+			input: `{"state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","slot":"1","field":"validators","index":"3","gindex":"2","leaf":"0x0100000000000000000000000000000000000000000000000000000000000000","branch":["0x0200000000000000000000000000000000000000000000000000000000000000"]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var proof util.MerkleProof
+			err := json.Unmarshal([]byte(test.input), &proof)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				output, err := json.Marshal(&proof)
+				require.NoError(t, err)
+				require.Equal(t, test.input, string(output))
+			}
+		})
+	}
+}
@@ -14,15 +14,24 @@
 package util
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"fmt"
+	"path"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/archivestore"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 	dirk "github.com/wealdtech/go-eth2-wallet-dirk"
@@ -40,6 +49,11 @@ func SetupStore() error {
 		return nil
 	}
 
+	remoteBaseDir, err := GetRemoteBaseDir()
+	if err != nil {
+		return err
+	}
+
 	// Set up our wallet store.
 	switch viper.GetString("store") {
 	case "s3":
@@ -58,15 +72,39 @@ func SetupStore() error {
 		if err != nil {
 			return errors.Wrap(err, "failed to access Amazon S3 wallet store")
 		}
-	case "filesystem":
-		opts := make([]filesystem.Option, 0)
-		if GetStorePassphrase("filesystem") != "" {
-			opts = append(opts, filesystem.WithPassphrase([]byte(GetStorePassphrase("filesystem"))))
+	case "archive":
+		if remoteBaseDir != nil {
+			return errors.New("remote base directory does not apply to the archive store")
 		}
-		if GetBaseDir() != "" {
-			opts = append(opts, filesystem.WithLocation(GetBaseDir()))
+		path := viper.GetString("stores.archive.path")
+		if path == "" {
+			path = defaultArchivePath()
+		}
+		if GetStorePassphrase("archive") == "" {
+			return errors.New("archive store requires a passphrase")
+		}
+		store, err = archivestore.New(archivestore.WithPath(path),
+			archivestore.WithPassphrase([]byte(GetStorePassphrase("archive"))),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to access archive wallet store")
+		}
+	case "filesystem":
+		if remoteBaseDir != nil {
+			store, err = remoteStore(remoteBaseDir)
+			if err != nil {
+				return err
+			}
+		} else {
+			opts := make([]filesystem.Option, 0)
+			if GetStorePassphrase("filesystem") != "" {
+				opts = append(opts, filesystem.WithPassphrase([]byte(GetStorePassphrase("filesystem"))))
+			}
+			if GetBaseDir() != "" {
+				opts = append(opts, filesystem.WithLocation(GetBaseDir()))
+			}
+			store = filesystem.New(opts...)
 		}
-		store = filesystem.New(opts...)
 	default:
 		return fmt.Errorf("unsupported wallet store %s", viper.GetString("store"))
 	}
@@ -78,6 +116,81 @@ func SetupStore() error {
 	return nil
 }
 
+// gcsEndpoint is the endpoint of the S3-compatible interface to Google Cloud Storage.
+const gcsEndpoint = "https://storage.googleapis.com"
+
+// remoteArchiveName is the name of the object holding the wallet archive in a
+// remote base directory.
+const remoteArchiveName = "wallets.archive"
+
+// remoteStore creates a store for wallets held in an object store.  The
+// wallets are held in a single archive object, so that every update can be
+// made conditional on the object being unchanged since it was read.  Google
+// Cloud Storage is accessed through its S3-compatible interface.  Credentials
+// are taken from the environment unless configured under "stores.s3".
+func remoteStore(baseDir *RemoteBaseDir) (e2wtypes.Store, error) {
+	passphrase := GetStorePassphrase("s3")
+	if passphrase == "" {
+		return nil, errors.New("remote wallet store requires a passphrase")
+	}
+
+	config := aws.NewConfig()
+	endpoint := viper.GetString("stores.s3.endpoint")
+	region := viper.GetString("stores.s3.region")
+	if baseDir.Scheme == "gs" {
+		if endpoint == "" {
+			endpoint = gcsEndpoint
+		}
+		if region == "" {
+			region = "auto"
+		}
+	}
+	if endpoint != "" {
+		config = config.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+	if region != "" {
+		config = config.WithRegion(region)
+	}
+	if viper.GetString("stores.s3.credentials.id") != "" {
+		config = config.WithCredentials(credentials.NewStaticCredentials(viper.GetString("stores.s3.credentials.id"),
+			viper.GetString("stores.s3.credentials.secret"),
+			"",
+		))
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create object store session")
+	}
+
+	store, err := archivestore.New(archivestore.WithObject(awss3.New(sess), baseDir.Scheme, baseDir.Bucket, path.Join(baseDir.Path, remoteArchiveName)),
+		archivestore.WithPassphrase([]byte(passphrase)),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to access wallet store at %s://%s", baseDir.Scheme, baseDir.Bucket))
+	}
+
+	return store, nil
+}
+
+// defaultArchivePath provides the path of the archive file when not
+// explicitly configured: in the base directory if supplied, otherwise
+// alongside the default location of filesystem wallets.
+func defaultArchivePath() string {
+	if GetBaseDir() != "" {
+		return filepath.Join(GetBaseDir(), "wallets.archive")
+	}
+	var store e2wtypes.Store = filesystem.New()
+	locationProvider, isProvider := store.(e2wtypes.StoreLocationProvider)
+	if !isProvider {
+		return "wallets.archive"
+	}
+
+	return filepath.Join(filepath.Dir(locationProvider.Location()), "wallets.archive")
+}
+
 // WalletFromInput obtains a wallet given the information in the viper variable
 // "account", or if not present the viper variable "wallet".
 func WalletFromInput(ctx context.Context) (e2wtypes.Wallet, error) {
@@ -165,17 +278,82 @@ func WalletAndAccountFromPath(ctx context.Context, path string) (e2wtypes.Wallet
 		}
 	}
 
-	accountByNameProvider, isAccountByNameProvider := wallet.(e2wtypes.WalletAccountByNameProvider)
-	if !isAccountByNameProvider {
-		return nil, nil, errors.New("wallet cannot obtain accounts by name")
-	}
-	account, err := accountByNameProvider.AccountByName(ctx, accountName)
+	account, err := accountFromWallet(ctx, wallet, accountName)
 	if err != nil {
+		if viper.GetString("remote") != "" {
+			// Remote wallets only provide the accounts to which the client is
+			// permitted access, so list them to help find the right account.
+			return nil, nil, errors.Wrap(err, fmt.Sprintf("failed to obtain account (accounts available: %s)", accountNames(ctx, wallet)))
+		}
 		return nil, nil, errors.Wrap(err, "failed to obtain account")
 	}
 	return wallet, account, nil
 }
 
+// accountFromWallet obtains an account from a wallet given its name, or its
+// public key as a 0x-prefixed hex string.  Local wallets are looked up using
+// an account index, to avoid reading every account in the wallet.
+func accountFromWallet(ctx context.Context, wallet e2wtypes.Wallet, accountName string) (e2wtypes.Account, error) {
+	var index *AccountIndex
+	if viper.GetString("remote") == "" && !strings.HasPrefix(accountName, "m/") {
+		// Not all wallets can be indexed, in which case the wallet is used directly.
+		index, _ = NewAccountIndex(ctx, wallet)
+	}
+
+	if pubKey, isPubKey := accountPublicKey(accountName); isPubKey {
+		if index != nil {
+			if account, err := index.AccountByPublicKey(ctx, pubKey); err == nil {
+				return account, nil
+			}
+		} else {
+			for account := range wallet.Accounts(ctx) {
+				if accountPubKey, err := BestPublicKey(account); err == nil && bytes.Equal(accountPubKey.Marshal(), pubKey) {
+					return account, nil
+				}
+			}
+		}
+		// Fall through, in case an account is named with a public key.
+	}
+
+	if index != nil {
+		return index.AccountByName(ctx, accountName)
+	}
+	accountByNameProvider, isAccountByNameProvider := wallet.(e2wtypes.WalletAccountByNameProvider)
+	if !isAccountByNameProvider {
+		return nil, errors.New("wallet cannot obtain accounts by name")
+	}
+
+	return accountByNameProvider.AccountByName(ctx, accountName)
+}
+
+// accountPublicKey returns the public key given as an account name, if it is one.
+func accountPublicKey(accountName string) ([]byte, bool) {
+	if !strings.HasPrefix(accountName, "0x") {
+		return nil, false
+	}
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(accountName, "0x"))
+	if err != nil || len(pubKey) != 48 {
+		return nil, false
+	}
+
+	return pubKey, true
+}
+
+// accountNames returns a sorted, comma-separated list of the names of the
+// accounts in a wallet.
+func accountNames(ctx context.Context, wallet e2wtypes.Wallet) string {
+	names := make([]string, 0)
+	for account := range wallet.Accounts(ctx) {
+		names = append(names, account.Name())
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
 // WalletAndAccountsFromPath obtains the wallet and matching accounts given a path specification.
 func WalletAndAccountsFromPath(ctx context.Context, path string) (e2wtypes.Wallet, []e2wtypes.Account, error) {
 	wallet, err := WalletFromPath(ctx, path)
@@ -195,10 +373,29 @@ func WalletAndAccountsFromPath(ctx context.Context, path string) (e2wtypes.Walle
 	re := regexp.MustCompile(accountSpec)
 
 	accounts := make([]e2wtypes.Account, 0)
-	for account := range wallet.Accounts(ctx) {
-		if re.Match([]byte(account.Name())) {
+	var index *AccountIndex
+	if viper.GetString("remote") == "" {
+		// The index avoids loading accounts that do not match.  Not all wallets
+		// can be indexed, in which case all accounts are loaded.
+		index, _ = NewAccountIndex(ctx, wallet)
+	}
+	if index != nil {
+		for _, name := range index.Names() {
+			if !re.Match([]byte(name)) {
+				continue
+			}
+			account, err := index.AccountByName(ctx, name)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "failed to obtain account")
+			}
 			accounts = append(accounts, account)
 		}
+	} else {
+		for account := range wallet.Accounts(ctx) {
+			if re.Match([]byte(account.Name())) {
+				accounts = append(accounts, account)
+			}
+		}
 	}
 
 	// Tidy up accounts by name.
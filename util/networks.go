@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
 
@@ -30,6 +31,14 @@ var networks = map[string]string{
 	"6f22ffbc56eff051aecf839396dd1ed9ad6bba9d": "Ropsten",
 }
 
+// genesisValidatorsRoots is a map of genesis validators roots to networks.
+var genesisValidatorsRoots = map[string]string{
+	"4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95": "Mainnet",
+	"043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb": "Prater",
+	"d8ea171f3c94aea21ebc42a1ed61052acf3f9209c00e4efbaaddac09ed9b8078": "Sepolia",
+	"9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1": "Holesky",
+}
+
 // Network returns the name of the network., calculated from the deposit contract information.
 // If not known, returns "Unknown".
 func Network(ctx context.Context, eth2Client eth2client.Service) (string, error) {
@@ -65,3 +74,13 @@ func network(address []byte) string {
 	}
 	return "Unknown"
 }
+
+// NetworkFromGenesisValidatorsRoot returns the name of the network with the
+// given genesis validators root.
+// If not known, returns "Unknown".
+func NetworkFromGenesisValidatorsRoot(root phase0.Root) string {
+	if network, exists := genesisValidatorsRoots[fmt.Sprintf("%x", root)]; exists {
+		return network
+	}
+	return "Unknown"
+}
@@ -0,0 +1,187 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// OperationSchedule is a set of signed operations to be broadcast once
+// specified epochs arrive.
+type OperationSchedule struct {
+	Operations []*ScheduledOperation `json:"operations"`
+}
+
+// ScheduledOperation is a signed operation to be broadcast at or after an epoch.
+type ScheduledOperation struct {
+	Type       string                  `json:"type"`
+	Epoch      phase0.Epoch            `json:"epoch"`
+	Validators []phase0.ValidatorIndex `json:"validators"`
+	Operation  json.RawMessage         `json:"operation"`
+	Added      time.Time               `json:"added"`
+	// Broadcast is the time at which the operation was broadcast, or nil if
+	// it has yet to be broadcast.
+	Broadcast *time.Time `json:"broadcast,omitempty"`
+	// LastError is the error from the most recent failed broadcast, if any.
+	LastError string `json:"last_error,omitempty"`
+}
+
+// NewScheduledOperation creates a scheduled operation from a signed voluntary
+// exit or signed credentials change operations, as generated by ethdo.
+func NewScheduledOperation(data []byte, epoch phase0.Epoch) (*ScheduledOperation, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("no operation supplied")
+	}
+
+	op := &ScheduledOperation{
+		Epoch: epoch,
+		Added: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if data[0] != '[' {
+		// A single operation; check for a credentials change, otherwise
+		// expect a voluntary exit.
+		var probe struct {
+			Message map[string]json.RawMessage `json:"message"`
+		}
+		if err := json.Unmarshal(data, &probe); err != nil {
+			return nil, errors.Wrap(err, "invalid operation")
+		}
+		if _, exists := probe.Message["from_bls_pubkey"]; !exists {
+			exit := &phase0.SignedVoluntaryExit{}
+			if err := json.Unmarshal(data, exit); err != nil {
+				return nil, errors.Wrap(err, "invalid voluntary exit")
+			}
+			if exit.Message.Epoch > epoch {
+				return nil, fmt.Errorf("voluntary exit is not valid until epoch %d", exit.Message.Epoch)
+			}
+			op.Type = OperationTypeVoluntaryExit
+			op.Validators = []phase0.ValidatorIndex{exit.Message.ValidatorIndex}
+			op.Operation, _ = json.Marshal(exit)
+
+			return op, nil
+		}
+		data = append(append([]byte("["), data...), ']')
+	}
+
+	changes := make([]*capella.SignedBLSToExecutionChange, 0)
+	if err := json.Unmarshal(data, &changes); err != nil {
+		return nil, errors.Wrap(err, "invalid credentials change operations")
+	}
+	if len(changes) == 0 {
+		return nil, errors.New("no credentials change operations supplied")
+	}
+	op.Type = OperationTypeBLSToExecutionChange
+	for _, change := range changes {
+		op.Validators = append(op.Validators, change.Message.ValidatorIndex)
+	}
+	op.Operation, _ = json.Marshal(changes)
+
+	return op, nil
+}
+
+// VoluntaryExit returns the signed voluntary exit of the operation.
+func (o *ScheduledOperation) VoluntaryExit() (*phase0.SignedVoluntaryExit, error) {
+	if o.Type != OperationTypeVoluntaryExit {
+		return nil, fmt.Errorf("operation is of type %s", o.Type)
+	}
+	exit := &phase0.SignedVoluntaryExit{}
+	if err := json.Unmarshal(o.Operation, exit); err != nil {
+		return nil, errors.Wrap(err, "invalid voluntary exit")
+	}
+
+	return exit, nil
+}
+
+// BLSToExecutionChanges returns the signed credentials change operations of
+// the operation.
+func (o *ScheduledOperation) BLSToExecutionChanges() ([]*capella.SignedBLSToExecutionChange, error) {
+	if o.Type != OperationTypeBLSToExecutionChange {
+		return nil, fmt.Errorf("operation is of type %s", o.Type)
+	}
+	changes := make([]*capella.SignedBLSToExecutionChange, 0)
+	if err := json.Unmarshal(o.Operation, &changes); err != nil {
+		return nil, errors.Wrap(err, "invalid credentials change operations")
+	}
+
+	return changes, nil
+}
+
+// ReadOperationSchedule reads an operation schedule from a file.  If the file
+// does not exist an empty schedule is returned.
+func ReadOperationSchedule(path string) (*OperationSchedule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &OperationSchedule{
+				Operations: make([]*ScheduledOperation, 0),
+			}, nil
+		}
+		return nil, errors.Wrap(err, "failed to read operation schedule file")
+	}
+	schedule := &OperationSchedule{}
+	if err := json.Unmarshal(data, schedule); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse operation schedule file %s", path))
+	}
+	if schedule.Operations == nil {
+		schedule.Operations = make([]*ScheduledOperation, 0)
+	}
+
+	return schedule, nil
+}
+
+// Write writes the operation schedule to a file.  The file is replaced
+// atomically, so a concurrent reader never sees a partial schedule.
+func (s *OperationSchedule) Write(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode operation schedule")
+	}
+	if err := WriteFileAtomically(path, data); err != nil {
+		return errors.Wrap(err, "failed to write operation schedule file")
+	}
+
+	return nil
+}
+
+// Add adds an operation to the schedule, keeping the schedule ordered by epoch.
+func (s *OperationSchedule) Add(op *ScheduledOperation) {
+	s.Operations = append(s.Operations, op)
+	sort.SliceStable(s.Operations, func(i int, j int) bool {
+		return s.Operations[i].Epoch < s.Operations[j].Epoch
+	})
+}
+
+// Due returns the operations that are due for broadcast at the given epoch
+// and have yet to be broadcast.
+func (s *OperationSchedule) Due(epoch phase0.Epoch) []*ScheduledOperation {
+	due := make([]*ScheduledOperation, 0)
+	for _, op := range s.Operations {
+		if op.Broadcast == nil && op.Epoch <= epoch {
+			due = append(due, op)
+		}
+	}
+
+	return due
+}
@@ -0,0 +1,150 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+var (
+	testSignature = fmt.Sprintf("0x%s", strings.Repeat("b7", 96))
+	testExit      = fmt.Sprintf(`{"message":{"epoch":"100","validator_index":"12345"},"signature":"%s"}`, testSignature)
+	testChange    = fmt.Sprintf(`{"message":{"validator_index":"%%d","from_bls_pubkey":"0x%s","to_execution_address":"0x%s"},"signature":"%s"}`, strings.Repeat("a9", 48), strings.Repeat("8c", 20), testSignature)
+)
+
+func TestNewScheduledOperation(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		epoch      phase0.Epoch
+		opType     string
+		validators []phase0.ValidatorIndex
+		err        string
+	}{
+		{
+			name: "Empty",
+			data: " ",
+			err:  "no operation supplied",
+		},
+		{
+			name: "Invalid",
+			data: "{",
+			err:  "invalid operation: unexpected end of JSON input",
+		},
+		{
+			name:  "ExitTooEarly",
+			data:  testExit,
+			epoch: 99,
+			err:   "voluntary exit is not valid until epoch 100",
+		},
+		{
+			name:       "Exit",
+			data:       testExit,
+			epoch:      200,
+			opType:     util.OperationTypeVoluntaryExit,
+			validators: []phase0.ValidatorIndex{12345},
+		},
+		{
+			name:       "Change",
+			data:       fmt.Sprintf(testChange, 1),
+			epoch:      200,
+			opType:     util.OperationTypeBLSToExecutionChange,
+			validators: []phase0.ValidatorIndex{1},
+		},
+		{
+			name:       "Changes",
+			data:       fmt.Sprintf("[%s,%s]", fmt.Sprintf(testChange, 1), fmt.Sprintf(testChange, 2)),
+			epoch:      200,
+			opType:     util.OperationTypeBLSToExecutionChange,
+			validators: []phase0.ValidatorIndex{1, 2},
+		},
+		{
+			name:  "ChangesEmpty",
+			data:  "[]",
+			epoch: 200,
+			err:   "no credentials change operations supplied",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			op, err := util.NewScheduledOperation([]byte(test.data), test.epoch)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.opType, op.Type)
+				require.Equal(t, test.epoch, op.Epoch)
+				require.Equal(t, test.validators, op.Validators)
+				require.Nil(t, op.Broadcast)
+			}
+		})
+	}
+}
+
+func TestScheduledOperationContents(t *testing.T) {
+	exitOp, err := util.NewScheduledOperation([]byte(testExit), 200)
+	require.NoError(t, err)
+	exit, err := exitOp.VoluntaryExit()
+	require.NoError(t, err)
+	require.Equal(t, phase0.Epoch(100), exit.Message.Epoch)
+	_, err = exitOp.BLSToExecutionChanges()
+	require.EqualError(t, err, "operation is of type voluntary_exit")
+
+	changeOp, err := util.NewScheduledOperation([]byte(fmt.Sprintf(testChange, 7)), 200)
+	require.NoError(t, err)
+	changes, err := changeOp.BLSToExecutionChanges()
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, phase0.ValidatorIndex(7), changes[0].Message.ValidatorIndex)
+	_, err = changeOp.VoluntaryExit()
+	require.EqualError(t, err, "operation is of type bls_to_execution_change")
+}
+
+func TestOperationSchedule(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduled-operations.json")
+
+	// Missing file provides an empty schedule.
+	schedule, err := util.ReadOperationSchedule(path)
+	require.NoError(t, err)
+	require.Empty(t, schedule.Operations)
+
+	later, err := util.NewScheduledOperation([]byte(testExit), 300)
+	require.NoError(t, err)
+	earlier, err := util.NewScheduledOperation([]byte(fmt.Sprintf(testChange, 1)), 200)
+	require.NoError(t, err)
+	schedule.Add(later)
+	schedule.Add(earlier)
+	require.Equal(t, phase0.Epoch(200), schedule.Operations[0].Epoch)
+	require.NoError(t, schedule.Write(path))
+
+	schedule, err = util.ReadOperationSchedule(path)
+	require.NoError(t, err)
+	require.Len(t, schedule.Operations, 2)
+	require.Empty(t, schedule.Due(199))
+	require.Len(t, schedule.Due(200), 1)
+	require.Len(t, schedule.Due(300), 2)
+
+	broadcast := schedule.Operations[0].Added
+	schedule.Operations[0].Broadcast = &broadcast
+	require.Len(t, schedule.Due(300), 1)
+	require.Equal(t, phase0.Epoch(300), schedule.Due(300)[0].Epoch)
+}
@@ -0,0 +1,285 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// OperationSearchSlots is the number of recent slots searched for blocks that
+// include an operation.
+var OperationSearchSlots = 64
+
+// OperationStatus is the status of an operation that is already known to the
+// network.
+type OperationStatus struct {
+	// Included is true if the operation has been included in a block;
+	// otherwise it is in the node's pool.
+	Included bool
+	// Slot is the slot of the block that included the operation.
+	Slot phase0.Slot
+}
+
+// String provides a description of the status.
+func (s *OperationStatus) String() string {
+	if s.Included {
+		return fmt.Sprintf("already included in block at slot %d", s.Slot)
+	}
+
+	return "already in node's pool"
+}
+
+// VoluntaryExitStatus checks the node's pool and recent blocks for a voluntary
+// exit with the same message as that supplied.  It returns nil if the exit is
+// not known.
+func VoluntaryExitStatus(ctx context.Context,
+	client consensusclient.Service,
+	timeout time.Duration,
+	op *phase0.SignedVoluntaryExit,
+) (
+	*OperationStatus,
+	error,
+) {
+	root, err := op.Message.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain root of exit")
+	}
+
+	statuses, err := operationStatuses(ctx, client, timeout, []phase0.Root{root}, "/eth/v1/beacon/pool/voluntary_exits",
+		func(data json.RawMessage) ([]phase0.Root, error) {
+			ops := make([]*phase0.SignedVoluntaryExit, 0)
+			if err := json.Unmarshal(data, &ops); err != nil {
+				return nil, err
+			}
+			roots := make([]phase0.Root, 0, len(ops))
+			for _, op := range ops {
+				root, err := op.Message.HashTreeRoot()
+				if err != nil {
+					return nil, err
+				}
+				roots = append(roots, root)
+			}
+			return roots, nil
+		},
+		func(block *spec.VersionedSignedBeaconBlock) ([]phase0.Root, error) {
+			roots := make([]phase0.Root, 0)
+			for _, op := range blockVoluntaryExits(block) {
+				root, err := op.Message.HashTreeRoot()
+				if err != nil {
+					return nil, err
+				}
+				roots = append(roots, root)
+			}
+			return roots, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses[0], nil
+}
+
+// BLSToExecutionChangeStatuses checks the node's pool and recent blocks for
+// credentials change operations with the same messages as those supplied.
+// It returns a status for each operation, which is nil if the operation is
+// not known.
+func BLSToExecutionChangeStatuses(ctx context.Context,
+	client consensusclient.Service,
+	timeout time.Duration,
+	ops []*capella.SignedBLSToExecutionChange,
+) (
+	[]*OperationStatus,
+	error,
+) {
+	roots := make([]phase0.Root, 0, len(ops))
+	for _, op := range ops {
+		root, err := op.Message.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain root of credentials change")
+		}
+		roots = append(roots, root)
+	}
+
+	return operationStatuses(ctx, client, timeout, roots, "/eth/v1/beacon/pool/bls_to_execution_changes",
+		func(data json.RawMessage) ([]phase0.Root, error) {
+			ops := make([]*capella.SignedBLSToExecutionChange, 0)
+			if err := json.Unmarshal(data, &ops); err != nil {
+				return nil, err
+			}
+			roots := make([]phase0.Root, 0, len(ops))
+			for _, op := range ops {
+				root, err := op.Message.HashTreeRoot()
+				if err != nil {
+					return nil, err
+				}
+				roots = append(roots, root)
+			}
+			return roots, nil
+		},
+		func(block *spec.VersionedSignedBeaconBlock) ([]phase0.Root, error) {
+			if block.Version != spec.DataVersionCapella || block.Capella == nil || block.Capella.Message == nil || block.Capella.Message.Body == nil {
+				return nil, nil
+			}
+			roots := make([]phase0.Root, 0)
+			for _, op := range block.Capella.Message.Body.BLSToExecutionChanges {
+				root, err := op.Message.HashTreeRoot()
+				if err != nil {
+					return nil, err
+				}
+				roots = append(roots, root)
+			}
+			return roots, nil
+		},
+	)
+}
+
+// operationStatuses obtains the statuses of operations, identified by the
+// roots of their messages.  Recent blocks are searched first, as inclusion in
+// a block supersedes presence in the pool.
+func operationStatuses(ctx context.Context,
+	client consensusclient.Service,
+	timeout time.Duration,
+	roots []phase0.Root,
+	poolPath string,
+	poolRoots func(json.RawMessage) ([]phase0.Root, error),
+	blockRoots func(*spec.VersionedSignedBeaconBlock) ([]phase0.Root, error),
+) (
+	[]*OperationStatus,
+	error,
+) {
+	statuses := make([]*OperationStatus, len(roots))
+	indices := make(map[phase0.Root][]int, len(roots))
+	for i, root := range roots {
+		indices[root] = append(indices[root], i)
+	}
+	remaining := len(roots)
+	mark := func(root phase0.Root, status *OperationStatus) {
+		for _, i := range indices[root] {
+			if statuses[i] == nil {
+				statuses[i] = status
+				remaining--
+			}
+		}
+	}
+
+	blockProvider, isProvider := client.(consensusclient.SignedBeaconBlockProvider)
+	if !isProvider {
+		return nil, errors.New("consensus node does not provide blocks")
+	}
+	block, err := blockProvider.SignedBeaconBlock(ctx, "head")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain head block")
+	}
+	if block == nil {
+		return nil, errors.New("no head block")
+	}
+	headSlot, err := block.Slot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain head slot")
+	}
+	for i := 0; i < OperationSearchSlots && remaining > 0; i++ {
+		if i > 0 {
+			if uint64(i) > uint64(headSlot) {
+				break
+			}
+			block, err = blockProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%d", headSlot-phase0.Slot(i)))
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block at slot %d", headSlot-phase0.Slot(i)))
+			}
+			if block == nil {
+				// Missed slot.
+				continue
+			}
+		}
+		included, err := blockRoots(block)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain operations in block")
+		}
+		for _, root := range included {
+			mark(root, &OperationStatus{
+				Included: true,
+				Slot:     headSlot - phase0.Slot(i),
+			})
+		}
+	}
+
+	if remaining > 0 {
+		data, err := fetchPool(ctx, client.Address(), timeout, poolPath)
+		if err != nil {
+			return nil, err
+		}
+		pooled, err := poolRoots(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse pool")
+		}
+		for _, root := range pooled {
+			mark(root, &OperationStatus{})
+		}
+	}
+
+	return statuses, nil
+}
+
+type poolJSON struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// fetchPool fetches the contents of an operation pool from a beacon node.
+// Pools are not available through the client, so are obtained directly.
+func fetchPool(ctx context.Context, address string, timeout time.Duration, path string) (json.RawMessage, error) {
+	data := &poolJSON{}
+	found, err := FetchBeaconNodeJSON(ctx, address, timeout, path, nil, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain pool")
+	}
+	if !found {
+		return nil, errors.New("failed to obtain pool: pool not available")
+	}
+
+	return data.Data, nil
+}
+
+// blockVoluntaryExits returns the voluntary exits included in a block.
+func blockVoluntaryExits(block *spec.VersionedSignedBeaconBlock) []*phase0.SignedVoluntaryExit {
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		if block.Phase0 != nil && block.Phase0.Message != nil && block.Phase0.Message.Body != nil {
+			return block.Phase0.Message.Body.VoluntaryExits
+		}
+	case spec.DataVersionAltair:
+		if block.Altair != nil && block.Altair.Message != nil && block.Altair.Message.Body != nil {
+			return block.Altair.Message.Body.VoluntaryExits
+		}
+	case spec.DataVersionBellatrix:
+		if block.Bellatrix != nil && block.Bellatrix.Message != nil && block.Bellatrix.Message.Body != nil {
+			return block.Bellatrix.Message.Body.VoluntaryExits
+		}
+	case spec.DataVersionCapella:
+		if block.Capella != nil && block.Capella.Message != nil && block.Capella.Message.Body != nil {
+			return block.Capella.Message.Body.VoluntaryExits
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+// testOperationsNode is a consensus node with blocks by slot and operation pools.
+type testOperationsNode struct {
+	address string
+	blocks  map[phase0.Slot]*spec.VersionedSignedBeaconBlock
+	head    phase0.Slot
+}
+
+func (*testOperationsNode) Name() string {
+	return "test"
+}
+
+func (n *testOperationsNode) Address() string {
+	return n.address
+}
+
+func (n *testOperationsNode) SignedBeaconBlock(_ context.Context, blockID string) (*spec.VersionedSignedBeaconBlock, error) {
+	if blockID == "head" {
+		return n.blocks[n.head], nil
+	}
+	for slot, block := range n.blocks {
+		if fmt.Sprintf("%d", slot) == blockID {
+			return block, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func testCapellaBlock(slot phase0.Slot, exits []*phase0.SignedVoluntaryExit, changes []*capella.SignedBLSToExecutionChange) *spec.VersionedSignedBeaconBlock {
+	return &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionCapella,
+		Capella: &capella.SignedBeaconBlock{
+			Message: &capella.BeaconBlock{
+				Slot: slot,
+				Body: &capella.BeaconBlockBody{
+					VoluntaryExits:        exits,
+					BLSToExecutionChanges: changes,
+				},
+			},
+		},
+	}
+}
+
+func testExit(index phase0.ValidatorIndex) *phase0.SignedVoluntaryExit {
+	return &phase0.SignedVoluntaryExit{
+		Message: &phase0.VoluntaryExit{
+			Epoch:          10,
+			ValidatorIndex: index,
+		},
+	}
+}
+
+func testChange(index phase0.ValidatorIndex) *capella.SignedBLSToExecutionChange {
+	return &capella.SignedBLSToExecutionChange{
+		Message: &capella.BLSToExecutionChange{
+			ValidatorIndex:     index,
+			ToExecutionAddress: bellatrix.ExecutionAddress{0x01},
+		},
+	}
+}
+
+func newTestOperationsNode(t *testing.T,
+	pooledExits []*phase0.SignedVoluntaryExit,
+	pooledChanges []*capella.SignedBLSToExecutionChange,
+) *testOperationsNode {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data []byte
+		var err error
+		switch r.URL.Path {
+		case "/eth/v1/beacon/pool/voluntary_exits":
+			data, err = json.Marshal(pooledExits)
+		case "/eth/v1/beacon/pool/bls_to_execution_changes":
+			data, err = json.Marshal(pooledChanges)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, err)
+		fmt.Fprintf(w, `{"data":%s}`, string(data))
+	}))
+	t.Cleanup(server.Close)
+
+	return &testOperationsNode{
+		address: server.URL,
+		head:    100,
+		blocks: map[phase0.Slot]*spec.VersionedSignedBeaconBlock{
+			100: testCapellaBlock(100, nil, nil),
+			98:  testCapellaBlock(98, []*phase0.SignedVoluntaryExit{testExit(1)}, []*capella.SignedBLSToExecutionChange{testChange(1)}),
+			// Outside of the search window.
+			20: testCapellaBlock(20, []*phase0.SignedVoluntaryExit{testExit(3)}, []*capella.SignedBLSToExecutionChange{testChange(3)}),
+		},
+	}
+}
+
+func TestVoluntaryExitStatus(t *testing.T) {
+	node := newTestOperationsNode(t, []*phase0.SignedVoluntaryExit{testExit(1), testExit(2)}, nil)
+
+	tests := []struct {
+		name   string
+		op     *phase0.SignedVoluntaryExit
+		status *OperationStatus
+	}{
+		{
+			name: "Included",
+			op:   testExit(1),
+			status: &OperationStatus{
+				Included: true,
+				Slot:     98,
+			},
+		},
+		{
+			name:   "Pooled",
+			op:     testExit(2),
+			status: &OperationStatus{},
+		},
+		{
+			name: "OutsideWindow",
+			op:   testExit(3),
+		},
+		{
+			name: "Unknown",
+			op:   testExit(4),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, err := VoluntaryExitStatus(context.Background(), node, time.Second, test.op)
+			require.NoError(t, err)
+			require.Equal(t, test.status, status)
+		})
+	}
+}
+
+func TestBLSToExecutionChangeStatuses(t *testing.T) {
+	node := newTestOperationsNode(t, nil, []*capella.SignedBLSToExecutionChange{testChange(2)})
+
+	statuses, err := BLSToExecutionChangeStatuses(context.Background(), node, time.Second, []*capella.SignedBLSToExecutionChange{
+		testChange(1),
+		testChange(2),
+		testChange(4),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*OperationStatus{
+		{Included: true, Slot: 98},
+		{},
+		nil,
+	}, statuses)
+	require.Equal(t, "already included in block at slot 98", statuses[0].String())
+	require.Equal(t, "already in node's pool", statuses[1].String())
+}
+
+func TestOperationStatusPoolUnavailable(t *testing.T) {
+	node := newTestOperationsNode(t, nil, nil)
+	node.address = "http://127.0.0.1:0"
+
+	_, err := VoluntaryExitStatus(context.Background(), node, time.Second, testExit(4))
+	require.ErrorContains(t, err, "failed to obtain pool")
+}
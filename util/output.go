@@ -0,0 +1,130 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats.
+const (
+	// OutputFormatJSON renders results as JSON.
+	OutputFormatJSON = "json"
+	// OutputFormatYAML renders results as YAML.
+	OutputFormatYAML = "yaml"
+	// OutputFormatTable renders results as a table.
+	OutputFormatTable = "table"
+)
+
+// TableRenderer is implemented by results that can be rendered as a table.
+type TableRenderer interface {
+	// TableHeaders provides the column headers of the table.
+	TableHeaders() []string
+	// TableRows provides the rows of the table.
+	TableRows() [][]string
+}
+
+// ValidOutputFormat returns true if the supplied output format is known.
+func ValidOutputFormat(format string) bool {
+	switch format {
+	case OutputFormatJSON, OutputFormatYAML, OutputFormatTable:
+		return true
+	default:
+		return false
+	}
+}
+
+// RenderOutput renders a command result in the given format.
+// JSON and YAML output is based on the JSON encoding of the result;
+// table output requires the result to implement TableRenderer.
+func RenderOutput(format string, result interface{}) (string, error) {
+	switch format {
+	case OutputFormatJSON:
+		data, err := json.Marshal(result)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to generate JSON")
+		}
+		return string(data), nil
+	case OutputFormatYAML:
+		return renderYAML(result)
+	case OutputFormatTable:
+		renderer, isRenderer := result.(TableRenderer)
+		if !isRenderer {
+			return "", errors.New("table output not supported for this result")
+		}
+		return renderTable(renderer)
+	default:
+		return "", fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+func renderYAML(result interface{}) (string, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate JSON")
+	}
+
+	// Parsing the JSON as YAML retains the field names and ordering of the JSON output.
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return "", errors.Wrap(err, "failed to parse JSON")
+	}
+	resetYAMLStyle(&node)
+
+	buf := new(bytes.Buffer)
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return "", errors.Wrap(err, "failed to generate YAML")
+	}
+	if err := encoder.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to generate YAML")
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// resetYAMLStyle removes the flow and quoting styles inherited from JSON,
+// so that the output uses block style.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+func renderTable(renderer TableRenderer) (string, error) {
+	buf := new(bytes.Buffer)
+	writer := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(writer, strings.Join(renderer.TableHeaders(), "\t")); err != nil {
+		return "", errors.Wrap(err, "failed to write table headers")
+	}
+	for _, row := range renderer.TableRows() {
+		if _, err := fmt.Fprintln(writer, strings.Join(row, "\t")); err != nil {
+			return "", errors.Wrap(err, "failed to write table row")
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return "", errors.Wrap(err, "failed to write table")
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
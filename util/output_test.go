@@ -0,0 +1,109 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type testResult struct {
+	Name  string `json:"name"`
+	Value uint64 `json:"value"`
+	Root  string `json:"root"`
+}
+
+func (r *testResult) TableHeaders() []string {
+	return []string{"Name", "Value"}
+}
+
+func (r *testResult) TableRows() [][]string {
+	return [][]string{
+		{r.Name, "1"},
+		{"longer name", "22"},
+	}
+}
+
+type testPlainResult struct {
+	Name string `json:"name"`
+}
+
+func TestValidOutputFormat(t *testing.T) {
+	require.True(t, util.ValidOutputFormat("json"))
+	require.True(t, util.ValidOutputFormat("yaml"))
+	require.True(t, util.ValidOutputFormat("table"))
+	require.False(t, util.ValidOutputFormat(""))
+	require.False(t, util.ValidOutputFormat("csv"))
+}
+
+func TestRenderOutput(t *testing.T) {
+	result := &testResult{
+		Name:  "test",
+		Value: 12345,
+		Root:  "0x0102",
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		result interface{}
+		res    string
+		err    string
+	}{
+		{
+			name:   "FormatUnknown",
+			format: "csv",
+			result: result,
+			err:    `unknown output format "csv"`,
+		},
+		{
+			name:   "JSON",
+			format: "json",
+			result: result,
+			res:    `{"name":"test","value":12345,"root":"0x0102"}`,
+		},
+		{
+			name:   "YAML",
+			format: "yaml",
+			result: result,
+			res:    "name: test\nvalue: 12345\nroot: \"0x0102\"",
+		},
+		{
+			name:   "Table",
+			format: "table",
+			result: result,
+			res:    "Name         Value\ntest         1\nlonger name  22",
+		},
+		{
+			name:   "TableUnsupported",
+			format: "table",
+			result: &testPlainResult{Name: "test"},
+			err:    "table output not supported for this result",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.RenderOutput(test.format, test.result)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
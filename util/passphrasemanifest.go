@@ -0,0 +1,125 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// passphraseManifest is the passphrase manifest supplied by the user, if any.
+var passphraseManifest *PassphraseManifest
+
+// PassphraseManifest holds the passphrases for individual accounts, allowing
+// accounts with different passphrases to be unlocked without trying every
+// passphrase against every account.
+type PassphraseManifest struct {
+	passphrases map[string]string
+}
+
+type passphraseManifestEntryJSON struct {
+	Passphrase     string `json:"passphrase"`
+	PassphraseFile string `json:"passphrase-file"`
+}
+
+// LoadPassphraseManifest loads a passphrase manifest.
+// The manifest is a JSON object keyed by account name, in the format
+// "<wallet>/<account>", or account public key.  Each entry provides either a
+// passphrase or a passphrase file, which if relative is relative to the
+// manifest.
+func LoadPassphraseManifest(filename string) (*PassphraseManifest, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read passphrase manifest")
+	}
+	entries := make(map[string]*passphraseManifestEntryJSON)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.Wrap(err, "failed to parse passphrase manifest")
+	}
+
+	manifest := &PassphraseManifest{
+		passphrases: make(map[string]string, len(entries)),
+	}
+	for key, entry := range entries {
+		if entry == nil {
+			return nil, fmt.Errorf("no passphrase for %s", key)
+		}
+		switch {
+		case entry.Passphrase != "" && entry.PassphraseFile != "":
+			return nil, fmt.Errorf("only one of passphrase and passphrase-file allowed for %s", key)
+		case entry.Passphrase != "":
+			manifest.passphrases[manifestKey(key)] = entry.Passphrase
+		case entry.PassphraseFile != "":
+			passphraseFile := entry.PassphraseFile
+			if !filepath.IsAbs(passphraseFile) {
+				passphraseFile = filepath.Join(filepath.Dir(filename), passphraseFile)
+			}
+			passphrases, err := readPassphrasesFromFile(passphraseFile)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain passphrase for %s", key))
+			}
+			if len(passphrases) != 1 {
+				return nil, fmt.Errorf("passphrase file for %s must contain a single passphrase", key)
+			}
+			manifest.passphrases[manifestKey(key)] = passphrases[0]
+		default:
+			return nil, fmt.Errorf("no passphrase for %s", key)
+		}
+	}
+
+	return manifest, nil
+}
+
+// Passphrase returns the passphrase for the first of the keys present in the manifest.
+func (m *PassphraseManifest) Passphrase(keys ...string) (string, bool) {
+	for _, key := range keys {
+		if passphrase, exists := m.passphrases[manifestKey(key)]; exists {
+			return passphrase, true
+		}
+	}
+
+	return "", false
+}
+
+// manifestPassphrases returns the passphrases with which to unlock the named
+// account, placing that from the passphrase manifest first if present.
+func manifestPassphrases(name string, account e2wtypes.Account, passphrases []string) []string {
+	if passphraseManifest == nil {
+		return passphrases
+	}
+	keys := []string{name}
+	if pubKey, err := BestPublicKey(account); err == nil {
+		keys = append(keys, fmt.Sprintf("%#x", pubKey.Marshal()))
+	}
+	passphrase, exists := passphraseManifest.Passphrase(keys...)
+	if !exists {
+		return passphrases
+	}
+
+	return append([]string{passphrase}, passphrases...)
+}
+
+// manifestKey normalises public keys, which may be supplied in any case.
+func manifestKey(key string) string {
+	if strings.HasPrefix(key, "0x") || strings.HasPrefix(key, "0X") {
+		return strings.ToLower(key)
+	}
+	return key
+}
@@ -0,0 +1,96 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestLoadPassphraseManifest(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret2.txt"), []byte("secret2\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "multiple.txt"), []byte("secret2\nsecret3\n"), 0o600))
+
+	tests := []struct {
+		name     string
+		manifest string
+		err      string
+	}{
+		{
+			name:     "Invalid",
+			manifest: `[]`,
+			err:      "failed to parse passphrase manifest: json: cannot unmarshal array into Go value of type map[string]*util.passphraseManifestEntryJSON",
+		},
+		{
+			name:     "EntryEmpty",
+			manifest: `{"Wallet/1":{}}`,
+			err:      "no passphrase for Wallet/1",
+		},
+		{
+			name:     "EntryNull",
+			manifest: `{"Wallet/1":null}`,
+			err:      "no passphrase for Wallet/1",
+		},
+		{
+			name:     "EntryBoth",
+			manifest: `{"Wallet/1":{"passphrase":"secret1","passphrase-file":"secret2.txt"}}`,
+			err:      "only one of passphrase and passphrase-file allowed for Wallet/1",
+		},
+		{
+			name:     "PassphraseFileMissing",
+			manifest: `{"Wallet/1":{"passphrase-file":"missing.txt"}}`,
+			err:      "failed to obtain passphrase for Wallet/1: failed to open passphrase file",
+		},
+		{
+			name:     "PassphraseFileMultiple",
+			manifest: `{"Wallet/1":{"passphrase-file":"multiple.txt"}}`,
+			err:      "passphrase file for Wallet/1 must contain a single passphrase",
+		},
+		{
+			name:     "Good",
+			manifest: `{"Wallet/1":{"passphrase":"secret1"},"0xA99A76ED7796F7BE22D5B7E85DEEB7C5677E88E511E0B337618F8C4EB61349B4BF2D153F649F7B53359FE8B94A38E44C":{"passphrase-file":"secret2.txt"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filename := filepath.Join(dir, "manifest.json")
+			require.NoError(t, os.WriteFile(filename, []byte(test.manifest), 0o600))
+			manifest, err := util.LoadPassphraseManifest(filename)
+			if test.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			passphrase, exists := manifest.Passphrase("Wallet/1")
+			require.True(t, exists)
+			require.Equal(t, "secret1", passphrase)
+
+			// Public keys match regardless of case.
+			passphrase, exists = manifest.Passphrase("Wallet/2", "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c")
+			require.True(t, exists)
+			require.Equal(t, "secret2", passphrase)
+
+			_, exists = manifest.Passphrase("Wallet/3")
+			require.False(t, exists)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+// progressInterval is the interval between updates of a progress display.
+var progressInterval = 200 * time.Millisecond
+
+// progressBarWidth is the number of characters in a progress bar.
+const progressBarWidth = 30
+
+// Progress displays the progress of a long-running operation.
+// A nil progress is valid, and does nothing.
+type Progress struct {
+	mutex    sync.Mutex
+	out      io.Writer
+	label    string
+	total    int
+	current  int
+	started  time.Time
+	done     chan struct{}
+	finished chan struct{}
+}
+
+// NewProgress creates a progress display on stderr for an operation with the
+// given number of steps, or 0 if the number of steps is not known in advance.
+// The display is only shown if stderr is a terminal and the quiet, verbose and
+// debug flags are not set, to avoid interfering with other output; otherwise
+// nil is returned.
+func NewProgress(label string, total int) *Progress {
+	if viper.GetBool("quiet") || viper.GetBool("verbose") || viper.GetBool("debug") {
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stderr.Fd())) {
+		return nil
+	}
+
+	return newProgress(os.Stderr, label, total)
+}
+
+func newProgress(out io.Writer, label string, total int) *Progress {
+	p := &Progress{
+		out:      out,
+		label:    label,
+		total:    total,
+		started:  time.Now(),
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(p.finished)
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				p.render()
+				fmt.Fprintln(p.out)
+				return
+			case <-ticker.C:
+				p.render()
+			}
+		}
+	}()
+
+	return p
+}
+
+// Add records the completion of the given number of steps.
+func (p *Progress) Add(steps int) {
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	p.current += steps
+	p.mutex.Unlock()
+}
+
+// Finish completes the progress display.
+func (p *Progress) Finish() {
+	if p == nil {
+		return
+	}
+	close(p.done)
+	<-p.finished
+}
+
+// render writes the current state of the progress display.
+func (p *Progress) render() {
+	p.mutex.Lock()
+	current := p.current
+	p.mutex.Unlock()
+	elapsed := time.Since(p.started).Round(time.Second)
+
+	var line string
+	switch {
+	case p.total > 0:
+		if current > p.total {
+			current = p.total
+		}
+		filled := progressBarWidth * current / p.total
+		line = fmt.Sprintf("%s [%s%s] %3d%% (%d/%d) %v",
+			p.label,
+			strings.Repeat("=", filled),
+			strings.Repeat(" ", progressBarWidth-filled),
+			100*current/p.total,
+			current,
+			p.total,
+			elapsed,
+		)
+	case current > 0:
+		line = fmt.Sprintf("%s %d %v", p.label, current, elapsed)
+	default:
+		line = fmt.Sprintf("%s %v", p.label, elapsed)
+	}
+	// Return to the start of the line and clear it before writing.
+	fmt.Fprintf(p.out, "\r\x1b[K%s", line)
+}
@@ -0,0 +1,76 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgress(t *testing.T) {
+	tests := []struct {
+		name  string
+		total int
+		steps int
+		res   string
+	}{
+		{
+			name: "Unknown",
+			res:  "Working 0s",
+		},
+		{
+			name:  "UnknownSteps",
+			steps: 7,
+			res:   "Working 7 0s",
+		},
+		{
+			name:  "Partial",
+			total: 4,
+			steps: 1,
+			res:   "Working [=======                       ]  25% (1/4) 0s",
+		},
+		{
+			name:  "Complete",
+			total: 4,
+			steps: 4,
+			res:   "Working [==============================] 100% (4/4) 0s",
+		},
+		{
+			name:  "Overflow",
+			total: 4,
+			steps: 5,
+			res:   "Working [==============================] 100% (4/4) 0s",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			progress := newProgress(out, "Working", test.total)
+			progress.Add(test.steps)
+			progress.Finish()
+			output := out.String()
+			require.True(t, strings.HasSuffix(output, "\r\x1b[K"+test.res+"\n"), output)
+		})
+	}
+}
+
+func TestProgressNil(t *testing.T) {
+	var progress *Progress
+	progress.Add(1)
+	progress.Finish()
+}
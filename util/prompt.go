@@ -0,0 +1,245 @@
+// Copyright © 2023 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/term"
+)
+
+// mnemonicLengths are the valid numbers of words in a mnemonic.
+var mnemonicLengths = map[int]bool{
+	12: true,
+	15: true,
+	18: true,
+	21: true,
+	24: true,
+}
+
+// maxMnemonicCompletions is the maximum number of candidate words shown for an
+// ambiguous entry.
+const maxMnemonicCompletions = 8
+
+// Prompter asks questions of the user and obtains their answers.
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	// terminal is the file descriptor of the input if it is a terminal, otherwise -1.
+	terminal int
+}
+
+// NewPrompter creates a new prompter that reads answers from in and writes questions to out.
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	p := &Prompter{
+		in:       bufio.NewReader(in),
+		out:      out,
+		terminal: -1,
+	}
+	if f, isFile := in.(*os.File); isFile && term.IsTerminal(int(f.Fd())) {
+		p.terminal = int(f.Fd())
+	}
+
+	return p
+}
+
+// Ask asks a free-form question, returning the default if no answer is supplied.
+func (p *Prompter) Ask(question string, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+
+	answer, err := p.readLine()
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return def, nil
+	}
+
+	return answer, nil
+}
+
+// AskSecret asks for a secret such as a mnemonic or passphrase.  If the input
+// is a terminal the answer is not echoed.
+func (p *Prompter) AskSecret(question string) (string, error) {
+	fmt.Fprintf(p.out, "%s: ", question)
+
+	return p.readSecretLine()
+}
+
+// AskMnemonic asks for a mnemonic a word at a time, completing each word from
+// the BIP-39 wordlist and rejecting words that are not in it.  Entry finishes
+// once 24 words have been supplied, or on an empty line after a valid number
+// of words.  If the input is a terminal the words are not echoed.
+func (p *Prompter) AskMnemonic() (string, error) {
+	fmt.Fprintln(p.out, "Enter the mnemonic one word at a time.  Words can be abbreviated to their first four letters.  Enter \"-\" to remove the previous word, or an empty line when all words have been entered.")
+
+	words := make([]string, 0, 24)
+	for len(words) < 24 {
+		fmt.Fprintf(p.out, "Word %d: ", len(words)+1)
+		answer, err := p.readSecretLine()
+		if err != nil {
+			return "", err
+		}
+
+		entries := strings.Fields(strings.ToLower(answer))
+		if len(entries) == 0 {
+			if mnemonicLengths[len(words)] {
+				break
+			}
+			fmt.Fprintf(p.out, "A mnemonic has 12, 15, 18, 21 or 24 words; %d entered so far\n", len(words))
+			continue
+		}
+
+		for _, entry := range entries {
+			if entry == "-" {
+				if len(words) > 0 {
+					words = words[:len(words)-1]
+				}
+				continue
+			}
+			word, err := completeMnemonicWord(entry)
+			if err != nil {
+				fmt.Fprintf(p.out, "%v\n", err)
+				break
+			}
+			words = append(words, word)
+		}
+	}
+
+	mnemonic := strings.Join(words, " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return "", errors.New("mnemonic checksum is invalid")
+	}
+
+	return mnemonic, nil
+}
+
+// completeMnemonicWord returns the BIP-39 word that starts with the entry.
+func completeMnemonicWord(entry string) (string, error) {
+	candidates := make([]string, 0)
+	for _, word := range bip39.GetWordList() {
+		if word == entry {
+			return word, nil
+		}
+		if strings.HasPrefix(word, entry) {
+			candidates = append(candidates, word)
+		}
+	}
+
+	switch {
+	case len(candidates) == 0:
+		return "", fmt.Errorf("%q is not a mnemonic word", entry)
+	case len(candidates) == 1:
+		return candidates[0], nil
+	case len(candidates) > maxMnemonicCompletions:
+		return "", fmt.Errorf("%q matches %d words; please enter more letters", entry, len(candidates))
+	default:
+		return "", fmt.Errorf("%q could be any of %s", entry, strings.Join(candidates, ", "))
+	}
+}
+
+// Choose asks a question with a fixed set of answers, repeating the question until
+// a valid answer is supplied.
+func (p *Prompter) Choose(question string, options []string, def string) (string, error) {
+	if len(options) == 0 {
+		return "", errors.New("no options supplied")
+	}
+
+	for {
+		answer, err := p.Ask(fmt.Sprintf("%s (%s)", question, strings.Join(options, "/")), def)
+		if err != nil {
+			return "", err
+		}
+		for _, option := range options {
+			if strings.EqualFold(answer, option) {
+				return option, nil
+			}
+		}
+		fmt.Fprintf(p.out, "Please answer one of %s\n", strings.Join(options, ", "))
+	}
+}
+
+// Confirm asks a yes/no question, returning the default if no answer is supplied.
+func (p *Prompter) Confirm(question string, def bool) (bool, error) {
+	options := "y/N"
+	if def {
+		options = "Y/n"
+	}
+
+	for {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, options)
+		answer, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintf(p.out, "Please answer yes or no\n")
+	}
+}
+
+// ConfirmTyped asks the user to confirm an action by typing the expected text,
+// returning true only if the text supplied matches exactly.
+func (p *Prompter) ConfirmTyped(question string, expected string) (bool, error) {
+	fmt.Fprintf(p.out, "%s: ", question)
+	answer, err := p.readLine()
+	if err != nil {
+		return false, err
+	}
+
+	return answer == expected, nil
+}
+
+// readSecretLine reads a single trimmed line of input, without echoing it if
+// the input is a terminal.
+func (p *Prompter) readSecretLine() (string, error) {
+	if p.terminal == -1 {
+		return p.readLine()
+	}
+
+	answer, err := term.ReadPassword(p.terminal)
+	// Move past the unechoed answer.
+	fmt.Fprintln(p.out)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read answer")
+	}
+
+	return strings.TrimSpace(string(answer)), nil
+}
+
+// readLine reads a single trimmed line of input.
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", errors.Wrap(err, "failed to read answer")
+	}
+
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,264 @@
+// Copyright © 2023 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestPrompterAsk(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		def   string
+		res   string
+		err   string
+	}{
+		{
+			name:  "Answer",
+			input: "answer\n",
+			res:   "answer",
+		},
+		{
+			name:  "Default",
+			input: "\n",
+			def:   "default",
+			res:   "default",
+		},
+		{
+			name:  "NoNewline",
+			input: "answer",
+			res:   "answer",
+		},
+		{
+			name:  "EOF",
+			input: "",
+			err:   "failed to read answer: EOF",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prompter := util.NewPrompter(strings.NewReader(test.input), &bytes.Buffer{})
+			res, err := prompter.Ask("Question", test.def)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestPrompterChoose(t *testing.T) {
+	out := &bytes.Buffer{}
+	prompter := util.NewPrompter(strings.NewReader("maybe\nOFFLINE\n"), out)
+	res, err := prompter.Choose("Mode", []string{"online", "offline"}, "")
+	require.NoError(t, err)
+	require.Equal(t, "offline", res)
+	require.Contains(t, out.String(), "Please answer one of online, offline")
+}
+
+func TestPrompterConfirm(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		def   bool
+		res   bool
+	}{
+		{
+			name:  "Yes",
+			input: "yes\n",
+			res:   true,
+		},
+		{
+			name:  "No",
+			input: "n\n",
+			def:   true,
+			res:   false,
+		},
+		{
+			name:  "DefaultTrue",
+			input: "\n",
+			def:   true,
+			res:   true,
+		},
+		{
+			name:  "Retry",
+			input: "what\ny\n",
+			res:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prompter := util.NewPrompter(strings.NewReader(test.input), &bytes.Buffer{})
+			res, err := prompter.Confirm("Proceed?", test.def)
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestPrompterConfirmTyped(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		res      bool
+		err      string
+	}{
+		{
+			name:     "Match",
+			input:    "123\n",
+			expected: "123",
+			res:      true,
+		},
+		{
+			name:     "Mismatch",
+			input:    "124\n",
+			expected: "123",
+			res:      false,
+		},
+		{
+			name:     "Empty",
+			input:    "\n",
+			expected: "123",
+			res:      false,
+		},
+		{
+			name:     "EOF",
+			input:    "",
+			expected: "123",
+			err:      "failed to read answer: EOF",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prompter := util.NewPrompter(strings.NewReader(test.input), &bytes.Buffer{})
+			res, err := prompter.ConfirmTyped("Confirm", test.expected)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestPrompterAskSecret(t *testing.T) {
+	// Input that is not a terminal is read as a line.
+	out := &bytes.Buffer{}
+	prompter := util.NewPrompter(strings.NewReader("secret words\n"), out)
+	res, err := prompter.AskSecret("Mnemonic")
+	require.NoError(t, err)
+	require.Equal(t, "secret words", res)
+	require.Equal(t, "Mnemonic: ", out.String())
+
+	_, err = prompter.AskSecret("Mnemonic")
+	require.EqualError(t, err, "failed to read answer: EOF")
+}
+
+func TestPrompterAskMnemonic(t *testing.T) {
+	mnemonic12 := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	mnemonic24 := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"
+
+	tests := []struct {
+		name   string
+		input  string
+		res    string
+		output string
+		err    string
+	}{
+		{
+			name:  "Empty",
+			input: "",
+			err:   "failed to read answer: EOF",
+		},
+		{
+			name:  "Words",
+			input: strings.Repeat("abandon\n", 11) + "about\n\n",
+			res:   mnemonic12,
+		},
+		{
+			name:  "Abbreviated",
+			input: strings.Repeat("aban\n", 11) + "abou\n\n",
+			res:   mnemonic12,
+		},
+		{
+			name:  "SingleLine",
+			input: mnemonic24 + "\n",
+			res:   mnemonic24,
+		},
+		{
+			name:   "UnknownWord",
+			input:  "xyzzy\n" + mnemonic12 + "\n\n",
+			res:    mnemonic12,
+			output: "\"xyzzy\" is not a mnemonic word\n",
+		},
+		{
+			name:   "Ambiguous",
+			input:  "abo\n" + mnemonic12 + "\n\n",
+			res:    mnemonic12,
+			output: "\"abo\" could be any of about, above\n",
+		},
+		{
+			name:   "TooShort",
+			input:  "ab\n" + mnemonic12 + "\n\n",
+			res:    mnemonic12,
+			output: "\"ab\" matches 10 words; please enter more letters\n",
+		},
+		{
+			name:  "Remove",
+			input: mnemonic12 + " abandon - \n\n",
+			res:   mnemonic12,
+		},
+		{
+			name:   "WrongLength",
+			input:  "abandon\n\n" + strings.Repeat("abandon\n", 10) + "about\n\n",
+			res:    mnemonic12,
+			output: "A mnemonic has 12, 15, 18, 21 or 24 words; 1 entered so far\n",
+		},
+		{
+			name:  "ChecksumInvalid",
+			input: strings.Repeat("abandon ", 12) + "\n\n",
+			err:   "mnemonic checksum is invalid",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			prompter := util.NewPrompter(strings.NewReader(test.input), out)
+			res, err := prompter.AskMnemonic()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+			if test.output != "" {
+				require.Contains(t, out.String(), test.output)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// releaseVersion is the version of ethdo recorded in provenance.
+var releaseVersion = "unknown"
+
+// SetReleaseVersion sets the version of ethdo recorded in provenance.
+func SetReleaseVersion(version string) {
+	releaseVersion = version
+}
+
+// Provenance contains metadata about the creation of signed operations.
+type Provenance struct {
+	EthdoVersion          string
+	Network               string
+	ForkVersion           phase0.Version
+	GenesisValidatorsRoot phase0.Root
+	CreatedAt             time.Time
+}
+
+type provenanceJSON struct {
+	EthdoVersion          string `json:"ethdo_version"`
+	Network               string `json:"network"`
+	ForkVersion           string `json:"fork_version"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	CreatedAt             string `json:"created_at"`
+}
+
+// NewProvenance creates provenance for operations signed with the given fork
+// version and genesis validators root.
+func NewProvenance(forkVersion phase0.Version, genesisValidatorsRoot phase0.Root) *Provenance {
+	return &Provenance{
+		EthdoVersion:          releaseVersion,
+		Network:               NetworkFromGenesisValidatorsRoot(genesisValidatorsRoot),
+		ForkVersion:           forkVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		CreatedAt:             time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// MarshalJSON implements custom JSON marshaller.
+func (p *Provenance) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&provenanceJSON{
+		EthdoVersion:          p.EthdoVersion,
+		Network:               p.Network,
+		ForkVersion:           fmt.Sprintf("%#x", p.ForkVersion),
+		GenesisValidatorsRoot: fmt.Sprintf("%#x", p.GenesisValidatorsRoot),
+		CreatedAt:             p.CreatedAt.Format(time.RFC3339),
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaller.
+func (p *Provenance) UnmarshalJSON(input []byte) error {
+	var data provenanceJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	p.EthdoVersion = data.EthdoVersion
+	p.Network = data.Network
+
+	if data.ForkVersion == "" {
+		return errors.New("fork version missing")
+	}
+	forkVersion, err := hex.DecodeString(strings.TrimPrefix(data.ForkVersion, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "fork version invalid")
+	}
+	if len(forkVersion) != phase0.ForkVersionLength {
+		return errors.New("fork version incorrect length")
+	}
+	copy(p.ForkVersion[:], forkVersion)
+
+	if data.GenesisValidatorsRoot == "" {
+		return errors.New("genesis validators root missing")
+	}
+	genesisValidatorsRoot, err := hex.DecodeString(strings.TrimPrefix(data.GenesisValidatorsRoot, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "genesis validators root invalid")
+	}
+	if len(genesisValidatorsRoot) != phase0.RootLength {
+		return errors.New("genesis validators root incorrect length")
+	}
+	copy(p.GenesisValidatorsRoot[:], genesisValidatorsRoot)
+
+	if data.CreatedAt != "" {
+		p.CreatedAt, err = time.Parse(time.RFC3339, data.CreatedAt)
+		if err != nil {
+			return errors.Wrap(err, "creation time invalid")
+		}
+	}
+
+	return nil
+}
+
+// ProvenanceFilename provides the name of the file holding the provenance
+// for the operations in the given file.
+func ProvenanceFilename(operationsFilename string) string {
+	return fmt.Sprintf("%s.provenance.json", strings.TrimSuffix(operationsFilename, filepath.Ext(operationsFilename)))
+}
+
+// WriteProvenance writes provenance alongside the given operations file.
+func WriteProvenance(operationsFilename string, provenance *Provenance) error {
+	data, err := json.Marshal(provenance)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal provenance")
+	}
+	filename := ProvenanceFilename(operationsFilename)
+	if err := os.WriteFile(filename, data, 0o600); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to write %s", filename))
+	}
+
+	return nil
+}
+
+// ReadProvenance reads the provenance for the given operations file, returning
+// nil if there is no provenance.
+func ReadProvenance(operationsFilename string) (*Provenance, error) {
+	filename := ProvenanceFilename(operationsFilename)
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to read %s", filename))
+	}
+
+	provenance := &Provenance{}
+	if err := json.Unmarshal(data, provenance); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse %s", filename))
+	}
+
+	return provenance, nil
+}
+
+// CheckGenesisValidatorsRoot checks that the operations were created for the
+// network with the given genesis validators root.
+func (p *Provenance) CheckGenesisValidatorsRoot(genesisValidatorsRoot phase0.Root) error {
+	if p.GenesisValidatorsRoot != genesisValidatorsRoot {
+		return fmt.Errorf("operations were created for network %s (genesis validators root %#x) but the chain is network %s (genesis validators root %#x)",
+			p.Network, p.GenesisValidatorsRoot, NetworkFromGenesisValidatorsRoot(genesisValidatorsRoot), genesisValidatorsRoot)
+	}
+
+	return nil
+}
+
+// CheckProvenance checks that the network recorded in the provenance of the
+// given operations file, if present, matches the network of the node.
+func CheckProvenance(ctx context.Context, eth2Client eth2client.Service, operationsFilename string) error {
+	provenance, err := ReadProvenance(operationsFilename)
+	if err != nil {
+		return err
+	}
+	if provenance == nil {
+		return nil
+	}
+
+	genesisProvider, isProvider := eth2Client.(eth2client.GenesisProvider)
+	if !isProvider {
+		return errors.New("client does not provide genesis")
+	}
+	genesis, err := genesisProvider.Genesis(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain genesis")
+	}
+
+	return provenance.CheckGenesisValidatorsRoot(genesis.GenesisValidatorsRoot)
+}
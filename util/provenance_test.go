@@ -0,0 +1,148 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestProvenanceFilename(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		expected string
+	}{
+		{
+			name:     "JSON",
+			filename: "exit-operation.json",
+			expected: "exit-operation.provenance.json",
+		},
+		{
+			name:     "SSZ",
+			filename: "change-operations.ssz",
+			expected: "change-operations.provenance.json",
+		},
+		{
+			name:     "NoExtension",
+			filename: filepath.Join("dir", "exit"),
+			expected: filepath.Join("dir", "exit.provenance.json"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, util.ProvenanceFilename(test.filename))
+		})
+	}
+}
+
+func TestProvenanceJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		err   string
+	}{
+		{
+			name:  "Empty",
+			input: []byte(`{}`),
+			err:   "fork version missing",
+		},
+		{
+			name:  "ForkVersionInvalid",
+			input: []byte(`{"fork_version":"0xinvalid","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"}`),
+			err:   "fork version invalid: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name:  "ForkVersionShort",
+			input: []byte(`{"fork_version":"0x030000","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"}`),
+			err:   "fork version incorrect length",
+		},
+		{
+			name:  "GenesisValidatorsRootMissing",
+			input: []byte(`{"fork_version":"0x03000000"}`),
+			err:   "genesis validators root missing",
+		},
+		{
+			name:  "GenesisValidatorsRootShort",
+			input: []byte(`{"fork_version":"0x03000000","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe"}`),
+			err:   "genesis validators root incorrect length",
+		},
+		{
+			name:  "CreatedAtInvalid",
+			input: []byte(`{"fork_version":"0x03000000","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95","created_at":"yesterday"}`),
+			err:   `creation time invalid: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`,
+		},
+		{
+			name:  "Good",
+			input: []byte(`{"ethdo_version":"1.28.0","network":"Mainnet","fork_version":"0x03000000","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95","created_at":"2023-04-01T12:00:00Z"}`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var provenance util.Provenance
+			err := json.Unmarshal(test.input, &provenance)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				rt, err := json.Marshal(&provenance)
+				require.NoError(t, err)
+				require.Equal(t, string(test.input), string(rt))
+			}
+		})
+	}
+}
+
+func TestProvenanceReadWrite(t *testing.T) {
+	dir := t.TempDir()
+	operationsFilename := filepath.Join(dir, "exit-operation.json")
+
+	// No provenance.
+	provenance, err := util.ReadProvenance(operationsFilename)
+	require.NoError(t, err)
+	require.Nil(t, provenance)
+
+	mainnetRoot := phase0.Root{
+		0x4b, 0x36, 0x3d, 0xb9, 0x4e, 0x28, 0x61, 0x20, 0xd7, 0x6e, 0xb9, 0x05, 0x34, 0x0f, 0xdd, 0x4e,
+		0x54, 0xbf, 0xe9, 0xf0, 0x6b, 0xf3, 0x3f, 0xf6, 0xcf, 0x5a, 0xd2, 0x7f, 0x51, 0x1b, 0xfe, 0x95,
+	}
+	written := util.NewProvenance(phase0.Version{0x03, 0x00, 0x00, 0x00}, mainnetRoot)
+	require.Equal(t, "Mainnet", written.Network)
+	require.NoError(t, util.WriteProvenance(operationsFilename, written))
+
+	provenance, err = util.ReadProvenance(operationsFilename)
+	require.NoError(t, err)
+	require.Equal(t, written.ForkVersion, provenance.ForkVersion)
+	require.Equal(t, written.GenesisValidatorsRoot, provenance.GenesisValidatorsRoot)
+	require.True(t, written.CreatedAt.Equal(provenance.CreatedAt))
+	require.True(t, time.Since(provenance.CreatedAt) < time.Minute)
+
+	require.NoError(t, provenance.CheckGenesisValidatorsRoot(mainnetRoot))
+	require.EqualError(t, provenance.CheckGenesisValidatorsRoot(phase0.Root{0x01}),
+		"operations were created for network Mainnet (genesis validators root 0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95) but the chain is network Unknown (genesis validators root 0x0100000000000000000000000000000000000000000000000000000000000000)")
+
+	// Bad provenance.
+	require.NoError(t, os.WriteFile(util.ProvenanceFilename(operationsFilename), []byte("bad"), 0o600))
+	_, err = util.ReadProvenance(operationsFilename)
+	require.Error(t, err)
+}
@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// ReadPubKeysFile reads a file containing validator public keys, one per line.
+// Blank lines and lines starting with '#' are ignored.  The public keys are
+// returned in the format 0x…, in the order in which they appear in the file.
+func ReadPubKeysFile(filename string) ([]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read public keys file")
+	}
+
+	return parsePubKeys(data)
+}
+
+func parsePubKeys(data []byte) ([]string, error) {
+	res := make([]string, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(text, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid public key on line %d", line))
+		}
+		if len(pubKey) != phase0.PublicKeyLength {
+			return nil, fmt.Errorf("public key on line %d has incorrect length", line)
+		}
+		res = append(res, fmt.Sprintf("%#x", pubKey))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to parse public keys file")
+	}
+	if len(res) == 0 {
+		return nil, errors.New("no public keys in public keys file")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,83 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePubKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		pubKeys []string
+		err     string
+	}{
+		{
+			name:  "Empty",
+			input: "",
+			err:   "no public keys in public keys file",
+		},
+		{
+			name:  "CommentsOnly",
+			input: "# Validators\n\n",
+			err:   "no public keys in public keys file",
+		},
+		{
+			name:  "Invalid",
+			input: "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c\nbad\n",
+			err:   "invalid public key on line 2: encoding/hex: odd length hex string",
+		},
+		{
+			name:  "Short",
+			input: "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e4\n",
+			err:   "public key on line 1 has incorrect length",
+		},
+		{
+			name:  "Good",
+			input: "# Validators\n0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c\n\n  b89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b  \n",
+			pubKeys: []string{
+				"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+				"0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pubKeys, err := parsePubKeys([]byte(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.pubKeys, pubKeys)
+			}
+		})
+	}
+}
+
+func TestReadPubKeysFile(t *testing.T) {
+	_, err := ReadPubKeysFile(filepath.Join(t.TempDir(), "missing.txt"))
+	require.ErrorContains(t, err, "failed to read public keys file")
+
+	filename := filepath.Join(t.TempDir(), "pubkeys.txt")
+	require.NoError(t, os.WriteFile(filename, []byte("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c\n"), 0600))
+	pubKeys, err := ReadPubKeysFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, []string{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"}, pubKeys)
+}
@@ -0,0 +1,130 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// qrFramePrefix identifies a QR code frame generated by ethdo, and the
+// version of the frame format.
+const qrFramePrefix = "ETHDO1"
+
+// QRFrames splits data into frames, each of which is small enough to be
+// carried by a single QR code.  Each frame is of the form
+// "ETHDO1:index/total:checksum:payload" where the checksum is common to all
+// frames for the same data, allowing frames to be scanned in any order and
+// reassembled with QRData.
+func QRFrames(data []byte, chunkSize int) ([]string, error) {
+	if len(data) == 0 {
+		return nil, errors.New("no data")
+	}
+	if chunkSize < 1 {
+		return nil, errors.New("chunk size must be at least 1")
+	}
+
+	checksum := qrChecksum(data)
+	total := (len(data) + chunkSize - 1) / chunkSize
+	frames := make([]string, 0, total)
+	for i := 0; i < total; i++ {
+		end := (i + 1) * chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		frames = append(frames, fmt.Sprintf("%s:%d/%d:%s:%s",
+			qrFramePrefix,
+			i+1,
+			total,
+			checksum,
+			base64.StdEncoding.EncodeToString(data[i*chunkSize:end]),
+		))
+	}
+
+	return frames, nil
+}
+
+// QRData reassembles the data from frames generated by QRFrames.  Frames can
+// be supplied in any order, and duplicates are ignored.
+func QRData(frames []string) ([]byte, error) {
+	if len(frames) == 0 {
+		return nil, errors.New("no frames")
+	}
+
+	checksum := ""
+	total := 0
+	chunks := make(map[int][]byte)
+	for _, frame := range frames {
+		parts := strings.SplitN(strings.TrimSpace(frame), ":", 4)
+		if len(parts) != 4 || parts[0] != qrFramePrefix {
+			return nil, errors.New("not an ethdo QR code")
+		}
+		position := strings.Split(parts[1], "/")
+		if len(position) != 2 {
+			return nil, fmt.Errorf("invalid frame position %q", parts[1])
+		}
+		index, err := strconv.Atoi(position[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid frame position %q", parts[1])
+		}
+		frameTotal, err := strconv.Atoi(position[1])
+		if err != nil || frameTotal < 1 || index < 1 || index > frameTotal {
+			return nil, fmt.Errorf("invalid frame position %q", parts[1])
+		}
+		if checksum == "" {
+			checksum = parts[2]
+			total = frameTotal
+		}
+		if parts[2] != checksum || frameTotal != total {
+			return nil, errors.New("frames are from different data")
+		}
+		chunk, err := base64.StdEncoding.DecodeString(parts[3])
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid payload for frame %d", index))
+		}
+		chunks[index] = chunk
+	}
+
+	missing := make([]string, 0)
+	for i := 1; i <= total; i++ {
+		if _, exists := chunks[i]; !exists {
+			missing = append(missing, strconv.Itoa(i))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing frames %s of %d", strings.Join(missing, ","), total)
+	}
+
+	data := make([]byte, 0)
+	for i := 1; i <= total; i++ {
+		data = append(data, chunks[i]...)
+	}
+	if qrChecksum(data) != checksum {
+		return nil, errors.New("checksum mismatch")
+	}
+
+	return data, nil
+}
+
+// qrChecksum returns a short checksum for data.
+func qrChecksum(data []byte) string {
+	hash := sha256.Sum256(data)
+
+	return fmt.Sprintf("%x", hash[:4])
+}
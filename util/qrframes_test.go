@@ -0,0 +1,164 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestQRFrames(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      []byte
+		chunkSize int
+		frames    []string
+		err       string
+	}{
+		{
+			name:      "Empty",
+			chunkSize: 10,
+			err:       "no data",
+		},
+		{
+			name:      "ChunkSizeZero",
+			data:      []byte("hello"),
+			chunkSize: 0,
+			err:       "chunk size must be at least 1",
+		},
+		{
+			name:      "Single",
+			data:      []byte("hello"),
+			chunkSize: 10,
+			frames: []string{
+				"ETHDO1:1/1:2cf24dba:aGVsbG8=",
+			},
+		},
+		{
+			name:      "Multiple",
+			data:      []byte("hello"),
+			chunkSize: 2,
+			frames: []string{
+				"ETHDO1:1/3:2cf24dba:aGU=",
+				"ETHDO1:2/3:2cf24dba:bGw=",
+				"ETHDO1:3/3:2cf24dba:bw==",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			frames, err := util.QRFrames(test.data, test.chunkSize)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.frames, frames)
+			}
+		})
+	}
+}
+
+func TestQRData(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []string
+		data   []byte
+		err    string
+	}{
+		{
+			name: "Empty",
+			err:  "no frames",
+		},
+		{
+			name:   "NotEthdo",
+			frames: []string{"https://example.com/"},
+			err:    "not an ethdo QR code",
+		},
+		{
+			name:   "PositionInvalid",
+			frames: []string{"ETHDO1:4/3:2cf24dba:aGU="},
+			err:    `invalid frame position "4/3"`,
+		},
+		{
+			name:   "PayloadInvalid",
+			frames: []string{"ETHDO1:1/1:2cf24dba:!!!"},
+			err:    "invalid payload for frame 1: illegal base64 data at input byte 0",
+		},
+		{
+			name: "DifferentData",
+			frames: []string{
+				"ETHDO1:1/3:2cf24dba:aGU=",
+				"ETHDO1:2/3:01020304:bGw=",
+			},
+			err: "frames are from different data",
+		},
+		{
+			name: "Missing",
+			frames: []string{
+				"ETHDO1:2/3:2cf24dba:bGw=",
+			},
+			err: "missing frames 1,3 of 3",
+		},
+		{
+			name: "ChecksumMismatch",
+			frames: []string{
+				"ETHDO1:1/1:2cf24dba:aGVsbG0=",
+			},
+			err: "checksum mismatch",
+		},
+		{
+			name: "Single",
+			frames: []string{
+				"ETHDO1:1/1:2cf24dba:aGVsbG8=",
+			},
+			data: []byte("hello"),
+		},
+		{
+			name: "OutOfOrderWithDuplicate",
+			frames: []string{
+				"ETHDO1:3/3:2cf24dba:bw==",
+				"ETHDO1:1/3:2cf24dba:aGU=",
+				"ETHDO1:3/3:2cf24dba:bw==",
+				"ETHDO1:2/3:2cf24dba:bGw=",
+			},
+			data: []byte("hello"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := util.QRData(test.frames)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.data, data)
+			}
+		})
+	}
+}
+
+func TestQRRoundTrip(t *testing.T) {
+	data := []byte(strings.Repeat(`{"message":{"epoch":"1","validator_index":"2"}}`, 50))
+	frames, err := util.QRFrames(data, 700)
+	require.NoError(t, err)
+	require.Len(t, frames, 4)
+	res, err := util.QRData(frames)
+	require.NoError(t, err)
+	require.Equal(t, data, res)
+}
@@ -0,0 +1,261 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	eth2http "github.com/attestantio/go-eth2-client/http"
+	"github.com/pkg/errors"
+)
+
+// beaconNodeRetries is the number of times that a beacon node API call that fails
+// with a transient error is retried.
+var beaconNodeRetries int
+
+// beaconNodeRetryBackoff is the delay before the first retry of a beacon node API
+// call; the delay doubles for each subsequent retry.
+var beaconNodeRetryBackoff = time.Second
+
+// maxRetryBackoff is the maximum delay between retries of a beacon node API call.
+var maxRetryBackoff = time.Minute
+
+// SetBeaconNodeRetries sets the number of times that beacon node API calls that
+// fail with a transient error, such as a timeout or a server error, are retried,
+// and the initial delay between retries.
+func SetBeaconNodeRetries(retries int, backoff time.Duration) error {
+	if retries < 0 {
+		return errors.New("retries cannot be negative")
+	}
+	if retries > 0 && backoff <= 0 {
+		return errors.New("retry backoff must be greater than 0")
+	}
+	beaconNodeRetries = retries
+	beaconNodeRetryBackoff = backoff
+	resetBeaconNodeClients()
+
+	return nil
+}
+
+// retryTimeout returns the total time allowed for a beacon node API call given
+// the timeout for each attempt, including all retries and the delays between them.
+func retryTimeout(timeout time.Duration) time.Duration {
+	total := timeout
+	for attempt := 0; attempt < beaconNodeRetries; attempt++ {
+		total += timeout + maxRetryDelay(beaconNodeRetryBackoff, attempt)
+	}
+
+	return total
+}
+
+// maxRetryDelay returns the maximum delay before the given retry.
+func maxRetryDelay(backoff time.Duration, attempt int) time.Duration {
+	delay := backoff
+	for i := 0; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+
+	return delay
+}
+
+// retryDelay returns the delay before the given retry, which is between half and
+// all of the maximum delay so that multiple callers do not retry in lockstep.
+func retryDelay(backoff time.Duration, attempt int) time.Duration {
+	delay := maxRetryDelay(backoff, attempt)
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+
+	// #nosec G404
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// idempotentPostPaths are the paths of beacon node API endpoints that use POST
+// to supply parameters too large for a query string, but only obtain data and
+// so can safely be retried.
+var idempotentPostPaths = []*regexp.Regexp{
+	regexp.MustCompile(`^/eth/v1/beacon/states/[^/]+/validators$`),
+	regexp.MustCompile(`^/eth/v1/beacon/states/[^/]+/validator_balances$`),
+	regexp.MustCompile(`^/eth/v1/validator/duties/attester/[0-9]+$`),
+	regexp.MustCompile(`^/eth/v1/validator/duties/sync/[0-9]+$`),
+	regexp.MustCompile(`^/eth/v1/beacon/rewards/attestations/[0-9]+$`),
+	regexp.MustCompile(`^/eth/v1/beacon/rewards/sync_committee/[^/]+$`),
+}
+
+// idempotent returns true if sending the request more than once has the same
+// effect as sending it once.
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		for _, path := range idempotentPostPaths {
+			if path.MatchString(req.URL.Path) {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return false
+	}
+}
+
+// retryable returns true if the result of an API call indicates a transient
+// failure that is worth retrying.
+func retryable(ctx context.Context, res *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		// The caller has given up.
+		return false
+	}
+	if err != nil {
+		var apiErr eth2http.Error
+		if errors.As(err, &apiErr) {
+			// The beacon node, or the proxy in front of it, responded with an error.
+			return retryableStatus(apiErr.StatusCode)
+		}
+		return transientError(err)
+	}
+
+	return retryableStatus(res.StatusCode)
+}
+
+// retryableStatus returns true if the status code of a response indicates a
+// transient failure.
+func retryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusNotImplemented, http.StatusHTTPVersionNotSupported:
+		// Retrying will not change the outcome.
+		return false
+	default:
+		return statusCode >= http.StatusInternalServerError
+	}
+}
+
+// transientError returns true if the error is a timeout or a dropped connection.
+func transientError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retryTransport retries requests that fail with a transient error.  Only
+// requests that are idempotent are retried; others, such as the submission
+// of blocks and operations, are attempted once.
+type retryTransport struct {
+	next    http.RoundTripper
+	retries int
+	backoff time.Duration
+	timeout time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The body is held so that it can be sent again.
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read request body")
+		}
+	}
+
+	// Event streams remain open indefinitely, so cannot have a timeout.
+	streaming := strings.Contains(req.Header.Get("Accept"), "text/event-stream")
+
+	retries := t.retries
+	if !idempotent(req) {
+		// Sending the request again could repeat its effects.
+		retries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		var ctx context.Context
+		var cancel context.CancelFunc
+		if t.timeout > 0 && !streaming {
+			ctx, cancel = context.WithTimeout(req.Context(), t.timeout)
+		} else {
+			ctx, cancel = context.WithCancel(req.Context())
+		}
+		attemptReq := req.Clone(ctx)
+		if body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+			attemptReq.ContentLength = int64(len(body))
+		}
+
+		res, err := t.next.RoundTrip(attemptReq)
+		if attempt == retries || !retryable(req.Context(), res, err) {
+			if err != nil {
+				cancel()
+
+				return nil, err
+			}
+			// The attempt's context must remain live until the body has been read.
+			res.Body = &cancelOnCloseBody{
+				ReadCloser: res.Body,
+				cancel:     cancel,
+			}
+
+			return res, nil
+		}
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+		cancel()
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(retryDelay(t.backoff, attempt)):
+		}
+	}
+}
+
+// cancelOnCloseBody cancels the context of a request when its response body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err
+}
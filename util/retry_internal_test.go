@@ -0,0 +1,367 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	eth2http "github.com/attestantio/go-eth2-client/http"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetBeaconNodeRetries(t *testing.T) {
+	defer func() {
+		beaconNodeRetries = 0
+		beaconNodeRetryBackoff = time.Second
+	}()
+
+	require.EqualError(t, SetBeaconNodeRetries(-1, time.Second), "retries cannot be negative")
+	require.EqualError(t, SetBeaconNodeRetries(3, 0), "retry backoff must be greater than 0")
+	require.NoError(t, SetBeaconNodeRetries(0, 0))
+	require.NoError(t, SetBeaconNodeRetries(3, 2*time.Second))
+	require.Equal(t, 3, beaconNodeRetries)
+	require.Equal(t, 2*time.Second, beaconNodeRetryBackoff)
+	// 4 attempts of 10s, plus delays of 2s, 4s and 8s.
+	require.Equal(t, 54*time.Second, retryTimeout(10*time.Second))
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff time.Duration
+		attempt int
+		max     time.Duration
+	}{
+		{
+			name:    "First",
+			backoff: time.Second,
+			attempt: 0,
+			max:     time.Second,
+		},
+		{
+			name:    "Third",
+			backoff: time.Second,
+			attempt: 2,
+			max:     4 * time.Second,
+		},
+		{
+			name:    "Capped",
+			backoff: time.Second,
+			attempt: 100,
+			max:     maxRetryBackoff,
+		},
+		{
+			name:    "Tiny",
+			backoff: time.Nanosecond,
+			attempt: 0,
+			max:     time.Nanosecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.max, maxRetryDelay(test.backoff, test.attempt))
+			for i := 0; i < 100; i++ {
+				delay := retryDelay(test.backoff, test.attempt)
+				require.GreaterOrEqual(t, delay, test.max/2)
+				require.LessOrEqual(t, delay, test.max)
+			}
+		})
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		status int
+		err    error
+		res    bool
+	}{
+		{
+			name:   "OK",
+			ctx:    context.Background(),
+			status: http.StatusOK,
+		},
+		{
+			name:   "NotFound",
+			ctx:    context.Background(),
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "BadRequest",
+			ctx:    context.Background(),
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "InternalServerError",
+			ctx:    context.Background(),
+			status: http.StatusInternalServerError,
+			res:    true,
+		},
+		{
+			name:   "ServiceUnavailable",
+			ctx:    context.Background(),
+			status: http.StatusServiceUnavailable,
+			res:    true,
+		},
+		{
+			name:   "NotImplemented",
+			ctx:    context.Background(),
+			status: http.StatusNotImplemented,
+		},
+		{
+			name: "Timeout",
+			ctx:  context.Background(),
+			err:  errors.Wrap(context.DeadlineExceeded, "request failed"),
+			res:  true,
+		},
+		{
+			name: "UnexpectedEOF",
+			ctx:  context.Background(),
+			err:  io.ErrUnexpectedEOF,
+			res:  true,
+		},
+		{
+			name: "OtherError",
+			ctx:  context.Background(),
+			err:  errors.New("unsupported protocol scheme"),
+		},
+		{
+			name: "ClientServerError",
+			ctx:  context.Background(),
+			err:  errors.Wrap(eth2http.Error{Method: http.MethodGet, StatusCode: http.StatusGatewayTimeout}, "failed to confirm node connection"),
+			res:  true,
+		},
+		{
+			name: "ClientBadRequest",
+			ctx:  context.Background(),
+			err:  errors.Wrap(eth2http.Error{Method: http.MethodGet, StatusCode: http.StatusBadRequest}, "failed to confirm node connection"),
+		},
+		{
+			name:   "CallerCancelled",
+			ctx:    cancelledCtx,
+			status: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res *http.Response
+			if test.err == nil {
+				res = &http.Response{StatusCode: test.status}
+			}
+			require.Equal(t, test.res, retryable(test.ctx, res, test.err))
+		})
+	}
+}
+
+func TestIdempotent(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		res    bool
+	}{
+		{
+			name:   "Get",
+			method: http.MethodGet,
+			path:   "/eth/v1/node/version",
+			res:    true,
+		},
+		{
+			name:   "Head",
+			method: http.MethodHead,
+			path:   "/eth/v1/node/health",
+			res:    true,
+		},
+		{
+			name:   "PostValidators",
+			method: http.MethodPost,
+			path:   "/eth/v1/beacon/states/head/validators",
+			res:    true,
+		},
+		{
+			name:   "PostAttesterDuties",
+			method: http.MethodPost,
+			path:   "/eth/v1/validator/duties/attester/100",
+			res:    true,
+		},
+		{
+			name:   "PostSyncDuties",
+			method: http.MethodPost,
+			path:   "/eth/v1/validator/duties/sync/100",
+			res:    true,
+		},
+		{
+			name:   "PostAttestationRewards",
+			method: http.MethodPost,
+			path:   "/eth/v1/beacon/rewards/attestations/100",
+			res:    true,
+		},
+		{
+			name:   "PostSyncCommitteeRewards",
+			method: http.MethodPost,
+			path:   "/eth/v1/beacon/rewards/sync_committee/head",
+			res:    true,
+		},
+		{
+			name:   "PostVoluntaryExit",
+			method: http.MethodPost,
+			path:   "/eth/v1/beacon/pool/voluntary_exits",
+		},
+		{
+			name:   "PostBlock",
+			method: http.MethodPost,
+			path:   "/eth/v1/beacon/blocks",
+		},
+		{
+			name:   "PostValidatorsPrefix",
+			method: http.MethodPost,
+			path:   "/eth/v1/beacon/states/head/validators/extra",
+		},
+		{
+			name:   "Delete",
+			method: http.MethodDelete,
+			path:   "/eth/v1/keystores",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, "http://localhost:5052"+test.path, nil)
+			require.NoError(t, err)
+			require.Equal(t, test.res, idempotent(req))
+		})
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := atomic.AddInt32(&calls, 1)
+		switch r.URL.Path {
+		case "/flaky", "/eth/v1/validator/duties/attester/10":
+			if call < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(append([]byte(r.Method), body...))
+		case "/slow":
+			if call < 2 {
+				time.Sleep(200 * time.Millisecond)
+			}
+			_, _ = w.Write([]byte("done"))
+		case "/bad":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &retryTransport{
+			next:    http.DefaultTransport,
+			retries: 2,
+			backoff: time.Millisecond,
+			timeout: 100 * time.Millisecond,
+		},
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+		body   string
+		calls  int32
+	}{
+		{
+			name:   "Flaky",
+			method: http.MethodGet,
+			path:   "/flaky",
+			status: http.StatusOK,
+			body:   "GETrequest",
+			calls:  3,
+		},
+		{
+			name:   "FlakyPost",
+			method: http.MethodPost,
+			path:   "/flaky",
+			status: http.StatusServiceUnavailable,
+			calls:  1,
+		},
+		{
+			name:   "FlakyIdempotentPost",
+			method: http.MethodPost,
+			path:   "/eth/v1/validator/duties/attester/10",
+			status: http.StatusOK,
+			body:   "POSTrequest",
+			calls:  3,
+		},
+		{
+			name:   "Slow",
+			method: http.MethodGet,
+			path:   "/slow",
+			status: http.StatusOK,
+			body:   "done",
+			calls:  2,
+		},
+		{
+			name:   "Fatal",
+			method: http.MethodGet,
+			path:   "/bad",
+			status: http.StatusBadRequest,
+			calls:  1,
+		},
+		{
+			name:   "Exhausted",
+			method: http.MethodGet,
+			path:   "/broken",
+			status: http.StatusInternalServerError,
+			calls:  3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+			req, err := http.NewRequest(test.method, server.URL+test.path, strings.NewReader("request"))
+			require.NoError(t, err)
+			res, err := client.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.Equal(t, test.status, res.StatusCode)
+			if test.body != "" {
+				require.Equal(t, test.body, string(body))
+			}
+			require.Equal(t, test.calls, atomic.LoadInt32(&calls))
+		})
+	}
+}
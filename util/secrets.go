@@ -0,0 +1,245 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// SetupSecrets obtains secrets supplied through standard input, files or file
+// descriptors rather than on the command line, so that they do not appear in
+// shell history or process listings.  A mnemonic of "-" is entered
+// interactively.
+func SetupSecrets(stdin io.Reader) error {
+	passphraseManifest = nil
+	if viper.GetString("passphrase-manifest") != "" {
+		manifest, err := LoadPassphraseManifest(viper.GetString("passphrase-manifest"))
+		if err != nil {
+			return err
+		}
+		passphraseManifest = manifest
+	}
+
+	if viper.GetString("mnemonic") == "-" {
+		if viper.GetBool("mnemonic-stdin") {
+			return errors.New("only one of mnemonic and mnemonic-stdin allowed")
+		}
+		mnemonic, err := NewPrompter(stdin, os.Stderr).AskMnemonic()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain mnemonic")
+		}
+		viper.Set("mnemonic", mnemonic)
+	}
+
+	if viper.GetBool("mnemonic-stdin") {
+		if viper.GetString("mnemonic") != "" {
+			return errors.New("only one of mnemonic and mnemonic-stdin allowed")
+		}
+		mnemonic, err := ReadMnemonic(stdin)
+		if err != nil {
+			return errors.Wrap(err, "failed to read mnemonic from standard input")
+		}
+		viper.Set("mnemonic", mnemonic)
+	}
+
+	for _, flag := range passphraseFlags {
+		if err := setupFlagPassphrase(flag); err != nil {
+			return err
+		}
+	}
+	if err := checkPassphraseFlags(); err != nil {
+		return err
+	}
+
+	passphraseFile := viper.GetString("passphrase-file")
+	passphraseFD := viper.GetString("passphrase-fd")
+	if passphraseFile == "" && passphraseFD == "" {
+		return nil
+	}
+	if passphraseFile != "" && passphraseFD != "" {
+		return errors.New("only one of passphrase-file and ETHDO_PASSPHRASE_FD allowed")
+	}
+	if len(GetPassphrases()) > 0 {
+		return errors.New("passphrase cannot be supplied alongside passphrase-file or ETHDO_PASSPHRASE_FD")
+	}
+
+	var passphrases []string
+	var err error
+	if passphraseFile != "" {
+		passphrases, err = readPassphrasesFromFile(passphraseFile)
+	} else {
+		passphrases, err = readPassphrasesFromFD(passphraseFD, "ETHDO_PASSPHRASE_FD")
+	}
+	if err != nil {
+		return err
+	}
+	viper.Set("passphrase", passphrases)
+
+	return nil
+}
+
+// passphraseFlags are the flags that supply a single passphrase.
+var passphraseFlags = []string{
+	"export-passphrase",
+	"keystore-passphrase",
+	"new-passphrase",
+	"store-passphrase",
+	"storepassphrase",
+	"wallet-passphrase",
+	"walletpassphrase",
+}
+
+// setupFlagPassphrase obtains the passphrase for a passphrase flag from the
+// file or file descriptor given in the flag's environment variables, for
+// example ETHDO_WALLET_PASSPHRASE_FILE or ETHDO_WALLET_PASSPHRASE_FD for
+// wallet-passphrase.
+func setupFlagPassphrase(flag string) error {
+	fileEnv := envName(flag + "-file")
+	fdEnv := envName(flag + "-fd")
+	passphraseFile := viper.GetString(flag + "-file")
+	passphraseFD := viper.GetString(flag + "-fd")
+	if passphraseFile == "" && passphraseFD == "" {
+		return nil
+	}
+	if passphraseFile != "" && passphraseFD != "" {
+		return fmt.Errorf("only one of %s and %s allowed", fileEnv, fdEnv)
+	}
+	if viper.GetString(flag) != "" {
+		return fmt.Errorf("%s cannot be supplied alongside %s or %s", flag, fileEnv, fdEnv)
+	}
+
+	var passphrases []string
+	var err error
+	if passphraseFile != "" {
+		passphrases, err = readPassphrasesFromFile(passphraseFile)
+	} else {
+		passphrases, err = readPassphrasesFromFD(passphraseFD, fdEnv)
+	}
+	if err != nil {
+		return errors.Wrap(err, flag)
+	}
+	if len(passphrases) != 1 {
+		return fmt.Errorf("%s: a single passphrase is required", flag)
+	}
+	viper.Set(flag, passphrases[0])
+
+	return nil
+}
+
+// envName provides the name of the environment variable for a key.
+func envName(key string) string {
+	return "ETHDO_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+// checkPassphraseFlags ensures that passphrases supplied on the command line
+// are not empty, as would happen for example with an unset shell variable.
+func checkPassphraseFlags() error {
+	for _, flag := range passphraseFlags {
+		if viper.IsSet(flag) && strings.TrimSpace(viper.GetString(flag)) == "" {
+			return fmt.Errorf("%s is empty", flag)
+		}
+	}
+	if viper.IsSet("passphrase") {
+		for _, passphrase := range viper.GetStringSlice("passphrase") {
+			if strings.TrimSpace(passphrase) == "" {
+				return errors.New("passphrase is empty")
+			}
+		}
+	}
+
+	return nil
+}
+
+// ReadMnemonic reads a mnemonic, normalising the whitespace between its words.
+func ReadMnemonic(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	mnemonic := strings.Join(strings.Fields(string(data)), " ")
+	if mnemonic == "" {
+		return "", errors.New("no mnemonic supplied")
+	}
+
+	return mnemonic, nil
+}
+
+// ReadPassphrases reads passphrases, one per line.
+// Passphrases are taken verbatim other than the line endings, and blank lines
+// are ignored.  A line containing only whitespace is an error, as it is almost
+// certainly not the intended passphrase.
+func ReadPassphrases(r io.Reader) ([]string, error) {
+	passphrases := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		passphrase := strings.TrimSuffix(scanner.Text(), "\r")
+		if passphrase == "" {
+			continue
+		}
+		if strings.TrimSpace(passphrase) == "" {
+			return nil, errors.New("empty passphrase supplied")
+		}
+		passphrases = append(passphrases, passphrase)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(passphrases) == 0 {
+		return nil, errors.New("no passphrases supplied")
+	}
+
+	return passphrases, nil
+}
+
+func readPassphrasesFromFile(filename string) ([]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open passphrase file")
+	}
+	defer f.Close()
+
+	passphrases, err := ReadPassphrases(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read passphrase file")
+	}
+
+	return passphrases, nil
+}
+
+func readPassphrasesFromFD(input string, env string) ([]string, error) {
+	fd, err := strconv.ParseUint(input, 10, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("invalid %s", env))
+	}
+	f := os.NewFile(uintptr(fd), fmt.Sprintf("fd%d", fd))
+	if f == nil {
+		return nil, fmt.Errorf("invalid %s", env)
+	}
+	defer f.Close()
+
+	passphrases, err := ReadPassphrases(f)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to read passphrases from %s", env))
+	}
+
+	return passphrases, nil
+}
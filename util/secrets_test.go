@@ -0,0 +1,399 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestReadMnemonic(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		res   string
+		err   string
+	}{
+		{
+			name:  "Empty",
+			input: "",
+			err:   "no mnemonic supplied",
+		},
+		{
+			name:  "Whitespace",
+			input: " \n\t",
+			err:   "no mnemonic supplied",
+		},
+		{
+			name:  "Good",
+			input: "abandon abandon art\n",
+			res:   "abandon abandon art",
+		},
+		{
+			name:  "ExtraWhitespace",
+			input: "  abandon\tabandon\n\nart  \r\n",
+			res:   "abandon abandon art",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.ReadMnemonic(strings.NewReader(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestReadPassphrases(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		res   []string
+		err   string
+	}{
+		{
+			name:  "Empty",
+			input: "",
+			err:   "no passphrases supplied",
+		},
+		{
+			name:  "Single",
+			input: "secret\n",
+			res:   []string{"secret"},
+		},
+		{
+			name:  "NoNewline",
+			input: "secret",
+			res:   []string{"secret"},
+		},
+		{
+			name:  "Multiple",
+			input: "secret1\r\n\nsecret2\n",
+			res:   []string{"secret1", "secret2"},
+		},
+		{
+			name:  "Spaces",
+			input: " secret with spaces \n",
+			res:   []string{" secret with spaces "},
+		},
+		{
+			name:  "WhitespaceOnly",
+			input: "secret\n \t\n",
+			err:   "empty passphrase supplied",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.ReadPassphrases(strings.NewReader(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestSetupSecrets(t *testing.T) {
+	passphraseFile := filepath.Join(t.TempDir(), "passphrases")
+	require.NoError(t, os.WriteFile(passphraseFile, []byte("secret1\nsecret2\n"), 0o600))
+	emptyPassphraseFile := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(emptyPassphraseFile, []byte("\n"), 0o600))
+	whitespacePassphraseFile := filepath.Join(t.TempDir(), "whitespace")
+	require.NoError(t, os.WriteFile(whitespacePassphraseFile, []byte("  \n"), 0o600))
+
+	tests := []struct {
+		name        string
+		vars        map[string]interface{}
+		stdin       string
+		mnemonic    string
+		passphrases []string
+		err         string
+	}{
+		{
+			name: "None",
+		},
+		{
+			name: "MnemonicStdin",
+			vars: map[string]interface{}{
+				"mnemonic-stdin": true,
+			},
+			stdin:    "abandon abandon art\n",
+			mnemonic: "abandon abandon art",
+		},
+		{
+			name: "MnemonicStdinAndMnemonic",
+			vars: map[string]interface{}{
+				"mnemonic-stdin": true,
+				"mnemonic":       "abandon abandon art",
+			},
+			err: "only one of mnemonic and mnemonic-stdin allowed",
+		},
+		{
+			name: "MnemonicStdinEmpty",
+			vars: map[string]interface{}{
+				"mnemonic-stdin": true,
+			},
+			err: "failed to read mnemonic from standard input: no mnemonic supplied",
+		},
+		{
+			name: "MnemonicInteractive",
+			vars: map[string]interface{}{
+				"mnemonic": "-",
+			},
+			stdin:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about\n\n",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		},
+		{
+			name: "MnemonicInteractiveAndMnemonicStdin",
+			vars: map[string]interface{}{
+				"mnemonic":       "-",
+				"mnemonic-stdin": true,
+			},
+			err: "only one of mnemonic and mnemonic-stdin allowed",
+		},
+		{
+			name: "PassphraseFile",
+			vars: map[string]interface{}{
+				"passphrase-file": passphraseFile,
+			},
+			passphrases: []string{"secret1", "secret2"},
+		},
+		{
+			name: "PassphraseFileMissing",
+			vars: map[string]interface{}{
+				"passphrase-file": filepath.Join(t.TempDir(), "missing"),
+			},
+			err: "failed to open passphrase file",
+		},
+		{
+			name: "PassphraseFileEmpty",
+			vars: map[string]interface{}{
+				"passphrase-file": emptyPassphraseFile,
+			},
+			err: "failed to read passphrase file: no passphrases supplied",
+		},
+		{
+			name: "PassphraseFileWhitespace",
+			vars: map[string]interface{}{
+				"passphrase-file": whitespacePassphraseFile,
+			},
+			err: "failed to read passphrase file: empty passphrase supplied",
+		},
+		{
+			name: "PassphraseFileAndPassphrase",
+			vars: map[string]interface{}{
+				"passphrase-file": passphraseFile,
+				"passphrase":      []string{"secret"},
+			},
+			err: "passphrase cannot be supplied alongside passphrase-file or ETHDO_PASSPHRASE_FD",
+		},
+		{
+			name: "PassphraseFileAndFD",
+			vars: map[string]interface{}{
+				"passphrase-file": passphraseFile,
+				"passphrase-fd":   "3",
+			},
+			err: "only one of passphrase-file and ETHDO_PASSPHRASE_FD allowed",
+		},
+		{
+			name: "PassphraseFDInvalid",
+			vars: map[string]interface{}{
+				"passphrase-fd": "three",
+			},
+			err: `invalid ETHDO_PASSPHRASE_FD: strconv.ParseUint: parsing "three": invalid syntax`,
+		},
+		{
+			name: "PassphraseEmpty",
+			vars: map[string]interface{}{
+				"passphrase": []string{"secret", ""},
+			},
+			err: "passphrase is empty",
+		},
+		{
+			name: "NewPassphrase",
+			vars: map[string]interface{}{
+				"new-passphrase": "secret",
+			},
+		},
+		{
+			name: "NewPassphraseEmpty",
+			vars: map[string]interface{}{
+				"new-passphrase": "",
+			},
+			err: "new-passphrase is empty",
+		},
+		{
+			name: "WalletPassphraseWhitespace",
+			vars: map[string]interface{}{
+				"wallet-passphrase": " ",
+			},
+			err: "wallet-passphrase is empty",
+		},
+		{
+			name: "StorePassphraseEmpty",
+			vars: map[string]interface{}{
+				"store-passphrase": "",
+			},
+			err: "store-passphrase is empty",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			err := util.SetupSecrets(strings.NewReader(test.stdin))
+			if test.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.mnemonic, viper.GetString("mnemonic"))
+				if test.passphrases != nil {
+					require.Equal(t, test.passphrases, util.GetPassphrases())
+				}
+			}
+		})
+	}
+}
+
+func TestSetupSecretsFD(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	_, err = w.WriteString("secret\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	viper.Reset()
+	viper.Set("passphrase-fd", strconv.FormatUint(uint64(r.Fd()), 10))
+	require.NoError(t, util.SetupSecrets(strings.NewReader("")))
+	require.Equal(t, []string{"secret"}, util.GetPassphrases())
+}
+
+func TestSetupSecretsFDEmpty(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	viper.Reset()
+	viper.Set("passphrase-fd", strconv.FormatUint(uint64(r.Fd()), 10))
+	require.EqualError(t, util.SetupSecrets(strings.NewReader("")), "failed to read passphrases from ETHDO_PASSPHRASE_FD: no passphrases supplied")
+}
+
+func TestSetupSecretsFlagPassphrase(t *testing.T) {
+	passphraseFile := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(passphraseFile, []byte("secret\n"), 0o600))
+	multiplePassphraseFile := filepath.Join(t.TempDir(), "passphrases")
+	require.NoError(t, os.WriteFile(multiplePassphraseFile, []byte("secret1\nsecret2\n"), 0o600))
+	emptyPassphraseFile := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(emptyPassphraseFile, []byte("\n"), 0o600))
+
+	tests := []struct {
+		name       string
+		vars       map[string]interface{}
+		passphrase string
+		err        string
+	}{
+		{
+			name: "WalletPassphraseFile",
+			vars: map[string]interface{}{
+				"wallet-passphrase-file": passphraseFile,
+			},
+			passphrase: "secret",
+		},
+		{
+			name: "WalletPassphraseFileMultiple",
+			vars: map[string]interface{}{
+				"wallet-passphrase-file": multiplePassphraseFile,
+			},
+			err: "wallet-passphrase: a single passphrase is required",
+		},
+		{
+			name: "WalletPassphraseFileEmpty",
+			vars: map[string]interface{}{
+				"wallet-passphrase-file": emptyPassphraseFile,
+			},
+			err: "wallet-passphrase: failed to read passphrase file: no passphrases supplied",
+		},
+		{
+			name: "WalletPassphraseFileAndWalletPassphrase",
+			vars: map[string]interface{}{
+				"wallet-passphrase-file": passphraseFile,
+				"wallet-passphrase":      "other",
+			},
+			err: "wallet-passphrase cannot be supplied alongside ETHDO_WALLET_PASSPHRASE_FILE or ETHDO_WALLET_PASSPHRASE_FD",
+		},
+		{
+			name: "WalletPassphraseFileAndFD",
+			vars: map[string]interface{}{
+				"wallet-passphrase-file": passphraseFile,
+				"wallet-passphrase-fd":   "3",
+			},
+			err: "only one of ETHDO_WALLET_PASSPHRASE_FILE and ETHDO_WALLET_PASSPHRASE_FD allowed",
+		},
+		{
+			name: "WalletPassphraseFDInvalid",
+			vars: map[string]interface{}{
+				"wallet-passphrase-fd": "three",
+			},
+			err: `wallet-passphrase: invalid ETHDO_WALLET_PASSPHRASE_FD: strconv.ParseUint: parsing "three": invalid syntax`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			err := util.SetupSecrets(strings.NewReader(""))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.passphrase, viper.GetString("wallet-passphrase"))
+			}
+		})
+	}
+}
+
+func TestSetupSecretsFlagPassphraseFD(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	_, err = w.WriteString("secret\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	viper.Reset()
+	viper.Set("new-passphrase-fd", strconv.FormatUint(uint64(r.Fd()), 10))
+	require.NoError(t, util.SetupSecrets(strings.NewReader("")))
+	require.Equal(t, "secret", viper.GetString("new-passphrase"))
+}
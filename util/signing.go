@@ -20,12 +20,25 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/auditlog"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 // SignRoot signs the hash tree root of a data structure
 func SignRoot(account e2wtypes.Account, root spec.Root, domain spec.Domain) (e2types.Signature, error) {
+	signature, err := signRoot(account, root, domain)
+	if err != nil {
+		return nil, NewSigningError(err)
+	}
+	if err := auditlog.Record(auditlog.ActionSign, account, root[:]); err != nil {
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+func signRoot(account e2wtypes.Account, root spec.Root, domain spec.Domain) (e2types.Signature, error) {
 	if _, isProtectingSigner := account.(e2wtypes.AccountProtectingSigner); isProtectingSigner {
 		// Signer builds the signing data.
 		return signGeneric(account, root, domain)
@@ -142,7 +155,7 @@ func unlock(account e2wtypes.Account) (bool, error) {
 		cancel()
 		if err == nil {
 			// Unlocked.
-			return false, nil
+			return false, auditlog.Record(auditlog.ActionUnlock, account, nil)
 		}
 	}
 
@@ -0,0 +1,99 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// signingContainer is an SSZ container that is signed.
+type signingContainer interface {
+	UnmarshalSSZ(buf []byte) error
+	HashTreeRoot() ([32]byte, error)
+}
+
+// signingContainers maps domain type names to the containers signed in that domain.
+var signingContainers = map[string]func() signingContainer{
+	"DOMAIN_BEACON_PROPOSER":                func() signingContainer { return &phase0.BeaconBlockHeader{} },
+	"DOMAIN_BEACON_ATTESTER":                func() signingContainer { return &phase0.AttestationData{} },
+	"DOMAIN_RANDAO":                         func() signingContainer { return new(sszUint64) },
+	"DOMAIN_DEPOSIT":                        func() signingContainer { return &phase0.DepositMessage{} },
+	"DOMAIN_VOLUNTARY_EXIT":                 func() signingContainer { return &phase0.VoluntaryExit{} },
+	"DOMAIN_SELECTION_PROOF":                func() signingContainer { return new(sszUint64) },
+	"DOMAIN_AGGREGATE_AND_PROOF":            func() signingContainer { return &phase0.AggregateAndProof{} },
+	"DOMAIN_SYNC_COMMITTEE":                 func() signingContainer { return new(sszRoot) },
+	"DOMAIN_SYNC_COMMITTEE_SELECTION_PROOF": func() signingContainer { return &altair.SyncAggregatorSelectionData{} },
+	"DOMAIN_CONTRIBUTION_AND_PROOF":         func() signingContainer { return &altair.ContributionAndProof{} },
+	"DOMAIN_BLS_TO_EXECUTION_CHANGE":        func() signingContainer { return &capella.BLSToExecutionChange{} },
+}
+
+// SigningContainerRoot decodes the SSZ-encoded container signed in the given domain
+// and returns its hash tree root.
+func SigningContainerRoot(domainType string, data []byte) (phase0.Root, error) {
+	constructor, exists := signingContainers[domainType]
+	if !exists {
+		return phase0.Root{}, fmt.Errorf("unsupported domain type %s", domainType)
+	}
+	container := constructor()
+	if err := container.UnmarshalSSZ(data); err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to decode container")
+	}
+	root, err := container.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to obtain container root")
+	}
+
+	return root, nil
+}
+
+// sszUint64 is an SSZ-encoded uint64, as signed for epochs and slots.
+type sszUint64 uint64
+
+func (s *sszUint64) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != 8 {
+		return fmt.Errorf("incorrect length %d for uint64", len(buf))
+	}
+	*s = sszUint64(binary.LittleEndian.Uint64(buf))
+
+	return nil
+}
+
+func (s *sszUint64) HashTreeRoot() ([32]byte, error) {
+	root := [32]byte{}
+	binary.LittleEndian.PutUint64(root[:8], uint64(*s))
+
+	return root, nil
+}
+
+// sszRoot is an SSZ-encoded root, as signed for block roots.
+type sszRoot phase0.Root
+
+func (s *sszRoot) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != 32 {
+		return fmt.Errorf("incorrect length %d for root", len(buf))
+	}
+	copy(s[:], buf)
+
+	return nil
+}
+
+func (s *sszRoot) HashTreeRoot() ([32]byte, error) {
+	return *s, nil
+}
@@ -0,0 +1,90 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestSigningContainerRoot(t *testing.T) {
+	exit := &phase0.VoluntaryExit{
+		Epoch:          194048,
+		ValidatorIndex: 12345,
+	}
+	exitData, err := exit.MarshalSSZ()
+	require.NoError(t, err)
+	exitRoot, err := exit.HashTreeRoot()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		domainType string
+		data       []byte
+		root       phase0.Root
+		err        string
+	}{
+		{
+			name:       "UnknownDomainType",
+			domainType: "DOMAIN_UNKNOWN",
+			data:       exitData,
+			err:        "unsupported domain type DOMAIN_UNKNOWN",
+		},
+		{
+			name:       "VoluntaryExit",
+			domainType: "DOMAIN_VOLUNTARY_EXIT",
+			data:       exitData,
+			root:       exitRoot,
+		},
+		{
+			name:       "RANDAOShort",
+			domainType: "DOMAIN_RANDAO",
+			data:       []byte{0x01, 0x02},
+			err:        "failed to decode container: incorrect length 2 for uint64",
+		},
+		{
+			name:       "RANDAO",
+			domainType: "DOMAIN_RANDAO",
+			data:       []byte{0x00, 0xf6, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00},
+			root:       phase0.Root{0x00, 0xf6, 0x02},
+		},
+		{
+			name:       "SyncCommitteeLong",
+			domainType: "DOMAIN_SYNC_COMMITTEE",
+			data:       make([]byte, 33),
+			err:        "failed to decode container: incorrect length 33 for root",
+		},
+		{
+			name:       "SyncCommittee",
+			domainType: "DOMAIN_SYNC_COMMITTEE",
+			data:       []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20},
+			root:       phase0.Root{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root, err := util.SigningContainerRoot(test.domainType, test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.root, root)
+			}
+		})
+	}
+}
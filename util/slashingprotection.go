@@ -0,0 +1,347 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// SlashingProtectionInterchangeFormatVersion is the version of the EIP-3076 interchange format supported.
+const SlashingProtectionInterchangeFormatVersion = "5"
+
+// SlashingProtection is slashing protection data in EIP-3076 interchange format.
+type SlashingProtection struct {
+	GenesisValidatorsRoot phase0.Root
+	Data                  []*SlashingProtectionData
+}
+
+// SlashingProtectionData is the slashing protection data for a single validator.
+type SlashingProtectionData struct {
+	PubKey             phase0.BLSPubKey
+	SignedBlocks       []*SlashingProtectionBlock
+	SignedAttestations []*SlashingProtectionAttestation
+}
+
+// SlashingProtectionBlock is a signed block entry.
+type SlashingProtectionBlock struct {
+	Slot        phase0.Slot
+	SigningRoot *phase0.Root
+}
+
+// SlashingProtectionAttestation is a signed attestation entry.
+type SlashingProtectionAttestation struct {
+	SourceEpoch phase0.Epoch
+	TargetEpoch phase0.Epoch
+	SigningRoot *phase0.Root
+}
+
+type slashingProtectionJSON struct {
+	Metadata *slashingProtectionMetadataJSON `json:"metadata"`
+	Data     []*slashingProtectionDataJSON   `json:"data"`
+}
+
+type slashingProtectionMetadataJSON struct {
+	InterchangeFormatVersion slashingProtectionNumber `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string                   `json:"genesis_validators_root"`
+}
+
+type slashingProtectionDataJSON struct {
+	PubKey             string                               `json:"pubkey"`
+	SignedBlocks       []*slashingProtectionBlockJSON       `json:"signed_blocks"`
+	SignedAttestations []*slashingProtectionAttestationJSON `json:"signed_attestations"`
+}
+
+type slashingProtectionBlockJSON struct {
+	Slot        slashingProtectionNumber `json:"slot"`
+	SigningRoot string                   `json:"signing_root,omitempty"`
+}
+
+type slashingProtectionAttestationJSON struct {
+	SourceEpoch slashingProtectionNumber `json:"source_epoch"`
+	TargetEpoch slashingProtectionNumber `json:"target_epoch"`
+	SigningRoot string                   `json:"signing_root,omitempty"`
+}
+
+// slashingProtectionNumber is a decimal value.  The specification requires these to be
+// strings, but some clients have exported them as JSON numbers so both are accepted.
+type slashingProtectionNumber string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *slashingProtectionNumber) UnmarshalJSON(input []byte) error {
+	*n = slashingProtectionNumber(strings.Trim(string(input), `"`))
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *SlashingProtection) MarshalJSON() ([]byte, error) {
+	data := make([]*slashingProtectionDataJSON, 0, len(s.Data))
+	for _, validator := range s.Data {
+		blocks := make([]*slashingProtectionBlockJSON, 0, len(validator.SignedBlocks))
+		for _, block := range validator.SignedBlocks {
+			blocks = append(blocks, &slashingProtectionBlockJSON{
+				Slot:        slashingProtectionNumber(fmt.Sprintf("%d", block.Slot)),
+				SigningRoot: rootToString(block.SigningRoot),
+			})
+		}
+		attestations := make([]*slashingProtectionAttestationJSON, 0, len(validator.SignedAttestations))
+		for _, attestation := range validator.SignedAttestations {
+			attestations = append(attestations, &slashingProtectionAttestationJSON{
+				SourceEpoch: slashingProtectionNumber(fmt.Sprintf("%d", attestation.SourceEpoch)),
+				TargetEpoch: slashingProtectionNumber(fmt.Sprintf("%d", attestation.TargetEpoch)),
+				SigningRoot: rootToString(attestation.SigningRoot),
+			})
+		}
+		data = append(data, &slashingProtectionDataJSON{
+			PubKey:             fmt.Sprintf("%#x", validator.PubKey),
+			SignedBlocks:       blocks,
+			SignedAttestations: attestations,
+		})
+	}
+
+	return json.Marshal(&slashingProtectionJSON{
+		Metadata: &slashingProtectionMetadataJSON{
+			InterchangeFormatVersion: SlashingProtectionInterchangeFormatVersion,
+			GenesisValidatorsRoot:    fmt.Sprintf("%#x", s.GenesisValidatorsRoot),
+		},
+		Data: data,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SlashingProtection) UnmarshalJSON(input []byte) error {
+	var data slashingProtectionJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	if data.Metadata == nil {
+		return errors.New("metadata missing")
+	}
+	if data.Metadata.InterchangeFormatVersion == "" {
+		return errors.New("interchange format version missing")
+	}
+	if string(data.Metadata.InterchangeFormatVersion) != SlashingProtectionInterchangeFormatVersion {
+		return fmt.Errorf("unsupported interchange format version %s", data.Metadata.InterchangeFormatVersion)
+	}
+	if data.Metadata.GenesisValidatorsRoot == "" {
+		return errors.New("genesis validators root missing")
+	}
+	genesisValidatorsRoot, err := stringToRoot(data.Metadata.GenesisValidatorsRoot)
+	if err != nil {
+		return errors.Wrap(err, "genesis validators root invalid")
+	}
+	s.GenesisValidatorsRoot = *genesisValidatorsRoot
+
+	s.Data = make([]*SlashingProtectionData, 0, len(data.Data))
+	for i, validatorData := range data.Data {
+		validator, err := validatorData.decode()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("data entry %d", i))
+		}
+		s.Data = append(s.Data, validator)
+	}
+
+	return nil
+}
+
+func (d *slashingProtectionDataJSON) decode() (*SlashingProtectionData, error) {
+	if d.PubKey == "" {
+		return nil, errors.New("public key missing")
+	}
+	pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(d.PubKey, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "public key invalid")
+	}
+	if len(pubKeyBytes) != phase0.PublicKeyLength {
+		return nil, errors.New("public key incorrect length")
+	}
+	res := &SlashingProtectionData{
+		SignedBlocks:       make([]*SlashingProtectionBlock, 0, len(d.SignedBlocks)),
+		SignedAttestations: make([]*SlashingProtectionAttestation, 0, len(d.SignedAttestations)),
+	}
+	copy(res.PubKey[:], pubKeyBytes)
+
+	for _, block := range d.SignedBlocks {
+		slot, err := strconv.ParseUint(string(block.Slot), 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "block slot invalid")
+		}
+		signingRoot, err := optionalStringToRoot(block.SigningRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "block signing root invalid")
+		}
+		res.SignedBlocks = append(res.SignedBlocks, &SlashingProtectionBlock{
+			Slot:        phase0.Slot(slot),
+			SigningRoot: signingRoot,
+		})
+	}
+
+	for _, attestation := range d.SignedAttestations {
+		sourceEpoch, err := strconv.ParseUint(string(attestation.SourceEpoch), 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "attestation source epoch invalid")
+		}
+		targetEpoch, err := strconv.ParseUint(string(attestation.TargetEpoch), 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "attestation target epoch invalid")
+		}
+		if sourceEpoch > targetEpoch {
+			return nil, fmt.Errorf("attestation source epoch %d after target epoch %d", sourceEpoch, targetEpoch)
+		}
+		signingRoot, err := optionalStringToRoot(attestation.SigningRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "attestation signing root invalid")
+		}
+		res.SignedAttestations = append(res.SignedAttestations, &SlashingProtectionAttestation{
+			SourceEpoch: phase0.Epoch(sourceEpoch),
+			TargetEpoch: phase0.Epoch(targetEpoch),
+			SigningRoot: signingRoot,
+		})
+	}
+
+	return res, nil
+}
+
+// MergeSlashingProtection merges multiple sets of slashing protection data in to a single set.
+// All sets must be for the same chain.  Duplicate entries are removed, and the results are
+// ordered by public key, slot and epoch.
+func MergeSlashingProtection(items []*SlashingProtection) (*SlashingProtection, error) {
+	if len(items) == 0 {
+		return nil, errors.New("no slashing protection data supplied")
+	}
+
+	res := &SlashingProtection{
+		GenesisValidatorsRoot: items[0].GenesisValidatorsRoot,
+		Data:                  make([]*SlashingProtectionData, 0),
+	}
+	validators := make(map[phase0.BLSPubKey]*SlashingProtectionData)
+	blocks := make(map[phase0.BLSPubKey]map[string]bool)
+	attestations := make(map[phase0.BLSPubKey]map[string]bool)
+	for i, item := range items {
+		if !bytes.Equal(item.GenesisValidatorsRoot[:], res.GenesisValidatorsRoot[:]) {
+			return nil, fmt.Errorf("genesis validators root %#x for item %d does not match %#x", item.GenesisValidatorsRoot, i, res.GenesisValidatorsRoot)
+		}
+		for _, data := range item.Data {
+			validator, exists := validators[data.PubKey]
+			if !exists {
+				validator = &SlashingProtectionData{
+					PubKey:             data.PubKey,
+					SignedBlocks:       make([]*SlashingProtectionBlock, 0),
+					SignedAttestations: make([]*SlashingProtectionAttestation, 0),
+				}
+				validators[data.PubKey] = validator
+				blocks[data.PubKey] = make(map[string]bool)
+				attestations[data.PubKey] = make(map[string]bool)
+				res.Data = append(res.Data, validator)
+			}
+			for _, block := range data.SignedBlocks {
+				key := fmt.Sprintf("%d:%s", block.Slot, rootToString(block.SigningRoot))
+				if !blocks[data.PubKey][key] {
+					blocks[data.PubKey][key] = true
+					validator.SignedBlocks = append(validator.SignedBlocks, block)
+				}
+			}
+			for _, attestation := range data.SignedAttestations {
+				key := fmt.Sprintf("%d:%d:%s", attestation.SourceEpoch, attestation.TargetEpoch, rootToString(attestation.SigningRoot))
+				if !attestations[data.PubKey][key] {
+					attestations[data.PubKey][key] = true
+					validator.SignedAttestations = append(validator.SignedAttestations, attestation)
+				}
+			}
+		}
+	}
+
+	sort.Slice(res.Data, func(i, j int) bool {
+		return bytes.Compare(res.Data[i].PubKey[:], res.Data[j].PubKey[:]) < 0
+	})
+	for _, validator := range res.Data {
+		sort.SliceStable(validator.SignedBlocks, func(i, j int) bool {
+			return validator.SignedBlocks[i].Slot < validator.SignedBlocks[j].Slot
+		})
+		sort.SliceStable(validator.SignedAttestations, func(i, j int) bool {
+			if validator.SignedAttestations[i].TargetEpoch != validator.SignedAttestations[j].TargetEpoch {
+				return validator.SignedAttestations[i].TargetEpoch < validator.SignedAttestations[j].TargetEpoch
+			}
+			return validator.SignedAttestations[i].SourceEpoch < validator.SignedAttestations[j].SourceEpoch
+		})
+	}
+
+	return res, nil
+}
+
+// Minify reduces the slashing protection data for each validator to a single block
+// and attestation, carrying the highest slot and epochs seen.  This provides the same
+// protection as the full data for clients that do not require the full history.
+func (s *SlashingProtection) Minify() {
+	for _, validator := range s.Data {
+		if len(validator.SignedBlocks) > 0 {
+			block := &SlashingProtectionBlock{}
+			for _, signedBlock := range validator.SignedBlocks {
+				if signedBlock.Slot > block.Slot {
+					block.Slot = signedBlock.Slot
+				}
+			}
+			validator.SignedBlocks = []*SlashingProtectionBlock{block}
+		}
+		if len(validator.SignedAttestations) > 0 {
+			attestation := &SlashingProtectionAttestation{}
+			for _, signedAttestation := range validator.SignedAttestations {
+				if signedAttestation.SourceEpoch > attestation.SourceEpoch {
+					attestation.SourceEpoch = signedAttestation.SourceEpoch
+				}
+				if signedAttestation.TargetEpoch > attestation.TargetEpoch {
+					attestation.TargetEpoch = signedAttestation.TargetEpoch
+				}
+			}
+			validator.SignedAttestations = []*SlashingProtectionAttestation{attestation}
+		}
+	}
+}
+
+func rootToString(root *phase0.Root) string {
+	if root == nil {
+		return ""
+	}
+	return fmt.Sprintf("%#x", *root)
+}
+
+func stringToRoot(input string) (*phase0.Root, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != phase0.RootLength {
+		return nil, errors.New("incorrect length")
+	}
+	root := phase0.Root{}
+	copy(root[:], data)
+
+	return &root, nil
+}
+
+func optionalStringToRoot(input string) (*phase0.Root, error) {
+	if input == "" {
+		return nil, nil
+	}
+	return stringToRoot(input)
+}
@@ -0,0 +1,120 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestSlashingProtectionJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		res   string
+		err   string
+	}{
+		{
+			name:  "Empty",
+			input: ``,
+			err:   "unexpected end of JSON input",
+		},
+		{
+			name:  "MetadataMissing",
+			input: `{"data":[]}`,
+			err:   "metadata missing",
+		},
+		{
+			name:  "VersionUnsupported",
+			input: `{"metadata":{"interchange_format_version":"4","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[]}`,
+			err:   "unsupported interchange format version 4",
+		},
+		{
+			name:  "GenesisValidatorsRootInvalid",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x0470"},"data":[]}`,
+			err:   "genesis validators root invalid: incorrect length",
+		},
+		{
+			name:  "PubKeyInvalid",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845","signed_blocks":[],"signed_attestations":[]}]}`,
+			err:   "data entry 0: public key incorrect length",
+		},
+		{
+			name:  "SlotInvalid",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[{"slot":"-1"}],"signed_attestations":[]}]}`,
+			err:   `data entry 0: block slot invalid: strconv.ParseUint: parsing "-1": invalid syntax`,
+		},
+		{
+			name:  "SourceAfterTarget",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[],"signed_attestations":[{"source_epoch":"5","target_epoch":"4"}]}]}`,
+			err:   "data entry 0: attestation source epoch 5 after target epoch 4",
+		},
+		{
+			name:  "Good",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[{"slot":"81952","signing_root":"0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"}],"signed_attestations":[{"source_epoch":"2290","target_epoch":"3007"}]}]}`,
+			res:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[{"slot":"81952","signing_root":"0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"}],"signed_attestations":[{"source_epoch":"2290","target_epoch":"3007"}]}]}`,
+		},
+		{
+			name:  "NumericValues",
+			input: `{"metadata":{"interchange_format_version":5,"genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[{"slot":81952}],"signed_attestations":[{"source_epoch":2290,"target_epoch":3007}]}]}`,
+			res:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[{"slot":"81952"}],"signed_attestations":[{"source_epoch":"2290","target_epoch":"3007"}]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res util.SlashingProtection
+			err := json.Unmarshal([]byte(test.input), &res)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				data, err := json.Marshal(&res)
+				require.NoError(t, err)
+				require.Equal(t, test.res, string(data))
+			}
+		})
+	}
+}
+
+func TestMergeSlashingProtection(t *testing.T) {
+	first := `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[{"slot":"81952"},{"slot":"81951"}],"signed_attestations":[{"source_epoch":"2290","target_epoch":"3008"}]}]}`
+	second := `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xa845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[],"signed_attestations":[]},{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[{"slot":"81953"},{"slot":"81951"}],"signed_attestations":[{"source_epoch":"2291","target_epoch":"3007"},{"source_epoch":"2290","target_epoch":"3008"}]}]}`
+	otherChain := `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"},"data":[]}`
+
+	var firstData, secondData, otherChainData util.SlashingProtection
+	require.NoError(t, json.Unmarshal([]byte(first), &firstData))
+	require.NoError(t, json.Unmarshal([]byte(second), &secondData))
+	require.NoError(t, json.Unmarshal([]byte(otherChain), &otherChainData))
+
+	_, err := util.MergeSlashingProtection(nil)
+	require.EqualError(t, err, "no slashing protection data supplied")
+
+	_, err = util.MergeSlashingProtection([]*util.SlashingProtection{&firstData, &otherChainData})
+	require.EqualError(t, err, "genesis validators root 0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95 for item 1 does not match 0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673")
+
+	merged, err := util.MergeSlashingProtection([]*util.SlashingProtection{&firstData, &secondData})
+	require.NoError(t, err)
+	data, err := json.Marshal(merged)
+	require.NoError(t, err)
+	require.Equal(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xa845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[],"signed_attestations":[]},{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[{"slot":"81951"},{"slot":"81952"},{"slot":"81953"}],"signed_attestations":[{"source_epoch":"2291","target_epoch":"3007"},{"source_epoch":"2290","target_epoch":"3008"}]}]}`, string(data))
+
+	merged.Minify()
+	data, err = json.Marshal(merged)
+	require.NoError(t, err)
+	require.Equal(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xa845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[],"signed_attestations":[]},{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[{"slot":"81953"}],"signed_attestations":[{"source_epoch":"2291","target_epoch":"3008"}]}]}`, string(data))
+}
@@ -0,0 +1,86 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+// ParseStateID parses input to provide a state ID suitable for use with the
+// beacon node API.  The input can be one of:
+//   - "head", "genesis", "finalized" or "justified"
+//   - a state root, as a 0x-prefixed hex string
+//   - a slot, either as a number or "slot:<slot>"
+//   - an epoch, as "epoch:<epoch>", in which case the state at the first slot
+//     of the epoch is used; the epoch is parsed as per ParseEpoch()
+//   - a fork, as "fork:<name>", in which case the state at the first slot of
+//     the fork is used
+//
+// An empty input provides "head".
+func ParseStateID(ctx context.Context, chainTime chaintime.Service, input string) (string, error) {
+	input = strings.TrimSpace(input)
+	switch {
+	case input == "":
+		return "head", nil
+	case input == "head", input == "genesis", input == "finalized", input == "justified":
+		return input, nil
+	case strings.HasPrefix(input, "0x"):
+		root, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+		if err != nil {
+			return "", errors.Wrap(err, "invalid state root")
+		}
+		if len(root) != phase0.RootLength {
+			return "", errors.New("state root must be 32 bytes")
+		}
+		return fmt.Sprintf("%#x", root), nil
+	case strings.HasPrefix(input, "slot:"):
+		slot, err := strconv.ParseUint(strings.TrimPrefix(input, "slot:"), 10, 64)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to parse slot")
+		}
+		return fmt.Sprintf("%d", slot), nil
+	case strings.HasPrefix(input, "epoch:"):
+		epoch, err := ParseEpoch(ctx, chainTime, strings.TrimPrefix(input, "epoch:"))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d", chainTime.FirstSlotOfEpoch(epoch)), nil
+	case strings.HasPrefix(input, "fork:"):
+		name := strings.ToLower(strings.TrimPrefix(input, "fork:"))
+		for _, fork := range chainTime.ForkSchedule() {
+			if fork.Name != name {
+				continue
+			}
+			if fork.Epoch > chainTime.CurrentEpoch() {
+				return "", fmt.Errorf("fork %s has not yet taken place", name)
+			}
+			return fmt.Sprintf("%d", chainTime.FirstSlotOfEpoch(fork.Epoch)), nil
+		}
+		return "", fmt.Errorf("unknown fork %s", name)
+	default:
+		slot, err := strconv.ParseUint(input, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid state %q", input)
+		}
+		return fmt.Sprintf("%d", slot), nil
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/testing/mock"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestParseStateID(t *testing.T) {
+	ctx := context.Background()
+
+	// genesis is 1 day ago.
+	genesisTime := time.Now().AddDate(0, 0, -1)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+		standardchaintime.WithForkScheduleProvider(mock.NewForkScheduleProvider([]*phase0.Fork{
+			{
+				CurrentVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+				Epoch:          0,
+			},
+			{
+				CurrentVersion: phase0.Version{0x01, 0x00, 0x00, 0x00},
+				Epoch:          10,
+			},
+			{
+				CurrentVersion: phase0.Version{0x02, 0x00, 0x00, 0x00},
+				Epoch:          100000,
+			},
+		})),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		err      string
+	}{
+		{
+			name:     "Empty",
+			input:    "",
+			expected: "head",
+		},
+		{
+			name:     "Finalized",
+			input:    "finalized",
+			expected: "finalized",
+		},
+		{
+			name:     "Root",
+			input:    "0x0102030405060708090A0B0C0D0E0F101112131415161718191A1B1C1D1E1F20",
+			expected: "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20",
+		},
+		{
+			name:  "RootShort",
+			input: "0x0102",
+			err:   "state root must be 32 bytes",
+		},
+		{
+			name:  "RootInvalid",
+			input: "0xinvalid",
+			err:   "invalid state root: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name:     "Slot",
+			input:    "12345",
+			expected: "12345",
+		},
+		{
+			name:     "SlotPrefixed",
+			input:    "slot:12345",
+			expected: "12345",
+		},
+		{
+			name:  "SlotInvalid",
+			input: "slot:bad",
+			err:   `failed to parse slot: strconv.ParseUint: parsing "bad": invalid syntax`,
+		},
+		{
+			name:     "Epoch",
+			input:    "epoch:10",
+			expected: "320",
+		},
+		{
+			name:     "EpochRelative",
+			input:    "epoch:-1",
+			expected: "7168",
+		},
+		{
+			name:  "EpochInvalid",
+			input: "epoch:bad",
+			err:   `failed to parse epoch: strconv.ParseInt: parsing "bad": invalid syntax`,
+		},
+		{
+			name:  "ForkUnknown",
+			input: "fork:capella",
+			err:   "unknown fork capella",
+		},
+		{
+			name:  "Invalid",
+			input: "yesterday",
+			err:   `invalid state "yesterday"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.ParseStateID(ctx, chainTime, test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
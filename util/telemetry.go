@@ -0,0 +1,302 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// telemetry is the telemetry for the running command, or nil if telemetry is not enabled.
+var telemetry *commandTelemetry
+
+// commandTelemetry contains information about the beacon node API usage and timings of a command.
+type commandTelemetry struct {
+	mutex     sync.Mutex
+	inflight  sync.WaitGroup
+	started   time.Time
+	endpoints map[string]*endpointTelemetry
+	caches    map[string]*cacheTelemetry
+	phases    []*phaseTelemetry
+}
+
+type endpointTelemetry struct {
+	calls    int
+	failures int
+	bytes    int64
+	elapsed  time.Duration
+}
+
+type cacheTelemetry struct {
+	hits   int
+	misses int
+}
+
+type phaseTelemetry struct {
+	name    string
+	started time.Time
+	elapsed time.Duration
+}
+
+// inflightTimeout is the maximum time to wait for in-flight API calls to complete
+// before providing the summary; event streams, for example, do not complete.
+var inflightTimeout = time.Second
+
+// idSegment matches path segments that identify a specific item, such as a slot or a root.
+var idSegment = regexp.MustCompile(`^([0-9]+|0x[0-9a-fA-F]+|head|genesis|finalized|justified)$`)
+
+// EnableTelemetry enables recording of telemetry for the running command.
+func EnableTelemetry() {
+	now := time.Now()
+	telemetry = &commandTelemetry{
+		started:   now,
+		endpoints: make(map[string]*endpointTelemetry),
+		caches:    make(map[string]*cacheTelemetry),
+		phases: []*phaseTelemetry{
+			{
+				name:    "setup",
+				started: now,
+			},
+		},
+	}
+	resetBeaconNodeClients()
+}
+
+// TelemetryEnabled returns true if telemetry is being recorded.
+func TelemetryEnabled() bool {
+	return telemetry != nil
+}
+
+// StartTelemetryPhase marks the start of a new phase of the running command,
+// ending the previous phase.
+func StartTelemetryPhase(name string) {
+	if telemetry == nil {
+		return
+	}
+	telemetry.mutex.Lock()
+	defer telemetry.mutex.Unlock()
+
+	now := time.Now()
+	current := telemetry.phases[len(telemetry.phases)-1]
+	if current.name == name {
+		return
+	}
+	current.elapsed = now.Sub(current.started)
+	telemetry.phases = append(telemetry.phases, &phaseTelemetry{
+		name:    name,
+		started: now,
+	})
+}
+
+// RecordCacheHit records a cache hit for the named cache.
+func RecordCacheHit(cache string) {
+	recordCacheAccess(cache, true)
+}
+
+// RecordCacheMiss records a cache miss for the named cache.
+func RecordCacheMiss(cache string) {
+	recordCacheAccess(cache, false)
+}
+
+func recordCacheAccess(cache string, hit bool) {
+	if telemetry == nil {
+		return
+	}
+	telemetry.mutex.Lock()
+	defer telemetry.mutex.Unlock()
+
+	entry, exists := telemetry.caches[cache]
+	if !exists {
+		entry = &cacheTelemetry{}
+		telemetry.caches[cache] = entry
+	}
+	if hit {
+		entry.hits++
+	} else {
+		entry.misses++
+	}
+}
+
+// recordAPICall records a call to a beacon node API endpoint.
+func recordAPICall(method string, path string, bytes int64, elapsed time.Duration, status int) {
+	if telemetry == nil {
+		return
+	}
+	telemetry.mutex.Lock()
+	defer telemetry.mutex.Unlock()
+
+	endpoint := fmt.Sprintf("%s %s", method, endpointTemplate(path))
+	entry, exists := telemetry.endpoints[endpoint]
+	if !exists {
+		entry = &endpointTelemetry{}
+		telemetry.endpoints[endpoint] = entry
+	}
+	entry.calls++
+	// Not found is a valid response from the beacon node, for example for an empty slot.
+	if status/100 != 2 && status != http.StatusNotFound {
+		entry.failures++
+	}
+	entry.bytes += bytes
+	entry.elapsed += elapsed
+}
+
+// endpointTemplate replaces the parts of an API path that identify a specific
+// item, so that calls to the same endpoint are grouped together.
+func endpointTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i := range segments {
+		if idSegment.MatchString(segments[i]) {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// TelemetrySummary provides a summary of the telemetry for the running command.
+// It returns an empty string if telemetry is not enabled.
+func TelemetrySummary() string {
+	if telemetry == nil {
+		return ""
+	}
+
+	completed := make(chan struct{})
+	go func() {
+		telemetry.inflight.Wait()
+		close(completed)
+	}()
+	select {
+	case <-completed:
+	case <-time.After(inflightTimeout):
+	}
+
+	telemetry.mutex.Lock()
+	defer telemetry.mutex.Unlock()
+
+	now := time.Now()
+	current := telemetry.phases[len(telemetry.phases)-1]
+	current.elapsed = now.Sub(current.started)
+
+	builder := strings.Builder{}
+	builder.WriteString("Telemetry:\n")
+	builder.WriteString(fmt.Sprintf("  Elapsed time: %v\n", now.Sub(telemetry.started).Round(time.Millisecond)))
+	builder.WriteString("  Phases:\n")
+	for _, phase := range telemetry.phases {
+		builder.WriteString(fmt.Sprintf("    %s: %v\n", phase.name, phase.elapsed.Round(time.Millisecond)))
+	}
+
+	endpoints := make([]string, 0, len(telemetry.endpoints))
+	calls := 0
+	bytes := int64(0)
+	for endpoint, entry := range telemetry.endpoints {
+		endpoints = append(endpoints, endpoint)
+		calls += entry.calls
+		bytes += entry.bytes
+	}
+	// Show the endpoints that took the most time first.
+	sort.Slice(endpoints, func(i int, j int) bool {
+		if telemetry.endpoints[endpoints[i]].elapsed != telemetry.endpoints[endpoints[j]].elapsed {
+			return telemetry.endpoints[endpoints[i]].elapsed > telemetry.endpoints[endpoints[j]].elapsed
+		}
+		return endpoints[i] < endpoints[j]
+	})
+	builder.WriteString(fmt.Sprintf("  Beacon node API calls: %d (%d bytes)\n", calls, bytes))
+	for _, endpoint := range endpoints {
+		entry := telemetry.endpoints[endpoint]
+		builder.WriteString(fmt.Sprintf("    %s: %d calls, %d bytes, %v", endpoint, entry.calls, entry.bytes, entry.elapsed.Round(time.Millisecond)))
+		if entry.failures > 0 {
+			builder.WriteString(fmt.Sprintf(", %d failed", entry.failures))
+		}
+		builder.WriteString("\n")
+	}
+
+	if len(telemetry.caches) > 0 {
+		caches := make([]string, 0, len(telemetry.caches))
+		for cache := range telemetry.caches {
+			caches = append(caches, cache)
+		}
+		sort.Strings(caches)
+		builder.WriteString("  Caches:\n")
+		for _, cache := range caches {
+			entry := telemetry.caches[cache]
+			builder.WriteString(fmt.Sprintf("    %s: %d hits, %d misses (%0.2f%% hit rate)\n", cache, entry.hits, entry.misses, 100.0*float64(entry.hits)/float64(entry.hits+entry.misses)))
+		}
+	}
+
+	return builder.String()
+}
+
+// telemetryTransport records telemetry for each request.
+type telemetryTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *telemetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	telemetry.inflight.Add(1)
+	started := time.Now()
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		recordAPICall(req.Method, req.URL.Path, 0, time.Since(started), http.StatusBadGateway)
+		telemetry.inflight.Done()
+
+		return nil, err
+	}
+
+	// The call is recorded when the body has been read and closed.
+	res.Body = &telemetryBody{
+		ReadCloser: res.Body,
+		method:     req.Method,
+		path:       req.URL.Path,
+		status:     res.StatusCode,
+		started:    started,
+	}
+
+	return res, nil
+}
+
+// telemetryBody records the size of a response body, and the API call
+// when it is closed.
+type telemetryBody struct {
+	io.ReadCloser
+	method  string
+	path    string
+	status  int
+	started time.Time
+	bytes   int64
+	closed  sync.Once
+}
+
+func (b *telemetryBody) Read(data []byte) (int, error) {
+	n, err := b.ReadCloser.Read(data)
+	b.bytes += int64(n)
+
+	return n, err
+}
+
+func (b *telemetryBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.closed.Do(func() {
+		recordAPICall(b.method, b.path, b.bytes, time.Since(b.started), b.status)
+		telemetry.inflight.Done()
+	})
+
+	return err
+}
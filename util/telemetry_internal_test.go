@@ -0,0 +1,120 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		res  string
+	}{
+		{
+			name: "Empty",
+			path: "",
+			res:  "",
+		},
+		{
+			name: "Static",
+			path: "/eth/v1/config/spec",
+			res:  "/eth/v1/config/spec",
+		},
+		{
+			name: "Slot",
+			path: "/eth/v2/beacon/blocks/12345",
+			res:  "/eth/v2/beacon/blocks/{id}",
+		},
+		{
+			name: "Root",
+			path: "/eth/v1/beacon/headers/0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20",
+			res:  "/eth/v1/beacon/headers/{id}",
+		},
+		{
+			name: "State",
+			path: "/eth/v1/beacon/states/head/validators",
+			res:  "/eth/v1/beacon/states/{id}/validators",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, endpointTemplate(test.path))
+		})
+	}
+}
+
+func TestTelemetry(t *testing.T) {
+	defer func() {
+		telemetry = nil
+	}()
+
+	// Disabled telemetry does nothing.
+	telemetry = nil
+	require.False(t, TelemetryEnabled())
+	StartTelemetryPhase("process")
+	RecordCacheHit("test")
+	require.Equal(t, "", TelemetrySummary())
+
+	EnableTelemetry()
+	require.True(t, TelemetryEnabled())
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/eth/v2/beacon/blocks/2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte("0123456789"))
+	}))
+	defer upstream.Close()
+
+	client := &http.Client{
+		Transport: &telemetryTransport{
+			next: http.DefaultTransport,
+		},
+	}
+
+	StartTelemetryPhase("process")
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(fmt.Sprintf("%s/eth/v2/beacon/blocks/%d", upstream.URL, i))
+		require.NoError(t, err)
+		_, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	RecordCacheHit("blocks")
+	RecordCacheMiss("blocks")
+	RecordCacheMiss("blocks")
+	RecordCacheMiss("blocks")
+	StartTelemetryPhase("output")
+
+	summary := TelemetrySummary()
+	require.True(t, strings.HasPrefix(summary, "Telemetry:\n"))
+	require.True(t, strings.Contains(summary, "    setup: "))
+	require.True(t, strings.Contains(summary, "    process: "))
+	require.True(t, strings.Contains(summary, "    output: "))
+	require.True(t, strings.Contains(summary, "  Beacon node API calls: 3 (20 bytes)\n"))
+	require.True(t, strings.Contains(summary, "    GET /eth/v2/beacon/blocks/{id}: 3 calls, 20 bytes, "))
+	require.True(t, strings.Contains(summary, ", 1 failed\n"))
+	require.True(t, strings.Contains(summary, "    blocks: 1 hits, 3 misses (25.00% hit rate)\n"))
+}
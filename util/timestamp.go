@@ -0,0 +1,75 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// timestampLayouts are the layouts accepted for timestamps, in the order in
+// which they are tried.  Layouts without a timezone are interpreted in the
+// supplied location.
+var timestampLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTimezone parses the name of a timezone.  An empty name, or "local",
+// provides the local timezone.
+func ParseTimezone(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "", "local":
+		return time.Local, nil
+	case "utc":
+		return time.UTC, nil
+	default:
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("unknown timezone %q", name)
+		}
+		return location, nil
+	}
+}
+
+// ParseTimestamp parses a timestamp.  The timestamp can be a Unix time in
+// seconds, an RFC3339 date and time, or a date with an optional time, in
+// which case it is interpreted in the supplied location.
+func ParseTimestamp(input string, location *time.Location) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return time.Time{}, errors.New("no timestamp supplied")
+	}
+
+	if seconds, err := strconv.ParseInt(input, 10, 64); err == nil {
+		return time.Unix(seconds, 0).In(location), nil
+	}
+
+	for _, layout := range timestampLayouts {
+		if timestamp, err := time.ParseInLocation(layout, input, location); err == nil {
+			return timestamp, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp %q; should be a Unix time or of the form YYYY-MM-DDTHH:MM:SS+ZZ:ZZ", input)
+}
@@ -0,0 +1,121 @@
+// Copyright © 2022 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestParseTimezone(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected *time.Location
+		err      string
+	}{
+		{
+			name:     "Empty",
+			expected: time.Local,
+		},
+		{
+			name:     "Local",
+			input:    "local",
+			expected: time.Local,
+		},
+		{
+			name:     "UTC",
+			input:    "UTC",
+			expected: time.UTC,
+		},
+		{
+			name:  "Unknown",
+			input: "Mars/Olympus_Mons",
+			err:   `unknown timezone "Mars/Olympus_Mons"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.ParseTimezone(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	location := time.FixedZone("UTC+2", 2*60*60)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+		err      string
+	}{
+		{
+			name: "Empty",
+			err:  "no timestamp supplied",
+		},
+		{
+			name:  "Invalid",
+			input: "yesterday",
+			err:   `invalid timestamp "yesterday"; should be a Unix time or of the form YYYY-MM-DDTHH:MM:SS+ZZ:ZZ`,
+		},
+		{
+			name:     "Unix",
+			input:    "1606824023",
+			expected: time.Unix(1606824023, 0),
+		},
+		{
+			name:     "RFC3339",
+			input:    "2020-12-01T12:00:23Z",
+			expected: time.Unix(1606824023, 0),
+		},
+		{
+			name:     "NumericZone",
+			input:    "2020-12-01T13:00:23+0100",
+			expected: time.Unix(1606824023, 0),
+		},
+		{
+			name:     "NoZone",
+			input:    "2020-12-01 14:00:23",
+			expected: time.Unix(1606824023, 0),
+		},
+		{
+			name:     "Date",
+			input:    "2020-12-01",
+			expected: time.Date(2020, 12, 1, 0, 0, 0, 0, location),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.ParseTimestamp(test.input, location)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.True(t, test.expected.Equal(res))
+			}
+		})
+	}
+}
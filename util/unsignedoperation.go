@@ -0,0 +1,222 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+const (
+	// OperationTypeVoluntaryExit is the type of a voluntary exit operation.
+	OperationTypeVoluntaryExit = "voluntary_exit"
+	// OperationTypeBLSToExecutionChange is the type of a credentials change operation.
+	OperationTypeBLSToExecutionChange = "bls_to_execution_change"
+)
+
+// UnsignedOperation is an operation to be signed outside of ethdo, along with
+// the information required to sign it and to verify the resultant signature.
+type UnsignedOperation struct {
+	Type        string
+	Message     json.RawMessage
+	PublicKey   phase0.BLSPubKey
+	Domain      phase0.Domain
+	SigningRoot phase0.Root
+}
+
+type unsignedOperationJSON struct {
+	Type        string          `json:"type"`
+	Message     json.RawMessage `json:"message"`
+	PublicKey   string          `json:"public_key"`
+	Domain      string          `json:"domain"`
+	SigningRoot string          `json:"signing_root"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o *UnsignedOperation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&unsignedOperationJSON{
+		Type:        o.Type,
+		Message:     o.Message,
+		PublicKey:   fmt.Sprintf("%#x", o.PublicKey),
+		Domain:      fmt.Sprintf("%#x", o.Domain),
+		SigningRoot: fmt.Sprintf("%#x", o.SigningRoot),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (o *UnsignedOperation) UnmarshalJSON(input []byte) error {
+	var data unsignedOperationJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	switch data.Type {
+	case "":
+		return errors.New("type missing")
+	case OperationTypeVoluntaryExit, OperationTypeBLSToExecutionChange:
+		o.Type = data.Type
+	default:
+		return fmt.Errorf("unsupported type %s", data.Type)
+	}
+
+	if len(data.Message) == 0 {
+		return errors.New("message missing")
+	}
+	o.Message = data.Message
+
+	if err := decodeFixedHex("public key", data.PublicKey, o.PublicKey[:]); err != nil {
+		return err
+	}
+	if err := decodeFixedHex("domain", data.Domain, o.Domain[:]); err != nil {
+		return err
+	}
+
+	return decodeFixedHex("signing root", data.SigningRoot, o.SigningRoot[:])
+}
+
+// decodeFixedHex decodes a hex string in to a fixed-length byte array.
+func decodeFixedHex(name string, input string, output []byte) error {
+	if input == "" {
+		return fmt.Errorf("%s missing", name)
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("invalid %s", name))
+	}
+	if len(data) != len(output) {
+		return fmt.Errorf("incorrect length for %s", name)
+	}
+	copy(output, data)
+
+	return nil
+}
+
+// NewUnsignedOperation creates an unsigned operation for the given message,
+// to be signed by the given public key in the given domain.
+func NewUnsignedOperation(message interface{}, pubKey phase0.BLSPubKey, domain phase0.Domain) (*UnsignedOperation, error) {
+	var opType string
+	var root phase0.Root
+	var err error
+	switch msg := message.(type) {
+	case *phase0.VoluntaryExit:
+		opType = OperationTypeVoluntaryExit
+		root, err = msg.HashTreeRoot()
+	case *capella.BLSToExecutionChange:
+		opType = OperationTypeBLSToExecutionChange
+		root, err = msg.HashTreeRoot()
+	default:
+		return nil, fmt.Errorf("unsupported operation %T", message)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain operation root")
+	}
+
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain signing root")
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode operation")
+	}
+
+	return &UnsignedOperation{
+		Type:        opType,
+		Message:     data,
+		PublicKey:   pubKey,
+		Domain:      domain,
+		SigningRoot: signingRoot,
+	}, nil
+}
+
+// Assemble combines the operation with an externally generated signature,
+// returning the signed operation.  The signing root is recalculated from the
+// message and domain, so an operation that has been altered since it was
+// created is rejected, as is a signature that does not verify.
+func (o *UnsignedOperation) Assemble(signature phase0.BLSSignature) (interface{}, error) {
+	var signed interface{}
+	var root phase0.Root
+	var err error
+	switch o.Type {
+	case OperationTypeVoluntaryExit:
+		msg := &phase0.VoluntaryExit{}
+		if err := json.Unmarshal(o.Message, msg); err != nil {
+			return nil, errors.Wrap(err, "invalid voluntary exit")
+		}
+		root, err = msg.HashTreeRoot()
+		signed = &phase0.SignedVoluntaryExit{
+			Message:   msg,
+			Signature: signature,
+		}
+	case OperationTypeBLSToExecutionChange:
+		msg := &capella.BLSToExecutionChange{}
+		if err := json.Unmarshal(o.Message, msg); err != nil {
+			return nil, errors.Wrap(err, "invalid credentials change")
+		}
+		root, err = msg.HashTreeRoot()
+		// Credentials changes are supplied as a list, as generated by "validator credentials set".
+		signed = []*capella.SignedBLSToExecutionChange{
+			{
+				Message:   msg,
+				Signature: signature,
+			},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %s", o.Type)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain operation root")
+	}
+
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     o.Domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain signing root")
+	}
+	if !bytes.Equal(signingRoot[:], o.SigningRoot[:]) {
+		return nil, fmt.Errorf("signing root %#x does not match that of the operation %#x", o.SigningRoot, signingRoot)
+	}
+
+	pubKeyBytes := make([]byte, len(o.PublicKey))
+	copy(pubKeyBytes, o.PublicKey[:])
+	pubKey, err := e2types.BLSPublicKeyFromBytes(pubKeyBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	sigBytes := make([]byte, len(signature))
+	copy(sigBytes, signature[:])
+	sig, err := e2types.BLSSignatureFromBytes(sigBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+	if !sig.Verify(signingRoot[:], pubKey) {
+		return nil, errors.New("signature does not verify")
+	}
+
+	return signed, nil
+}
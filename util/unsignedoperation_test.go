@@ -0,0 +1,181 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestUnsignedOperation(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	keyData, err := hex.DecodeString("25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866")
+	require.NoError(t, err)
+	key, err := e2types.BLSPrivateKeyFromBytes(keyData)
+	require.NoError(t, err)
+	pubKey := phase0.BLSPubKey{}
+	copy(pubKey[:], key.PublicKey().Marshal())
+	domain := phase0.Domain{0x04, 0x00, 0x00, 0x00, 0x01}
+
+	exit := &phase0.VoluntaryExit{
+		Epoch:          194048,
+		ValidatorIndex: 12345,
+	}
+	change := &capella.BLSToExecutionChange{
+		ValidatorIndex:     12345,
+		FromBLSPubkey:      pubKey,
+		ToExecutionAddress: [20]byte{0x01, 0x02},
+	}
+
+	tests := []struct {
+		name    string
+		message interface{}
+		alter   func(op *util.UnsignedOperation)
+		wrong   bool
+		err     string
+	}{
+		{
+			name:    "Unsupported",
+			message: &phase0.AttestationData{},
+			err:     "unsupported operation *phase0.AttestationData",
+		},
+		{
+			name:    "VoluntaryExit",
+			message: exit,
+		},
+		{
+			name:    "BLSToExecutionChange",
+			message: change,
+		},
+		{
+			name:    "MessageAltered",
+			message: exit,
+			alter: func(op *util.UnsignedOperation) {
+				op.Message = json.RawMessage(`{"epoch":"194049","validator_index":"12345"}`)
+			},
+			err: "does not match that of the operation",
+		},
+		{
+			name:    "SignatureWrong",
+			message: exit,
+			wrong:   true,
+			err:     "signature does not verify",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			op, err := util.NewUnsignedOperation(test.message, pubKey, domain)
+			if err != nil {
+				require.EqualError(t, err, test.err)
+				return
+			}
+
+			// Round-trip through JSON, as would happen with a root file.
+			data, err := json.Marshal(op)
+			require.NoError(t, err)
+			op = &util.UnsignedOperation{}
+			require.NoError(t, json.Unmarshal(data, op))
+			if test.alter != nil {
+				test.alter(op)
+			}
+
+			root := op.SigningRoot
+			if test.wrong {
+				root[0] ^= 0xff
+			}
+			signature := phase0.BLSSignature{}
+			copy(signature[:], key.Sign(root[:]).Marshal())
+
+			signed, err := op.Assemble(signature)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			switch op.Type {
+			case util.OperationTypeVoluntaryExit:
+				require.Equal(t, exit, signed.(*phase0.SignedVoluntaryExit).Message)
+			case util.OperationTypeBLSToExecutionChange:
+				require.Equal(t, change, signed.([]*capella.SignedBLSToExecutionChange)[0].Message)
+			}
+		})
+	}
+}
+
+func TestUnsignedOperationUnmarshalJSON(t *testing.T) {
+	root := "0x0101010101010101010101010101010101010101010101010101010101010101"
+	pubKey := "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "TypeMissing",
+			input: `{"message":{},"public_key":"` + pubKey + `","domain":"` + root + `","signing_root":"` + root + `"}`,
+			err:   "type missing",
+		},
+		{
+			name:  "TypeUnsupported",
+			input: `{"type":"deposit","message":{},"public_key":"` + pubKey + `","domain":"` + root + `","signing_root":"` + root + `"}`,
+			err:   "unsupported type deposit",
+		},
+		{
+			name:  "MessageMissing",
+			input: `{"type":"voluntary_exit","public_key":"` + pubKey + `","domain":"` + root + `","signing_root":"` + root + `"}`,
+			err:   "message missing",
+		},
+		{
+			name:  "PublicKeyShort",
+			input: `{"type":"voluntary_exit","message":{},"public_key":"0x01","domain":"` + root + `","signing_root":"` + root + `"}`,
+			err:   "incorrect length for public key",
+		},
+		{
+			name:  "DomainInvalid",
+			input: `{"type":"voluntary_exit","message":{},"public_key":"` + pubKey + `","domain":"0xzz","signing_root":"` + root + `"}`,
+			err:   "invalid domain: encoding/hex: invalid byte: U+007A 'z'",
+		},
+		{
+			name:  "SigningRootMissing",
+			input: `{"type":"voluntary_exit","message":{},"public_key":"` + pubKey + `","domain":"` + root + `"}`,
+			err:   "signing root missing",
+		},
+		{
+			name:  "Good",
+			input: `{"type":"voluntary_exit","message":{},"public_key":"` + pubKey + `","domain":"` + root + `","signing_root":"` + root + `"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var op util.UnsignedOperation
+			err := json.Unmarshal([]byte(test.input), &op)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
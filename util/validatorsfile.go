@@ -0,0 +1,71 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// ReadValidatorsFile reads validators from a file, one per line, as indices,
+// public keys or accounts.  Empty lines and lines starting with '#' are
+// ignored.
+func ReadValidatorsFile(name string) ([]string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open validators file")
+	}
+	defer file.Close()
+
+	return ReadValidators(file)
+}
+
+// ReadValidators reads validators from a reader, in the same format as
+// ReadValidatorsFile.
+func ReadValidators(reader io.Reader) ([]string, error) {
+	validators := make([]string, 0)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		validators = append(validators, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read validators")
+	}
+
+	return validators, nil
+}
+
+// WithdrawalCredentialsType returns the type prefix of withdrawal credentials,
+// for example "0x01", or "unknown" if the type is not recognised.
+func WithdrawalCredentialsType(credentials []byte) string {
+	if len(credentials) != phase0.HashLength {
+		return "unknown"
+	}
+	switch credentials[0] {
+	case 0, 1, 2:
+		return fmt.Sprintf("%#02x", credentials[0])
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadValidatorsFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "validators.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# Fleet\n1\n\n  0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c  \nWallet/Account\n"), 0o600))
+
+	validators, err := ReadValidatorsFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"1",
+		"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+		"Wallet/Account",
+	}, validators)
+
+	_, err = ReadValidatorsFile(filepath.Join(dir, "missing.txt"))
+	require.ErrorContains(t, err, "failed to open validators file")
+}
+
+func TestWithdrawalCredentialsType(t *testing.T) {
+	credentials := make([]byte, 32)
+	require.Equal(t, "0x00", WithdrawalCredentialsType(credentials))
+	credentials[0] = 0x01
+	require.Equal(t, "0x01", WithdrawalCredentialsType(credentials))
+	credentials[0] = 0x02
+	require.Equal(t, "0x02", WithdrawalCredentialsType(credentials))
+	credentials[0] = 0x03
+	require.Equal(t, "unknown", WithdrawalCredentialsType(credentials))
+	require.Equal(t, "unknown", WithdrawalCredentialsType(credentials[:31]))
+}